@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +19,11 @@ type applyPackageOptions struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	// prune deletes the packages in the namespaces of the file that are not in the file.
+	prune bool
+	// wait waits for each package to be installed before applying the next one.
+	wait        bool
+	waitTimeout time.Duration
 }
 
 var apo = &applyPackageOptions{}
@@ -30,6 +37,12 @@ func init() {
 		"Path to an optional kubeconfig file to use.")
 	applyPackagesCommand.Flags().StringVar(&apo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	applyPackagesCommand.Flags().BoolVar(&apo.prune, "prune", false,
+		"Delete the packages in the namespaces of the file that are not in the file")
+	applyPackagesCommand.Flags().BoolVar(&apo.wait, "wait", false,
+		"Wait for each package to be installed before applying the next one")
+	applyPackagesCommand.Flags().DurationVar(&apo.waitTimeout, "wait-timeout", 10*time.Minute,
+		"Maximum time to wait for each package to be installed")
 
 	err := applyPackagesCommand.MarkFlagRequired("filename")
 	if err != nil {
@@ -40,7 +53,7 @@ func init() {
 var applyPackagesCommand = &cobra.Command{
 	Use:          "package(s) [flags]",
 	Short:        "Apply curated packages",
-	Long:         "Apply Curated Packages Custom Resources to the cluster",
+	Long:         "Apply Curated Packages Custom Resources to the cluster, creating the missing packages and updating the ones that changed in the order they appear in the file",
 	Aliases:      []string{"package", "packages"},
 	PreRunE:      preRunPackages,
 	SilenceUsage: true,
@@ -62,15 +75,23 @@ func applyPackages(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
-	packages := curatedpackages.NewPackageClient(
-		deps.Kubectl,
-	)
-
-	curatedpackages.PrintLicense()
-	err = packages.ApplyPackages(ctx, apo.fileName, kubeConfig)
+	content, err := os.ReadFile(apo.fileName)
+	if err != nil {
+		return fmt.Errorf("reading packages file: %v", err)
+	}
+	set, err := curatedpackages.ParsePackageSet(content)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	var opts []curatedpackages.PackageSetApplierOpt
+	if apo.prune {
+		opts = append(opts, curatedpackages.WithPrune())
+	}
+	if apo.wait {
+		opts = append(opts, curatedpackages.WithWaitForInstalled(apo.waitTimeout))
+	}
+
+	curatedpackages.PrintLicense()
+	return curatedpackages.NewPackageSetApplier(deps.Kubectl, opts...).Apply(ctx, kubeConfig, set)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -54,7 +55,7 @@ func checkImages(context context.Context, clusterSpecPath string) error {
 		return err
 	}
 
-	clusterSpec, err := readAndValidateClusterSpec(clusterSpecPath, version.Get())
+	clusterSpec, err := client.ReadAndValidateClusterSpec(clusterSpecPath, version.Get())
 	if err != nil {
 		return err
 	}
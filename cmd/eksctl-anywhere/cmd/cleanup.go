@@ -5,11 +5,23 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
+
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Clean up resources",
+	Long:  "Use eksctl anywhere cleanup to remove the resources left behind by interrupted commands",
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+}
+
 func cleanup(deps *dependencies.Dependencies, commandErr *error) {
 	if *commandErr == nil {
 		deps.Writer.CleanUpTemp()
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/janitor"
+)
+
+type cleanupAdminMachineOptions struct {
+	maxAge time.Duration
+	dir    string
+	dryRun bool
+}
+
+var cao = &cleanupAdminMachineOptions{}
+
+var cleanupAdminMachineCmd = &cobra.Command{
+	Use:          "admin-machine",
+	Short:        "Remove the stale bootstrap clusters, tools containers and temporary directories from the admin machine",
+	Long:         "This command removes the kind bootstrap clusters, tools containers and temporary directories left behind in the admin machine by commands that were interrupted, as long as they are older than the max age. The temporary directories are searched in the directory the commands were run from and hold the checkpoints to resume failed commands",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cleanupAdminMachine(cmd.Context(), cao)
+	},
+}
+
+func init() {
+	cleanupCmd.AddCommand(cleanupAdminMachineCmd)
+	cleanupAdminMachineCmd.Flags().DurationVar(&cao.maxAge, "max-age", janitor.DefaultMaxAge, "Only remove the resources older than this")
+	cleanupAdminMachineCmd.Flags().StringVar(&cao.dir, "dir", ".", "Directory the commands were run from, where the temporary directories are searched")
+	cleanupAdminMachineCmd.Flags().BoolVar(&cao.dryRun, "dry-run", false, "Only list the stale resources without removing them")
+}
+
+func cleanupAdminMachine(ctx context.Context, opts *cleanupAdminMachineOptions) error {
+	if opts.maxAge <= 0 {
+		return fmt.Errorf("max-age must be positive, got %s", opts.maxAge)
+	}
+
+	janitorOpts := []janitor.Option{janitor.WithMaxAge(opts.maxAge), janitor.WithDir(opts.dir)}
+	if opts.dryRun {
+		janitorOpts = append(janitorOpts, janitor.WithDryRun())
+	}
+
+	report, err := janitor.New(executables.BuildDockerExecutable(), janitorOpts...).Clean(ctx)
+	if err != nil {
+		return fmt.Errorf("cleaning up admin machine: %v", err)
+	}
+
+	if report.IsEmpty() {
+		fmt.Printf("No resources older than %s found\n", opts.maxAge)
+		return nil
+	}
+
+	action := "Removed"
+	if opts.dryRun {
+		action = "Would remove"
+	}
+	for _, c := range report.BootstrapClusters {
+		fmt.Printf("%s bootstrap cluster %s\n", action, c)
+	}
+	for _, c := range report.ToolsContainers {
+		fmt.Printf("%s tools container %s\n", action, c)
+	}
+	for _, d := range report.TempDirs {
+		fmt.Printf("%s temporary directory %s\n", action, d)
+	}
+
+	return nil
+}
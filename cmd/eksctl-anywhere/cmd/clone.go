@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Clone resources",
+	Long:  "Use eksctl anywhere clone to create a copy of an existing resource",
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+}
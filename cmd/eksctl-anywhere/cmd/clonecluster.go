@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+)
+
+type cloneClusterOptions struct {
+	createClusterOptions
+	from                 string
+	name                 string
+	namespace            string
+	controlPlaneEndpoint string
+	tinkerbellIP         string
+	hardwareSelectors    []string
+	dryRun               bool
+}
+
+var clo = &cloneClusterOptions{}
+
+var cloneClusterCmd = &cobra.Command{
+	Use:          "cluster --from <cluster-name> --name <new-cluster-name> [flags]",
+	Short:        "Create a new cluster with the config of an existing one",
+	Long:         "This command copies the cluster config of an existing cluster, replaces its name, control plane endpoint and the other values that can't be shared between clusters, and creates a new cluster with it. Values not set through flags are prompted for",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := clo.cloneCluster(cmd); err != nil {
+			return fmt.Errorf("failed to clone cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cloneCmd.AddCommand(cloneClusterCmd)
+	cloneClusterCmd.Flags().StringVar(&clo.from, "from", "", "Name of the cluster to clone")
+	cloneClusterCmd.Flags().StringVar(&clo.name, "name", "", "Name of the new cluster")
+	cloneClusterCmd.Flags().StringVarP(&clo.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster to clone in the management cluster")
+	cloneClusterCmd.Flags().StringVar(&clo.controlPlaneEndpoint, "control-plane-endpoint", "", "Control plane endpoint host of the new cluster")
+	cloneClusterCmd.Flags().StringVar(&clo.tinkerbellIP, "tinkerbell-ip", "", "Tinkerbell IP of the new cluster, only for self-managed Tinkerbell clusters")
+	cloneClusterCmd.Flags().StringArrayVar(&clo.hardwareSelectors, "hardware-selector", nil, "Hardware selector for a node group of the new cluster in the form node-group=key=value[,key=value], can be repeated. Use control-plane and etcd for the control plane and external etcd machines")
+	cloneClusterCmd.Flags().BoolVar(&clo.dryRun, "dry-run", false, "Only write the cluster config of the new cluster without creating it")
+	flags.String(flags.BundleOverride, &clo.bundlesOverride, cloneClusterCmd.Flags())
+	cloneClusterCmd.Flags().StringVar(&clo.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	cloneClusterCmd.Flags().StringArrayVar(&clo.overlays, "overlay", nil, "Path to a cluster config overlay to merge on top of the cloned cluster config, can be repeated. Overlays are applied in order")
+	applyTimeoutFlags(cloneClusterCmd.Flags(), &clo.timeoutOptions)
+	applyTinkerbellHardwareFlag(cloneClusterCmd.Flags(), &clo.hardwareCSVPath)
+	flags.String(flags.TinkerbellBootstrapIP, &clo.tinkerbellBootstrapIP, cloneClusterCmd.Flags())
+	cloneClusterCmd.Flags().BoolVar(&clo.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
+	cloneClusterCmd.Flags().StringVar(&clo.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	cloneClusterCmd.Flags().StringVar(&clo.ipamConfigPath, "ipam-config", "", "Path to an IPAM config file. The control plane and Tinkerbell IPs are checked against its allocations and, if enabled, reserved in it")
+	cloneClusterCmd.Flags().StringArrayVar(&clo.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass create validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(createvalidations.SkippableValidations[:], ",")))
+
+	for _, f := range []string{"from", "name"} {
+		if err := cloneClusterCmd.MarkFlagRequired(f); err != nil {
+			log.Fatalf("marking %s flag as required: %s", f, err)
+		}
+	}
+}
+
+func (clo *cloneClusterOptions) cloneCluster(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	// The EKS-A objects live in the management cluster, which is the cluster itself for self-managed clusters.
+	client, closer, err := buildRevisionsClient(ctx, getKubeconfigPath(clo.from, clo.managementKubeconfig))
+	if err != nil {
+		return err
+	}
+	config, err := clusterconfig.ConfigFromCluster(ctx, client, clo.from, clo.namespace)
+	closer()
+	if err != nil {
+		return err
+	}
+
+	opts, err := clo.cloneOptions(config)
+	if err != nil {
+		return err
+	}
+
+	clone, err := clusterconfig.Clone(config, *opts)
+	if err != nil {
+		return err
+	}
+
+	content, err := clusterconfig.Marshal(clone)
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(clo.name, clo.name+"-cluster.yaml")
+	if err := os.MkdirAll(clo.name, os.ModePerm); err != nil {
+		return fmt.Errorf("creating cluster directory: %v", err)
+	}
+	if err := os.WriteFile(configFile, content, 0o644); err != nil {
+		return fmt.Errorf("writing cluster config: %v", err)
+	}
+
+	if clo.dryRun {
+		fmt.Printf("Cluster config for %s written to %s\n", clo.name, configFile)
+		return nil
+	}
+
+	logger.Info("Creating clone of cluster", "cluster", clo.name, "from", clo.from, "config", configFile)
+	clo.fileName = configFile
+	// A self-managed clone is a new management cluster, the kubeconfig was only needed to read the original.
+	if clone.Cluster.IsSelfManaged() {
+		clo.managementKubeconfig = ""
+	}
+
+	return clo.createCluster(cmd, nil)
+}
+
+// cloneOptions builds the options to clone the cluster in config from the flags, prompting for the required
+// values that weren't set and, for Tinkerbell clusters, for the hardware selectors if none were set.
+func (clo *cloneClusterOptions) cloneOptions(config *cluster.Config) (*clusterconfig.CloneOptions, error) {
+	selectors, err := clusterconfig.ParseHardwareSelectors(clo.hardwareSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &clusterconfig.CloneOptions{
+		Name:                 clo.name,
+		ControlPlaneEndpoint: clo.controlPlaneEndpoint,
+		TinkerbellIP:         clo.tinkerbellIP,
+		HardwareSelectors:    selectors,
+	}
+
+	askEndpoint := clusterconfig.RequiresControlPlaneEndpoint(config) && opts.ControlPlaneEndpoint == ""
+	askTinkerbellIP := clusterconfig.RequiresTinkerbellIP(config) && opts.TinkerbellIP == ""
+	askSelectors := config.TinkerbellDatacenter != nil && len(opts.HardwareSelectors) == 0
+	if !askEndpoint && !askTinkerbellIP && !askSelectors {
+		return opts, nil
+	}
+
+	// Without a terminal to prompt, the missing required values are reported when cloning.
+	if !isInteractive() {
+		return opts, nil
+	}
+
+	prompter := clusterconfig.NewPrompter(os.Stdin, os.Stderr)
+	if askEndpoint {
+		old := config.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+		opts.ControlPlaneEndpoint, err = prompter.Input("Control plane endpoint of the new cluster", "", differentFrom(old))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if askTinkerbellIP {
+		opts.TinkerbellIP, err = prompter.Input("Tinkerbell IP of the new cluster", "", differentFrom(config.TinkerbellDatacenter.Spec.TinkerbellIP))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if askSelectors {
+		current := clusterconfig.HardwareSelectors(config)
+		groups := make([]string, 0, len(current))
+		for group := range current {
+			groups = append(groups, group)
+		}
+		sort.Strings(groups)
+
+		opts.HardwareSelectors = make(map[string]v1alpha1.HardwareSelector, len(groups))
+		for _, group := range groups {
+			var selector v1alpha1.HardwareSelector
+			_, err := prompter.Input(fmt.Sprintf("Hardware selector for node group %s", group), hardwareSelectorString(current[group]), func(answer string) error {
+				s, err := clusterconfig.ParseHardwareSelector(answer)
+				selector = s
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			opts.HardwareSelectors[group] = selector
+		}
+	}
+
+	return opts, nil
+}
+
+func differentFrom(old string) func(string) error {
+	return func(answer string) error {
+		if answer == "" {
+			return errors.New("a value is required")
+		}
+		if answer == old {
+			return fmt.Errorf("must be different from %s", old)
+		}
+		return nil
+	}
+}
+
+func hardwareSelectorString(selector v1alpha1.HardwareSelector) string {
+	labels := make([]string, 0, len(selector))
+	for k, v := range selector {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+
+	return strings.Join(labels, ",")
+}
+
+// isInteractive returns true if stdin is a terminal the user can answer prompts from.
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
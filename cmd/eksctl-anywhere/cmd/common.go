@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -21,7 +22,7 @@ func getImages(clusterSpecPath, bundlesOverride string) ([]v1alpha1.Image, error
 		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(bundlesOverride))
 	}
 	cliVersion := version.Get()
-	spec, err := readClusterSpec(clusterSpecPath, cliVersion, specOpts...)
+	spec, err := client.ReadClusterSpec(clusterSpecPath, cliVersion, specOpts...)
 	if err != nil {
 		return nil, err
 	}
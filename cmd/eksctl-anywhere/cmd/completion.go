@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+var providerNames = []string{
+	constants.CloudStackProviderName,
+	constants.DockerProviderName,
+	constants.NutanixProviderName,
+	constants.SnowProviderName,
+	constants.TinkerbellProviderName,
+	constants.VSphereProviderName,
+}
+
+// clusterNameFlags are the flags that take the name of a cluster created by the CLI.
+var clusterNameFlags = []string{"cluster", "from-cluster"}
+
+// registerCompletions wires the dynamic shell completion functions into the flags of the
+// command and all its subcommands. It must run after all the commands have been added.
+func registerCompletions(cmd *cobra.Command) {
+	for _, name := range clusterNameFlags {
+		registerFlagCompletion(cmd, name, completeClusterNames)
+	}
+	registerFlagCompletion(cmd, "provider", completeProviders)
+
+	for _, c := range cmd.Commands() {
+		registerCompletions(c)
+	}
+}
+
+func registerFlagCompletion(cmd *cobra.Command, flag string, f func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	if cmd.Flags().Lookup(flag) == nil {
+		return
+	}
+	// This only fails if the flag already has a completion function, which is kept.
+	_ = cmd.RegisterFlagCompletionFunc(flag, f)
+}
+
+// isCompletionCmd returns true for the commands that generate the completion scripts
+// or the completion suggestions.
+func isCompletionCmd(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd ||
+		cmd.Name() == cobra.ShellCompNoDescRequestCmd ||
+		(cmd.Parent() != nil && cmd.Parent().Name() == "completion")
+}
+
+// completeClusterNames suggests the clusters with a kubeconfig in the current directory,
+// which are the ones created by the CLI from it.
+func completeClusterNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := kubeconfig.ClusterNamesInDir(".")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProviders(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterPrefix(providerNames, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePackageNames suggests the packages in the active package bundle of the cluster
+// set in the --cluster flag. It only completes the first argument.
+func completePackageNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	clusterName, _ := cmd.Flags().GetString("cluster")
+	if clusterName == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	kubeconfigFlag, _ := cmd.Flags().GetString("kubeconfig")
+	kubeconfigPath, err := kubeconfig.ResolveAndValidateFilename(kubeconfigFlag, clusterName)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	client, err := kubernetes.NewRuntimeClientFromFileName(kubeconfigPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names, err := curatedpackages.ActiveBundlePackageNames(cmd.Context(), clientutil.NewKubeClient(client), clusterName)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	var filtered []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
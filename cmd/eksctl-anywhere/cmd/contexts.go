@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cli"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	contextFlagName = "context"
+	// contextFlagAnnotation marks the flags set from the management cluster context.
+	contextFlagAnnotation = "anywhere.eks.amazonaws.com/context"
+	// ignoreContextFlagsAnnotation is a command annotation with the comma separated flags the
+	// context doesn't set, for the commands where the flag doesn't refer to the management cluster.
+	ignoreContextFlagsAnnotation = "anywhere.eks.amazonaws.com/ignore-context-flags"
+)
+
+// applyManagementContext sets the flags of cmd that were not set by the user to the values
+// of the selected management cluster context.
+func applyManagementContext(cmd *cobra.Command) error {
+	flags := contextFlags(cmd)
+	if len(flags) == 0 {
+		return nil
+	}
+
+	managementContext, err := selectedManagementContext()
+	if err != nil || managementContext == nil {
+		return err
+	}
+
+	for name, value := range managementContext.FlagValues() {
+		if !flags[name] {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return err
+		}
+		if err := cmd.Flags().SetAnnotation(name, contextFlagAnnotation, []string{managementContext.Name}); err != nil {
+			return err
+		}
+		logger.V(4).Info("Flag set from context", "context", managementContext.Name, "flag", name, "value", value)
+	}
+
+	return nil
+}
+
+// contextFlags returns the flags of cmd a context can set.
+func contextFlags(cmd *cobra.Command) map[string]bool {
+	ignored := map[string]bool{}
+	for _, name := range strings.Split(cmd.Annotations[ignoreContextFlagsAnnotation], ",") {
+		ignored[name] = true
+	}
+
+	flags := map[string]bool{}
+	for _, name := range cli.ContextFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag != nil && !flag.Changed && !ignored[name] {
+			flags[name] = true
+		}
+	}
+	return flags
+}
+
+func selectedManagementContext() (*cli.ManagementContext, error) {
+	path, err := cli.ContextsConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	config, err := cli.ReadContextsConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.Context(viper.GetString(contextFlagName))
+}
+
+// flagSetFromContext returns true if the flag was set from the management cluster context
+// instead of by the user.
+func flagSetFromContext(flags *pflag.FlagSet, name string) bool {
+	flag := flags.Lookup(name)
+	return flag != nil && len(flag.Annotations[contextFlagAnnotation]) > 0
+}
+
+func completeContexts(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	path, err := cli.ContextsConfigPath()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	config, err := cli.ReadContextsConfig(path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var names []string
+	for _, c := range config.Contexts {
+		if strings.HasPrefix(c.Name, toComplete) {
+			names = append(names, c.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
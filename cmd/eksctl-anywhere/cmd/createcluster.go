@@ -3,24 +3,18 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/awsiamauth"
-	"github.com/aws/eks-anywhere/pkg/clustermanager"
-	"github.com/aws/eks-anywhere/pkg/dependencies"
-	"github.com/aws/eks-anywhere/pkg/executables"
-	"github.com/aws/eks-anywhere/pkg/features"
-	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/client"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
-	"github.com/aws/eks-anywhere/pkg/workflow/management"
-	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 type createClusterOptions struct {
@@ -32,6 +26,9 @@ type createClusterOptions struct {
 	tinkerbellBootstrapIP string
 	installPackages       string
 	skipValidations       []string
+	clockSkewImage        string
+	showTemplateDiff      bool
+	ipamConfigPath        string
 }
 
 var cc = &createClusterOptions{}
@@ -55,6 +52,9 @@ func init() {
 	hideForceCleanup(createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().StringVar(&cc.clockSkewImage, "clock-skew-image", "", "Image with curl used to validate the clocks of the cluster nodes are in sync once they are up. The validation only runs when it's set")
+	createClusterCmd.Flags().BoolVar(&cc.showTemplateDiff, "show-template-diff", false, "Print the changes the cluster templateOverrides make to the generated CAPI templates")
+	createClusterCmd.Flags().StringVar(&cc.ipamConfigPath, "ipam-config", "", "Path to an IPAM config file. The control plane and Tinkerbell IPs are checked against its allocations and, if enabled, reserved in it")
 	createClusterCmd.Flags().StringArrayVar(&cc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass create validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(createvalidations.SkippableValidations[:], ",")))
 
 	flags.MarkRequired(createClusterCmd.Flags(), flags.ClusterConfig.Name)
@@ -70,12 +70,18 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 
 	clusterConfigFileExist := validations.FileExists(cc.fileName)
 	if !clusterConfigFileExist {
-		return fmt.Errorf("the cluster config file %s does not exist", cc.fileName)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", cc.fileName))
 	}
 
+	cleanupRenderedConfig, err := cc.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
 	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(cc.fileName)
 	if err != nil {
-		return fmt.Errorf("the cluster config file provided is invalid: %v", err)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
 	}
 
 	if clusterConfig.Spec.DatacenterRef.Kind == v1alpha1.TinkerbellDatacenterKind {
@@ -84,139 +90,30 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		}
 	}
 
-	if clusterConfig.Spec.EtcdEncryption != nil {
-		return errors.New("etcdEncryption is not supported during cluster creation")
-	}
-
-	docker := executables.BuildDockerExecutable()
-
-	if err := validations.CheckMinimumDockerVersion(ctx, docker); err != nil {
-		return fmt.Errorf("failed to validate docker: %v", err)
+	// A new management cluster is not managed by the management cluster of the context.
+	if !clusterConfig.IsManaged() && flagSetFromContext(cmd.Flags(), "kubeconfig") {
+		cc.managementKubeconfig = ""
 	}
 
-	validations.CheckDockerAllocatedMemory(ctx, docker)
-
-	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
-	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
-		return fmt.Errorf(
-			"old cluster config file exists under %s, please use a different clusterName to proceed",
-			clusterConfig.Name,
-		)
-	}
-
-	clusterSpec, err := newClusterSpec(cc.clusterOptions)
-	if err != nil {
-		return err
-	}
-
-	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
-		return err
-	}
-
-	cliConfig := buildCliConfig(clusterSpec)
-	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig, cc.installPackages)
-	if err != nil {
-		return err
-	}
-
-	createCLIConfig, err := buildCreateCliConfig(cc)
-	if err != nil {
-		return err
-	}
-
-	clusterManagerTimeoutOpts, err := buildClusterManagerOpts(cc.timeoutOptions, clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	timeouts, err := buildTimeouts(cc.timeoutOptions)
 	if err != nil {
 		return fmt.Errorf("failed to build cluster manager opts: %v", err)
 	}
 
-	var skippedValidations map[string]bool
-	if len(cc.skipValidations) != 0 {
-		skippedValidations, err = validations.ValidateSkippableValidation(cc.skipValidations, createvalidations.SkippableValidations)
-		if err != nil {
-			return err
-		}
-	}
-
-	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
-		WithBootstrapper().
-		WithCliConfig(cliConfig).
-		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
-		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareCSVPath, cc.forceClean, cc.tinkerbellBootstrapIP, skippedValidations).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
-		WithWriter().
-		WithEksdInstaller().
-		WithPackageInstaller(clusterSpec, cc.installPackages, cc.managementKubeconfig).
-		WithValidatorClients().
-		WithCreateClusterDefaulter(createCLIConfig)
-
-	if cc.timeoutOptions.noTimeouts {
-		factory.WithNoTimeouts()
-	}
-
-	deps, err := factory.Build(ctx)
-	if err != nil {
-		return err
-	}
-	defer close(ctx, deps)
-
-	clusterSpec, err = deps.CreateClusterDefaulter.Run(ctx, clusterSpec)
-	if err != nil {
-		return err
-	}
-
-	createCluster := workflows.NewCreate(
-		deps.Bootstrapper,
-		deps.Provider,
-		deps.ClusterManager,
-		deps.GitOpsFlux,
-		deps.Writer,
-		deps.EksdInstaller,
-		deps.PackageInstaller,
-	)
-
-	validationOpts := &validations.Opts{
-		Kubectl: deps.UnAuthKubectlClient,
-		Spec:    clusterSpec,
-		WorkloadCluster: &types.Cluster{
-			Name:           clusterSpec.Cluster.Name,
-			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
-		},
-		ManagementCluster:  getManagementCluster(clusterSpec),
-		Provider:           deps.Provider,
-		CliConfig:          cliConfig,
-		SkippedValidations: skippedValidations,
-	}
-	createValidations := createvalidations.New(validationOpts)
-
-	if features.UseNewWorkflows().IsActive() {
-		deps, err = factory.
-			WithCNIInstaller(clusterSpec, deps.Provider).
-			Build(ctx)
-		if err != nil {
-			return err
-		}
-
-		wflw := &management.CreateCluster{
-			Spec:                          clusterSpec,
-			Bootstrapper:                  deps.Bootstrapper,
-			CreateBootstrapClusterOptions: deps.Provider,
-			CNIInstaller:                  deps.CNIInstaller,
-			Cluster:                       clustermanager.NewCreateClusterShim(clusterSpec, deps.ClusterManager, deps.Provider),
-			FS:                            deps.Writer,
-		}
-		wflw.WithHookRegistrar(awsiamauth.NewHookRegistrar(deps.AwsIamAuth, clusterSpec))
-
-		// Not all provider implementations want to bind hooks so we explicitly check if they
-		// want to bind hooks before registering it.
-		if registrar, ok := deps.Provider.(management.CreateClusterHookRegistrar); ok {
-			wflw.WithHookRegistrar(registrar)
-		}
-
-		err = wflw.Run(ctx)
-	} else {
-		err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
+	var clientOpts []client.Opt
+	if cc.showTemplateDiff {
+		clientOpts = append(clientOpts, client.WithTemplateDiff(os.Stdout))
 	}
 
-	cleanup(deps, &err)
-	return err
+	return cc.newClient(clientOpts...).CreateCluster(ctx, client.CreateClusterOptions{
+		ClusterConfigFile:     cc.fileName,
+		HardwareCSVPath:       cc.hardwareCSVPath,
+		TinkerbellBootstrapIP: cc.tinkerbellBootstrapIP,
+		SkipIPCheck:           cc.skipIpCheck,
+		InstallPackages:       cc.installPackages,
+		SkipValidations:       cc.skipValidations,
+		ClockSkewImage:        cc.clockSkewImage,
+		IPAMConfigPath:        cc.ipamConfigPath,
+		Timeouts:              timeouts,
+	})
 }
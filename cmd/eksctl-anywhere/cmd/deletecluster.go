@@ -7,12 +7,10 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
-	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 type deleteClusterOptions struct {
@@ -82,58 +80,9 @@ func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) err
 }
 
 func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
-	clusterSpec, err := newClusterSpec(dc.clusterOptions)
-	if err != nil {
-		return fmt.Errorf("unable to get cluster config from file: %v", err)
-	}
-
-	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
-		return err
-	}
-
-	cliConfig := buildCliConfig(clusterSpec)
-	dirs, err := dc.directoriesToMount(clusterSpec, cliConfig)
-	if err != nil {
-		return err
-	}
-
-	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
-		WithBootstrapper().
-		WithCliConfig(cliConfig).
-		WithClusterManager(clusterSpec.Cluster, nil).
-		WithProvider(dc.fileName, clusterSpec.Cluster, cc.skipIpCheck, dc.hardwareFileName, false, dc.tinkerbellBootstrapIP, map[string]bool{}).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
-		WithWriter().
-		Build(ctx)
-	if err != nil {
-		return err
-	}
-	defer close(ctx, deps)
-
-	deleteCluster := workflows.NewDelete(
-		deps.Bootstrapper,
-		deps.Provider,
-		deps.ClusterManager,
-		deps.GitOpsFlux,
-		deps.Writer,
-	)
-
-	var cluster *types.Cluster
-	if clusterSpec.ManagementCluster == nil {
-		cluster = &types.Cluster{
-			Name:               clusterSpec.Cluster.Name,
-			KubeconfigFile:     kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
-			ExistingManagement: false,
-		}
-	} else {
-		cluster = &types.Cluster{
-			Name:               clusterSpec.Cluster.Name,
-			KubeconfigFile:     clusterSpec.ManagementCluster.KubeconfigFile,
-			ExistingManagement: true,
-		}
-	}
-
-	err = deleteCluster.Run(ctx, cluster, clusterSpec, dc.forceCleanup, dc.managementKubeconfig)
-	cleanup(deps, &err)
-	return err
+	return dc.newClient().DeleteCluster(ctx, client.DeleteClusterOptions{
+		ClusterConfigFile:     dc.fileName,
+		HardwareCSVPath:       dc.hardwareFileName,
+		TinkerbellBootstrapIP: dc.tinkerbellBootstrapIP,
+	})
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -69,7 +70,7 @@ func importImages(ctx context.Context, clusterSpecPath string) error {
 	if registryUsername == "" || registryPassword == "" {
 		return fmt.Errorf("username or password not set. Provide REGISTRY_USERNAME and REGISTRY_PASSWORD for importing helm charts (e.g. cilium)")
 	}
-	clusterSpec, err := readAndValidateClusterSpec(clusterSpecPath, version.Get())
+	clusterSpec, err := client.ReadAndValidateClusterSpec(clusterSpecPath, version.Get())
 	if err != nil {
 		return err
 	}
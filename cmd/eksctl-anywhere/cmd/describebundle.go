@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type describeBundleOptions struct {
+	version         string
+	kubeVersions    []string
+	bundlesOverride string
+}
+
+var dbo = &describeBundleOptions{}
+
+func init() {
+	describeCmd.AddCommand(describeBundleCommand)
+	describeBundleCommand.Flags().StringVar(&dbo.version, "version", "", "EKS Anywhere version of the Bundles manifest to describe (defaults to the CLI version)")
+	describeBundleCommand.Flags().StringSliceVar(&dbo.kubeVersions, "kube-version", nil, "Only describe the artifacts for these Kubernetes versions. Format <major>.<minor>")
+	describeBundleCommand.Flags().StringVar(&dbo.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	describeBundleCommand.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
+}
+
+var describeBundleCommand = &cobra.Command{
+	Use:   "bundle",
+	Short: "Describe the components and images of a Bundles manifest",
+	Long:  "Lists the component versions, image URIs and chart URIs included in the Bundles manifest of an EKS Anywhere version",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if err := viper.BindPFlag(flag.Name, flag); err != nil {
+				log.Fatalf("Error initializing flags: %v", err)
+			}
+		})
+		return nil
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return describeBundle(cmd.Context(), dbo)
+	},
+}
+
+func describeBundle(ctx context.Context, opts *describeBundleOptions) error {
+	deps, err := dependencies.NewFactory().
+		WithFileReader().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	var b *releasev1.Bundles
+	if opts.bundlesOverride != "" {
+		b, err = bundles.Read(deps.FileReader, opts.bundlesOverride)
+	} else {
+		eksaVersion := opts.version
+		if eksaVersion == "" {
+			eksaVersion = version.Get().GitVersion
+		}
+		b, err = deps.ManifestReader.ReadBundlesForVersion(eksaVersion)
+	}
+	if err != nil {
+		return err
+	}
+
+	serializedDescription, err := serializeBundleDescription(bundles.Describe(b, opts.kubeVersions...), output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(serializedDescription)
+	return nil
+}
+
+func serializeBundleDescription(description *bundles.Description, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeBundleDescriptionToText(description)
+	case outputJson:
+		jsonDescription, err := json.Marshal(description)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the bundle description to json: %v", err)
+		}
+		return string(jsonDescription), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeBundleDescriptionToText(description *bundles.Description) (string, error) {
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "Bundles manifest %d (CLI versions %s - %s)\n", description.Number, description.CliMinVersion, description.CliMaxVersion)
+
+	for _, v := range description.VersionsBundles {
+		fmt.Fprintf(&buffer, "\nKubernetes %s (EKS-D %s)\n", v.KubeVersion, v.EksD)
+		w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "TYPE\tNAME\tVERSION/URI")
+		for _, c := range v.Components {
+			fmt.Fprintf(w, "component\t%s\t%s\n", c.Name, c.Version)
+		}
+		for _, i := range v.Images {
+			fmt.Fprintf(w, "image\t%s\t%s\n", i.Name, i.URI)
+		}
+		for _, c := range v.Charts {
+			fmt.Fprintf(w, "chart\t%s\t%s\n", c.Name, c.URI)
+		}
+		if err := w.Flush(); err != nil {
+			return "", fmt.Errorf("failed flushing table writer: %v", err)
+		}
+	}
+
+	return buffer.String(), nil
+}
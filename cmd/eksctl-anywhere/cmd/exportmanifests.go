@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifestexport"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type exportManifestsOptions struct {
+	clusterOptions
+	outputDir string
+}
+
+var emo = &exportManifestsOptions{}
+
+var exportManifestsCmd = &cobra.Command{
+	Use:          "manifests -f <cluster-config-file> -o <output-dir>",
+	Short:        "Export the manifests of a cluster",
+	Long:         "This command renders the CAPI, provider and CNI manifests EKS Anywhere applies for a new cluster and writes them to a directory, without creating the cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return emo.exportManifests(cmd.Context())
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportManifestsCmd)
+	flags.String(flags.ClusterConfig, &emo.fileName, exportManifestsCmd.Flags())
+	flags.String(flags.BundleOverride, &emo.bundlesOverride, exportManifestsCmd.Flags())
+	exportManifestsCmd.Flags().StringArrayVar(&emo.templateValues, "set", nil, "Render the cluster config file as a template with the value key=value, can be repeated. Values are available as ${key} and {{ .Values.key }} and environment variables as ${VAR} and {{ .Env.VAR }}")
+	exportManifestsCmd.Flags().StringArrayVar(&emo.overlays, "overlay", nil, "Path to a cluster config overlay to merge on top of the cluster config file, can be repeated. Overlays are applied in order")
+	exportManifestsCmd.Flags().StringVarP(&emo.outputDir, "output", "o", "", "Directory to write the manifests to")
+
+	flags.MarkRequired(exportManifestsCmd.Flags(), flags.ClusterConfig.Name)
+	if err := exportManifestsCmd.MarkFlagRequired("output"); err != nil {
+		log.Fatalf("Cannot mark 'output' flag as required: %s", err)
+	}
+}
+
+func (o *exportManifestsOptions) exportManifests(ctx context.Context) error {
+	cleanupRenderedConfig, err := o.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
+	var opts []cluster.FileSpecBuilderOpt
+	if o.bundlesOverride != "" {
+		opts = append(opts, cluster.WithOverrideBundlesManifest(o.bundlesOverride))
+	}
+
+	clusterSpec, err := client.ReadAndValidateClusterSpec(o.fileName, version.Get(), opts...)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster config from file: %w", err)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(o.mountDirs()...).
+		WithCiliumTemplater().
+		WithFileReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	writer, err := filewriter.NewWriter(o.outputDir)
+	if err != nil {
+		return err
+	}
+	defer writer.CleanUpTemp()
+
+	exporter := manifestexport.NewExporter(deps.CiliumTemplater, deps.FileReader, writer)
+	paths, err := exporter.Export(ctx, logger.Get(), clusterSpec)
+	if err != nil {
+		return fmt.Errorf("exporting manifests: %v", err)
+	}
+
+	for _, p := range paths {
+		fmt.Println(p)
+	}
+
+	return nil
+}
@@ -39,6 +39,8 @@ func applyClusterOptionFlags(flagSet *pflag.FlagSet, clusterOpt *clusterOptions)
 	flags.String(flags.ClusterConfig, &clusterOpt.fileName, flagSet)
 	flags.String(flags.BundleOverride, &clusterOpt.bundlesOverride, flagSet)
 	flagSet.StringVar(&clusterOpt.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	flagSet.StringArrayVar(&clusterOpt.templateValues, "set", nil, "Set the value key=value to render the cluster config file template, can be repeated. Config files with template markers are always rendered: values are available as ${key} and {{ .Values.key }} and environment variables as ${VAR} and {{ .Env.VAR }}")
+	flagSet.StringArrayVar(&clusterOpt.overlays, "overlay", nil, "Path to a cluster config overlay to merge on top of the cluster config file, can be repeated. Overlays are applied in order")
 }
 
 func applyTinkerbellHardwareFlag(flagSet *pflag.FlagSet, pathOut *string) {
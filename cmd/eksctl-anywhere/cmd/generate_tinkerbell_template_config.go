@@ -104,7 +104,7 @@ func NewGenerateTinkerbellTemplateConfig() *cobra.Command {
 			tinkerbellIP := cs.TinkerbellDatacenter.Spec.TinkerbellIP
 
 			cfg := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(cs.Cluster, bundle, osImageURL,
-				opts.BootstrapTinkerbellIP, tinkerbellIP, osFamily)
+				opts.BootstrapTinkerbellIP, tinkerbellIP, osFamily, controlPlaneMachineConfig.Spec.HostNetwork)
 
 			return yaml.NewK8sEncoder(os.Stdout).Encode(cfg)
 		},
@@ -10,8 +10,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -67,11 +69,11 @@ func (gsbo *generateSupportBundleOptions) validateCmdInput() error {
 	if f != "" {
 		clusterConfigFileExist := validations.FileExists(f)
 		if !clusterConfigFileExist {
-			return fmt.Errorf("the cluster config file %s does not exist", f)
+			return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", f))
 		}
 		_, err := v1alpha1.GetAndValidateClusterConfig(f)
 		if err != nil {
-			return fmt.Errorf("unable to get cluster config from file: %v", err)
+			return fmt.Errorf("unable to get cluster config from file: %w", err)
 		}
 	}
 	return nil
@@ -83,9 +85,9 @@ func (gsbo *generateSupportBundleOptions) generateBundleConfig(ctx context.Conte
 		return gsbo.generateDefaultBundleConfig(ctx)
 	}
 
-	clusterSpec, err := readAndValidateClusterSpec(clusterConfigPath, version.Get())
+	clusterSpec, err := client.ReadAndValidateClusterSpec(clusterConfigPath, version.Get())
 	if err != nil {
-		return nil, fmt.Errorf("unable to get cluster config from file: %v", err)
+		return nil, fmt.Errorf("unable to get cluster config from file: %w", err)
 	}
 
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -10,12 +13,19 @@ import (
 	"github.com/spf13/viper"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/version"
 )
 
 var removeFromDefaultConfig = []string{"spec.clusterNetwork.dns"}
@@ -26,11 +36,18 @@ var generateClusterConfigCmd = &cobra.Command{
 	Long:   "This command is used to generate a cluster config yaml for the create cluster command",
 	PreRun: preRunGenerateClusterConfig,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if fromCluster := viper.GetString("from-cluster"); fromCluster != "" {
+			if err := generateClusterConfigFromCluster(cmd.Context(), cmd.Flags(), fromCluster); err != nil {
+				return fmt.Errorf("generating eks-a cluster config from cluster: %v", err)
+			}
+			return nil
+		}
+
 		clusterName, err := validations.ValidateClusterNameArg(args)
 		if err != nil {
 			return err
 		}
-		err = generateClusterConfig(clusterName)
+		err = generateClusterConfig(cmd.Context(), clusterName)
 		if err != nil {
 			return fmt.Errorf("generating eks-a cluster config: %v", err) // need to have better error handling here in own func
 		}
@@ -49,19 +66,71 @@ func preRunGenerateClusterConfig(cmd *cobra.Command, args []string) {
 
 func init() {
 	generateCmd.AddCommand(generateClusterConfigCmd)
-	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker)")
-	err := generateClusterConfigCmd.MarkFlagRequired("provider")
+	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker). Required unless --interactive is set")
+	generateClusterConfigCmd.Flags().BoolP("interactive", "i", false, "Prompt for the cluster configuration and generate a validated cluster config")
+	generateClusterConfigCmd.Flags().String("from-cluster", "", "Name of an existing cluster to generate the cluster config from")
+	generateClusterConfigCmd.Flags().String("kubeconfig", "", "kubeconfig file pointing to the management cluster of the cluster set in --from-cluster")
+	generateClusterConfigCmd.Flags().StringP("namespace", "n", constants.DefaultNamespace, "Namespace of the cluster set in --from-cluster")
+}
+
+func generateClusterConfigFromCluster(ctx context.Context, flags *pflag.FlagSet, clusterName string) error {
+	providerSet := viper.GetString("provider") != "" && !flagSetFromContext(flags, "provider")
+	if viper.GetBool("interactive") || providerSet {
+		return errors.New("--from-cluster can't be used with --provider or --interactive")
+	}
+
+	kubeconfigPath, err := kubeconfig.ResolveAndValidateFilename(viper.GetString("kubeconfig"), "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().WithUnAuthKubeClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	client := kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeconfigPath)
+	config, err := clusterconfig.FromCluster(ctx, client, clusterName, viper.GetString("namespace"))
 	if err != nil {
-		log.Fatalf("marking flag as required: %v", err)
+		return err
 	}
+
+	fmt.Println(string(config))
+	return nil
 }
 
-func generateClusterConfig(clusterName string) error {
+func generateClusterConfig(ctx context.Context, clusterName string) error {
+	provider := strings.ToLower(viper.GetString("provider"))
+	interactive := viper.GetBool("interactive")
+	if provider == "" && !interactive {
+		return errors.New("required flag(s) \"provider\" not set")
+	}
+
+	var answers *clusterconfig.Answers
+	if interactive {
+		kubernetesVersions, err := supportedKubernetesVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		wizard := clusterconfig.NewWizard(
+			clusterconfig.NewPrompter(os.Stdin, os.Stderr),
+			kubernetesVersions,
+			clusterconfig.WithVSphereDiscoverer(govcDiscoverer{}),
+		)
+		answers, err = wizard.Run(ctx, provider)
+		if err != nil {
+			return err
+		}
+		provider = answers.Provider
+	}
+
 	var resources [][]byte
 	var datacenterYaml []byte
 	var machineGroupYaml [][]byte
 	var clusterConfigOpts []v1alpha1.ClusterGenerateOpt
-	switch strings.ToLower(viper.GetString("provider")) {
+	switch provider {
 	case constants.DockerProviderName:
 		datacenterConfig := v1alpha1.NewDockerDatacenterConfigGenerate(clusterName)
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
@@ -79,6 +148,7 @@ func generateClusterConfig(clusterName string) error {
 	case constants.VSphereProviderName:
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
 		datacenterConfig := v1alpha1.NewVSphereDatacenterConfigGenerate(clusterName)
+		answers.ApplyToVSphereDatacenterConfig(datacenterConfig)
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(2),
@@ -96,6 +166,9 @@ func generateClusterConfig(clusterName string) error {
 		cpMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
 		workerMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(clusterName)
 		etcdMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(providers.GetEtcdNodeName(clusterName))
+		for _, mc := range []*v1alpha1.VSphereMachineConfigGenerate{cpMachineConfig, workerMachineConfig, etcdMachineConfig} {
+			answers.ApplyToVSphereMachineConfig(mc)
+		}
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
@@ -245,6 +318,7 @@ func generateClusterConfig(clusterName string) error {
 		return fmt.Errorf("not a valid provider")
 	}
 	config := v1alpha1.NewClusterGenerate(clusterName, clusterConfigOpts...)
+	answers.ApplyToCluster(config)
 
 	configMarshal, err := yaml.Marshal(config)
 	if err != nil {
@@ -259,6 +333,50 @@ func generateClusterConfig(clusterName string) error {
 		resources = append(resources, machineGroupYaml...)
 	}
 
-	fmt.Println(string(templater.AppendYamlResources(resources...)))
+	content := templater.AppendYamlResources(resources...)
+	if interactive {
+		if err := clusterconfig.ValidateClusterConfig(content); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(string(content))
 	return nil
 }
+
+// supportedKubernetesVersions returns the Kubernetes versions supported by the bundles of the CLI version.
+func supportedKubernetesVersions(ctx context.Context) ([]string, error) {
+	deps, err := dependencies.NewFactory().WithManifestReader().Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles, err := deps.ManifestReader.ReadBundlesForVersion(version.Get().GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundles for the supported kubernetes versions: %v", err)
+	}
+
+	return clusterconfig.KubernetesVersions(bundles)
+}
+
+// govcDiscoverer lists the vSphere inventory with govc for the interactive cluster config generation.
+type govcDiscoverer struct{}
+
+func (govcDiscoverer) Networks(ctx context.Context, server, datacenter string, insecure bool) ([]string, error) {
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Server:     server,
+			Datacenter: datacenter,
+			Insecure:   insecure,
+		},
+	}
+	envMap := vsphere.GovcEnvMap(datacenterConfig, config.NewVsphereUserConfig())
+
+	deps, err := dependencies.NewFactory().WithGovcEnvMap(envMap).WithGovc().Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer close(ctx, deps)
+
+	return deps.Govc.ListNetworks(ctx, datacenter)
+}
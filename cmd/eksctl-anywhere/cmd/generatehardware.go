@@ -3,15 +3,19 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"net/http"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
+const netboxConfigFlagName = "netbox-config"
+
 type hardwareOptions struct {
-	csvPath    string
-	outputPath string
+	csvPath          string
+	netboxConfigPath string
+	outputPath       string
 }
 
 var hOpts = &hardwareOptions{}
@@ -20,7 +24,8 @@ var generateHardwareCmd = &cobra.Command{
 	Use:   "hardware",
 	Short: "Generate hardware files",
 	Long: `
-Generate Kubernetes hardware YAML manifests for each Hardware entry in the source.
+Generate Kubernetes hardware YAML manifests for each Hardware entry in the source. The source is
+either a CSV file or the devices of a NetBox DCIM selected by a NetBox config file.
 `,
 	RunE: hOpts.generateHardware,
 }
@@ -37,16 +42,30 @@ func init() {
 		"",
 		TinkerbellHardwareCSVFlagDescription,
 	)
-
-	if err := generateHardwareCmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
-		panic(err)
-	}
+	flags.StringVar(&hOpts.netboxConfigPath, netboxConfigFlagName, "", "Path to a NetBox config file selecting the devices to generate hardware for. The NetBox API token is read from the NETBOX_TOKEN environment variable.")
+	generateHardwareCmd.MarkFlagsMutuallyExclusive(TinkerbellHardwareCSVFlagName, netboxConfigFlagName)
 }
 
 func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string) error {
-	hardwareYaml, err := hardware.BuildHardwareYAML(hOpts.csvPath)
-	if err != nil {
-		return fmt.Errorf("building hardware yaml from csv: %v", err)
+	var hardwareYaml []byte
+	var err error
+	switch {
+	case hOpts.csvPath != "":
+		hardwareYaml, err = hardware.BuildHardwareYAML(hOpts.csvPath)
+		if err != nil {
+			return fmt.Errorf("building hardware yaml from csv: %v", err)
+		}
+	case hOpts.netboxConfigPath != "":
+		config, err := hardware.ParseNetboxConfigFile(hOpts.netboxConfigPath)
+		if err != nil {
+			return err
+		}
+		hardwareYaml, err = hardware.BuildHardwareYAMLFromReader(hardware.NewNormalizedNetboxReader(cmd.Context(), http.DefaultClient, config))
+		if err != nil {
+			return fmt.Errorf("building hardware yaml from netbox: %v", err)
+		}
+	default:
+		return fmt.Errorf("one of --%s or --%s is required", TinkerbellHardwareCSVFlagName, netboxConfigFlagName)
 	}
 
 	fh, err := hardware.CreateOrStdout(hOpts.outputPath)
@@ -37,13 +37,14 @@ func init() {
 }
 
 var generatePackageCommand = &cobra.Command{
-	Use:          "packages [flags] package",
-	Aliases:      []string{"package", "packages"},
-	Short:        "Generate package(s) configuration",
-	Long:         "Generates Kubernetes configuration files for curated packages",
-	PreRunE:      preRunPackages,
-	SilenceUsage: true,
-	RunE:         runGeneratePackages,
+	Use:               "packages [flags] package",
+	Aliases:           []string{"package", "packages"},
+	Short:             "Generate package(s) configuration",
+	Long:              "Generates Kubernetes configuration files for curated packages",
+	PreRunE:           preRunPackages,
+	SilenceUsage:      true,
+	RunE:              runGeneratePackages,
+	ValidArgsFunction: completePackageNames,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err == nil {
 			return nil
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+type schemaOptions struct {
+	kind       string
+	outputPath string
+}
+
+var sOpts = &schemaOptions{}
+
+var generateSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Generate the cluster config JSON schema",
+	Long: `
+Generate the JSON schema for the EKS Anywhere cluster config kinds. The schema can be configured
+in editors that support JSON schemas for yaml files to validate and autocomplete cluster configs.
+By default the schema accepts any of the config kinds, use --kind to generate the schema for a single kind.
+`,
+	Args: cobra.NoArgs,
+	RunE: sOpts.generateSchema,
+}
+
+func init() {
+	generateCmd.AddCommand(generateSchemaCmd)
+
+	flags := generateSchemaCmd.Flags()
+	flags.StringVar(&sOpts.kind, "kind", "", "Config kind to generate the schema for, ex. Cluster or VSphereMachineConfig")
+	flags.StringVarP(&sOpts.outputPath, "output", "o", "", "Path to output the JSON schema. Defaults to stdout")
+}
+
+func (sOpts *schemaOptions) generateSchema(cmd *cobra.Command, args []string) error {
+	registry, err := schema.Load()
+	if err != nil {
+		return fmt.Errorf("loading schemas: %v", err)
+	}
+
+	var content []byte
+	if sOpts.kind != "" {
+		content, err = registry.JSONSchema(sOpts.kind)
+	} else {
+		content, err = registry.CombinedJSONSchema()
+	}
+	if err != nil {
+		return fmt.Errorf("generating schema: %v", err)
+	}
+	content = append(content, '\n')
+
+	if sOpts.outputPath == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+
+	if err := os.WriteFile(sOpts.outputPath, content, 0o644); err != nil {
+		return fmt.Errorf("writing schema to %s: %v", sOpts.outputPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cli"
+)
+
+var getContextsCmd = &cobra.Command{
+	Use:          "contexts",
+	Short:        "Get the management cluster contexts",
+	Long:         "This command lists the management cluster contexts of the CLI config file, set with the EKSA_CLI_CONFIG environment variable or $HOME/.eks-anywhere/config.yaml by default. The selected context is marked with *",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getContexts()
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getContextsCmd)
+}
+
+func getContexts() error {
+	path, err := cli.ContextsConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := cli.ReadContextsConfig(path)
+	if err != nil {
+		return err
+	}
+	if len(config.Contexts) == 0 {
+		fmt.Printf("No contexts found in %s\n", path)
+		return nil
+	}
+
+	selected, err := config.Context(viper.GetString(contextFlagName))
+	if err != nil {
+		return err
+	}
+
+	table, err := contextsTable(config.Contexts, selected)
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+	return nil
+}
+
+func contextsTable(contexts []cli.ManagementContext, selected *cli.ManagementContext) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tKUBECONFIG\tPROVIDER\tREGISTRY MIRROR")
+	for _, c := range contexts {
+		current := ""
+		if selected != nil && selected.Name == c.Name {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", current, c.Name, orDash(c.Kubeconfig), orDash(c.Provider), orDash(c.RegistryMirror))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+type getOperationsOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+}
+
+var goo = &getOperationsOptions{}
+
+var getOperationsCmd = &cobra.Command{
+	Use:          "operations",
+	Short:        "Get the lifecycle operations run on clusters",
+	Long:         "This command lists the create, upgrade, delete and scale operations run on the clusters of a management cluster, with who started them and their outcome. Use --cluster to only list the operations of one cluster.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getOperations(cmd.Context(), goo)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getOperationsCmd)
+	getOperationsCmd.Flags().StringVar(&goo.clusterName, "cluster", "", "Name of the cluster")
+	getOperationsCmd.Flags().StringVarP(&goo.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster objects in the management cluster")
+	getOperationsCmd.Flags().StringVar(&goo.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+}
+
+func getOperations(ctx context.Context, opts *getOperationsOptions) error {
+	client, closer, err := buildRevisionsClient(ctx, opts.kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	operations, err := cluster.ListOperations(ctx, client, opts.clusterName, opts.namespace)
+	if err != nil {
+		return err
+	}
+	if len(operations) == 0 {
+		fmt.Println("No operations found")
+		return nil
+	}
+
+	table, err := operationsTable(operations)
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+	return nil
+}
+
+const operationTimeFormat = "2006-01-02T15:04:05Z"
+
+func operationsTable(operations []v1alpha1.ClusterOperation) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tTYPE\tINITIATOR\tOUTCOME\tSTARTED\tCOMPLETED\tSPEC HASH")
+	for _, o := range operations {
+		initiator := string(o.Spec.Initiator.Type)
+		if o.Spec.Initiator.User != "" {
+			initiator = fmt.Sprintf("%s/%s", initiator, o.Spec.Initiator.User)
+		}
+		completed := "-"
+		if o.Status.CompletedAt != nil {
+			completed = o.Status.CompletedAt.UTC().Format(operationTimeFormat)
+		}
+		specHash := o.Spec.SpecHash
+		if len(specHash) > 12 {
+			specHash = specHash[:12]
+		}
+		if specHash == "" {
+			specHash = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", o.Spec.ClusterName, o.Spec.Type, initiator, o.Status.Outcome,
+			o.Status.StartedAt.UTC().Format(operationTimeFormat), completed, specHash)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
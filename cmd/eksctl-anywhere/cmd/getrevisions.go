@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type getRevisionsOptions struct {
+	clusterName string
+	namespace   string
+	revision    int
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+}
+
+var gro = &getRevisionsOptions{}
+
+var getRevisionsCmd = &cobra.Command{
+	Use:          "revisions",
+	Short:        "Get the cluster spec revisions of a cluster",
+	Long:         "This command lists the cluster spec revisions recorded on every successful create and upgrade of a cluster. Use --revision to print the full cluster config of a revision.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getRevisions(cmd.Context(), gro)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getRevisionsCmd)
+	getRevisionsCmd.Flags().StringVar(&gro.clusterName, "cluster", "", "Name of the cluster")
+	getRevisionsCmd.Flags().StringVarP(&gro.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	getRevisionsCmd.Flags().IntVar(&gro.revision, "revision", 0, "Revision to print the cluster config for")
+	getRevisionsCmd.Flags().StringVar(&gro.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	if err := getRevisionsCmd.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("marking cluster flag as required: %s", err)
+	}
+}
+
+func getRevisions(ctx context.Context, opts *getRevisionsOptions) error {
+	client, closer, err := buildRevisionsClient(ctx, opts.kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	if opts.revision > 0 {
+		revision, err := cluster.GetSpecRevision(ctx, client, opts.clusterName, opts.namespace, opts.revision)
+		if err != nil {
+			return err
+		}
+		fmt.Print(revision.Spec.Config)
+		return nil
+	}
+
+	revisions, err := cluster.ListSpecRevisions(ctx, client, opts.clusterName, opts.namespace)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		fmt.Printf("No revisions found for cluster %s\n", opts.clusterName)
+		return nil
+	}
+
+	table, err := revisionsTable(revisions)
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+	return nil
+}
+
+func buildRevisionsClient(ctx context.Context, kubeConfigPath string) (kubernetes.Client, func(), error) {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(kubeConfigPath, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deps, err := dependencies.NewFactory().WithUnAuthKubeClient().Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeConfig), func() { close(ctx, deps) }, nil
+}
+
+func revisionsTable(revisions []v1alpha1.ClusterSpecRevision) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tEKS-A VERSION\tBUNDLES\tCREATED")
+	for _, r := range revisions {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", r.Spec.Revision, r.Spec.EksaVersion, r.Spec.BundlesNumber, r.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
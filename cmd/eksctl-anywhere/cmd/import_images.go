@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
@@ -103,7 +104,7 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		return err
 	}
 
-	dirsToMount, err := cc.cloudStackDirectoriesToMount()
+	dirsToMount, err := client.CloudStackDirectoriesToMount()
 	if err != nil {
 		return err
 	}
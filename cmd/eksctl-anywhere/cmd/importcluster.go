@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type importClusterOptions struct {
+	cluster              string
+	namespace            string
+	managementKubeconfig string
+	dryRun               bool
+}
+
+var imco = &importClusterOptions{}
+
+var importClusterCmd = &cobra.Command{
+	Use:          "cluster --cluster <cluster-name> --kubeconfig <management-kubeconfig> [flags]",
+	Short:        "Import an existing CAPI cluster into an EKS-A management cluster",
+	Long:         "This command generates the EKS-A cluster config of a cluster created with Cluster API in the eksa-system namespace of an EKS-A management cluster and creates the EKS-A objects for it, so the cluster is managed by EKS-A from then on. The generated cluster config is written to <cluster-name>/<cluster-name>-eks-a-cluster.yaml. Only vSphere clusters with a kubeadm control plane and stacked etcd are supported",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := imco.importCluster(cmd); err != nil {
+			return fmt.Errorf("failed to import cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importClusterCmd)
+	importClusterCmd.Flags().StringVar(&imco.cluster, "cluster", "", "Name of the CAPI cluster to import")
+	importClusterCmd.Flags().StringVarP(&imco.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace to create the EKS-A cluster in")
+	importClusterCmd.Flags().StringVar(&imco.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	importClusterCmd.Flags().BoolVar(&imco.dryRun, "dry-run", false, "Only write the generated cluster config without importing the cluster")
+
+	for _, f := range []string{"cluster", "kubeconfig"} {
+		if err := importClusterCmd.MarkFlagRequired(f); err != nil {
+			log.Fatalf("marking %s flag as required: %s", f, err)
+		}
+	}
+}
+
+func (imco *importClusterOptions) importCluster(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if err := kubeconfig.ValidateFilename(imco.managementKubeconfig); err != nil {
+		return err
+	}
+
+	client, closer, err := buildRevisionsClient(ctx, imco.managementKubeconfig)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	importer := clusterimport.NewImporter(client)
+	config, err := importer.Generate(ctx, imco.cluster, imco.namespace)
+	if err != nil {
+		return err
+	}
+
+	content, err := clusterconfig.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := clusterconfig.ValidateClusterConfig(content); err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(imco.cluster, imco.cluster+"-eks-a-cluster.yaml")
+	if err := os.MkdirAll(imco.cluster, os.ModePerm); err != nil {
+		return fmt.Errorf("creating cluster directory: %v", err)
+	}
+	if err := os.WriteFile(configFile, content, 0o644); err != nil {
+		return fmt.Errorf("writing cluster config: %v", err)
+	}
+
+	if imco.dryRun {
+		fmt.Printf("Cluster config for %s written to %s\n", imco.cluster, configFile)
+		return nil
+	}
+
+	logger.Info("Importing cluster", "cluster", imco.cluster, "namespace", imco.namespace, "config", configFile)
+	if err := importer.Import(ctx, config); err != nil {
+		return err
+	}
+
+	kubeconfigContent, err := importer.Kubeconfig(ctx, imco.cluster)
+	if err != nil {
+		return err
+	}
+	kubeconfigFile := kubeconfig.FromClusterName(imco.cluster)
+	if err := os.WriteFile(kubeconfigFile, kubeconfigContent, 0o600); err != nil {
+		return fmt.Errorf("writing kubeconfig: %v", err)
+	}
+
+	logger.MarkSuccess("Cluster imported", "cluster", imco.cluster, "config", configFile, "kubeconfig", kubeconfigFile)
+	return nil
+}
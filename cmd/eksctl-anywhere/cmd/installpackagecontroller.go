@@ -8,7 +8,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -46,7 +48,7 @@ var installPackageControllerCommand = &cobra.Command{
 func runInstallPackageController(cmd *cobra.Command, args []string) error {
 	clusterConfigFileExist := validations.FileExists(ico.fileName)
 	if !clusterConfigFileExist {
-		return fmt.Errorf("the cluster config file %s does not exist", ico.fileName)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", ico.fileName))
 	}
 	return installPackageController(cmd.Context())
 }
@@ -54,9 +56,9 @@ func runInstallPackageController(cmd *cobra.Command, args []string) error {
 func installPackageController(ctx context.Context) error {
 	kubeConfig := kubeconfig.FromEnvironment()
 
-	clusterSpec, err := readAndValidateClusterSpec(ico.fileName, version.Get())
+	clusterSpec, err := client.ReadAndValidateClusterSpec(ico.fileName, version.Get())
 	if err != nil {
-		return fmt.Errorf("the cluster config file provided is invalid: %v", err)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
 	}
 
 	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig), WithClusterSpec(clusterSpec), WithKubeConfig(ico.kubeConfig), WithBundlesOverride(ico.bundlesOverride))
@@ -21,6 +21,11 @@ type installPackageOptions struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	// test runs the helm tests of the package chart once it's installed.
+	test bool
+	// testKubeconfig is the kubeconfig of the cluster the package is installed in, used to run
+	// its tests. Defaults to kubeConfig.
+	testKubeconfig string
 }
 
 var ipo = &installPackageOptions{}
@@ -42,6 +47,10 @@ func init() {
 		"Target cluster for installation.")
 	installPackageCommand.Flags().StringVar(&ipo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	installPackageCommand.Flags().BoolVar(&ipo.test, "test", false,
+		"Run the helm tests of the package once it's installed and record failures in the package status")
+	installPackageCommand.Flags().StringVar(&ipo.testKubeconfig, "test-kubeconfig", "",
+		"Kubeconfig of the cluster the package is installed in, used to run its tests. Defaults to --kubeconfig")
 
 	if err := installPackageCommand.MarkFlagRequired("package-name"); err != nil {
 		log.Fatalf("marking package-name flag as required: %s", err)
@@ -52,13 +61,14 @@ func init() {
 }
 
 var installPackageCommand = &cobra.Command{
-	Use:          "package [flags] package",
-	Aliases:      []string{"package"},
-	Short:        "Install package",
-	Long:         "This command is used to Install a curated package. Use list to discover curated packages",
-	PreRunE:      preRunPackages,
-	SilenceUsage: true,
-	RunE:         runInstallPackages,
+	Use:               "package [flags] package",
+	Aliases:           []string{"package"},
+	Short:             "Install package",
+	Long:              "This command is used to Install a curated package. Use list to discover curated packages",
+	PreRunE:           preRunPackages,
+	SilenceUsage:      true,
+	RunE:              runInstallPackages,
+	ValidArgsFunction: completePackageNames,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err == nil {
 			return nil
@@ -80,7 +90,11 @@ func installPackages(ctx context.Context, args []string) error {
 	if err != nil {
 		return err
 	}
-	deps, err := NewDependenciesForPackages(ctx, WithRegistryName(ipo.registry), WithKubeVersion(ipo.kubeVersion), WithMountPaths(kubeConfig), WithBundlesOverride(ipo.bundlesOverride))
+	testKubeconfig := kubeConfig
+	if ipo.testKubeconfig != "" {
+		testKubeconfig = ipo.testKubeconfig
+	}
+	deps, err := NewDependenciesForPackages(ctx, WithRegistryName(ipo.registry), WithKubeVersion(ipo.kubeVersion), WithMountPaths(kubeConfig, testKubeconfig), WithBundlesOverride(ipo.bundlesOverride))
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
@@ -110,5 +124,12 @@ func installPackages(ctx context.Context, args []string) error {
 	if err != nil {
 		return err
 	}
+
+	if ipo.test {
+		tester := curatedpackages.NewPackageTester(deps.Kubectl, deps.Helm)
+		if err := tester.TestPackage(ctx, kubeConfig, testKubeconfig, ipo.clusterName, ipo.packageName); err != nil {
+			return err
+		}
+	}
 	return nil
 }
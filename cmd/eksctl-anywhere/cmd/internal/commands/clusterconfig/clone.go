@@ -0,0 +1,331 @@
+package clusterconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	// ControlPlaneNodeGroup is the node group name used to set the hardware selector of the control plane.
+	ControlPlaneNodeGroup = "control-plane"
+	// EtcdNodeGroup is the node group name used to set the hardware selector of the external etcd machines.
+	EtcdNodeGroup = "etcd"
+)
+
+// CloneOptions are the values that change between a cluster and its clone.
+type CloneOptions struct {
+	// Name is the name of the new cluster.
+	Name string
+	// ControlPlaneEndpoint is the control plane endpoint host of the new cluster.
+	// Required when the cluster has an endpoint, see RequiresControlPlaneEndpoint.
+	ControlPlaneEndpoint string
+	// TinkerbellIP is the IP of the Tinkerbell stack of the new cluster.
+	// Required for self-managed Tinkerbell clusters, see RequiresTinkerbellIP.
+	TinkerbellIP string
+	// HardwareSelectors replace the hardware selectors of the Tinkerbell machine configs of the node groups,
+	// by node group name. The control plane and the external etcd are ControlPlaneNodeGroup and EtcdNodeGroup.
+	HardwareSelectors map[string]v1alpha1.HardwareSelector
+}
+
+// RequiresControlPlaneEndpoint returns true if a clone of the cluster needs a new control plane endpoint.
+func RequiresControlPlaneEndpoint(config *cluster.Config) bool {
+	endpoint := config.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+	return endpoint != nil && endpoint.Host != ""
+}
+
+// RequiresTinkerbellIP returns true if a clone of the cluster needs a new Tinkerbell IP. Workload clusters
+// share the Tinkerbell stack of their management cluster, so only self-managed clusters need one.
+func RequiresTinkerbellIP(config *cluster.Config) bool {
+	return config.TinkerbellDatacenter != nil && config.Cluster.IsSelfManaged()
+}
+
+// Clone returns a copy of config for a new cluster. The cluster and the objects it owns are renamed, replacing the
+// name of the cluster in their names or prefixing them with the new name, and the values that can't be shared
+// between two clusters are replaced with the ones in opts. The EKS-A version and bundles are cleared, so the
+// new cluster is created with the version of the CLI. config is not modified.
+func Clone(config *cluster.Config, opts CloneOptions) (*cluster.Config, error) {
+	from := config.Cluster.Name
+	if opts.Name == "" {
+		return nil, errors.New("the name of the new cluster is required")
+	}
+	if opts.Name == from {
+		return nil, fmt.Errorf("the name of the new cluster must be different from %s", from)
+	}
+
+	c := config.DeepCopy()
+	rename := func(name string) string {
+		if strings.Contains(name, from) {
+			return strings.ReplaceAll(name, from, opts.Name)
+		}
+		return opts.Name + "-" + name
+	}
+
+	selfManaged := c.Cluster.IsSelfManaged()
+	c.Cluster.Name = opts.Name
+	if selfManaged {
+		c.Cluster.SetSelfManaged()
+	}
+	c.Cluster.Spec.EksaVersion = nil
+	c.Cluster.Spec.BundlesRef = nil
+
+	if err := cloneEndpoints(config, c, opts); err != nil {
+		return nil, err
+	}
+
+	spec := &c.Cluster.Spec
+	spec.DatacenterRef.Name = rename(spec.DatacenterRef.Name)
+	renameDatacenter(c, spec.DatacenterRef.Name)
+
+	if ref := spec.ControlPlaneConfiguration.MachineGroupRef; ref != nil {
+		ref.Name = rename(ref.Name)
+	}
+	if spec.ExternalEtcdConfiguration != nil && spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		spec.ExternalEtcdConfiguration.MachineGroupRef.Name = rename(spec.ExternalEtcdConfiguration.MachineGroupRef.Name)
+	}
+	for i := range spec.WorkerNodeGroupConfigurations {
+		if ref := spec.WorkerNodeGroupConfigurations[i].MachineGroupRef; ref != nil {
+			ref.Name = rename(ref.Name)
+		}
+	}
+
+	c.VSphereMachineConfigs = renameObjects(c.VSphereMachineConfigs, rename)
+	c.CloudStackMachineConfigs = renameObjects(c.CloudStackMachineConfigs, rename)
+	c.NutanixMachineConfigs = renameObjects(c.NutanixMachineConfigs, rename)
+	c.SnowMachineConfigs = renameObjects(c.SnowMachineConfigs, rename)
+	c.TinkerbellMachineConfigs = renameObjects(c.TinkerbellMachineConfigs, rename)
+	c.TinkerbellTemplateConfigs = renameObjects(c.TinkerbellTemplateConfigs, rename)
+	for _, m := range c.TinkerbellMachineConfigs {
+		if m.Spec.TemplateRef.Name != "" {
+			m.Spec.TemplateRef.Name = rename(m.Spec.TemplateRef.Name)
+		}
+	}
+
+	if err := setHardwareSelectors(c, opts.HardwareSelectors); err != nil {
+		return nil, err
+	}
+
+	// A self-managed clone is a new management cluster with its own GitOps config, which must not sync
+	// the cluster config of the original cluster.
+	if selfManaged {
+		cloneGitOps(c, from, rename)
+	}
+
+	return c, nil
+}
+
+func cloneEndpoints(original, c *cluster.Config, opts CloneOptions) error {
+	if RequiresControlPlaneEndpoint(original) {
+		if err := validateNewValue("control plane endpoint", original.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, opts.ControlPlaneEndpoint); err != nil {
+			return err
+		}
+		c.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = opts.ControlPlaneEndpoint
+	} else if opts.ControlPlaneEndpoint != "" {
+		return fmt.Errorf("cluster %s doesn't have a control plane endpoint", original.Cluster.Name)
+	}
+
+	if RequiresTinkerbellIP(original) {
+		if err := validateNewValue("Tinkerbell IP", original.TinkerbellDatacenter.Spec.TinkerbellIP, opts.TinkerbellIP); err != nil {
+			return err
+		}
+		c.TinkerbellDatacenter.Spec.TinkerbellIP = opts.TinkerbellIP
+	} else if opts.TinkerbellIP != "" {
+		return errors.New("the Tinkerbell IP can only be set when cloning self-managed Tinkerbell clusters")
+	}
+
+	return nil
+}
+
+func validateNewValue(name, old, new string) error {
+	if new == "" {
+		return fmt.Errorf("the %s of the new cluster is required", name)
+	}
+	if new == old {
+		return fmt.Errorf("the %s of the new cluster must be different from %s", name, old)
+	}
+	return nil
+}
+
+func renameDatacenter(c *cluster.Config, name string) {
+	var datacenter kubernetes.Object
+	switch {
+	case c.VSphereDatacenter != nil:
+		datacenter = c.VSphereDatacenter
+	case c.CloudStackDatacenter != nil:
+		datacenter = c.CloudStackDatacenter
+	case c.NutanixDatacenter != nil:
+		datacenter = c.NutanixDatacenter
+	case c.SnowDatacenter != nil:
+		datacenter = c.SnowDatacenter
+	case c.TinkerbellDatacenter != nil:
+		datacenter = c.TinkerbellDatacenter
+	case c.DockerDatacenter != nil:
+		datacenter = c.DockerDatacenter
+	default:
+		return
+	}
+	datacenter.SetName(name)
+}
+
+func renameObjects[O kubernetes.Object](m map[string]O, rename func(string) string) map[string]O {
+	if m == nil {
+		return nil
+	}
+
+	renamed := make(map[string]O, len(m))
+	for _, o := range m {
+		o.SetName(rename(o.GetName()))
+		renamed[o.GetName()] = o
+	}
+
+	return renamed
+}
+
+// setHardwareSelectors sets the hardware selectors of the machine configs of the node groups. Node groups
+// sharing a machine config must get the same selector.
+func setHardwareSelectors(c *cluster.Config, selectors map[string]v1alpha1.HardwareSelector) error {
+	if len(selectors) == 0 {
+		return nil
+	}
+	if c.TinkerbellDatacenter == nil {
+		return errors.New("hardware selectors can only be set when cloning Tinkerbell clusters")
+	}
+
+	machineConfigs := nodeGroupMachineConfigs(c.Cluster)
+	setBy := map[string]string{}
+	for _, group := range sortedKeys(selectors) {
+		name, ok := machineConfigs[group]
+		if !ok {
+			return fmt.Errorf("node group %s not found in cluster", group)
+		}
+		m, ok := c.TinkerbellMachineConfigs[name]
+		if !ok {
+			return fmt.Errorf("machine config %s of node group %s not found", name, group)
+		}
+		if other, ok := setBy[name]; ok && !reflect.DeepEqual(selectors[other], selectors[group]) {
+			return fmt.Errorf("node groups %s and %s share machine config %s and must have the same hardware selector", other, group, name)
+		}
+		m.Spec.HardwareSelector = selectors[group]
+		setBy[name] = group
+	}
+
+	return nil
+}
+
+// HardwareSelectors returns the hardware selectors of the node groups of a Tinkerbell cluster, by node group name.
+func HardwareSelectors(config *cluster.Config) map[string]v1alpha1.HardwareSelector {
+	selectors := map[string]v1alpha1.HardwareSelector{}
+	for group, name := range nodeGroupMachineConfigs(config.Cluster) {
+		if m, ok := config.TinkerbellMachineConfigs[name]; ok {
+			selectors[group] = m.Spec.HardwareSelector
+		}
+	}
+
+	return selectors
+}
+
+func nodeGroupMachineConfigs(c *v1alpha1.Cluster) map[string]string {
+	machineConfigs := map[string]string{}
+	if c.Spec.ControlPlaneConfiguration.MachineGroupRef != nil {
+		machineConfigs[ControlPlaneNodeGroup] = c.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	}
+	if c.Spec.ExternalEtcdConfiguration != nil && c.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		machineConfigs[EtcdNodeGroup] = c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
+	}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef != nil {
+			machineConfigs[w.Name] = w.MachineGroupRef.Name
+		}
+	}
+
+	return machineConfigs
+}
+
+func cloneGitOps(c *cluster.Config, from string, rename func(string) string) {
+	if c.Cluster.Spec.GitOpsRef != nil {
+		c.Cluster.Spec.GitOpsRef.Name = rename(c.Cluster.Spec.GitOpsRef.Name)
+	}
+
+	if c.FluxConfig != nil {
+		c.FluxConfig.Name = rename(c.FluxConfig.Name)
+		c.FluxConfig.Spec.ClusterConfigPath = cloneClusterConfigPath(c.FluxConfig.Spec.ClusterConfigPath, from, c.Cluster.Name)
+	}
+	if c.GitOpsConfig != nil {
+		c.GitOpsConfig.Name = rename(c.GitOpsConfig.Name)
+		github := &c.GitOpsConfig.Spec.Flux.Github
+		github.ClusterConfigPath = cloneClusterConfigPath(github.ClusterConfigPath, from, c.Cluster.Name)
+	}
+}
+
+// cloneClusterConfigPath replaces the cluster name in the path where the cluster config is synced from.
+// Paths that don't include the name are cleared so the new cluster gets the default one.
+func cloneClusterConfigPath(p, from, to string) string {
+	segments := strings.Split(p, "/")
+	found := false
+	for i, s := range segments {
+		if s == from {
+			segments[i] = to
+			found = true
+		}
+	}
+	if !found {
+		return ""
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ParseHardwareSelectors parses a list of node-group=key=value[,key=value] into the hardware selectors of
+// CloneOptions. Repeating a node group adds labels to its selector.
+func ParseHardwareSelectors(values []string) (map[string]v1alpha1.HardwareSelector, error) {
+	selectors := make(map[string]v1alpha1.HardwareSelector, len(values))
+	for _, v := range values {
+		group, labels, ok := strings.Cut(v, "=")
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid hardware selector %q, must be in the form node-group=key=value[,key=value]", v)
+		}
+
+		selector, err := ParseHardwareSelector(labels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hardware selector %q: %v", v, err)
+		}
+		if selectors[group] == nil {
+			selectors[group] = v1alpha1.HardwareSelector{}
+		}
+		for k, l := range selector {
+			selectors[group][k] = l
+		}
+	}
+
+	return selectors, nil
+}
+
+// ParseHardwareSelector parses a key=value[,key=value] hardware selector.
+func ParseHardwareSelector(s string) (v1alpha1.HardwareSelector, error) {
+	selector := v1alpha1.HardwareSelector{}
+	for _, label := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(label), "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("label %q must be in the form key=value", label)
+		}
+		selector[k] = v
+	}
+
+	return selector, nil
+}
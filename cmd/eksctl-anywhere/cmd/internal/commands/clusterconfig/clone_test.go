@@ -0,0 +1,254 @@
+package clusterconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func vSphereConfig() *cluster.Config {
+	c, datacenter, machineConfig := vSphereClusterObjects()
+	c.SetSelfManaged()
+	version := v1alpha1.EksaVersion("v0.17.0")
+	c.Spec.EksaVersion = &version
+	c.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{
+			Name:            "md-0",
+			MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "workers"},
+		},
+	}
+	c.Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "my-cluster-flux"}
+	workers := machineConfig.DeepCopy()
+	workers.Name = "workers"
+
+	return &cluster.Config{
+		Cluster:           c,
+		VSphereDatacenter: datacenter,
+		VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+			machineConfig.Name: machineConfig,
+			workers.Name:       workers,
+		},
+		FluxConfig: &v1alpha1.FluxConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-flux", Namespace: "eksa-ns"},
+			Spec:       v1alpha1.FluxConfigSpec{ClusterConfigPath: "clusters/my-cluster"},
+		},
+	}
+}
+
+func tinkerbellConfig() *cluster.Config {
+	c := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint:        &v1alpha1.Endpoint{Host: "10.0.0.10"},
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.TinkerbellMachineConfigKind, Name: "my-cluster-cp"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:            "md-0",
+					MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.TinkerbellMachineConfigKind, Name: "my-cluster-workers"},
+				},
+			},
+			DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.TinkerbellDatacenterKind, Name: "my-cluster"},
+		},
+	}
+	c.SetSelfManaged()
+
+	return &cluster.Config{
+		Cluster: c,
+		TinkerbellDatacenter: &v1alpha1.TinkerbellDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+			Spec:       v1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.0.0.11"},
+		},
+		TinkerbellMachineConfigs: map[string]*v1alpha1.TinkerbellMachineConfig{
+			"my-cluster-cp": {
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-cp", Namespace: "default"},
+				Spec: v1alpha1.TinkerbellMachineConfigSpec{
+					HardwareSelector: v1alpha1.HardwareSelector{"type": "cp"},
+					TemplateRef:      v1alpha1.Ref{Kind: v1alpha1.TinkerbellTemplateConfigKind, Name: "my-cluster-template"},
+				},
+			},
+			"my-cluster-workers": {
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-workers", Namespace: "default"},
+				Spec: v1alpha1.TinkerbellMachineConfigSpec{
+					HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+				},
+			},
+		},
+		TinkerbellTemplateConfigs: map[string]*v1alpha1.TinkerbellTemplateConfig{
+			"my-cluster-template": {
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-template", Namespace: "default"},
+			},
+		},
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := NewWithT(t)
+	config := vSphereConfig()
+
+	clone, err := clusterconfig.Clone(config, clusterconfig.CloneOptions{
+		Name:                 "new-cluster",
+		ControlPlaneEndpoint: "1.2.3.5",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(clone.Cluster.Name).To(Equal("new-cluster"))
+	g.Expect(clone.Cluster.Namespace).To(Equal("eksa-ns"))
+	g.Expect(clone.Cluster.Spec.ManagementCluster.Name).To(Equal("new-cluster"))
+	g.Expect(clone.Cluster.Spec.EksaVersion).To(BeNil())
+	g.Expect(clone.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("1.2.3.5"))
+	g.Expect(clone.Cluster.Spec.DatacenterRef.Name).To(Equal("new-cluster"))
+	g.Expect(clone.VSphereDatacenter.Name).To(Equal("new-cluster"))
+	g.Expect(clone.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name).To(Equal("new-cluster-cp"))
+	g.Expect(clone.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name).To(Equal("new-cluster-workers"))
+	g.Expect(clone.VSphereMachineConfigs).To(HaveKey("new-cluster-cp"))
+	g.Expect(clone.VSphereMachineConfigs).To(HaveKey("new-cluster-workers"))
+	g.Expect(clone.VSphereMachineConfigs["new-cluster-workers"].Name).To(Equal("new-cluster-workers"))
+	g.Expect(clone.Cluster.Spec.GitOpsRef.Name).To(Equal("new-cluster-flux"))
+	g.Expect(clone.FluxConfig.Name).To(Equal("new-cluster-flux"))
+	g.Expect(clone.FluxConfig.Spec.ClusterConfigPath).To(Equal("clusters/new-cluster"))
+
+	// The original config is left untouched.
+	g.Expect(config.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(config.VSphereMachineConfigs).To(HaveKey("workers"))
+
+	content, err := clusterconfig.Marshal(clone)
+	g.Expect(err).NotTo(HaveOccurred())
+	parsed := &v1alpha1.Cluster{}
+	g.Expect(v1alpha1.ParseClusterConfigFromContent(content, parsed)).To(Succeed())
+	g.Expect(parsed.Name).To(Equal("new-cluster"))
+}
+
+func TestCloneWorkloadCluster(t *testing.T) {
+	g := NewWithT(t)
+	config := vSphereConfig()
+	config.Cluster.Spec.ManagementCluster.Name = "mgmt"
+	config.FluxConfig.Name = "mgmt-flux"
+	config.Cluster.Spec.GitOpsRef.Name = "mgmt-flux"
+
+	clone, err := clusterconfig.Clone(config, clusterconfig.CloneOptions{
+		Name:                 "new-cluster",
+		ControlPlaneEndpoint: "1.2.3.5",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clone.Cluster.Spec.ManagementCluster.Name).To(Equal("mgmt"))
+	g.Expect(clone.Cluster.Spec.GitOpsRef.Name).To(Equal("mgmt-flux"))
+	g.Expect(clone.FluxConfig.Spec.ClusterConfigPath).To(Equal("clusters/my-cluster"))
+}
+
+func TestCloneTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	config := tinkerbellConfig()
+	g.Expect(clusterconfig.RequiresControlPlaneEndpoint(config)).To(BeTrue())
+	g.Expect(clusterconfig.RequiresTinkerbellIP(config)).To(BeTrue())
+	g.Expect(clusterconfig.HardwareSelectors(config)).To(Equal(map[string]v1alpha1.HardwareSelector{
+		"control-plane": {"type": "cp"},
+		"md-0":          {"type": "worker"},
+	}))
+
+	clone, err := clusterconfig.Clone(config, clusterconfig.CloneOptions{
+		Name:                 "lab",
+		ControlPlaneEndpoint: "10.0.1.10",
+		TinkerbellIP:         "10.0.1.11",
+		HardwareSelectors: map[string]v1alpha1.HardwareSelector{
+			"control-plane": {"type": "cp", "rack": "b"},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clone.TinkerbellDatacenter.Spec.TinkerbellIP).To(Equal("10.0.1.11"))
+	g.Expect(clone.TinkerbellMachineConfigs["lab-cp"].Spec.HardwareSelector).To(Equal(v1alpha1.HardwareSelector{"type": "cp", "rack": "b"}))
+	g.Expect(clone.TinkerbellMachineConfigs["lab-cp"].Spec.TemplateRef.Name).To(Equal("lab-template"))
+	g.Expect(clone.TinkerbellTemplateConfigs).To(HaveKey("lab-template"))
+	g.Expect(clone.TinkerbellMachineConfigs["lab-workers"].Spec.HardwareSelector).To(Equal(v1alpha1.HardwareSelector{"type": "worker"}))
+}
+
+func TestCloneErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *cluster.Config
+		opts    clusterconfig.CloneOptions
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{ControlPlaneEndpoint: "1.2.3.5"},
+			wantErr: "the name of the new cluster is required",
+		},
+		{
+			name:    "same name",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "my-cluster", ControlPlaneEndpoint: "1.2.3.5"},
+			wantErr: "the name of the new cluster must be different from my-cluster",
+		},
+		{
+			name:    "missing endpoint",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "new-cluster"},
+			wantErr: "the control plane endpoint of the new cluster is required",
+		},
+		{
+			name:    "same endpoint",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "new-cluster", ControlPlaneEndpoint: "1.2.3.4"},
+			wantErr: "the control plane endpoint of the new cluster must be different from 1.2.3.4",
+		},
+		{
+			name:    "tinkerbell ip for vsphere",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "new-cluster", ControlPlaneEndpoint: "1.2.3.5", TinkerbellIP: "1.2.3.6"},
+			wantErr: "the Tinkerbell IP can only be set when cloning self-managed Tinkerbell clusters",
+		},
+		{
+			name:    "hardware selectors for vsphere",
+			config:  vSphereConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "new-cluster", ControlPlaneEndpoint: "1.2.3.5", HardwareSelectors: map[string]v1alpha1.HardwareSelector{"md-0": {"type": "a"}}},
+			wantErr: "hardware selectors can only be set when cloning Tinkerbell clusters",
+		},
+		{
+			name:    "missing tinkerbell ip",
+			config:  tinkerbellConfig(),
+			opts:    clusterconfig.CloneOptions{Name: "lab", ControlPlaneEndpoint: "10.0.1.10"},
+			wantErr: "the Tinkerbell IP of the new cluster is required",
+		},
+		{
+			name:   "unknown node group",
+			config: tinkerbellConfig(),
+			opts: clusterconfig.CloneOptions{
+				Name: "lab", ControlPlaneEndpoint: "10.0.1.10", TinkerbellIP: "10.0.1.11",
+				HardwareSelectors: map[string]v1alpha1.HardwareSelector{"md-1": {"type": "a"}},
+			},
+			wantErr: "node group md-1 not found in cluster",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := clusterconfig.Clone(tc.config, tc.opts)
+			g.Expect(err).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestParseHardwareSelectors(t *testing.T) {
+	g := NewWithT(t)
+
+	selectors, err := clusterconfig.ParseHardwareSelectors([]string{"control-plane=type=cp,rack=b", "md-0=type=worker", "control-plane=zone=1"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(selectors).To(Equal(map[string]v1alpha1.HardwareSelector{
+		"control-plane": {"type": "cp", "rack": "b", "zone": "1"},
+		"md-0":          {"type": "worker"},
+	}))
+
+	_, err = clusterconfig.ParseHardwareSelectors([]string{"md-0"})
+	g.Expect(err).To(MatchError(ContainSubstring("must be in the form node-group=key=value")))
+
+	_, err = clusterconfig.ParseHardwareSelectors([]string{"md-0=type"})
+	g.Expect(err).To(MatchError(ContainSubstring(`label "type" must be in the form key=value`)))
+}
@@ -0,0 +1,201 @@
+package clusterconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/pkg/api"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// transientAnnotations are set by the CLI, kubectl or the controller while operating on a
+// cluster and don't belong in a cluster config meant to be applied again.
+var transientAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"anywhere.eks.amazonaws.com/paused",
+	v1alpha1.ManagedByCLIAnnotation,
+}
+
+// FromCluster reconstructs the cluster config for the cluster name in namespace by reading
+// the Cluster and all the objects it references from the API server. Server managed metadata
+// and status are not included, so the output can be used with create cluster or stored in git.
+// Credentials secrets are never included.
+func FromCluster(ctx context.Context, client cluster.Client, name, namespace string) ([]byte, error) {
+	config, err := ConfigFromCluster(ctx, client, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return Marshal(config)
+}
+
+// ConfigFromCluster reads the Cluster name in namespace and all the objects it references from the API server.
+func ConfigFromCluster(ctx context.Context, client cluster.Client, name, namespace string) (*cluster.Config, error) {
+	c := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, name, namespace, c); err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", name, err)
+	}
+
+	return cluster.NewDefaultConfigClientBuilder().
+		Register(getTinkerbellTemplateConfigs).
+		Build(ctx, client, c)
+}
+
+// Marshal writes config as a multi-document cluster config file, without the server managed metadata and status.
+func Marshal(config *cluster.Config) ([]byte, error) {
+	c := config.Cluster
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	objs := []kubernetes.Object{config.Cluster}
+	objs = appendIfNotNil(objs,
+		config.VSphereDatacenter,
+		config.CloudStackDatacenter,
+		config.NutanixDatacenter,
+		config.SnowDatacenter,
+		config.TinkerbellDatacenter,
+		config.DockerDatacenter,
+	)
+	objs = append(objs, sortedByName(config.VSphereMachineConfigs)...)
+	objs = append(objs, sortedByName(config.CloudStackMachineConfigs)...)
+	objs = append(objs, sortedByName(config.NutanixMachineConfigs)...)
+	objs = append(objs, sortedByName(config.SnowMachineConfigs)...)
+	objs = append(objs, sortedByName(config.SnowIPPools)...)
+	objs = append(objs, sortedByName(config.TinkerbellMachineConfigs)...)
+	objs = append(objs, sortedByName(config.TinkerbellTemplateConfigs)...)
+	// Same as when writing the config during cluster creation, prefer the deprecated
+	// GitOpsConfig if it exists so the original spec is preserved.
+	if config.GitOpsConfig != nil {
+		objs = append(objs, config.GitOpsConfig)
+	} else {
+		objs = appendIfNotNil(objs, config.FluxConfig)
+	}
+	objs = append(objs, sortedByName(config.OIDCConfigs)...)
+	objs = append(objs, sortedByName(config.AWSIAMConfigs)...)
+
+	resources := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		if err := sanitize(scheme, obj); err != nil {
+			return nil, err
+		}
+
+		resource, err := yaml.Marshal(marshallable(obj))
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+
+		if c.Spec.ClusterNetwork.DNS.ResolvConf == nil {
+			resource, err = api.CleanupPathsFromYaml(resource, []string{"spec.clusterNetwork.dns"})
+			if err != nil {
+				return nil, fmt.Errorf("cleaning paths from yaml: %v", err)
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func getTinkerbellTemplateConfigs(ctx context.Context, client cluster.Client, c *cluster.Config) error {
+	for _, m := range c.TinkerbellMachineConfigs {
+		if m.Spec.TemplateRef.Name == "" {
+			continue
+		}
+		if _, ok := c.TinkerbellTemplateConfigs[m.Spec.TemplateRef.Name]; ok {
+			continue
+		}
+
+		t := &v1alpha1.TinkerbellTemplateConfig{}
+		if err := client.Get(ctx, m.Spec.TemplateRef.Name, c.Cluster.Namespace, t); err != nil {
+			return err
+		}
+
+		if c.TinkerbellTemplateConfigs == nil {
+			c.TinkerbellTemplateConfigs = map[string]*v1alpha1.TinkerbellTemplateConfig{}
+		}
+		c.TinkerbellTemplateConfigs[t.Name] = t
+	}
+
+	return nil
+}
+
+// sanitize sets the object kind, which is not always populated by the clients, and removes the
+// annotations that only make sense for the live object.
+func sanitize(scheme *runtime.Scheme, obj kubernetes.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	annotations := obj.GetAnnotations()
+	for _, a := range transientAnnotations {
+		delete(annotations, a)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	return nil
+}
+
+func marshallable(obj kubernetes.Object) interface{} {
+	switch o := obj.(type) {
+	case *v1alpha1.Cluster:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.SnowIPPool:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.TinkerbellTemplateConfig:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.GitOpsConfig:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.FluxConfig:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.OIDCConfig:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case *v1alpha1.AWSIamConfig:
+		return o.ConvertConfigToConfigGenerateStruct()
+	case interface{ Marshallable() v1alpha1.Marshallable }:
+		return o.Marshallable()
+	default:
+		return obj
+	}
+}
+
+func appendIfNotNil(objs []kubernetes.Object, elems ...kubernetes.Object) []kubernetes.Object {
+	for _, e := range elems {
+		// Typed nil pointers are not nil once stored in the interface.
+		if !reflect.ValueOf(e).IsNil() {
+			objs = append(objs, e)
+		}
+	}
+
+	return objs
+}
+
+func sortedByName[O kubernetes.Object](m map[string]O) []kubernetes.Object {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	objs := make([]kubernetes.Object, 0, len(m))
+	for _, name := range names {
+		objs = append(objs, m[name])
+	}
+
+	return objs
+}
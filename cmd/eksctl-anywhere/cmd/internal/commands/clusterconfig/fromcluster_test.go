@@ -0,0 +1,109 @@
+package clusterconfig_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func vSphereClusterObjects() (*v1alpha1.Cluster, *v1alpha1.VSphereDatacenterConfig, *v1alpha1.VSphereMachineConfig) {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-cluster",
+			Namespace:       "eksa-ns",
+			ResourceVersion: "1234",
+			UID:             "abcd",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				v1alpha1.ManagedByCLIAnnotation:                    "true",
+			},
+		},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube127,
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:    1,
+				Endpoint: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+				MachineGroupRef: &v1alpha1.Ref{
+					Kind: v1alpha1.VSphereMachineConfigKind,
+					Name: "my-cluster-cp",
+				},
+			},
+			DatacenterRef: v1alpha1.Ref{
+				Kind: v1alpha1.VSphereDatacenterKind,
+				Name: "my-cluster",
+			},
+		},
+		Status: v1alpha1.ClusterStatus{
+			FailureMessage: ptr.String("failed"),
+		},
+	}
+	datacenter := &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "eksa-ns"},
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Datacenter: "dc",
+			Network:    "net",
+			Server:     "vcenter",
+		},
+	}
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-cp", Namespace: "eksa-ns"},
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			NumCPUs:  2,
+			OSFamily: v1alpha1.Ubuntu,
+		},
+	}
+
+	return cluster, datacenter, machineConfig
+}
+
+func TestFromCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, datacenter, machineConfig := vSphereClusterObjects()
+	client := test.NewFakeKubeClient(cluster, datacenter, machineConfig)
+
+	content, err := clusterconfig.FromCluster(ctx, client, "my-cluster", "eksa-ns")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg := string(content)
+	g.Expect(strings.Count(cfg, "\n---\n")).To(Equal(3))
+	g.Expect(cfg).To(ContainSubstring("kind: Cluster\n"))
+	g.Expect(cfg).To(ContainSubstring("kind: VSphereDatacenterConfig\n"))
+	g.Expect(cfg).To(ContainSubstring("kind: VSphereMachineConfig\n"))
+	g.Expect(cfg).To(ContainSubstring("apiVersion: anywhere.eks.amazonaws.com/v1alpha1"))
+	g.Expect(cfg).To(ContainSubstring("namespace: eksa-ns"))
+	g.Expect(cfg).ToNot(ContainSubstring("resourceVersion"))
+	g.Expect(cfg).ToNot(ContainSubstring("status"))
+	g.Expect(cfg).ToNot(ContainSubstring("last-applied-configuration"))
+	g.Expect(cfg).ToNot(ContainSubstring(v1alpha1.ManagedByCLIAnnotation))
+
+	parsed := &v1alpha1.Cluster{}
+	g.Expect(v1alpha1.ParseClusterConfigFromContent(content, parsed)).To(Succeed())
+	g.Expect(parsed.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube127))
+	g.Expect(parsed.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("1.2.3.4"))
+}
+
+func TestFromClusterMissingCluster(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient()
+
+	_, err := clusterconfig.FromCluster(context.Background(), client, "my-cluster", "default")
+	g.Expect(err).To(MatchError(ContainSubstring("getting cluster my-cluster")))
+}
+
+func TestFromClusterMissingDatacenter(t *testing.T) {
+	g := NewWithT(t)
+	cluster, _, machineConfig := vSphereClusterObjects()
+	client := test.NewFakeKubeClient(cluster, machineConfig)
+
+	_, err := clusterconfig.FromCluster(context.Background(), client, "my-cluster", "eksa-ns")
+	g.Expect(err).To(MatchError(ContainSubstring("building Config from a cluster client")))
+}
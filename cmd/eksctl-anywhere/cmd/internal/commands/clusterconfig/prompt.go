@@ -0,0 +1,152 @@
+package clusterconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Prompter asks questions through an output stream and reads the answers from an input stream.
+// Invalid answers are reported and the question is asked again.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a new Prompter that reads answers from in and writes questions to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Input asks question and returns the answer, or defaultValue if the answer is empty. When
+// validate is not nil, the question is repeated until validate accepts the answer.
+func (p *Prompter) Input(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "Invalid answer: %v\n", err)
+			continue
+		}
+
+		return answer, nil
+	}
+}
+
+// Select asks the user to choose one of options, either by its value or by its number. Values
+// take precedence so numeric options can be selected by value.
+func (p *Prompter) Select(question string, options []string, defaultValue string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("no options available for %q", question)
+	}
+
+	fmt.Fprintln(p.out, question)
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+
+	var selected string
+	_, err := p.Input("Select an option", defaultValue, func(answer string) error {
+		for _, o := range options {
+			if o == answer {
+				selected = o
+				return nil
+			}
+		}
+
+		if i, err := strconv.Atoi(answer); err == nil {
+			if i < 1 || i > len(options) {
+				return fmt.Errorf("option must be between 1 and %d", len(options))
+			}
+			selected = options[i-1]
+			return nil
+		}
+
+		return fmt.Errorf("%q is not one of the options", answer)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
+
+// Int asks question and returns the answer as an integer greater than or equal to min.
+func (p *Prompter) Int(question string, defaultValue, min int) (int, error) {
+	var value int
+	_, err := p.Input(question, strconv.Itoa(defaultValue), func(answer string) error {
+		i, err := strconv.Atoi(answer)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", answer)
+		}
+		if i < min {
+			return fmt.Errorf("value must be greater than or equal to %d", min)
+		}
+		value = i
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// Confirm asks a yes/no question and returns true if the answer is yes.
+func (p *Prompter) Confirm(question string, defaultValue bool) (bool, error) {
+	d := "n"
+	if defaultValue {
+		d = "y"
+	}
+
+	var confirmed bool
+	_, err := p.Input(question+" (y/n)", d, func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			confirmed = true
+		case "n", "no":
+			confirmed = false
+		default:
+			return errors.New("answer must be y or n")
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return confirmed, nil
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", errors.New("reading answer: input closed before all questions were answered")
+		}
+		return "", fmt.Errorf("reading answer: %v", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
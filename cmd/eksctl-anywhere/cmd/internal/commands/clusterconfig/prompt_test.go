@@ -0,0 +1,94 @@
+package clusterconfig_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+)
+
+var errEmpty = errors.New("empty")
+
+func newPrompter(answers ...string) (*clusterconfig.Prompter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return clusterconfig.NewPrompter(strings.NewReader(strings.Join(answers, "\n")+"\n"), out), out
+}
+
+func TestPrompterInputDefault(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("")
+
+	g.Expect(p.Input("Name", "my-cluster", nil)).To(Equal("my-cluster"))
+	g.Expect(out.String()).To(Equal("Name [my-cluster]: "))
+}
+
+func TestPrompterInputRetriesInvalidAnswer(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("", "value")
+
+	g.Expect(p.Input("Name", "", func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	})).To(Equal("value"))
+	g.Expect(out.String()).To(ContainSubstring("Invalid answer: empty"))
+}
+
+func TestPrompterInputClosed(t *testing.T) {
+	g := NewWithT(t)
+	p := clusterconfig.NewPrompter(strings.NewReader(""), &bytes.Buffer{})
+
+	_, err := p.Input("Name", "default", nil)
+	g.Expect(err).To(MatchError(ContainSubstring("input closed")))
+}
+
+func TestPrompterSelect(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		want   string
+	}{
+		{name: "by number", answer: "2", want: "b"},
+		{name: "by value", answer: "c", want: "c"},
+		{name: "default", answer: "", want: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, _ := newPrompter(tt.answer)
+
+			g.Expect(p.Select("Pick one", []string{"a", "b", "c"}, "a")).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPrompterSelectOutOfRange(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("4", "d", "1")
+
+	g.Expect(p.Select("Pick one", []string{"a", "b", "c"}, "")).To(Equal("a"))
+	g.Expect(out.String()).To(ContainSubstring("option must be between 1 and 3"))
+	g.Expect(out.String()).To(ContainSubstring(`"d" is not one of the options`))
+}
+
+func TestPrompterInt(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("two", "0", "3")
+
+	g.Expect(p.Int("Count", 1, 1)).To(Equal(3))
+	g.Expect(out.String()).To(ContainSubstring(`"two" is not a number`))
+	g.Expect(out.String()).To(ContainSubstring("value must be greater than or equal to 1"))
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	g := NewWithT(t)
+	p, _ := newPrompter("maybe", "yes", "")
+
+	g.Expect(p.Confirm("Continue?", false)).To(BeTrue())
+	g.Expect(p.Confirm("Continue?", false)).To(BeFalse())
+}
@@ -0,0 +1,350 @@
+package clusterconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// VSphereDiscoverer discovers vSphere inventory so the wizard can offer it as options.
+type VSphereDiscoverer interface {
+	Networks(ctx context.Context, server, datacenter string, insecure bool) ([]string, error)
+}
+
+// Answers is the cluster configuration collected by the Wizard.
+type Answers struct {
+	Provider          string
+	KubernetesVersion v1alpha1.KubernetesVersion
+	ControlPlaneCount int
+	WorkerCount       int
+
+	// EtcdCount is the number of external etcd machines. It's zero for providers that run
+	// stacked etcd by default.
+	EtcdCount int
+
+	// EndpointHost is the control plane endpoint. It's empty for providers that assign the
+	// endpoint themselves.
+	EndpointHost string
+
+	// VSphere is only set for the vSphere provider.
+	VSphere *VSphereAnswers
+}
+
+// VSphereAnswers is the vSphere specific configuration collected by the Wizard.
+type VSphereAnswers struct {
+	Server     string
+	Insecure   bool
+	Datacenter string
+	Network    string
+	NumCPUs    int
+	MemoryMiB  int
+	DiskGiB    int
+}
+
+type providerDefaults struct {
+	controlPlaneCount int
+	workerCount       int
+	etcdCount         int
+	endpoint          bool
+	credentialEnvs    []string
+}
+
+// defaults mirror the values used by the non interactive cluster config generation.
+var defaults = map[string]providerDefaults{
+	constants.VSphereProviderName: {
+		controlPlaneCount: 2, workerCount: 2, etcdCount: 3, endpoint: true,
+		credentialEnvs: []string{config.EksavSphereUsernameKey, config.EksavSpherePasswordKey},
+	},
+	constants.CloudStackProviderName: {
+		controlPlaneCount: 2, workerCount: 2, etcdCount: 3, endpoint: true,
+		credentialEnvs: []string{decoder.EksacloudStackCloudConfigB64SecretKey},
+	},
+	constants.NutanixProviderName: {
+		controlPlaneCount: 3, workerCount: 3, endpoint: true,
+		credentialEnvs: []string{constants.EksaNutanixUsernameKey, constants.EksaNutanixPasswordKey},
+	},
+	constants.SnowProviderName: {
+		controlPlaneCount: 3, workerCount: 3, endpoint: true,
+		credentialEnvs: []string{aws.EksaAwsCredentialsFileKey, aws.EksaAwsCABundlesFileKey},
+	},
+	constants.TinkerbellProviderName: {
+		controlPlaneCount: 1, workerCount: 1, endpoint: true,
+	},
+	constants.DockerProviderName: {
+		controlPlaneCount: 1, workerCount: 1, etcdCount: 1,
+	},
+}
+
+var providerNames = []string{
+	constants.VSphereProviderName,
+	constants.CloudStackProviderName,
+	constants.NutanixProviderName,
+	constants.SnowProviderName,
+	constants.TinkerbellProviderName,
+	constants.DockerProviderName,
+}
+
+// KubernetesVersions returns the Kubernetes versions supported by bundles, oldest first.
+func KubernetesVersions(bundles *releasev1.Bundles) ([]string, error) {
+	semvers := map[string]*semver.Version{}
+	versions := make([]string, 0, len(bundles.Spec.VersionsBundles))
+	for _, vb := range bundles.Spec.VersionsBundles {
+		if _, ok := semvers[vb.KubeVersion]; ok {
+			continue
+		}
+		v, err := v1alpha1.KubeVersionToSemver(v1alpha1.KubernetesVersion(vb.KubeVersion))
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubernetes version in bundles: %v", err)
+		}
+		semvers[vb.KubeVersion] = v
+		versions = append(versions, vb.KubeVersion)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return semvers[versions[i]].LessThan(semvers[versions[j]])
+	})
+
+	return versions, nil
+}
+
+// Wizard collects the configuration for a new cluster by prompting the user. Questions and
+// warnings are written to the prompter output so the generated config can be written to a
+// different stream.
+type Wizard struct {
+	prompter           *Prompter
+	kubernetesVersions []string
+	vSphere            VSphereDiscoverer
+	lookupEnv          func(string) (string, bool)
+}
+
+// WizardOpt configures a Wizard.
+type WizardOpt func(*Wizard)
+
+// WithVSphereDiscoverer configures the Wizard to list the vSphere inventory with d instead of
+// asking for free text.
+func WithVSphereDiscoverer(d VSphereDiscoverer) WizardOpt {
+	return func(w *Wizard) {
+		w.vSphere = d
+	}
+}
+
+// NewWizard returns a new Wizard that asks questions using prompter. kubernetesVersions are the
+// versions offered for the cluster, usually the ones supported by the bundles of the CLI version.
+func NewWizard(prompter *Prompter, kubernetesVersions []string, opts ...WizardOpt) *Wizard {
+	w := &Wizard{
+		prompter:           prompter,
+		kubernetesVersions: kubernetesVersions,
+		lookupEnv:          os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Run prompts the user for the cluster configuration. When provider is not empty the provider
+// question is skipped.
+func (w *Wizard) Run(ctx context.Context, provider string) (*Answers, error) {
+	var err error
+	if provider == "" {
+		provider, err = w.prompter.Select("Which provider will the cluster run on?", providerNames, constants.VSphereProviderName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d, ok := defaults[provider]
+	if !ok {
+		return nil, fmt.Errorf("not a valid provider: %s", provider)
+	}
+	w.checkCredentials(provider, d)
+
+	a := &Answers{Provider: provider}
+
+	version, err := w.prompter.Select("Which Kubernetes version will the cluster run?", w.kubernetesVersions, w.defaultKubernetesVersion())
+	if err != nil {
+		return nil, err
+	}
+	a.KubernetesVersion = v1alpha1.KubernetesVersion(version)
+
+	if a.ControlPlaneCount, err = w.prompter.Int("Number of control plane nodes", d.controlPlaneCount, 1); err != nil {
+		return nil, err
+	}
+
+	if d.etcdCount > 0 {
+		etcd, err := w.prompter.Select("Number of external etcd nodes", []string{"1", "3", "5"}, strconv.Itoa(d.etcdCount))
+		if err != nil {
+			return nil, err
+		}
+		a.EtcdCount, _ = strconv.Atoi(etcd)
+	}
+
+	if a.WorkerCount, err = w.prompter.Int("Number of worker nodes", d.workerCount, 1); err != nil {
+		return nil, err
+	}
+
+	if d.endpoint {
+		if a.EndpointHost, err = w.prompter.Input("Control plane endpoint IP", "", validateIP); err != nil {
+			return nil, err
+		}
+	}
+
+	if provider == constants.VSphereProviderName {
+		if a.VSphere, err = w.askVSphere(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// defaultKubernetesVersion returns the cluster default version if it's offered, the newest one otherwise.
+func (w *Wizard) defaultKubernetesVersion() string {
+	if len(w.kubernetesVersions) == 0 {
+		return ""
+	}
+
+	defaultVersion := string(v1alpha1.GetClusterDefaultKubernetesVersion())
+	for _, v := range w.kubernetesVersions {
+		if v == defaultVersion {
+			return defaultVersion
+		}
+	}
+
+	return w.kubernetesVersions[len(w.kubernetesVersions)-1]
+}
+
+func (w *Wizard) checkCredentials(provider string, d providerDefaults) {
+	for _, env := range d.credentialEnvs {
+		if v, ok := w.lookupEnv(env); !ok || v == "" {
+			fmt.Fprintf(w.prompter.out, "Warning: %s is not set, it's required to create a %s cluster\n", env, provider)
+		}
+	}
+}
+
+func (w *Wizard) askVSphere(ctx context.Context) (*VSphereAnswers, error) {
+	v := &VSphereAnswers{}
+	var err error
+
+	if v.Server, err = w.prompter.Input("vCenter server", "", validateNotEmpty); err != nil {
+		return nil, err
+	}
+	if v.Insecure, err = w.prompter.Confirm("Skip the vCenter TLS certificate verification?", false); err != nil {
+		return nil, err
+	}
+	if v.Datacenter, err = w.prompter.Input("vSphere datacenter", "", validateNotEmpty); err != nil {
+		return nil, err
+	}
+	if v.Network, err = w.askVSphereNetwork(ctx, v); err != nil {
+		return nil, err
+	}
+	if v.NumCPUs, err = w.prompter.Int("Number of CPUs per machine", v1alpha1.DefaultVSphereNumCPUs, 1); err != nil {
+		return nil, err
+	}
+	if v.MemoryMiB, err = w.prompter.Int("Memory per machine in MiB", v1alpha1.DefaultVSphereMemoryMiB, 1); err != nil {
+		return nil, err
+	}
+	if v.DiskGiB, err = w.prompter.Int("Disk size per machine in GiB", v1alpha1.DefaultVSphereDiskGiB, 1); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (w *Wizard) askVSphereNetwork(ctx context.Context, v *VSphereAnswers) (string, error) {
+	const question = "Network for the cluster machines"
+	if w.vSphere != nil {
+		networks, err := w.vSphere.Networks(ctx, v.Server, v.Datacenter, v.Insecure)
+		if err != nil {
+			fmt.Fprintf(w.prompter.out, "Warning: unable to list vSphere networks, enter the network manually: %v\n", err)
+		} else if len(networks) > 0 {
+			return w.prompter.Select(question, networks, networks[0])
+		}
+	}
+
+	return w.prompter.Input(question, "", validateNotEmpty)
+}
+
+// ApplyToCluster sets the answers in cluster.
+func (a *Answers) ApplyToCluster(cluster *v1alpha1.ClusterGenerate) {
+	if a == nil {
+		return
+	}
+
+	cluster.Spec.KubernetesVersion = a.KubernetesVersion
+	cluster.Spec.ControlPlaneConfiguration.Count = a.ControlPlaneCount
+	if cluster.Spec.ControlPlaneConfiguration.Endpoint != nil {
+		cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = a.EndpointHost
+	}
+	if cluster.Spec.ExternalEtcdConfiguration != nil && a.EtcdCount > 0 {
+		cluster.Spec.ExternalEtcdConfiguration.Count = a.EtcdCount
+	}
+	if len(cluster.Spec.WorkerNodeGroupConfigurations) > 0 {
+		count := a.WorkerCount
+		cluster.Spec.WorkerNodeGroupConfigurations[0].Count = &count
+	}
+}
+
+// ApplyToVSphereDatacenterConfig sets the vSphere answers in datacenter.
+func (a *Answers) ApplyToVSphereDatacenterConfig(datacenter *v1alpha1.VSphereDatacenterConfigGenerate) {
+	if a == nil || a.VSphere == nil {
+		return
+	}
+
+	datacenter.Spec.Server = a.VSphere.Server
+	datacenter.Spec.Insecure = a.VSphere.Insecure
+	datacenter.Spec.Datacenter = a.VSphere.Datacenter
+	datacenter.Spec.Network = a.VSphere.Network
+}
+
+// ApplyToVSphereMachineConfig sets the vSphere machine sizing answers in machineConfig.
+func (a *Answers) ApplyToVSphereMachineConfig(machineConfig *v1alpha1.VSphereMachineConfigGenerate) {
+	if a == nil || a.VSphere == nil {
+		return
+	}
+
+	machineConfig.Spec.NumCPUs = a.VSphere.NumCPUs
+	machineConfig.Spec.MemoryMiB = a.VSphere.MemoryMiB
+	machineConfig.Spec.DiskGiB = a.VSphere.DiskGiB
+}
+
+// ValidateClusterConfig validates the Cluster in a generated cluster config.
+func ValidateClusterConfig(content []byte) error {
+	cluster := &v1alpha1.Cluster{}
+	if err := v1alpha1.ParseClusterConfigFromContent(content, cluster); err != nil {
+		return fmt.Errorf("parsing generated cluster config: %v", err)
+	}
+
+	if err := v1alpha1.ValidateClusterConfigContent(cluster); err != nil {
+		return fmt.Errorf("validating generated cluster config: %v", err)
+	}
+
+	return nil
+}
+
+func validateIP(answer string) error {
+	if net.ParseIP(answer) == nil {
+		return fmt.Errorf("%q is not a valid IP address", answer)
+	}
+	return nil
+}
+
+func validateNotEmpty(answer string) error {
+	if answer == "" {
+		return errors.New("value can't be empty")
+	}
+	return nil
+}
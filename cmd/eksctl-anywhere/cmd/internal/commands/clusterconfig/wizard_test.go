@@ -0,0 +1,211 @@
+package clusterconfig_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/clusterconfig"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+var kubernetesVersions = []string{"1.26", "1.27", "1.28"}
+
+type fakeVSphereDiscoverer struct {
+	networks []string
+	err      error
+}
+
+func (f fakeVSphereDiscoverer) Networks(_ context.Context, _, _ string, _ bool) ([]string, error) {
+	return f.networks, f.err
+}
+
+func TestWizardRunDocker(t *testing.T) {
+	g := NewWithT(t)
+	// provider, kubernetes version, control plane count, etcd count, worker count
+	p, _ := newPrompter("docker", "1.28", "", "3", "2")
+
+	answers, err := clusterconfig.NewWizard(p, kubernetesVersions).Run(context.Background(), "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(Equal(&clusterconfig.Answers{
+		Provider:          "docker",
+		KubernetesVersion: v1alpha1.Kube128,
+		ControlPlaneCount: 1,
+		EtcdCount:         3,
+		WorkerCount:       2,
+	}))
+}
+
+func TestWizardRunVSphereWithDiscoveredNetworks(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(config.EksavSphereUsernameKey, "user")
+	t.Setenv(config.EksavSpherePasswordKey, "pass")
+	// kubernetes version, control plane count, etcd count, worker count, endpoint, server,
+	// insecure, datacenter, network, cpus, memory, disk
+	p, out := newPrompter("", "3", "", "", "10.0.0.10", "vcenter.local", "y", "dc", "2", "4", "16384", "")
+	discoverer := fakeVSphereDiscoverer{networks: []string{"/dc/network/a", "/dc/network/b"}}
+
+	answers, err := clusterconfig.NewWizard(p, kubernetesVersions, clusterconfig.WithVSphereDiscoverer(discoverer)).Run(context.Background(), "vsphere")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(out.String()).ToNot(ContainSubstring("Warning"))
+	g.Expect(answers).To(Equal(&clusterconfig.Answers{
+		Provider:          "vsphere",
+		KubernetesVersion: v1alpha1.GetClusterDefaultKubernetesVersion(),
+		ControlPlaneCount: 3,
+		EtcdCount:         3,
+		WorkerCount:       2,
+		EndpointHost:      "10.0.0.10",
+		VSphere: &clusterconfig.VSphereAnswers{
+			Server:     "vcenter.local",
+			Insecure:   true,
+			Datacenter: "dc",
+			Network:    "/dc/network/b",
+			NumCPUs:    4,
+			MemoryMiB:  16384,
+			DiskGiB:    v1alpha1.DefaultVSphereDiskGiB,
+		},
+	}))
+}
+
+func TestWizardRunVSphereDiscoveryFailure(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(config.EksavSphereUsernameKey, "")
+	p, out := newPrompter("", "", "", "", "10.0.0.10", "vcenter.local", "", "dc", "/dc/network/manual", "", "", "")
+	discoverer := fakeVSphereDiscoverer{err: errors.New("connection refused")}
+
+	answers, err := clusterconfig.NewWizard(p, kubernetesVersions, clusterconfig.WithVSphereDiscoverer(discoverer)).Run(context.Background(), "vsphere")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers.VSphere.Network).To(Equal("/dc/network/manual"))
+	g.Expect(out.String()).To(ContainSubstring("Warning: EKSA_VSPHERE_USERNAME is not set"))
+	g.Expect(out.String()).To(ContainSubstring("unable to list vSphere networks, enter the network manually: connection refused"))
+}
+
+func TestWizardRunInvalidEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("", "", "", "not-an-ip", "10.0.0.10")
+
+	answers, err := clusterconfig.NewWizard(p, kubernetesVersions).Run(context.Background(), "tinkerbell")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers.EndpointHost).To(Equal("10.0.0.10"))
+	g.Expect(out.String()).To(ContainSubstring(`"not-an-ip" is not a valid IP address`))
+}
+
+func TestWizardRunDefaultsToNewestVersion(t *testing.T) {
+	g := NewWithT(t)
+	p, _ := newPrompter("", "", "", "10.0.0.10")
+
+	answers, err := clusterconfig.NewWizard(p, []string{"1.29", "1.30"}).Run(context.Background(), "tinkerbell")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers.KubernetesVersion).To(Equal(v1alpha1.KubernetesVersion("1.30")))
+}
+
+func TestKubernetesVersions(t *testing.T) {
+	g := NewWithT(t)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{KubeVersion: "1.28"},
+				{KubeVersion: "1.9"},
+				{KubeVersion: "1.27"},
+				{KubeVersion: "1.28"},
+			},
+		},
+	}
+
+	g.Expect(clusterconfig.KubernetesVersions(bundles)).To(Equal([]string{"1.9", "1.27", "1.28"}))
+}
+
+func TestKubernetesVersionsInvalid(t *testing.T) {
+	g := NewWithT(t)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{{KubeVersion: "latest"}},
+		},
+	}
+
+	_, err := clusterconfig.KubernetesVersions(bundles)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid kubernetes version in bundles")))
+}
+
+func TestWizardRunInvalidProvider(t *testing.T) {
+	g := NewWithT(t)
+	p, _ := newPrompter()
+
+	_, err := clusterconfig.NewWizard(p, kubernetesVersions).Run(context.Background(), "unknown")
+	g.Expect(err).To(MatchError("not a valid provider: unknown"))
+}
+
+func TestAnswersApply(t *testing.T) {
+	g := NewWithT(t)
+	answers := &clusterconfig.Answers{
+		Provider:          "vsphere",
+		KubernetesVersion: v1alpha1.Kube126,
+		ControlPlaneCount: 3,
+		EtcdCount:         5,
+		WorkerCount:       4,
+		EndpointHost:      "10.0.0.10",
+		VSphere: &clusterconfig.VSphereAnswers{
+			Server: "vcenter.local", Datacenter: "dc", Network: "net", NumCPUs: 4, MemoryMiB: 16384, DiskGiB: 50,
+		},
+	}
+
+	datacenter := v1alpha1.NewVSphereDatacenterConfigGenerate("test")
+	answers.ApplyToVSphereDatacenterConfig(datacenter)
+	machineConfig := v1alpha1.NewVSphereMachineConfigGenerate("test")
+	answers.ApplyToVSphereMachineConfig(machineConfig)
+	cluster := v1alpha1.NewClusterGenerate("test",
+		v1alpha1.WithClusterEndpoint(),
+		v1alpha1.WithDatacenterRef(datacenter),
+		v1alpha1.ControlPlaneConfigCount(2),
+		v1alpha1.ExternalETCDConfigCount(3),
+		v1alpha1.WorkerNodeConfigCount(2),
+		v1alpha1.WorkerNodeConfigName("md-0"),
+		v1alpha1.WithCPMachineGroupRef(machineConfig),
+		v1alpha1.WithWorkerMachineGroupRef(machineConfig),
+		v1alpha1.WithEtcdMachineGroupRef(machineConfig),
+	)
+	answers.ApplyToCluster(cluster)
+
+	g.Expect(cluster.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube126))
+	g.Expect(cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+	g.Expect(cluster.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("10.0.0.10"))
+	g.Expect(cluster.Spec.ExternalEtcdConfiguration.Count).To(Equal(5))
+	g.Expect(*cluster.Spec.WorkerNodeGroupConfigurations[0].Count).To(Equal(4))
+	g.Expect(datacenter.Spec.Server).To(Equal("vcenter.local"))
+	g.Expect(datacenter.Spec.Network).To(Equal("net"))
+	g.Expect(machineConfig.Spec.NumCPUs).To(Equal(4))
+	g.Expect(machineConfig.Spec.DiskGiB).To(Equal(50))
+
+	clusterYaml, err := yaml.Marshal(cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusterconfig.ValidateClusterConfig(templater.AppendYamlResources(clusterYaml))).To(Succeed())
+}
+
+func TestAnswersApplyNil(t *testing.T) {
+	g := NewWithT(t)
+	var answers *clusterconfig.Answers
+	cluster := v1alpha1.NewClusterGenerate("test", v1alpha1.ControlPlaneConfigCount(2))
+
+	answers.ApplyToCluster(cluster)
+	g.Expect(cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(2))
+}
+
+func TestValidateClusterConfigInvalid(t *testing.T) {
+	g := NewWithT(t)
+	cluster := v1alpha1.NewClusterGenerate("test",
+		v1alpha1.WithClusterEndpoint(),
+		v1alpha1.WithDatacenterRef(v1alpha1.NewVSphereDatacenterConfigGenerate("test")),
+		v1alpha1.ControlPlaneConfigCount(1),
+		v1alpha1.WorkerNodeConfigCount(1),
+	)
+	clusterYaml, err := yaml.Marshal(cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(clusterconfig.ValidateClusterConfig(clusterYaml)).To(MatchError(ContainSubstring("Endpoint.Host is not set or is empty")))
+}
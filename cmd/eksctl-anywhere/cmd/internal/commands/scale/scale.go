@@ -0,0 +1,123 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// NodeGroup sets the count of a worker node group of an EKS-A cluster without running the
+// upgrade workflow. It updates the count both in the EKS-A cluster object, so the controller
+// doesn't revert it, and in the MachineDeployment so CAPI starts scaling right away. For
+// Tinkerbell clusters, it checks there is enough available hardware before scaling up.
+// The scale is recorded as a ClusterOperation in the namespace of the cluster.
+func NodeGroup(ctx context.Context, client kubernetes.Client, clusterName, namespace, nodeGroup string, count int) error {
+	if count < 0 {
+		return errors.New("count can't be negative")
+	}
+
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, clusterName, namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", clusterName, err)
+	}
+
+	op := v1alpha1.NewClusterOperation(cluster, v1alpha1.ScaleClusterOperation, anywhereCluster.CLIOperationInitiator(), time.Now())
+	recordOperation(ctx, client, op)
+	err := scaleNodeGroup(ctx, client, cluster, nodeGroup, count, op)
+	op.Complete(time.Now(), err)
+	recordOperation(ctx, client, op)
+
+	return err
+}
+
+func scaleNodeGroup(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, nodeGroup string, count int, op *v1alpha1.ClusterOperation) error {
+	clusterName := cluster.Name
+	if cluster.Spec.GitOpsRef != nil {
+		return fmt.Errorf("cluster %s is managed with GitOps, update the worker node group count in the git repository instead", clusterName)
+	}
+
+	index := -1
+	for i, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.Name == nodeGroup {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("worker node group %s not found in cluster %s", nodeGroup, clusterName)
+	}
+
+	workerNodeGroup := cluster.Spec.WorkerNodeGroupConfigurations[index]
+	if workerNodeGroup.AutoScalingConfiguration != nil {
+		return fmt.Errorf("worker node group %s is managed by the cluster autoscaler and can't be scaled manually", nodeGroup)
+	}
+
+	current := 0
+	if workerNodeGroup.Count != nil {
+		current = *workerNodeGroup.Count
+	}
+
+	if cluster.Spec.DatacenterRef.Kind == v1alpha1.TinkerbellDatacenterKind && count > current {
+		if err := validateHardwareAvailable(ctx, client, cluster, workerNodeGroup, count-current); err != nil {
+			return err
+		}
+	}
+
+	if count != current {
+		cluster.Spec.WorkerNodeGroupConfigurations[index].Count = &count
+		if err := client.Update(ctx, cluster); err != nil {
+			return fmt.Errorf("updating cluster %s: %v", clusterName, err)
+		}
+
+		// The cluster is read again since not all clients return the new generation on update.
+		// The generation tells the controller the change was made by this operation.
+		if err := client.Get(ctx, clusterName, cluster.Namespace, cluster); err != nil {
+			return fmt.Errorf("getting cluster %s: %v", clusterName, err)
+		}
+		op.Spec.ClusterGeneration = cluster.Generation
+	}
+
+	if err := clusterapi.ScaleWorkerNodeGroup(ctx, client, cluster, workerNodeGroup, count); err != nil {
+		return err
+	}
+
+	logger.Info("Scaled worker node group", "cluster", clusterName, "nodeGroup", nodeGroup, "from", current, "to", count)
+	return nil
+}
+
+// recordOperation writes op to the cluster. A failure is only logged, so it doesn't make the scale fail.
+func recordOperation(ctx context.Context, client kubernetes.Client, op *v1alpha1.ClusterOperation) {
+	if err := anywhereCluster.RecordOperation(ctx, client, op); err != nil {
+		logger.Info("Warning: failed to record cluster operation", "operation", op.Name, "error", err)
+	}
+}
+
+func validateHardwareAvailable(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration, needed int) error {
+	if workerNodeGroup.MachineGroupRef == nil {
+		return fmt.Errorf("worker node group %s has no machineGroupRef", workerNodeGroup.Name)
+	}
+
+	machineConfig := &v1alpha1.TinkerbellMachineConfig{}
+	if err := client.Get(ctx, workerNodeGroup.MachineGroupRef.Name, cluster.Namespace, machineConfig); err != nil {
+		return fmt.Errorf("getting tinkerbell machine config %s: %v", workerNodeGroup.MachineGroupRef.Name, err)
+	}
+
+	available, err := hardware.CountAvailable(ctx, client, machineConfig.Spec.HardwareSelector)
+	if err != nil {
+		return err
+	}
+
+	if available < needed {
+		return fmt.Errorf("not enough hardware available for worker node group %s: need %d, found %d matching hardware selector %v", workerNodeGroup.Name, needed, available, machineConfig.Spec.HardwareSelector)
+	}
+
+	return nil
+}
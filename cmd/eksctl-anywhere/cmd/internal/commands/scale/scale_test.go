@@ -0,0 +1,159 @@
+package scale_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/scale"
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func clusterObjects(datacenterKind string) []client.Object {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{Kind: datacenterKind, Name: "my-cluster"},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:            "md-0",
+					Count:           ptr.Int(2),
+					MachineGroupRef: &v1alpha1.Ref{Name: "my-cluster-md-0"},
+				},
+			},
+		},
+	}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: constants.EksaSystemNamespace},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.Int32(2),
+		},
+	}
+
+	return []client.Object{cluster, md}
+}
+
+func expectCount(g *WithT, c kubernetes.Client, count int) {
+	ctx := context.Background()
+	cluster := &v1alpha1.Cluster{}
+	g.Expect(c.Get(ctx, "my-cluster", "default", cluster)).To(Succeed())
+	g.Expect(*cluster.Spec.WorkerNodeGroupConfigurations[0].Count).To(Equal(count))
+
+	md := &clusterv1.MachineDeployment{}
+	g.Expect(c.Get(ctx, "my-cluster-md-0", constants.EksaSystemNamespace, md)).To(Succeed())
+	g.Expect(*md.Spec.Replicas).To(BeEquivalentTo(count))
+}
+
+func TestNodeGroup(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewFakeKubeClient(clusterObjects(v1alpha1.VSphereDatacenterKind)...)
+
+	g.Expect(scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 7)).To(Succeed())
+	expectCount(g, c, 7)
+
+	cluster := &v1alpha1.Cluster{}
+	g.Expect(c.Get(context.Background(), "my-cluster", "default", cluster)).To(Succeed())
+	operations, err := anywhereCluster.ListOperations(context.Background(), c, "my-cluster", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(operations).To(HaveLen(1))
+	g.Expect(operations[0].Spec.Type).To(Equal(v1alpha1.ScaleClusterOperation))
+	g.Expect(operations[0].Spec.Initiator.Type).To(Equal(v1alpha1.CLIInitiator))
+	g.Expect(operations[0].Spec.ClusterGeneration).To(Equal(cluster.Generation))
+	g.Expect(operations[0].Status.Outcome).To(Equal(v1alpha1.OperationSucceeded))
+}
+
+func TestNodeGroupNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewFakeKubeClient(clusterObjects(v1alpha1.VSphereDatacenterKind)...)
+
+	err := scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-1", 7)
+	g.Expect(err).To(MatchError("worker node group md-1 not found in cluster my-cluster"))
+
+	operations, err := anywhereCluster.ListOperations(context.Background(), c, "my-cluster", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(operations).To(HaveLen(1))
+	g.Expect(operations[0].Status.Outcome).To(Equal(v1alpha1.OperationFailed))
+	g.Expect(operations[0].Status.Message).To(Equal("worker node group md-1 not found in cluster my-cluster"))
+}
+
+func TestNodeGroupNegativeCount(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewFakeKubeClient()
+
+	err := scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", -1)
+	g.Expect(err).To(MatchError("count can't be negative"))
+}
+
+func TestNodeGroupGitOps(t *testing.T) {
+	g := NewWithT(t)
+	objs := clusterObjects(v1alpha1.VSphereDatacenterKind)
+	objs[0].(*v1alpha1.Cluster).Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "flux"}
+	c := test.NewFakeKubeClient(objs...)
+
+	err := scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 7)
+	g.Expect(err).To(MatchError(ContainSubstring("is managed with GitOps")))
+}
+
+func TestNodeGroupAutoscaler(t *testing.T) {
+	g := NewWithT(t)
+	objs := clusterObjects(v1alpha1.VSphereDatacenterKind)
+	objs[0].(*v1alpha1.Cluster).Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5}
+	c := test.NewFakeKubeClient(objs...)
+
+	err := scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 4)
+	g.Expect(err).To(MatchError(ContainSubstring("managed by the cluster autoscaler")))
+}
+
+func TestNodeGroupTinkerbell(t *testing.T) {
+	machineConfig := &v1alpha1.TinkerbellMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "default"},
+		Spec: v1alpha1.TinkerbellMachineConfigSpec{
+			HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		},
+	}
+	hw := &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hw1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{"type": "worker"},
+		},
+	}
+
+	t.Run("enough hardware", func(t *testing.T) {
+		g := NewWithT(t)
+		objs := append(clusterObjects(v1alpha1.TinkerbellDatacenterKind), machineConfig.DeepCopy(), hw.DeepCopy())
+		c := test.NewFakeKubeClient(objs...)
+
+		g.Expect(scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 3)).To(Succeed())
+		expectCount(g, c, 3)
+	})
+
+	t.Run("not enough hardware", func(t *testing.T) {
+		g := NewWithT(t)
+		objs := append(clusterObjects(v1alpha1.TinkerbellDatacenterKind), machineConfig.DeepCopy(), hw.DeepCopy())
+		c := test.NewFakeKubeClient(objs...)
+
+		err := scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 4)
+		g.Expect(err).To(MatchError(ContainSubstring("not enough hardware available for worker node group md-0: need 2, found 1")))
+		expectCount(g, c, 2)
+	})
+
+	t.Run("scale down", func(t *testing.T) {
+		g := NewWithT(t)
+		objs := append(clusterObjects(v1alpha1.TinkerbellDatacenterKind), machineConfig.DeepCopy())
+		c := test.NewFakeKubeClient(objs...)
+
+		g.Expect(scale.NodeGroup(context.Background(), c, "my-cluster", "default", "md-0", 1)).To(Succeed())
+		expectCount(g, c, 1)
+	})
+}
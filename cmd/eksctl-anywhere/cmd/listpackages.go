@@ -19,6 +19,8 @@ type listPackagesOption struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	// versions lists the chart versions available in the registry for each package.
+	versions bool
 }
 
 var lpo = &listPackagesOption{}
@@ -36,6 +38,8 @@ func init() {
 		"Name of cluster for package list.")
 	listPackagesCommand.Flags().StringVar(&lpo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	listPackagesCommand.Flags().BoolVar(&lpo.versions, "versions", false,
+		"List the package versions available in the registry, including the ones not in the active bundle.")
 }
 
 var listPackagesCommand = &cobra.Command{
@@ -76,6 +80,10 @@ func listPackages(ctx context.Context) error {
 	packages := curatedpackages.NewPackageClient(
 		deps.Kubectl,
 		curatedpackages.WithBundle(bundle),
+		curatedpackages.WithChartVersionLister(deps.Helm),
 	)
+	if lpo.versions {
+		return packages.DisplayPackagesWithAvailableVersions(ctx, os.Stdout, lpo.registry)
+	}
 	return packages.DisplayPackages(os.Stdout)
 }
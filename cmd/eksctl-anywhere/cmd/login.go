@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Login to resources",
+	Long:  "Use eksctl anywhere login to obtain credentials for a resource under your own identity",
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/oidc"
+)
+
+const oidcKubeconfigFormat = "%s-eks-a-oidc.kubeconfig"
+
+type loginClusterOptions struct {
+	fileName             string
+	certificateAuthority string
+	server               string
+	scopes               []string
+	output               string
+}
+
+var lco = &loginClusterOptions{}
+
+var loginClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file> --certificate-authority <ca-file>",
+	Short:        "Login to a cluster with its OIDC provider",
+	Long:         "This command obtains credentials for a cluster from the OIDC provider configured in the cluster spec with the device authorization flow and writes them to a kubeconfig file, which can be used with the --kubeconfig flag of the lifecycle commands instead of the admin kubeconfig",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return loginCluster(cmd.Context(), lco)
+	},
+}
+
+func init() {
+	loginCmd.AddCommand(loginClusterCmd)
+	loginClusterCmd.Flags().StringVarP(&lco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	loginClusterCmd.Flags().StringVar(&lco.certificateAuthority, "certificate-authority", "", "Path to the CA certificate of the cluster API server")
+	loginClusterCmd.Flags().StringVar(&lco.server, "server", "", "URL of the cluster API server. Defaults to the control plane endpoint in the cluster config")
+	loginClusterCmd.Flags().StringSliceVar(&lco.scopes, "scopes", nil, "Additional OIDC scopes to request, like groups")
+	loginClusterCmd.Flags().StringVarP(&lco.output, "output", "o", "", "Path of the kubeconfig file to write. Defaults to <cluster-name>/<cluster-name>-eks-a-oidc.kubeconfig")
+	for _, flag := range []string{"filename", "certificate-authority"} {
+		if err := loginClusterCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("marking %s flag as required: %s", flag, err)
+		}
+	}
+}
+
+func loginCluster(ctx context.Context, opts *loginClusterOptions) error {
+	config, err := cluster.ParseConfigFromFile(opts.fileName)
+	if err != nil {
+		return err
+	}
+
+	oidcConfig := clusterOIDCConfig(config)
+	if oidcConfig == nil {
+		return fmt.Errorf("cluster %s doesn't have an OIDC identity provider", config.Cluster.Name)
+	}
+
+	caData, err := os.ReadFile(opts.certificateAuthority)
+	if err != nil {
+		return fmt.Errorf("reading certificate authority: %v", err)
+	}
+
+	server := opts.server
+	if server == "" {
+		server, err = apiServerURL(config.Cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	flow := oidc.NewDeviceFlow(http.DefaultClient, oidcConfig.Spec.IssuerUrl, oidcConfig.Spec.ClientId, opts.scopes)
+	token, err := flow.Login(ctx, func(auth *oidc.DeviceAuthorization) {
+		if auth.VerificationURIComplete != "" {
+			fmt.Printf("To login, open %s and confirm the code %s\n", auth.VerificationURIComplete, auth.UserCode)
+			return
+		}
+		fmt.Printf("To login, open %s and enter the code %s\n", auth.VerificationURI, auth.UserCode)
+	})
+	if err != nil {
+		return fmt.Errorf("logging in to cluster %s: %v", config.Cluster.Name, err)
+	}
+
+	content, err := oidc.Kubeconfig(oidc.KubeconfigConfig{
+		ClusterName: config.Cluster.Name,
+		Server:      server,
+		CAData:      caData,
+		IssuerURL:   oidcConfig.Spec.IssuerUrl,
+		ClientID:    oidcConfig.Spec.ClientId,
+	}, token)
+	if err != nil {
+		return err
+	}
+
+	output := opts.output
+	if output == "" {
+		output = filepath.Join(config.Cluster.Name, fmt.Sprintf(oidcKubeconfigFormat, config.Cluster.Name))
+	}
+	if err = os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return fmt.Errorf("creating kubeconfig directory: %v", err)
+	}
+	if err = os.WriteFile(output, content, 0o600); err != nil {
+		return fmt.Errorf("writing kubeconfig: %v", err)
+	}
+
+	logger.MarkSuccess("Logged in to cluster")
+	fmt.Printf("Use --kubeconfig %s to run commands against cluster %s\n", output, config.Cluster.Name)
+	return nil
+}
+
+func clusterOIDCConfig(config *cluster.Config) *v1alpha1.OIDCConfig {
+	for _, ref := range config.Cluster.Spec.IdentityProviderRefs {
+		if ref.Kind == v1alpha1.OIDCConfigKind {
+			return config.OIDCConfig(ref.Name)
+		}
+	}
+	return nil
+}
+
+func apiServerURL(c *v1alpha1.Cluster) (string, error) {
+	if c.Spec.ControlPlaneConfiguration.Endpoint == nil || c.Spec.ControlPlaneConfiguration.Endpoint.Host == "" {
+		return "", fmt.Errorf("cluster %s doesn't have a control plane endpoint, use --server to set the API server URL", c.Name)
+	}
+
+	host, port, err := v1alpha1.GetControlPlaneHostPort(c.Spec.ControlPlaneConfiguration.Endpoint.Host, v1alpha1.ControlEndpointDefaultPort)
+	if err != nil {
+		return "", fmt.Errorf("parsing control plane endpoint: %v", err)
+	}
+
+	return "https://" + net.JoinHostPort(host, port), nil
+}
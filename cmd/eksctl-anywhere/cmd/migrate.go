@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate resources",
+	Long:  "Use eksctl anywhere migrate to move a resource to a new configuration that can't be changed with an upgrade, such as the control plane endpoint of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
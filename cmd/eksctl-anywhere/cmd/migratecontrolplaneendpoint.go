@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/endpointmigration"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+type migrateControlPlaneEndpointOptions struct {
+	clusterName string
+	namespace   string
+	endpoint    string
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+	// workloadKubeConfig is the kubeconfig of the cluster being migrated.
+	workloadKubeConfig string
+}
+
+var mcpeo = &migrateControlPlaneEndpointOptions{}
+
+var migrateControlPlaneEndpointCmd = &cobra.Command{
+	Use:          "control-plane-endpoint",
+	Short:        "Change the control plane endpoint of a cluster",
+	Long:         "This command changes the control plane endpoint host of an existing cluster. The control plane machines are rolled out serving both endpoints, the kubeconfigs and CAPI objects are moved to the new one and all the machines are rolled out again serving only the new endpoint. The workload cluster kubeconfig file is overwritten with one pointing to the new endpoint. Only vSphere and Nutanix clusters are supported.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mcpeo.migrateControlPlaneEndpoint(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to migrate control plane endpoint: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateControlPlaneEndpointCmd)
+	migrateControlPlaneEndpointCmd.Flags().StringVar(&mcpeo.clusterName, "cluster", "", "Name of the cluster")
+	migrateControlPlaneEndpointCmd.Flags().StringVarP(&mcpeo.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	migrateControlPlaneEndpointCmd.Flags().StringVar(&mcpeo.endpoint, "endpoint", "", "New control plane endpoint host. It must be an unused IP")
+	migrateControlPlaneEndpointCmd.Flags().StringVar(&mcpeo.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	migrateControlPlaneEndpointCmd.Flags().StringVar(&mcpeo.workloadKubeConfig, "workload-kubeconfig", "", "Workload cluster kubeconfig file, defaults to <cluster>/<cluster>-eks-a-cluster.kubeconfig. Ignored for management clusters")
+	for _, flag := range []string{"cluster", "endpoint"} {
+		if err := migrateControlPlaneEndpointCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("marking %s flag as required: %s", flag, err)
+		}
+	}
+}
+
+func (o *migrateControlPlaneEndpointOptions) migrateControlPlaneEndpoint(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(o.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	workloadKubeConfig := o.workloadKubeConfig
+	if workloadKubeConfig == "" {
+		workloadKubeConfig = kubeconfig.FromClusterName(o.clusterName)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig, workloadKubeConfig).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	// The kubeconfig files are rewritten during the migration, so the clients can't cache them.
+	clients := kubernetes.NewUnAuthClient(deps.Kubectl)
+	if err := clients.Init(); err != nil {
+		return fmt.Errorf("building kube client: %v", err)
+	}
+
+	cluster := &v1alpha1.Cluster{}
+	if err := clients.Get(ctx, o.clusterName, o.namespace, kubeConfig, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", o.clusterName, err)
+	}
+
+	if cluster.IsSelfManaged() {
+		workloadKubeConfig = kubeConfig
+	} else if err := kubeconfig.ValidateFilename(workloadKubeConfig); err != nil {
+		return err
+	}
+
+	migrator := endpointmigration.NewMigrator(clients, &networkutils.DefaultNetClient{})
+	migration := endpointmigration.Migration{
+		Cluster:              cluster,
+		Host:                 o.endpoint,
+		ManagementKubeconfig: kubeConfig,
+		WorkloadKubeconfig:   workloadKubeConfig,
+	}
+	if err := migrator.Migrate(ctx, migration); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Control plane endpoint migrated", "cluster", cluster.Name, "endpoint", o.endpoint)
+	logger.Info("Update the control plane endpoint host in the cluster config file before the next upgrade", "host", o.endpoint)
+	return nil
+}
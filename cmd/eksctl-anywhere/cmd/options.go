@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,23 +9,14 @@ import (
 
 	"github.com/spf13/pflag"
 
-	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/dependencies"
-	"github.com/aws/eks-anywhere/pkg/files"
-	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
-	"github.com/aws/eks-anywhere/pkg/types"
-	"github.com/aws/eks-anywhere/pkg/validations"
-	"github.com/aws/eks-anywhere/pkg/version"
 )
 
-const defaultTinkerbellNodeStartupTimeout = 20 * time.Minute
-
 const timeoutErrorTemplate = "failed to parse timeout %s: %v"
 
 type timeoutOptions struct {
@@ -47,40 +37,34 @@ func applyTimeoutFlags(flagSet *pflag.FlagSet, t *timeoutOptions) {
 	flagSet.BoolVar(&t.noTimeouts, noTimeoutsFlag, false, "Disable timeout for all wait operations")
 }
 
-// buildClusterManagerOpts builds options for constructing a ClusterManager from CLI flags.
-// datacenterKind is an API kind such as v1alpha1.TinkerbellDatacenterKind.
-func buildClusterManagerOpts(t timeoutOptions, datacenterKind string) (*dependencies.ClusterManagerTimeoutOptions, error) {
+// buildTimeouts parses the timeouts of the cluster operations from the CLI flags.
+func buildTimeouts(t timeoutOptions) (client.Timeouts, error) {
 	cpWaitTimeout, err := time.ParseDuration(t.cpWaitTimeout)
 	if err != nil {
-		return nil, fmt.Errorf(timeoutErrorTemplate, cpWaitTimeoutFlag, err)
+		return client.Timeouts{}, fmt.Errorf(timeoutErrorTemplate, cpWaitTimeoutFlag, err)
 	}
 
 	externalEtcdWaitTimeout, err := time.ParseDuration(t.externalEtcdWaitTimeout)
 	if err != nil {
-		return nil, fmt.Errorf(timeoutErrorTemplate, externalEtcdWaitTimeoutFlag, err)
+		return client.Timeouts{}, fmt.Errorf(timeoutErrorTemplate, externalEtcdWaitTimeoutFlag, err)
 	}
 
 	perMachineWaitTimeout, err := time.ParseDuration(t.perMachineWaitTimeout)
 	if err != nil {
-		return nil, fmt.Errorf(timeoutErrorTemplate, perMachineWaitTimeoutFlag, err)
+		return client.Timeouts{}, fmt.Errorf(timeoutErrorTemplate, perMachineWaitTimeoutFlag, err)
 	}
 
 	unhealthyMachineTimeout, err := time.ParseDuration(t.unhealthyMachineTimeout)
 	if err != nil {
-		return nil, fmt.Errorf(timeoutErrorTemplate, unhealthyMachineTimeoutFlag, err)
-	}
-
-	if t.nodeStartupTimeout == clustermanager.DefaultNodeStartupTimeout.String() &&
-		datacenterKind == v1alpha1.TinkerbellDatacenterKind {
-		t.nodeStartupTimeout = defaultTinkerbellNodeStartupTimeout.String()
+		return client.Timeouts{}, fmt.Errorf(timeoutErrorTemplate, unhealthyMachineTimeoutFlag, err)
 	}
 
 	nodeStartupTimeout, err := time.ParseDuration(t.nodeStartupTimeout)
 	if err != nil {
-		return nil, fmt.Errorf(timeoutErrorTemplate, nodeStartupTimeoutFlag, err)
+		return client.Timeouts{}, fmt.Errorf(timeoutErrorTemplate, nodeStartupTimeoutFlag, err)
 	}
 
-	return &dependencies.ClusterManagerTimeoutOptions{
+	return client.Timeouts{
 		ControlPlaneWait:     cpWaitTimeout,
 		ExternalEtcdWait:     externalEtcdWaitTimeout,
 		MachineWait:          perMachineWaitTimeout,
@@ -94,6 +78,8 @@ type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
 	managementKubeconfig string
+	templateValues       []string
+	overlays             []string
 }
 
 func (c clusterOptions) mountDirs() []string {
@@ -105,185 +91,96 @@ func (c clusterOptions) mountDirs() []string {
 	return dirs
 }
 
-func readClusterSpec(clusterConfigPath string, cliVersion version.Info, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
-	b := cluster.NewFileSpecBuilder(
-		files.NewReader(files.WithEKSAUserAgent("cli", cliVersion.GitVersion)),
-		cliVersion,
-		opts...,
-	)
-	return b.Build(clusterConfigPath)
-}
-
-func readAndValidateClusterSpec(clusterConfigPath string, cliVersion version.Info, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
-	clusterSpec, err := readClusterSpec(clusterConfigPath, cliVersion, opts...)
+// renderClusterConfig renders the cluster config file as a template when it has template markers
+// and merges the overlays on top of it. Env variables are substituted even if no template values
+// are set. fileName is then pointed to the rendered config, so every step reading the config file
+// gets the same content. The returned function removes the rendered file.
+func (c *clusterOptions) renderClusterConfig() (cleanup func(), err error) {
+	cleanup = func() {}
+	values, err := cluster.ParseTemplateValues(c.templateValues)
 	if err != nil {
 		return nil, err
 	}
-	if err = cluster.ValidateConfig(clusterSpec.Config); err != nil {
-		return nil, err
-	}
 
-	return clusterSpec, nil
-}
-
-func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
-	var opts []cluster.FileSpecBuilderOpt
-	if options.bundlesOverride != "" {
-		opts = append(opts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
-	}
-
-	clusterSpec, err := readAndValidateClusterSpec(options.fileName, version.Get(), opts...)
+	content, err := os.ReadFile(c.fileName)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get cluster config from file: %v", err)
+		return nil, fmt.Errorf("reading cluster config file: %v", err)
 	}
 
-	if clusterSpec.Cluster.IsManaged() {
-		if options.managementKubeconfig == "" {
-			managementKubeconfig, err := getManagementClusterKubeconfig(clusterSpec.Cluster.Spec.ManagementCluster.Name)
-			if err != nil {
-				return nil, err
-			}
-			options.managementKubeconfig = managementKubeconfig
-		}
-		managementCluster, err := cluster.LoadManagement(options.managementKubeconfig)
-		if err != nil {
-			return nil, fmt.Errorf("unable to get management cluster from kubeconfig: %v", err)
-		}
-		clusterSpec.ManagementCluster = managementCluster
+	if len(c.overlays) == 0 && !cluster.IsConfigTemplate(content) {
+		return cleanup, nil
 	}
 
-	return clusterSpec, nil
-}
-
-func markFlagHidden(flagSet *pflag.FlagSet, flagName string) {
-	if err := flagSet.MarkHidden(flagName); err != nil {
-		logger.V(5).Info("Warning: Failed to mark flag as hidden: " + flagName)
+	if content, err = renderClusterConfigTemplate(content, values); err != nil {
+		return nil, err
 	}
-}
 
-func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
-	cliConfig := &config.CliConfig{}
-	if clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Git != nil {
-		cliConfig.GitSshKeyPassphrase = os.Getenv(config.EksaGitPassphraseTokenEnv)
-		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
-		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
+	overlays := make([][]byte, 0, len(c.overlays))
+	for _, o := range c.overlays {
+		overlay, err := readClusterConfigTemplate(o, values)
+		if err != nil {
+			return nil, err
+		}
+		overlays = append(overlays, overlay)
 	}
 
-	return cliConfig
-}
-
-func buildCreateCliConfig(clusterOptions *createClusterOptions) (*config.CreateClusterCLIConfig, error) {
-	createCliConfig := &config.CreateClusterCLIConfig{}
-	createCliConfig.SkipCPIPCheck = clusterOptions.skipIpCheck
-	if clusterOptions.noTimeouts {
-		maxTime := time.Duration(math.MaxInt64)
-		createCliConfig.NodeStartupTimeout = maxTime
-		createCliConfig.UnhealthyMachineTimeout = maxTime
-
-		return createCliConfig, nil
+	if content, err = cluster.ApplyConfigOverlays(content, overlays...); err != nil {
+		return nil, err
 	}
 
-	unhealthyMachineTimeout, err := time.ParseDuration(clusterOptions.unhealthyMachineTimeout)
+	f, err := os.CreateTemp("", strings.TrimSuffix(filepath.Base(c.fileName), filepath.Ext(c.fileName))+"-rendered-*.yaml")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("creating rendered cluster config file: %v", err)
 	}
+	defer f.Close()
 
-	nodeStartupTimeout, err := time.ParseDuration(clusterOptions.nodeStartupTimeout)
-	if err != nil {
-		return nil, err
+	if _, err := f.Write(content); err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("writing rendered cluster config file: %v", err)
 	}
 
-	createCliConfig.NodeStartupTimeout = nodeStartupTimeout
-	createCliConfig.UnhealthyMachineTimeout = unhealthyMachineTimeout
+	logger.V(4).Info("Rendered cluster config", "base", c.fileName, "overlays", c.overlays, "config", f.Name())
+	c.fileName = f.Name()
 
-	return createCliConfig, nil
+	return func() { os.Remove(f.Name()) }, nil
 }
 
-func buildUpgradeCliConfig(clusterOptions *upgradeClusterOptions) (*config.UpgradeClusterCLIConfig, error) {
-	upgradeCliConfig := config.UpgradeClusterCLIConfig{}
-	if clusterOptions.noTimeouts {
-		maxTime := time.Duration(math.MaxInt64)
-		upgradeCliConfig.NodeStartupTimeout = maxTime
-		upgradeCliConfig.UnhealthyMachineTimeout = maxTime
-
-		return &upgradeCliConfig, nil
-	}
-
-	unhealthyMachineTimeout, err := time.ParseDuration(clusterOptions.unhealthyMachineTimeout)
-	if err != nil {
-		return nil, err
-	}
-
-	nodeStartupTimeout, err := time.ParseDuration(clusterOptions.nodeStartupTimeout)
+func readClusterConfigTemplate(path string, values map[string]string) ([]byte, error) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("reading cluster config file: %v", err)
 	}
 
-	upgradeCliConfig.NodeStartupTimeout = nodeStartupTimeout
-	upgradeCliConfig.UnhealthyMachineTimeout = unhealthyMachineTimeout
-
-	return &upgradeCliConfig, nil
+	return renderClusterConfigTemplate(content, values)
 }
 
-func getManagementClusterKubeconfig(clusterName string) (string, error) {
-	envKubeconfig := kubeconfig.FromEnvironment()
-	if envKubeconfig != "" {
-		return envKubeconfig, nil
+// renderClusterConfigTemplate renders content if it has template markers. Files without them are
+// returned untouched.
+func renderClusterConfigTemplate(content []byte, values map[string]string) ([]byte, error) {
+	if !cluster.IsConfigTemplate(content) {
+		return content, nil
 	}
-	// check if kubeconfig for management cluster exists locally
-	managementKubeconfigPath := kubeconfig.FromClusterName(clusterName)
-	if validations.FileExistsAndIsNotEmpty(managementKubeconfigPath) {
-		return managementKubeconfigPath, nil
-	}
-	return "", fmt.Errorf("management kubeconfig file not found, must be present for workload cluster operations")
-}
 
-func getManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
-	if clusterSpec.ManagementCluster == nil {
-		return &types.Cluster{
-			Name:           clusterSpec.Cluster.Name,
-			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
-		}
-	} else {
-		return &types.Cluster{
-			Name:           clusterSpec.ManagementCluster.Name,
-			KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
-		}
-	}
+	return cluster.RenderConfigTemplate(content, values)
 }
 
-func (c *clusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig, addDirs ...string) ([]string, error) {
-	dirs := c.mountDirs()
-	fluxConfig := clusterSpec.FluxConfig
-	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
-		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
-		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
-	}
+// newClient builds the client to run the cluster operations with the management cluster and
+// bundles of the options.
+func (c clusterOptions) newClient(opts ...client.Opt) *client.Client {
+	opts = append(opts, client.WithManagementKubeconfig(c.managementKubeconfig), client.WithBundlesOverride(c.bundlesOverride))
+	return client.New(opts...)
+}
 
-	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.CloudStackDatacenterKind {
-		if extraDirs, err := c.cloudStackDirectoriesToMount(); err == nil {
-			dirs = append(dirs, extraDirs...)
-		}
-	}
+func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
+	return client.NewClusterSpec(options.fileName, options.bundlesOverride, options.managementKubeconfig)
+}
 
-	for _, addDir := range addDirs {
-		dirs = append(dirs, filepath.Dir(addDir))
+func markFlagHidden(flagSet *pflag.FlagSet, flagName string) {
+	if err := flagSet.MarkHidden(flagName); err != nil {
+		logger.V(5).Info("Warning: Failed to mark flag as hidden: " + flagName)
 	}
-
-	return dirs, nil
 }
 
-func (c *clusterOptions) cloudStackDirectoriesToMount() ([]string, error) {
-	dirs := []string{}
-	env, found := os.LookupEnv(decoder.EksaCloudStackHostPathToMount)
-	if found && len(env) > 0 {
-		mountDirs := strings.Split(env, ",")
-		for _, dir := range mountDirs {
-			if _, err := os.Stat(dir); err != nil {
-				return nil, fmt.Errorf("invalid host path to mount: %v", err)
-			}
-			dirs = append(dirs, dir)
-		}
-	}
-	return dirs, nil
+func (c *clusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig) []string {
+	return client.DirectoriesToMount(clusterSpec, cliConfig, c.managementKubeconfig)
 }
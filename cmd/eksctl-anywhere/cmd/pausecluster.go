@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type clusterRolloutOptions struct {
+	clusterName string
+	namespace   string
+	nodeGroups  []string
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+}
+
+var pauseClusterOpts = &clusterRolloutOptions{}
+
+var pauseClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Pause the machine rollouts of a cluster",
+	Long:         "This command pauses the machine rollouts of a cluster so no machines are created or deleted, ex. during a provider maintenance window. Use --node-group to only pause some worker node groups.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setClusterRolloutsPaused(cmd.Context(), pauseClusterOpts, true)
+	},
+}
+
+func init() {
+	pauseCmd.AddCommand(pauseClusterCmd)
+	applyClusterRolloutFlags(pauseClusterCmd, pauseClusterOpts)
+}
+
+func applyClusterRolloutFlags(cmd *cobra.Command, opts *clusterRolloutOptions) {
+	cmd.Flags().StringVar(&opts.clusterName, "cluster", "", "Name of the cluster")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	cmd.Flags().StringSliceVar(&opts.nodeGroups, "node-group", nil, "Worker node groups to apply to, all the machines of the cluster including the control plane if not set")
+	cmd.Flags().StringVar(&opts.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	if err := cmd.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("marking cluster flag as required: %s", err)
+	}
+}
+
+func setClusterRolloutsPaused(ctx context.Context, opts *clusterRolloutOptions, paused bool) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().WithUnAuthKubeClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	client := kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeConfig)
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, opts.clusterName, opts.namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", opts.clusterName, err)
+	}
+
+	if err := clusterapi.SetRolloutsPaused(ctx, client, cluster, opts.nodeGroups, paused); err != nil {
+		return err
+	}
+
+	action := "Resumed"
+	if paused {
+		action = "Paused"
+	}
+	if len(opts.nodeGroups) > 0 {
+		logger.Info(fmt.Sprintf("%s machine rollouts", action), "cluster", cluster.Name, "nodeGroups", opts.nodeGroups)
+	} else {
+		logger.Info(fmt.Sprintf("%s machine rollouts", action), "cluster", cluster.Name)
+	}
+
+	return nil
+}
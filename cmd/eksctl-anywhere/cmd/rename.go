@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename resources",
+	Long:  "Use eksctl anywhere rename to change the name of a resource, such as a workload cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+}
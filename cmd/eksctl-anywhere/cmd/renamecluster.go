@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterrename"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type renameClusterOptions struct {
+	clusterOptions
+	newName string
+}
+
+var rnco = &renameClusterOptions{}
+
+var renameClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file> --new-name <name>",
+	Short:        "Rename a workload cluster",
+	Long:         "This command renames a workload cluster without rebuilding it. The objects of the cluster in the management cluster are moved to the new name, the kubeconfig of the renamed cluster is written to <new-name>/<new-name>-eks-a-cluster.kubeconfig and, for clusters managed with GitOps, the cluster config files are moved in the Git repository. Only vSphere clusters are supported",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rnco.renameCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to rename cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	renameCmd.AddCommand(renameClusterCmd)
+	applyClusterOptionFlags(renameClusterCmd.Flags(), &rnco.clusterOptions)
+	renameClusterCmd.Flags().StringVar(&rnco.newName, "new-name", "", "New name of the cluster")
+
+	flags.MarkRequired(renameClusterCmd.Flags(), flags.ClusterConfig.Name, "new-name")
+}
+
+func (o *renameClusterOptions) renameCluster(ctx context.Context) error {
+	if !validations.FileExists(o.fileName) {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", o.fileName))
+	}
+
+	cleanupRenderedConfig, err := o.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is a management cluster, only workload clusters can be renamed", clusterSpec.Cluster.Name)
+	}
+
+	oldName := clusterSpec.Cluster.Name
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.ManagedBy(),
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.ManagedBy(), o.managementKubeconfig),
+	}
+	if err := kubeconfig.ValidateFilename(managementCluster.KubeconfigFile); err != nil {
+		return err
+	}
+
+	cliConfig := client.BuildCliConfig(clusterSpec)
+	dirs := append(o.directoriesToMount(clusterSpec, cliConfig), oldName, o.newName)
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithProvider(o.fileName, clusterSpec.Cluster, false, "", false, "", nil).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	clients := kubernetes.NewUnAuthClient(deps.Kubectl)
+	if err := clients.Init(); err != nil {
+		return fmt.Errorf("building kube client: %v", err)
+	}
+
+	namespace := clusterSpec.Cluster.Namespace
+	if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+
+	cluster := &v1alpha1.Cluster{}
+	if err := clients.Get(ctx, oldName, namespace, managementCluster.KubeconfigFile, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", oldName, err)
+	}
+
+	managementClient, err := kubernetes.NewRuntimeClientFromFileName(managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	renamer := clusterrename.NewRenamer(managementClient)
+	rename := clusterrename.Rename{
+		Cluster:        cluster,
+		NewName:        o.newName,
+		BackupFile:     filepath.Join(oldName, oldName+"-rename-backup.yaml"),
+		KubeconfigFile: kubeconfig.FromClusterName(o.newName),
+	}
+	if err := renamer.Validate(ctx, rename); err != nil {
+		return err
+	}
+
+	// Flux would create the cluster with the old name again from the Git repository, so it's
+	// suspended until the cluster config files are moved.
+	logger.Info("Suspending GitOps cluster reconciliation")
+	if err := deps.GitOpsFlux.SuspendKustomization(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+
+	logger.Info("Renaming cluster", "cluster", oldName, "newName", o.newName, "backup", rename.BackupFile)
+	if err := renamer.Rename(ctx, rename); err != nil {
+		if resumeErr := deps.GitOpsFlux.ResumeKustomization(ctx, managementCluster, clusterSpec); resumeErr != nil {
+			logger.Error(resumeErr, "Resuming GitOps cluster reconciliation")
+		}
+		return err
+	}
+
+	clusterSpec.Cluster.Name = o.newName
+	logger.Info("Moving cluster config files in Git repository")
+	if err := deps.GitOpsFlux.RenameGitCluster(ctx, oldName, clusterSpec, deps.Provider.DatacenterConfig(clusterSpec), deps.Provider.MachineConfigs(clusterSpec)); err != nil {
+		return fmt.Errorf("cluster renamed but its GitOps reconciliation is still suspended: %v", err)
+	}
+
+	logger.Info("Resuming GitOps cluster reconciliation")
+	if err := deps.GitOpsFlux.ResumeKustomization(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Cluster renamed", "cluster", oldName, "newName", o.newName, "kubeconfig", rename.KubeconfigFile)
+	logger.Info("Update the cluster name in the cluster config file before the next upgrade", "name", o.newName)
+	return nil
+}
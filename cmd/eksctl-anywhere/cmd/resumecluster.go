@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var resumeClusterOpts = &clusterRolloutOptions{}
+
+var resumeClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Resume the machine rollouts of a cluster",
+	Long:         "This command resumes the machine rollouts of a cluster paused with eksctl anywhere pause cluster. Use --node-group to only resume some worker node groups.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setClusterRolloutsPaused(cmd.Context(), resumeClusterOpts, false)
+	},
+}
+
+func init() {
+	resumeCmd.AddCommand(resumeClusterCmd)
+	applyClusterRolloutFlags(resumeClusterCmd, resumeClusterOpts)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type rollbackClusterOptions struct {
+	upgradeClusterOptions
+	clusterName string
+	namespace   string
+	toRevision  int
+}
+
+var rco = &rollbackClusterOptions{}
+
+var rollbackClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Rollback a cluster to a previous spec revision",
+	Long:         "This command upgrades a cluster with the cluster config recorded in one of its spec revisions. The current EKS-A version is used, EKS-A and Kubernetes version downgrades are not supported.",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rco.rollbackCluster(cmd); err != nil {
+			return fmt.Errorf("failed to rollback cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rollbackCmd.AddCommand(rollbackClusterCmd)
+	rollbackClusterCmd.Flags().StringVar(&rco.clusterName, "cluster", "", "Name of the cluster")
+	rollbackClusterCmd.Flags().StringVarP(&rco.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	rollbackClusterCmd.Flags().IntVar(&rco.toRevision, "to-revision", 0, "Revision to rollback the cluster to")
+	rollbackClusterCmd.Flags().StringVar(&rco.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	rollbackClusterCmd.Flags().StringVarP(&rco.wConfig, "w-config", "w", "", "Kubeconfig file to use when rolling back a workload cluster")
+	applyTimeoutFlags(rollbackClusterCmd.Flags(), &rco.timeoutOptions)
+	applyTinkerbellHardwareFlag(rollbackClusterCmd.Flags(), &rco.hardwareCSVPath)
+
+	for _, f := range []string{"cluster", "to-revision"} {
+		if err := rollbackClusterCmd.MarkFlagRequired(f); err != nil {
+			log.Fatalf("marking %s flag as required: %s", f, err)
+		}
+	}
+}
+
+func (rco *rollbackClusterOptions) rollbackCluster(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	// The EKS-A objects live in the management cluster, which is the cluster itself for self-managed clusters.
+	client, closer, err := buildRevisionsClient(ctx, getKubeconfigPath(rco.clusterName, rco.managementKubeconfig))
+	if err != nil {
+		return err
+	}
+	revision, err := cluster.GetSpecRevision(ctx, client, rco.clusterName, rco.namespace, rco.toRevision)
+	closer()
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(rco.clusterName, fmt.Sprintf("%s-revision-%d.yaml", rco.clusterName, rco.toRevision))
+	if err := os.MkdirAll(rco.clusterName, os.ModePerm); err != nil {
+		return fmt.Errorf("creating cluster directory: %v", err)
+	}
+	if err := os.WriteFile(configFile, []byte(revision.Spec.Config), 0o644); err != nil {
+		return fmt.Errorf("writing cluster config for revision %d: %v", rco.toRevision, err)
+	}
+
+	logger.Info("Rolling back cluster", "cluster", rco.clusterName, "revision", rco.toRevision, "config", configFile)
+	rco.fileName = configFile
+
+	return rco.upgradeCluster(cmd)
+}
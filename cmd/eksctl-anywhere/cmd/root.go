@@ -11,7 +11,16 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	errorFormatFlagName = "error-format"
+	fastRetriesFlagName = "fast-retries"
+	// fastRetriesFactor is how many times shorter the waits between retries are with --fast-retries.
+	fastRetriesFactor = 10
 )
 
 var rootCmd = &cobra.Command{
@@ -19,6 +28,8 @@ var rootCmd = &cobra.Command{
 	Short:            "Amazon EKS Anywhere",
 	Long:             `Use eksctl anywhere to build your own self-managing cluster on your hardware with the best of Amazon EKS`,
 	PersistentPreRun: rootPersistentPreRun,
+	// Errors are printed by Execute, with their code and in the format from --error-format.
+	SilenceErrors: true,
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		outputFilePath := logger.GetOutputFilePath()
 		if outputFilePath == "" {
@@ -32,15 +43,34 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the log level verbosity")
+	rootCmd.PersistentFlags().String(contextFlagName, "", "Management cluster context from the CLI config file to take the default kubeconfig, provider, registry and bundles override flags from")
+	_ = rootCmd.RegisterFlagCompletionFunc(contextFlagName, completeContexts)
+	rootCmd.PersistentFlags().String(errorFormatFlagName, eksaerrors.FormatText, "Format of the error printed when the command fails (text|json)")
+	rootCmd.PersistentFlags().Bool(fastRetriesFlagName, false, "Debug mode that shortens the waits between retries. Timeouts are not changed")
+	markFlagHidden(rootCmd.PersistentFlags(), fastRetriesFlagName)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return eksaerrors.WithCode(eksaerrors.CodeInvalidInput, err)
+	})
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags for root: %v", err)
 	}
 }
 
 func rootPersistentPreRun(cmd *cobra.Command, args []string) {
+	// Completions run on every tab, they shouldn't leave log files behind.
+	if isCompletionCmd(cmd) {
+		return
+	}
 	if err := initLogger(); err != nil {
 		log.Fatal(err)
 	}
+	if err := applyManagementContext(cmd); err != nil {
+		log.Fatal(err)
+	}
+	if viper.GetBool(fastRetriesFlagName) {
+		logger.Info("Warning: fast retries enabled, the waits between retries are shortened. This is only meant for debugging")
+		retrier.UseFastRetries(fastRetriesFactor)
+	}
 }
 
 func initLogger() error {
@@ -62,7 +92,19 @@ func initLogger() error {
 }
 
 func Execute() error {
-	return rootCmd.ExecuteContext(context.Background())
+	registerCompletions(rootCmd)
+	err := rootCmd.ExecuteContext(context.Background())
+	if err != nil {
+		printFailure(err)
+	}
+	return err
+}
+
+func printFailure(err error) {
+	if fErr := eksaerrors.WriteFailure(os.Stderr, viper.GetString(errorFormatFlagName), err); fErr != nil {
+		fmt.Fprintln(os.Stderr, fErr)
+		_ = eksaerrors.WriteFailure(os.Stderr, eksaerrors.FormatText, err)
+	}
 }
 
 // RootCmd returns the eksctl-anywhere root cmd.
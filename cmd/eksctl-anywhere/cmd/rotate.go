@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate resources",
+	Long:  "Use eksctl anywhere rotate to rotate resources, such as provider credentials",
+}
+
+func init() {
+	rootCmd.AddCommand(rotateCmd)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/credentials"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type rotateCredentialsOptions struct {
+	clusterOptions
+	provider string
+}
+
+var rtco = &rotateCredentialsOptions{}
+
+var rotateCredentialsCmd = &cobra.Command{
+	Use:          "credentials",
+	Short:        "Rotate the provider credentials of a management cluster",
+	Long:         "This command validates the provider credentials set in the environment and replaces the ones used by the controllers of a management cluster and all its workload clusters. The provider controllers are restarted to pick up the new credentials and the rotation time is recorded in the status of the clusters",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rtco.rotateCredentials(cmd); err != nil {
+			return fmt.Errorf("failed to rotate credentials: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateCredentialsCmd)
+	applyClusterOptionFlags(rotateCredentialsCmd.Flags(), &rtco.clusterOptions)
+	rotateCredentialsCmd.Flags().StringVar(&rtco.provider, "provider", "", "Provider of the credentials to rotate. Only vsphere is supported")
+
+	flags.MarkRequired(rotateCredentialsCmd.Flags(), flags.ClusterConfig.Name)
+	if err := rotateCredentialsCmd.MarkFlagRequired("provider"); err != nil {
+		log.Fatalf("marking provider flag as required: %s", err)
+	}
+}
+
+func (rtco *rotateCredentialsOptions) rotateCredentials(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if rtco.provider != constants.VSphereProviderName {
+		return fmt.Errorf("credentials rotation is not supported for provider %s, supported providers: %s", rtco.provider, constants.VSphereProviderName)
+	}
+
+	if !validations.FileExists(rtco.fileName) {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", rtco.fileName))
+	}
+
+	cleanupRenderedConfig, err := rtco.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
+	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(rtco.fileName)
+	if err != nil {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
+	}
+
+	if !clusterConfig.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is not a management cluster, credentials can only be rotated in management clusters", clusterConfig.Name)
+	}
+
+	if clusterConfig.Spec.DatacenterRef.Kind != v1alpha1.VSphereDatacenterKind {
+		return fmt.Errorf("cluster %s doesn't use the %s provider", clusterConfig.Name, rtco.provider)
+	}
+
+	clusterSpec, err := newClusterSpec(rtco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(rtco.mountDirs()...).
+		WithProviderCredentials(clusterSpec.Cluster).
+		WithGovc().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, rtco.managementKubeconfig),
+	}
+
+	if err := credentials.NewRotator(deps.Govc, deps.Kubectl).Rotate(ctx, managementCluster, clusterSpec.VSphereDatacenter); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Provider credentials rotated")
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cis"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type runCISBenchmarkOptions struct {
+	clusterName     string
+	kubeConfig      string
+	output          string
+	bundlesOverride string
+}
+
+var rcbo = &runCISBenchmarkOptions{}
+
+var runCISBenchmarkCmd = &cobra.Command{
+	Use:          "cis-benchmark --cluster <cluster-name>",
+	Short:        "Run the CIS Kubernetes Benchmark checks against a cluster",
+	Long:         "This command runs the CIS Kubernetes Benchmark checks against the nodes of a cluster and reports a score. The checks are adapted to the file layout of the EKS Anywhere nodes for each provider and OS, including Bottlerocket, and run in a privileged pod in each node that is deleted once the benchmark completes",
+	SilenceUsage: true,
+	// The kubeconfig is the one of the benchmarked cluster, which is not always the management cluster.
+	Annotations: map[string]string{ignoreContextFlagsAnnotation: "kubeconfig"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCISBenchmark(cmd.Context(), rcbo)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(runCISBenchmarkCmd)
+	runCISBenchmarkCmd.Flags().StringVar(&rcbo.clusterName, "cluster", "", "Name of the cluster")
+	runCISBenchmarkCmd.Flags().StringVar(&rcbo.kubeConfig, "kubeconfig", "", "Cluster kubeconfig file. Defaults to <cluster-name>/<cluster-name>-eks-a-cluster.kubeconfig")
+	runCISBenchmarkCmd.Flags().StringVarP(&rcbo.output, "output", "o", outputText, "Output format: text|json")
+	runCISBenchmarkCmd.Flags().StringVar(&rcbo.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	if err := runCISBenchmarkCmd.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func runCISBenchmark(ctx context.Context, opts *runCISBenchmarkOptions) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format %s, must be one of: %s, %s", opts.output, outputText, outputJson)
+	}
+
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, opts.clusterName)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithFileReader().
+		WithManifestReader().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	var b *releasev1.Bundles
+	if opts.bundlesOverride != "" {
+		b, err = bundles.Read(deps.FileReader, opts.bundlesOverride)
+	} else {
+		b, err = deps.ManifestReader.ReadBundlesForVersion(version.Get().GitVersion)
+	}
+	if err != nil {
+		return err
+	}
+	if len(b.Spec.VersionsBundles) == 0 {
+		return fmt.Errorf("bundles manifest doesn't include any versions bundle")
+	}
+
+	image := b.Spec.VersionsBundles[0].Eksa.DiagnosticCollector.VersionedImage()
+	runner := cis.NewRunner(deps.Kubectl, image)
+	report, err := runner.Run(ctx, &types.Cluster{Name: opts.clusterName, KubeconfigFile: kubeConfig})
+	if err != nil {
+		return fmt.Errorf("running CIS benchmark: %v", err)
+	}
+
+	if opts.output == outputJson {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("serializing CIS benchmark report: %v", err)
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	return report.WriteText(os.Stdout)
+}
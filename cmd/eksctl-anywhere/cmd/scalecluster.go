@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/scale"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type scaleClusterOptions struct {
+	clusterName string
+	namespace   string
+	nodeGroup   string
+	count       int
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+}
+
+var scaleClusterOpts = &scaleClusterOptions{}
+
+var scaleClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Scale a worker node group of a cluster",
+	Long:         "This command changes the number of nodes of a worker node group without running a full cluster upgrade",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaleCluster(cmd.Context(), scaleClusterOpts)
+	},
+}
+
+func init() {
+	scaleCmd.AddCommand(scaleClusterCmd)
+	scaleClusterCmd.Flags().StringVar(&scaleClusterOpts.clusterName, "cluster", "", "Name of the cluster")
+	scaleClusterCmd.Flags().StringVarP(&scaleClusterOpts.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	scaleClusterCmd.Flags().StringVar(&scaleClusterOpts.nodeGroup, "node-group", "", "Name of the worker node group to scale")
+	scaleClusterCmd.Flags().IntVar(&scaleClusterOpts.count, "count", 0, "Number of nodes for the worker node group")
+	scaleClusterCmd.Flags().StringVar(&scaleClusterOpts.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	for _, flag := range []string{"cluster", "node-group", "count"} {
+		if err := scaleClusterCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("marking %s flag as required: %s", flag, err)
+		}
+	}
+}
+
+func scaleCluster(ctx context.Context, opts *scaleClusterOptions) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().WithUnAuthKubeClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	client := kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeConfig)
+	return scale.NodeGroup(ctx, client, opts.clusterName, opts.namespace, opts.nodeGroup, opts.count)
+}
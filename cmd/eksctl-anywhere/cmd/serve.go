@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/apiserver"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type serveOptions struct {
+	address   string
+	tokenFile string
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+}
+
+var so = &serveOptions{}
+
+var serveCmd = &cobra.Command{
+	Use:          "serve",
+	Short:        "Serve the cluster lifecycle API of a management cluster",
+	Long:         "This command runs a local REST API server to create, upgrade and delete the workload clusters of a management cluster. Create, upgrade and delete run asynchronously and return an operation to poll until it completes. The server generates a token on startup and writes it to --token-file, clients must send it in an Authorization: Bearer header.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serve(cmd.Context(), so)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&so.address, "address", apiserver.DefaultAddress, "Address to listen on")
+	serveCmd.Flags().StringVar(&so.tokenFile, "token-file", "eks-a-api-token", "File to write the token of the API clients to")
+	serveCmd.Flags().StringVar(&so.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+}
+
+func serve(ctx context.Context, opts *serveOptions) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	managementCluster, err := cluster.LoadManagement(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().WithClusterApplier().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	token, err := apiserver.NewToken()
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(opts.tokenFile, []byte(token), 0o600); err != nil {
+		return fmt.Errorf("writing API token: %v", err)
+	}
+	defer os.Remove(opts.tokenFile)
+	logger.Info("API token written", "file", opts.tokenFile)
+
+	server := apiserver.New(
+		logger.Get(),
+		kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeConfig),
+		deps.ClusterApplier,
+		*managementCluster,
+		opts.address,
+		token,
+	)
+
+	return server.Serve(ctx)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start resources",
+	Long:  "Use eksctl anywhere start to power on the machines of a stopped resource",
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+}
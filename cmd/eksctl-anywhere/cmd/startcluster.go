@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var startClusterOpts = &clusterHibernationOptions{}
+
+var startClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Power on the machines of a stopped workload cluster",
+	Long:         "This command powers on all the machines of a workload cluster stopped with eksctl anywhere stop cluster, makes the worker nodes schedulable again and resumes the cluster reconciliation, unless it was already paused before the cluster was stopped.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return hibernateCluster(cmd.Context(), startClusterOpts, false)
+	},
+}
+
+func init() {
+	startCmd.AddCommand(startClusterCmd)
+	applyClusterHibernationFlags(startClusterCmd, startClusterOpts)
+}
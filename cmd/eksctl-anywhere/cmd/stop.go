@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop resources",
+	Long:  "Use eksctl anywhere stop to power off the machines of a resource",
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+}
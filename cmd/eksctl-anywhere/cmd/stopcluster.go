@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/hibernation"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+type clusterHibernationOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is the kubeconfig of the management cluster.
+	kubeConfig string
+	// workloadKubeConfig is the kubeconfig of the cluster being stopped or started.
+	workloadKubeConfig string
+}
+
+var stopClusterOpts = &clusterHibernationOptions{}
+
+var stopClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Power off the machines of a workload cluster",
+	Long:         "This command pauses the reconciliation of a workload cluster, drains its worker nodes and powers off all its machines so they don't use capacity until started again with eksctl anywhere start cluster. Only vSphere clusters are supported.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return hibernateCluster(cmd.Context(), stopClusterOpts, true)
+	},
+}
+
+func init() {
+	stopCmd.AddCommand(stopClusterCmd)
+	applyClusterHibernationFlags(stopClusterCmd, stopClusterOpts)
+}
+
+func applyClusterHibernationFlags(cmd *cobra.Command, opts *clusterHibernationOptions) {
+	cmd.Flags().StringVar(&opts.clusterName, "cluster", "", "Name of the workload cluster")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", constants.DefaultNamespace, "Namespace of the cluster object in the management cluster")
+	cmd.Flags().StringVar(&opts.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	cmd.Flags().StringVar(&opts.workloadKubeConfig, "workload-kubeconfig", "", "Workload cluster kubeconfig file, defaults to <cluster>/<cluster>-eks-a-cluster.kubeconfig")
+	if err := cmd.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("marking cluster flag as required: %s", err)
+	}
+}
+
+func hibernateCluster(ctx context.Context, opts *clusterHibernationOptions, stop bool) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	workloadKubeConfig := opts.workloadKubeConfig
+	if workloadKubeConfig == "" {
+		workloadKubeConfig = kubeconfig.FromClusterName(opts.clusterName)
+	}
+	if err := kubeconfig.ValidateFilename(workloadKubeConfig); err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig, workloadKubeConfig).
+		WithUnAuthKubeClient().
+		WithGovc().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	client := kubernetes.NewKubeconfigClient(deps.UnAuthKubeClient, kubeConfig)
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, opts.clusterName, opts.namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", opts.clusterName, err)
+	}
+
+	if cluster.Spec.DatacenterRef.Kind != v1alpha1.VSphereDatacenterKind {
+		return fmt.Errorf("stopping and starting clusters is not supported for %s", cluster.Spec.DatacenterRef.Kind)
+	}
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	if err := client.Get(ctx, cluster.Spec.DatacenterRef.Name, cluster.Namespace, datacenterConfig); err != nil {
+		return fmt.Errorf("getting vsphere datacenter config %s: %v", cluster.Spec.DatacenterRef.Name, err)
+	}
+
+	if err := vsphere.SetupEnvVars(datacenterConfig); err != nil {
+		return err
+	}
+
+	hibernator := hibernation.NewHibernator(
+		client,
+		&vSpherePowerManager{govc: deps.Govc, datacenter: datacenterConfig.Spec.Datacenter},
+		&kubectlNodeDrainer{kubectl: deps.Kubectl, kubeconfig: workloadKubeConfig},
+	)
+
+	if stop {
+		if err := hibernator.Stop(ctx, cluster); err != nil {
+			return err
+		}
+		logger.Info("Cluster stopped", "cluster", cluster.Name)
+		return nil
+	}
+
+	if err := hibernator.Start(ctx, cluster); err != nil {
+		return err
+	}
+	logger.Info("Cluster started", "cluster", cluster.Name)
+	return nil
+}
+
+type vSpherePowerManager struct {
+	govc       *executables.Govc
+	datacenter string
+}
+
+func (v *vSpherePowerManager) PowerOffVM(ctx context.Context, name string) error {
+	return v.govc.PowerOffVM(ctx, v.datacenter, name)
+}
+
+func (v *vSpherePowerManager) PowerOnVM(ctx context.Context, name string) error {
+	return v.govc.PowerOnVM(ctx, v.datacenter, name)
+}
+
+type kubectlNodeDrainer struct {
+	kubectl    *executables.Kubectl
+	kubeconfig string
+}
+
+func (k *kubectlNodeDrainer) DrainNode(ctx context.Context, name string) error {
+	return k.kubectl.DrainNode(ctx, name, k.kubeconfig)
+}
+
+func (k *kubectlNodeDrainer) UncordonNode(ctx context.Context, name string) error {
+	return k.kubectl.UncordonNode(ctx, name, k.kubeconfig)
+}
@@ -4,18 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
+// presignedURLUploadTimeout bounds the upload of a support bundle archive to a pre-signed url,
+// including reading the response, so a stalled connection doesn't hang the command.
+const presignedURLUploadTimeout = 30 * time.Minute
+
 type createSupportBundleOptions struct {
 	fileName              string
 	wConfig               string
@@ -24,6 +33,12 @@ type createSupportBundleOptions struct {
 	bundleConfig          string
 	hardwareFileName      string
 	tinkerbellBootstrapIP string
+	uploadS3URI           string
+	uploadURL             string
+	profile               string
+	maxLogLines           int64
+	maxLogBytes           int64
+	collectorTimeout      time.Duration
 }
 
 var csbo = &createSupportBundleOptions{}
@@ -52,6 +67,13 @@ func init() {
 	supportbundleCmd.Flags().StringVarP(&csbo.bundleConfig, "bundle-config", "", "", "Bundle Config file to use when generating support bundle")
 	supportbundleCmd.Flags().StringVarP(&csbo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	supportbundleCmd.Flags().StringVarP(&csbo.wConfig, "w-config", "w", "", "Kubeconfig file to use when creating support bundle for a workload cluster")
+	supportbundleCmd.Flags().StringVar(&csbo.uploadS3URI, "upload-s3-uri", "", "S3 URI like s3://bucket/prefix to upload the support bundle archive to, using the default AWS credentials chain")
+	supportbundleCmd.Flags().StringVar(&csbo.uploadURL, "upload-url", "", "Pre-signed URL to upload the support bundle archive to, like the ones provided by AWS Support")
+	supportbundleCmd.MarkFlagsMutuallyExclusive("upload-s3-uri", "upload-url")
+	supportbundleCmd.Flags().StringVar(&csbo.profile, "profile", string(diagnostics.StandardProfile), fmt.Sprintf("Collection profile, one of %v. minimal skips the node host collectors, full adds the logs of all the pods in the cluster", diagnostics.CollectionProfiles))
+	supportbundleCmd.Flags().Int64Var(&csbo.maxLogLines, "max-log-lines", 0, "Maximum number of lines collected per container log, no limit if 0")
+	supportbundleCmd.Flags().Int64Var(&csbo.maxLogBytes, "max-log-bytes", 0, "Maximum size in bytes collected per container log, no limit if 0")
+	supportbundleCmd.Flags().DurationVar(&csbo.collectorTimeout, "collector-timeout", 0, "Maximum duration of each collector that runs commands or pods in the cluster, like 30s or 2m")
 	err := supportbundleCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -83,13 +105,30 @@ func preRunSupportBundle(cmd *cobra.Command, args []string) error {
 }
 
 func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since, sinceTime, bundleConfig string) error {
-	clusterSpec, err := readAndValidateClusterSpec(csbo.fileName, version.Get())
+	clusterSpec, err := client.ReadAndValidateClusterSpec(csbo.fileName, version.Get())
+	if err != nil {
+		return fmt.Errorf("unable to get cluster config from file: %w", err)
+	}
+
+	// Build the uploader before collecting so an invalid upload target fails fast.
+	uploader, err := csbo.archiveUploader()
 	if err != nil {
-		return fmt.Errorf("unable to get cluster config from file: %v", err)
+		return err
+	}
+
+	profile, err := diagnostics.ParseCollectionProfile(csbo.profile)
+	if err != nil {
+		return err
 	}
 
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).
 		WithProvider(csbo.fileName, clusterSpec.Cluster, cc.skipIpCheck, csbo.hardwareFileName, false, csbo.tinkerbellBootstrapIP, map[string]bool{}).
+		WithDiagnosticCollectionOptions(diagnostics.CollectionOptions{
+			Profile:          profile,
+			MaxLogLines:      csbo.maxLogLines,
+			MaxLogBytes:      csbo.maxLogBytes,
+			CollectorTimeout: csbo.collectorTimeout,
+		}).
 		WithDiagnosticBundleFactory().
 		Build(ctx)
 	if err != nil {
@@ -118,5 +157,33 @@ func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since,
 		return fmt.Errorf("printing analysis")
 	}
 
+	if uploader == nil {
+		return nil
+	}
+
+	metadata := map[string]string{
+		"cluster":      clusterSpec.Cluster.Name,
+		"eksa-version": version.Get().GitVersion,
+	}
+	if err := uploader.Upload(ctx, supportBundle.ArchivePath(), metadata); err != nil {
+		return err
+	}
+	logger.Info("Support bundle archive uploaded")
+
 	return nil
 }
+
+func (csbo *createSupportBundleOptions) archiveUploader() (diagnostics.ArchiveUploader, error) {
+	switch {
+	case csbo.uploadS3URI != "":
+		sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+		if err != nil {
+			return nil, fmt.Errorf("creating aws session: %v", err)
+		}
+		return diagnostics.NewS3Uploader(s3manager.NewUploader(sess), csbo.uploadS3URI)
+	case csbo.uploadURL != "":
+		return diagnostics.NewPresignedURLUploader(&http.Client{Timeout: presignedURLUploadTimeout}, csbo.uploadURL), nil
+	default:
+		return nil, nil
+	}
+}
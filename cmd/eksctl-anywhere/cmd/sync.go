@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync resources",
+	Long:  "Use eksctl anywhere sync to keep resources in sync with an external source",
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const (
+	netboxHardwareSource = "netbox"
+	// netboxWebhookSecretEnv is the environment variable holding the secret of the NetBox webhook.
+	netboxWebhookSecretEnv = "NETBOX_WEBHOOK_SECRET"
+	netboxWebhookPath      = "/netbox"
+)
+
+type syncHardwareOptions struct {
+	netboxConfigPath string
+	kubeConfig       string
+	watch            bool
+	listenAddress    string
+	resyncInterval   time.Duration
+}
+
+var sho = &syncHardwareOptions{}
+
+var syncHardwareCmd = &cobra.Command{
+	Use:          "hardware --netbox-config <netbox-config-file>",
+	Short:        "Sync the Tinkerbell hardware of a management cluster with NetBox",
+	Long:         "This command imports the devices of a NetBox DCIM as Tinkerbell hardware in a management cluster, updates the hardware that changed in NetBox and deletes the hardware that was removed from it, unless it's in use by a cluster. With --watch, it keeps running and syncs the hardware when NetBox notifies a change through a webhook and every resync interval",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syncHardware(cmd.Context(), sho)
+	},
+}
+
+func init() {
+	syncCmd.AddCommand(syncHardwareCmd)
+	syncHardwareCmd.Flags().StringVar(&sho.netboxConfigPath, netboxConfigFlagName, "", "Path to a NetBox config file selecting the devices to sync and the field mapping rules. The NetBox API token is read from the NETBOX_TOKEN environment variable")
+	syncHardwareCmd.Flags().StringVar(&sho.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	syncHardwareCmd.Flags().BoolVar(&sho.watch, "watch", false, "Keep running and sync the hardware on NetBox webhook events and every resync interval")
+	syncHardwareCmd.Flags().StringVar(&sho.listenAddress, "listen-address", ":8080", "Address of the NetBox webhook endpoint in watch mode. The webhook is served in the /netbox path and its secret is read from the NETBOX_WEBHOOK_SECRET environment variable")
+	syncHardwareCmd.Flags().DurationVar(&sho.resyncInterval, "resync-interval", 10*time.Minute, "Interval between syncs in watch mode when NetBox doesn't notify changes")
+	if err := syncHardwareCmd.MarkFlagRequired(netboxConfigFlagName); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func syncHardware(ctx context.Context, opts *syncHardwareOptions) error {
+	config, err := hardware.ParseNetboxConfigFile(opts.netboxConfigPath)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewRuntimeClientFromFileName(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	syncer := hardware.NewSyncer(client, netboxHardwareSource)
+	sync := func() error {
+		result, err := syncer.Sync(ctx, hardware.NewNormalizedNetboxReader(ctx, http.DefaultClient, config))
+		if err != nil {
+			return fmt.Errorf("syncing hardware from netbox: %v", err)
+		}
+		logSyncResult(result)
+		return nil
+	}
+
+	if err := sync(); err != nil {
+		return err
+	}
+	if !opts.watch {
+		return nil
+	}
+
+	webhook := hardware.NewNetboxWebhook(os.Getenv(netboxWebhookSecretEnv))
+	mux := http.NewServeMux()
+	mux.Handle(netboxWebhookPath, webhook)
+	server := &http.Server{Addr: opts.listenAddress, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+	defer server.Close()
+	logger.Info("Watching NetBox for hardware changes", "webhook", opts.listenAddress+netboxWebhookPath, "resyncInterval", opts.resyncInterval)
+
+	ticker := time.NewTicker(opts.resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serverErr:
+			return fmt.Errorf("serving netbox webhook: %v", err)
+		case <-webhook.Events():
+			logger.V(4).Info("NetBox notified an inventory change")
+		case <-ticker.C:
+		}
+
+		// Errors are transient in watch mode, the next event or resync retries.
+		if err := sync(); err != nil {
+			logger.Info("Warning: hardware sync failed", "error", err)
+		}
+	}
+}
+
+func logSyncResult(result *hardware.SyncResult) {
+	logger.Info("Synced hardware from NetBox", "created", len(result.Created), "updated", len(result.Updated),
+		"deleted", len(result.Deleted), "unchanged", len(result.Unchanged), "skipped", len(result.Skipped))
+	for action, names := range map[string][]string{"Created": result.Created, "Updated": result.Updated, "Deleted": result.Deleted} {
+		if len(names) > 0 {
+			logger.V(2).Info(action+" hardware", "names", strings.Join(names, ", "))
+		}
+	}
+
+	skipped := make([]string, 0, len(result.Skipped))
+	for name := range result.Skipped {
+		skipped = append(skipped, name)
+	}
+	sort.Strings(skipped)
+	for _, name := range skipped {
+		logger.Info("Warning: hardware skipped", "name", name, "reason", result.Skipped[name])
+	}
+}
@@ -4,21 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/dependencies"
-	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/client"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
-	"github.com/aws/eks-anywhere/pkg/workflows"
-	"github.com/aws/eks-anywhere/pkg/workflows/management"
+	"github.com/aws/eks-anywhere/pkg/velero"
 )
 
 type upgradeClusterOptions struct {
@@ -29,6 +28,12 @@ type upgradeClusterOptions struct {
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	skipValidations       []string
+	backupWorkloads       bool
+	veleroNamespace       string
+	etcdBenchmarkImage    string
+	mtuProbeImage         string
+	clockSkewImage        string
+	showTemplateDiff      bool
 }
 
 var uc = &upgradeClusterOptions{}
@@ -60,6 +65,12 @@ func init() {
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(upgradeClusterCmd.Flags())
+	upgradeClusterCmd.Flags().BoolVar(&uc.backupWorkloads, "backup-workloads", false, "Create a Velero backup of the cluster workloads and wait for it to complete before upgrading")
+	upgradeClusterCmd.Flags().StringVar(&uc.veleroNamespace, "velero-namespace", velero.DefaultNamespace, "Namespace Velero is installed in, used with --backup-workloads")
+	upgradeClusterCmd.Flags().StringVar(&uc.etcdBenchmarkImage, "etcd-disk-benchmark-image", "", "Image with fio used to validate the etcd disk latency of the control plane nodes before upgrading. The validation only runs when it's set")
+	upgradeClusterCmd.Flags().StringVar(&uc.mtuProbeImage, "mtu-probe-image", "", "Image with ping used to validate the network MTU between the cluster nodes before upgrading. The validation only runs when it's set")
+	upgradeClusterCmd.Flags().StringVar(&uc.clockSkewImage, "clock-skew-image", "", "Image with curl used to validate the clocks of the cluster nodes are in sync before upgrading. The validation only runs when it's set")
+	upgradeClusterCmd.Flags().BoolVar(&uc.showTemplateDiff, "show-template-diff", false, "Print the changes the cluster templateOverrides make to the generated CAPI templates")
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(upgradevalidations.SkippableValidations[:], ",")))
 
 	flags.MarkRequired(createClusterCmd.Flags(), flags.ClusterConfig.Name)
@@ -70,12 +81,18 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 
 	clusterConfigFileExist := validations.FileExists(uc.fileName)
 	if !clusterConfigFileExist {
-		return fmt.Errorf("the cluster config file %s does not exist", uc.fileName)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", uc.fileName))
 	}
 
+	cleanupRenderedConfig, err := uc.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
 	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(uc.fileName)
 	if err != nil {
-		return fmt.Errorf("the cluster config file provided is invalid: %v", err)
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
 	}
 
 	if clusterConfig.Spec.DatacenterRef.Kind == v1alpha1.TinkerbellDatacenterKind {
@@ -84,132 +101,33 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		}
 	}
 
-	if err := v1alpha1.ValidateEtcdEncryptionConfig(clusterConfig.Spec.EtcdEncryption); err != nil {
-		return err
-	}
-
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
-	clusterSpec, err := newClusterSpec(uc.clusterOptions)
-	if err != nil {
-		return err
-	}
-
-	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
-		return err
-	}
 
-	cliConfig := buildCliConfig(clusterSpec)
-	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
-	if err != nil {
-		return err
-	}
-
-	upgradeCLIConfig, err := buildUpgradeCliConfig(uc)
-	if err != nil {
-		return err
-	}
-
-	clusterManagerTimeoutOpts, err := buildClusterManagerOpts(uc.timeoutOptions, clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	timeouts, err := buildTimeouts(uc.timeoutOptions)
 	if err != nil {
 		return fmt.Errorf("failed to build cluster manager opts: %v", err)
 	}
 
-	var skippedValidations map[string]bool
-	if len(uc.skipValidations) != 0 {
-		skippedValidations, err = validations.ValidateSkippableValidation(uc.skipValidations, upgradevalidations.SkippableValidations)
-		if err != nil {
-			return err
-		}
-	}
-
-	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
-		WithBootstrapper().
-		WithCliConfig(cliConfig).
-		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
-		WithClusterApplier().
-		WithProvider(uc.fileName, clusterSpec.Cluster, cc.skipIpCheck, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP, skippedValidations).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
-		WithWriter().
-		WithCAPIManager().
-		WithEksdUpgrader().
-		WithEksdInstaller().
-		WithKubectl().
-		WithValidatorClients().
-		WithUpgradeClusterDefaulter(upgradeCLIConfig)
-
-	if uc.timeoutOptions.noTimeouts {
-		factory.WithNoTimeouts()
-	}
-
-	deps, err := factory.Build(ctx)
-	if err != nil {
-		return err
-	}
-	defer close(ctx, deps)
-
-	clusterSpec, err = deps.UpgradeClusterDefaulter.Run(ctx, clusterSpec)
-	if err != nil {
-		return err
-	}
-
-	workloadCluster := &types.Cluster{
-		Name:           clusterSpec.Cluster.Name,
-		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, uc.wConfig),
-	}
-
-	var managementCluster *types.Cluster
-	if clusterSpec.ManagementCluster == nil {
-		managementCluster = workloadCluster
-	} else {
-		managementCluster = clusterSpec.ManagementCluster
-	}
-
-	validationOpts := &validations.Opts{
-		Kubectl:            deps.UnAuthKubectlClient,
-		Spec:               clusterSpec,
-		WorkloadCluster:    workloadCluster,
-		ManagementCluster:  managementCluster,
-		Provider:           deps.Provider,
-		CliConfig:          cliConfig,
-		SkippedValidations: skippedValidations,
-	}
-
-	upgradeValidations := upgradevalidations.New(validationOpts)
-
-	if features.ExperimentalSelfManagedClusterUpgrade().IsActive() && clusterConfig.IsSelfManaged() {
-		logger.Info("Management kindless upgrade")
-		upgrade := management.NewUpgrade(
-			deps.Provider,
-			deps.CAPIManager,
-			deps.ClusterManager,
-			deps.GitOpsFlux,
-			deps.Writer,
-			deps.EksdUpgrader,
-			deps.EksdInstaller,
-			deps.ClusterApplier,
-		)
-
-		err = upgrade.Run(ctx, clusterSpec, managementCluster, upgradeValidations)
-
-	} else {
-		upgrade := workflows.NewUpgrade(
-			deps.Bootstrapper,
-			deps.Provider,
-			deps.CAPIManager,
-			deps.ClusterManager,
-			deps.GitOpsFlux,
-			deps.Writer,
-			deps.EksdUpgrader,
-			deps.EksdInstaller,
-		)
-
-		err = upgrade.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, uc.forceClean)
-	}
-
-	cleanup(deps, &err)
-	return err
+	var clientOpts []client.Opt
+	if uc.showTemplateDiff {
+		clientOpts = append(clientOpts, client.WithTemplateDiff(os.Stdout))
+	}
+
+	return uc.newClient(clientOpts...).UpgradeCluster(ctx, client.UpgradeClusterOptions{
+		ClusterConfigFile:      uc.fileName,
+		WorkloadKubeconfig:     uc.wConfig,
+		HardwareCSVPath:        uc.hardwareCSVPath,
+		TinkerbellBootstrapIP:  uc.tinkerbellBootstrapIP,
+		SkipValidations:        uc.skipValidations,
+		BackupWorkloads:        uc.backupWorkloads,
+		VeleroNamespace:        uc.veleroNamespace,
+		EtcdDiskBenchmarkImage: uc.etcdBenchmarkImage,
+		MTUProbeImage:          uc.mtuProbeImage,
+		ClockSkewImage:         uc.clockSkewImage,
+		Timeouts:               timeouts,
+	})
 }
 
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
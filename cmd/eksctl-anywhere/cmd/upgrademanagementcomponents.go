@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/flags"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/crdmigration"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	"github.com/aws/eks-anywhere/pkg/workflows/management"
+)
+
+type upgradeManagementComponentsOptions struct {
+	clusterOptions
+	timeoutOptions
+}
+
+var umco = &upgradeManagementComponentsOptions{}
+
+var upgradeManagementComponentsCmd = &cobra.Command{
+	Use:          "management-components",
+	Short:        "Upgrade management components in a management cluster",
+	Long:         "This command is used to upgrade the management components (CAPI, providers, GitOps and EKS-A controllers) of a management cluster without changing the Kubernetes version of any cluster. Once the components are upgraded, the objects of the CRDs in the cluster are migrated to their storage versions and a report with the migrated CRDs is written to crd-storage-migration-report.yaml in the cluster folder",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := umco.upgradeManagementComponents(cmd); err != nil {
+			return fmt.Errorf("failed to upgrade management components: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	upgradeCmd.AddCommand(upgradeManagementComponentsCmd)
+	applyClusterOptionFlags(upgradeManagementComponentsCmd.Flags(), &umco.clusterOptions)
+	applyTimeoutFlags(upgradeManagementComponentsCmd.Flags(), &umco.timeoutOptions)
+
+	flags.MarkRequired(upgradeManagementComponentsCmd.Flags(), flags.ClusterConfig.Name)
+}
+
+func (umco *upgradeManagementComponentsOptions) upgradeManagementComponents(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if !validations.FileExists(umco.fileName) {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", umco.fileName))
+	}
+
+	cleanupRenderedConfig, err := umco.renderClusterConfig()
+	if err != nil {
+		return fmt.Errorf("rendering the cluster config file: %v", err)
+	}
+	defer cleanupRenderedConfig()
+
+	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(umco.fileName)
+	if err != nil {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
+	}
+
+	if !clusterConfig.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is not a management cluster, management components can only be upgraded in management clusters", clusterConfig.Name)
+	}
+
+	if _, err := commonValidation(ctx, umco.fileName); err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	clusterSpec, err := newClusterSpec(umco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
+		return err
+	}
+
+	cliConfig := client.BuildCliConfig(clusterSpec)
+	dirs := umco.directoriesToMount(clusterSpec, cliConfig)
+
+	timeouts, err := buildTimeouts(umco.timeoutOptions)
+	if err != nil {
+		return fmt.Errorf("failed to build cluster manager opts: %v", err)
+	}
+
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster, timeouts.ClusterManagerTimeouts(clusterSpec.Cluster.Spec.DatacenterRef.Kind)).
+		WithProvider(umco.fileName, clusterSpec.Cluster, false, "", false, "", nil).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		WithCAPIManager().
+		WithKubectl().
+		WithValidatorClients()
+
+	if umco.timeoutOptions.noTimeouts {
+		factory.WithNoTimeouts()
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, umco.managementKubeconfig),
+	}
+
+	validationOpts := &validations.Opts{
+		Kubectl:           deps.UnAuthKubectlClient,
+		Spec:              clusterSpec,
+		WorkloadCluster:   managementCluster,
+		ManagementCluster: managementCluster,
+		Provider:          deps.Provider,
+		CliConfig:         cliConfig,
+	}
+
+	upgrade := management.NewUpgradeManagementComponents(
+		deps.Provider,
+		deps.CAPIManager,
+		deps.ClusterManager,
+		deps.GitOpsFlux,
+		deps.Writer,
+		management.WithCRDStorageMigrator(crdmigration.NewMigrator(deps.Kubectl)),
+	)
+
+	err = upgrade.Run(ctx, clusterSpec, managementCluster, upgradevalidations.New(validationOpts))
+
+	cleanup(deps, &err)
+	return err
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	capiupgrader "github.com/aws/eks-anywhere/pkg/clusterapi"
 	eksaupgrader "github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
 	fluxupgrader "github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
@@ -81,6 +83,7 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		WithProvider(uc.fileName, newClusterSpec.Cluster, false, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP, map[string]bool{}).
 		WithGitOpsFlux(newClusterSpec.Cluster, newClusterSpec.FluxConfig, nil).
 		WithCAPIManager().
+		WithKubectl().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -116,6 +119,27 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 
 	fmt.Print(serializedDiff)
 
+	if output != outputText {
+		return nil
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           newClusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(newClusterSpec.Cluster.Name, uc.wConfig),
+	}
+	scanner := deprecatedapis.NewScanner(deps.Kubectl)
+	findings, err := scanner.Scan(ctx, workloadCluster, currentSpec.Cluster.Spec.KubernetesVersion, newClusterSpec.Cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("scanning cluster for removed APIs: %v", err)
+	}
+
+	serializedFindings, err := serializeRemovedAPIsToText(findings, newClusterSpec.Cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serializedFindings)
+
 	return nil
 }
 
@@ -148,6 +172,33 @@ func serializeToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
 	return buffer.String(), nil
 }
 
+func serializeRemovedAPIsToText(findings []deprecatedapis.Finding, kubeVersion v1alpha1.KubernetesVersion) (string, error) {
+	if len(findings) == 0 {
+		return "", nil
+	}
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "\nThe following objects use APIs removed in Kubernetes %s and must be migrated before upgrading\n", kubeVersion)
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tKIND\tAPI VERSION\tREPLACEMENT\tSOURCE")
+	for _, f := range findings {
+		name := f.Name
+		if f.Namespace != "" {
+			name = f.Namespace + "/" + f.Name
+		}
+		replacement := f.Replacement
+		if replacement == "" {
+			replacement = "none"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, f.Kind, f.APIVersion, replacement, f.Source)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
 func serializeToJson(componentChangeDiffs *types.ChangeDiff) (string, error) {
 	if componentChangeDiffs == nil {
 		componentChangeDiffs = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
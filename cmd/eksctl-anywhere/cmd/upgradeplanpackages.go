@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type upgradePlanPackagesOptions struct {
+	kubeVersion string
+	clusterName string
+	registry    string
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig      string
+	bundlesOverride string
+}
+
+var uppo = &upgradePlanPackagesOptions{}
+
+var upgradePlanPackagesCmd = &cobra.Command{
+	Use:          "packages",
+	Short:        "Provides new package versions for the next curated packages upgrade",
+	Long:         "Compares the package versions in the active package bundle of the cluster with the latest package bundle available",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := upgradePlanPackages(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to display packages upgrade plan: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	upgradePlanCmd.AddCommand(upgradePlanPackagesCmd)
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.clusterName, "cluster", "", "Cluster to plan the packages upgrade for")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.kubeVersion, "kube-version", "", "Kubernetes Version of the cluster. Format <major>.<minor>")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.registry, "registry", "", "Used to specify an alternative registry for the package bundles")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.kubeConfig, "kubeconfig", "", "Path to an optional kubeconfig file to use.")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	upgradePlanPackagesCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	for _, flag := range []string{"cluster", "kube-version"} {
+		if err := upgradePlanPackagesCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func upgradePlanPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(uppo.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithRegistryName(uppo.registry), WithKubeVersion(uppo.kubeVersion), WithMountPaths(kubeConfig), WithBundlesOverride(uppo.bundlesOverride))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+	bm := curatedpackages.CreateBundleManager(deps.Logger)
+
+	b := curatedpackages.NewBundleReader(kubeConfig, uppo.clusterName, deps.Kubectl, bm, deps.BundleRegistry)
+
+	current, err := b.GetLatestBundle(ctx, "")
+	if err != nil {
+		return fmt.Errorf("getting active package bundle: %v", err)
+	}
+	latest, err := b.GetLatestBundle(ctx, uppo.kubeVersion)
+	if err != nil {
+		return fmt.Errorf("getting latest package bundle: %v", err)
+	}
+
+	serializedPlan, err := serializePackagesUpgradePlan(curatedpackages.PlanPackagesUpgrade(current, latest), output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(serializedPlan)
+	return nil
+}
+
+func serializePackagesUpgradePlan(plan *curatedpackages.PackagesUpgradePlan, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializePackagesUpgradePlanToText(plan)
+	case outputJson:
+		jsonPlan, err := json.Marshal(plan)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the packages upgrade plan to json: %v", err)
+		}
+		return string(jsonPlan), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializePackagesUpgradePlanToText(plan *curatedpackages.PackagesUpgradePlan) (string, error) {
+	if len(plan.Packages) == 0 {
+		return fmt.Sprintf("All the packages are up to date with the latest package bundle %s", plan.LatestBundle), nil
+	}
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "Package bundle %s -> %s\n\n", plan.CurrentBundle, plan.LatestBundle)
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCURRENT VERSION\tNEXT VERSION\tCHANGELOG")
+	for _, p := range plan.Packages {
+		currentVersion := p.CurrentVersion
+		if currentVersion == "" {
+			currentVersion = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, currentVersion, p.LatestVersion, p.Changelog)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -47,7 +48,7 @@ func init() {
 func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
-	clusterSpec, err := readClusterSpec(valOpt.fileName, version.Get())
+	clusterSpec, err := client.ReadClusterSpec(valOpt.fileName, version.Get())
 	if err != nil {
 		return err
 	}
@@ -58,11 +59,8 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		}
 	}
 
-	cliConfig := buildCliConfig(clusterSpec)
-	dirs, err := valOpt.directoriesToMount(clusterSpec, cliConfig)
-	if err != nil {
-		return err
-	}
+	cliConfig := client.BuildCliConfig(clusterSpec)
+	dirs := valOpt.directoriesToMount(clusterSpec, cliConfig)
 
 	tmpPath, err := os.MkdirTemp("./", "tmpValidate")
 	if err != nil {
@@ -91,7 +89,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 			Name:           clusterSpec.Cluster.Name,
 			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
 		},
-		ManagementCluster: getManagementCluster(clusterSpec),
+		ManagementCluster: client.ManagementCluster(clusterSpec),
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -18,11 +19,11 @@ func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.
 	validations.CheckDockerAllocatedMemory(ctx, docker)
 	clusterConfigFileExist := validations.FileExists(clusterConfigFile)
 	if !clusterConfigFileExist {
-		return nil, fmt.Errorf("the cluster config file %s does not exist", clusterConfigFile)
+		return nil, eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("the cluster config file %s does not exist", clusterConfigFile))
 	}
 	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(clusterConfigFile)
 	if err != nil {
-		return nil, fmt.Errorf("the cluster config file provided is invalid: %v", err)
+		return nil, eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("the cluster config file provided is invalid: %w", err))
 	}
 	return clusterConfig, nil
 }
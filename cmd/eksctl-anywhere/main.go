@@ -6,6 +6,9 @@ import (
 	"os/signal"
 	"syscall"
 
+	// Registers the oidc auth provider used by the kubeconfig written by login cluster.
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd"
 	"github.com/aws/eks-anywhere/pkg/eksctl"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -0,0 +1,10 @@
+// Package crd embeds the CRD manifests generated by controller-gen so they can be consumed
+// from Go code.
+package crd
+
+import "embed"
+
+// Bases contains the CRD manifests in the bases folder.
+//
+//go:embed bases/*.yaml
+var Bases embed.FS
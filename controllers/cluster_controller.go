@@ -38,6 +38,9 @@ import (
 
 const (
 	defaultRequeueTime = time.Minute
+	// shutdownPatchTimeout is how long the controller waits to persist the cluster status
+	// of a reconciliation interrupted by the manager shutdown.
+	shutdownPatchTimeout = 10 * time.Second
 	// ClusterFinalizerName is the finalizer added to clusters to handle deletion.
 	ClusterFinalizerName = "clusters.anywhere.eks.amazonaws.com/finalizer"
 )
@@ -50,6 +53,9 @@ type ClusterReconciler struct {
 	clusterValidator           ClusterValidator
 	packagesClient             PackagesClient
 	machineHealthCheck         MachineHealthCheckReconciler
+	selfUpgrade                SelfUpgradeReconciler
+	dns                        DNSReconciler
+	clusterResourceSets        ClusterResourceSetReconciler
 
 	// experimentalSelfManagedUpgrade enables management cluster full upgrades.
 	// The default behavior for management cluster only reconciles the worker nodes.
@@ -83,9 +89,27 @@ type MachineHealthCheckReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// SelfUpgradeReconciler upgrades the eks-a components of a self-managed cluster, including the
+// controller running the reconciliation, when the cluster bundle changes.
+type SelfUpgradeReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// DNSReconciler keeps the DNS record of the control plane endpoint of an eks-a cluster up to date.
+type DNSReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+	ReconcileDelete(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+}
+
+// ClusterResourceSetReconciler manages the ClusterResourceSets a management cluster applies to its workload clusters.
+type ClusterResourceSetReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+}
+
 // ClusterValidator runs cluster level preflight validations before it goes to provider reconciler.
 type ClusterValidator interface {
 	ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
+	ValidateClusterNameUnique(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
 }
 
 // ClusterReconcilerOption allows to configure the ClusterReconciler.
@@ -117,6 +141,30 @@ func WithExperimentalSelfManagedClusterUpgrades(exp bool) ClusterReconcilerOptio
 	}
 }
 
+// WithSelfUpgradeReconciler allows self-managed clusters to upgrade the eks-a components from
+// within the cluster, before they are reconciled with a new bundle.
+func WithSelfUpgradeReconciler(r SelfUpgradeReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.selfUpgrade = r
+	}
+}
+
+// WithDNSReconciler allows to register the DNS record of the control plane endpoint of the clusters
+// that configure one.
+func WithDNSReconciler(r DNSReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.dns = r
+	}
+}
+
+// WithClusterResourceSetReconciler allows management clusters to define ClusterResourceSets
+// applied to their workload clusters.
+func WithClusterResourceSetReconciler(r ClusterResourceSetReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.clusterResourceSets = r
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger) error {
 	childObjectHandler := handlers.ChildObjectToClusters(log)
@@ -187,14 +235,16 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete;patch;update
 // +kubebuilder:rbac:groups="",namespace=eksa-system,resources=secrets,verbs=patch;update
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=create;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=create;delete;get;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=list
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;awsiamconfigs;fluxconfigs,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;snowmachineconfigs/status;snowippools/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;tinkerbelldatacenterconfigs/status;tinkerbellmachineconfigs/status;cloudstackdatacenterconfigs/status;cloudstackmachineconfigs/status;awsiamconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters,verbs=delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=bundles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusteroperations,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;snowmachineconfigs/finalizers;snowippools/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;cloudstackdatacenterconfigs/finalizers;cloudstackmachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers;tinkerbelldatacenterconfigs/finalizers;tinkerbellmachineconfigs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigtemplates,verbs=create;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machinedeployments,verbs=list;watch;get;patch;update;create;delete
@@ -208,9 +258,17 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 // +kubebuilder:rbac:groups=tinkerbell.org,resources=hardware,verbs=list;watch
 // +kubebuilder:rbac:groups=bmc.tinkerbell.org,resources=machines,verbs=list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowclusters;awssnowmachinetemplates;awssnowippools;vsphereclusters;vspheremachinetemplates;dockerclusters;dockermachinetemplates;tinkerbellclusters;tinkerbellmachinetemplates;cloudstackclusters;cloudstackmachinetemplates;nutanixclusters;nutanixmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kustomize.toolkit.fluxcd.io,resources=kustomizations,verbs=get
 // +kubebuilder:rbac:groups=packages.eks.amazonaws.com,resources=packages,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=packages.eks.amazonaws.com,namespace=eksa-system,resources=packagebundlecontrollers,verbs=delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=eksareleases,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps;serviceaccounts;services,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;bind;escalate
 // The eksareleases permissions are being moved to the ClusterRole due to client trying to list this resource from cache.
 // When trying to list resources not already in cache, it starts an informer for that type using the scope of the cache.
 // So if the manager is cluster-scoped, the new informers created by the cache will be cluster-scoped
@@ -237,7 +295,17 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 
 	defer func() {
-		err := r.updateStatus(ctx, log, cluster)
+		// When the manager is shutting down, the reconciliation context is canceled. A new context
+		// is used so the progress of the interrupted reconciliation is still persisted.
+		patchCtx := ctx
+		if ctx.Err() != nil {
+			log.Info("Reconciliation interrupted by controller shutdown, persisting cluster status")
+			var cancel context.CancelFunc
+			patchCtx, cancel = context.WithTimeout(context.Background(), shutdownPatchTimeout)
+			defer cancel()
+		}
+
+		err := r.updateStatus(patchCtx, log, cluster)
 		if err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
@@ -253,7 +321,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		if reterr == nil {
 			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
 		}
-		if err := patchCluster(ctx, patchHelper, cluster, patchOpts...); err != nil {
+		if err := patchCluster(patchCtx, patchHelper, cluster, patchOpts...); err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 
@@ -264,6 +332,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && conditions.IsFalse(cluster, anywherev1.ReadyCondition) {
 			result = ctrl.Result{RequeueAfter: 10 * time.Second}
 		}
+
+		// Make sure we get a reconcile request when the cluster TTL expires, even if nothing changes.
+		if reterr == nil && cluster.DeletionTimestamp.IsZero() {
+			result = requeueBeforeExpiration(cluster, result)
+		}
 	}()
 
 	if !cluster.DeletionTimestamp.IsZero() {
@@ -276,6 +349,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, nil
 	}
 
+	if expired, err := r.deleteIfExpired(ctx, log, cluster); err != nil || expired {
+		return ctrl.Result{}, err
+	}
+
 	// AddFinalizer	is idempotent
 	controllerutil.AddFinalizer(cluster, ClusterFinalizerName)
 
@@ -301,18 +378,68 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	// then return without any further processing.
 	if aggregatedGeneration == cluster.Status.ChildrenReconciledGeneration && cluster.Status.ReconciledGeneration == cluster.Generation {
 		log.Info("Generation and aggregated generation match reconciled generations for cluster and child objects, skipping reconciliation.")
+		r.clearReconcileProgress(ctx, log, cluster)
+		return ctrl.Result{}, nil
+	}
+
+	specHash, err := config.SpecHash()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The generations change with every update of the objects, even if the resulting specs don't change,
+	// like when the child objects are recreated. If the specs match the ones from the last successful
+	// reconciliation, there is nothing to do, so only the reconciled generations are updated.
+	// A cleared reconciled generation forces a reconciliation, like after the provider credentials
+	// are rotated, so the spec hash is ignored in that case.
+	if cluster.Status.ReconciledGeneration != 0 && specHash == cluster.Status.SpecHash {
+		log.Info("Spec hash matches reconciled spec hash for cluster and child objects, skipping reconciliation.")
+		cluster.Status.ReconciledGeneration = cluster.Generation
+		cluster.Status.ChildrenReconciledGeneration = aggregatedGeneration
+		r.clearReconcileProgress(ctx, log, cluster)
 		return ctrl.Result{}, nil
 	}
 
-	return r.reconcile(ctx, log, cluster, aggregatedGeneration)
+	// Clear the hash until the reconciliation finishes, so a spec reverted to the last
+	// reconciled one before that is not skipped.
+	cluster.Status.SpecHash = ""
+	previousProgress := cluster.Status.ReconcileProgress
+	startReconcileProgress(log, cluster, specHash)
+	if cluster.Status.ReconcileProgress != previousProgress {
+		r.startClusterOperation(ctx, log, cluster, previousProgress)
+	}
+
+	// The progress is removed when the reconciliation completes, so the operation is read before.
+	operation := cluster.Status.ReconcileProgress.Operation
+	result, err = r.reconcile(ctx, log, cluster, aggregatedGeneration, specHash)
+	r.completeClusterOperation(ctx, log, cluster, operation, err)
+
+	return result, err
 }
 
-func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, aggregatedGeneration int64) (ctrl.Result, error) {
+func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, aggregatedGeneration int64, specHash string) (ctrl.Result, error) {
 	clusterProviderReconciler := r.providerReconcilerRegistry.Get(cluster.Spec.DatacenterRef.Kind)
 
 	var reconcileResult controller.Result
 	var err error
 
+	// A reconciliation resumed after a restart that already went past the self-upgrade has already
+	// checked the eks-a components for this spec, so it doesn't repeat the handoff.
+	if r.selfUpgrade != nil && !reconcileProgressPast(cluster, anywherev1.SelfUpgradeReconcilePhase) {
+		setReconcilePhase(cluster, anywherev1.SelfUpgradeReconcilePhase)
+		// The eks-a components must be upgraded before anything else, so this controller never
+		// reconciles the cluster with a bundle from a newer eks-a version.
+		reconcileResult, err = r.selfUpgrade.Reconcile(ctx, log, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if reconcileResult.Return() {
+			return reconcileResult.ToCtrlResult(), nil
+		}
+	}
+
+	setReconcilePhase(cluster, anywherev1.PreProviderReconcilePhase)
 	reconcileResult, err = r.preClusterProviderReconcile(ctx, log, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -322,6 +449,7 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	setReconcilePhase(cluster, anywherev1.ProviderReconcilePhase)
 	if cluster.IsSelfManaged() && !r.experimentalSelfManagedUpgrade {
 		// self-managed clusters should only reconcile worker nodes to avoid control plane instability
 		reconcileResult, err = clusterProviderReconciler.ReconcileWorkerNodes(ctx, log, cluster)
@@ -337,6 +465,7 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	setReconcilePhase(cluster, anywherev1.PostProviderReconcilePhase)
 	reconcileResult, err = r.postClusterProviderReconcile(ctx, log, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -352,11 +481,13 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 	// be placed above this line.
 	cluster.Status.ReconciledGeneration = cluster.Generation
 	cluster.Status.ChildrenReconciledGeneration = aggregatedGeneration
+	cluster.Status.SpecHash = specHash
 
 	// TODO(eksa-controller-SME): properly handle packages reconcile error and not triggering machine upgrade when
 	// packages reconcile is still in progress.
 	// Moving the packages reconcile after the above two generation fields are set, so that packages reconcile error
 	// does not cause side effect of rolling out of workload cluster machines during management cluster upgrade.
+	setReconcilePhase(cluster, anywherev1.PackagesReconcilePhase)
 	reconcileResult, err = r.packagesReconcile(ctx, log, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -366,9 +497,60 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	cluster.Status.ReconcileProgress = nil
+
 	return ctrl.Result{}, nil
 }
 
+// reconcilePhases are the phases of the cluster reconciliation, in order.
+var reconcilePhases = []anywherev1.ReconcilePhase{
+	anywherev1.SelfUpgradeReconcilePhase,
+	anywherev1.PreProviderReconcilePhase,
+	anywherev1.ProviderReconcilePhase,
+	anywherev1.PostProviderReconcilePhase,
+	anywherev1.PackagesReconcilePhase,
+}
+
+// startReconcileProgress records in the cluster status the start of the reconciliation of a spec.
+// If the status already has the progress of a reconciliation of the same spec, like when the
+// controller restarted in the middle of it, the progress is kept so the reconciliation resumes.
+func startReconcileProgress(log logr.Logger, cluster *anywherev1.Cluster, specHash string) {
+	if p := cluster.Status.ReconcileProgress; p != nil && p.SpecHash == specHash {
+		log.Info("Resuming cluster reconciliation in progress", "phase", p.Phase, "startedAt", p.StartedAt.Time)
+		return
+	}
+
+	cluster.Status.ReconcileProgress = &anywherev1.ReconcileProgress{
+		SpecHash:  specHash,
+		StartedAt: metav1.Now(),
+	}
+}
+
+// setReconcilePhase records the phase the reconciliation is starting. Phases are only recorded
+// forward, so the progress of a resumed reconciliation is not lost when earlier phases run again.
+func setReconcilePhase(cluster *anywherev1.Cluster, phase anywherev1.ReconcilePhase) {
+	p := cluster.Status.ReconcileProgress
+	if p == nil || reconcilePhaseIndex(phase) < reconcilePhaseIndex(p.Phase) {
+		return
+	}
+	p.Phase = phase
+}
+
+// reconcileProgressPast checks if the reconciliation in progress already went past a phase.
+func reconcileProgressPast(cluster *anywherev1.Cluster, phase anywherev1.ReconcilePhase) bool {
+	p := cluster.Status.ReconcileProgress
+	return p != nil && reconcilePhaseIndex(p.Phase) > reconcilePhaseIndex(phase)
+}
+
+func reconcilePhaseIndex(phase anywherev1.ReconcilePhase) int {
+	for i, p := range reconcilePhases {
+		if p == phase {
+			return i
+		}
+	}
+	return -1
+}
+
 func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
 	// Run some preflight validations that can't be checked in webhook
 	if cluster.HasAWSIamConfig() {
@@ -385,6 +567,12 @@ func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log
 			return controller.Result{}, err
 		}
 
+		if err := r.clusterValidator.ValidateClusterNameUnique(ctx, log, cluster); err != nil {
+			log.Error(err, "Invalid cluster configuration")
+			cluster.SetFailure(anywherev1.ClusterInvalidReason, err.Error())
+			return controller.Result{}, err
+		}
+
 		mgmt, err := getManagementCluster(ctx, cluster, r.client)
 		if err != nil {
 			return controller.Result{}, err
@@ -422,13 +610,32 @@ func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, lo
 		return controller.Result{}, err
 	}
 
+	if r.dns != nil && hasEndpointDNS(cluster) {
+		if err := r.dns.Reconcile(ctx, log, cluster); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	// Self-managed clusters are reconciled even without clusterResourceSets, so the ones removed
+	// from the spec are deleted.
+	if r.clusterResourceSets != nil && cluster.IsSelfManaged() {
+		if err := r.clusterResourceSets.Reconcile(ctx, log, cluster); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
 	return controller.Result{}, nil
 }
 
+func hasEndpointDNS(cluster *anywherev1.Cluster) bool {
+	endpoint := cluster.Spec.ControlPlaneConfiguration.Endpoint
+	return endpoint != nil && endpoint.DNS != nil
+}
+
 func (r *ClusterReconciler) packagesReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
 	// Self-managed clusters can support curated packages, but that support
-	// comes from the CLI at this time.
-	if cluster.IsManaged() && cluster.IsPackagesEnabled() {
+	// comes from the CLI at this time, unless they upgrade their own controllers.
+	if (cluster.IsManaged() || cluster.IsSelfUpgradeEnabled()) && cluster.IsPackagesEnabled() {
 		if err := r.packagesClient.Reconcile(ctx, log, r.client, cluster); err != nil {
 			return controller.Result{}, err
 		}
@@ -457,14 +664,27 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, c
 
 	clusters.UpdateClusterStatusForCNI(ctx, cluster)
 
+	if err := clusters.UpdateClusterStatusForGitOps(ctx, r.client, cluster); err != nil {
+		return errors.Wrap(err, "updating status for gitops")
+	}
+
+	if err := clusters.UpdateClusterStatusForGitOpsReconciliation(ctx, r.client, cluster); err != nil {
+		return errors.Wrap(err, "updating status for gitops reconciliation")
+	}
+
+	readyConditions := []anywherev1.ConditionType{
+		anywherev1.ControlPlaneInitializedCondition,
+		anywherev1.ControlPlaneReadyCondition,
+		anywherev1.WorkersReadyCondition,
+	}
+
+	// For Clusters managed with GitOps, the Cluster is only ready once Flux has applied the repository content.
+	if clusters.GitOpsReconciliationGatesReadiness(cluster) {
+		readyConditions = append(readyConditions, anywherev1.GitOpsReconciledCondition)
+	}
+
 	// Always update the readyCondition by summarizing the state of other conditions.
-	conditions.SetSummary(cluster,
-		conditions.WithConditions(
-			anywherev1.ControlPlaneInitializedCondition,
-			anywherev1.ControlPlaneReadyCondition,
-			anywherev1.WorkersReadyCondition,
-		),
-	)
+	conditions.SetSummary(cluster, conditions.WithConditions(readyConditions...))
 
 	return nil
 }
@@ -485,6 +705,8 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, log logr.Logger
 		return ctrl.Result{}, nil
 	}
 
+	r.recordDeleteOperation(ctx, log, cluster, false)
+
 	capiCluster := &clusterv1.Cluster{}
 	capiClusterName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
 	log.Info("Deleting", "name", cluster.Name)
@@ -514,15 +736,56 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, log logr.Logger
 		}
 	}
 
+	if r.dns != nil && hasEndpointDNS(cluster) {
+		if err := r.dns.ReconcileDelete(ctx, log, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if cluster.IsManaged() {
 		if err := r.packagesClient.ReconcileDelete(ctx, log, r.client, cluster); err != nil {
 			return ctrl.Result{}, fmt.Errorf("deleting packages for cluster %q: %w", cluster.Name, err)
 		}
 	}
 
+	r.recordDeleteOperation(ctx, log, cluster, true)
+
 	return ctrl.Result{}, nil
 }
 
+// deleteIfExpired deletes workload clusters past their TTL. The deletion is then handled
+// by reconcileDelete like any other cluster deletion.
+func (r *ClusterReconciler) deleteIfExpired(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (bool, error) {
+	expiresAt, ok := cluster.ExpiresAt()
+	if !ok || cluster.IsSelfManaged() || time.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	log.Info("Cluster TTL expired, deleting cluster", "ttl", cluster.Spec.TTL.Duration, "expiresAt", expiresAt)
+	if err := r.client.Delete(ctx, cluster); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("deleting expired cluster: %v", err)
+	}
+
+	return true, nil
+}
+
+func requeueBeforeExpiration(cluster *anywherev1.Cluster, result ctrl.Result) ctrl.Result {
+	expiresAt, ok := cluster.ExpiresAt()
+	if !ok || cluster.IsSelfManaged() {
+		return result
+	}
+
+	untilExpiration := time.Until(expiresAt)
+	if untilExpiration <= 0 {
+		untilExpiration = time.Second
+	}
+	if result.RequeueAfter <= 0 || untilExpiration < result.RequeueAfter {
+		result.RequeueAfter = untilExpiration
+	}
+
+	return result
+}
+
 func (r *ClusterReconciler) buildClusterConfig(ctx context.Context, clus *anywherev1.Cluster) (*c.Config, error) {
 	builder := c.NewDefaultConfigClientBuilder()
 	config, err := builder.Build(ctx, clientutil.NewKubeClient(r.client), clus)
@@ -14,7 +14,9 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -106,16 +108,16 @@ func newVsphereClusterReconcilerTest(t *testing.T, objs ...runtime.Object) *vsph
 
 	vcb := govmomi.NewVMOMIClientBuilder()
 
-	validator := vsphere.NewValidator(govcClient, vcb)
-	defaulter := vsphere.NewDefaulter(govcClient)
+	validatorFactory := vsphere.NewValidatorFactory(func(map[string]string) vsphere.ProviderGovcClient {
+		return govcClient
+	}, vcb)
 	cniReconciler := vspherereconcilermocks.NewMockCNIReconciler(ctrl)
 	ipValidator := vspherereconcilermocks.NewMockIPValidator(ctrl)
 	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(ctrl)
 
 	reconciler := vspherereconciler.New(
 		cl,
-		validator,
-		defaulter,
+		validatorFactory,
 		cniReconciler,
 		nil,
 		ipValidator,
@@ -191,12 +193,123 @@ func TestClusterReconcilerReconcileSelfManagedCluster(t *testing.T) {
 	g.Expect(result).To(Equal(ctrl.Result{}))
 }
 
+func TestClusterReconcilerReconcileSelfManagedClusterEndpointDNS(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Endpoint: &anywherev1.Endpoint{
+					Host: "10.0.0.10",
+					DNS: &anywherev1.EndpointDNS{
+						Name:    "api.prod.example.com",
+						Route53: &anywherev1.Route53DNS{HostedZoneID: "Z0123456789"},
+					},
+				},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	controller := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(controller)
+	iam := mocks.NewMockAWSIamConfigReconciler(controller)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(controller)
+	dnsReconciler := mocks.NewMockDNSReconciler(controller)
+
+	clusterValidator := mocks.NewMockClusterValidator(controller)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp).Build()
+	mockPkgs := mocks.NewMockPackagesClient(controller)
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+	dnsReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithDNSReconciler(dnsReconciler),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
+
+func TestClusterReconcilerReconcileSelfManagedClusterResourceSets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			ClusterResourceSets: []anywherev1.ClusterResourceSet{
+				{Name: "storage", ConfigMaps: []string{"storage-classes"}},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	controller := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(controller)
+	iam := mocks.NewMockAWSIamConfigReconciler(controller)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(controller)
+	crsReconciler := mocks.NewMockClusterResourceSetReconciler(controller)
+
+	clusterValidator := mocks.NewMockClusterValidator(controller)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp).Build()
+	mockPkgs := mocks.NewMockPackagesClient(controller)
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+	crsReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithClusterResourceSetReconciler(crsReconciler),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
+
 func TestClusterReconcilerReconcileConditions(t *testing.T) {
 	testCases := []struct {
 		testName                string
 		skipCNIUpgrade          bool
 		kcpStatus               controlplanev1.KubeadmControlPlaneStatus
 		machineDeploymentStatus clusterv1.MachineDeploymentStatus
+		kustomizationReady      string
 		result                  ctrl.Result
 		wantConditions          []anywherev1.Condition
 	}{
@@ -308,6 +421,74 @@ func TestClusterReconcilerReconcileConditions(t *testing.T) {
 			},
 			result: ctrl.Result{},
 		},
+		{
+			testName: "cluster not ready, gitops not reconciled",
+			kcpStatus: controlplanev1.KubeadmControlPlaneStatus{
+				ReadyReplicas:   1,
+				Replicas:        1,
+				UpdatedReplicas: 1,
+				Conditions: clusterv1.Conditions{
+					{
+						Type:   controlplanev1.ControlPlaneComponentsHealthyCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+					{
+						Type:   controlplanev1.AvailableCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+					{
+						Type:   clusterv1.ReadyCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+				},
+			},
+			machineDeploymentStatus: clusterv1.MachineDeploymentStatus{
+				ReadyReplicas:   1,
+				Replicas:        1,
+				UpdatedReplicas: 1,
+			},
+			kustomizationReady: "Unknown",
+			wantConditions: []anywherev1.Condition{
+				*conditions.FalseCondition(anywherev1.ReadyCondition, anywherev1.GitOpsReconciliationInProgressReason, clusterv1.ConditionSeverityInfo, "Flux kustomization flux-system/flux-system is reconciling"),
+				*conditions.TrueCondition(anywherev1.ControlPlaneReadyCondition),
+				*conditions.TrueCondition(anywherev1.WorkersReadyCondition),
+				*conditions.FalseCondition(anywherev1.GitOpsReconciledCondition, anywherev1.GitOpsReconciliationInProgressReason, clusterv1.ConditionSeverityInfo, "Flux kustomization flux-system/flux-system is reconciling"),
+			},
+			result: ctrl.Result{Requeue: false, RequeueAfter: 10 * time.Second},
+		},
+		{
+			testName: "cluster ready, gitops reconciled",
+			kcpStatus: controlplanev1.KubeadmControlPlaneStatus{
+				ReadyReplicas:   1,
+				Replicas:        1,
+				UpdatedReplicas: 1,
+				Conditions: clusterv1.Conditions{
+					{
+						Type:   controlplanev1.ControlPlaneComponentsHealthyCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+					{
+						Type:   controlplanev1.AvailableCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+					{
+						Type:   clusterv1.ReadyCondition,
+						Status: apiv1.ConditionStatus("True"),
+					},
+				},
+			},
+			machineDeploymentStatus: clusterv1.MachineDeploymentStatus{
+				ReadyReplicas:   1,
+				Replicas:        1,
+				UpdatedReplicas: 1,
+			},
+			kustomizationReady: "True",
+			wantConditions: []anywherev1.Condition{
+				*conditions.TrueCondition(anywherev1.ReadyCondition),
+				*conditions.TrueCondition(anywherev1.GitOpsReconciledCondition),
+			},
+			result: ctrl.Result{},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -316,6 +497,14 @@ func TestClusterReconcilerReconcileConditions(t *testing.T) {
 			config.Cluster.Generation = 2
 			config.Cluster.Status.ObservedGeneration = 1
 			config.Cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+			if tt.kustomizationReady != "" {
+				config.FluxConfig = &anywherev1.FluxConfig{
+					TypeMeta:   metav1.TypeMeta{Kind: anywherev1.FluxConfigKind, APIVersion: anywherev1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: config.Cluster.Namespace},
+					Spec:       anywherev1.FluxConfigSpec{SystemNamespace: "flux-system"},
+				}
+				config.Cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"}
+			}
 
 			config.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.SkipUpgrade = ptr.Bool(tt.skipCNIUpgrade)
 
@@ -353,6 +542,10 @@ func TestClusterReconcilerReconcileConditions(t *testing.T) {
 				objs = append(objs, o)
 			}
 
+			if tt.kustomizationReady != "" {
+				objs = append(objs, fluxKustomization(tt.kustomizationReady))
+			}
+
 			testClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
 
 			mockCtrl := gomock.NewController(t)
@@ -371,6 +564,7 @@ func TestClusterReconcilerReconcileConditions(t *testing.T) {
 			iam.EXPECT().Reconcile(logCtx, log, sameName(config.Cluster)).Return(controller.Result{}, nil)
 			providerReconciler.EXPECT().Reconcile(logCtx, log, sameName(config.Cluster)).Times(1)
 			clusterValidator.EXPECT().ValidateManagementClusterName(logCtx, log, sameName(config.Cluster)).Return(nil)
+			clusterValidator.EXPECT().ValidateClusterNameUnique(logCtx, log, sameName(config.Cluster)).Return(nil)
 
 			mockPkgs.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
@@ -663,6 +857,7 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 		workerMachineConfigGeneration int64
 		oidcGeneration                int64
 		awsIAMGeneration              int64
+		specHashMatches               bool
 
 		wantReconciliation            bool
 		wantChildReconciledGeneration int64
@@ -719,6 +914,20 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 			wantReconciliation:            true,
 			wantChildReconciledGeneration: 14,
 		},
+		{
+			testName:                      "non-matching generation, matching spec hash",
+			clusterGeneration:             3,
+			reconciledGeneration:          2,
+			childReconciledGeneration:     12,
+			datacenterGeneration:          1,
+			cpMachineConfigGeneration:     2,
+			workerMachineConfigGeneration: 5,
+			oidcGeneration:                3,
+			awsIAMGeneration:              3,
+			specHashMatches:               true,
+			wantReconciliation:            false,
+			wantChildReconciledGeneration: 14,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -744,6 +953,14 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 				awsIAM.Generation = tt.awsIAMGeneration
 			}
 
+			if tt.specHashMatches {
+				specHash, err := config.SpecHash()
+				if err != nil {
+					t.Fatalf("could not compute spec hash: %v", err)
+				}
+				config.Cluster.Status.SpecHash = specHash
+			}
+
 			kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
 			machineDeployments := machineDeploymentsFromCluster(config.Cluster)
 
@@ -797,11 +1014,70 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 				g.Expect(c.Status.ChildrenReconciledGeneration).To(
 					Equal(tt.wantChildReconciledGeneration), "status children generation should have been updated to the aggregated generation's value",
 				)
+
+				g.Expect(c.Status.ReconcileProgress).To(BeNil(), "reconcile progress should have been removed after the reconciliation")
 			})
 		})
 	}
 }
 
+func TestClusterReconcilerReconcileAfterCredentialsRotation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 2
+	config.Cluster.Status.ObservedGeneration = 2
+	config.Cluster.Status.ReconciledGeneration = 2
+	config.Cluster.Status.ChildrenReconciledGeneration = aggregatedGenerationForTest(config)
+	specHash, err := config.SpecHash()
+	g.Expect(err).NotTo(HaveOccurred())
+	config.Cluster.Status.SpecHash = specHash
+
+	objs := []runtime.Object{config.Cluster, bundles, testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+	for _, md := range machineDeploymentsFromCluster(config.Cluster) {
+		objs = append(objs, md.DeepCopy())
+	}
+	kubeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	// Same status patch the vSphere credentials rotation applies to the clusters.
+	rotation := []byte(`{"status":{"providerCredentialsRotatedAt":"2023-05-10T12:00:00Z","reconciledGeneration":null,"specHash":null}}`)
+	g.Expect(kubeClient.Status().Patch(ctx, config.Cluster, client.RawPatch(k8stypes.MergePatchType, rotation))).To(Succeed())
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	iam.EXPECT().EnsureCASecret(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(config.Cluster)).Return(controller.Result{}, nil)
+	iam.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(config.Cluster)).Return(controller.Result{}, nil)
+	// The provider reconciler regenerates the credential secrets of the cluster.
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(kubeClient, newRegistryMock(providerReconciler), iam, mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mhcReconciler)
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+
+	c := envtest.CloneNameNamespace(config.Cluster)
+	envtest.NewAPIExpecter(t, kubeClient).ShouldEventuallyMatch(ctx, c, func(g Gomega) {
+		g.Expect(c.Status.ReconciledGeneration).To(Equal(c.Generation))
+		g.Expect(c.Status.SpecHash).To(Equal(specHash))
+	})
+}
+
+func aggregatedGenerationForTest(config *cluster.Config) int64 {
+	var generation int64
+	for _, o := range config.ChildObjects() {
+		generation += o.GetGeneration()
+	}
+	return generation
+}
+
 func TestClusterReconcilerReconcileSelfManagedClusterWithExperimentalUpgrades(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -849,6 +1125,230 @@ func TestClusterReconcilerReconcileSelfManagedClusterWithExperimentalUpgrades(t
 	g.Expect(result).To(Equal(ctrl.Result{}))
 }
 
+func TestClusterReconcilerReconcileSelfManagedClusterSelfUpgradeInProgress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ManagementControllers: &anywherev1.ManagementControllersConfiguration{
+				SelfUpgrade: true,
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster).Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	selfUpgrade := mocks.NewMockSelfUpgradeReconciler(mockCtrl)
+
+	selfUpgrade.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).
+		Return(controller.ResultWithRequeue(time.Minute), nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithSelfUpgradeReconciler(selfUpgrade),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+	api := envtest.NewAPIExpecter(t, c)
+	cluster := envtest.CloneNameNamespace(selfManagedCluster)
+	api.ShouldEventuallyMatch(ctx, cluster, func(g Gomega) {
+		g.Expect(cluster.Status.ReconcileProgress).NotTo(BeNil())
+		g.Expect(cluster.Status.ReconcileProgress.Phase).To(Equal(anywherev1.SelfUpgradeReconcilePhase))
+		g.Expect(cluster.Status.ReconcileProgress.SpecHash).NotTo(BeEmpty())
+	})
+
+	g.Expect(cluster.Status.ReconcileProgress.Operation).NotTo(BeEmpty())
+	op := &anywherev1.ClusterOperation{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: cluster.Status.ReconcileProgress.Operation, Namespace: "default"}, op)).To(Succeed())
+	g.Expect(op.Spec.ClusterName).To(Equal("my-management-cluster"))
+	g.Expect(op.Spec.Type).To(Equal(anywherev1.UpgradeClusterOperation))
+	g.Expect(op.Spec.Initiator.Type).To(Equal(anywherev1.ControllerInitiator))
+	g.Expect(op.Spec.SpecHash).To(Equal(cluster.Status.ReconcileProgress.SpecHash))
+	g.Expect(op.Status.Outcome).To(Equal(anywherev1.OperationInProgress))
+}
+
+func TestClusterReconcilerReconcileSkipsOperationRecordedByCLI(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-management-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ManagementControllers: &anywherev1.ManagementControllersConfiguration{
+				SelfUpgrade: true,
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+	cliOperation := anywherev1.NewClusterOperation(selfManagedCluster, anywherev1.UpgradeClusterOperation,
+		anywherev1.ClusterOperationInitiator{Type: anywherev1.CLIInitiator, User: "admin"}, time.Now())
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, cliOperation).Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	selfUpgrade := mocks.NewMockSelfUpgradeReconciler(mockCtrl)
+
+	selfUpgrade.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).
+		Return(controller.ResultWithRequeue(time.Minute), nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithSelfUpgradeReconciler(selfUpgrade),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cluster := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(selfManagedCluster), cluster)).To(Succeed())
+	g.Expect(cluster.Status.ReconcileProgress).NotTo(BeNil())
+	g.Expect(cluster.Status.ReconcileProgress.Operation).To(BeEmpty())
+
+	operations := &anywherev1.ClusterOperationList{}
+	g.Expect(c.List(ctx, operations)).To(Succeed())
+	g.Expect(operations.Items).To(HaveLen(1))
+	g.Expect(operations.Items[0].Name).To(Equal(cliOperation.Name))
+}
+
+func TestClusterReconcilerReconcileSelfManagedClusterResumeAfterSelfUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ManagementControllers: &anywherev1.ManagementControllersConfiguration{
+				SelfUpgrade: true,
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+	specHash, err := (&cluster.Config{Cluster: selfManagedCluster}).SpecHash()
+	g.Expect(err).ToNot(HaveOccurred())
+	startedAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	selfManagedCluster.Status.ReconcileProgress = &anywherev1.ReconcileProgress{
+		SpecHash:  specHash,
+		Phase:     anywherev1.ProviderReconcilePhase,
+		StartedAt: startedAt,
+	}
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster).Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	selfUpgrade := mocks.NewMockSelfUpgradeReconciler(mockCtrl)
+
+	selfUpgrade.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).
+		Return(controller.ResultWithRequeue(time.Minute), nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithSelfUpgradeReconciler(selfUpgrade),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+	api := envtest.NewAPIExpecter(t, c)
+	cluster := envtest.CloneNameNamespace(selfManagedCluster)
+	api.ShouldEventuallyMatch(ctx, cluster, func(g Gomega) {
+		g.Expect(cluster.Status.ReconcileProgress).NotTo(BeNil())
+		g.Expect(cluster.Status.ReconcileProgress.Phase).To(Equal(anywherev1.ProviderReconcilePhase))
+		g.Expect(cluster.Status.ReconcileProgress.StartedAt.Time).To(BeTemporally("==", startedAt.Time))
+	})
+}
+
+func TestClusterReconcilerReconcileSelfManagedClusterSelfUpgradePackages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			EksaVersion: &version,
+			ManagementControllers: &anywherev1.ManagementControllersConfiguration{
+				SelfUpgrade: true,
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster).Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	selfUpgrade := mocks.NewMockSelfUpgradeReconciler(mockCtrl)
+
+	selfUpgrade.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+	mockPkgs.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.Any(), sameName(selfManagedCluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithSelfUpgradeReconciler(selfUpgrade),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
+
 func TestClusterReconcilerReconcilePausedCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -890,6 +1390,63 @@ func TestClusterReconcilerReconcilePausedCluster(t *testing.T) {
 	})
 }
 
+func TestClusterReconcilerReconcileExpiredCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	managementCluster := vsphereCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := vsphereCluster()
+	cluster.SetManagedBy(managementCluster.Name)
+	cluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	cluster.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	controllerutil.AddFinalizer(cluster, controllers.ClusterFinalizerName)
+
+	c := fake.NewClientBuilder().WithRuntimeObjects(managementCluster, cluster).Build()
+
+	ctrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(ctrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(ctrl)
+	clusterValidator := mocks.NewMockClusterValidator(ctrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(ctrl)
+
+	registry := newRegistryMock(providerReconciler)
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, nil, mhcReconciler)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	api := envtest.NewAPIExpecter(t, c)
+	cl := envtest.CloneNameNamespace(cluster)
+	api.ShouldEventuallyMatch(ctx, cl, func(g Gomega) {
+		g.Expect(cl.DeletionTimestamp.IsZero()).To(BeFalse(), "Cluster should be marked for deletion")
+	})
+}
+
+func TestClusterReconcilerReconcileClusterRequeuesBeforeExpiration(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	managementCluster := vsphereCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := vsphereCluster()
+	cluster.SetManagedBy(managementCluster.Name)
+	cluster.CreationTimestamp = metav1.NewTime(time.Now())
+	cluster.Spec.TTL = &metav1.Duration{Duration: 5 * time.Second}
+	cluster.PauseReconcile()
+
+	c := fake.NewClientBuilder().WithRuntimeObjects(managementCluster, cluster).Build()
+
+	ctrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(ctrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(ctrl)
+	clusterValidator := mocks.NewMockClusterValidator(ctrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(ctrl)
+
+	registry := newRegistryMock(providerReconciler)
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, nil, mhcReconciler)
+	result, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically("<=", 5*time.Second))
+}
+
 func TestClusterReconcilerReconcileDeletedSelfManagedCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -1360,6 +1917,7 @@ func TestClusterReconcilerPackagesInstall(s *testing.T) {
 		mockIAM := mocks.NewMockAWSIamConfigReconciler(ctrl)
 		mockValid := mocks.NewMockClusterValidator(ctrl)
 		mockValid.EXPECT().ValidateManagementClusterName(logCtx, log, gomock.Any()).Return(nil)
+		mockValid.EXPECT().ValidateClusterNameUnique(logCtx, log, gomock.Any()).Return(nil)
 		mockPkgs := mocks.NewMockPackagesClient(ctrl)
 		mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(ctrl)
 
@@ -1415,6 +1973,7 @@ func TestClusterReconcilerValidateManagementEksaVersionFail(t *testing.T) {
 
 	iam.EXPECT().EnsureCASecret(logCtx, log, sameName(config.Cluster)).Return(controller.Result{}, nil)
 	clusterValidator.EXPECT().ValidateManagementClusterName(logCtx, log, sameName(config.Cluster)).Return(nil)
+	clusterValidator.EXPECT().ValidateClusterNameUnique(logCtx, log, sameName(config.Cluster)).Return(nil)
 
 	r := controllers.NewClusterReconciler(testClient, registry, iam, clusterValidator, mockPkgs, nil)
 
@@ -1663,6 +2222,27 @@ func (s *sameNameCluster) String() string {
 	return fmt.Sprintf("has name %s and namespace %s", s.c.Name, s.c.Namespace)
 }
 
+func fluxKustomization(readyStatus string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"observedGeneration": int64(1),
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               "Ready",
+					"status":             readyStatus,
+					"reason":             "ReconciliationSucceeded",
+					"lastTransitionTime": "2023-06-01T00:00:00Z",
+				},
+			},
+		},
+	}}
+	u.SetGroupVersionKind(controller.FluxKustomizationGVK)
+	u.SetName("flux-system")
+	u.SetNamespace("flux-system")
+	u.SetGeneration(1)
+	return u
+}
+
 func baseTestVsphereCluster() (*cluster.Config, *releasev1.Bundles) {
 	config := &cluster.Config{
 		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{},
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+)
+
+// fluxKustomizationLabel is set by Flux on all the objects it applies from a Kustomization.
+const fluxKustomizationLabel = "kustomize.toolkit.fluxcd.io/name"
+
+// The cluster operations are audit records, so failing to write them is only logged
+// and doesn't block the reconciliation of the cluster.
+
+// startClusterOperation records a ClusterOperation for the reconciliation started in the cluster progress.
+// The operation of the reconciliation of a previous spec, if any, is marked as superseded.
+func (r *ClusterReconciler) startClusterOperation(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, previous *anywherev1.ReconcileProgress) {
+	r.finishAbandonedOperation(ctx, log, cluster, previous)
+
+	// Self-managed clusters are always created by the CLI, which records the operation itself.
+	if cluster.IsSelfManaged() && cluster.Status.ReconciledGeneration == 0 {
+		return
+	}
+
+	opType := anywherev1.UpgradeClusterOperation
+	if cluster.Status.ReconciledGeneration == 0 {
+		opType = anywherev1.CreateClusterOperation
+	}
+
+	progress := cluster.Status.ReconcileProgress
+	op := anywherev1.NewClusterOperation(cluster, opType, operationInitiator(cluster), progress.StartedAt.Time)
+	op.Spec.SpecHash = progress.SpecHash
+	op.Spec.ClusterGeneration = cluster.Generation
+	if r.createClusterOperation(ctx, log, cluster, op) {
+		progress.Operation = op.Name
+	}
+}
+
+// completeClusterOperation records the result of a reconciliation in its ClusterOperation. The operation
+// succeeds once the reconciliation completes and fails if the cluster reports a failure. Reconcile errors
+// are retried, so they are only recorded as the operation message.
+func (r *ClusterReconciler) completeClusterOperation(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, operation string, reconcileErr error) {
+	if operation == "" {
+		return
+	}
+
+	r.updateClusterOperation(ctx, log, cluster.Namespace, operation, func(op *anywherev1.ClusterOperation) bool {
+		switch {
+		case reconcileErr != nil:
+			if op.Status.Message == reconcileErr.Error() {
+				return false
+			}
+			op.Status.Message = reconcileErr.Error()
+		case cluster.Status.ReconcileProgress == nil:
+			op.Complete(time.Now(), nil)
+		case cluster.Status.FailureMessage != nil && op.Status.Outcome != anywherev1.OperationFailed:
+			op.Complete(time.Now(), errors.New(*cluster.Status.FailureMessage))
+		default:
+			return false
+		}
+		return true
+	})
+}
+
+// clearReconcileProgress removes the progress of a reconciliation that is no longer needed
+// because the cluster spec matches the last reconciled one.
+func (r *ClusterReconciler) clearReconcileProgress(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) {
+	r.finishAbandonedOperation(ctx, log, cluster, cluster.Status.ReconcileProgress)
+	cluster.Status.ReconcileProgress = nil
+}
+
+// finishAbandonedOperation completes the operation of a reconciliation that won't continue. It succeeded
+// if its spec is the last reconciled one and it was superseded by a newer spec otherwise.
+func (r *ClusterReconciler) finishAbandonedOperation(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, progress *anywherev1.ReconcileProgress) {
+	if progress == nil || progress.Operation == "" {
+		return
+	}
+
+	r.updateClusterOperation(ctx, log, cluster.Namespace, progress.Operation, func(op *anywherev1.ClusterOperation) bool {
+		if !op.IsInProgress() {
+			return false
+		}
+		if progress.SpecHash == cluster.Status.SpecHash {
+			op.Complete(time.Now(), nil)
+		} else {
+			op.Supersede(time.Now())
+		}
+		return true
+	})
+}
+
+// recordDeleteOperation records the deletion of a cluster. The operation is named after the deletion
+// timestamp, so the same ClusterOperation is updated in every reconciliation of the deletion.
+func (r *ClusterReconciler) recordDeleteOperation(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, completed bool) {
+	op := anywherev1.NewClusterOperation(cluster, anywherev1.DeleteClusterOperation, operationInitiator(cluster), cluster.DeletionTimestamp.Time)
+	existing := &anywherev1.ClusterOperation{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: op.Name, Namespace: op.Namespace}, existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if completed {
+			op.Complete(time.Now(), nil)
+		}
+		r.createClusterOperation(ctx, log, cluster, op)
+	case err != nil:
+		log.Error(err, "Failed to get cluster operation", "operation", op.Name)
+	case completed:
+		r.updateClusterOperation(ctx, log, cluster.Namespace, op.Name, func(op *anywherev1.ClusterOperation) bool {
+			op.Complete(time.Now(), nil)
+			return true
+		})
+	}
+}
+
+// createClusterOperation creates op unless the changes to the cluster were made by an operation run by the CLI,
+// which records it itself: either a CLI operation is in progress or it produced the current cluster generation.
+// It returns true if the operation was created.
+func (r *ClusterReconciler) createClusterOperation(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, op *anywherev1.ClusterOperation) bool {
+	operations, err := c.ListOperations(ctx, clientutil.NewKubeClient(r.client), cluster.Name, cluster.Namespace)
+	if err != nil {
+		log.Error(err, "Failed to record cluster operation", "operation", op.Name)
+		return false
+	}
+
+	for _, o := range operations {
+		if o.Spec.Initiator.Type != anywherev1.CLIInitiator {
+			continue
+		}
+		producedGeneration := o.Spec.ClusterGeneration != 0 && o.Spec.ClusterGeneration == cluster.Generation
+		if o.IsInProgress() || (op.Spec.Type != anywherev1.DeleteClusterOperation && producedGeneration) {
+			log.V(4).Info("Cluster changes were made by a CLI operation, not recording cluster operation", "cliOperation", o.Name)
+			return false
+		}
+	}
+
+	if err = r.client.Create(ctx, op); err != nil {
+		log.Error(err, "Failed to record cluster operation", "operation", op.Name)
+		return false
+	}
+	log.Info("Recorded cluster operation", "operation", op.Name, "type", op.Spec.Type, "initiator", op.Spec.Initiator.Type)
+
+	return true
+}
+
+// updateClusterOperation applies update to a ClusterOperation and saves it if update returns true.
+func (r *ClusterReconciler) updateClusterOperation(ctx context.Context, log logr.Logger, namespace, name string, update func(*anywherev1.ClusterOperation) bool) {
+	op := &anywherev1.ClusterOperation{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, op); err != nil {
+		log.Error(err, "Failed to get cluster operation", "operation", name)
+		return
+	}
+
+	if !update(op) {
+		return
+	}
+	if err := r.client.Update(ctx, op); err != nil {
+		log.Error(err, "Failed to update cluster operation", "operation", name)
+	}
+}
+
+// operationInitiator returns the initiator of the changes to a cluster reconciled by the controller.
+func operationInitiator(cluster *anywherev1.Cluster) anywherev1.ClusterOperationInitiator {
+	if _, ok := cluster.Labels[fluxKustomizationLabel]; ok {
+		return anywherev1.ClusterOperationInitiator{Type: anywherev1.GitOpsInitiator}
+	}
+	return anywherev1.ClusterOperationInitiator{Type: anywherev1.ControllerInitiator}
+}
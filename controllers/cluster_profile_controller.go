@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+)
+
+// ClusterProfileReconciler reconciles a ClusterProfile object.
+type ClusterProfileReconciler struct {
+	client client.Client
+}
+
+// NewClusterProfileReconciler constructs a new ClusterProfileReconciler.
+func NewClusterProfileReconciler(client client.Client) *ClusterProfileReconciler {
+	return &ClusterProfileReconciler{
+		client: client,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.ClusterProfile{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterprofiles,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterprofiles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;fluxconfigs,verbs=create
+
+// Reconcile implements the reconcile.Reconciler interface.
+func (r *ClusterProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	profile := &anywherev1.ClusterProfile{}
+	log.Info("Reconciling clusterprofile")
+	if err := r.client.Get(ctx, req.NamespacedName, profile); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(profile, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		if err := patchHelper.Patch(ctx, profile); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching clusterprofile: %v", err)})
+		}
+	}()
+
+	// The objects created from the profile are not owned by it, so deleting
+	// a profile never deletes the clusters stamped from it.
+	if !profile.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcile(ctx, log, profile); err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling clusterprofile: %v", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ClusterProfileReconciler) reconcile(ctx context.Context, log logr.Logger, profile *anywherev1.ClusterProfile) error {
+	statuses := make([]anywherev1.ClusterProfileInstanceStatus, 0, len(profile.Spec.Instances))
+	seen := make(map[string]struct{}, len(profile.Spec.Instances))
+	var allErrs []error
+	for _, instance := range profile.Spec.Instances {
+		status := anywherev1.ClusterProfileInstanceStatus{Name: instance.Name}
+
+		var err error
+		if _, ok := seen[instance.Name]; ok {
+			err = fmt.Errorf("instance name %s is duplicated", instance.Name)
+		} else {
+			seen[instance.Name] = struct{}{}
+			err = r.reconcileInstance(ctx, profile, instance)
+		}
+
+		if err != nil {
+			failureMessage := err.Error()
+			status.FailureMessage = &failureMessage
+			allErrs = append(allErrs, fmt.Errorf("instance %s: %v", instance.Name, err))
+		} else {
+			status.Applied = true
+			log.V(3).Info("Applied clusterprofile instance", "instance", instance.Name)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	profile.Status.Instances = statuses
+	profile.Status.ObservedGeneration = profile.Generation
+
+	return kerrors.NewAggregate(allErrs)
+}
+
+func (r *ClusterProfileReconciler) reconcileInstance(ctx context.Context, profile *anywherev1.ClusterProfile, instance anywherev1.ClusterProfileInstance) error {
+	objs, err := profileInstanceObjects(profile, instance)
+	if err != nil {
+		return err
+	}
+
+	return serverside.ReconcileObjects(ctx, r.client, objs)
+}
+
+// profileInstanceObjects renders the profile template for an instance. All the objects are
+// created in the namespace of the profile and only EKS-A objects are allowed, so a profile
+// can't be used to create arbitrary objects with the permissions of the controller.
+func profileInstanceObjects(profile *anywherev1.ClusterProfile, instance anywherev1.ClusterProfileInstance) ([]client.Object, error) {
+	rendered, err := cluster.RenderProfileTemplate([]byte(profile.Spec.Template), profile.InstanceValues(instance))
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := clientutil.YamlToClientObjects(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("parsing rendered template: %v", err)
+	}
+
+	for _, o := range objs {
+		gvk := o.GetObjectKind().GroupVersionKind()
+		if gvk.Group != anywherev1.GroupVersion.Group || gvk.Kind == anywherev1.ClusterProfileKind {
+			return nil, fmt.Errorf("object %s %s is not supported in cluster profiles, only EKS-A cluster objects are allowed", gvk.Kind, o.GetName())
+		}
+
+		if o.GetNamespace() != "" && o.GetNamespace() != profile.Namespace {
+			return nil, fmt.Errorf("object %s %s must be in the namespace of the profile %s", gvk.Kind, o.GetName(), profile.Namespace)
+		}
+		o.SetNamespace(profile.Namespace)
+
+		clientutil.AddLabel(o, anywherev1.ClusterProfileLabel, profile.Name)
+		clientutil.AddLabel(o, anywherev1.ClusterProfileInstanceLabel, instance.Name)
+	}
+
+	return objs, nil
+}
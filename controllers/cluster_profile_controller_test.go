@@ -0,0 +1,147 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const clusterProfileTemplate = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: {{ .Values.clusterName }}
+  annotations:
+    site: ${site}
+`
+
+func clusterProfile(namespace, template string, instances ...anywherev1.ClusterProfileInstance) *anywherev1.ClusterProfile {
+	return &anywherev1.ClusterProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edge",
+			Namespace: namespace,
+		},
+		Spec: anywherev1.ClusterProfileSpec{
+			Template:  template,
+			Defaults:  map[string]string{"site": "default-site"},
+			Instances: instances,
+		},
+	}
+}
+
+func reconcileClusterProfile(ctx context.Context, profile *anywherev1.ClusterProfile) error {
+	r := controllers.NewClusterProfileReconciler(env.Client())
+	_, err := r.Reconcile(ctx, reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: profile.Name, Namespace: profile.Namespace},
+	})
+	return err
+}
+
+func TestClusterProfileReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewClusterProfileReconciler(client)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestClusterProfileReconcilerReconcileSuccess(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := env.Client()
+	ns := env.CreateNamespaceForTest(ctx, t)
+
+	profile := clusterProfile(ns, clusterProfileTemplate,
+		anywherev1.ClusterProfileInstance{Name: "edge-1"},
+		anywherev1.ClusterProfileInstance{Name: "edge-2", Values: map[string]string{"site": "store-2"}},
+	)
+	g.Expect(client.Create(ctx, profile)).To(Succeed())
+
+	g.Expect(reconcileClusterProfile(ctx, profile)).To(Succeed())
+
+	for name, site := range map[string]string{"edge-1": "default-site", "edge-2": "store-2"} {
+		dc := &anywherev1.DockerDatacenterConfig{}
+		g.Expect(client.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, dc)).To(Succeed())
+		g.Expect(dc.Annotations).To(HaveKeyWithValue("site", site))
+		g.Expect(dc.Labels).To(HaveKeyWithValue(anywherev1.ClusterProfileLabel, "edge"))
+		g.Expect(dc.Labels).To(HaveKeyWithValue(anywherev1.ClusterProfileInstanceLabel, name))
+	}
+
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: profile.Name, Namespace: ns}, profile)).To(Succeed())
+	g.Expect(profile.Status.ObservedGeneration).To(Equal(profile.Generation))
+	g.Expect(profile.Status.Instances).To(ConsistOf(
+		anywherev1.ClusterProfileInstanceStatus{Name: "edge-1", Applied: true},
+		anywherev1.ClusterProfileInstanceStatus{Name: "edge-2", Applied: true},
+	))
+}
+
+func TestClusterProfileReconcilerReconcileInstanceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{
+			name:     "missing value",
+			template: "kind: DockerDatacenterConfig\nname: ${missing}\n",
+			wantErr:  "variables not set: missing",
+		},
+		{
+			name:     "not eksa object",
+			template: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.clusterName }}\n",
+			wantErr:  "object ConfigMap edge-1 is not supported in cluster profiles",
+		},
+		{
+			name:     "other namespace",
+			template: "apiVersion: anywhere.eks.amazonaws.com/v1alpha1\nkind: DockerDatacenterConfig\nmetadata:\n  name: {{ .Values.clusterName }}\n  namespace: eksa-system\n",
+			wantErr:  "must be in the namespace of the profile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			client := env.Client()
+			ns := env.CreateNamespaceForTest(ctx, t)
+
+			profile := clusterProfile(ns, tt.template, anywherev1.ClusterProfileInstance{Name: "edge-1"})
+			g.Expect(client.Create(ctx, profile)).To(Succeed())
+
+			g.Expect(reconcileClusterProfile(ctx, profile)).To(MatchError(ContainSubstring(tt.wantErr)))
+
+			g.Expect(client.Get(ctx, types.NamespacedName{Name: profile.Name, Namespace: ns}, profile)).To(Succeed())
+			g.Expect(profile.Status.Instances).To(HaveLen(1))
+			g.Expect(profile.Status.Instances[0].Applied).To(BeFalse())
+			g.Expect(profile.Status.Instances[0].FailureMessage).To(HaveValue(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestClusterProfileReconcilerReconcileDuplicatedInstance(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := env.Client()
+	ns := env.CreateNamespaceForTest(ctx, t)
+
+	profile := clusterProfile(ns, clusterProfileTemplate,
+		anywherev1.ClusterProfileInstance{Name: "edge-1"},
+		anywherev1.ClusterProfileInstance{Name: "edge-1"},
+	)
+	g.Expect(client.Create(ctx, profile)).To(Succeed())
+
+	g.Expect(reconcileClusterProfile(ctx, profile)).To(MatchError(ContainSubstring("instance name edge-1 is duplicated")))
+}
+
+func TestClusterProfileReconcilerReconcileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+
+	g.Expect(reconcileClusterProfile(ctx, clusterProfile(ns, clusterProfileTemplate))).To(Succeed())
+}
@@ -0,0 +1,372 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/emergencyaccess"
+)
+
+const (
+	// EmergencyAccessFinalizerName is the finalizer added to emergency accesses to remove the key from the nodes.
+	EmergencyAccessFinalizerName = "emergencyaccesses.anywhere.eks.amazonaws.com/finalizer"
+
+	// emergencyAccessRequeueAfter is how often an active access checks the cluster nodes,
+	// so the key is authorized in the new nodes matching the selector.
+	emergencyAccessRequeueAfter = 5 * time.Minute
+)
+
+// RemoteClientRegistry gets the clients of the workload clusters.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// EmergencyAccessReconciler reconciles an EmergencyAccess object.
+type EmergencyAccessReconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// NewEmergencyAccessReconciler constructs a new EmergencyAccessReconciler.
+func NewEmergencyAccessReconciler(client client.Client, remoteClientRegistry RemoteClientRegistry) *EmergencyAccessReconciler {
+	return &EmergencyAccessReconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EmergencyAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.EmergencyAccess{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=emergencyaccesses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=emergencyaccesses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=emergencyaccesses/finalizers,verbs=update
+
+// Reconcile implements the reconcile.Reconciler interface.
+func (r *EmergencyAccessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	access := &anywherev1.EmergencyAccess{}
+	log.Info("Reconciling emergencyaccess")
+	if err := r.client.Get(ctx, req.NamespacedName, access); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(access, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		if err := patchHelper.Patch(ctx, access); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching emergencyaccess: %v", err)})
+		}
+	}()
+
+	if !access.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, log, access)
+	}
+
+	controllerutil.AddFinalizer(access, EmergencyAccessFinalizerName)
+
+	// An expired access is never granted again, a new EmergencyAccess is needed.
+	if access.Status.Phase == anywherev1.EmergencyAccessExpired {
+		return ctrl.Result{}, nil
+	}
+
+	access.Status.ObservedGeneration = access.Generation
+	if access.Status.Phase == "" {
+		access.Status.Phase = anywherev1.EmergencyAccessPending
+	}
+
+	if err := access.Validate(); err != nil {
+		setEmergencyAccessFailure(access, err)
+		log.Error(err, "Invalid emergencyaccess")
+		return ctrl.Result{}, nil
+	}
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: access.Spec.ClusterName, Namespace: access.Namespace}, cluster); err != nil {
+		err = fmt.Errorf("getting cluster %s: %v", access.Spec.ClusterName, err)
+		setEmergencyAccessFailure(access, err)
+		return ctrl.Result{}, err
+	}
+
+	result, err := r.reconcile(ctx, log, cluster, access)
+	if err != nil {
+		setEmergencyAccessFailure(access, err)
+		return ctrl.Result{}, fmt.Errorf("reconciling emergencyaccess: %v", err)
+	}
+
+	return result, nil
+}
+
+func (r *EmergencyAccessReconciler) reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, access *anywherev1.EmergencyAccess) (ctrl.Result, error) {
+	now := time.Now()
+	if access.Status.GrantedAt == nil {
+		r.grant(ctx, log, cluster, access, now)
+	}
+
+	if access.IsExpired(now) {
+		if err := r.revoke(ctx, cluster, access); err != nil {
+			return ctrl.Result{}, err
+		}
+		access.Status.Phase = anywherev1.EmergencyAccessExpired
+		access.Status.Nodes = nil
+		access.Status.FailureMessage = nil
+		r.completeOperation(ctx, log, access, "access expired")
+		log.Info("Emergency access expired, key removed from the nodes")
+		return ctrl.Result{}, nil
+	}
+
+	requeueAfter := access.Status.ExpiresAt.Sub(now)
+	if requeueAfter > emergencyAccessRequeueAfter {
+		requeueAfter = emergencyAccessRequeueAfter
+	}
+
+	// The audit trail records the key, user and reason of the access when granted, so they can't be
+	// changed afterwards. The access granted keeps running until it expires or is deleted.
+	changed, err := r.accessChanged(ctx, access)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if changed {
+		failureMessage := "sshAuthorizedKey, user and reason can't be changed once the access is granted, create a new EmergencyAccess instead"
+		access.Status.FailureMessage = &failureMessage
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if err := r.authorize(ctx, cluster, access); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	access.Status.Phase = anywherev1.EmergencyAccessActive
+	access.Status.FailureMessage = nil
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// grant starts the access at now and records it in the audit trail of the cluster.
+func (r *EmergencyAccessReconciler) grant(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, access *anywherev1.EmergencyAccess, now time.Time) {
+	grantedAt := metav1.NewTime(now)
+	expiresAt := metav1.NewTime(now.Add(access.AccessDuration()))
+	access.Status.GrantedAt = &grantedAt
+	access.Status.ExpiresAt = &expiresAt
+
+	// The cluster operations are audit records, so failing to write them is only logged.
+	op := anywherev1.NewClusterOperation(cluster, anywherev1.EmergencyAccessClusterOperation, anywherev1.ClusterOperationInitiator{Type: anywherev1.ControllerInitiator}, now)
+	op.Spec.Description = emergencyAccessDescription(access)
+	if err := r.client.Create(ctx, op); err != nil {
+		log.Error(err, "Failed to record cluster operation", "operation", op.Name)
+		return
+	}
+	access.Status.Operation = op.Name
+	log.Info("Emergency access granted", "operation", op.Name, "expiresAt", expiresAt)
+}
+
+// accessChanged returns true if the key, user or reason of the access are not the ones recorded
+// in its ClusterOperation.
+func (r *EmergencyAccessReconciler) accessChanged(ctx context.Context, access *anywherev1.EmergencyAccess) (bool, error) {
+	if access.Status.Operation == "" {
+		return false, nil
+	}
+
+	op := &anywherev1.ClusterOperation{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: access.Status.Operation, Namespace: access.Namespace}, op); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting cluster operation %s: %v", access.Status.Operation, err)
+	}
+
+	return op.Spec.Description != emergencyAccessDescription(access), nil
+}
+
+func (r *EmergencyAccessReconciler) reconcileDelete(ctx context.Context, log logr.Logger, access *anywherev1.EmergencyAccess) error {
+	if !controllerutil.ContainsFinalizer(access, EmergencyAccessFinalizerName) {
+		return nil
+	}
+
+	if access.Status.Phase != anywherev1.EmergencyAccessExpired {
+		cluster := &anywherev1.Cluster{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: access.Spec.ClusterName, Namespace: access.Namespace}, cluster)
+		switch {
+		case apierrors.IsNotFound(err):
+			// There are no nodes to remove the key from when the cluster is gone.
+		case err != nil:
+			return err
+		case cluster.DeletionTimestamp.IsZero():
+			if err := r.revoke(ctx, cluster, access); err != nil {
+				return fmt.Errorf("revoking emergencyaccess: %v", err)
+			}
+		}
+		r.completeOperation(ctx, log, access, "access revoked")
+		log.Info("Emergency access revoked, key removed from the nodes")
+	}
+
+	controllerutil.RemoveFinalizer(access, EmergencyAccessFinalizerName)
+	return nil
+}
+
+// authorize runs the DaemonSet that authorizes the key in the selected nodes of the cluster.
+func (r *EmergencyAccessReconciler) authorize(ctx context.Context, cluster *anywherev1.Cluster, access *anywherev1.EmergencyAccess) error {
+	remoteClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return err
+	}
+
+	nodes, err := emergencyAccessNodes(ctx, remoteClient, access)
+	if err != nil {
+		return err
+	}
+	access.Status.Nodes = nodes
+	if len(nodes) == 0 {
+		return deleteEmergencyAccessDaemonSet(ctx, remoteClient, access)
+	}
+
+	bundles, err := c.BundlesForCluster(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return fmt.Errorf("getting bundles for cluster: %v", err)
+	}
+	versionsBundle, err := c.GetVersionsBundle(cluster.Spec.KubernetesVersion, bundles)
+	if err != nil {
+		return err
+	}
+
+	ds := emergencyaccess.DaemonSet(access, versionsBundle.Eksa.DiagnosticCollector.VersionedImage(), nodes, access.Status.ExpiresAt.Time)
+	existing := &appsv1.DaemonSet{}
+	err = remoteClient.Get(ctx, client.ObjectKeyFromObject(ds), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := remoteClient.Create(ctx, ds); err != nil {
+			return fmt.Errorf("creating emergency access daemonset: %v", err)
+		}
+	case err != nil:
+		return fmt.Errorf("getting emergency access daemonset: %v", err)
+	default:
+		existing.Labels = ds.Labels
+		existing.Spec = ds.Spec
+		if err := remoteClient.Update(ctx, existing); err != nil {
+			return fmt.Errorf("updating emergency access daemonset: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// revoke deletes the DaemonSet of the access, whose pods remove the key from the nodes when terminated.
+func (r *EmergencyAccessReconciler) revoke(ctx context.Context, cluster *anywherev1.Cluster, access *anywherev1.EmergencyAccess) error {
+	remoteClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return err
+	}
+	return deleteEmergencyAccessDaemonSet(ctx, remoteClient, access)
+}
+
+// completeOperation marks the ClusterOperation of the access as succeeded with message.
+func (r *EmergencyAccessReconciler) completeOperation(ctx context.Context, log logr.Logger, access *anywherev1.EmergencyAccess, message string) {
+	if access.Status.Operation == "" {
+		return
+	}
+
+	op := &anywherev1.ClusterOperation{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: access.Status.Operation, Namespace: access.Namespace}, op); err != nil {
+		log.Error(err, "Failed to get cluster operation", "operation", access.Status.Operation)
+		return
+	}
+	if !op.IsInProgress() {
+		return
+	}
+
+	op.Complete(time.Now(), nil)
+	op.Status.Message = message
+	if err := r.client.Update(ctx, op); err != nil {
+		log.Error(err, "Failed to update cluster operation", "operation", op.Name)
+	}
+}
+
+// emergencyAccessNodes returns the names of the nodes selected by the access, excluding the Bottlerocket
+// nodes since their authorized keys are managed by the admin container.
+func emergencyAccessNodes(ctx context.Context, remoteClient client.Client, access *anywherev1.EmergencyAccess) ([]string, error) {
+	selector := labels.Everything()
+	if access.Spec.NodeSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(access.Spec.NodeSelector); err != nil {
+			return nil, fmt.Errorf("invalid nodeSelector: %v", err)
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := remoteClient.List(ctx, nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing nodes: %v", err)
+	}
+
+	var names []string
+	for _, n := range nodes.Items {
+		if strings.Contains(strings.ToLower(n.Status.NodeInfo.OSImage), "bottlerocket") {
+			continue
+		}
+		names = append(names, n.Name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func deleteEmergencyAccessDaemonSet(ctx context.Context, remoteClient client.Client, access *anywherev1.EmergencyAccess) error {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      emergencyaccess.DaemonSetName(access),
+			Namespace: constants.KubeSystemNamespace,
+		},
+	}
+	if err := remoteClient.Delete(ctx, ds); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting emergency access daemonset: %v", err)
+	}
+	return nil
+}
+
+// emergencyAccessDescription describes the access in its ClusterOperation. The key is identified by its
+// fingerprint, like in the sshd logs of the nodes.
+func emergencyAccessDescription(access *anywherev1.EmergencyAccess) string {
+	fingerprint := ""
+	if key, err := access.PublicKey(); err == nil {
+		fingerprint = ssh.FingerprintSHA256(key)
+	}
+	return fmt.Sprintf("%s (user: %s, key: %s)", access.Spec.Reason, access.AuthorizedUser(), fingerprint)
+}
+
+func setEmergencyAccessFailure(access *anywherev1.EmergencyAccess, err error) {
+	failureMessage := err.Error()
+	access.Status.FailureMessage = &failureMessage
+}
@@ -0,0 +1,361 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/emergencyaccess"
+)
+
+const emergencyAccessTestKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIYZTw/C3nMKfLhUzRt7DpeebiFjNautSCtz4oSGFPfr admin@example.com"
+
+type fakeRemoteClientRegistry struct {
+	client client.Client
+}
+
+func (f fakeRemoteClientRegistry) GetClient(_ context.Context, _ client.ObjectKey) (client.Client, error) {
+	return f.client, nil
+}
+
+func emergencyAccessCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube124,
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:      "bundles-1",
+				Namespace: "default",
+			},
+		},
+	}
+}
+
+func emergencyAccessObject(opts ...func(*anywherev1.EmergencyAccess)) *anywherev1.EmergencyAccess {
+	a := &anywherev1.EmergencyAccess{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.EmergencyAccessKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "debug-kubelet",
+			Namespace: "default",
+		},
+		Spec: anywherev1.EmergencyAccessSpec{
+			ClusterName:      "workload",
+			SSHAuthorizedKey: emergencyAccessTestKey,
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"group": "md-0"},
+			},
+			Reason: "debug kubelet crash loop",
+		},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func emergencyAccessNode(name, osImage string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{OSImage: osImage},
+		},
+	}
+}
+
+func workloadNodes() []client.Object {
+	return []client.Object{
+		emergencyAccessNode("md-0-a", "Ubuntu 20.04.6 LTS", map[string]string{"group": "md-0"}),
+		emergencyAccessNode("md-0-b", "Ubuntu 20.04.6 LTS", map[string]string{"group": "md-0"}),
+		emergencyAccessNode("md-0-br", "Bottlerocket OS 1.14.1 (aws-k8s-1.24)", map[string]string{"group": "md-0"}),
+		emergencyAccessNode("md-1-a", "Ubuntu 20.04.6 LTS", map[string]string{"group": "md-1"}),
+	}
+}
+
+// grantedEmergencyAccess returns an access granted at grantedAt and recorded in op.
+func grantedEmergencyAccess(grantedAt time.Time, op *anywherev1.ClusterOperation) *anywherev1.EmergencyAccess {
+	return emergencyAccessObject(func(a *anywherev1.EmergencyAccess) {
+		a.Finalizers = []string{controllers.EmergencyAccessFinalizerName}
+		start := metav1.NewTime(grantedAt)
+		expiresAt := metav1.NewTime(grantedAt.Add(time.Hour))
+		a.Status = anywherev1.EmergencyAccessStatus{
+			Phase:     anywherev1.EmergencyAccessActive,
+			GrantedAt: &start,
+			ExpiresAt: &expiresAt,
+			Nodes:     []string{"md-0-a"},
+			Operation: op.Name,
+		}
+	})
+}
+
+func emergencyAccessOperation(grantedAt time.Time) *anywherev1.ClusterOperation {
+	op := anywherev1.NewClusterOperation(emergencyAccessCluster(), anywherev1.EmergencyAccessClusterOperation, anywherev1.ClusterOperationInitiator{Type: anywherev1.ControllerInitiator}, grantedAt)
+	op.Spec.Description = "debug kubelet crash loop (user: ec2-user, key: SHA256:32Ik0qPvyLcqZELQiL0rRFfj3MaF0UOaTcvoXY1/8x4)"
+	return op
+}
+
+func emergencyAccessDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "emergency-access-debug-kubelet",
+			Namespace: constants.KubeSystemNamespace,
+		},
+	}
+}
+
+type emergencyAccessTest struct {
+	*WithT
+	ctx     context.Context
+	client  client.Client
+	remote  client.Client
+	request reconcile.Request
+	r       *controllers.EmergencyAccessReconciler
+}
+
+func newEmergencyAccessTest(t *testing.T, objs []client.Object, remoteObjs []client.Object) *emergencyAccessTest {
+	toRuntime := func(objs []client.Object) []runtime.Object {
+		runtimeObjs := make([]runtime.Object, 0, len(objs))
+		for _, o := range objs {
+			runtimeObjs = append(runtimeObjs, o)
+		}
+		return runtimeObjs
+	}
+
+	objs = append(objs, emergencyAccessCluster(), test.Bundle())
+	cl := fake.NewClientBuilder().WithRuntimeObjects(toRuntime(objs)...).Build()
+	remote := fake.NewClientBuilder().WithRuntimeObjects(toRuntime(remoteObjs)...).Build()
+
+	return &emergencyAccessTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  cl,
+		remote:  remote,
+		request: reconcile.Request{NamespacedName: types.NamespacedName{Name: "debug-kubelet", Namespace: "default"}},
+		r:       controllers.NewEmergencyAccessReconciler(cl, fakeRemoteClientRegistry{client: remote}),
+	}
+}
+
+func (tt *emergencyAccessTest) reconcile() (reconcile.Result, error) {
+	return tt.r.Reconcile(tt.ctx, tt.request)
+}
+
+func (tt *emergencyAccessTest) access() *anywherev1.EmergencyAccess {
+	access := &anywherev1.EmergencyAccess{}
+	tt.Expect(tt.client.Get(tt.ctx, tt.request.NamespacedName, access)).To(Succeed())
+	return access
+}
+
+func (tt *emergencyAccessTest) operation(name string) *anywherev1.ClusterOperation {
+	op := &anywherev1.ClusterOperation{}
+	tt.Expect(tt.client.Get(tt.ctx, types.NamespacedName{Name: name, Namespace: "default"}, op)).To(Succeed())
+	return op
+}
+
+func (tt *emergencyAccessTest) expectNoDaemonSet() {
+	err := tt.remote.Get(tt.ctx, client.ObjectKeyFromObject(emergencyAccessDaemonSet()), &appsv1.DaemonSet{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "DaemonSet should not exist")
+}
+
+func TestEmergencyAccessReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewEmergencyAccessReconciler(client, fakeRemoteClientRegistry{client: client})
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestEmergencyAccessReconcilerReconcileGrant(t *testing.T) {
+	tt := newEmergencyAccessTest(t, []client.Object{emergencyAccessObject()}, workloadNodes())
+
+	result, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+	access := tt.access()
+	tt.Expect(access.Finalizers).To(ConsistOf(controllers.EmergencyAccessFinalizerName))
+	tt.Expect(access.Status.Phase).To(Equal(anywherev1.EmergencyAccessActive))
+	tt.Expect(access.Status.FailureMessage).To(BeNil())
+	tt.Expect(access.Status.Nodes).To(Equal([]string{"md-0-a", "md-0-b"}))
+	tt.Expect(access.Status.ExpiresAt.Sub(access.Status.GrantedAt.Time)).To(Equal(time.Hour))
+
+	op := tt.operation(access.Status.Operation)
+	tt.Expect(op.Spec.Type).To(Equal(anywherev1.EmergencyAccessClusterOperation))
+	tt.Expect(op.Spec.ClusterName).To(Equal("workload"))
+	tt.Expect(op.Spec.Initiator.Type).To(Equal(anywherev1.ControllerInitiator))
+	tt.Expect(op.Spec.Description).To(Equal("debug kubelet crash loop (user: ec2-user, key: SHA256:32Ik0qPvyLcqZELQiL0rRFfj3MaF0UOaTcvoXY1/8x4)"))
+	tt.Expect(op.IsInProgress()).To(BeTrue())
+
+	ds := &appsv1.DaemonSet{}
+	tt.Expect(tt.remote.Get(tt.ctx, client.ObjectKeyFromObject(emergencyAccessDaemonSet()), ds)).To(Succeed())
+	tt.Expect(ds.Labels).To(HaveKeyWithValue(emergencyaccess.AccessLabel, "debug-kubelet"))
+	tt.Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("public.ecr.aws/eks-anywhere/diagnostic-collector:v0.9.1-eks-a-10"))
+	tt.Expect(ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(
+		Equal([]string{"md-0-a", "md-0-b"}),
+	)
+}
+
+func TestEmergencyAccessReconcilerReconcileUpdatesNodes(t *testing.T) {
+	grantedAt := time.Now().Add(-10 * time.Minute)
+	op := emergencyAccessOperation(grantedAt)
+	existing := emergencyAccessDaemonSet()
+	existing.Spec.Template.Spec.Containers = []corev1.Container{{Name: "authorize-key"}}
+	tt := newEmergencyAccessTest(t,
+		[]client.Object{grantedEmergencyAccess(grantedAt, op), op},
+		append(workloadNodes(), existing),
+	)
+
+	_, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	access := tt.access()
+	tt.Expect(access.Status.Phase).To(Equal(anywherev1.EmergencyAccessActive))
+	tt.Expect(access.Status.Nodes).To(Equal([]string{"md-0-a", "md-0-b"}))
+	tt.Expect(access.Status.GrantedAt.Unix()).To(Equal(grantedAt.Unix()))
+
+	ds := &appsv1.DaemonSet{}
+	tt.Expect(tt.remote.Get(tt.ctx, client.ObjectKeyFromObject(existing), ds)).To(Succeed())
+	tt.Expect(ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(
+		Equal([]string{"md-0-a", "md-0-b"}),
+	)
+}
+
+func TestEmergencyAccessReconcilerReconcileNoNodes(t *testing.T) {
+	grantedAt := time.Now().Add(-10 * time.Minute)
+	op := emergencyAccessOperation(grantedAt)
+	access := grantedEmergencyAccess(grantedAt, op)
+	access.Spec.NodeSelector.MatchLabels["group"] = "md-2"
+	tt := newEmergencyAccessTest(t,
+		[]client.Object{access, op},
+		append(workloadNodes(), emergencyAccessDaemonSet()),
+	)
+
+	_, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.Expect(tt.access().Status.Nodes).To(BeEmpty())
+	tt.expectNoDaemonSet()
+}
+
+func TestEmergencyAccessReconcilerReconcileExpired(t *testing.T) {
+	grantedAt := time.Now().Add(-2 * time.Hour)
+	op := emergencyAccessOperation(grantedAt)
+	tt := newEmergencyAccessTest(t,
+		[]client.Object{grantedEmergencyAccess(grantedAt, op), op},
+		append(workloadNodes(), emergencyAccessDaemonSet()),
+	)
+
+	result, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.RequeueAfter).To(BeZero())
+
+	access := tt.access()
+	tt.Expect(access.Status.Phase).To(Equal(anywherev1.EmergencyAccessExpired))
+	tt.Expect(access.Status.Nodes).To(BeEmpty())
+	tt.expectNoDaemonSet()
+
+	op = tt.operation(op.Name)
+	tt.Expect(op.Status.Outcome).To(Equal(anywherev1.OperationSucceeded))
+	tt.Expect(op.Status.Message).To(Equal("access expired"))
+	tt.Expect(op.Status.CompletedAt).NotTo(BeNil())
+
+	// An expired access is not granted again.
+	_, err = tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.expectNoDaemonSet()
+}
+
+func TestEmergencyAccessReconcilerReconcileDelete(t *testing.T) {
+	grantedAt := time.Now().Add(-10 * time.Minute)
+	op := emergencyAccessOperation(grantedAt)
+	access := grantedEmergencyAccess(grantedAt, op)
+	now := metav1.Now()
+	access.DeletionTimestamp = &now
+	tt := newEmergencyAccessTest(t,
+		[]client.Object{access, op},
+		append(workloadNodes(), emergencyAccessDaemonSet()),
+	)
+
+	_, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.expectNoDaemonSet()
+	op = tt.operation(op.Name)
+	tt.Expect(op.Status.Outcome).To(Equal(anywherev1.OperationSucceeded))
+	tt.Expect(op.Status.Message).To(Equal("access revoked"))
+
+	deleted := &anywherev1.EmergencyAccess{}
+	err = tt.client.Get(tt.ctx, tt.request.NamespacedName, deleted)
+	if err == nil {
+		tt.Expect(deleted.Finalizers).To(BeEmpty())
+	} else {
+		tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+}
+
+func TestEmergencyAccessReconcilerReconcileInvalid(t *testing.T) {
+	tt := newEmergencyAccessTest(t, []client.Object{emergencyAccessObject(func(a *anywherev1.EmergencyAccess) {
+		a.Spec.SSHAuthorizedKey = "no-pty " + emergencyAccessTestKey
+	})}, workloadNodes())
+
+	_, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	access := tt.access()
+	tt.Expect(access.Status.Phase).To(Equal(anywherev1.EmergencyAccessPending))
+	tt.Expect(access.Status.GrantedAt).To(BeNil())
+	tt.Expect(access.Status.FailureMessage).To(HaveValue(Equal("sshAuthorizedKey can't have options")))
+	tt.expectNoDaemonSet()
+}
+
+func TestEmergencyAccessReconcilerReconcileClusterNotFound(t *testing.T) {
+	tt := newEmergencyAccessTest(t, []client.Object{emergencyAccessObject(func(a *anywherev1.EmergencyAccess) {
+		a.Spec.ClusterName = "other"
+	})}, workloadNodes())
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("getting cluster other")))
+	tt.Expect(tt.access().Status.FailureMessage).To(HaveValue(ContainSubstring("getting cluster other")))
+}
+
+func TestEmergencyAccessReconcilerReconcileKeyChanged(t *testing.T) {
+	grantedAt := time.Now().Add(-10 * time.Minute)
+	op := emergencyAccessOperation(grantedAt)
+	access := grantedEmergencyAccess(grantedAt, op)
+	access.Spec.Reason = "something else"
+	tt := newEmergencyAccessTest(t, []client.Object{access, op}, workloadNodes())
+
+	result, err := tt.reconcile()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+	tt.Expect(tt.access().Status.FailureMessage).To(HaveValue(ContainSubstring("can't be changed once the access is granted")))
+	tt.expectNoDaemonSet()
+}
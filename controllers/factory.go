@@ -12,14 +12,19 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	awsiamconfigreconciler "github.com/aws/eks-anywhere/pkg/awsiamauth/reconciler"
 	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	crsreconciler "github.com/aws/eks-anywhere/pkg/clusterapi/clusterresourceset/reconciler"
 	mhcreconciler "github.com/aws/eks-anywhere/pkg/clusterapi/machinehealthcheck/reconciler"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/dns"
+	dnsreconciler "github.com/aws/eks-anywhere/pkg/dns/reconciler"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
@@ -29,7 +34,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
 	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+	selfupgradereconciler "github.com/aws/eks-anywhere/pkg/selfupgrade/reconciler"
+	"github.com/aws/eks-anywhere/pkg/version"
 )
 
 type Manager = manager.Manager
@@ -52,10 +60,14 @@ type Factory struct {
 	ipValidator                  *clusters.IPValidator
 	awsIamConfigReconciler       *awsiamconfigreconciler.Reconciler
 	machineHealthCheckReconciler *mhcreconciler.Reconciler
+	selfUpgradeReconciler        *selfupgradereconciler.Reconciler
+	dnsReconciler                *dnsreconciler.Reconciler
+	clusterResourceSetReconciler *crsreconciler.Reconciler
 	logger                       logr.Logger
 	deps                         *dependencies.Dependencies
 	packageControllerClient      *curatedpackages.PackageControllerClient
 	cloudStackValidatorRegistry  cloudstack.ValidatorRegistry
+	vsphereValidatorFactory      *vsphere.ValidatorFactory
 }
 
 type Reconcilers struct {
@@ -66,6 +78,9 @@ type Reconcilers struct {
 	TinkerbellDatacenterReconciler *TinkerbellDatacenterReconciler
 	CloudStackDatacenterReconciler *CloudStackDatacenterReconciler
 	NutanixDatacenterReconciler    *NutanixDatacenterReconciler
+	ClusterProfileReconciler       *ClusterProfileReconciler
+	UpgradeRolloutReconciler       *UpgradeRolloutReconciler
+	EmergencyAccessReconciler      *EmergencyAccessReconciler
 }
 
 type buildStep func(ctx context.Context) error
@@ -110,13 +125,22 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 		WithProviderClusterReconcilerRegistry(capiProviders).
 		withAWSIamConfigReconciler().
 		withPackageControllerClient().
-		withMachineHealthCheckReconciler()
+		withMachineHealthCheckReconciler().
+		withSelfUpgradeReconciler().
+		withDNSReconciler().
+		withClusterResourceSetReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.ClusterReconciler != nil {
 			return nil
 		}
 
+		opts = append([]ClusterReconcilerOption{
+			WithSelfUpgradeReconciler(f.selfUpgradeReconciler),
+			WithDNSReconciler(f.dnsReconciler),
+			WithClusterResourceSetReconciler(f.clusterResourceSetReconciler),
+		}, opts...)
+
 		f.reconcilers.ClusterReconciler = NewClusterReconciler(
 			f.manager.GetClient(),
 			f.registry,
@@ -149,7 +173,7 @@ func (f *Factory) WithDockerDatacenterReconciler() *Factory {
 }
 
 func (f *Factory) WithVSphereDatacenterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
+	f.withVSphereValidatorFactory()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.VSphereDatacenterReconciler != nil {
@@ -158,8 +182,7 @@ func (f *Factory) WithVSphereDatacenterReconciler() *Factory {
 
 		f.reconcilers.VSphereDatacenterReconciler = NewVSphereDatacenterReconciler(
 			f.manager.GetClient(),
-			f.deps.VSphereValidator,
-			f.deps.VSphereDefaulter,
+			f.vsphereValidatorFactory,
 		)
 
 		return nil
@@ -218,6 +241,57 @@ func (f *Factory) WithCloudStackDatacenterReconciler() *Factory {
 	return f
 }
 
+// WithClusterProfileReconciler adds the ClusterProfileReconciler to the controller factory.
+func (f *Factory) WithClusterProfileReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.ClusterProfileReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.ClusterProfileReconciler = NewClusterProfileReconciler(
+			f.manager.GetClient(),
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithUpgradeRolloutReconciler adds the UpgradeRolloutReconciler to the controller factory.
+func (f *Factory) WithUpgradeRolloutReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.UpgradeRolloutReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.UpgradeRolloutReconciler = NewUpgradeRolloutReconciler(
+			f.manager.GetClient(),
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithEmergencyAccessReconciler adds the EmergencyAccessReconciler to the controller factory.
+func (f *Factory) WithEmergencyAccessReconciler() *Factory {
+	f.withTracker()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.EmergencyAccessReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.EmergencyAccessReconciler = NewEmergencyAccessReconciler(
+			f.manager.GetClient(),
+			f.tracker,
+		)
+
+		return nil
+	})
+	return f
+}
+
 // WithNutanixDatacenterReconciler adds the NutanixDatacenterReconciler to the controller factory.
 func (f *Factory) WithNutanixDatacenterReconciler() *Factory {
 	f.dependencyFactory.WithNutanixDefaulter()
@@ -355,8 +429,7 @@ func (f *Factory) withDockerClusterReconciler() *Factory {
 }
 
 func (f *Factory) withVSphereClusterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
-	f.withTracker().withCNIReconciler().withIPValidator()
+	f.withVSphereValidatorFactory().withTracker().withCNIReconciler().withIPValidator()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.vsphereClusterReconciler != nil {
 			return nil
@@ -364,8 +437,7 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 
 		f.vsphereClusterReconciler = vspherereconciler.New(
 			f.manager.GetClient(),
-			f.deps.VSphereValidator,
-			f.deps.VSphereDefaulter,
+			f.vsphereValidatorFactory,
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
@@ -462,6 +534,27 @@ func (f *Factory) withCloudStackValidatorRegistry() *Factory {
 	return f
 }
 
+// withVSphereValidatorFactory builds the vSphere validators and defaulters with govc clients that get the
+// credentials of each cluster explicitly, since they are reconciled concurrently with different credentials.
+func (f *Factory) withVSphereValidatorFactory() *Factory {
+	f.dependencyFactory.WithGovc()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.vsphereValidatorFactory != nil {
+			return nil
+		}
+
+		govcBuilder := func(envMap map[string]string) vsphere.ProviderGovcClient {
+			return executables.NewGovc(f.deps.Govc.Executable, f.deps.Writer, executables.WithGovcEnvMap(envMap))
+		}
+		f.vsphereValidatorFactory = vsphere.NewValidatorFactory(govcBuilder, govmomi.NewVMOMIClientBuilder())
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) withCNIReconciler() *Factory {
 	f.dependencyFactory.WithCiliumTemplater()
 
@@ -550,3 +643,51 @@ func (f *Factory) withMachineHealthCheckReconciler() *Factory {
 
 	return f
 }
+
+func (f *Factory) withSelfUpgradeReconciler() *Factory {
+	f.dependencyFactory.WithFileReader()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.selfUpgradeReconciler != nil {
+			return nil
+		}
+
+		f.selfUpgradeReconciler = selfupgradereconciler.New(
+			f.manager.GetClient(),
+			clustermanager.NewEKSAComponentGenerator(f.logger, f.deps.FileReader),
+			version.Get().GitVersion,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withDNSReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dnsReconciler != nil {
+			return nil
+		}
+
+		f.dnsReconciler = dnsreconciler.New(f.manager.GetClient(), dns.NewProvider)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withClusterResourceSetReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.clusterResourceSetReconciler != nil {
+			return nil
+		}
+
+		f.clusterResourceSetReconciler = crsreconciler.New(f.manager.GetClient())
+
+		return nil
+	})
+
+	return f
+}
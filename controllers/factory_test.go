@@ -92,6 +92,66 @@ func TestFactoryBuildAllCloudStackReconciler(t *testing.T) {
 	g.Expect(reconcilers.CloudStackDatacenterReconciler).NotTo(BeNil())
 }
 
+func TestFactoryBuildClusterProfileReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithClusterProfileReconciler()
+
+	// testing idempotence
+	f.WithClusterProfileReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.ClusterProfileReconciler).NotTo(BeNil())
+}
+
+func TestFactoryBuildUpgradeRolloutReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithUpgradeRolloutReconciler()
+
+	// testing idempotence
+	f.WithUpgradeRolloutReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.UpgradeRolloutReconciler).NotTo(BeNil())
+}
+
+func TestFactoryBuildEmergencyAccessReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithEmergencyAccessReconciler()
+
+	// testing idempotence
+	f.WithEmergencyAccessReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.EmergencyAccessReconciler).NotTo(BeNil())
+}
+
 func TestFactoryBuildAllNutanixReconciler(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -230,6 +230,132 @@ func (mr *MockMachineHealthCheckReconcilerMockRecorder) Reconcile(ctx, logger, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockMachineHealthCheckReconciler)(nil).Reconcile), ctx, logger, cluster)
 }
 
+// MockSelfUpgradeReconciler is a mock of SelfUpgradeReconciler interface.
+type MockSelfUpgradeReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockSelfUpgradeReconcilerMockRecorder
+}
+
+// MockSelfUpgradeReconcilerMockRecorder is the mock recorder for MockSelfUpgradeReconciler.
+type MockSelfUpgradeReconcilerMockRecorder struct {
+	mock *MockSelfUpgradeReconciler
+}
+
+// NewMockSelfUpgradeReconciler creates a new mock instance.
+func NewMockSelfUpgradeReconciler(ctrl *gomock.Controller) *MockSelfUpgradeReconciler {
+	mock := &MockSelfUpgradeReconciler{ctrl: ctrl}
+	mock.recorder = &MockSelfUpgradeReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSelfUpgradeReconciler) EXPECT() *MockSelfUpgradeReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockSelfUpgradeReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockSelfUpgradeReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockSelfUpgradeReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockDNSReconciler is a mock of DNSReconciler interface.
+type MockDNSReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDNSReconcilerMockRecorder
+}
+
+// MockDNSReconcilerMockRecorder is the mock recorder for MockDNSReconciler.
+type MockDNSReconcilerMockRecorder struct {
+	mock *MockDNSReconciler
+}
+
+// NewMockDNSReconciler creates a new mock instance.
+func NewMockDNSReconciler(ctrl *gomock.Controller) *MockDNSReconciler {
+	mock := &MockDNSReconciler{ctrl: ctrl}
+	mock.recorder = &MockDNSReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDNSReconciler) EXPECT() *MockDNSReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockDNSReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockDNSReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDNSReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// ReconcileDelete mocks base method.
+func (m *MockDNSReconciler) ReconcileDelete(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileDelete", ctx, logger, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileDelete indicates an expected call of ReconcileDelete.
+func (mr *MockDNSReconcilerMockRecorder) ReconcileDelete(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDelete", reflect.TypeOf((*MockDNSReconciler)(nil).ReconcileDelete), ctx, logger, cluster)
+}
+
+// MockClusterResourceSetReconciler is a mock of ClusterResourceSetReconciler interface.
+type MockClusterResourceSetReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterResourceSetReconcilerMockRecorder
+}
+
+// MockClusterResourceSetReconcilerMockRecorder is the mock recorder for MockClusterResourceSetReconciler.
+type MockClusterResourceSetReconcilerMockRecorder struct {
+	mock *MockClusterResourceSetReconciler
+}
+
+// NewMockClusterResourceSetReconciler creates a new mock instance.
+func NewMockClusterResourceSetReconciler(ctrl *gomock.Controller) *MockClusterResourceSetReconciler {
+	mock := &MockClusterResourceSetReconciler{ctrl: ctrl}
+	mock.recorder = &MockClusterResourceSetReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterResourceSetReconciler) EXPECT() *MockClusterResourceSetReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockClusterResourceSetReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockClusterResourceSetReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockClusterResourceSetReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
 // MockClusterValidator is a mock of ClusterValidator interface.
 type MockClusterValidator struct {
 	ctrl     *gomock.Controller
@@ -253,6 +379,20 @@ func (m *MockClusterValidator) EXPECT() *MockClusterValidatorMockRecorder {
 	return m.recorder
 }
 
+// ValidateClusterNameUnique mocks base method.
+func (m *MockClusterValidator) ValidateClusterNameUnique(ctx context.Context, log logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateClusterNameUnique", ctx, log, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateClusterNameUnique indicates an expected call of ValidateClusterNameUnique.
+func (mr *MockClusterValidatorMockRecorder) ValidateClusterNameUnique(ctx, log, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterNameUnique", reflect.TypeOf((*MockClusterValidator)(nil).ValidateClusterNameUnique), ctx, log, cluster)
+}
+
 // ValidateManagementClusterName mocks base method.
 func (m *MockClusterValidator) ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *v1alpha1.Cluster) error {
 	m.ctrl.T.Helper()
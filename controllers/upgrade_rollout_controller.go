@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// upgradeRolloutRequeueAfter is how often a rollout in progress checks the state of its clusters.
+const upgradeRolloutRequeueAfter = 30 * time.Second
+
+// UpgradeRolloutReconciler reconciles an UpgradeRollout object.
+type UpgradeRolloutReconciler struct {
+	client client.Client
+}
+
+// NewUpgradeRolloutReconciler constructs a new UpgradeRolloutReconciler.
+func NewUpgradeRolloutReconciler(client client.Client) *UpgradeRolloutReconciler {
+	return &UpgradeRolloutReconciler{
+		client: client,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UpgradeRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.UpgradeRollout{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=upgraderollouts,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=upgraderollouts/status,verbs=get;update;patch
+
+// Reconcile implements the reconcile.Reconciler interface.
+func (r *UpgradeRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	rollout := &anywherev1.UpgradeRollout{}
+	log.Info("Reconciling upgraderollout")
+	if err := r.client.Get(ctx, req.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(rollout, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		if err := patchHelper.Patch(ctx, rollout); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching upgraderollout: %v", err)})
+		}
+	}()
+
+	// Deleting a rollout stops it but the clusters already upgraded are not rolled back.
+	if !rollout.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := rollout.Validate(); err != nil {
+		failureMessage := err.Error()
+		rollout.Status.FailureMessage = &failureMessage
+		rollout.Status.Phase = anywherev1.UpgradeRolloutPaused
+		rollout.Status.ObservedGeneration = rollout.Generation
+		log.Error(err, "Invalid upgraderollout")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcile(ctx, log, rollout); err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling upgraderollout: %v", err)
+	}
+
+	if rollout.Status.Phase == anywherev1.UpgradeRolloutCompleted {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: upgradeRolloutRequeueAfter}, nil
+}
+
+func (r *UpgradeRolloutReconciler) reconcile(ctx context.Context, log logr.Logger, rollout *anywherev1.UpgradeRollout) error {
+	clusters, err := r.selectClusters(ctx, rollout)
+	if err != nil {
+		return err
+	}
+
+	// The statuses are in the same order as the clusters, grouped by wave.
+	statuses := make([]anywherev1.UpgradeRolloutClusterStatus, 0, len(clusters))
+	waveSizes := rollout.WaveSizes(len(clusters))
+	for wave, size := range waveSizes {
+		for _, c := range clusters[len(statuses) : len(statuses)+size] {
+			statuses = append(statuses, upgradeRolloutClusterStatus(rollout, c, wave))
+		}
+	}
+
+	rollout.Status.ObservedGeneration = rollout.Generation
+	rollout.Status.Clusters = statuses
+	rollout.Status.FailureMessage = nil
+
+	failures := 0
+	for _, s := range statuses {
+		if s.State == anywherev1.UpgradeRolloutClusterFailed {
+			failures++
+		}
+	}
+
+	// The current wave is the first one with clusters that haven't finished their upgrade.
+	// Failed clusters don't block the next waves as long as they are under the failure threshold.
+	currentWave := len(waveSizes)
+	for _, s := range statuses {
+		if s.State == anywherev1.UpgradeRolloutClusterPending || s.State == anywherev1.UpgradeRolloutClusterUpgrading {
+			currentWave = s.Wave
+			break
+		}
+	}
+
+	switch {
+	case currentWave == len(waveSizes) && failures == 0:
+		rollout.Status.Phase = anywherev1.UpgradeRolloutCompleted
+		return nil
+	case failures > rollout.Spec.Strategy.MaxFailures:
+		failureMessage := fmt.Sprintf("%d clusters failed to upgrade, more than the %d allowed by maxFailures", failures, rollout.Spec.Strategy.MaxFailures)
+		rollout.Status.FailureMessage = &failureMessage
+		rollout.Status.Phase = anywherev1.UpgradeRolloutPaused
+		return nil
+	case rollout.Spec.Paused:
+		rollout.Status.Phase = anywherev1.UpgradeRolloutPaused
+		return nil
+	case currentWave == len(waveSizes):
+		// All the clusters have finished and the failures are tolerated, but the rollout
+		// keeps progressing until the failed clusters are upgraded.
+		rollout.Status.Phase = anywherev1.UpgradeRolloutProgressing
+		return nil
+	}
+
+	rollout.Status.Phase = anywherev1.UpgradeRolloutProgressing
+	rollout.Status.CurrentWave = currentWave
+
+	for i := range statuses {
+		status := &rollout.Status.Clusters[i]
+		if status.Wave != currentWave || status.State != anywherev1.UpgradeRolloutClusterPending {
+			continue
+		}
+
+		log.Info("Upgrading cluster", "cluster", status.Name, "wave", currentWave)
+		if err := r.upgradeCluster(ctx, rollout, clusters[i]); err != nil {
+			return fmt.Errorf("upgrading cluster %s: %v", status.Name, err)
+		}
+		status.State = anywherev1.UpgradeRolloutClusterUpgrading
+	}
+
+	return nil
+}
+
+// selectClusters returns the workload clusters selected by the rollout, sorted by name,
+// which is the order they are assigned to the waves in.
+func (r *UpgradeRolloutReconciler) selectClusters(ctx context.Context, rollout *anywherev1.UpgradeRollout) ([]*anywherev1.Cluster, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.ClusterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing clusterSelector: %v", err)
+	}
+
+	clusterList := &anywherev1.ClusterList{}
+	if err := r.client.List(ctx, clusterList, client.InNamespace(rollout.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	clusters := make([]*anywherev1.Cluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		c := &clusterList.Items[i]
+		if c.IsSelfManaged() || !c.DeletionTimestamp.IsZero() {
+			continue
+		}
+		clusters = append(clusters, c)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	return clusters, nil
+}
+
+func (r *UpgradeRolloutReconciler) upgradeCluster(ctx context.Context, rollout *anywherev1.UpgradeRollout, cluster *anywherev1.Cluster) error {
+	patchHelper, err := patch.NewHelper(cluster, r.client)
+	if err != nil {
+		return err
+	}
+
+	rollout.SetTargetVersions(cluster)
+
+	return patchHelper.Patch(ctx, cluster)
+}
+
+func upgradeRolloutClusterStatus(rollout *anywherev1.UpgradeRollout, cluster *anywherev1.Cluster, wave int) anywherev1.UpgradeRolloutClusterStatus {
+	status := anywherev1.UpgradeRolloutClusterStatus{
+		Name:  cluster.Name,
+		Wave:  wave,
+		State: anywherev1.UpgradeRolloutClusterPending,
+	}
+
+	switch {
+	case !rollout.TargetsCluster(cluster):
+	case cluster.Status.FailureMessage != nil:
+		status.State = anywherev1.UpgradeRolloutClusterFailed
+		failureMessage := *cluster.Status.FailureMessage
+		status.FailureMessage = &failureMessage
+	case cluster.Status.ObservedGeneration == cluster.Generation && conditions.IsTrue(cluster, anywherev1.ReadyCondition):
+		status.State = anywherev1.UpgradeRolloutClusterUpgraded
+	default:
+		status.State = anywherev1.UpgradeRolloutClusterUpgrading
+	}
+
+	return status
+}
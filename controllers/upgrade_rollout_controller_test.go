@@ -0,0 +1,250 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func rolloutCluster(name string, version anywherev1.KubernetesVersion, opts ...func(*anywherev1.Cluster)) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Generation: 1,
+			Labels:     map[string]string{"fleet": "edge"},
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: version,
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+		},
+		Status: anywherev1.ClusterStatus{
+			ObservedGeneration: 1,
+			Conditions: clusterv1.Conditions{
+				{Type: anywherev1.ReadyCondition, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func upgradeRollout(opts ...func(*anywherev1.UpgradeRollout)) *anywherev1.UpgradeRollout {
+	r := &anywherev1.UpgradeRollout{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.UpgradeRolloutKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edge-1-27",
+			Namespace: "default",
+		},
+		Spec: anywherev1.UpgradeRolloutSpec{
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"fleet": "edge"},
+			},
+			KubernetesVersion: anywherev1.Kube127,
+			Strategy: anywherev1.UpgradeRolloutStrategy{
+				WavePercentage: ptr.Int(50),
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func reconcileUpgradeRollout(t *testing.T, objs ...client.Object) (client.Client, *anywherev1.UpgradeRollout) {
+	t.Helper()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	runtimeObjs := make([]runtime.Object, 0, len(objs))
+	for _, o := range objs {
+		runtimeObjs = append(runtimeObjs, o)
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(runtimeObjs...).Build()
+
+	r := controllers.NewUpgradeRolloutReconciler(cl)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "edge-1-27", Namespace: "default"}}
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	rollout := &anywherev1.UpgradeRollout{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, rollout)).To(Succeed())
+
+	return cl, rollout
+}
+
+func TestUpgradeRolloutReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewUpgradeRolloutReconciler(client)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestUpgradeRolloutReconcilerReconcileStartsCanary(t *testing.T) {
+	g := NewWithT(t)
+	cl, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(),
+		rolloutCluster("edge-a", anywherev1.Kube126),
+		rolloutCluster("edge-b", anywherev1.Kube126),
+		rolloutCluster("edge-c", anywherev1.Kube126),
+		rolloutCluster("mgmt", anywherev1.Kube126, func(c *anywherev1.Cluster) {
+			c.Spec.ManagementCluster.Name = "mgmt"
+		}),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutProgressing))
+	g.Expect(rollout.Status.CurrentWave).To(Equal(0))
+	g.Expect(rollout.Status.Clusters).To(Equal([]anywherev1.UpgradeRolloutClusterStatus{
+		{Name: "edge-a", Wave: 0, State: anywherev1.UpgradeRolloutClusterUpgrading},
+		{Name: "edge-b", Wave: 1, State: anywherev1.UpgradeRolloutClusterPending},
+		{Name: "edge-c", Wave: 1, State: anywherev1.UpgradeRolloutClusterPending},
+	}))
+
+	for name, version := range map[string]anywherev1.KubernetesVersion{
+		"edge-a": anywherev1.Kube127,
+		"edge-b": anywherev1.Kube126,
+		"mgmt":   anywherev1.Kube126,
+	} {
+		c := &anywherev1.Cluster{}
+		g.Expect(cl.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, c)).To(Succeed())
+		g.Expect(c.Spec.KubernetesVersion).To(Equal(version), name)
+	}
+}
+
+func TestUpgradeRolloutReconcilerReconcileNextWave(t *testing.T) {
+	g := NewWithT(t)
+	cl, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(),
+		rolloutCluster("edge-a", anywherev1.Kube127),
+		rolloutCluster("edge-b", anywherev1.Kube126),
+		rolloutCluster("edge-c", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutProgressing))
+	g.Expect(rollout.Status.CurrentWave).To(Equal(1))
+	g.Expect(rollout.Status.Clusters).To(Equal([]anywherev1.UpgradeRolloutClusterStatus{
+		{Name: "edge-a", Wave: 0, State: anywherev1.UpgradeRolloutClusterUpgraded},
+		{Name: "edge-b", Wave: 1, State: anywherev1.UpgradeRolloutClusterUpgrading},
+		{Name: "edge-c", Wave: 1, State: anywherev1.UpgradeRolloutClusterUpgrading},
+	}))
+
+	c := &anywherev1.Cluster{}
+	g.Expect(cl.Get(context.Background(), types.NamespacedName{Name: "edge-c", Namespace: "default"}, c)).To(Succeed())
+	g.Expect(c.Spec.KubernetesVersion).To(Equal(anywherev1.Kube127))
+}
+
+func TestUpgradeRolloutReconcilerReconcileWaitsForCanary(t *testing.T) {
+	g := NewWithT(t)
+	_, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(),
+		rolloutCluster("edge-a", anywherev1.Kube127, func(c *anywherev1.Cluster) {
+			c.Generation = 2
+		}),
+		rolloutCluster("edge-b", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutProgressing))
+	g.Expect(rollout.Status.Clusters).To(Equal([]anywherev1.UpgradeRolloutClusterStatus{
+		{Name: "edge-a", Wave: 0, State: anywherev1.UpgradeRolloutClusterUpgrading},
+		{Name: "edge-b", Wave: 1, State: anywherev1.UpgradeRolloutClusterPending},
+	}))
+}
+
+func TestUpgradeRolloutReconcilerReconcilePausesOnFailures(t *testing.T) {
+	g := NewWithT(t)
+	_, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(),
+		rolloutCluster("edge-a", anywherev1.Kube127, func(c *anywherev1.Cluster) {
+			c.Status.FailureMessage = ptr.String("control plane not ready")
+		}),
+		rolloutCluster("edge-b", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutPaused))
+	g.Expect(rollout.Status.FailureMessage).To(HaveValue(ContainSubstring("1 clusters failed to upgrade")))
+	g.Expect(rollout.Status.Clusters).To(Equal([]anywherev1.UpgradeRolloutClusterStatus{
+		{Name: "edge-a", Wave: 0, State: anywherev1.UpgradeRolloutClusterFailed, FailureMessage: ptr.String("control plane not ready")},
+		{Name: "edge-b", Wave: 1, State: anywherev1.UpgradeRolloutClusterPending},
+	}))
+}
+
+func TestUpgradeRolloutReconcilerReconcileToleratesFailures(t *testing.T) {
+	g := NewWithT(t)
+	_, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(func(r *anywherev1.UpgradeRollout) {
+			r.Spec.Strategy.MaxFailures = 1
+		}),
+		rolloutCluster("edge-a", anywherev1.Kube127, func(c *anywherev1.Cluster) {
+			c.Status.FailureMessage = ptr.String("control plane not ready")
+		}),
+		rolloutCluster("edge-b", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutProgressing))
+	g.Expect(rollout.Status.Clusters[1].State).To(Equal(anywherev1.UpgradeRolloutClusterUpgrading))
+}
+
+func TestUpgradeRolloutReconcilerReconcilePaused(t *testing.T) {
+	g := NewWithT(t)
+	cl, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(func(r *anywherev1.UpgradeRollout) {
+			r.Spec.Paused = true
+		}),
+		rolloutCluster("edge-a", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutPaused))
+	g.Expect(rollout.Status.FailureMessage).To(BeNil())
+
+	c := &anywherev1.Cluster{}
+	g.Expect(cl.Get(context.Background(), types.NamespacedName{Name: "edge-a", Namespace: "default"}, c)).To(Succeed())
+	g.Expect(c.Spec.KubernetesVersion).To(Equal(anywherev1.Kube126))
+}
+
+func TestUpgradeRolloutReconcilerReconcileCompleted(t *testing.T) {
+	g := NewWithT(t)
+	_, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(),
+		rolloutCluster("edge-a", anywherev1.Kube127),
+		rolloutCluster("edge-b", anywherev1.Kube127),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutCompleted))
+}
+
+func TestUpgradeRolloutReconcilerReconcileInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, rollout := reconcileUpgradeRollout(t,
+		upgradeRollout(func(r *anywherev1.UpgradeRollout) {
+			r.Spec.Strategy.WavePercentage = ptr.Int(0)
+		}),
+		rolloutCluster("edge-a", anywherev1.Kube126),
+	)
+
+	g.Expect(rollout.Status.Phase).To(Equal(anywherev1.UpgradeRolloutPaused))
+	g.Expect(rollout.Status.FailureMessage).To(HaveValue(ContainSubstring("wavePercentage 0 must be between 1 and 100")))
+}
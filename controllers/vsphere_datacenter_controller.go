@@ -17,17 +17,15 @@ import (
 
 // VSphereDatacenterReconciler reconciles a VSphereDatacenterConfig object.
 type VSphereDatacenterReconciler struct {
-	client    client.Client
-	defaulter *vsphere.Defaulter
-	validator *vsphere.Validator
+	client           client.Client
+	validatorFactory *vsphere.ValidatorFactory
 }
 
 // NewVSphereDatacenterReconciler constructs a new VSphereDatacenterReconciler.
-func NewVSphereDatacenterReconciler(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter) *VSphereDatacenterReconciler {
+func NewVSphereDatacenterReconciler(client client.Client, validatorFactory *vsphere.ValidatorFactory) *VSphereDatacenterReconciler {
 	return &VSphereDatacenterReconciler{
-		client:    client,
-		validator: validator,
-		defaulter: defaulter,
+		client:           client,
+		validatorFactory: validatorFactory,
 	}
 }
 
@@ -80,16 +78,25 @@ func (r *VSphereDatacenterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 }
 
 func (r *VSphereDatacenterReconciler) reconcile(ctx context.Context, vsphereDatacenter *anywherev1.VSphereDatacenterConfig, log logr.Logger) (_ ctrl.Result, reterr error) {
-	// Set up envs for executing Govc cmd and default values for datacenter config
-	if err := reconciler.SetupEnvVars(ctx, vsphereDatacenter, r.client); err != nil {
-		log.Error(err, "Failed to set up env vars and default values for VsphereDatacenterConfig")
+	// Get the credentials for executing Govc cmd and set default values for datacenter config
+	vuc, err := reconciler.UserConfig(ctx, vsphereDatacenter, r.client)
+	if err != nil {
+		log.Error(err, "Failed to get vSphere credentials for VsphereDatacenterConfig")
+		return ctrl.Result{}, err
+	}
+	defaulter, err := r.validatorFactory.Defaulter(ctx, vsphereDatacenter, vuc)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
-	if err := r.defaulter.SetDefaultsForDatacenterConfig(ctx, vsphereDatacenter); err != nil {
+	if err := defaulter.SetDefaultsForDatacenterConfig(ctx, vsphereDatacenter); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed setting default values for vsphere datacenter config: %v", err)
 	}
 	// Determine if VsphereDatacenterConfig is valid
-	if err := r.validator.ValidateVCenterConfig(ctx, vsphereDatacenter); err != nil {
+	validator, err := r.validatorFactory.Validator(ctx, vsphereDatacenter, vuc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := validator.ValidateVCenterConfig(ctx, vsphereDatacenter); err != nil {
 		log.Error(err, "Failed to validate VsphereDatacenterConfig")
 		return ctrl.Result{}, err
 	}
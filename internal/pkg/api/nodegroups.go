@@ -41,6 +41,13 @@ func WithCount(count int) WorkerNodeGroupFiller {
 	}
 }
 
+// WithWorkerKubernetesVersion sets the Kubernetes version of the worker node group.
+func WithWorkerKubernetesVersion(version anywherev1.KubernetesVersion) WorkerNodeGroupFiller {
+	return func(w *anywherev1.WorkerNodeGroupConfiguration) {
+		w.KubernetesVersion = &version
+	}
+}
+
 func WithMachineGroupRef(name, kind string) WorkerNodeGroupFiller {
 	return func(w *anywherev1.WorkerNodeGroupConfiguration) {
 		w.MachineGroupRef = &anywherev1.Ref{
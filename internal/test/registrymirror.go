@@ -25,6 +25,27 @@ func RegistryMirrorInsecureSkipVerifyEnabledAndCACert() *anywherev1.RegistryMirr
 	}
 }
 
+// RegistryMirrorWithHosts returns a test RegistryMirrorConfiguration with a CACert specified and
+// per registry hosts configuration.
+func RegistryMirrorWithHosts() *anywherev1.RegistryMirrorConfiguration {
+	return &anywherev1.RegistryMirrorConfiguration{
+		Endpoint:      "0.0.0.0",
+		Port:          "5000",
+		CACertContent: CACertContent(),
+		Hosts: []anywherev1.RegistryHostsConfiguration{
+			{
+				Registry: "docker.io",
+				Hosts: []anywherev1.RegistryHost{
+					{
+						URL:          "https://0.0.0.0:5000/v2/dockerhub",
+						OverridePath: true,
+					},
+				},
+			},
+		},
+	}
+}
+
 // CACertContent returns a test string representing a cacert contents.
 func CACertContent() string {
 	return `-----BEGIN CERTIFICATE-----
@@ -77,6 +98,42 @@ func RegistryMirrorConfigFilesInsecureSkipVerifyAndCACert() []bootstrapv1.File {
 	}
 }
 
+// RegistryMirrorConfigFilesWithHosts returns cluster-api bootstrap files that configure containerd
+// to load the registry mirror hosts configuration from the certs.d directory.
+func RegistryMirrorConfigFilesWithHosts() []bootstrapv1.File {
+	return []bootstrapv1.File{
+		{
+			Content: CACertContent(),
+			Owner:   "root:root",
+			Path:    "/etc/containerd/certs.d/0.0.0.0:5000/ca.crt",
+		},
+		{
+			Content: `[host."https://0.0.0.0:5000/v2/dockerhub"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/0.0.0.0:5000/ca.crt"
+  override_path = true
+`,
+			Owner: "root:root",
+			Path:  "/etc/containerd/certs.d/docker.io/hosts.toml",
+		},
+		{
+			Content: `[host."https://0.0.0.0:5000"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/0.0.0.0:5000/ca.crt"
+`,
+			Owner: "root:root",
+			Path:  "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+		},
+		{
+			Content: `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "/etc/containerd/certs.d"
+`,
+			Owner: "root:root",
+			Path:  "/etc/containerd/config_append.toml",
+		},
+	}
+}
+
 // RegistryMirrorPreKubeadmCommands returns a list of commands to writes a config_append.toml file
 // to configure the registry mirror and restart containerd.
 func RegistryMirrorPreKubeadmCommands() []string {
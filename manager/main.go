@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -69,6 +70,10 @@ func init() {
 type config struct {
 	metricsAddr          string
 	enableLeaderElection bool
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
+	shutdownTimeout      time.Duration
 	probeAddr            string
 	gates                []string
 	logging              *logsv1.LoggingConfiguration
@@ -92,6 +97,14 @@ func initFlags(fs *pflag.FlagSet, config *config) {
 	fs.BoolVar(&config.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	fs.DurationVar(&config.leaseDuration, "leader-elect-lease-duration", anywherev1.DefaultLeaderElectionLeaseDuration,
+		"Duration that non-leader candidates will wait before trying to acquire a lease that hasn't been renewed.")
+	fs.DurationVar(&config.renewDeadline, "leader-elect-renew-deadline", anywherev1.DefaultLeaderElectionRenewDeadline,
+		"Duration that the leader will retry refreshing the lease before giving it up.")
+	fs.DurationVar(&config.retryPeriod, "leader-elect-retry-period", anywherev1.DefaultLeaderElectionRetryPeriod,
+		"Duration the candidates wait between attempts to acquire or renew the lease.")
+	fs.DurationVar(&config.shutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"Duration the controllers are given to finish the reconciliations in progress and persist their status when the manager is stopped.")
 	fs.StringSliceVar(&config.gates, "feature-gates", []string{}, "A set of key=value pairs that describe feature gates for alpha/experimental features. ")
 }
 
@@ -123,6 +136,15 @@ func main() {
 		HealthProbeBindAddress: config.probeAddr,
 		LeaderElection:         config.enableLeaderElection,
 		LeaderElectionID:       "f64ae69e.eks.amazonaws.com",
+		LeaseDuration:          &config.leaseDuration,
+		RenewDeadline:          &config.renewDeadline,
+		RetryPeriod:            &config.retryPeriod,
+		// Reconciliations in progress are given some time to finish when the manager stops,
+		// so their progress is persisted in the cluster status before the leader lease is released.
+		GracefulShutdownTimeout: &config.shutdownTimeout,
+		// Releasing the lease on shutdown allows a new controller to take over right away when
+		// the deployment is rolled out, like during self-upgrades.
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -180,7 +202,10 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		WithVSphereDatacenterReconciler().
 		WithSnowMachineConfigReconciler().
 		WithNutanixDatacenterReconciler().
-		WithCloudStackDatacenterReconciler()
+		WithCloudStackDatacenterReconciler().
+		WithClusterProfileReconciler().
+		WithUpgradeRolloutReconciler().
+		WithEmergencyAccessReconciler()
 
 	reconcilers, err := factory.Build(ctx)
 	if err != nil {
@@ -219,6 +244,24 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	setupLog.Info("Setting up clusterprofile controller")
+	if err := (reconcilers.ClusterProfileReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.ClusterProfileKind)
+		failed = true
+	}
+
+	setupLog.Info("Setting up upgraderollout controller")
+	if err := (reconcilers.UpgradeRolloutReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.UpgradeRolloutKind)
+		failed = true
+	}
+
+	setupLog.Info("Setting up emergencyaccess controller")
+	if err := (reconcilers.EmergencyAccessReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.EmergencyAccessKind)
+		failed = true
+	}
+
 	if failed {
 		if err := factory.Close(ctx); err != nil {
 			setupLog.Error(err, "Failed closing controller factory")
@@ -0,0 +1,90 @@
+// Package accessentries generates the Kubernetes RBAC and AWS IAM Authenticator configuration
+// for the Cluster access entries, so new clusters can be accessed by groups of users
+// authenticated through the cluster identity providers and not only with the admin kubeconfig.
+package accessentries
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	bindingNamePrefix = "eksa-access-"
+	// maxNameLength leaves room in the 253 characters limit for the prefix and the hash suffix.
+	maxNameLength = 200
+
+	// iamUsername is the username assigned to the IAM roles of the access entries. The AWS IAM
+	// Authenticator replaces SessionName with the session name of the assumed role.
+	iamUsername = "eksa-access:{{SessionName}}"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ClusterRoleBindings returns a ClusterRoleBinding per access entry, binding the entry ClusterRole
+// to its group.
+func ClusterRoleBindings(cluster *v1alpha1.Cluster) []*rbacv1.ClusterRoleBinding {
+	bindings := make([]*rbacv1.ClusterRoleBinding, 0, len(cluster.Spec.AccessEntries))
+	for _, entry := range cluster.Spec.AccessEntries {
+		bindings = append(bindings, &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "rbac.authorization.k8s.io/v1",
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: BindingName(entry),
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     entry.ClusterRole,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.GroupKind,
+					Name:     entry.Group,
+				},
+			},
+		})
+	}
+	return bindings
+}
+
+// MapRoles returns the AWS IAM Authenticator role mappings that add the IAM roles of the access
+// entries to their group.
+func MapRoles(cluster *v1alpha1.Cluster) []v1alpha1.MapRoles {
+	var mapRoles []v1alpha1.MapRoles
+	for _, entry := range cluster.Spec.AccessEntries {
+		for _, arn := range entry.IAMRoleARNs {
+			mapRoles = append(mapRoles, v1alpha1.MapRoles{
+				RoleARN:  arn,
+				Username: iamUsername,
+				Groups:   []string{entry.Group},
+			})
+		}
+	}
+	return mapRoles
+}
+
+// BindingName returns the name of the ClusterRoleBinding for an access entry. Groups and
+// ClusterRoles can contain characters not allowed in object names, so they are sanitized
+// and a hash of the original values is appended to avoid collisions.
+func BindingName(entry v1alpha1.AccessEntry) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(entry.Group+"-"+entry.ClusterRole), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	name = strings.Trim(name, "-.")
+
+	h := fnv.New32a()
+	h.Write([]byte(entry.Group + "/" + entry.ClusterRole))
+
+	return fmt.Sprintf("%s%s-%08x", bindingNamePrefix, name, h.Sum32())
+}
@@ -0,0 +1,85 @@
+package accessentries_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/aws/eks-anywhere/pkg/accessentries"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestClusterRoleBindings(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			AccessEntries: []v1alpha1.AccessEntry{
+				{Group: "oidc:platform-admins", ClusterRole: "cluster-admin"},
+				{Group: "developers", ClusterRole: "view"},
+			},
+		},
+	}
+
+	bindings := accessentries.ClusterRoleBindings(cluster)
+	g.Expect(bindings).To(HaveLen(2))
+
+	g.Expect(bindings[0].Name).To(HavePrefix("eksa-access-oidc-platform-admins-cluster-admin-"))
+	g.Expect(bindings[0].Kind).To(Equal("ClusterRoleBinding"))
+	g.Expect(bindings[0].RoleRef).To(Equal(rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "ClusterRole",
+		Name:     "cluster-admin",
+	}))
+	g.Expect(bindings[0].Subjects).To(ConsistOf(rbacv1.Subject{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Group",
+		Name:     "oidc:platform-admins",
+	}))
+
+	g.Expect(bindings[1].Name).To(HavePrefix("eksa-access-developers-view-"))
+	g.Expect(bindings[1].RoleRef.Name).To(Equal("view"))
+	g.Expect(bindings[1].Subjects[0].Name).To(Equal("developers"))
+}
+
+func TestClusterRoleBindingsNoEntries(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(accessentries.ClusterRoleBindings(&v1alpha1.Cluster{})).To(BeEmpty())
+}
+
+func TestMapRoles(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			AccessEntries: []v1alpha1.AccessEntry{
+				{Group: "oidc:platform-admins", ClusterRole: "cluster-admin"},
+				{
+					Group:       "developers",
+					ClusterRole: "edit",
+					IAMRoleARNs: []string{"arn:aws:iam::123456789012:role/dev", "arn:aws:iam::123456789012:role/qa"},
+				},
+			},
+		},
+	}
+
+	g.Expect(accessentries.MapRoles(cluster)).To(Equal([]v1alpha1.MapRoles{
+		{RoleARN: "arn:aws:iam::123456789012:role/dev", Username: "eksa-access:{{SessionName}}", Groups: []string{"developers"}},
+		{RoleARN: "arn:aws:iam::123456789012:role/qa", Username: "eksa-access:{{SessionName}}", Groups: []string{"developers"}},
+	}))
+}
+
+func TestBindingNameAvoidsCollisions(t *testing.T) {
+	g := NewWithT(t)
+	a := accessentries.BindingName(v1alpha1.AccessEntry{Group: "oidc:dev", ClusterRole: "view"})
+	b := accessentries.BindingName(v1alpha1.AccessEntry{Group: "oidc-dev", ClusterRole: "view"})
+
+	g.Expect(a).NotTo(Equal(b))
+}
+
+func TestBindingNameLongGroup(t *testing.T) {
+	g := NewWithT(t)
+	name := accessentries.BindingName(v1alpha1.AccessEntry{Group: strings.Repeat("a", 300), ClusterRole: "view"})
+
+	g.Expect(len(name)).To(BeNumerically("<=", 253))
+}
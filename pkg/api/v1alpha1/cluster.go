@@ -4,24 +4,31 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/semver"
@@ -32,6 +39,9 @@ const (
 	ClusterKind              = "Cluster"
 	RegistryMirrorCAKey      = "EKSA_REGISTRY_MIRROR_CA"
 	podSubnetNodeMaskMaxDiff = 16
+	// minMTU is the minimum MTU required by IPv6 and maxMTU the usual jumbo frame size.
+	minMTU = 1280
+	maxMTU = 9000
 )
 
 var re = regexp.MustCompile(constants.DefaultCuratedPackagesRegistryRegex)
@@ -173,6 +183,7 @@ func WithEtcdMachineGroupRef(ref ProviderRefAccessor) ClusterGenerateOpt {
 var clusterConfigValidations = []func(*Cluster) error{
 	validateClusterConfigName,
 	validateControlPlaneEndpoint,
+	validateControlPlaneEndpointDNS,
 	validateExternalEtcdSupport,
 	validateMachineGroupRefs,
 	validateControlPlaneReplicas,
@@ -186,8 +197,27 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validatePodIAMConfig,
 	validateCPUpgradeRolloutStrategy,
 	validateControlPlaneLabels,
+	validateDedicatedPlacement,
 	validatePackageControllerConfiguration,
 	validateEksaVersion,
+	validateClusterLabels,
+	validateClusterTTL,
+	validateManagementControllers,
+	validateImageWarmCache,
+	validateAccessEntries,
+	validatePodSecurityAdmission,
+	validatePolicyEngine,
+	validateCertManager,
+	validateMetalLB,
+	validateProviderCredentials,
+	validateComponentImageOverrides,
+	validateTemplateOverrides,
+	validateFIPS,
+	validateTLSConfiguration,
+	validateHelmPostRenderer,
+	validateScale,
+	validatePinImageDigests,
+	validateClusterResourceSets,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -412,6 +442,218 @@ func validateControlPlaneLabels(clusterConfig *Cluster) error {
 	return nil
 }
 
+// dedicatedPlacementProviders are the datacenter kinds that support placing the control plane
+// and etcd machines in dedicated infrastructure.
+var dedicatedPlacementProviders = map[string]struct{}{
+	VSphereDatacenterKind: {},
+	NutanixDatacenterKind: {},
+}
+
+func validateDedicatedPlacement(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.ControlPlaneConfiguration.DedicatedPlacement {
+		return nil
+	}
+	if _, ok := dedicatedPlacementProviders[clusterConfig.Spec.DatacenterRef.Kind]; !ok {
+		return fmt.Errorf("controlPlaneConfiguration.dedicatedPlacement is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+	return nil
+}
+
+func validateClusterLabels(clusterConfig *Cluster) error {
+	if len(clusterConfig.Spec.ClusterLabels) == 0 {
+		return nil
+	}
+	if clusterConfig.Spec.DatacenterRef.Kind != NutanixDatacenterKind {
+		return fmt.Errorf("clusterLabels is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+	if err := validateNodeLabels(clusterConfig.Spec.ClusterLabels, field.NewPath("spec", "clusterLabels")); err != nil {
+		return fmt.Errorf("clusterLabels not valid: %v", err)
+	}
+	return nil
+}
+
+func validateClusterTTL(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.TTL == nil {
+		return nil
+	}
+	if clusterConfig.IsSelfManaged() {
+		return errors.New("ttl is only supported for workload clusters")
+	}
+	if clusterConfig.Spec.TTL.Duration <= 0 {
+		return fmt.Errorf("ttl must be positive, got %s", clusterConfig.Spec.TTL.Duration)
+	}
+	return nil
+}
+
+func validateManagementControllers(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.ManagementControllers
+	if c == nil {
+		return nil
+	}
+	if !clusterConfig.IsSelfManaged() {
+		return errors.New("managementControllers is only supported for management clusters")
+	}
+	if c.KubeAPIQPS < 0 || c.KubeAPIBurst < 0 || c.Concurrency < 0 || c.MachineConcurrency < 0 {
+		return errors.New("managementControllers kubeAPIQPS, kubeAPIBurst, concurrency and machineConcurrency can't be negative")
+	}
+	if c.MachineConcurrency > 0 && !supportsMachineConcurrency(clusterConfig.Spec.DatacenterRef.Kind) {
+		return fmt.Errorf("managementControllers machineConcurrency is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+	if c.KubeAPIQPS > 0 && c.KubeAPIBurst > 0 && c.KubeAPIBurst < c.KubeAPIQPS {
+		return fmt.Errorf("managementControllers kubeAPIBurst (%d) can't be lower than kubeAPIQPS (%d)", c.KubeAPIBurst, c.KubeAPIQPS)
+	}
+	if c.Replicas < 0 {
+		return errors.New("managementControllers replicas can't be negative")
+	}
+	return validateLeaderElection(c.LeaderElection)
+}
+
+func supportsMachineConcurrency(datacenterKind string) bool {
+	switch datacenterKind {
+	case VSphereDatacenterKind, NutanixDatacenterKind, CloudStackDatacenterKind, DockerDatacenterKind:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateLeaderElection(c *LeaderElectionConfiguration) error {
+	if c == nil {
+		return nil
+	}
+	leaseDuration, renewDeadline, retryPeriod := c.Durations()
+	if leaseDuration <= 0 || renewDeadline <= 0 || retryPeriod <= 0 {
+		return errors.New("managementControllers leaderElection leaseDuration, renewDeadline and retryPeriod must be positive")
+	}
+	if leaseDuration <= renewDeadline {
+		return fmt.Errorf("managementControllers leaderElection leaseDuration (%s) must be greater than renewDeadline (%s)", leaseDuration, renewDeadline)
+	}
+	// The leader election client adds up to 20% of jitter to the retry period.
+	if renewDeadline <= time.Duration(1.2*float64(retryPeriod)) {
+		return fmt.Errorf("managementControllers leaderElection renewDeadline (%s) must be greater than 1.2 times retryPeriod (%s)", renewDeadline, retryPeriod)
+	}
+	return nil
+}
+
+func validateImageWarmCache(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.ImageWarmCache
+	if c == nil {
+		return nil
+	}
+	for _, image := range c.AdditionalImages {
+		if image == "" {
+			return errors.New("imageWarmCache additionalImages can't contain empty images")
+		}
+	}
+	return nil
+}
+
+func validateAccessEntries(clusterConfig *Cluster) error {
+	hasAWSIamConfig := false
+	for _, ref := range clusterConfig.Spec.IdentityProviderRefs {
+		if ref.Kind == AWSIamConfigKind {
+			hasAWSIamConfig = true
+		}
+	}
+
+	for _, entry := range clusterConfig.Spec.AccessEntries {
+		if entry.Group == "" {
+			return errors.New("accessEntries group can't be empty")
+		}
+		if entry.ClusterRole == "" {
+			return fmt.Errorf("accessEntries clusterRole for group %s can't be empty", entry.Group)
+		}
+		if strings.HasPrefix(entry.Group, "system:") {
+			return fmt.Errorf("accessEntries group %s can't use the reserved system: prefix", entry.Group)
+		}
+		if len(entry.IAMRoleARNs) > 0 && !hasAWSIamConfig {
+			return fmt.Errorf("accessEntries iamRoleARNs for group %s require an AWSIamConfig identity provider", entry.Group)
+		}
+		for _, arn := range entry.IAMRoleARNs {
+			if !strings.HasPrefix(arn, "arn:") {
+				return fmt.Errorf("accessEntries iamRoleARN %s for group %s is not a valid ARN", arn, entry.Group)
+			}
+		}
+	}
+	return nil
+}
+
+var podSecurityVersionRegex = regexp.MustCompile(`^(latest|v1\.\d+)$`)
+
+func validatePodSecurityAdmission(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.PodSecurityAdmission
+	if c == nil {
+		return nil
+	}
+
+	if clusterConfig.Spec.KubernetesVersion != "" {
+		kubeVersion, err := KubeVersionToSemver(clusterConfig.Spec.KubernetesVersion)
+		if err != nil {
+			return fmt.Errorf("parsing kubernetes version for podSecurityAdmission: %v", err)
+		}
+		minVersion, _ := KubeVersionToSemver(Kube123)
+		if kubeVersion.LessThan(minVersion) {
+			return fmt.Errorf("podSecurityAdmission requires kubernetes version %s or later", Kube123)
+		}
+	}
+
+	modes := []struct {
+		name  string
+		level PodSecurityLevel
+	}{
+		{name: "enforce", level: c.Enforce},
+		{name: "audit", level: c.Audit},
+		{name: "warn", level: c.Warn},
+	}
+	for _, mode := range modes {
+		switch mode.level {
+		case "", PodSecurityPrivileged, PodSecurityBaseline, PodSecurityRestricted:
+		default:
+			return fmt.Errorf("invalid podSecurityAdmission %s level %s, must be one of privileged, baseline, restricted", mode.name, mode.level)
+		}
+	}
+
+	if c.Version != "" && !podSecurityVersionRegex.MatchString(c.Version) {
+		return fmt.Errorf("invalid podSecurityAdmission version %s, must be latest or v1.<minor>", c.Version)
+	}
+
+	for _, namespace := range c.ExemptNamespaces {
+		if namespace == "" {
+			return errors.New("podSecurityAdmission exemptNamespaces can't contain empty namespaces")
+		}
+	}
+
+	return nil
+}
+
+func validatePolicyEngine(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.PolicyEngine
+	if c == nil {
+		return nil
+	}
+
+	if c.Engine != KyvernoPolicyEngine {
+		return fmt.Errorf("unsupported policyEngine engine %s, must be %s", c.Engine, KyvernoPolicyEngine)
+	}
+
+	switch c.Mode {
+	case "", PolicyEngineEnforce, PolicyEngineAudit:
+	default:
+		return fmt.Errorf("invalid policyEngine mode %s, must be one of enforce, audit", c.Mode)
+	}
+
+	for _, registry := range c.AllowedRegistries {
+		if registry == "" {
+			return errors.New("policyEngine allowedRegistries can't contain empty registries")
+		}
+		if strings.Contains(registry, "://") {
+			return fmt.Errorf("invalid policyEngine allowed registry %s, must not include a scheme", registry)
+		}
+	}
+
+	return nil
+}
+
 func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 	if (clusterConfig.Spec.ControlPlaneConfiguration.Endpoint == nil || len(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host) <= 0) && clusterConfig.Spec.DatacenterRef.Kind != DockerDatacenterKind {
 		return errors.New("cluster controlPlaneConfiguration.Endpoint.Host is not set or is empty")
@@ -419,6 +661,66 @@ func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateControlPlaneEndpointDNS(clusterConfig *Cluster) error {
+	endpoint := clusterConfig.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.DNS == nil {
+		return nil
+	}
+	dns := endpoint.DNS
+
+	if clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+		return errors.New("control plane endpoint dns is not supported for docker clusters")
+	}
+
+	if net.ParseIP(endpoint.Host) == nil {
+		return fmt.Errorf("control plane endpoint dns requires the endpoint host to be an IP, got %s", endpoint.Host)
+	}
+
+	name := strings.TrimSuffix(dns.Name, ".")
+	if errs := utilvalidation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid control plane endpoint dns name %s: %s", dns.Name, strings.Join(errs, ", "))
+	}
+
+	if dns.TTL < 0 {
+		return fmt.Errorf("control plane endpoint dns ttl %d can't be negative", dns.TTL)
+	}
+
+	providers := 0
+	if dns.Route53 != nil {
+		providers++
+		if dns.Route53.HostedZoneID == "" {
+			return errors.New("control plane endpoint dns route53 hostedZoneID is required")
+		}
+	}
+	if dns.RFC2136 != nil {
+		providers++
+		if dns.RFC2136.Server == "" || dns.RFC2136.Zone == "" {
+			return errors.New("control plane endpoint dns rfc2136 server and zone are required")
+		}
+		zone := strings.TrimSuffix(dns.RFC2136.Zone, ".")
+		if name != zone && !strings.HasSuffix(name, "."+zone) {
+			return fmt.Errorf("control plane endpoint dns name %s is not in zone %s", dns.Name, dns.RFC2136.Zone)
+		}
+		switch dns.RFC2136.TSIGAlgorithm {
+		case "", "hmac-sha256", "hmac-sha512":
+		default:
+			return fmt.Errorf("unsupported control plane endpoint dns tsigAlgorithm %s, must be hmac-sha256 or hmac-sha512", dns.RFC2136.TSIGAlgorithm)
+		}
+	}
+	if dns.Infoblox != nil {
+		providers++
+		if dns.Infoblox.Server == "" {
+			return errors.New("control plane endpoint dns infoblox server is required")
+		}
+	}
+
+	if providers != 1 {
+		return errors.New("control plane endpoint dns requires exactly one of route53, rfc2136 or infoblox")
+	}
+
+	return nil
+}
+
 func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	workerNodeGroupConfigs := clusterConfig.Spec.WorkerNodeGroupConfigurations
 	if len(workerNodeGroupConfigs) <= 0 {
@@ -462,6 +764,18 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 			}
 		}
 
+		if err := validateCapacityType(clusterConfig, &workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("validating capacity type for worker node group %v: %v", workerNodeGroupConfig.Name, err)
+		}
+
+		if err := validateReplacementStrategy(clusterConfig, &workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("validating replacement strategy for worker node group %v: %v", workerNodeGroupConfig.Name, err)
+		}
+
+		if err := validateWorkerNodeGroupKubernetesVersion(clusterConfig, &workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("validating kubernetesVersion for worker node group %v: %v", workerNodeGroupConfig.Name, err)
+		}
+
 		workerNodeGroupField := fmt.Sprintf("workerNodeGroupConfigurations[%d]", i)
 		if err := validateNodeLabels(workerNodeGroupConfig.Labels, field.NewPath("spec", workerNodeGroupField, "labels")); err != nil {
 			return fmt.Errorf("labels for worker node group %v not valid: %v", workerNodeGroupConfig.Name, err)
@@ -485,6 +799,71 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	return nil
 }
 
+// spotCapacityProviders are the datacenter kinds that support worker node groups on spot capacity.
+var spotCapacityProviders = map[string]struct{}{
+	SnowDatacenterKind:       {},
+	CloudStackDatacenterKind: {},
+}
+
+func validateCapacityType(clusterConfig *Cluster, w *WorkerNodeGroupConfiguration) error {
+	switch w.CapacityType {
+	case "", OnDemandCapacity:
+		return nil
+	case SpotCapacity:
+		if _, ok := spotCapacityProviders[clusterConfig.Spec.DatacenterRef.Kind]; !ok {
+			return fmt.Errorf("spot capacity is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+		}
+		return nil
+	default:
+		return fmt.Errorf("capacityType %s is not supported, valid values are %s and %s", w.CapacityType, OnDemandCapacity, SpotCapacity)
+	}
+}
+
+// blueGreenMachineDeploymentSuffix is appended to the MachineDeployment name of blue/green worker
+// node groups while they are replaced. It must match the one used by the clusterapi package.
+const blueGreenMachineDeploymentSuffix = "-green"
+
+func validateReplacementStrategy(clusterConfig *Cluster, w *WorkerNodeGroupConfiguration) error {
+	switch w.ReplacementStrategy {
+	case "", RollingReplacement:
+		return nil
+	case BlueGreenReplacement:
+		// Bare metal clusters can't provision the extra machines without reserving the hardware.
+		if clusterConfig.Spec.DatacenterRef.Kind == TinkerbellDatacenterKind {
+			return fmt.Errorf("blueGreen replacement is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+		}
+		if w.UpgradeRolloutStrategy != nil {
+			return errors.New("upgradeRolloutStrategy can't be set with blueGreen replacement")
+		}
+		// The MachineDeployment name is used as a label value, which is limited to 63 characters.
+		if name := fmt.Sprintf("%s-%s%s", clusterConfig.Name, w.Name, blueGreenMachineDeploymentSuffix); len(name) > utilvalidation.LabelValueMaxLength {
+			return fmt.Errorf("blueGreen replacement requires the cluster and worker node group names to be shorter, %s is longer than %d characters", name, utilvalidation.LabelValueMaxLength)
+		}
+		return nil
+	default:
+		return fmt.Errorf("replacementStrategy %s is not supported, valid values are %s and %s", w.ReplacementStrategy, RollingReplacement, BlueGreenReplacement)
+	}
+}
+
+// validateWorkerNodeGroupKubernetesVersion checks that a worker node group doesn't run a newer Kubernetes
+// version than the control plane and is at most 2 minor versions behind it.
+func validateWorkerNodeGroupKubernetesVersion(clusterConfig *Cluster, w *WorkerNodeGroupConfiguration) error {
+	if w.KubernetesVersion == nil || clusterConfig.Spec.KubernetesVersion == "" {
+		return nil
+	}
+
+	validSkew, err := validKubeMinorVersionDiff(*w.KubernetesVersion, clusterConfig.Spec.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	if !validSkew {
+		return fmt.Errorf("kubernetesVersion %s must not be newer than the cluster kubernetesVersion %s or more than 2 minor versions older", *w.KubernetesVersion, clusterConfig.Spec.KubernetesVersion)
+	}
+
+	return nil
+}
+
 func validateAutoscalingConfig(w *WorkerNodeGroupConfiguration) error {
 	if w == nil {
 		return nil
@@ -599,6 +978,10 @@ func validateNetworking(clusterConfig *Cluster) error {
 		return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
 	}
 
+	if clusterNetwork.MTU != 0 && (clusterNetwork.MTU < minMTU || clusterNetwork.MTU > maxMTU) {
+		return fmt.Errorf("cluster network mtu %d is invalid, it must be between %d and %d", clusterNetwork.MTU, minMTU, maxMTU)
+	}
+
 	return validateCNIPlugin(clusterNetwork)
 }
 
@@ -654,7 +1037,7 @@ func validateCiliumConfig(cilium *CiliumConfig) error {
 	}
 
 	if !cilium.IsManaged() {
-		if cilium.PolicyEnforcementMode != "" {
+		if cilium.PolicyEnforcementMode != "" || cilium.KubeProxyReplacement {
 			return errors.New("when using skipUpgrades for cilium all other fields must be empty")
 		}
 	}
@@ -747,6 +1130,40 @@ func validateMirrorConfig(clusterConfig *Cluster) error {
 			return errors.New("registry must be public.ecr.aws when only one mapping is specified")
 		}
 	}
+
+	hosts := clusterConfig.Spec.RegistryMirrorConfiguration.Hosts
+	if len(hosts) > 0 && clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind && clusterConfig.Spec.DatacenterRef.Kind != SnowDatacenterKind {
+		return fmt.Errorf("RegistryMirrorConfiguration.Hosts is not supported for provider %s", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+	return validateRegistryHosts(hosts)
+}
+
+func validateRegistryHosts(hostsConfigs []RegistryHostsConfiguration) error {
+	registries := make(map[string]bool, len(hostsConfigs))
+	for _, hostsConfig := range hostsConfigs {
+		if hostsConfig.Registry == "" {
+			return errors.New("registry can't be set to empty in RegistryMirrorConfiguration.Hosts")
+		}
+		if registries[hostsConfig.Registry] {
+			return fmt.Errorf("registry %s is duplicated in RegistryMirrorConfiguration.Hosts", hostsConfig.Registry)
+		}
+		registries[hostsConfig.Registry] = true
+
+		if len(hostsConfig.Hosts) == 0 {
+			return fmt.Errorf("no hosts specified for registry %s", hostsConfig.Registry)
+		}
+		for _, host := range hostsConfig.Hosts {
+			u, err := url.Parse(host.URL)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("invalid host url %s for registry %s, it must include the scheme and host", host.URL, hostsConfig.Registry)
+			}
+			for _, c := range host.Capabilities {
+				if c != RegistryHostPull && c != RegistryHostResolve && c != RegistryHostPush {
+					return fmt.Errorf("unsupported capability %s for host %s, supported capabilities are %s, %s and %s", c, host.URL, RegistryHostPull, RegistryHostResolve, RegistryHostPush)
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -862,3 +1279,403 @@ func validateEksaVersion(clusterConfig *Cluster) error {
 
 	return nil
 }
+
+func validateCertManager(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.CertManager
+	if c == nil {
+		return nil
+	}
+
+	names := map[string]struct{}{}
+	for _, issuer := range c.Issuers {
+		if issuer.Name == "" {
+			return errors.New("certManager issuer name can't be empty")
+		}
+		if _, ok := names[issuer.Name]; ok {
+			return fmt.Errorf("duplicate certManager issuer %s", issuer.Name)
+		}
+		names[issuer.Name] = struct{}{}
+
+		if (issuer.ACME == nil) == (issuer.Vault == nil) {
+			return fmt.Errorf("certManager issuer %s must set exactly one of acme or vault", issuer.Name)
+		}
+
+		if issuer.ACME != nil {
+			if err := validateIssuerServer(issuer.Name, issuer.ACME.Server); err != nil {
+				return err
+			}
+			if issuer.ACME.Email == "" {
+				return fmt.Errorf("certManager issuer %s acme email can't be empty", issuer.Name)
+			}
+			if issuer.ACME.Route53.Region == "" {
+				return fmt.Errorf("certManager issuer %s acme route53 region can't be empty", issuer.Name)
+			}
+		}
+
+		if issuer.Vault != nil {
+			if err := validateIssuerServer(issuer.Name, issuer.Vault.Server); err != nil {
+				return err
+			}
+			if issuer.Vault.Path == "" {
+				return fmt.Errorf("certManager issuer %s vault path can't be empty", issuer.Name)
+			}
+			if issuer.Vault.TokenSecretName == "" {
+				return fmt.Errorf("certManager issuer %s vault tokenSecretName can't be empty", issuer.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateIssuerServer(issuer, server string) error {
+	u, err := url.ParseRequestURI(server)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("invalid certManager issuer %s server %s, must be an http or https URL", issuer, server)
+	}
+	return nil
+}
+
+func validateMetalLB(clusterConfig *Cluster) error {
+	c := clusterConfig.Spec.MetalLB
+	if c == nil {
+		return nil
+	}
+
+	if clusterConfig.Spec.Packages != nil && clusterConfig.Spec.Packages.Disable {
+		return errors.New("metalLB requires curated packages, which are disabled")
+	}
+
+	if len(c.AddressPools) == 0 {
+		return errors.New("metalLB requires at least one address pool")
+	}
+
+	names := map[string]struct{}{}
+	for _, pool := range c.AddressPools {
+		if pool.Name == "" {
+			return errors.New("metalLB address pool name can't be empty")
+		}
+		if _, ok := names[pool.Name]; ok {
+			return fmt.Errorf("duplicate metalLB address pool %s", pool.Name)
+		}
+		names[pool.Name] = struct{}{}
+
+		if len(pool.Addresses) == 0 {
+			return fmt.Errorf("metalLB address pool %s must have at least one address", pool.Name)
+		}
+		for _, address := range pool.Addresses {
+			if !isValidMetalLBAddress(address) {
+				return fmt.Errorf("invalid metalLB address %s in pool %s, must be a CIDR or an IP range", address, pool.Name)
+			}
+		}
+	}
+
+	switch c.Mode {
+	case "", MetalLBL2Mode:
+		if len(c.BGPPeers) > 0 {
+			return errors.New("metalLB bgpPeers are only supported in bgp mode")
+		}
+	case MetalLBBGPMode:
+		if len(c.BGPPeers) == 0 {
+			return errors.New("metalLB bgp mode requires at least one bgp peer")
+		}
+		for _, peer := range c.BGPPeers {
+			if net.ParseIP(peer.PeerAddress) == nil {
+				return fmt.Errorf("invalid metalLB bgp peer address %s", peer.PeerAddress)
+			}
+			if peer.PeerASN == 0 || peer.MyASN == 0 {
+				return fmt.Errorf("metalLB bgp peer %s requires peerASN and myASN", peer.PeerAddress)
+			}
+			for _, asn := range []uint32{peer.PeerASN, peer.MyASN} {
+				if isReservedASN(asn) {
+					return fmt.Errorf("metalLB bgp peer %s uses reserved ASN %d", peer.PeerAddress, asn)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("invalid metalLB mode %s, must be one of l2, bgp", c.Mode)
+	}
+
+	return nil
+}
+
+// isReservedASN checks if an ASN can't be used in a BGP session, as defined in RFC 7607,
+// RFC 6793 (AS_TRANS) and RFC 7300 (last ASNs of the 2 and 4 bytes ranges).
+func isReservedASN(asn uint32) bool {
+	return asn == 0 || asn == 23456 || asn == 65535 || asn == 4294967295
+}
+
+func isValidMetalLBAddress(address string) bool {
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return true
+	}
+
+	start, end, found := strings.Cut(address, "-")
+	if !found {
+		return false
+	}
+	startIP, endIP := net.ParseIP(strings.TrimSpace(start)), net.ParseIP(strings.TrimSpace(end))
+	if startIP == nil || endIP == nil || (startIP.To4() == nil) != (endIP.To4() == nil) {
+		return false
+	}
+	return bytes.Compare(startIP.To16(), endIP.To16()) <= 0
+}
+
+const (
+	// AWSSecretsManagerSecretRefScheme is the scheme of the references to AWS Secrets Manager secrets,
+	// aws-secretsmanager://<secret name or ARN>[#<key>].
+	AWSSecretsManagerSecretRefScheme = "aws-secretsmanager"
+	// VaultSecretRefScheme is the scheme of the references to Vault secrets, vault://<secret path>#<key>.
+	VaultSecretRefScheme = "vault"
+	// SOPSSecretRefScheme is the scheme of the references to values in SOPS encrypted files, sops://<file path>#<key>.
+	SOPSSecretRefScheme = "sops"
+)
+
+// SecretRefSchemes are the schemes of the references to secrets in the supported external secret managers.
+var SecretRefSchemes = []string{AWSSecretsManagerSecretRefScheme, VaultSecretRefScheme, SOPSSecretRefScheme}
+
+func validateProviderCredentials(clusterConfig *Cluster) error {
+	names := map[string]struct{}{}
+	for _, c := range clusterConfig.Spec.ProviderCredentials {
+		if c.Name == "" {
+			return errors.New("providerCredentials name can't be empty")
+		}
+		if _, ok := names[c.Name]; ok {
+			return fmt.Errorf("duplicate providerCredentials %s", c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		if !IsSecretRef(c.ValueFrom) {
+			return fmt.Errorf("invalid providerCredentials valueFrom %q for %s, must start with one of %s://",
+				c.ValueFrom, c.Name, strings.Join(SecretRefSchemes, "://, "))
+		}
+	}
+	return nil
+}
+
+func validateComponentImageOverrides(clusterConfig *Cluster) error {
+	o := clusterConfig.Spec.ComponentImageOverrides
+	if o == nil {
+		return nil
+	}
+
+	if o.KubeProxy != "" && clusterConfig.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		return errors.New("componentImageOverrides kubeProxy can't be set when cilium kubeProxyReplacement is enabled")
+	}
+
+	mirror := ""
+	if m := clusterConfig.Spec.RegistryMirrorConfiguration; m != nil {
+		mirror = net.JoinHostPort(m.Endpoint, m.Port)
+	}
+
+	overrides := []struct {
+		field, image, name string
+	}{
+		{field: "coreDNS", image: o.CoreDNS, name: "coredns"},
+		{field: "kubeProxy", image: o.KubeProxy},
+		{field: "etcd", image: o.Etcd, name: "etcd"},
+	}
+	for _, override := range overrides {
+		if override.image == "" {
+			continue
+		}
+		if err := validateComponentImage(override.image, override.name, mirror); err != nil {
+			return fmt.Errorf("invalid componentImageOverrides %s: %v", override.field, err)
+		}
+	}
+	return nil
+}
+
+// validateComponentImage checks an image override has a registry and a tag, since kubeadm builds
+// the image references from a repository and a tag, and that it's pulled from the registry mirror
+// when the cluster has one, since nodes might not have access to any other registry.
+func validateComponentImage(image, name, mirror string) error {
+	ref, digest, hasDigest := strings.Cut(image, "@")
+	if hasDigest && !strings.HasPrefix(digest, "sha256:") {
+		return fmt.Errorf("image %s digest must be a sha256 digest", image)
+	}
+
+	lastSlash := strings.LastIndex(ref, "/")
+	if lastSlash == -1 {
+		return fmt.Errorf("image %s must include the registry", image)
+	}
+
+	imageName, tag, _ := strings.Cut(ref[lastSlash+1:], ":")
+	if tag == "" {
+		return fmt.Errorf("image %s must include a tag", image)
+	}
+	if name != "" && imageName != name {
+		return fmt.Errorf("image %s name must be %s", image, name)
+	}
+
+	if mirror != "" && !strings.HasPrefix(image, mirror+"/") {
+		return fmt.Errorf("image %s must be pulled from the registry mirror %s", image, mirror)
+	}
+	return nil
+}
+
+func validateTemplateOverrides(clusterConfig *Cluster) error {
+	for i, o := range clusterConfig.Spec.TemplateOverrides {
+		if o.Kind == "" {
+			return fmt.Errorf("templateOverrides[%d] kind can't be empty", i)
+		}
+		if _, err := path.Match(o.Name, ""); err != nil {
+			return fmt.Errorf("invalid templateOverrides[%d] name %q: %v", i, o.Name, err)
+		}
+		patch, err := o.PatchObject()
+		if err != nil {
+			return fmt.Errorf("invalid templateOverrides[%d]: %v", i, err)
+		}
+		if len(patch) == 0 {
+			return fmt.Errorf("templateOverrides[%d] patch for %s can't be empty", i, o.Kind)
+		}
+	}
+	return nil
+}
+
+func validateFIPS(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.FIPS {
+		return nil
+	}
+
+	if clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+		return errors.New("fips is not supported for the docker provider")
+	}
+	return nil
+}
+
+func validateTLSConfiguration(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.TLSConfiguration
+	if config == nil {
+		return nil
+	}
+
+	if config.MinVersion != "" && config.MinVersion != TLSVersion12 && config.MinVersion != TLSVersion13 {
+		return fmt.Errorf("tlsConfiguration minVersion %s is not supported, must be %s or %s", config.MinVersion, TLSVersion12, TLSVersion13)
+	}
+
+	if len(config.CipherSuites) > 0 && config.MinVersion == TLSVersion13 {
+		return fmt.Errorf("tlsConfiguration cipherSuites can't be set when minVersion is %s", TLSVersion13)
+	}
+
+	tls12CipherSuites := map[string]bool{}
+	for _, suite := range tls.CipherSuites() {
+		if slices.Contains(suite.SupportedVersions, tls.VersionTLS12) {
+			tls12CipherSuites[suite.Name] = true
+		}
+	}
+	insecureCipherSuites := map[string]bool{}
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecureCipherSuites[suite.Name] = true
+	}
+
+	for _, name := range config.CipherSuites {
+		switch {
+		case insecureCipherSuites[name]:
+			return fmt.Errorf("tlsConfiguration cipher suite %s is insecure", name)
+		case !tls12CipherSuites[name]:
+			return fmt.Errorf("tlsConfiguration cipher suite %s is not a supported TLS 1.2 cipher suite", name)
+		case clusterConfig.Spec.FIPS && !crypto.IsFIPSCipherSuite(name):
+			return fmt.Errorf("tlsConfiguration cipher suite %s is not FIPS approved", name)
+		}
+	}
+
+	return nil
+}
+
+func validateHelmPostRenderer(clusterConfig *Cluster) error {
+	renderer := clusterConfig.Spec.HelmPostRenderer
+	if renderer == nil {
+		return nil
+	}
+
+	if renderer.BinaryPath == "" {
+		return errors.New("helmPostRenderer binaryPath is required")
+	}
+
+	if !filepath.IsAbs(renderer.BinaryPath) {
+		return fmt.Errorf("helmPostRenderer binaryPath %s must be an absolute path", renderer.BinaryPath)
+	}
+
+	return nil
+}
+
+func validateScale(clusterConfig *Cluster) error {
+	switch clusterConfig.Spec.Scale {
+	case "", ClusterScaleStandard, ClusterScaleLarge:
+		return nil
+	}
+	return fmt.Errorf("scale %s is not supported, must be %s or %s", clusterConfig.Spec.Scale, ClusterScaleStandard, ClusterScaleLarge)
+}
+
+func validatePinImageDigests(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.PinImageDigests {
+		return nil
+	}
+
+	// The FIPS variants of the images are released without digests in the bundle.
+	if clusterConfig.Spec.FIPS {
+		return errors.New("pinImageDigests is not supported in FIPS mode")
+	}
+
+	o := clusterConfig.Spec.ComponentImageOverrides
+	if o == nil {
+		return nil
+	}
+	overrides := []struct {
+		field, image string
+	}{
+		{field: "coreDNS", image: o.CoreDNS},
+		{field: "kubeProxy", image: o.KubeProxy},
+		{field: "etcd", image: o.Etcd},
+	}
+	for _, override := range overrides {
+		if override.image != "" && !strings.Contains(override.image, "@") {
+			return fmt.Errorf("componentImageOverrides %s image %s must be pinned to a digest when pinImageDigests is enabled", override.field, override.image)
+		}
+	}
+	return nil
+}
+
+func validateClusterResourceSets(clusterConfig *Cluster) error {
+	sets := clusterConfig.Spec.ClusterResourceSets
+	if len(sets) == 0 {
+		return nil
+	}
+
+	if clusterConfig.IsManaged() {
+		return errors.New("clusterResourceSets can only be set in management clusters")
+	}
+
+	names := make(map[string]struct{}, len(sets))
+	for _, set := range sets {
+		if errs := utilvalidation.IsDNS1123Label(set.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid clusterResourceSets name %q: %s", set.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := names[set.Name]; ok {
+			return fmt.Errorf("clusterResourceSets %s is defined more than once", set.Name)
+		}
+		names[set.Name] = struct{}{}
+
+		if len(set.ConfigMaps) == 0 {
+			return fmt.Errorf("clusterResourceSets %s must reference at least one configMap", set.Name)
+		}
+		for _, cm := range set.ConfigMaps {
+			if errs := utilvalidation.IsDNS1123Subdomain(cm); len(errs) > 0 {
+				return fmt.Errorf("invalid configMap name %q in clusterResourceSets %s: %s", cm, set.Name, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsSecretRef returns true if value is a reference to a secret in one of the SecretRefSchemes.
+func IsSecretRef(value string) bool {
+	for _, scheme := range SecretRefSchemes {
+		if strings.HasPrefix(value, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
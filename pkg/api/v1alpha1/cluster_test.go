@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
@@ -760,6 +761,12 @@ func TestGetAndValidateClusterConfig(t *testing.T) {
 			wantCluster: nil,
 			wantErr:     true,
 		},
+		{
+			testName:    "with worker node group kubernetes version newer than the cluster",
+			fileName:    "testdata/cluster_invalid_worker_kubernetes_version_newer.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
 		{
 			testName:    "with GitOps branch invalid",
 			fileName:    "testdata/cluster_1_19_gitops_invalid_branch.yaml",
@@ -2205,6 +2212,17 @@ func TestClusterNetworkEquals(t *testing.T) {
 				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{PolicyEnforcementMode: "default"}},
 			},
 		},
+		{
+			name: "previous != new, diff mtu",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+				MTU:       1400,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2435,6 +2453,56 @@ func TestValidateNetworking(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "mtu invalid",
+			wantErr: fmt.Errorf("cluster network mtu 9216 is invalid, it must be between 1280 and 9000"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+						MTU:       9216,
+					},
+				},
+			},
+		},
+		{
+			name:    "mtu valid",
+			wantErr: nil,
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+						MTU:       1400,
+					},
+				},
+			},
+		},
 		{
 			name:    "both pods CIDR block and service CIDR block do not conflict with control plane endpoint",
 			wantErr: nil,
@@ -2837,6 +2905,19 @@ func TestValidateCNIConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CiliumSkipUpgradeWithKubeProxyReplacement",
+			wantErr: fmt.Errorf("validating cniConfig: when using skipUpgrades for cilium all " +
+				"other fields must be empty"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						SkipUpgrade:          ptr.Bool(true),
+						KubeProxyReplacement: true,
+					},
+				},
+			},
+		},
 		{
 			name: "CiliumSkipUpgradeExplicitFalseWithOtherFields",
 			clusterNetwork: &ClusterNetwork{
@@ -2987,6 +3068,187 @@ func TestValidateMirrorConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid registry hosts",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL:          "https://harbor.test/v2/dockerhub",
+										Capabilities: []RegistryHostCapability{"pull"},
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "registry hosts on unsupported provider",
+			wantErr: "RegistryMirrorConfiguration.Hosts is not supported for provider TinkerbellDatacenterConfig",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL: "https://harbor.test",
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: TinkerbellDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "empty registry in hosts",
+			wantErr: "registry can't be set to empty in RegistryMirrorConfiguration.Hosts",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "",
+								Hosts: []RegistryHost{
+									{
+										URL: "https://harbor.test",
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "duplicated registry in hosts",
+			wantErr: "registry docker.io is duplicated in RegistryMirrorConfiguration.Hosts",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL: "https://harbor.test",
+									},
+								},
+							},
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL: "https://harbor.test",
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: SnowDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "no hosts for registry",
+			wantErr: "no hosts specified for registry docker.io",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts:    []RegistryHost{},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "host url without scheme",
+			wantErr: "invalid host url harbor.test for registry docker.io",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL: "harbor.test",
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "unsupported host capability",
+			wantErr: "unsupported capability delete for host https://harbor.test",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Hosts: []RegistryHostsConfiguration{
+							{
+								Registry: "docker.io",
+								Hosts: []RegistryHost{
+									{
+										URL:          "https://harbor.test",
+										Capabilities: []RegistryHostCapability{"delete"},
+									},
+								},
+							},
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3500,160 +3762,1740 @@ func TestValidateEksaVersion(t *testing.T) {
 	}
 }
 
-func TestGetClusterDefaultKubernetesVersion(t *testing.T) {
-	g := NewWithT(t)
-	g.Expect(GetClusterDefaultKubernetesVersion()).To(Equal(Kube127))
-}
-
-func TestClusterWorkerNodeConfigCount(t *testing.T) {
+func TestValidateClusterLabels(t *testing.T) {
 	tests := []struct {
-		name    string
-		cluster *Cluster
-		want    []WorkerNodeGroupConfiguration
+		name           string
+		wantErr        string
+		datacenterKind string
+		labels         map[string]string
 	}{
 		{
-			name: "with worker node config count",
-			cluster: &Cluster{
-				Spec: ClusterSpec{},
-			},
-			want: []WorkerNodeGroupConfiguration{
-				{
-					Name:                     "",
-					Count:                    ptr.Int(5),
-					AutoScalingConfiguration: nil,
-					MachineGroupRef:          nil,
-					Taints:                   nil,
-					Labels:                   nil,
-				},
-			},
+			name:           "no labels",
+			wantErr:        "",
+			datacenterKind: VSphereDatacenterKind,
+			labels:         nil,
+		},
+		{
+			name:           "valid labels",
+			wantErr:        "",
+			datacenterKind: NutanixDatacenterKind,
+			labels:         map[string]string{"cost-center": "1234", "team": "platform"},
+		},
+		{
+			name:           "invalid label value",
+			wantErr:        "clusterLabels not valid",
+			datacenterKind: NutanixDatacenterKind,
+			labels:         map[string]string{"team": "platform team"},
+		},
+		{
+			name:           "unsupported provider",
+			wantErr:        "clusterLabels is not supported for VSphereDatacenterConfig",
+			datacenterKind: VSphereDatacenterKind,
+			labels:         map[string]string{"team": "platform"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(5))
 			g := NewWithT(t)
-			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+			config := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					ClusterLabels: tt.labels,
+				},
+			}
+			err := validateClusterLabels(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
 		})
 	}
 }
 
-func TestClusterCPUpgradeRolloutStrategyNil(t *testing.T) {
+func TestValidateDedicatedPlacement(t *testing.T) {
 	tests := []struct {
-		name    string
-		cluster *Cluster
-		want    ControlPlaneConfiguration
+		name           string
+		wantErr        string
+		datacenterKind string
+		dedicated      bool
 	}{
 		{
-			name: "with control plane rollout upgrade strategy nil",
-			cluster: &Cluster{
-				Spec: ClusterSpec{},
-			},
-			want: ControlPlaneConfiguration{
-				Endpoint:               nil,
-				Count:                  1,
-				MachineGroupRef:        nil,
-				Taints:                 nil,
-				Labels:                 nil,
-				UpgradeRolloutStrategy: nil,
-			},
+			name:           "not dedicated",
+			datacenterKind: DockerDatacenterKind,
+			dedicated:      false,
+		},
+		{
+			name:           "vsphere",
+			datacenterKind: VSphereDatacenterKind,
+			dedicated:      true,
+		},
+		{
+			name:           "nutanix",
+			datacenterKind: NutanixDatacenterKind,
+			dedicated:      true,
+		},
+		{
+			name:           "unsupported provider",
+			wantErr:        "controlPlaneConfiguration.dedicatedPlacement is not supported for DockerDatacenterConfig",
+			datacenterKind: DockerDatacenterKind,
+			dedicated:      true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cg := NewClusterGenerate("test-cluster", ControlPlaneConfigCount(1))
 			g := NewWithT(t)
-			g.Expect(cg.Spec.ControlPlaneConfiguration).To(Equal(tt.want))
-		})
-	}
+			config := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef:             Ref{Kind: tt.datacenterKind},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{DedicatedPlacement: tt.dedicated},
+				},
+			}
+			err := validateDedicatedPlacement(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateCapacityType(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantErr        string
+		datacenterKind string
+		capacityType   CapacityType
+	}{
+		{
+			name:           "no capacity type",
+			wantErr:        "",
+			datacenterKind: VSphereDatacenterKind,
+			capacityType:   "",
+		},
+		{
+			name:           "on demand",
+			wantErr:        "",
+			datacenterKind: VSphereDatacenterKind,
+			capacityType:   OnDemandCapacity,
+		},
+		{
+			name:           "spot in snow",
+			wantErr:        "",
+			datacenterKind: SnowDatacenterKind,
+			capacityType:   SpotCapacity,
+		},
+		{
+			name:           "spot in cloudstack",
+			wantErr:        "",
+			datacenterKind: CloudStackDatacenterKind,
+			capacityType:   SpotCapacity,
+		},
+		{
+			name:           "spot unsupported provider",
+			wantErr:        "spot capacity is not supported for VSphereDatacenterConfig",
+			datacenterKind: VSphereDatacenterKind,
+			capacityType:   SpotCapacity,
+		},
+		{
+			name:           "invalid capacity type",
+			wantErr:        "capacityType preemptible is not supported",
+			datacenterKind: SnowDatacenterKind,
+			capacityType:   "preemptible",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+				},
+			}
+			err := validateCapacityType(config, &WorkerNodeGroupConfiguration{CapacityType: tt.capacityType})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantErr       string
+		workerVersion KubernetesVersion
+	}{
+		{
+			name:          "no worker version",
+			wantErr:       "",
+			workerVersion: "",
+		},
+		{
+			name:          "same version",
+			wantErr:       "",
+			workerVersion: Kube127,
+		},
+		{
+			name:          "two minor versions older",
+			wantErr:       "",
+			workerVersion: Kube125,
+		},
+		{
+			name:          "three minor versions older",
+			wantErr:       "kubernetesVersion 1.24 must not be newer than the cluster kubernetesVersion 1.27 or more than 2 minor versions older",
+			workerVersion: Kube124,
+		},
+		{
+			name:          "newer than the control plane",
+			wantErr:       "kubernetesVersion 1.28 must not be newer than the cluster kubernetesVersion 1.27",
+			workerVersion: Kube128,
+		},
+		{
+			name:          "invalid version",
+			wantErr:       "could not parse version",
+			workerVersion: "latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion: Kube127,
+				},
+			}
+			worker := &WorkerNodeGroupConfiguration{}
+			if tt.workerVersion != "" {
+				worker.KubernetesVersion = &tt.workerVersion
+			}
+			err := validateWorkerNodeGroupKubernetesVersion(config, worker)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateReplacementStrategy(t *testing.T) {
+	tests := []struct {
+		name                   string
+		wantErr                string
+		datacenterKind         string
+		clusterName            string
+		replacementStrategy    ReplacementStrategy
+		upgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy
+	}{
+		{
+			name:                "no replacement strategy",
+			wantErr:             "",
+			datacenterKind:      VSphereDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: "",
+		},
+		{
+			name:                "rolling",
+			wantErr:             "",
+			datacenterKind:      VSphereDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: RollingReplacement,
+		},
+		{
+			name:                "blue green",
+			wantErr:             "",
+			datacenterKind:      VSphereDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: BlueGreenReplacement,
+		},
+		{
+			name:                "blue green in tinkerbell",
+			wantErr:             "blueGreen replacement is not supported for TinkerbellDatacenterConfig",
+			datacenterKind:      TinkerbellDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: BlueGreenReplacement,
+		},
+		{
+			name:                "blue green with upgrade rollout strategy",
+			wantErr:             "upgradeRolloutStrategy can't be set with blueGreen replacement",
+			datacenterKind:      CloudStackDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: BlueGreenReplacement,
+			upgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{
+				Type: "RollingUpdate",
+			},
+		},
+		{
+			name:                "blue green with long names",
+			wantErr:             "is longer than 63 characters",
+			datacenterKind:      VSphereDatacenterKind,
+			clusterName:         strings.Repeat("a", 55),
+			replacementStrategy: BlueGreenReplacement,
+		},
+		{
+			name:                "invalid replacement strategy",
+			wantErr:             "replacementStrategy recreate is not supported",
+			datacenterKind:      VSphereDatacenterKind,
+			clusterName:         "my-cluster",
+			replacementStrategy: "recreate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+				},
+			}
+			err := validateReplacementStrategy(config, &WorkerNodeGroupConfiguration{
+				Name:                   "md-0",
+				ReplacementStrategy:    tt.replacementStrategy,
+				UpgradeRolloutStrategy: tt.upgradeRolloutStrategy,
+			})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateClusterTTL(t *testing.T) {
+	tests := []struct {
+		name              string
+		wantErr           string
+		managementCluster string
+		ttl               *metav1.Duration
+	}{
+		{
+			name:              "no ttl",
+			wantErr:           "",
+			managementCluster: "my-cluster",
+			ttl:               nil,
+		},
+		{
+			name:              "workload cluster",
+			wantErr:           "",
+			managementCluster: "mgmt",
+			ttl:               &metav1.Duration{Duration: 8 * time.Hour},
+		},
+		{
+			name:              "management cluster",
+			wantErr:           "ttl is only supported for workload clusters",
+			managementCluster: "my-cluster",
+			ttl:               &metav1.Duration{Duration: 8 * time.Hour},
+		},
+		{
+			name:              "negative ttl",
+			wantErr:           "ttl must be positive, got -1h0m0s",
+			managementCluster: "mgmt",
+			ttl:               &metav1.Duration{Duration: -time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: ClusterSpec{
+					ManagementCluster: ManagementCluster{Name: tt.managementCluster},
+					TTL:               tt.ttl,
+				},
+			}
+			err := validateClusterTTL(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateManagementControllers(t *testing.T) {
+	tests := []struct {
+		name              string
+		wantErr           string
+		managementCluster string
+		datacenterKind    string
+		config            *ManagementControllersConfiguration
+	}{
+		{
+			name:              "no config",
+			managementCluster: "mgmt",
+		},
+		{
+			name:              "management cluster",
+			managementCluster: "my-cluster",
+			config:            &ManagementControllersConfiguration{KubeAPIQPS: 50, KubeAPIBurst: 100, Concurrency: 20},
+		},
+		{
+			name:              "workload cluster",
+			wantErr:           "managementControllers is only supported for management clusters",
+			managementCluster: "mgmt",
+			config:            &ManagementControllersConfiguration{Concurrency: 20},
+		},
+		{
+			name:              "negative concurrency",
+			wantErr:           "managementControllers kubeAPIQPS, kubeAPIBurst, concurrency and machineConcurrency can't be negative",
+			managementCluster: "my-cluster",
+			config:            &ManagementControllersConfiguration{Concurrency: -1},
+		},
+		{
+			name:              "machine concurrency",
+			managementCluster: "my-cluster",
+			datacenterKind:    VSphereDatacenterKind,
+			config:            &ManagementControllersConfiguration{MachineConcurrency: 5},
+		},
+		{
+			name:              "negative machine concurrency",
+			wantErr:           "managementControllers kubeAPIQPS, kubeAPIBurst, concurrency and machineConcurrency can't be negative",
+			managementCluster: "my-cluster",
+			datacenterKind:    NutanixDatacenterKind,
+			config:            &ManagementControllersConfiguration{MachineConcurrency: -1},
+		},
+		{
+			name:              "machine concurrency unsupported provider",
+			wantErr:           "managementControllers machineConcurrency is not supported for TinkerbellDatacenterConfig",
+			managementCluster: "my-cluster",
+			datacenterKind:    TinkerbellDatacenterKind,
+			config:            &ManagementControllersConfiguration{MachineConcurrency: 5},
+		},
+		{
+			name:              "burst lower than qps",
+			wantErr:           "managementControllers kubeAPIBurst (10) can't be lower than kubeAPIQPS (50)",
+			managementCluster: "my-cluster",
+			config:            &ManagementControllersConfiguration{KubeAPIQPS: 50, KubeAPIBurst: 10},
+		},
+		{
+			name:              "replicas and leader election",
+			managementCluster: "my-cluster",
+			config: &ManagementControllersConfiguration{
+				Replicas: 3,
+				LeaderElection: &LeaderElectionConfiguration{
+					LeaseDuration: &metav1.Duration{Duration: 60 * time.Second},
+					RenewDeadline: &metav1.Duration{Duration: 40 * time.Second},
+				},
+			},
+		},
+		{
+			name:              "negative replicas",
+			wantErr:           "managementControllers replicas can't be negative",
+			managementCluster: "my-cluster",
+			config:            &ManagementControllersConfiguration{Replicas: -1},
+		},
+		{
+			name:              "negative lease duration",
+			wantErr:           "managementControllers leaderElection leaseDuration, renewDeadline and retryPeriod must be positive",
+			managementCluster: "my-cluster",
+			config: &ManagementControllersConfiguration{
+				LeaderElection: &LeaderElectionConfiguration{LeaseDuration: &metav1.Duration{Duration: -time.Second}},
+			},
+		},
+		{
+			name:              "lease duration lower than default renew deadline",
+			wantErr:           "managementControllers leaderElection leaseDuration (5s) must be greater than renewDeadline (10s)",
+			managementCluster: "my-cluster",
+			config: &ManagementControllersConfiguration{
+				LeaderElection: &LeaderElectionConfiguration{LeaseDuration: &metav1.Duration{Duration: 5 * time.Second}},
+			},
+		},
+		{
+			name:              "renew deadline too close to retry period",
+			wantErr:           "managementControllers leaderElection renewDeadline (10s) must be greater than 1.2 times retryPeriod (9s)",
+			managementCluster: "my-cluster",
+			config: &ManagementControllersConfiguration{
+				LeaderElection: &LeaderElectionConfiguration{RetryPeriod: &metav1.Duration{Duration: 9 * time.Second}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: ClusterSpec{
+					ManagementCluster:     ManagementCluster{Name: tt.managementCluster},
+					ManagementControllers: tt.config,
+					DatacenterRef:         Ref{Kind: tt.datacenterKind},
+				},
+			}
+			err := validateManagementControllers(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateImageWarmCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		config  *ImageWarmCacheConfiguration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "additional images",
+			config: &ImageWarmCacheConfiguration{AdditionalImages: []string{"public.ecr.aws/app/cache:v1"}},
+		},
+		{
+			name:    "empty additional image",
+			wantErr: "imageWarmCache additionalImages can't contain empty images",
+			config:  &ImageWarmCacheConfiguration{AdditionalImages: []string{""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					ImageWarmCache: tt.config,
+				},
+			}
+			err := validateImageWarmCache(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateAccessEntries(t *testing.T) {
+	awsIamRefs := []Ref{{Kind: AWSIamConfigKind, Name: "aws-iam"}}
+	tests := []struct {
+		name         string
+		wantErr      string
+		entries      []AccessEntry
+		identityRefs []Ref
+	}{
+		{
+			name: "no entries",
+		},
+		{
+			name:    "oidc group",
+			entries: []AccessEntry{{Group: "oidc:platform", ClusterRole: "cluster-admin"}},
+		},
+		{
+			name:         "iam roles",
+			entries:      []AccessEntry{{Group: "developers", ClusterRole: "edit", IAMRoleARNs: []string{"arn:aws:iam::123456789012:role/dev"}}},
+			identityRefs: awsIamRefs,
+		},
+		{
+			name:    "empty group",
+			wantErr: "accessEntries group can't be empty",
+			entries: []AccessEntry{{ClusterRole: "view"}},
+		},
+		{
+			name:    "empty cluster role",
+			wantErr: "accessEntries clusterRole for group developers can't be empty",
+			entries: []AccessEntry{{Group: "developers"}},
+		},
+		{
+			name:    "system group",
+			wantErr: "accessEntries group system:masters can't use the reserved system: prefix",
+			entries: []AccessEntry{{Group: "system:masters", ClusterRole: "cluster-admin"}},
+		},
+		{
+			name:    "iam roles without aws iam config",
+			wantErr: "accessEntries iamRoleARNs for group developers require an AWSIamConfig identity provider",
+			entries: []AccessEntry{{Group: "developers", ClusterRole: "edit", IAMRoleARNs: []string{"arn:aws:iam::123456789012:role/dev"}}},
+		},
+		{
+			name:         "invalid iam role",
+			wantErr:      "accessEntries iamRoleARN dev for group developers is not a valid ARN",
+			entries:      []AccessEntry{{Group: "developers", ClusterRole: "edit", IAMRoleARNs: []string{"dev"}}},
+			identityRefs: awsIamRefs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					AccessEntries:        tt.entries,
+					IdentityProviderRefs: tt.identityRefs,
+				},
+			}
+			err := validateAccessEntries(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidatePodSecurityAdmission(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantErr     string
+		kubeVersion KubernetesVersion
+		config      *PodSecurityAdmissionConfiguration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:        "valid config",
+			kubeVersion: Kube127,
+			config: &PodSecurityAdmissionConfiguration{
+				Enforce:          PodSecurityBaseline,
+				Audit:            PodSecurityRestricted,
+				Warn:             PodSecurityRestricted,
+				Version:          "v1.27",
+				ExemptNamespaces: []string{"monitoring"},
+			},
+		},
+		{
+			name:        "defaults",
+			kubeVersion: Kube123,
+			config:      &PodSecurityAdmissionConfiguration{},
+		},
+		{
+			name:        "kubernetes version too old",
+			kubeVersion: Kube122,
+			config:      &PodSecurityAdmissionConfiguration{Enforce: PodSecurityBaseline},
+			wantErr:     "podSecurityAdmission requires kubernetes version 1.23 or later",
+		},
+		{
+			name:        "invalid level",
+			kubeVersion: Kube127,
+			config:      &PodSecurityAdmissionConfiguration{Warn: "strict"},
+			wantErr:     "invalid podSecurityAdmission warn level strict, must be one of privileged, baseline, restricted",
+		},
+		{
+			name:        "invalid version",
+			kubeVersion: Kube127,
+			config:      &PodSecurityAdmissionConfiguration{Version: "1.27"},
+			wantErr:     "invalid podSecurityAdmission version 1.27, must be latest or v1.<minor>",
+		},
+		{
+			name:        "empty exempt namespace",
+			kubeVersion: Kube127,
+			config:      &PodSecurityAdmissionConfiguration{ExemptNamespaces: []string{""}},
+			wantErr:     "podSecurityAdmission exemptNamespaces can't contain empty namespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion:    tt.kubeVersion,
+					PodSecurityAdmission: tt.config,
+				},
+			}
+			err := validatePodSecurityAdmission(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterExpiresAt(t *testing.T) {
+	g := NewWithT(t)
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+
+	_, ok := cluster.ExpiresAt()
+	g.Expect(ok).To(BeFalse())
+
+	cluster.Spec.TTL = &metav1.Duration{Duration: 2 * time.Hour}
+	expiresAt, ok := cluster.ExpiresAt()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(expiresAt).To(Equal(created.Add(2 * time.Hour)))
+}
+
+func TestGetClusterDefaultKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(GetClusterDefaultKubernetesVersion()).To(Equal(Kube127))
+}
+
+func TestClusterWorkerNodeConfigCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    []WorkerNodeGroupConfiguration
+	}{
+		{
+			name: "with worker node config count",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+			want: []WorkerNodeGroupConfiguration{
+				{
+					Name:                     "",
+					Count:                    ptr.Int(5),
+					AutoScalingConfiguration: nil,
+					MachineGroupRef:          nil,
+					Taints:                   nil,
+					Labels:                   nil,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(5))
+			g := NewWithT(t)
+			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterCPUpgradeRolloutStrategyNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    ControlPlaneConfiguration
+	}{
+		{
+			name: "with control plane rollout upgrade strategy nil",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+			want: ControlPlaneConfiguration{
+				Endpoint:               nil,
+				Count:                  1,
+				MachineGroupRef:        nil,
+				Taints:                 nil,
+				Labels:                 nil,
+				UpgradeRolloutStrategy: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewClusterGenerate("test-cluster", ControlPlaneConfigCount(1))
+			g := NewWithT(t)
+			g.Expect(cg.Spec.ControlPlaneConfiguration).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterCPUpgradeRolloutStrategyNotNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    ControlPlaneConfiguration
+	}{
+		{
+			name: "with control plane rollout upgrade strategy non-nil",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+			want: ControlPlaneConfiguration{
+				Endpoint:               nil,
+				Count:                  1,
+				MachineGroupRef:        nil,
+				Taints:                 nil,
+				Labels:                 nil,
+				UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "RollingUpdate", RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewClusterGenerate("test-cluster", ControlPlaneConfigCount(1), WithCPUpgradeRolloutStrategy(5, 2))
+			g := NewWithT(t)
+			g.Expect(cg.Spec.ControlPlaneConfiguration).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterMDUpgradeRolloutStrategyNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    []WorkerNodeGroupConfiguration
+	}{
+		{
+			name: "with md rollout upgrade strategy knobs not specified",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+			want: []WorkerNodeGroupConfiguration{
+				{
+					Count:           ptr.Int(1),
+					MachineGroupRef: nil,
+					Taints:          nil,
+					Labels:          nil,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(1))
+			g := NewWithT(t)
+			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterMDUpgradeRolloutStrategyNotNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    []WorkerNodeGroupConfiguration
+	}{
+		{
+			name: "with md rollout upgrade strategy",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+			want: []WorkerNodeGroupConfiguration{
+				{
+					Count:                  ptr.Int(1),
+					MachineGroupRef:        nil,
+					Taints:                 nil,
+					Labels:                 nil,
+					UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "RollingUpdate", RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 5, MaxUnavailable: 2}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(1), WithWorkerMachineUpgradeRolloutStrategy(5, 2))
+			g := NewWithT(t)
+			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidatePolicyEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		config  *PolicyEngineConfiguration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name: "valid config",
+			config: &PolicyEngineConfiguration{
+				Engine:            KyvernoPolicyEngine,
+				Mode:              PolicyEngineAudit,
+				AllowedRegistries: []string{"registry.example.com"},
+			},
+		},
+		{
+			name:    "unsupported engine",
+			wantErr: "unsupported policyEngine engine gatekeeper, must be kyverno",
+			config:  &PolicyEngineConfiguration{Engine: "gatekeeper"},
+		},
+		{
+			name:    "invalid mode",
+			wantErr: "invalid policyEngine mode warn, must be one of enforce, audit",
+			config:  &PolicyEngineConfiguration{Engine: KyvernoPolicyEngine, Mode: "warn"},
+		},
+		{
+			name:    "empty allowed registry",
+			wantErr: "policyEngine allowedRegistries can't contain empty registries",
+			config:  &PolicyEngineConfiguration{Engine: KyvernoPolicyEngine, AllowedRegistries: []string{""}},
+		},
+		{
+			name:    "allowed registry with scheme",
+			wantErr: "invalid policyEngine allowed registry https://registry.example.com, must not include a scheme",
+			config:  &PolicyEngineConfiguration{Engine: KyvernoPolicyEngine, AllowedRegistries: []string{"https://registry.example.com"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					PolicyEngine: tt.config,
+				},
+			}
+			err := validatePolicyEngine(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateCertManager(t *testing.T) {
+	acme := &ACMEIssuer{
+		Server:  "https://acme-v02.api.letsencrypt.org/directory",
+		Email:   "admin@example.com",
+		Route53: Route53DNS01Solver{Region: "us-west-2"},
+	}
+	vault := &VaultIssuer{
+		Server:          "https://vault.example.com:8200",
+		Path:            "pki/sign/example-dot-com",
+		TokenSecretName: "vault-token",
+	}
+	tests := []struct {
+		name    string
+		wantErr string
+		config  *CertManagerConfiguration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "no issuers",
+			config: &CertManagerConfiguration{},
+		},
+		{
+			name: "valid issuers",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "letsencrypt", ACME: acme},
+				{Name: "vault", Vault: vault},
+			}},
+		},
+		{
+			name:    "empty name",
+			wantErr: "certManager issuer name can't be empty",
+			config:  &CertManagerConfiguration{Issuers: []CertManagerIssuer{{ACME: acme}}},
+		},
+		{
+			name:    "duplicate name",
+			wantErr: "duplicate certManager issuer letsencrypt",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "letsencrypt", ACME: acme},
+				{Name: "letsencrypt", Vault: vault},
+			}},
+		},
+		{
+			name:    "no issuer type",
+			wantErr: "certManager issuer letsencrypt must set exactly one of acme or vault",
+			config:  &CertManagerConfiguration{Issuers: []CertManagerIssuer{{Name: "letsencrypt"}}},
+		},
+		{
+			name:    "both issuer types",
+			wantErr: "certManager issuer letsencrypt must set exactly one of acme or vault",
+			config:  &CertManagerConfiguration{Issuers: []CertManagerIssuer{{Name: "letsencrypt", ACME: acme, Vault: vault}}},
+		},
+		{
+			name:    "invalid acme server",
+			wantErr: "invalid certManager issuer letsencrypt server acme.example.com, must be an http or https URL",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "letsencrypt", ACME: &ACMEIssuer{Server: "acme.example.com", Email: "admin@example.com", Route53: Route53DNS01Solver{Region: "us-west-2"}}},
+			}},
+		},
+		{
+			name:    "empty acme email",
+			wantErr: "certManager issuer letsencrypt acme email can't be empty",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "letsencrypt", ACME: &ACMEIssuer{Server: acme.Server, Route53: acme.Route53}},
+			}},
+		},
+		{
+			name:    "empty route53 region",
+			wantErr: "certManager issuer letsencrypt acme route53 region can't be empty",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "letsencrypt", ACME: &ACMEIssuer{Server: acme.Server, Email: acme.Email}},
+			}},
+		},
+		{
+			name:    "empty vault path",
+			wantErr: "certManager issuer vault vault path can't be empty",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "vault", Vault: &VaultIssuer{Server: vault.Server, TokenSecretName: vault.TokenSecretName}},
+			}},
+		},
+		{
+			name:    "empty vault token secret",
+			wantErr: "certManager issuer vault vault tokenSecretName can't be empty",
+			config: &CertManagerConfiguration{Issuers: []CertManagerIssuer{
+				{Name: "vault", Vault: &VaultIssuer{Server: vault.Server, Path: vault.Path}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					CertManager: tt.config,
+				},
+			}
+			err := validateCertManager(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateMetalLB(t *testing.T) {
+	pools := []MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.0/28", "10.0.1.10-10.0.1.20"}}}
+	peers := []MetalLBBGPPeer{{PeerAddress: "10.0.0.1", PeerASN: 64501, MyASN: 64500}}
+	tests := []struct {
+		name     string
+		wantErr  string
+		packages *PackageConfiguration
+		config   *MetalLBConfiguration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "l2 mode",
+			config: &MetalLBConfiguration{AddressPools: pools},
+		},
+		{
+			name:   "bgp mode",
+			config: &MetalLBConfiguration{Mode: MetalLBBGPMode, AddressPools: pools, BGPPeers: peers},
+		},
+		{
+			name:     "packages disabled",
+			wantErr:  "metalLB requires curated packages, which are disabled",
+			packages: &PackageConfiguration{Disable: true},
+			config:   &MetalLBConfiguration{AddressPools: pools},
+		},
+		{
+			name:    "no pools",
+			wantErr: "metalLB requires at least one address pool",
+			config:  &MetalLBConfiguration{},
+		},
+		{
+			name:    "empty pool name",
+			wantErr: "metalLB address pool name can't be empty",
+			config:  &MetalLBConfiguration{AddressPools: []MetalLBAddressPool{{Addresses: []string{"10.0.0.0/28"}}}},
+		},
+		{
+			name:    "duplicate pool",
+			wantErr: "duplicate metalLB address pool default",
+			config:  &MetalLBConfiguration{AddressPools: append(pools, pools...)},
+		},
+		{
+			name:    "pool without addresses",
+			wantErr: "metalLB address pool default must have at least one address",
+			config:  &MetalLBConfiguration{AddressPools: []MetalLBAddressPool{{Name: "default"}}},
+		},
+		{
+			name:    "invalid address",
+			wantErr: "invalid metalLB address 10.0.0.1 in pool default, must be a CIDR or an IP range",
+			config:  &MetalLBConfiguration{AddressPools: []MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.1"}}}},
+		},
+		{
+			name:    "inverted range",
+			wantErr: "invalid metalLB address 10.0.0.20-10.0.0.10 in pool default, must be a CIDR or an IP range",
+			config:  &MetalLBConfiguration{AddressPools: []MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.20-10.0.0.10"}}}},
+		},
+		{
+			name:    "mixed family range",
+			wantErr: "invalid metalLB address 10.0.0.1-fd00::1 in pool default, must be a CIDR or an IP range",
+			config:  &MetalLBConfiguration{AddressPools: []MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.1-fd00::1"}}}},
+		},
+		{
+			name:    "invalid mode",
+			wantErr: "invalid metalLB mode arp, must be one of l2, bgp",
+			config:  &MetalLBConfiguration{Mode: "arp", AddressPools: pools},
+		},
+		{
+			name:    "peers in l2 mode",
+			wantErr: "metalLB bgpPeers are only supported in bgp mode",
+			config:  &MetalLBConfiguration{AddressPools: pools, BGPPeers: peers},
+		},
+		{
+			name:    "bgp mode without peers",
+			wantErr: "metalLB bgp mode requires at least one bgp peer",
+			config:  &MetalLBConfiguration{Mode: MetalLBBGPMode, AddressPools: pools},
+		},
+		{
+			name:    "invalid peer address",
+			wantErr: "invalid metalLB bgp peer address router",
+			config: &MetalLBConfiguration{
+				Mode: MetalLBBGPMode, AddressPools: pools,
+				BGPPeers: []MetalLBBGPPeer{{PeerAddress: "router", PeerASN: 64501, MyASN: 64500}},
+			},
+		},
+		{
+			name:    "peer with reserved asn",
+			wantErr: "metalLB bgp peer 10.0.0.1 uses reserved ASN 23456",
+			config: &MetalLBConfiguration{
+				Mode: MetalLBBGPMode, AddressPools: pools,
+				BGPPeers: []MetalLBBGPPeer{{PeerAddress: "10.0.0.1", PeerASN: 23456, MyASN: 64500}},
+			},
+		},
+		{
+			name:    "peer without asn",
+			wantErr: "metalLB bgp peer 10.0.0.1 requires peerASN and myASN",
+			config: &MetalLBConfiguration{
+				Mode: MetalLBBGPMode, AddressPools: pools,
+				BGPPeers: []MetalLBBGPPeer{{PeerAddress: "10.0.0.1", PeerASN: 64501}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					Packages: tt.packages,
+					MetalLB:  tt.config,
+				},
+			}
+			err := validateMetalLB(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneEndpointDNS(t *testing.T) {
+	route53 := &Route53DNS{HostedZoneID: "Z0123456789"}
+	rfc2136 := &RFC2136DNS{Server: "10.0.0.2", Zone: "example.com."}
+	tests := []struct {
+		name    string
+		wantErr string
+		kind    string
+		host    string
+		dns     *EndpointDNS
+	}{
+		{
+			name: "no dns",
+		},
+		{
+			name: "route53",
+			dns:  &EndpointDNS{Name: "api.prod.example.com", Route53: route53},
+		},
+		{
+			name: "rfc2136",
+			dns:  &EndpointDNS{Name: "api.prod.example.com.", RFC2136: rfc2136},
+		},
+		{
+			name: "infoblox",
+			dns:  &EndpointDNS{Name: "api.prod.example.com", Infoblox: &InfobloxDNS{Server: "infoblox.example.com"}},
+		},
+		{
+			name:    "docker",
+			wantErr: "control plane endpoint dns is not supported for docker clusters",
+			kind:    DockerDatacenterKind,
+			dns:     &EndpointDNS{Name: "api.prod.example.com", Route53: route53},
+		},
+		{
+			name:    "host is not an ip",
+			wantErr: "control plane endpoint dns requires the endpoint host to be an IP, got api.example.com",
+			host:    "api.example.com",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", Route53: route53},
+		},
+		{
+			name:    "invalid name",
+			wantErr: "invalid control plane endpoint dns name api_prod.example.com",
+			dns:     &EndpointDNS{Name: "api_prod.example.com", Route53: route53},
+		},
+		{
+			name:    "negative ttl",
+			wantErr: "control plane endpoint dns ttl -1 can't be negative",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", TTL: -1, Route53: route53},
+		},
+		{
+			name:    "no provider",
+			wantErr: "control plane endpoint dns requires exactly one of route53, rfc2136 or infoblox",
+			dns:     &EndpointDNS{Name: "api.prod.example.com"},
+		},
+		{
+			name:    "two providers",
+			wantErr: "control plane endpoint dns requires exactly one of route53, rfc2136 or infoblox",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", Route53: route53, RFC2136: rfc2136},
+		},
+		{
+			name:    "route53 without hosted zone",
+			wantErr: "control plane endpoint dns route53 hostedZoneID is required",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", Route53: &Route53DNS{}},
+		},
+		{
+			name:    "rfc2136 without zone",
+			wantErr: "control plane endpoint dns rfc2136 server and zone are required",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", RFC2136: &RFC2136DNS{Server: "10.0.0.2"}},
+		},
+		{
+			name:    "rfc2136 name out of zone",
+			wantErr: "control plane endpoint dns name api.prod.example.org is not in zone example.com.",
+			dns:     &EndpointDNS{Name: "api.prod.example.org", RFC2136: rfc2136},
+		},
+		{
+			name:    "rfc2136 invalid algorithm",
+			wantErr: "unsupported control plane endpoint dns tsigAlgorithm hmac-md5, must be hmac-sha256 or hmac-sha512",
+			dns: &EndpointDNS{Name: "api.prod.example.com", RFC2136: &RFC2136DNS{
+				Server: "10.0.0.2", Zone: "example.com", TSIGKeyName: "eksa", TSIGAlgorithm: "hmac-md5",
+			}},
+		},
+		{
+			name:    "infoblox without server",
+			wantErr: "control plane endpoint dns infoblox server is required",
+			dns:     &EndpointDNS{Name: "api.prod.example.com", Infoblox: &InfobloxDNS{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			if tt.kind == "" {
+				tt.kind = VSphereDatacenterKind
+			}
+			if tt.host == "" {
+				tt.host = "10.0.0.10"
+			}
+			config := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.kind},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{Host: tt.host, DNS: tt.dns},
+					},
+				},
+			}
+			err := validateControlPlaneEndpointDNS(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateProviderCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantErr     string
+		credentials []ProviderCredential
+	}{
+		{
+			name: "no credentials",
+		},
+		{
+			name: "valid references",
+			credentials: []ProviderCredential{
+				{Name: "EKSA_VSPHERE_USERNAME", ValueFrom: "aws-secretsmanager://eksa/vsphere#username"},
+				{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "vault://secret/data/vsphere#password"},
+				{Name: "EKSA_NUTANIX_PASSWORD", ValueFrom: "sops://creds.enc.yaml#nutanix.password"},
+			},
+		},
+		{
+			name:        "empty name",
+			wantErr:     "providerCredentials name can't be empty",
+			credentials: []ProviderCredential{{ValueFrom: "vault://secret/data/vsphere#password"}},
+		},
+		{
+			name:    "duplicate name",
+			wantErr: "duplicate providerCredentials EKSA_VSPHERE_PASSWORD",
+			credentials: []ProviderCredential{
+				{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "vault://secret/data/vsphere#password"},
+				{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "sops://creds.enc.yaml#password"},
+			},
+		},
+		{
+			name:        "plaintext value",
+			wantErr:     `invalid providerCredentials valueFrom "hunter2" for EKSA_VSPHERE_PASSWORD, must start with one of aws-secretsmanager://, vault://, sops://`,
+			credentials: []ProviderCredential{{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "hunter2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					ProviderCredentials: tt.credentials,
+				},
+			}
+			err := validateProviderCredentials(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateComponentImageOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   string
+		overrides *ComponentImageOverrides
+		mirror    *RegistryMirrorConfiguration
+		network   ClusterNetwork
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			overrides: &ComponentImageOverrides{
+				CoreDNS:   "public.ecr.aws/eks-distro/coredns/coredns:v1.9.3-eks-1-27-8",
+				KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4-eks-1-27-9@sha256:0123456789abcdef",
+				Etcd:      "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.9-eks-1-27-9",
+			},
+		},
+		{
+			name:      "valid override from mirror",
+			overrides: &ComponentImageOverrides{CoreDNS: "mirror.local:5000/eks-distro/coredns/coredns:v1.9.3-eks-1-27-8"},
+			mirror:    &RegistryMirrorConfiguration{Endpoint: "mirror.local", Port: "5000"},
+		},
+		{
+			name:      "no tag",
+			wantErr:   "invalid componentImageOverrides kubeProxy: image public.ecr.aws/eks-distro/kubernetes/kube-proxy@sha256:0123456789abcdef must include a tag",
+			overrides: &ComponentImageOverrides{KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy@sha256:0123456789abcdef"},
+		},
+		{
+			name:      "invalid digest",
+			wantErr:   "invalid componentImageOverrides etcd: image public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.9@md5:abc digest must be a sha256 digest",
+			overrides: &ComponentImageOverrides{Etcd: "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.9@md5:abc"},
+		},
+		{
+			name:      "no registry",
+			wantErr:   "invalid componentImageOverrides kubeProxy: image kube-proxy:v1.27.4 must include the registry",
+			overrides: &ComponentImageOverrides{KubeProxy: "kube-proxy:v1.27.4"},
+		},
+		{
+			name:      "wrong image name",
+			wantErr:   "invalid componentImageOverrides coreDNS: image public.ecr.aws/eks-distro/coredns/dns:v1.9.3 name must be coredns",
+			overrides: &ComponentImageOverrides{CoreDNS: "public.ecr.aws/eks-distro/coredns/dns:v1.9.3"},
+		},
+		{
+			name:      "kube-proxy with kube-proxy replacement",
+			wantErr:   "componentImageOverrides kubeProxy can't be set when cilium kubeProxyReplacement is enabled",
+			overrides: &ComponentImageOverrides{KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4"},
+			network: ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{KubeProxyReplacement: true}},
+			},
+		},
+		{
+			name:      "not in mirror",
+			wantErr:   "invalid componentImageOverrides coreDNS: image public.ecr.aws/eks-distro/coredns/coredns:v1.9.3 must be pulled from the registry mirror mirror.local:5000",
+			overrides: &ComponentImageOverrides{CoreDNS: "public.ecr.aws/eks-distro/coredns/coredns:v1.9.3"},
+			mirror:    &RegistryMirrorConfiguration{Endpoint: "mirror.local", Port: "5000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					ComponentImageOverrides:     tt.overrides,
+					RegistryMirrorConfiguration: tt.mirror,
+					ClusterNetwork:              tt.network,
+				},
+			}
+			err := validateComponentImageOverrides(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
 }
 
-func TestClusterCPUpgradeRolloutStrategyNotNil(t *testing.T) {
+func TestValidateTemplateOverrides(t *testing.T) {
 	tests := []struct {
-		name    string
-		cluster *Cluster
-		want    ControlPlaneConfiguration
+		name      string
+		wantErr   string
+		overrides []TemplateOverride
 	}{
 		{
-			name: "with control plane rollout upgrade strategy non-nil",
-			cluster: &Cluster{
-				Spec: ClusterSpec{},
-			},
-			want: ControlPlaneConfiguration{
-				Endpoint:               nil,
-				Count:                  1,
-				MachineGroupRef:        nil,
-				Taints:                 nil,
-				Labels:                 nil,
-				UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "RollingUpdate", RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 5}},
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			overrides: []TemplateOverride{
+				{Kind: "KubeadmControlPlane", Patch: "spec:\n  kubeadmConfigSpec:\n    preKubeadmCommands:\n    - echo hello\n"},
+				{Kind: "KubeadmConfigTemplate", Name: "*-md-0-*", Patch: "spec: {}"},
 			},
 		},
+		{
+			name:      "no kind",
+			wantErr:   "templateOverrides[0] kind can't be empty",
+			overrides: []TemplateOverride{{Patch: "spec: {}"}},
+		},
+		{
+			name:      "invalid name",
+			wantErr:   `invalid templateOverrides[0] name "md-[": syntax error in pattern`,
+			overrides: []TemplateOverride{{Kind: "MachineDeployment", Name: "md-[", Patch: "spec: {}"}},
+		},
+		{
+			name:      "patch not an object",
+			wantErr:   "invalid templateOverrides[1]: parsing templateOverride patch for KubeadmConfigTemplate",
+			overrides: []TemplateOverride{{Kind: "KubeadmControlPlane", Patch: "spec: {}"}, {Kind: "KubeadmConfigTemplate", Patch: "- spec"}},
+		},
+		{
+			name:      "empty patch",
+			wantErr:   "templateOverrides[0] patch for KubeadmControlPlane can't be empty",
+			overrides: []TemplateOverride{{Kind: "KubeadmControlPlane"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cg := NewClusterGenerate("test-cluster", ControlPlaneConfigCount(1), WithCPUpgradeRolloutStrategy(5, 2))
 			g := NewWithT(t)
-			g.Expect(cg.Spec.ControlPlaneConfiguration).To(Equal(tt.want))
+			config := &Cluster{
+				Spec: ClusterSpec{
+					TemplateOverrides: tt.overrides,
+				},
+			}
+			err := validateTemplateOverrides(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
 		})
 	}
 }
 
-func TestClusterMDUpgradeRolloutStrategyNil(t *testing.T) {
+func TestValidateFIPS(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantErr        string
+		fips           bool
+		datacenterKind string
+	}{
+		{
+			name:           "fips disabled",
+			datacenterKind: DockerDatacenterKind,
+		},
+		{
+			name:           "fips enabled",
+			fips:           true,
+			datacenterKind: VSphereDatacenterKind,
+		},
+		{
+			name:           "fips docker",
+			wantErr:        "fips is not supported for the docker provider",
+			fips:           true,
+			datacenterKind: DockerDatacenterKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					FIPS:          tt.fips,
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+				},
+			}
+			err := validateFIPS(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateTLSConfiguration(t *testing.T) {
 	tests := []struct {
 		name    string
-		cluster *Cluster
-		want    []WorkerNodeGroupConfiguration
+		wantErr string
+		fips    bool
+		config  *TLSConfiguration
 	}{
 		{
-			name: "with md rollout upgrade strategy knobs not specified",
-			cluster: &Cluster{
-				Spec: ClusterSpec{},
+			name: "no tls configuration",
+		},
+		{
+			name: "valid tls 1.2",
+			config: &TLSConfiguration{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+				MinVersion:   TLSVersion12,
 			},
-			want: []WorkerNodeGroupConfiguration{
-				{
-					Count:           ptr.Int(1),
-					MachineGroupRef: nil,
-					Taints:          nil,
-					Labels:          nil,
-				},
+		},
+		{
+			name:   "valid tls 1.3",
+			config: &TLSConfiguration{MinVersion: TLSVersion13},
+		},
+		{
+			name:    "unsupported min version",
+			wantErr: "tlsConfiguration minVersion VersionTLS11 is not supported, must be VersionTLS12 or VersionTLS13",
+			config:  &TLSConfiguration{MinVersion: "VersionTLS11"},
+		},
+		{
+			name:    "cipher suites with tls 1.3",
+			wantErr: "tlsConfiguration cipherSuites can't be set when minVersion is VersionTLS13",
+			config: &TLSConfiguration{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+				MinVersion:   TLSVersion13,
 			},
 		},
+		{
+			name:    "insecure cipher suite",
+			wantErr: "tlsConfiguration cipher suite TLS_RSA_WITH_RC4_128_SHA is insecure",
+			config:  &TLSConfiguration{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+		},
+		{
+			name:    "unknown cipher suite",
+			wantErr: "tlsConfiguration cipher suite TLS_FAKE is not a supported TLS 1.2 cipher suite",
+			config:  &TLSConfiguration{CipherSuites: []string{"TLS_FAKE"}},
+		},
+		{
+			name:    "tls 1.3 cipher suite",
+			wantErr: "tlsConfiguration cipher suite TLS_AES_128_GCM_SHA256 is not a supported TLS 1.2 cipher suite",
+			config:  &TLSConfiguration{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+		},
+		{
+			name:    "cipher suite not fips approved",
+			wantErr: "tlsConfiguration cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not FIPS approved",
+			fips:    true,
+			config:  &TLSConfiguration{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(1))
 			g := NewWithT(t)
-			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+			config := &Cluster{
+				Spec: ClusterSpec{
+					FIPS:             tt.fips,
+					TLSConfiguration: tt.config,
+				},
+			}
+			err := validateTLSConfiguration(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
 		})
 	}
 }
 
-func TestClusterMDUpgradeRolloutStrategyNotNil(t *testing.T) {
+func TestValidateHelmPostRenderer(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		renderer *HelmPostRenderer
+	}{
+		{
+			name: "no post-renderer",
+		},
+		{
+			name:     "valid",
+			renderer: &HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels", Args: []string{"--env", "prod"}},
+		},
+		{
+			name:     "missing binary path",
+			wantErr:  "helmPostRenderer binaryPath is required",
+			renderer: &HelmPostRenderer{Args: []string{"--env", "prod"}},
+		},
+		{
+			name:     "relative binary path",
+			wantErr:  "helmPostRenderer binaryPath bin/kustomize-labels must be an absolute path",
+			renderer: &HelmPostRenderer{BinaryPath: "bin/kustomize-labels"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					HelmPostRenderer: tt.renderer,
+				},
+			}
+			err := validateHelmPostRenderer(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateScale(t *testing.T) {
 	tests := []struct {
 		name    string
-		cluster *Cluster
-		want    []WorkerNodeGroupConfiguration
+		wantErr string
+		scale   ClusterScale
 	}{
 		{
-			name: "with md rollout upgrade strategy",
-			cluster: &Cluster{
-				Spec: ClusterSpec{},
+			name: "no scale",
+		},
+		{
+			name:  "standard",
+			scale: ClusterScaleStandard,
+		},
+		{
+			name:  "large",
+			scale: ClusterScaleLarge,
+		},
+		{
+			name:    "unsupported",
+			wantErr: "scale huge is not supported, must be standard or large",
+			scale:   "huge",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					Scale: tt.scale,
+				},
+			}
+			err := validateScale(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePinImageDigests(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   string
+		pin       bool
+		fips      bool
+		overrides *ComponentImageOverrides
+	}{
+		{
+			name:      "disabled",
+			fips:      true,
+			overrides: &ComponentImageOverrides{CoreDNS: "public.ecr.aws/eks-distro/coredns/coredns:v1.9.3"},
+		},
+		{
+			name: "enabled",
+			pin:  true,
+		},
+		{
+			name: "pinned overrides",
+			pin:  true,
+			overrides: &ComponentImageOverrides{
+				CoreDNS: "public.ecr.aws/eks-distro/coredns/coredns:v1.9.3@sha256:8e7d2d9e",
+				Etcd:    "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.9@sha256:7e1d9f1a",
 			},
-			want: []WorkerNodeGroupConfiguration{
-				{
-					Count:                  ptr.Int(1),
-					MachineGroupRef:        nil,
-					Taints:                 nil,
-					Labels:                 nil,
-					UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "RollingUpdate", RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 5, MaxUnavailable: 2}},
+		},
+		{
+			name:    "fips",
+			wantErr: "pinImageDigests is not supported in FIPS mode",
+			pin:     true,
+			fips:    true,
+		},
+		{
+			name:      "override not pinned",
+			wantErr:   "componentImageOverrides kubeProxy image public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4 must be pinned to a digest",
+			pin:       true,
+			overrides: &ComponentImageOverrides{KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &Cluster{
+				Spec: ClusterSpec{
+					PinImageDigests:         tt.pin,
+					FIPS:                    tt.fips,
+					ComponentImageOverrides: tt.overrides,
 				},
+			}
+			err := validatePinImageDigests(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateClusterResourceSets(t *testing.T) {
+	tests := []struct {
+		name              string
+		wantErr           string
+		managementCluster string
+		sets              []ClusterResourceSet
+	}{
+		{
+			name:              "no sets",
+			managementCluster: "workload-mgmt",
+		},
+		{
+			name:              "valid",
+			managementCluster: "mgmt",
+			sets: []ClusterResourceSet{
+				{Name: "storage", ConfigMaps: []string{"storage-classes"}},
+				{Name: "policies", ConfigMaps: []string{"priority-classes", "network-policies"}},
 			},
 		},
+		{
+			name:              "workload cluster",
+			wantErr:           "clusterResourceSets can only be set in management clusters",
+			managementCluster: "workload-mgmt",
+			sets:              []ClusterResourceSet{{Name: "storage", ConfigMaps: []string{"storage-classes"}}},
+		},
+		{
+			name:              "invalid name",
+			wantErr:           "invalid clusterResourceSets name \"Storage\"",
+			managementCluster: "mgmt",
+			sets:              []ClusterResourceSet{{Name: "Storage", ConfigMaps: []string{"storage-classes"}}},
+		},
+		{
+			name:              "duplicated name",
+			wantErr:           "clusterResourceSets storage is defined more than once",
+			managementCluster: "mgmt",
+			sets: []ClusterResourceSet{
+				{Name: "storage", ConfigMaps: []string{"storage-classes"}},
+				{Name: "storage", ConfigMaps: []string{"priority-classes"}},
+			},
+		},
+		{
+			name:              "no configmaps",
+			wantErr:           "clusterResourceSets storage must reference at least one configMap",
+			managementCluster: "mgmt",
+			sets:              []ClusterResourceSet{{Name: "storage"}},
+		},
+		{
+			name:              "invalid configmap name",
+			wantErr:           "invalid configMap name \"storage_classes\" in clusterResourceSets storage",
+			managementCluster: "mgmt",
+			sets:              []ClusterResourceSet{{Name: "storage", ConfigMaps: []string{"storage_classes"}}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cg := NewClusterGenerate("test-cluster", WorkerNodeConfigCount(1), WithWorkerMachineUpgradeRolloutStrategy(5, 2))
 			g := NewWithT(t)
-			g.Expect(cg.Spec.WorkerNodeGroupConfigurations).To(Equal(tt.want))
+			config := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mgmt",
+				},
+				Spec: ClusterSpec{
+					ManagementCluster:   ManagementCluster{Name: tt.managementCluster},
+					ClusterResourceSets: tt.sets,
+				},
+			}
+			err := validateClusterResourceSets(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
 		})
 	}
 }
@@ -3,11 +3,16 @@ package v1alpha1
 import (
 	"fmt"
 	"net"
+	"path"
 	"strings"
+	"time"
 
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/semver"
@@ -36,6 +41,11 @@ const (
 	// skipIPCheckAnnotation skips the availability control plane IP validation during cluster creation. Use only if your network configuration is conflicting with the default port scan.
 	skipIPCheckAnnotation = "anywhere.eks.amazonaws.com/skip-ip-check"
 
+	// ControlPlaneEndpointMigrationAnnotation allows to change the control plane endpoint host of a
+	// paused cluster to the annotation value in a later update. It's set when the cluster is paused at
+	// the start of the endpoint migration and removed when it's resumed.
+	ControlPlaneEndpointMigrationAnnotation = "anywhere.eks.amazonaws.com/control-plane-endpoint-migration"
+
 	// managementAnnotation points to the name of a management cluster
 	// cluster object.
 	managementAnnotation = "anywhere.eks.amazonaws.com/managed-by"
@@ -53,7 +63,7 @@ const (
 type ClusterSpec struct {
 	KubernetesVersion             KubernetesVersion              `json:"kubernetesVersion,omitempty"`
 	ControlPlaneConfiguration     ControlPlaneConfiguration      `json:"controlPlaneConfiguration,omitempty"`
-	WorkerNodeGroupConfigurations []WorkerNodeGroupConfiguration `json:"workerNodeGroupConfigurations,omitempty"`
+	WorkerNodeGroupConfigurations []WorkerNodeGroupConfiguration `json:"workerNodeGroupConfigurations,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 	DatacenterRef                 Ref                            `json:"datacenterRef,omitempty"`
 	IdentityProviderRefs          []Ref                          `json:"identityProviderRefs,omitempty"`
 	GitOpsRef                     *Ref                           `json:"gitOpsRef,omitempty"`
@@ -71,6 +81,62 @@ type ClusterSpec struct {
 	EksaVersion        *EksaVersion        `json:"eksaVersion,omitempty"`
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
 	EtcdEncryption     *[]EtcdEncryption   `json:"etcdEncryption,omitempty"`
+	// ClusterLabels are key/value pairs propagated to the infrastructure created for the
+	// cluster by the provider, ex. as Nutanix categories on the cluster VMs, so it can be
+	// tied back to the cluster by billing and inventory systems.
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+	// TTL is how long after its creation the cluster is deleted by the controller. It's only
+	// supported for workload clusters and it's meant to avoid leaving dev and test clusters behind.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// ManagementControllers tunes the Cluster API controllers installed in a management cluster.
+	ManagementControllers *ManagementControllersConfiguration `json:"managementControllers,omitempty"`
+	// ImageWarmCache installs a DaemonSet that pre-pulls the cluster critical images on every node.
+	ImageWarmCache *ImageWarmCacheConfiguration `json:"imageWarmCache,omitempty"`
+	// AccessEntries bind ClusterRoles to groups of users authenticated through the cluster identity
+	// providers. They are applied once when the cluster is created.
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+	// PodSecurityAdmission configures the cluster-wide defaults of the PodSecurity admission controller.
+	PodSecurityAdmission *PodSecurityAdmissionConfiguration `json:"podSecurityAdmission,omitempty"`
+	// PolicyEngine installs a policy engine in the cluster with a baseline set of policies.
+	// It's upgraded together with the cluster.
+	PolicyEngine *PolicyEngineConfiguration `json:"policyEngine,omitempty"`
+	// CertManager manages cert-manager as a cluster add-on and the ClusterIssuers generated from
+	// this spec. It's upgraded together with the cluster.
+	CertManager *CertManagerConfiguration `json:"certManager,omitempty"`
+	// MetalLB installs the MetalLB curated package configured with these address pools, so the
+	// cluster can serve LoadBalancer Services. It's applied once when the cluster is created.
+	MetalLB *MetalLBConfiguration `json:"metalLB,omitempty"`
+	// ProviderCredentials reads the provider credentials from external secret managers instead of
+	// from plaintext environment variables in the admin machine. They are only used by the CLI.
+	ProviderCredentials []ProviderCredential `json:"providerCredentials,omitempty"`
+	// ComponentImageOverrides replaces the CoreDNS, kube-proxy and etcd images from the EKS-A bundle,
+	// so patched images can be rolled out without waiting for a new bundle.
+	ComponentImageOverrides *ComponentImageOverrides `json:"componentImageOverrides,omitempty"`
+	// TemplateOverrides are strategic merge patches applied to the CAPI objects generated for the
+	// cluster. Only the paths each provider allows can be overridden.
+	TemplateOverrides []TemplateOverride `json:"templateOverrides,omitempty"`
+	// FIPS runs the cluster in FIPS mode. The FIPS variants of the bundle images are used, TLS is
+	// restricted to FIPS approved cipher suites and nodes must boot a kernel in FIPS mode.
+	FIPS bool `json:"fips,omitempty"`
+	// TLSConfiguration sets the TLS cipher suites and minimum version accepted by the API server,
+	// etcd, the controller manager and the kubelet.
+	TLSConfiguration *TLSConfiguration `json:"tlsConfiguration,omitempty"`
+	// HelmPostRenderer passes the manifests of the Helm charts installed by EKS-A, like Cilium and
+	// the curated packages controller, through a post-renderer before they are applied.
+	HelmPostRenderer *HelmPostRenderer `json:"helmPostRenderer,omitempty"`
+	// Scale selects the defaults of the control plane components, standard or large. Large clusters
+	// get more API server inflight requests, a bigger etcd quota, slower kube-vip leader election,
+	// more CoreDNS replicas and a higher controller manager QPS. When not set, clusters with more than
+	// 100 nodes are large.
+	Scale ClusterScale `json:"scale,omitempty"`
+	// PinImageDigests pins the images from the EKS-A bundle and the EKS-D release to the digests they
+	// were released with, so the nodes and the cluster components don't pull a different image if a
+	// tag is moved in a registry mirror. Component image overrides must be pinned to a digest too.
+	PinImageDigests bool `json:"pinImageDigests,omitempty"`
+	// ClusterResourceSets are sets of manifests stored in ConfigMaps that Cluster API applies to
+	// every workload cluster managed by this cluster once it's created. Only management clusters
+	// can define them.
+	ClusterResourceSets []ClusterResourceSet `json:"clusterResourceSets,omitempty"`
 }
 
 // ClusterSpecGenerate is the same as ClusterSpec except for removing the omitempty tag from BundlesRef.
@@ -101,6 +167,57 @@ type ClusterSpecGenerate struct {
 	BundlesRef         *BundlesRef         `json:"bundlesRef"`
 	EksaVersion        *EksaVersion        `json:"eksaVersion,omitempty"`
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
+	ClusterLabels      map[string]string   `json:"clusterLabels,omitempty"`
+	TTL                *metav1.Duration    `json:"ttl,omitempty"`
+	// ManagementControllers tunes the Cluster API controllers installed in a management cluster.
+	ManagementControllers *ManagementControllersConfiguration `json:"managementControllers,omitempty"`
+	// ImageWarmCache installs a DaemonSet that pre-pulls the cluster critical images on every node.
+	ImageWarmCache *ImageWarmCacheConfiguration `json:"imageWarmCache,omitempty"`
+	// AccessEntries bind ClusterRoles to groups of users authenticated through the cluster identity
+	// providers. They are applied once when the cluster is created.
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+	// PodSecurityAdmission configures the cluster-wide defaults of the PodSecurity admission controller.
+	PodSecurityAdmission *PodSecurityAdmissionConfiguration `json:"podSecurityAdmission,omitempty"`
+	// PolicyEngine installs a policy engine in the cluster with a baseline set of policies.
+	// It's upgraded together with the cluster.
+	PolicyEngine *PolicyEngineConfiguration `json:"policyEngine,omitempty"`
+	// CertManager manages cert-manager as a cluster add-on and the ClusterIssuers generated from
+	// this spec. It's upgraded together with the cluster.
+	CertManager *CertManagerConfiguration `json:"certManager,omitempty"`
+	// MetalLB installs the MetalLB curated package configured with these address pools, so the
+	// cluster can serve LoadBalancer Services. It's applied once when the cluster is created.
+	MetalLB *MetalLBConfiguration `json:"metalLB,omitempty"`
+	// ProviderCredentials reads the provider credentials from external secret managers instead of
+	// from plaintext environment variables in the admin machine. They are only used by the CLI.
+	ProviderCredentials []ProviderCredential `json:"providerCredentials,omitempty"`
+	// ComponentImageOverrides replaces the CoreDNS, kube-proxy and etcd images from the EKS-A bundle,
+	// so patched images can be rolled out without waiting for a new bundle.
+	ComponentImageOverrides *ComponentImageOverrides `json:"componentImageOverrides,omitempty"`
+	// TemplateOverrides are strategic merge patches applied to the CAPI objects generated for the
+	// cluster. Only the paths each provider allows can be overridden.
+	TemplateOverrides []TemplateOverride `json:"templateOverrides,omitempty"`
+	// FIPS runs the cluster in FIPS mode. The FIPS variants of the bundle images are used, TLS is
+	// restricted to FIPS approved cipher suites and nodes must boot a kernel in FIPS mode.
+	FIPS bool `json:"fips,omitempty"`
+	// TLSConfiguration sets the TLS cipher suites and minimum version accepted by the API server,
+	// etcd, the controller manager and the kubelet.
+	TLSConfiguration *TLSConfiguration `json:"tlsConfiguration,omitempty"`
+	// HelmPostRenderer passes the manifests of the Helm charts installed by EKS-A, like Cilium and
+	// the curated packages controller, through a post-renderer before they are applied.
+	HelmPostRenderer *HelmPostRenderer `json:"helmPostRenderer,omitempty"`
+	// Scale selects the defaults of the control plane components, standard or large. Large clusters
+	// get more API server inflight requests, a bigger etcd quota, slower kube-vip leader election,
+	// more CoreDNS replicas and a higher controller manager QPS. When not set, clusters with more than
+	// 100 nodes are large.
+	Scale ClusterScale `json:"scale,omitempty"`
+	// PinImageDigests pins the images from the EKS-A bundle and the EKS-D release to the digests they
+	// were released with, so the nodes and the cluster components don't pull a different image if a
+	// tag is moved in a registry mirror. Component image overrides must be pinned to a digest too.
+	PinImageDigests bool `json:"pinImageDigests,omitempty"`
+	// ClusterResourceSets are sets of manifests stored in ConfigMaps that Cluster API applies to
+	// every workload cluster managed by this cluster once it's created. Only management clusters
+	// can define them.
+	ClusterResourceSets []ClusterResourceSet `json:"clusterResourceSets,omitempty"`
 }
 
 // EksaVersion is the semver identifying the release of eks-a used to populate the cluster components.
@@ -137,6 +254,12 @@ func (c *Cluster) IsPackagesEnabled() bool {
 	return c.Spec.Packages == nil || !c.Spec.Packages.Disable
 }
 
+// IsSelfUpgradeEnabled checks if the eks-anywhere controller of a self-managed cluster
+// upgrades itself when the cluster bundle changes.
+func (c *Cluster) IsSelfUpgradeEnabled() bool {
+	return c.IsSelfManaged() && c.Spec.ManagementControllers != nil && c.Spec.ManagementControllers.SelfUpgrade
+}
+
 func (n *Cluster) Equal(o *Cluster) bool {
 	if n == o {
 		return true
@@ -190,6 +313,57 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.EksaVersion.Equal(o.Spec.EksaVersion) {
 		return false
 	}
+	if !maps.Equal(n.Spec.ClusterLabels, o.Spec.ClusterLabels) {
+		return false
+	}
+	if !durationEqual(n.Spec.TTL, o.Spec.TTL) {
+		return false
+	}
+	if !n.Spec.ManagementControllers.Equal(o.Spec.ManagementControllers) {
+		return false
+	}
+	if !n.Spec.ImageWarmCache.Equal(o.Spec.ImageWarmCache) {
+		return false
+	}
+	if !AccessEntriesEqual(n.Spec.AccessEntries, o.Spec.AccessEntries) {
+		return false
+	}
+	if !n.Spec.PodSecurityAdmission.Equal(o.Spec.PodSecurityAdmission) {
+		return false
+	}
+	if !n.Spec.PolicyEngine.Equal(o.Spec.PolicyEngine) {
+		return false
+	}
+	if !n.Spec.CertManager.Equal(o.Spec.CertManager) {
+		return false
+	}
+	if !n.Spec.MetalLB.Equal(o.Spec.MetalLB) {
+		return false
+	}
+	if !n.Spec.ComponentImageOverrides.Equal(o.Spec.ComponentImageOverrides) {
+		return false
+	}
+	if !slices.Equal(n.Spec.TemplateOverrides, o.Spec.TemplateOverrides) {
+		return false
+	}
+	if n.Spec.FIPS != o.Spec.FIPS {
+		return false
+	}
+	if !n.Spec.TLSConfiguration.Equal(o.Spec.TLSConfiguration) {
+		return false
+	}
+	if !n.Spec.HelmPostRenderer.Equal(o.Spec.HelmPostRenderer) {
+		return false
+	}
+	if n.Spec.Scale != o.Spec.Scale {
+		return false
+	}
+	if n.Spec.PinImageDigests != o.Spec.PinImageDigests {
+		return false
+	}
+	if !ClusterResourceSetsEqual(n.Spec.ClusterResourceSets, o.Spec.ClusterResourceSets) {
+		return false
+	}
 
 	return true
 }
@@ -243,8 +417,47 @@ type RegistryMirrorConfiguration struct {
 	// InsecureSkipVerify skips the registry certificate verification.
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// Hosts defines per registry containerd hosts configuration. When set, the registry mirror
+	// configuration is written to the nodes as containerd certs.d hosts files instead of
+	// a global mirrors section, and these hosts override the ones generated for OCINamespaces.
+	Hosts []RegistryHostsConfiguration `json:"hosts,omitempty"`
+}
+
+// RegistryHostsConfiguration defines the containerd hosts configuration for an upstream registry.
+type RegistryHostsConfiguration struct {
+	// Registry is the upstream registry the configuration applies to, like docker.io.
+	Registry string `json:"registry"`
+	// Server overrides the upstream registry URL containerd falls back to when no host can serve the request.
+	Server string `json:"server,omitempty"`
+	// Hosts are tried in order before falling back to the upstream registry.
+	Hosts []RegistryHost `json:"hosts"`
+}
+
+// RegistryHost defines a registry host containerd can pull images from.
+type RegistryHost struct {
+	// URL of the registry host, including the scheme and optionally the port and path.
+	URL string `json:"url"`
+	// Capabilities are the operations the host supports, any of pull, resolve and push.
+	// Defaults to pull and resolve.
+	Capabilities []RegistryHostCapability `json:"capabilities,omitempty"`
+	// CACertContent defines the contents of the host CA certificate.
+	CACertContent string `json:"caCertContent,omitempty"`
+	// InsecureSkipVerify skips the host certificate verification.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// OverridePath uses the URL path as the full registry API path instead of appending /v2 to it.
+	OverridePath bool `json:"overridePath,omitempty"`
 }
 
+// RegistryHostCapability is an operation a registry host supports.
+type RegistryHostCapability string
+
+const (
+	RegistryHostPull    RegistryHostCapability = "pull"
+	RegistryHostResolve RegistryHostCapability = "resolve"
+	RegistryHostPush    RegistryHostCapability = "push"
+)
+
 // OCINamespace represents an entity in a local reigstry to group related images.
 type OCINamespace struct {
 	// Name refers to the name of the upstream registry
@@ -262,7 +475,29 @@ func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool
 	}
 	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent &&
 		n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate &&
-		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces)
+		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces) && registryHostsSliceEqual(n.Hosts, o.Hosts)
+}
+
+func registryHostsSliceEqual(a, b []RegistryHostsConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Registry != b[i].Registry || a[i].Server != b[i].Server || len(a[i].Hosts) != len(b[i].Hosts) {
+			return false
+		}
+		for j := range a[i].Hosts {
+			if !a[i].Hosts[j].equal(b[i].Hosts[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (h RegistryHost) equal(o RegistryHost) bool {
+	return h.URL == o.URL && h.CACertContent == o.CACertContent && h.InsecureSkipVerify == o.InsecureSkipVerify &&
+		h.OverridePath == o.OverridePath && slices.Equal(h.Capabilities, o.Capabilities)
 }
 
 // OCINamespacesSliceEqual is used to check equality of the OCINamespaces fields of two RegistryMirrorConfiguration.
@@ -308,6 +543,10 @@ type ControlPlaneConfiguration struct {
 	// SkipLoadBalancerDeployment skip deploying control plane load balancer.
 	// Make sure your infrastructure can handle control plane load balancing when you set this field to true.
 	SkipLoadBalancerDeployment bool `json:"skipLoadBalancerDeployment,omitempty"`
+	// DedicatedPlacement requires the control plane and etcd machines to be placed in infrastructure
+	// not shared with the worker nodes (resource pool for vSphere, Prism Element cluster for Nutanix),
+	// so noisy workers can't affect the control plane and etcd hosts.
+	DedicatedPlacement bool `json:"dedicatedPlacement,omitempty"`
 }
 
 // MachineHealthCheck allows to configure timeouts for machine health checks. Machine Health Checks are responsible for remediating unhealthy Machines.
@@ -319,6 +558,547 @@ type MachineHealthCheck struct {
 	UnhealthyMachineTimeout *metav1.Duration `json:"unhealthyMachineTimeout,omitempty"`
 }
 
+// ManagementControllersConfiguration configures the client rate limits and concurrency of the
+// Cluster API core, kubeadm bootstrap and kubeadm control plane controllers. The controller
+// defaults are used for the fields that are not set. The defaults are usually too low for
+// management clusters that manage many workload clusters. It also allows the eks-anywhere
+// controller to upgrade itself and to run with multiple replicas.
+type ManagementControllersConfiguration struct {
+	// KubeAPIQPS is the maximum queries per second from the controllers to the API server.
+	KubeAPIQPS int `json:"kubeAPIQPS,omitempty"`
+	// KubeAPIBurst is the maximum number of queries from the controllers to the API server
+	// allowed in a burst.
+	KubeAPIBurst int `json:"kubeAPIBurst,omitempty"`
+	// Concurrency is the number of objects of each type reconciled in parallel by the controllers.
+	Concurrency int `json:"concurrency,omitempty"`
+	// MachineConcurrency is the number of machines the infrastructure provider controller creates
+	// and deletes in parallel. Only supported for the vSphere, Nutanix, CloudStack and Docker providers.
+	MachineConcurrency int `json:"machineConcurrency,omitempty"`
+	// SelfUpgrade enables the eks-anywhere controller to upgrade its components and the curated
+	// packages controller when the cluster bundle changes, without running the CLI.
+	SelfUpgrade bool `json:"selfUpgrade,omitempty"`
+	// Replicas is the number of replicas of the eks-anywhere controller. Only the replica holding
+	// the leader election lease reconciles, the rest take over if it fails. Defaults to 1.
+	Replicas int `json:"replicas,omitempty"`
+	// LeaderElection tunes the leader election of the eks-anywhere controller replicas.
+	LeaderElection *LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+}
+
+// Equal checks if two ManagementControllersConfigurations are equal.
+func (n *ManagementControllersConfiguration) Equal(o *ManagementControllersConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.KubeAPIQPS == o.KubeAPIQPS &&
+		n.KubeAPIBurst == o.KubeAPIBurst &&
+		n.Concurrency == o.Concurrency &&
+		n.MachineConcurrency == o.MachineConcurrency &&
+		n.SelfUpgrade == o.SelfUpgrade &&
+		n.Replicas == o.Replicas &&
+		n.LeaderElection.Equal(o.LeaderElection)
+}
+
+// ControllerReplicas returns the number of replicas of the eks-anywhere controller.
+func (n *ManagementControllersConfiguration) ControllerReplicas() int {
+	if n == nil || n.Replicas == 0 {
+		return 1
+	}
+	return n.Replicas
+}
+
+// LeaderElectionConfiguration configures the leader election lease of the eks-anywhere controller.
+// The controller defaults are used for the fields that are not set.
+type LeaderElectionConfiguration struct {
+	// LeaseDuration is how long the replicas that are not the leader wait before taking over
+	// a lease that hasn't been renewed. Defaults to 15s.
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is how long the leader keeps retrying to renew the lease before giving it up.
+	// Defaults to 10s.
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is how long the replicas wait between attempts to acquire or renew the lease.
+	// Defaults to 2s.
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+const (
+	// DefaultLeaderElectionLeaseDuration is the default lease duration of the eks-anywhere controller.
+	DefaultLeaderElectionLeaseDuration = 15 * time.Second
+	// DefaultLeaderElectionRenewDeadline is the default renew deadline of the eks-anywhere controller.
+	DefaultLeaderElectionRenewDeadline = 10 * time.Second
+	// DefaultLeaderElectionRetryPeriod is the default retry period of the eks-anywhere controller.
+	DefaultLeaderElectionRetryPeriod = 2 * time.Second
+)
+
+// Equal checks if two LeaderElectionConfigurations are equal.
+func (n *LeaderElectionConfiguration) Equal(o *LeaderElectionConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return durationEqual(n.LeaseDuration, o.LeaseDuration) &&
+		durationEqual(n.RenewDeadline, o.RenewDeadline) &&
+		durationEqual(n.RetryPeriod, o.RetryPeriod)
+}
+
+// Durations returns the lease duration, renew deadline and retry period, using the defaults
+// for the ones not set.
+func (n *LeaderElectionConfiguration) Durations() (leaseDuration, renewDeadline, retryPeriod time.Duration) {
+	leaseDuration, renewDeadline, retryPeriod = DefaultLeaderElectionLeaseDuration, DefaultLeaderElectionRenewDeadline, DefaultLeaderElectionRetryPeriod
+	if n == nil {
+		return leaseDuration, renewDeadline, retryPeriod
+	}
+	if n.LeaseDuration != nil {
+		leaseDuration = n.LeaseDuration.Duration
+	}
+	if n.RenewDeadline != nil {
+		renewDeadline = n.RenewDeadline.Duration
+	}
+	if n.RetryPeriod != nil {
+		retryPeriod = n.RetryPeriod.Duration
+	}
+	return leaseDuration, renewDeadline, retryPeriod
+}
+
+// ImageWarmCacheConfiguration enables pre-pulling the bundle critical images (pause, CNI and
+// kube-proxy) on every node right after it joins the cluster, so pods start faster and nodes
+// keep the images available during registry outages.
+type ImageWarmCacheConfiguration struct {
+	// AdditionalImages are extra images to pre-pull on every node. They must provide a sh shell.
+	AdditionalImages []string `json:"additionalImages,omitempty"`
+}
+
+// Equal checks if two ImageWarmCacheConfigurations are equal.
+func (n *ImageWarmCacheConfiguration) Equal(o *ImageWarmCacheConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return slices.Equal(n.AdditionalImages, o.AdditionalImages)
+}
+
+// ComponentImageOverrides are full image references, including a tag and optionally a digest,
+// that replace the images from the EKS-A bundle for the cluster core components.
+type ComponentImageOverrides struct {
+	// CoreDNS replaces the CoreDNS image. The image name must be coredns.
+	CoreDNS string `json:"coreDNS,omitempty"`
+	// KubeProxy replaces the kube-proxy image in the kube-proxy DaemonSet.
+	KubeProxy string `json:"kubeProxy,omitempty"`
+	// Etcd replaces the etcd image used by stacked etcd and by Bottlerocket external etcd nodes.
+	// The image name must be etcd.
+	Etcd string `json:"etcd,omitempty"`
+}
+
+// Equal checks if two ComponentImageOverrides are equal.
+func (n *ComponentImageOverrides) Equal(o *ComponentImageOverrides) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
+}
+
+// TemplateOverride is a strategic merge patch applied to the CAPI objects of a kind generated for
+// the cluster, like the KubeadmControlPlane or the KubeadmConfigTemplates of the worker nodes.
+type TemplateOverride struct {
+	// Kind is the kind of the generated CAPI objects the patch is applied to.
+	Kind string `json:"kind"`
+	// Name is a glob pattern selecting the objects of Kind the patch is applied to, like "*-md-0*".
+	// The patch is applied to all the objects of Kind when it's empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Patch is the strategic merge patch, in yaml format.
+	Patch string `json:"patch"`
+}
+
+// Matches checks if the override applies to an object with the given kind and name.
+func (o *TemplateOverride) Matches(kind, name string) bool {
+	if o.Kind != kind {
+		return false
+	}
+	if o.Name == "" {
+		return true
+	}
+	matched, _ := path.Match(o.Name, name)
+	return matched
+}
+
+// PatchObject parses the override patch. It must be a yaml object.
+func (o *TemplateOverride) PatchObject() (map[string]interface{}, error) {
+	patch := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(o.Patch), &patch); err != nil {
+		return nil, fmt.Errorf("parsing templateOverride patch for %s: %v", o.Kind, err)
+	}
+	return patch, nil
+}
+
+// TLSVersion is the name of a TLS version, as defined by the Kubernetes components flags.
+type TLSVersion string
+
+const (
+	// TLSVersion12 is TLS 1.2.
+	TLSVersion12 TLSVersion = "VersionTLS12"
+	// TLSVersion13 is TLS 1.3.
+	TLSVersion13 TLSVersion = "VersionTLS13"
+)
+
+// TLSConfiguration restricts the TLS connections accepted by the control plane components and the kubelet.
+type TLSConfiguration struct {
+	// CipherSuites are the TLS 1.2 cipher suites allowed, using the Go crypto/tls names like
+	// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Insecure cipher suites are not allowed. They can't be set
+	// when MinVersion is VersionTLS13 since the TLS 1.3 cipher suites are not configurable.
+	// Defaults to the EKS-A secure cipher suites, or the FIPS approved ones in FIPS mode.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// MinVersion is the minimum TLS version accepted by the API server, the controller manager and the kubelet,
+	// VersionTLS12 or VersionTLS13. etcd always accepts TLS 1.2 or higher.
+	MinVersion TLSVersion `json:"minVersion,omitempty"`
+}
+
+// Equal checks if two TLSConfigurations are equal.
+func (n *TLSConfiguration) Equal(o *TLSConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MinVersion == o.MinVersion && slices.Equal(n.CipherSuites, o.CipherSuites)
+}
+
+// HelmPostRenderer is an executable Helm runs with the rendered manifests of a chart in stdin and
+// applies the manifests it writes to stdout, ex. a kustomize wrapper adding mandated labels or sidecars.
+type HelmPostRenderer struct {
+	// BinaryPath is the absolute path of the post-renderer executable. It must exist in the admin
+	// machine and, for the charts the EKS-A controller installs, in the controller container.
+	BinaryPath string `json:"binaryPath"`
+	// Args are passed to the post-renderer executable.
+	Args []string `json:"args,omitempty"`
+}
+
+// Equal checks if two HelmPostRenderers are equal.
+func (n *HelmPostRenderer) Equal(o *HelmPostRenderer) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.BinaryPath == o.BinaryPath && slices.Equal(n.Args, o.Args)
+}
+
+// ClusterScale is the size class of a cluster.
+type ClusterScale string
+
+const (
+	// ClusterScaleStandard uses the upstream defaults of the control plane components, even for
+	// clusters above the large cluster threshold.
+	ClusterScaleStandard ClusterScale = "standard"
+	// ClusterScaleLarge tunes the control plane components for clusters with hundreds of nodes.
+	ClusterScaleLarge ClusterScale = "large"
+)
+
+// LargeClusterNodeThreshold is the number of nodes above which a cluster without a scale is large.
+const LargeClusterNodeThreshold = 100
+
+// NodeCount returns the number of nodes of the cluster: the control plane, etcd and worker nodes.
+// Autoscaled worker node groups count with their maximum size.
+func (c *Cluster) NodeCount() int {
+	count := c.Spec.ControlPlaneConfiguration.Count
+	if c.Spec.ExternalEtcdConfiguration != nil {
+		count += c.Spec.ExternalEtcdConfiguration.Count
+	}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		switch {
+		case w.AutoScalingConfiguration != nil:
+			count += w.AutoScalingConfiguration.MaxCount
+		case w.Count != nil:
+			count += *w.Count
+		}
+	}
+	return count
+}
+
+// IsLargeScale checks if the control plane components use the large cluster defaults, either
+// because the cluster sets the large scale or because it has more nodes than the threshold.
+func (c *Cluster) IsLargeScale() bool {
+	switch c.Spec.Scale {
+	case ClusterScaleLarge:
+		return true
+	case ClusterScaleStandard:
+		return false
+	}
+	return c.NodeCount() > LargeClusterNodeThreshold
+}
+
+// ClusterResourceSet is a set of manifests applied once to every new workload cluster. The
+// manifests are read from ConfigMaps in the eksa-system namespace of the management cluster.
+type ClusterResourceSet struct {
+	// Name identifies the set in the management cluster. The Cluster API ClusterResourceSet is
+	// named after the management cluster and this name.
+	Name string `json:"name"`
+	// ConfigMaps are the names of the ConfigMaps in the eksa-system namespace holding the
+	// manifests. Every key of a ConfigMap can hold one or more manifests. They are applied in order.
+	ConfigMaps []string `json:"configMaps"`
+}
+
+// ClusterResourceSetsEqual checks if two lists of ClusterResourceSets are equal, taking order into account.
+func ClusterResourceSetsEqual(a, b []ClusterResourceSet) bool {
+	return slices.EqualFunc(a, b, func(x, y ClusterResourceSet) bool {
+		return x.Name == y.Name && slices.Equal(x.ConfigMaps, y.ConfigMaps)
+	})
+}
+
+// AccessEntry grants a ClusterRole to a group of users so the cluster can be accessed without
+// the admin kubeconfig from the moment it's created.
+type AccessEntry struct {
+	// Group is the Kubernetes group bound to the ClusterRole. For OIDC users, it must include the
+	// groupsPrefix configured in the OIDCConfig, if any.
+	Group string `json:"group"`
+	// ClusterRole is the name of the ClusterRole bound to the group, ex. cluster-admin, edit or view.
+	ClusterRole string `json:"clusterRole"`
+	// IAMRoleARNs are the IAM roles mapped to the group in the AWS IAM Authenticator configuration.
+	// They require an AWSIamConfig in the cluster identityProviderRefs.
+	IAMRoleARNs []string `json:"iamRoleARNs,omitempty"`
+}
+
+// AccessEntriesEqual checks if two lists of AccessEntries are equal, taking order into account.
+func AccessEntriesEqual(a, b []AccessEntry) bool {
+	return slices.EqualFunc(a, b, func(x, y AccessEntry) bool {
+		return x.Group == y.Group && x.ClusterRole == y.ClusterRole && slices.Equal(x.IAMRoleARNs, y.IAMRoleARNs)
+	})
+}
+
+// PodSecurityLevel is one of the Pod Security Standards levels.
+type PodSecurityLevel string
+
+const (
+	// PodSecurityPrivileged is the unrestricted Pod Security Standards level.
+	PodSecurityPrivileged PodSecurityLevel = "privileged"
+	// PodSecurityBaseline is the Pod Security Standards level that prevents known privilege escalations.
+	PodSecurityBaseline PodSecurityLevel = "baseline"
+	// PodSecurityRestricted is the Pod Security Standards level following pod hardening best practices.
+	PodSecurityRestricted PodSecurityLevel = "restricted"
+)
+
+// PodSecurityAdmissionConfiguration sets the levels the PodSecurity admission controller applies
+// to the namespaces without pod-security.kubernetes.io labels. It's rendered into the kube-apiserver
+// admission configuration of every control plane node.
+type PodSecurityAdmissionConfiguration struct {
+	// Enforce is the level for which violations reject the pod. Defaults to privileged.
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+	// Audit is the level for which violations are recorded in the audit log. Defaults to privileged.
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+	// Warn is the level for which violations are returned as warnings to the user. Defaults to privileged.
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+	// Version is the Pod Security Standards version the levels are checked against, "latest"
+	// or a Kubernetes minor version like v1.27. Defaults to latest.
+	Version string `json:"version,omitempty"`
+	// ExemptNamespaces are namespaces not evaluated by the PodSecurity admission controller.
+	// kube-system and eksa-system are always exempt.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// Equal checks if two PodSecurityAdmissionConfigurations are equal.
+func (n *PodSecurityAdmissionConfiguration) Equal(o *PodSecurityAdmissionConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Enforce == o.Enforce && n.Audit == o.Audit && n.Warn == o.Warn &&
+		n.Version == o.Version && slices.Equal(n.ExemptNamespaces, o.ExemptNamespaces)
+}
+
+// PolicyEngineType identifies a policy engine supported as a managed add-on.
+type PolicyEngineType string
+
+// KyvernoPolicyEngine is the Kyverno policy engine.
+const KyvernoPolicyEngine PolicyEngineType = "kyverno"
+
+// PolicyEngineMode defines what happens to the resources violating a policy.
+type PolicyEngineMode string
+
+const (
+	// PolicyEngineEnforce rejects the resources violating a policy.
+	PolicyEngineEnforce PolicyEngineMode = "enforce"
+	// PolicyEngineAudit admits the resources violating a policy and reports the violation.
+	PolicyEngineAudit PolicyEngineMode = "audit"
+)
+
+// PolicyEngineConfiguration configures the managed policy engine add-on and its baseline policies:
+// no privileged containers and only images from an allowlist of registries.
+type PolicyEngineConfiguration struct {
+	// Engine is the policy engine to install. Only kyverno is supported.
+	Engine PolicyEngineType `json:"engine"`
+	// Mode is the action taken for resources violating the baseline policies, enforce or audit.
+	// Defaults to enforce.
+	Mode PolicyEngineMode `json:"mode,omitempty"`
+	// AllowedRegistries are allowed in pod images on top of the registries of the EKS Anywhere
+	// components, or the registry mirror when one is configured.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+}
+
+// Equal checks if two PolicyEngineConfigurations are equal.
+func (n *PolicyEngineConfiguration) Equal(o *PolicyEngineConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Engine == o.Engine && n.Mode == o.Mode && slices.Equal(n.AllowedRegistries, o.AllowedRegistries)
+}
+
+// CertManagerConfiguration configures the managed cert-manager add-on.
+type CertManagerConfiguration struct {
+	// Issuers are the ClusterIssuers created in the cluster.
+	Issuers []CertManagerIssuer `json:"issuers,omitempty"`
+}
+
+// CertManagerIssuer defines a cert-manager ClusterIssuer. Exactly one of ACME or Vault must be set.
+type CertManagerIssuer struct {
+	// Name is the name of the ClusterIssuer.
+	Name string `json:"name"`
+	// ACME issues certificates from an ACME server, solving DNS01 challenges with Route53.
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+	// Vault issues certificates from a Vault PKI secrets engine.
+	Vault *VaultIssuer `json:"vault,omitempty"`
+}
+
+// ACMEIssuer configures a ClusterIssuer for an ACME server.
+type ACMEIssuer struct {
+	// Server is the URL of the ACME server directory, like https://acme-v02.api.letsencrypt.org/directory.
+	Server string `json:"server"`
+	// Email is the address used to register the ACME account.
+	Email string `json:"email"`
+	// Route53 configures the DNS01 challenge solver.
+	Route53 Route53DNS01Solver `json:"route53"`
+}
+
+// Route53DNS01Solver configures the Route53 DNS01 challenge solver of an ACMEIssuer.
+type Route53DNS01Solver struct {
+	// Region is the AWS region of the Route53 API.
+	Region string `json:"region"`
+	// HostedZoneID limits the solver to a hosted zone. If empty, the zone is discovered.
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+	// CredentialsSecretName is a Secret in the cert-manager namespace with the access-key-id and
+	// secret-access-key keys. If empty, the ambient credentials of the cert-manager pod are used.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// VaultIssuer configures a ClusterIssuer for a Vault PKI secrets engine.
+type VaultIssuer struct {
+	// Server is the URL of the Vault server.
+	Server string `json:"server"`
+	// Path is the Vault path of the PKI role used to sign certificates, like pki/sign/example-dot-com.
+	Path string `json:"path"`
+	// CABundle is the base64 encoded PEM bundle used to validate the Vault server certificate.
+	CABundle string `json:"caBundle,omitempty"`
+	// TokenSecretName is a Secret in the cert-manager namespace with a token key to authenticate with Vault.
+	TokenSecretName string `json:"tokenSecretName"`
+}
+
+// Equal checks if two CertManagerConfigurations are equal.
+func (n *CertManagerConfiguration) Equal(o *CertManagerConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return slices.EqualFunc(n.Issuers, o.Issuers, func(a, b CertManagerIssuer) bool {
+		return a.Name == b.Name && pointerValuesEqual(a.ACME, b.ACME) && pointerValuesEqual(a.Vault, b.Vault)
+	})
+}
+
+// MetalLBMode is the protocol MetalLB uses to announce the Service addresses.
+type MetalLBMode string
+
+const (
+	// MetalLBL2Mode announces the addresses with ARP and NDP.
+	MetalLBL2Mode MetalLBMode = "l2"
+	// MetalLBBGPMode announces the addresses to BGP peers.
+	MetalLBBGPMode MetalLBMode = "bgp"
+)
+
+// MetalLBConfiguration configures the MetalLB curated package.
+type MetalLBConfiguration struct {
+	// Mode is how the addresses of the pools are announced, l2 or bgp. Defaults to l2.
+	Mode MetalLBMode `json:"mode,omitempty"`
+	// AddressPools are the addresses assigned to LoadBalancer Services.
+	AddressPools []MetalLBAddressPool `json:"addressPools"`
+	// BGPPeers are the routers MetalLB peers with in bgp mode.
+	BGPPeers []MetalLBBGPPeer `json:"bgpPeers,omitempty"`
+}
+
+// MetalLBAddressPool is a named set of addresses for LoadBalancer Services.
+type MetalLBAddressPool struct {
+	// Name of the pool. Services can request addresses from a pool with the
+	// metallb.universe.tf/address-pool annotation.
+	Name string `json:"name"`
+	// Addresses are CIDRs, like 10.0.0.0/28, or ranges, like 10.0.0.10-10.0.0.20.
+	Addresses []string `json:"addresses"`
+	// AutoAssign allows MetalLB to assign addresses from this pool to Services without the
+	// address-pool annotation. Defaults to true.
+	AutoAssign *bool `json:"autoAssign,omitempty"`
+}
+
+// MetalLBBGPPeer is a BGP router MetalLB announces the addresses to.
+type MetalLBBGPPeer struct {
+	// PeerAddress is the IP of the router.
+	PeerAddress string `json:"peerAddress"`
+	// PeerASN is the autonomous system number of the router.
+	PeerASN uint32 `json:"peerASN"`
+	// MyASN is the autonomous system number MetalLB uses.
+	MyASN uint32 `json:"myASN"`
+}
+
+// Equal checks if two MetalLBConfigurations are equal.
+func (n *MetalLBConfiguration) Equal(o *MetalLBConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Mode == o.Mode && slices.Equal(n.BGPPeers, o.BGPPeers) &&
+		slices.EqualFunc(n.AddressPools, o.AddressPools, func(a, b MetalLBAddressPool) bool {
+			return a.Name == b.Name && slices.Equal(a.Addresses, b.Addresses) && pointerValuesEqual(a.AutoAssign, b.AutoAssign)
+		})
+}
+
+// ProviderCredential references the external secret a provider credential is read from.
+type ProviderCredential struct {
+	// Name is the environment variable the provider reads the credential from, ex. EKSA_VSPHERE_PASSWORD.
+	Name string `json:"name"`
+	// ValueFrom references the secret holding the credential, with the format
+	// aws-secretsmanager://<secret name or ARN>[#<key>], vault://<secret path>#<key> or sops://<file path>#<key>.
+	ValueFrom string `json:"valueFrom"`
+}
+
+func pointerValuesEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func durationEqual(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Duration == b.Duration
+}
+
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
 	if len(s1) != len(s2) {
 		return false
@@ -367,6 +1147,56 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 type Endpoint struct {
 	// Host defines the ip that you want to use to connect to the control plane
 	Host string `json:"host"`
+	// DNS registers a DNS record for the control plane endpoint, so the API server
+	// can be reached through a stable hostname instead of the IP.
+	DNS *EndpointDNS `json:"dns,omitempty"`
+}
+
+// EndpointDNS configures the DNS record of the control plane endpoint. Only one of the
+// DNS providers can be set.
+type EndpointDNS struct {
+	// Name is the fully qualified name of the A record pointing to the endpoint host.
+	Name string `json:"name"`
+	// TTL of the record in seconds. Defaults to 300.
+	TTL int `json:"ttl,omitempty"`
+	// Route53 registers the record in an AWS Route53 hosted zone.
+	Route53 *Route53DNS `json:"route53,omitempty"`
+	// RFC2136 registers the record with dynamic updates to a DNS server, like BIND.
+	RFC2136 *RFC2136DNS `json:"rfc2136,omitempty"`
+	// Infoblox registers the record through the Infoblox WAPI.
+	Infoblox *InfobloxDNS `json:"infoblox,omitempty"`
+}
+
+// Route53DNS is an AWS Route53 hosted zone.
+type Route53DNS struct {
+	// HostedZoneID is the ID of the hosted zone the record is created in.
+	HostedZoneID string `json:"hostedZoneID"`
+}
+
+// RFC2136DNS is a DNS server accepting dynamic updates.
+type RFC2136DNS struct {
+	// Server is the address of the DNS server, host or host:port. The port defaults to 53.
+	Server string `json:"server"`
+	// Zone is the zone the record is updated in.
+	Zone string `json:"zone"`
+	// TSIGKeyName is the name of the key the updates are signed with. The updates are
+	// not signed when it's empty.
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+	// TSIGAlgorithm is the algorithm of the TSIG key, hmac-sha256 or hmac-sha512.
+	// Defaults to hmac-sha256.
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+}
+
+// InfobloxDNS is an Infoblox grid.
+type InfobloxDNS struct {
+	// Server is the address of the grid master, host or host:port.
+	Server string `json:"server"`
+	// View is the DNS view the record is created in. Defaults to default.
+	View string `json:"view,omitempty"`
+	// WAPIVersion is the version of the WAPI. Defaults to 2.11.
+	WAPIVersion string `json:"wapiVersion,omitempty"`
+	// InsecureSkipVerify disables the validation of the grid master certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // Equal compares if expected endpoint and existing endpoint are equal for non CloudStack clusters.
@@ -451,6 +1281,47 @@ type WorkerNodeGroupConfiguration struct {
 	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
 	// KuberenetesVersion defines the version for worker nodes. If not set, the top level spec kubernetesVersion will be used.
 	KubernetesVersion *KubernetesVersion `json:"kubernetesVersion,omitempty"`
+	// CapacityType defines the type of capacity backing the worker nodes. Spot capacity is cheaper
+	// but can be reclaimed by the infrastructure at any time. Defaults to onDemand.
+	CapacityType CapacityType `json:"capacityType,omitempty"`
+	// ReplacementStrategy defines how the worker nodes are replaced when their machines change.
+	// With blueGreen, a new set of nodes is created and becomes ready before the old nodes are
+	// cordoned, drained and deleted. Defaults to rolling.
+	ReplacementStrategy ReplacementStrategy `json:"replacementStrategy,omitempty"`
+}
+
+// CapacityType is the type of capacity backing the machines of a worker node group.
+type CapacityType string
+
+const (
+	// OnDemandCapacity machines are not reclaimed by the infrastructure.
+	OnDemandCapacity CapacityType = "onDemand"
+	// SpotCapacity machines can be reclaimed by the infrastructure at any time.
+	SpotCapacity CapacityType = "spot"
+
+	// CapacityTypeLabelName is the node label with the capacity type of spot worker nodes.
+	CapacityTypeLabelName = "anywhere.eks.amazonaws.com/capacity-type"
+)
+
+// IsSpot returns true if the worker node group runs on spot capacity.
+func (w WorkerNodeGroupConfiguration) IsSpot() bool {
+	return w.CapacityType == SpotCapacity
+}
+
+// ReplacementStrategy is the strategy used to replace the machines of a worker node group.
+type ReplacementStrategy string
+
+const (
+	// RollingReplacement replaces the machines in place following the upgradeRolloutStrategy.
+	RollingReplacement ReplacementStrategy = "rolling"
+	// BlueGreenReplacement creates a new group of machines and deletes the old one once the new
+	// machines are ready and the workloads have been drained from the old ones.
+	BlueGreenReplacement ReplacementStrategy = "blueGreen"
+)
+
+// IsBlueGreen returns true if the worker node group is replaced with the blue/green strategy.
+func (w WorkerNodeGroupConfiguration) IsBlueGreen() bool {
+	return w.ReplacementStrategy == BlueGreenReplacement
 }
 
 // Equal compares two WorkerNodeGroupConfigurations.
@@ -460,6 +1331,8 @@ func (w WorkerNodeGroupConfiguration) Equal(other WorkerNodeGroupConfiguration)
 		w.AutoScalingConfiguration.Equal(other.AutoScalingConfiguration) &&
 		w.MachineGroupRef.Equal(other.MachineGroupRef) &&
 		w.KubernetesVersion.Equal(other.KubernetesVersion) &&
+		w.CapacityType == other.CapacityType &&
+		w.ReplacementStrategy == other.ReplacementStrategy &&
 		TaintsSliceEqual(w.Taints, other.Taints) &&
 		MapEqual(w.Labels, other.Labels) &&
 		w.UpgradeRolloutStrategy.Equal(other.UpgradeRolloutStrategy)
@@ -538,6 +1411,9 @@ type ClusterNetwork struct {
 	CNIConfig *CNIConfig `json:"cniConfig,omitempty"`
 	DNS       DNS        `json:"dns,omitempty"`
 	Nodes     *Nodes     `json:"nodes,omitempty"`
+	// MTU of the node network interfaces. It's also configured in the CNI, which takes the
+	// encapsulation overhead into account. Defaults to the MTU of the node network.
+	MTU int `json:"mtu,omitempty"`
 }
 
 func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
@@ -561,7 +1437,8 @@ func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
 	return n.Pods.Equal(&o.Pods) &&
 		n.Services.Equal(&o.Services) &&
 		n.DNS.Equal(&o.DNS) &&
-		n.Nodes.Equal(o.Nodes)
+		n.Nodes.Equal(o.Nodes) &&
+		n.MTU == o.MTU
 }
 
 func getCNIConfig(cn *ClusterNetwork) *CNIConfig {
@@ -626,6 +1503,10 @@ func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
 		return false
 	}
 
+	if n.KubeProxyReplacement != o.KubeProxyReplacement {
+		return false
+	}
+
 	oSkipUpgradeIsFalse := o.SkipUpgrade == nil || !*o.SkipUpgrade
 	nSkipUpgradeIsFalse := n.SkipUpgrade == nil || !*n.SkipUpgrade
 
@@ -847,6 +1728,17 @@ type CiliumConfig struct {
 	// be used when operators wish to self manage the Cilium installation.
 	// +optional
 	SkipUpgrade *bool `json:"skipUpgrade,omitempty"`
+
+	// KubeProxyReplacement runs Cilium in kube-proxy replacement mode, where Services are
+	// implemented with eBPF and kube-proxy is not installed in the cluster. It can only be set
+	// when the cluster is created.
+	// +optional
+	KubeProxyReplacement bool `json:"kubeProxyReplacement,omitempty"`
+}
+
+// KubeProxyReplacementEnabled returns true if the cluster runs Cilium in kube-proxy replacement mode.
+func (n *ClusterNetwork) KubeProxyReplacementEnabled() bool {
+	return n.CNIConfig != nil && n.CNIConfig.Cilium != nil && n.CNIConfig.Cilium.KubeProxyReplacement
 }
 
 // IsManaged returns true if SkipUpgrade is nil or false indicating EKS-A is responsible for
@@ -949,8 +1841,59 @@ type ClusterStatus struct {
 	// subject to change in the future.
 	ChildrenReconciledGeneration int64 `json:"childrenReconciledGeneration,omitempty"`
 
+	// SpecHash is a hash of the specs of the cluster and all its linked objects, computed
+	// the last time the cluster was successfully reconciled. It allows to detect applies
+	// that don't change the cluster spec without comparing all the objects.
+	// NOTE: This field was added for internal use and we do not provide guarantees
+	// to its behavior if changed externally. Its meaning and implementation are
+	// subject to change in the future.
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// ReconcileProgress records the progress of the reconciliation in flight, so a controller
+	// restarted in the middle of it, like during an upgrade, resumes it instead of starting over.
+	// It's removed once the cluster is successfully reconciled.
+	// NOTE: This field was added for internal use and we do not provide guarantees
+	// to its behavior if changed externally. Its meaning and implementation are
+	// subject to change in the future.
+	// +optional
+	ReconcileProgress *ReconcileProgress `json:"reconcileProgress,omitempty"`
+
 	// ObservedGeneration is the latest generation observed by the controller.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ProviderCredentialsRotatedAt is the last time the provider credentials used by the cluster
+	// were rotated with the CLI.
+	// +optional
+	ProviderCredentialsRotatedAt *metav1.Time `json:"providerCredentialsRotatedAt,omitempty"`
+}
+
+// ReconcilePhase is a step of the cluster reconciliation.
+type ReconcilePhase string
+
+const (
+	// SelfUpgradeReconcilePhase upgrades the eks-a components of self-managed clusters.
+	SelfUpgradeReconcilePhase ReconcilePhase = "SelfUpgrade"
+	// PreProviderReconcilePhase validates the cluster before reconciling its infrastructure.
+	PreProviderReconcilePhase ReconcilePhase = "PreProvider"
+	// ProviderReconcilePhase reconciles the control plane, CNI and workers of the cluster.
+	ProviderReconcilePhase ReconcilePhase = "Provider"
+	// PostProviderReconcilePhase reconciles the cluster add-ons, like the machine health checks.
+	PostProviderReconcilePhase ReconcilePhase = "PostProvider"
+	// PackagesReconcilePhase reconciles the curated packages of the cluster.
+	PackagesReconcilePhase ReconcilePhase = "Packages"
+)
+
+// ReconcileProgress is the progress of a cluster reconciliation.
+type ReconcileProgress struct {
+	// SpecHash is the hash of the cluster spec being reconciled.
+	SpecHash string `json:"specHash"`
+	// Phase is the last phase the reconciliation started.
+	Phase ReconcilePhase `json:"phase,omitempty"`
+	// StartedAt is when the reconciliation of the spec started.
+	StartedAt metav1.Time `json:"startedAt"`
+	// Operation is the name of the ClusterOperation recording the reconciliation, if any.
+	Operation string `json:"operation,omitempty"`
 }
 
 type EksdReleaseRef struct {
@@ -1020,6 +1963,13 @@ type PackageConfiguration struct {
 
 	// Cronjob for ecr token refresher
 	CronJob *PackageControllerCronJob `json:"cronjob,omitempty"`
+
+	// Region is the AWS region of the ECR registry curated packages are pulled from.
+	// It takes precedence over the EKSA_AWS_REGION environment variable.
+	Region string `json:"region,omitempty"`
+
+	// BundleRegistry overrides the registry the package controller pulls the package bundles from.
+	BundleRegistry string `json:"bundleRegistry,omitempty"`
 }
 
 // Equal for PackageConfiguration.
@@ -1030,7 +1980,8 @@ func (n *PackageConfiguration) Equal(o *PackageConfiguration) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Disable == o.Disable && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob)
+	return n.Disable == o.Disable && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob) &&
+		n.Region == o.Region && n.BundleRegistry == o.BundleRegistry
 }
 
 // PackageControllerConfiguration configure aspects of package controller.
@@ -1289,6 +2240,12 @@ func (c *Cluster) ControlPlaneIPCheckDisabled() bool {
 	return false
 }
 
+// ControlPlaneEndpointMigrationHost returns the host the control plane endpoint is being migrated
+// to, or an empty string if it's not being migrated.
+func (c *Cluster) ControlPlaneEndpointMigrationHost() string {
+	return c.Annotations[ControlPlaneEndpointMigrationAnnotation]
+}
+
 func (c *Cluster) ResourceType() string {
 	return clusterResourceType
 }
@@ -1301,6 +2258,15 @@ func (c *Cluster) IsSelfManaged() bool {
 	return c.Spec.ManagementCluster.Name == "" || c.Spec.ManagementCluster.Name == c.Name
 }
 
+// ExpiresAt returns the time at which the cluster should be deleted according to its TTL.
+// It returns false if the cluster doesn't have a TTL.
+func (c *Cluster) ExpiresAt() (time.Time, bool) {
+	if c.Spec.TTL == nil {
+		return time.Time{}, false
+	}
+	return c.CreationTimestamp.Add(c.Spec.TTL.Duration), true
+}
+
 func (c *Cluster) SetManagedBy(managementClusterName string) {
 	if c.Annotations == nil {
 		c.Annotations = map[string]string{}
@@ -1432,6 +2398,18 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 			BundlesRef:                    c.Spec.BundlesRef,
 			EksaVersion:                   c.Spec.EksaVersion,
 			MachineHealthCheck:            c.Spec.MachineHealthCheck,
+			ClusterLabels:                 c.Spec.ClusterLabels,
+			TTL:                           c.Spec.TTL,
+			ManagementControllers:         c.Spec.ManagementControllers,
+			ImageWarmCache:                c.Spec.ImageWarmCache,
+			AccessEntries:                 c.Spec.AccessEntries,
+			PodSecurityAdmission:          c.Spec.PodSecurityAdmission,
+			PolicyEngine:                  c.Spec.PolicyEngine,
+			CertManager:                   c.Spec.CertManager,
+			MetalLB:                       c.Spec.MetalLB,
+			ProviderCredentials:           c.Spec.ProviderCredentials,
+			ComponentImageOverrides:       c.Spec.ComponentImageOverrides,
+			TemplateOverrides:             c.Spec.TemplateOverrides,
 		},
 	}
 
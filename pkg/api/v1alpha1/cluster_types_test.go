@@ -3,6 +3,7 @@ package v1alpha1_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -139,6 +140,136 @@ func TestClusterIsSelfManaged(t *testing.T) {
 	}
 }
 
+func TestClusterIsSelfUpgradeEnabled(t *testing.T) {
+	testCases := []struct {
+		testName string
+		cluster  *v1alpha1.Cluster
+		want     bool
+	}{
+		{
+			testName: "no management controllers",
+			cluster:  &v1alpha1.Cluster{},
+			want:     false,
+		},
+		{
+			testName: "self upgrade disabled",
+			cluster: &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					ManagementControllers: &v1alpha1.ManagementControllersConfiguration{Concurrency: 10},
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "self upgrade enabled",
+			cluster: &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					ManagementControllers: &v1alpha1.ManagementControllersConfiguration{SelfUpgrade: true},
+				},
+			},
+			want: true,
+		},
+		{
+			testName: "workload cluster",
+			cluster: &v1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-2",
+				},
+				Spec: v1alpha1.ClusterSpec{
+					ManagementCluster: v1alpha1.ManagementCluster{
+						Name: "cluster-1",
+					},
+					ManagementControllers: &v1alpha1.ManagementControllersConfiguration{SelfUpgrade: true},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.cluster.IsSelfUpgradeEnabled()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestManagementControllersConfigurationEqual(t *testing.T) {
+	testCases := []struct {
+		testName string
+		a, b     *v1alpha1.ManagementControllersConfiguration
+		want     bool
+	}{
+		{
+			testName: "both nil",
+			want:     true,
+		},
+		{
+			testName: "one nil",
+			a:        &v1alpha1.ManagementControllersConfiguration{},
+			want:     false,
+		},
+		{
+			testName: "same leader election",
+			a: &v1alpha1.ManagementControllersConfiguration{
+				Replicas:       2,
+				LeaderElection: &v1alpha1.LeaderElectionConfiguration{LeaseDuration: &metav1.Duration{Duration: time.Minute}},
+			},
+			b: &v1alpha1.ManagementControllersConfiguration{
+				Replicas:       2,
+				LeaderElection: &v1alpha1.LeaderElectionConfiguration{LeaseDuration: &metav1.Duration{Duration: time.Minute}},
+			},
+			want: true,
+		},
+		{
+			testName: "different replicas",
+			a:        &v1alpha1.ManagementControllersConfiguration{Replicas: 2},
+			b:        &v1alpha1.ManagementControllersConfiguration{Replicas: 3},
+			want:     false,
+		},
+		{
+			testName: "different machine concurrency",
+			a:        &v1alpha1.ManagementControllersConfiguration{MachineConcurrency: 5},
+			b:        &v1alpha1.ManagementControllersConfiguration{MachineConcurrency: 10},
+			want:     false,
+		},
+		{
+			testName: "different leader election",
+			a: &v1alpha1.ManagementControllersConfiguration{
+				LeaderElection: &v1alpha1.LeaderElectionConfiguration{RetryPeriod: &metav1.Duration{Duration: time.Second}},
+			},
+			b: &v1alpha1.ManagementControllersConfiguration{
+				LeaderElection: &v1alpha1.LeaderElectionConfiguration{},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.a.Equal(tt.b)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestManagementControllersConfigurationControllerReplicas(t *testing.T) {
+	g := NewWithT(t)
+	var c *v1alpha1.ManagementControllersConfiguration
+	g.Expect(c.ControllerReplicas()).To(Equal(1))
+	g.Expect((&v1alpha1.ManagementControllersConfiguration{}).ControllerReplicas()).To(Equal(1))
+	g.Expect((&v1alpha1.ManagementControllersConfiguration{Replicas: 3}).ControllerReplicas()).To(Equal(3))
+}
+
+func TestLeaderElectionConfigurationDurations(t *testing.T) {
+	g := NewWithT(t)
+	var c *v1alpha1.LeaderElectionConfiguration
+	lease, renew, retry := c.Durations()
+	g.Expect([]time.Duration{lease, renew, retry}).To(Equal([]time.Duration{15 * time.Second, 10 * time.Second, 2 * time.Second}))
+
+	c = &v1alpha1.LeaderElectionConfiguration{RenewDeadline: &metav1.Duration{Duration: 20 * time.Second}}
+	lease, renew, retry = c.Durations()
+	g.Expect([]time.Duration{lease, renew, retry}).To(Equal([]time.Duration{15 * time.Second, 20 * time.Second, 2 * time.Second}))
+}
+
 func TestClusterSetManagedBy(t *testing.T) {
 	c := &v1alpha1.Cluster{}
 	managementClusterName := "managament-cluster"
@@ -1420,6 +1551,227 @@ func TestClusterEqualEksaVersion(t *testing.T) {
 	}
 }
 
+func TestClusterEqualTLSConfiguration(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		config1, config2 *v1alpha1.TLSConfiguration
+		want             bool
+	}{
+		{
+			testName: "both nil",
+			want:     true,
+		},
+		{
+			testName: "one nil, one exists",
+			config1:  &v1alpha1.TLSConfiguration{MinVersion: v1alpha1.TLSVersion13},
+			want:     false,
+		},
+		{
+			testName: "both exist, same",
+			config1:  &v1alpha1.TLSConfiguration{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			config2:  &v1alpha1.TLSConfiguration{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			want:     true,
+		},
+		{
+			testName: "both exist, diff cipher suites",
+			config1:  &v1alpha1.TLSConfiguration{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			config2:  &v1alpha1.TLSConfiguration{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			want:     false,
+		},
+		{
+			testName: "both exist, diff min version",
+			config1:  &v1alpha1.TLSConfiguration{MinVersion: v1alpha1.TLSVersion12},
+			config2:  &v1alpha1.TLSConfiguration{MinVersion: v1alpha1.TLSVersion13},
+			want:     false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster1 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					TLSConfiguration: tt.config1,
+				},
+			}
+			cluster2 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					TLSConfiguration: tt.config2,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster1.Equal(cluster2)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterEqualHelmPostRenderer(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		renderer1, renderer2 *v1alpha1.HelmPostRenderer
+		want                 bool
+	}{
+		{
+			testName: "both nil",
+			want:     true,
+		},
+		{
+			testName:  "one nil, one exists",
+			renderer1: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels"},
+			want:      false,
+		},
+		{
+			testName:  "both exist, same",
+			renderer1: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels", Args: []string{"prod"}},
+			renderer2: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels", Args: []string{"prod"}},
+			want:      true,
+		},
+		{
+			testName:  "both exist, diff binary path",
+			renderer1: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels"},
+			renderer2: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-sidecars"},
+			want:      false,
+		},
+		{
+			testName:  "both exist, diff args",
+			renderer1: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels", Args: []string{"prod"}},
+			renderer2: &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels", Args: []string{"dev"}},
+			want:      false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster1 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					HelmPostRenderer: tt.renderer1,
+				},
+			}
+			cluster2 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					HelmPostRenderer: tt.renderer2,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster1.Equal(cluster2)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterEqualScale(t *testing.T) {
+	cluster1 := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			Scale: v1alpha1.ClusterScaleLarge,
+		},
+	}
+	cluster2 := cluster1.DeepCopy()
+
+	g := NewWithT(t)
+	g.Expect(cluster1.Equal(cluster2)).To(BeTrue())
+
+	cluster2.Spec.Scale = v1alpha1.ClusterScaleStandard
+	g.Expect(cluster1.Equal(cluster2)).To(BeFalse())
+}
+
+func TestClusterEqualPinImageDigests(t *testing.T) {
+	cluster1 := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			PinImageDigests: true,
+		},
+	}
+	cluster2 := cluster1.DeepCopy()
+
+	g := NewWithT(t)
+	g.Expect(cluster1.Equal(cluster2)).To(BeTrue())
+
+	cluster2.Spec.PinImageDigests = false
+	g.Expect(cluster1.Equal(cluster2)).To(BeFalse())
+}
+
+func TestClusterEqualClusterResourceSets(t *testing.T) {
+	cluster1 := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ClusterResourceSets: []v1alpha1.ClusterResourceSet{
+				{Name: "storage", ConfigMaps: []string{"storage-classes", "priority-classes"}},
+			},
+		},
+	}
+	cluster2 := cluster1.DeepCopy()
+
+	g := NewWithT(t)
+	g.Expect(cluster1.Equal(cluster2)).To(BeTrue())
+
+	cluster2.Spec.ClusterResourceSets[0].ConfigMaps = []string{"priority-classes", "storage-classes"}
+	g.Expect(cluster1.Equal(cluster2)).To(BeFalse())
+}
+
+func TestClusterNodeCount(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{Count: 3},
+			ExternalEtcdConfiguration: &v1alpha1.ExternalEtcdConfiguration{Count: 3},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr.Int(10)},
+				{
+					Name:                     "md-1",
+					Count:                    ptr.Int(5),
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 5, MaxCount: 50},
+				},
+			},
+		},
+	}
+
+	g.Expect(cluster.NodeCount()).To(Equal(66))
+}
+
+func TestClusterIsLargeScale(t *testing.T) {
+	testCases := []struct {
+		testName string
+		scale    v1alpha1.ClusterScale
+		workers  int
+		want     bool
+	}{
+		{
+			testName: "small cluster",
+			workers:  97,
+			want:     false,
+		},
+		{
+			testName: "cluster above the threshold",
+			workers:  98,
+			want:     true,
+		},
+		{
+			testName: "small cluster with large scale",
+			scale:    v1alpha1.ClusterScaleLarge,
+			workers:  3,
+			want:     true,
+		},
+		{
+			testName: "cluster above the threshold with standard scale",
+			scale:    v1alpha1.ClusterScaleStandard,
+			workers:  500,
+			want:     false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{Count: 3},
+					WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+						{Name: "md-0", Count: ptr.Int(tt.workers)},
+					},
+					Scale: tt.scale,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster.IsLargeScale()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestClusterEqualDifferentBundlesRef(t *testing.T) {
 	cluster1 := &v1alpha1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2112,6 +2464,18 @@ func TestPackageConfiguration_Equal(t *testing.T) {
 			pco:  &v1alpha1.PackageConfiguration{Disable: false},
 			want: false,
 		},
+		{
+			name: "not equal region",
+			pcn:  &v1alpha1.PackageConfiguration{Region: "us-west-2"},
+			pco:  &v1alpha1.PackageConfiguration{Region: "us-east-2"},
+			want: false,
+		},
+		{
+			name: "not equal bundle registry",
+			pcn:  &v1alpha1.PackageConfiguration{BundleRegistry: "a.example.com"},
+			pco:  &v1alpha1.PackageConfiguration{BundleRegistry: "b.example.com"},
+			want: false,
+		},
 		{
 			name: "not equal controller",
 			pcn: &v1alpha1.PackageConfiguration{
@@ -3169,3 +3533,47 @@ func TestCNIConfigIsManaged(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateOverrideMatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		override   v1alpha1.TemplateOverride
+		kind, obj  string
+		wantResult bool
+	}{
+		{
+			name:       "any name",
+			override:   v1alpha1.TemplateOverride{Kind: "KubeadmControlPlane"},
+			kind:       "KubeadmControlPlane",
+			obj:        "test-cluster",
+			wantResult: true,
+		},
+		{
+			name:       "different kind",
+			override:   v1alpha1.TemplateOverride{Kind: "KubeadmControlPlane"},
+			kind:       "KubeadmConfigTemplate",
+			obj:        "test-cluster",
+			wantResult: false,
+		},
+		{
+			name:       "matching glob",
+			override:   v1alpha1.TemplateOverride{Kind: "KubeadmConfigTemplate", Name: "*-md-0-*"},
+			kind:       "KubeadmConfigTemplate",
+			obj:        "test-cluster-md-0-1",
+			wantResult: true,
+		},
+		{
+			name:       "not matching glob",
+			override:   v1alpha1.TemplateOverride{Kind: "KubeadmConfigTemplate", Name: "*-md-0-*"},
+			kind:       "KubeadmConfigTemplate",
+			obj:        "test-cluster-md-1-1",
+			wantResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.override.Matches(tt.kind, tt.obj)).To(Equal(tt.wantResult))
+		})
+	}
+}
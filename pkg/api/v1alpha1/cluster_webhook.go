@@ -107,6 +107,8 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) error {
 
 	allErrs = append(allErrs, ValidateWorkerKubernetesVersionSkew(r, oldCluster)...)
 
+	allErrs = append(allErrs, validateTTLCluster(r, oldCluster)...)
+
 	if err := ValidateEtcdEncryptionConfig(r.Spec.EtcdEncryption); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec.etcdEncryption"), r.Spec.EtcdEncryption, err.Error()))
 	}
@@ -126,6 +128,25 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) error {
 	return nil
 }
 
+// validateTTLCluster only allows the ttl of an existing cluster to be extended or removed. The ttl is
+// counted from the creation of the cluster, so adding or shortening it could delete the cluster right away.
+func validateTTLCluster(new, old *Cluster) field.ErrorList {
+	if new.Spec.TTL == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec", "ttl")
+	if old.Spec.TTL == nil {
+		return field.ErrorList{field.Forbidden(path, "ttl can't be added to an existing cluster")}
+	}
+
+	if new.Spec.TTL.Duration < old.Spec.TTL.Duration {
+		return field.ErrorList{field.Forbidden(path, fmt.Sprintf("ttl can only be extended, got %s but current ttl is %s", new.Spec.TTL.Duration, old.Spec.TTL.Duration))}
+	}
+
+	return nil
+}
+
 // ValidateEksaVersionSkew ensures that upgrades are sequential by CLI minor versions.
 func ValidateEksaVersionSkew(new, old *Cluster) field.ErrorList {
 	var allErrs field.ErrorList
@@ -268,7 +289,8 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("datacenterRef"), fmt.Sprintf("field is immutable %v", new.Spec.DatacenterRef)))
 	}
 
-	if !new.Spec.ControlPlaneConfiguration.Endpoint.Equal(old.Spec.ControlPlaneConfiguration.Endpoint, new.Spec.DatacenterRef.Kind) {
+	if !new.Spec.ControlPlaneConfiguration.Endpoint.Equal(old.Spec.ControlPlaneConfiguration.Endpoint, new.Spec.DatacenterRef.Kind) &&
+		!isControlPlaneEndpointMigration(new, old) {
 		allErrs = append(
 			allErrs,
 			field.Forbidden(specPath.Child("ControlPlaneConfiguration.endpoint"), fmt.Sprintf("field is immutable %v", new.Spec.ControlPlaneConfiguration.Endpoint)))
@@ -307,6 +329,12 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 		)
 	}
 
+	if new.Spec.ClusterNetwork.KubeProxyReplacementEnabled() != old.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("clusterNetwork", "cniConfig", "cilium", "kubeProxyReplacement"), "field is immutable"))
+	}
+
 	if !new.Spec.ClusterNetwork.Nodes.Equal(old.Spec.ClusterNetwork.Nodes) {
 		allErrs = append(
 			allErrs,
@@ -340,6 +368,18 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("GitOpsRef"), fmt.Sprintf("field is immutable %v", new.Spec.GitOpsRef)))
 	}
 
+	if !AccessEntriesEqual(new.Spec.AccessEntries, old.Spec.AccessEntries) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("accessEntries"), "field is immutable"))
+	}
+
+	if !new.Spec.MetalLB.Equal(old.Spec.MetalLB) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("metalLB"), "field is immutable"))
+	}
+
 	if new.Spec.DatacenterRef.Kind == TinkerbellDatacenterKind {
 		if !reflect.DeepEqual(new.Spec.ControlPlaneConfiguration.Labels, old.Spec.ControlPlaneConfiguration.Labels) {
 			allErrs = append(
@@ -422,6 +462,19 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	return allErrs
 }
 
+// isControlPlaneEndpointMigration returns true if the control plane endpoint host changes to the
+// host of the endpoint migration annotation, the only endpoint change allowed. The annotation and the
+// paused annotation must already be set in the current cluster, so they can't be set in the same update
+// that changes the endpoint.
+func isControlPlaneEndpointMigration(new, old *Cluster) bool {
+	host := old.ControlPlaneEndpointMigrationHost()
+	return host != "" &&
+		old.IsReconcilePaused() &&
+		old.Spec.ControlPlaneConfiguration.Endpoint != nil &&
+		new.Spec.ControlPlaneConfiguration.Endpoint != nil &&
+		new.Spec.ControlPlaneConfiguration.Endpoint.Host == host
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (r *Cluster) ValidateDelete() error {
 	clusterlog.Info("validate delete", "name", r.Name)
@@ -473,7 +526,6 @@ func validateKubeVersionSkew(newVersion, oldVersion KubernetesVersion, path *fie
 // ValidateWorkerKubernetesVersionSkew validates worker node group Kubernetes version skew between upgrades.
 func ValidateWorkerKubernetesVersionSkew(new, old *Cluster) field.ErrorList {
 	var allErrs field.ErrorList
-	path := field.NewPath("spec").Child("WorkerNodeConfiguration.kubernetesVersion")
 
 	newClusterVersion := new.Spec.KubernetesVersion
 	oldClusterVersion := old.Spec.KubernetesVersion
@@ -485,11 +537,6 @@ func ValidateWorkerKubernetesVersionSkew(new, old *Cluster) field.ErrorList {
 	for _, nodeGroupNewSpec := range new.Spec.WorkerNodeGroupConfigurations {
 		newVersion := nodeGroupNewSpec.KubernetesVersion
 
-		if newVersion != nil && nodeGroupNewSpec.MachineGroupRef.Kind == TinkerbellMachineConfigKind {
-			allErrs = append(allErrs, field.Forbidden(path, "worker node group level kubernetesVersion is not supported for Tinkerbell"))
-			return allErrs
-		}
-
 		if workerNodeGrpOldSpec, ok := workerNodeGroupMap[nodeGroupNewSpec.Name]; ok {
 			oldVersion := workerNodeGrpOldSpec.KubernetesVersion
 			allErrs = append(allErrs, performWorkerKubernetesValidations(oldVersion, newVersion, oldClusterVersion, newClusterVersion)...)
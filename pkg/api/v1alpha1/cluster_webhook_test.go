@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -97,6 +98,55 @@ func TestClusterValidateUpdateManagementBothNilImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
 }
 
+func TestClusterValidateUpdateTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldTTL  *metav1.Duration
+		newTTL  *metav1.Duration
+		wantErr string
+	}{
+		{
+			name:    "added",
+			newTTL:  &metav1.Duration{Duration: 8 * time.Hour},
+			wantErr: "ttl can't be added to an existing cluster",
+		},
+		{
+			name:    "shortened",
+			oldTTL:  &metav1.Duration{Duration: 8 * time.Hour},
+			newTTL:  &metav1.Duration{Duration: time.Hour},
+			wantErr: "ttl can only be extended",
+		},
+		{
+			name:   "extended",
+			oldTTL: &metav1.Duration{Duration: 8 * time.Hour},
+			newTTL: &metav1.Duration{Duration: 24 * time.Hour},
+		},
+		{
+			name:   "removed",
+			oldTTL: &metav1.Duration{Duration: 8 * time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cOld := baseCluster(func(c *v1alpha1.Cluster) {
+				c.Name = "workload"
+				c.SetManagedBy("mgmt")
+				c.Spec.TTL = tt.oldTTL
+			})
+			c := cOld.DeepCopy()
+			c.Spec.TTL = tt.newTTL
+
+			err := c.ValidateUpdate(cOld)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func TestManagementClusterValidateUpdateKubernetesVersionImmutable(t *testing.T) {
 	features.ClearCache()
 	cOld := &v1alpha1.Cluster{
@@ -682,6 +732,28 @@ func TestClusterValidateUpdateProxyConfigurationNewNilImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.ProxyConfiguration: Forbidden: field is immutable")))
 }
 
+func TestClusterValidateUpdateAccessEntriesImmutable(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.AccessEntries = []v1alpha1.AccessEntry{{Group: "developers", ClusterRole: "view"}}
+	c := cOld.DeepCopy()
+	c.Spec.AccessEntries[0].ClusterRole = "cluster-admin"
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.accessEntries: Forbidden: field is immutable")))
+}
+
+func TestClusterValidateUpdateMetalLBImmutable(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.MetalLB = &v1alpha1.MetalLBConfiguration{
+		AddressPools: []v1alpha1.MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.0/28"}}},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.MetalLB.AddressPools[0].Addresses = []string{"10.0.1.0/28"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.metalLB: Forbidden: field is immutable")))
+}
+
 func TestClusterValidateUpdateGitOpsRefImmutableNilEqual(t *testing.T) {
 	cOld := baseCluster()
 	cOld.Spec.GitOpsRef = nil
@@ -1530,7 +1602,7 @@ func TestClusterUpdateControlPlaneLabelsInvalid(t *testing.T) {
 		Labels: map[string]string{
 			"test": "val1",
 		},
-		Endpoint:        &v1alpha1.Endpoint{"1.1.1.1"},
+		Endpoint:        &v1alpha1.Endpoint{Host: "1.1.1.1"},
 		MachineGroupRef: &v1alpha1.Ref{},
 		Count:           1,
 	}
@@ -2025,6 +2097,16 @@ func TestClusterValidateUpdateSkipUpgradeImmutability(t *testing.T) {
 	}
 }
 
+func TestClusterValidateUpdateKubeProxyReplacementImmutable(t *testing.T) {
+	cOld := baseCluster()
+	cNew := cOld.DeepCopy()
+	cNew.Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement = true
+
+	g := NewWithT(t)
+	err := cNew.ValidateUpdate(cOld)
+	g.Expect(err).To(MatchError(ContainSubstring("spec.clusterNetwork.cniConfig.cilium.kubeProxyReplacement: Forbidden: field is immutable")))
+}
+
 func TestClusterValidateUpdateVersionSkew(t *testing.T) {
 	features.ClearCache()
 	cOld := baseCluster()
@@ -2317,7 +2399,7 @@ func TestValidateWorkerVersionSkewAddNodeGroup(t *testing.T) {
 	g.Expect(err).To(Succeed())
 }
 
-func TestValidateWorkerVersionBlockTinkerbell(t *testing.T) {
+func TestValidateWorkerVersionTinkerbell(t *testing.T) {
 	kube119 := v1alpha1.KubernetesVersion("1.19")
 
 	newCluster := baseCluster()
@@ -2341,5 +2423,52 @@ func TestValidateWorkerVersionBlockTinkerbell(t *testing.T) {
 
 	err := newCluster.ValidateUpdate(oldCluster)
 	g := NewWithT(t)
-	g.Expect(err).ToNot(BeNil())
+	g.Expect(err).To(Succeed())
+}
+
+func TestClusterValidateUpdateControlPlaneEndpointMigration(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.1.1.1"}
+	cOld.Annotations = map[string]string{
+		cOld.PausedAnnotation():                          "true",
+		v1alpha1.ControlPlaneEndpointMigrationAnnotation: "2.2.2.2",
+	}
+	c := cOld.DeepCopy()
+	c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "2.2.2.2"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
+func TestClusterValidateUpdateControlPlaneEndpointMigrationSameUpdate(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.1.1.1"}
+	c := cOld.DeepCopy()
+	c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "2.2.2.2"}
+	c.Annotations = map[string]string{v1alpha1.ControlPlaneEndpointMigrationAnnotation: "2.2.2.2"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.ControlPlaneConfiguration.endpoint: Forbidden: field is immutable")))
+}
+
+func TestClusterValidateUpdateControlPlaneEndpointMigrationNotPaused(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.1.1.1"}
+	cOld.Annotations = map[string]string{v1alpha1.ControlPlaneEndpointMigrationAnnotation: "2.2.2.2"}
+	c := cOld.DeepCopy()
+	c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "2.2.2.2"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.ControlPlaneConfiguration.endpoint: Forbidden: field is immutable")))
+}
+
+func TestClusterValidateUpdateControlPlaneEndpointMigrationOtherHost(t *testing.T) {
+	cOld := baseCluster()
+	cOld.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.1.1.1"}
+	cOld.Annotations = map[string]string{v1alpha1.ControlPlaneEndpointMigrationAnnotation: "2.2.2.2"}
+	c := cOld.DeepCopy()
+	c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "3.3.3.3"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.ControlPlaneConfiguration.endpoint: Forbidden: field is immutable")))
 }
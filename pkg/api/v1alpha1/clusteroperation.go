@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterOperationKind is the object kind name for ClusterOperation.
+	ClusterOperationKind = "ClusterOperation"
+
+	// ClusterOperationClusterLabel is the label with the name of the cluster a ClusterOperation belongs to.
+	ClusterOperationClusterLabel = "anywhere.eks.amazonaws.com/cluster-name"
+)
+
+// NewClusterOperation returns an in progress ClusterOperation for a cluster, started at startedAt.
+func NewClusterOperation(cluster *Cluster, opType ClusterOperationType, initiator ClusterOperationInitiator, startedAt time.Time) *ClusterOperation {
+	namespace := cluster.Namespace
+	if namespace == "" {
+		namespace = defaultEksaNamespace
+	}
+
+	return &ClusterOperation{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersion.String(),
+			Kind:       ClusterOperationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterOperationName(cluster.Name, opType, startedAt),
+			Namespace: namespace,
+			Labels:    map[string]string{ClusterOperationClusterLabel: cluster.Name},
+		},
+		Spec: ClusterOperationSpec{
+			ClusterName: cluster.Name,
+			Type:        opType,
+			Initiator:   initiator,
+		},
+		Status: ClusterOperationStatus{
+			StartedAt: metav1.NewTime(startedAt),
+			Outcome:   OperationInProgress,
+		},
+	}
+}
+
+// ClusterOperationName returns the name of the ClusterOperation object for an operation on a cluster.
+func ClusterOperationName(clusterName string, opType ClusterOperationType, startedAt time.Time) string {
+	return fmt.Sprintf("%s-%s-%s", clusterName, strings.ToLower(string(opType)), startedAt.UTC().Format("20060102150405"))
+}
+
+// IsInProgress returns true if the operation hasn't finished yet.
+func (o *ClusterOperation) IsInProgress() bool {
+	return o.Status.Outcome == OperationInProgress
+}
+
+// Complete marks the operation as finished at completedAt. The operation fails if err is not nil.
+func (o *ClusterOperation) Complete(completedAt time.Time, err error) {
+	if err != nil {
+		o.finish(completedAt, OperationFailed, err.Error())
+		return
+	}
+	o.finish(completedAt, OperationSucceeded, "")
+}
+
+// Supersede marks the operation as replaced by a newer one before it finished.
+func (o *ClusterOperation) Supersede(completedAt time.Time) {
+	o.finish(completedAt, OperationSuperseded, "the cluster spec changed before the operation finished")
+}
+
+func (o *ClusterOperation) finish(completedAt time.Time, outcome ClusterOperationOutcome, message string) {
+	t := metav1.NewTime(completedAt)
+	o.Status.CompletedAt = &t
+	o.Status.Outcome = outcome
+	o.Status.Message = message
+}
@@ -0,0 +1,137 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterOperationType is the kind of lifecycle operation run on a cluster.
+type ClusterOperationType string
+
+const (
+	// CreateClusterOperation creates a new cluster.
+	CreateClusterOperation ClusterOperationType = "Create"
+
+	// UpgradeClusterOperation applies a new spec to an existing cluster.
+	UpgradeClusterOperation ClusterOperationType = "Upgrade"
+
+	// DeleteClusterOperation deletes a cluster.
+	DeleteClusterOperation ClusterOperationType = "Delete"
+
+	// ScaleClusterOperation changes the number of nodes of a worker node group.
+	ScaleClusterOperation ClusterOperationType = "Scale"
+
+	// EmergencyAccessClusterOperation authorizes a temporary SSH key in the nodes of a cluster.
+	EmergencyAccessClusterOperation ClusterOperationType = "EmergencyAccess"
+)
+
+// ClusterOperationInitiatorType is the kind of actor that started a ClusterOperation.
+type ClusterOperationInitiatorType string
+
+const (
+	// CLIInitiator means the operation was run by the eksctl anywhere CLI.
+	CLIInitiator ClusterOperationInitiatorType = "CLI"
+
+	// GitOpsInitiator means the operation was started by a change applied by Flux from the git repository.
+	GitOpsInitiator ClusterOperationInitiatorType = "GitOps"
+
+	// ControllerInitiator means the operation was started by a change applied directly to the
+	// cluster objects, like with kubectl, and run by the EKS-A controller.
+	ControllerInitiator ClusterOperationInitiatorType = "Controller"
+)
+
+// ClusterOperationInitiator identifies who started a ClusterOperation.
+type ClusterOperationInitiator struct {
+	// Type is the kind of actor that started the operation.
+	Type ClusterOperationInitiatorType `json:"type"`
+
+	// User is the OS user that ran the CLI. It's only set for CLI operations.
+	// +optional
+	User string `json:"user,omitempty"`
+}
+
+// ClusterOperationSpec defines the operation recorded by a ClusterOperation.
+type ClusterOperationSpec struct {
+	// ClusterName is the name of the cluster the operation was run on.
+	ClusterName string `json:"clusterName"`
+
+	// Type is the kind of operation.
+	Type ClusterOperationType `json:"type"`
+
+	// Initiator identifies who started the operation.
+	Initiator ClusterOperationInitiator `json:"initiator"`
+
+	// SpecHash is the hash of the cluster spec applied by the operation.
+	// It's not set for delete operations.
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// ClusterGeneration is the generation of the Cluster object produced by the operation, when known.
+	// +optional
+	ClusterGeneration int64 `json:"clusterGeneration,omitempty"`
+
+	// Description gives the details of operations that don't change the cluster spec, like the reason
+	// and the key of an emergency access.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ClusterOperationOutcome is the result of a ClusterOperation.
+type ClusterOperationOutcome string
+
+const (
+	// OperationInProgress means the operation hasn't finished yet.
+	OperationInProgress ClusterOperationOutcome = "InProgress"
+
+	// OperationSucceeded means the operation finished successfully.
+	OperationSucceeded ClusterOperationOutcome = "Succeeded"
+
+	// OperationFailed means the operation finished with an error.
+	OperationFailed ClusterOperationOutcome = "Failed"
+
+	// OperationSuperseded means the cluster spec changed again before the operation finished,
+	// so the controller moved on to a new operation.
+	OperationSuperseded ClusterOperationOutcome = "Superseded"
+)
+
+// ClusterOperationStatus defines the progress and result of a ClusterOperation.
+type ClusterOperationStatus struct {
+	// StartedAt is when the operation started.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// CompletedAt is when the operation finished. It's not set while the operation is in progress.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// Outcome is the result of the operation.
+	Outcome ClusterOperationOutcome `json:"outcome"`
+
+	// Message explains the outcome, like the error that made the operation fail.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterOperation is the Schema for the clusteroperations API.
+// It's an audit record of a create, upgrade, delete or scale operation run on a cluster.
+// The status is not a subresource so the CLI can record an operation with a single write.
+type ClusterOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterOperationSpec   `json:"spec,omitempty"`
+	Status ClusterOperationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterOperationList contains a list of ClusterOperation.
+type ClusterOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterOperation{}, &ClusterOperationList{})
+}
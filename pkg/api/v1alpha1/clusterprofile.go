@@ -0,0 +1,30 @@
+package v1alpha1
+
+const (
+	// ClusterProfileKind is the object kind name for ClusterProfile.
+	ClusterProfileKind = "ClusterProfile"
+
+	// ClusterProfileLabel is the label with the name of the ClusterProfile an object was created from.
+	ClusterProfileLabel = "anywhere.eks.amazonaws.com/cluster-profile"
+
+	// ClusterProfileInstanceLabel is the label with the name of the ClusterProfile instance an object was created for.
+	ClusterProfileInstanceLabel = "anywhere.eks.amazonaws.com/cluster-profile-instance"
+
+	// ClusterProfileClusterNameValue is the template value set to the name of the instance being rendered.
+	ClusterProfileClusterNameValue = "clusterName"
+)
+
+// InstanceValues returns the template values for an instance, which are the profile defaults
+// overridden by the instance values. The clusterName value is always the name of the instance.
+func (p *ClusterProfile) InstanceValues(instance ClusterProfileInstance) map[string]string {
+	values := make(map[string]string, len(p.Spec.Defaults)+len(instance.Values)+1)
+	for k, v := range p.Spec.Defaults {
+		values[k] = v
+	}
+	for k, v := range instance.Values {
+		values[k] = v
+	}
+	values[ClusterProfileClusterNameValue] = instance.Name
+
+	return values
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterProfileSpec defines a parameterized template and the clusters instantiated from it.
+type ClusterProfileSpec struct {
+	// Template is a multi document yaml with the EKS-A objects of a cluster, like the Cluster and
+	// its datacenter and machine configs. It's rendered for every instance as a go template with
+	// the instance values available as .Values, and ${VAR} references are replaced with the value VAR.
+	Template string `json:"template"`
+
+	// Defaults are the values used by the instances that don't set them.
+	Defaults map[string]string `json:"defaults,omitempty"`
+
+	// Instances are the clusters created from the template.
+	Instances []ClusterProfileInstance `json:"instances,omitempty"`
+}
+
+// ClusterProfileInstance is a cluster created from a ClusterProfile template.
+type ClusterProfileInstance struct {
+	// Name is the name of the instance. It's available in the template as the clusterName value.
+	Name string `json:"name"`
+
+	// Values are the template values for the instance. They take precedence over the profile defaults.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// ClusterProfileStatus defines the observed state of ClusterProfile.
+type ClusterProfileStatus struct {
+	// ObservedGeneration is the latest generation of the profile applied to the instances.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Instances is the state of every instance in the profile.
+	Instances []ClusterProfileInstanceStatus `json:"instances,omitempty"`
+}
+
+// ClusterProfileInstanceStatus is the state of an instance of a ClusterProfile.
+type ClusterProfileInstanceStatus struct {
+	// Name is the name of the instance.
+	Name string `json:"name"`
+
+	// Applied is true when the objects rendered for the instance have been applied.
+	Applied bool `json:"applied"`
+
+	// FailureMessage explains why the objects for the instance couldn't be rendered or applied.
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ClusterProfile is the Schema for the clusterprofiles API.
+// It stamps many similar clusters from a single parameterized template.
+type ClusterProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterProfileSpec   `json:"spec,omitempty"`
+	Status ClusterProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterProfileList contains a list of ClusterProfile.
+type ClusterProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterProfile{}, &ClusterProfileList{})
+}
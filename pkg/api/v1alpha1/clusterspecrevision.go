@@ -0,0 +1,16 @@
+package v1alpha1
+
+import "fmt"
+
+const (
+	// ClusterSpecRevisionKind is the object kind name for ClusterSpecRevision.
+	ClusterSpecRevisionKind = "ClusterSpecRevision"
+
+	// ClusterSpecRevisionClusterLabel is the label with the name of the cluster a ClusterSpecRevision belongs to.
+	ClusterSpecRevisionClusterLabel = "anywhere.eks.amazonaws.com/cluster-name"
+)
+
+// ClusterSpecRevisionName returns the name of the ClusterSpecRevision object for a cluster revision.
+func ClusterSpecRevisionName(clusterName string, revision int) string {
+	return fmt.Sprintf("%s-rev-%d", clusterName, revision)
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSpecRevisionSpec defines the cluster spec captured in a ClusterSpecRevision.
+type ClusterSpecRevisionSpec struct {
+	// ClusterName is the name of the cluster the revision belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// Revision is the sequence number of the revision for the cluster,
+	// starting from 1 on cluster creation.
+	Revision int `json:"revision"`
+
+	// EksaVersion is the EKS-A version of the bundle used by the cluster spec.
+	EksaVersion string `json:"eksaVersion,omitempty"`
+
+	// BundlesNumber is the number of the bundle used by the cluster spec.
+	BundlesNumber int `json:"bundlesNumber,omitempty"`
+
+	// Config is the full rendered cluster config, including the datacenter and machine configs.
+	Config string `json:"config"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterSpecRevision is the Schema for the clusterspecrevisions API.
+// It captures the cluster spec applied by a successful cluster create or upgrade.
+type ClusterSpecRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterSpecRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterSpecRevisionList contains a list of ClusterSpecRevision.
+type ClusterSpecRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSpecRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSpecRevision{}, &ClusterSpecRevisionList{})
+}
@@ -62,3 +62,29 @@ const (
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
 )
+
+const (
+	// GitOpsInSyncCondition reports whether the Cluster spec matches the one committed to the GitOps repository.
+	// It's only set when the FluxConfig drift remediation policy for Clusters is alert.
+	GitOpsInSyncCondition ConditionType = "GitOpsInSync"
+
+	// GitOpsDriftDetectedReason reports the Cluster spec has been changed outside the GitOps flow.
+	GitOpsDriftDetectedReason = "GitOpsDriftDetected"
+
+	// GitOpsReconciledCondition reports whether Flux has applied the last revision of the GitOps repository
+	// and all the resources it applied are healthy. It's only set for Clusters with a FluxConfig and, when
+	// present, the Cluster is not Ready until the repository content is fully reconciled.
+	GitOpsReconciledCondition ConditionType = "GitOpsReconciled"
+
+	// GitOpsReconciliationInProgressReason reports Flux is still applying the last revision of the GitOps repository.
+	GitOpsReconciliationInProgressReason = "GitOpsReconciliationInProgress"
+
+	// GitOpsReconciliationFailedReason reports Flux failed to apply the GitOps repository content or some of the
+	// applied resources are not healthy.
+	GitOpsReconciliationFailedReason = "GitOpsReconciliationFailed"
+
+	// GitOpsReconciliationSuspendedReason reports Flux is not applying the GitOps repository content to the Cluster,
+	// either because the Flux Kustomization is suspended or the Cluster reconciliation is disabled for Flux.
+	// The GitOpsReconciled condition doesn't affect the Cluster readiness in this case.
+	GitOpsReconciliationSuspendedReason = "GitOpsReconciliationSuspended"
+)
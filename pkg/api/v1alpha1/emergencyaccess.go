@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// EmergencyAccessKind is the object kind name for EmergencyAccess.
+	EmergencyAccessKind = "EmergencyAccess"
+
+	// MaxEmergencyAccessDuration is the longest an EmergencyAccess can authorize a key for.
+	MaxEmergencyAccessDuration = 24 * time.Hour
+
+	defaultEmergencyAccessDuration = time.Hour
+	defaultEmergencyAccessUser     = "ec2-user"
+)
+
+// emergencyAccessUserRegex matches the portable POSIX user names, which are safe to use in the
+// scripts that authorize the key in the nodes.
+var emergencyAccessUserRegex = regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+
+// Validate checks the access spec.
+func (a *EmergencyAccess) Validate() error {
+	if a.Spec.ClusterName == "" {
+		return errors.New("clusterName is required")
+	}
+
+	if strings.TrimSpace(a.Spec.Reason) == "" {
+		return errors.New("reason is required")
+	}
+
+	if _, err := a.PublicKey(); err != nil {
+		return err
+	}
+
+	if a.Spec.User != "" && !emergencyAccessUserRegex.MatchString(a.Spec.User) {
+		return fmt.Errorf("invalid user %q", a.Spec.User)
+	}
+
+	if d := a.Spec.Duration; d != nil && (d.Duration <= 0 || d.Duration > MaxEmergencyAccessDuration) {
+		return fmt.Errorf("duration %s must be positive and not longer than %s", d.Duration, MaxEmergencyAccessDuration)
+	}
+
+	return nil
+}
+
+// PublicKey parses the SSH public key of the access. The key can't have options, since the expiration
+// of the access is set as an option when the key is authorized in the nodes.
+func (a *EmergencyAccess) PublicKey() (ssh.PublicKey, error) {
+	if strings.ContainsAny(strings.TrimSpace(a.Spec.SSHAuthorizedKey), "\r\n") {
+		return nil, errors.New("sshAuthorizedKey must be a single key")
+	}
+
+	key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(a.Spec.SSHAuthorizedKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sshAuthorizedKey: %v", err)
+	}
+	if len(options) > 0 {
+		return nil, errors.New("sshAuthorizedKey can't have options")
+	}
+
+	return key, nil
+}
+
+// AuthorizedUser returns the node OS user the key is authorized for.
+func (a *EmergencyAccess) AuthorizedUser() string {
+	if a.Spec.User == "" {
+		return defaultEmergencyAccessUser
+	}
+	return a.Spec.User
+}
+
+// AccessDuration returns how long the key is authorized for.
+func (a *EmergencyAccess) AccessDuration() time.Duration {
+	if a.Spec.Duration == nil {
+		return defaultEmergencyAccessDuration
+	}
+	return a.Spec.Duration.Duration
+}
+
+// IsExpired returns true if the access was granted and its expiration is not after now.
+func (a *EmergencyAccess) IsExpired(now time.Time) bool {
+	return a.Status.ExpiresAt != nil && !now.Before(a.Status.ExpiresAt.Time)
+}
@@ -0,0 +1,131 @@
+package v1alpha1_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const emergencyAccessKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIYZTw/C3nMKfLhUzRt7DpeebiFjNautSCtz4oSGFPfr admin@example.com"
+
+func emergencyAccess(opts ...func(*v1alpha1.EmergencyAccess)) *v1alpha1.EmergencyAccess {
+	a := &v1alpha1.EmergencyAccess{
+		Spec: v1alpha1.EmergencyAccessSpec{
+			ClusterName:      "workload",
+			SSHAuthorizedKey: emergencyAccessKey,
+			Reason:           "debug kubelet crash loop",
+		},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func TestEmergencyAccessValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  *v1alpha1.EmergencyAccess
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			access: emergencyAccess(),
+		},
+		{
+			name: "valid with user and duration",
+			access: emergencyAccess(func(a *v1alpha1.EmergencyAccess) {
+				a.Spec.User = "capv"
+				a.Spec.Duration = &metav1.Duration{Duration: 24 * time.Hour}
+			}),
+		},
+		{
+			name:    "missing cluster name",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.ClusterName = "" }),
+			wantErr: "clusterName is required",
+		},
+		{
+			name:    "missing reason",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.Reason = " " }),
+			wantErr: "reason is required",
+		},
+		{
+			name:    "invalid key",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.SSHAuthorizedKey = "ssh-rsa AAAA" }),
+			wantErr: "invalid sshAuthorizedKey",
+		},
+		{
+			name:    "multiple keys",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.SSHAuthorizedKey += "\n" + emergencyAccessKey }),
+			wantErr: "sshAuthorizedKey must be a single key",
+		},
+		{
+			name:    "key with options",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.SSHAuthorizedKey = "no-pty " + emergencyAccessKey }),
+			wantErr: "sshAuthorizedKey can't have options",
+		},
+		{
+			name:    "invalid user",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.User = "root:x" }),
+			wantErr: "invalid user",
+		},
+		{
+			name:    "user with shell characters",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.User = "ec2-user$(id)" }),
+			wantErr: "invalid user",
+		},
+		{
+			name:    "user starting with a dash",
+			access:  emergencyAccess(func(a *v1alpha1.EmergencyAccess) { a.Spec.User = "-ec2-user" }),
+			wantErr: "invalid user",
+		},
+		{
+			name: "duration too long",
+			access: emergencyAccess(func(a *v1alpha1.EmergencyAccess) {
+				a.Spec.Duration = &metav1.Duration{Duration: 25 * time.Hour}
+			}),
+			wantErr: "duration 25h0m0s must be positive and not longer than 24h0m0s",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.access.Validate()
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestEmergencyAccessDefaults(t *testing.T) {
+	g := NewWithT(t)
+	a := emergencyAccess()
+
+	g.Expect(a.AuthorizedUser()).To(Equal("ec2-user"))
+	g.Expect(a.AccessDuration()).To(Equal(time.Hour))
+
+	a.Spec.User = "capv"
+	a.Spec.Duration = &metav1.Duration{Duration: 30 * time.Minute}
+	g.Expect(a.AuthorizedUser()).To(Equal("capv"))
+	g.Expect(a.AccessDuration()).To(Equal(30 * time.Minute))
+}
+
+func TestEmergencyAccessIsExpired(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC)
+	a := emergencyAccess()
+
+	g.Expect(a.IsExpired(now)).To(BeFalse())
+
+	expiresAt := metav1.NewTime(now.Add(time.Minute))
+	a.Status.ExpiresAt = &expiresAt
+	g.Expect(a.IsExpired(now)).To(BeFalse())
+	g.Expect(a.IsExpired(now.Add(time.Minute))).To(BeTrue())
+}
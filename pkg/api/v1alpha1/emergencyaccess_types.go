@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EmergencyAccessSpec defines the SSH key to authorize in the nodes of a cluster and for how long.
+type EmergencyAccessSpec struct {
+	// ClusterName is the name of the cluster, in the namespace of the EmergencyAccess, whose nodes get the key.
+	ClusterName string `json:"clusterName"`
+
+	// SSHAuthorizedKey is the SSH public key to authorize, in the authorized_keys format without options.
+	SSHAuthorizedKey string `json:"sshAuthorizedKey"`
+
+	// User is the node OS user the key is authorized for. Defaults to ec2-user.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// NodeSelector selects the nodes of the cluster the key is authorized in. All the nodes are selected when empty.
+	// Bottlerocket nodes are never selected.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// Duration is how long the key is authorized for since the access is granted. Defaults to 1h and can't be longer than 24h.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Reason explains why the access is needed. It's recorded in the audit trail of the cluster.
+	Reason string `json:"reason"`
+}
+
+// EmergencyAccessPhase is the phase of an EmergencyAccess.
+type EmergencyAccessPhase string
+
+const (
+	// EmergencyAccessPending means that the access hasn't been granted yet.
+	EmergencyAccessPending EmergencyAccessPhase = "Pending"
+
+	// EmergencyAccessActive means that the key is authorized in the selected nodes.
+	EmergencyAccessActive EmergencyAccessPhase = "Active"
+
+	// EmergencyAccessExpired means that the access expired and the key was removed from the nodes.
+	// An expired access is never granted again.
+	EmergencyAccessExpired EmergencyAccessPhase = "Expired"
+)
+
+// EmergencyAccessStatus defines the observed state of EmergencyAccess.
+type EmergencyAccessStatus struct {
+	// ObservedGeneration is the latest generation of the access observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the phase of the access.
+	Phase EmergencyAccessPhase `json:"phase,omitempty"`
+
+	// GrantedAt is when the key was first authorized.
+	GrantedAt *metav1.Time `json:"grantedAt,omitempty"`
+
+	// ExpiresAt is when the key stops being authorized.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Nodes are the nodes the key is authorized in.
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Operation is the name of the ClusterOperation that records the access in the audit trail of the cluster.
+	Operation string `json:"operation,omitempty"`
+
+	// FailureMessage explains why the access can't be granted, like an invalid spec or a missing cluster.
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// EmergencyAccess is the Schema for the emergencyaccesses API.
+// It authorizes a temporary SSH public key in the nodes of a cluster for break-glass debugging.
+type EmergencyAccess struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmergencyAccessSpec   `json:"spec,omitempty"`
+	Status EmergencyAccessStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EmergencyAccessList contains a list of EmergencyAccess.
+type EmergencyAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmergencyAccess `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EmergencyAccess{}, &EmergencyAccessList{})
+}
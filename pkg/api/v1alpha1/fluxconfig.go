@@ -42,9 +42,49 @@ func validateFluxConfig(config *FluxConfig) error {
 		}
 	}
 
+	if config.Spec.DriftRemediation != nil {
+		if err := validateDriftRemediation(config.Spec.DriftRemediation); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func validateDriftRemediation(config *DriftRemediationConfig) error {
+	if config.Policy != "" {
+		if err := validateDriftRemediationPolicy(config.Policy); err != nil {
+			return err
+		}
+	}
+
+	kinds := make(map[string]struct{}, len(config.Overrides))
+	for _, o := range config.Overrides {
+		if o.Kind == "" {
+			return errors.New("driftRemediation override kind can't be empty")
+		}
+		if _, ok := kinds[o.Kind]; ok {
+			return fmt.Errorf("duplicated driftRemediation override for kind %s", o.Kind)
+		}
+		kinds[o.Kind] = struct{}{}
+
+		if err := validateDriftRemediationPolicy(o.Policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDriftRemediationPolicy(policy DriftRemediationPolicy) error {
+	switch policy {
+	case DriftRemediate, DriftAlert, DriftIgnore:
+		return nil
+	default:
+		return fmt.Errorf("invalid driftRemediation policy %s, must be one of %s, %s, %s", policy, DriftRemediate, DriftAlert, DriftIgnore)
+	}
+}
+
 func validateGitProviderConfig(gitProviderConfig GitProviderConfig) error {
 	if len(gitProviderConfig.RepositoryUrl) <= 0 {
 		return errors.New("'repositoryUrl' is not set or empty in gitProviderConfig; repositoryUrl is a required field")
@@ -208,3 +208,122 @@ func TestValidateFluxConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFluxConfigDriftRemediation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *DriftRemediationConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: &DriftRemediationConfig{
+				Policy: DriftAlert,
+				Overrides: []DriftRemediationOverride{
+					{Kind: ClusterKind, Policy: DriftRemediate},
+					{Kind: VSphereMachineConfigKind, Policy: DriftIgnore},
+				},
+			},
+		},
+		{
+			name:   "empty default policy",
+			config: &DriftRemediationConfig{},
+		},
+		{
+			name:    "invalid default policy",
+			config:  &DriftRemediationConfig{Policy: "revert"},
+			wantErr: "invalid driftRemediation policy revert, must be one of remediate, alert, ignore",
+		},
+		{
+			name: "invalid override policy",
+			config: &DriftRemediationConfig{
+				Overrides: []DriftRemediationOverride{{Kind: ClusterKind}},
+			},
+			wantErr: "invalid driftRemediation policy , must be one of remediate, alert, ignore",
+		},
+		{
+			name: "empty override kind",
+			config: &DriftRemediationConfig{
+				Overrides: []DriftRemediationOverride{{Policy: DriftAlert}},
+			},
+			wantErr: "driftRemediation override kind can't be empty",
+		},
+		{
+			name: "duplicated override kind",
+			config: &DriftRemediationConfig{
+				Overrides: []DriftRemediationOverride{
+					{Kind: ClusterKind, Policy: DriftAlert},
+					{Kind: ClusterKind, Policy: DriftIgnore},
+				},
+			},
+			wantErr: "duplicated driftRemediation override for kind Cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &FluxConfig{
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					DriftRemediation: tt.config,
+				},
+			}
+			err := config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDriftRemediationConfigPolicyFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *DriftRemediationConfig
+		kind   string
+		want   DriftRemediationPolicy
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			kind:   ClusterKind,
+			want:   DriftRemediate,
+		},
+		{
+			name:   "empty default",
+			config: &DriftRemediationConfig{},
+			kind:   ClusterKind,
+			want:   DriftRemediate,
+		},
+		{
+			name:   "default",
+			config: &DriftRemediationConfig{Policy: DriftIgnore},
+			kind:   ClusterKind,
+			want:   DriftIgnore,
+		},
+		{
+			name: "override",
+			config: &DriftRemediationConfig{
+				Policy:    DriftIgnore,
+				Overrides: []DriftRemediationOverride{{Kind: ClusterKind, Policy: DriftAlert}},
+			},
+			kind: ClusterKind,
+			want: DriftAlert,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.PolicyFor(tt.kind); got != tt.want {
+				t.Fatalf("PolicyFor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
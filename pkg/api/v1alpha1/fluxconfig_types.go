@@ -35,6 +35,57 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// DriftRemediation controls how Flux handles the differences between the EKS Anywhere
+	// objects in Git and the ones in the cluster. Defaults to remediate for all kinds.
+	DriftRemediation *DriftRemediationConfig `json:"driftRemediation,omitempty"`
+}
+
+// DriftRemediationPolicy defines what happens when an object in the cluster drifts from Git.
+type DriftRemediationPolicy string
+
+const (
+	// DriftRemediate makes Flux revert the object in the cluster to the Git version.
+	DriftRemediate DriftRemediationPolicy = "remediate"
+	// DriftAlert stops Flux from reconciling the object and reports the drift in the Cluster conditions.
+	DriftAlert DriftRemediationPolicy = "alert"
+	// DriftIgnore stops Flux from reconciling the object without reporting the drift.
+	DriftIgnore DriftRemediationPolicy = "ignore"
+)
+
+// DriftRemediationConfig defines the drift remediation policies for the EKS Anywhere objects
+// in the Git repository.
+type DriftRemediationConfig struct {
+	// Policy applied to all the kinds without an override. Defaults to remediate.
+	Policy DriftRemediationPolicy `json:"policy,omitempty"`
+
+	// Overrides sets the policy for specific kinds, like Cluster or VSphereMachineConfig.
+	Overrides []DriftRemediationOverride `json:"overrides,omitempty"`
+}
+
+// DriftRemediationOverride sets the drift remediation policy for one kind.
+type DriftRemediationOverride struct {
+	// Kind of the EKS Anywhere object.
+	Kind string `json:"kind"`
+
+	// Policy applied to the objects of this kind.
+	Policy DriftRemediationPolicy `json:"policy"`
+}
+
+// PolicyFor returns the drift remediation policy for a kind.
+func (c *DriftRemediationConfig) PolicyFor(kind string) DriftRemediationPolicy {
+	if c == nil {
+		return DriftRemediate
+	}
+	for _, o := range c.Overrides {
+		if o.Kind == kind {
+			return o.Policy
+		}
+	}
+	if c.Policy == "" {
+		return DriftRemediate
+	}
+	return c.Policy
 }
 
 type GithubProviderConfig struct {
@@ -97,6 +148,7 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
+	// DriftRemediation is not compared since it can be changed after the cluster is created.
 	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
 }
 
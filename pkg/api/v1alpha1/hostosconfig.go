@@ -6,12 +6,43 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
 )
 
+// cloudInitReservedPaths are the directories with files generated by EKS Anywhere,
+// which can't be written by the cloud-init user data.
+var cloudInitReservedPaths = []string{
+	"/etc/kubernetes/",
+	"/var/lib/kubeadm/",
+	"/etc/containerd/",
+	"/etc/systemd/system/containerd.service.d/",
+	"/etc/eks-anywhere/",
+}
+
+// CloudInitUserData is a cloud-config snippet merged with the user data generated for the machines.
+// +kubebuilder:object:generate=false
+type CloudInitUserData struct {
+	WriteFiles []CloudInitFile `json:"write_files,omitempty"`
+	BootCmd    []string        `json:"bootcmd,omitempty"`
+	RunCmd     []string        `json:"runcmd,omitempty"`
+}
+
+// CloudInitFile is a file in the write_files key of a cloud-config.
+// +kubebuilder:object:generate=false
+type CloudInitFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	Owner       string `json:"owner,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+}
+
 func validateHostOSConfig(config *HostOSConfiguration, osFamily OSFamily) error {
 	if config == nil {
 		return nil
@@ -27,6 +58,14 @@ func validateHostOSConfig(config *HostOSConfiguration, osFamily OSFamily) error
 		}
 	}
 
+	if err := validateFirewallConfig(config.FirewallConfiguration, osFamily); err != nil {
+		return err
+	}
+
+	if err := validateCloudInitUserData(config, osFamily); err != nil {
+		return err
+	}
+
 	return validateBotterocketConfig(config.BottlerocketConfiguration, osFamily)
 }
 
@@ -78,6 +117,150 @@ func validateCertBundles(config *certBundle, osFamily OSFamily) error {
 	return nil
 }
 
+func validateFirewallConfig(config *FirewallConfiguration, osFamily OSFamily) error {
+	if config == nil {
+		return nil
+	}
+
+	if config.NodePortRange != "" {
+		if _, _, err := parsePortRange(config.NodePortRange); err != nil {
+			return fmt.Errorf("FirewallConfiguration.NodePortRange %s is invalid: %v", config.NodePortRange, err)
+		}
+	}
+
+	if len(config.Rules) == 0 {
+		return nil
+	}
+
+	if osFamily == Bottlerocket {
+		return fmt.Errorf("FirewallConfiguration.Rules can not be used with osFamily: \"%s\"", Bottlerocket)
+	}
+
+	for _, rule := range config.Rules {
+		if strings.TrimSpace(rule) == "" {
+			return errors.New("FirewallConfiguration.Rules can not have an empty rule")
+		}
+	}
+
+	if _, err := config.RenderRules(); err != nil {
+		return fmt.Errorf("FirewallConfiguration.Rules is invalid: %v", err)
+	}
+
+	return nil
+}
+
+// parsePortRange parses a port range with the format "min-max".
+func parsePortRange(portRange string) (min, max int, err error) {
+	minPort, maxPort, found := strings.Cut(portRange, "-")
+	if !found {
+		return 0, 0, errors.New("port range must have the format min-max")
+	}
+
+	if min, err = strconv.Atoi(minPort); err != nil {
+		return 0, 0, fmt.Errorf("invalid min port %s", minPort)
+	}
+	if max, err = strconv.Atoi(maxPort); err != nil {
+		return 0, 0, fmt.Errorf("invalid max port %s", maxPort)
+	}
+
+	if min < 1 || max > 65535 || min > max {
+		return 0, 0, errors.New("ports must be between 1 and 65535 and min can't be greater than max")
+	}
+
+	return min, max, nil
+}
+
+// GetNodePortRange returns the NodePort range, or the Kubernetes default range if it's not set.
+func (c *FirewallConfiguration) GetNodePortRange() string {
+	if c == nil || c.NodePortRange == "" {
+		return DefaultNodePortRange
+	}
+	return c.NodePortRange
+}
+
+// RenderRules executes the rule templates and returns the nftables rules.
+func (c *FirewallConfiguration) RenderRules() ([]string, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	values := struct{ NodePortRange string }{NodePortRange: c.GetNodePortRange()}
+	rules := make([]string, 0, len(c.Rules))
+	for _, rule := range c.Rules {
+		t, err := template.New("rule").Option("missingkey=error").Parse(rule)
+		if err != nil {
+			return nil, fmt.Errorf("parsing rule %s: %v", rule, err)
+		}
+
+		var b strings.Builder
+		if err := t.Execute(&b, values); err != nil {
+			return nil, fmt.Errorf("executing rule %s: %v", rule, err)
+		}
+		rules = append(rules, strings.TrimSpace(b.String()))
+	}
+
+	return rules, nil
+}
+
+// GetCloudInitUserData parses the cloud-init user data snippet.
+// It returns nil if the host OS configuration doesn't have cloud-init user data.
+func (c *HostOSConfiguration) GetCloudInitUserData() (*CloudInitUserData, error) {
+	if c == nil || c.CloudInitUserData == "" {
+		return nil, nil
+	}
+
+	userData := &CloudInitUserData{}
+	if err := yaml.UnmarshalStrict([]byte(c.CloudInitUserData), userData); err != nil {
+		return nil, fmt.Errorf("parsing cloudInitUserData: %v", err)
+	}
+
+	return userData, nil
+}
+
+func validateCloudInitUserData(config *HostOSConfiguration, osFamily OSFamily) error {
+	userData, err := config.GetCloudInitUserData()
+	if err != nil {
+		return err
+	}
+	if userData == nil {
+		return nil
+	}
+
+	if osFamily == Bottlerocket {
+		return fmt.Errorf("CloudInitUserData can not be used with osFamily: \"%s\"", Bottlerocket)
+	}
+
+	paths := map[string]struct{}{}
+	for _, f := range userData.WriteFiles {
+		if !strings.HasPrefix(f.Path, "/") {
+			return fmt.Errorf("cloudInitUserData write_files path [%s] must be absolute", f.Path)
+		}
+		for _, reserved := range cloudInitReservedPaths {
+			if strings.HasPrefix(f.Path, reserved) {
+				return fmt.Errorf("cloudInitUserData write_files path [%s] can not be in %s, it's managed by EKS Anywhere", f.Path, reserved)
+			}
+		}
+		if _, ok := paths[f.Path]; ok {
+			return fmt.Errorf("cloudInitUserData write_files path [%s] is duplicated", f.Path)
+		}
+		paths[f.Path] = struct{}{}
+
+		switch v1beta1.Encoding(f.Encoding) {
+		case "", v1beta1.Base64, v1beta1.Gzip, v1beta1.GzipBase64:
+		default:
+			return fmt.Errorf("cloudInitUserData write_files encoding [%s] is not supported", f.Encoding)
+		}
+	}
+
+	for _, cmd := range append(userData.BootCmd, userData.RunCmd...) {
+		if strings.TrimSpace(cmd) == "" {
+			return errors.New("cloudInitUserData bootcmd and runcmd can not have an empty command")
+		}
+	}
+
+	return nil
+}
+
 func validateBotterocketConfig(config *BottlerocketConfiguration, osFamily OSFamily) error {
 	if config == nil {
 		return nil
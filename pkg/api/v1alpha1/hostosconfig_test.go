@@ -406,6 +406,154 @@ OelAabtJKd8B2BUsR7JRIN8=
 			osFamily: Bottlerocket,
 			wantErr:  "",
 		},
+		{
+			name: "valid firewall config",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					NodePortRange: "31000-31999",
+					Rules: []string{
+						"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop",
+						"tcp dport 10250 ip saddr 10.0.0.0/8 accept",
+					},
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "",
+		},
+		{
+			name: "valid nodePortRange with Bottlerocket",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					NodePortRange: "31000-31999",
+				},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "",
+		},
+		{
+			name: "invalid nodePortRange format",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					NodePortRange: "31000",
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "FirewallConfiguration.NodePortRange 31000 is invalid: port range must have the format min-max",
+		},
+		{
+			name: "invalid nodePortRange ports",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					NodePortRange: "32000-31000",
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "min can't be greater than max",
+		},
+		{
+			name: "firewall rules with Bottlerocket",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					Rules: []string{"tcp dport 22 drop"},
+				},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "FirewallConfiguration.Rules can not be used with osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "empty firewall rule",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					Rules: []string{" "},
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "FirewallConfiguration.Rules can not have an empty rule",
+		},
+		{
+			name: "invalid firewall rule template",
+			hostOSConfig: &HostOSConfiguration{
+				FirewallConfiguration: &FirewallConfiguration{
+					Rules: []string{"tcp dport {{ .NodePorts }} drop"},
+				},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "FirewallConfiguration.Rules is invalid",
+		},
+		{
+			name: "valid cloud-init user data",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: `#cloud-config
+write_files:
+- path: /etc/agent/config.yaml
+  content: |
+    endpoint: agent.local
+  permissions: "0600"
+bootcmd:
+- mkdir -p /var/lib/agent
+runcmd:
+- /usr/local/bin/install-agent.sh
+`,
+			},
+			osFamily: Ubuntu,
+			wantErr:  "",
+		},
+		{
+			name: "cloud-init user data with Bottlerocket",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "runcmd:\n- ls\n",
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "CloudInitUserData can not be used with osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "cloud-init user data with unsupported key",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "packages:\n- curl\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "parsing cloudInitUserData",
+		},
+		{
+			name: "cloud-init user data with relative path",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "write_files:\n- path: agent.yaml\n  content: a\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "cloudInitUserData write_files path [agent.yaml] must be absolute",
+		},
+		{
+			name: "cloud-init user data with reserved path",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "write_files:\n- path: /etc/kubernetes/audit-policy.yaml\n  content: a\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "cloudInitUserData write_files path [/etc/kubernetes/audit-policy.yaml] can not be in /etc/kubernetes/",
+		},
+		{
+			name: "cloud-init user data with duplicated path",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "write_files:\n- path: /etc/a\n  content: a\n- path: /etc/a\n  content: b\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "cloudInitUserData write_files path [/etc/a] is duplicated",
+		},
+		{
+			name: "cloud-init user data with invalid encoding",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "write_files:\n- path: /etc/a\n  content: a\n  encoding: zip\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "cloudInitUserData write_files encoding [zip] is not supported",
+		},
+		{
+			name: "cloud-init user data with empty command",
+			hostOSConfig: &HostOSConfiguration{
+				CloudInitUserData: "runcmd:\n- \"\"\n",
+			},
+			osFamily: Ubuntu,
+			wantErr:  "cloudInitUserData bootcmd and runcmd can not have an empty command",
+		},
 	}
 
 	for _, tt := range tests {
@@ -420,3 +568,21 @@ OelAabtJKd8B2BUsR7JRIN8=
 		})
 	}
 }
+
+func TestFirewallConfigurationRenderRules(t *testing.T) {
+	g := NewWithT(t)
+	config := &FirewallConfiguration{
+		Rules: []string{
+			"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop ",
+			"udp dport 53 accept",
+		},
+	}
+
+	g.Expect(config.RenderRules()).To(Equal([]string{
+		"tcp dport 30000-32767 ip saddr != 10.0.0.0/8 drop",
+		"udp dport 53 accept",
+	}))
+
+	config.NodePortRange = "31000-31999"
+	g.Expect(config.RenderRules()).To(ContainElement("tcp dport 31000-31999 ip saddr != 10.0.0.0/8 drop"))
+}
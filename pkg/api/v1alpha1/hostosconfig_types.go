@@ -2,6 +2,9 @@ package v1alpha1
 
 import "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 
+// DefaultNodePortRange is the range of ports reserved for NodePort services by Kubernetes by default.
+const DefaultNodePortRange = "30000-32767"
+
 // HostOSConfiguration defines the configuration settings on the host OS.
 type HostOSConfiguration struct {
 	// +optional
@@ -12,6 +15,16 @@ type HostOSConfiguration struct {
 
 	// +optional
 	CertBundles []certBundle `json:"certBundles,omitempty"`
+
+	// +optional
+	FirewallConfiguration *FirewallConfiguration `json:"firewallConfiguration,omitempty"`
+
+	// CloudInitUserData defines a cloud-config snippet merged with the user data generated for the machines.
+	// Only the write_files, bootcmd and runcmd keys are supported. Files are written and bootcmd commands
+	// run before kubeadm, runcmd commands run after the node joins the cluster.
+	// It's not supported for the bottlerocket `osFamily`.
+	// +optional
+	CloudInitUserData string `json:"cloudInitUserData,omitempty"`
 }
 
 // NTPConfiguration defines the NTP configuration on the host OS.
@@ -34,6 +47,21 @@ type BottlerocketConfiguration struct {
 	Boot *v1beta1.BottlerocketBootSettings `json:"boot,omitempty"`
 }
 
+// FirewallConfiguration defines the host firewall and the NodePort range on the host OS.
+type FirewallConfiguration struct {
+	// NodePortRange defines the range of ports reserved for NodePort services, like "30000-32767".
+	// It's set as the kube-apiserver service node port range on control plane nodes.
+	// Defaults to 30000-32767.
+	// +optional
+	NodePortRange string `json:"nodePortRange,omitempty"`
+
+	// Rules defines nftables rules added to the input chain of the host firewall.
+	// They are Go templates and can reference the NodePort range with {{ .NodePortRange }}.
+	// Rules are not supported for the bottlerocket `osFamily`.
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+}
+
 // Cert defines additional trusted cert bundles on the host OS.
 type certBundle struct {
 	// Name defines the cert bundle name.
@@ -0,0 +1,28 @@
+package v1alpha1
+
+// templateRotatedFields lists, by machine config kind, the spec fields that are immutable in the
+// provider machine templates but can still be updated through the API for workload cluster machine
+// configs. The controller detects the change when comparing the generated machine template with the
+// current one, creates a new machine template with a new name and rolls out the machines, the same
+// way upgrades do.
+var templateRotatedFields = map[string]map[string]struct{}{
+	VSphereMachineConfigKind: {
+		"storagePolicyName": {},
+	},
+	NutanixMachineConfigKind: {
+		"cluster": {},
+		"subnet":  {},
+	},
+}
+
+// rotatesMachineTemplate returns true if a change in the spec field of a machine config of the
+// given kind is handled by rotating the provider machine template instead of being rejected.
+// Only workload cluster machine configs, reconciled by the controller, can rotate templates.
+func rotatesMachineTemplate(kind, field string, managed bool) bool {
+	if !managed {
+		return false
+	}
+
+	_, ok := templateRotatedFields[kind][field]
+	return ok
+}
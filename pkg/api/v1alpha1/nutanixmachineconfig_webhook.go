@@ -94,11 +94,11 @@ func validateImmutableFieldsNutantixMachineConfig(new, old *NutanixMachineConfig
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("OSFamily"), "field is immutable"))
 	}
 
-	if !reflect.DeepEqual(new.Spec.Cluster, old.Spec.Cluster) {
+	if !reflect.DeepEqual(new.Spec.Cluster, old.Spec.Cluster) && !rotatesMachineTemplate(NutanixMachineConfigKind, "cluster", old.IsManaged()) {
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("Cluster"), "field is immutable"))
 	}
 
-	if !reflect.DeepEqual(new.Spec.Subnet, old.Spec.Subnet) {
+	if !reflect.DeepEqual(new.Spec.Subnet, old.Spec.Subnet) && !rotatesMachineTemplate(NutanixMachineConfigKind, "subnet", old.IsManaged()) {
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("Subnet"), "field is immutable"))
 	}
 
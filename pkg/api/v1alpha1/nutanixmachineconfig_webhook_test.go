@@ -218,6 +218,22 @@ func TestValidateUpdate(t *testing.T) {
 	g.Expect(newConfig.ValidateUpdate(oldConfig)).To(HaveOccurred())
 }
 
+func TestValidateUpdateWorkloadClusterRotatesTemplate(t *testing.T) {
+	g := NewWithT(t)
+	oldConfig := nutanixMachineConfig()
+	oldConfig.SetManagedBy("mgmt-cluster")
+	newConfig := oldConfig.DeepCopy()
+	newConfig.Spec.Cluster = v1alpha1.NutanixResourceIdentifier{
+		Type: v1alpha1.NutanixIdentifierName,
+		Name: ptr.String("cluster-2"),
+	}
+	newConfig.Spec.Subnet = v1alpha1.NutanixResourceIdentifier{
+		Type: v1alpha1.NutanixIdentifierName,
+		Name: ptr.String("subnet-2"),
+	}
+	g.Expect(newConfig.ValidateUpdate(oldConfig)).To(Succeed())
+}
+
 func TestValidateUpdate_Invalid(t *testing.T) {
 	g := NewWithT(t)
 	tests := []struct {
@@ -72,6 +72,14 @@ func validateDatacenterConfig(config *TinkerbellDatacenterConfig) error {
 		return fmt.Errorf("TinkerbellDatacenterConfig: invalid tinkerbell ip: %v", err)
 	}
 
+	if config.Spec.StackReplicas < 0 {
+		return fmt.Errorf("TinkerbellDatacenterConfig: stackReplicas %d can't be negative", config.Spec.StackReplicas)
+	}
+
+	if config.Spec.StackReplicas > 1 && config.Spec.SkipLoadBalancerDeployment {
+		return errors.New("TinkerbellDatacenterConfig: stackReplicas greater than 1 requires the load balancer deployment to expose the stack replicas behind the tinkerbellIP")
+	}
+
 	return nil
 }
 
@@ -18,6 +18,9 @@ type TinkerbellDatacenterConfigSpec struct {
 	// SkipLoadBalancerDeployment when set to "true" can be used to skip deploying a load balancer to expose Tinkerbell stack.
 	// Users will need to deploy and configure a load balancer manually after the cluster is created.
 	SkipLoadBalancerDeployment bool `json:"skipLoadBalancerDeployment,omitempty"`
+	// StackReplicas is the number of replicas for the tink-server, hegel and boots services.
+	// Setting it to more than 1 runs the Tinkerbell stack in HA behind the TinkerbellIP.
+	StackReplicas int `json:"stackReplicas,omitempty"`
 }
 
 // TinkerbellDatacenterConfigStatus defines the observed state of TinkerbellDatacenterConfig
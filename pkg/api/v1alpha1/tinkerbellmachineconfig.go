@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -99,6 +100,66 @@ func validateTinkerbellMachineConfig(config *TinkerbellMachineConfig) error {
 		return fmt.Errorf("HostOSConfiguration is invalid for TinkerbellMachineConfig %s: %v", config.Name, err)
 	}
 
+	if err := validateHostNetwork(config.Spec.HostNetwork); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: invalid spec.hostNetwork: %s: %v", config.Name, err)
+	}
+
+	return nil
+}
+
+var bondModes = map[BondMode]bool{
+	BondModeBalanceRR:    true,
+	BondModeActiveBackup: true,
+	BondModeBalanceXOR:   true,
+	BondModeBroadcast:    true,
+	BondModeLACP:         true,
+	BondModeBalanceTLB:   true,
+	BondModeBalanceALB:   true,
+}
+
+func validateHostNetwork(hostNetwork *HostNetworkConfiguration) error {
+	if hostNetwork == nil {
+		return nil
+	}
+
+	// valid VLAN IDs are between 1 and 4094 - https://en.m.wikipedia.org/wiki/VLAN#IEEE_802.1Q
+	if hostNetwork.VLANID != 0 && (hostNetwork.VLANID < 1 || hostNetwork.VLANID > 4094) {
+		return fmt.Errorf("vlanID %d must be between 1 and 4094", hostNetwork.VLANID)
+	}
+
+	bond := hostNetwork.Bond
+	if bond == nil {
+		return nil
+	}
+
+	if !bondModes[bond.Mode] {
+		return fmt.Errorf("unsupported bond mode %q; Please use one of the following: %s, %s, %s, %s, %s, %s, %s",
+			bond.Mode,
+			BondModeBalanceRR,
+			BondModeActiveBackup,
+			BondModeBalanceXOR,
+			BondModeBroadcast,
+			BondModeLACP,
+			BondModeBalanceTLB,
+			BondModeBalanceALB,
+		)
+	}
+
+	if len(bond.Interfaces) < 2 {
+		return errors.New("bond requires at least 2 interfaces")
+	}
+
+	seen := make(map[string]bool, len(bond.Interfaces))
+	for _, iface := range bond.Interfaces {
+		if iface == "" {
+			return errors.New("bond interface names can't be empty")
+		}
+		if seen[iface] {
+			return fmt.Errorf("bond interface %s is duplicated", iface)
+		}
+		seen[iface] = true
+	}
+
 	return nil
 }
 
@@ -15,7 +15,40 @@ type TinkerbellMachineConfigSpec struct {
 	OSFamily            OSFamily             `json:"osFamily"`
 	Users               []UserConfiguration  `json:"users,omitempty"`
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
-}
+	// HostNetwork configures the bonding and VLAN tagging of the machine network interfaces.
+	HostNetwork *HostNetworkConfiguration `json:"hostNetwork,omitempty"`
+}
+
+// HostNetworkConfiguration defines the network interfaces configuration written to the
+// machines when they are provisioned.
+type HostNetworkConfiguration struct {
+	// Bond aggregates the machine network interfaces. The bond takes the IP configuration of the hardware.
+	Bond *BondConfiguration `json:"bond,omitempty"`
+	// VLANID tags the machine traffic with a VLAN. It must be between 1 and 4094.
+	VLANID int `json:"vlanID,omitempty"`
+}
+
+// BondConfiguration defines a bond of the machine network interfaces.
+type BondConfiguration struct {
+	// Mode is the bonding mode.
+	Mode BondMode `json:"mode"`
+	// Interfaces are the names of the network interfaces to bond. All the hardware selected by
+	// the machine config must have these interfaces.
+	Interfaces []string `json:"interfaces"`
+}
+
+// BondMode is a Linux bonding mode.
+type BondMode string
+
+const (
+	BondModeBalanceRR    BondMode = "balance-rr"
+	BondModeActiveBackup BondMode = "active-backup"
+	BondModeBalanceXOR   BondMode = "balance-xor"
+	BondModeBroadcast    BondMode = "broadcast"
+	BondModeLACP         BondMode = "802.3ad"
+	BondModeBalanceTLB   BondMode = "balance-tlb"
+	BondModeBalanceALB   BondMode = "balance-alb"
+)
 
 // HardwareSelector models a simple key-value selector used in Tinkerbell provisioning.
 type HardwareSelector map[string]string
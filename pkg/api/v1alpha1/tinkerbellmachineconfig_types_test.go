@@ -14,6 +14,21 @@ func TestTinkerbellMachineConfigValidateSucceed(t *testing.T) {
 	g.Expect(machineConfig.Validate()).To(Succeed())
 }
 
+func TestTinkerbellMachineConfigValidateHostNetworkSucceed(t *testing.T) {
+	machineConfig := CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+		mc.Spec.HostNetwork = &HostNetworkConfiguration{
+			Bond: &BondConfiguration{
+				Mode:       BondModeLACP,
+				Interfaces: []string{"eno1", "eno2"},
+			},
+			VLANID: 100,
+		}
+	})
+
+	g := NewWithT(t)
+	g.Expect(machineConfig.Validate()).To(Succeed())
+}
+
 func TestTinkerbellMachineConfigValidateFail(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -66,6 +81,40 @@ func TestTinkerbellMachineConfigValidateFail(t *testing.T) {
 			),
 			expectedErr: "HostOSConfiguration is invalid for TinkerbellMachineConfig tinkerbellmachineconfig: NTPConfiguration.Servers can not be empty",
 		},
+		{
+			name: "Invalid hostNetwork VLAN ID",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.HostNetwork = &HostNetworkConfiguration{VLANID: 4095}
+			}),
+			expectedErr: "vlanID 4095 must be between 1 and 4094",
+		},
+		{
+			name: "Invalid bond mode",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.HostNetwork = &HostNetworkConfiguration{
+					Bond: &BondConfiguration{Mode: "lacp", Interfaces: []string{"eno1", "eno2"}},
+				}
+			}),
+			expectedErr: "unsupported bond mode \"lacp\"",
+		},
+		{
+			name: "Bond with a single interface",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.HostNetwork = &HostNetworkConfiguration{
+					Bond: &BondConfiguration{Mode: BondModeLACP, Interfaces: []string{"eno1"}},
+				}
+			}),
+			expectedErr: "bond requires at least 2 interfaces",
+		},
+		{
+			name: "Bond with duplicated interfaces",
+			machineConfig: CreateTinkerbellMachineConfig(func(mc *TinkerbellMachineConfig) {
+				mc.Spec.HostNetwork = &HostNetworkConfiguration{
+					Bond: &BondConfiguration{Mode: BondModeLACP, Interfaces: []string{"eno1", "eno1"}},
+				}
+			}),
+			expectedErr: "bond interface eno1 is duplicated",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
@@ -10,6 +11,7 @@ import (
 
 const (
 	bottlerocketBootconfig = `kernel {}`
+	bondInterfaceName      = "bond0"
 
 	cloudInit = `datasource:
   Ec2:
@@ -22,8 +24,9 @@ warnings:
 )
 
 // GetDefaultActionsFromBundle constructs a set of default actions for the given osFamily using the
-// bundle as the source of action images.
-func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle, osImageOverride, tinkerbellLocalIP, tinkerbellLBIP string, osFamily OSFamily) []ActionOpt {
+// bundle as the source of action images. The host network configuration, if any, is rendered
+// into the network configuration written to the machines.
+func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle, osImageOverride, tinkerbellLocalIP, tinkerbellLBIP string, osFamily OSFamily, hostNetwork *HostNetworkConfiguration) []ActionOpt {
 	// The metadata string will have two URLs:
 	// 1. one that will be used initially for bootstrap and will point to hegel running on kind.
 	// 2. one that will be used when the workload cluster is up and will point to hegel running on
@@ -67,7 +70,7 @@ func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle
 			withBottlerocketUserDataAction(b, partitionPath, strings.Join(metadataURLs, ",")),
 			// Order matters. This action needs to append to an existing user-data.toml file so
 			// must be after withBottlerocketUserDataAction().
-			withNetplanAction(b, partitionPath, osFamily),
+			withNetplanAction(b, partitionPath, osFamily, hostNetwork, clusterSpec.Spec.ClusterNetwork.MTU),
 			withRebootAction(b),
 		)
 	case RedHat:
@@ -79,7 +82,7 @@ func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle
 		partitionPath := fmt.Sprintf(paritionPathFmt, "1")
 
 		actions = append(actions,
-			withNetplanAction(b, partitionPath, osFamily),
+			withNetplanAction(b, partitionPath, osFamily, hostNetwork, clusterSpec.Spec.ClusterNetwork.MTU),
 			withDisableCloudInitNetworkCapabilities(b, partitionPath),
 			withTinkCloudInitAction(b, partitionPath, strings.Join(mu, ",")),
 			withDsCloudInitAction(b, partitionPath),
@@ -89,7 +92,7 @@ func GetDefaultActionsFromBundle(clusterSpec *Cluster, b v1alpha1.VersionsBundle
 		partitionPath := fmt.Sprintf(paritionPathFmt, "2")
 
 		actions = append(actions,
-			withNetplanAction(b, partitionPath, osFamily),
+			withNetplanAction(b, partitionPath, osFamily, hostNetwork, clusterSpec.Spec.ClusterNetwork.MTU),
 			withDisableCloudInitNetworkCapabilities(b, partitionPath),
 			withTinkCloudInitAction(b, partitionPath, strings.Join(metadataURLs, ",")),
 			withDsCloudInitAction(b, partitionPath),
@@ -130,7 +133,7 @@ func withStreamImageAction(b v1alpha1.VersionsBundle, disk, osImageOverride stri
 	}
 }
 
-func withNetplanAction(b v1alpha1.VersionsBundle, disk string, osFamily OSFamily) ActionOpt {
+func withNetplanAction(b v1alpha1.VersionsBundle, disk string, osFamily OSFamily, hostNetwork *HostNetworkConfiguration, mtu int) ActionOpt {
 	return func(a *[]tinkerbell.Action) {
 		netplanAction := tinkerbell.Action{
 			Name:    "write-netplan",
@@ -156,10 +159,34 @@ func withNetplanAction(b v1alpha1.VersionsBundle, disk string, osFamily OSFamily
 		} else {
 			netplanAction.Environment["STATIC_NETPLAN"] = "true"
 		}
+		addHostNetworkEnv(netplanAction.Environment, hostNetwork, osFamily)
+		if mtu != 0 {
+			netplanAction.Environment["MTU"] = strconv.Itoa(mtu)
+		}
 		*a = append(*a, netplanAction)
 	}
 }
 
+// addHostNetworkEnv configures the write-netplan action to set up the bond and VLAN in the
+// static network configuration generated from the hardware IP configuration.
+func addHostNetworkEnv(env map[string]string, hostNetwork *HostNetworkConfiguration, osFamily OSFamily) {
+	if hostNetwork == nil {
+		return
+	}
+
+	if hostNetwork.Bond != nil {
+		env["BOND_MODE"] = string(hostNetwork.Bond.Mode)
+		env["BOND_INTERFACES"] = strings.Join(hostNetwork.Bond.Interfaces, ",")
+		if osFamily == Bottlerocket {
+			env["IFNAME"] = bondInterfaceName
+		}
+	}
+
+	if hostNetwork.VLANID != 0 {
+		env["VLAN_ID"] = strconv.Itoa(hostNetwork.VLANID)
+	}
+}
+
 func withDisableCloudInitNetworkCapabilities(b v1alpha1.VersionsBundle, disk string) ActionOpt {
 	return func(a *[]tinkerbell.Action) {
 		*a = append(*a, tinkerbell.Action{
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -541,7 +542,7 @@ warnings:
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			givenActions := []tinkerbell.Action{}
-			opts := GetDefaultActionsFromBundle(tt.clusterSpec, vBundle, tt.osImageOverride, tinkerbellLocalIp, tinkerbellLBIP, tt.osFamily)
+			opts := GetDefaultActionsFromBundle(tt.clusterSpec, vBundle, tt.osImageOverride, tinkerbellLocalIp, tinkerbellLBIP, tt.osFamily, nil)
 			for _, opt := range opts {
 				opt(&givenActions)
 			}
@@ -552,6 +553,77 @@ warnings:
 	}
 }
 
+func TestWithDefaultActionsFromBundleHostNetwork(t *testing.T) {
+	hostNetwork := &HostNetworkConfiguration{
+		Bond: &BondConfiguration{
+			Mode:       BondModeLACP,
+			Interfaces: []string{"eno1", "eno2"},
+		},
+		VLANID: 100,
+	}
+
+	tests := []struct {
+		osFamily OSFamily
+		wantEnv  map[string]string
+	}{
+		{
+			osFamily: Ubuntu,
+			wantEnv:  map[string]string{"STATIC_NETPLAN": "true", "BOND_MODE": "802.3ad", "BOND_INTERFACES": "eno1,eno2", "VLAN_ID": "100"},
+		},
+		{
+			osFamily: Bottlerocket,
+			wantEnv:  map[string]string{"STATIC_BOTTLEROCKET": "true", "IFNAME": "bond0", "BOND_MODE": "802.3ad", "BOND_INTERFACES": "eno1,eno2", "VLAN_ID": "100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.osFamily), func(t *testing.T) {
+			g := NewWithT(t)
+			givenActions := []tinkerbell.Action{}
+			opts := GetDefaultActionsFromBundle(&Cluster{}, givenVersionBundle(), "", "127.0.0.1", "1.2.3.4", tt.osFamily, hostNetwork)
+			for _, opt := range opts {
+				opt(&givenActions)
+			}
+
+			var netplan *tinkerbell.Action
+			for i := range givenActions {
+				if givenActions[i].Name == "write-netplan" {
+					netplan = &givenActions[i]
+				}
+			}
+			g.Expect(netplan).ToNot(BeNil())
+			for k, v := range tt.wantEnv {
+				g.Expect(netplan.Environment).To(HaveKeyWithValue(k, v))
+			}
+		})
+	}
+}
+
+func TestWithDefaultActionsFromBundleMTU(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			ClusterNetwork: ClusterNetwork{
+				MTU: 1400,
+			},
+		},
+	}
+	givenActions := []tinkerbell.Action{}
+	opts := GetDefaultActionsFromBundle(cluster, givenVersionBundle(), "", "127.0.0.1", "1.2.3.4", Ubuntu, nil)
+	for _, opt := range opts {
+		opt(&givenActions)
+	}
+
+	var netplan *tinkerbell.Action
+	for i := range givenActions {
+		if givenActions[i].Name == "write-netplan" {
+			netplan = &givenActions[i]
+		}
+	}
+	g.Expect(netplan).ToNot(BeNil())
+	g.Expect(netplan.Environment).To(HaveKeyWithValue("MTU", "1400"))
+}
+
 func givenVersionBundle() v1alpha1.VersionsBundle {
 	return v1alpha1.VersionsBundle{
 		EksD: v1alpha1.EksDRelease{
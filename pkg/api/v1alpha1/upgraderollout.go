@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// UpgradeRolloutKind is the object kind name for UpgradeRollout.
+	UpgradeRolloutKind = "UpgradeRollout"
+
+	defaultUpgradeRolloutCanaryClusters = 1
+	defaultUpgradeRolloutWavePercentage = 25
+)
+
+// Validate checks the rollout spec.
+func (r *UpgradeRollout) Validate() error {
+	if r.Spec.KubernetesVersion == "" && r.Spec.EksaVersion == nil {
+		return errors.New("at least one of kubernetesVersion and eksaVersion must be set")
+	}
+
+	if r.Spec.KubernetesVersion != "" {
+		if _, err := KubeVersionToSemver(r.Spec.KubernetesVersion); err != nil {
+			return fmt.Errorf("invalid kubernetesVersion: %v", err)
+		}
+	}
+
+	if c := r.Spec.Strategy.CanaryClusters; c != nil && *c < 0 {
+		return fmt.Errorf("canaryClusters %d can't be negative", *c)
+	}
+
+	if p := r.Spec.Strategy.WavePercentage; p != nil && (*p < 1 || *p > 100) {
+		return fmt.Errorf("wavePercentage %d must be between 1 and 100", *p)
+	}
+
+	if r.Spec.Strategy.MaxFailures < 0 {
+		return fmt.Errorf("maxFailures %d can't be negative", r.Spec.Strategy.MaxFailures)
+	}
+
+	return nil
+}
+
+// WaveSizes splits a number of clusters in the waves of the rollout and returns the number of
+// clusters in every wave. The first wave holds the canary clusters and every following wave
+// holds the configured percentage of all the clusters, rounded up.
+func (r *UpgradeRollout) WaveSizes(clusters int) []int {
+	canary := defaultUpgradeRolloutCanaryClusters
+	if r.Spec.Strategy.CanaryClusters != nil {
+		canary = *r.Spec.Strategy.CanaryClusters
+	}
+	if canary > clusters {
+		canary = clusters
+	}
+
+	percentage := defaultUpgradeRolloutWavePercentage
+	if r.Spec.Strategy.WavePercentage != nil {
+		percentage = *r.Spec.Strategy.WavePercentage
+	}
+	waveSize := (clusters*percentage + 99) / 100
+	if waveSize < 1 {
+		waveSize = 1
+	}
+
+	var sizes []int
+	if canary > 0 {
+		sizes = append(sizes, canary)
+	}
+	for remaining := clusters - canary; remaining > 0; remaining -= waveSize {
+		if remaining < waveSize {
+			sizes = append(sizes, remaining)
+		} else {
+			sizes = append(sizes, waveSize)
+		}
+	}
+
+	return sizes
+}
+
+// TargetsCluster returns true if the spec of the cluster already has the versions of the rollout.
+func (r *UpgradeRollout) TargetsCluster(cluster *Cluster) bool {
+	if r.Spec.KubernetesVersion != "" && cluster.Spec.KubernetesVersion != r.Spec.KubernetesVersion {
+		return false
+	}
+
+	if r.Spec.EksaVersion != nil && !r.Spec.EksaVersion.Equal(cluster.Spec.EksaVersion) {
+		return false
+	}
+
+	return true
+}
+
+// SetTargetVersions sets the versions of the rollout in the spec of the cluster.
+func (r *UpgradeRollout) SetTargetVersions(cluster *Cluster) {
+	if r.Spec.KubernetesVersion != "" {
+		cluster.Spec.KubernetesVersion = r.Spec.KubernetesVersion
+	}
+
+	if r.Spec.EksaVersion != nil {
+		v := *r.Spec.EksaVersion
+		cluster.Spec.EksaVersion = &v
+	}
+}
@@ -0,0 +1,134 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestUpgradeRolloutWaveSizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy v1alpha1.UpgradeRolloutStrategy
+		clusters int
+		want     []int
+	}{
+		{
+			name:     "defaults",
+			clusters: 10,
+			want:     []int{1, 3, 3, 3},
+		},
+		{
+			name:     "no clusters",
+			clusters: 0,
+			want:     nil,
+		},
+		{
+			name:     "fewer clusters than canaries",
+			strategy: v1alpha1.UpgradeRolloutStrategy{CanaryClusters: ptr.Int(3)},
+			clusters: 2,
+			want:     []int{2},
+		},
+		{
+			name:     "no canary",
+			strategy: v1alpha1.UpgradeRolloutStrategy{CanaryClusters: ptr.Int(0), WavePercentage: ptr.Int(50)},
+			clusters: 5,
+			want:     []int{3, 2},
+		},
+		{
+			name:     "small percentage",
+			strategy: v1alpha1.UpgradeRolloutStrategy{CanaryClusters: ptr.Int(2), WavePercentage: ptr.Int(1)},
+			clusters: 4,
+			want:     []int{2, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := &v1alpha1.UpgradeRollout{Spec: v1alpha1.UpgradeRolloutSpec{Strategy: tt.strategy}}
+			g.Expect(r.WaveSizes(tt.clusters)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestUpgradeRolloutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.UpgradeRolloutSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: v1alpha1.UpgradeRolloutSpec{KubernetesVersion: v1alpha1.Kube127},
+		},
+		{
+			name:    "no versions",
+			spec:    v1alpha1.UpgradeRolloutSpec{},
+			wantErr: "at least one of kubernetesVersion and eksaVersion must be set",
+		},
+		{
+			name:    "invalid kubernetes version",
+			spec:    v1alpha1.UpgradeRolloutSpec{KubernetesVersion: "latest"},
+			wantErr: "invalid kubernetesVersion",
+		},
+		{
+			name: "negative canary",
+			spec: v1alpha1.UpgradeRolloutSpec{
+				KubernetesVersion: v1alpha1.Kube127,
+				Strategy:          v1alpha1.UpgradeRolloutStrategy{CanaryClusters: ptr.Int(-1)},
+			},
+			wantErr: "canaryClusters -1 can't be negative",
+		},
+		{
+			name: "percentage too big",
+			spec: v1alpha1.UpgradeRolloutSpec{
+				KubernetesVersion: v1alpha1.Kube127,
+				Strategy:          v1alpha1.UpgradeRolloutStrategy{WavePercentage: ptr.Int(101)},
+			},
+			wantErr: "wavePercentage 101 must be between 1 and 100",
+		},
+		{
+			name: "negative max failures",
+			spec: v1alpha1.UpgradeRolloutSpec{
+				KubernetesVersion: v1alpha1.Kube127,
+				Strategy:          v1alpha1.UpgradeRolloutStrategy{MaxFailures: -1},
+			},
+			wantErr: "maxFailures -1 can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := &v1alpha1.UpgradeRollout{Spec: tt.spec}
+			err := r.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestUpgradeRolloutTargetVersions(t *testing.T) {
+	g := NewWithT(t)
+	eksaVersion := v1alpha1.EksaVersion("v0.17.0")
+	r := &v1alpha1.UpgradeRollout{
+		Spec: v1alpha1.UpgradeRolloutSpec{
+			KubernetesVersion: v1alpha1.Kube127,
+			EksaVersion:       &eksaVersion,
+		},
+	}
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{KubernetesVersion: v1alpha1.Kube126},
+	}
+
+	g.Expect(r.TargetsCluster(cluster)).To(BeFalse())
+	r.SetTargetVersions(cluster)
+	g.Expect(r.TargetsCluster(cluster)).To(BeTrue())
+	g.Expect(cluster.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube127))
+	g.Expect(*cluster.Spec.EksaVersion).To(Equal(eksaVersion))
+}
@@ -0,0 +1,137 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeRolloutSpec defines the workload clusters to upgrade and how the upgrade is rolled out across them.
+type UpgradeRolloutSpec struct {
+	// ClusterSelector selects the workload clusters in the namespace of the rollout to upgrade.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// KubernetesVersion is the Kubernetes version the selected clusters are upgraded to.
+	// +optional
+	KubernetesVersion KubernetesVersion `json:"kubernetesVersion,omitempty"`
+
+	// EksaVersion is the EKS-A version the selected clusters are upgraded to.
+	// +optional
+	EksaVersion *EksaVersion `json:"eksaVersion,omitempty"`
+
+	// Strategy defines the waves the clusters are upgraded in and when the rollout is paused.
+	// +optional
+	Strategy UpgradeRolloutStrategy `json:"strategy,omitempty"`
+
+	// Paused stops the rollout from starting the upgrade of more clusters.
+	// The upgrades already started are not interrupted.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// UpgradeRolloutStrategy defines the waves of an UpgradeRollout.
+type UpgradeRolloutStrategy struct {
+	// CanaryClusters is the number of clusters upgraded in the first wave, before any other cluster.
+	// Defaults to 1.
+	// +optional
+	CanaryClusters *int `json:"canaryClusters,omitempty"`
+
+	// WavePercentage is the percentage of the selected clusters upgraded in every wave after the canary.
+	// Defaults to 25.
+	// +optional
+	WavePercentage *int `json:"wavePercentage,omitempty"`
+
+	// MaxFailures is the number of failed cluster upgrades tolerated by the rollout.
+	// When more clusters fail, the rollout is paused until the failures are fixed.
+	// +optional
+	MaxFailures int `json:"maxFailures,omitempty"`
+}
+
+// UpgradeRolloutPhase is the phase of an UpgradeRollout.
+type UpgradeRolloutPhase string
+
+const (
+	// UpgradeRolloutProgressing means that clusters are being upgraded.
+	UpgradeRolloutProgressing UpgradeRolloutPhase = "Progressing"
+
+	// UpgradeRolloutPaused means that no more cluster upgrades are started, either because the rollout
+	// is paused or because too many clusters failed to upgrade.
+	UpgradeRolloutPaused UpgradeRolloutPhase = "Paused"
+
+	// UpgradeRolloutCompleted means that all the selected clusters have been upgraded.
+	UpgradeRolloutCompleted UpgradeRolloutPhase = "Completed"
+)
+
+// UpgradeRolloutClusterState is the upgrade state of a cluster in an UpgradeRollout.
+type UpgradeRolloutClusterState string
+
+const (
+	// UpgradeRolloutClusterPending means that the upgrade of the cluster hasn't started.
+	UpgradeRolloutClusterPending UpgradeRolloutClusterState = "Pending"
+
+	// UpgradeRolloutClusterUpgrading means that the cluster spec has been updated and the cluster is not ready yet.
+	UpgradeRolloutClusterUpgrading UpgradeRolloutClusterState = "Upgrading"
+
+	// UpgradeRolloutClusterUpgraded means that the cluster has been upgraded and is ready.
+	UpgradeRolloutClusterUpgraded UpgradeRolloutClusterState = "Upgraded"
+
+	// UpgradeRolloutClusterFailed means that the cluster reported a failure while being upgraded.
+	UpgradeRolloutClusterFailed UpgradeRolloutClusterState = "Failed"
+)
+
+// UpgradeRolloutStatus defines the observed state of UpgradeRollout.
+type UpgradeRolloutStatus struct {
+	// ObservedGeneration is the latest generation of the rollout observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the phase of the rollout.
+	Phase UpgradeRolloutPhase `json:"phase,omitempty"`
+
+	// CurrentWave is the index of the wave being upgraded, starting at 0.
+	CurrentWave int `json:"currentWave"`
+
+	// Clusters is the upgrade state of every selected cluster.
+	Clusters []UpgradeRolloutClusterStatus `json:"clusters,omitempty"`
+
+	// FailureMessage explains why the rollout has been paused, like an invalid spec or too many failed clusters.
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+// UpgradeRolloutClusterStatus is the upgrade state of a cluster selected by an UpgradeRollout.
+type UpgradeRolloutClusterStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+
+	// Wave is the index of the wave the cluster is upgraded in.
+	Wave int `json:"wave"`
+
+	// State is the upgrade state of the cluster.
+	State UpgradeRolloutClusterState `json:"state"`
+
+	// FailureMessage is the failure reported by the cluster.
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// UpgradeRollout is the Schema for the upgraderollouts API.
+// It upgrades a set of workload clusters in waves, starting with a canary.
+type UpgradeRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UpgradeRolloutSpec   `json:"spec,omitempty"`
+	Status UpgradeRolloutStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// UpgradeRolloutList contains a list of UpgradeRollout.
+type UpgradeRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UpgradeRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UpgradeRollout{}, &UpgradeRolloutList{})
+}
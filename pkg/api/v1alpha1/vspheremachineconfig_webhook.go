@@ -114,7 +114,7 @@ func validateImmutableFieldsVSphereMachineConfig(new, old *VSphereMachineConfig)
 		)
 	}
 
-	if old.Spec.StoragePolicyName != new.Spec.StoragePolicyName {
+	if old.Spec.StoragePolicyName != new.Spec.StoragePolicyName && !rotatesMachineTemplate(VSphereMachineConfigKind, "storagePolicyName", old.IsManaged()) {
 		allErrs = append(
 			allErrs,
 			field.Forbidden(specPath.Child("storagePolicyName"), "field is immutable"),
@@ -691,6 +691,17 @@ func TestVSphereMachineValidateUpdateStoragePolicyImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(&vOld)).To(MatchError(ContainSubstring("spec.storagePolicyName: Forbidden: field is immutable")))
 }
 
+func TestWorkloadVSphereMachineValidateUpdateStoragePolicySuccess(t *testing.T) {
+	vOld := vsphereMachineConfig()
+	vOld.SetManagedBy("test-cluster")
+	vOld.Spec.StoragePolicyName = "Space-Inefficient"
+	c := vOld.DeepCopy()
+
+	c.Spec.StoragePolicyName = "Space-Efficient"
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(&vOld)).To(Succeed())
+}
+
 func TestVSphereMachineConfigValidateCreateSuccess(t *testing.T) {
 	config := vsphereMachineConfig()
 
@@ -28,6 +28,21 @@ import (
 	apiv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuer.
+func (in *ACMEIssuer) DeepCopy() *ACMEIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSDatacenterConfig) DeepCopyInto(out *AWSDatacenterConfig) {
 	*out = *in
@@ -225,6 +240,26 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessEntry) DeepCopyInto(out *AccessEntry) {
+	*out = *in
+	if in.IAMRoleARNs != nil {
+		in, out := &in.IAMRoleARNs, &out.IAMRoleARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessEntry.
+func (in *AccessEntry) DeepCopy() *AccessEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -240,6 +275,26 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondConfiguration) DeepCopyInto(out *BondConfiguration) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondConfiguration.
+func (in *BondConfiguration) DeepCopy() *BondConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BondConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BottlerocketConfiguration) DeepCopyInto(out *BottlerocketConfiguration) {
 	*out = *in
@@ -310,6 +365,53 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerConfiguration) DeepCopyInto(out *CertManagerConfiguration) {
+	*out = *in
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]CertManagerIssuer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfiguration.
+func (in *CertManagerConfiguration) DeepCopy() *CertManagerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuer) DeepCopyInto(out *CertManagerIssuer) {
+	*out = *in
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(ACMEIssuer)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultIssuer)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuer.
+func (in *CertManagerIssuer) DeepCopy() *CertManagerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
@@ -712,245 +814,136 @@ func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+func (in *ClusterOperation) DeepCopyInto(out *ClusterOperation) {
 	*out = *in
-	in.ControlPlaneConfiguration.DeepCopyInto(&out.ControlPlaneConfiguration)
-	if in.WorkerNodeGroupConfigurations != nil {
-		in, out := &in.WorkerNodeGroupConfigurations, &out.WorkerNodeGroupConfigurations
-		*out = make([]WorkerNodeGroupConfiguration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	out.DatacenterRef = in.DatacenterRef
-	if in.IdentityProviderRefs != nil {
-		in, out := &in.IdentityProviderRefs, &out.IdentityProviderRefs
-		*out = make([]Ref, len(*in))
-		copy(*out, *in)
-	}
-	if in.GitOpsRef != nil {
-		in, out := &in.GitOpsRef, &out.GitOpsRef
-		*out = new(Ref)
-		**out = **in
-	}
-	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
-	if in.ExternalEtcdConfiguration != nil {
-		in, out := &in.ExternalEtcdConfiguration, &out.ExternalEtcdConfiguration
-		*out = new(ExternalEtcdConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProxyConfiguration != nil {
-		in, out := &in.ProxyConfiguration, &out.ProxyConfiguration
-		*out = new(ProxyConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RegistryMirrorConfiguration != nil {
-		in, out := &in.RegistryMirrorConfiguration, &out.RegistryMirrorConfiguration
-		*out = new(RegistryMirrorConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	out.ManagementCluster = in.ManagementCluster
-	if in.PodIAMConfig != nil {
-		in, out := &in.PodIAMConfig, &out.PodIAMConfig
-		*out = new(PodIAMConfig)
-		**out = **in
-	}
-	if in.Packages != nil {
-		in, out := &in.Packages, &out.Packages
-		*out = new(PackageConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BundlesRef != nil {
-		in, out := &in.BundlesRef, &out.BundlesRef
-		*out = new(BundlesRef)
-		**out = **in
-	}
-	if in.EksaVersion != nil {
-		in, out := &in.EksaVersion, &out.EksaVersion
-		*out = new(EksaVersion)
-		**out = **in
-	}
-	if in.MachineHealthCheck != nil {
-		in, out := &in.MachineHealthCheck, &out.MachineHealthCheck
-		*out = new(MachineHealthCheck)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EtcdEncryption != nil {
-		in, out := &in.EtcdEncryption, &out.EtcdEncryption
-		*out = new([]EtcdEncryption)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]EtcdEncryption, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
-func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperation.
+func (in *ClusterOperation) DeepCopy() *ClusterOperation {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpec)
+	out := new(ClusterOperation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+func (in *ClusterOperationInitiator) DeepCopyInto(out *ClusterOperationInitiator) {
 	*out = *in
-	if in.FailureMessage != nil {
-		in, out := &in.FailureMessage, &out.FailureMessage
-		*out = new(string)
-		**out = **in
-	}
-	if in.FailureReason != nil {
-		in, out := &in.FailureReason, &out.FailureReason
-		*out = new(FailureReasonType)
-		**out = **in
-	}
-	if in.EksdReleaseRef != nil {
-		in, out := &in.EksdReleaseRef, &out.EksdReleaseRef
-		*out = new(EksdReleaseRef)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1beta1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
-func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationInitiator.
+func (in *ClusterOperationInitiator) DeepCopy() *ClusterOperationInitiator {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStatus)
+	out := new(ClusterOperationInitiator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
+func (in *ClusterOperationList) DeepCopyInto(out *ClusterOperationList) {
 	*out = *in
-	if in.Endpoint != nil {
-		in, out := &in.Endpoint, &out.Endpoint
-		*out = new(Endpoint)
-		**out = **in
-	}
-	if in.MachineGroupRef != nil {
-		in, out := &in.MachineGroupRef, &out.MachineGroupRef
-		*out = new(Ref)
-		**out = **in
-	}
-	if in.Taints != nil {
-		in, out := &in.Taints, &out.Taints
-		*out = make([]v1.Taint, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOperation, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.UpgradeRolloutStrategy != nil {
-		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
-		*out = new(ControlPlaneUpgradeRolloutStrategy)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
-func (in *ControlPlaneConfiguration) DeepCopy() *ControlPlaneConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationList.
+func (in *ClusterOperationList) DeepCopy() *ClusterOperationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfiguration)
+	out := new(ClusterOperationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneRollingUpdateParams) DeepCopyInto(out *ControlPlaneRollingUpdateParams) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneRollingUpdateParams.
-func (in *ControlPlaneRollingUpdateParams) DeepCopy() *ControlPlaneRollingUpdateParams {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ControlPlaneRollingUpdateParams)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+func (in *ClusterOperationSpec) DeepCopyInto(out *ClusterOperationSpec) {
 	*out = *in
-	out.RollingUpdate = in.RollingUpdate
+	out.Initiator = in.Initiator
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
-func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationSpec.
+func (in *ClusterOperationSpec) DeepCopy() *ClusterOperationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneUpgradeRolloutStrategy)
+	out := new(ClusterOperationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNS) DeepCopyInto(out *DNS) {
+func (in *ClusterOperationStatus) DeepCopyInto(out *ClusterOperationStatus) {
 	*out = *in
-	if in.ResolvConf != nil {
-		in, out := &in.ResolvConf, &out.ResolvConf
-		*out = new(ResolvConf)
-		**out = **in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
-func (in *DNS) DeepCopy() *DNS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationStatus.
+func (in *ClusterOperationStatus) DeepCopy() *ClusterOperationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNS)
+	out := new(ClusterOperationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DockerDatacenterConfig) DeepCopyInto(out *DockerDatacenterConfig) {
+func (in *ClusterProfile) DeepCopyInto(out *ClusterProfile) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerDatacenterConfig.
-func (in *DockerDatacenterConfig) DeepCopy() *DockerDatacenterConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfile.
+func (in *ClusterProfile) DeepCopy() *ClusterProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(DockerDatacenterConfig)
+	out := new(ClusterProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DockerDatacenterConfig) DeepCopyObject() runtime.Object {
+func (in *ClusterProfile) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -958,10 +951,579 @@ func (in *DockerDatacenterConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DockerDatacenterConfigList) DeepCopyInto(out *DockerDatacenterConfigList) {
+func (in *ClusterProfileInstance) DeepCopyInto(out *ClusterProfileInstance) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileInstance.
+func (in *ClusterProfileInstance) DeepCopy() *ClusterProfileInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileInstanceStatus) DeepCopyInto(out *ClusterProfileInstanceStatus) {
+	*out = *in
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileInstanceStatus.
+func (in *ClusterProfileInstanceStatus) DeepCopy() *ClusterProfileInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileList) DeepCopyInto(out *ClusterProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileList.
+func (in *ClusterProfileList) DeepCopy() *ClusterProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileSpec) DeepCopyInto(out *ClusterProfileSpec) {
+	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]ClusterProfileInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileSpec.
+func (in *ClusterProfileSpec) DeepCopy() *ClusterProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileStatus) DeepCopyInto(out *ClusterProfileStatus) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]ClusterProfileInstanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileStatus.
+func (in *ClusterProfileStatus) DeepCopy() *ClusterProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceSet) DeepCopyInto(out *ClusterResourceSet) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSet.
+func (in *ClusterResourceSet) DeepCopy() *ClusterResourceSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	in.ControlPlaneConfiguration.DeepCopyInto(&out.ControlPlaneConfiguration)
+	if in.WorkerNodeGroupConfigurations != nil {
+		in, out := &in.WorkerNodeGroupConfigurations, &out.WorkerNodeGroupConfigurations
+		*out = make([]WorkerNodeGroupConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DatacenterRef = in.DatacenterRef
+	if in.IdentityProviderRefs != nil {
+		in, out := &in.IdentityProviderRefs, &out.IdentityProviderRefs
+		*out = make([]Ref, len(*in))
+		copy(*out, *in)
+	}
+	if in.GitOpsRef != nil {
+		in, out := &in.GitOpsRef, &out.GitOpsRef
+		*out = new(Ref)
+		**out = **in
+	}
+	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
+	if in.ExternalEtcdConfiguration != nil {
+		in, out := &in.ExternalEtcdConfiguration, &out.ExternalEtcdConfiguration
+		*out = new(ExternalEtcdConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyConfiguration != nil {
+		in, out := &in.ProxyConfiguration, &out.ProxyConfiguration
+		*out = new(ProxyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryMirrorConfiguration != nil {
+		in, out := &in.RegistryMirrorConfiguration, &out.RegistryMirrorConfiguration
+		*out = new(RegistryMirrorConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ManagementCluster = in.ManagementCluster
+	if in.PodIAMConfig != nil {
+		in, out := &in.PodIAMConfig, &out.PodIAMConfig
+		*out = new(PodIAMConfig)
+		**out = **in
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackageConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BundlesRef != nil {
+		in, out := &in.BundlesRef, &out.BundlesRef
+		*out = new(BundlesRef)
+		**out = **in
+	}
+	if in.EksaVersion != nil {
+		in, out := &in.EksaVersion, &out.EksaVersion
+		*out = new(EksaVersion)
+		**out = **in
+	}
+	if in.MachineHealthCheck != nil {
+		in, out := &in.MachineHealthCheck, &out.MachineHealthCheck
+		*out = new(MachineHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdEncryption != nil {
+		in, out := &in.EtcdEncryption, &out.EtcdEncryption
+		*out = new([]EtcdEncryption)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]EtcdEncryption, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ManagementControllers != nil {
+		in, out := &in.ManagementControllers, &out.ManagementControllers
+		*out = new(ManagementControllersConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageWarmCache != nil {
+		in, out := &in.ImageWarmCache, &out.ImageWarmCache
+		*out = new(ImageWarmCacheConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessEntries != nil {
+		in, out := &in.AccessEntries, &out.AccessEntries
+		*out = make([]AccessEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityAdmission != nil {
+		in, out := &in.PodSecurityAdmission, &out.PodSecurityAdmission
+		*out = new(PodSecurityAdmissionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyEngine != nil {
+		in, out := &in.PolicyEngine, &out.PolicyEngine
+		*out = new(PolicyEngineConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetalLB != nil {
+		in, out := &in.MetalLB, &out.MetalLB
+		*out = new(MetalLBConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderCredentials != nil {
+		in, out := &in.ProviderCredentials, &out.ProviderCredentials
+		*out = make([]ProviderCredential, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComponentImageOverrides != nil {
+		in, out := &in.ComponentImageOverrides, &out.ComponentImageOverrides
+		*out = new(ComponentImageOverrides)
+		**out = **in
+	}
+	if in.TemplateOverrides != nil {
+		in, out := &in.TemplateOverrides, &out.TemplateOverrides
+		*out = make([]TemplateOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSConfiguration != nil {
+		in, out := &in.TLSConfiguration, &out.TLSConfiguration
+		*out = new(TLSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmPostRenderer != nil {
+		in, out := &in.HelmPostRenderer, &out.HelmPostRenderer
+		*out = new(HelmPostRenderer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterResourceSets != nil {
+		in, out := &in.ClusterResourceSets, &out.ClusterResourceSets
+		*out = make([]ClusterResourceSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevision) DeepCopyInto(out *ClusterSpecRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevision.
+func (in *ClusterSpecRevision) DeepCopy() *ClusterSpecRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSpecRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevisionList) DeepCopyInto(out *ClusterSpecRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSpecRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevisionList.
+func (in *ClusterSpecRevisionList) DeepCopy() *ClusterSpecRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSpecRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecRevisionSpec) DeepCopyInto(out *ClusterSpecRevisionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecRevisionSpec.
+func (in *ClusterSpecRevisionSpec) DeepCopy() *ClusterSpecRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(FailureReasonType)
+		**out = **in
+	}
+	if in.EksdReleaseRef != nil {
+		in, out := &in.EksdReleaseRef, &out.EksdReleaseRef
+		*out = new(EksdReleaseRef)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1beta1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProviderCredentialsRotatedAt != nil {
+		in, out := &in.ProviderCredentialsRotatedAt, &out.ProviderCredentialsRotatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ReconcileProgress != nil {
+		in, out := &in.ReconcileProgress, &out.ReconcileProgress
+		*out = new(ReconcileProgress)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentImageOverrides) DeepCopyInto(out *ComponentImageOverrides) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentImageOverrides.
+func (in *ComponentImageOverrides) DeepCopy() *ComponentImageOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentImageOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(Endpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineGroupRef != nil {
+		in, out := &in.MachineGroupRef, &out.MachineGroupRef
+		*out = new(Ref)
+		**out = **in
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UpgradeRolloutStrategy != nil {
+		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
+		*out = new(ControlPlaneUpgradeRolloutStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
+func (in *ControlPlaneConfiguration) DeepCopy() *ControlPlaneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneRollingUpdateParams) DeepCopyInto(out *ControlPlaneRollingUpdateParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneRollingUpdateParams.
+func (in *ControlPlaneRollingUpdateParams) DeepCopy() *ControlPlaneRollingUpdateParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneRollingUpdateParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+	*out = *in
+	out.RollingUpdate = in.RollingUpdate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNS) DeepCopyInto(out *DNS) {
+	*out = *in
+	if in.ResolvConf != nil {
+		in, out := &in.ResolvConf, &out.ResolvConf
+		*out = new(ResolvConf)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
+func (in *DNS) DeepCopy() *DNS {
+	if in == nil {
+		return nil
+	}
+	out := new(DNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerDatacenterConfig) DeepCopyInto(out *DockerDatacenterConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerDatacenterConfig.
+func (in *DockerDatacenterConfig) DeepCopy() *DockerDatacenterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerDatacenterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DockerDatacenterConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerDatacenterConfigList) DeepCopyInto(out *DockerDatacenterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]DockerDatacenterConfig, len(*in))
@@ -1019,6 +1581,41 @@ func (in *DockerDatacenterConfigStatus) DeepCopy() *DockerDatacenterConfigStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediationConfig) DeepCopyInto(out *DriftRemediationConfig) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]DriftRemediationOverride, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediationConfig.
+func (in *DriftRemediationConfig) DeepCopy() *DriftRemediationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediationOverride) DeepCopyInto(out *DriftRemediationOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediationOverride.
+func (in *DriftRemediationOverride) DeepCopy() *DriftRemediationOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediationOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EksdReleaseRef) DeepCopyInto(out *EksdReleaseRef) {
 	*out = *in
@@ -1034,17 +1631,169 @@ func (in *EksdReleaseRef) DeepCopy() *EksdReleaseRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyAccess) DeepCopyInto(out *EmergencyAccess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyAccess.
+func (in *EmergencyAccess) DeepCopy() *EmergencyAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmergencyAccess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyAccessList) DeepCopyInto(out *EmergencyAccessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmergencyAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyAccessList.
+func (in *EmergencyAccessList) DeepCopy() *EmergencyAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmergencyAccessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyAccessSpec) DeepCopyInto(out *EmergencyAccessSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyAccessSpec.
+func (in *EmergencyAccessSpec) DeepCopy() *EmergencyAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyAccessStatus) DeepCopyInto(out *EmergencyAccessStatus) {
+	*out = *in
+	if in.GrantedAt != nil {
+		in, out := &in.GrantedAt, &out.GrantedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyAccessStatus.
+func (in *EmergencyAccessStatus) DeepCopy() *EmergencyAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(EndpointDNS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointDNS) DeepCopyInto(out *EndpointDNS) {
+	*out = *in
+	if in.Route53 != nil {
+		in, out := &in.Route53, &out.Route53
+		*out = new(Route53DNS)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136DNS)
+		**out = **in
+	}
+	if in.Infoblox != nil {
+		in, out := &in.Infoblox, &out.Infoblox
+		*out = new(InfobloxDNS)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointDNS.
+func (in *EndpointDNS) DeepCopy() *EndpointDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoint)
+	out := new(EndpointDNS)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1116,6 +1865,26 @@ func (in *ExternalEtcdConfiguration) DeepCopy() *ExternalEtcdConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallConfiguration) DeepCopyInto(out *FirewallConfiguration) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallConfiguration.
+func (in *FirewallConfiguration) DeepCopy() *FirewallConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Flux) DeepCopyInto(out *Flux) {
 	*out = *in
@@ -1204,6 +1973,11 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 		*out = new(GitProviderConfig)
 		**out = **in
 	}
+	if in.DriftRemediation != nil {
+		in, out := &in.DriftRemediation, &out.DriftRemediation
+		*out = new(DriftRemediationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfigSpec.
@@ -1387,6 +2161,46 @@ func (in HardwareSelector) DeepCopy() HardwareSelector {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmPostRenderer) DeepCopyInto(out *HelmPostRenderer) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPostRenderer.
+func (in *HelmPostRenderer) DeepCopy() *HelmPostRenderer {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmPostRenderer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkConfiguration) DeepCopyInto(out *HostNetworkConfiguration) {
+	*out = *in
+	if in.Bond != nil {
+		in, out := &in.Bond, &out.Bond
+		*out = new(BondConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkConfiguration.
+func (in *HostNetworkConfiguration) DeepCopy() *HostNetworkConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostOSConfiguration) DeepCopyInto(out *HostOSConfiguration) {
 	*out = *in
@@ -1405,6 +2219,11 @@ func (in *HostOSConfiguration) DeepCopyInto(out *HostOSConfiguration) {
 		*out = make([]certBundle, len(*in))
 		copy(*out, *in)
 	}
+	if in.FirewallConfiguration != nil {
+		in, out := &in.FirewallConfiguration, &out.FirewallConfiguration
+		*out = new(FirewallConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOSConfiguration.
@@ -1447,6 +2266,41 @@ func (in *ImageResource) DeepCopy() *ImageResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageWarmCacheConfiguration) DeepCopyInto(out *ImageWarmCacheConfiguration) {
+	*out = *in
+	if in.AdditionalImages != nil {
+		in, out := &in.AdditionalImages, &out.AdditionalImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageWarmCacheConfiguration.
+func (in *ImageWarmCacheConfiguration) DeepCopy() *ImageWarmCacheConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageWarmCacheConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfobloxDNS) DeepCopyInto(out *InfobloxDNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfobloxDNS.
+func (in *InfobloxDNS) DeepCopy() *InfobloxDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(InfobloxDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KMS) DeepCopyInto(out *KMS) {
 	*out = *in
@@ -1487,6 +2341,36 @@ func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElectionConfiguration) DeepCopyInto(out *LeaderElectionConfiguration) {
+	*out = *in
+	if in.LeaseDuration != nil {
+		in, out := &in.LeaseDuration, &out.LeaseDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewDeadline != nil {
+		in, out := &in.RenewDeadline, &out.RenewDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryPeriod != nil {
+		in, out := &in.RetryPeriod, &out.RetryPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionConfiguration.
+func (in *LeaderElectionConfiguration) DeepCopy() *LeaderElectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderElectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineHealthCheck) DeepCopyInto(out *MachineHealthCheck) {
 	*out = *in
@@ -1527,6 +2411,26 @@ func (in *ManagementCluster) DeepCopy() *ManagementCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementControllersConfiguration) DeepCopyInto(out *ManagementControllersConfiguration) {
+	*out = *in
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(LeaderElectionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementControllersConfiguration.
+func (in *ManagementControllersConfiguration) DeepCopy() *ManagementControllersConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementControllersConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MapRoles) DeepCopyInto(out *MapRoles) {
 	*out = *in
@@ -1567,6 +2471,73 @@ func (in *MapUsers) DeepCopy() *MapUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalLBAddressPool) DeepCopyInto(out *MetalLBAddressPool) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoAssign != nil {
+		in, out := &in.AutoAssign, &out.AutoAssign
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalLBAddressPool.
+func (in *MetalLBAddressPool) DeepCopy() *MetalLBAddressPool {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalLBAddressPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalLBBGPPeer) DeepCopyInto(out *MetalLBBGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalLBBGPPeer.
+func (in *MetalLBBGPPeer) DeepCopy() *MetalLBBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalLBBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalLBConfiguration) DeepCopyInto(out *MetalLBConfiguration) {
+	*out = *in
+	if in.AddressPools != nil {
+		in, out := &in.AddressPools, &out.AddressPools
+		*out = make([]MetalLBAddressPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BGPPeers != nil {
+		in, out := &in.BGPPeers, &out.BGPPeers
+		*out = make([]MetalLBBGPPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalLBConfiguration.
+func (in *MetalLBConfiguration) DeepCopy() *MetalLBConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalLBConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NTPConfiguration) DeepCopyInto(out *NTPConfiguration) {
 	*out = *in
@@ -2035,135 +3006,263 @@ func (in *PackageConfiguration) DeepCopyInto(out *PackageConfiguration) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageConfiguration.
-func (in *PackageConfiguration) DeepCopy() *PackageConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageConfiguration.
+func (in *PackageConfiguration) DeepCopy() *PackageConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageControllerConfiguration) DeepCopyInto(out *PackageControllerConfiguration) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Resources = in.Resources
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerConfiguration.
+func (in *PackageControllerConfiguration) DeepCopy() *PackageControllerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageControllerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageControllerCronJob) DeepCopyInto(out *PackageControllerCronJob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerCronJob.
+func (in *PackageControllerCronJob) DeepCopy() *PackageControllerCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageControllerCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageControllerResources) DeepCopyInto(out *PackageControllerResources) {
+	*out = *in
+	out.Requests = in.Requests
+	out.Limits = in.Limits
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerResources.
+func (in *PackageControllerResources) DeepCopy() *PackageControllerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageControllerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIAMConfig) DeepCopyInto(out *PodIAMConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIAMConfig.
+func (in *PodIAMConfig) DeepCopy() *PodIAMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIAMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAdmissionConfiguration) DeepCopyInto(out *PodSecurityAdmissionConfiguration) {
+	*out = *in
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfiguration.
+func (in *PodSecurityAdmissionConfiguration) DeepCopy() *PodSecurityAdmissionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAdmissionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pods) DeepCopyInto(out *Pods) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pods.
+func (in *Pods) DeepCopy() *Pods {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageConfiguration)
+	out := new(Pods)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageControllerConfiguration) DeepCopyInto(out *PackageControllerConfiguration) {
+func (in *PolicyEngineConfiguration) DeepCopyInto(out *PolicyEngineConfiguration) {
 	*out = *in
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.Resources = in.Resources
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerConfiguration.
-func (in *PackageControllerConfiguration) DeepCopy() *PackageControllerConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyEngineConfiguration.
+func (in *PolicyEngineConfiguration) DeepCopy() *PolicyEngineConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageControllerConfiguration)
+	out := new(PolicyEngineConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageControllerCronJob) DeepCopyInto(out *PackageControllerCronJob) {
+func (in *ProviderCredential) DeepCopyInto(out *ProviderCredential) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerCronJob.
-func (in *PackageControllerCronJob) DeepCopy() *PackageControllerCronJob {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredential.
+func (in *ProviderCredential) DeepCopy() *ProviderCredential {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageControllerCronJob)
+	out := new(ProviderCredential)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageControllerResources) DeepCopyInto(out *PackageControllerResources) {
+func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
 	*out = *in
-	out.Requests = in.Requests
-	out.Limits = in.Limits
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageControllerResources.
-func (in *PackageControllerResources) DeepCopy() *PackageControllerResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfiguration.
+func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageControllerResources)
+	out := new(ProxyConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodIAMConfig) DeepCopyInto(out *PodIAMConfig) {
+func (in *RFC2136DNS) DeepCopyInto(out *RFC2136DNS) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIAMConfig.
-func (in *PodIAMConfig) DeepCopy() *PodIAMConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136DNS.
+func (in *RFC2136DNS) DeepCopy() *RFC2136DNS {
 	if in == nil {
 		return nil
 	}
-	out := new(PodIAMConfig)
+	out := new(RFC2136DNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pods) DeepCopyInto(out *Pods) {
+func (in *ReconcileProgress) DeepCopyInto(out *ReconcileProgress) {
 	*out = *in
-	if in.CidrBlocks != nil {
-		in, out := &in.CidrBlocks, &out.CidrBlocks
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileProgress.
+func (in *ReconcileProgress) DeepCopy() *ReconcileProgress {
+	if in == nil {
+		return nil
 	}
+	out := new(ReconcileProgress)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pods.
-func (in *Pods) DeepCopy() *Pods {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ref) DeepCopyInto(out *Ref) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ref.
+func (in *Ref) DeepCopy() *Ref {
 	if in == nil {
 		return nil
 	}
-	out := new(Pods)
+	out := new(Ref)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
+func (in *RegistryHost) DeepCopyInto(out *RegistryHost) {
 	*out = *in
-	if in.NoProxy != nil {
-		in, out := &in.NoProxy, &out.NoProxy
-		*out = make([]string, len(*in))
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]RegistryHostCapability, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfiguration.
-func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryHost.
+func (in *RegistryHost) DeepCopy() *RegistryHost {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfiguration)
+	out := new(RegistryHost)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Ref) DeepCopyInto(out *Ref) {
+func (in *RegistryHostsConfiguration) DeepCopyInto(out *RegistryHostsConfiguration) {
 	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]RegistryHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ref.
-func (in *Ref) DeepCopy() *Ref {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryHostsConfiguration.
+func (in *RegistryHostsConfiguration) DeepCopy() *RegistryHostsConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Ref)
+	out := new(RegistryHostsConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2176,6 +3275,13 @@ func (in *RegistryMirrorConfiguration) DeepCopyInto(out *RegistryMirrorConfigura
 		*out = make([]OCINamespace, len(*in))
 		copy(*out, *in)
 	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]RegistryHostsConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirrorConfiguration.
@@ -2203,6 +3309,36 @@ func (in *ResolvConf) DeepCopy() *ResolvConf {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route53DNS) DeepCopyInto(out *Route53DNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53DNS.
+func (in *Route53DNS) DeepCopy() *Route53DNS {
+	if in == nil {
+		return nil
+	}
+	out := new(Route53DNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route53DNS01Solver) DeepCopyInto(out *Route53DNS01Solver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53DNS01Solver.
+func (in *Route53DNS01Solver) DeepCopy() *Route53DNS01Solver {
+	if in == nil {
+		return nil
+	}
+	out := new(Route53DNS01Solver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Services) DeepCopyInto(out *Services) {
 	*out = *in
@@ -2596,6 +3732,41 @@ func (in SymlinkMaps) DeepCopy() SymlinkMaps {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfiguration) DeepCopyInto(out *TLSConfiguration) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfiguration.
+func (in *TLSConfiguration) DeepCopy() *TLSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateOverride) DeepCopyInto(out *TemplateOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateOverride.
+func (in *TemplateOverride) DeepCopy() *TemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinkerbellDatacenterConfig) DeepCopyInto(out *TinkerbellDatacenterConfig) {
 	*out = *in
@@ -2767,6 +3938,11 @@ func (in *TinkerbellMachineConfigSpec) DeepCopyInto(out *TinkerbellMachineConfig
 		*out = new(HostOSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(HostNetworkConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellMachineConfigSpec.
@@ -2884,6 +4060,159 @@ func (in *TinkerbellTemplateConfigStatus) DeepCopy() *TinkerbellTemplateConfigSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRollout) DeepCopyInto(out *UpgradeRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRollout.
+func (in *UpgradeRollout) DeepCopy() *UpgradeRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutClusterStatus) DeepCopyInto(out *UpgradeRolloutClusterStatus) {
+	*out = *in
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutClusterStatus.
+func (in *UpgradeRolloutClusterStatus) DeepCopy() *UpgradeRolloutClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutList) DeepCopyInto(out *UpgradeRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UpgradeRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutList.
+func (in *UpgradeRolloutList) DeepCopy() *UpgradeRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutSpec) DeepCopyInto(out *UpgradeRolloutSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.EksaVersion != nil {
+		in, out := &in.EksaVersion, &out.EksaVersion
+		*out = new(EksaVersion)
+		**out = **in
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutSpec.
+func (in *UpgradeRolloutSpec) DeepCopy() *UpgradeRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutStatus) DeepCopyInto(out *UpgradeRolloutStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]UpgradeRolloutClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutStatus.
+func (in *UpgradeRolloutStatus) DeepCopy() *UpgradeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutStrategy) DeepCopyInto(out *UpgradeRolloutStrategy) {
+	*out = *in
+	if in.CanaryClusters != nil {
+		in, out := &in.CanaryClusters, &out.CanaryClusters
+		*out = new(int)
+		**out = **in
+	}
+	if in.WavePercentage != nil {
+		in, out := &in.WavePercentage, &out.WavePercentage
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutStrategy.
+func (in *UpgradeRolloutStrategy) DeepCopy() *UpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
 	*out = *in
@@ -3104,6 +4433,21 @@ func (in *VSphereMachineConfigStatus) DeepCopy() *VSphereMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIssuer.
+func (in *VaultIssuer) DeepCopy() *VaultIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfiguration) {
 	*out = *in
@@ -0,0 +1,134 @@
+package apiserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+)
+
+// OperationType is the kind of lifecycle operation run by the server.
+type OperationType string
+
+// Types of operations.
+const (
+	CreateOperation  OperationType = "create"
+	UpgradeOperation OperationType = "upgrade"
+	DeleteOperation  OperationType = "delete"
+)
+
+// OperationStatus is the state of an operation.
+type OperationStatus string
+
+// Statuses of an operation.
+const (
+	OperationRunning   OperationStatus = "running"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// Operation is a cluster lifecycle operation run asynchronously by the server. Clients poll it
+// until it's no longer running.
+type Operation struct {
+	ID          string              `json:"id"`
+	Type        OperationType       `json:"type"`
+	Cluster     string              `json:"cluster"`
+	Namespace   string              `json:"namespace"`
+	Status      OperationStatus     `json:"status"`
+	Error       *eksaerrors.Failure `json:"error,omitempty"`
+	StartedAt   time.Time           `json:"startedAt"`
+	CompletedAt *time.Time          `json:"completedAt,omitempty"`
+}
+
+// operations tracks the operations run by the server in memory. They are lost when the server
+// stops, the state of the clusters is always available from the management cluster.
+type operations struct {
+	mu    sync.Mutex
+	byID  map[string]*Operation
+	ids   []string
+	now   func() time.Time
+	newID func() string
+}
+
+func newOperations() *operations {
+	return &operations{
+		byID:  map[string]*Operation{},
+		now:   time.Now,
+		newID: uuid.NewString,
+	}
+}
+
+// start registers a new running operation. Only one operation can run at a time for a cluster.
+func (o *operations) start(opType OperationType, clusterName, namespace string) (Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, op := range o.byID {
+		if op.Cluster == clusterName && op.Namespace == namespace && op.Status == OperationRunning {
+			return Operation{}, fmt.Errorf("operation %s (%s) is already running for cluster %s", op.ID, op.Type, clusterName)
+		}
+	}
+
+	op := &Operation{
+		ID:        o.newID(),
+		Type:      opType,
+		Cluster:   clusterName,
+		Namespace: namespace,
+		Status:    OperationRunning,
+		StartedAt: o.now().UTC(),
+	}
+	o.byID[op.ID] = op
+	o.ids = append(o.ids, op.ID)
+
+	return *op, nil
+}
+
+// finish marks an operation as completed, failed if err is not nil.
+func (o *operations) finish(id string, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.byID[id]
+	if !ok {
+		return
+	}
+
+	completedAt := o.now().UTC()
+	op.CompletedAt = &completedAt
+	op.Status = OperationSucceeded
+	if err != nil {
+		f := eksaerrors.NewFailure(err)
+		op.Status = OperationFailed
+		op.Error = &f
+	}
+}
+
+func (o *operations) get(id string) (Operation, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.byID[id]
+	if !ok {
+		return Operation{}, false
+	}
+	return *op, true
+}
+
+// list returns the operations in the order they were started. If clusterName is not empty, only
+// the operations of that cluster are returned.
+func (o *operations) list(clusterName string) []Operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	ops := make([]Operation, 0, len(o.ids))
+	for _, id := range o.ids {
+		op := o.byID[id]
+		if clusterName == "" || op.Cluster == clusterName {
+			ops = append(ops, *op)
+		}
+	}
+	return ops
+}
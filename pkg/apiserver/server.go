@@ -0,0 +1,541 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// DefaultAddress is the address the server listens on by default. It's only reachable from
+	// the admin machine.
+	DefaultAddress = "127.0.0.1:8181"
+
+	waitForClusterDeletionTimeout = time.Hour
+	retryBackOff                  = 5 * time.Second
+	maxConfigSize                 = 10 << 20
+	shutdownTimeout               = 30 * time.Second
+	tokenSize                     = 32
+)
+
+// configContentTypes are the content types accepted for the cluster config of create and upgrade.
+var configContentTypes = []string{"application/yaml", "application/json"}
+
+// ClusterApplier applies a cluster spec to the management cluster and waits until the changes
+// are fully reconciled.
+type ClusterApplier interface {
+	Run(ctx context.Context, spec *cluster.Spec, managementCluster types.Cluster) error
+}
+
+// ServerOpt allows to customize a Server on construction.
+type ServerOpt func(*Server)
+
+// WithDeleteTimeout configures how long the server waits for a deleted cluster to be gone.
+func WithDeleteTimeout(timeout time.Duration) ServerOpt {
+	return func(s *Server) {
+		s.deleteTimeout = timeout
+	}
+}
+
+// WithRetryBackOff configures how long the server waits between checks of a deleted cluster.
+// Generally only used in tests.
+func WithRetryBackOff(backOff time.Duration) ServerOpt {
+	return func(s *Server) {
+		s.retryBackOff = backOff
+	}
+}
+
+// Server exposes the lifecycle operations of the workload clusters of a management cluster over
+// a REST API. Create, upgrade and delete run asynchronously and are tracked as Operations.
+type Server struct {
+	log               logr.Logger
+	client            kubernetes.Client
+	applier           ClusterApplier
+	managementCluster types.Cluster
+	address           string
+	allowedHosts      map[string]struct{}
+	port              string
+	token             string
+	operations        *operations
+	deleteTimeout     time.Duration
+	retryBackOff      time.Duration
+
+	// ctx is the parent context of the operations, canceled by Close.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// New builds a Server for managementCluster. client must be a client for the management cluster.
+// The server listens on address and only accepts requests for the hosts it can be reached at on
+// that port, authenticated with the bearer token.
+func New(log logr.Logger, client kubernetes.Client, applier ClusterApplier, managementCluster types.Cluster, address, token string, opts ...ServerOpt) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		log:               log,
+		client:            client,
+		applier:           applier,
+		managementCluster: managementCluster,
+		address:           address,
+		token:             token,
+		operations:        newOperations(),
+		deleteTimeout:     waitForClusterDeletionTimeout,
+		retryBackOff:      retryBackOff,
+		ctx:               ctx,
+		cancel:            cancel,
+	}
+
+	s.allowedHosts, s.port = allowedHosts(address)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// allowedHosts returns the hosts the server listening on address can be reached at and its port.
+// These are the loopback and unspecified addresses, localhost and the address host or, when it's
+// unspecified, the addresses of all the interfaces of the machine.
+func allowedHosts(address string) (map[string]struct{}, string) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return map[string]struct{}{}, ""
+	}
+
+	hosts := map[string]struct{}{}
+	for _, h := range []string{host, "localhost", "127.0.0.1", "::1", "0.0.0.0", "::"} {
+		hosts[normalizeHost(h)] = struct{}{}
+	}
+
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		addrs, err := net.InterfaceAddrs()
+		if err != nil {
+			return hosts, port
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				hosts[ipNet.IP.String()] = struct{}{}
+			}
+		}
+	}
+
+	return hosts, port
+}
+
+// normalizeHost makes the different spellings of a host comparable.
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return host
+}
+
+func (s *Server) hostAllowed(hostPort string) bool {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil || port != s.port {
+		return false
+	}
+	_, ok := s.allowedHosts[normalizeHost(host)]
+	return ok
+}
+
+// NewToken generates a random token to authenticate the clients of a Server.
+func NewToken() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating API token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handler returns the http.Handler with the API routes:
+//
+//	GET    /healthz
+//	GET    /v1/clusters[?namespace=]
+//	POST   /v1/clusters
+//	GET    /v1/clusters/{name}[?namespace=]
+//	PUT    /v1/clusters/{name}
+//	DELETE /v1/clusters/{name}[?namespace=]
+//	GET    /v1/operations[?cluster=]
+//	GET    /v1/operations/{id}
+//
+// All the requests must be sent to the address of the server and, except /healthz, carry the
+// token in an Authorization: Bearer header.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/v1/clusters", s.clusters)
+	mux.HandleFunc("/v1/clusters/", s.clusterByName)
+	mux.HandleFunc("/v1/operations", s.listOperations)
+	mux.HandleFunc("/v1/operations/", s.getOperation)
+	return s.authenticate(mux)
+}
+
+// authenticate rejects the requests for a host the server can't be reached at, which protects the
+// API from DNS rebinding, and the requests without the server token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			writeError(w, http.StatusForbidden, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("host %s is not allowed", r.Host)))
+			return
+		}
+
+		if r.URL.Path != "/healthz" && !s.validToken(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, errors.New("missing or invalid bearer token")))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) validToken(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// Serve listens on the server address and serves the API until ctx is canceled. Then it stops
+// accepting requests and cancels the running operations.
+func (s *Server) Serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %v", s.address, err)
+	}
+	// The port might have been picked by the system
+	_, s.port, _ = net.SplitHostPort(listener.Addr().String())
+
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	s.log.Info("Serving the EKS Anywhere API", "address", listener.Addr().String(), "managementCluster", s.managementCluster.Name)
+
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err = server.Shutdown(shutdownCtx)
+	}
+	s.Close()
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving API: %v", err)
+	}
+	return nil
+}
+
+// Close cancels the running operations and waits for them to return.
+func (s *Server) Close() {
+	s.cancel()
+	s.running.Wait()
+}
+
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) clusters(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listClusters(w, r)
+	case http.MethodPost:
+		s.applyCluster(w, r, CreateOperation, "")
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (s *Server) clusterByName(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/v1/clusters/")
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("path %s not found", r.URL.Path)))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.getCluster(w, r, name)
+	case http.MethodPut:
+		s.applyCluster(w, r, UpgradeOperation, name)
+	case http.MethodDelete:
+		s.deleteCluster(w, r, name)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request) {
+	list := &anywherev1.ClusterList{}
+	if err := s.client.List(r.Context(), list); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("listing clusters: %v", err))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	clusters := make([]anywherev1.Cluster, 0, len(list.Items))
+	for _, c := range list.Items {
+		if namespace == "" || c.Namespace == namespace {
+			clusters = append(clusters, c)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, clusters)
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, r *http.Request, name string) {
+	c, ok := s.readCluster(r.Context(), w, name, namespaceFromQuery(r))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+// applyCluster starts the create or upgrade of the workload cluster in the request body, a cluster
+// config with the same format as the one used with the CLI.
+func (s *Server) applyCluster(w http.ResponseWriter, r *http.Request, opType OperationType, name string) {
+	if !isConfigContentType(r.Header.Get("Content-Type")) {
+		writeError(w, http.StatusUnsupportedMediaType, eksaerrors.WithCode(eksaerrors.CodeInvalidInput,
+			fmt.Errorf("content type must be one of %s", strings.Join(configContentTypes, ", ")),
+		))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxConfigSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("reading cluster config: %v", err)))
+		return
+	}
+
+	config, err := cluster.ParseConfig(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, fmt.Errorf("parsing cluster config: %v", err)))
+		return
+	}
+	// The objects are applied with the cluster client, which doesn't default the namespace.
+	for _, obj := range config.ClusterAndChildren() {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(constants.DefaultNamespace)
+		}
+	}
+	if err = cluster.SetConfigDefaults(config); err != nil {
+		writeError(w, http.StatusBadRequest, eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, fmt.Errorf("setting cluster config defaults: %v", err)))
+		return
+	}
+	if err = cluster.ValidateConfig(config); err != nil {
+		writeError(w, http.StatusBadRequest, eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, err))
+		return
+	}
+
+	c := config.Cluster
+	if name != "" && c.Name != name {
+		writeError(w, http.StatusBadRequest, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("cluster config is for cluster %s, not %s", c.Name, name)))
+		return
+	}
+	if err = s.validateWorkloadCluster(c); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = s.client.Get(r.Context(), c.Name, c.Namespace, &anywherev1.Cluster{})
+	switch {
+	case err == nil && opType == CreateOperation:
+		writeError(w, http.StatusConflict, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("cluster %s already exists", c.Name)))
+		return
+	case apierrors.IsNotFound(err) && opType == UpgradeOperation:
+		writeError(w, http.StatusNotFound, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("cluster %s not found", c.Name)))
+		return
+	case err != nil && !apierrors.IsNotFound(err):
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("getting cluster %s: %v", c.Name, err))
+		return
+	}
+
+	code := eksaerrors.CodeClusterCreate
+	if opType == UpgradeOperation {
+		code = eksaerrors.CodeClusterUpgrade
+	}
+	s.startOperation(w, opType, c, func(ctx context.Context) error {
+		spec := &cluster.Spec{Config: config}
+		return eksaerrors.WithCode(code, s.applier.Run(ctx, spec, s.managementCluster))
+	})
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, r *http.Request, name string) {
+	c, ok := s.readCluster(r.Context(), w, name, namespaceFromQuery(r))
+	if !ok {
+		return
+	}
+	if err := s.validateWorkloadCluster(c); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.startOperation(w, DeleteOperation, c, func(ctx context.Context) error {
+		return eksaerrors.WithCode(eksaerrors.CodeClusterDelete, s.waitForDeletion(ctx, c))
+	})
+}
+
+// waitForDeletion deletes the cluster object and waits until it's gone. The EKS Anywhere
+// controller deletes the workload cluster before removing its finalizer.
+func (s *Server) waitForDeletion(ctx context.Context, c *anywherev1.Cluster) error {
+	if err := s.client.Delete(ctx, c); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting cluster %s: %v", c.Name, err)
+	}
+
+	return retrier.New(s.deleteTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(s.retryBackOff))).Retry(func() error {
+		err := s.client.Get(ctx, c.Name, c.Namespace, &anywherev1.Cluster{})
+		switch {
+		case apierrors.IsNotFound(err):
+			return nil
+		case err != nil:
+			return fmt.Errorf("getting cluster %s: %v", c.Name, err)
+		default:
+			return fmt.Errorf("cluster %s is still being deleted", c.Name)
+		}
+	})
+}
+
+// startOperation registers an operation for c and runs it in the background, replying with the
+// running operation.
+func (s *Server) startOperation(w http.ResponseWriter, opType OperationType, c *anywherev1.Cluster, run func(ctx context.Context) error) {
+	op, err := s.operations.start(opType, c.Name, c.Namespace)
+	if err != nil {
+		writeError(w, http.StatusConflict, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, err))
+		return
+	}
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		log := s.log.WithValues("operation", op.ID, "type", op.Type, "cluster", op.Cluster)
+		log.Info("Operation started")
+		err := run(s.ctx)
+		s.operations.finish(op.ID, err)
+		if err != nil {
+			log.Error(err, "Operation failed")
+			return
+		}
+		log.Info("Operation succeeded")
+	}()
+
+	w.Header().Set("Location", "/v1/operations/"+op.ID)
+	writeJSON(w, http.StatusAccepted, op)
+}
+
+func (s *Server) validateWorkloadCluster(c *anywherev1.Cluster) error {
+	if c.IsSelfManaged() || c.ManagedBy() != s.managementCluster.Name {
+		return eksaerrors.WithCode(eksaerrors.CodeInvalidInput,
+			fmt.Errorf("cluster %s is not a workload cluster of the management cluster %s", c.Name, s.managementCluster.Name),
+		)
+	}
+	return nil
+}
+
+func (s *Server) readCluster(ctx context.Context, w http.ResponseWriter, name, namespace string) (*anywherev1.Cluster, bool) {
+	c := &anywherev1.Cluster{}
+	err := s.client.Get(ctx, name, namespace, c)
+	switch {
+	case apierrors.IsNotFound(err):
+		writeError(w, http.StatusNotFound, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("cluster %s not found", name)))
+		return nil, false
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("getting cluster %s: %v", name, err))
+		return nil, false
+	}
+	return c, true
+}
+
+func (s *Server) listOperations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.operations.list(r.URL.Query().Get("cluster")))
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/operations/")
+	op, ok := s.operations.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, fmt.Errorf("operation %s not found", id)))
+		return
+	}
+	writeJSON(w, http.StatusOK, op)
+}
+
+func isConfigContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range configContentTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+func namespaceFromQuery(r *http.Request) string {
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+		return namespace
+	}
+	return constants.DefaultNamespace
+}
+
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, eksaerrors.WithCode(eksaerrors.CodeInvalidInput, errors.New("method not allowed")))
+}
+
+// writeError replies with the error as a Failure, the same object the CLI prints with
+// --error-format json.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, eksaerrors.NewFailure(err))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
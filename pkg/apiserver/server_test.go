@@ -0,0 +1,517 @@
+package apiserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/apiserver"
+	"github.com/aws/eks-anywhere/pkg/apiserver/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const workloadConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: w01
+spec:
+  clusterNetwork:
+    cniConfig:
+      cilium: {}
+    pods:
+      cidrBlocks:
+      - 192.168.0.0/16
+    services:
+      cidrBlocks:
+      - 10.96.0.0/12
+  controlPlaneConfiguration:
+    count: 1
+  datacenterRef:
+    kind: DockerDatacenterConfig
+    name: w01
+  kubernetesVersion: "1.27"
+  managementCluster:
+    name: mgmt
+  workerNodeGroupConfigurations:
+  - name: md-0
+    count: 1
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: w01
+spec: {}
+`
+
+const token = "token"
+
+type serverTest struct {
+	*WithT
+	applier *mocks.MockClusterApplier
+	server  *apiserver.Server
+	http    *httptest.Server
+}
+
+func newServerTest(t *testing.T, objs ...client.Object) *serverTest {
+	ctrl := gomock.NewController(t)
+	applier := mocks.NewMockClusterApplier(ctrl)
+	httpServer := httptest.NewUnstartedServer(nil)
+	server := apiserver.New(
+		test.NewNullLogger(),
+		test.NewFakeKubeClient(objs...),
+		applier,
+		types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		httpServer.Listener.Addr().String(),
+		token,
+		apiserver.WithDeleteTimeout(time.Second),
+		apiserver.WithRetryBackOff(10*time.Millisecond),
+	)
+	httpServer.Config.Handler = server.Handler()
+	httpServer.Start()
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Close()
+	})
+
+	return &serverTest{
+		WithT:   NewWithT(t),
+		applier: applier,
+		server:  server,
+		http:    httpServer,
+	}
+}
+
+func (tt *serverTest) do(method, path, body string) (int, []byte) {
+	req := tt.request(method, path, body)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/yaml")
+	}
+	return tt.send(req)
+}
+
+func (tt *serverTest) request(method, path, body string) *http.Request {
+	req, err := http.NewRequest(method, tt.http.URL+path, strings.NewReader(body))
+	tt.Expect(err).NotTo(HaveOccurred())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func (tt *serverTest) send(req *http.Request) (int, []byte) {
+	resp, err := tt.http.Client().Do(req)
+	tt.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return resp.StatusCode, b
+}
+
+func (tt *serverTest) operation(body []byte) apiserver.Operation {
+	op := apiserver.Operation{}
+	tt.Expect(json.Unmarshal(body, &op)).To(Succeed())
+	return op
+}
+
+func (tt *serverTest) failure(body []byte) eksaerrors.Failure {
+	f := eksaerrors.Failure{}
+	tt.Expect(json.Unmarshal(body, &f)).To(Succeed())
+	return f
+}
+
+// waitForOperation polls the operation until it's no longer running.
+func (tt *serverTest) waitForOperation(id string) apiserver.Operation {
+	var op apiserver.Operation
+	tt.Eventually(func() apiserver.OperationStatus {
+		status, body := tt.do(http.MethodGet, "/v1/operations/"+id, "")
+		tt.Expect(status).To(Equal(http.StatusOK))
+		op = tt.operation(body)
+		return op.Status
+	}, 5*time.Second, 10*time.Millisecond).ShouldNot(Equal(apiserver.OperationRunning))
+	return op
+}
+
+func workloadCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+		},
+	}
+}
+
+func TestServerHealthz(t *testing.T) {
+	tt := newServerTest(t)
+	status, body := tt.do(http.MethodGet, "/healthz", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	tt.Expect(string(body)).To(Equal("ok"))
+}
+
+func TestNewToken(t *testing.T) {
+	g := NewWithT(t)
+	t1, err := apiserver.NewToken()
+	g.Expect(err).NotTo(HaveOccurred())
+	t2, err := apiserver.NewToken()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(t1).To(HaveLen(64))
+	g.Expect(t1).NotTo(Equal(t2))
+}
+
+func TestServerHealthzWithoutToken(t *testing.T) {
+	tt := newServerTest(t)
+	req := tt.request(http.MethodGet, "/healthz", "")
+	req.Header.Del("Authorization")
+
+	status, _ := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusOK))
+}
+
+func TestServerMissingToken(t *testing.T) {
+	tt := newServerTest(t)
+	req := tt.request(http.MethodGet, "/v1/clusters", "")
+	req.Header.Del("Authorization")
+
+	status, body := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusUnauthorized))
+	tt.Expect(tt.failure(body).Message).To(Equal("missing or invalid bearer token"))
+}
+
+func TestServerInvalidToken(t *testing.T) {
+	tt := newServerTest(t)
+	req := tt.request(http.MethodDelete, "/v1/clusters/w01", "")
+	req.Header.Set("Authorization", "Bearer other")
+
+	status, body := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusUnauthorized))
+	tt.Expect(tt.failure(body).Message).To(Equal("missing or invalid bearer token"))
+}
+
+func TestServerHostNotAllowed(t *testing.T) {
+	tt := newServerTest(t)
+	req := tt.request(http.MethodGet, "/v1/clusters", "")
+	req.Host = "attacker.example.com:8181"
+
+	status, body := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusForbidden))
+	tt.Expect(tt.failure(body).Message).To(Equal("host attacker.example.com:8181 is not allowed"))
+}
+
+func TestServerHostsAllowed(t *testing.T) {
+	tt := newServerTest(t)
+	_, port, err := net.SplitHostPort(tt.http.Listener.Addr().String())
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	for _, host := range []string{"127.0.0.1", "localhost", "LocalHost.", "[::1]", "0.0.0.0"} {
+		req := tt.request(http.MethodGet, "/v1/clusters", "")
+		req.Host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+
+		status, _ := tt.send(req)
+		tt.Expect(status).To(Equal(http.StatusOK), "host %s", req.Host)
+	}
+}
+
+func TestServerHostOtherPortNotAllowed(t *testing.T) {
+	tt := newServerTest(t)
+	for _, host := range []string{"127.0.0.1:1", "127.0.0.1", "localhost"} {
+		req := tt.request(http.MethodGet, "/v1/clusters", "")
+		req.Host = host
+
+		status, _ := tt.send(req)
+		tt.Expect(status).To(Equal(http.StatusForbidden), "host %s", host)
+	}
+}
+
+func TestServerUnspecifiedAddressAllowsInterfaceHosts(t *testing.T) {
+	g := NewWithT(t)
+	addrs, err := net.InterfaceAddrs()
+	g.Expect(err).NotTo(HaveOccurred())
+	var ip net.IP
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+			ip = ipNet.IP
+			break
+		}
+	}
+	if ip == nil {
+		t.Skip("no non loopback interface address")
+	}
+
+	server := apiserver.New(
+		test.NewNullLogger(),
+		test.NewFakeKubeClient(),
+		mocks.NewMockClusterApplier(gomock.NewController(t)),
+		types.Cluster{Name: "mgmt"},
+		"0.0.0.0:8181",
+		token,
+	)
+	t.Cleanup(server.Close)
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	healthz := func(host string) int {
+		req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/healthz", nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		req.Host = host
+		resp, err := httpServer.Client().Do(req)
+		g.Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	g.Expect(healthz(net.JoinHostPort(ip.String(), "8181"))).To(Equal(http.StatusOK))
+	g.Expect(healthz("attacker.example.com:8181")).To(Equal(http.StatusForbidden))
+}
+
+func TestServerCreateClusterUnsupportedContentType(t *testing.T) {
+	tt := newServerTest(t)
+	req := tt.request(http.MethodPost, "/v1/clusters", workloadConfig)
+	req.Header.Set("Content-Type", "text/plain")
+
+	status, body := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusUnsupportedMediaType))
+	tt.Expect(tt.failure(body).Message).To(Equal("content type must be one of application/yaml, application/json"))
+}
+
+func TestServerUpgradeClusterMissingContentType(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+	req := tt.request(http.MethodPut, "/v1/clusters/w01", workloadConfig)
+
+	status, _ := tt.send(req)
+	tt.Expect(status).To(Equal(http.StatusUnsupportedMediaType))
+}
+
+func TestServerCreateCluster(t *testing.T) {
+	tt := newServerTest(t)
+	tt.applier.EXPECT().Run(gomock.Any(), gomock.Any(), types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}).
+		DoAndReturn(func(_ context.Context, spec *cluster.Spec, _ types.Cluster) error {
+			tt.Expect(spec.Cluster.Name).To(Equal("w01"))
+			tt.Expect(spec.Cluster.Namespace).To(Equal("default"))
+			tt.Expect(spec.DockerDatacenter.Namespace).To(Equal("default"))
+			return nil
+		})
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusAccepted), string(body))
+	op := tt.operation(body)
+	tt.Expect(op.Type).To(Equal(apiserver.CreateOperation))
+	tt.Expect(op.Cluster).To(Equal("w01"))
+	tt.Expect(op.Namespace).To(Equal("default"))
+	tt.Expect(op.Status).To(Equal(apiserver.OperationRunning))
+
+	op = tt.waitForOperation(op.ID)
+	tt.Expect(op.Status).To(Equal(apiserver.OperationSucceeded))
+	tt.Expect(op.Error).To(BeNil())
+	tt.Expect(op.CompletedAt).NotTo(BeNil())
+}
+
+func TestServerCreateClusterFailed(t *testing.T) {
+	tt := newServerTest(t)
+	tt.applier.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("waiting for cluster to be ready"))
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusAccepted), string(body))
+
+	op := tt.waitForOperation(tt.operation(body).ID)
+	tt.Expect(op.Status).To(Equal(apiserver.OperationFailed))
+	tt.Expect(op.Error).To(Equal(&eksaerrors.Failure{
+		Code:     eksaerrors.CodeClusterCreate,
+		Category: eksaerrors.CategoryCluster,
+		Message:  "waiting for cluster to be ready",
+	}))
+}
+
+func TestServerCreateClusterAlreadyExists(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusConflict))
+	tt.Expect(tt.failure(body).Message).To(Equal("cluster w01 already exists"))
+}
+
+func TestServerCreateClusterInvalidConfig(t *testing.T) {
+	tt := newServerTest(t)
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", "kind: Cluster\n\tinvalid")
+	tt.Expect(status).To(Equal(http.StatusBadRequest))
+	tt.Expect(tt.failure(body).Code).To(Equal(eksaerrors.CodeClusterConfigRead))
+}
+
+func TestServerCreateClusterNotWorkloadCluster(t *testing.T) {
+	tt := newServerTest(t)
+	config := strings.Replace(workloadConfig, "name: mgmt", "name: w01", 1)
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", config)
+	tt.Expect(status).To(Equal(http.StatusBadRequest))
+	tt.Expect(tt.failure(body)).To(Equal(eksaerrors.Failure{
+		Code:     eksaerrors.CodeInvalidInput,
+		Category: eksaerrors.CategoryInput,
+		Message:  "cluster w01 is not a workload cluster of the management cluster mgmt",
+	}))
+}
+
+func TestServerCreateClusterOperationRunning(t *testing.T) {
+	tt := newServerTest(t)
+	release := make(chan struct{})
+	tt.applier.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *cluster.Spec, _ types.Cluster) error {
+			<-release
+			return nil
+		},
+	)
+
+	status, body := tt.do(http.MethodPost, "/v1/clusters", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusAccepted))
+	op := tt.operation(body)
+
+	status, body = tt.do(http.MethodPost, "/v1/clusters", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusConflict))
+	tt.Expect(tt.failure(body).Message).To(ContainSubstring("is already running for cluster w01"))
+
+	close(release)
+	tt.Expect(tt.waitForOperation(op.ID).Status).To(Equal(apiserver.OperationSucceeded))
+}
+
+func TestServerUpgradeCluster(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+	tt.applier.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	status, body := tt.do(http.MethodPut, "/v1/clusters/w01", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusAccepted), string(body))
+	op := tt.operation(body)
+	tt.Expect(op.Type).To(Equal(apiserver.UpgradeOperation))
+	tt.Expect(tt.waitForOperation(op.ID).Status).To(Equal(apiserver.OperationSucceeded))
+}
+
+func TestServerUpgradeClusterNotFound(t *testing.T) {
+	tt := newServerTest(t)
+
+	status, body := tt.do(http.MethodPut, "/v1/clusters/w01", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusNotFound))
+	tt.Expect(tt.failure(body).Message).To(Equal("cluster w01 not found"))
+}
+
+func TestServerUpgradeClusterNameMismatch(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+
+	status, body := tt.do(http.MethodPut, "/v1/clusters/w02", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusBadRequest))
+	tt.Expect(tt.failure(body).Message).To(Equal("cluster config is for cluster w01, not w02"))
+}
+
+func TestServerDeleteCluster(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+
+	status, body := tt.do(http.MethodDelete, "/v1/clusters/w01", "")
+	tt.Expect(status).To(Equal(http.StatusAccepted), string(body))
+	op := tt.operation(body)
+	tt.Expect(op.Type).To(Equal(apiserver.DeleteOperation))
+	tt.Expect(tt.waitForOperation(op.ID).Status).To(Equal(apiserver.OperationSucceeded))
+
+	status, _ = tt.do(http.MethodGet, "/v1/clusters/w01", "")
+	tt.Expect(status).To(Equal(http.StatusNotFound))
+}
+
+func TestServerDeleteClusterTimeout(t *testing.T) {
+	c := workloadCluster()
+	// The finalizer is never removed without the controller, so the cluster is never gone.
+	c.Finalizers = []string{"clusters.anywhere.eks.amazonaws.com/finalizer"}
+	tt := newServerTest(t, c)
+
+	status, body := tt.do(http.MethodDelete, "/v1/clusters/w01", "")
+	tt.Expect(status).To(Equal(http.StatusAccepted), string(body))
+
+	op := tt.waitForOperation(tt.operation(body).ID)
+	tt.Expect(op.Status).To(Equal(apiserver.OperationFailed))
+	tt.Expect(op.Error.Code).To(Equal(eksaerrors.CodeClusterDelete))
+	tt.Expect(op.Error.Message).To(ContainSubstring("cluster w01 is still being deleted"))
+}
+
+func TestServerDeleteManagementCluster(t *testing.T) {
+	mgmt := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"}}
+	mgmt.SetSelfManaged()
+	tt := newServerTest(t, mgmt)
+
+	status, body := tt.do(http.MethodDelete, "/v1/clusters/mgmt", "")
+	tt.Expect(status).To(Equal(http.StatusBadRequest))
+	tt.Expect(tt.failure(body).Code).To(Equal(eksaerrors.CodeInvalidInput))
+}
+
+func TestServerGetCluster(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+
+	status, body := tt.do(http.MethodGet, "/v1/clusters/w01?namespace=default", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	c := &anywherev1.Cluster{}
+	tt.Expect(json.Unmarshal(body, c)).To(Succeed())
+	tt.Expect(c.Name).To(Equal("w01"))
+	tt.Expect(c.ManagedBy()).To(Equal("mgmt"))
+}
+
+func TestServerListClusters(t *testing.T) {
+	other := workloadCluster()
+	other.Name = "w02"
+	other.Namespace = "team-a"
+	tt := newServerTest(t, workloadCluster(), other)
+
+	status, body := tt.do(http.MethodGet, "/v1/clusters", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	clusters := []anywherev1.Cluster{}
+	tt.Expect(json.Unmarshal(body, &clusters)).To(Succeed())
+	tt.Expect(clusters).To(HaveLen(2))
+
+	status, body = tt.do(http.MethodGet, "/v1/clusters?namespace=team-a", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	tt.Expect(json.Unmarshal(body, &clusters)).To(Succeed())
+	tt.Expect(clusters).To(HaveLen(1))
+	tt.Expect(clusters[0].Name).To(Equal("w02"))
+}
+
+func TestServerListOperations(t *testing.T) {
+	tt := newServerTest(t, workloadCluster())
+	tt.applier.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	status, body := tt.do(http.MethodPut, "/v1/clusters/w01", workloadConfig)
+	tt.Expect(status).To(Equal(http.StatusAccepted))
+	tt.waitForOperation(tt.operation(body).ID)
+
+	status, body = tt.do(http.MethodGet, "/v1/operations?cluster=w01", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	ops := []apiserver.Operation{}
+	tt.Expect(json.Unmarshal(body, &ops)).To(Succeed())
+	tt.Expect(ops).To(HaveLen(1))
+
+	status, body = tt.do(http.MethodGet, "/v1/operations?cluster=w02", "")
+	tt.Expect(status).To(Equal(http.StatusOK))
+	tt.Expect(json.Unmarshal(body, &ops)).To(Succeed())
+	tt.Expect(ops).To(BeEmpty())
+}
+
+func TestServerGetOperationNotFound(t *testing.T) {
+	tt := newServerTest(t)
+
+	status, body := tt.do(http.MethodGet, "/v1/operations/1234", "")
+	tt.Expect(status).To(Equal(http.StatusNotFound))
+	tt.Expect(tt.failure(body).Message).To(Equal("operation 1234 not found"))
+}
+
+func TestServerMethodNotAllowed(t *testing.T) {
+	tt := newServerTest(t)
+
+	status, _ := tt.do(http.MethodPatch, "/v1/clusters", "")
+	tt.Expect(status).To(Equal(http.StatusMethodNotAllowed))
+}
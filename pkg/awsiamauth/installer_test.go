@@ -323,3 +323,78 @@ func TestUpgradeAWSIAMAuth(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(manifest), "testdata/UpgradeAWSIAMAuth-manifest.yaml")
 }
+
+func TestInstallAWSIAMAuthWithAccessEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certs := cryptomocks.NewMockCertificateGenerator(ctrl)
+	clusterID := uuid.MustParse("36db102f-9e1e-4ca4-8300-271d30b14161")
+
+	var manifest []byte
+	k8s := NewMockKubernetesClient(ctrl)
+	k8s.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, cluster *types.Cluster, data []byte) error {
+			manifest = data
+			return nil
+		},
+	)
+	k8s.EXPECT().GetAPIServerURL(gomock.Any(), gomock.Any()).Return("api-server-url", nil)
+	k8s.EXPECT().GetClusterCACert(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("ca-cert"), nil)
+
+	writer := filewritermock.NewMockFileWriter(ctrl)
+	writer.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return("some file", nil)
+
+	spec := &cluster.Spec{
+		Config: &cluster.Config{
+			Cluster: &v1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: v1alpha1.ClusterSpec{
+					KubernetesVersion: v1alpha1.Kube124,
+					AccessEntries: []v1alpha1.AccessEntry{
+						{
+							Group:       "developers",
+							ClusterRole: "edit",
+							IAMRoleARNs: []string{"arn:aws:iam::123456789012:role/dev"},
+						},
+					},
+				},
+			},
+		},
+		VersionsBundles: test.VersionsBundlesMap(),
+		AWSIamConfig: &v1alpha1.AWSIamConfig{
+			Spec: v1alpha1.AWSIamConfigSpec{
+				AWSRegion:   "test-region",
+				BackendMode: []string{"EKSConfigMap"},
+				MapRoles: []v1alpha1.MapRoles{
+					{
+						RoleARN:  "test-role-arn",
+						Username: "test",
+						Groups:   []string{"group1"},
+					},
+				},
+				Partition: "aws",
+			},
+		},
+	}
+
+	installer := awsiamauth.NewInstaller(certs, clusterID, k8s, writer)
+
+	err := installer.InstallAWSIAMAuth(context.Background(), &types.Cluster{}, &types.Cluster{}, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMapRoles := `  mapRoles: |
+    - rolearn: test-role-arn
+      username: test
+      groups:
+        - group1
+    - rolearn: arn:aws:iam::123456789012:role/dev
+      username: eksa-access:{{SessionName}}
+      groups:
+        - developers`
+	if !strings.Contains(string(manifest), wantMapRoles) {
+		t.Fatalf("InstallAWSIAMAuth() manifest doesn't contain the access entries roles, got:\n%s", manifest)
+	}
+}
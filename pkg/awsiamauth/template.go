@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"gopkg.in/yaml.v3"
 
+	"github.com/aws/eks-anywhere/pkg/accessentries"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -59,7 +60,9 @@ func (t *TemplateBuilder) GenerateManifest(clusterSpec *cluster.Spec, clusterID
 		data["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 	}
 
-	mapRoles, err := t.mapRolesToYaml(clusterSpec.AWSIamConfig.Spec.MapRoles)
+	// Access entries roles are appended so a role in the AWSIamConfig keeps its original mapping.
+	roles := append(append([]v1alpha1.MapRoles{}, clusterSpec.AWSIamConfig.Spec.MapRoles...), accessentries.MapRoles(clusterSpec.Cluster)...)
+	mapRoles, err := t.mapRolesToYaml(roles)
 	if err != nil {
 		return nil, fmt.Errorf("generating aws-iam-authenticator manifest: %v", err)
 	}
@@ -0,0 +1,87 @@
+package certmanager
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/manifests"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/cluster-issuers.yaml
+var clusterIssuersTemplate string
+
+// KubernetesClient applies manifests to a cluster.
+type KubernetesClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Installer installs and upgrades cert-manager and the ClusterIssuers defined in the cluster spec.
+type Installer struct {
+	reader manifests.FileReader
+	client KubernetesClient
+}
+
+// NewInstaller builds an Installer.
+func NewInstaller(reader manifests.FileReader, client KubernetesClient) *Installer {
+	return &Installer{
+		reader: reader,
+		client: client,
+	}
+}
+
+// Install applies the cert-manager manifest from the cluster bundle and the ClusterIssuers.
+// Management clusters already get cert-manager installed and upgraded with the Cluster API
+// components, so only the ClusterIssuers are applied to them. Since it only applies manifests,
+// it's also used to upgrade. It's a noop if the cluster doesn't have cert-manager configured.
+func (i *Installer) Install(ctx context.Context, c *types.Cluster, spec *cluster.Spec) error {
+	if spec.Cluster.Spec.CertManager == nil {
+		return nil
+	}
+
+	if spec.Cluster.IsManaged() {
+		manifest, err := bundles.ReadManifest(i.reader, spec.RootVersionsBundle().CertManager.Manifest)
+		if err != nil {
+			return err
+		}
+
+		if err = i.client.ApplyKubeSpecFromBytes(ctx, c, manifest.Content); err != nil {
+			return fmt.Errorf("applying cert-manager manifest: %v", err)
+		}
+	}
+
+	if len(spec.Cluster.Spec.CertManager.Issuers) == 0 {
+		return nil
+	}
+
+	issuers, err := ClusterIssuers(spec)
+	if err != nil {
+		return err
+	}
+
+	if err = i.client.ApplyKubeSpecFromBytes(ctx, c, issuers); err != nil {
+		return fmt.Errorf("applying cert-manager issuers: %v", err)
+	}
+
+	return nil
+}
+
+// ClusterIssuers generates the manifest with a ClusterIssuer for each issuer in the cluster spec.
+// ACME issuers solve the challenges with Route53 DNS01 and Vault issuers authenticate with a token.
+// The referenced Secrets are expected to exist in the cert-manager namespace.
+func ClusterIssuers(spec *cluster.Spec) ([]byte, error) {
+	values := map[string]interface{}{
+		"issuers": spec.Cluster.Spec.CertManager.Issuers,
+	}
+
+	manifest, err := templater.Execute(clusterIssuersTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("generating cert-manager issuers: %v", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,149 @@
+package certmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certmanager"
+	"github.com/aws/eks-anywhere/pkg/certmanager/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func clusterSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.CertManager = &v1alpha1.CertManagerConfiguration{
+			Issuers: []v1alpha1.CertManagerIssuer{
+				{
+					Name: "letsencrypt",
+					ACME: &v1alpha1.ACMEIssuer{
+						Server: "https://acme-v02.api.letsencrypt.org/directory",
+						Email:  "admin@example.com",
+						Route53: v1alpha1.Route53DNS01Solver{
+							Region:                "us-west-2",
+							HostedZoneID:          "Z0123456789",
+							CredentialsSecretName: "route53-credentials",
+						},
+					},
+				},
+				{
+					Name: "letsencrypt-ambient",
+					ACME: &v1alpha1.ACMEIssuer{
+						Server:  "https://acme-v02.api.letsencrypt.org/directory",
+						Email:   "admin@example.com",
+						Route53: v1alpha1.Route53DNS01Solver{Region: "us-west-2"},
+					},
+				},
+				{
+					Name: "vault",
+					Vault: &v1alpha1.VaultIssuer{
+						Server:          "https://vault.example.com:8200",
+						Path:            "pki/sign/example-dot-com",
+						CABundle:        "Y2EtYnVuZGxl",
+						TokenSecretName: "vault-token",
+					},
+				},
+			},
+		}
+		s.VersionsBundles[v1alpha1.Kube119].CertManager = releasev1.CertManagerBundle{
+			Manifest: releasev1.Manifest{URI: "testdata/cert-manager.yaml"},
+		}
+	})
+}
+
+type installerTest struct {
+	*WithT
+	ctx       context.Context
+	client    *mocks.MockKubernetesClient
+	installer *certmanager.Installer
+	cluster   *types.Cluster
+	spec      *cluster.Spec
+}
+
+func newInstallerTest(t *testing.T) *installerTest {
+	client := mocks.NewMockKubernetesClient(gomock.NewController(t))
+	return &installerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		client:    client,
+		installer: certmanager.NewInstaller(files.NewReader(), client),
+		cluster:   &types.Cluster{Name: "workload", KubeconfigFile: "workload.kubeconfig"},
+		spec:      clusterSpec(),
+	}
+}
+
+func TestClusterIssuers(t *testing.T) {
+	g := NewWithT(t)
+	issuers, err := certmanager.ClusterIssuers(clusterSpec())
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(issuers), "testdata/expected_cluster_issuers.yaml")
+}
+
+func TestInstallerInstallManagementCluster(t *testing.T) {
+	tt := newInstallerTest(t)
+	issuers, err := certmanager.ClusterIssuers(tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, issuers)
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallWorkloadCluster(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.SetManagedBy("mgmt")
+	issuers, err := certmanager.ClusterIssuers(tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	gomock.InOrder(
+		tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, test.ReadFileAsBytes(t, "testdata/cert-manager.yaml")),
+		tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, issuers),
+	)
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallNoIssuers(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.SetManagedBy("mgmt")
+	tt.spec.Cluster.Spec.CertManager.Issuers = nil
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, test.ReadFileAsBytes(t, "testdata/cert-manager.yaml"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallNoCertManager(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.CertManager = nil
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallReadManifestError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.SetManagedBy("mgmt")
+	tt.spec.VersionsBundles[v1alpha1.Kube119].CertManager.Manifest.URI = "testdata/missing.yaml"
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError(ContainSubstring("reading manifest testdata/missing.yaml")))
+}
+
+func TestInstallerInstallManifestApplyError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.SetManagedBy("mgmt")
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("apply error"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("applying cert-manager manifest: apply error"))
+}
+
+func TestInstallerInstallIssuersApplyError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("apply error"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("applying cert-manager issuers: apply error"))
+}
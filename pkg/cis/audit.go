@@ -0,0 +1,290 @@
+package cis
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// The audit script runs in a pod with the node root filesystem mounted in hostRoot and the host
+// PID namespace. It only collects the node facts the checks need, printing each one in a section
+// that starts with a sectionMarker line, and the checks are evaluated from them by the CLI. This
+// keeps the script simple enough for the sh and coreutils available in the diagnostic collector image.
+const (
+	hostRoot      = "/host"
+	sectionMarker = "==> "
+	endSection    = "end"
+
+	// commLength is the maximum length of a process name in /proc/<pid>/comm.
+	commLength = 15
+)
+
+// Processes the checks look for.
+const (
+	kubelet               = "kubelet"
+	kubeAPIServer         = "kube-apiserver"
+	kubeControllerManager = "kube-controller-manager"
+	kubeScheduler         = "kube-scheduler"
+	etcd                  = "etcd"
+)
+
+// Files the checks look for.
+const (
+	kubeletConfigFile         = "kubelet-config"
+	kubeletKubeconfigFile     = "kubelet-kubeconfig"
+	kubeletServiceFile        = "kubelet-service"
+	clientCAFile              = "client-ca"
+	apiServerManifest         = "kube-apiserver-manifest"
+	controllerManagerManifest = "kube-controller-manager-manifest"
+	schedulerManifest         = "kube-scheduler-manifest"
+	etcdManifest              = "etcd-manifest"
+	etcdDataDir               = "etcd-data-dir"
+	adminConf                 = "admin-conf"
+	schedulerConf             = "scheduler-conf"
+	controllerManagerConf     = "controller-manager-conf"
+)
+
+const scriptFunctions = `H=` + hostRoot + `
+# proc_args prints the arguments of the first process named $1, one per line.
+proc_args() {
+  for p in /proc/[0-9]*; do
+    if [ "$(cat "$p/comm" 2>/dev/null)" = "$1" ]; then
+      tr '\0' '\n' < "$p/cmdline"
+      return 0
+    fi
+  done
+  return 1
+}
+# proc_arg prints the value of the flag $2 of the process named $1.
+proc_arg() {
+  proc_args "$1" | awk -v f="--$2" 'index($0, f "=") == 1 { v = substr($0, length(f) + 2) } prev == f { v = $0 } { prev = $0 } END { print v }'
+}
+proc_section() {
+  if args=$(proc_args "$2"); then
+    echo "` + sectionMarker + `proc $1"
+    echo "$args"
+  fi
+}
+# file_section prints the mode and owner of the first of the files $2... that exists.
+file_section() {
+  key=$1
+  shift
+  for f in "$@"; do
+    if [ -e "$H$f" ]; then
+      echo "` + sectionMarker + `file $key $f"
+      stat -L -c '%a %u:%g' "$H$f"
+      return
+    fi
+  done
+  echo "` + sectionMarker + `file $key $1"
+  echo missing
+}
+`
+
+// auditScript returns the script that collects the facts of node.
+func auditScript(node Node) string {
+	l := node.layout()
+	s := &strings.Builder{}
+	s.WriteString(scriptFunctions)
+
+	fmt.Fprintf(s, "kubelet_config=$(proc_arg %s config)\nkubelet_config=${kubelet_config:-%s}\n", comm(kubelet), l.kubeletConfig)
+	fmt.Fprintf(s, "kubelet_kubeconfig=$(proc_arg %s kubeconfig)\nkubelet_kubeconfig=${kubelet_kubeconfig:-%s}\n", comm(kubelet), l.kubeletKubeconfig)
+	fmt.Fprintf(s, "static_pods=$(sed -n 's/^staticPodPath: *//p' \"$H$kubelet_config\" 2>/dev/null | tr -d \"\\\"'\")\nstatic_pods=${static_pods:-%s}\nstatic_pods=${static_pods%%/}\n", l.staticPods)
+
+	processes := []string{kubelet}
+	if node.ControlPlane {
+		processes = append(processes, kubeAPIServer, kubeControllerManager, kubeScheduler, etcd)
+	}
+	for _, p := range processes {
+		fmt.Fprintf(s, "proc_section %s %s\n", p, comm(p))
+	}
+
+	files := [][]string{
+		{kubeletConfigFile, `"$kubelet_config"`},
+		{kubeletKubeconfigFile, `"$kubelet_kubeconfig"`},
+		{clientCAFile, l.clientCA},
+	}
+	if len(l.kubeletService) > 0 {
+		files = append(files, append([]string{kubeletServiceFile}, l.kubeletService...))
+	}
+	if node.ControlPlane {
+		fmt.Fprintf(s, "etcd_data=$(proc_arg %s data-dir)\netcd_data=${etcd_data:-%s}\n", comm(etcd), l.etcdData)
+		files = append(files,
+			[]string{apiServerManifest, `"$static_pods/kube-apiserver.yaml"`},
+			[]string{controllerManagerManifest, `"$static_pods/kube-controller-manager.yaml"`},
+			[]string{schedulerManifest, `"$static_pods/kube-scheduler.yaml"`},
+			[]string{etcdManifest, `"$static_pods/etcd.yaml"`},
+			[]string{etcdDataDir, `"$etcd_data"`},
+			[]string{adminConf, l.adminConf},
+			[]string{schedulerConf, l.schedulerConf},
+			[]string{controllerManagerConf, l.controllerManagerConf},
+		)
+	}
+	for _, f := range files {
+		fmt.Fprintf(s, "file_section %s\n", strings.Join(f, " "))
+	}
+
+	if node.ControlPlane {
+		fmt.Fprintf(s, "echo '%spki %s'\n", sectionMarker, l.pki)
+		fmt.Fprintf(s, "find \"$H%s\" -type f | while read -r f; do printf '%%s %%s\\n' \"$(stat -L -c '%%a %%u:%%g' \"$f\")\" \"${f#$H}\"; done\n", l.pki)
+	}
+
+	s.WriteString("echo \"" + sectionMarker + "kubelet-config $kubelet_config\"\n")
+	s.WriteString("cat \"$H$kubelet_config\" 2>/dev/null\n")
+	s.WriteString("echo '" + sectionMarker + endSection + "'\n")
+
+	return s.String()
+}
+
+// comm returns the name of process as it appears in /proc/<pid>/comm.
+func comm(process string) string {
+	if len(process) > commLength {
+		return process[:commLength]
+	}
+	return process
+}
+
+// fileInfo is the mode and owner of a node file.
+type fileInfo struct {
+	path    string
+	mode    uint32
+	owner   string
+	missing bool
+}
+
+// facts are the node facts collected by the audit script.
+type facts struct {
+	files map[string]fileInfo
+	// processes are the flags of the processes running in the node.
+	processes     map[string]map[string]string
+	pki           []fileInfo
+	kubeletConfig map[string]interface{}
+}
+
+func (f *facts) running(process string) bool {
+	_, ok := f.processes[process]
+	return ok
+}
+
+// parseAuditOutput parses the facts printed by the audit script.
+func parseAuditOutput(output string) (*facts, error) {
+	f := &facts{
+		files:     map[string]fileInfo{},
+		processes: map[string]map[string]string{},
+	}
+
+	sections := map[string][]string{}
+	var order []string
+	var current string
+	complete := false
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, sectionMarker) {
+			current = strings.TrimPrefix(line, sectionMarker)
+			if current == endSection {
+				complete = true
+				break
+			}
+			order = append(order, current)
+			sections[current] = []string{}
+			continue
+		}
+		if current != "" {
+			sections[current] = append(sections[current], line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit output: %v", err)
+	}
+	if !complete {
+		return nil, fmt.Errorf("audit output is incomplete")
+	}
+
+	for _, header := range order {
+		lines := sections[header]
+		fields := strings.Fields(header)
+		switch fields[0] {
+		case "proc":
+			if len(lines) > 0 {
+				// The first argument is the process binary.
+				lines = lines[1:]
+			}
+			f.processes[fields[1]] = parseFlags(lines)
+		case "file":
+			if len(fields) < 3 || len(lines) == 0 {
+				return nil, fmt.Errorf("invalid audit output for %s", header)
+			}
+			info, err := parseFileInfo(fields[2], lines[0])
+			if err != nil {
+				return nil, err
+			}
+			f.files[fields[1]] = info
+		case "pki":
+			for _, line := range lines {
+				parts := strings.SplitN(line, " ", 3)
+				if len(parts) != 3 {
+					continue
+				}
+				info, err := parseFileInfo(parts[2], parts[0]+" "+parts[1])
+				if err != nil {
+					return nil, err
+				}
+				f.pki = append(f.pki, info)
+			}
+		case "kubelet-config":
+			config := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &config); err != nil {
+				return nil, fmt.Errorf("parsing kubelet config: %v", err)
+			}
+			f.kubeletConfig = config
+		}
+	}
+
+	return f, nil
+}
+
+func parseFileInfo(path, stat string) (fileInfo, error) {
+	if stat == "missing" {
+		return fileInfo{path: path, missing: true}, nil
+	}
+	parts := strings.Fields(stat)
+	if len(parts) != 2 {
+		return fileInfo{}, fmt.Errorf("invalid file info for %s: %s", path, stat)
+	}
+	mode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return fileInfo{}, fmt.Errorf("invalid file mode for %s: %s", path, parts[0])
+	}
+
+	return fileInfo{path: path, mode: uint32(mode), owner: parts[1]}, nil
+}
+
+// parseFlags parses command line flags in the --flag=value and --flag value forms.
+// Flags without a value are set to true.
+func parseFlags(args []string) map[string]string {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if n, value, ok := strings.Cut(name, "="); ok {
+			flags[n] = value
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			flags[name] = args[i+1]
+			i++
+			continue
+		}
+		flags[name] = "true"
+	}
+
+	return flags
+}
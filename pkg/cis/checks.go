@@ -0,0 +1,481 @@
+package cis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// Status is the result of a check.
+type Status string
+
+const (
+	// Pass means the node complies with the check.
+	Pass Status = "PASS"
+	// Fail means the node doesn't comply with a scored check.
+	Fail Status = "FAIL"
+	// Warn means the check needs a manual review, either because it's not scored or because
+	// the facts it needs couldn't be collected.
+	Warn Status = "WARN"
+	// Skip means the check doesn't apply to the node.
+	Skip Status = "SKIP"
+)
+
+// Check is a CIS Kubernetes Benchmark recommendation adapted to the layout of EKS Anywhere nodes.
+// IDs follow the numbering of the CIS Kubernetes Benchmark v1.8.0.
+type Check struct {
+	ID          string
+	Text        string
+	Remediation string
+	// Scored checks count towards the score. Failed checks that are not scored are reported as warnings.
+	Scored bool
+	// ControlPlane checks only run on control plane nodes.
+	ControlPlane bool
+	// process is the process the check needs. The check is skipped for etcd and reported as a warning
+	// for other processes if it doesn't run in the node.
+	process string
+	// skip returns the reason the check doesn't apply to node, if any.
+	skip func(node Node) string
+	test func(f *facts) (Status, string)
+}
+
+// Result is the result of running a check in a node.
+type Result struct {
+	ID          string `json:"id"`
+	Text        string `json:"text"`
+	Status      Status `json:"status"`
+	Scored      bool   `json:"scored"`
+	Reason      string `json:"reason,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+func (c Check) appliesTo(node Node) bool {
+	return !c.ControlPlane || node.ControlPlane
+}
+
+func (c Check) evaluate(node Node, f *facts) Result {
+	r := Result{ID: c.ID, Text: c.Text, Scored: c.Scored}
+
+	switch {
+	case c.skip != nil && c.skip(node) != "":
+		r.Status, r.Reason = Skip, c.skip(node)
+	case c.process == etcd && !f.running(etcd):
+		r.Status, r.Reason = Skip, "etcd doesn't run in the node, the cluster uses external etcd"
+	case c.process != "" && !f.running(c.process):
+		r.Status, r.Reason = Warn, fmt.Sprintf("%s is not running in the node", c.process)
+	default:
+		r.Status, r.Reason = c.test(f)
+	}
+
+	if r.Status == Fail && !c.Scored {
+		r.Status = Warn
+	}
+	if r.Status == Fail || r.Status == Warn {
+		r.Remediation = c.Remediation
+	}
+
+	return r
+}
+
+// Checks returns the checks run by the benchmark.
+func Checks() []Check {
+	checks := []Check{
+		filePermissions("1.1.1", apiServerManifest, kubeAPIServer, "API server pod specification file", 0o600),
+		fileOwnership("1.1.2", apiServerManifest, kubeAPIServer, "API server pod specification file"),
+		filePermissions("1.1.3", controllerManagerManifest, kubeControllerManager, "controller manager pod specification file", 0o600),
+		fileOwnership("1.1.4", controllerManagerManifest, kubeControllerManager, "controller manager pod specification file"),
+		filePermissions("1.1.5", schedulerManifest, kubeScheduler, "scheduler pod specification file", 0o600),
+		fileOwnership("1.1.6", schedulerManifest, kubeScheduler, "scheduler pod specification file"),
+		filePermissions("1.1.7", etcdManifest, etcd, "etcd pod specification file", 0o600),
+		fileOwnership("1.1.8", etcdManifest, etcd, "etcd pod specification file"),
+		filePermissions("1.1.11", etcdDataDir, etcd, "etcd data directory", 0o700),
+		filePermissions("1.1.13", adminConf, kubeAPIServer, "admin.conf file", 0o600),
+		fileOwnership("1.1.14", adminConf, kubeAPIServer, "admin.conf file"),
+		filePermissions("1.1.15", schedulerConf, kubeScheduler, "scheduler.conf file", 0o600),
+		fileOwnership("1.1.16", schedulerConf, kubeScheduler, "scheduler.conf file"),
+		filePermissions("1.1.17", controllerManagerConf, kubeControllerManager, "controller-manager.conf file", 0o600),
+		fileOwnership("1.1.18", controllerManagerConf, kubeControllerManager, "controller-manager.conf file"),
+		{
+			ID:           "1.1.19",
+			Text:         "Ensure that the Kubernetes PKI directory and file ownership is set to root:root",
+			Remediation:  "Run chown -R root:root on the Kubernetes PKI directory in the control plane nodes.",
+			Scored:       true,
+			ControlPlane: true,
+			process:      kubeAPIServer,
+			test: pkiFiles(func(i fileInfo) bool {
+				return i.owner != rootOwner
+			}, "not owned by root:root"),
+		},
+		{
+			ID:           "1.1.20",
+			Text:         "Ensure that the Kubernetes PKI certificate file permissions are set to 600 or more restrictive",
+			Remediation:  "Run chmod -R 600 on the certificate files in the Kubernetes PKI directory in the control plane nodes.",
+			ControlPlane: true,
+			process:      kubeAPIServer,
+			test: pkiFiles(func(i fileInfo) bool {
+				return strings.HasSuffix(i.path, ".crt") && i.mode&^0o600 != 0
+			}, "with permissions more permissive than 600"),
+		},
+		{
+			ID:           "1.1.21",
+			Text:         "Ensure that the Kubernetes PKI key file permissions are set to 600",
+			Remediation:  "Run chmod -R 600 on the key files in the Kubernetes PKI directory in the control plane nodes.",
+			ControlPlane: true,
+			Scored:       true,
+			process:      kubeAPIServer,
+			test: pkiFiles(func(i fileInfo) bool {
+				return strings.HasSuffix(i.path, ".key") && i.mode&^0o600 != 0
+			}, "with permissions more permissive than 600"),
+		},
+
+		apiServer("1.2.1", "Ensure that the --anonymous-auth argument is set to false", false, equals("anonymous-auth", "false")),
+		apiServer("1.2.2", "Ensure that the --token-auth-file parameter is not set", true, notSet("token-auth-file")),
+		apiServer("1.2.4", "Ensure that the --kubelet-client-certificate and --kubelet-client-key arguments are set as appropriate", true,
+			isSet("kubelet-client-certificate"), isSet("kubelet-client-key")),
+		apiServer("1.2.5", "Ensure that the --kubelet-certificate-authority argument is set as appropriate", true, isSet("kubelet-certificate-authority")),
+		apiServer("1.2.6", "Ensure that the --authorization-mode argument is not set to AlwaysAllow", true, notContains("authorization-mode", "AlwaysAllow")),
+		apiServer("1.2.7", "Ensure that the --authorization-mode argument includes Node", true, contains("authorization-mode", "Node")),
+		apiServer("1.2.8", "Ensure that the --authorization-mode argument includes RBAC", true, contains("authorization-mode", "RBAC")),
+		apiServer("1.2.10", "Ensure that the admission control plugin AlwaysAdmit is not set", true, notContains("enable-admission-plugins", "AlwaysAdmit")),
+		apiServer("1.2.13", "Ensure that the admission control plugin ServiceAccount is set", true, notContains("disable-admission-plugins", "ServiceAccount")),
+		apiServer("1.2.14", "Ensure that the admission control plugin NamespaceLifecycle is set", true, notContains("disable-admission-plugins", "NamespaceLifecycle")),
+		apiServer("1.2.15", "Ensure that the admission control plugin NodeRestriction is set", true, contains("enable-admission-plugins", "NodeRestriction")),
+		apiServer("1.2.16", "Ensure that the --profiling argument is set to false", true, equals("profiling", "false")),
+		apiServer("1.2.17", "Ensure that the --audit-log-path argument is set", true, isSet("audit-log-path")),
+		apiServer("1.2.18", "Ensure that the --audit-log-maxage argument is set to 30 or as appropriate", true, atLeast("audit-log-maxage", 30)),
+		apiServer("1.2.19", "Ensure that the --audit-log-maxbackup argument is set to 10 or as appropriate", true, atLeast("audit-log-maxbackup", 10)),
+		apiServer("1.2.20", "Ensure that the --audit-log-maxsize argument is set to 100 or as appropriate", true, atLeast("audit-log-maxsize", 100)),
+		apiServer("1.2.22", "Ensure that the --service-account-lookup argument is set to true", true, notEquals("service-account-lookup", "false")),
+		apiServer("1.2.23", "Ensure that the --service-account-key-file argument is set as appropriate", true, isSet("service-account-key-file")),
+		apiServer("1.2.24", "Ensure that the --etcd-certfile and --etcd-keyfile arguments are set as appropriate", true,
+			isSet("etcd-certfile"), isSet("etcd-keyfile")),
+		apiServer("1.2.25", "Ensure that the --tls-cert-file and --tls-private-key-file arguments are set as appropriate", true,
+			isSet("tls-cert-file"), isSet("tls-private-key-file")),
+		apiServer("1.2.26", "Ensure that the --client-ca-file argument is set as appropriate", true, isSet("client-ca-file")),
+		apiServer("1.2.27", "Ensure that the --etcd-cafile argument is set as appropriate", true, isSet("etcd-cafile")),
+		apiServer("1.2.29", "Ensure that the API Server only makes use of Strong Cryptographic Ciphers", false, isSet("tls-cipher-suites")),
+
+		controllerManager("1.3.1", "Ensure that the --terminated-pod-gc-threshold argument is set as appropriate", false, isSet("terminated-pod-gc-threshold")),
+		controllerManager("1.3.2", "Ensure that the --profiling argument is set to false", true, equals("profiling", "false")),
+		controllerManager("1.3.3", "Ensure that the --use-service-account-credentials argument is set to true", true, equals("use-service-account-credentials", "true")),
+		controllerManager("1.3.4", "Ensure that the --service-account-private-key-file argument is set as appropriate", true, isSet("service-account-private-key-file")),
+		controllerManager("1.3.5", "Ensure that the --root-ca-file argument is set as appropriate", true, isSet("root-ca-file")),
+		controllerManager("1.3.6", "Ensure that the RotateKubeletServerCertificate argument is set to true", true,
+			notContains("feature-gates", "RotateKubeletServerCertificate=false")),
+		controllerManager("1.3.7", "Ensure that the --bind-address argument is set to 127.0.0.1", true, equals("bind-address", "127.0.0.1")),
+
+		scheduler("1.4.1", "Ensure that the --profiling argument is set to false", true, equals("profiling", "false")),
+		scheduler("1.4.2", "Ensure that the --bind-address argument is set to 127.0.0.1", true, equals("bind-address", "127.0.0.1")),
+
+		etcdFlags("2.1", "Ensure that the --cert-file and --key-file arguments are set as appropriate", isSet("cert-file"), isSet("key-file")),
+		etcdFlags("2.2", "Ensure that the --client-cert-auth argument is set to true", equals("client-cert-auth", "true")),
+		etcdFlags("2.3", "Ensure that the --auto-tls argument is not set to true", notEquals("auto-tls", "true")),
+		etcdFlags("2.4", "Ensure that the --peer-cert-file and --peer-key-file arguments are set as appropriate",
+			isSet("peer-cert-file"), isSet("peer-key-file")),
+		etcdFlags("2.5", "Ensure that the --peer-client-cert-auth argument is set to true", equals("peer-client-cert-auth", "true")),
+		etcdFlags("2.6", "Ensure that the --peer-auto-tls argument is not set to true", notEquals("peer-auto-tls", "true")),
+	}
+
+	kubeletService := []Check{
+		filePermissions("4.1.1", kubeletServiceFile, kubelet, "kubelet service file", 0o600),
+		fileOwnership("4.1.2", kubeletServiceFile, kubelet, "kubelet service file"),
+	}
+	for i := range kubeletService {
+		kubeletService[i].skip = func(node Node) string {
+			if node.OSFamily == v1alpha1.Bottlerocket {
+				return "the kubelet service is part of the Bottlerocket read-only root filesystem"
+			}
+			return ""
+		}
+	}
+	checks = append(checks, kubeletService...)
+
+	protectKernelDefaults := kubeletCheck("4.2.6", "Ensure that the --protect-kernel-defaults argument is set to true", true,
+		kubeletSetting{flag: "protect-kernel-defaults", config: "protectKernelDefaults", defaultValue: "false"}, equalsValue("true"))
+	protectKernelDefaults.skip = func(node Node) string {
+		if node.Provider == constants.DockerProviderName {
+			return "docker nodes share the kernel of the host"
+		}
+		return ""
+	}
+
+	return append(checks,
+		filePermissions("4.1.5", kubeletKubeconfigFile, kubelet, "kubelet kubeconfig file", 0o600),
+		fileOwnership("4.1.6", kubeletKubeconfigFile, kubelet, "kubelet kubeconfig file"),
+		filePermissions("4.1.7", clientCAFile, kubelet, "certificate authorities file", 0o600),
+		fileOwnership("4.1.8", clientCAFile, kubelet, "certificate authorities file"),
+		filePermissions("4.1.9", kubeletConfigFile, kubelet, "kubelet configuration file", 0o600),
+		fileOwnership("4.1.10", kubeletConfigFile, kubelet, "kubelet configuration file"),
+
+		kubeletCheck("4.2.1", "Ensure that the --anonymous-auth argument is set to false", true,
+			kubeletSetting{flag: "anonymous-auth", config: "authentication.anonymous.enabled", defaultValue: "true"}, equalsValue("false")),
+		kubeletCheck("4.2.2", "Ensure that the --authorization-mode argument is not set to AlwaysAllow", true,
+			kubeletSetting{flag: "authorization-mode", config: "authorization.mode", defaultValue: "AlwaysAllow"}, notEqualsValue("AlwaysAllow")),
+		kubeletCheck("4.2.3", "Ensure that the --client-ca-file argument is set as appropriate", true,
+			kubeletSetting{flag: "client-ca-file", config: "authentication.x509.clientCAFile"}, isSetValue()),
+		kubeletCheck("4.2.4", "Verify that the --read-only-port argument is set to 0", false,
+			kubeletSetting{flag: "read-only-port", config: "readOnlyPort", defaultValue: "0"}, equalsValue("0")),
+		kubeletCheck("4.2.5", "Ensure that the --streaming-connection-idle-timeout argument is not set to 0", false,
+			kubeletSetting{flag: "streaming-connection-idle-timeout", config: "streamingConnectionIdleTimeout", defaultValue: "4h0m0s"}, notEqualsValue("0", "0s")),
+		protectKernelDefaults,
+		kubeletCheck("4.2.7", "Ensure that the --make-iptables-util-chains argument is set to true", true,
+			kubeletSetting{flag: "make-iptables-util-chains", config: "makeIPTablesUtilChains", defaultValue: "true"}, equalsValue("true")),
+		kubeletCheck("4.2.10", "Ensure that the --tls-cert-file and --tls-private-key-file arguments are set as appropriate", false,
+			kubeletSetting{flag: "tls-cert-file", config: "tlsCertFile"}, isSetValue()),
+		kubeletCheck("4.2.11", "Ensure that the --rotate-certificates argument is not set to false", true,
+			kubeletSetting{flag: "rotate-certificates", config: "rotateCertificates", defaultValue: "true"}, notEqualsValue("false")),
+		kubeletCheck("4.2.12", "Verify that the RotateKubeletServerCertificate argument is set to true", true,
+			kubeletSetting{flag: "rotate-server-certificates", config: "serverTLSBootstrap", defaultValue: "false"}, equalsValue("true")),
+		kubeletCheck("4.2.13", "Ensure that the Kubelet only makes use of Strong Cryptographic Ciphers", false,
+			kubeletSetting{flag: "tls-cipher-suites", config: "tlsCipherSuites"}, isSetValue()),
+	)
+}
+
+// rootOwner is the owner of files owned by root:root as printed by the audit script.
+const rootOwner = "0:0"
+
+func filePermissions(id, file, process, description string, mode uint32) Check {
+	return Check{
+		ID:           id,
+		Text:         fmt.Sprintf("Ensure that the %s permissions are set to %o or more restrictive", description, mode),
+		Remediation:  fmt.Sprintf("Run chmod %o on the %s in the node.", mode, description),
+		Scored:       true,
+		ControlPlane: process != kubelet,
+		process:      process,
+		test: fileTest(file, func(i fileInfo) (bool, string) {
+			return i.mode&^mode == 0, fmt.Sprintf("%s has permissions %o", i.path, i.mode)
+		}),
+	}
+}
+
+func fileOwnership(id, file, process, description string) Check {
+	return Check{
+		ID:           id,
+		Text:         fmt.Sprintf("Ensure that the %s ownership is set to root:root", description),
+		Remediation:  fmt.Sprintf("Run chown root:root on the %s in the node.", description),
+		Scored:       true,
+		ControlPlane: process != kubelet,
+		process:      process,
+		test: fileTest(file, func(i fileInfo) (bool, string) {
+			return i.owner == rootOwner, fmt.Sprintf("%s is owned by %s", i.path, i.owner)
+		}),
+	}
+}
+
+func fileTest(file string, test func(fileInfo) (bool, string)) func(*facts) (Status, string) {
+	return func(f *facts) (Status, string) {
+		info, ok := f.files[file]
+		if !ok || info.missing {
+			return Warn, fmt.Sprintf("%s file not found", file)
+		}
+		if ok, reason := test(info); !ok {
+			return Fail, reason
+		}
+		return Pass, ""
+	}
+}
+
+func pkiFiles(matches func(fileInfo) bool, description string) func(*facts) (Status, string) {
+	return func(f *facts) (Status, string) {
+		var paths []string
+		for _, i := range f.pki {
+			if matches(i) {
+				paths = append(paths, i.path)
+			}
+		}
+		if len(paths) > 0 {
+			return Fail, fmt.Sprintf("files %s: %s", description, strings.Join(paths, ", "))
+		}
+		return Pass, ""
+	}
+}
+
+// flagTest checks the value of a command line flag of a process.
+type flagTest func(flags map[string]string) (bool, string)
+
+func processCheck(id, text, process string, scored bool, tests ...flagTest) Check {
+	return Check{
+		ID:           id,
+		Text:         text,
+		Remediation:  fmt.Sprintf("Edit the %s static pod manifest in the control plane nodes, or set the argument in the cluster spec if it's supported, to comply with: %s.", process, text),
+		Scored:       scored,
+		ControlPlane: true,
+		process:      process,
+		test: func(f *facts) (Status, string) {
+			for _, test := range tests {
+				if ok, reason := test(f.processes[process]); !ok {
+					return Fail, reason
+				}
+			}
+			return Pass, ""
+		},
+	}
+}
+
+func apiServer(id, text string, scored bool, tests ...flagTest) Check {
+	return processCheck(id, text, kubeAPIServer, scored, tests...)
+}
+
+func controllerManager(id, text string, scored bool, tests ...flagTest) Check {
+	return processCheck(id, text, kubeControllerManager, scored, tests...)
+}
+
+func scheduler(id, text string, scored bool, tests ...flagTest) Check {
+	return processCheck(id, text, kubeScheduler, scored, tests...)
+}
+
+func etcdFlags(id, text string, tests ...flagTest) Check {
+	return processCheck(id, text, etcd, true, tests...)
+}
+
+func equals(flag, want string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		value, ok := flags[flag]
+		if !ok {
+			return false, fmt.Sprintf("--%s is not set", flag)
+		}
+		return value == want, fmt.Sprintf("--%s is set to %s", flag, value)
+	}
+}
+
+func notEquals(flag, unwanted string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		value := flags[flag]
+		return value != unwanted, fmt.Sprintf("--%s is set to %s", flag, value)
+	}
+}
+
+func isSet(flag string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		_, ok := flags[flag]
+		return ok, fmt.Sprintf("--%s is not set", flag)
+	}
+}
+
+func notSet(flag string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		_, ok := flags[flag]
+		return !ok, fmt.Sprintf("--%s is set", flag)
+	}
+}
+
+func contains(flag, item string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		return listContains(flags[flag], item), fmt.Sprintf("--%s doesn't include %s", flag, item)
+	}
+}
+
+func notContains(flag, item string) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		return !listContains(flags[flag], item), fmt.Sprintf("--%s includes %s", flag, item)
+	}
+}
+
+func atLeast(flag string, min int) flagTest {
+	return func(flags map[string]string) (bool, string) {
+		value, ok := flags[flag]
+		if !ok {
+			return false, fmt.Sprintf("--%s is not set", flag)
+		}
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= min, fmt.Sprintf("--%s is set to %s", flag, value)
+	}
+}
+
+func listContains(list, item string) bool {
+	for _, i := range strings.Split(list, ",") {
+		if strings.TrimSpace(i) == item {
+			return true
+		}
+	}
+	return false
+}
+
+// kubeletSetting is a kubelet setting that can be set with a command line flag or in the kubelet
+// config file. The flag takes precedence over the config file.
+type kubeletSetting struct {
+	flag string
+	// config is the path of the setting in the config file, with the fields separated by dots.
+	config string
+	// defaultValue is the value used by the kubelet when the setting is not set.
+	defaultValue string
+}
+
+// value returns the value of the setting in the node and whether it's set.
+func (s kubeletSetting) value(f *facts) (string, bool) {
+	if value, ok := f.processes[kubelet][s.flag]; ok {
+		return value, true
+	}
+
+	var current interface{} = f.kubeletConfig
+	for _, field := range strings.Split(s.config, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return s.defaultValue, false
+		}
+		if current, ok = m[field]; !ok {
+			return s.defaultValue, false
+		}
+	}
+	if current == nil {
+		return s.defaultValue, false
+	}
+	if list, ok := current.([]interface{}); ok {
+		items := make([]string, 0, len(list))
+		for _, i := range list {
+			items = append(items, fmt.Sprint(i))
+		}
+		return strings.Join(items, ","), true
+	}
+
+	return fmt.Sprint(current), true
+}
+
+// valueTest checks the value of a kubelet setting.
+type valueTest func(value string, set bool) bool
+
+func kubeletCheck(id, text string, scored bool, setting kubeletSetting, test valueTest) Check {
+	return Check{
+		ID:          id,
+		Text:        text,
+		Remediation: fmt.Sprintf("Set %s in the kubelet config file or --%s in the kubelet arguments of the nodes.", setting.config, setting.flag),
+		Scored:      scored,
+		process:     kubelet,
+		test: func(f *facts) (Status, string) {
+			value, set := setting.value(f)
+			if test(value, set) {
+				return Pass, ""
+			}
+			if !set {
+				return Fail, fmt.Sprintf("%s is not set", setting.config)
+			}
+			return Fail, fmt.Sprintf("%s is set to %s", setting.config, value)
+		},
+	}
+}
+
+func equalsValue(want string) valueTest {
+	return func(value string, _ bool) bool {
+		return value == want
+	}
+}
+
+func notEqualsValue(unwanted ...string) valueTest {
+	return func(value string, _ bool) bool {
+		for _, u := range unwanted {
+			if value == u {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isSetValue() valueTest {
+	return func(_ string, set bool) bool {
+		return set
+	}
+}
@@ -0,0 +1,128 @@
+package cis
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const controlPlaneNodeLabel = "node-role.kubernetes.io/control-plane"
+
+// providerIDPrefixes maps the provider ID scheme set by each provider in the nodes to the provider name.
+var providerIDPrefixes = map[string]string{
+	"vsphere://":    constants.VSphereProviderName,
+	"docker://":     constants.DockerProviderName,
+	"aws-snow://":   constants.SnowProviderName,
+	"tinkerbell://": constants.TinkerbellProviderName,
+	"cloudstack://": constants.CloudStackProviderName,
+	"nutanix://":    constants.NutanixProviderName,
+}
+
+// Node is a cluster node the benchmark runs on.
+type Node struct {
+	Name         string
+	ControlPlane bool
+	OSFamily     v1alpha1.OSFamily
+	// Provider is the name of the provider that created the node. It's empty if it can't be
+	// detected from the node provider ID.
+	Provider string
+}
+
+// NewNode builds a Node detecting its role, OS and provider from the node object.
+func NewNode(node corev1.Node) Node {
+	_, controlPlane := node.Labels[controlPlaneNodeLabel]
+	n := Node{
+		Name:         node.Name,
+		ControlPlane: controlPlane,
+		OSFamily:     osFamily(node.Status.NodeInfo.OSImage),
+	}
+	for prefix, provider := range providerIDPrefixes {
+		if strings.HasPrefix(node.Spec.ProviderID, prefix) {
+			n.Provider = provider
+		}
+	}
+
+	return n
+}
+
+// Role returns the role of the node in the cluster.
+func (n Node) Role() string {
+	if n.ControlPlane {
+		return "control-plane"
+	}
+	return "worker"
+}
+
+func osFamily(osImage string) v1alpha1.OSFamily {
+	image := strings.ToLower(osImage)
+	switch {
+	case strings.Contains(image, "bottlerocket"):
+		return v1alpha1.Bottlerocket
+	case strings.Contains(image, "red hat"):
+		return v1alpha1.RedHat
+	default:
+		// Ubuntu node images and the kind node images used by the docker provider.
+		return v1alpha1.Ubuntu
+	}
+}
+
+// layout is the location of the Kubernetes component files in a node. The kubelet config,
+// kubeconfig and static pod paths are read from the running kubelet when possible, the
+// layout paths are only used when the kubelet doesn't set them.
+type layout struct {
+	kubeletConfig     string
+	kubeletKubeconfig string
+	// kubeletService are the candidate paths of the kubelet systemd drop-in, the first one
+	// that exists is checked. It's empty if the kubelet unit can't be changed in the node.
+	kubeletService        []string
+	clientCA              string
+	staticPods            string
+	pki                   string
+	etcdData              string
+	adminConf             string
+	schedulerConf         string
+	controllerManagerConf string
+}
+
+// kubeadmLayout is the layout of the Ubuntu and Red Hat images built by image-builder and of the kind node
+// images, where the kubelet is configured by kubeadm.
+var kubeadmLayout = layout{
+	kubeletConfig:     "/var/lib/kubelet/config.yaml",
+	kubeletKubeconfig: "/etc/kubernetes/kubelet.conf",
+	kubeletService: []string{
+		"/etc/systemd/system/kubelet.service.d/10-kubeadm.conf",
+		"/usr/lib/systemd/system/kubelet.service.d/10-kubeadm.conf",
+		"/lib/systemd/system/kubelet.service.d/10-kubeadm.conf",
+	},
+	clientCA:              "/etc/kubernetes/pki/ca.crt",
+	staticPods:            "/etc/kubernetes/manifests",
+	pki:                   "/etc/kubernetes/pki",
+	etcdData:              "/var/lib/etcd",
+	adminConf:             "/etc/kubernetes/admin.conf",
+	schedulerConf:         "/etc/kubernetes/scheduler.conf",
+	controllerManagerConf: "/etc/kubernetes/controller-manager.conf",
+}
+
+// bottlerocketLayout is the layout of Bottlerocket nodes. The kubelet config is rendered by Bottlerocket
+// from its settings API and the kubelet unit lives in the read-only root filesystem.
+var bottlerocketLayout = layout{
+	kubeletConfig:         "/etc/kubernetes/kubelet/config",
+	kubeletKubeconfig:     "/etc/kubernetes/kubelet/kubeconfig",
+	clientCA:              "/etc/kubernetes/pki/ca.crt",
+	staticPods:            "/etc/kubernetes/static-pods",
+	pki:                   "/etc/kubernetes/pki",
+	etcdData:              "/var/lib/etcd",
+	adminConf:             "/etc/kubernetes/admin.conf",
+	schedulerConf:         "/etc/kubernetes/scheduler.conf",
+	controllerManagerConf: "/etc/kubernetes/controller-manager.conf",
+}
+
+func (n Node) layout() layout {
+	if n.OSFamily == v1alpha1.Bottlerocket {
+		return bottlerocketLayout
+	}
+	return kubeadmLayout
+}
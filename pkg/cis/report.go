@@ -0,0 +1,125 @@
+package cis
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Summary counts the results of the checks. Score is the percentage of scored checks that passed.
+type Summary struct {
+	Pass  int     `json:"pass"`
+	Fail  int     `json:"fail"`
+	Warn  int     `json:"warn"`
+	Skip  int     `json:"skip"`
+	Score float64 `json:"score"`
+
+	scoredPass int
+	scoredFail int
+}
+
+func (s *Summary) add(r Result) {
+	switch r.Status {
+	case Pass:
+		s.Pass++
+		if r.Scored {
+			s.scoredPass++
+		}
+	case Fail:
+		s.Fail++
+		if r.Scored {
+			s.scoredFail++
+		}
+	case Warn:
+		s.Warn++
+	case Skip:
+		s.Skip++
+	}
+
+	if total := s.scoredPass + s.scoredFail; total > 0 {
+		s.Score = float64(s.scoredPass) * 100 / float64(total)
+	}
+}
+
+// NodeReport is the result of the benchmark in a node.
+type NodeReport struct {
+	Name     string   `json:"name"`
+	Role     string   `json:"role"`
+	OSFamily string   `json:"osFamily"`
+	Provider string   `json:"provider,omitempty"`
+	Results  []Result `json:"results"`
+	Summary  Summary  `json:"summary"`
+}
+
+// Report is the result of the benchmark in a cluster.
+type Report struct {
+	Cluster string       `json:"cluster"`
+	Nodes   []NodeReport `json:"nodes"`
+	Summary Summary      `json:"summary"`
+}
+
+// evaluateNode runs the checks that apply to node with the facts collected from it.
+func evaluateNode(node Node, f *facts) NodeReport {
+	report := NodeReport{
+		Name:     node.Name,
+		Role:     node.Role(),
+		OSFamily: string(node.OSFamily),
+		Provider: node.Provider,
+	}
+	for _, check := range Checks() {
+		if !check.appliesTo(node) {
+			continue
+		}
+		result := check.evaluate(node, f)
+		report.Results = append(report.Results, result)
+		report.Summary.add(result)
+	}
+
+	return report
+}
+
+func (r *Report) addNode(node NodeReport) {
+	r.Nodes = append(r.Nodes, node)
+	for _, result := range node.Results {
+		r.Summary.add(result)
+	}
+}
+
+// WriteText writes the report in a human readable format: a table with the results of each node,
+// followed by the reason and remediation of the failed checks and warnings.
+func (r *Report) WriteText(out io.Writer) error {
+	for _, node := range r.Nodes {
+		fmt.Fprintf(out, "Node %s (%s, %s", node.Name, node.Role, node.OSFamily)
+		if node.Provider != "" {
+			fmt.Fprintf(out, ", %s", node.Provider)
+		}
+		fmt.Fprintln(out, ")")
+
+		w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS\tCHECK")
+		for _, result := range node.Results {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", result.ID, result.Status, result.Text)
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed flushing table writer: %v", err)
+		}
+
+		for _, result := range node.Results {
+			if result.Status != Fail && result.Status != Warn {
+				continue
+			}
+			fmt.Fprintf(out, "\n[%s] %s %s\n", result.Status, result.ID, result.Reason)
+			if result.Remediation != "" {
+				fmt.Fprintf(out, "  Remediation: %s\n", result.Remediation)
+			}
+		}
+		fmt.Fprintf(out, "\n%s\n\n", summaryLine(node.Summary))
+	}
+
+	fmt.Fprintf(out, "Cluster %s %s\n", r.Cluster, summaryLine(r.Summary))
+	return nil
+}
+
+func summaryLine(s Summary) string {
+	return fmt.Sprintf("score: %.1f%% (%d passed, %d failed, %d warnings, %d skipped)", s.Score, s.Pass, s.Fail, s.Warn, s.Skip)
+}
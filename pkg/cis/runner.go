@@ -0,0 +1,173 @@
+package cis
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// Namespace is the namespace where the benchmark pods run. It's deleted once the benchmark completes.
+	Namespace = "eksa-cis-benchmark"
+
+	podNamePrefix    = "cis-benchmark-"
+	containerName    = "cis-benchmark"
+	podWaitTimeout   = "5m"
+	hostRootVolume   = "host"
+	podSecurityLevel = "privileged"
+)
+
+// KubernetesClient runs the benchmark pods in the cluster.
+type KubernetesClient interface {
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForPodCompleted(ctx context.Context, cluster *types.Cluster, name string, timeout string, namespace string) error
+	GetPodLogs(ctx context.Context, namespace, podName, containerName, kubeconfig string) (string, error)
+	DeleteNamespace(ctx context.Context, kubeconfig string, namespace string) error
+}
+
+// Runner runs the CIS benchmark checks in the nodes of a cluster.
+type Runner struct {
+	client KubernetesClient
+	// image is the image of the pods that collect the node facts. It needs sh, awk, stat and find.
+	image string
+}
+
+// NewRunner builds a Runner that collects the node facts with pods running image.
+func NewRunner(client KubernetesClient, image string) *Runner {
+	return &Runner{
+		client: client,
+		image:  image,
+	}
+}
+
+// Run runs the benchmark in all the nodes of cluster. It runs a privileged pod in each node that
+// collects the facts the checks need from the host and evaluates the checks with them.
+func (r *Runner) Run(ctx context.Context, cluster *types.Cluster) (*Report, error) {
+	nodeObjs, err := r.client.GetNodes(ctx, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster nodes: %v", err)
+	}
+	if len(nodeObjs) == 0 {
+		return nil, fmt.Errorf("cluster %s doesn't have any nodes", cluster.Name)
+	}
+
+	nodes := make([]Node, 0, len(nodeObjs))
+	objs := []runtime.Object{namespace()}
+	for _, n := range nodeObjs {
+		node := NewNode(n)
+		nodes = append(nodes, node)
+		objs = append(objs, r.auditPod(node))
+	}
+
+	manifest, err := templater.ObjectsToYaml(objs...)
+	if err != nil {
+		return nil, fmt.Errorf("generating benchmark pods: %v", err)
+	}
+
+	logger.V(4).Info("Running CIS benchmark pods", "nodes", len(nodes))
+	if err = r.client.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return nil, fmt.Errorf("creating benchmark pods: %v", err)
+	}
+	defer func() {
+		if err := r.client.DeleteNamespace(ctx, cluster.KubeconfigFile, Namespace); err != nil {
+			logger.Info("Warning: failed deleting CIS benchmark namespace", "namespace", Namespace, "error", err)
+		}
+	}()
+
+	report := &Report{Cluster: cluster.Name}
+	for _, node := range nodes {
+		facts, err := r.collectFacts(ctx, cluster, node)
+		if err != nil {
+			return nil, fmt.Errorf("collecting CIS benchmark facts from node %s: %v", node.Name, err)
+		}
+		report.addNode(evaluateNode(node, facts))
+	}
+
+	return report, nil
+}
+
+func (r *Runner) collectFacts(ctx context.Context, cluster *types.Cluster, node Node) (*facts, error) {
+	name := podName(node)
+	if err := r.client.WaitForPodCompleted(ctx, cluster, name, podWaitTimeout, Namespace); err != nil {
+		return nil, err
+	}
+
+	logs, err := r.client.GetPodLogs(ctx, Namespace, name, containerName, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAuditOutput(logs)
+}
+
+func podName(node Node) string {
+	return podNamePrefix + node.Name
+}
+
+// namespace returns the benchmark namespace. The pods need access to the host, so the namespace
+// allows privileged pods even if the cluster enforces a stricter pod security standard.
+func namespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: Namespace,
+			Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": podSecurityLevel,
+			},
+		},
+	}
+}
+
+func (r *Runner) auditPod(node Node) *corev1.Pod {
+	privileged := true
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName(node),
+			Namespace: Namespace,
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      node.Name,
+			HostPID:       true,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    containerName,
+					Image:   r.image,
+					Command: []string{"/bin/sh", "-c", auditScript(node)},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: hostRootVolume, MountPath: hostRoot, ReadOnly: true},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: hostRootVolume,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+					},
+				},
+			},
+		},
+	}
+}
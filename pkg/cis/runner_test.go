@@ -0,0 +1,329 @@
+package cis_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/cis"
+	"github.com/aws/eks-anywhere/pkg/cis/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	controlPlaneOutput = `==> proc kubelet
+/usr/bin/kubelet
+--config=/var/lib/kubelet/config.yaml
+--kubeconfig
+/etc/kubernetes/kubelet.conf
+==> proc kube-apiserver
+kube-apiserver
+--anonymous-auth=false
+--authorization-mode=Node,RBAC
+--enable-admission-plugins=NodeRestriction
+--profiling=false
+--audit-log-path=/var/log/kubernetes/api-audit.log
+--audit-log-maxage=30
+--audit-log-maxbackup=10
+--audit-log-maxsize=512
+--client-ca-file=/etc/kubernetes/pki/ca.crt
+--etcd-cafile=/etc/kubernetes/pki/etcd/ca.crt
+--etcd-certfile=/etc/kubernetes/pki/apiserver-etcd-client.crt
+--etcd-keyfile=/etc/kubernetes/pki/apiserver-etcd-client.key
+--kubelet-client-certificate=/etc/kubernetes/pki/apiserver-kubelet-client.crt
+--kubelet-client-key=/etc/kubernetes/pki/apiserver-kubelet-client.key
+--service-account-key-file=/etc/kubernetes/pki/sa.pub
+--tls-cert-file=/etc/kubernetes/pki/apiserver.crt
+--tls-private-key-file=/etc/kubernetes/pki/apiserver.key
+--tls-cipher-suites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+==> proc kube-controller-manager
+kube-controller-manager
+--profiling=false
+--use-service-account-credentials=true
+--service-account-private-key-file=/etc/kubernetes/pki/sa.key
+--root-ca-file=/etc/kubernetes/pki/ca.crt
+--bind-address=127.0.0.1
+==> proc kube-scheduler
+kube-scheduler
+--profiling=false
+--bind-address=127.0.0.1
+==> file kubelet-config /var/lib/kubelet/config.yaml
+600 0:0
+==> file kubelet-kubeconfig /etc/kubernetes/kubelet.conf
+600 0:0
+==> file client-ca /etc/kubernetes/pki/ca.crt
+644 0:0
+==> file kubelet-service /etc/systemd/system/kubelet.service.d/10-kubeadm.conf
+644 0:0
+==> file kube-apiserver-manifest /etc/kubernetes/manifests/kube-apiserver.yaml
+600 0:0
+==> file kube-controller-manager-manifest /etc/kubernetes/manifests/kube-controller-manager.yaml
+600 0:0
+==> file kube-scheduler-manifest /etc/kubernetes/manifests/kube-scheduler.yaml
+600 0:0
+==> file etcd-manifest /etc/kubernetes/manifests/etcd.yaml
+missing
+==> file etcd-data-dir /var/lib/etcd
+missing
+==> file admin-conf /etc/kubernetes/admin.conf
+600 0:0
+==> file scheduler-conf /etc/kubernetes/scheduler.conf
+600 0:0
+==> file controller-manager-conf /etc/kubernetes/controller-manager.conf
+600 1000:0
+==> pki /etc/kubernetes/pki
+644 0:0 /etc/kubernetes/pki/ca.crt
+600 0:0 /etc/kubernetes/pki/ca.key
+==> kubelet-config /var/lib/kubelet/config.yaml
+authentication:
+  anonymous:
+    enabled: false
+  x509:
+    clientCAFile: /etc/kubernetes/pki/ca.crt
+authorization:
+  mode: Webhook
+protectKernelDefaults: true
+serverTLSBootstrap: true
+tlsCipherSuites:
+- TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+==> end
+`
+
+	workerOutput = `==> proc kubelet
+/usr/bin/kubelet
+--config
+/etc/kubernetes/kubelet/config
+==> file kubelet-config /etc/kubernetes/kubelet/config
+644 0:0
+==> file kubelet-kubeconfig /etc/kubernetes/kubelet/kubeconfig
+600 0:0
+==> file client-ca /etc/kubernetes/pki/ca.crt
+600 0:0
+==> kubelet-config /etc/kubernetes/kubelet/config
+authentication:
+  anonymous:
+    enabled: true
+authorization:
+  mode: Webhook
+readOnlyPort: 10255
+==> end
+`
+)
+
+type runnerTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockKubernetesClient
+	runner  *cis.Runner
+	cluster *types.Cluster
+}
+
+func newRunnerTest(t *testing.T) *runnerTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockKubernetesClient(ctrl)
+	return &runnerTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		client: client,
+		runner: cis.NewRunner(client, "public.ecr.aws/eks-anywhere/diagnostic-collector:v0.18.0"),
+		cluster: &types.Cluster{
+			Name:           "test-cluster",
+			KubeconfigFile: "test-cluster.kubeconfig",
+		},
+	}
+}
+
+func testNodes() []corev1.Node {
+	return []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cp-1",
+				Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+			},
+			Spec: corev1.NodeSpec{ProviderID: "vsphere://4231a2b2-1234"},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 20.04.6 LTS"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Spec:       corev1.NodeSpec{ProviderID: "vsphere://4231a2b2-5678"},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{OSImage: "Bottlerocket OS 1.15.1 (vmware-k8s-1.27)"},
+			},
+		},
+	}
+}
+
+func resultsByID(results []cis.Result) map[string]cis.Result {
+	m := map[string]cis.Result{}
+	for _, r := range results {
+		m[r.ID] = r
+	}
+	return m
+}
+
+func TestRunnerRun(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(testNodes(), nil)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			manifest := string(data)
+			tt.Expect(manifest).To(ContainSubstring("pod-security.kubernetes.io/enforce: privileged"))
+			tt.Expect(manifest).To(ContainSubstring("name: cis-benchmark-cp-1"))
+			tt.Expect(manifest).To(ContainSubstring("nodeName: worker-1"))
+			tt.Expect(manifest).To(ContainSubstring("hostPID: true"))
+			tt.Expect(manifest).To(ContainSubstring("/etc/kubernetes/kubelet/config"))
+			return nil
+		},
+	)
+	tt.client.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "cis-benchmark-cp-1", "5m", cis.Namespace).Return(nil)
+	tt.client.EXPECT().GetPodLogs(tt.ctx, cis.Namespace, "cis-benchmark-cp-1", "cis-benchmark", tt.cluster.KubeconfigFile).Return(controlPlaneOutput, nil)
+	tt.client.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "cis-benchmark-worker-1", "5m", cis.Namespace).Return(nil)
+	tt.client.EXPECT().GetPodLogs(tt.ctx, cis.Namespace, "cis-benchmark-worker-1", "cis-benchmark", tt.cluster.KubeconfigFile).Return(workerOutput, nil)
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.cluster.KubeconfigFile, cis.Namespace).Return(nil)
+
+	report, err := tt.runner.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(report.Cluster).To(Equal("test-cluster"))
+	tt.Expect(report.Nodes).To(HaveLen(2))
+
+	cp := report.Nodes[0]
+	tt.Expect(cp.Role).To(Equal("control-plane"))
+	tt.Expect(cp.OSFamily).To(Equal("ubuntu"))
+	tt.Expect(cp.Provider).To(Equal("vsphere"))
+	results := resultsByID(cp.Results)
+	tt.Expect(results["1.1.1"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["1.1.7"].Status).To(Equal(cis.Skip))
+	tt.Expect(results["2.2"].Status).To(Equal(cis.Skip))
+	tt.Expect(results["1.1.18"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["1.1.18"].Reason).To(Equal("/etc/kubernetes/controller-manager.conf is owned by 1000:0"))
+	tt.Expect(results["1.1.20"].Status).To(Equal(cis.Warn))
+	tt.Expect(results["1.1.21"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["1.2.5"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["1.2.5"].Reason).To(Equal("--kubelet-certificate-authority is not set"))
+	tt.Expect(results["1.2.7"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["1.2.20"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["1.3.1"].Status).To(Equal(cis.Warn))
+	tt.Expect(results["4.1.1"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["4.1.1"].Remediation).To(Equal("Run chmod 600 on the kubelet service file in the node."))
+	tt.Expect(results["4.2.1"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["4.2.4"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["4.2.6"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["4.2.12"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["4.2.13"].Status).To(Equal(cis.Pass))
+
+	worker := report.Nodes[1]
+	tt.Expect(worker.Role).To(Equal("worker"))
+	tt.Expect(worker.OSFamily).To(Equal("bottlerocket"))
+	results = resultsByID(worker.Results)
+	tt.Expect(results).NotTo(HaveKey("1.1.1"))
+	tt.Expect(results).NotTo(HaveKey("1.2.1"))
+	tt.Expect(results["4.1.1"].Status).To(Equal(cis.Skip))
+	tt.Expect(results["4.1.7"].Status).To(Equal(cis.Pass))
+	tt.Expect(results["4.1.9"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["4.2.1"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["4.2.1"].Reason).To(Equal("authentication.anonymous.enabled is set to true"))
+	tt.Expect(results["4.2.4"].Status).To(Equal(cis.Warn))
+	tt.Expect(results["4.2.6"].Status).To(Equal(cis.Fail))
+	tt.Expect(results["4.2.6"].Reason).To(Equal("protectKernelDefaults is not set"))
+	tt.Expect(results["4.2.11"].Status).To(Equal(cis.Pass))
+
+	summary := report.Summary
+	tt.Expect(summary.Pass + summary.Fail + summary.Warn + summary.Skip).To(Equal(len(cp.Results) + len(worker.Results)))
+	tt.Expect(summary.Score).To(BeNumerically(">", 0))
+	tt.Expect(summary.Score).To(BeNumerically("<", 100))
+}
+
+func TestRunnerRunDockerSkipsKernelDefaults(t *testing.T) {
+	tt := newRunnerTest(t)
+	node := testNodes()[1]
+	node.Spec.ProviderID = "docker:////worker-1"
+	node.Status.NodeInfo.OSImage = "Ubuntu 22.04.2 LTS"
+	tt.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{node}, nil)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.client.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "cis-benchmark-worker-1", "5m", cis.Namespace).Return(nil)
+	tt.client.EXPECT().GetPodLogs(tt.ctx, cis.Namespace, "cis-benchmark-worker-1", "cis-benchmark", tt.cluster.KubeconfigFile).Return(workerOutput, nil)
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.cluster.KubeconfigFile, cis.Namespace).Return(nil)
+
+	report, err := tt.runner.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(Succeed())
+	results := resultsByID(report.Nodes[0].Results)
+	tt.Expect(results["4.2.6"].Status).To(Equal(cis.Skip))
+	tt.Expect(results["4.2.6"].Reason).To(Equal("docker nodes share the kernel of the host"))
+	tt.Expect(results["4.1.1"].Status).To(Equal(cis.Warn))
+}
+
+func TestRunnerRunIncompleteOutput(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(testNodes()[1:], nil)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.client.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "cis-benchmark-worker-1", "5m", cis.Namespace).Return(nil)
+	tt.client.EXPECT().GetPodLogs(tt.ctx, cis.Namespace, "cis-benchmark-worker-1", "cis-benchmark", tt.cluster.KubeconfigFile).
+		Return(strings.TrimSuffix(workerOutput, "==> end\n"), nil)
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.cluster.KubeconfigFile, cis.Namespace).Return(nil)
+
+	_, err := tt.runner.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("collecting CIS benchmark facts from node worker-1: audit output is incomplete"))
+}
+
+func TestRunnerRunPodError(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(testNodes()[1:], nil)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.client.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "cis-benchmark-worker-1", "5m", cis.Namespace).Return(errors.New("timed out"))
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.cluster.KubeconfigFile, cis.Namespace).Return(nil)
+
+	_, err := tt.runner.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("collecting CIS benchmark facts from node worker-1: timed out"))
+}
+
+func TestRunnerRunNoNodes(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nil, nil)
+
+	_, err := tt.runner.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("cluster test-cluster doesn't have any nodes"))
+}
+
+func TestReportWriteText(t *testing.T) {
+	g := NewWithT(t)
+	report := &cis.Report{
+		Cluster: "test-cluster",
+		Nodes: []cis.NodeReport{
+			{
+				Name:     "cp-1",
+				Role:     "control-plane",
+				OSFamily: "ubuntu",
+				Provider: "vsphere",
+				Results: []cis.Result{
+					{ID: "1.1.1", Text: "Ensure that the API server pod specification file permissions are set to 600 or more restrictive", Status: cis.Pass, Scored: true},
+					{ID: "1.2.5", Text: "Ensure that the --kubelet-certificate-authority argument is set as appropriate", Status: cis.Fail, Scored: true, Reason: "--kubelet-certificate-authority is not set", Remediation: "Set it."},
+				},
+				Summary: cis.Summary{Pass: 1, Fail: 1, Score: 50},
+			},
+		},
+		Summary: cis.Summary{Pass: 1, Fail: 1, Score: 50},
+	}
+
+	out := &strings.Builder{}
+	g.Expect(report.WriteText(out)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`Node cp-1 (control-plane, ubuntu, vsphere)
+ID        STATUS    CHECK
+1.1.1     PASS      Ensure that the API server pod specification file permissions are set to 600 or more restrictive
+1.2.5     FAIL      Ensure that the --kubelet-certificate-authority argument is set as appropriate
+
+[FAIL] 1.2.5 --kubelet-certificate-authority is not set
+  Remediation: Set it.
+
+score: 50.0% (1 passed, 1 failed, 0 warnings, 0 skipped)
+
+Cluster test-cluster score: 50.0% (1 passed, 1 failed, 0 warnings, 0 skipped)
+`))
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ContextsConfigEnv is the environment variable holding the path of the contexts config file.
+	ContextsConfigEnv = "EKSA_CLI_CONFIG"
+	// ContextEnv is the environment variable holding the name of the context to use when
+	// the --context flag is not set.
+	ContextEnv = "EKSA_CONTEXT"
+
+	defaultContextsConfigDir  = ".eks-anywhere"
+	defaultContextsConfigFile = "config.yaml"
+
+	kubeconfigFlag      = "kubeconfig"
+	providerFlag        = "provider"
+	registryFlag        = "registry"
+	bundlesOverrideFlag = "bundles-override"
+)
+
+// ContextFlags are the CLI flags a ManagementContext sets.
+var ContextFlags = []string{kubeconfigFlag, providerFlag, registryFlag, bundlesOverrideFlag}
+
+// ContextsConfig is the CLI config file holding the management cluster contexts.
+type ContextsConfig struct {
+	// CurrentContext is the context used when no context is selected with the --context flag
+	// or the EKSA_CONTEXT environment variable.
+	CurrentContext string              `json:"currentContext,omitempty"`
+	Contexts       []ManagementContext `json:"contexts,omitempty"`
+}
+
+// ManagementContext holds the defaults of the CLI flags used to operate a management cluster.
+// The defaults only apply to the commands that have the flag and when the flag is not set.
+type ManagementContext struct {
+	Name string `json:"name"`
+	// Kubeconfig is the kubeconfig file of the management cluster, used as the --kubeconfig flag.
+	// Relative paths are relative to the contexts config file.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Provider is the default provider, used as the --provider flag.
+	Provider string `json:"provider,omitempty"`
+	// RegistryMirror is the registry mirror of the management cluster, used as the --registry flag.
+	RegistryMirror string `json:"registryMirror,omitempty"`
+	// BundlesOverride is a bundles manifest, used as the --bundles-override flag.
+	BundlesOverride string `json:"bundlesOverride,omitempty"`
+}
+
+// FlagValues returns the values of the CLI flags set by the context by flag name.
+func (c ManagementContext) FlagValues() map[string]string {
+	values := map[string]string{}
+	for flag, value := range map[string]string{
+		kubeconfigFlag:      c.Kubeconfig,
+		providerFlag:        c.Provider,
+		registryFlag:        c.RegistryMirror,
+		bundlesOverrideFlag: c.BundlesOverride,
+	} {
+		if value != "" {
+			values[flag] = value
+		}
+	}
+	return values
+}
+
+// ContextsConfigPath returns the path of the contexts config file, which is the EKSA_CLI_CONFIG
+// environment variable when set or $HOME/.eks-anywhere/config.yaml otherwise.
+func ContextsConfigPath() (string, error) {
+	if path := os.Getenv(ContextsConfigEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting the contexts config path: %v", err)
+	}
+	return filepath.Join(home, defaultContextsConfigDir, defaultContextsConfigFile), nil
+}
+
+// ReadContextsConfig reads the contexts config file in path. It returns an empty config if
+// the file doesn't exist.
+func ReadContextsConfig(path string) (*ContextsConfig, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &ContextsConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading contexts config: %v", err)
+	}
+
+	config := &ContextsConfig{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("parsing contexts config %s: %v", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("contexts config %s: %v", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i := range config.Contexts {
+		c := &config.Contexts[i]
+		c.Kubeconfig = resolvePath(dir, c.Kubeconfig)
+		c.BundlesOverride = resolvePath(dir, c.BundlesOverride)
+	}
+
+	return config, nil
+}
+
+func (c *ContextsConfig) validate() error {
+	names := map[string]bool{}
+	for _, context := range c.Contexts {
+		if context.Name == "" {
+			return errors.New("context name is required")
+		}
+		if names[context.Name] {
+			return fmt.Errorf("duplicate context %s", context.Name)
+		}
+		names[context.Name] = true
+	}
+	if c.CurrentContext != "" && !names[c.CurrentContext] {
+		return fmt.Errorf("current context %s not found", c.CurrentContext)
+	}
+	return nil
+}
+
+// Context returns the context with name. When name is empty, it returns the context selected in the
+// EKSA_CONTEXT environment variable or the current context. It returns nil if no context is selected.
+func (c *ContextsConfig) Context(name string) (*ManagementContext, error) {
+	if name == "" {
+		name = os.Getenv(ContextEnv)
+	}
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("context %s not found", name)
+}
+
+// resolvePath expands ~ to the home dir and makes relative paths relative to dir.
+// Remote paths, like the bundles manifest URLs, are returned as is.
+func resolvePath(dir, path string) string {
+	switch {
+	case path == "", strings.Contains(path, "://"), filepath.IsAbs(path):
+		return path
+	case path == "~" || strings.HasPrefix(path, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		return filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,189 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cli"
+)
+
+func writeContextsConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadContextsConfig(t *testing.T) {
+	g := NewWithT(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := writeContextsConfig(t, `currentContext: prod
+contexts:
+- name: prod
+  kubeconfig: prod/prod-eks-a-cluster.kubeconfig
+  provider: vsphere
+  registryMirror: registry.prod:443
+  bundlesOverride: https://example.com/bundles.yaml
+- name: lab
+  kubeconfig: ~/lab/lab-eks-a-cluster.kubeconfig
+`)
+
+	config, err := cli.ReadContextsConfig(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&cli.ContextsConfig{
+		CurrentContext: "prod",
+		Contexts: []cli.ManagementContext{
+			{
+				Name:            "prod",
+				Kubeconfig:      filepath.Join(filepath.Dir(path), "prod/prod-eks-a-cluster.kubeconfig"),
+				Provider:        "vsphere",
+				RegistryMirror:  "registry.prod:443",
+				BundlesOverride: "https://example.com/bundles.yaml",
+			},
+			{
+				Name:       "lab",
+				Kubeconfig: filepath.Join(home, "lab/lab-eks-a-cluster.kubeconfig"),
+			},
+		},
+	}))
+}
+
+func TestReadContextsConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cli.ReadContextsConfig(filepath.Join(t.TempDir(), "config.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&cli.ContextsConfig{}))
+}
+
+func TestReadContextsConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "contexts:\n- name: prod\n  cluster: prod",
+			wantErr: "parsing contexts config",
+		},
+		{
+			name:    "missing name",
+			content: "contexts:\n- kubeconfig: prod.kubeconfig",
+			wantErr: "context name is required",
+		},
+		{
+			name:    "duplicate context",
+			content: "contexts:\n- name: prod\n- name: prod",
+			wantErr: "duplicate context prod",
+		},
+		{
+			name:    "current context not found",
+			content: "currentContext: lab\ncontexts:\n- name: prod",
+			wantErr: "current context lab not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := cli.ReadContextsConfig(writeContextsConfig(t, tc.content))
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestContextsConfigContext(t *testing.T) {
+	config := &cli.ContextsConfig{
+		CurrentContext: "prod",
+		Contexts:       []cli.ManagementContext{{Name: "prod"}, {Name: "lab"}, {Name: "dev"}},
+	}
+	tests := []struct {
+		name     string
+		flag     string
+		env      string
+		current  string
+		wantName string
+		wantErr  string
+	}{
+		{
+			name:     "flag",
+			flag:     "lab",
+			env:      "dev",
+			current:  "prod",
+			wantName: "lab",
+		},
+		{
+			name:     "env",
+			env:      "dev",
+			current:  "prod",
+			wantName: "dev",
+		},
+		{
+			name:     "current context",
+			current:  "prod",
+			wantName: "prod",
+		},
+		{
+			name: "no context selected",
+		},
+		{
+			name:    "not found",
+			flag:    "staging",
+			wantErr: "context staging not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv(cli.ContextEnv, tc.env)
+			config.CurrentContext = tc.current
+
+			got, err := config.Context(tc.flag)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.wantName == "" {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got.Name).To(Equal(tc.wantName))
+		})
+	}
+}
+
+func TestContextsConfigPath(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("HOME", "/home/user")
+	t.Setenv(cli.ContextsConfigEnv, "")
+
+	path, err := cli.ContextsConfigPath()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(path).To(Equal("/home/user/.eks-anywhere/config.yaml"))
+
+	t.Setenv(cli.ContextsConfigEnv, "/etc/eksa/config.yaml")
+	path, err = cli.ContextsConfigPath()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(path).To(Equal("/etc/eksa/config.yaml"))
+}
+
+func TestManagementContextFlagValues(t *testing.T) {
+	g := NewWithT(t)
+	c := cli.ManagementContext{
+		Name:           "prod",
+		Kubeconfig:     "prod.kubeconfig",
+		RegistryMirror: "registry.prod:443",
+	}
+
+	g.Expect(c.FlagValues()).To(Equal(map[string]string{
+		"kubeconfig": "prod.kubeconfig",
+		"registry":   "registry.prod:443",
+	}))
+}
@@ -0,0 +1,159 @@
+// Package client is the Go SDK to create, upgrade and delete EKS Anywhere clusters in-process.
+// It runs the same workflows as the eksctl anywhere CLI, which is built on top of it, so the
+// requirements of the admin machine are the same: Docker must be running and the binaries of
+// the tools image are run in containers.
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// Client runs cluster lifecycle operations. A Client has no state between operations, the
+// same Client can run several of them, but not concurrently for the same cluster. Running
+// operations share their tools containers when they use the same image and mount dirs.
+type Client struct {
+	managementKubeconfig string
+	bundlesOverride      string
+	templateDiff         io.Writer
+	executablesPool      *executables.ExecutablesPool
+}
+
+// Opt configures a Client.
+type Opt func(*Client)
+
+// WithManagementKubeconfig sets the kubeconfig of the management cluster of the workload
+// clusters the client operates on. If not set, it's taken from the KUBECONFIG env var or the
+// kubeconfig written by the CLI for the management cluster in the current directory.
+func WithManagementKubeconfig(kubeconfig string) Opt {
+	return func(c *Client) {
+		c.managementKubeconfig = kubeconfig
+	}
+}
+
+// WithBundlesOverride sets a Bundles manifest to use instead of the one of the EKS Anywhere
+// version. It's not recommended outside of testing.
+func WithBundlesOverride(bundlesManifest string) Opt {
+	return func(c *Client) {
+		c.bundlesOverride = bundlesManifest
+	}
+}
+
+// WithTemplateDiff writes to w the changes the cluster templateOverrides make to the generated
+// CAPI templates when creating and upgrading clusters.
+func WithTemplateDiff(w io.Writer) Opt {
+	return func(c *Client) {
+		c.templateDiff = w
+	}
+}
+
+// New builds a Client.
+func New(opts ...Opt) *Client {
+	c := &Client{
+		executablesPool: executables.NewExecutablesPool(executables.BuildDockerExecutable()),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Timeouts configures how long the operations wait for the cluster machines and components.
+// Zero durations use the default of each timeout.
+type Timeouts struct {
+	// ControlPlaneWait is how long to wait for the control plane to be ready.
+	ControlPlaneWait time.Duration
+	// ExternalEtcdWait is how long to wait for the external etcd to be ready.
+	ExternalEtcdWait time.Duration
+	// MachineWait is how long to wait for each machine to be ready.
+	MachineWait time.Duration
+	// UnhealthyMachineWait is how long an unhealthy machine is kept before being remediated.
+	UnhealthyMachineWait time.Duration
+	// NodeStartupWait is how long a machine can take to join the cluster before being remediated.
+	NodeStartupWait time.Duration
+	// NoTimeouts disables the timeouts of all the waits.
+	NoTimeouts bool
+}
+
+func (t Timeouts) withDefaults() Timeouts {
+	if t.ControlPlaneWait == 0 {
+		t.ControlPlaneWait = clustermanager.DefaultControlPlaneWait
+	}
+	if t.ExternalEtcdWait == 0 {
+		t.ExternalEtcdWait = clustermanager.DefaultEtcdWait
+	}
+	if t.MachineWait == 0 {
+		t.MachineWait = clustermanager.DefaultMaxWaitPerMachine
+	}
+	if t.UnhealthyMachineWait == 0 {
+		t.UnhealthyMachineWait = constants.DefaultUnhealthyMachineTimeout
+	}
+	if t.NodeStartupWait == 0 {
+		t.NodeStartupWait = constants.DefaultNodeStartupTimeout
+	}
+	return t
+}
+
+// ClusterManagerTimeouts returns the timeouts of the cluster manager for a cluster of the given
+// datacenter kind. Bare Metal machines take longer to start, so the default node startup
+// timeout is longer for them.
+func (t Timeouts) ClusterManagerTimeouts(datacenterKind string) *dependencies.ClusterManagerTimeoutOptions {
+	t = t.withDefaults()
+	if t.NodeStartupWait == clustermanager.DefaultNodeStartupTimeout && datacenterKind == v1alpha1.TinkerbellDatacenterKind {
+		t.NodeStartupWait = constants.DefaultTinkerbellNodeStartupTimeout
+	}
+
+	return &dependencies.ClusterManagerTimeoutOptions{
+		ControlPlaneWait:     t.ControlPlaneWait,
+		ExternalEtcdWait:     t.ExternalEtcdWait,
+		MachineWait:          t.MachineWait,
+		UnhealthyMachineWait: t.UnhealthyMachineWait,
+		NodeStartupWait:      t.NodeStartupWait,
+		NoTimeouts:           t.NoTimeouts,
+	}
+}
+
+// machineHealthCheckTimeouts returns the node startup and unhealthy machine timeouts used to
+// default the machine health checks of the cluster.
+func (t Timeouts) machineHealthCheckTimeouts() (nodeStartup, unhealthyMachine time.Duration) {
+	if t.NoTimeouts {
+		maxTime := time.Duration(math.MaxInt64)
+		return maxTime, maxTime
+	}
+
+	t = t.withDefaults()
+	return t.NodeStartupWait, t.UnhealthyMachineWait
+}
+
+func skippedValidations(names, skippable []string) (map[string]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	return validations.ValidateSkippableValidation(names, skippable)
+}
+
+// cleanup removes the temporary files of an operation if it succeeded. They are kept when it
+// fails to help troubleshooting.
+func cleanup(deps *dependencies.Dependencies, err error) {
+	if err == nil {
+		deps.Writer.CleanUpTemp()
+	}
+}
+
+func closeDeps(ctx context.Context, deps *dependencies.Dependencies) {
+	if err := deps.Close(ctx); err != nil {
+		logger.Error(err, "Closer failed", "closerType", fmt.Sprintf("%T", deps))
+	}
+}
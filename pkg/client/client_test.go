@@ -0,0 +1,53 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+func TestTimeoutsClusterManagerTimeoutsDefaults(t *testing.T) {
+	g := NewWithT(t)
+	got := client.Timeouts{}.ClusterManagerTimeouts(v1alpha1.VSphereDatacenterKind)
+	g.Expect(got).To(Equal(&dependencies.ClusterManagerTimeoutOptions{
+		ControlPlaneWait:     clustermanager.DefaultControlPlaneWait,
+		ExternalEtcdWait:     clustermanager.DefaultEtcdWait,
+		MachineWait:          clustermanager.DefaultMaxWaitPerMachine,
+		UnhealthyMachineWait: constants.DefaultUnhealthyMachineTimeout,
+		NodeStartupWait:      constants.DefaultNodeStartupTimeout,
+	}))
+}
+
+func TestTimeoutsClusterManagerTimeoutsTinkerbellDefaultNodeStartup(t *testing.T) {
+	g := NewWithT(t)
+	got := client.Timeouts{}.ClusterManagerTimeouts(v1alpha1.TinkerbellDatacenterKind)
+	g.Expect(got.NodeStartupWait).To(Equal(constants.DefaultTinkerbellNodeStartupTimeout))
+}
+
+func TestTimeoutsClusterManagerTimeoutsOverrides(t *testing.T) {
+	g := NewWithT(t)
+	timeouts := client.Timeouts{
+		ControlPlaneWait:     time.Minute,
+		ExternalEtcdWait:     2 * time.Minute,
+		MachineWait:          3 * time.Minute,
+		UnhealthyMachineWait: 4 * time.Minute,
+		NodeStartupWait:      5 * time.Minute,
+		NoTimeouts:           true,
+	}
+	got := timeouts.ClusterManagerTimeouts(v1alpha1.TinkerbellDatacenterKind)
+	g.Expect(got).To(Equal(&dependencies.ClusterManagerTimeoutOptions{
+		ControlPlaneWait:     time.Minute,
+		ExternalEtcdWait:     2 * time.Minute,
+		MachineWait:          3 * time.Minute,
+		UnhealthyMachineWait: 4 * time.Minute,
+		NodeStartupWait:      5 * time.Minute,
+		NoTimeouts:           true,
+	}))
+}
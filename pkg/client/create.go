@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/clockskew"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/dns"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+	"github.com/aws/eks-anywhere/pkg/janitor"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/workflow/management"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+// CreateClusterOptions configures the creation of a cluster.
+type CreateClusterOptions struct {
+	// ClusterConfigFile is the path of the cluster config file. Required.
+	ClusterConfigFile string
+	// HardwareCSVPath is the path of the hardware CSV file of Bare Metal clusters.
+	HardwareCSVPath string
+	// TinkerbellBootstrapIP overrides the IP of the Tinkerbell stack in the bootstrap cluster.
+	TinkerbellBootstrapIP string
+	// SkipIPCheck skips checking whether the control plane IP is in use.
+	SkipIPCheck bool
+	// InstallPackages is the path of the curated packages config to install in the cluster.
+	InstallPackages string
+	// SkipValidations are the names of the create validations to skip, from
+	// createvalidations.SkippableValidations.
+	SkipValidations []string
+	// ClockSkewImage is the image with curl used to validate the clocks of the nodes are in sync.
+	// The validation only runs when it's set.
+	ClockSkewImage string
+	// IPAMConfigPath is the path of the IPAM config the cluster IPs are checked against.
+	IPAMConfigPath string
+	// Timeouts configures the waits of the creation.
+	Timeouts Timeouts
+}
+
+// CreateCluster creates the cluster of the cluster config file. Workload clusters are created
+// from their management cluster. The kubeconfig of new management clusters is written in the
+// <cluster-name> directory of the current directory.
+func (c *Client) CreateCluster(ctx context.Context, opts CreateClusterOptions) error {
+	docker := executables.BuildDockerExecutable()
+
+	if err := validations.CheckMinimumDockerVersion(ctx, docker); err != nil {
+		return fmt.Errorf("failed to validate docker: %v", err)
+	}
+
+	validations.CheckDockerAllocatedMemory(ctx, docker)
+	cleanupStaleToolsContainers(ctx, docker)
+
+	clusterSpec, err := NewClusterSpec(opts.ClusterConfigFile, c.bundlesOverride, c.managementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Spec.EtcdEncryption != nil {
+		return errors.New("etcdEncryption is not supported during cluster creation")
+	}
+
+	hasCheckpoint := hasCreateCheckpoint(clusterSpec.Cluster.Name)
+	resuming := hasCheckpoint && features.IsActive(features.CheckpointEnabled())
+	if resuming {
+		logger.Info("Found checkpoint from a previous run, resuming cluster creation", "cluster", clusterSpec.Cluster.Name)
+	}
+
+	kubeconfigPath := kubeconfig.FromClusterName(clusterSpec.Cluster.Name)
+	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) && !resuming {
+		if hasCheckpoint {
+			return fmt.Errorf(
+				"old cluster config file exists under %s from a failed create run, set %s=true to resume it or use a different clusterName to proceed",
+				clusterSpec.Cluster.Name, features.CheckpointEnabledEnvVar,
+			)
+		}
+		return fmt.Errorf(
+			"old cluster config file exists under %s, please use a different clusterName to proceed",
+			clusterSpec.Cluster.Name,
+		)
+	}
+
+	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
+		return err
+	}
+
+	cliConfig := BuildCliConfig(clusterSpec)
+	dirs := DirectoriesToMount(clusterSpec, cliConfig, c.managementKubeconfig, opts.InstallPackages)
+
+	createCLIConfig := &config.CreateClusterCLIConfig{SkipCPIPCheck: opts.SkipIPCheck}
+	createCLIConfig.NodeStartupTimeout, createCLIConfig.UnhealthyMachineTimeout = opts.Timeouts.machineHealthCheckTimeouts()
+
+	skipped, err := skippedValidations(opts.SkipValidations, createvalidations.SkippableValidations)
+	if err != nil {
+		return err
+	}
+
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithExecutablesPool(c.executablesPool).
+		WithBootstrapper().
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster, opts.Timeouts.ClusterManagerTimeouts(clusterSpec.Cluster.Spec.DatacenterRef.Kind)).
+		WithProvider(opts.ClusterConfigFile, clusterSpec.Cluster, opts.SkipIPCheck, opts.HardwareCSVPath, false, opts.TinkerbellBootstrapIP, skipped).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		WithEksdInstaller().
+		WithPackageInstaller(clusterSpec, opts.InstallPackages, c.managementKubeconfig).
+		WithValidatorClients().
+		WithCreateClusterDefaulter(createCLIConfig)
+
+	if opts.Timeouts.NoTimeouts {
+		factory.WithNoTimeouts()
+	}
+
+	if c.templateDiff != nil {
+		factory.WithTemplateDiff(c.templateDiff)
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDeps(ctx, deps)
+
+	clusterSpec, err = deps.CreateClusterDefaulter.Run(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	createOpts := []workflows.CreateOpt{workflows.WithCreateOperationRecorder(deps.ClusterManager)}
+	if opts.ClockSkewImage != "" {
+		createOpts = append(createOpts, workflows.WithClockSkewValidator(clockskew.NewChecker(deps.Kubectl, opts.ClockSkewImage)))
+	}
+	if endpoint := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && endpoint.DNS != nil {
+		credentials := dns.CredentialsFromEnv()
+		if err := credentials.ValidateEnv(endpoint.DNS); err != nil {
+			return err
+		}
+		createOpts = append(createOpts, workflows.WithDNSRegistrar(dns.NewEndpointRegistrar(deps.Kubectl, credentials, dns.NewProvider)))
+	}
+
+	ipConflicts, err := buildIPConflictDetector(opts.IPAMConfigPath)
+	if err != nil {
+		return err
+	}
+
+	createCluster := workflows.NewCreate(
+		deps.Bootstrapper,
+		deps.Provider,
+		deps.ClusterManager,
+		deps.GitOpsFlux,
+		deps.Writer,
+		deps.EksdInstaller,
+		deps.PackageInstaller,
+		createOpts...,
+	)
+
+	validationOpts := &validations.Opts{
+		Kubectl: deps.UnAuthKubectlClient,
+		Spec:    clusterSpec,
+		WorkloadCluster: &types.Cluster{
+			Name:           clusterSpec.Cluster.Name,
+			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
+		},
+		ManagementCluster:  ManagementCluster(clusterSpec),
+		Provider:           deps.Provider,
+		CliConfig:          cliConfig,
+		IPConflicts:        ipConflicts,
+		SkippedValidations: skipped,
+	}
+	createValidations := createvalidations.New(validationOpts)
+
+	if features.UseNewWorkflows().IsActive() {
+		deps, err = factory.
+			WithCNIInstaller(clusterSpec, deps.Provider).
+			Build(ctx)
+		if err != nil {
+			return err
+		}
+
+		wflw := &management.CreateCluster{
+			Spec:                          clusterSpec,
+			Bootstrapper:                  deps.Bootstrapper,
+			CreateBootstrapClusterOptions: deps.Provider,
+			CNIInstaller:                  deps.CNIInstaller,
+			Cluster:                       clustermanager.NewCreateClusterShim(clusterSpec, deps.ClusterManager, deps.Provider),
+			FS:                            deps.Writer,
+		}
+		wflw.WithHookRegistrar(awsiamauth.NewHookRegistrar(deps.AwsIamAuth, clusterSpec))
+
+		// Not all provider implementations want to bind hooks so we explicitly check if they
+		// want to bind hooks before registering it.
+		if registrar, ok := deps.Provider.(management.CreateClusterHookRegistrar); ok {
+			wflw.WithHookRegistrar(registrar)
+		}
+
+		err = wflw.Run(ctx)
+	} else {
+		err = createCluster.Run(ctx, clusterSpec, createValidations, false)
+	}
+
+	cleanup(deps, err)
+	return err
+}
+
+// buildIPConflictDetector returns the detector of the IP conflicts of the new cluster, checking the
+// allocations of the IPAM configured in ipamConfigPath, if set.
+func buildIPConflictDetector(ipamConfigPath string) (*ipconflict.Detector, error) {
+	if ipamConfigPath == "" {
+		return ipconflict.NewDetector(), nil
+	}
+
+	config, err := ipconflict.ParseIPAMConfigFile(ipamConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	ipam, err := ipconflict.NewIPAM(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipconflict.NewDetector(ipconflict.WithIPAM(ipam, config.Reserve)), nil
+}
+
+// hasCreateCheckpoint returns true if a previous create run for clusterName failed, leaving behind
+// a checkpoint to resume from. A create can only be resumed from a checkpoint: the kind bootstrap
+// cluster and the CAPI objects left behind by a run without checkpoints are not reused.
+func hasCreateCheckpoint(clusterName string) bool {
+	return validations.FileExists(filepath.Join(clusterName, filewriter.DefaultTmpFolder, task.CheckpointFileName(clusterName)))
+}
+
+// cleanupStaleToolsContainers removes the tools containers left behind by interrupted commands before a new
+// command creates its own. The bootstrap clusters and temporary directories can be needed to recover from the
+// failed command, so they are only removed by the cleanup admin-machine command. Failing to clean up doesn't
+// stop the command.
+func cleanupStaleToolsContainers(ctx context.Context, docker janitor.DockerClient) {
+	report, err := janitor.New(docker, janitor.WithoutBootstrapClusters(), janitor.WithoutTempDirs()).Clean(ctx)
+	if err != nil {
+		logger.V(3).Info("Failed cleaning up stale tools containers in the admin machine", "error", err)
+		return
+	}
+	if !report.IsEmpty() {
+		logger.Info("Removed stale tools containers from interrupted commands", "containers", report.ToolsContainers)
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+// DeleteClusterOptions configures the deletion of a cluster.
+type DeleteClusterOptions struct {
+	// ClusterConfigFile is the path of the cluster config file. Required.
+	ClusterConfigFile string
+	// HardwareCSVPath is the path of the hardware CSV file of Bare Metal clusters.
+	HardwareCSVPath string
+	// TinkerbellBootstrapIP overrides the IP of the Tinkerbell stack in the bootstrap cluster.
+	TinkerbellBootstrapIP string
+}
+
+// DeleteCluster deletes the cluster of the cluster config file. Workload clusters are deleted
+// from their management cluster.
+func (c *Client) DeleteCluster(ctx context.Context, opts DeleteClusterOptions) error {
+	clusterSpec, err := NewClusterSpec(opts.ClusterConfigFile, c.bundlesOverride, c.managementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
+		return err
+	}
+
+	cliConfig := BuildCliConfig(clusterSpec)
+	dirs := DirectoriesToMount(clusterSpec, cliConfig, c.managementKubeconfig)
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithExecutablesPool(c.executablesPool).
+		WithBootstrapper().
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster, nil).
+		WithProvider(opts.ClusterConfigFile, clusterSpec.Cluster, false, opts.HardwareCSVPath, false, opts.TinkerbellBootstrapIP, map[string]bool{}).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDeps(ctx, deps)
+
+	deleteCluster := workflows.NewDelete(
+		deps.Bootstrapper,
+		deps.Provider,
+		deps.ClusterManager,
+		deps.GitOpsFlux,
+		deps.Writer,
+		workflows.WithDeleteOperationRecorder(deps.ClusterManager),
+	)
+
+	var cluster *types.Cluster
+	if clusterSpec.ManagementCluster == nil {
+		cluster = &types.Cluster{
+			Name:               clusterSpec.Cluster.Name,
+			KubeconfigFile:     kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
+			ExistingManagement: false,
+		}
+	} else {
+		cluster = &types.Cluster{
+			Name:               clusterSpec.Cluster.Name,
+			KubeconfigFile:     clusterSpec.ManagementCluster.KubeconfigFile,
+			ExistingManagement: true,
+		}
+	}
+
+	err = deleteCluster.Run(ctx, cluster, clusterSpec, false, c.managementKubeconfig)
+	cleanup(deps, err)
+	return err
+}
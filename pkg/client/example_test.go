@@ -0,0 +1,36 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func ExampleClient_CreateCluster() {
+	c := client.New(client.WithManagementKubeconfig("mgmt/mgmt-eks-a-cluster.kubeconfig"))
+
+	err := c.CreateCluster(context.Background(), client.CreateClusterOptions{
+		ClusterConfigFile: "workload.yaml",
+		Timeouts: client.Timeouts{
+			ControlPlaneWait: 30 * time.Minute,
+		},
+	})
+	if err != nil {
+		fmt.Printf("Failed creating cluster: %v\n", err)
+	}
+}
+
+func ExampleClient_UpgradeCluster() {
+	c := client.New(client.WithManagementKubeconfig("mgmt/mgmt-eks-a-cluster.kubeconfig"))
+
+	err := c.UpgradeCluster(context.Background(), client.UpgradeClusterOptions{
+		ClusterConfigFile: "workload.yaml",
+		SkipValidations:   []string{validations.PDB},
+	})
+	if err != nil {
+		fmt.Printf("Failed upgrading cluster: %v\n", err)
+	}
+}
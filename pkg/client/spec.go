@@ -0,0 +1,189 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/schema"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+// ReadClusterSpec reads the cluster spec from the cluster config file, validating the file
+// against the API schemas first.
+func ReadClusterSpec(clusterConfigPath string, cliVersion version.Info, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
+	reader := files.NewReader(files.WithEKSAUserAgent("cli", cliVersion.GitVersion))
+	if err := validateClusterConfigSchema(reader, clusterConfigPath); err != nil {
+		return nil, eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, err)
+	}
+
+	b := cluster.NewFileSpecBuilder(reader, cliVersion, opts...)
+	clusterSpec, err := b.Build(clusterConfigPath)
+	if err != nil {
+		return nil, eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, err)
+	}
+
+	return clusterSpec, nil
+}
+
+// validateClusterConfigSchema validates the cluster config file against the API schemas before
+// it's parsed, so errors point to the line of the invalid field. Errors reading the file are
+// left to the spec builder.
+func validateClusterConfigSchema(reader *files.Reader, clusterConfigPath string) error {
+	content, err := reader.ReadFile(clusterConfigPath)
+	if err != nil {
+		return nil
+	}
+
+	registry, err := schema.Load()
+	if err != nil {
+		return fmt.Errorf("loading cluster config schemas: %v", err)
+	}
+
+	return registry.Validate(content)
+}
+
+// ReadAndValidateClusterSpec reads the cluster spec from the cluster config file and validates
+// its config.
+func ReadAndValidateClusterSpec(clusterConfigPath string, cliVersion version.Info, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
+	clusterSpec, err := ReadClusterSpec(clusterConfigPath, cliVersion, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = cluster.ValidateConfig(clusterSpec.Config); err != nil {
+		return nil, eksaerrors.WithCode(eksaerrors.CodeClusterConfigInvalid, err)
+	}
+
+	return clusterSpec, nil
+}
+
+// NewClusterSpec reads and validates the cluster spec from the cluster config file with the
+// bundles of this version, or bundlesOverride if set. The management cluster of workload
+// clusters is loaded from managementKubeconfig, defaulting to ManagementClusterKubeconfig.
+func NewClusterSpec(clusterConfigPath, bundlesOverride, managementKubeconfig string) (*cluster.Spec, error) {
+	var opts []cluster.FileSpecBuilderOpt
+	if bundlesOverride != "" {
+		opts = append(opts, cluster.WithOverrideBundlesManifest(bundlesOverride))
+	}
+
+	clusterSpec, err := ReadAndValidateClusterSpec(clusterConfigPath, version.Get(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster config from file: %w", err)
+	}
+
+	if clusterSpec.Cluster.IsManaged() {
+		if managementKubeconfig == "" {
+			managementKubeconfig, err = ManagementClusterKubeconfig(clusterSpec.Cluster.Spec.ManagementCluster.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		managementCluster, err := cluster.LoadManagement(managementKubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get management cluster from kubeconfig: %v", err)
+		}
+		clusterSpec.ManagementCluster = managementCluster
+	}
+
+	return clusterSpec, nil
+}
+
+// ManagementClusterKubeconfig returns the kubeconfig of the management cluster clusterName,
+// from the KUBECONFIG env var or the kubeconfig written by the CLI in the current directory.
+func ManagementClusterKubeconfig(clusterName string) (string, error) {
+	envKubeconfig := kubeconfig.FromEnvironment()
+	if envKubeconfig != "" {
+		return envKubeconfig, nil
+	}
+	// check if kubeconfig for management cluster exists locally
+	managementKubeconfigPath := kubeconfig.FromClusterName(clusterName)
+	if validations.FileExistsAndIsNotEmpty(managementKubeconfigPath) {
+		return managementKubeconfigPath, nil
+	}
+	return "", fmt.Errorf("management kubeconfig file not found, must be present for workload cluster operations")
+}
+
+// ManagementCluster returns the management cluster of the cluster, which is the cluster itself
+// for management clusters.
+func ManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster == nil {
+		return &types.Cluster{
+			Name:           clusterSpec.Cluster.Name,
+			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
+		}
+	}
+
+	return &types.Cluster{
+		Name:           clusterSpec.ManagementCluster.Name,
+		KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+	}
+}
+
+// BuildCliConfig builds the CLI config of the cluster, reading the Git credentials of the
+// GitOps config from the environment.
+func BuildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
+	cliConfig := &config.CliConfig{}
+	if clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Git != nil {
+		cliConfig.GitSshKeyPassphrase = os.Getenv(config.EksaGitPassphraseTokenEnv)
+		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
+		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
+	}
+
+	return cliConfig
+}
+
+// DirectoriesToMount returns the directories of the admin machine the tools containers need
+// for the cluster, plus the directories of files, ignoring the empty ones.
+func DirectoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig, files ...string) []string {
+	var dirs []string
+	fluxConfig := clusterSpec.FluxConfig
+	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
+		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
+		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
+	}
+
+	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.CloudStackDatacenterKind {
+		if extraDirs, err := CloudStackDirectoriesToMount(); err == nil {
+			dirs = append(dirs, extraDirs...)
+		}
+	}
+
+	if renderer := clusterSpec.Cluster.Spec.HelmPostRenderer; renderer != nil {
+		dirs = append(dirs, filepath.Dir(renderer.BinaryPath))
+	}
+
+	for _, f := range files {
+		if f != "" {
+			dirs = append(dirs, filepath.Dir(f))
+		}
+	}
+
+	return dirs
+}
+
+// CloudStackDirectoriesToMount returns the directories of the admin machine set in the
+// EKSA_CLOUDSTACK_HOST_PATHS_TO_MOUNT env var, failing if any of them doesn't exist.
+func CloudStackDirectoriesToMount() ([]string, error) {
+	dirs := []string{}
+	env, found := os.LookupEnv(decoder.EksaCloudStackHostPathToMount)
+	if found && len(env) > 0 {
+		mountDirs := strings.Split(env, ",")
+		for _, dir := range mountDirs {
+			if _, err := os.Stat(dir); err != nil {
+				return nil, fmt.Errorf("invalid host path to mount: %v", err)
+			}
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
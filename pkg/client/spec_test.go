@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/client"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestDirectoriesToMount(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.HelmPostRenderer = &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/renderer/bin/render"}
+	})
+
+	got := client.DirectoriesToMount(spec, &config.CliConfig{}, "/home/user/mgmt/mgmt.kubeconfig", "")
+	g.Expect(got).To(Equal([]string{"/opt/renderer/bin", "/home/user/mgmt"}))
+}
+
+func TestDirectoriesToMountGitOps(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.FluxConfig = &v1alpha1.FluxConfig{
+			Spec: v1alpha1.FluxConfigSpec{Git: &v1alpha1.GitProviderConfig{}},
+		}
+	})
+	cliConfig := &config.CliConfig{
+		GitPrivateKeyFile: "/keys/id_rsa",
+		GitKnownHostsFile: "/ssh/known_hosts",
+	}
+
+	g.Expect(client.DirectoriesToMount(spec, cliConfig)).To(Equal([]string{"/keys", "/ssh"}))
+}
+
+func TestCloudStackDirectoriesToMount(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	t.Setenv("EKSA_CLOUDSTACK_HOST_PATHS_TO_MOUNT", dir)
+
+	g.Expect(client.CloudStackDirectoriesToMount()).To(Equal([]string{dir}))
+}
+
+func TestCloudStackDirectoriesToMountNotFound(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("EKSA_CLOUDSTACK_HOST_PATHS_TO_MOUNT", "/does/not/exist")
+
+	_, err := client.CloudStackDirectoriesToMount()
+	g.Expect(err).To(MatchError(ContainSubstring("invalid host path to mount")))
+}
+
+func TestManagementClusterSelfManaged(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "mgmt"
+	})
+
+	g.Expect(client.ManagementCluster(spec)).To(Equal(&types.Cluster{
+		Name:           "mgmt",
+		KubeconfigFile: "mgmt/mgmt-eks-a-cluster.kubeconfig",
+	}))
+}
+
+func TestManagementClusterWorkload(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "workload"
+		s.ManagementCluster = &types.Cluster{Name: "mgmt", KubeconfigFile: "/mgmt.kubeconfig"}
+	})
+
+	g.Expect(client.ManagementCluster(spec)).To(Equal(&types.Cluster{
+		Name:           "mgmt",
+		KubeconfigFile: "/mgmt.kubeconfig",
+	}))
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clockskew"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networking/mtu"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	"github.com/aws/eks-anywhere/pkg/velero"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/management"
+)
+
+// UpgradeClusterOptions configures the upgrade of a cluster.
+type UpgradeClusterOptions struct {
+	// ClusterConfigFile is the path of the cluster config file with the new spec. Required.
+	ClusterConfigFile string
+	// WorkloadKubeconfig is the kubeconfig of the cluster. If not set, the kubeconfig written by
+	// the CLI in the <cluster-name> directory of the current directory is used.
+	WorkloadKubeconfig string
+	// HardwareCSVPath is the path of the hardware CSV file of Bare Metal clusters.
+	HardwareCSVPath string
+	// TinkerbellBootstrapIP overrides the IP of the Tinkerbell stack in the bootstrap cluster.
+	TinkerbellBootstrapIP string
+	// SkipValidations are the names of the upgrade validations to skip, from
+	// upgradevalidations.SkippableValidations.
+	SkipValidations []string
+	// BackupWorkloads creates a Velero backup of the cluster workloads before upgrading.
+	BackupWorkloads bool
+	// VeleroNamespace is the namespace Velero is installed in. Defaults to velero.DefaultNamespace.
+	VeleroNamespace string
+	// EtcdDiskBenchmarkImage is the image with fio used to validate the etcd disk latency.
+	// The validation only runs when it's set.
+	EtcdDiskBenchmarkImage string
+	// MTUProbeImage is the image with ping used to validate the network MTU between the nodes.
+	// The validation only runs when it's set.
+	MTUProbeImage string
+	// ClockSkewImage is the image with curl used to validate the clocks of the nodes are in sync.
+	// The validation only runs when it's set.
+	ClockSkewImage string
+	// Timeouts configures the waits of the upgrade.
+	Timeouts Timeouts
+}
+
+// UpgradeCluster upgrades the cluster to the spec of the cluster config file. Workload clusters
+// are upgraded from their management cluster.
+func (c *Client) UpgradeCluster(ctx context.Context, opts UpgradeClusterOptions) error {
+	clusterSpec, err := NewClusterSpec(opts.ClusterConfigFile, c.bundlesOverride, c.managementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if err := v1alpha1.ValidateEtcdEncryptionConfig(clusterSpec.Cluster.Spec.EtcdEncryption); err != nil {
+		return err
+	}
+
+	if err := validations.ValidateAuthenticationForRegistryMirror(clusterSpec); err != nil {
+		return err
+	}
+
+	cliConfig := BuildCliConfig(clusterSpec)
+	dirs := DirectoriesToMount(clusterSpec, cliConfig, c.managementKubeconfig)
+
+	upgradeCLIConfig := &config.UpgradeClusterCLIConfig{}
+	upgradeCLIConfig.NodeStartupTimeout, upgradeCLIConfig.UnhealthyMachineTimeout = opts.Timeouts.machineHealthCheckTimeouts()
+
+	skipped, err := skippedValidations(opts.SkipValidations, upgradevalidations.SkippableValidations)
+	if err != nil {
+		return err
+	}
+
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithExecutablesPool(c.executablesPool).
+		WithBootstrapper().
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster, opts.Timeouts.ClusterManagerTimeouts(clusterSpec.Cluster.Spec.DatacenterRef.Kind)).
+		WithClusterApplier().
+		WithProvider(opts.ClusterConfigFile, clusterSpec.Cluster, false, opts.HardwareCSVPath, false, opts.TinkerbellBootstrapIP, skipped).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		WithCAPIManager().
+		WithEksdUpgrader().
+		WithEksdInstaller().
+		WithKubectl().
+		WithUnAuthKubeClient().
+		WithValidatorClients().
+		WithUpgradeClusterDefaulter(upgradeCLIConfig)
+
+	if opts.Timeouts.NoTimeouts {
+		factory.WithNoTimeouts()
+	}
+
+	if c.templateDiff != nil {
+		factory.WithTemplateDiff(c.templateDiff)
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDeps(ctx, deps)
+
+	clusterSpec, err = deps.UpgradeClusterDefaulter.Run(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	workloadKubeconfig := opts.WorkloadKubeconfig
+	if workloadKubeconfig == "" {
+		workloadKubeconfig = kubeconfig.FromClusterName(clusterSpec.Cluster.Name)
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: workloadKubeconfig,
+	}
+
+	var managementCluster *types.Cluster
+	if clusterSpec.ManagementCluster == nil {
+		managementCluster = workloadCluster
+	} else {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	validationOpts := &validations.Opts{
+		Kubectl:            deps.UnAuthKubectlClient,
+		Spec:               clusterSpec,
+		WorkloadCluster:    workloadCluster,
+		ManagementCluster:  managementCluster,
+		Provider:           deps.Provider,
+		CliConfig:          cliConfig,
+		SkippedValidations: skipped,
+		APIScanner:         deprecatedapis.NewScanner(deps.Kubectl),
+	}
+	if opts.EtcdDiskBenchmarkImage != "" {
+		validationOpts.EtcdDiskBenchmark = etcdbenchmark.NewBenchmark(deps.Kubectl, opts.EtcdDiskBenchmarkImage)
+	}
+	if opts.MTUProbeImage != "" {
+		validationOpts.MTUProber = mtu.NewProber(deps.Kubectl, opts.MTUProbeImage)
+	}
+	if opts.ClockSkewImage != "" {
+		validationOpts.ClockSkew = clockskew.NewChecker(deps.Kubectl, opts.ClockSkewImage)
+	}
+
+	upgradeValidations := upgradevalidations.New(validationOpts)
+
+	if features.ExperimentalSelfManagedClusterUpgrade().IsActive() && clusterSpec.Cluster.IsSelfManaged() {
+		if opts.BackupWorkloads {
+			return errors.New("backing up the workloads is not supported with the experimental management cluster upgrade")
+		}
+		logger.Info("Management kindless upgrade")
+		upgrade := management.NewUpgrade(
+			deps.Provider,
+			deps.CAPIManager,
+			deps.ClusterManager,
+			deps.GitOpsFlux,
+			deps.Writer,
+			deps.EksdUpgrader,
+			deps.EksdInstaller,
+			deps.ClusterApplier,
+			management.WithOperationRecorder(deps.ClusterManager),
+		)
+
+		err = upgrade.Run(ctx, clusterSpec, managementCluster, upgradeValidations)
+
+	} else {
+		upgradeOpts := []workflows.UpgradeOpt{workflows.WithUpgradeOperationRecorder(deps.ClusterManager)}
+		if opts.BackupWorkloads {
+			veleroNamespace := opts.VeleroNamespace
+			if veleroNamespace == "" {
+				veleroNamespace = velero.DefaultNamespace
+			}
+			upgradeOpts = append(upgradeOpts, workflows.WithWorkloadBackup(velero.NewBackup(deps.UnAuthKubeClient, veleroNamespace)))
+		}
+
+		upgrade := workflows.NewUpgrade(
+			deps.Bootstrapper,
+			deps.Provider,
+			deps.CAPIManager,
+			deps.ClusterManager,
+			deps.GitOpsFlux,
+			deps.Writer,
+			deps.EksdUpgrader,
+			deps.EksdInstaller,
+			upgradeOpts...,
+		)
+
+		err = upgrade.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, false)
+	}
+
+	cleanup(deps, err)
+	return err
+}
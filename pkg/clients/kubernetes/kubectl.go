@@ -34,6 +34,16 @@ type KubectlGetOptions struct {
 	// ClusterScoped identifies the resourced as no namespaced. This is mutually exclusive with
 	// Namespace and requires to also specify a Name.
 	ClusterScoped *bool
+
+	// LabelSelector filters the objects by label, following the kubectl selector syntax.
+	LabelSelector string
+
+	// FieldSelector filters the objects by field, following the kubectl field selector syntax.
+	FieldSelector string
+
+	// ChunkSize is the number of objects requested at a time when listing large collections.
+	// kubectl's default is used if zero.
+	ChunkSize int64
 }
 
 var _ KubectlGetOption = &KubectlGetOptions{}
@@ -49,6 +59,15 @@ func (o *KubectlGetOptions) ApplyToGet(kgo *KubectlGetOptions) {
 	if o.ClusterScoped != nil {
 		kgo.ClusterScoped = o.ClusterScoped
 	}
+	if o.LabelSelector != "" {
+		kgo.LabelSelector = o.LabelSelector
+	}
+	if o.FieldSelector != "" {
+		kgo.FieldSelector = o.FieldSelector
+	}
+	if o.ChunkSize != 0 {
+		kgo.ChunkSize = o.ChunkSize
+	}
 }
 
 // KubectlApplyOption is some configuration that modifies options for an apply command.
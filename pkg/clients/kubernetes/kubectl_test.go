@@ -69,6 +69,24 @@ func TestKubectlGetOptionsApplyToGet(t *testing.T) {
 				ClusterScoped: ptr.Bool(true),
 			},
 		},
+		{
+			name: "selectors and chunk size",
+			option: &kubernetes.KubectlGetOptions{
+				LabelSelector: "app=my-app",
+				FieldSelector: "type=Opaque",
+				ChunkSize:     100,
+			},
+			in: &kubernetes.KubectlGetOptions{
+				Namespace:     "ns",
+				LabelSelector: "app=other",
+			},
+			want: &kubernetes.KubectlGetOptions{
+				Namespace:     "ns",
+				LabelSelector: "app=my-app",
+				FieldSelector: "type=Opaque",
+				ChunkSize:     100,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,9 +1,12 @@
 package kubernetes
 
 import (
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	tinkerbellv1 "github.com/tinkerbell/cluster-api-provider-tinkerbell/api/v1beta1"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta3"
@@ -37,6 +40,9 @@ var schemeAdders = []schemeAdder{
 	etcdv1.AddToScheme,
 	addonsv1.AddToScheme,
 	tinkerbellv1.AddToScheme,
+	tinkv1alpha1.AddToScheme,
+	rufiov1alpha1.AddToScheme,
+	packagesv1.AddToScheme,
 }
 
 func addToScheme(scheme *runtime.Scheme, schemeAdders ...schemeAdder) error {
@@ -48,3 +54,8 @@ func addToScheme(scheme *runtime.Scheme, schemeAdders ...schemeAdder) error {
 
 	return nil
 }
+
+// AddToScheme adds all the API types EKS Anywhere works with to the scheme.
+func AddToScheme(scheme *runtime.Scheme) error {
+	return addToScheme(scheme, schemeAdders...)
+}
@@ -3,11 +3,15 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
@@ -16,14 +20,43 @@ import (
 type UnAuthClient struct {
 	kubectl Kubectl
 	scheme  *runtime.Scheme
+
+	runtimeClientBuilder RuntimeClientBuilder
+	runtimeClientsLock   sync.Mutex
+	runtimeClients       map[string]client.Client
+}
+
+// RuntimeClientBuilder builds a controller-runtime client from a kubeconfig file
+// using the provided scheme.
+type RuntimeClientBuilder func(kubeconfig string, scheme *runtime.Scheme) (client.Client, error)
+
+// UnAuthClientOpt allows to customize an UnAuthClient on construction.
+type UnAuthClientOpt func(*UnAuthClient)
+
+// WithRuntimeClients makes the client read and patch objects by calling the kube API server
+// directly instead of running kubectl. One client is built and cached per kubeconfig file.
+// If builder is nil, clients are built from the kubeconfig file contents.
+func WithRuntimeClients(builder RuntimeClientBuilder) UnAuthClientOpt {
+	return func(c *UnAuthClient) {
+		if builder == nil {
+			builder = runtimeClientFromKubeconfigFile
+		}
+		c.runtimeClientBuilder = builder
+		c.runtimeClients = map[string]client.Client{}
+	}
 }
 
 // NewUnAuthClient builds a new UnAuthClient.
-func NewUnAuthClient(kubectl Kubectl) *UnAuthClient {
-	return &UnAuthClient{
+func NewUnAuthClient(kubectl Kubectl, opts ...UnAuthClientOpt) *UnAuthClient {
+	c := &UnAuthClient{
 		kubectl: kubectl,
 		scheme:  runtime.NewScheme(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Init initializes the client internal API scheme
@@ -41,6 +74,14 @@ func (c *UnAuthClient) Get(ctx context.Context, name, namespace, kubeconfig stri
 		return fmt.Errorf("getting kubernetes resource: %v", err)
 	}
 
+	if o, ok := obj.(client.Object); ok && c.runtimeClientBuilder != nil {
+		cl, err := c.runtimeClient(kubeconfig)
+		if err != nil {
+			return fmt.Errorf("getting %s: %v", resourceType, err)
+		}
+		return cl.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, o)
+	}
+
 	return c.kubectl.Get(ctx, resourceType, kubeconfig, obj, &KubectlGetOptions{Name: name, Namespace: namespace})
 }
 
@@ -68,6 +109,10 @@ func (c *UnAuthClient) ApplyServerSide(ctx context.Context, kubeconfig, fieldMan
 		opt.ApplyToApplyServerSide(o)
 	}
 
+	if c.runtimeClientBuilder != nil {
+		return c.patchServerSide(ctx, kubeconfig, fieldManager, obj, o)
+	}
+
 	ko := KubectlApplyOptions{
 		ServerSide:   true,
 		FieldManager: fieldManager,
@@ -87,6 +132,14 @@ func (c *UnAuthClient) List(ctx context.Context, kubeconfig string, list ObjectL
 		return fmt.Errorf("getting kubernetes resource: %v", err)
 	}
 
+	if c.runtimeClientBuilder != nil {
+		cl, err := c.runtimeClient(kubeconfig)
+		if err != nil {
+			return fmt.Errorf("listing %s: %v", resourceType, err)
+		}
+		return cl.List(ctx, list)
+	}
+
 	return c.kubectl.Get(ctx, resourceType, kubeconfig, list)
 }
 
@@ -132,6 +185,63 @@ func (c *UnAuthClient) DeleteAllOf(ctx context.Context, kubeconfig string, obj O
 	return c.kubectl.Delete(ctx, resourceType, kubeconfig, o)
 }
 
+func (c *UnAuthClient) patchServerSide(ctx context.Context, kubeconfig, fieldManager string, obj Object, o *ApplyServerSideOptions) error {
+	cl, err := c.runtimeClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("applying object: %v", err)
+	}
+
+	// Server side apply patches need the object type, which typed objects don't
+	// always have populated.
+	if obj.GetObjectKind().GroupVersionKind().Empty() {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return fmt.Errorf("applying object: %v", err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	patchOpts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if o.ForceOwnership {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+
+	return cl.Patch(ctx, obj, client.Apply, patchOpts...)
+}
+
+// runtimeClient returns the cached controller-runtime client for a kubeconfig file,
+// building it if it doesn't exist yet.
+func (c *UnAuthClient) runtimeClient(kubeconfig string) (client.Client, error) {
+	c.runtimeClientsLock.Lock()
+	defer c.runtimeClientsLock.Unlock()
+
+	if cl, ok := c.runtimeClients[kubeconfig]; ok {
+		return cl, nil
+	}
+
+	cl, err := c.runtimeClientBuilder(kubeconfig, c.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client for %s: %v", kubeconfig, err)
+	}
+	c.runtimeClients[kubeconfig] = cl
+
+	return cl, nil
+}
+
+func runtimeClientFromKubeconfigFile(kubeconfig string, scheme *runtime.Scheme) (client.Client, error) {
+	data, err := os.ReadFile(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
 func (c *UnAuthClient) resourceTypeForObj(obj runtime.Object) (string, error) {
 	groupVersionKind, err := apiutil.GVKForObject(obj, c.scheme)
 	if err != nil {
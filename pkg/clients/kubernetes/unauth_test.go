@@ -2,16 +2,19 @@ package kubernetes_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterapiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
@@ -377,3 +380,90 @@ func TestUnauthClientApplyServerSide(t *testing.T) {
 		})
 	}
 }
+
+type patchRecorder struct {
+	client.Client
+	obj   client.Object
+	patch client.Patch
+	opts  *client.PatchOptions
+}
+
+func (p *patchRecorder) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	p.obj = obj
+	p.patch = patch
+	p.opts = &client.PatchOptions{}
+	p.opts.ApplyOptions(opts)
+	return nil
+}
+
+func TestUnAuthClientWithRuntimeClientsGetAndList(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectl(ctrl)
+	builds := 0
+	builder := func(kubeconfig string, scheme *runtime.Scheme) (client.Client, error) {
+		builds++
+		g.Expect(kubeconfig).To(Equal("k.kubeconfig"))
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		).Build(), nil
+	}
+
+	c := kubernetes.NewUnAuthClient(kubectl, kubernetes.WithRuntimeClients(builder))
+	g.Expect(c.Init()).To(Succeed())
+
+	cluster := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, "my-cluster", "default", "k.kubeconfig", cluster)).To(Succeed())
+	g.Expect(cluster.Name).To(Equal("my-cluster"))
+
+	nodes := &corev1.NodeList{}
+	g.Expect(c.List(ctx, "k.kubeconfig", nodes)).To(Succeed())
+	g.Expect(nodes.Items).To(HaveLen(1))
+
+	err := c.Get(ctx, "other-cluster", "default", "k.kubeconfig", &anywherev1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(builds).To(Equal(1))
+}
+
+func TestUnAuthClientWithRuntimeClientsBuildError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectl(ctrl)
+	builder := func(string, *runtime.Scheme) (client.Client, error) {
+		return nil, errors.New("invalid kubeconfig")
+	}
+
+	c := kubernetes.NewUnAuthClient(kubectl, kubernetes.WithRuntimeClients(builder))
+	g.Expect(c.Init()).To(Succeed())
+
+	g.Expect(c.Get(ctx, "my-cluster", "default", "k.kubeconfig", &anywherev1.Cluster{})).To(MatchError(
+		"getting Cluster.v1alpha1.anywhere.eks.amazonaws.com: building kubernetes client for k.kubeconfig: invalid kubeconfig",
+	))
+}
+
+func TestUnAuthClientWithRuntimeClientsApplyServerSide(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectl(ctrl)
+	recorder := &patchRecorder{}
+	builder := func(string, *runtime.Scheme) (client.Client, error) {
+		return recorder, nil
+	}
+
+	c := kubernetes.NewUnAuthClient(kubectl, kubernetes.WithRuntimeClients(builder))
+	g.Expect(c.Init()).To(Succeed())
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "eksa-system"}}
+	g.Expect(c.ApplyServerSide(ctx, "k.kubeconfig", "eks-a-cli", secret, &kubernetes.ApplyServerSideOptions{ForceOwnership: true})).To(Succeed())
+
+	g.Expect(recorder.obj).To(Equal(secret))
+	g.Expect(recorder.patch).To(Equal(client.Apply))
+	g.Expect(recorder.opts.FieldManager).To(Equal("eks-a-cli"))
+	g.Expect(*recorder.opts.Force).To(BeTrue())
+	g.Expect(secret.Kind).To(Equal("Secret"))
+	g.Expect(secret.APIVersion).To(Equal("v1"))
+}
@@ -0,0 +1,162 @@
+package clockskew
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/pod.yaml
+var podTemplate string
+
+const (
+	// MaxClockSkew is the maximum difference allowed between the clock of a node and the clock of the
+	// kube-apiserver. etcd starts warning about clock differences between members over one second.
+	MaxClockSkew = 2 * time.Second
+
+	containerName = "clock"
+	podTimeout    = "5m"
+)
+
+// KubectlClient runs the clock pods in a cluster.
+type KubectlClient interface {
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForPodCompleted(ctx context.Context, cluster *types.Cluster, name string, timeout string, namespace string) error
+	GetPodLogs(ctx context.Context, namespace, podName, containerName, kubeconfig string) (string, error)
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Result is the skew measured for a node.
+type Result struct {
+	Node string
+	// Skew is how much the node clock is ahead of the kube-apiserver clock. It's negative if it's behind.
+	Skew time.Duration
+}
+
+// Checker compares the clock of each node of a cluster with the clock of the kube-apiserver.
+type Checker struct {
+	kubectl KubectlClient
+	image   string
+}
+
+// NewChecker builds a Checker that reads the clocks from image, which must have a shell, curl
+// and GNU date in its PATH.
+func NewChecker(kubectl KubectlClient, image string) *Checker {
+	return &Checker{
+		kubectl: kubectl,
+		image:   image,
+	}
+}
+
+// Measure runs a pod in each node of the cluster, one at a time, that reads the node clock right before
+// and after requesting the kube-apiserver, and compares it with the Date header of the response. Since
+// the header has a one second resolution, the measured skew is accurate to around half a second.
+func (c *Checker) Measure(ctx context.Context, cluster *types.Cluster) ([]Result, error) {
+	nodes, err := c.kubectl.GetNodes(ctx, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(nodes))
+	for _, node := range nodes {
+		logger.V(3).Info("Measuring clock skew", "node", node.Name)
+		skew, err := c.measureNode(ctx, cluster, node.Name)
+		if err != nil {
+			return nil, fmt.Errorf("measuring clock skew of node %s: %v", node.Name, err)
+		}
+		results = append(results, Result{Node: node.Name, Skew: skew})
+	}
+
+	return results, nil
+}
+
+// ValidateClockSkew returns an error if the clock of any node of the cluster is off by more than
+// MaxClockSkew. Skewed clocks break etcd and make TLS certificates look expired or not valid yet.
+func (c *Checker) ValidateClockSkew(ctx context.Context, cluster *types.Cluster) error {
+	results, err := c.Measure(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	var skewed []string
+	for _, r := range results {
+		logger.V(3).Info("Node clock skew", "node", r.Node, "skew", r.Skew)
+		if r.Skew > MaxClockSkew || r.Skew < -MaxClockSkew {
+			skewed = append(skewed, fmt.Sprintf("%s (%s)", r.Node, r.Skew.Round(100*time.Millisecond)))
+		}
+	}
+
+	if len(skewed) > 0 {
+		return fmt.Errorf("clock skew with the kube-apiserver is over %s in nodes: %s", MaxClockSkew, strings.Join(skewed, ", "))
+	}
+
+	return nil
+}
+
+func (c *Checker) measureNode(ctx context.Context, cluster *types.Cluster, node string) (skew time.Duration, reterr error) {
+	name := "eksa-clock-skew-" + node
+	pod, err := templater.Execute(podTemplate, map[string]string{
+		"name":      name,
+		"namespace": constants.KubeSystemNamespace,
+		"nodeName":  node,
+		"container": containerName,
+		"image":     c.image,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("generating clock pod: %v", err)
+	}
+
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, cluster, pod); err != nil {
+		return 0, fmt.Errorf("creating clock pod: %v", err)
+	}
+	defer func() {
+		if err := c.kubectl.DeleteKubeSpecFromBytes(ctx, cluster, pod); err != nil && reterr == nil {
+			reterr = fmt.Errorf("deleting clock pod: %v", err)
+		}
+	}()
+
+	if err = c.kubectl.WaitForPodCompleted(ctx, cluster, name, podTimeout, constants.KubeSystemNamespace); err != nil {
+		return 0, fmt.Errorf("waiting for clock pod: %v", err)
+	}
+
+	logs, err := c.kubectl.GetPodLogs(ctx, constants.KubeSystemNamespace, name, containerName, cluster.KubeconfigFile)
+	if err != nil {
+		return 0, fmt.Errorf("reading clock pod output: %v", err)
+	}
+
+	return parseSkew(logs)
+}
+
+// parseSkew reads the "<node ns before> <node ns after> <server seconds>" line printed by the clock pod.
+// The server time is moved to the middle of its second to compensate for the truncated Date header.
+func parseSkew(output string) (time.Duration, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 3 {
+		return 0, fmt.Errorf("invalid clock pod output: %q", strings.TrimSpace(output))
+	}
+
+	values := make([]int64, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid clock pod output: %q", strings.TrimSpace(output))
+		}
+		values = append(values, v)
+	}
+
+	node := time.Unix(0, values[0]+(values[1]-values[0])/2)
+	server := time.Unix(values[2], int64(500*time.Millisecond))
+
+	return node.Sub(server), nil
+}
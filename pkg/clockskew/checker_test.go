@@ -0,0 +1,123 @@
+package clockskew_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/clockskew"
+	"github.com/aws/eks-anywhere/pkg/clockskew/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const clockImage = "registry.example.com/netshoot:v0.11"
+
+type checkerTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlClient
+	cluster *types.Cluster
+	checker *clockskew.Checker
+}
+
+func newCheckerTest(t *testing.T) *checkerTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	return &checkerTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		cluster: &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		checker: clockskew.NewChecker(kubectl, clockImage),
+	}
+}
+
+func nodes(names ...string) []corev1.Node {
+	n := make([]corev1.Node, 0, len(names))
+	for _, name := range names {
+		n = append(n, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return n
+}
+
+func (tt *checkerTest) expectMeasure(node, logs string) {
+	name := "eksa-clock-skew-" + node
+	pod := gomock.AssignableToTypeOf([]byte{})
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, pod).DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: " + name))
+			tt.Expect(string(data)).To(ContainSubstring("nodeName: " + node))
+			tt.Expect(string(data)).To(ContainSubstring("image: " + clockImage))
+			return nil
+		}),
+		tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, name, "5m", "kube-system").Return(nil),
+		tt.kubectl.EXPECT().GetPodLogs(tt.ctx, "kube-system", name, "clock", tt.cluster.KubeconfigFile).Return(logs, nil),
+		tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil),
+	)
+}
+
+func TestCheckerMeasure(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nodes("cp-1", "md-1"), nil)
+	tt.expectMeasure("cp-1", "1700000000400000000 1700000000600000000 1700000000\n")
+	tt.expectMeasure("md-1", "1699999965000000000 1699999965200000000 1700000000\n")
+
+	tt.Expect(tt.checker.Measure(tt.ctx, tt.cluster)).To(Equal([]clockskew.Result{
+		{Node: "cp-1", Skew: 0},
+		{Node: "md-1", Skew: -35400 * time.Millisecond},
+	}))
+}
+
+func TestCheckerMeasureGetNodesError(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nil, errors.New("connection refused"))
+
+	_, err := tt.checker.Measure(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestCheckerMeasurePodFailed(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nodes("cp-1"), nil)
+	pod := gomock.AssignableToTypeOf([]byte{})
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil)
+	tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "eksa-clock-skew-cp-1", "5m", "kube-system").Return(errors.New("timed out"))
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil)
+
+	_, err := tt.checker.Measure(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("measuring clock skew of node cp-1: waiting for clock pod: timed out"))
+}
+
+func TestCheckerMeasureInvalidOutput(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nodes("cp-1"), nil)
+	tt.expectMeasure("cp-1", "failed to read the kube-apiserver date\n")
+
+	_, err := tt.checker.Measure(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(`measuring clock skew of node cp-1: invalid clock pod output: "failed to read the kube-apiserver date"`))
+}
+
+func TestCheckerValidateClockSkew(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nodes("cp-1", "md-1"), nil)
+	tt.expectMeasure("cp-1", "1700000001900000000 1700000002100000000 1700000000\n")
+	tt.expectMeasure("md-1", "1700000000400000000 1700000000600000000 1700000000\n")
+
+	tt.Expect(tt.checker.ValidateClockSkew(tt.ctx, tt.cluster)).To(Succeed())
+}
+
+func TestCheckerValidateClockSkewOverMax(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nodes("cp-1", "md-1", "md-2"), nil)
+	tt.expectMeasure("cp-1", "1700000000400000000 1700000000600000000 1700000000\n")
+	tt.expectMeasure("md-1", "1699999965000000000 1699999965200000000 1700000000\n")
+	tt.expectMeasure("md-2", "1700000003000000000 1700000003000000000 1700000000\n")
+
+	tt.Expect(tt.checker.ValidateClockSkew(tt.ctx, tt.cluster)).To(MatchError("clock skew with the kube-apiserver is over 2s in nodes: md-1 (-35.4s), md-2 (2.5s)"))
+}
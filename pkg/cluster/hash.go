@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+// hashedObject is the part of an API object included in the spec hash.
+type hashedObject struct {
+	Kind string          `json:"kind"`
+	Name string          `json:"name"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+// SpecHash returns a canonical hash of the specs of the Cluster and all its child objects.
+// Metadata and status are not included, so the hash only changes when the desired state of
+// the cluster changes. The bundle and eks-a version used by the cluster are part of the Cluster
+// spec, so the hash also changes when the cluster is upgraded to a new release.
+func (c *Config) SpecHash() (string, error) {
+	objs := c.ClusterAndChildren()
+	hashed := make([]hashedObject, 0, len(objs))
+	for _, o := range objs {
+		h, err := newHashedObject(o)
+		if err != nil {
+			return "", err
+		}
+		hashed = append(hashed, h)
+	}
+
+	// Child objects are stored in maps, so they are sorted to make the hash deterministic.
+	sort.Slice(hashed, func(i, j int) bool {
+		if hashed[i].Kind != hashed[j].Kind {
+			return hashed[i].Kind < hashed[j].Kind
+		}
+		return hashed[i].Name < hashed[j].Name
+	})
+
+	content, err := json.Marshal(hashed)
+	if err != nil {
+		return "", fmt.Errorf("marshalling cluster config for hash: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func newHashedObject(obj kubernetes.Object) (hashedObject, error) {
+	// The type name is used instead of the object kind since the TypeMeta
+	// is not always populated for objects read from the API server.
+	kind := reflect.TypeOf(obj).Elem().Name()
+
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return hashedObject{}, fmt.Errorf("marshalling %s %s for hash: %v", kind, obj.GetName(), err)
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return hashedObject{}, fmt.Errorf("unmarshalling %s %s for hash: %v", kind, obj.GetName(), err)
+	}
+
+	return hashedObject{
+		Kind: kind,
+		Name: obj.GetName(),
+		Spec: fields["spec"],
+	}, nil
+}
@@ -0,0 +1,124 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func hashTestConfig() *cluster.Config {
+	return &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Generation: 2},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube127,
+				DatacenterRef: anywherev1.Ref{
+					Kind: anywherev1.VSphereDatacenterKind,
+					Name: "my-cluster",
+				},
+			},
+		},
+		VSphereDatacenter: &anywherev1.VSphereDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+			Spec: anywherev1.VSphereDatacenterConfigSpec{
+				Server: "vsphere.local",
+			},
+		},
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"cp": {
+				ObjectMeta: metav1.ObjectMeta{Name: "cp"},
+				Spec:       anywherev1.VSphereMachineConfigSpec{NumCPUs: 2},
+			},
+			"workers": {
+				ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+				Spec:       anywherev1.VSphereMachineConfigSpec{NumCPUs: 4},
+			},
+		},
+	}
+}
+
+func TestConfigSpecHashDeterministic(t *testing.T) {
+	g := NewWithT(t)
+	config := hashTestConfig()
+
+	want, err := config.SpecHash()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(want).To(HaveLen(64))
+
+	for i := 0; i < 10; i++ {
+		g.Expect(config.DeepCopy().SpecHash()).To(Equal(want))
+	}
+}
+
+func TestConfigSpecHashIgnoresMetadataAndStatus(t *testing.T) {
+	g := NewWithT(t)
+	config := hashTestConfig()
+	want, err := config.SpecHash()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config.Cluster.Generation = 5
+	config.Cluster.Annotations = map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}
+	config.Cluster.Status.ReconciledGeneration = 5
+	config.VSphereMachineConfigs["cp"].ResourceVersion = "999"
+	config.VSphereDatacenter.TypeMeta = metav1.TypeMeta{
+		Kind:       anywherev1.VSphereDatacenterKind,
+		APIVersion: anywherev1.GroupVersion.String(),
+	}
+
+	g.Expect(config.SpecHash()).To(Equal(want))
+}
+
+func TestConfigSpecHashSpecChanged(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*cluster.Config)
+	}{
+		{
+			name: "cluster spec",
+			change: func(c *cluster.Config) {
+				c.Cluster.Spec.KubernetesVersion = anywherev1.Kube128
+			},
+		},
+		{
+			name: "datacenter spec",
+			change: func(c *cluster.Config) {
+				c.VSphereDatacenter.Spec.Server = "other.local"
+			},
+		},
+		{
+			name: "machine config spec",
+			change: func(c *cluster.Config) {
+				c.VSphereMachineConfigs["workers"].Spec.NumCPUs = 8
+			},
+		},
+		{
+			name: "machine config name",
+			change: func(c *cluster.Config) {
+				c.VSphereMachineConfigs["workers"].Name = "workers-2"
+			},
+		},
+		{
+			name: "new child object",
+			change: func(c *cluster.Config) {
+				c.OIDCConfigs = map[string]*anywherev1.OIDCConfig{
+					"oidc": {ObjectMeta: metav1.ObjectMeta{Name: "oidc"}},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := hashTestConfig()
+			original, err := config.SpecHash()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tt.change(config)
+			g.Expect(config.SpecHash()).NotTo(Equal(original))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+// ListOperations returns the ClusterOperations in namespace, sorted from oldest to newest.
+// If clusterName is not empty, only the operations of that cluster are returned.
+func ListOperations(ctx context.Context, client kubernetes.Reader, clusterName, namespace string) ([]v1alpha1.ClusterOperation, error) {
+	list := &v1alpha1.ClusterOperationList{}
+	if err := client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing cluster operations: %v", err)
+	}
+
+	namespace = revisionsNamespace(namespace)
+	operations := make([]v1alpha1.ClusterOperation, 0, len(list.Items))
+	for _, o := range list.Items {
+		if o.Namespace == namespace && (clusterName == "" || o.Spec.ClusterName == clusterName) {
+			operations = append(operations, o)
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if !operations[i].Status.StartedAt.Equal(&operations[j].Status.StartedAt) {
+			return operations[i].Status.StartedAt.Before(&operations[j].Status.StartedAt)
+		}
+		return operations[i].Name < operations[j].Name
+	})
+
+	return operations, nil
+}
+
+// RecordOperation creates op or, if it already exists, updates it with the spec and status of op.
+func RecordOperation(ctx context.Context, client kubernetes.Client, op *v1alpha1.ClusterOperation) error {
+	current := &v1alpha1.ClusterOperation{}
+	err := client.Get(ctx, op.Name, op.Namespace, current)
+	if apierrors.IsNotFound(err) {
+		if err = client.Create(ctx, op); err != nil {
+			return fmt.Errorf("creating cluster operation %s: %v", op.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting cluster operation %s: %v", op.Name, err)
+	}
+
+	current.Spec = op.Spec
+	current.Status = op.Status
+	if err = client.Update(ctx, current); err != nil {
+		return fmt.Errorf("updating cluster operation %s: %v", op.Name, err)
+	}
+
+	return nil
+}
+
+// CLIOperationInitiator returns the initiator for operations run by the CLI, with the current OS user.
+func CLIOperationInitiator() v1alpha1.ClusterOperationInitiator {
+	initiator := v1alpha1.ClusterOperationInitiator{Type: v1alpha1.CLIInitiator}
+	if u, err := user.Current(); err == nil {
+		initiator.User = u.Username
+	} else {
+		initiator.User = os.Getenv("USER")
+	}
+
+	return initiator
+}
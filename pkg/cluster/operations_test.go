@@ -0,0 +1,75 @@
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func clusterOperation(clusterName, namespace string, opType anywherev1.ClusterOperationType, startedAt time.Time) *anywherev1.ClusterOperation {
+	c := &anywherev1.Cluster{}
+	c.Name = clusterName
+	c.Namespace = namespace
+	return anywherev1.NewClusterOperation(c, opType, anywherev1.ClusterOperationInitiator{Type: anywherev1.CLIInitiator}, startedAt)
+}
+
+func TestListOperations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	client := test.NewFakeKubeClient(
+		clusterOperation("my-cluster", "default", anywherev1.UpgradeClusterOperation, now),
+		clusterOperation("my-cluster", "default", anywherev1.CreateClusterOperation, now.Add(-time.Hour)),
+		clusterOperation("my-cluster", "other", anywherev1.UpgradeClusterOperation, now),
+		clusterOperation("other-cluster", "default", anywherev1.ScaleClusterOperation, now.Add(-time.Minute)),
+	)
+
+	operations, err := cluster.ListOperations(ctx, client, "my-cluster", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(operations).To(HaveLen(2))
+	g.Expect(operations[0].Spec.Type).To(Equal(anywherev1.CreateClusterOperation))
+	g.Expect(operations[1].Spec.Type).To(Equal(anywherev1.UpgradeClusterOperation))
+
+	operations, err = cluster.ListOperations(ctx, client, "", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(operations).To(HaveLen(3))
+	g.Expect(operations[1].Spec.ClusterName).To(Equal("other-cluster"))
+}
+
+func TestRecordOperation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	startedAt := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	op := clusterOperation("my-cluster", "", anywherev1.UpgradeClusterOperation, startedAt)
+	op.Spec.SpecHash = "hash"
+
+	g.Expect(op.Name).To(Equal("my-cluster-upgrade-20230501103000"))
+	g.Expect(op.Namespace).To(Equal("default"))
+	g.Expect(op.Labels).To(HaveKeyWithValue(anywherev1.ClusterOperationClusterLabel, "my-cluster"))
+
+	g.Expect(cluster.RecordOperation(ctx, client, op)).To(Succeed())
+
+	op.Complete(startedAt.Add(time.Minute), errors.New("machines not ready"))
+	g.Expect(cluster.RecordOperation(ctx, client, op)).To(Succeed())
+
+	operations, err := cluster.ListOperations(ctx, client, "my-cluster", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(operations).To(HaveLen(1))
+	g.Expect(operations[0].Spec.SpecHash).To(Equal("hash"))
+	g.Expect(operations[0].Status.Outcome).To(Equal(anywherev1.OperationFailed))
+	g.Expect(operations[0].Status.Message).To(Equal("machines not ready"))
+	g.Expect(operations[0].Status.CompletedAt).NotTo(BeNil())
+}
+
+func TestCLIOperationInitiator(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(cluster.CLIOperationInitiator().Type).To(Equal(anywherev1.CLIInitiator))
+}
@@ -0,0 +1,162 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// deletePatch is the value of the $patch key that removes a whole document from the base.
+const deletePatch = "delete"
+
+type configDocument struct {
+	id   documentID
+	json []byte
+}
+
+type documentID struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+func (d documentID) String() string {
+	return fmt.Sprintf("%s %s", d.Kind, d.Name)
+}
+
+type documentHeader struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Patch string `json:"$patch"`
+}
+
+// ApplyConfigOverlays merges overlays on top of a base cluster config. Each overlay is a
+// multi document yaml where each document is matched with the document in the base with the
+// same apiVersion, kind and name and merged into it using strategic merge patch semantics.
+// Worker node groups are merged by name and the rest of the lists are replaced. A document
+// with "$patch: delete" removes the matching document from the base and documents that don't
+// match any document in the base are added to the config. Overlays are applied in order.
+func ApplyConfigOverlays(base []byte, overlays ...[]byte) ([]byte, error) {
+	docs, err := splitConfigDocuments(base)
+	if err != nil {
+		return nil, fmt.Errorf("parsing base cluster config: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	for i, overlay := range overlays {
+		patches, err := splitConfigDocuments(overlay)
+		if err != nil {
+			return nil, fmt.Errorf("parsing cluster config overlay %d: %v", i, err)
+		}
+
+		for _, patch := range patches {
+			docs, err = applyDocumentPatch(scheme, docs, patch)
+			if err != nil {
+				return nil, fmt.Errorf("applying cluster config overlay %d to %s: %v", i, patch.id, err)
+			}
+		}
+	}
+
+	resources := make([][]byte, 0, len(docs))
+	for _, d := range docs {
+		r, err := yaml.JSONToYAML(d.json)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func applyDocumentPatch(scheme *runtime.Scheme, docs []configDocument, patch configDocument) ([]configDocument, error) {
+	header := &documentHeader{}
+	if err := json.Unmarshal(patch.json, header); err != nil {
+		return nil, err
+	}
+
+	for i, d := range docs {
+		if d.id != patch.id {
+			continue
+		}
+
+		if header.Patch == deletePatch {
+			return append(docs[:i], docs[i+1:]...), nil
+		}
+
+		obj, err := scheme.New(schema.FromAPIVersionAndKind(d.id.APIVersion, d.id.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("only EKS Anywhere objects can be patched: %v", err)
+		}
+
+		merged, err := strategicpatch.StrategicMergePatch(d.json, patch.json, obj)
+		if err != nil {
+			return nil, err
+		}
+		docs[i].json = merged
+
+		return docs, nil
+	}
+
+	if header.Patch == deletePatch {
+		return nil, fmt.Errorf("can't delete %s, it's not in the base cluster config", patch.id)
+	}
+
+	return append(docs, patch), nil
+}
+
+func splitConfigDocuments(content []byte) ([]configDocument, error) {
+	var docs []configDocument
+	r := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		d, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		j, err := yaml.YAMLToJSON(d)
+		if err != nil {
+			return nil, err
+		}
+		if string(j) == "null" {
+			continue
+		}
+
+		header := &documentHeader{}
+		if err := json.Unmarshal(j, header); err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, configDocument{
+			id: documentID{
+				APIVersion: header.APIVersion,
+				Kind:       header.Kind,
+				Name:       header.Metadata.Name,
+			},
+			json: j,
+		})
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,168 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const overlayBase = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: site
+spec:
+  kubernetesVersion: "1.27"
+  datacenterRef:
+    kind: VSphereDatacenterConfig
+    name: site
+  controlPlaneConfiguration:
+    count: 1
+    endpoint:
+      host: 1.1.1.1
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      name: site-cp
+  workerNodeGroupConfigurations:
+  - name: md-0
+    count: 1
+    labels:
+      tier: base
+  - name: md-1
+    count: 1
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: site-cp
+spec:
+  numCPUs: 2
+  memoryMiB: 8192
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: OIDCConfig
+metadata:
+  name: site
+spec:
+  clientId: base
+`
+
+func TestApplyConfigOverlays(t *testing.T) {
+	g := NewWithT(t)
+	siteOverlay := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: site
+spec:
+  controlPlaneConfiguration:
+    endpoint:
+      host: 2.2.2.2
+  workerNodeGroupConfigurations:
+  - name: md-1
+    count: 3
+  - name: md-2
+    count: 2
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      name: site-md-2
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: site-cp
+spec:
+  numCPUs: 4
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: OIDCConfig
+metadata:
+  name: site
+$patch: delete
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: site-md-2
+spec:
+  numCPUs: 8
+`)
+	versionOverlay := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: site
+spec:
+  kubernetesVersion: "1.28"
+`)
+
+	content, err := cluster.ApplyConfigOverlays([]byte(overlayBase), siteOverlay, versionOverlay)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config, err := cluster.ParseConfig(content)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	c := config.Cluster
+	g.Expect(c.Spec.KubernetesVersion).To(Equal(anywherev1.Kube128))
+	g.Expect(c.Spec.ControlPlaneConfiguration.Count).To(Equal(1))
+	g.Expect(c.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("2.2.2.2"))
+	g.Expect(c.Spec.WorkerNodeGroupConfigurations).To(HaveLen(3))
+	g.Expect(c.Spec.WorkerNodeGroupConfigurations[0].Name).To(Equal("md-0"))
+	g.Expect(c.Spec.WorkerNodeGroupConfigurations[0].Labels).To(HaveKeyWithValue("tier", "base"))
+	g.Expect(*c.Spec.WorkerNodeGroupConfigurations[1].Count).To(Equal(3))
+	g.Expect(c.Spec.WorkerNodeGroupConfigurations[2].Name).To(Equal("md-2"))
+
+	g.Expect(config.VSphereMachineConfigs).To(HaveLen(2))
+	g.Expect(config.VSphereMachineConfigs["site-cp"].Spec.NumCPUs).To(Equal(4))
+	g.Expect(config.VSphereMachineConfigs["site-cp"].Spec.MemoryMiB).To(Equal(8192))
+	g.Expect(config.VSphereMachineConfigs["site-md-2"].Spec.NumCPUs).To(Equal(8))
+	g.Expect(string(content)).ToNot(ContainSubstring("kind: OIDCConfig"))
+}
+
+func TestApplyConfigOverlaysNoOverlays(t *testing.T) {
+	g := NewWithT(t)
+	content, err := cluster.ApplyConfigOverlays([]byte(overlayBase))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config, err := cluster.ParseConfig(content)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations).To(HaveLen(2))
+}
+
+func TestApplyConfigOverlaysErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{
+			name:    "invalid yaml",
+			overlay: "kind: [Cluster",
+			wantErr: "parsing cluster config overlay 0",
+		},
+		{
+			name: "delete missing document",
+			overlay: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: OIDCConfig
+metadata:
+  name: other
+$patch: delete
+`,
+			wantErr: "applying cluster config overlay 0 to OIDCConfig other: can't delete OIDCConfig other, it's not in the base cluster config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := cluster.ApplyConfigOverlays([]byte(overlayBase), []byte(tt.overlay))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestApplyConfigOverlaysNonEKSAObject(t *testing.T) {
+	g := NewWithT(t)
+	base := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: b\n")
+	_, err := cluster.ApplyConfigOverlays(base, base)
+	g.Expect(err).To(MatchError(ContainSubstring("only EKS Anywhere objects can be patched")))
+}
@@ -62,7 +62,7 @@ func TestParseConfig(t *testing.T) {
 						},
 						{
 							Name:              "workers-2",
-							KubernetesVersion: &kube120,
+							KubernetesVersion: &kube119,
 							Count:             ptr.Int(1),
 							MachineGroupRef: &anywherev1.Ref{
 								Kind: "VSphereMachineConfig",
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ListSpecRevisions returns the ClusterSpecRevisions of a cluster, sorted from oldest to newest.
+func ListSpecRevisions(ctx context.Context, client kubernetes.Reader, clusterName, namespace string) ([]v1alpha1.ClusterSpecRevision, error) {
+	list := &v1alpha1.ClusterSpecRevisionList{}
+	if err := client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing cluster spec revisions: %v", err)
+	}
+
+	namespace = revisionsNamespace(namespace)
+	revisions := make([]v1alpha1.ClusterSpecRevision, 0, len(list.Items))
+	for _, r := range list.Items {
+		if r.Spec.ClusterName == clusterName && r.Namespace == namespace {
+			revisions = append(revisions, r)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Spec.Revision < revisions[j].Spec.Revision
+	})
+
+	return revisions, nil
+}
+
+// GetSpecRevision returns a ClusterSpecRevision of a cluster by its revision number.
+func GetSpecRevision(ctx context.Context, client kubernetes.Reader, clusterName, namespace string, revision int) (*v1alpha1.ClusterSpecRevision, error) {
+	r := &v1alpha1.ClusterSpecRevision{}
+	name := v1alpha1.ClusterSpecRevisionName(clusterName, revision)
+	if err := client.Get(ctx, name, revisionsNamespace(namespace), r); err != nil {
+		return nil, fmt.Errorf("getting revision %d for cluster %s: %v", revision, clusterName, err)
+	}
+
+	return r, nil
+}
+
+// CreateSpecRevision records config, the rendered cluster config for spec, as the next revision of the
+// cluster. Only the newest maxRevisions are kept, older ones are deleted.
+func CreateSpecRevision(ctx context.Context, client kubernetes.Client, spec *Spec, config []byte, maxRevisions int) (*v1alpha1.ClusterSpecRevision, error) {
+	clusterName := spec.Cluster.Name
+	revisions, err := ListSpecRevisions(ctx, client, clusterName, spec.Cluster.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 1
+	if len(revisions) > 0 {
+		next = revisions[len(revisions)-1].Spec.Revision + 1
+	}
+
+	r := &v1alpha1.ClusterSpecRevision{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       v1alpha1.ClusterSpecRevisionKind,
+		},
+	}
+	r.Name = v1alpha1.ClusterSpecRevisionName(clusterName, next)
+	r.Namespace = revisionsNamespace(spec.Cluster.Namespace)
+	r.Labels = map[string]string{v1alpha1.ClusterSpecRevisionClusterLabel: clusterName}
+	r.Spec = v1alpha1.ClusterSpecRevisionSpec{
+		ClusterName: clusterName,
+		Revision:    next,
+		Config:      string(config),
+	}
+	if spec.EKSARelease != nil {
+		r.Spec.EksaVersion = spec.EKSARelease.Spec.Version
+	}
+	if spec.Bundles != nil {
+		r.Spec.BundlesNumber = spec.Bundles.Spec.Number
+	}
+
+	if err := client.Create(ctx, r); err != nil {
+		return nil, fmt.Errorf("creating revision %d for cluster %s: %v", next, clusterName, err)
+	}
+
+	revisions = append(revisions, *r)
+	if maxRevisions > 0 && len(revisions) > maxRevisions {
+		for i := range revisions[:len(revisions)-maxRevisions] {
+			if err := client.Delete(ctx, &revisions[i]); err != nil {
+				return nil, fmt.Errorf("deleting old revision %d for cluster %s: %v", revisions[i].Spec.Revision, clusterName, err)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func revisionsNamespace(namespace string) string {
+	if namespace == "" {
+		return constants.DefaultNamespace
+	}
+	return namespace
+}
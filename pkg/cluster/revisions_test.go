@@ -0,0 +1,107 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func specRevision(clusterName, namespace string, revision int) *anywherev1.ClusterSpecRevision {
+	return &anywherev1.ClusterSpecRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      anywherev1.ClusterSpecRevisionName(clusterName, revision),
+			Namespace: namespace,
+		},
+		Spec: anywherev1.ClusterSpecRevisionSpec{
+			ClusterName: clusterName,
+			Revision:    revision,
+			Config:      "config",
+		},
+	}
+}
+
+func TestListSpecRevisions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient(
+		specRevision("my-cluster", "default", 2),
+		specRevision("my-cluster", "default", 1),
+		specRevision("my-cluster", "other", 3),
+		specRevision("other-cluster", "default", 1),
+	)
+
+	revisions, err := cluster.ListSpecRevisions(ctx, client, "my-cluster", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revisions).To(HaveLen(2))
+	g.Expect(revisions[0].Spec.Revision).To(Equal(1))
+	g.Expect(revisions[1].Spec.Revision).To(Equal(2))
+}
+
+func TestGetSpecRevision(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient(specRevision("my-cluster", "default", 1))
+
+	revision, err := cluster.GetSpecRevision(ctx, client, "my-cluster", "default", 1)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision.Spec.Config).To(Equal("config"))
+
+	_, err = cluster.GetSpecRevision(ctx, client, "my-cluster", "default", 2)
+	g.Expect(err).To(MatchError(ContainSubstring("getting revision 2 for cluster my-cluster")))
+}
+
+func TestCreateSpecRevisionFirst(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Bundles.Spec.Number = 5
+	})
+
+	revision, err := cluster.CreateSpecRevision(ctx, client, spec, []byte("cluster config"), 10)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision.Name).To(Equal("my-cluster-rev-1"))
+	g.Expect(revision.Namespace).To(Equal("default"))
+	g.Expect(revision.Labels).To(HaveKeyWithValue(anywherev1.ClusterSpecRevisionClusterLabel, "my-cluster"))
+	g.Expect(revision.Spec).To(Equal(anywherev1.ClusterSpecRevisionSpec{
+		ClusterName:   "my-cluster",
+		Revision:      1,
+		EksaVersion:   spec.EKSARelease.Spec.Version,
+		BundlesNumber: 5,
+		Config:        "cluster config",
+	}))
+
+	got, err := cluster.GetSpecRevision(ctx, client, "my-cluster", "default", 1)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Spec).To(Equal(revision.Spec))
+}
+
+func TestCreateSpecRevisionPrunesOldRevisions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient(
+		specRevision("my-cluster", "default", 1),
+		specRevision("my-cluster", "default", 2),
+		specRevision("my-cluster", "default", 3),
+	)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+	})
+
+	revision, err := cluster.CreateSpecRevision(ctx, client, spec, []byte("cluster config"), 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision.Spec.Revision).To(Equal(4))
+
+	revisions, err := cluster.ListSpecRevisions(ctx, client, "my-cluster", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revisions).To(HaveLen(2))
+	g.Expect(revisions[0].Spec.Revision).To(Equal(3))
+	g.Expect(revisions[1].Spec.Revision).To(Equal(4))
+}
@@ -103,6 +103,16 @@ func NewSpec(config *Config, bundles *v1alpha1.Bundles, eksdReleases []eksdv1alp
 	s.VersionsBundles = vb
 	s.EKSARelease = eksaRelease
 
+	if s.Cluster.Spec.FIPS {
+		for _, vb := range s.VersionsBundles {
+			vb.UseFIPSImages()
+		}
+	}
+
+	if rootBundle := s.RootVersionsBundle(); rootBundle != nil && s.Cluster.Spec.ComponentImageOverrides != nil {
+		applyComponentImageOverrides(rootBundle.KubeDistro, s.Cluster.Spec.ComponentImageOverrides)
+	}
+
 	// Get first aws iam config if it exists
 	// Config supports multiple configs because Cluster references a slice
 	// But we validate that only one of each type is referenced
@@ -135,7 +145,7 @@ func getAllVersionsBundles(cluster *eksav1alpha1.Cluster, bundles *v1alpha1.Bund
 			continue
 		}
 
-		versionBundle, err := getVersionBundles(version, bundles, &eksd)
+		versionBundle, err := getVersionBundles(version, bundles, &eksd, cluster.Spec.PinImageDigests)
 		if err != nil {
 			return nil, err
 		}
@@ -146,17 +156,23 @@ func getAllVersionsBundles(cluster *eksav1alpha1.Cluster, bundles *v1alpha1.Bund
 	return m, nil
 }
 
-func getVersionBundles(version eksav1alpha1.KubernetesVersion, b *v1alpha1.Bundles, eksdRelease *eksdv1alpha1.Release) (*VersionsBundle, error) {
+func getVersionBundles(version eksav1alpha1.KubernetesVersion, b *v1alpha1.Bundles, eksdRelease *eksdv1alpha1.Release, pinDigests bool) (*VersionsBundle, error) {
 	v, err := GetVersionsBundle(version, b)
 	if err != nil {
 		return nil, err
 	}
 
-	kd, err := buildKubeDistro(eksdRelease)
+	kd, err := buildKubeDistro(eksdRelease, pinDigests)
 	if err != nil {
 		return nil, err
 	}
 
+	if pinDigests {
+		if unpinned := v.PinImageDigests(); len(unpinned) > 0 {
+			return nil, fmt.Errorf("pinning image digests: bundle for kubernetes %s doesn't include the digest of %s", version, strings.Join(unpinned, ", "))
+		}
+	}
+
 	vb := &VersionsBundle{
 		VersionsBundle: v,
 		KubeDistro:     kd,
@@ -188,7 +204,9 @@ func (s *Spec) WorkerNodeGroupVersionsBundle(w eksav1alpha1.WorkerNodeGroupConfi
 	return s.RootVersionsBundle()
 }
 
-func buildKubeDistro(eksd *eksdv1alpha1.Release) (*KubeDistro, error) {
+// buildKubeDistro reads the eks-d images from an eks-d release. When pinDigests is true, the image
+// references include the digest from the release.
+func buildKubeDistro(eksd *eksdv1alpha1.Release, pinDigests bool) (*KubeDistro, error) {
 	kubeDistro := &KubeDistro{
 		EKSD: EKSD{
 			Channel: eksd.Spec.Channel,
@@ -233,6 +251,13 @@ func buildKubeDistro(eksd *eksdv1alpha1.Release) (*KubeDistro, error) {
 		}
 
 		image.URI = i.URI
+		if pinDigests {
+			if i.ImageDigest == "" {
+				return nil, fmt.Errorf("pinning image digests: asset %s in eksd release %s doesn't include a digest", assetName, eksd.Spec.Channel)
+			}
+			image.URI = i.URI + "@" + i.ImageDigest
+			image.ImageDigest = i.ImageDigest
+		}
 	}
 
 	kubeDistroRepositories := map[string]*VersionedRepository{
@@ -248,6 +273,14 @@ func buildKubeDistro(eksd *eksdv1alpha1.Release) (*KubeDistro, error) {
 		}
 
 		image.Repository, image.Tag = kubeDistroRepository(i)
+		// kubeadm builds the image references from the repository and the tag, so the digest is kept
+		// with the tag like in the component image overrides.
+		if pinDigests {
+			if i.ImageDigest == "" {
+				return nil, fmt.Errorf("pinning image digests: asset %s in eksd release %s doesn't include a digest", assetName, eksd.Spec.Channel)
+			}
+			image.Tag = image.Tag + "@" + i.ImageDigest
+		}
 	}
 
 	return kubeDistro, nil
@@ -266,6 +299,38 @@ func kubeDistroRepository(image *eksdv1alpha1.AssetImage) (repo, tag string) {
 	return i.Image()[:lastInd], i.Tag()
 }
 
+// applyComponentImageOverrides replaces the core component images from the eks-d release with
+// the ones in the cluster componentImageOverrides. They only apply to the cluster root Kubernetes
+// version, since the images are built for a specific Kubernetes version.
+func applyComponentImageOverrides(kubeDistro *KubeDistro, overrides *eksav1alpha1.ComponentImageOverrides) {
+	if overrides.CoreDNS != "" {
+		kubeDistro.CoreDNS.Repository, kubeDistro.CoreDNS.Tag = overrideRepository(overrides.CoreDNS)
+	}
+	if overrides.Etcd != "" {
+		kubeDistro.Etcd.Repository, kubeDistro.Etcd.Tag = overrideRepository(overrides.Etcd)
+		kubeDistro.EtcdImage.URI = overrides.Etcd
+	}
+	if overrides.KubeProxy != "" {
+		kubeDistro.KubeProxy.URI = overrides.KubeProxy
+	}
+}
+
+// overrideRepository splits an image override in the repository and tag kubeadm expects, where the
+// repository doesn't include the image name and the tag keeps the digest, if any.
+func overrideRepository(image string) (repo, tag string) {
+	ref, digest, hasDigest := strings.Cut(image, "@")
+	lastInd := strings.LastIndex(ref, "/")
+	_, tag, _ = strings.Cut(ref[lastInd+1:], ":")
+	if hasDigest {
+		tag = tag + "@" + digest
+	}
+	if lastInd == -1 {
+		return "", tag
+	}
+
+	return ref[:lastInd], tag
+}
+
 func (vb *VersionsBundle) Ovas() []v1alpha1.Archive {
 	return vb.VersionsBundle.Ovas()
 }
@@ -2,6 +2,7 @@ package cluster_test
 
 import (
 	"embed"
+	"strings"
 	"testing"
 
 	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
@@ -120,6 +121,187 @@ func TestNewSpecValid(t *testing.T) {
 	g.Expect(spec.OIDCConfig).NotTo(BeNil())
 }
 
+func TestNewSpecComponentImageOverrides(t *testing.T) {
+	g := NewWithT(t)
+	version := test.DevEksaVersion()
+	kube119 := anywherev1.KubernetesVersion("1.19")
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: kube119,
+				EksaVersion:       &version,
+				ComponentImageOverrides: &anywherev1.ComponentImageOverrides{
+					CoreDNS:   "mirror.local:5000/eks-distro/coredns/coredns:v1.8.0-eks-1-19-4-patched",
+					KubeProxy: "mirror.local:5000/eks-distro/kubernetes/kube-proxy:v1.19.8-eks-1-19-4-patched",
+					Etcd:      "mirror.local:5000/eks-distro/etcd-io/etcd:v3.4.14-eks-1-19-4@sha256:0123456789abcdef",
+				},
+			},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 2,
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.19",
+				},
+			},
+		},
+	}
+	eksd := []eksdv1.Release{
+		*test.EksdRelease("1-19"),
+	}
+
+	spec, err := cluster.NewSpec(config, bundles, eksd, test.EKSARelease())
+	g.Expect(err).NotTo(HaveOccurred())
+	kubeDistro := spec.RootVersionsBundle().KubeDistro
+	g.Expect(kubeDistro.CoreDNS).To(Equal(cluster.VersionedRepository{
+		Repository: "mirror.local:5000/eks-distro/coredns",
+		Tag:        "v1.8.0-eks-1-19-4-patched",
+	}))
+	g.Expect(kubeDistro.Etcd).To(Equal(cluster.VersionedRepository{
+		Repository: "mirror.local:5000/eks-distro/etcd-io",
+		Tag:        "v3.4.14-eks-1-19-4@sha256:0123456789abcdef",
+	}))
+	g.Expect(kubeDistro.EtcdImage.URI).To(Equal("mirror.local:5000/eks-distro/etcd-io/etcd:v3.4.14-eks-1-19-4@sha256:0123456789abcdef"))
+	g.Expect(kubeDistro.KubeProxy.URI).To(Equal("mirror.local:5000/eks-distro/kubernetes/kube-proxy:v1.19.8-eks-1-19-4-patched"))
+}
+
+func TestNewSpecFIPS(t *testing.T) {
+	g := NewWithT(t)
+	version := test.DevEksaVersion()
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube119,
+				EksaVersion:       &version,
+				FIPS:              true,
+			},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 2,
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.19",
+					Eksa: releasev1.EksaBundle{
+						ClusterController: releasev1.Image{
+							URI:         "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0",
+							FIPSURI:     "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0-fips",
+							ImageDigest: "sha256:0123456789abcdef",
+						},
+						CliTools: releasev1.Image{
+							URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.18.0",
+						},
+					},
+				},
+			},
+		},
+	}
+	eksd := []eksdv1.Release{
+		*test.EksdRelease("1-19"),
+	}
+
+	spec, err := cluster.NewSpec(config, bundles, eksd, test.EKSARelease())
+	g.Expect(err).NotTo(HaveOccurred())
+	eksa := spec.RootVersionsBundle().Eksa
+	g.Expect(eksa.ClusterController.URI).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0-fips"))
+	g.Expect(eksa.ClusterController.ImageDigest).To(BeEmpty())
+	g.Expect(eksa.CliTools.URI).To(Equal("public.ecr.aws/eks-anywhere/cli-tools:v0.18.0"))
+	g.Expect(bundles.Spec.VersionsBundles[0].Eksa.ClusterController.URI).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0"))
+}
+
+func pinnableEksdRelease(channel string) *eksdv1.Release {
+	eksd := test.EksdRelease(channel)
+	for _, c := range eksd.Status.Components {
+		for _, a := range c.Assets {
+			if a.Image != nil {
+				a.Image.URI = "public.ecr.aws/eks-distro/kubernetes/" + strings.TrimSuffix(a.Name, "-image") + ":v1.19.8-eks-1-19-4"
+				a.Image.ImageDigest = "sha256:" + a.Name
+			}
+		}
+	}
+	return eksd
+}
+
+func TestNewSpecPinImageDigests(t *testing.T) {
+	g := NewWithT(t)
+	version := test.DevEksaVersion()
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube119,
+				EksaVersion:       &version,
+				PinImageDigests:   true,
+			},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 2,
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.19",
+					Eksa: releasev1.EksaBundle{
+						ClusterController: releasev1.Image{
+							URI:         "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0",
+							ImageDigest: "sha256:0123456789abcdef",
+						},
+					},
+				},
+			},
+		},
+	}
+	eksd := []eksdv1.Release{*pinnableEksdRelease("1-19")}
+
+	spec, err := cluster.NewSpec(config, bundles, eksd, test.EKSARelease())
+	g.Expect(err).NotTo(HaveOccurred())
+	vb := spec.RootVersionsBundle()
+	g.Expect(vb.Eksa.ClusterController.VersionedImage()).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0@sha256:0123456789abcdef"))
+	g.Expect(vb.KubeDistro.KubeProxy.VersionedImage()).To(Equal("public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.19.8-eks-1-19-4@sha256:kube-proxy-image"))
+	g.Expect(vb.KubeDistro.CoreDNS).To(Equal(cluster.VersionedRepository{
+		Repository: "public.ecr.aws/eks-distro/kubernetes",
+		Tag:        "v1.19.8-eks-1-19-4@sha256:coredns-image",
+	}))
+	g.Expect(bundles.Spec.VersionsBundles[0].Eksa.ClusterController.URI).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0"))
+}
+
+func TestNewSpecPinImageDigestsMissingDigest(t *testing.T) {
+	g := NewWithT(t)
+	version := test.DevEksaVersion()
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube119,
+				EksaVersion:       &version,
+				PinImageDigests:   true,
+			},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 2,
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.19",
+					Eksa: releasev1.EksaBundle{
+						CliTools: releasev1.Image{
+							URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.18.0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := cluster.NewSpec(config, bundles, []eksdv1.Release{*pinnableEksdRelease("1-19")}, test.EKSARelease())
+	g.Expect(err).To(MatchError("pinning image digests: bundle for kubernetes 1.19 doesn't include the digest of public.ecr.aws/eks-anywhere/cli-tools:v0.18.0"))
+
+	_, err = cluster.NewSpec(config, bundles, []eksdv1.Release{*test.EksdRelease("1-19")}, test.EKSARelease())
+	g.Expect(err).To(MatchError(ContainSubstring("pinning image digests: asset")))
+}
+
 func TestSpecDeepCopy(t *testing.T) {
 	g := NewWithT(t)
 	r := files.NewReader()
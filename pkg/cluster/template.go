@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+)
+
+// templateFuncs is the subset of the sprig functions available in cluster config templates.
+// Functions that access the system or generate random values are left out so rendering the
+// same template with the same values always produces the same config.
+var templateFuncs = []string{
+	"default", "empty", "coalesce", "ternary",
+	"upper", "lower", "title", "trim", "trimPrefix", "trimSuffix", "replace", "contains",
+	"hasPrefix", "hasSuffix", "quote", "squote", "indent", "nindent", "b64enc", "b64dec",
+	"toString", "atoi", "int", "add", "sub", "mul", "div", "list", "join", "split", "splitList",
+}
+
+var (
+	envVarRegex        = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+	documentSeparator  = regexp.MustCompile(`(?m)^---[ \t]*$`)
+	tinkerbellTemplate = regexp.MustCompile(`(?m)^kind:[ \t]*TinkerbellTemplateConfig[ \t]*$`)
+)
+
+type templateData struct {
+	Values map[string]string
+	Env    map[string]string
+}
+
+// RenderConfigTemplate renders a cluster config template. The content is first executed as a
+// go template with access to the values as .Values and the environment variables as .Env,
+// then ${VAR} and ${VAR:-default} references are replaced with the value of VAR or, if it's
+// not in values, the environment variable VAR. Use $${ to write a literal ${.
+// TinkerbellTemplateConfig documents are left untouched since they contain their own templates.
+func RenderConfigTemplate(content []byte, values map[string]string) ([]byte, error) {
+	environ := os.Environ()
+	data := templateData{
+		Values: values,
+		Env:    make(map[string]string, len(environ)),
+	}
+	for _, e := range environ {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			data.Env[k] = v
+		}
+	}
+
+	return renderTemplate(content, data)
+}
+
+// IsConfigTemplate returns true if content has go template actions or ${VAR} references, so it
+// has to be rendered with RenderConfigTemplate before it's used, even without template values.
+func IsConfigTemplate(content []byte) bool {
+	return bytes.Contains(content, []byte("{{")) || bytes.Contains(content, []byte("${"))
+}
+
+// RenderProfileTemplate renders the template of a ClusterProfile with the values of an instance.
+// It behaves like RenderConfigTemplate but without access to the environment variables, so
+// templates created through the API can't read the environment of the controller.
+func RenderProfileTemplate(content []byte, values map[string]string) ([]byte, error) {
+	return renderTemplate(content, templateData{Values: values, Env: map[string]string{}})
+}
+
+func renderTemplate(content []byte, data templateData) ([]byte, error) {
+	funcs := template.FuncMap{}
+	sprigFuncs := sprig.TxtFuncMap()
+	for _, name := range templateFuncs {
+		funcs[name] = sprigFuncs[name]
+	}
+
+	separators := documentSeparator.FindAllIndex(content, -1)
+	rendered := make([]byte, 0, len(content))
+	start := 0
+	for i := 0; i <= len(separators); i++ {
+		end := len(content)
+		if i < len(separators) {
+			end = separators[i][0]
+		}
+
+		doc, err := renderDocument(content[start:end], data, funcs)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, doc...)
+
+		if i < len(separators) {
+			rendered = append(rendered, content[separators[i][0]:separators[i][1]]...)
+			start = separators[i][1]
+		}
+	}
+
+	return rendered, nil
+}
+
+func renderDocument(doc []byte, data templateData, funcs template.FuncMap) ([]byte, error) {
+	if tinkerbellTemplate.Match(doc) {
+		return doc, nil
+	}
+
+	t, err := template.New("cluster-config").Option("missingkey=error").Funcs(funcs).Parse(string(doc))
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering cluster config template: %v", err)
+	}
+
+	var missing []string
+	out := envVarRegex.ReplaceAllFunc(buf.Bytes(), func(match []byte) []byte {
+		if string(match) == "$${" {
+			return []byte("${")
+		}
+
+		groups := envVarRegex.FindSubmatch(match)
+		name := string(groups[1])
+		if v, ok := data.Values[name]; ok {
+			return []byte(v)
+		}
+		if v, ok := data.Env[name]; ok {
+			return []byte(v)
+		}
+		if len(groups[2]) > 0 {
+			return groups[3]
+		}
+
+		missing = append(missing, name)
+		return match
+	})
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("rendering cluster config template: variables not set: %s", strings.Join(missing, ", "))
+	}
+
+	return out, nil
+}
+
+// ParseTemplateValues parses a list of key=value pairs into the values for
+// RenderConfigTemplate.
+func ParseTemplateValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid template value %q, must be in the form key=value", p)
+		}
+		values[k] = v
+	}
+
+	return values, nil
+}
@@ -0,0 +1,153 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func TestRenderConfigTemplate(t *testing.T) {
+	t.Setenv("TEMPLATE_TEST_ENDPOINT", "1.2.3.4")
+	t.Setenv("TEMPLATE_TEST_PORT", "6443")
+	tests := []struct {
+		name    string
+		content string
+		values  map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no template",
+			content: "kind: Cluster\nmetadata:\n  name: test\n",
+			want:    "kind: Cluster\nmetadata:\n  name: test\n",
+		},
+		{
+			name:    "variables from values and env",
+			content: "name: ${name}\nhost: ${TEMPLATE_TEST_ENDPOINT}\n",
+			values:  map[string]string{"name": "prod"},
+			want:    "name: prod\nhost: 1.2.3.4\n",
+		},
+		{
+			name:    "variables from env without values",
+			content: "host: ${TEMPLATE_TEST_ENDPOINT}\nport: {{ .Env.TEMPLATE_TEST_PORT }}\n",
+			want:    "host: 1.2.3.4\nport: 6443\n",
+		},
+		{
+			name:    "values take precedence over env",
+			content: "host: ${TEMPLATE_TEST_ENDPOINT}\n",
+			values:  map[string]string{"TEMPLATE_TEST_ENDPOINT": "5.6.7.8"},
+			want:    "host: 5.6.7.8\n",
+		},
+		{
+			name:    "default values",
+			content: "count: ${TEMPLATE_TEST_COUNT:-3}\nempty: \"${TEMPLATE_TEST_EMPTY:-}\"\n",
+			want:    "count: 3\nempty: \"\"\n",
+		},
+		{
+			name:    "escaped variable",
+			content: "script: echo $${HOME}\n",
+			want:    "script: echo ${HOME}\n",
+		},
+		{
+			name:    "go template with functions",
+			content: "name: {{ .Values.env | upper }}-cluster\nhost: {{ .Env.TEMPLATE_TEST_ENDPOINT | quote }}\ncount: {{ .Values.count | default \"1\" }}\n",
+			values:  map[string]string{"env": "dev", "count": ""},
+			want:    "name: DEV-cluster\nhost: \"1.2.3.4\"\ncount: 1\n",
+		},
+		{
+			name:    "tinkerbell templates are not rendered",
+			content: "kind: Cluster\nname: ${name}\n---\nkind: TinkerbellTemplateConfig\nspec:\n  worker: \"{{.device_1}}\"\n  script: ${name}\n",
+			values:  map[string]string{"name": "prod"},
+			want:    "kind: Cluster\nname: prod\n---\nkind: TinkerbellTemplateConfig\nspec:\n  worker: \"{{.device_1}}\"\n  script: ${name}\n",
+		},
+		{
+			name:    "missing variable",
+			content: "name: ${TEMPLATE_TEST_MISSING}\n",
+			wantErr: "variables not set: TEMPLATE_TEST_MISSING",
+		},
+		{
+			name:    "missing template value",
+			content: "name: {{ .Values.missing }}\n",
+			values:  map[string]string{},
+			wantErr: "rendering cluster config template",
+		},
+		{
+			name:    "invalid template",
+			content: "name: {{ .Values.name\n",
+			wantErr: "parsing cluster config template",
+		},
+		{
+			name:    "functions outside the allowed subset",
+			content: "name: {{ env \"HOME\" }}\n",
+			wantErr: "parsing cluster config template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := cluster.RenderConfigTemplate([]byte(tt.content), tt.values)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(got)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRenderProfileTemplate(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("TEMPLATE_TEST_ENDPOINT", "1.2.3.4")
+	content := "name: {{ .Values.clusterName }}\nhost: ${host}\n"
+
+	got, err := cluster.RenderProfileTemplate([]byte(content), map[string]string{"clusterName": "edge-1", "host": "5.6.7.8"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(got)).To(Equal("name: edge-1\nhost: 5.6.7.8\n"))
+}
+
+func TestRenderProfileTemplateNoEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("TEMPLATE_TEST_ENDPOINT", "1.2.3.4")
+
+	_, err := cluster.RenderProfileTemplate([]byte("host: ${TEMPLATE_TEST_ENDPOINT}\n"), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("variables not set: TEMPLATE_TEST_ENDPOINT")))
+
+	_, err = cluster.RenderProfileTemplate([]byte("host: {{ .Env.TEMPLATE_TEST_ENDPOINT }}\n"), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("map has no entry for key")))
+}
+
+func TestParseTemplateValues(t *testing.T) {
+	g := NewWithT(t)
+	values, err := cluster.ParseTemplateValues([]string{"name=prod", "labels=a=b", "empty="})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(values).To(Equal(map[string]string{"name": "prod", "labels": "a=b", "empty": ""}))
+}
+
+func TestParseTemplateValuesInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := cluster.ParseTemplateValues([]string{"name"})
+	g.Expect(err).To(MatchError("invalid template value \"name\", must be in the form key=value"))
+}
+
+func TestIsConfigTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "plain config", content: "kind: Cluster\nmetadata:\n  name: test\n", want: false},
+		{name: "env variable", content: "host: ${ENDPOINT}\n", want: true},
+		{name: "escaped variable", content: "script: $${HOME}\n", want: true},
+		{name: "go template", content: "name: {{ .Values.name }}\n", want: true},
+		{name: "dollar without brace", content: "password: pa$$word\n", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(cluster.IsConfigTemplate([]byte(tt.content))).To(Equal(tt.want))
+		})
+	}
+}
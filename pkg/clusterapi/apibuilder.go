@@ -2,6 +2,7 @@ package clusterapi
 
 import (
 	"fmt"
+	"strings"
 
 	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -15,7 +16,6 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 )
 
 const (
@@ -26,6 +26,7 @@ const (
 	machineDeploymentKind     = "MachineDeployment"
 	EKSAClusterLabelName      = "cluster.anywhere.eks.amazonaws.com/cluster-name"
 	EKSAClusterLabelNamespace = "cluster.anywhere.eks.amazonaws.com/cluster-namespace"
+	kubeProxyAddonPhase       = "addon/kube-proxy"
 )
 
 var (
@@ -124,6 +125,16 @@ func Cluster(clusterSpec *cluster.Spec, infrastructureObject, controlPlaneObject
 	return cluster
 }
 
+// ControlPlaneCertSANs returns the extra SANs of the API server certificate. It has the name of the DNS
+// record of the control plane endpoint, so the API server can be reached through it.
+func ControlPlaneCertSANs(cluster *anywherev1.Cluster) []string {
+	endpoint := cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.DNS == nil {
+		return nil
+	}
+	return []string{strings.TrimSuffix(endpoint.DNS.Name, ".")}
+}
+
 func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObject) (*controlplanev1.KubeadmControlPlane, error) {
 	replicas := int32(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count)
 	bundle := clusterSpec.RootVersionsBundle()
@@ -156,9 +167,10 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 					},
 					APIServer: bootstrapv1.APIServer{
 						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
-							ExtraArgs:    map[string]string{},
+							ExtraArgs:    CustomTLSExtraArgs(clusterSpec.Cluster).Append(LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)),
 							ExtraVolumes: []bootstrapv1.HostPathMount{},
 						},
+						CertSANs: ControlPlaneCertSANs(clusterSpec.Cluster),
 					},
 					ControllerManager: bootstrapv1.ControlPlaneComponent{
 						ExtraArgs:    ControllerManagerArgs(clusterSpec),
@@ -171,14 +183,14 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 				},
 				InitConfiguration: &bootstrapv1.InitConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
-						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
+						KubeletExtraArgs: TLSExtraArgs(clusterSpec.Cluster).
 							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
 						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 					},
 				},
 				JoinConfiguration: &bootstrapv1.JoinConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
-						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
+						KubeletExtraArgs: TLSExtraArgs(clusterSpec.Cluster).
 							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
 						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 					},
@@ -194,13 +206,31 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 
 	SetIdentityAuthInKubeadmControlPlane(kcp, clusterSpec)
 
+	if err := SetPodSecurityAdmissionInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission); err != nil {
+		return nil, err
+	}
+
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration == nil {
-		setStackedEtcdConfigInKubeadmControlPlane(kcp, bundle.KubeDistro.Etcd)
+		setStackedEtcdConfigInKubeadmControlPlane(kcp, bundle.KubeDistro.Etcd, clusterSpec.Cluster)
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		SkipKubeProxyInKubeadmControlPlane(kcp)
 	}
 
 	return kcp, nil
 }
 
+// SkipKubeProxyInKubeadmControlPlane configures the KubeadmControlPlane to not install kube-proxy,
+// for clusters where the CNI replaces it. KCP also stops updating the kube-proxy image on upgrades.
+func SkipKubeProxyInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane) {
+	if kcp.Annotations == nil {
+		kcp.Annotations = map[string]string{}
+	}
+	kcp.Annotations[controlplanev1.SkipKubeProxyAnnotation] = ""
+	kcp.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = append(kcp.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases, kubeProxyAddonPhase)
+}
+
 func KubeadmConfigTemplate(clusterSpec *cluster.Spec, workerNodeGroupConfig anywherev1.WorkerNodeGroupConfiguration) (*bootstrapv1.KubeadmConfigTemplate, error) {
 	kct := &bootstrapv1.KubeadmConfigTemplate{
 		TypeMeta: metav1.TypeMeta{
@@ -289,6 +319,10 @@ func MachineDeployment(clusterSpec *cluster.Spec, workerNodeGroupConfig anywhere
 		},
 	}
 
+	if workerNodeGroupConfig.IsSpot() {
+		md.Spec.Template.ObjectMeta.Labels[anywherev1.CapacityTypeLabelName] = string(anywherev1.SpotCapacity)
+	}
+
 	ConfigureAutoscalingInMachineDeployment(md, workerNodeGroupConfig.AutoScalingConfiguration)
 
 	return md
@@ -310,7 +344,7 @@ func EtcdadmCluster(clusterSpec *cluster.Spec, infrastructureTemplate APIObject)
 			Replicas: &replicas,
 			EtcdadmConfigSpec: etcdbootstrapv1.EtcdadmConfigSpec{
 				EtcdadmBuiltin:     true,
-				CipherSuites:       crypto.SecureCipherSuitesString(),
+				CipherSuites:       EtcdCipherSuites(clusterSpec.Cluster),
 				PreEtcdadmCommands: []string{},
 			},
 			InfrastructureTemplate: v1.ObjectReference{
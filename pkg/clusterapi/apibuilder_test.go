@@ -373,6 +373,32 @@ func TestKubeadmControlPlane(t *testing.T) {
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestKubeadmControlPlaneKubeProxyReplacement(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+		Cilium: &anywherev1.CiliumConfig{KubeProxyReplacement: true},
+	}
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	want := wantKubeadmControlPlane()
+	want.Annotations = map[string]string{"controlplane.cluster.x-k8s.io/skip-kube-proxy": ""}
+	want.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = []string{"addon/kube-proxy"}
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestKubeadmControlPlaneEndpointDNS(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS = &anywherev1.EndpointDNS{
+		Name:    "api.prod.example.com.",
+		Route53: &anywherev1.Route53DNS{HostedZoneID: "Z0123456789"},
+	}
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	want := wantKubeadmControlPlane()
+	want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"api.prod.example.com"}
+	tt.Expect(got).To(Equal(want))
+}
+
 func wantKubeadmConfigTemplate() *bootstrapv1.KubeadmConfigTemplate {
 	return &bootstrapv1.KubeadmConfigTemplate{
 		TypeMeta: metav1.TypeMeta{
@@ -485,6 +511,15 @@ func TestMachineDeployment(t *testing.T) {
 	tt.Expect(got).To(BeComparableTo(wantMachineDeployment()))
 }
 
+func TestMachineDeploymentSpotCapacity(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.workerNodeGroupConfig.CapacityType = anywherev1.SpotCapacity
+	want := wantMachineDeployment()
+	want.Spec.Template.ObjectMeta.Labels["anywhere.eks.amazonaws.com/capacity-type"] = "spot"
+	got := clusterapi.MachineDeployment(tt.clusterSpec, *tt.workerNodeGroupConfig, tt.kubeadmConfigTemplate, tt.providerMachineTemplate)
+	tt.Expect(got).To(BeComparableTo(want))
+}
+
 func TestClusterName(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,87 @@
+package clusterapi
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ReplacedMachineDeploymentAnnotation is set on the MachineDeployment that replaces another one
+	// of a blue/green worker node group. Its value is the name of the replaced MachineDeployment.
+	ReplacedMachineDeploymentAnnotation = "anywhere.eks.amazonaws.com/replaced-machine-deployment"
+
+	blueGreenMachineDeploymentSuffix = "-green"
+)
+
+// BlueGreenMachineDeploymentNames returns the two names the MachineDeployment of a blue/green
+// worker node group alternates between, one per replacement.
+func BlueGreenMachineDeploymentNames(cluster *v1alpha1.Cluster, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) (blue, green string) {
+	blue = MachineDeploymentName(cluster, workerNodeGroupConfig)
+	return blue, blue + blueGreenMachineDeploymentSuffix
+}
+
+// IsReplacing returns true if md is replacing another MachineDeployment of a blue/green worker node group.
+func IsReplacing(md *clusterv1.MachineDeployment) bool {
+	_, ok := md.Annotations[ReplacedMachineDeploymentAnnotation]
+	return ok
+}
+
+// workerMachineDeployments returns the MachineDeployments of a worker node group in the cluster.
+// Blue/green worker node groups have two while they are replaced, the replacing one goes first.
+func workerMachineDeployments(ctx context.Context, kubeclient KubeClient, cluster *v1alpha1.Cluster, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) ([]*clusterv1.MachineDeployment, error) {
+	names := []string{MachineDeploymentName(cluster, workerNodeGroupConfig)}
+	if workerNodeGroupConfig.IsBlueGreen() {
+		_, green := BlueGreenMachineDeploymentNames(cluster, workerNodeGroupConfig)
+		names = append(names, green)
+	}
+
+	var mds []*clusterv1.MachineDeployment
+	for _, name := range names {
+		md := &clusterv1.MachineDeployment{}
+		err := kubeclient.Get(ctx, name, constants.EksaSystemNamespace, md)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if IsReplacing(md) {
+			mds = append([]*clusterv1.MachineDeployment{md}, mds...)
+		} else {
+			mds = append(mds, md)
+		}
+	}
+
+	return mds, nil
+}
+
+// machineDeploymentNamesInCluster returns the names of the MachineDeployments of a worker node group
+// in the cluster, the replacing one first. It defaults to MachineDeploymentName when there are none,
+// so callers reading them get a not found error.
+func machineDeploymentNamesInCluster(ctx context.Context, kubeclient KubeClient, cluster *v1alpha1.Cluster, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) ([]string, error) {
+	if !workerNodeGroupConfig.IsBlueGreen() {
+		return []string{MachineDeploymentName(cluster, workerNodeGroupConfig)}, nil
+	}
+
+	mds, err := workerMachineDeployments(ctx, kubeclient, cluster, workerNodeGroupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mds) == 0 {
+		return []string{MachineDeploymentName(cluster, workerNodeGroupConfig)}, nil
+	}
+
+	names := make([]string, 0, len(mds))
+	for _, md := range mds {
+		names = append(names, md.Name)
+	}
+
+	return names, nil
+}
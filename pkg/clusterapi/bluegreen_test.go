@@ -0,0 +1,84 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestBlueGreenMachineDeploymentNames(t *testing.T) {
+	tt := newRolloutTest(t)
+
+	blue, green := clusterapi.BlueGreenMachineDeploymentNames(tt.cluster, tt.cluster.Spec.WorkerNodeGroupConfigurations[0])
+	tt.Expect(blue).To(Equal("my-cluster-md-0"))
+	tt.Expect(green).To(Equal("my-cluster-md-0-green"))
+}
+
+func TestMachineDeploymentInClusterBlueGreenReplacing(t *testing.T) {
+	tt := newBlueGreenTest(t)
+
+	got, err := clusterapi.MachineDeploymentInCluster(tt.ctx, tt.client, &cluster.Spec{Config: &cluster.Config{Cluster: tt.cluster}}, tt.cluster.Spec.WorkerNodeGroupConfigurations[0])
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(Equal("my-cluster-md-0"))
+}
+
+func TestMachineDeploymentInClusterBlueGreenOnlyGreen(t *testing.T) {
+	tt := newRolloutTest(t)
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].ReplacementStrategy = v1alpha1.BlueGreenReplacement
+	tt.client = test.NewFakeKubeClient(&clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-green", Namespace: constants.EksaSystemNamespace},
+	})
+
+	got, err := clusterapi.MachineDeploymentInCluster(tt.ctx, tt.client, &cluster.Spec{Config: &cluster.Config{Cluster: tt.cluster}}, tt.cluster.Spec.WorkerNodeGroupConfigurations[0])
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(Equal("my-cluster-md-0-green"))
+}
+
+func TestScaleWorkerNodeGroupBlueGreenReplacing(t *testing.T) {
+	tt := newBlueGreenTest(t)
+
+	tt.Expect(clusterapi.ScaleWorkerNodeGroup(tt.ctx, tt.client, tt.cluster, tt.cluster.Spec.WorkerNodeGroupConfigurations[0], 3)).To(Succeed())
+	tt.Expect(*tt.machineDeployment("my-cluster-md-0").Spec.Replicas).To(BeEquivalentTo(3))
+	tt.Expect(tt.machineDeployment("my-cluster-md-0-green").Spec.Replicas).To(BeNil())
+}
+
+func TestSetRolloutsPausedBlueGreenReplacing(t *testing.T) {
+	tt := newBlueGreenTest(t)
+
+	tt.Expect(clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, []string{"md-0"}, true)).To(Succeed())
+	tt.Expect(tt.machineDeployment("my-cluster-md-0").Spec.Paused).To(BeTrue())
+	tt.Expect(tt.machineDeployment("my-cluster-md-0-green").Spec.Paused).To(BeTrue())
+}
+
+// newBlueGreenTest returns a rolloutTest where the blue/green worker node group md-0 is being
+// replaced, with my-cluster-md-0 replacing my-cluster-md-0-green.
+func newBlueGreenTest(t *testing.T) rolloutTest {
+	tt := newRolloutTest(t)
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].ReplacementStrategy = v1alpha1.BlueGreenReplacement
+	tt.client = test.NewFakeKubeClient(
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-green", Namespace: constants.EksaSystemNamespace},
+		},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster-md-0",
+				Namespace: constants.EksaSystemNamespace,
+				Annotations: map[string]string{
+					clusterapi.ReplacedMachineDeploymentAnnotation: "my-cluster-md-0-green",
+				},
+			},
+			Spec: clusterv1.MachineDeploymentSpec{Replicas: ptr.Int32(1)},
+		},
+	)
+
+	return tt
+}
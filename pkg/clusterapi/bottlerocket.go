@@ -15,7 +15,7 @@ func bottlerocketBootstrap(image v1alpha1.Image) bootstrapv1.BottlerocketBootstr
 	return bootstrapv1.BottlerocketBootstrap{
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: image.Image(),
-			ImageTag:        image.Tag(),
+			ImageTag:        image.PinnedTag(),
 		},
 	}
 }
@@ -24,7 +24,7 @@ func bottlerocketAdmin(image v1alpha1.Image) bootstrapv1.BottlerocketAdmin {
 	return bootstrapv1.BottlerocketAdmin{
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: image.Image(),
-			ImageTag:        image.Tag(),
+			ImageTag:        image.PinnedTag(),
 		},
 	}
 }
@@ -33,7 +33,7 @@ func bottlerocketControl(image v1alpha1.Image) bootstrapv1.BottlerocketControl {
 	return bootstrapv1.BottlerocketControl{
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: image.Image(),
-			ImageTag:        image.Tag(),
+			ImageTag:        image.PinnedTag(),
 		},
 	}
 }
@@ -42,7 +42,7 @@ func pause(image v1alpha1.Image) bootstrapv1.Pause {
 	return bootstrapv1.Pause{
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: image.Image(),
-			ImageTag:        image.Tag(),
+			ImageTag:        image.PinnedTag(),
 		},
 	}
 }
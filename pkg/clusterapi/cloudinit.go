@@ -0,0 +1,75 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// HostOSKubeadmConfigSpec returns the files and the commands added to the kubeadm config of the machines
+// for the host OS configuration: the host firewall followed by the cloud-init user data.
+func HostOSKubeadmConfigSpec(hostOSConfig *v1alpha1.HostOSConfiguration) (*bootstrapv1.KubeadmConfigSpec, error) {
+	files, err := HostFirewallFiles(hostOSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kcs := &bootstrapv1.KubeadmConfigSpec{
+		Files:              files,
+		PreKubeadmCommands: HostFirewallCommands(hostOSConfig),
+	}
+	if err := mergeCloudInitUserData(kcs, hostOSConfig); err != nil {
+		return nil, err
+	}
+
+	return kcs, nil
+}
+
+// SetCloudInitUserDataInKubeadmControlPlane merges the cloud-init user data of the host OS configuration in kubeadmControlPlane.
+func SetCloudInitUserDataInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, hostOSConfig *v1alpha1.HostOSConfiguration) error {
+	return mergeCloudInitUserData(&kcp.Spec.KubeadmConfigSpec, hostOSConfig)
+}
+
+// SetCloudInitUserDataInKubeadmConfigTemplate merges the cloud-init user data of the host OS configuration in kubeadmConfigTemplate.
+func SetCloudInitUserDataInKubeadmConfigTemplate(kct *bootstrapv1.KubeadmConfigTemplate, hostOSConfig *v1alpha1.HostOSConfiguration) error {
+	return mergeCloudInitUserData(&kct.Spec.Template.Spec, hostOSConfig)
+}
+
+// mergeCloudInitUserData appends the files and commands of the cloud-init user data to the kubeadm config.
+// The files can't replace the files already in the kubeadm config.
+func mergeCloudInitUserData(kcs *bootstrapv1.KubeadmConfigSpec, hostOSConfig *v1alpha1.HostOSConfiguration) error {
+	userData, err := hostOSConfig.GetCloudInitUserData()
+	if err != nil {
+		return err
+	}
+	if userData == nil {
+		return nil
+	}
+
+	paths := make(map[string]struct{}, len(kcs.Files))
+	for _, f := range kcs.Files {
+		paths[f.Path] = struct{}{}
+	}
+
+	for _, f := range userData.WriteFiles {
+		if _, ok := paths[f.Path]; ok {
+			return fmt.Errorf("cloudInitUserData file %s conflicts with a file generated by EKS Anywhere", f.Path)
+		}
+
+		kcs.Files = append(kcs.Files, bootstrapv1.File{
+			Path:        f.Path,
+			Owner:       f.Owner,
+			Permissions: f.Permissions,
+			Encoding:    bootstrapv1.Encoding(f.Encoding),
+			Content:     f.Content,
+		})
+	}
+
+	kcs.PreKubeadmCommands = append(kcs.PreKubeadmCommands, userData.BootCmd...)
+	kcs.PostKubeadmCommands = append(kcs.PostKubeadmCommands, userData.RunCmd...)
+
+	return nil
+}
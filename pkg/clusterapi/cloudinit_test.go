@@ -0,0 +1,91 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+var cloudInitUserData = `#cloud-config
+write_files:
+- path: /etc/agent/config.yaml
+  owner: root:root
+  permissions: "0600"
+  content: |
+    endpoint: agent.local
+bootcmd:
+- mkdir -p /var/lib/agent
+runcmd:
+- /usr/local/bin/install-agent.sh
+`
+
+var cloudInitFiles = []bootstrapv1.File{
+	{
+		Path:        "/etc/agent/config.yaml",
+		Owner:       "root:root",
+		Permissions: "0600",
+		Content:     "endpoint: agent.local\n",
+	},
+}
+
+func TestHostOSKubeadmConfigSpec(t *testing.T) {
+	g := NewWithT(t)
+	hostOSConfig := hostFirewallConfig.DeepCopy()
+	hostOSConfig.CloudInitUserData = cloudInitUserData
+
+	got, err := clusterapi.HostOSKubeadmConfigSpec(hostOSConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&bootstrapv1.KubeadmConfigSpec{
+		Files:               append(append([]bootstrapv1.File{}, hostFirewallFiles...), cloudInitFiles...),
+		PreKubeadmCommands:  append(append([]string{}, enableHostFirewallCommands...), "mkdir -p /var/lib/agent"),
+		PostKubeadmCommands: []string{"/usr/local/bin/install-agent.sh"},
+	}))
+}
+
+func TestHostOSKubeadmConfigSpecEmpty(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.HostOSKubeadmConfigSpec(nil)).To(Equal(&bootstrapv1.KubeadmConfigSpec{}))
+}
+
+func TestHostOSKubeadmConfigSpecInvalidUserData(t *testing.T) {
+	g := NewWithT(t)
+	_, err := clusterapi.HostOSKubeadmConfigSpec(&v1alpha1.HostOSConfiguration{CloudInitUserData: "packages: [curl]"})
+	g.Expect(err).To(MatchError(ContainSubstring("parsing cloudInitUserData")))
+}
+
+func TestSetCloudInitUserDataInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmControlPlane()
+	want := wantKubeadmControlPlane()
+	want.Spec.KubeadmConfigSpec.Files = append(want.Spec.KubeadmConfigSpec.Files, cloudInitFiles...)
+	want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, "mkdir -p /var/lib/agent")
+	want.Spec.KubeadmConfigSpec.PostKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PostKubeadmCommands, "/usr/local/bin/install-agent.sh")
+
+	g.Expect(clusterapi.SetCloudInitUserDataInKubeadmControlPlane(got, &v1alpha1.HostOSConfiguration{CloudInitUserData: cloudInitUserData})).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
+
+func TestSetCloudInitUserDataInKubeadmControlPlaneConflict(t *testing.T) {
+	g := NewWithT(t)
+	kcp := wantKubeadmControlPlane()
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{Path: "/etc/agent/config.yaml"})
+
+	err := clusterapi.SetCloudInitUserDataInKubeadmControlPlane(kcp, &v1alpha1.HostOSConfiguration{CloudInitUserData: cloudInitUserData})
+	g.Expect(err).To(MatchError("cloudInitUserData file /etc/agent/config.yaml conflicts with a file generated by EKS Anywhere"))
+}
+
+func TestSetCloudInitUserDataInKubeadmConfigTemplate(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmConfigTemplate()
+	want := wantKubeadmConfigTemplate()
+	want.Spec.Template.Spec.Files = append(want.Spec.Template.Spec.Files, cloudInitFiles...)
+	want.Spec.Template.Spec.PreKubeadmCommands = append(want.Spec.Template.Spec.PreKubeadmCommands, "mkdir -p /var/lib/agent")
+	want.Spec.Template.Spec.PostKubeadmCommands = append(want.Spec.Template.Spec.PostKubeadmCommands, "/usr/local/bin/install-agent.sh")
+
+	g.Expect(clusterapi.SetCloudInitUserDataInKubeadmConfigTemplate(got, &v1alpha1.HostOSConfiguration{CloudInitUserData: cloudInitUserData})).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
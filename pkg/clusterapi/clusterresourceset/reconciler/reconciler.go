@@ -0,0 +1,76 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	addons "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+)
+
+// Reconciler allows to reconcile the ClusterResourceSets a management cluster defines for its
+// workload clusters.
+type Reconciler struct {
+	client client.Client
+}
+
+// New returns a new Reconciler.
+func New(client client.Client) *Reconciler {
+	return &Reconciler{
+		client: client,
+	}
+}
+
+// Reconcile applies the ClusterResourceSets of a management cluster and deletes the ones that
+// have been removed from its spec. Workload clusters can't define ClusterResourceSets, so they
+// are skipped.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if cluster.IsManaged() {
+		return nil
+	}
+
+	sets := clusterapi.WorkloadClusterResourceSets(cluster)
+	if len(sets) > 0 {
+		log.Info("Applying cluster resource sets for workload clusters", "cluster", cluster.Name)
+		if err := serverside.ReconcileObjects(ctx, r.client, clientutil.ObjectsToClientObjects(sets)); err != nil {
+			return fmt.Errorf("applying cluster resource sets: %v", err)
+		}
+	}
+
+	existing := &addons.ClusterResourceSetList{}
+	if err := r.client.List(ctx, existing,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterapi.WorkloadResourceSetClusterLabel: cluster.Name},
+	); err != nil {
+		return fmt.Errorf("listing cluster resource sets: %v", err)
+	}
+
+	desired := make(map[string]struct{}, len(sets))
+	for _, s := range sets {
+		desired[s.Name] = struct{}{}
+	}
+
+	for i := range existing.Items {
+		s := &existing.Items[i]
+		if _, ok := desired[s.Name]; ok {
+			continue
+		}
+
+		// Cluster API doesn't remove the resources it already applied to the workload clusters,
+		// it only stops applying them to new ones.
+		log.Info("Deleting cluster resource set removed from the cluster spec", "name", s.Name)
+		if err := r.client.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting cluster resource set %s: %v", s.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	addons "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterapi/clusterresourceset/reconciler"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestReconcilerReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := managementCluster()
+	// The fake client can't create objects with server side apply, so the set is updated.
+	storage := clusterResourceSet("mgmt-storage", map[string]string{clusterapi.WorkloadResourceSetClusterLabel: "mgmt"})
+	stale := clusterResourceSet("mgmt-old", map[string]string{clusterapi.WorkloadResourceSetClusterLabel: "mgmt"})
+	unrelated := clusterResourceSet("mgmt-crs-0", nil)
+	c := newFakeClient(g, storage, stale, unrelated)
+
+	g.Expect(reconciler.New(c).Reconcile(ctx, nullLog(), cluster)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(storage), storage)).To(Succeed())
+	g.Expect(storage.Labels).To(HaveKeyWithValue(clusterapi.WorkloadResourceSetClusterLabel, "mgmt"))
+	g.Expect(storage.Spec.Resources).To(Equal([]addons.ResourceRef{
+		{Name: "storage-classes", Kind: "ConfigMap"},
+		{Name: "priority-classes", Kind: "ConfigMap"},
+	}))
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "mgmt-old"}, &addons.ClusterResourceSet{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(unrelated), &addons.ClusterResourceSet{})).To(Succeed())
+}
+
+func TestReconcilerReconcileNoClusterResourceSets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := managementCluster()
+	cluster.Spec.ClusterResourceSets = nil
+	stale := clusterResourceSet("mgmt-storage", map[string]string{clusterapi.WorkloadResourceSetClusterLabel: "mgmt"})
+	c := newFakeClient(g, stale)
+
+	g.Expect(reconciler.New(c).Reconcile(ctx, nullLog(), cluster)).To(Succeed())
+
+	list := &addons.ClusterResourceSetList{}
+	g.Expect(c.List(ctx, list)).To(Succeed())
+	g.Expect(list.Items).To(BeEmpty())
+}
+
+func TestReconcilerReconcileWorkloadCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := managementCluster()
+	cluster.Spec.ManagementCluster.Name = "other-mgmt"
+	c := newFakeClient(g)
+
+	g.Expect(reconciler.New(c).Reconcile(ctx, nullLog(), cluster)).To(Succeed())
+
+	list := &addons.ClusterResourceSetList{}
+	g.Expect(c.List(ctx, list)).To(Succeed())
+	g.Expect(list.Items).To(BeEmpty())
+}
+
+func managementCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mgmt",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster: anywherev1.ManagementCluster{
+				Name: "mgmt",
+			},
+			ClusterResourceSets: []anywherev1.ClusterResourceSet{
+				{
+					Name:       "storage",
+					ConfigMaps: []string{"storage-classes", "priority-classes"},
+				},
+			},
+		},
+	}
+}
+
+func clusterResourceSet(name string, labels map[string]string) *addons.ClusterResourceSet {
+	return &addons.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: addons.ClusterResourceSetSpec{
+			Resources: []addons.ResourceRef{{Name: "cm", Kind: "ConfigMap"}},
+		},
+	}
+}
+
+func newFakeClient(g *WithT, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(addons.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
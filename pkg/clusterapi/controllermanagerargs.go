@@ -5,6 +5,7 @@ import (
 )
 
 func ControllerManagerArgs(clusterSpec *cluster.Spec) ExtraArgs {
-	return SecureTlsCipherSuitesExtraArgs().
-		Append(NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	return TLSExtraArgs(clusterSpec.Cluster).
+		Append(NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(LargeClusterControllerManagerExtraArgs(clusterSpec.Cluster))
 }
@@ -62,12 +62,12 @@ func SetUnstackedEtcdConfigInKubeadmControlPlaneForUbuntu(kcp *controlplanev1.Ku
 }
 
 // setStackedEtcdConfigInKubeadmControlPlane sets up stacked etcd configuration in kubeadmControlPlane.
-func setStackedEtcdConfigInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, etcd cluster.VersionedRepository) {
+func setStackedEtcdConfigInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, etcd cluster.VersionedRepository, eksaCluster *v1alpha1.Cluster) {
 	kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local = &bootstrapv1.LocalEtcd{
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: etcd.Repository,
 			ImageTag:        etcd.Tag,
 		},
-		ExtraArgs: SecureEtcdTlsCipherSuitesExtraArgs(),
+		ExtraArgs: EtcdTLSExtraArgs(eksaCluster).Append(LargeClusterEtcdExtraArgs(eksaCluster)),
 	}
 }
@@ -82,6 +82,16 @@ func NodeCIDRMaskExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
 	return args
 }
 
+// NodePortRangeExtraArgs returns the kube-apiserver args to set the NodePort range configured in the host OS configuration.
+func NodePortRangeExtraArgs(hostOSConfig *v1alpha1.HostOSConfiguration) ExtraArgs {
+	if hostOSConfig == nil || hostOSConfig.FirewallConfiguration == nil {
+		return nil
+	}
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("service-node-port-range", hostOSConfig.FirewallConfiguration.NodePortRange)
+	return args
+}
+
 func ResolvConfExtraArgs(resolvConf *v1alpha1.ResolvConf) ExtraArgs {
 	if resolvConf == nil {
 		return nil
@@ -104,8 +114,75 @@ func SecureEtcdTlsCipherSuitesExtraArgs() ExtraArgs {
 	return args
 }
 
+// TLSExtraArgs returns the TLS args for the API server, the controller manager and the kubelet.
+// The cipher suites are not set for TLS 1.3, since the Kubernetes components reject them.
+func TLSExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	minVersion := TLSMinVersion(cluster)
+	if minVersion != string(v1alpha1.TLSVersion13) {
+		args.AddIfNotEmpty("tls-cipher-suites", TLSCipherSuites(cluster))
+	}
+	args.AddIfNotEmpty("tls-min-version", minVersion)
+	return args
+}
+
+// CustomTLSExtraArgs returns the TLS args for components that don't restrict TLS by default, like
+// the API server of some providers. It's empty unless the cluster configures TLS or runs in FIPS mode.
+func CustomTLSExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	if !cluster.Spec.FIPS && cluster.Spec.TLSConfiguration == nil {
+		return ExtraArgs{}
+	}
+	return TLSExtraArgs(cluster)
+}
+
+// EtcdTLSExtraArgs returns the TLS args for stacked etcd.
+func EtcdTLSExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("cipher-suites", EtcdCipherSuites(cluster))
+	return args
+}
+
+// EtcdCipherSuites returns the cipher suites allowed by etcd as a comma separated list.
+// They are set even for TLS 1.3 since etcd accepts TLS 1.2 connections.
+func EtcdCipherSuites(cluster *v1alpha1.Cluster) string {
+	return TLSCipherSuites(cluster)
+}
+
+// TLSCipherSuites returns the cipher suites allowed by the cluster components as a comma separated list:
+// the ones in the cluster TLS configuration, the FIPS approved ones in FIPS mode or the secure defaults.
+func TLSCipherSuites(cluster *v1alpha1.Cluster) string {
+	if config := cluster.Spec.TLSConfiguration; config != nil && len(config.CipherSuites) > 0 {
+		return strings.Join(config.CipherSuites, ",")
+	}
+	if cluster.Spec.FIPS {
+		return crypto.FIPSCipherSuitesString()
+	}
+	return crypto.SecureCipherSuitesString()
+}
+
+// TLSMinVersion returns the minimum TLS version accepted by the cluster components. It's empty
+// when the cluster doesn't set one, so the components default is used.
+func TLSMinVersion(cluster *v1alpha1.Cluster) string {
+	if config := cluster.Spec.TLSConfiguration; config != nil && config.MinVersion != "" {
+		return string(config.MinVersion)
+	}
+	if cluster.Spec.FIPS {
+		return crypto.FIPSMinTLSVersion
+	}
+	return ""
+}
+
 func WorkerNodeLabelsExtraArgs(wnc v1alpha1.WorkerNodeGroupConfiguration) ExtraArgs {
-	return nodeLabelsExtraArgs(wnc.Labels)
+	if !wnc.IsSpot() {
+		return nodeLabelsExtraArgs(wnc.Labels)
+	}
+
+	labels := make(map[string]string, len(wnc.Labels)+1)
+	for k, v := range wnc.Labels {
+		labels[k] = v
+	}
+	labels[v1alpha1.CapacityTypeLabelName] = string(v1alpha1.SpotCapacity)
+	return nodeLabelsExtraArgs(labels)
 }
 
 func ControlPlaneNodeLabelsExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
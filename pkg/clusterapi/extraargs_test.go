@@ -310,6 +310,161 @@ func TestSecureEtcdTlsCipherSuitesExtraArgs(t *testing.T) {
 	}
 }
 
+func TestTLSExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		fips     bool
+		config   *v1alpha1.TLSConfiguration
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "default",
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": crypto.SecureCipherSuitesString(),
+			},
+		},
+		{
+			testName: "fips enabled",
+			fips:     true,
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": crypto.FIPSCipherSuitesString(),
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+		{
+			testName: "tls configuration",
+			config: &v1alpha1.TLSConfiguration{
+				CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+				MinVersion:   v1alpha1.TLSVersion12,
+			},
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+		{
+			testName: "tls configuration only min version",
+			config: &v1alpha1.TLSConfiguration{
+				MinVersion: v1alpha1.TLSVersion12,
+			},
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": crypto.SecureCipherSuitesString(),
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+		{
+			testName: "tls 1.3",
+			fips:     true,
+			config: &v1alpha1.TLSConfiguration{
+				MinVersion: v1alpha1.TLSVersion13,
+			},
+			want: clusterapi.ExtraArgs{
+				"tls-min-version": "VersionTLS13",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{FIPS: tt.fips, TLSConfiguration: tt.config}}
+			if got := clusterapi.TLSExtraArgs(cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TLSExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomTLSExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		fips     bool
+		config   *v1alpha1.TLSConfiguration
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "default",
+			want:     clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "fips enabled",
+			fips:     true,
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": crypto.FIPSCipherSuitesString(),
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+		{
+			testName: "tls configuration",
+			config: &v1alpha1.TLSConfiguration{
+				MinVersion: v1alpha1.TLSVersion13,
+			},
+			want: clusterapi.ExtraArgs{
+				"tls-min-version": "VersionTLS13",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{FIPS: tt.fips, TLSConfiguration: tt.config}}
+			if got := clusterapi.CustomTLSExtraArgs(cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CustomTLSExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEtcdTLSExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		fips     bool
+		config   *v1alpha1.TLSConfiguration
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "default",
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": crypto.SecureCipherSuitesString(),
+			},
+		},
+		{
+			testName: "fips enabled",
+			fips:     true,
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": crypto.FIPSCipherSuitesString(),
+			},
+		},
+		{
+			testName: "tls configuration",
+			fips:     true,
+			config: &v1alpha1.TLSConfiguration{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			},
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			},
+		},
+		{
+			testName: "tls 1.3",
+			config: &v1alpha1.TLSConfiguration{
+				MinVersion: v1alpha1.TLSVersion13,
+			},
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": crypto.SecureCipherSuitesString(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{FIPS: tt.fips, TLSConfiguration: tt.config}}
+			if got := clusterapi.EtcdTLSExtraArgs(cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EtcdTLSExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCgroupDriverCgroupfsExtraArgs(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -377,6 +532,17 @@ func TestNodeLabelsExtraArgs(t *testing.T) {
 				"node-labels": "label1=foo,label2=bar",
 			},
 		},
+		{
+			testName: "spot capacity",
+			wnc: v1alpha1.WorkerNodeGroupConfiguration{
+				Count:        ptr.Int(3),
+				Labels:       map[string]string{"label1": "foo"},
+				CapacityType: v1alpha1.SpotCapacity,
+			},
+			want: clusterapi.ExtraArgs{
+				"node-labels": "anywhere.eks.amazonaws.com/capacity-type=spot,label1=foo",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -472,6 +638,49 @@ func TestAppend(t *testing.T) {
 	}
 }
 
+func TestNodePortRangeExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName     string
+		hostOSConfig *v1alpha1.HostOSConfiguration
+		want         clusterapi.ExtraArgs
+	}{
+		{
+			testName:     "no host os config",
+			hostOSConfig: nil,
+			want:         nil,
+		},
+		{
+			testName:     "no firewall config",
+			hostOSConfig: &v1alpha1.HostOSConfiguration{},
+			want:         nil,
+		},
+		{
+			testName: "no node port range",
+			hostOSConfig: &v1alpha1.HostOSConfiguration{
+				FirewallConfiguration: &v1alpha1.FirewallConfiguration{},
+			},
+			want: clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "with node port range",
+			hostOSConfig: &v1alpha1.HostOSConfiguration{
+				FirewallConfiguration: &v1alpha1.FirewallConfiguration{NodePortRange: "31000-31999"},
+			},
+			want: clusterapi.ExtraArgs{
+				"service-node-port-range": "31000-31999",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.NodePortRangeExtraArgs(tt.hostOSConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NodePortRangeExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 	nodeCidrMaskSize := new(int)
 	*nodeCidrMaskSize = 28
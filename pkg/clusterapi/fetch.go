@@ -18,16 +18,17 @@ type KubeClient interface {
 	Get(ctx context.Context, name, namespace string, obj kubernetes.Object) error
 }
 
+// MachineDeploymentInCluster returns the MachineDeployment of a worker node group, or nil if it doesn't exist.
+// For blue/green worker node groups being replaced, it returns the replacing one.
 func MachineDeploymentInCluster(ctx context.Context, kubeclient KubeClient, clusterSpec *cluster.Spec, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) (*clusterv1.MachineDeployment, error) {
-	md := &clusterv1.MachineDeployment{}
-	err := kubeclient.Get(ctx, MachineDeploymentName(clusterSpec.Cluster, workerNodeGroupConfig), constants.EksaSystemNamespace, md)
-	if apierrors.IsNotFound(err) {
-		return nil, nil
-	}
+	mds, err := workerMachineDeployments(ctx, kubeclient, clusterSpec.Cluster, workerNodeGroupConfig)
 	if err != nil {
 		return nil, err
 	}
-	return md, nil
+	if len(mds) == 0 {
+		return nil, nil
+	}
+	return mds[0], nil
 }
 
 func KubeadmConfigTemplateInCluster(ctx context.Context, kubeclient KubeClient, md *clusterv1.MachineDeployment) (*bootstrapv1.KubeadmConfigTemplate, error) {
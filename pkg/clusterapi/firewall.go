@@ -0,0 +1,118 @@
+package clusterapi
+
+import (
+	_ "embed"
+	"fmt"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const (
+	hostFirewallTable       = "eksa_host_firewall"
+	hostFirewallRulesetPath = "/etc/eks-anywhere/host-firewall.nft"
+	hostFirewallUnit        = "eksa-host-firewall.service"
+)
+
+//go:embed config/host-firewall.nft
+var hostFirewallRuleset string
+
+//go:embed config/host-firewall.service
+var hostFirewallService string
+
+var enableHostFirewallCommands = []string{
+	"systemctl daemon-reload",
+	"systemctl enable --now " + hostFirewallUnit,
+}
+
+// HostFirewallFiles returns the nftables ruleset and the systemd unit that applies it on boot
+// for the firewall rules in the host OS configuration. It returns nil if there are no rules.
+func HostFirewallFiles(hostOSConfig *v1alpha1.HostOSConfiguration) ([]bootstrapv1.File, error) {
+	if hostOSConfig == nil || hostOSConfig.FirewallConfiguration == nil || len(hostOSConfig.FirewallConfiguration.Rules) == 0 {
+		return nil, nil
+	}
+
+	rules, err := hostOSConfig.FirewallConfiguration.RenderRules()
+	if err != nil {
+		return nil, fmt.Errorf("rendering host firewall rules: %v", err)
+	}
+
+	val := values{
+		"table":       hostFirewallTable,
+		"rules":       rules,
+		"rulesetPath": hostFirewallRulesetPath,
+	}
+
+	ruleset, err := templater.Execute(hostFirewallRuleset, val)
+	if err != nil {
+		return nil, fmt.Errorf("building host firewall ruleset: %v", err)
+	}
+
+	service, err := templater.Execute(hostFirewallService, val)
+	if err != nil {
+		return nil, fmt.Errorf("building host firewall systemd unit: %v", err)
+	}
+
+	return []bootstrapv1.File{
+		{
+			Path:        hostFirewallRulesetPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     string(ruleset),
+		},
+		{
+			Path:    "/etc/systemd/system/" + hostFirewallUnit,
+			Owner:   "root:root",
+			Content: string(service),
+		},
+	}, nil
+}
+
+// HostFirewallCommands returns the prekubeadm commands that enable the host firewall.
+// It returns nil if there are no firewall rules in the host OS configuration.
+func HostFirewallCommands(hostOSConfig *v1alpha1.HostOSConfiguration) []string {
+	if hostOSConfig == nil || hostOSConfig.FirewallConfiguration == nil || len(hostOSConfig.FirewallConfiguration.Rules) == 0 {
+		return nil
+	}
+
+	return enableHostFirewallCommands
+}
+
+// SetHostFirewallInKubeadmControlPlane adds the host firewall files and commands in kubeadmControlPlane
+// and sets the NodePort range in the kube-apiserver.
+func SetHostFirewallInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, hostOSConfig *v1alpha1.HostOSConfiguration) error {
+	if hostOSConfig == nil || hostOSConfig.FirewallConfiguration == nil {
+		return nil
+	}
+
+	apiServerExtraArgs := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs
+	for k, v := range NodePortRangeExtraArgs(hostOSConfig) {
+		apiServerExtraArgs[k] = v
+	}
+
+	files, err := HostFirewallFiles(hostOSConfig)
+	if err != nil {
+		return err
+	}
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, files...)
+	kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands, HostFirewallCommands(hostOSConfig)...)
+
+	return nil
+}
+
+// SetHostFirewallInKubeadmConfigTemplate adds the host firewall files and commands in kubeadmConfigTemplate.
+func SetHostFirewallInKubeadmConfigTemplate(kct *bootstrapv1.KubeadmConfigTemplate, hostOSConfig *v1alpha1.HostOSConfiguration) error {
+	files, err := HostFirewallFiles(hostOSConfig)
+	if err != nil {
+		return err
+	}
+
+	kct.Spec.Template.Spec.Files = append(kct.Spec.Template.Spec.Files, files...)
+	kct.Spec.Template.Spec.PreKubeadmCommands = append(kct.Spec.Template.Spec.PreKubeadmCommands, HostFirewallCommands(hostOSConfig)...)
+
+	return nil
+}
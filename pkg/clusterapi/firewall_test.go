@@ -0,0 +1,120 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+var hostFirewallConfig = &v1alpha1.HostOSConfiguration{
+	FirewallConfiguration: &v1alpha1.FirewallConfiguration{
+		NodePortRange: "31000-31999",
+		Rules: []string{
+			"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop",
+			"tcp dport 10250 ip saddr 10.0.0.0/8 accept",
+		},
+	},
+}
+
+var hostFirewallFiles = []bootstrapv1.File{
+	{
+		Path:        "/etc/eks-anywhere/host-firewall.nft",
+		Owner:       "root:root",
+		Permissions: "0600",
+		Content: `#!/usr/sbin/nft -f
+table inet eksa_host_firewall
+delete table inet eksa_host_firewall
+table inet eksa_host_firewall {
+  chain input {
+    type filter hook input priority filter; policy accept;
+    tcp dport 31000-31999 ip saddr != 10.0.0.0/8 drop
+    tcp dport 10250 ip saddr 10.0.0.0/8 accept
+  }
+}
+`,
+	},
+	{
+		Path:  "/etc/systemd/system/eksa-host-firewall.service",
+		Owner: "root:root",
+		Content: `[Unit]
+Description=EKS Anywhere host firewall
+Wants=network-pre.target
+Before=network-pre.target kubelet.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/usr/sbin/nft -f /etc/eks-anywhere/host-firewall.nft
+ExecStop=/usr/sbin/nft delete table inet eksa_host_firewall
+
+[Install]
+WantedBy=multi-user.target
+`,
+	},
+}
+
+var enableHostFirewallCommands = []string{
+	"systemctl daemon-reload",
+	"systemctl enable --now eksa-host-firewall.service",
+}
+
+func TestHostFirewallFiles(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.HostFirewallFiles(hostFirewallConfig)).To(Equal(hostFirewallFiles))
+}
+
+func TestHostFirewallFilesNoRules(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.HostOSConfiguration{
+		FirewallConfiguration: &v1alpha1.FirewallConfiguration{NodePortRange: "31000-31999"},
+	}
+	g.Expect(clusterapi.HostFirewallFiles(config)).To(BeEmpty())
+	g.Expect(clusterapi.HostFirewallCommands(config)).To(BeEmpty())
+	g.Expect(clusterapi.HostFirewallFiles(nil)).To(BeEmpty())
+	g.Expect(clusterapi.HostFirewallCommands(nil)).To(BeEmpty())
+}
+
+func TestHostFirewallFilesInvalidRule(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.HostOSConfiguration{
+		FirewallConfiguration: &v1alpha1.FirewallConfiguration{
+			Rules: []string{"tcp dport {{ .Ports }} drop"},
+		},
+	}
+	_, err := clusterapi.HostFirewallFiles(config)
+	g.Expect(err).To(MatchError(ContainSubstring("rendering host firewall rules")))
+}
+
+func TestSetHostFirewallInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmControlPlane()
+	want := wantKubeadmControlPlane()
+	want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs["service-node-port-range"] = "31000-31999"
+	want.Spec.KubeadmConfigSpec.Files = append(want.Spec.KubeadmConfigSpec.Files, hostFirewallFiles...)
+	want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, enableHostFirewallCommands...)
+
+	g.Expect(clusterapi.SetHostFirewallInKubeadmControlPlane(got, hostFirewallConfig)).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
+
+func TestSetHostFirewallInKubeadmControlPlaneNoConfig(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmControlPlane()
+	g.Expect(clusterapi.SetHostFirewallInKubeadmControlPlane(got, &v1alpha1.HostOSConfiguration{})).To(Succeed())
+	g.Expect(got).To(Equal(wantKubeadmControlPlane()))
+}
+
+func TestSetHostFirewallInKubeadmConfigTemplate(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmConfigTemplate()
+	want := wantKubeadmConfigTemplate()
+	want.Spec.Template.Spec.Files = append(want.Spec.Template.Spec.Files, hostFirewallFiles...)
+	want.Spec.Template.Spec.PreKubeadmCommands = append(want.Spec.Template.Spec.PreKubeadmCommands, enableHostFirewallCommands...)
+
+	g.Expect(clusterapi.SetHostFirewallInKubeadmConfigTemplate(got, hostFirewallConfig)).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
@@ -2,6 +2,7 @@ package clusterapi
 
 import (
 	"fmt"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -9,12 +10,36 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
+// KubeVipLeaderElection is the leader election config, in seconds, of the kube-vip instances
+// competing for the control plane endpoint.
+type KubeVipLeaderElection struct {
+	LeaseDuration int
+	RenewDeadline int
+	RetryPeriod   int
+}
+
+var (
+	defaultKubeVipLeaderElection = KubeVipLeaderElection{LeaseDuration: 15, RenewDeadline: 10, RetryPeriod: 2}
+	// Large clusters renew the lease less often, so a busy API server doesn't make kube-vip
+	// lose the lease and move the endpoint between control plane nodes.
+	largeClusterKubeVipLeaderElection = KubeVipLeaderElection{LeaseDuration: 30, RenewDeadline: 20, RetryPeriod: 4}
+)
+
+// KubeVipLeaderElectionForCluster returns the kube-vip leader election config of a cluster.
+func KubeVipLeaderElectionForCluster(cluster *v1alpha1.Cluster) KubeVipLeaderElection {
+	if cluster.IsLargeScale() {
+		return largeClusterKubeVipLeaderElection
+	}
+	return defaultKubeVipLeaderElection
+}
+
 // SetKubeVipInKubeadmControlPlane appends kube-vip manifest to kubeadmControlPlane's kubeadmConfigSpec files.
-func SetKubeVipInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, address, image string) error {
-	b, err := yaml.Marshal(kubeVip(address, image))
+func SetKubeVipInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, address, image string, leaderElection KubeVipLeaderElection) error {
+	b, err := yaml.Marshal(kubeVip(address, image, leaderElection))
 	if err != nil {
 		return fmt.Errorf("marshalling kube-vip pod: %v", err)
 	}
@@ -28,7 +53,7 @@ func SetKubeVipInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, ad
 	return nil
 }
 
-func kubeVip(address, image string) *corev1.Pod {
+func kubeVip(address, image string, leaderElection KubeVipLeaderElection) *corev1.Pod {
 	return &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -75,15 +100,15 @@ func kubeVip(address, image string) *corev1.Pod {
 						},
 						{
 							Name:  "vip_leaseduration",
-							Value: "15",
+							Value: strconv.Itoa(leaderElection.LeaseDuration),
 						},
 						{
 							Name:  "vip_renewdeadline",
-							Value: "10",
+							Value: strconv.Itoa(leaderElection.RenewDeadline),
 						},
 						{
 							Name:  "vip_retryperiod",
-							Value: "2",
+							Value: strconv.Itoa(leaderElection.RetryPeriod),
 						},
 						{
 							Name:  "address",
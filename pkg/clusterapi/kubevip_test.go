@@ -22,6 +22,6 @@ func TestSetKubeVipInKubeadmControlPlane(t *testing.T) {
 		},
 	}
 
-	g.Expect(clusterapi.SetKubeVipInKubeadmControlPlane(got, g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.1433")).To(Succeed())
+	g.Expect(clusterapi.SetKubeVipInKubeadmControlPlane(got, g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.1433", clusterapi.KubeVipLeaderElectionForCluster(g.clusterSpec.Cluster))).To(Succeed())
 	g.Expect(got).To(Equal(want))
 }
@@ -0,0 +1,49 @@
+package clusterapi
+
+import (
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// The large cluster defaults double the upstream API server inflight requests and controller manager
+// client rate limits, and raise the etcd quota from 2GiB to 8GiB, so clusters with hundreds of nodes
+// don't get their requests throttled and don't run out of etcd space.
+const (
+	largeClusterMaxRequestsInflight         = "800"
+	largeClusterMaxMutatingRequestsInflight = "400"
+	largeClusterEtcdQuotaBackendBytes       = "8589934592"
+	largeClusterKubeAPIQPS                  = "100"
+	largeClusterKubeAPIBurst                = "200"
+)
+
+// LargeClusterAPIServerExtraArgs returns the API server args of large clusters. It's empty for other clusters.
+func LargeClusterAPIServerExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	if !cluster.IsLargeScale() {
+		return args
+	}
+	args.AddIfNotEmpty("max-requests-inflight", largeClusterMaxRequestsInflight)
+	args.AddIfNotEmpty("max-mutating-requests-inflight", largeClusterMaxMutatingRequestsInflight)
+	return args
+}
+
+// LargeClusterEtcdExtraArgs returns the stacked etcd args of large clusters. It's empty for other clusters.
+func LargeClusterEtcdExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	if !cluster.IsLargeScale() {
+		return args
+	}
+	args.AddIfNotEmpty("quota-backend-bytes", largeClusterEtcdQuotaBackendBytes)
+	return args
+}
+
+// LargeClusterControllerManagerExtraArgs returns the controller manager args of large clusters. It's empty
+// for other clusters.
+func LargeClusterControllerManagerExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	args := ExtraArgs{}
+	if !cluster.IsLargeScale() {
+		return args
+	}
+	args.AddIfNotEmpty("kube-api-qps", largeClusterKubeAPIQPS)
+	args.AddIfNotEmpty("kube-api-burst", largeClusterKubeAPIBurst)
+	return args
+}
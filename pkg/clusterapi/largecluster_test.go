@@ -0,0 +1,61 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestLargeClusterExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName                  string
+		scale                     v1alpha1.ClusterScale
+		wantAPIServer             clusterapi.ExtraArgs
+		wantEtcd                  clusterapi.ExtraArgs
+		wantControllerManager     clusterapi.ExtraArgs
+		wantKubeVipLeaderElection clusterapi.KubeVipLeaderElection
+	}{
+		{
+			testName:                  "standard cluster",
+			wantAPIServer:             clusterapi.ExtraArgs{},
+			wantEtcd:                  clusterapi.ExtraArgs{},
+			wantControllerManager:     clusterapi.ExtraArgs{},
+			wantKubeVipLeaderElection: clusterapi.KubeVipLeaderElection{LeaseDuration: 15, RenewDeadline: 10, RetryPeriod: 2},
+		},
+		{
+			testName: "large cluster",
+			scale:    v1alpha1.ClusterScaleLarge,
+			wantAPIServer: clusterapi.ExtraArgs{
+				"max-requests-inflight":          "800",
+				"max-mutating-requests-inflight": "400",
+			},
+			wantEtcd: clusterapi.ExtraArgs{
+				"quota-backend-bytes": "8589934592",
+			},
+			wantControllerManager: clusterapi.ExtraArgs{
+				"kube-api-qps":   "100",
+				"kube-api-burst": "200",
+			},
+			wantKubeVipLeaderElection: clusterapi.KubeVipLeaderElection{LeaseDuration: 30, RenewDeadline: 20, RetryPeriod: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{Count: 3},
+					Scale:                     tt.scale,
+				},
+			}
+
+			g.Expect(clusterapi.LargeClusterAPIServerExtraArgs(cluster)).To(Equal(tt.wantAPIServer))
+			g.Expect(clusterapi.LargeClusterEtcdExtraArgs(cluster)).To(Equal(tt.wantEtcd))
+			g.Expect(clusterapi.LargeClusterControllerManagerExtraArgs(cluster)).To(Equal(tt.wantControllerManager))
+			g.Expect(clusterapi.KubeVipLeaderElectionForCluster(cluster)).To(Equal(tt.wantKubeVipLeaderElection))
+		})
+	}
+}
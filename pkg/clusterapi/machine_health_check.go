@@ -1,6 +1,8 @@
 package clusterapi
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -15,6 +17,10 @@ const (
 	machineHealthCheckKind   = "MachineHealthCheck"
 	maxUnhealthyControlPlane = "100%"
 	maxUnhealthyWorker       = "40%"
+	// Spot capacity is usually reclaimed in bulk, so remediation can't be blocked by the number of unhealthy machines.
+	maxUnhealthySpotWorker = "100%"
+	// spotUnhealthyMachineTimeout caps how long reclaimed spot machines are kept before being replaced.
+	spotUnhealthyMachineTimeout = 2 * time.Minute
 )
 
 func machineHealthCheck(clusterName string, unhealthyTimeout, nodeStartupTimeout *metav1.Duration) *clusterv1.MachineHealthCheck {
@@ -70,10 +76,31 @@ func MachineHealthCheckForWorkers(cluster *v1alpha1.Cluster) []*clusterv1.Machin
 }
 
 func machineHealthCheckForWorker(cluster *v1alpha1.Cluster, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration) *clusterv1.MachineHealthCheck {
-	mhc := machineHealthCheck(ClusterName(cluster), cluster.Spec.MachineHealthCheck.UnhealthyMachineTimeout, cluster.Spec.MachineHealthCheck.NodeStartupTimeout)
+	unhealthyTimeout := cluster.Spec.MachineHealthCheck.UnhealthyMachineTimeout
+	maxUnhealthyValue := maxUnhealthyWorker
+	if workerNodeGroupConfig.IsSpot() {
+		if unhealthyTimeout.Duration > spotUnhealthyMachineTimeout {
+			unhealthyTimeout = &metav1.Duration{Duration: spotUnhealthyMachineTimeout}
+		}
+		maxUnhealthyValue = maxUnhealthySpotWorker
+	}
+
+	mhc := machineHealthCheck(ClusterName(cluster), unhealthyTimeout, cluster.Spec.MachineHealthCheck.NodeStartupTimeout)
 	mhc.SetName(WorkerMachineHealthCheckName(cluster, workerNodeGroupConfig))
-	mhc.Spec.Selector.MatchLabels[clusterv1.MachineDeploymentNameLabel] = MachineDeploymentName(cluster, workerNodeGroupConfig)
-	maxUnhealthy := intstr.Parse(maxUnhealthyWorker)
+	if workerNodeGroupConfig.IsBlueGreen() {
+		// The machines of blue/green worker node groups can belong to either of their MachineDeployments.
+		blue, green := BlueGreenMachineDeploymentNames(cluster, workerNodeGroupConfig)
+		mhc.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{
+				Key:      clusterv1.MachineDeploymentNameLabel,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{blue, green},
+			},
+		}
+	} else {
+		mhc.Spec.Selector.MatchLabels[clusterv1.MachineDeploymentNameLabel] = MachineDeploymentName(cluster, workerNodeGroupConfig)
+	}
+	maxUnhealthy := intstr.Parse(maxUnhealthyValue)
 	mhc.Spec.MaxUnhealthy = &maxUnhealthy
 	return mhc
 }
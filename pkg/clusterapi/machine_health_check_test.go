@@ -133,6 +133,47 @@ func expectedMachineHealthCheckForWorkers(timeout time.Duration) []*clusterv1.Ma
 	}
 }
 
+func TestMachineHealthCheckForSpotWorkers(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.workerNodeGroupConfig.CapacityType = v1alpha1.SpotCapacity
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{*tt.workerNodeGroupConfig}
+	tt.clusterSpec.Cluster.Spec.MachineHealthCheck = &v1alpha1.MachineHealthCheck{
+		NodeStartupTimeout:      &metav1.Duration{Duration: 10 * time.Minute},
+		UnhealthyMachineTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	want := expectedMachineHealthCheckForWorkers(2 * time.Minute)
+	want[0].Spec.NodeStartupTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+	maxUnhealthy := intstr.Parse("100%")
+	want[0].Spec.MaxUnhealthy = &maxUnhealthy
+
+	got := clusterapi.MachineHealthCheckForWorkers(tt.clusterSpec.Cluster)
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestMachineHealthCheckForBlueGreenWorkers(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.workerNodeGroupConfig.ReplacementStrategy = v1alpha1.BlueGreenReplacement
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{*tt.workerNodeGroupConfig}
+	tt.clusterSpec.Cluster.Spec.MachineHealthCheck = &v1alpha1.MachineHealthCheck{
+		NodeStartupTimeout:      &metav1.Duration{Duration: 5 * time.Minute},
+		UnhealthyMachineTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	want := expectedMachineHealthCheckForWorkers(5 * time.Minute)
+	want[0].Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "cluster.x-k8s.io/deployment-name",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"test-cluster-wng-1", "test-cluster-wng-1-green"},
+			},
+		},
+	}
+
+	got := clusterapi.MachineHealthCheckForWorkers(tt.clusterSpec.Cluster)
+	tt.Expect(got).To(Equal(want))
+}
+
 func TestMachineHealthCheckObjects(t *testing.T) {
 	tt := newApiBuilerTest(t)
 	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{*tt.workerNodeGroupConfig}
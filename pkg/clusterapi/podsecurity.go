@@ -0,0 +1,187 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// The admission configuration is written in the kubeadm directory, like the aws-iam-authenticator
+	// configuration, since it's writable on every OS, and then mounted in the kube-apiserver pod.
+	admissionConfigHostDir  = "/var/lib/kubeadm/admission/"
+	admissionConfigMountDir = "/etc/kubernetes/admission/"
+	admissionConfigFileName = "admission-configuration.yaml"
+
+	defaultPodSecurityVersion = "latest"
+)
+
+var admissionConfigMount = bootstrapv1.HostPathMount{
+	Name:      "admission-config",
+	HostPath:  admissionConfigHostDir,
+	MountPath: admissionConfigMountDir,
+	ReadOnly:  true,
+	PathType:  corev1.HostPathDirectoryOrCreate,
+}
+
+// alwaysExemptNamespaces run the cluster components, which need privileged pods.
+var alwaysExemptNamespaces = []string{constants.KubeSystemNamespace, constants.EksaSystemNamespace}
+
+type admissionConfiguration struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Plugins    []admissionPluginConfig `json:"plugins"`
+}
+
+type admissionPluginConfig struct {
+	Name          string                     `json:"name"`
+	Configuration podSecurityAdmissionConfig `json:"configuration"`
+}
+
+type podSecurityAdmissionConfig struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Defaults   podSecurityDefaults   `json:"defaults"`
+	Exemptions podSecurityExemptions `json:"exemptions"`
+}
+
+type podSecurityDefaults struct {
+	Enforce        v1alpha1.PodSecurityLevel `json:"enforce"`
+	EnforceVersion string                    `json:"enforce-version"`
+	Audit          v1alpha1.PodSecurityLevel `json:"audit"`
+	AuditVersion   string                    `json:"audit-version"`
+	Warn           v1alpha1.PodSecurityLevel `json:"warn"`
+	WarnVersion    string                    `json:"warn-version"`
+}
+
+type podSecurityExemptions struct {
+	Usernames      []string `json:"usernames"`
+	RuntimeClasses []string `json:"runtimeClasses"`
+	Namespaces     []string `json:"namespaces"`
+}
+
+// PodSecurityAdmissionExtraArgs returns the kube-apiserver extra args to load the admission configuration
+// with the PodSecurity defaults, if configured.
+func PodSecurityAdmissionExtraArgs(config *v1alpha1.PodSecurityAdmissionConfiguration) ExtraArgs {
+	args := ExtraArgs{}
+	if config == nil {
+		return args
+	}
+	args.AddIfNotEmpty("admission-control-config-file", admissionConfigMountDir+admissionConfigFileName)
+	return args
+}
+
+// PodSecurityAdmissionConfiguration generates the kube-apiserver AdmissionConfiguration with the cluster
+// PodSecurity defaults. Unset levels default to privileged, same as the PodSecurity admission controller.
+// It returns an empty string when PodSecurity admission is not configured.
+func PodSecurityAdmissionConfiguration(kubeVersion v1alpha1.KubernetesVersion, config *v1alpha1.PodSecurityAdmissionConfiguration) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+
+	version := config.Version
+	if version == "" {
+		version = defaultPodSecurityVersion
+	}
+
+	podSecurityAPIVersion, err := podSecurityConfigAPIVersion(kubeVersion)
+	if err != nil {
+		return "", err
+	}
+
+	exemptNamespaces := append([]string{}, alwaysExemptNamespaces...)
+	for _, n := range config.ExemptNamespaces {
+		if !slices.Contains(exemptNamespaces, n) {
+			exemptNamespaces = append(exemptNamespaces, n)
+		}
+	}
+
+	c := admissionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "AdmissionConfiguration",
+		Plugins: []admissionPluginConfig{
+			{
+				Name: "PodSecurity",
+				Configuration: podSecurityAdmissionConfig{
+					APIVersion: podSecurityAPIVersion,
+					Kind:       "PodSecurityConfiguration",
+					Defaults: podSecurityDefaults{
+						Enforce:        levelOrPrivileged(config.Enforce),
+						EnforceVersion: version,
+						Audit:          levelOrPrivileged(config.Audit),
+						AuditVersion:   version,
+						Warn:           levelOrPrivileged(config.Warn),
+						WarnVersion:    version,
+					},
+					Exemptions: podSecurityExemptions{
+						Usernames:      []string{},
+						RuntimeClasses: []string{},
+						Namespaces:     exemptNamespaces,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("marshalling pod security admission configuration: %v", err)
+	}
+
+	return string(b), nil
+}
+
+// SetPodSecurityAdmissionInKubeadmControlPlane configures the kube-apiserver of a KubeadmControlPlane
+// with the cluster PodSecurity defaults.
+func SetPodSecurityAdmissionInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, kubeVersion v1alpha1.KubernetesVersion, config *v1alpha1.PodSecurityAdmissionConfiguration) error {
+	if config == nil {
+		return nil
+	}
+
+	content, err := PodSecurityAdmissionConfiguration(kubeVersion, config)
+	if err != nil {
+		return err
+	}
+
+	apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	for k, v := range PodSecurityAdmissionExtraArgs(config) {
+		apiServer.ExtraArgs[k] = v
+	}
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, admissionConfigMount)
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:    admissionConfigHostDir + admissionConfigFileName,
+		Owner:   "root:root",
+		Content: content,
+	})
+
+	return nil
+}
+
+// podSecurityConfigAPIVersion returns the PodSecurityConfiguration version served by the kube-apiserver.
+// v1 is only available from Kubernetes 1.25.
+func podSecurityConfigAPIVersion(kubeVersion v1alpha1.KubernetesVersion) (string, error) {
+	v, err := v1alpha1.KubeVersionToSemver(kubeVersion)
+	if err != nil {
+		return "", fmt.Errorf("parsing kubernetes version for pod security admission: %v", err)
+	}
+	v125, _ := v1alpha1.KubeVersionToSemver(v1alpha1.Kube125)
+	if v.LessThan(v125) {
+		return "pod-security.admission.config.k8s.io/v1beta1", nil
+	}
+	return "pod-security.admission.config.k8s.io/v1", nil
+}
+
+func levelOrPrivileged(level v1alpha1.PodSecurityLevel) v1alpha1.PodSecurityLevel {
+	if level == "" {
+		return v1alpha1.PodSecurityPrivileged
+	}
+	return level
+}
@@ -0,0 +1,141 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestPodSecurityAdmissionConfiguration(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeVersion v1alpha1.KubernetesVersion
+		config      *v1alpha1.PodSecurityAdmissionConfiguration
+		want        string
+	}{
+		{
+			name:        "not configured",
+			kubeVersion: v1alpha1.Kube127,
+			want:        "",
+		},
+		{
+			name:        "defaults",
+			kubeVersion: v1alpha1.Kube127,
+			config:      &v1alpha1.PodSecurityAdmissionConfiguration{},
+			want: `apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+- configuration:
+    apiVersion: pod-security.admission.config.k8s.io/v1
+    defaults:
+      audit: privileged
+      audit-version: latest
+      enforce: privileged
+      enforce-version: latest
+      warn: privileged
+      warn-version: latest
+    exemptions:
+      namespaces:
+      - kube-system
+      - eksa-system
+      runtimeClasses: []
+      usernames: []
+    kind: PodSecurityConfiguration
+  name: PodSecurity
+`,
+		},
+		{
+			name:        "kubernetes 1.24",
+			kubeVersion: v1alpha1.Kube124,
+			config: &v1alpha1.PodSecurityAdmissionConfiguration{
+				Enforce:          v1alpha1.PodSecurityBaseline,
+				Audit:            v1alpha1.PodSecurityRestricted,
+				Warn:             v1alpha1.PodSecurityRestricted,
+				Version:          "v1.24",
+				ExemptNamespaces: []string{"monitoring", "kube-system"},
+			},
+			want: `apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+- configuration:
+    apiVersion: pod-security.admission.config.k8s.io/v1beta1
+    defaults:
+      audit: restricted
+      audit-version: v1.24
+      enforce: baseline
+      enforce-version: v1.24
+      warn: restricted
+      warn-version: v1.24
+    exemptions:
+      namespaces:
+      - kube-system
+      - eksa-system
+      - monitoring
+      runtimeClasses: []
+      usernames: []
+    kind: PodSecurityConfiguration
+  name: PodSecurity
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := clusterapi.PodSecurityAdmissionConfiguration(tt.kubeVersion, tt.config)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPodSecurityAdmissionConfigurationInvalidKubeVersion(t *testing.T) {
+	g := NewWithT(t)
+	_, err := clusterapi.PodSecurityAdmissionConfiguration("1.x", &v1alpha1.PodSecurityAdmissionConfiguration{})
+	g.Expect(err).To(MatchError(ContainSubstring("parsing kubernetes version for pod security admission")))
+}
+
+func TestPodSecurityAdmissionExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.PodSecurityAdmissionExtraArgs(nil)).To(BeEmpty())
+	g.Expect(clusterapi.PodSecurityAdmissionExtraArgs(&v1alpha1.PodSecurityAdmissionConfiguration{})).To(Equal(clusterapi.ExtraArgs{
+		"admission-control-config-file": "/etc/kubernetes/admission/admission-configuration.yaml",
+	}))
+}
+
+func TestSetPodSecurityAdmissionInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	kcp := wantKubeadmControlPlane()
+	config := &v1alpha1.PodSecurityAdmissionConfiguration{Enforce: v1alpha1.PodSecurityBaseline}
+	content, err := clusterapi.PodSecurityAdmissionConfiguration(v1alpha1.Kube127, config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(clusterapi.SetPodSecurityAdmissionInKubeadmControlPlane(kcp, v1alpha1.Kube127, config)).To(Succeed())
+
+	apiServer := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	g.Expect(apiServer.ExtraArgs).To(HaveKeyWithValue("admission-control-config-file", "/etc/kubernetes/admission/admission-configuration.yaml"))
+	g.Expect(apiServer.ExtraVolumes).To(ContainElement(bootstrapv1.HostPathMount{
+		Name:      "admission-config",
+		HostPath:  "/var/lib/kubeadm/admission/",
+		MountPath: "/etc/kubernetes/admission/",
+		ReadOnly:  true,
+		PathType:  corev1.HostPathDirectoryOrCreate,
+	}))
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files).To(ContainElement(bootstrapv1.File{
+		Path:    "/var/lib/kubeadm/admission/admission-configuration.yaml",
+		Owner:   "root:root",
+		Content: content,
+	}))
+}
+
+func TestSetPodSecurityAdmissionInKubeadmControlPlaneNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	kcp := wantKubeadmControlPlane()
+
+	g.Expect(clusterapi.SetPodSecurityAdmissionInKubeadmControlPlane(kcp, v1alpha1.Kube127, nil)).To(Succeed())
+	g.Expect(kcp).To(Equal(wantKubeadmControlPlane()))
+}
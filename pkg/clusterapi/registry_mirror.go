@@ -92,6 +92,10 @@ func registryMirrorConfigContent(registryMirror *registrymirror.RegistryMirror)
 }
 
 func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration) (files []bootstrapv1.File, err error) {
+	if containerd.UsesHostsFiles(registryMirrorConfig) {
+		return registryHostsConfig(registryMirrorConfig)
+	}
+
 	registryMirror := registrymirror.FromClusterRegistryMirrorConfiguration(registryMirrorConfig)
 	registryConfig, err := registryMirrorConfigContent(registryMirror)
 	if err != nil {
@@ -116,6 +120,32 @@ func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfigura
 	return files, nil
 }
 
+// registryHostsConfig builds the files to configure containerd to load the per registry
+// hosts configuration from the certs.d directory.
+func registryHostsConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration) ([]bootstrapv1.File, error) {
+	registryConfig, err := templater.Execute(containerdConfig, values{"configPath": containerd.CertsDir})
+	if err != nil {
+		return nil, fmt.Errorf("building containerd config file: %v", err)
+	}
+
+	files := []bootstrapv1.File{
+		{
+			Path:    "/etc/containerd/config_append.toml",
+			Owner:   "root:root",
+			Content: string(registryConfig),
+		},
+	}
+	for _, f := range containerd.HostsFiles(registryMirrorConfig) {
+		files = append(files, bootstrapv1.File{
+			Path:    f.Path,
+			Owner:   "root:root",
+			Content: f.Content,
+		})
+	}
+
+	return files, nil
+}
+
 func addRegistryMirrorInKubeadmConfigSpecFiles(kcs *bootstrapv1.KubeadmConfigSpec, mirrorConfig *v1alpha1.RegistryMirrorConfiguration) error {
 	containerdFiles, err := registryMirrorConfig(mirrorConfig)
 	if err != nil {
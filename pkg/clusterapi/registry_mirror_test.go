@@ -126,6 +126,70 @@ var registryMirrorTests = []struct {
 			CACert:   "xyz",
 		},
 	},
+	{
+		name: "with registry hosts",
+		registryMirrorConfig: &v1alpha1.RegistryMirrorConfiguration{
+			Endpoint:      "1.2.3.4",
+			Port:          "443",
+			CACertContent: "xyz",
+			Hosts: []v1alpha1.RegistryHostsConfiguration{
+				{
+					Registry: "docker.io",
+					Server:   "https://registry-1.docker.io",
+					Hosts: []v1alpha1.RegistryHost{
+						{
+							URL:           "https://harbor.test:8443/v2/dockerhub",
+							CACertContent: "abc",
+							OverridePath:  true,
+						},
+					},
+				},
+			},
+		},
+		wantFiles: []bootstrapv1.File{
+			{
+				Path:  "/etc/containerd/config_append.toml",
+				Owner: "root:root",
+				Content: `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "/etc/containerd/certs.d"`,
+			},
+			{
+				Path:    "/etc/containerd/certs.d/1.2.3.4:443/ca.crt",
+				Owner:   "root:root",
+				Content: "xyz",
+			},
+			{
+				Path:    "/etc/containerd/certs.d/docker.io/harbor.test_8443.crt",
+				Owner:   "root:root",
+				Content: "abc",
+			},
+			{
+				Path:  "/etc/containerd/certs.d/docker.io/hosts.toml",
+				Owner: "root:root",
+				Content: `server = "https://registry-1.docker.io"
+
+[host."https://harbor.test:8443/v2/dockerhub"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/docker.io/harbor.test_8443.crt"
+  override_path = true`,
+			},
+			{
+				Path:  "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+				Owner: "root:root",
+				Content: `[host."https://1.2.3.4:443"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"`,
+			},
+		},
+		wantRegistryConfig: bootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4:443",
+			CACert:   "xyz",
+		},
+		wantRegistryConfigEtcd: &etcdbootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4:443",
+			CACert:   "xyz",
+		},
+	},
 }
 
 func TestSetRegistryMirrorInKubeadmControlPlaneBottleRocket(t *testing.T) {
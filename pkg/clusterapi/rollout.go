@@ -0,0 +1,107 @@
+package clusterapi
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// SetRolloutsPaused pauses or resumes the machine rollouts of an EKS-A cluster. If nodeGroups
+// is empty, it applies to the MachineDeployments of all the worker node groups and to the
+// KubeadmControlPlane. Otherwise, it only applies to the MachineDeployments of the given worker
+// node groups. While paused, CAPI doesn't create or delete machines for those objects.
+func SetRolloutsPaused(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, nodeGroups []string, paused bool) error {
+	workerNodeGroups, err := selectWorkerNodeGroups(cluster, nodeGroups)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range workerNodeGroups {
+		names, err := machineDeploymentNamesInCluster(ctx, client, cluster, w)
+		if err != nil {
+			return fmt.Errorf("reading machine deployments for worker node group %s: %v", w.Name, err)
+		}
+
+		for _, name := range names {
+			if err := setMachineDeploymentPaused(ctx, client, name, paused); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(nodeGroups) > 0 {
+		return nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	name := KubeadmControlPlaneName(cluster)
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, kcp); err != nil {
+		return fmt.Errorf("reading kubeadm control plane %s: %v", name, err)
+	}
+
+	_, isPaused := kcp.Annotations[clusterv1.PausedAnnotation]
+	if isPaused == paused {
+		return nil
+	}
+
+	if paused {
+		if kcp.Annotations == nil {
+			kcp.Annotations = map[string]string{}
+		}
+		kcp.Annotations[clusterv1.PausedAnnotation] = "true"
+	} else {
+		delete(kcp.Annotations, clusterv1.PausedAnnotation)
+	}
+
+	if err := client.Update(ctx, kcp); err != nil {
+		return fmt.Errorf("updating kubeadm control plane %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func selectWorkerNodeGroups(cluster *v1alpha1.Cluster, names []string) ([]v1alpha1.WorkerNodeGroupConfiguration, error) {
+	if len(names) == 0 {
+		return cluster.Spec.WorkerNodeGroupConfigurations, nil
+	}
+
+	byName := make(map[string]v1alpha1.WorkerNodeGroupConfiguration, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		byName[w.Name] = w
+	}
+
+	selected := make([]v1alpha1.WorkerNodeGroupConfiguration, 0, len(names))
+	for _, n := range names {
+		w, ok := byName[n]
+		if !ok {
+			return nil, fmt.Errorf("worker node group %s not found in cluster %s", n, cluster.Name)
+		}
+		selected = append(selected, w)
+	}
+
+	return selected, nil
+}
+
+func setMachineDeploymentPaused(ctx context.Context, client kubernetes.Client, name string, paused bool) error {
+	md := &clusterv1.MachineDeployment{}
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, md); err != nil {
+		return fmt.Errorf("reading machine deployment %s: %v", name, err)
+	}
+
+	if md.Spec.Paused == paused {
+		return nil
+	}
+
+	md.Spec.Paused = paused
+	if err := client.Update(ctx, md); err != nil {
+		return fmt.Errorf("updating machine deployment %s: %v", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package clusterapi_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+type rolloutTest struct {
+	*WithT
+	ctx     context.Context
+	client  kubernetes.Client
+	cluster *v1alpha1.Cluster
+}
+
+func newRolloutTest(t *testing.T) rolloutTest {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		Spec: v1alpha1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0"},
+				{Name: "md-1"},
+			},
+		},
+	}
+
+	objs := []client.Object{
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+		},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: constants.EksaSystemNamespace},
+		},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-1", Namespace: constants.EksaSystemNamespace},
+		},
+	}
+
+	return rolloutTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  test.NewFakeKubeClient(objs...),
+		cluster: cluster,
+	}
+}
+
+func (tt rolloutTest) machineDeployment(name string) *clusterv1.MachineDeployment {
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, name, constants.EksaSystemNamespace, md)).To(Succeed())
+	return md
+}
+
+func (tt rolloutTest) kubeadmControlPlane() *controlplanev1.KubeadmControlPlane {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, kcp)).To(Succeed())
+	return kcp
+}
+
+func TestSetRolloutsPausedCluster(t *testing.T) {
+	tt := newRolloutTest(t)
+
+	tt.Expect(clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, nil, true)).To(Succeed())
+	tt.Expect(tt.machineDeployment("my-cluster-md-0").Spec.Paused).To(BeTrue())
+	tt.Expect(tt.machineDeployment("my-cluster-md-1").Spec.Paused).To(BeTrue())
+	tt.Expect(tt.kubeadmControlPlane().Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+
+	tt.Expect(clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, nil, false)).To(Succeed())
+	tt.Expect(tt.machineDeployment("my-cluster-md-0").Spec.Paused).To(BeFalse())
+	tt.Expect(tt.machineDeployment("my-cluster-md-1").Spec.Paused).To(BeFalse())
+	tt.Expect(tt.kubeadmControlPlane().Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+}
+
+func TestSetRolloutsPausedNodeGroups(t *testing.T) {
+	tt := newRolloutTest(t)
+
+	tt.Expect(clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, []string{"md-1"}, true)).To(Succeed())
+	tt.Expect(tt.machineDeployment("my-cluster-md-0").Spec.Paused).To(BeFalse())
+	tt.Expect(tt.machineDeployment("my-cluster-md-1").Spec.Paused).To(BeTrue())
+	tt.Expect(tt.kubeadmControlPlane().Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+}
+
+func TestSetRolloutsPausedNodeGroupNotFound(t *testing.T) {
+	tt := newRolloutTest(t)
+
+	err := clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, []string{"md-2"}, true)
+	tt.Expect(err).To(MatchError("worker node group md-2 not found in cluster my-cluster"))
+}
+
+func TestSetRolloutsPausedMachineDeploymentNotFound(t *testing.T) {
+	tt := newRolloutTest(t)
+	tt.client = test.NewFakeKubeClient()
+
+	err := clusterapi.SetRolloutsPaused(tt.ctx, tt.client, tt.cluster, []string{"md-0"}, true)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading machine deployment my-cluster-md-0")))
+}
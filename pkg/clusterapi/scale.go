@@ -0,0 +1,38 @@
+package clusterapi
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ScaleWorkerNodeGroup sets the replicas of the MachineDeployment for an EKS-A worker node group.
+func ScaleWorkerNodeGroup(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration, replicas int) error {
+	names, err := machineDeploymentNamesInCluster(ctx, client, cluster, workerNodeGroupConfig)
+	if err != nil {
+		return fmt.Errorf("reading machine deployments for worker node group %s: %v", workerNodeGroupConfig.Name, err)
+	}
+
+	md := &clusterv1.MachineDeployment{}
+	name := names[0]
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, md); err != nil {
+		return fmt.Errorf("reading machine deployment %s: %v", name, err)
+	}
+
+	r := int32(replicas)
+	if md.Spec.Replicas != nil && *md.Spec.Replicas == r {
+		return nil
+	}
+
+	md.Spec.Replicas = &r
+	if err := client.Update(ctx, md); err != nil {
+		return fmt.Errorf("updating machine deployment %s: %v", name, err)
+	}
+
+	return nil
+}
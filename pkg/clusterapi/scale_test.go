@@ -0,0 +1,26 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestScaleWorkerNodeGroup(t *testing.T) {
+	tt := newRolloutTest(t)
+
+	tt.Expect(clusterapi.ScaleWorkerNodeGroup(tt.ctx, tt.client, tt.cluster, tt.cluster.Spec.WorkerNodeGroupConfigurations[1], 5)).To(Succeed())
+	tt.Expect(*tt.machineDeployment("my-cluster-md-1").Spec.Replicas).To(BeEquivalentTo(5))
+}
+
+func TestScaleWorkerNodeGroupMachineDeploymentNotFound(t *testing.T) {
+	tt := newRolloutTest(t)
+	tt.client = test.NewFakeKubeClient()
+
+	err := clusterapi.ScaleWorkerNodeGroup(tt.ctx, tt.client, tt.cluster, v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}, 5)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading machine deployment my-cluster-md-0")))
+}
@@ -0,0 +1,340 @@
+package clusterapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// kubeadmTemplateOverridePaths are the paths of the generated CAPI objects that can be
+// overridden in all the providers. The rest of the fields are managed by EKS Anywhere and
+// overriding them could break the cluster lifecycle.
+var kubeadmTemplateOverridePaths = map[string][]string{
+	"KubeadmControlPlane": {
+		"spec.kubeadmConfigSpec.clusterConfiguration.apiServer.extraArgs",
+		"spec.kubeadmConfigSpec.clusterConfiguration.controllerManager.extraArgs",
+		"spec.kubeadmConfigSpec.clusterConfiguration.scheduler.extraArgs",
+		"spec.kubeadmConfigSpec.initConfiguration.nodeRegistration.kubeletExtraArgs",
+		"spec.kubeadmConfigSpec.joinConfiguration.nodeRegistration.kubeletExtraArgs",
+		"spec.kubeadmConfigSpec.files",
+		"spec.kubeadmConfigSpec.preKubeadmCommands",
+		"spec.kubeadmConfigSpec.postKubeadmCommands",
+	},
+	"KubeadmConfigTemplate": {
+		"spec.template.spec.joinConfiguration.nodeRegistration.kubeletExtraArgs",
+		"spec.template.spec.files",
+		"spec.template.spec.preKubeadmCommands",
+		"spec.template.spec.postKubeadmCommands",
+	},
+	"MachineDeployment": {
+		"metadata.labels",
+		"metadata.annotations",
+		"spec.template.metadata.labels",
+		"spec.template.metadata.annotations",
+	},
+}
+
+// TemplateOverridePaths returns the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides, by kind. It includes the kubeadm paths supported by all
+// the providers and the provider specific machineTemplatePaths.
+func TemplateOverridePaths(machineTemplatePaths map[string][]string) map[string][]string {
+	paths := make(map[string][]string, len(kubeadmTemplateOverridePaths)+len(machineTemplatePaths))
+	for kind, p := range kubeadmTemplateOverridePaths {
+		paths[kind] = p
+	}
+	for kind, p := range machineTemplatePaths {
+		paths[kind] = p
+	}
+	return paths
+}
+
+// ValidateTemplateOverrides checks all the paths set by the template overrides patches
+// can be overridden.
+func ValidateTemplateOverrides(overrides []v1alpha1.TemplateOverride, allowedPaths map[string][]string) error {
+	for i := range overrides {
+		if err := validateTemplateOverride(&overrides[i], allowedPaths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTemplateOverride(override *v1alpha1.TemplateOverride, allowedPaths map[string][]string) error {
+	allowed, ok := allowedPaths[override.Kind]
+	if !ok {
+		return fmt.Errorf("templateOverrides for kind %s are not supported", override.Kind)
+	}
+
+	patch, err := override.PatchObject()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range patchPaths("", patch) {
+		if !pathAllowed(p, allowed) {
+			return fmt.Errorf("templateOverrides can't override %s in %s, only %s can be overridden", p, override.Kind, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// patchPaths returns the paths of all the fields set by a patch. Lists are treated as a single
+// field since they are replaced by the patch.
+func patchPaths(parent string, patch map[string]interface{}) []string {
+	var paths []string
+	for k, v := range patch {
+		// Strategic merge patch directives apply to the parent object or to one of its fields.
+		if strings.HasPrefix(k, "$") {
+			p := parent
+			if _, field, ok := strings.Cut(k, "/"); ok {
+				p = joinPath(parent, field)
+			}
+			paths = append(paths, p)
+			continue
+		}
+
+		p := joinPath(parent, k)
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			paths = append(paths, patchPaths(p, m)...)
+			continue
+		}
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func joinPath(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+	return parent + "." + field
+}
+
+func pathAllowed(path string, allowed []string) bool {
+	for _, a := range allowed {
+		if path == a || strings.HasPrefix(path, a+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateOverridesChanged checks if the template overrides changed between two cluster specs.
+// Machine templates are immutable, so new ones are needed when the overrides change.
+func TemplateOverridesChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !slices.Equal(oldSpec.Cluster.Spec.TemplateOverrides, newSpec.Cluster.Spec.TemplateOverrides)
+}
+
+type templateDocument struct {
+	kind, apiVersion, name string
+	original               []byte
+	// patched is the json of the document after applying the overrides. It's nil when
+	// no override matches the document.
+	patched []byte
+}
+
+type templateDocumentHeader struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+}
+
+// ApplyTemplateOverrides applies the template overrides to the CAPI objects in content, a multi
+// document yaml, matching them by kind and name. It fails if an override sets a path not
+// included in allowedPaths. content is returned unchanged when no override matches its objects.
+func ApplyTemplateOverrides(content []byte, overrides []v1alpha1.TemplateOverride, allowedPaths map[string][]string) ([]byte, error) {
+	if len(overrides) == 0 {
+		return content, nil
+	}
+
+	docs, err := applyTemplateOverrides(content, overrides, allowedPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([][]byte, 0, len(docs))
+	patched := false
+	for _, d := range docs {
+		if d.patched == nil {
+			resources = append(resources, d.original)
+			continue
+		}
+
+		patched = true
+		r, err := yaml.JSONToYAML(d.patched)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	if !patched {
+		return content, nil
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+// TemplateOverridesDiff returns a unified diff with the changes the template overrides make
+// to the CAPI objects in content. It's empty when no override matches the objects.
+func TemplateOverridesDiff(content []byte, overrides []v1alpha1.TemplateOverride, allowedPaths map[string][]string) (string, error) {
+	if len(overrides) == 0 {
+		return "", nil
+	}
+
+	docs, err := applyTemplateOverrides(content, overrides, allowedPaths)
+	if err != nil {
+		return "", err
+	}
+
+	b := &strings.Builder{}
+	for _, d := range docs {
+		if d.patched == nil {
+			continue
+		}
+
+		// The original document is converted to json and back so the diff
+		// only shows the fields changed by the overrides, not the formatting.
+		j, err := yaml.YAMLToJSON(d.original)
+		if err != nil {
+			return "", err
+		}
+		original, err := yaml.JSONToYAML(j)
+		if err != nil {
+			return "", err
+		}
+		patched, err := yaml.JSONToYAML(d.patched)
+		if err != nil {
+			return "", err
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(strings.TrimSuffix(string(original), "\n")),
+			B:        difflib.SplitLines(strings.TrimSuffix(string(patched), "\n")),
+			FromFile: fmt.Sprintf("%s %s (generated)", d.kind, d.name),
+			ToFile:   fmt.Sprintf("%s %s (templateOverrides)", d.kind, d.name),
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(diff)
+	}
+
+	return b.String(), nil
+}
+
+func applyTemplateOverrides(content []byte, overrides []v1alpha1.TemplateOverride, allowedPaths map[string][]string) ([]templateDocument, error) {
+	if err := ValidateTemplateOverrides(overrides, allowedPaths); err != nil {
+		return nil, err
+	}
+
+	docs, err := splitTemplateDocuments(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated CAPI objects: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := kubernetes.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	for i := range docs {
+		d := &docs[i]
+		for j := range overrides {
+			o := &overrides[j]
+			if !o.Matches(d.kind, d.name) {
+				continue
+			}
+
+			if err := d.applyOverride(scheme, o); err != nil {
+				return nil, fmt.Errorf("applying templateOverride to %s %s: %v", d.kind, d.name, err)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+func (d *templateDocument) applyOverride(scheme *runtime.Scheme, override *v1alpha1.TemplateOverride) error {
+	obj, err := scheme.New(schema.FromAPIVersionAndKind(d.apiVersion, d.kind))
+	if err != nil {
+		return err
+	}
+
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return err
+	}
+
+	current := d.patched
+	if current == nil {
+		if current, err = yaml.YAMLToJSON(d.original); err != nil {
+			return err
+		}
+	}
+
+	merged, err := strategicpatch.StrategicMergePatch(current, patch, obj)
+	if err != nil {
+		return err
+	}
+	d.patched = merged
+
+	return nil
+}
+
+func splitTemplateDocuments(content []byte) ([]templateDocument, error) {
+	var docs []templateDocument
+	r := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		d, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		j, err := yaml.YAMLToJSON(d)
+		if err != nil {
+			return nil, err
+		}
+		if string(j) == "null" {
+			continue
+		}
+
+		header := &templateDocumentHeader{}
+		if err := json.Unmarshal(j, header); err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, templateDocument{
+			kind:       header.Kind,
+			apiVersion: header.APIVersion,
+			name:       header.Metadata.Name,
+			original:   d,
+		})
+	}
+
+	return docs, nil
+}
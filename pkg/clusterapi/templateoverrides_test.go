@@ -0,0 +1,247 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+var generatedTemplates = []byte(`apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test-cluster
+  namespace: eksa-system
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      apiServer:
+        extraArgs:
+          audit-log-maxage: "30"
+  replicas: 3
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: test-cluster-md-0-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      joinConfiguration:
+        nodeRegistration:
+          kubeletExtraArgs:
+            node-labels: group=md-0
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: test-cluster-md-1-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      joinConfiguration:
+        nodeRegistration:
+          kubeletExtraArgs:
+            node-labels: group=md-1
+`)
+
+var templateOverridePaths = clusterapi.TemplateOverridePaths(map[string][]string{
+	"VSphereMachineTemplate": {"spec.template.spec.customVMXKeys"},
+})
+
+func TestTemplateOverridePaths(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(templateOverridePaths).To(HaveKeyWithValue("VSphereMachineTemplate", []string{"spec.template.spec.customVMXKeys"}))
+	g.Expect(templateOverridePaths).To(HaveKey("KubeadmControlPlane"))
+	g.Expect(templateOverridePaths).To(HaveKey("KubeadmConfigTemplate"))
+	g.Expect(templateOverridePaths).To(HaveKey("MachineDeployment"))
+}
+
+func TestValidateTemplateOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []v1alpha1.TemplateOverride
+		wantErr   string
+	}{
+		{
+			name: "allowed paths",
+			overrides: []v1alpha1.TemplateOverride{
+				{
+					Kind:  "KubeadmControlPlane",
+					Patch: "spec:\n  kubeadmConfigSpec:\n    clusterConfiguration:\n      apiServer:\n        extraArgs:\n          audit-log-maxage: \"60\"\n",
+				},
+				{
+					Kind:  "VSphereMachineTemplate",
+					Patch: "spec:\n  template:\n    spec:\n      customVMXKeys:\n        guestinfo.test: \"true\"\n",
+				},
+			},
+		},
+		{
+			name: "list directive",
+			overrides: []v1alpha1.TemplateOverride{
+				{
+					Kind:  "KubeadmConfigTemplate",
+					Patch: "spec:\n  template:\n    spec:\n      $setElementOrder/preKubeadmCommands: []\n",
+				},
+			},
+		},
+		{
+			name: "kind not supported",
+			overrides: []v1alpha1.TemplateOverride{
+				{Kind: "EtcdadmCluster", Patch: "spec: {}"},
+			},
+			wantErr: "templateOverrides for kind EtcdadmCluster are not supported",
+		},
+		{
+			name: "path not allowed",
+			overrides: []v1alpha1.TemplateOverride{
+				{Kind: "KubeadmControlPlane", Patch: "spec:\n  replicas: 5\n"},
+			},
+			wantErr: "templateOverrides can't override spec.replicas in KubeadmControlPlane",
+		},
+		{
+			name: "delete object",
+			overrides: []v1alpha1.TemplateOverride{
+				{Kind: "MachineDeployment", Patch: "$patch: delete\n"},
+			},
+			wantErr: "templateOverrides can't override  in MachineDeployment",
+		},
+		{
+			name: "parent of allowed path",
+			overrides: []v1alpha1.TemplateOverride{
+				{Kind: "KubeadmConfigTemplate", Patch: "spec:\n  template:\n    spec:\n      joinConfiguration: null\n"},
+			},
+			wantErr: "templateOverrides can't override spec.template.spec.joinConfiguration in KubeadmConfigTemplate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := clusterapi.ValidateTemplateOverrides(tt.overrides, templateOverridePaths)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestApplyTemplateOverrides(t *testing.T) {
+	g := NewWithT(t)
+	overrides := []v1alpha1.TemplateOverride{
+		{
+			Kind:  "KubeadmControlPlane",
+			Patch: "spec:\n  kubeadmConfigSpec:\n    clusterConfiguration:\n      apiServer:\n        extraArgs:\n          audit-log-maxage: \"60\"\n",
+		},
+		{
+			Kind:  "KubeadmConfigTemplate",
+			Name:  "*-md-1-*",
+			Patch: "spec:\n  template:\n    spec:\n      postKubeadmCommands:\n      - echo done\n",
+		},
+	}
+
+	got, err := clusterapi.ApplyTemplateOverrides(generatedTemplates, overrides, templateOverridePaths)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(`apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test-cluster
+  namespace: eksa-system
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      apiServer:
+        extraArgs:
+          audit-log-maxage: "60"
+  replicas: 3
+
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: test-cluster-md-0-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      joinConfiguration:
+        nodeRegistration:
+          kubeletExtraArgs:
+            node-labels: group=md-0
+
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: test-cluster-md-1-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      joinConfiguration:
+        nodeRegistration:
+          kubeletExtraArgs:
+            node-labels: group=md-1
+      postKubeadmCommands:
+      - echo done
+
+---
+`))
+}
+
+func TestApplyTemplateOverridesNoMatch(t *testing.T) {
+	g := NewWithT(t)
+	overrides := []v1alpha1.TemplateOverride{
+		{
+			Kind:  "MachineDeployment",
+			Patch: "metadata:\n  labels:\n    team: edge\n",
+		},
+	}
+
+	got, err := clusterapi.ApplyTemplateOverrides(generatedTemplates, overrides, templateOverridePaths)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(generatedTemplates))
+}
+
+func TestApplyTemplateOverridesPathNotAllowed(t *testing.T) {
+	g := NewWithT(t)
+	overrides := []v1alpha1.TemplateOverride{
+		{Kind: "KubeadmControlPlane", Patch: "spec:\n  replicas: 1\n"},
+	}
+
+	_, err := clusterapi.ApplyTemplateOverrides(generatedTemplates, overrides, templateOverridePaths)
+	g.Expect(err).To(MatchError(ContainSubstring("templateOverrides can't override spec.replicas")))
+}
+
+func TestTemplateOverridesDiff(t *testing.T) {
+	g := NewWithT(t)
+	overrides := []v1alpha1.TemplateOverride{
+		{
+			Kind:  "KubeadmConfigTemplate",
+			Name:  "*-md-0-*",
+			Patch: "spec:\n  template:\n    spec:\n      joinConfiguration:\n        nodeRegistration:\n          kubeletExtraArgs:\n            max-pods: \"50\"\n",
+		},
+	}
+
+	got, err := clusterapi.TemplateOverridesDiff(generatedTemplates, overrides, templateOverridePaths)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(`--- KubeadmConfigTemplate test-cluster-md-0-1 (generated)
++++ KubeadmConfigTemplate test-cluster-md-0-1 (templateOverrides)
+@@ -9,4 +9,5 @@
+       joinConfiguration:
+         nodeRegistration:
+           kubeletExtraArgs:
++            max-pods: "50"
+             node-labels: group=md-0
+`))
+}
+
+func TestTemplateOverridesDiffNoOverrides(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.TemplateOverridesDiff(generatedTemplates, nil, templateOverridePaths)).To(BeEmpty())
+}
@@ -0,0 +1,67 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	addons "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// WorkloadResourceSetClusterLabel is the label with the name of the management cluster that
+// defines the ClusterResourceSets applied to its workload clusters.
+const WorkloadResourceSetClusterLabel = "anywhere.eks.amazonaws.com/cluster-resource-set-cluster"
+
+// WorkloadClusterResourceSets builds the ClusterResourceSets a management cluster defines for its
+// workload clusters. They select every CAPI cluster in the eksa-system namespace except the
+// management cluster itself.
+func WorkloadClusterResourceSets(cluster *v1alpha1.Cluster) []*addons.ClusterResourceSet {
+	sets := make([]*addons.ClusterResourceSet, 0, len(cluster.Spec.ClusterResourceSets))
+	for _, s := range cluster.Spec.ClusterResourceSets {
+		resources := make([]addons.ResourceRef, 0, len(s.ConfigMaps))
+		for _, cm := range s.ConfigMaps {
+			resources = append(resources, addons.ResourceRef{
+				Name: cm,
+				Kind: string(addons.ConfigMapClusterResourceSetResourceKind),
+			})
+		}
+
+		sets = append(sets, &addons.ClusterResourceSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: addons.GroupVersion.Identifier(),
+				Kind:       constants.ClusterResourceSetKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      WorkloadClusterResourceSetName(cluster.Name, s.Name),
+				Namespace: constants.EksaSystemNamespace,
+				Labels: map[string]string{
+					WorkloadResourceSetClusterLabel: cluster.Name,
+				},
+			},
+			Spec: addons.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      clusterv1.ClusterNameLabel,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   []string{cluster.Name},
+						},
+					},
+				},
+				Resources: resources,
+				Strategy:  string(addons.ClusterResourceSetStrategyApplyOnce),
+			},
+		})
+	}
+
+	return sets
+}
+
+// WorkloadClusterResourceSetName returns the name of the ClusterResourceSet built for one of the
+// clusterResourceSets of a management cluster.
+func WorkloadClusterResourceSetName(clusterName, setName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, setName)
+}
@@ -0,0 +1,80 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addons "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestWorkloadClusterResourceSets(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mgmt"},
+		Spec: v1alpha1.ClusterSpec{
+			ClusterResourceSets: []v1alpha1.ClusterResourceSet{
+				{Name: "storage", ConfigMaps: []string{"storage-classes"}},
+				{Name: "policies", ConfigMaps: []string{"priority-classes", "network-policies"}},
+			},
+		},
+	}
+
+	want := []*addons.ClusterResourceSet{
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "addons.cluster.x-k8s.io/v1beta1",
+				Kind:       "ClusterResourceSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mgmt-storage",
+				Namespace: "eksa-system",
+				Labels:    map[string]string{clusterapi.WorkloadResourceSetClusterLabel: "mgmt"},
+			},
+			Spec: addons.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "cluster.x-k8s.io/cluster-name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"mgmt"}},
+					},
+				},
+				Resources: []addons.ResourceRef{{Name: "storage-classes", Kind: "ConfigMap"}},
+				Strategy:  "ApplyOnce",
+			},
+		},
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "addons.cluster.x-k8s.io/v1beta1",
+				Kind:       "ClusterResourceSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mgmt-policies",
+				Namespace: "eksa-system",
+				Labels:    map[string]string{clusterapi.WorkloadResourceSetClusterLabel: "mgmt"},
+			},
+			Spec: addons.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "cluster.x-k8s.io/cluster-name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"mgmt"}},
+					},
+				},
+				Resources: []addons.ResourceRef{
+					{Name: "priority-classes", Kind: "ConfigMap"},
+					{Name: "network-policies", Kind: "ConfigMap"},
+				},
+				Strategy: "ApplyOnce",
+			},
+		},
+	}
+
+	g.Expect(clusterapi.WorkloadClusterResourceSets(cluster)).To(Equal(want))
+}
+
+func TestWorkloadClusterResourceSetsEmpty(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt"}}
+
+	g.Expect(clusterapi.WorkloadClusterResourceSets(cluster)).To(BeEmpty())
+}
@@ -0,0 +1,451 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	nodeGroupMinSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size"
+	nodeGroupMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
+	nodeLabelsKubeletArg       = "node-labels"
+)
+
+// Importer adopts existing CAPI clusters under the management of EKS-A. It generates the
+// EKS-A objects describing a CAPI cluster and creates them in the management cluster, so the
+// EKS-A controller reconciles the CAPI objects of the cluster from then on.
+type Importer struct {
+	client kubernetes.Client
+}
+
+// NewImporter builds an Importer. client is a client for the management cluster.
+func NewImporter(client kubernetes.Client) *Importer {
+	return &Importer{client: client}
+}
+
+// Generate builds the EKS-A cluster config of the CAPI cluster name in the eksa-system namespace,
+// with its EKS-A objects in namespace. The cluster is managed by the management cluster the client
+// points to.
+//
+// Only vSphere clusters with a KubeadmControlPlane with stacked etcd are supported. The control
+// plane and the machine deployments must be named the way EKS-A names them, so the EKS-A controller
+// updates them instead of creating new ones.
+func (i *Importer) Generate(ctx context.Context, name, namespace string) (*cluster.Config, error) {
+	capiCluster := &clusterv1.Cluster{}
+	if err := i.client.Get(ctx, name, constants.EksaSystemNamespace, capiCluster); apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("CAPI cluster %s not found in namespace %s, only clusters in this namespace can be imported", name, constants.EksaSystemNamespace)
+	} else if err != nil {
+		return nil, fmt.Errorf("getting CAPI cluster %s: %v", name, err)
+	}
+
+	if err := i.validateNotManaged(ctx, capiCluster, namespace); err != nil {
+		return nil, err
+	}
+
+	if err := validateRefs(capiCluster); err != nil {
+		return nil, err
+	}
+
+	if !capiCluster.Status.ControlPlaneReady || !capiCluster.Status.InfrastructureReady {
+		return nil, fmt.Errorf("CAPI cluster %s is not ready", name)
+	}
+
+	managementCluster, err := i.managementCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		client:      i.client,
+		capiCluster: capiCluster,
+		config: &cluster.Config{
+			Cluster:               newCluster(capiCluster, namespace, managementCluster),
+			VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{},
+		},
+	}
+
+	if err := g.controlPlane(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := g.workers(ctx); err != nil {
+		return nil, err
+	}
+
+	bundles, err := cluster.BundlesForCluster(ctx, i.client, managementCluster)
+	if err != nil {
+		return nil, fmt.Errorf("getting bundles of management cluster %s: %v", managementCluster.Name, err)
+	}
+	for _, version := range g.config.Cluster.KubernetesVersions() {
+		if _, err := cluster.GetVersionsBundle(version, bundles); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := v1alpha1.ValidateClusterConfigContent(g.config.Cluster); err != nil {
+		return nil, fmt.Errorf("validating generated cluster config: %v", err)
+	}
+
+	return g.config, nil
+}
+
+// Import creates the EKS-A objects of config in the management cluster and labels the CAPI cluster
+// with the EKS-A cluster it belongs to. The EKS-A cluster is created last: once it exists, the cluster
+// is managed by EKS-A and deleting it deletes the CAPI cluster. If any step before fails, the objects
+// already created are deleted and the labels removed.
+func (i *Importer) Import(ctx context.Context, config *cluster.Config) error {
+	var created []kubernetes.Object
+	for _, obj := range config.ChildObjects() {
+		obj.SetNamespace(config.Cluster.Namespace)
+		if err := i.client.Create(ctx, obj); err != nil {
+			i.rollback(ctx, config.Cluster.Name, created)
+			return fmt.Errorf("creating %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		created = append(created, obj)
+	}
+
+	// The EKS-A controller maps the events of the CAPI cluster to the EKS-A cluster through these labels.
+	if err := i.updateCAPIClusterLabels(ctx, config.Cluster.Name, func(labels map[string]string) {
+		labels[clusterapi.EKSAClusterLabelName] = config.Cluster.Name
+		labels[clusterapi.EKSAClusterLabelNamespace] = config.Cluster.Namespace
+	}); err != nil {
+		i.rollback(ctx, config.Cluster.Name, created)
+		return err
+	}
+
+	if err := i.client.Create(ctx, config.Cluster); err != nil {
+		i.rollback(ctx, config.Cluster.Name, created)
+		return fmt.Errorf("creating Cluster %s: %v", config.Cluster.Name, err)
+	}
+
+	return nil
+}
+
+// rollback deletes the objects created for the import of the cluster name and removes the EKS-A
+// labels of its CAPI cluster. It's best effort, the errors are only logged.
+func (i *Importer) rollback(ctx context.Context, name string, created []kubernetes.Object) {
+	for j := len(created) - 1; j >= 0; j-- {
+		if err := i.client.Delete(ctx, created[j]); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "Deleting object created for import", "name", created[j].GetName())
+		}
+	}
+
+	if err := i.updateCAPIClusterLabels(ctx, name, func(labels map[string]string) {
+		delete(labels, clusterapi.EKSAClusterLabelName)
+		delete(labels, clusterapi.EKSAClusterLabelNamespace)
+	}); err != nil {
+		logger.Error(err, "Removing EKS-A labels from CAPI cluster", "name", name)
+	}
+}
+
+func (i *Importer) updateCAPIClusterLabels(ctx context.Context, name string, update func(labels map[string]string)) error {
+	capiCluster := &clusterv1.Cluster{}
+	if err := i.client.Get(ctx, name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return fmt.Errorf("getting CAPI cluster %s: %v", name, err)
+	}
+
+	labels := capiCluster.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	update(labels)
+	capiCluster.SetLabels(labels)
+	if err := i.client.Update(ctx, capiCluster); err != nil {
+		return fmt.Errorf("updating labels of CAPI cluster %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// Kubeconfig returns the admin kubeconfig of the cluster name, generated by CAPI.
+func (i *Importer) Kubeconfig(ctx context.Context, name string) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := i.client.Get(ctx, secret.Name(name, secret.Kubeconfig), constants.EksaSystemNamespace, s); err != nil {
+		return nil, fmt.Errorf("getting kubeconfig of cluster %s: %v", name, err)
+	}
+
+	return s.Data[secret.KubeconfigDataName], nil
+}
+
+func (i *Importer) validateNotManaged(ctx context.Context, capiCluster *clusterv1.Cluster, namespace string) error {
+	if owner, ok := capiCluster.Labels[clusterapi.EKSAClusterLabelName]; ok {
+		return fmt.Errorf("CAPI cluster %s is already managed by EKS-A cluster %s", capiCluster.Name, owner)
+	}
+
+	err := i.client.Get(ctx, capiCluster.Name, namespace, &v1alpha1.Cluster{})
+	if err == nil {
+		return fmt.Errorf("EKS-A cluster %s already exists in namespace %s", capiCluster.Name, namespace)
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("checking if EKS-A cluster %s exists: %v", capiCluster.Name, err)
+	}
+
+	return nil
+}
+
+func (i *Importer) managementCluster(ctx context.Context) (*v1alpha1.Cluster, error) {
+	clusters := &v1alpha1.ClusterList{}
+	if err := i.client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("listing EKS-A clusters: %v", err)
+	}
+
+	for j := range clusters.Items {
+		if clusters.Items[j].IsSelfManaged() {
+			return &clusters.Items[j], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no EKS-A management cluster found, clusters can only be imported into a management cluster")
+}
+
+func validateRefs(capiCluster *clusterv1.Cluster) error {
+	infra := capiCluster.Spec.InfrastructureRef
+	if infra == nil || infra.Kind != "VSphereCluster" {
+		return fmt.Errorf("CAPI cluster %s is not a vSphere cluster, only vSphere clusters can be imported", capiCluster.Name)
+	}
+
+	controlPlane := capiCluster.Spec.ControlPlaneRef
+	if controlPlane == nil || controlPlane.Kind != "KubeadmControlPlane" {
+		return fmt.Errorf("CAPI cluster %s doesn't use a KubeadmControlPlane, only kubeadm control planes can be imported", capiCluster.Name)
+	}
+
+	// The EKS-A controller finds these objects by the name of the cluster.
+	if infra.Name != capiCluster.Name {
+		return fmt.Errorf("VSphereCluster %s must be named after the cluster %s", infra.Name, capiCluster.Name)
+	}
+	if controlPlane.Name != capiCluster.Name {
+		return fmt.Errorf("KubeadmControlPlane %s must be named after the cluster %s", controlPlane.Name, capiCluster.Name)
+	}
+
+	return nil
+}
+
+func newCluster(capiCluster *clusterv1.Cluster, namespace string, managementCluster *v1alpha1.Cluster) *v1alpha1.Cluster {
+	c := &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.ClusterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capiCluster.Name,
+			Namespace: namespace,
+		},
+	}
+	c.Spec.ManagementCluster.Name = managementCluster.Name
+	c.Spec.EksaVersion = managementCluster.Spec.EksaVersion
+	c.Spec.BundlesRef = managementCluster.Spec.BundlesRef
+	c.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: capiCluster.Name}
+	c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: capiCluster.Spec.ControlPlaneEndpoint.Host}
+
+	if network := capiCluster.Spec.ClusterNetwork; network != nil {
+		if network.Pods != nil {
+			c.Spec.ClusterNetwork.Pods.CidrBlocks = network.Pods.CIDRBlocks
+		}
+		if network.Services != nil {
+			c.Spec.ClusterNetwork.Services.CidrBlocks = network.Services.CIDRBlocks
+		}
+	}
+
+	// The CNI of the cluster was not installed by EKS-A, so it's left alone.
+	c.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{SkipUpgrade: ptr.Bool(true)}}
+
+	return c
+}
+
+// generator builds the cluster config of a CAPI cluster from its objects.
+type generator struct {
+	client      kubernetes.Client
+	capiCluster *clusterv1.Cluster
+	config      *cluster.Config
+}
+
+func (g *generator) controlPlane(ctx context.Context) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := g.client.Get(ctx, g.capiCluster.Name, constants.EksaSystemNamespace, kcp); err != nil {
+		return fmt.Errorf("getting KubeadmControlPlane %s: %v", g.capiCluster.Name, err)
+	}
+
+	if config := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration; config != nil && config.Etcd.External != nil {
+		return fmt.Errorf("KubeadmControlPlane %s uses an external etcd, only stacked etcd can be imported", kcp.Name)
+	}
+
+	version, err := kubernetesVersion(kcp.Spec.Version)
+	if err != nil {
+		return fmt.Errorf("parsing version of KubeadmControlPlane %s: %v", kcp.Name, err)
+	}
+
+	c := g.config.Cluster
+	c.Spec.KubernetesVersion = version
+	if kcp.Spec.Replicas != nil {
+		c.Spec.ControlPlaneConfiguration.Count = int(*kcp.Spec.Replicas)
+	}
+
+	machineConfigName := c.Name + "-cp"
+	if err := g.machineConfig(ctx, machineConfigName, kcp.Spec.MachineTemplate.InfrastructureRef.Name, &kcp.Spec.KubeadmConfigSpec); err != nil {
+		return err
+	}
+	c.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: machineConfigName}
+
+	return nil
+}
+
+func (g *generator) workers(ctx context.Context) error {
+	mds := &clusterv1.MachineDeploymentList{}
+	if err := g.client.List(ctx, mds); err != nil {
+		return fmt.Errorf("listing machine deployments: %v", err)
+	}
+
+	c := g.config.Cluster
+	c.Spec.WorkerNodeGroupConfigurations = nil
+	for _, md := range mds.Items {
+		if md.Namespace != constants.EksaSystemNamespace || md.Spec.ClusterName != g.capiCluster.Name {
+			continue
+		}
+
+		w, err := g.workerNodeGroup(ctx, &md)
+		if err != nil {
+			return err
+		}
+		c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations, *w)
+	}
+
+	return nil
+}
+
+func (g *generator) workerNodeGroup(ctx context.Context, md *clusterv1.MachineDeployment) (*v1alpha1.WorkerNodeGroupConfiguration, error) {
+	c := g.config.Cluster
+	prefix := c.Name + "-"
+	// The EKS-A controller names the machine deployments <cluster>-<worker node group>.
+	if !strings.HasPrefix(md.Name, prefix) {
+		return nil, fmt.Errorf("MachineDeployment %s must be named with the cluster name %s as prefix", md.Name, c.Name)
+	}
+
+	w := &v1alpha1.WorkerNodeGroupConfiguration{
+		Name: strings.TrimPrefix(md.Name, prefix),
+	}
+	if md.Spec.Replicas != nil {
+		w.Count = ptr.Int(int(*md.Spec.Replicas))
+	}
+
+	if md.Spec.Template.Spec.Version != nil {
+		version, err := kubernetesVersion(*md.Spec.Template.Spec.Version)
+		if err != nil {
+			return nil, fmt.Errorf("parsing version of MachineDeployment %s: %v", md.Name, err)
+		}
+		if version != c.Spec.KubernetesVersion {
+			w.KubernetesVersion = &version
+		}
+	}
+
+	autoscaling, err := autoScalingConfiguration(md)
+	if err != nil {
+		return nil, err
+	}
+	w.AutoScalingConfiguration = autoscaling
+
+	bootstrap := md.Spec.Template.Spec.Bootstrap.ConfigRef
+	if bootstrap == nil || bootstrap.Kind != "KubeadmConfigTemplate" {
+		return nil, fmt.Errorf("MachineDeployment %s doesn't use a KubeadmConfigTemplate", md.Name)
+	}
+	kct := &bootstrapv1.KubeadmConfigTemplate{}
+	if err := g.client.Get(ctx, bootstrap.Name, constants.EksaSystemNamespace, kct); err != nil {
+		return nil, fmt.Errorf("getting KubeadmConfigTemplate %s: %v", bootstrap.Name, err)
+	}
+
+	if join := kct.Spec.Template.Spec.JoinConfiguration; join != nil {
+		w.Taints = join.NodeRegistration.Taints
+		w.Labels = parseNodeLabels(join.NodeRegistration.KubeletExtraArgs[nodeLabelsKubeletArg])
+	}
+
+	machineConfigName := md.Name
+	if err := g.machineConfig(ctx, machineConfigName, md.Spec.Template.Spec.InfrastructureRef.Name, &kct.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	w.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: machineConfigName}
+
+	return w, nil
+}
+
+// machineConfig adds the machine config name built from the VSphereMachineTemplate templateName
+// and the kubeadm config of the machines. The datacenter config is built from the first one.
+func (g *generator) machineConfig(ctx context.Context, name, templateName string, kubeadmConfig *bootstrapv1.KubeadmConfigSpec) error {
+	template := &vspherev1.VSphereMachineTemplate{}
+	if err := g.client.Get(ctx, templateName, constants.EksaSystemNamespace, template); err != nil {
+		return fmt.Errorf("getting VSphereMachineTemplate %s: %v", templateName, err)
+	}
+
+	clone := template.Spec.Template.Spec.VirtualMachineCloneSpec
+	datacenter := vsphereDatacenterConfig(g.config.Cluster, clone)
+	if g.config.VSphereDatacenter == nil {
+		g.config.VSphereDatacenter = datacenter
+	} else if g.config.VSphereDatacenter.Spec != datacenter.Spec {
+		return fmt.Errorf("VSphereMachineTemplate %s uses a different vCenter, datacenter or network than the rest of the cluster, only clusters in a single one can be imported", templateName)
+	}
+
+	g.config.VSphereMachineConfigs[name] = vsphereMachineConfig(name, g.config.Cluster.Namespace, clone, kubeadmConfig)
+	return nil
+}
+
+// kubernetesVersion returns the major.minor Kubernetes version of a CAPI version.
+func kubernetesVersion(version string) (v1alpha1.KubernetesVersion, error) {
+	v, err := semver.New(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v1alpha1.KubernetesVersion(fmt.Sprintf("%d.%d", v.Major, v.Minor)), nil
+}
+
+func autoScalingConfiguration(md *clusterv1.MachineDeployment) (*v1alpha1.AutoScalingConfiguration, error) {
+	minSize, hasMin := md.Annotations[nodeGroupMinSizeAnnotation]
+	maxSize, hasMax := md.Annotations[nodeGroupMaxSizeAnnotation]
+	if !hasMin || !hasMax {
+		return nil, nil
+	}
+
+	minCount, err := strconv.Atoi(minSize)
+	if err != nil {
+		return nil, fmt.Errorf("parsing autoscaler min size of MachineDeployment %s: %v", md.Name, err)
+	}
+	maxCount, err := strconv.Atoi(maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("parsing autoscaler max size of MachineDeployment %s: %v", md.Name, err)
+	}
+
+	return &v1alpha1.AutoScalingConfiguration{MinCount: minCount, MaxCount: maxCount}, nil
+}
+
+// parseNodeLabels parses the node labels kubelet argument, in the form key=value[,key=value].
+func parseNodeLabels(arg string) map[string]string {
+	if arg == "" {
+		return nil
+	}
+
+	labels := map[string]string{}
+	for _, label := range strings.Split(arg, ",") {
+		key, value, _ := strings.Cut(label, "=")
+		labels[key] = value
+	}
+
+	return labels
+}
@@ -0,0 +1,417 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type importerTest struct {
+	*WithT
+	ctx context.Context
+
+	managementCluster *v1alpha1.Cluster
+	bundles           *releasev1.Bundles
+	capiCluster       *clusterv1.Cluster
+	vsphereCluster    *vspherev1.VSphereCluster
+	kcp               *controlplanev1.KubeadmControlPlane
+	cpTemplate        *vspherev1.VSphereMachineTemplate
+	md                *clusterv1.MachineDeployment
+	kct               *bootstrapv1.KubeadmConfigTemplate
+	workerTemplate    *vspherev1.VSphereMachineTemplate
+	kubeconfig        *corev1.Secret
+	extraObjs         []client.Object
+}
+
+func newImporterTest(t *testing.T) *importerTest {
+	ns := constants.EksaSystemNamespace
+	cloneSpec := func(template string, cpus int32) vspherev1.VirtualMachineCloneSpec {
+		return vspherev1.VirtualMachineCloneSpec{
+			Template:     template,
+			CloneMode:    vspherev1.LinkedClone,
+			Server:       "vcenter.example.com",
+			Thumbprint:   "AB:CD",
+			Datacenter:   "dc1",
+			Folder:       "/dc1/vm/legacy",
+			Datastore:    "/dc1/datastore/ds1",
+			ResourcePool: "/dc1/host/cluster1/Resources",
+			Network:      vspherev1.NetworkSpec{Devices: []vspherev1.NetworkDeviceSpec{{NetworkName: "/dc1/network/vm"}}},
+			NumCPUs:      cpus,
+			MemoryMiB:    8192,
+			DiskGiB:      25,
+		}
+	}
+	users := []bootstrapv1.User{{Name: "capv", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}}
+
+	return &importerTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		managementCluster: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+			Spec: v1alpha1.ClusterSpec{
+				ManagementCluster: v1alpha1.ManagementCluster{Name: "mgmt"},
+				BundlesRef:        &v1alpha1.BundlesRef{Name: "bundles-1", Namespace: ns, APIVersion: releasev1.GroupVersion.String()},
+			},
+		},
+		bundles: &releasev1.Bundles{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundles-1", Namespace: ns},
+			Spec: releasev1.BundlesSpec{
+				Number:          1,
+				VersionsBundles: []releasev1.VersionsBundle{{KubeVersion: "1.26"}, {KubeVersion: "1.27"}},
+			},
+		},
+		capiCluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: ns, Labels: map[string]string{"team": "infra"}},
+			Spec: clusterv1.ClusterSpec{
+				ClusterNetwork: &clusterv1.ClusterNetwork{
+					Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.10.0.0/16"}},
+					Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.20.0.0/16"}},
+				},
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.10", Port: 6443},
+				ControlPlaneRef:      &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "legacy"},
+				InfrastructureRef:    &corev1.ObjectReference{Kind: "VSphereCluster", Name: "legacy"},
+			},
+			Status: clusterv1.ClusterStatus{ControlPlaneReady: true, InfrastructureReady: true},
+		},
+		vsphereCluster: &vspherev1.VSphereCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: ns},
+			Spec:       vspherev1.VSphereClusterSpec{Server: "vcenter.example.com", Thumbprint: "AB:CD"},
+		},
+		kcp: &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: ns},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Replicas: ptr.Int32(3),
+				Version:  "v1.27.4+eks-1-27-10",
+				MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+					InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: "legacy-control-plane"},
+				},
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{ClusterName: "legacy"},
+					Users:                users,
+				},
+			},
+		},
+		cpTemplate: &vspherev1.VSphereMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-control-plane", Namespace: ns},
+			Spec: vspherev1.VSphereMachineTemplateSpec{
+				Template: vspherev1.VSphereMachineTemplateResource{
+					Spec: vspherev1.VSphereMachineSpec{VirtualMachineCloneSpec: cloneSpec("ubuntu-2204-kube-v1.27", 2)},
+				},
+			},
+		},
+		md: &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy-md-0",
+				Namespace: ns,
+				Annotations: map[string]string{
+					"cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size": "1",
+					"cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size": "5",
+				},
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				ClusterName: "legacy",
+				Replicas:    ptr.Int32(2),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						ClusterName:       "legacy",
+						Version:           ptr.String("v1.26.7+eks-1-26-19"),
+						Bootstrap:         clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "legacy-md-0"}},
+						InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: "legacy-worker"},
+					},
+				},
+			},
+		},
+		kct: &bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-md-0", Namespace: ns},
+			Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+				Template: bootstrapv1.KubeadmConfigTemplateResource{
+					Spec: bootstrapv1.KubeadmConfigSpec{
+						Users: users,
+						JoinConfiguration: &bootstrapv1.JoinConfiguration{
+							NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+								KubeletExtraArgs: map[string]string{"node-labels": "tier=backend,zone=a"},
+								Taints:           []corev1.Taint{{Key: "dedicated", Value: "backend", Effect: corev1.TaintEffectPreferNoSchedule}},
+							},
+						},
+					},
+				},
+			},
+		},
+		workerTemplate: &vspherev1.VSphereMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-worker", Namespace: ns},
+			Spec: vspherev1.VSphereMachineTemplateSpec{
+				Template: vspherev1.VSphereMachineTemplateResource{
+					Spec: vspherev1.VSphereMachineSpec{VirtualMachineCloneSpec: cloneSpec("ubuntu-2204-kube-v1.26", 4)},
+				},
+			},
+		},
+		kubeconfig: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-kubeconfig", Namespace: ns},
+			Data:       map[string][]byte{"value": []byte("legacy kubeconfig")},
+		},
+	}
+}
+
+func (tt *importerTest) client() kubernetes.Client {
+	objs := []client.Object{
+		tt.managementCluster, tt.bundles, tt.capiCluster, tt.vsphereCluster, tt.kcp, tt.cpTemplate,
+		tt.md, tt.kct, tt.workerTemplate, tt.kubeconfig,
+	}
+	return test.NewFakeKubeClient(append(objs, tt.extraObjs...)...)
+}
+
+func TestImporterGenerate(t *testing.T) {
+	tt := newImporterTest(t)
+	// A machine deployment of another cluster, which must be ignored.
+	other := tt.md.DeepCopy()
+	other.Name = "other-md-0"
+	other.Spec.ClusterName = "other"
+	tt.extraObjs = append(tt.extraObjs, other)
+
+	config, err := clusterimport.NewImporter(tt.client()).Generate(tt.ctx, "legacy", "clusters")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	c := config.Cluster
+	tt.Expect(c.Name).To(Equal("legacy"))
+	tt.Expect(c.Namespace).To(Equal("clusters"))
+	tt.Expect(c.TypeMeta.Kind).To(Equal(v1alpha1.ClusterKind))
+	tt.Expect(c.Spec.ManagementCluster.Name).To(Equal("mgmt"))
+	tt.Expect(c.Spec.BundlesRef).To(Equal(tt.managementCluster.Spec.BundlesRef))
+	tt.Expect(c.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube127))
+	tt.Expect(c.Spec.ClusterNetwork.Pods.CidrBlocks).To(ConsistOf("10.10.0.0/16"))
+	tt.Expect(c.Spec.ClusterNetwork.Services.CidrBlocks).To(ConsistOf("10.20.0.0/16"))
+	tt.Expect(*c.Spec.ClusterNetwork.CNIConfig.Cilium.SkipUpgrade).To(BeTrue())
+	tt.Expect(c.Spec.DatacenterRef).To(Equal(v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "legacy"}))
+	tt.Expect(c.Spec.ControlPlaneConfiguration).To(Equal(v1alpha1.ControlPlaneConfiguration{
+		Count:           3,
+		Endpoint:        &v1alpha1.Endpoint{Host: "10.0.0.10"},
+		MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "legacy-cp"},
+	}))
+
+	kube126 := v1alpha1.Kube126
+	tt.Expect(c.Spec.WorkerNodeGroupConfigurations).To(ConsistOf(v1alpha1.WorkerNodeGroupConfiguration{
+		Name:                     "md-0",
+		Count:                    ptr.Int(2),
+		AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+		MachineGroupRef:          &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "legacy-md-0"},
+		Taints:                   []corev1.Taint{{Key: "dedicated", Value: "backend", Effect: corev1.TaintEffectPreferNoSchedule}},
+		Labels:                   map[string]string{"tier": "backend", "zone": "a"},
+		KubernetesVersion:        &kube126,
+	}))
+
+	tt.Expect(config.VSphereDatacenter.Name).To(Equal("legacy"))
+	tt.Expect(config.VSphereDatacenter.Namespace).To(Equal("clusters"))
+	tt.Expect(config.VSphereDatacenter.Spec).To(Equal(v1alpha1.VSphereDatacenterConfigSpec{
+		Datacenter: "dc1",
+		Network:    "/dc1/network/vm",
+		Server:     "vcenter.example.com",
+		Thumbprint: "AB:CD",
+	}))
+
+	tt.Expect(config.VSphereMachineConfigs).To(HaveLen(2))
+	tt.Expect(config.VSphereMachineConfigs["legacy-cp"].Spec).To(Equal(v1alpha1.VSphereMachineConfigSpec{
+		DiskGiB:      25,
+		Datastore:    "/dc1/datastore/ds1",
+		Folder:       "/dc1/vm/legacy",
+		NumCPUs:      2,
+		MemoryMiB:    8192,
+		OSFamily:     v1alpha1.Ubuntu,
+		ResourcePool: "/dc1/host/cluster1/Resources",
+		Template:     "ubuntu-2204-kube-v1.27",
+		CloneMode:    v1alpha1.LinkedClone,
+		Users:        []v1alpha1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+	}))
+	tt.Expect(config.VSphereMachineConfigs["legacy-md-0"].Spec.NumCPUs).To(Equal(4))
+	tt.Expect(config.VSphereMachineConfigs["legacy-md-0"].Namespace).To(Equal("clusters"))
+}
+
+func TestImporterGenerateBottlerocket(t *testing.T) {
+	tt := newImporterTest(t)
+	tt.kcp.Spec.KubeadmConfigSpec.Format = bootstrapv1.Bottlerocket
+
+	config, err := clusterimport.NewImporter(tt.client()).Generate(tt.ctx, "legacy", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.VSphereMachineConfigs["legacy-cp"].Spec.OSFamily).To(Equal(v1alpha1.Bottlerocket))
+	tt.Expect(config.VSphereMachineConfigs["legacy-md-0"].Spec.OSFamily).To(Equal(v1alpha1.Ubuntu))
+}
+
+func TestImporterGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*importerTest)
+		wantErr string
+	}{
+		{
+			name: "not found",
+			mutate: func(tt *importerTest) {
+				tt.capiCluster.Name = "another"
+			},
+			wantErr: "CAPI cluster legacy not found in namespace eksa-system",
+		},
+		{
+			name: "already managed",
+			mutate: func(tt *importerTest) {
+				tt.capiCluster.Labels[clusterapi.EKSAClusterLabelName] = "legacy"
+			},
+			wantErr: "already managed by EKS-A cluster legacy",
+		},
+		{
+			name: "existing EKS-A cluster",
+			mutate: func(tt *importerTest) {
+				tt.extraObjs = append(tt.extraObjs, &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"}})
+			},
+			wantErr: "EKS-A cluster legacy already exists in namespace default",
+		},
+		{
+			name: "not vSphere",
+			mutate: func(tt *importerTest) {
+				tt.capiCluster.Spec.InfrastructureRef.Kind = "DockerCluster"
+			},
+			wantErr: "only vSphere clusters can be imported",
+		},
+		{
+			name: "control plane name",
+			mutate: func(tt *importerTest) {
+				tt.capiCluster.Spec.ControlPlaneRef.Name = "legacy-control-plane"
+			},
+			wantErr: "KubeadmControlPlane legacy-control-plane must be named after the cluster legacy",
+		},
+		{
+			name: "not ready",
+			mutate: func(tt *importerTest) {
+				tt.capiCluster.Status.ControlPlaneReady = false
+			},
+			wantErr: "CAPI cluster legacy is not ready",
+		},
+		{
+			name: "no management cluster",
+			mutate: func(tt *importerTest) {
+				tt.managementCluster.Spec.ManagementCluster.Name = "other"
+			},
+			wantErr: "no EKS-A management cluster found",
+		},
+		{
+			name: "external etcd",
+			mutate: func(tt *importerTest) {
+				tt.kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = &bootstrapv1.ExternalEtcd{Endpoints: []string{"https://10.0.0.20:2379"}}
+			},
+			wantErr: "only stacked etcd can be imported",
+		},
+		{
+			name: "machine deployment name",
+			mutate: func(tt *importerTest) {
+				tt.md.Name = "workers"
+			},
+			wantErr: "MachineDeployment workers must be named with the cluster name legacy as prefix",
+		},
+		{
+			name: "different datacenter",
+			mutate: func(tt *importerTest) {
+				tt.workerTemplate.Spec.Template.Spec.Datacenter = "dc2"
+			},
+			wantErr: "VSphereMachineTemplate legacy-worker uses a different vCenter, datacenter or network",
+		},
+		{
+			name: "unsupported version",
+			mutate: func(tt *importerTest) {
+				tt.kcp.Spec.Version = "v1.28.2"
+			},
+			wantErr: "kubernetes version 1.28 is not supported by bundles manifest 1",
+		},
+		{
+			name: "invalid autoscaler annotation",
+			mutate: func(tt *importerTest) {
+				tt.md.Annotations["cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"] = "many"
+			},
+			wantErr: "parsing autoscaler max size of MachineDeployment legacy-md-0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newImporterTest(t)
+			tc.mutate(tt)
+
+			_, err := clusterimport.NewImporter(tt.client()).Generate(tt.ctx, "legacy", "default")
+			tt.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestImporterImport(t *testing.T) {
+	tt := newImporterTest(t)
+	c := tt.client()
+	importer := clusterimport.NewImporter(c)
+
+	config, err := importer.Generate(tt.ctx, "legacy", "clusters")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(importer.Import(tt.ctx, config)).To(Succeed())
+
+	tt.Expect(c.Get(tt.ctx, "legacy", "clusters", &v1alpha1.Cluster{})).To(Succeed())
+	tt.Expect(c.Get(tt.ctx, "legacy", "clusters", &v1alpha1.VSphereDatacenterConfig{})).To(Succeed())
+	tt.Expect(c.Get(tt.ctx, "legacy-cp", "clusters", &v1alpha1.VSphereMachineConfig{})).To(Succeed())
+	tt.Expect(c.Get(tt.ctx, "legacy-md-0", "clusters", &v1alpha1.VSphereMachineConfig{})).To(Succeed())
+
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(c.Get(tt.ctx, "legacy", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	tt.Expect(capiCluster.Labels).To(Equal(map[string]string{
+		"team":                               "infra",
+		clusterapi.EKSAClusterLabelName:      "legacy",
+		clusterapi.EKSAClusterLabelNamespace: "clusters",
+	}))
+}
+
+func TestImporterImportRollback(t *testing.T) {
+	tt := newImporterTest(t)
+	c := tt.client()
+	importer := clusterimport.NewImporter(c)
+
+	config, err := importer.Generate(tt.ctx, "legacy", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	// A cluster created after generating the config makes the import fail on its last step.
+	tt.Expect(c.Create(tt.ctx, &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"}})).To(Succeed())
+
+	tt.Expect(importer.Import(tt.ctx, config)).To(MatchError(ContainSubstring("creating Cluster legacy")))
+
+	err = c.Get(tt.ctx, "legacy", "default", &v1alpha1.VSphereDatacenterConfig{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = c.Get(tt.ctx, "legacy-cp", "default", &v1alpha1.VSphereMachineConfig{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(c.Get(tt.ctx, "legacy", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	tt.Expect(capiCluster.Labels).To(Equal(map[string]string{"team": "infra"}))
+}
+
+func TestImporterKubeconfig(t *testing.T) {
+	tt := newImporterTest(t)
+
+	tt.Expect(clusterimport.NewImporter(tt.client()).Kubeconfig(tt.ctx, "legacy")).To(Equal([]byte("legacy kubeconfig")))
+}
+
+func TestImporterKubeconfigNotFound(t *testing.T) {
+	tt := newImporterTest(t)
+	tt.kubeconfig.Name = "other-kubeconfig"
+
+	_, err := clusterimport.NewImporter(tt.client()).Kubeconfig(tt.ctx, "legacy")
+	tt.Expect(err).To(MatchError(ContainSubstring("getting kubeconfig of cluster legacy")))
+}
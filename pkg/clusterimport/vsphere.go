@@ -0,0 +1,83 @@
+package clusterimport
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func vsphereDatacenterConfig(cluster *v1alpha1.Cluster, clone vspherev1.VirtualMachineCloneSpec) *v1alpha1.VSphereDatacenterConfig {
+	d := &v1alpha1.VSphereDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.VSphereDatacenterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Spec.DatacenterRef.Name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Datacenter: clone.Datacenter,
+			Server:     clone.Server,
+			Thumbprint: clone.Thumbprint,
+			Insecure:   clone.Thumbprint == "",
+		},
+	}
+	if len(clone.Network.Devices) > 0 {
+		d.Spec.Network = clone.Network.Devices[0].NetworkName
+	}
+
+	return d
+}
+
+func vsphereMachineConfig(name, namespace string, clone vspherev1.VirtualMachineCloneSpec, kubeadmConfig *bootstrapv1.KubeadmConfigSpec) *v1alpha1.VSphereMachineConfig {
+	m := &v1alpha1.VSphereMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.VSphereMachineConfigKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			DiskGiB:           int(clone.DiskGiB),
+			Datastore:         clone.Datastore,
+			Folder:            clone.Folder,
+			NumCPUs:           int(clone.NumCPUs),
+			MemoryMiB:         int(clone.MemoryMiB),
+			OSFamily:          osFamily(clone.Template, kubeadmConfig),
+			ResourcePool:      clone.ResourcePool,
+			StoragePolicyName: clone.StoragePolicyName,
+			Template:          clone.Template,
+			TagIDs:            clone.TagIDs,
+			CloneMode:         v1alpha1.CloneMode(clone.CloneMode),
+		},
+	}
+
+	for _, u := range kubeadmConfig.Users {
+		m.Spec.Users = append(m.Spec.Users, v1alpha1.UserConfiguration{
+			Name:              u.Name,
+			SshAuthorizedKeys: u.SSHAuthorizedKeys,
+		})
+	}
+
+	return m
+}
+
+// osFamily guesses the OS family of the machines from the bootstrap format and the name of the VM template.
+func osFamily(template string, kubeadmConfig *bootstrapv1.KubeadmConfigSpec) v1alpha1.OSFamily {
+	template = strings.ToLower(template)
+	switch {
+	case kubeadmConfig.Format == bootstrapv1.Bottlerocket, strings.Contains(template, "bottlerocket"):
+		return v1alpha1.Bottlerocket
+	case strings.Contains(template, "rhel"), strings.Contains(template, "redhat"):
+		return v1alpha1.RedHat
+	default:
+		return v1alpha1.Ubuntu
+	}
+}
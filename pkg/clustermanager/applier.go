@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/utils/clock"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
@@ -34,6 +35,7 @@ type Applier struct {
 	clientFactory                                                       ClientFactory
 	applyClusterTimeout, waitForClusterReconcile, waitForFailureMessage time.Duration
 	retryBackOff                                                        time.Duration
+	clock                                                               clock.Clock
 }
 
 // NewApplier builds an Applier.
@@ -45,6 +47,7 @@ func NewApplier(log logr.Logger, clientFactory ClientFactory, opts ...ApplierOpt
 		waitForClusterReconcile: waitForClusterReconcileTimeout,
 		waitForFailureMessage:   waitForFailureMessageErrorTimeout,
 		retryBackOff:            retryBackOff,
+		clock:                   clock.RealClock{},
 	}
 
 	for _, opt := range opts {
@@ -91,6 +94,15 @@ func WithApplierRetryBackOff(backOff time.Duration) ApplierOpt {
 	}
 }
 
+// WithApplierClock allows to configure the clock the applier measures its timeouts with and
+// waits between retries with.
+// Generally only used in tests.
+func WithApplierClock(c clock.Clock) ApplierOpt {
+	return func(a *Applier) {
+		a.clock = c
+	}
+}
+
 // Run applies the cluster's spec in the management cluster and waits
 // until the changes are fully reconciled.
 func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster types.Cluster) error {
@@ -100,6 +112,7 @@ func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster
 	err := retrier.New(
 		a.applyClusterTimeout,
 		retrier.WithRetryPolicy(retrier.BackOffPolicy(a.retryBackOff)),
+		retrier.WithClock(a.clock),
 	).Retry(func() error {
 		// We build the client inside the retrier to take advantage of the configurable timeout.
 		// The only time when a client can fail to init is when there is a transient error contacting
@@ -130,7 +143,7 @@ func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster
 	}
 
 	// We use this start time to compute the leftover time on each condition wait
-	waitStartTime := time.Now()
+	waitStartTime := a.clock.Now()
 	retry := a.retrierForWait(waitStartTime)
 
 	if err := cluster.WaitFor(ctx, client, spec.Cluster, a.retrierForFailureMessage(), func(c *anywherev1.Cluster) error {
@@ -172,8 +185,9 @@ func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster
 
 func (a Applier) retrierForWait(waitStartTime time.Time) *retrier.Retrier {
 	return retrier.New(
-		a.waitForClusterReconcile-time.Since(waitStartTime),
+		a.waitForClusterReconcile-a.clock.Since(waitStartTime),
 		retrier.WithRetryPolicy(retrier.BackOffPolicy(a.retryBackOff)),
+		retrier.WithClock(a.clock),
 	)
 }
 
@@ -181,5 +195,6 @@ func (a Applier) retrierForFailureMessage() *retrier.Retrier {
 	return retrier.New(
 		a.waitForFailureMessage,
 		retrier.WithRetryPolicy(retrier.BackOffPolicy(a.retryBackOff)),
+		retrier.WithClock(a.clock),
 	)
 }
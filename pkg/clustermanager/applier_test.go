@@ -10,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clocktesting "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/cluster-api/util/conditions"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -185,6 +186,7 @@ func TestApplierRunFailureMessage(t *testing.T) {
 	tt.startFakeController()
 	a := clustermanager.NewApplier(tt.log, tt.clientFactory,
 		clustermanager.WithApplierRetryBackOff(time.Millisecond),
+		clustermanager.WithApplierClock(clocktesting.NewFakeClock(time.Now())),
 	)
 
 	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("cluster has a validation error that doesn't seem transient")))
@@ -235,3 +237,17 @@ func TestApplierRunClusterNotReady(t *testing.T) {
 
 	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("waiting for cluster to be ready")))
 }
+
+func TestApplierRunControlPlaneNotReadyWithClock(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.buildClient(tt.spec.ClusterAndChildren()...)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	a := clustermanager.NewApplier(tt.log, tt.clientFactory,
+		clustermanager.WithApplierClock(fakeClock),
+	)
+
+	start := fakeClock.Now()
+	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("waiting for cluster's control plane to be ready")))
+	// The default timeouts are used, but the fake clock doesn't wait for them.
+	tt.Expect(fakeClock.Since(start)).To(BeNumerically(">=", time.Hour))
+}
@@ -16,11 +16,13 @@ import (
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/integer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-anywhere/pkg/accessentries"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -31,6 +33,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/imagecache"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -67,6 +70,8 @@ const (
 	DefaultNodeStartupTimeout = 10 * time.Minute
 	// DefaultClusterctlMoveTimeout is arbitrarily established.  Equal to kubectl wait default timeouts.
 	DefaultClusterctlMoveTimeout = 30 * time.Minute
+	// maxSpecRevisions is the number of cluster spec revisions kept per cluster.
+	maxSpecRevisions = 10
 )
 
 var (
@@ -84,6 +89,8 @@ type ClusterManager struct {
 	networking         Networking
 	diagnosticsFactory diagnostics.DiagnosticBundleFactory
 	awsIamAuth         AwsIamAuth
+	policyEngine       PolicyEngine
+	certManager        CertManager
 
 	machineMaxWait                   time.Duration
 	machineBackoff                   time.Duration
@@ -96,6 +103,8 @@ type ClusterManager struct {
 	clusterWaitTimeout               time.Duration
 	deploymentWaitTimeout            time.Duration
 	clusterctlMoveTimeout            time.Duration
+
+	templateDiffWriter io.Writer
 }
 
 // ClientFactory builds Kubernetes clients.
@@ -171,6 +180,16 @@ type EKSAComponents interface {
 	Upgrade(ctx context.Context, log logr.Logger, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 }
 
+// PolicyEngine installs and upgrades the managed policy engine add-on.
+type PolicyEngine interface {
+	Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
+}
+
+// CertManager installs and upgrades the cert-manager add-on and its ClusterIssuers.
+type CertManager interface {
+	Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
+}
+
 type ClusterManagerOpt func(*ClusterManager)
 
 // DefaultRetrier builds a retrier with the default configuration.
@@ -260,6 +279,20 @@ func WithRetrier(retrier *retrier.Retrier) ClusterManagerOpt {
 	}
 }
 
+// WithPolicyEngine sets the installer for the policy engine add-on.
+func WithPolicyEngine(policyEngine PolicyEngine) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.policyEngine = policyEngine
+	}
+}
+
+// WithCertManager sets the installer for the cert-manager add-on.
+func WithCertManager(certManager CertManager) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.certManager = certManager
+	}
+}
+
 // WithNoTimeouts disables the timeout for all the waits and retries in cluster manager.
 func WithNoTimeouts() ClusterManagerOpt {
 	return func(c *ClusterManager) {
@@ -277,6 +310,14 @@ func WithNoTimeouts() ClusterManagerOpt {
 	}
 }
 
+// WithTemplateDiffWriter makes the cluster manager write to w the changes the cluster
+// templateOverrides make to the generated CAPI objects before applying them.
+func WithTemplateDiffWriter(w io.Writer) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.templateDiffWriter = w
+	}
+}
+
 func clusterctlMoveWaitForInfrastructureRetryPolicy(totalRetries int, err error) (retry bool, wait time.Duration) {
 	// Retry both network and cluster move errors.
 	if match := (clusterctlNetworkErrorRegex.MatchString(err.Error()) || clusterctlMoveProvisionedInfraErrorRegex.MatchString(err.Error())); match {
@@ -499,6 +540,13 @@ func (c *ClusterManager) applyProviderManifests(
 
 	content := templater.AppendYamlResources(cpContent, mdContent)
 
+	err = c.writeTemplateOverridesDiff(spec, provider, func(s *cluster.Spec) ([]byte, []byte, error) {
+		return provider.GenerateCAPISpecForCreate(ctx, management, s)
+	})
+	if err != nil {
+		return err
+	}
+
 	if err = c.writeCAPISpecFile(spec.Cluster.Name, content); err != nil {
 		return err
 	}
@@ -511,6 +559,37 @@ func (c *ClusterManager) applyProviderManifests(
 	return nil
 }
 
+// writeTemplateOverridesDiff generates the CAPI objects without the cluster templateOverrides
+// and writes the changes the overrides make to them to the template diff writer, if configured.
+func (c *ClusterManager) writeTemplateOverridesDiff(
+	spec *cluster.Spec,
+	provider providers.Provider,
+	generate func(*cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error),
+) error {
+	overrides := spec.Cluster.Spec.TemplateOverrides
+	if c.templateDiffWriter == nil || len(overrides) == 0 {
+		return nil
+	}
+
+	base := spec.DeepCopy()
+	base.Cluster.Spec.TemplateOverrides = nil
+	cpContent, mdContent, err := generate(base)
+	if err != nil {
+		return fmt.Errorf("generating capi spec without templateOverrides: %v", err)
+	}
+
+	diff, err := clusterapi.TemplateOverridesDiff(templater.AppendYamlResources(cpContent, mdContent), overrides, provider.Capabilities().TemplateOverridePaths)
+	if err != nil {
+		return fmt.Errorf("generating templateOverrides diff: %v", err)
+	}
+
+	if _, err = io.WriteString(c.templateDiffWriter, diff); err != nil {
+		return fmt.Errorf("writing templateOverrides diff: %v", err)
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) getWorkloadClusterKubeconfig(ctx context.Context, clusterName string, managementCluster *types.Cluster, w io.Writer) error {
 	kubeconfig, err := c.clusterClient.GetWorkloadKubeconfig(ctx, clusterName, managementCluster)
 	if err != nil {
@@ -604,6 +683,15 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("generating capi spec: %v", err)
 	}
 
+	err = c.writeTemplateOverridesDiff(newClusterSpec, provider, func(s *cluster.Spec) ([]byte, []byte, error) {
+		current := currentSpec.DeepCopy()
+		current.Cluster.Spec.TemplateOverrides = nil
+		return provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, current, s)
+	})
+	if err != nil {
+		return err
+	}
+
 	if err = c.writeCAPISpecFile(newClusterSpec.Cluster.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
 		return err
 	}
@@ -729,6 +817,11 @@ func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, clus *types
 		return true, nil
 	}
 
+	if specHashMatches(cc, newClusterSpec) {
+		logger.V(3).Info("New cluster spec hash matches the reconciled spec hash")
+		return false, nil
+	}
+
 	currentClusterSpec, err := c.buildSpecForCluster(ctx, clus, cc)
 	if err != nil {
 		return false, err
@@ -745,6 +838,23 @@ func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, clus *types
 	return changed, nil
 }
 
+// specHashMatches checks if the hash of the new cluster spec is the same as the one of the
+// last spec reconciled by the controller, which avoids fetching and comparing all the
+// cluster child objects. A spec that can't be hashed is considered different.
+func specHashMatches(current *v1alpha1.Cluster, newClusterSpec *cluster.Spec) bool {
+	if current.Status.SpecHash == "" || current.Status.ReconciledGeneration != current.Generation {
+		return false
+	}
+
+	newHash, err := newClusterSpec.Config.SpecHash()
+	if err != nil {
+		logger.V(4).Info("Failed to compute new cluster spec hash", "error", err)
+		return false
+	}
+
+	return newHash == current.Status.SpecHash
+}
+
 func compareEKSAClusterSpec(ctx context.Context, currentClusterSpec, newClusterSpec *cluster.Spec) (bool, error) {
 	newVersionsBundle := newClusterSpec.RootVersionsBundle()
 	oldVersionsBundle := currentClusterSpec.RootVersionsBundle()
@@ -843,6 +953,54 @@ func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, cluster
 	return nil
 }
 
+// InstallImageWarmCache installs the DaemonSet that pre-pulls the cluster critical images on every node.
+func (c *ClusterManager) InstallImageWarmCache(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	ds, err := templater.ObjectsToYaml(imagecache.DaemonSet(clusterSpec))
+	if err != nil {
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, ds); err != nil {
+		return fmt.Errorf("applying image warm cache: %v", err)
+	}
+	return nil
+}
+
+// InstallAccessEntries applies the ClusterRoleBindings for the cluster access entries.
+func (c *ClusterManager) InstallAccessEntries(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	bindings := accessentries.ClusterRoleBindings(clusterSpec.Cluster)
+	objs := make([]runtime.Object, 0, len(bindings))
+	for _, b := range bindings {
+		objs = append(objs, b)
+	}
+
+	manifest, err := templater.ObjectsToYaml(objs...)
+	if err != nil {
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, manifest); err != nil {
+		return fmt.Errorf("applying access entries: %v", err)
+	}
+	return nil
+}
+
+// InstallPolicyEngine installs or upgrades the policy engine add-on and its baseline policies.
+func (c *ClusterManager) InstallPolicyEngine(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	if c.policyEngine == nil {
+		return errors.New("policy engine installer is not configured")
+	}
+	return c.policyEngine.Install(ctx, workloadCluster, clusterSpec)
+}
+
+// InstallCertManager installs or upgrades the cert-manager add-on and its ClusterIssuers.
+func (c *ClusterManager) InstallCertManager(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	if c.certManager == nil {
+		return errors.New("cert-manager installer is not configured")
+	}
+	return c.certManager.Install(ctx, workloadCluster, clusterSpec)
+}
+
 // InstallAwsIamAuth applies the aws-iam-authenticator manifest based on cluster spec inputs.
 // Generates a kubeconfig for interacting with the cluster with aws-iam-authenticator client.
 func (c *ClusterManager) InstallAwsIamAuth(ctx context.Context, management, workload *types.Cluster, spec *cluster.Spec) error {
@@ -1167,6 +1325,41 @@ func (c *ClusterManager) CreateEKSAResources(ctx context.Context, cluster *types
 	return c.ApplyReleases(ctx, clusterSpec, cluster)
 }
 
+// CreateSpecRevision records the cluster spec applied to the cluster as a new ClusterSpecRevision in the
+// cluster that holds the EKS-A objects, so it can be inspected or rolled back to later.
+func (c *ClusterManager) CreateSpecRevision(ctx context.Context, clus *types.Cluster, clusterSpec *cluster.Spec,
+	datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig,
+) error {
+	resourcesSpec, err := clustermarshaller.MarshalClusterSpec(clusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.ClientFactory.BuildClientFromKubeconfig(clus.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	revision, err := cluster.CreateSpecRevision(ctx, client, clusterSpec, resourcesSpec, maxSpecRevisions)
+	if err != nil {
+		return err
+	}
+	logger.V(3).Info("Cluster spec revision created", "cluster", clusterSpec.Cluster.Name, "revision", revision.Spec.Revision)
+
+	return nil
+}
+
+// RecordOperation creates or updates op, a ClusterOperation recording a lifecycle operation run by
+// the CLI, in the cluster that holds the EKS-A objects.
+func (c *ClusterManager) RecordOperation(ctx context.Context, clus *types.Cluster, op *v1alpha1.ClusterOperation) error {
+	client, err := c.ClientFactory.BuildClientFromKubeconfig(clus.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	return cluster.RecordOperation(ctx, client, op)
+}
+
 func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	bundleObj, err := yaml.Marshal(clusterSpec.Bundles)
 	if err != nil {
@@ -1,6 +1,7 @@
 package clustermanager_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/accessentries"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
@@ -27,10 +29,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	mockswriter "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/imagecache"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	mocksprovider "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
@@ -345,6 +349,53 @@ func TestClusterManagerCreateWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerCreateWorkloadClusterTemplateDiff(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	overrides := []v1alpha1.TemplateOverride{
+		{
+			Kind:  "KubeadmConfigTemplate",
+			Patch: "spec:\n  template:\n    spec:\n      postKubeadmCommands:\n      - echo done\n",
+		},
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.TemplateOverrides = overrides
+	})
+	generated := []byte("apiVersion: bootstrap.cluster.x-k8s.io/v1beta1\nkind: KubeadmConfigTemplate\nmetadata:\n  name: cluster-name-md-0-1\nspec:\n  template:\n    spec: {}\n")
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+
+	diff := &bytes.Buffer{}
+	c, m := newClusterManager(t, clustermanager.WithTemplateDiffWriter(diff))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, clusterSpec)
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, mgmtCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, s *cluster.Spec) ([]byte, []byte, error) {
+			g.Expect(s.Cluster.Spec.TemplateOverrides).To(BeEmpty())
+			return nil, generated, nil
+		},
+	)
+	m.provider.EXPECT().Capabilities().Return(providers.Capabilities{
+		TemplateOverridePaths: map[string][]string{"KubeadmConfigTemplate": {"spec.template.spec.postKubeadmCommands"}},
+	})
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, mgmtCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().WaitForControlPlaneAvailable(ctx, mgmtCluster, "1h0m0s", clusterName)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, mgmtCluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	_, err := c.CreateWorkloadCluster(ctx, mgmtCluster, clusterSpec, m.provider)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff.String()).To(ContainSubstring("+++ KubeadmConfigTemplate cluster-name-md-0-1 (templateOverrides)"))
+	g.Expect(diff.String()).To(ContainSubstring("+      postKubeadmCommands:\n+      - echo done\n"))
+}
+
 func TestClusterManagerCreateWorkloadClusterErrorGetKubeconfig(t *testing.T) {
 	tt := newTest(t)
 	tt.clusterSpec.Cluster.Name = tt.clusterName
@@ -1988,6 +2039,82 @@ func TestInstallMachineHealthChecksWithNoTimeout(t *testing.T) {
 	tt.Expect(tt.clusterManager.InstallMachineHealthChecks(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
 }
 
+func TestInstallImageWarmCache(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.ImageWarmCache = &v1alpha1.ImageWarmCacheConfiguration{}
+	ds, err := templater.ObjectsToYaml(imagecache.DaemonSet(tt.clusterSpec))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, ds)
+
+	tt.Expect(tt.clusterManager.InstallImageWarmCache(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallImageWarmCacheApplyError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+	tt.clusterSpec.Cluster.Spec.ImageWarmCache = &v1alpha1.ImageWarmCacheConfiguration{}
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("apply error")).Times(2)
+
+	tt.Expect(tt.clusterManager.InstallImageWarmCache(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError(ContainSubstring("applying image warm cache: apply error")))
+}
+
+func TestInstallPolicyEngine(t *testing.T) {
+	policyEngine := mocksmanager.NewMockPolicyEngine(gomock.NewController(t))
+	tt := newTest(t, clustermanager.WithPolicyEngine(policyEngine))
+	policyEngine.EXPECT().Install(tt.ctx, tt.cluster, tt.clusterSpec)
+
+	tt.Expect(tt.clusterManager.InstallPolicyEngine(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallPolicyEngineError(t *testing.T) {
+	policyEngine := mocksmanager.NewMockPolicyEngine(gomock.NewController(t))
+	tt := newTest(t, clustermanager.WithPolicyEngine(policyEngine))
+	policyEngine.EXPECT().Install(tt.ctx, tt.cluster, tt.clusterSpec).Return(errors.New("helm error"))
+
+	tt.Expect(tt.clusterManager.InstallPolicyEngine(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError("helm error"))
+}
+
+func TestInstallPolicyEngineNotConfigured(t *testing.T) {
+	tt := newTest(t)
+
+	tt.Expect(tt.clusterManager.InstallPolicyEngine(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError("policy engine installer is not configured"))
+}
+
+func TestInstallCertManager(t *testing.T) {
+	certManager := mocksmanager.NewMockCertManager(gomock.NewController(t))
+	tt := newTest(t, clustermanager.WithCertManager(certManager))
+	certManager.EXPECT().Install(tt.ctx, tt.cluster, tt.clusterSpec)
+
+	tt.Expect(tt.clusterManager.InstallCertManager(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallCertManagerNotConfigured(t *testing.T) {
+	tt := newTest(t)
+
+	tt.Expect(tt.clusterManager.InstallCertManager(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError("cert-manager installer is not configured"))
+}
+
+func TestInstallAccessEntries(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.AccessEntries = []v1alpha1.AccessEntry{
+		{Group: "developers", ClusterRole: "view"},
+		{Group: "oidc:admins", ClusterRole: "cluster-admin"},
+	}
+	bindings := accessentries.ClusterRoleBindings(tt.clusterSpec.Cluster)
+	manifest, err := templater.ObjectsToYaml(bindings[0], bindings[1])
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, manifest)
+
+	tt.Expect(tt.clusterManager.InstallAccessEntries(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestInstallAccessEntriesApplyError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+	tt.clusterSpec.Cluster.Spec.AccessEntries = []v1alpha1.AccessEntry{{Group: "developers", ClusterRole: "view"}}
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("apply error")).Times(2)
+
+	tt.Expect(tt.clusterManager.InstallAccessEntries(tt.ctx, tt.clusterSpec, tt.cluster)).To(MatchError(ContainSubstring("applying access entries: apply error")))
+}
+
 func TestInstallMachineHealthChecksApplyError(t *testing.T) {
 	ctx := context.Background()
 	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
@@ -2560,6 +2687,41 @@ func TestClusterManagerClusterSpecChangedNoChanges(t *testing.T) {
 	assert.False(t, diff, "No changes should have been detected")
 }
 
+func TestClusterManagerClusterSpecChangedSpecHashMatches(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	specHash, err := tt.clusterSpec.Config.SpecHash()
+	if err != nil {
+		t.Fatalf("could not compute spec hash: %v", err)
+	}
+	tt.oldClusterConfig.Status.SpecHash = specHash
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	assert.Nil(t, err, "Error should be nil")
+	assert.False(t, diff, "No changes should have been detected")
+}
+
+func TestClusterManagerClusterSpecChangedSpecHashMatchesNotReconciled(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	specHash, err := tt.clusterSpec.Config.SpecHash()
+	if err != nil {
+		t.Fatalf("could not compute spec hash: %v", err)
+	}
+	tt.oldClusterConfig.Generation = 2
+	tt.oldClusterConfig.Status.ReconciledGeneration = 1
+	tt.oldClusterConfig.Status.SpecHash = specHash
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	diffBundle := test.VersionBundle()
+	diffBundle.EksD.Name = "different"
+	tt.clusterSpec.VersionsBundles[v1alpha1.Kube119] = diffBundle
+
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, diff, "Changes should have been detected")
+}
+
 func TestClusterManagerClusterSpecChangedNewEksdRelease(t *testing.T) {
 	tt := newSpecChangedTest(t)
 
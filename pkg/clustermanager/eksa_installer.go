@@ -12,8 +12,12 @@ import (
 	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -22,6 +26,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
@@ -65,11 +70,14 @@ func (i *EKSAInstaller) Install(ctx context.Context, log logr.Logger, cluster *t
 		return err
 	}
 
-	objs := make([]runtime.Object, 0, len(components.rest)+1)
+	objs := make([]runtime.Object, 0, len(components.rest)+2)
 	objs = append(objs, components.deployment)
 	for _, o := range components.rest {
 		objs = append(objs, o)
 	}
+	if components.podDisruptionBudget != nil {
+		objs = append(objs, components.podDisruptionBudget)
+	}
 
 	for _, o := range objs {
 		if err = i.client.Apply(ctx, cluster.KubeconfigFile, o); err != nil {
@@ -94,7 +102,15 @@ func (i *EKSAInstaller) Upgrade(ctx context.Context, log logr.Logger, c *types.C
 	}
 	changeDiff := EksaChangeDiff(currentSpec, newSpec)
 	if changeDiff == nil {
-		log.V(1).Info("Nothing to upgrade for controller and CRDs")
+		if !controllerConfigChanged(currentSpec, newSpec) {
+			log.V(1).Info("Nothing to upgrade for controller and CRDs")
+			return nil, nil
+		}
+
+		log.V(1).Info("Reconfiguring EKS-A controller")
+		if err := i.Install(ctx, log, c, newSpec); err != nil {
+			return nil, fmt.Errorf("reconfiguring EKS-A controller: %v", err)
+		}
 		return nil, nil
 	}
 	log.V(1).Info("Starting EKS-A components upgrade")
@@ -115,6 +131,45 @@ type EKSAComponentGenerator struct {
 	reader manifests.FileReader
 }
 
+// NewEKSAComponentGenerator builds an EKSAComponentGenerator.
+func NewEKSAComponentGenerator(log logr.Logger, reader manifests.FileReader) *EKSAComponentGenerator {
+	return &EKSAComponentGenerator{
+		log:    log,
+		reader: reader,
+	}
+}
+
+// EKSAComponentObjects are the eks-a components objects, configured for a cluster.
+type EKSAComponentObjects struct {
+	CRDs []client.Object
+	// Objects are all the objects that are not CRDs, except the controller Deployment.
+	Objects    []client.Object
+	Deployment *appsv1.Deployment
+}
+
+// Objects generates the eks-a components for the bundle of a spec, split so they can be applied
+// in order during an in place upgrade.
+func (g *EKSAComponentGenerator) Objects(spec *cluster.Spec) (*EKSAComponentObjects, error) {
+	components, err := g.buildEKSAComponentsSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &EKSAComponentObjects{Deployment: components.deployment}
+	for _, o := range components.rest {
+		if o.GetKind() == "CustomResourceDefinition" {
+			c.CRDs = append(c.CRDs, o)
+		} else {
+			c.Objects = append(c.Objects, o)
+		}
+	}
+	if components.podDisruptionBudget != nil {
+		c.Objects = append(c.Objects, components.podDisruptionBudget)
+	}
+
+	return c, nil
+}
+
 func (g *EKSAComponentGenerator) buildEKSAComponentsSpec(spec *cluster.Spec) (*eksaComponents, error) {
 	components, err := g.parseEKSAComponentsSpec(spec)
 	if err != nil {
@@ -132,6 +187,8 @@ func (g *EKSAComponentGenerator) configureEKSAComponents(c *eksaComponents, spec
 	// so leaving this for later.
 	setManagerFlags(c.deployment, spec)
 	setManagerEnvVars(c.deployment, spec)
+	setManagerReplicas(c.deployment, spec)
+	c.podDisruptionBudget = managerPodDisruptionBudget(c.deployment, spec)
 }
 
 func setManagerFlags(d *appsv1.Deployment, spec *cluster.Spec) {
@@ -145,6 +202,15 @@ func setManagerFlags(d *appsv1.Deployment, spec *cluster.Spec) {
 		args = append(args, fmt.Sprintf("--feature-gates=%s", strings.Join(gates, ",")))
 	}
 
+	if c := spec.Cluster.Spec.ManagementControllers; c != nil && c.LeaderElection != nil {
+		leaseDuration, renewDeadline, retryPeriod := c.LeaderElection.Durations()
+		args = append(args,
+			fmt.Sprintf("--leader-elect-lease-duration=%s", leaseDuration),
+			fmt.Sprintf("--leader-elect-renew-deadline=%s", renewDeadline),
+			fmt.Sprintf("--leader-elect-retry-period=%s", retryPeriod),
+		)
+	}
+
 	d.Spec.Template.Spec.Containers[0].Args = args
 }
 
@@ -162,6 +228,78 @@ func setManagerEnvVars(d *appsv1.Deployment, spec *cluster.Spec) {
 	d.Spec.Template.Spec.Containers[0].Env = envVars
 }
 
+// setManagerReplicas sets the replicas of the controller Deployment. With more than one replica,
+// the pods prefer running in different nodes, so the controller keeps running when a node fails.
+func setManagerReplicas(d *appsv1.Deployment, spec *cluster.Spec) {
+	c := spec.Cluster.Spec.ManagementControllers
+	if c == nil || c.Replicas == 0 {
+		return
+	}
+
+	d.Spec.Replicas = ptr.Int32(int32(c.Replicas))
+	if c.Replicas == 1 {
+		return
+	}
+
+	if d.Spec.Template.Spec.Affinity == nil {
+		d.Spec.Template.Spec.Affinity = &v1.Affinity{}
+	}
+	d.Spec.Template.Spec.Affinity.PodAntiAffinity = &v1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: v1.PodAffinityTerm{
+					LabelSelector: d.Spec.Selector.DeepCopy(),
+					TopologyKey:   v1.LabelHostname,
+				},
+			},
+		},
+	}
+}
+
+// managerPodDisruptionBudget returns a PodDisruptionBudget for the controller Deployment when it
+// runs more than one replica, so node drains don't evict all the replicas at the same time.
+func managerPodDisruptionBudget(d *appsv1.Deployment, spec *cluster.Spec) *policyv1.PodDisruptionBudget {
+	if spec.Cluster.Spec.ManagementControllers.ControllerReplicas() < 2 {
+		return nil
+	}
+
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.Name,
+			Namespace: d.Namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       d.Spec.Selector.DeepCopy(),
+		},
+	}
+}
+
+// controllerConfigChanged checks if the configuration of the controller Deployment changed
+// between two specs, which requires reinstalling it even if the eks-a version didn't change.
+func controllerConfigChanged(currentSpec, newSpec *cluster.Spec) bool {
+	currentConfig := currentSpec.Cluster.Spec.ManagementControllers
+	newConfig := newSpec.Cluster.Spec.ManagementControllers
+	if currentConfig.ControllerReplicas() != newConfig.ControllerReplicas() {
+		return true
+	}
+
+	var currentLeaderElection, newLeaderElection *anywherev1.LeaderElectionConfiguration
+	if currentConfig != nil {
+		currentLeaderElection = currentConfig.LeaderElection
+	}
+	if newConfig != nil {
+		newLeaderElection = newConfig.LeaderElection
+	}
+	return !currentLeaderElection.Equal(newLeaderElection)
+}
+
 func managerEnabledGates(spec *cluster.Spec) []string {
 	g := []string{}
 	// TODO(pjshah): remove this feature flag after we implement kindless upgrade managaement feature for all the providers.
@@ -216,8 +354,9 @@ func (g *EKSAComponentGenerator) parseEKSAComponentsSpec(spec *cluster.Spec) (*e
 }
 
 type eksaComponents struct {
-	deployment *appsv1.Deployment
-	rest       []*unstructured.Unstructured
+	deployment          *appsv1.Deployment
+	podDisruptionBudget *policyv1.PodDisruptionBudget
+	rest                []*unstructured.Unstructured
 }
 
 func (c *eksaComponents) BuildFromParsed(lookup yamlutil.ObjectLookup) error {
@@ -4,12 +4,14 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -71,7 +74,7 @@ func TestEKSAInstallerInstallSuccessWithRealManifest(t *testing.T) {
 	tt := newInstallerTest(t)
 	tt.newSpec.VersionsBundles["1.19"].Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
 	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}))
-	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(34) // there are 34 objects in the manifest
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(41) // there are 41 objects in the manifest
 	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m0s", "Available", "eksa-controller-manager", "eksa-system")
 
 	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
@@ -147,7 +150,7 @@ func TestEKSAInstallerInstallSuccessWithNoTimeout(t *testing.T) {
 	tt := newInstallerTest(t, clustermanager.WithEKSAInstallerNoTimeouts())
 	tt.newSpec.VersionsBundles["1.19"].Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
 	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}))
-	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(34) // there are 34 objects in the manifest
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(41) // there are 41 objects in the manifest
 	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, maxTime.String(), "Available", "eksa-controller-manager", "eksa-system")
 
 	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
@@ -190,6 +193,33 @@ func TestInstallerUpgradeSuccess(t *testing.T) {
 	tt.Expect(tt.installer.Upgrade(tt.ctx, tt.log, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
 }
 
+func TestInstallerUpgradeControllerConfigChanged(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundles["1.19"].Eksa.Components = v1alpha1.Manifest{
+		URI: "testdata/eksa_components.yaml",
+	}
+	tt.newSpec.Cluster.Spec.ManagementControllers = &anywherev1.ManagementControllersConfiguration{Replicas: 3}
+
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}))
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&unstructured.Unstructured{}))
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&policyv1.PodDisruptionBudget{}))
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m0s", "Available", "eksa-controller-manager", "eksa-system")
+	tt.Expect(tt.installer.Upgrade(tt.ctx, tt.log, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
+func TestInstallerUpgradeLeaderElectionChangedError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.Cluster.Spec.ManagementControllers = &anywherev1.ManagementControllersConfiguration{
+		LeaderElection: &anywherev1.LeaderElectionConfiguration{
+			LeaseDuration: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	// components file not set so this should return an error in failing to load manifest
+	_, err := tt.installer.Upgrade(tt.ctx, tt.log, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reconfiguring EKS-A controller")))
+}
+
 func TestInstallerUpgradeInstallError(t *testing.T) {
 	tt := newInstallerTest(t)
 
@@ -200,6 +230,49 @@ func TestInstallerUpgradeInstallError(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestEKSAComponentGeneratorObjectsRealManifest(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundles["1.19"].Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
+	g := clustermanager.NewEKSAComponentGenerator(tt.log, files.NewReader())
+
+	components, err := g.Objects(tt.newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(components.CRDs).To(HaveLen(26))
+	for _, crd := range components.CRDs {
+		tt.Expect(crd.GetObjectKind().GroupVersionKind().Kind).To(Equal("CustomResourceDefinition"))
+	}
+	tt.Expect(components.Objects).To(HaveLen(15))
+	tt.Expect(components.Deployment.Name).To(Equal("eksa-controller-manager"))
+}
+
+func TestEKSAComponentGeneratorObjectsMultipleReplicas(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundles["1.19"].Eksa.Components.URI = "testdata/eksa_components.yaml"
+	tt.newSpec.Cluster.Spec.ManagementControllers = &anywherev1.ManagementControllersConfiguration{Replicas: 3}
+	g := clustermanager.NewEKSAComponentGenerator(tt.log, files.NewReader())
+
+	components, err := g.Objects(tt.newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(components.Objects).To(HaveLen(2))
+	tt.Expect(*components.Deployment.Spec.Replicas).To(Equal(int32(3)))
+
+	pdb, ok := components.Objects[1].(*policyv1.PodDisruptionBudget)
+	tt.Expect(ok).To(BeTrue())
+	tt.Expect(pdb.Name).To(Equal("eksa-controller-manager"))
+	tt.Expect(pdb.Namespace).To(Equal("eksa-system"))
+	tt.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+	tt.Expect(pdb.Spec.Selector).To(Equal(components.Deployment.Spec.Selector))
+}
+
+func TestEKSAComponentGeneratorObjectsError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundles["1.19"].Eksa.Components.URI = "testdata/missing.yaml"
+	g := clustermanager.NewEKSAComponentGenerator(tt.log, files.NewReader())
+
+	_, err := g.Objects(tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("loading manifest for eksa components")))
+}
+
 func TestSetManagerFlags(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -225,6 +298,25 @@ func TestSetManagerFlags(t *testing.T) {
 				}
 			}),
 		},
+		{
+			name:       "leader election",
+			deployment: deployment(),
+			spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ManagementControllers = &anywherev1.ManagementControllersConfiguration{
+					LeaderElection: &anywherev1.LeaderElectionConfiguration{
+						LeaseDuration: &metav1.Duration{Duration: time.Minute},
+						RenewDeadline: &metav1.Duration{Duration: 40 * time.Second},
+					},
+				}
+			}),
+			want: deployment(func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Args = []string{
+					"--leader-elect-lease-duration=1m0s",
+					"--leader-elect-renew-deadline=40s",
+					"--leader-elect-retry-period=2s",
+				}
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -299,6 +391,66 @@ func TestSetManagerEnvVars(t *testing.T) {
 	}
 }
 
+func TestSetManagerReplicas(t *testing.T) {
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"control-plane": "eksa-controller-manager"},
+	}
+	tests := []struct {
+		name     string
+		replicas int
+		want     *appsv1.Deployment
+	}{
+		{
+			name: "default replicas",
+			want: deployment(func(d *appsv1.Deployment) {
+				d.Spec.Selector = selector
+			}),
+		},
+		{
+			name:     "one replica",
+			replicas: 1,
+			want: deployment(func(d *appsv1.Deployment) {
+				d.Spec.Selector = selector
+				d.Spec.Replicas = ptr.Int32(1)
+			}),
+		},
+		{
+			name:     "multiple replicas",
+			replicas: 3,
+			want: deployment(func(d *appsv1.Deployment) {
+				d.Spec.Selector = selector
+				d.Spec.Replicas = ptr.Int32(3)
+				d.Spec.Template.Spec.Affinity = &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{
+								Weight: 100,
+								PodAffinityTerm: corev1.PodAffinityTerm{
+									LabelSelector: selector,
+									TopologyKey:   "kubernetes.io/hostname",
+								},
+							},
+						},
+					},
+				}
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			d := deployment(func(d *appsv1.Deployment) {
+				d.Spec.Selector = selector
+			})
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ManagementControllers = &anywherev1.ManagementControllersConfiguration{Replicas: tt.replicas}
+			})
+			clustermanager.SetManagerReplicas(d, spec)
+			g.Expect(d).To(Equal(tt.want))
+		})
+	}
+}
+
 type deploymentOpt func(*appsv1.Deployment)
 
 func deployment(opts ...deploymentOpt) *appsv1.Deployment {
@@ -1,6 +1,7 @@
 package clustermanager
 
 var (
-	SetManagerFlags   = setManagerFlags
-	SetManagerEnvVars = setManagerEnvVars
+	SetManagerFlags    = setManagerFlags
+	SetManagerEnvVars  = setManagerEnvVars
+	SetManagerReplicas = setManagerReplicas
 )
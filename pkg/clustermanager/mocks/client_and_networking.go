@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth,EKSAComponents,KubernetesClient,ClientFactory)
+// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth,EKSAComponents,KubernetesClient,ClientFactory,PolicyEngine,CertManager)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -1147,3 +1147,77 @@ func (mr *MockClientFactoryMockRecorder) BuildClientFromKubeconfig(arg0 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildClientFromKubeconfig", reflect.TypeOf((*MockClientFactory)(nil).BuildClientFromKubeconfig), arg0)
 }
+
+// MockPolicyEngine is a mock of PolicyEngine interface.
+type MockPolicyEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyEngineMockRecorder
+}
+
+// MockPolicyEngineMockRecorder is the mock recorder for MockPolicyEngine.
+type MockPolicyEngineMockRecorder struct {
+	mock *MockPolicyEngine
+}
+
+// NewMockPolicyEngine creates a new mock instance.
+func NewMockPolicyEngine(ctrl *gomock.Controller) *MockPolicyEngine {
+	mock := &MockPolicyEngine{ctrl: ctrl}
+	mock.recorder = &MockPolicyEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyEngine) EXPECT() *MockPolicyEngineMockRecorder {
+	return m.recorder
+}
+
+// Install mocks base method.
+func (m *MockPolicyEngine) Install(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Install", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Install indicates an expected call of Install.
+func (mr *MockPolicyEngineMockRecorder) Install(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Install", reflect.TypeOf((*MockPolicyEngine)(nil).Install), arg0, arg1, arg2)
+}
+
+// MockCertManager is a mock of CertManager interface.
+type MockCertManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertManagerMockRecorder
+}
+
+// MockCertManagerMockRecorder is the mock recorder for MockCertManager.
+type MockCertManagerMockRecorder struct {
+	mock *MockCertManager
+}
+
+// NewMockCertManager creates a new mock instance.
+func NewMockCertManager(ctrl *gomock.Controller) *MockCertManager {
+	mock := &MockCertManager{ctrl: ctrl}
+	mock.recorder = &MockCertManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertManager) EXPECT() *MockCertManagerMockRecorder {
+	return m.recorder
+}
+
+// Install mocks base method.
+func (m *MockCertManager) Install(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Install", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Install indicates an expected call of Install.
+func (mr *MockCertManagerMockRecorder) Install(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Install", reflect.TypeOf((*MockCertManager)(nil).Install), arg0, arg1, arg2)
+}
@@ -0,0 +1,200 @@
+package clusterrename
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// journal applies the changes of a rename and records how to undo them, so a failed rename
+// can be rolled back to the original objects.
+type journal struct {
+	client client.Client
+	undo   []undoStep
+}
+
+type undoStep struct {
+	description string
+	run         func(ctx context.Context) error
+}
+
+func newJournal(c client.Client) *journal {
+	return &journal{client: c}
+}
+
+func (j *journal) record(description string, run func(ctx context.Context) error) {
+	j.undo = append(j.undo, undoStep{description: description, run: run})
+}
+
+// create creates obj with the owner references resolved by name and restores its status.
+func (j *journal) create(ctx context.Context, obj *unstructured.Unstructured) error {
+	if err := createObject(ctx, j.client, obj); err != nil {
+		return err
+	}
+
+	created := obj.DeepCopy()
+	j.record(fmt.Sprintf("deleting %s %s", obj.GetKind(), obj.GetName()), func(ctx context.Context) error {
+		return deleteObject(ctx, j.client, created)
+	})
+	return nil
+}
+
+// update replaces an object with after, with the owner references resolved by name.
+// The undo restores before.
+func (j *journal) update(ctx context.Context, before, after *unstructured.Unstructured) error {
+	if err := updateObject(ctx, j.client, after); err != nil {
+		return err
+	}
+
+	original := before.DeepCopy()
+	j.record(fmt.Sprintf("restoring %s %s", before.GetKind(), before.GetName()), func(ctx context.Context) error {
+		return updateObject(ctx, j.client, original.DeepCopy())
+	})
+	return nil
+}
+
+// delete removes the finalizers of an object and deletes it, orphaning the objects it owns.
+// The undo creates it again from its last state.
+func (j *journal) delete(ctx context.Context, obj *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := j.client.Get(ctx, client.ObjectKeyFromObject(obj), current); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	if err := deleteObject(ctx, j.client, current); err != nil {
+		return err
+	}
+
+	j.record(fmt.Sprintf("creating %s %s", obj.GetKind(), obj.GetName()), func(ctx context.Context) error {
+		return createObject(ctx, j.client, current.DeepCopy())
+	})
+	return nil
+}
+
+// rollback undoes all the recorded changes in reverse order. It keeps going after an error, so as
+// much as possible is restored, and returns all the errors.
+func (j *journal) rollback(ctx context.Context) error {
+	var errs []error
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		step := j.undo[i]
+		logger.V(3).Info("Rolling back", "step", step.description)
+		if err := step.run(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	j.undo = nil
+
+	return kerrors.NewAggregate(errs)
+}
+
+// createObject creates obj as a new object with the owner references resolved by name. The status
+// is restored after creating it, since it's ignored on creation.
+func createObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	status, hasStatus := obj.Object["status"]
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetDeletionTimestamp(nil)
+	obj.SetDeletionGracePeriodSeconds(nil)
+	obj.SetManagedFields(nil)
+	obj.SetGeneration(0)
+
+	if err := resolveOwners(ctx, c, obj); err != nil {
+		return err
+	}
+
+	if err := c.Create(ctx, obj); err != nil {
+		return fmt.Errorf("creating %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	if !hasStatus {
+		return nil
+	}
+
+	obj.Object["status"] = status
+	if err := c.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("restoring status of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// updateObject replaces the current state of an object with obj, with the owner references resolved by name.
+func updateObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return fmt.Errorf("reading %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	obj.SetResourceVersion(current.GetResourceVersion())
+	obj.SetUID(current.GetUID())
+	obj.SetManagedFields(nil)
+	if err := resolveOwners(ctx, c, obj); err != nil {
+		return err
+	}
+
+	if err := c.Update(ctx, obj); err != nil {
+		return fmt.Errorf("updating %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// deleteObject deletes an object without running the finalizers of its controllers and orphaning
+// the objects it owns, since they are moved to the renamed objects.
+func deleteObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	if len(obj.GetFinalizers()) > 0 {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+
+		current.SetFinalizers(nil)
+		if err := c.Update(ctx, current); err != nil {
+			return fmt.Errorf("removing finalizers of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	if err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// resolveOwners sets the UIDs of the owner references of obj to the ones of the current owners
+// with the same name, since recreated objects get new UIDs.
+func resolveOwners(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	owners := obj.GetOwnerReferences()
+	for i := range owners {
+		gv, err := schema.ParseGroupVersion(owners[i].APIVersion)
+		if err != nil {
+			return fmt.Errorf("parsing owner reference of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+
+		owner := &unstructured.Unstructured{}
+		owner.SetGroupVersionKind(gv.WithKind(owners[i].Kind))
+		if err := c.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: owners[i].Name}, owner); err != nil {
+			return fmt.Errorf("reading owner %s %s of %s %s: %v", owners[i].Kind, owners[i].Name, obj.GetKind(), obj.GetName(), err)
+		}
+		owners[i].UID = owner.GetUID()
+	}
+	obj.SetOwnerReferences(owners)
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package clusterrename
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+// capiLabelDomain is the domain of the CAPI labels and annotations, the only ones whose values are renamed.
+const capiLabelDomain = "cluster.x-k8s.io/"
+
+// kind is a kind of object of the cluster in the eksa-system namespace.
+type kind struct {
+	gvk schema.GroupVersionKind
+	// recreate is true for the kinds that reference the cluster name in immutable fields or that
+	// are found by name by the controllers. Their objects are created again with the new name,
+	// the objects of the other kinds are updated in place.
+	recreate bool
+}
+
+// kinds are the kinds of the objects of a cluster. The recreated kinds are sorted so owners are
+// created before the objects they own.
+var kinds = []kind{
+	{gvk: clusterv1.GroupVersion.WithKind("Cluster"), recreate: true},
+	{gvk: vspherev1.GroupVersion.WithKind("VSphereCluster"), recreate: true},
+	{gvk: controlplanev1.GroupVersion.WithKind("KubeadmControlPlane"), recreate: true},
+	{gvk: etcdv1.GroupVersion.WithKind("EtcdadmCluster"), recreate: true},
+	{gvk: clusterv1.GroupVersion.WithKind("MachineDeployment"), recreate: true},
+	{gvk: clusterv1.GroupVersion.WithKind("MachineSet"), recreate: true},
+	{gvk: clusterv1.GroupVersion.WithKind("Machine"), recreate: true},
+	{gvk: clusterv1.GroupVersion.WithKind("MachineHealthCheck"), recreate: true},
+	{gvk: schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, recreate: true},
+	{gvk: vspherev1.GroupVersion.WithKind("VSphereMachineTemplate")},
+	{gvk: vspherev1.GroupVersion.WithKind("VSphereMachine")},
+	{gvk: vspherev1.GroupVersion.WithKind("VSphereVM")},
+	{gvk: bootstrapv1.GroupVersion.WithKind("KubeadmConfigTemplate")},
+	{gvk: bootstrapv1.GroupVersion.WithKind("KubeadmConfig")},
+	{gvk: etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")},
+}
+
+// bootstrapKinds own the bootstrap data secrets of the machines. Those secrets are named after
+// the machines but referenced by the bootstrap configs, which are updated in place, so they keep
+// their names.
+var bootstrapKinds = map[string]bool{
+	"KubeadmConfig": true,
+	"EtcdadmConfig": true,
+}
+
+func kindRecreated(kindName string) bool {
+	for _, k := range kinds {
+		if k.gvk.Kind == kindName {
+			return k.recreate
+		}
+	}
+	return false
+}
+
+// objects are the objects of a cluster in the eksa-system namespace.
+type objects struct {
+	// recreated are sorted so owners come before the objects they own.
+	recreated []*unstructured.Unstructured
+	updated   []*unstructured.Unstructured
+	// kubeconfig is the kubeconfig secret of the cluster. It's deleted instead of recreated, so the
+	// control plane provider generates it again for the new name.
+	kubeconfig *unstructured.Unstructured
+}
+
+func (o *objects) all() []*unstructured.Unstructured {
+	all := append([]*unstructured.Unstructured{}, o.recreated...)
+	all = append(all, o.updated...)
+	if o.kubeconfig != nil {
+		all = append(all, o.kubeconfig)
+	}
+	return all
+}
+
+// renaming renames the references to a cluster and its objects.
+type renaming struct {
+	oldName, newName string
+	// names are the names of the recreated objects by kind.
+	names map[string]map[string]bool
+}
+
+func newRenaming(oldName, newName string) *renaming {
+	return &renaming{
+		oldName: oldName,
+		newName: newName,
+		names:   map[string]map[string]bool{},
+	}
+}
+
+// add registers an object recreated with the new name.
+func (r *renaming) add(kindName, name string) {
+	if r.names[kindName] == nil {
+		r.names[kindName] = map[string]bool{}
+	}
+	r.names[kindName][name] = true
+}
+
+// derived returns true if name is the cluster name or starts with it, like the names of the objects
+// generated for the cluster.
+func (r *renaming) derived(name string) bool {
+	return name == r.oldName || strings.HasPrefix(name, r.oldName+"-")
+}
+
+// name returns the new name of an object named after the cluster.
+func (r *renaming) name(name string) string {
+	return r.newName + strings.TrimPrefix(name, r.oldName)
+}
+
+// renamed returns true if an object of a kind with a name is recreated with a new name.
+func (r *renaming) renamed(kindName, name string) bool {
+	return r.names[kindName][name]
+}
+
+// renamedValue returns true if a label or annotation value is the name of a recreated object.
+func (r *renaming) renamedValue(value string) bool {
+	if value == r.oldName {
+		return true
+	}
+	for _, names := range r.names {
+		if names[value] {
+			return true
+		}
+	}
+	return false
+}
+
+// object renames the references to the recreated objects in an object: labels and annotations, owner
+// references, object references and cluster names in its spec and status.
+func (r *renaming) object(obj *unstructured.Unstructured) error {
+	obj.SetLabels(r.labels(obj.GetLabels()))
+
+	annotations, err := r.annotations(obj.GetAnnotations())
+	if err != nil {
+		return fmt.Errorf("renaming annotations of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	obj.SetAnnotations(annotations)
+
+	owners := obj.GetOwnerReferences()
+	for i := range owners {
+		if r.renamed(owners[i].Kind, owners[i].Name) {
+			owners[i].Name = r.name(owners[i].Name)
+		}
+	}
+	obj.SetOwnerReferences(owners)
+
+	for _, field := range []string{"spec", "status"} {
+		if content, ok := obj.Object[field]; ok {
+			obj.Object[field] = r.walk("", content)
+		}
+	}
+
+	return nil
+}
+
+func (r *renaming) labels(labels map[string]string) map[string]string {
+	for k, v := range labels {
+		if strings.Contains(k, capiLabelDomain) && r.renamedValue(v) {
+			labels[k] = r.name(v)
+		}
+	}
+	return labels
+}
+
+func (r *renaming) annotations(annotations map[string]string) (map[string]string, error) {
+	for k, v := range annotations {
+		switch {
+		case k == controlplanev1.KubeadmClusterConfigurationAnnotation:
+			// KCP compares the cluster configuration of the control plane machines with its own to
+			// decide if they have to be rolled out, so the cluster name must be renamed in both.
+			config := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(v), &config); err != nil {
+				return nil, err
+			}
+			config = r.walk("", config).(map[string]interface{})
+			b, err := json.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+			annotations[k] = string(b)
+		case strings.Contains(k, capiLabelDomain), k == clusterapi.ReplacedMachineDeploymentAnnotation:
+			if r.renamedValue(v) {
+				annotations[k] = r.name(v)
+			}
+		}
+	}
+	return annotations, nil
+}
+
+// walk renames the references in the content of a field with the given key.
+func (r *renaming) walk(key string, content interface{}) interface{} {
+	switch c := content.(type) {
+	case map[string]interface{}:
+		if key == "labels" || key == "matchLabels" {
+			return stringMap(r.labels(toStringMap(c)))
+		}
+
+		kindName, _ := c["kind"].(string)
+		if key == "secret" {
+			kindName = "Secret"
+		}
+		if name, ok := c["name"].(string); ok && kindName != "" && r.renamed(kindName, name) {
+			c["name"] = r.name(name)
+		}
+
+		for k, v := range c {
+			c[k] = r.walk(k, v)
+		}
+		return c
+	case []interface{}:
+		for i := range c {
+			c[i] = r.walk(key, c[i])
+		}
+		return c
+	case string:
+		if key == "clusterName" && c == r.oldName {
+			return r.newName
+		}
+		return c
+	default:
+		return content
+	}
+}
+
+func toStringMap(m map[string]interface{}) map[string]string {
+	s := make(map[string]string, len(m))
+	for k, v := range m {
+		if str, ok := v.(string); ok {
+			s[k] = str
+		}
+	}
+	return s
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	s := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		s[k] = v
+	}
+	return s
+}
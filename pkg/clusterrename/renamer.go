@@ -0,0 +1,512 @@
+package clusterrename
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const defaultKubeconfigTimeout = 30 * time.Minute
+
+// Rename describes a cluster rename.
+type Rename struct {
+	// Cluster is the cluster to rename, as read from the management cluster.
+	Cluster *v1alpha1.Cluster
+	// NewName is the new name of the cluster.
+	NewName string
+	// BackupFile is where the objects of the cluster are written before changing them. It's
+	// not written if empty.
+	BackupFile string
+	// KubeconfigFile is where the kubeconfig generated for the renamed cluster is written.
+	// It's not written if empty.
+	KubeconfigFile string
+}
+
+// Renamer renames workload clusters without rebuilding them. The objects of the cluster in the
+// management cluster are moved to the new name: the objects that reference the cluster name in
+// immutable fields are created again with the new name and the rest are updated in place. The
+// machines and the workload cluster are left untouched.
+type Renamer struct {
+	client  client.Client
+	retrier *retrier.Retrier
+}
+
+// RenamerOpt allows to customize a Renamer on construction.
+type RenamerOpt func(*Renamer)
+
+// WithRetrier sets the retrier used to wait for the kubeconfig of the renamed cluster.
+func WithRetrier(r *retrier.Retrier) RenamerOpt {
+	return func(rn *Renamer) {
+		rn.retrier = r
+	}
+}
+
+// NewRenamer builds a Renamer. client is a client for the management cluster.
+func NewRenamer(client client.Client, opts ...RenamerOpt) *Renamer {
+	r := &Renamer{
+		client:  client,
+		retrier: retrier.New(defaultKubeconfigTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(5*time.Second))),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Validate checks the cluster can be renamed to the new name.
+func (r *Renamer) Validate(ctx context.Context, rename Rename) error {
+	cluster := rename.Cluster
+	if cluster.IsSelfManaged() {
+		return errors.New("management clusters can't be renamed")
+	}
+
+	if cluster.Spec.DatacenterRef.Kind != v1alpha1.VSphereDatacenterKind {
+		return fmt.Errorf("renaming clusters is not supported for %s", cluster.Spec.DatacenterRef.Kind)
+	}
+
+	if rename.NewName == cluster.Name {
+		return fmt.Errorf("cluster is already named %s", cluster.Name)
+	}
+
+	if err := v1alpha1.ValidateClusterName(rename.NewName); err != nil {
+		return err
+	}
+
+	if err := v1alpha1.ValidateClusterNameLength(rename.NewName); err != nil {
+		return err
+	}
+
+	if cluster.Spec.EtcdEncryption != nil {
+		return errors.New("clusters with etcd encryption can't be renamed")
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return fmt.Errorf("cluster %s is being deleted", cluster.Name)
+	}
+
+	if cluster.IsReconcilePaused() {
+		return fmt.Errorf("cluster %s reconciliation is paused, resume it before renaming it", cluster.Name)
+	}
+
+	if !conditions.IsTrue(cluster, v1alpha1.ReadyCondition) {
+		return fmt.Errorf("cluster %s is not ready", cluster.Name)
+	}
+
+	if err := r.validateNameNotInUse(ctx, rename); err != nil {
+		return err
+	}
+
+	pbc := &packagesv1.PackageBundleController{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: packagesv1.PackageNamespace, Name: cluster.Name}, pbc)
+	switch {
+	case err == nil:
+		return fmt.Errorf("cluster %s has curated packages, which can't be moved to a new name", cluster.Name)
+	case !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err):
+		return fmt.Errorf("reading package bundle controller for cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+func (r *Renamer) validateNameNotInUse(ctx context.Context, rename Rename) error {
+	existing := []struct {
+		obj       client.Object
+		namespace string
+	}{
+		{obj: &v1alpha1.Cluster{}, namespace: rename.Cluster.Namespace},
+		{obj: &clusterv1.Cluster{}, namespace: constants.EksaSystemNamespace},
+	}
+
+	for _, e := range existing {
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: e.namespace, Name: rename.NewName}, e.obj)
+		if err == nil {
+			return fmt.Errorf("cluster %s already exists", rename.NewName)
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("checking if cluster %s exists: %v", rename.NewName, err)
+		}
+	}
+
+	return nil
+}
+
+// Rename renames a cluster:
+//  1. The reconciliation of the cluster and its CAPI cluster is paused.
+//  2. The cluster objects are created again with the new name and the objects of its machines are moved to them.
+//  3. The objects with the old name are deleted without running their finalizers.
+//  4. The reconciliation of the renamed cluster is resumed, which generates its kubeconfig.
+//
+// If any of the first three steps fails, the changes are rolled back and the cluster keeps its old name.
+func (r *Renamer) Rename(ctx context.Context, rename Rename) error {
+	if err := r.Validate(ctx, rename); err != nil {
+		return err
+	}
+
+	j := newJournal(r.client)
+	if err := r.rename(ctx, j, rename); err != nil {
+		logger.Info("Renaming cluster failed, rolling back", "cluster", rename.Cluster.Name, "error", err)
+		if rollbackErr := j.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("renaming cluster %s: %v; rolling back: %v", rename.Cluster.Name, err, rollbackErr)
+		}
+		return fmt.Errorf("renaming cluster %s, changes rolled back: %v", rename.Cluster.Name, err)
+	}
+
+	logger.Info("Resuming renamed cluster reconciliation", "cluster", rename.NewName)
+	if err := r.resume(ctx, rename.Cluster.Namespace, rename.NewName); err != nil {
+		return err
+	}
+
+	if rename.KubeconfigFile == "" {
+		return nil
+	}
+
+	return r.writeKubeconfig(ctx, rename.NewName, rename.KubeconfigFile)
+}
+
+func (r *Renamer) rename(ctx context.Context, j *journal, rename Rename) error {
+	oldName, newName := rename.Cluster.Name, rename.NewName
+	renaming := newRenaming(oldName, newName)
+
+	eksaCluster, err := r.pause(ctx, j, rename.Cluster)
+	if err != nil {
+		return err
+	}
+
+	objs, err := r.collect(ctx, renaming)
+	if err != nil {
+		return err
+	}
+
+	if rename.BackupFile != "" {
+		if err := writeBackup(rename.BackupFile, append([]*unstructured.Unstructured{eksaCluster}, objs.all()...)); err != nil {
+			return err
+		}
+		logger.Info("Cluster objects backed up", "file", rename.BackupFile)
+	}
+
+	logger.Info("Creating cluster objects with the new name", "cluster", newName)
+	renamedCluster := eksaCluster.DeepCopy()
+	renamedCluster.SetName(newName)
+	if err := j.create(ctx, renamedCluster); err != nil {
+		return err
+	}
+
+	for _, obj := range objs.recreated {
+		renamed := obj.DeepCopy()
+		if err := renaming.object(renamed); err != nil {
+			return err
+		}
+		renamed.SetName(renaming.name(obj.GetName()))
+		if err := j.create(ctx, renamed); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Moving machine objects to the renamed cluster", "cluster", newName)
+	for _, obj := range objs.updated {
+		moved := obj.DeepCopy()
+		if err := renaming.object(moved); err != nil {
+			return err
+		}
+		if err := j.update(ctx, obj, moved); err != nil {
+			return err
+		}
+	}
+
+	if err := r.moveClusterConfigs(ctx, j, rename.Cluster, newName); err != nil {
+		return err
+	}
+
+	logger.Info("Deleting cluster objects with the old name", "cluster", oldName)
+	if objs.kubeconfig != nil {
+		if err := j.delete(ctx, objs.kubeconfig); err != nil {
+			return err
+		}
+	}
+
+	for i := len(objs.recreated) - 1; i >= 0; i-- {
+		if err := j.delete(ctx, objs.recreated[i]); err != nil {
+			return err
+		}
+	}
+
+	return j.delete(ctx, eksaCluster)
+}
+
+// pause pauses the reconciliation of the cluster and its CAPI cluster, and returns the paused cluster.
+func (r *Renamer) pause(ctx context.Context, j *journal, cluster *v1alpha1.Cluster) (*unstructured.Unstructured, error) {
+	eksaCluster, err := r.get(ctx, v1alpha1.GroupVersion.WithKind(v1alpha1.ClusterKind), cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	paused := eksaCluster.DeepCopy()
+	annotations := paused.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[cluster.PausedAnnotation()] = "true"
+	paused.SetAnnotations(annotations)
+	if err := j.update(ctx, eksaCluster, paused); err != nil {
+		return nil, err
+	}
+
+	capiCluster, err := r.get(ctx, clusterv1.GroupVersion.WithKind("Cluster"), constants.EksaSystemNamespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	pausedCAPI := capiCluster.DeepCopy()
+	if err := unstructured.SetNestedField(pausedCAPI.Object, true, "spec", "paused"); err != nil {
+		return nil, fmt.Errorf("pausing CAPI cluster %s: %v", cluster.Name, err)
+	}
+	if err := j.update(ctx, capiCluster, pausedCAPI); err != nil {
+		return nil, err
+	}
+
+	return r.get(ctx, v1alpha1.GroupVersion.WithKind(v1alpha1.ClusterKind), cluster.Namespace, cluster.Name)
+}
+
+// collect reads the objects of the cluster in the eksa-system namespace: the ones labeled with its
+// name or referencing it, and the ones owned by them.
+func (r *Renamer) collect(ctx context.Context, renaming *renaming) (*objects, error) {
+	var candidates []*unstructured.Unstructured
+	for _, k := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(k.gvk.GroupVersion().WithKind(k.gvk.Kind + "List"))
+		err := r.client.List(ctx, list, client.InNamespace(constants.EksaSystemNamespace))
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %v", k.gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			candidates = append(candidates, &list.Items[i])
+		}
+	}
+
+	selected := map[types.UID]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, obj := range candidates {
+			if !selected[obj.GetUID()] && belongs(obj, renaming.oldName, selected) {
+				selected[obj.GetUID()] = true
+				changed = true
+			}
+		}
+	}
+
+	objs := &objects{}
+	kubeconfigSecret := secret.Name(renaming.oldName, secret.Kubeconfig)
+	for _, obj := range candidates {
+		if !selected[obj.GetUID()] {
+			continue
+		}
+
+		switch {
+		case obj.GetKind() == "Secret" && obj.GetName() == kubeconfigSecret:
+			objs.kubeconfig = obj
+		case kindRecreated(obj.GetKind()) && renaming.derived(obj.GetName()) && !ownedByBootstrapConfig(obj):
+			objs.recreated = append(objs.recreated, obj)
+			renaming.add(obj.GetKind(), obj.GetName())
+		default:
+			objs.updated = append(objs.updated, obj)
+		}
+	}
+
+	return objs, nil
+}
+
+// belongs returns true if obj is an object of the cluster with the given name.
+func belongs(obj *unstructured.Unstructured, clusterName string, selected map[types.UID]bool) bool {
+	if name, ok := obj.GetLabels()[clusterv1.ClusterNameLabel]; ok {
+		return name == clusterName
+	}
+
+	if specClusterName, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterName"); specClusterName != "" {
+		return specClusterName == clusterName
+	}
+
+	switch {
+	case obj.GetKind() == "Cluster" && obj.GetName() == clusterName:
+		return true
+	case obj.GetKind() == "Secret" && obj.GetName() == awsiamauth.CASecretName(clusterName):
+		return true
+	}
+
+	for _, owner := range obj.GetOwnerReferences() {
+		if selected[owner.UID] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ownedByBootstrapConfig(obj *unstructured.Unstructured) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if bootstrapKinds[owner.Kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// moveClusterConfigs moves the owner references of the datacenter, machine and other configs
+// of the cluster to the renamed cluster, so they aren't garbage collected with the old one.
+func (r *Renamer) moveClusterConfigs(ctx context.Context, j *journal, cluster *v1alpha1.Cluster, newName string) error {
+	refs := []v1alpha1.Ref{cluster.Spec.DatacenterRef}
+	refs = append(refs, cluster.MachineConfigRefs()...)
+	refs = append(refs, cluster.Spec.IdentityProviderRefs...)
+	if cluster.Spec.GitOpsRef != nil {
+		refs = append(refs, *cluster.Spec.GitOpsRef)
+	}
+
+	for _, ref := range refs {
+		config, err := r.get(ctx, v1alpha1.GroupVersion.WithKind(ref.Kind), cluster.Namespace, ref.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		moved := config.DeepCopy()
+		owners := moved.GetOwnerReferences()
+		changed := false
+		for i := range owners {
+			if owners[i].Kind == v1alpha1.ClusterKind && owners[i].Name == cluster.Name && strings.HasPrefix(owners[i].APIVersion, v1alpha1.GroupVersion.Group+"/") {
+				owners[i].Name = newName
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		moved.SetOwnerReferences(owners)
+		if err := j.update(ctx, config, moved); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resume resumes the reconciliation of the renamed CAPI cluster and cluster.
+func (r *Renamer) resume(ctx context.Context, namespace, name string) error {
+	capiCluster := &clusterv1.Cluster{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: name}, capiCluster); err != nil {
+		return fmt.Errorf("reading CAPI cluster %s: %v", name, err)
+	}
+
+	capiCluster.Spec.Paused = false
+	if err := r.client.Update(ctx, capiCluster); err != nil {
+		return fmt.Errorf("resuming CAPI cluster %s: %v", name, err)
+	}
+
+	cluster := &v1alpha1.Cluster{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cluster); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", name, err)
+	}
+
+	cluster.ClearPauseAnnotation()
+	if err := r.client.Update(ctx, cluster); err != nil {
+		return fmt.Errorf("resuming cluster %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// writeKubeconfig waits for the control plane provider to generate the kubeconfig secret of the
+// renamed cluster and writes it to a file.
+func (r *Renamer) writeKubeconfig(ctx context.Context, clusterName, file string) error {
+	name := secret.Name(clusterName, secret.Kubeconfig)
+	var content []byte
+	err := r.retrier.Retry(func() error {
+		s := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: name}, s); err != nil {
+			return err
+		}
+
+		content = s.Data[secret.KubeconfigDataName]
+		if len(content) == 0 {
+			return fmt.Errorf("kubeconfig secret %s is empty", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for kubeconfig secret %s: %v", name, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("creating kubeconfig directory: %v", err)
+	}
+
+	if err := os.WriteFile(file, content, 0o600); err != nil {
+		return fmt.Errorf("writing kubeconfig file: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Renamer) get(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		return nil, fmt.Errorf("reading %s %s: %w", gvk.Kind, name, err)
+	}
+
+	return obj, nil
+}
+
+// writeBackup writes the objects to a file as a multi-document YAML, so they can be restored manually.
+// It contains secrets, so it's only readable by the owner.
+func writeBackup(file string, objs []*unstructured.Unstructured) error {
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		o := obj.DeepCopy()
+		o.SetManagedFields(nil)
+		b, err := yaml.Marshal(o.Object)
+		if err != nil {
+			return fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		docs = append(docs, string(b))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("creating backup directory: %v", err)
+	}
+
+	if err := os.WriteFile(file, []byte(strings.Join(docs, "---\n")), 0o600); err != nil {
+		return fmt.Errorf("writing backup file: %v", err)
+	}
+
+	return nil
+}
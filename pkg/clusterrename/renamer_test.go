@@ -0,0 +1,533 @@
+package clusterrename_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterrename"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	oldName = "prod"
+	newName = "prod-eu-west"
+)
+
+// controllersClient simulates the controllers of the management cluster, which generate the
+// kubeconfig secret of a cluster when its CAPI cluster is resumed, and can fail the deletion
+// of an object.
+type controllersClient struct {
+	client.Client
+	failDelete string
+}
+
+func (c *controllersClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	cluster, ok := obj.(*clusterv1.Cluster)
+	if !ok || cluster.Spec.Paused {
+		return nil
+	}
+
+	return c.Client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: cluster.Name + "-kubeconfig", Namespace: constants.EksaSystemNamespace},
+		Data:       map[string][]byte{"value": []byte("kubeconfig " + cluster.Name)},
+	})
+}
+
+func (c *controllersClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if obj.GetName() == c.failDelete {
+		return errors.New("delete failed")
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+type renamerTest struct {
+	*WithT
+	ctx     context.Context
+	client  *controllersClient
+	rename  clusterrename.Rename
+	renamer *clusterrename.Renamer
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		clusterv1.AddToScheme,
+		controlplanev1.AddToScheme,
+		bootstrapv1.AddToScheme,
+		vspherev1.AddToScheme,
+		etcdv1.AddToScheme,
+		v1alpha1.AddToScheme,
+		packagesv1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return scheme
+}
+
+func meta(name string, labels map[string]string, owners ...metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       constants.EksaSystemNamespace,
+		UID:             types.UID(name + "-uid"),
+		Labels:          labels,
+		OwnerReferences: owners,
+	}
+}
+
+func owner(apiVersion, kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: types.UID(name + "-uid")}
+}
+
+func clusterLabels(cluster string, extra ...string) map[string]string {
+	labels := map[string]string{clusterv1.ClusterNameLabel: cluster}
+	for i := 0; i+1 < len(extra); i += 2 {
+		labels[extra[i]] = extra[i+1]
+	}
+	return labels
+}
+
+func clusterObjects(name string) []client.Object {
+	capi := clusterv1.GroupVersion.String()
+	kcpName, mdName, msName := name, name+"-md-0", name+"-md-0-abc"
+	cpMachine, workerMachine := name+"-cp-xyz", name+"-md-0-abc-xyz"
+
+	return []client.Object{
+		&clusterv1.Cluster{
+			ObjectMeta: meta(name, clusterLabels(name)),
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef:   &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: kcpName},
+				InfrastructureRef: &corev1.ObjectReference{Kind: "VSphereCluster", Name: name},
+			},
+			Status: clusterv1.ClusterStatus{InfrastructureReady: true, ControlPlaneReady: true},
+		},
+		&vspherev1.VSphereCluster{
+			ObjectMeta: meta(name, nil, owner(capi, "Cluster", name)),
+			Spec: vspherev1.VSphereClusterSpec{
+				IdentityRef: &vspherev1.VSphereIdentityReference{Kind: vspherev1.SecretKind, Name: name + "-vsphere-credentials"},
+			},
+		},
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: meta(kcpName, nil, owner(capi, "Cluster", name)),
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+					InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: name + "-control-plane-1"},
+				},
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{ClusterName: name},
+					Files: []bootstrapv1.File{
+						{
+							Path:        "/etc/kubernetes/aws-iam-authenticator/pki/ca.crt",
+							ContentFrom: &bootstrapv1.FileSource{Secret: bootstrapv1.SecretFileSource{Name: name + "-aws-iam-authenticator-ca", Key: "cert.pem"}},
+						},
+					},
+				},
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true, Ready: true},
+		},
+		&vspherev1.VSphereMachineTemplate{ObjectMeta: meta(name+"-control-plane-1", nil, owner(capi, "Cluster", name))},
+		&vspherev1.VSphereMachineTemplate{ObjectMeta: meta(name+"-md-0-1", nil, owner(capi, "Cluster", name))},
+		&bootstrapv1.KubeadmConfigTemplate{ObjectMeta: meta(name+"-md-0-1", nil, owner(capi, "Cluster", name))},
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cpMachine,
+				Namespace: constants.EksaSystemNamespace,
+				UID:       types.UID(cpMachine + "-uid"),
+				Labels:    clusterLabels(name, clusterv1.MachineControlPlaneNameLabel, kcpName),
+				Annotations: map[string]string{
+					controlplanev1.KubeadmClusterConfigurationAnnotation: `{"clusterName":"` + name + `","networking":{}}`,
+				},
+				OwnerReferences: []metav1.OwnerReference{owner(controlplanev1.GroupVersion.String(), "KubeadmControlPlane", kcpName)},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName:       name,
+				Bootstrap:         clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfig", Name: cpMachine}, DataSecretName: &cpMachine},
+				InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachine", Name: name + "-control-plane-1-xyz"},
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &corev1.ObjectReference{Kind: "Node", Name: name + "-control-plane-1-xyz"}},
+		},
+		&bootstrapv1.KubeadmConfig{ObjectMeta: meta(cpMachine, clusterLabels(name), owner(capi, "Machine", cpMachine))},
+		&vspherev1.VSphereMachine{ObjectMeta: meta(name+"-control-plane-1-xyz", clusterLabels(name), owner(capi, "Machine", cpMachine))},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: meta(mdName, clusterLabels(name), owner(capi, "Cluster", name)),
+			Spec: clusterv1.MachineDeploymentSpec{
+				ClusterName: name,
+				Selector:    metav1.LabelSelector{MatchLabels: clusterLabels(name, clusterv1.MachineDeploymentNameLabel, mdName)},
+				Template: clusterv1.MachineTemplateSpec{
+					ObjectMeta: clusterv1.ObjectMeta{Labels: clusterLabels(name, clusterv1.MachineDeploymentNameLabel, mdName)},
+					Spec: clusterv1.MachineSpec{
+						ClusterName:       name,
+						Bootstrap:         clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: name + "-md-0-1"}},
+						InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: name + "-md-0-1"},
+					},
+				},
+			},
+		},
+		&clusterv1.MachineSet{
+			ObjectMeta: meta(msName, clusterLabels(name, clusterv1.MachineDeploymentNameLabel, mdName), owner(capi, "MachineDeployment", mdName)),
+			Spec:       clusterv1.MachineSetSpec{ClusterName: name},
+		},
+		&clusterv1.Machine{
+			ObjectMeta: meta(workerMachine, clusterLabels(name, clusterv1.MachineDeploymentNameLabel, mdName, clusterv1.MachineSetNameLabel, msName), owner(capi, "MachineSet", msName)),
+			Spec: clusterv1.MachineSpec{
+				ClusterName:       name,
+				Bootstrap:         clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfig", Name: workerMachine}, DataSecretName: &workerMachine},
+				InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachine", Name: name + "-md-0-1-xyz"},
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &corev1.ObjectReference{Kind: "Node", Name: name + "-md-0-1-xyz"}},
+		},
+		&bootstrapv1.KubeadmConfig{ObjectMeta: meta(workerMachine, clusterLabels(name), owner(capi, "Machine", workerMachine))},
+		&vspherev1.VSphereMachine{ObjectMeta: meta(name+"-md-0-1-xyz", clusterLabels(name), owner(capi, "Machine", workerMachine))},
+		&vspherev1.VSphereVM{ObjectMeta: meta(name+"-md-0-1-xyz", clusterLabels(name), owner(vspherev1.GroupVersion.String(), "VSphereMachine", name+"-md-0-1-xyz"))},
+		&clusterv1.MachineHealthCheck{
+			ObjectMeta: meta(name+"-md-0-worker-unhealthy", nil, owner(capi, "Cluster", name)),
+			Spec: clusterv1.MachineHealthCheckSpec{
+				ClusterName: name,
+				Selector:    metav1.LabelSelector{MatchLabels: map[string]string{clusterv1.MachineDeploymentNameLabel: mdName}},
+			},
+		},
+		&corev1.Secret{ObjectMeta: meta(name+"-ca", clusterLabels(name), owner(controlplanev1.GroupVersion.String(), "KubeadmControlPlane", kcpName))},
+		&corev1.Secret{ObjectMeta: meta(name+"-kubeconfig", clusterLabels(name), owner(controlplanev1.GroupVersion.String(), "KubeadmControlPlane", kcpName))},
+		&corev1.Secret{ObjectMeta: meta(name+"-aws-iam-authenticator-ca", nil)},
+		&corev1.Secret{ObjectMeta: meta(workerMachine, clusterLabels(name), owner(bootstrapv1.GroupVersion.String(), "KubeadmConfig", workerMachine))},
+	}
+}
+
+func newRenamerTest(t *testing.T) renamerTest {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: oldName, Namespace: "default", UID: "eksa-prod-uid"},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef:     v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: oldName},
+			ManagementCluster: v1alpha1.ManagementCluster{Name: "mgmt"},
+		},
+	}
+	conditions.MarkTrue(cluster, v1alpha1.ReadyCondition)
+
+	datacenter := &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      oldName,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.ClusterKind, Name: oldName, UID: cluster.UID},
+			},
+		},
+	}
+
+	objs := append([]client.Object{cluster.DeepCopy(), datacenter}, clusterObjects(oldName)...)
+	// Another cluster with a name starting with the old name, whose objects must not be renamed.
+	objs = append(objs, clusterObjects("prod-ap")...)
+
+	c := &controllersClient{
+		Client: fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(objs...).Build(),
+	}
+
+	dir := t.TempDir()
+	return renamerTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		client: c,
+		rename: clusterrename.Rename{
+			Cluster:        cluster,
+			NewName:        newName,
+			BackupFile:     filepath.Join(dir, oldName, "backup.yaml"),
+			KubeconfigFile: filepath.Join(dir, newName, newName+"-eks-a-cluster.kubeconfig"),
+		},
+		renamer: clusterrename.NewRenamer(c, clusterrename.WithRetrier(retrier.NewWithMaxRetries(2, time.Millisecond))),
+	}
+}
+
+func (tt *renamerTest) get(namespace, name string, obj client.Object) {
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj)).To(Succeed())
+}
+
+func (tt *renamerTest) expectNotFound(namespace, name string, obj client.Object) {
+	err := tt.client.Get(tt.ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj)
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%T %s should not exist", obj, name)
+}
+
+func ownerNames(obj client.Object) []string {
+	var names []string
+	for _, o := range obj.GetOwnerReferences() {
+		names = append(names, o.Kind+"/"+o.Name)
+	}
+	return names
+}
+
+func TestRenamerRename(t *testing.T) {
+	tt := newRenamerTest(t)
+	ns := constants.EksaSystemNamespace
+
+	tt.Expect(tt.renamer.Rename(tt.ctx, tt.rename)).To(Succeed())
+
+	cluster := &v1alpha1.Cluster{}
+	tt.get("default", newName, cluster)
+	tt.Expect(cluster.IsReconcilePaused()).To(BeFalse())
+	tt.Expect(conditions.IsTrue(cluster, v1alpha1.ReadyCondition)).To(BeTrue())
+	tt.expectNotFound("default", oldName, &v1alpha1.Cluster{})
+
+	datacenter := &v1alpha1.VSphereDatacenterConfig{}
+	tt.get("default", oldName, datacenter)
+	tt.Expect(ownerNames(datacenter)).To(ConsistOf("Cluster/" + newName))
+
+	capiCluster := &clusterv1.Cluster{}
+	tt.get(ns, newName, capiCluster)
+	tt.Expect(capiCluster.Spec.Paused).To(BeFalse())
+	tt.Expect(capiCluster.Spec.ControlPlaneRef.Name).To(Equal(newName))
+	tt.Expect(capiCluster.Spec.InfrastructureRef.Name).To(Equal(newName))
+	tt.Expect(capiCluster.Status.ControlPlaneReady).To(BeTrue())
+	tt.expectNotFound(ns, oldName, &clusterv1.Cluster{})
+
+	vsphereCluster := &vspherev1.VSphereCluster{}
+	tt.get(ns, newName, vsphereCluster)
+	tt.Expect(vsphereCluster.Spec.IdentityRef.Name).To(Equal("prod-vsphere-credentials"), "secrets regenerated by the cluster controller aren't moved")
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.get(ns, newName, kcp)
+	tt.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.ClusterName).To(Equal(newName))
+	tt.Expect(kcp.Spec.KubeadmConfigSpec.Files[0].ContentFrom.Secret.Name).To(Equal(newName + "-aws-iam-authenticator-ca"))
+	tt.Expect(kcp.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("prod-control-plane-1"), "machine templates keep their names")
+	tt.Expect(kcp.Status.Initialized).To(BeTrue())
+	tt.Expect(ownerNames(kcp)).To(ConsistOf("Cluster/" + newName))
+
+	cpMachine := &clusterv1.Machine{}
+	tt.get(ns, newName+"-cp-xyz", cpMachine)
+	tt.Expect(cpMachine.Spec.ClusterName).To(Equal(newName))
+	tt.Expect(cpMachine.Labels).To(HaveKeyWithValue(clusterv1.MachineControlPlaneNameLabel, newName))
+	tt.Expect(cpMachine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation]).To(MatchJSON(`{"clusterName":"` + newName + `","networking":{}}`))
+	tt.Expect(ownerNames(cpMachine)).To(ConsistOf("KubeadmControlPlane/" + newName))
+
+	md := &clusterv1.MachineDeployment{}
+	tt.get(ns, newName+"-md-0", md)
+	tt.Expect(md.Spec.ClusterName).To(Equal(newName))
+	tt.Expect(md.Spec.Template.Spec.ClusterName).To(Equal(newName))
+	tt.Expect(md.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clusterv1.MachineDeploymentNameLabel, newName+"-md-0"))
+	tt.Expect(md.Spec.Template.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, newName))
+	tt.Expect(md.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("prod-md-0-1"))
+
+	ms := &clusterv1.MachineSet{}
+	tt.get(ns, newName+"-md-0-abc", ms)
+	tt.Expect(ownerNames(ms)).To(ConsistOf("MachineDeployment/" + newName + "-md-0"))
+
+	worker := &clusterv1.Machine{}
+	tt.get(ns, newName+"-md-0-abc-xyz", worker)
+	tt.Expect(worker.Labels).To(HaveKeyWithValue(clusterv1.MachineSetNameLabel, newName+"-md-0-abc"))
+	tt.Expect(worker.Spec.Bootstrap.ConfigRef.Name).To(Equal("prod-md-0-abc-xyz"), "bootstrap configs keep their names")
+	tt.Expect(*worker.Spec.Bootstrap.DataSecretName).To(Equal("prod-md-0-abc-xyz"))
+	tt.Expect(worker.Status.NodeRef.Name).To(Equal("prod-md-0-1-xyz"))
+	tt.Expect(ownerNames(worker)).To(ConsistOf("MachineSet/" + newName + "-md-0-abc"))
+	tt.expectNotFound(ns, "prod-md-0-abc-xyz", &clusterv1.Machine{})
+
+	mhc := &clusterv1.MachineHealthCheck{}
+	tt.get(ns, newName+"-md-0-worker-unhealthy", mhc)
+	tt.Expect(mhc.Spec.ClusterName).To(Equal(newName))
+	tt.Expect(mhc.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clusterv1.MachineDeploymentNameLabel, newName+"-md-0"))
+
+	vsphereMachine := &vspherev1.VSphereMachine{}
+	tt.get(ns, "prod-md-0-1-xyz", vsphereMachine)
+	tt.Expect(vsphereMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, newName))
+	tt.Expect(ownerNames(vsphereMachine)).To(ConsistOf("Machine/" + newName + "-md-0-abc-xyz"))
+
+	vm := &vspherev1.VSphereVM{}
+	tt.get(ns, "prod-md-0-1-xyz", vm)
+	tt.Expect(vm.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, newName))
+	tt.Expect(ownerNames(vm)).To(ConsistOf("VSphereMachine/prod-md-0-1-xyz"))
+
+	kubeadmConfig := &bootstrapv1.KubeadmConfig{}
+	tt.get(ns, "prod-md-0-abc-xyz", kubeadmConfig)
+	tt.Expect(ownerNames(kubeadmConfig)).To(ConsistOf("Machine/" + newName + "-md-0-abc-xyz"))
+
+	template := &vspherev1.VSphereMachineTemplate{}
+	tt.get(ns, "prod-md-0-1", template)
+	tt.Expect(ownerNames(template)).To(ConsistOf("Cluster/" + newName))
+
+	ca := &corev1.Secret{}
+	tt.get(ns, newName+"-ca", ca)
+	tt.Expect(ownerNames(ca)).To(ConsistOf("KubeadmControlPlane/" + newName))
+	tt.Expect(ca.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, newName))
+	tt.get(ns, newName+"-aws-iam-authenticator-ca", &corev1.Secret{})
+	tt.expectNotFound(ns, "prod-ca", &corev1.Secret{})
+	tt.expectNotFound(ns, "prod-kubeconfig", &corev1.Secret{})
+
+	bootstrapSecret := &corev1.Secret{}
+	tt.get(ns, "prod-md-0-abc-xyz", bootstrapSecret)
+	tt.Expect(bootstrapSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, newName))
+
+	otherMachine := &clusterv1.Machine{}
+	tt.get(ns, "prod-ap-md-0-abc-xyz", otherMachine)
+	tt.Expect(otherMachine.Spec.ClusterName).To(Equal("prod-ap"))
+	tt.get(ns, "prod-ap", &clusterv1.Cluster{})
+	tt.get(ns, "prod-ap-ca", &corev1.Secret{})
+
+	kubeconfig, err := os.ReadFile(tt.rename.KubeconfigFile)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(kubeconfig)).To(Equal("kubeconfig " + newName))
+
+	backup, err := os.ReadFile(tt.rename.BackupFile)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(backup)).To(ContainSubstring("name: prod-md-0-abc-xyz"))
+	tt.Expect(string(backup)).NotTo(ContainSubstring("prod-ap"))
+}
+
+func TestRenamerRenameRollback(t *testing.T) {
+	tt := newRenamerTest(t)
+	tt.client.failDelete = "prod-md-0-abc"
+	ns := constants.EksaSystemNamespace
+
+	tt.Expect(tt.renamer.Rename(tt.ctx, tt.rename)).To(MatchError(ContainSubstring("changes rolled back")))
+
+	cluster := &v1alpha1.Cluster{}
+	tt.get("default", oldName, cluster)
+	tt.Expect(cluster.IsReconcilePaused()).To(BeFalse())
+	tt.expectNotFound("default", newName, &v1alpha1.Cluster{})
+
+	datacenter := &v1alpha1.VSphereDatacenterConfig{}
+	tt.get("default", oldName, datacenter)
+	tt.Expect(ownerNames(datacenter)).To(ConsistOf("Cluster/" + oldName))
+
+	capiCluster := &clusterv1.Cluster{}
+	tt.get(ns, oldName, capiCluster)
+	tt.Expect(capiCluster.Spec.Paused).To(BeFalse())
+	tt.expectNotFound(ns, newName, &clusterv1.Cluster{})
+
+	// The machines were deleted before failing and are created again.
+	worker := &clusterv1.Machine{}
+	tt.get(ns, "prod-md-0-abc-xyz", worker)
+	tt.Expect(worker.Spec.ClusterName).To(Equal(oldName))
+	tt.Expect(worker.Status.NodeRef.Name).To(Equal("prod-md-0-1-xyz"))
+	tt.Expect(ownerNames(worker)).To(ConsistOf("MachineSet/prod-md-0-abc"))
+	tt.expectNotFound(ns, newName+"-md-0-abc-xyz", &clusterv1.Machine{})
+	tt.expectNotFound(ns, newName+"-md-0-abc", &clusterv1.MachineSet{})
+
+	vsphereMachine := &vspherev1.VSphereMachine{}
+	tt.get(ns, "prod-md-0-1-xyz", vsphereMachine)
+	tt.Expect(vsphereMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, oldName))
+	tt.Expect(ownerNames(vsphereMachine)).To(ConsistOf("Machine/prod-md-0-abc-xyz"))
+
+	tt.get(ns, "prod-ca", &corev1.Secret{})
+	tt.get(ns, "prod-kubeconfig", &corev1.Secret{})
+	tt.expectNotFound(ns, newName+"-ca", &corev1.Secret{})
+
+	_, err := os.Stat(tt.rename.KubeconfigFile)
+	tt.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func TestRenamerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*renamerTest)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*renamerTest) {},
+		},
+		{
+			name: "management cluster",
+			mutate: func(tt *renamerTest) {
+				tt.rename.Cluster.Spec.ManagementCluster.Name = oldName
+			},
+			wantErr: "management clusters can't be renamed",
+		},
+		{
+			name: "unsupported provider",
+			mutate: func(tt *renamerTest) {
+				tt.rename.Cluster.Spec.DatacenterRef.Kind = v1alpha1.DockerDatacenterKind
+			},
+			wantErr: "not supported for DockerDatacenterConfig",
+		},
+		{
+			name: "same name",
+			mutate: func(tt *renamerTest) {
+				tt.rename.NewName = oldName
+			},
+			wantErr: "already named prod",
+		},
+		{
+			name: "invalid name",
+			mutate: func(tt *renamerTest) {
+				tt.rename.NewName = "Prod_EU"
+			},
+			wantErr: "not a valid cluster name",
+		},
+		{
+			name: "etcd encryption",
+			mutate: func(tt *renamerTest) {
+				tt.rename.Cluster.Spec.EtcdEncryption = &[]v1alpha1.EtcdEncryption{}
+			},
+			wantErr: "etcd encryption",
+		},
+		{
+			name: "paused",
+			mutate: func(tt *renamerTest) {
+				tt.rename.Cluster.PauseReconcile()
+			},
+			wantErr: "reconciliation is paused",
+		},
+		{
+			name: "not ready",
+			mutate: func(tt *renamerTest) {
+				conditions.MarkFalse(tt.rename.Cluster, v1alpha1.ReadyCondition, "Upgrading", clusterv1.ConditionSeverityInfo, "")
+			},
+			wantErr: "is not ready",
+		},
+		{
+			name: "existing cluster",
+			mutate: func(tt *renamerTest) {
+				tt.rename.NewName = "prod-ap"
+			},
+			wantErr: "cluster prod-ap already exists",
+		},
+		{
+			name: "curated packages",
+			mutate: func(tt *renamerTest) {
+				tt.Expect(tt.client.Create(tt.ctx, &packagesv1.PackageBundleController{
+					ObjectMeta: metav1.ObjectMeta{Name: oldName, Namespace: packagesv1.PackageNamespace},
+				})).To(Succeed())
+			},
+			wantErr: "has curated packages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newRenamerTest(t)
+			tc.mutate(&tt)
+
+			err := tt.renamer.Validate(tt.ctx, tt.rename)
+			if tc.wantErr == "" {
+				tt.Expect(err).NotTo(HaveOccurred())
+			} else {
+				tt.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			}
+		})
+	}
+}
@@ -86,6 +86,9 @@ const (
 	DefaultNodeStartupTimeout = 10 * time.Minute
 	// DefaultTinkerbellNodeStartupTimeout is the default node start up timeout for Tinkerbell.
 	DefaultTinkerbellNodeStartupTimeout = 20 * time.Minute
+
+	// ConsoleLogsFolder is the folder, relative to the cluster folder, where captured machine console logs are written.
+	ConsoleLogsFolder = "console-logs"
 )
 
 type Operation int
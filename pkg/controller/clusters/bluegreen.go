@@ -0,0 +1,265 @@
+package clusters
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+// blueGreenRequeueAfter is how often the replacement of a blue/green worker node group is checked.
+const blueGreenRequeueAfter = 30 * time.Second
+
+// RemoteClientRegistry gives access to the API server of the workload clusters.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// reconcileBlueGreenWorkers drives the replacement of the worker node groups with the blue/green
+// replacement strategy. When the machines of one of them change, its desired MachineDeployment is
+// created under the alternate name instead of updating the current one. Once the new machines are
+// ready, the old nodes are cordoned and the old MachineDeployment is deleted, which drains them.
+// It returns the names of the MachineDeployments being replaced, which must be kept until then.
+func reconcileBlueGreenWorkers(ctx context.Context, log logr.Logger, c client.Client, remoteClientRegistry RemoteClientRegistry, cluster *anywherev1.Cluster, w *Workers) (controller.Result, []string, error) {
+	result := controller.Result{}
+	var replaced []string
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if !wng.IsBlueGreen() {
+			continue
+		}
+
+		desired := w.machineDeployment(clusterapi.MachineDeploymentName(cluster, wng))
+		if desired == nil {
+			continue
+		}
+
+		blue, green := clusterapi.BlueGreenMachineDeploymentNames(cluster, wng)
+		current, replacing, err := blueGreenMachineDeployments(ctx, c, blue, green)
+		if err != nil {
+			return controller.Result{}, nil, errors.Wrapf(err, "reading machine deployments for worker node group %s", wng.Name)
+		}
+
+		log := log.WithValues("workerNodeGroup", wng.Name)
+		switch {
+		case current == nil:
+			// New worker node group, there is nothing to replace.
+			continue
+		case replacing == nil && !machinesChanged(current, desired):
+			desired.Name = current.Name
+			continue
+		case replacing == nil:
+			replacingName := green
+			if current.Name == green {
+				replacingName = blue
+			}
+			log.Info("Machines changed, creating replacement machine deployment", "current", current.Name, "replacement", replacingName)
+			setReplacing(desired, current, replacingName, wng)
+			replaced = append(replaced, current.Name)
+			result = controller.ResultWithRequeue(blueGreenRequeueAfter)
+		default:
+			setReplacing(desired, current, replacing.Name, wng)
+			replaced = append(replaced, current.Name)
+			r, err := finishReplacement(ctx, log, c, remoteClientRegistry, cluster, current, replacing)
+			if err != nil {
+				return controller.Result{}, nil, errors.Wrapf(err, "replacing machines for worker node group %s", wng.Name)
+			}
+			if r.Return() {
+				result = r
+			}
+		}
+	}
+
+	return result, replaced, nil
+}
+
+// blueGreenMachineDeployments returns the MachineDeployment currently serving a blue/green worker node
+// group and the one replacing it, if any.
+func blueGreenMachineDeployments(ctx context.Context, c client.Client, blue, green string) (current, replacing *clusterv1.MachineDeployment, err error) {
+	blueMD, err := controller.GetMachineDeployment(ctx, c, blue)
+	if err != nil {
+		return nil, nil, err
+	}
+	greenMD, err := controller.GetMachineDeployment(ctx, c, green)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case blueMD == nil:
+		return greenMD, nil, nil
+	case greenMD == nil:
+		return blueMD, nil, nil
+	case blueMD.Annotations[clusterapi.ReplacedMachineDeploymentAnnotation] == green:
+		return greenMD, blueMD, nil
+	case greenMD.Annotations[clusterapi.ReplacedMachineDeploymentAnnotation] == blue:
+		return blueMD, greenMD, nil
+	case blueMD.CreationTimestamp.Before(&greenMD.CreationTimestamp):
+		// Without the annotation, the newest one is assumed to be the replacement.
+		return blueMD, greenMD, nil
+	default:
+		return greenMD, blueMD, nil
+	}
+}
+
+// machinesChanged returns true if the desired MachineDeployment would roll out the machines of the current one.
+func machinesChanged(current, desired *clusterv1.MachineDeployment) bool {
+	currentSpec, desiredSpec := current.Spec.Template.Spec, desired.Spec.Template.Spec
+	return currentSpec.InfrastructureRef.Name != desiredSpec.InfrastructureRef.Name ||
+		!configRefNameEqual(currentSpec.Bootstrap.ConfigRef, desiredSpec.Bootstrap.ConfigRef) ||
+		!stringPtrEqual(currentSpec.Version, desiredSpec.Version)
+}
+
+func configRefNameEqual(a, b *corev1.ObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// setReplacing turns the desired MachineDeployment into the replacement of current. Autoscaled
+// groups start with the replicas of current, so the capacity doesn't drop once the workloads move.
+func setReplacing(desired, current *clusterv1.MachineDeployment, name string, wng anywherev1.WorkerNodeGroupConfiguration) {
+	desired.Name = name
+	if desired.Annotations == nil {
+		desired.Annotations = map[string]string{}
+	}
+	desired.Annotations[clusterapi.ReplacedMachineDeploymentAnnotation] = current.Name
+	if wng.AutoScalingConfiguration != nil && current.Spec.Replicas != nil {
+		replicas := *current.Spec.Replicas
+		desired.Spec.Replicas = &replicas
+	}
+}
+
+// finishReplacement waits for the machines of the replacing MachineDeployment to be ready and its nodes
+// to be ready in the workload cluster. Then it cordons the nodes of current, so its workloads can only
+// be rescheduled in the new ones, and deletes it. CAPI drains each machine before deleting it.
+func finishReplacement(ctx context.Context, log logr.Logger, c client.Client, remoteClientRegistry RemoteClientRegistry, cluster *anywherev1.Cluster, current, replacing *clusterv1.MachineDeployment) (controller.Result, error) {
+	if !current.DeletionTimestamp.IsZero() {
+		log.Info("Waiting for replaced machines to be drained and deleted", "machineDeployment", current.Name)
+		return controller.ResultWithRequeue(blueGreenRequeueAfter), nil
+	}
+
+	if !machineDeploymentReady(replacing) {
+		log.Info("Waiting for replacement machines to be ready", "machineDeployment", replacing.Name)
+		return controller.ResultWithRequeue(blueGreenRequeueAfter), nil
+	}
+
+	remoteClient, err := remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	newNodes, err := machineDeploymentNodeNames(ctx, c, replacing)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	if len(newNodes) < int(*replacing.Spec.Replicas) {
+		log.Info("Waiting for replacement machines to have nodes", "machineDeployment", replacing.Name)
+		return controller.ResultWithRequeue(blueGreenRequeueAfter), nil
+	}
+
+	for _, name := range newNodes {
+		node := &corev1.Node{}
+		if err := remoteClient.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			return controller.Result{}, errors.Wrapf(err, "reading replacement node %s", name)
+		}
+		if !nodeReady(node) || node.Spec.Unschedulable {
+			log.Info("Waiting for replacement node to be ready and schedulable", "node", name)
+			return controller.ResultWithRequeue(blueGreenRequeueAfter), nil
+		}
+	}
+
+	oldNodes, err := machineDeploymentNodeNames(ctx, c, current)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	for _, name := range oldNodes {
+		if err := cordonNode(ctx, remoteClient, name); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	log.Info("Replacement machines ready, deleting replaced machine deployment", "machineDeployment", current.Name, "cordonedNodes", len(oldNodes))
+	if err := c.Delete(ctx, current); err != nil && !apierrors.IsNotFound(err) {
+		return controller.Result{}, errors.Wrapf(err, "deleting replaced machine deployment %s", current.Name)
+	}
+
+	return controller.ResultWithRequeue(blueGreenRequeueAfter), nil
+}
+
+func machineDeploymentReady(md *clusterv1.MachineDeployment) bool {
+	if md.Spec.Replicas == nil || md.Status.ObservedGeneration < md.Generation {
+		return false
+	}
+
+	replicas := *md.Spec.Replicas
+	return md.Status.Replicas == replicas &&
+		md.Status.UpdatedReplicas == replicas &&
+		md.Status.ReadyReplicas == replicas
+}
+
+// machineDeploymentNodeNames returns the names of the nodes of the machines of a MachineDeployment.
+func machineDeploymentNodeNames(ctx context.Context, c client.Client, md *clusterv1.MachineDeployment) ([]string, error) {
+	machines := &clusterv1.MachineList{}
+	if err := c.List(ctx, machines,
+		client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name},
+		client.InNamespace(md.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "listing machines for machine deployment %s", md.Name)
+	}
+
+	var names []string
+	for _, m := range machines.Items {
+		if m.Status.NodeRef != nil {
+			names = append(names, m.Status.NodeRef.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func cordonNode(ctx context.Context, c client.Client, name string) error {
+	node := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, node); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "reading node %s", name)
+	}
+
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return errors.Wrapf(err, "cordoning node %s", name)
+	}
+
+	return nil
+}
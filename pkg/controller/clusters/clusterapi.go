@@ -37,3 +37,25 @@ func CheckControlPlaneReady(ctx context.Context, client client.Client, log logr.
 	log.Info("CAPI control plane is ready")
 	return controller.Result{}, nil
 }
+
+// CheckControlPlaneUpgraded is a controller helper to check whether all the machines of the
+// KubeadmControlPlane of an eks-a cluster run its desired Kubernetes version. Reconcilers use it
+// before reconciling the worker nodes, so worker node groups are always upgraded after the control
+// plane and never run a newer version than it. It requeues while the control plane is being upgraded.
+func CheckControlPlaneUpgraded(ctx context.Context, client client.Client, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	kcp, err := controller.GetKubeadmControlPlane(ctx, client, cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if kcp == nil || kcp.Status.Version == nil {
+		return controller.Result{}, nil
+	}
+
+	if *kcp.Status.Version != kcp.Spec.Version {
+		log.Info("CAPI control plane is being upgraded, requeuing", "version", *kcp.Status.Version, "desiredVersion", kcp.Spec.Version)
+		return controller.ResultWithRequeue(30 * time.Second), nil
+	}
+
+	return controller.Result{}, nil
+}
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestCheckControlPlaneReadyItIsReady(t *testing.T) {
@@ -82,6 +84,74 @@ func TestCheckControlPlaneReadyErrorReading(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("no kind is registered for the type")))
 }
 
+func TestCheckControlPlaneUpgradedUpgrading(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+	kcp := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Status.Version = ptr.String("v1.26.4-eks-1-26-10")
+	})
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, kcp).Build()
+
+	result, err := clusters.CheckControlPlaneUpgraded(ctx, client, test.NewNullLogger(), eksaCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(
+		controller.Result{Result: &controllerruntime.Result{RequeueAfter: 30 * time.Second}}),
+	)
+}
+
+func TestCheckControlPlaneUpgradedItIsUpgraded(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+	kcp := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Status.Version = ptr.String(k.Spec.Version)
+	})
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, kcp).Build()
+
+	result, err := clusters.CheckControlPlaneUpgraded(ctx, client, test.NewNullLogger(), eksaCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestCheckControlPlaneUpgradedNoStatusVersion(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, kubeadmControlPlane()).Build()
+
+	result, err := clusters.CheckControlPlaneUpgraded(ctx, client, test.NewNullLogger(), eksaCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestCheckControlPlaneUpgradedNoKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster).Build()
+
+	result, err := clusters.CheckControlPlaneUpgraded(ctx, client, test.NewNullLogger(), eksaCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestCheckControlPlaneUpgradedErrorReading(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+
+	// This should make the client fail because CRDs are not registered
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	_, err := clusters.CheckControlPlaneUpgraded(ctx, client, test.NewNullLogger(), eksaCluster)
+	g.Expect(err).To(MatchError(ContainSubstring("no kind is registered for the type")))
+}
+
 func eksaCluster() *anywherev1.Cluster {
 	return &anywherev1.Cluster{
 		TypeMeta: metav1.TypeMeta{
@@ -114,3 +184,27 @@ func capiCluster(opts ...capiClusterOpt) *clusterv1.Cluster {
 
 	return c
 }
+
+type kubeadmControlPlaneOpt func(*controlplanev1.KubeadmControlPlane)
+
+func kubeadmControlPlane(opts ...kubeadmControlPlaneOpt) *controlplanev1.KubeadmControlPlane {
+	k := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.27.1-eks-1-27-4",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
+}
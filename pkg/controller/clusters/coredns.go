@@ -0,0 +1,61 @@
+package clusters
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+const (
+	coreDNSDeploymentName = "coredns"
+	// coreDNSNodesPerReplica is the default of the cluster-proportional-autoscaler linear mode.
+	coreDNSNodesPerReplica = 16
+	coreDNSMinReplicas     = 2
+)
+
+// CoreDNSReplicas returns the number of CoreDNS replicas for a cluster: one for every 16 nodes
+// and never less than 2.
+func CoreDNSReplicas(cluster *anywherev1.Cluster) int32 {
+	replicas := (cluster.NodeCount() + coreDNSNodesPerReplica - 1) / coreDNSNodesPerReplica
+	if replicas < coreDNSMinReplicas {
+		replicas = coreDNSMinReplicas
+	}
+	return int32(replicas)
+}
+
+// ReconcileCoreDNS scales up the CoreDNS deployment of a cluster to the number of replicas its nodes
+// need. It never scales it down, so the replicas added by users or autoscalers are kept. The client
+// must point to the cluster itself, not to its management cluster.
+func ReconcileCoreDNS(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster) (controller.Result, error) {
+	deployment := &appsv1.Deployment{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: constants.KubeSystemNamespace, Name: coreDNSDeploymentName}, deployment)
+	if apierrors.IsNotFound(err) {
+		log.Info("CoreDNS deployment not found, skipping scaling")
+		return controller.Result{}, nil
+	}
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "reading CoreDNS deployment")
+	}
+
+	replicas := CoreDNSReplicas(cluster)
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas >= replicas {
+		return controller.Result{}, nil
+	}
+
+	log.Info("Scaling CoreDNS", "replicas", replicas)
+	patch := client.MergeFrom(deployment.DeepCopy())
+	deployment.Spec.Replicas = &replicas
+	if err := c.Patch(ctx, deployment, patch); err != nil {
+		return controller.Result{}, errors.Wrap(err, "scaling CoreDNS deployment")
+	}
+
+	return controller.Result{}, nil
+}
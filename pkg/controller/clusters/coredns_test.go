@@ -0,0 +1,91 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func coreDNSCluster(workers int) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 3},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr.Int(workers)},
+			},
+		},
+	}
+}
+
+func coreDNSDeployment(replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "coredns",
+			Namespace: constants.KubeSystemNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(replicas),
+		},
+	}
+}
+
+func TestCoreDNSReplicas(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		want    int32
+	}{
+		{name: "small cluster", workers: 1, want: 2},
+		{name: "one replica per 16 nodes", workers: 157, want: 10},
+		{name: "rounds up", workers: 158, want: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusters.CoreDNSReplicas(coreDNSCluster(tt.workers))).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileCoreDNSScaleUp(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(coreDNSDeployment(2)).Build()
+
+	g.Expect(clusters.ReconcileCoreDNS(ctx, test.NewNullLogger(), c, coreDNSCluster(197))).To(Equal(controller.Result{}))
+
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.KubeSystemNamespace, Name: "coredns"}, deployment)).To(Succeed())
+	g.Expect(*deployment.Spec.Replicas).To(Equal(int32(13)))
+}
+
+func TestReconcileCoreDNSNoScaleDown(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(coreDNSDeployment(20)).Build()
+
+	g.Expect(clusters.ReconcileCoreDNS(ctx, test.NewNullLogger(), c, coreDNSCluster(197))).To(Equal(controller.Result{}))
+
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.KubeSystemNamespace, Name: "coredns"}, deployment)).To(Succeed())
+	g.Expect(*deployment.Spec.Replicas).To(Equal(int32(20)))
+}
+
+func TestReconcileCoreDNSNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+
+	g.Expect(clusters.ReconcileCoreDNS(context.Background(), test.NewNullLogger(), c, coreDNSCluster(197))).To(Equal(controller.Result{}))
+}
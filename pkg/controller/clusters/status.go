@@ -2,10 +2,15 @@ package clusters
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strings"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -82,6 +87,150 @@ func UpdateClusterStatusForCNI(ctx context.Context, cluster *anywherev1.Cluster)
 	}
 }
 
+const (
+	// fluxFieldManager is the field manager used by Flux's kustomize-controller when applying the GitOps repository content.
+	fluxFieldManager = "kustomize-controller"
+
+	// fluxReconcileAnnotation is used to disable Flux's reconciliation of individual objects.
+	fluxReconcileAnnotation = "kustomize.toolkit.fluxcd.io/reconcile"
+)
+
+// UpdateClusterStatusForGitOps updates the GitOpsInSync condition for Clusters whose FluxConfig is configured
+// to only alert on drift. Since Flux doesn't revert those changes, drift is detected by looking for spec
+// updates made by any field manager other than Flux after Flux's last apply.
+func UpdateClusterStatusForGitOps(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	fluxConfig, err := getFluxConfig(ctx, client, cluster)
+	if err != nil {
+		return err
+	}
+
+	if fluxConfig == nil || fluxConfig.Spec.DriftRemediation.PolicyFor(anywherev1.ClusterKind) != anywherev1.DriftAlert {
+		conditions.Delete(cluster, anywherev1.GitOpsInSyncCondition)
+		return nil
+	}
+
+	if managers := specDriftManagers(cluster.ManagedFields); len(managers) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.GitOpsInSyncCondition, anywherev1.GitOpsDriftDetectedReason, clusterv1.ConditionSeverityWarning, "Cluster spec modified outside of GitOps by %s", strings.Join(managers, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.GitOpsInSyncCondition)
+	return nil
+}
+
+// UpdateClusterStatusForGitOpsReconciliation updates the GitOpsReconciled condition for Clusters managed with Flux,
+// after checking the health of the Flux Kustomization that applies the cluster config path of the GitOps repository.
+func UpdateClusterStatusForGitOpsReconciliation(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	fluxConfig, err := getFluxConfig(ctx, client, cluster)
+	if err != nil {
+		return err
+	}
+
+	if fluxConfig == nil {
+		conditions.Delete(cluster, anywherev1.GitOpsReconciledCondition)
+		return nil
+	}
+
+	kustomization, err := controller.GetFluxKustomization(ctx, client, fluxConfig)
+	if err != nil {
+		return errors.Wrap(err, "getting flux kustomization")
+	}
+
+	// Flux is installed after the cluster is created, there is no GitOps state to wait for until then.
+	if kustomization == nil {
+		conditions.Delete(cluster, anywherev1.GitOpsReconciledCondition)
+		return nil
+	}
+
+	updateGitOpsReconciledCondition(cluster, kustomization)
+	return nil
+}
+
+// GitOpsReconciliationGatesReadiness returns true if the Cluster can't be Ready until Flux reconciles the
+// GitOps repository content.
+func GitOpsReconciliationGatesReadiness(cluster *anywherev1.Cluster) bool {
+	c := conditions.Get(cluster, anywherev1.GitOpsReconciledCondition)
+	return c != nil && c.Reason != anywherev1.GitOpsReconciliationSuspendedReason
+}
+
+func updateGitOpsReconciledCondition(cluster *anywherev1.Cluster, kustomization *controller.FluxKustomization) {
+	// The CLI disables Flux's reconciliation of the Cluster while it upgrades it, so the Cluster readiness can't
+	// depend on Flux in the meantime.
+	if kustomization.Suspended || cluster.Annotations[fluxReconcileAnnotation] == "disabled" {
+		conditions.MarkFalse(cluster, anywherev1.GitOpsReconciledCondition, anywherev1.GitOpsReconciliationSuspendedReason, clusterv1.ConditionSeverityInfo, "Flux is not reconciling the cluster")
+		return
+	}
+
+	if kustomization.Failed {
+		conditions.MarkFalse(cluster, anywherev1.GitOpsReconciledCondition, anywherev1.GitOpsReconciliationFailedReason, clusterv1.ConditionSeverityError, "Flux kustomization %s/%s is not healthy: %s", kustomization.Namespace, kustomization.Name, kustomization.Message)
+		return
+	}
+
+	if !kustomization.Reconciled {
+		conditions.MarkFalse(cluster, anywherev1.GitOpsReconciledCondition, anywherev1.GitOpsReconciliationInProgressReason, clusterv1.ConditionSeverityInfo, "Flux kustomization %s/%s is reconciling", kustomization.Namespace, kustomization.Name)
+		return
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.GitOpsReconciledCondition)
+}
+
+// getFluxConfig reads the FluxConfig referenced by the Cluster. If the Cluster is not managed with Flux,
+// the method returns (nil, nil).
+func getFluxConfig(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) (*anywherev1.FluxConfig, error) {
+	if cluster.Spec.GitOpsRef == nil || cluster.Spec.GitOpsRef.Kind != anywherev1.FluxConfigKind {
+		return nil, nil
+	}
+
+	fluxConfig := &anywherev1.FluxConfig{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.GitOpsRef.Name}
+	if err := client.Get(ctx, key, fluxConfig); err != nil {
+		return nil, errors.Wrap(err, "getting flux config")
+	}
+
+	return fluxConfig, nil
+}
+
+// specDriftManagers returns the field managers, other than Flux, that updated the object spec after
+// Flux's last apply. If Flux has never applied the object, there is no GitOps state to drift from.
+func specDriftManagers(managedFields []metav1.ManagedFieldsEntry) []string {
+	var fluxLastApply *metav1.Time
+	for _, m := range managedFields {
+		if m.Manager == fluxFieldManager && m.Subresource == "" && m.Time != nil {
+			if fluxLastApply == nil || m.Time.After(fluxLastApply.Time) {
+				fluxLastApply = m.Time
+			}
+		}
+	}
+	if fluxLastApply == nil {
+		return nil
+	}
+
+	var managers []string
+	for _, m := range managedFields {
+		if m.Manager == fluxFieldManager || m.Subresource != "" || m.Time == nil || !m.Time.After(fluxLastApply.Time) {
+			continue
+		}
+		if managesSpec(m.FieldsV1) {
+			managers = append(managers, m.Manager)
+		}
+	}
+	sort.Strings(managers)
+
+	return managers
+}
+
+func managesSpec(fields *metav1.FieldsV1) bool {
+	if fields == nil {
+		return false
+	}
+	f := map[string]json.RawMessage{}
+	if err := json.Unmarshal(fields.Raw, &f); err != nil {
+		return false
+	}
+	_, ok := f["f:spec"]
+	return ok
+}
+
 // updateConditionsForEtcdAndControlPlane updates the ControlPlaneReady condition if etcdadm cluster is not ready.
 func updateConditionsForEtcdAndControlPlane(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) {
 	// Make sure etcd cluster is ready before marking ControlPlaneReady status to true
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -21,6 +23,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
@@ -993,3 +996,268 @@ func TestUpdateClusterStatusForCNI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateClusterStatusForGitOps(t *testing.T) {
+	fluxApply := metav1.NewTime(time.Now().Add(-time.Hour))
+	manualEdit := metav1.NewTime(time.Now())
+	specFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:workerNodeGroupConfigurations":{}}}`)}
+	statusFields := &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{}}}`)}
+
+	tests := []struct {
+		name          string
+		driftConfig   *anywherev1.DriftRemediationConfig
+		gitOpsRef     *anywherev1.Ref
+		managedFields []metav1.ManagedFieldsEntry
+		wantCondition *anywherev1.Condition
+	}{
+		{
+			name:      "no gitops",
+			gitOpsRef: nil,
+		},
+		{
+			name:      "drift remediated by flux",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: &fluxApply, FieldsV1: specFields},
+				{Manager: "kubectl-edit", Time: &manualEdit, FieldsV1: specFields},
+			},
+		},
+		{
+			name:        "in sync",
+			driftConfig: &anywherev1.DriftRemediationConfig{Policy: anywherev1.DriftAlert},
+			gitOpsRef:   &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: &manualEdit, FieldsV1: specFields},
+				{Manager: "kubectl-edit", Time: &fluxApply, FieldsV1: specFields},
+				{Manager: "manager", Time: &manualEdit, FieldsV1: statusFields, Subresource: "status"},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:   anywherev1.GitOpsInSyncCondition,
+				Status: "True",
+			},
+		},
+		{
+			name: "drift detected",
+			driftConfig: &anywherev1.DriftRemediationConfig{
+				Policy: anywherev1.DriftRemediate,
+				Overrides: []anywherev1.DriftRemediationOverride{
+					{Kind: anywherev1.ClusterKind, Policy: anywherev1.DriftAlert},
+				},
+			},
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: &fluxApply, FieldsV1: specFields},
+				{Manager: "kubectl-edit", Time: &manualEdit, FieldsV1: specFields},
+				{Manager: "manager", Time: &manualEdit, FieldsV1: statusFields},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsInSyncCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsDriftDetectedReason,
+				Severity: clusterv1.ConditionSeverityWarning,
+				Message:  "Cluster spec modified outside of GitOps by kubectl-edit",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "management-cluster"
+				s.Cluster.Namespace = "default"
+				s.Cluster.Spec.GitOpsRef = tt.gitOpsRef
+				s.Cluster.ManagedFields = tt.managedFields
+				s.Cluster.Status.Conditions = []anywherev1.Condition{
+					{Type: anywherev1.GitOpsInSyncCondition, Status: "Unknown"},
+				}
+			})
+			fluxConfig := &anywherev1.FluxConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+				Spec:       anywherev1.FluxConfigSpec{DriftRemediation: tt.driftConfig},
+			}
+			client := fake.NewClientBuilder().WithRuntimeObjects(fluxConfig).Build()
+
+			g.Expect(clusters.UpdateClusterStatusForGitOps(ctx, client, spec.Cluster)).To(Succeed())
+
+			condition := conditions.Get(spec.Cluster, anywherev1.GitOpsInSyncCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
+
+func TestUpdateClusterStatusForGitOpsMissingFluxConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"}
+	})
+	client := fake.NewClientBuilder().Build()
+
+	g.Expect(clusters.UpdateClusterStatusForGitOps(context.Background(), client, spec.Cluster)).To(MatchError(ContainSubstring("getting flux config")))
+}
+
+func TestUpdateClusterStatusForGitOpsReconciliation(t *testing.T) {
+	tests := []struct {
+		name               string
+		gitOpsRef          *anywherev1.Ref
+		annotations        map[string]string
+		kustomization      *unstructured.Unstructured
+		wantCondition      *anywherev1.Condition
+		wantGatesReadiness bool
+	}{
+		{
+			name:          "no gitops",
+			gitOpsRef:     nil,
+			kustomization: fluxKustomization(false, "True", "Applied revision: main@sha1:abc"),
+		},
+		{
+			name:      "flux not installed",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+		},
+		{
+			name:          "reconciled",
+			gitOpsRef:     &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			kustomization: fluxKustomization(false, "True", "Applied revision: main@sha1:abc"),
+			wantCondition: &anywherev1.Condition{
+				Type:   anywherev1.GitOpsReconciledCondition,
+				Status: "True",
+			},
+			wantGatesReadiness: true,
+		},
+		{
+			name:          "reconciling",
+			gitOpsRef:     &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			kustomization: fluxKustomization(false, "Unknown", "Reconciliation in progress"),
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsReconciledCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsReconciliationInProgressReason,
+				Severity: clusterv1.ConditionSeverityInfo,
+				Message:  "Flux kustomization flux-system/flux-system is reconciling",
+			},
+			wantGatesReadiness: true,
+		},
+		{
+			name:          "failed",
+			gitOpsRef:     &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			kustomization: fluxKustomization(false, "False", "Deployment/default/app status: 'Failed'"),
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsReconciledCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsReconciliationFailedReason,
+				Severity: clusterv1.ConditionSeverityError,
+				Message:  "Flux kustomization flux-system/flux-system is not healthy: Deployment/default/app status: 'Failed'",
+			},
+			wantGatesReadiness: true,
+		},
+		{
+			name:          "kustomization suspended",
+			gitOpsRef:     &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			kustomization: fluxKustomization(true, "False", "Deployment/default/app status: 'Failed'"),
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsReconciledCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsReconciliationSuspendedReason,
+				Severity: clusterv1.ConditionSeverityInfo,
+				Message:  "Flux is not reconciling the cluster",
+			},
+		},
+		{
+			name:          "cluster reconcile disabled",
+			gitOpsRef:     &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			annotations:   map[string]string{"kustomize.toolkit.fluxcd.io/reconcile": "disabled"},
+			kustomization: fluxKustomization(false, "Unknown", "Reconciliation in progress"),
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsReconciledCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsReconciliationSuspendedReason,
+				Severity: clusterv1.ConditionSeverityInfo,
+				Message:  "Flux is not reconciling the cluster",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "management-cluster"
+				s.Cluster.Namespace = "default"
+				s.Cluster.Annotations = tt.annotations
+				s.Cluster.Spec.GitOpsRef = tt.gitOpsRef
+				s.Cluster.Status.Conditions = []anywherev1.Condition{
+					{Type: anywherev1.GitOpsReconciledCondition, Status: "Unknown"},
+				}
+			})
+			objs := []client.Object{
+				&anywherev1.FluxConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+					Spec:       anywherev1.FluxConfigSpec{SystemNamespace: "flux-system"},
+				},
+			}
+			if tt.kustomization != nil {
+				objs = append(objs, tt.kustomization)
+			}
+			client := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+			g.Expect(clusters.UpdateClusterStatusForGitOpsReconciliation(ctx, client, spec.Cluster)).To(Succeed())
+			g.Expect(clusters.GitOpsReconciliationGatesReadiness(spec.Cluster)).To(Equal(tt.wantGatesReadiness))
+
+			condition := conditions.Get(spec.Cluster, anywherev1.GitOpsReconciledCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
+
+func TestUpdateClusterStatusForGitOpsReconciliationMissingFluxConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"}
+	})
+	client := fake.NewClientBuilder().Build()
+
+	g.Expect(clusters.UpdateClusterStatusForGitOpsReconciliation(context.Background(), client, spec.Cluster)).To(MatchError(ContainSubstring("getting flux config")))
+}
+
+func fluxKustomization(suspend bool, readyStatus, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"suspend": suspend,
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": int64(1),
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               "Ready",
+					"status":             readyStatus,
+					"reason":             "Progressing",
+					"message":            message,
+					"lastTransitionTime": "2023-06-01T00:00:00Z",
+				},
+			},
+		},
+	}}
+	u.SetGroupVersionKind(controller.FluxKustomizationGVK)
+	u.SetName("flux-system")
+	u.SetNamespace("flux-system")
+	u.SetGeneration(1)
+	return u
+}
@@ -44,3 +44,26 @@ func (v *ClusterValidator) ValidateManagementClusterName(ctx context.Context, lo
 
 	return nil
 }
+
+// ValidateClusterNameUnique checks that no cluster with the same name exists in another namespace. The CAPI
+// objects of all the clusters are created in the eksa-system namespace, so cluster names must be unique
+// across namespaces. When two clusters share a name, only the oldest one is considered valid.
+func (v *ClusterValidator) ValidateClusterNameUnique(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	clusterList := &anywherev1.ClusterList{}
+	if err := v.client.List(ctx, clusterList, client.MatchingFields{"metadata.name": cluster.Name}); err != nil {
+		return fmt.Errorf("listing clusters with name %s: %v", cluster.Name, err)
+	}
+
+	for _, c := range clusterList.Items {
+		if c.Namespace == cluster.Namespace {
+			continue
+		}
+
+		if c.CreationTimestamp.Before(&cluster.CreationTimestamp) ||
+			(c.CreationTimestamp.Equal(&cluster.CreationTimestamp) && c.Namespace < cluster.Namespace) {
+			return fmt.Errorf("cluster name %s is already used in namespace %s, cluster names must be unique across namespaces", cluster.Name, c.Namespace)
+		}
+	}
+
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -111,3 +113,46 @@ func newClusterValidatorTest(t *testing.T) *clusterValidatorTest {
 		managementCluster: managementCluster,
 	}
 }
+
+func TestValidateClusterNameUniqueSuccess(t *testing.T) {
+	tt := newClusterValidatorTest(t)
+	other := tt.cluster.DeepCopy()
+	other.Name = "other-cluster"
+	other.Namespace = "other-namespace"
+
+	objs := []runtime.Object{tt.cluster, tt.managementCluster, other}
+	cl := fakeClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	validator := clusters.NewClusterValidator(cl)
+	tt.Expect(validator.ValidateClusterNameUnique(context.Background(), tt.logger, tt.cluster)).To(Succeed())
+}
+
+func TestValidateClusterNameUniqueNewerDuplicate(t *testing.T) {
+	tt := newClusterValidatorTest(t)
+	tt.cluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	duplicate := tt.cluster.DeepCopy()
+	duplicate.Namespace = "other-namespace"
+	duplicate.CreationTimestamp = metav1.NewTime(time.Now())
+
+	objs := []runtime.Object{tt.cluster, tt.managementCluster, duplicate}
+	cl := fakeClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	validator := clusters.NewClusterValidator(cl)
+	tt.Expect(validator.ValidateClusterNameUnique(context.Background(), tt.logger, tt.cluster)).To(Succeed())
+}
+
+func TestValidateClusterNameUniqueOlderDuplicate(t *testing.T) {
+	tt := newClusterValidatorTest(t)
+	tt.cluster.CreationTimestamp = metav1.NewTime(time.Now())
+	duplicate := tt.cluster.DeepCopy()
+	duplicate.Namespace = "other-namespace"
+	duplicate.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+	objs := []runtime.Object{tt.cluster, tt.managementCluster, duplicate}
+	cl := fakeClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	validator := clusters.NewClusterValidator(cl)
+	tt.Expect(validator.ValidateClusterNameUnique(context.Background(), tt.logger, tt.cluster)).To(
+		MatchError("cluster name my-cluster is already used in namespace other-namespace, cluster names must be unique across namespaces"),
+	)
+}
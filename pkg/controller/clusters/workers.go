@@ -39,6 +39,17 @@ func (w *Workers) objects() []client.Object {
 	return objs
 }
 
+// machineDeployment returns the desired MachineDeployment with the given name, or nil if there is none.
+func (w *Workers) machineDeployment(name string) *clusterv1.MachineDeployment {
+	for _, g := range w.Groups {
+		if g.MachineDeployment.Name == name {
+			return g.MachineDeployment
+		}
+	}
+
+	return nil
+}
+
 // WorkerGroup represents the CAPI spec for an eks-a worker group.
 type WorkerGroup struct {
 	KubeadmConfigTemplate   *kubeadmv1.KubeadmConfigTemplate
@@ -76,8 +87,10 @@ func ToWorkers[M clusterapi.Object[M]](capiWorkers *clusterapi.Workers[M]) *Work
 
 // ReconcileWorkersForEKSA orchestrates the worker node reconciliation logic for a particular EKS-A cluster.
 // It takes care of applying all desired objects in the Workers spec and deleting the
-// old MachineDeployments that are not in it.
-func ReconcileWorkersForEKSA(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster, w *Workers) (controller.Result, error) {
+// old MachineDeployments that are not in it. The machines of worker node groups with the
+// blue/green replacement strategy are replaced by a new MachineDeployment, using the
+// remoteClientRegistry to cordon the old nodes in the workload cluster.
+func ReconcileWorkersForEKSA(ctx context.Context, log logr.Logger, c client.Client, remoteClientRegistry RemoteClientRegistry, cluster *anywherev1.Cluster, w *Workers) (controller.Result, error) {
 	capiCluster, err := controller.GetCAPICluster(ctx, c, cluster)
 	if err != nil {
 		return controller.Result{}, errors.Wrap(err, "reconciling workers for EKS-A cluster")
@@ -89,13 +102,29 @@ func ReconcileWorkersForEKSA(ctx context.Context, log logr.Logger, c client.Clie
 		return controller.ResultWithRequeue(5 * time.Second), nil
 	}
 
-	return ReconcileWorkers(ctx, c, capiCluster, w)
+	blueGreenResult, replaced, err := reconcileBlueGreenWorkers(ctx, log, c, remoteClientRegistry, cluster, w)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "reconciling blue/green worker node groups")
+	}
+
+	result, err := reconcileWorkers(ctx, c, capiCluster, w, replaced...)
+	if err != nil || result.Return() {
+		return result, err
+	}
+
+	return blueGreenResult, nil
 }
 
 // ReconcileWorkers orchestrates the worker node reconciliation logic.
 // It takes care of applying all desired objects in the Workers spec and deleting the
 // old MachineDeployments that are not in it.
 func ReconcileWorkers(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, w *Workers) (controller.Result, error) {
+	return reconcileWorkers(ctx, c, cluster, w)
+}
+
+// reconcileWorkers applies the Workers spec and deletes the MachineDeployments of the cluster
+// that are not in it, except the ones in keep.
+func reconcileWorkers(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, w *Workers, keep ...string) (controller.Result, error) {
 	if err := serverside.ReconcileObjects(ctx, c, w.objects()); err != nil {
 		return controller.Result{}, errors.Wrap(err, "applying worker nodes CAPI objects")
 	}
@@ -110,6 +139,9 @@ func ReconcileWorkers(ctx context.Context, c client.Client, cluster *clusterv1.C
 	desiredMachineDeploymentNames := collection.MapSet(w.Groups, func(g WorkerGroup) string {
 		return g.MachineDeployment.Name
 	})
+	for _, name := range keep {
+		desiredMachineDeploymentNames.Add(name)
+	}
 
 	var allErrs []error
 
@@ -5,12 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -21,6 +24,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestReconcileWorkersSuccess(t *testing.T) {
@@ -205,7 +210,7 @@ func TestReconcileWorkersForEKSAErrorGettingCAPICluster(t *testing.T) {
 	}
 
 	g.Expect(
-		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, cluster, w),
+		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, nil, cluster, w),
 	).Error().To(MatchError(ContainSubstring("reconciling workers for EKS-A cluster")))
 }
 
@@ -223,7 +228,7 @@ func TestReconcileWorkersForEKSANoCAPICluster(t *testing.T) {
 	}
 
 	g.Expect(
-		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, cluster, w),
+		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, nil, cluster, w),
 	).To(Equal(controller.Result{Result: &reconcile.Result{RequeueAfter: 5 * time.Second}}))
 }
 
@@ -257,7 +262,7 @@ func TestReconcileWorkersForEKSASuccess(t *testing.T) {
 	)
 
 	g.Expect(
-		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, cluster, w),
+		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, nil, cluster, w),
 	).To(Equal(controller.Result{}))
 
 	api.ShouldEventuallyExist(ctx, w.Groups[0].MachineDeployment)
@@ -269,3 +274,185 @@ func TestReconcileWorkersForEKSASuccess(t *testing.T) {
 
 	api.DeleteAndWait(ctx, capiCluster)
 }
+
+func TestReconcileWorkersForEKSABlueGreenCreatesReplacement(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	capiCluster, cluster := blueGreenClusters("bg-create-cluster")
+	current := blueGreenMachineDeployment("bg-create-cluster", "bg-create-cluster-md-0", "bg-create-cluster-md-0-1")
+	envtest.CreateObjs(ctx, t, c,
+		test.Namespace(constants.EksaSystemNamespace),
+		capiCluster,
+		current,
+	)
+
+	w := &clusters.Workers{
+		Groups: []clusters.WorkerGroup{
+			{
+				MachineDeployment:       blueGreenMachineDeployment("bg-create-cluster", "bg-create-cluster-md-0", "bg-create-cluster-md-0-2"),
+				KubeadmConfigTemplate:   kubeadmConfigTemplate("bg-create-cluster-md-0-2", constants.EksaSystemNamespace),
+				ProviderMachineTemplate: dockerMachineTemplate("bg-create-cluster-md-0-2", constants.EksaSystemNamespace),
+			},
+		},
+	}
+
+	g.Expect(
+		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, nil, cluster, w),
+	).To(Equal(controller.Result{Result: &reconcile.Result{RequeueAfter: 30 * time.Second}}))
+
+	replacement := blueGreenMachineDeployment("bg-create-cluster", "bg-create-cluster-md-0-green", "bg-create-cluster-md-0-2")
+	api.ShouldEventuallyMatch(ctx, replacement, func(g Gomega) {
+		g.Expect(replacement.Annotations).To(HaveKeyWithValue(clusterapi.ReplacedMachineDeploymentAnnotation, current.Name))
+	})
+	api.ShouldEventuallyMatch(ctx, current, func(g Gomega) {
+		g.Expect(current.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("bg-create-cluster-md-0-1"))
+	})
+
+	api.DeleteAndWait(ctx, capiCluster, current, replacement)
+}
+
+func TestReconcileWorkersForEKSABlueGreenDeletesReplaced(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	capiCluster, cluster := blueGreenClusters("bg-delete-cluster")
+	current := blueGreenMachineDeployment("bg-delete-cluster", "bg-delete-cluster-md-0", "bg-delete-cluster-md-0-1")
+	replacement := blueGreenMachineDeployment("bg-delete-cluster", "bg-delete-cluster-md-0-green", "bg-delete-cluster-md-0-2")
+	replacement.Annotations = map[string]string{clusterapi.ReplacedMachineDeploymentAnnotation: current.Name}
+	replacement.Status = clusterv1.MachineDeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		ReadyReplicas:      1,
+	}
+	oldMachine := blueGreenMachine("bg-delete-cluster", "bg-delete-cluster-old", current.Name, "old-node")
+	newMachine := blueGreenMachine("bg-delete-cluster", "bg-delete-cluster-new", replacement.Name, "new-node")
+	envtest.CreateObjs(ctx, t, c,
+		test.Namespace(constants.EksaSystemNamespace),
+		capiCluster,
+		current,
+		replacement,
+		oldMachine,
+		newMachine,
+	)
+
+	oldNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "old-node"}}
+	newNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(oldNode, newNode).Build()
+	remoteClientRegistry.EXPECT().GetClient(ctx, controller.CapiClusterObjectKey(cluster)).Return(remoteClient, nil)
+
+	w := &clusters.Workers{
+		Groups: []clusters.WorkerGroup{
+			{
+				MachineDeployment:       blueGreenMachineDeployment("bg-delete-cluster", "bg-delete-cluster-md-0", "bg-delete-cluster-md-0-2"),
+				KubeadmConfigTemplate:   kubeadmConfigTemplate("bg-delete-cluster-md-0-2", constants.EksaSystemNamespace),
+				ProviderMachineTemplate: dockerMachineTemplate("bg-delete-cluster-md-0-2", constants.EksaSystemNamespace),
+			},
+		},
+	}
+
+	g.Expect(
+		clusters.ReconcileWorkersForEKSA(ctx, env.Manager().GetLogger(), c, remoteClientRegistry, cluster, w),
+	).To(Equal(controller.Result{Result: &reconcile.Result{RequeueAfter: 30 * time.Second}}))
+
+	api.ShouldEventuallyNotExist(ctx, current)
+	api.ShouldEventuallyExist(ctx, replacement)
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(oldNode), oldNode)).To(Succeed())
+	g.Expect(oldNode.Spec.Unschedulable).To(BeTrue())
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(newNode), newNode)).To(Succeed())
+	g.Expect(newNode.Spec.Unschedulable).To(BeFalse())
+
+	api.DeleteAndWait(ctx, capiCluster, replacement, oldMachine, newMachine)
+}
+
+func blueGreenClusters(name string) (*clusterv1.Cluster, *anywherev1.Cluster) {
+	capiCluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "cluster.x-k8s.io/v1beta1",
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					Name:                "md-0",
+					Count:               ptr.Int(1),
+					ReplacementStrategy: anywherev1.BlueGreenReplacement,
+				},
+			},
+		},
+	}
+
+	return capiCluster, cluster
+}
+
+func blueGreenMachineDeployment(clusterName, name, templateName string) *clusterv1.MachineDeployment {
+	md := machineDeployment(name, constants.EksaSystemNamespace)
+	md.Labels[clusterv1.ClusterNameLabel] = clusterName
+	md.Spec.ClusterName = clusterName
+	md.Spec.Template.Spec.ClusterName = clusterName
+	md.Spec.Replicas = ptr.Int32(1)
+	md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		Kind:       "DockerMachineTemplate",
+		Name:       templateName,
+	}
+	md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+		APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+		Kind:       "KubeadmConfigTemplate",
+		Name:       templateName,
+	}
+	return md
+}
+
+func blueGreenMachine(clusterName, name, machineDeploymentName, nodeName string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "cluster.x-k8s.io/v1beta1",
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           clusterName,
+				clusterv1.MachineDeploymentNameLabel: machineDeploymentName,
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: clusterName,
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:       "DockerMachine",
+				Name:       name,
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				DataSecretName: ptr.String(name),
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: nodeName},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// FluxKustomizationGVK is the GroupVersionKind of the Flux Kustomizations.
+var FluxKustomizationGVK = schema.GroupVersionKind{
+	Group:   "kustomize.toolkit.fluxcd.io",
+	Version: "v1",
+	Kind:    "Kustomization",
+}
+
+// FluxKustomization is the state of the Flux Kustomization that syncs the cluster config path
+// of a FluxConfig's repository.
+type FluxKustomization struct {
+	Name      string
+	Namespace string
+	// Suspended is true when Flux has been told to stop applying the repository content.
+	Suspended bool
+	// Reconciled is true when Flux applied the last revision of the repository and all the
+	// applied resources are healthy.
+	Reconciled bool
+	// Failed is true when Flux's last attempt to apply the repository content failed.
+	Failed bool
+	// Message is the message of the Kustomization Ready condition.
+	Message string
+	// Revision is the last repository revision Flux applied successfully.
+	Revision string
+}
+
+type fluxKustomizationState struct {
+	Spec struct {
+		Suspend bool `json:"suspend,omitempty"`
+	} `json:"spec,omitempty"`
+	Status struct {
+		ObservedGeneration  int64              `json:"observedGeneration,omitempty"`
+		LastAppliedRevision string             `json:"lastAppliedRevision,omitempty"`
+		Conditions          []metav1.Condition `json:"conditions,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// GetFluxKustomization reads the Flux Kustomization created when bootstrapping Flux for a FluxConfig
+// using a kube client. If the Kustomization is not found, the method returns (nil, nil).
+func GetFluxKustomization(ctx context.Context, client client.Client, fluxConfig *anywherev1.FluxConfig) (*FluxKustomization, error) {
+	// flux bootstrap names the Kustomization after the namespace it installs Flux in.
+	namespace := fluxConfig.Spec.SystemNamespace
+	if namespace == "" {
+		namespace = anywherev1.FluxDefaultNamespace
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(FluxKustomizationGVK)
+	err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: namespace}, u)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &fluxKustomizationState{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, state); err != nil {
+		return nil, err
+	}
+
+	k := &FluxKustomization{
+		Name:      u.GetName(),
+		Namespace: u.GetNamespace(),
+		Suspended: state.Spec.Suspend,
+		Revision:  state.Status.LastAppliedRevision,
+	}
+
+	// We make sure to check that the status is up to date before using it
+	ready := meta.FindStatusCondition(state.Status.Conditions, "Ready")
+	if ready == nil || state.Status.ObservedGeneration != u.GetGeneration() {
+		return k, nil
+	}
+
+	k.Message = ready.Message
+	k.Reconciled = ready.Status == metav1.ConditionTrue
+	k.Failed = ready.Status == metav1.ConditionFalse
+
+	return k, nil
+}
@@ -0,0 +1,174 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+func TestGetFluxKustomization(t *testing.T) {
+	tests := []struct {
+		name          string
+		suspend       bool
+		generation    int64
+		status        map[string]interface{}
+		wantKustomize *controller.FluxKustomization
+	}{
+		{
+			name:       "reconciled",
+			generation: 2,
+			status: kustomizationStatus(2, "main@sha1:abc", map[string]interface{}{
+				"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded", "message": "Applied revision: main@sha1:abc",
+			}),
+			wantKustomize: &controller.FluxKustomization{
+				Name:       "flux-system",
+				Namespace:  "flux-system",
+				Reconciled: true,
+				Message:    "Applied revision: main@sha1:abc",
+				Revision:   "main@sha1:abc",
+			},
+		},
+		{
+			name:       "failed",
+			generation: 1,
+			status: kustomizationStatus(1, "main@sha1:abc", map[string]interface{}{
+				"type": "Ready", "status": "False", "reason": "HealthCheckFailed", "message": "health check failed",
+			}),
+			wantKustomize: &controller.FluxKustomization{
+				Name:      "flux-system",
+				Namespace: "flux-system",
+				Failed:    true,
+				Message:   "health check failed",
+				Revision:  "main@sha1:abc",
+			},
+		},
+		{
+			name:       "progressing",
+			generation: 1,
+			status: kustomizationStatus(1, "", map[string]interface{}{
+				"type": "Ready", "status": "Unknown", "reason": "Progressing", "message": "Reconciliation in progress",
+			}),
+			wantKustomize: &controller.FluxKustomization{
+				Name:      "flux-system",
+				Namespace: "flux-system",
+				Message:   "Reconciliation in progress",
+			},
+		},
+		{
+			name:       "outdated status",
+			generation: 2,
+			status: kustomizationStatus(1, "main@sha1:abc", map[string]interface{}{
+				"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded", "message": "Applied revision: main@sha1:abc",
+			}),
+			wantKustomize: &controller.FluxKustomization{
+				Name:      "flux-system",
+				Namespace: "flux-system",
+				Revision:  "main@sha1:abc",
+			},
+		},
+		{
+			name:       "suspended",
+			suspend:    true,
+			generation: 1,
+			status:     kustomizationStatus(1, "main@sha1:abc"),
+			wantKustomize: &controller.FluxKustomization{
+				Name:      "flux-system",
+				Namespace: "flux-system",
+				Suspended: true,
+				Revision:  "main@sha1:abc",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			kustomization := fluxKustomization("flux-system", tt.generation, tt.suspend, tt.status)
+			client := fake.NewClientBuilder().WithObjects(kustomization).Build()
+
+			g.Expect(controller.GetFluxKustomization(ctx, client, fluxConfig(""))).To(Equal(tt.wantKustomize))
+		})
+	}
+}
+
+func TestGetFluxKustomizationCustomNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kustomization := fluxKustomization("custom-flux", 1, false, kustomizationStatus(1, "main@sha1:abc", map[string]interface{}{
+		"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded",
+	}))
+	client := fake.NewClientBuilder().WithObjects(kustomization).Build()
+
+	k, err := controller.GetFluxKustomization(ctx, client, fluxConfig("custom-flux"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(k.Namespace).To(Equal("custom-flux"))
+	g.Expect(k.Reconciled).To(BeTrue())
+}
+
+func TestGetFluxKustomizationNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	g.Expect(controller.GetFluxKustomization(ctx, client, fluxConfig(""))).To(BeNil())
+}
+
+func TestGetFluxKustomizationError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := failingGetClient{Client: fake.NewClientBuilder().Build(), err: errors.New("connection refused")}
+
+	_, err := controller.GetFluxKustomization(ctx, client, fluxConfig(""))
+	g.Expect(err).To(MatchError("connection refused"))
+}
+
+type failingGetClient struct {
+	client.Client
+	err error
+}
+
+func (c failingGetClient) Get(_ context.Context, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return c.err
+}
+
+func fluxConfig(systemNamespace string) *anywherev1.FluxConfig {
+	return &anywherev1.FluxConfig{
+		Spec: anywherev1.FluxConfigSpec{
+			SystemNamespace: systemNamespace,
+		},
+	}
+}
+
+func fluxKustomization(namespace string, generation int64, suspend bool, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"suspend": suspend,
+			"path":    "./clusters/my-cluster",
+		},
+		"status": status,
+	}}
+	u.SetGroupVersionKind(controller.FluxKustomizationGVK)
+	u.SetName(namespace)
+	u.SetNamespace(namespace)
+	u.SetGeneration(generation)
+	return u
+}
+
+func kustomizationStatus(observedGeneration int64, revision string, conditions ...interface{}) map[string]interface{} {
+	for _, c := range conditions {
+		c.(map[string]interface{})["lastTransitionTime"] = "2023-06-01T00:00:00Z"
+	}
+	return map[string]interface{}{
+		"observedGeneration":  observedGeneration,
+		"lastAppliedRevision": revision,
+		"conditions":          conditions,
+	}
+}
@@ -0,0 +1,198 @@
+package crdmigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	crdResourceType = "customresourcedefinitions"
+	maxRetries      = 3
+	backOffPeriod   = 5 * time.Second
+)
+
+// managementGroupSuffixes are the API groups of the CRDs installed by the management components.
+// Their schemas change between versions, so their objects are always rewritten to prune the
+// fields that were removed from the schema.
+var managementGroupSuffixes = []string{
+	"cluster.x-k8s.io",
+	"eks.amazonaws.com",
+	"tinkerbell.org",
+	"toolkit.fluxcd.io",
+}
+
+// KubectlClient reads and rewrites objects in a cluster.
+type KubectlClient interface {
+	Get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...kubernetes.KubectlGetOption) error
+	ReplaceFromBytes(ctx context.Context, kubeconfig string, data []byte) error
+	MergePatchClusterResourceStatus(ctx context.Context, resource, name, patch, kubeconfig string) error
+}
+
+// Result is the outcome of migrating the objects of a CRD.
+type Result struct {
+	CRD            string `json:"crd"`
+	StorageVersion string `json:"storageVersion"`
+	// StoredVersions are the versions the objects were stored in before the migration.
+	StoredVersions []string `json:"storedVersions"`
+	// Migrated is true when the objects of the CRD were rewritten.
+	Migrated bool `json:"migrated"`
+	// Objects is the number of objects rewritten.
+	Objects int `json:"objects"`
+}
+
+// Migrator moves the objects of the CRDs of a cluster to their storage version and prunes the
+// fields that are no longer part of their schema.
+type Migrator struct {
+	kubectl KubectlClient
+	retrier *retrier.Retrier
+}
+
+// MigratorOpt allows to customize a Migrator.
+type MigratorOpt func(*Migrator)
+
+// WithRetrier sets the retrier used to rewrite the objects of a CRD.
+func WithRetrier(r *retrier.Retrier) MigratorOpt {
+	return func(m *Migrator) {
+		m.retrier = r
+	}
+}
+
+// NewMigrator builds a Migrator.
+func NewMigrator(kubectl KubectlClient, opts ...MigratorOpt) *Migrator {
+	m := &Migrator{
+		kubectl: kubectl,
+		retrier: retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+	}
+
+	for _, o := range opts {
+		o(m)
+	}
+
+	return m
+}
+
+// Migrate rewrites, without changes, all the objects of the CRDs that have objects stored in versions
+// different than the storage version and of the CRDs installed by the management components. The API
+// server stores the rewritten objects in the storage version, dropping any field not in the schema.
+// Once all the objects of a CRD are rewritten, the old versions are removed from its stored versions,
+// so they can be removed from the CRD in future upgrades.
+func (m *Migrator) Migrate(ctx context.Context, cluster *types.Cluster) ([]Result, error) {
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := m.kubectl.Get(ctx, crdResourceType, cluster.KubeconfigFile, crds); err != nil {
+		return nil, fmt.Errorf("listing CRDs: %v", err)
+	}
+
+	results := make([]Result, 0, len(crds.Items))
+	for _, crd := range crds.Items {
+		r := Result{
+			CRD:            crd.Name,
+			StorageVersion: storageVersion(crd),
+			StoredVersions: crd.Status.StoredVersions,
+		}
+
+		if !needsMigration(crd, r.StorageVersion) {
+			results = append(results, r)
+			continue
+		}
+
+		logger.V(3).Info("Migrating CRD objects", "crd", crd.Name, "storedVersions", r.StoredVersions, "storageVersion", r.StorageVersion)
+		objects, err := m.rewriteObjects(ctx, cluster, crd)
+		if err != nil {
+			return nil, fmt.Errorf("migrating objects of CRD %s: %v", crd.Name, err)
+		}
+		r.Migrated = true
+		r.Objects = objects
+
+		if !isStoredOnlyIn(crd, r.StorageVersion) {
+			if err = m.updateStoredVersions(ctx, cluster, crd.Name, r.StorageVersion); err != nil {
+				return nil, err
+			}
+		}
+
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+func (m *Migrator) rewriteObjects(ctx context.Context, cluster *types.Cluster, crd apiextensionsv1.CustomResourceDefinition) (int, error) {
+	resourceType := crd.Spec.Names.Plural + "." + crd.Spec.Group
+	var objects int
+	err := m.retrier.Retry(func() error {
+		// Objects are listed again on every retry so the replace doesn't fail with conflicts
+		// when they are updated by their controllers in the meantime.
+		list := &unstructured.UnstructuredList{}
+		if err := m.kubectl.Get(ctx, resourceType, cluster.KubeconfigFile, list); err != nil {
+			return err
+		}
+
+		objects = len(list.Items)
+		if objects == 0 {
+			return nil
+		}
+
+		data, err := json.Marshal(list)
+		if err != nil {
+			return fmt.Errorf("marshalling %s: %v", resourceType, err)
+		}
+
+		return m.kubectl.ReplaceFromBytes(ctx, cluster.KubeconfigFile, data)
+	})
+
+	return objects, err
+}
+
+func (m *Migrator) updateStoredVersions(ctx context.Context, cluster *types.Cluster, crd, version string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"storedVersions": []string{version},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("generating stored versions patch: %v", err)
+	}
+
+	return m.kubectl.MergePatchClusterResourceStatus(ctx, crdResourceType, crd, string(patch), cluster.KubeconfigFile)
+}
+
+func storageVersion(crd apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+func needsMigration(crd apiextensionsv1.CustomResourceDefinition, storageVersion string) bool {
+	if storageVersion == "" {
+		return false
+	}
+
+	return !isStoredOnlyIn(crd, storageVersion) || isManagementCRD(crd)
+}
+
+func isStoredOnlyIn(crd apiextensionsv1.CustomResourceDefinition, version string) bool {
+	return len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == version
+}
+
+func isManagementCRD(crd apiextensionsv1.CustomResourceDefinition) bool {
+	for _, suffix := range managementGroupSuffixes {
+		if crd.Spec.Group == suffix || strings.HasSuffix(crd.Spec.Group, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
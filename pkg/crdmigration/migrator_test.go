@@ -0,0 +1,150 @@
+package crdmigration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/crdmigration"
+	"github.com/aws/eks-anywhere/pkg/crdmigration/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type migratorTest struct {
+	*WithT
+	ctx      context.Context
+	cluster  *types.Cluster
+	kubectl  *mocks.MockKubectlClient
+	migrator *crdmigration.Migrator
+}
+
+func newMigratorTest(t *testing.T) *migratorTest {
+	kubectl := mocks.NewMockKubectlClient(gomock.NewController(t))
+	return &migratorTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		cluster:  &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		kubectl:  kubectl,
+		migrator: crdmigration.NewMigrator(kubectl, crdmigration.WithRetrier(retrier.NewWithMaxRetries(2, 0))),
+	}
+}
+
+func crd(group, plural string, storedVersions ...string) apiextensionsv1.CustomResourceDefinition {
+	return apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural + "." + group},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: plural},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha4"},
+				{Name: "v1beta1", Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+		},
+	}
+}
+
+func (tt *migratorTest) expectListCRDs(crds ...apiextensionsv1.CustomResourceDefinition) {
+	tt.kubectl.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.cluster.KubeconfigFile, &apiextensionsv1.CustomResourceDefinitionList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*apiextensionsv1.CustomResourceDefinitionList).Items = crds
+			return nil
+		})
+}
+
+func (tt *migratorTest) expectListObjects(resourceType string, names ...string) *gomock.Call {
+	return tt.kubectl.EXPECT().Get(tt.ctx, resourceType, tt.cluster.KubeconfigFile, &unstructured.UnstructuredList{}).
+		DoAndReturn(func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			list := obj.(*unstructured.UnstructuredList)
+			for _, n := range names {
+				o := unstructured.Unstructured{}
+				o.SetName(n)
+				list.Items = append(list.Items, o)
+			}
+			return nil
+		})
+}
+
+func TestMigratorMigrate(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.expectListCRDs(
+		crd("cluster.x-k8s.io", "clusters", "v1alpha4", "v1beta1"),
+		crd("infrastructure.cluster.x-k8s.io", "vsphereclusters", "v1beta1"),
+		crd("example.com", "widgets", "v1beta1"),
+		crd("example.com", "gadgets", "v1alpha4"),
+	)
+	tt.expectListObjects("clusters.cluster.x-k8s.io", "mgmt", "w01")
+	tt.kubectl.EXPECT().ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any())
+	tt.kubectl.EXPECT().MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "clusters.cluster.x-k8s.io",
+		`{"status":{"storedVersions":["v1beta1"]}}`, tt.cluster.KubeconfigFile)
+	tt.expectListObjects("vsphereclusters.infrastructure.cluster.x-k8s.io", "mgmt")
+	tt.kubectl.EXPECT().ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any())
+	tt.expectListObjects("gadgets.example.com")
+	tt.kubectl.EXPECT().MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "gadgets.example.com",
+		`{"status":{"storedVersions":["v1beta1"]}}`, tt.cluster.KubeconfigFile)
+
+	results, err := tt.migrator.Migrate(tt.ctx, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(results).To(Equal([]crdmigration.Result{
+		{CRD: "clusters.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1alpha4", "v1beta1"}, Migrated: true, Objects: 2},
+		{CRD: "vsphereclusters.infrastructure.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1beta1"}, Migrated: true, Objects: 1},
+		{CRD: "widgets.example.com", StorageVersion: "v1beta1", StoredVersions: []string{"v1beta1"}},
+		{CRD: "gadgets.example.com", StorageVersion: "v1beta1", StoredVersions: []string{"v1alpha4"}, Migrated: true},
+	}))
+}
+
+func TestMigratorMigrateRetriesReplace(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.expectListCRDs(crd("cluster.x-k8s.io", "machines", "v1alpha4", "v1beta1"))
+	tt.expectListObjects("machines.cluster.x-k8s.io", "m1").Times(2)
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("conflict")),
+		tt.kubectl.EXPECT().ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()),
+	)
+	tt.kubectl.EXPECT().MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "machines.cluster.x-k8s.io", gomock.Any(), tt.cluster.KubeconfigFile)
+
+	results, err := tt.migrator.Migrate(tt.ctx, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(results).To(HaveLen(1))
+	tt.Expect(results[0].Objects).To(Equal(1))
+}
+
+func TestMigratorMigrateReplaceError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.expectListCRDs(crd("cluster.x-k8s.io", "machines", "v1alpha4", "v1beta1"))
+	tt.expectListObjects("machines.cluster.x-k8s.io", "m1").Times(2)
+	tt.kubectl.EXPECT().ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("conflict")).Times(2)
+
+	_, err := tt.migrator.Migrate(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("migrating objects of CRD machines.cluster.x-k8s.io: conflict")))
+}
+
+func TestMigratorMigrateListCRDsError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.kubectl.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("unauthorized"))
+
+	_, err := tt.migrator.Migrate(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("listing CRDs: unauthorized")))
+}
+
+func TestMigratorMigrateStoredVersionsError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.expectListCRDs(crd("example.com", "gadgets", "v1alpha4"))
+	tt.expectListObjects("gadgets.example.com")
+	tt.kubectl.EXPECT().MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "gadgets.example.com", gomock.Any(), tt.cluster.KubeconfigFile).
+		Return(errors.New("forbidden"))
+
+	_, err := tt.migrator.Migrate(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("forbidden")))
+}
@@ -2,6 +2,8 @@ package crypto
 
 import (
 	"strings"
+
+	"golang.org/x/exp/slices"
 )
 
 // This is what we currently support as the default. In the future,
@@ -14,3 +16,27 @@ func secureCipherSuiteNames() []string {
 func SecureCipherSuitesString() string {
 	return strings.Join(secureCipherSuiteNames(), ",")
 }
+
+// FIPSMinTLSVersion is the minimum TLS version allowed in FIPS mode.
+const FIPSMinTLSVersion = "VersionTLS12"
+
+// fipsCipherSuiteNames are the TLS 1.2 cipher suites approved by FIPS 140-2
+// that the Kubernetes components and etcd support.
+func fipsCipherSuiteNames() []string {
+	return []string{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}
+}
+
+// FIPSCipherSuitesString returns the FIPS approved cipher suites as a comma separated list.
+func FIPSCipherSuitesString() string {
+	return strings.Join(fipsCipherSuiteNames(), ",")
+}
+
+// IsFIPSCipherSuite checks if a cipher suite is FIPS approved.
+func IsFIPSCipherSuite(name string) bool {
+	return slices.Contains(fipsCipherSuiteNames(), name)
+}
@@ -128,6 +128,9 @@ func GetPackageBundleRef(vb releasev1.VersionsBundle) (string, error) {
 	// Use package controller registry to fetch packageBundles.
 	// Format of controller image is: <uri>/<env_type>/<repository_name>
 	controllerImage := strings.Split(packageController.Controller.Image(), "/")
+	if len(controllerImage) < 2 {
+		return "", fmt.Errorf("invalid package controller image %s", packageController.Controller.URI)
+	}
 	major, minor, err := parseKubeVersion(vb.KubeVersion)
 	if err != nil {
 		logger.MarkFail("unable to parse kubeversion", "error", err)
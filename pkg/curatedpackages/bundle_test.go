@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
 	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 type bundleTest struct {
@@ -178,6 +179,33 @@ func TestUpgradeBundleFails(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestGetPackageBundleRefPinnedController(t *testing.T) {
+	g := NewWithT(t)
+	vb := releasev1.VersionsBundle{
+		KubeVersion: "1.27",
+		PackageController: releasev1.PackageBundle{
+			Controller: releasev1.Image{
+				URI: "public.ecr.aws/eks-anywhere/eks-anywhere-packages:v0.3.0@sha256:0123456789abcdef",
+			},
+		},
+	}
+
+	g.Expect(curatedpackages.GetPackageBundleRef(vb)).To(Equal("public.ecr.aws/eks-anywhere/eks-anywhere-packages-bundles:v1-27-latest"))
+}
+
+func TestGetPackageBundleRefInvalidController(t *testing.T) {
+	g := NewWithT(t)
+	vb := releasev1.VersionsBundle{
+		KubeVersion: "1.27",
+		PackageController: releasev1.PackageBundle{
+			Controller: releasev1.Image{URI: "eks-anywhere-packages:v0.3.0"},
+		},
+	}
+
+	_, err := curatedpackages.GetPackageBundleRef(vb)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid package controller image")))
+}
+
 func convertJsonToBytes(obj interface{}) bytes.Buffer {
 	b, _ := json.Marshal(obj)
 	return *bytes.NewBuffer(b)
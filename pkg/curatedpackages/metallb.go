@@ -0,0 +1,104 @@
+package curatedpackages
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	metalLBPackageName     = "metallb"
+	metalLBTargetNamespace = "metallb-system"
+)
+
+type metalLBConfig struct {
+	IPAddressPools    []metalLBIPAddressPool      `json:"IPAddressPools"`
+	L2Advertisements  []metalLBAdvertisement      `json:"L2Advertisements,omitempty"`
+	BGPAdvertisements []metalLBAdvertisement      `json:"BGPAdvertisements,omitempty"`
+	BGPPeers          []anywherev1.MetalLBBGPPeer `json:"BGPPeers,omitempty"`
+}
+
+type metalLBIPAddressPool struct {
+	Name       string   `json:"name"`
+	Addresses  []string `json:"addresses"`
+	AutoAssign *bool    `json:"autoAssign,omitempty"`
+}
+
+type metalLBAdvertisement struct {
+	IPAddressPools []string `json:"ipAddressPools"`
+}
+
+// MetalLBPackage builds the MetalLB curated package for the cluster from its metalLB configuration.
+// All the address pools are announced, with L2 or BGP depending on the configured mode.
+func MetalLBPackage(cluster *anywherev1.Cluster) (*packagesv1.Package, error) {
+	c := cluster.Spec.MetalLB
+	config := metalLBConfig{}
+	advertisement := metalLBAdvertisement{}
+	for _, pool := range c.AddressPools {
+		config.IPAddressPools = append(config.IPAddressPools, metalLBIPAddressPool{
+			Name:       pool.Name,
+			Addresses:  pool.Addresses,
+			AutoAssign: pool.AutoAssign,
+		})
+		advertisement.IPAddressPools = append(advertisement.IPAddressPools, pool.Name)
+	}
+
+	if c.Mode == anywherev1.MetalLBBGPMode {
+		config.BGPAdvertisements = []metalLBAdvertisement{advertisement}
+		config.BGPPeers = c.BGPPeers
+	} else {
+		config.L2Advertisements = []metalLBAdvertisement{advertisement}
+	}
+
+	configYaml, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling metallb package config: %v", err)
+	}
+
+	return &packagesv1.Package{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       kind,
+			APIVersion: packagesv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CustomName + metalLBPackageName,
+			Namespace: constants.EksaPackagesName + "-" + cluster.Name,
+		},
+		Spec: packagesv1.PackageSpec{
+			PackageName:     metalLBPackageName,
+			TargetNamespace: metalLBTargetNamespace,
+			Config:          string(configYaml),
+		},
+	}, nil
+}
+
+func (pi *Installer) installMetalLB(ctx context.Context) error {
+	if pi.spec.Cluster.Spec.MetalLB == nil {
+		return nil
+	}
+
+	logger.Info("Installing MetalLB package")
+	p, err := MetalLBPackage(pi.spec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	packageYaml, err := yaml.Marshal(NewDisplayablePackage(p))
+	if err != nil {
+		return fmt.Errorf("marshalling metallb package: %v", err)
+	}
+
+	params := []string{"apply", "-f", "-", "--kubeconfig", pi.mgmtKubeconfig}
+	if _, err = pi.kubectl.ExecuteFromYaml(ctx, packageYaml, params...); err != nil {
+		return fmt.Errorf("applying metallb package: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func metalLBConfig() *anywherev1.MetalLBConfiguration {
+	autoAssign := false
+	return &anywherev1.MetalLBConfiguration{
+		AddressPools: []anywherev1.MetalLBAddressPool{
+			{Name: "default", Addresses: []string{"10.0.0.0/28"}},
+			{Name: "reserved", Addresses: []string{"10.0.1.10-10.0.1.20"}, AutoAssign: &autoAssign},
+		},
+	}
+}
+
+func TestMetalLBPackageL2(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+	cluster.Name = "test-cluster"
+	cluster.Spec.MetalLB = metalLBConfig()
+
+	p, err := curatedpackages.MetalLBPackage(cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Name).To(Equal("generated-metallb"))
+	g.Expect(p.Namespace).To(Equal("eksa-packages-test-cluster"))
+	g.Expect(p.Spec.PackageName).To(Equal("metallb"))
+	g.Expect(p.Spec.TargetNamespace).To(Equal("metallb-system"))
+	g.Expect(p.Spec.Config).To(MatchYAML(`
+IPAddressPools:
+- name: default
+  addresses:
+  - 10.0.0.0/28
+- name: reserved
+  addresses:
+  - 10.0.1.10-10.0.1.20
+  autoAssign: false
+L2Advertisements:
+- ipAddressPools:
+  - default
+  - reserved
+`))
+}
+
+func TestMetalLBPackageBGP(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+	cluster.Name = "test-cluster"
+	cluster.Spec.MetalLB = metalLBConfig()
+	cluster.Spec.MetalLB.Mode = anywherev1.MetalLBBGPMode
+	cluster.Spec.MetalLB.BGPPeers = []anywherev1.MetalLBBGPPeer{{PeerAddress: "10.0.0.1", PeerASN: 64501, MyASN: 64500}}
+
+	p, err := curatedpackages.MetalLBPackage(cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Spec.Config).To(MatchYAML(`
+IPAddressPools:
+- name: default
+  addresses:
+  - 10.0.0.0/28
+- name: reserved
+  addresses:
+  - 10.0.1.10-10.0.1.20
+  autoAssign: false
+BGPAdvertisements:
+- ipAddressPools:
+  - default
+  - reserved
+BGPPeers:
+- peerAddress: 10.0.0.1
+  peerASN: 64501
+  myASN: 64500
+`))
+}
+
+func TestPackageInstallerInstallsMetalLB(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.spec.Cluster.Spec.MetalLB = metalLBConfig()
+	p, err := curatedpackages.MetalLBPackage(tt.spec.Cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	packageYaml, err := yaml.Marshal(curatedpackages.NewDisplayablePackage(p))
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.packageControllerClient.EXPECT().Enable(tt.ctx).Return(nil)
+	gomock.InOrder(
+		tt.kubectlRunner.EXPECT().ExecuteFromYaml(tt.ctx, packageYaml, "apply", "-f", "-", "--kubeconfig", tt.kubeConfigPath),
+		tt.packageClient.EXPECT().CreatePackages(tt.ctx, tt.packagePath, tt.kubeConfigPath).Return(nil),
+	)
+
+	tt.command.InstallCuratedPackages(tt.ctx)
+}
+
+func TestPackageInstallerMetalLBFails(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.spec.Cluster.Spec.MetalLB = metalLBConfig()
+
+	tt.packageControllerClient.EXPECT().Enable(tt.ctx).Return(nil)
+	tt.kubectlRunner.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, errors.New("apply failed"))
+
+	tt.command.InstallCuratedPackages(tt.ctx)
+}
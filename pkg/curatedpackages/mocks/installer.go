@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	executables "github.com/aws/eks-anywhere/pkg/executables"
 	gomock "github.com/golang/mock/gomock"
 	types "k8s.io/apimachinery/pkg/types"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
@@ -75,17 +76,22 @@ func (m *MockChartInstaller) EXPECT() *MockChartInstallerMockRecorder {
 }
 
 // InstallChart mocks base method.
-func (m *MockChartInstaller) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string) error {
+func (m *MockChartInstaller) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string, opts ...executables.HelmOpt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallChart", ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values)
+	varargs := []interface{}{ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InstallChart", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallChart indicates an expected call of InstallChart.
-func (mr *MockChartInstallerMockRecorder) InstallChart(ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values interface{}) *gomock.Call {
+func (mr *MockChartInstallerMockRecorder) InstallChart(ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallChart", reflect.TypeOf((*MockChartInstaller)(nil).InstallChart), ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values)
+	varargs := append([]interface{}{ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallChart", reflect.TypeOf((*MockChartInstaller)(nil).InstallChart), varargs...)
 }
 
 // MockChartUninstaller is a mock of ChartUninstaller interface.
@@ -163,17 +169,22 @@ func (mr *MockChartManagerMockRecorder) Delete(ctx, kubeconfigFilePath, installN
 }
 
 // InstallChart mocks base method.
-func (m *MockChartManager) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string) error {
+func (m *MockChartManager) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string, opts ...executables.HelmOpt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallChart", ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values)
+	varargs := []interface{}{ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InstallChart", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallChart indicates an expected call of InstallChart.
-func (mr *MockChartManagerMockRecorder) InstallChart(ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values interface{}) *gomock.Call {
+func (mr *MockChartManagerMockRecorder) InstallChart(ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallChart", reflect.TypeOf((*MockChartManager)(nil).InstallChart), ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values)
+	varargs := append([]interface{}{ctx, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath, skipCRDs, values}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallChart", reflect.TypeOf((*MockChartManager)(nil).InstallChart), varargs...)
 }
 
 // MockKubeDeleter is a mock of KubeDeleter interface.
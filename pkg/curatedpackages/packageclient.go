@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,11 +23,17 @@ const (
 
 type PackageClientOpt func(*PackageClient)
 
+// ChartVersionLister lists the versions of a chart available in an OCI registry.
+type ChartVersionLister interface {
+	ListChartVersions(ctx context.Context, ociURI string) ([]string, error)
+}
+
 type PackageClient struct {
 	bundle         *packagesv1.PackageBundle
 	customPackages []string
 	kubectl        KubectlRunner
 	customConfigs  []string
+	versionLister  ChartVersionLister
 }
 
 func NewPackageClient(kubectl KubectlRunner, options ...PackageClientOpt) *PackageClient {
@@ -66,6 +73,40 @@ func (pc *PackageClient) DisplayPackages(w io.Writer) error {
 	return tw.writeTable(lines)
 }
 
+// DisplayPackagesWithAvailableVersions pretty-prints a table of available packages, including
+// the chart versions found in the registry. If registry is empty, the registry of each package
+// source in the bundle is used.
+func (pc *PackageClient) DisplayPackagesWithAvailableVersions(ctx context.Context, w io.Writer, registry string) error {
+	if pc.versionLister == nil {
+		return errors.New("listing available package versions requires a chart version lister")
+	}
+
+	lines := append([][]string{}, packagesWithAvailableVersionsHeaderLines...)
+	for _, pkg := range pc.bundle.Spec.Packages {
+		versions := sourceWithVersions(pkg.Source).VersionsSlice()
+
+		packageRegistry := pkg.Source.Registry
+		if registry != "" {
+			packageRegistry = registry
+		}
+		available, err := pc.versionLister.ListChartVersions(ctx, "oci://"+path.Join(packageRegistry, pkg.Source.Repository))
+		if err != nil {
+			return fmt.Errorf("listing available versions for package %s: %v", pkg.Name, err)
+		}
+
+		lines = append(lines, []string{pkg.Name, strings.Join(versions, ", "), strings.Join(available, ", ")})
+	}
+
+	tw := newCPTabwriter(w, nil)
+	defer tw.Flush()
+	return tw.writeTable(lines)
+}
+
+var packagesWithAvailableVersionsHeaderLines = [][]string{
+	{"Package", "Version(s)", "Available Version(s)"},
+	{"-------", "----------", "--------------------"},
+}
+
 // packagesHeaderLines pretties-up a table of curated packages info.
 var packagesHeaderLines = [][]string{
 	{"Package", "Version(s)"},
@@ -230,3 +271,10 @@ func WithCustomConfigs(customConfigs []string) func(*PackageClient) {
 		config.customConfigs = customConfigs
 	}
 }
+
+// WithChartVersionLister sets the ChartVersionLister used to find the package versions available in a registry.
+func WithChartVersionLister(lister ChartVersionLister) func(*PackageClient) {
+	return func(config *PackageClient) {
+		config.versionLister = lister
+	}
+}
@@ -270,3 +270,57 @@ func TestDisplayPackages(t *testing.T) {
 	expected := "Package\t\tVersion(s)\t\n-------\t\t----------\t\nharbor-test\t0.0.1, 0.0.2\t\nredis-test\t0.0.3, 0.0.4\t\n"
 	tt.Expect(buf.String()).To(Equal(expected))
 }
+
+type fakeChartVersionLister struct {
+	uris     []string
+	versions map[string][]string
+	err      error
+}
+
+func (f *fakeChartVersionLister) ListChartVersions(_ context.Context, ociURI string) ([]string, error) {
+	f.uris = append(f.uris, ociURI)
+	return f.versions[ociURI], f.err
+}
+
+func TestDisplayPackagesWithAvailableVersions(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle.Spec.Packages[0].Source.Registry = "public.ecr.aws/eks-anywhere"
+	tt.bundle.Spec.Packages[0].Source.Repository = "harbor"
+	tt.bundle.Spec.Packages[1].Source.Registry = "public.ecr.aws/eks-anywhere"
+	tt.bundle.Spec.Packages[1].Source.Repository = "redis"
+	lister := &fakeChartVersionLister{versions: map[string][]string{
+		"oci://my-mirror.example.com/harbor": {"0.0.3", "0.0.2", "0.0.1"},
+		"oci://my-mirror.example.com/redis":  {"0.0.4"},
+	}}
+	pc := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithChartVersionLister(lister))
+	buf := &bytes.Buffer{}
+
+	tt.Expect(pc.DisplayPackagesWithAvailableVersions(tt.ctx, buf, "my-mirror.example.com")).To(Succeed())
+	expected := "Package\t\tVersion(s)\tAvailable Version(s)\t\n" +
+		"-------\t\t----------\t--------------------\t\n" +
+		"harbor-test\t0.0.1, 0.0.2\t0.0.3, 0.0.2, 0.0.1\t\n" +
+		"redis-test\t0.0.3, 0.0.4\t0.0.4\t\t\t\n"
+	tt.Expect(buf.String()).To(Equal(expected))
+}
+
+func TestDisplayPackagesWithAvailableVersionsBundleRegistry(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle.Spec.Packages = tt.bundle.Spec.Packages[:1]
+	tt.bundle.Spec.Packages[0].Source.Registry = "public.ecr.aws/eks-anywhere"
+	tt.bundle.Spec.Packages[0].Source.Repository = "harbor"
+	lister := &fakeChartVersionLister{}
+	pc := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithChartVersionLister(lister))
+
+	tt.Expect(pc.DisplayPackagesWithAvailableVersions(tt.ctx, &bytes.Buffer{}, "")).To(Succeed())
+	tt.Expect(lister.uris).To(Equal([]string{"oci://public.ecr.aws/eks-anywhere/harbor"}))
+}
+
+func TestDisplayPackagesWithAvailableVersionsError(t *testing.T) {
+	tt := newPackageTest(t)
+	lister := &fakeChartVersionLister{err: errors.New("unauthorized")}
+	pc := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithChartVersionLister(lister))
+
+	tt.Expect(pc.DisplayPackagesWithAvailableVersions(tt.ctx, &bytes.Buffer{}, "")).To(
+		MatchError("listing available versions for package harbor-test: unauthorized"),
+	)
+}
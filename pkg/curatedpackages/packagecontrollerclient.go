@@ -23,6 +23,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
@@ -85,7 +86,7 @@ type ClientBuilder interface {
 }
 
 type ChartInstaller interface {
-	InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string) error
+	InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string, opts ...executables.HelmOpt) error
 }
 
 // ChartUninstaller handles deleting chart installations.
@@ -227,7 +228,7 @@ func (pc *PackageControllerClient) Enable(ctx context.Context) error {
 		skipCRDs = true
 	}
 
-	if err := pc.chartManager.InstallChart(ctx, chartName, ociURI, pc.chart.Tag(), pc.kubeConfig, constants.EksaPackagesName, valueFilePath, skipCRDs, values); err != nil {
+	if err := pc.chartManager.InstallChart(ctx, chartName, ociURI, pc.chart.Tag(), pc.kubeConfig, constants.EksaPackagesName, valueFilePath, skipCRDs, values, pc.helmOpts()...); err != nil {
 		return err
 	}
 
@@ -254,6 +255,7 @@ func (pc *PackageControllerClient) GetCuratedPackagesRegistries(ctx context.Cont
 		sourceRegistry = stagingDevECR
 	}
 	defaultRegistry = sourceRegistry
+	region := pc.region()
 
 	if pc.registryMirror != nil {
 		// account is added as part of registry name in package controller helm chart
@@ -264,12 +266,12 @@ func (pc *PackageControllerClient) GetCuratedPackagesRegistries(ctx context.Cont
 			defaultImageRegistry = gatedOCINamespace
 		}
 	} else {
-		if pc.eksaRegion != eksaDefaultRegion {
-			defaultImageRegistry = strings.ReplaceAll(defaultImageRegistry, eksaDefaultRegion, pc.eksaRegion)
+		if region != eksaDefaultRegion {
+			defaultImageRegistry = strings.ReplaceAll(defaultImageRegistry, eksaDefaultRegion, region)
 		}
 
-		regionalRegistry := GetRegionalRegistry(defaultRegistry, pc.eksaRegion)
-		if err := pc.registryAccessTester(ctx, pc.eksaAccessKeyID, pc.eksaSecretAccessKey, regionalRegistry, pc.eksaRegion); err == nil {
+		regionalRegistry := GetRegionalRegistry(defaultRegistry, region)
+		if err := pc.registryAccessTester(ctx, pc.eksaAccessKeyID, pc.eksaSecretAccessKey, regionalRegistry, region); err == nil {
 			// use regional registry when the above credential is good
 			logger.V(6).Info("Using regional registry")
 			defaultRegistry = regionalRegistry
@@ -278,9 +280,33 @@ func (pc *PackageControllerClient) GetCuratedPackagesRegistries(ctx context.Cont
 			logger.V(6).Info("Using fallback registry", "Registry", defaultRegistry, "RegionalRegistryAccessIssue", err)
 		}
 	}
+
+	if pc.clusterSpec != nil && pc.clusterSpec.Packages != nil && pc.clusterSpec.Packages.BundleRegistry != "" {
+		defaultRegistry = pc.clusterSpec.Packages.BundleRegistry
+	}
+
 	return sourceRegistry, defaultRegistry, defaultImageRegistry
 }
 
+// region returns the region configured in the cluster spec, falling back to the one
+// the client was built with.
+func (pc *PackageControllerClient) region() string {
+	if pc.clusterSpec != nil && pc.clusterSpec.Packages != nil && pc.clusterSpec.Packages.Region != "" {
+		return pc.clusterSpec.Packages.Region
+	}
+	return pc.eksaRegion
+}
+
+// helmOpts returns the helm options for the cluster. The client is shared by all the clusters
+// in the controller, so the cluster post-renderer is passed in each install.
+func (pc *PackageControllerClient) helmOpts() []executables.HelmOpt {
+	if pc.clusterSpec == nil || pc.clusterSpec.HelmPostRenderer == nil {
+		return nil
+	}
+	renderer := pc.clusterSpec.HelmPostRenderer
+	return []executables.HelmOpt{executables.WithPostRenderer(renderer.BinaryPath, renderer.Args...)}
+}
+
 // CreateHelmOverrideValuesYaml creates a temp file to override certain values in package controller helm install.
 func (pc *PackageControllerClient) CreateHelmOverrideValuesYaml() (string, []byte, error) {
 	content, err := pc.generateHelmOverrideValues()
@@ -314,7 +340,7 @@ func (pc *PackageControllerClient) generateHelmOverrideValues() ([]byte, error)
 	templateValues := map[string]interface{}{
 		"eksaAccessKeyId":     base64.StdEncoding.EncodeToString([]byte(pc.eksaAccessKeyID)),
 		"eksaSecretAccessKey": base64.StdEncoding.EncodeToString([]byte(pc.eksaSecretAccessKey)),
-		"eksaRegion":          base64.StdEncoding.EncodeToString([]byte(pc.eksaRegion)),
+		"eksaRegion":          base64.StdEncoding.EncodeToString([]byte(pc.region())),
 		"eksaAwsConfig":       base64.StdEncoding.EncodeToString([]byte(pc.eksaAwsConfig)),
 		"mirrorEndpoint":      base64.StdEncoding.EncodeToString([]byte(endpoint)),
 		"mirrorUsername":      base64.StdEncoding.EncodeToString([]byte(username)),
@@ -490,7 +516,8 @@ func (pc *PackageControllerClient) Reconcile(ctx context.Context, logger logr.Lo
 	// No Kubeconfig is passed. This is intentional. The helm executable will
 	// get that configuration from its environment.
 	if err := pc.EnableFullLifecycle(ctx, logger, cluster.Name, "", image, registry,
-		WithManagementClusterName(cluster.ManagedBy())); err != nil {
+		WithManagementClusterName(cluster.ManagedBy()),
+		withClusterConfig(cluster)); err != nil {
 		return fmt.Errorf("packages client error: %w", err)
 	}
 
@@ -618,6 +645,12 @@ func WithClusterSpec(clusterSpec *cluster.Spec) func(client *PackageControllerCl
 	}
 }
 
+func withClusterConfig(cluster *anywherev1.Cluster) func(client *PackageControllerClient) {
+	return func(config *PackageControllerClient) {
+		config.clusterSpec = &cluster.Spec
+	}
+}
+
 // WithRegistryAccessTester sets the registryTester.
 func WithRegistryAccessTester(registryTester registryAccessTester) func(client *PackageControllerClient) {
 	return func(config *PackageControllerClient) {
@@ -303,6 +303,38 @@ func TestEnableSuccess(t *testing.T) {
 	}
 }
 
+func TestEnableWithHelmPostRenderer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	k := mocks.NewMockKubectlRunner(ctrl)
+	cm := mocks.NewMockChartManager(ctrl)
+	chart := &artifactsv1.Image{Name: "test_controller", URI: "test_registry/eks-anywhere/eks-anywhere-packages:v1"}
+	writer, _ := filewriter.NewWriter("billy")
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.HelmPostRenderer = &v1alpha1.HelmPostRenderer{BinaryPath: "/opt/bin/kustomize-labels"}
+	})
+	command := curatedpackages.NewPackageControllerClient(
+		cm, k, "billy", "kubeconfig.kubeconfig", chart, nil,
+		curatedpackages.WithManagementClusterName("billy"),
+		curatedpackages.WithValuesFileWriter(writer),
+		curatedpackages.WithClusterSpec(clusterSpec),
+	)
+
+	// The last matcher is the post-renderer helm option.
+	cm.EXPECT().InstallChart(ctx, chart.Name, gomock.Any(), chart.Tag(), "kubeconfig.kubeconfig", constants.EksaPackagesName, gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil)
+	k.EXPECT().
+		GetObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(getPBCSuccess(t)).
+		AnyTimes()
+	k.EXPECT().
+		HasResource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _, _ interface{}) (bool, error) { return true, nil }).
+		AnyTimes()
+
+	g.Expect(command.Enable(ctx)).To(Succeed())
+}
+
 func TestEnableSucceedInWorkloadCluster(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		tt.command = curatedpackages.NewPackageControllerClient(
@@ -1012,6 +1044,56 @@ func TestGetCuratedPackagesRegistriesCustomRegion(t *testing.T) {
 	g.Expect(img).To(Equal("783794618700.dkr.ecr.test.amazonaws.com"))
 }
 
+func TestGetCuratedPackagesRegistriesSpecRegion(t *testing.T) {
+	clusterSpec := v1alpha1.ClusterSpec{
+		Packages: &v1alpha1.PackageConfiguration{
+			Region: "us-east-2",
+		},
+	}
+	chart := &artifactsv1.Image{
+		Name: "test_controller",
+		URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
+	}
+	g := NewWithT(t)
+	var testedRegion string
+	cluster := cluster.Spec{Config: &cluster.Config{Cluster: &v1alpha1.Cluster{Spec: clusterSpec}}}
+	sut := curatedpackages.NewPackageControllerClient(nil, nil, "billy", "", chart, nil,
+		curatedpackages.WithClusterSpec(&cluster),
+		curatedpackages.WithEksaRegion("test"),
+		curatedpackages.WithRegistryAccessTester(func(ctx context.Context, accessKey, secret, registry, region string) error {
+			testedRegion = region
+			return nil
+		}),
+	)
+	_, defaultRegistry, img := sut.GetCuratedPackagesRegistries(context.Background())
+	g.Expect(testedRegion).To(Equal("us-east-2"))
+	g.Expect(defaultRegistry).To(Equal("TODO.dkr.ecr.us-east-2.amazonaws.com"))
+	g.Expect(img).To(Equal("TODO.dkr.ecr.us-east-2.amazonaws.com"))
+}
+
+func TestGetCuratedPackagesRegistriesSpecBundleRegistry(t *testing.T) {
+	clusterSpec := v1alpha1.ClusterSpec{
+		Packages: &v1alpha1.PackageConfiguration{
+			BundleRegistry: "my-registry.example.com/bundles",
+		},
+	}
+	chart := &artifactsv1.Image{
+		Name: "test_controller",
+		URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
+	}
+	g := NewWithT(t)
+	cluster := cluster.Spec{Config: &cluster.Config{Cluster: &v1alpha1.Cluster{Spec: clusterSpec}}}
+	sut := curatedpackages.NewPackageControllerClient(nil, nil, "billy", "", chart, nil,
+		curatedpackages.WithClusterSpec(&cluster),
+		curatedpackages.WithRegistryAccessTester(func(ctx context.Context, accessKey, secret, registry, region string) error {
+			return nil
+		}),
+	)
+	_, defaultRegistry, img := sut.GetCuratedPackagesRegistries(context.Background())
+	g.Expect(defaultRegistry).To(Equal("my-registry.example.com/bundles"))
+	g.Expect(img).To(Equal("TODO.dkr.ecr.us-west-2.amazonaws.com"))
+}
+
 func TestGetPackageControllerConfigurationError(t *testing.T) {
 	clusterSpec := v1alpha1.ClusterSpec{
 		Packages: &v1alpha1.PackageConfiguration{
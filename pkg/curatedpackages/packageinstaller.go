@@ -77,6 +77,9 @@ func (pi *Installer) installPackagesController(ctx context.Context) error {
 }
 
 func (pi *Installer) installPackages(ctx context.Context) error {
+	if err := pi.installMetalLB(ctx); err != nil {
+		return err
+	}
 	if pi.packagesLocation == "" {
 		return nil
 	}
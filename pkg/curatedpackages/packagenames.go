@@ -0,0 +1,34 @@
+package curatedpackages
+
+import (
+	"context"
+	"fmt"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ActiveBundlePackageNames returns the names of the packages in the active package bundle
+// of a cluster, as set in its package bundle controller.
+func ActiveBundlePackageNames(ctx context.Context, client kubernetes.Reader, clusterName string) ([]string, error) {
+	controller := &packagesv1.PackageBundleController{}
+	if err := client.Get(ctx, clusterName, constants.EksaPackagesName, controller); err != nil {
+		return nil, fmt.Errorf("getting package bundle controller: %v", err)
+	}
+	if controller.Spec.ActiveBundle == "" {
+		return nil, fmt.Errorf("package bundle controller %s has no active bundle", clusterName)
+	}
+
+	bundle := &packagesv1.PackageBundle{}
+	if err := client.Get(ctx, controller.Spec.ActiveBundle, constants.EksaPackagesName, bundle); err != nil {
+		return nil, fmt.Errorf("getting active package bundle: %v", err)
+	}
+
+	names := make([]string, 0, len(bundle.Spec.Packages))
+	for _, p := range bundle.Spec.Packages {
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,62 @@
+package curatedpackages_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func newPackagesKubeClient(t *testing.T, objs ...client.Object) kubernetes.Client {
+	scheme := runtime.NewScheme()
+	if err := packagesv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return test.NewKubeClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
+
+func TestActiveBundlePackageNames(t *testing.T) {
+	g := NewWithT(t)
+	controller := &packagesv1.PackageBundleController{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaPackagesName},
+		Spec:       packagesv1.PackageBundleControllerSpec{ActiveBundle: "v1-27-100"},
+	}
+	bundle := &packagesv1.PackageBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-27-100", Namespace: constants.EksaPackagesName},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{{Name: "harbor"}, {Name: "metallb"}},
+		},
+	}
+	client := newPackagesKubeClient(t, controller, bundle)
+
+	g.Expect(curatedpackages.ActiveBundlePackageNames(context.Background(), client, "my-cluster")).To(Equal([]string{"harbor", "metallb"}))
+}
+
+func TestActiveBundlePackageNamesNoActiveBundle(t *testing.T) {
+	g := NewWithT(t)
+	controller := &packagesv1.PackageBundleController{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaPackagesName},
+	}
+	client := newPackagesKubeClient(t, controller)
+
+	_, err := curatedpackages.ActiveBundlePackageNames(context.Background(), client, "my-cluster")
+	g.Expect(err).To(MatchError("package bundle controller my-cluster has no active bundle"))
+}
+
+func TestActiveBundlePackageNamesNoController(t *testing.T) {
+	g := NewWithT(t)
+	client := newPackagesKubeClient(t)
+
+	_, err := curatedpackages.ActiveBundlePackageNames(context.Background(), client, "my-cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("getting package bundle controller")))
+}
@@ -0,0 +1,238 @@
+package curatedpackages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	yamlutil "github.com/aws/eks-anywhere/pkg/utils/yaml"
+)
+
+// PackageSet is a set of Package resources and the resources they depend on, like the Secrets
+// referenced in their configuration, read from a manifest.
+type PackageSet struct {
+	// Packages are applied in the order they appear in the manifest.
+	Packages []packagesv1.Package
+	// Resources are the other resources in the manifest. They are applied before the packages.
+	Resources [][]byte
+}
+
+// ParsePackageSet reads a PackageSet from a multi-document manifest. Packages must set their
+// namespace and can only appear once.
+func ParsePackageSet(content []byte) (*PackageSet, error) {
+	docs, err := yamlutil.SplitDocuments(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("splitting packages manifest: %v", err)
+	}
+
+	set := &PackageSet{}
+	seen := map[string]bool{}
+	for _, doc := range docs {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("parsing packages manifest: %v", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.GroupVersionKind().GroupKind() != packagesv1.GroupVersion.WithKind(kind).GroupKind() {
+			set.Resources = append(set.Resources, doc)
+			continue
+		}
+
+		p := packagesv1.Package{}
+		if err := yaml.UnmarshalStrict(doc, &p); err != nil {
+			return nil, fmt.Errorf("parsing package %s: %v", obj.GetName(), err)
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("package for %s is missing a name", p.Spec.PackageName)
+		}
+		if p.Namespace == "" {
+			return nil, fmt.Errorf("package %s is missing a namespace, packages are stored in the eksa-packages-<cluster name> namespace of their cluster", p.Name)
+		}
+		key := p.Namespace + "/" + p.Name
+		if seen[key] {
+			return nil, fmt.Errorf("package %s is defined more than once", key)
+		}
+		seen[key] = true
+		set.Packages = append(set.Packages, p)
+	}
+
+	return set, nil
+}
+
+// namespaces returns the namespaces of the packages in the set, sorted.
+func (s *PackageSet) namespaces() []string {
+	unique := map[string]bool{}
+	for _, p := range s.Packages {
+		unique[p.Namespace] = true
+	}
+	namespaces := make([]string, 0, len(unique))
+	for ns := range unique {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// PackageSetApplier reconciles the packages in a cluster to match a PackageSet.
+type PackageSetApplier struct {
+	kubectl         KubectlRunner
+	prune           bool
+	wait            bool
+	installTimeout  time.Duration
+	installInterval time.Duration
+}
+
+// PackageSetApplierOpt configures a PackageSetApplier.
+type PackageSetApplierOpt func(*PackageSetApplier)
+
+// WithPrune makes the applier delete the packages in the namespaces of the set that are not
+// in the set.
+func WithPrune() PackageSetApplierOpt {
+	return func(a *PackageSetApplier) {
+		a.prune = true
+	}
+}
+
+// WithWaitForInstalled makes the applier wait for each package to be installed before applying the
+// next one, for at most timeout.
+func WithWaitForInstalled(timeout time.Duration) PackageSetApplierOpt {
+	return func(a *PackageSetApplier) {
+		a.wait = true
+		a.installTimeout = timeout
+	}
+}
+
+// WithInstallPollInterval sets how often the applier checks if a package is installed.
+func WithInstallPollInterval(interval time.Duration) PackageSetApplierOpt {
+	return func(a *PackageSetApplier) {
+		a.installInterval = interval
+	}
+}
+
+// NewPackageSetApplier returns a PackageSetApplier.
+func NewPackageSetApplier(kubectl KubectlRunner, opts ...PackageSetApplierOpt) *PackageSetApplier {
+	a := &PackageSetApplier{
+		kubectl:         kubectl,
+		installTimeout:  defaultPackageInstallTimeout,
+		installInterval: defaultPackageInstallInterval,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Apply creates the packages of the set missing in the cluster, updates the ones that changed
+// and, when pruning, deletes the ones not in the set. The other resources of the set are applied
+// first, then the packages in order and finally the deletions.
+func (a *PackageSetApplier) Apply(ctx context.Context, kubeconfig string, set *PackageSet) error {
+	existing, err := a.existingPackages(ctx, kubeconfig, set.namespaces())
+	if err != nil {
+		return err
+	}
+
+	if len(set.Resources) > 0 {
+		logger.Info("Applying package resources", "count", len(set.Resources))
+		if _, err := a.kubectl.ExecuteFromYaml(ctx, yamlutil.Join(set.Resources), "apply", "-f", "-", "--kubeconfig", kubeconfig); err != nil {
+			return fmt.Errorf("applying package resources: %v", err)
+		}
+	}
+
+	var created, updated, unchanged, deleted int
+	desired := map[string]bool{}
+	for i, p := range set.Packages {
+		key := p.Namespace + "/" + p.Name
+		desired[key] = true
+		progress := fmt.Sprintf("[%d/%d]", i+1, len(set.Packages))
+
+		current, found := existing[key]
+		switch {
+		case found && reflect.DeepEqual(current.Spec, p.Spec):
+			logger.Info(progress+" Package unchanged", "package", key)
+			unchanged++
+			continue
+		case found:
+			logger.Info(progress+" Updating package", "package", key)
+			updated++
+		default:
+			logger.Info(progress+" Creating package", "package", key)
+			created++
+		}
+
+		if err := a.applyPackage(ctx, kubeconfig, p); err != nil {
+			return err
+		}
+
+		if a.wait {
+			if _, err := waitForPackageInstalled(ctx, a.kubectl, kubeconfig, p.Name, p.Namespace, a.installTimeout, a.installInterval); err != nil {
+				return err
+			}
+			logger.Info(progress+" Package installed", "package", key)
+		}
+	}
+
+	if a.prune {
+		keys := make([]string, 0, len(existing))
+		for key := range existing {
+			if !desired[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			p := existing[key]
+			logger.Info("Deleting package", "package", key)
+			if _, err := a.kubectl.ExecuteCommand(ctx, "delete", packageResource, p.Name, "--namespace", p.Namespace, "--kubeconfig", kubeconfig); err != nil {
+				return fmt.Errorf("deleting package %s: %v", key, err)
+			}
+			deleted++
+		}
+	}
+
+	logger.Info("Packages applied", "created", created, "updated", updated, "unchanged", unchanged, "deleted", deleted)
+	return nil
+}
+
+func (a *PackageSetApplier) applyPackage(ctx context.Context, kubeconfig string, p packagesv1.Package) error {
+	p.TypeMeta.APIVersion = packagesv1.GroupVersion.String()
+	p.TypeMeta.Kind = kind
+	content, err := yaml.Marshal(NewDisplayablePackage(&p))
+	if err != nil {
+		return fmt.Errorf("marshalling package %s: %v", p.Name, err)
+	}
+	if _, err := a.kubectl.ExecuteFromYaml(ctx, content, "apply", "-f", "-", "--kubeconfig", kubeconfig); err != nil {
+		return fmt.Errorf("applying package %s/%s: %v", p.Namespace, p.Name, err)
+	}
+	return nil
+}
+
+// existingPackages returns the packages in the namespaces, keyed by namespace/name.
+func (a *PackageSetApplier) existingPackages(ctx context.Context, kubeconfig string, namespaces []string) (map[string]packagesv1.Package, error) {
+	existing := map[string]packagesv1.Package{}
+	for _, ns := range namespaces {
+		out, err := a.kubectl.ExecuteCommand(ctx, "get", packageResource, "--namespace", ns, "--kubeconfig", kubeconfig, "-o", "json")
+		if err != nil {
+			return nil, fmt.Errorf("listing packages in %s: %v", ns, err)
+		}
+		list := &packagesv1.PackageList{}
+		if err := json.Unmarshal(out.Bytes(), list); err != nil {
+			return nil, fmt.Errorf("parsing packages in %s: %v", ns, err)
+		}
+		for _, p := range list.Items {
+			existing[p.Namespace+"/"+p.Name] = p
+		}
+	}
+	return existing, nil
+}
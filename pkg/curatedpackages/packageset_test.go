@@ -0,0 +1,259 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+const packageSetManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: harbor-admin
+  namespace: harbor
+stringData:
+  password: secret
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-cert-manager
+  namespace: eksa-packages-billy
+spec:
+  packageName: cert-manager
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+  targetNamespace: harbor
+  config: |
+    secretKey: use-a-secret-key
+`
+
+func testPackage(name, packageName string) packagesv1.Package {
+	return packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "eksa-packages-billy"},
+		Spec:       packagesv1.PackageSpec{PackageName: packageName},
+	}
+}
+
+func TestParsePackageSet(t *testing.T) {
+	g := NewWithT(t)
+	set, err := curatedpackages.ParsePackageSet([]byte(packageSetManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(set.Resources).To(HaveLen(1))
+	g.Expect(string(set.Resources[0])).To(ContainSubstring("name: harbor-admin"))
+	g.Expect(set.Packages).To(HaveLen(2))
+	g.Expect(set.Packages[0].Name).To(Equal("my-cert-manager"))
+	g.Expect(set.Packages[1].Name).To(Equal("my-harbor"))
+	g.Expect(set.Packages[1].Spec).To(Equal(packagesv1.PackageSpec{
+		PackageName:     "harbor",
+		TargetNamespace: "harbor",
+		Config:          "secretKey: use-a-secret-key\n",
+	}))
+}
+
+func TestParsePackageSetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name: "missing namespace",
+			manifest: `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+spec:
+  packageName: harbor
+`,
+			wantErr: "package my-harbor is missing a namespace",
+		},
+		{
+			name: "duplicated package",
+			manifest: `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+`,
+			wantErr: "package eksa-packages-billy/my-harbor is defined more than once",
+		},
+		{
+			name: "unknown field",
+			manifest: `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  package: harbor
+`,
+			wantErr: "parsing package my-harbor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := curatedpackages.ParsePackageSet([]byte(tt.manifest))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+type packageSetApplierTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlRunner
+}
+
+func newPackageSetApplierTest(t *testing.T) *packageSetApplierTest {
+	ctrl := gomock.NewController(t)
+	return &packageSetApplierTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: mocks.NewMockKubectlRunner(ctrl),
+	}
+}
+
+func (tt *packageSetApplierTest) expectListPackages(packages ...packagesv1.Package) {
+	list, err := json.Marshal(packagesv1.PackageList{Items: packages})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.kubectl.EXPECT().
+		ExecuteCommand(tt.ctx, "get", "packages", "--namespace", "eksa-packages-billy", "--kubeconfig", "kubeconfig", "-o", "json").
+		Return(*bytes.NewBuffer(list), nil)
+}
+
+func (tt *packageSetApplierTest) expectApplyPackage(name string) *gomock.Call {
+	return tt.kubectl.EXPECT().
+		ExecuteFromYaml(tt.ctx, gomock.Any(), "apply", "-f", "-", "--kubeconfig", "kubeconfig").
+		DoAndReturn(func(_ context.Context, content []byte, _ ...string) (bytes.Buffer, error) {
+			tt.Expect(string(content)).To(ContainSubstring("kind: Package"))
+			tt.Expect(string(content)).To(ContainSubstring("name: " + name))
+			tt.Expect(string(content)).NotTo(ContainSubstring("status"))
+			return bytes.Buffer{}, nil
+		})
+}
+
+func TestPackageSetApplierApply(t *testing.T) {
+	tt := newPackageSetApplierTest(t)
+	set := &curatedpackages.PackageSet{
+		Packages: []packagesv1.Package{
+			testPackage("my-cert-manager", "cert-manager"),
+			testPackage("my-harbor", "harbor"),
+			testPackage("my-emissary", "emissary"),
+		},
+		Resources: [][]byte{[]byte("kind: Secret")},
+	}
+	currentHarbor := testPackage("my-harbor", "harbor")
+	currentHarbor.Spec.Config = "old: config"
+	tt.expectListPackages(testPackage("my-cert-manager", "cert-manager"), currentHarbor, testPackage("my-hello", "hello-eks-anywhere"))
+
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, []byte("kind: Secret"), "apply", "-f", "-", "--kubeconfig", "kubeconfig"),
+		tt.expectApplyPackage("my-harbor"),
+		tt.expectApplyPackage("my-emissary"),
+	)
+
+	tt.Expect(curatedpackages.NewPackageSetApplier(tt.kubectl).Apply(tt.ctx, "kubeconfig", set)).To(Succeed())
+}
+
+func TestPackageSetApplierApplyPrune(t *testing.T) {
+	tt := newPackageSetApplierTest(t)
+	set := &curatedpackages.PackageSet{
+		Packages: []packagesv1.Package{testPackage("my-harbor", "harbor")},
+	}
+	tt.expectListPackages(testPackage("my-hello", "hello-eks-anywhere"), testPackage("my-emissary", "emissary"))
+
+	gomock.InOrder(
+		tt.expectApplyPackage("my-harbor"),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "delete", "packages", "my-emissary", "--namespace", "eksa-packages-billy", "--kubeconfig", "kubeconfig"),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "delete", "packages", "my-hello", "--namespace", "eksa-packages-billy", "--kubeconfig", "kubeconfig"),
+	)
+
+	tt.Expect(curatedpackages.NewPackageSetApplier(tt.kubectl, curatedpackages.WithPrune()).Apply(tt.ctx, "kubeconfig", set)).To(Succeed())
+}
+
+func TestPackageSetApplierApplyWait(t *testing.T) {
+	tt := newPackageSetApplierTest(t)
+	set := &curatedpackages.PackageSet{
+		Packages: []packagesv1.Package{testPackage("my-cert-manager", "cert-manager"), testPackage("my-harbor", "harbor")},
+	}
+	tt.expectListPackages()
+	getInstalled := func(name string) *gomock.Call {
+		return tt.kubectl.EXPECT().
+			GetObject(gomock.Any(), "packages", name, "eksa-packages-billy", "kubeconfig", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+				obj.(*packagesv1.Package).Status.State = packagesv1.StateInstalled
+				return nil
+			})
+	}
+
+	gomock.InOrder(
+		tt.expectApplyPackage("my-cert-manager"),
+		getInstalled("my-cert-manager"),
+		tt.expectApplyPackage("my-harbor"),
+		getInstalled("my-harbor"),
+	)
+
+	applier := curatedpackages.NewPackageSetApplier(tt.kubectl,
+		curatedpackages.WithWaitForInstalled(time.Second),
+		curatedpackages.WithInstallPollInterval(time.Millisecond),
+	)
+	tt.Expect(applier.Apply(tt.ctx, "kubeconfig", set)).To(Succeed())
+}
+
+func TestPackageSetApplierApplyError(t *testing.T) {
+	tt := newPackageSetApplierTest(t)
+	set := &curatedpackages.PackageSet{
+		Packages: []packagesv1.Package{testPackage("my-cert-manager", "cert-manager"), testPackage("my-harbor", "harbor")},
+	}
+	tt.expectListPackages()
+	tt.kubectl.EXPECT().
+		ExecuteFromYaml(tt.ctx, gomock.Any(), "apply", "-f", "-", "--kubeconfig", "kubeconfig").
+		Return(bytes.Buffer{}, errors.New("webhook denied"))
+
+	err := curatedpackages.NewPackageSetApplier(tt.kubectl).Apply(tt.ctx, "kubeconfig", set)
+	tt.Expect(err).To(MatchError("applying package eksa-packages-billy/my-cert-manager: webhook denied"))
+}
+
+func TestPackageSetApplierApplyListError(t *testing.T) {
+	tt := newPackageSetApplierTest(t)
+	set := &curatedpackages.PackageSet{
+		Packages: []packagesv1.Package{testPackage("my-harbor", "harbor")},
+	}
+	tt.kubectl.EXPECT().
+		ExecuteCommand(tt.ctx, "get", "packages", "--namespace", "eksa-packages-billy", "--kubeconfig", "kubeconfig", "-o", "json").
+		Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	err := curatedpackages.NewPackageSetApplier(tt.kubectl).Apply(tt.ctx, "kubeconfig", set)
+	tt.Expect(err).To(MatchError("listing packages in eksa-packages-billy: connection refused"))
+}
@@ -0,0 +1,136 @@
+package curatedpackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	packageResource               = "packages"
+	defaultPackageInstallTimeout  = 10 * time.Minute
+	defaultPackageInstallInterval = 5 * time.Second
+)
+
+// ReleaseTester runs the test hooks of helm releases.
+type ReleaseTester interface {
+	// Test runs the tests of a release and returns the helm output with the logs of the test pods.
+	Test(ctx context.Context, kubeconfigFilePath, release, namespace string) (string, error)
+}
+
+// PackageTester verifies the functional health of installed packages by running the helm tests
+// of their charts.
+type PackageTester struct {
+	kubectl         KubectlRunner
+	helm            ReleaseTester
+	installTimeout  time.Duration
+	installInterval time.Duration
+}
+
+// PackageTesterOpt configures a PackageTester.
+type PackageTesterOpt func(*PackageTester)
+
+// WithPackageInstallTimeout sets how long the tester waits for a package to be installed before
+// running its tests.
+func WithPackageInstallTimeout(timeout time.Duration) PackageTesterOpt {
+	return func(t *PackageTester) {
+		t.installTimeout = timeout
+	}
+}
+
+// WithPackageInstallInterval sets how often the tester checks if a package is installed.
+func WithPackageInstallInterval(interval time.Duration) PackageTesterOpt {
+	return func(t *PackageTester) {
+		t.installInterval = interval
+	}
+}
+
+// NewPackageTester returns a PackageTester.
+func NewPackageTester(kubectl KubectlRunner, helm ReleaseTester, opts ...PackageTesterOpt) *PackageTester {
+	t := &PackageTester{
+		kubectl:         kubectl,
+		helm:            helm,
+		installTimeout:  defaultPackageInstallTimeout,
+		installInterval: defaultPackageInstallInterval,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// TestPackage waits for the package to be installed and runs the helm tests of its release.
+// Packages are stored in the management cluster, in kubeconfig, while their releases are installed
+// in the cluster they target, in releaseKubeconfig. If the tests fail, the failure is recorded in
+// the detail of the package status and returned with the logs of the test pods.
+func (t *PackageTester) TestPackage(ctx context.Context, kubeconfig, releaseKubeconfig, clusterName, name string) error {
+	namespace := constants.EksaPackagesName + "-" + clusterName
+	p, err := waitForPackageInstalled(ctx, t.kubectl, kubeconfig, name, namespace, t.installTimeout, t.installInterval)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Running package tests", "package", name)
+	out, err := t.helm.Test(ctx, releaseKubeconfig, p.Name, p.Spec.TargetNamespace)
+	if err != nil {
+		detail := fmt.Sprintf("helm tests failed: %v", err)
+		if patchErr := t.setStatusDetail(ctx, kubeconfig, name, namespace, detail); patchErr != nil {
+			logger.V(2).Info("Failed recording package test failure in status", "package", name, "error", patchErr)
+		}
+		return fmt.Errorf("package %s tests failed: %v\n%s", name, err, out)
+	}
+
+	logger.V(4).Info("Package tests succeeded", "package", name, "output", out)
+	return nil
+}
+
+// waitForPackageInstalled polls the package until the packages controller installs it.
+func waitForPackageInstalled(ctx context.Context, kubectl KubectlRunner, kubeconfig, name, namespace string, timeout, interval time.Duration) (*packagesv1.Package, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	p := &packagesv1.Package{}
+	for {
+		err := kubectl.GetObject(timeoutCtx, packageResource, name, namespace, kubeconfig, p)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting package %s: %v", name, err)
+		}
+		if err == nil && p.Status.State == packagesv1.StateInstalled {
+			return p, nil
+		}
+		logger.V(6).Info("Waiting for package to be installed", "package", name, "state", p.Status.State)
+
+		select {
+		case <-timeoutCtx.Done():
+			return nil, fmt.Errorf("timed out waiting for package %s to be installed, last state %q: %s", name, p.Status.State, p.Status.Detail)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (t *PackageTester) setStatusDetail(ctx context.Context, kubeconfig, name, namespace, detail string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"detail": detail},
+	})
+	if err != nil {
+		return err
+	}
+
+	params := []string{
+		"patch", packageResource, name,
+		"--namespace", namespace,
+		"--kubeconfig", kubeconfig,
+		"--subresource", "status",
+		"--type", "merge",
+		"-p", string(patch),
+	}
+	_, err = t.kubectl.ExecuteCommand(ctx, params...)
+	return err
+}
@@ -0,0 +1,120 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+type packageTesterTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlRunner
+	helm    *mocks.MockReleaseTester
+	tester  *curatedpackages.PackageTester
+}
+
+func newPackageTesterTest(t *testing.T) *packageTesterTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlRunner(ctrl)
+	helm := mocks.NewMockReleaseTester(ctrl)
+	return &packageTesterTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		helm:    helm,
+		tester: curatedpackages.NewPackageTester(kubectl, helm,
+			curatedpackages.WithPackageInstallTimeout(time.Second),
+			curatedpackages.WithPackageInstallInterval(time.Millisecond),
+		),
+	}
+}
+
+func (tt *packageTesterTest) expectGetPackage(state packagesv1.StateEnum) *gomock.Call {
+	return tt.kubectl.EXPECT().
+		GetObject(gomock.Any(), "packages", "my-harbor", "eksa-packages-billy", "mgmt.kubeconfig", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			p := obj.(*packagesv1.Package)
+			p.Name = "my-harbor"
+			p.Spec.TargetNamespace = "harbor"
+			p.Status.State = state
+			return nil
+		})
+}
+
+func TestPackageTesterTestPackageSuccess(t *testing.T) {
+	tt := newPackageTesterTest(t)
+	gomock.InOrder(
+		tt.kubectl.EXPECT().
+			GetObject(gomock.Any(), "packages", "my-harbor", "eksa-packages-billy", "mgmt.kubeconfig", gomock.Any()).
+			Return(apierrors.NewNotFound(schema.GroupResource{}, "my-harbor")),
+		tt.expectGetPackage(packagesv1.StateInstalling),
+		tt.expectGetPackage(packagesv1.StateInstalled),
+		tt.helm.EXPECT().Test(tt.ctx, "workload.kubeconfig", "my-harbor", "harbor").Return("Phase: Succeeded", nil),
+	)
+
+	tt.Expect(tt.tester.TestPackage(tt.ctx, "mgmt.kubeconfig", "workload.kubeconfig", "billy", "my-harbor")).To(Succeed())
+}
+
+func TestPackageTesterTestPackageFailure(t *testing.T) {
+	tt := newPackageTesterTest(t)
+	tt.expectGetPackage(packagesv1.StateInstalled)
+	tt.helm.EXPECT().Test(tt.ctx, "workload.kubeconfig", "my-harbor", "harbor").
+		Return("POD LOGS: my-harbor-test\nconnection refused", errors.New("testing helm release my-harbor: pod my-harbor-test failed"))
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx,
+		"patch", "packages", "my-harbor",
+		"--namespace", "eksa-packages-billy",
+		"--kubeconfig", "mgmt.kubeconfig",
+		"--subresource", "status",
+		"--type", "merge",
+		"-p", `{"status":{"detail":"helm tests failed: testing helm release my-harbor: pod my-harbor-test failed"}}`,
+	).Return(bytes.Buffer{}, nil)
+
+	err := tt.tester.TestPackage(tt.ctx, "mgmt.kubeconfig", "workload.kubeconfig", "billy", "my-harbor")
+	tt.Expect(err).To(MatchError(ContainSubstring("package my-harbor tests failed: testing helm release my-harbor: pod my-harbor-test failed")))
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")), "the error should include the test pod logs")
+}
+
+func TestPackageTesterTestPackageFailureStatusPatchError(t *testing.T) {
+	tt := newPackageTesterTest(t)
+	tt.expectGetPackage(packagesv1.StateInstalled)
+	tt.helm.EXPECT().Test(tt.ctx, "workload.kubeconfig", "my-harbor", "harbor").Return("", errors.New("pod my-harbor-test failed"))
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	err := tt.tester.TestPackage(tt.ctx, "mgmt.kubeconfig", "workload.kubeconfig", "billy", "my-harbor")
+	tt.Expect(err).To(MatchError(ContainSubstring("package my-harbor tests failed: pod my-harbor-test failed")))
+}
+
+func TestPackageTesterTestPackageGetError(t *testing.T) {
+	tt := newPackageTesterTest(t)
+	tt.kubectl.EXPECT().
+		GetObject(gomock.Any(), "packages", "my-harbor", "eksa-packages-billy", "mgmt.kubeconfig", gomock.Any()).
+		Return(errors.New("connection refused"))
+
+	err := tt.tester.TestPackage(tt.ctx, "mgmt.kubeconfig", "workload.kubeconfig", "billy", "my-harbor")
+	tt.Expect(err).To(MatchError("getting package my-harbor: connection refused"))
+}
+
+func TestPackageTesterTestPackageInstallTimeout(t *testing.T) {
+	tt := newPackageTesterTest(t)
+	tt.tester = curatedpackages.NewPackageTester(tt.kubectl, tt.helm,
+		curatedpackages.WithPackageInstallTimeout(10*time.Millisecond),
+		curatedpackages.WithPackageInstallInterval(time.Millisecond),
+	)
+	tt.expectGetPackage(packagesv1.StateInstalling).AnyTimes()
+
+	err := tt.tester.TestPackage(tt.ctx, "mgmt.kubeconfig", "workload.kubeconfig", "billy", "my-harbor")
+	tt.Expect(err).To(MatchError(ContainSubstring(`timed out waiting for package my-harbor to be installed, last state "installing"`)))
+}
@@ -0,0 +1,74 @@
+package curatedpackages
+
+import (
+	"fmt"
+	"strings"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+const packageDocsURL = "https://anywhere.eks.amazonaws.com/docs/packages"
+
+// PackagesUpgradePlan is the list of package version changes between the bundle installed
+// in a cluster and the latest bundle available.
+type PackagesUpgradePlan struct {
+	CurrentBundle string               `json:"currentBundle"`
+	LatestBundle  string               `json:"latestBundle"`
+	Packages      []PackageVersionDiff `json:"packages"`
+}
+
+// PackageVersionDiff is the version change of a package between two bundles.
+// CurrentVersion is empty for packages that are not in the current bundle.
+type PackageVersionDiff struct {
+	Name           string `json:"name"`
+	CurrentVersion string `json:"currentVersion"`
+	LatestVersion  string `json:"latestVersion"`
+	Changelog      string `json:"changelog"`
+}
+
+// PlanPackagesUpgrade compares the default version of every package in the latest bundle
+// with the same package in the current bundle and returns the ones that change.
+func PlanPackagesUpgrade(current, latest *packagesv1.PackageBundle) *PackagesUpgradePlan {
+	plan := &PackagesUpgradePlan{
+		CurrentBundle: current.Name,
+		LatestBundle:  latest.Name,
+		Packages:      []PackageVersionDiff{},
+	}
+
+	currentVersions := make(map[string]string, len(current.Spec.Packages))
+	for _, p := range current.Spec.Packages {
+		currentVersions[p.Name] = defaultPackageVersion(p)
+	}
+
+	for _, p := range latest.Spec.Packages {
+		latestVersion := defaultPackageVersion(p)
+		currentVersion := currentVersions[p.Name]
+		if latestVersion == "" || latestVersion == currentVersion {
+			continue
+		}
+		plan.Packages = append(plan.Packages, PackageVersionDiff{
+			Name:           p.Name,
+			CurrentVersion: currentVersion,
+			LatestVersion:  latestVersion,
+			Changelog:      PackageChangelogURL(p.Name, latestVersion),
+		})
+	}
+
+	return plan
+}
+
+// PackageChangelogURL returns the link to the release notes of a package version in the
+// EKS Anywhere docs. Bundle versions are suffixed with the build digest, which is dropped.
+func PackageChangelogURL(packageName, version string) string {
+	release, _, _ := strings.Cut(version, "-")
+	return fmt.Sprintf("%s/%s/v%s/", packageDocsURL, packageName, strings.TrimPrefix(release, "v"))
+}
+
+// defaultPackageVersion returns the version a package is installed with by default,
+// which is the first one in the bundle.
+func defaultPackageVersion(p packagesv1.BundlePackage) string {
+	if len(p.Source.Versions) == 0 {
+		return ""
+	}
+	return p.Source.Versions[0].Name
+}
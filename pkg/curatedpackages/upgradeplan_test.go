@@ -0,0 +1,84 @@
+package curatedpackages_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func bundleWithPackages(name string, versions map[string]string) *packagesv1.PackageBundle {
+	bundle := &packagesv1.PackageBundle{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, pkg := range []string{"cert-manager", "harbor", "metallb"} {
+		version, ok := versions[pkg]
+		if !ok {
+			continue
+		}
+		bundle.Spec.Packages = append(bundle.Spec.Packages, packagesv1.BundlePackage{
+			Name: pkg,
+			Source: packagesv1.BundlePackageSource{
+				Versions: []packagesv1.SourceVersion{{Name: version}},
+			},
+		})
+	}
+	return bundle
+}
+
+func TestPlanPackagesUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	current := bundleWithPackages("v1-27-100", map[string]string{
+		"harbor":  "2.5.1-ee7e5a6898b6c35668a1c5789aa0d654fad6c913",
+		"metallb": "0.13.7-758df43f8c5a3c2ac693365d06e7b0feba87efd5",
+	})
+	latest := bundleWithPackages("v1-27-120", map[string]string{
+		"cert-manager": "1.9.1-dc0c845b5f71bea6869efccd3ca3f2dd11b5c95f",
+		"harbor":       "2.7.1-9f1b1e2b8d0e4c1f5c6a7b8c9d0e1f2a3b4c5d6e",
+		"metallb":      "0.13.7-758df43f8c5a3c2ac693365d06e7b0feba87efd5",
+	})
+
+	g.Expect(curatedpackages.PlanPackagesUpgrade(current, latest)).To(Equal(&curatedpackages.PackagesUpgradePlan{
+		CurrentBundle: "v1-27-100",
+		LatestBundle:  "v1-27-120",
+		Packages: []curatedpackages.PackageVersionDiff{
+			{
+				Name:           "cert-manager",
+				CurrentVersion: "",
+				LatestVersion:  "1.9.1-dc0c845b5f71bea6869efccd3ca3f2dd11b5c95f",
+				Changelog:      "https://anywhere.eks.amazonaws.com/docs/packages/cert-manager/v1.9.1/",
+			},
+			{
+				Name:           "harbor",
+				CurrentVersion: "2.5.1-ee7e5a6898b6c35668a1c5789aa0d654fad6c913",
+				LatestVersion:  "2.7.1-9f1b1e2b8d0e4c1f5c6a7b8c9d0e1f2a3b4c5d6e",
+				Changelog:      "https://anywhere.eks.amazonaws.com/docs/packages/harbor/v2.7.1/",
+			},
+		},
+	}))
+}
+
+func TestPlanPackagesUpgradeUpToDate(t *testing.T) {
+	g := NewWithT(t)
+	versions := map[string]string{"harbor": "2.7.1-9f1b1e2b8d0e4c1f5c6a7b8c9d0e1f2a3b4c5d6e"}
+
+	plan := curatedpackages.PlanPackagesUpgrade(bundleWithPackages("v1-27-120", versions), bundleWithPackages("v1-27-120", versions))
+	g.Expect(plan.Packages).To(BeEmpty())
+}
+
+func TestPackageChangelogURL(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "0.13.7-758df43f8c5a3c2ac693365d06e7b0feba87efd5", want: "https://anywhere.eks.amazonaws.com/docs/packages/metallb/v0.13.7/"},
+		{version: "v0.13.7", want: "https://anywhere.eks.amazonaws.com/docs/packages/metallb/v0.13.7/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(curatedpackages.PackageChangelogURL("metallb", tt.version)).To(Equal(tt.want))
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -16,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/aws"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/certmanager"
 	"github.com/aws/eks-anywhere/pkg/cli"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -28,6 +31,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -40,6 +45,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/policyengine"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
@@ -105,6 +111,7 @@ type Dependencies struct {
 	NutanixValidator            *nutanix.Validator
 	SnowValidator               *snow.Validator
 	IPValidator                 *validator.IPValidator
+	SecretResolver              *externalsecrets.Resolver
 	UnAuthKubectlClient         KubeClients
 	CreateClusterDefaulter      cli.CreateClusterDefaulter
 	UpgradeClusterDefaulter     cli.UpgradeClusterDefaulter
@@ -135,6 +142,8 @@ func ForSpec(ctx context.Context, clusterSpec *cluster.Spec) *Factory {
 		WithRegistryMirror(registrymirror.FromCluster(clusterSpec.Cluster)).
 		UseProxyConfiguration(clusterSpec.Cluster.ProxyConfiguration()).
 		WithWriterFolder(clusterSpec.Cluster.Name).
+		WithHelmTemplateCache(filepath.Join(clusterSpec.Cluster.Name, filewriter.DefaultTmpFolder, "helm-template-cache")).
+		WithHelmPostRenderer(clusterSpec.Cluster.Spec.HelmPostRenderer).
 		WithDiagnosticCollectorImage(versionsBundle.Eksa.DiagnosticCollector.VersionedImage())
 }
 
@@ -145,7 +154,11 @@ type Factory struct {
 	registryMirror           *registrymirror.RegistryMirror
 	proxyConfiguration       map[string]string
 	writerFolder             string
+	govcEnvMap               map[string]string
+	helmTemplateCacheDir     string
+	helmPostRenderer         *v1alpha1.HelmPostRenderer
 	diagnosticCollectorImage string
+	diagnosticCollection     diagnostics.CollectionOptions
 	buildSteps               []buildStep
 	dependencies             Dependencies
 }
@@ -156,11 +169,13 @@ type executablesConfig struct {
 	useDockerContainer bool
 	dockerClient       executables.DockerClient
 	mountDirs          []string
+	pool               *executables.ExecutablesPool
 }
 
 type config struct {
-	bundlesOverride string
-	noTimeouts      bool
+	bundlesOverride    string
+	noTimeouts         bool
+	templateDiffWriter io.Writer
 }
 
 type buildStep func(ctx context.Context) error
@@ -196,6 +211,26 @@ func (f *Factory) WithWriterFolder(folder string) *Factory {
 	return f
 }
 
+// WithGovcEnvMap configures the Govc executable to run its commands with envMap instead of the
+// credentials and vCenter settings in the process env.
+func (f *Factory) WithGovcEnvMap(envMap map[string]string) *Factory {
+	f.govcEnvMap = envMap
+	return f
+}
+
+// WithHelmTemplateCache configures the Helm executable to cache rendered templates in dir.
+func (f *Factory) WithHelmTemplateCache(dir string) *Factory {
+	f.helmTemplateCacheDir = dir
+	return f
+}
+
+// WithHelmPostRenderer configures the Helm executable to pass the manifests of the charts it
+// templates and installs through the post-renderer. Nil disables it.
+func (f *Factory) WithHelmPostRenderer(renderer *v1alpha1.HelmPostRenderer) *Factory {
+	f.helmPostRenderer = renderer
+	return f
+}
+
 // WithRegistryMirror configures the factory to use registry mirror wherever applicable.
 func (f *Factory) WithRegistryMirror(registryMirror *registrymirror.RegistryMirror) *Factory {
 	f.registryMirror = registryMirror
@@ -297,6 +332,13 @@ func (f *Factory) WithLocalExecutables() *Factory {
 	return f
 }
 
+// WithExecutablesPool makes the executables builder share its tools container with the other
+// builders of pool for the same image and mount dirs, instead of starting its own container.
+func (f *Factory) WithExecutablesPool(pool *executables.ExecutablesPool) *Factory {
+	f.executablesConfig.pool = pool
+	return f
+}
+
 // UseExecutablesDockerClient forces a specific DockerClient to build
 // Executables as opposed to follow the normal building flow
 // This is only for testing.
@@ -344,25 +386,18 @@ func (f *Factory) WithExecutableBuilder() *Factory {
 			return nil
 		}
 
+		var closer executables.Closer
+		var err error
 		if f.executablesConfig.useDockerContainer {
 			image := f.executablesConfig.image
 			if f.registryMirror != nil {
 				image = f.registryMirror.ReplaceRegistry(image)
 			}
-			b, err := executables.NewInDockerExecutablesBuilder(
-				f.executablesConfig.dockerClient,
-				image,
-				f.executablesConfig.mountDirs...,
-			)
-			if err != nil {
-				return err
-			}
-			f.executablesConfig.builder = b
+			closer, err = f.initInDockerExecutablesBuilder(ctx, image)
 		} else {
 			f.executablesConfig.builder = executables.NewLocalExecutablesBuilder()
+			closer, err = f.executablesConfig.builder.Init(ctx)
 		}
-
-		closer, err := f.executablesConfig.builder.Init(ctx)
 		if err != nil {
 			return err
 		}
@@ -381,8 +416,34 @@ func (f *Factory) WithExecutableBuilder() *Factory {
 	return f
 }
 
+// initInDockerExecutablesBuilder builds and inits the executables builder for a tools container
+// of image, taking it from the executables pool if the factory has one.
+func (f *Factory) initInDockerExecutablesBuilder(ctx context.Context, image string) (executables.Closer, error) {
+	if f.executablesConfig.pool != nil {
+		b, closer, err := f.executablesConfig.pool.Get(ctx, image, f.executablesConfig.mountDirs...)
+		if err != nil {
+			return nil, err
+		}
+		f.executablesConfig.builder = b
+		return closer, nil
+	}
+
+	b, err := executables.NewInDockerExecutablesBuilder(
+		f.executablesConfig.dockerClient,
+		image,
+		f.executablesConfig.mountDirs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	f.executablesConfig.builder = b
+	return b.Init(ctx)
+}
+
 // WithProvider initializes the provider dependency and adds to the build steps.
 func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIPCheck bool, hardwareCSVPath string, force bool, tinkerbellBootstrapIP string, skippedValidations map[string]bool) *Factory { // nolint:gocyclo
+	f.WithProviderCredentials(clusterConfig)
+
 	switch clusterConfig.Spec.DatacenterRef.Kind {
 	case v1alpha1.VSphereDatacenterKind:
 		f.WithKubectl().WithGovc().WithWriter().WithIPValidator()
@@ -490,6 +551,19 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				return err
 			}
 
+			provider.ResolveBMCSecretsWith(f.dependencies.SecretResolver)
+
+			if features.IsActive(features.TinkerbellConsoleLogs()) {
+				consoleLogs := tinkerbell.NewConsoleLogCollector(
+					f.executablesConfig.builder.BuildIpmitoolExecutable(f.dependencies.Writer),
+					f.dependencies.Writer,
+				)
+				provider.EnableConsoleLogs(consoleLogs)
+				// The provider stops the capture once the cluster is provisioned. Closing it with the
+				// dependencies also stops it when the command fails before that.
+				f.dependencies.closers = append(f.dependencies.closers, consoleLogs)
+			}
+
 			f.dependencies.Provider = provider
 
 		case v1alpha1.DockerDatacenterKind:
@@ -583,7 +657,11 @@ func (f *Factory) WithGovc() *Factory {
 			return nil
 		}
 
-		f.dependencies.Govc = f.executablesConfig.builder.BuildGovcExecutable(f.dependencies.Writer)
+		var opts []executables.GovcOpt
+		if f.govcEnvMap != nil {
+			opts = append(opts, executables.WithGovcEnvMap(f.govcEnvMap))
+		}
+		f.dependencies.Govc = f.executablesConfig.builder.BuildGovcExecutable(f.dependencies.Writer, opts...)
 		f.dependencies.closers = append(f.dependencies.closers, f.dependencies.Govc)
 
 		return nil
@@ -746,6 +824,14 @@ func (f *Factory) WithHelm(opts ...executables.HelmOpt) *Factory {
 			opts = append(opts, executables.WithEnv(f.proxyConfiguration))
 		}
 
+		if f.helmTemplateCacheDir != "" {
+			opts = append(opts, executables.WithTemplateCache(f.helmTemplateCacheDir))
+		}
+
+		if f.helmPostRenderer != nil {
+			opts = append(opts, executables.WithPostRenderer(f.helmPostRenderer.BinaryPath, f.helmPostRenderer.Args...))
+		}
+
 		f.dependencies.Helm = f.executablesConfig.builder.BuildHelmExecutable(opts...)
 		return nil
 	})
@@ -881,6 +967,32 @@ func (f *Factory) WithIPValidator() *Factory {
 	return f
 }
 
+// WithSecretResolver builds a resolver for the references to external secrets. SOPS files are
+// decrypted with the sops binary in the admin machine, where the decryption keys are.
+func (f *Factory) WithSecretResolver() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.SecretResolver != nil {
+			return nil
+		}
+		f.dependencies.SecretResolver = externalsecrets.NewDefaultResolver(
+			executables.NewLocalExecutablesBuilder().BuildSopsExecutable(),
+		)
+		return nil
+	})
+	return f
+}
+
+// WithProviderCredentials reads the provider credentials referenced by clusterConfig from their
+// external secret managers and sets them in the environment, before the provider is built.
+func (f *Factory) WithProviderCredentials(clusterConfig *v1alpha1.Cluster) *Factory {
+	f.WithSecretResolver()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		return externalsecrets.ResolveProviderCredentials(ctx, f.dependencies.SecretResolver, clusterConfig)
+	})
+	return f
+}
+
 type bootstrapperClient struct {
 	*executables.Kind
 	*executables.Kubectl
@@ -958,7 +1070,7 @@ func (f *Factory) clusterManagerOpts(timeoutOpts *ClusterManagerTimeoutOptions)
 
 // WithClusterManager builds a cluster manager based on the cluster config and timeout options.
 func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, timeoutOpts *ClusterManagerTimeoutOptions) *Factory {
-	f.WithClusterctl().WithKubectl().WithNetworking(clusterConfig).WithWriter().WithDiagnosticBundleFactory().WithAwsIamAuth().WithFileReader().WithUnAuthKubeClient()
+	f.WithClusterctl().WithKubectl().WithNetworking(clusterConfig).WithWriter().WithDiagnosticBundleFactory().WithAwsIamAuth().WithFileReader().WithUnAuthKubeClient().WithHelm(executables.WithInsecure())
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.ClusterManager != nil {
@@ -981,6 +1093,13 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, timeoutOpt
 		)
 
 		installer := clustermanager.NewEKSAInstaller(client, f.dependencies.FileReader, f.eksaInstallerOpts()...)
+		opts := append(f.clusterManagerOpts(timeoutOpts),
+			clustermanager.WithPolicyEngine(policyengine.NewInstaller(f.dependencies.Helm, client)),
+			clustermanager.WithCertManager(certmanager.NewInstaller(f.dependencies.FileReader, client)),
+		)
+		if f.config.templateDiffWriter != nil {
+			opts = append(opts, clustermanager.WithTemplateDiffWriter(f.config.templateDiffWriter))
+		}
 
 		f.dependencies.ClusterManager = clustermanager.New(
 			f.dependencies.UnAuthKubeClient,
@@ -990,7 +1109,7 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, timeoutOpt
 			f.dependencies.DignosticCollectorFactory,
 			f.dependencies.AwsIamAuth,
 			installer,
-			f.clusterManagerOpts(timeoutOpts)...,
+			opts...,
 		)
 		return nil
 	})
@@ -1008,6 +1127,13 @@ func (f *Factory) WithNoTimeouts() *Factory {
 	return f
 }
 
+// WithTemplateDiff makes the cluster manager write to w the changes the cluster
+// templateOverrides make to the generated CAPI objects.
+func (f *Factory) WithTemplateDiff(w io.Writer) *Factory {
+	f.config.templateDiffWriter = w
+	return f
+}
+
 // WithCliConfig builds a cli config.
 func (f *Factory) WithCliConfig(cliConfig *cliconfig.CliConfig) *Factory {
 	f.dependencies.CliConfig = cliConfig
@@ -1334,6 +1460,7 @@ func (f *Factory) WithDiagnosticBundleFactory() *Factory {
 			CollectorFactory: f.dependencies.CollectorFactory,
 			Kubectl:          f.dependencies.Kubectl,
 			Writer:           f.dependencies.Writer,
+			Collection:       f.diagnosticCollection,
 		}
 
 		f.dependencies.DignosticCollectorFactory = diagnostics.NewFactory(opts)
@@ -1361,6 +1488,13 @@ func (f *Factory) WithDiagnosticCollectorImage(diagnosticCollectorImage string)
 	return f
 }
 
+// WithDiagnosticCollectionOptions configures the collection profile and limits of the
+// support bundles built by the diagnostic bundle factory.
+func (f *Factory) WithDiagnosticCollectionOptions(opts diagnostics.CollectionOptions) *Factory {
+	f.diagnosticCollection = opts
+	return f
+}
+
 func (f *Factory) WithCollectorFactory() *Factory {
 	f.WithFileReader()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -1431,7 +1565,12 @@ func (f *Factory) WithUnAuthKubeClient() *Factory {
 			return nil
 		}
 
-		f.dependencies.UnAuthKubeClient = kubernetes.NewUnAuthClient(f.dependencies.Kubectl)
+		// Reads and server side applies go straight to the API server, which avoids spawning
+		// a kubectl process per call. Everything else still goes through kubectl.
+		f.dependencies.UnAuthKubeClient = kubernetes.NewUnAuthClient(
+			f.dependencies.Kubectl,
+			kubernetes.WithRuntimeClients(nil),
+		)
 		if err := f.dependencies.UnAuthKubeClient.Init(); err != nil {
 			return fmt.Errorf("building unauth kube client: %v", err)
 		}
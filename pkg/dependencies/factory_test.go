@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -519,6 +521,46 @@ func TestFactoryBuildWithExecutablesUsingDocker(t *testing.T) {
 	tt.Expect(deps.Helm).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithGovcEnvMap(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		UseExecutablesDockerClient(dummyDockerClient{}).
+		UseExecutableImage("myimage").
+		WithGovcEnvMap(map[string]string{"GOVC_URL": "vcenter.local"}).
+		WithGovc().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Govc).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithExecutablesPool(t *testing.T) {
+	tt := newTest(t, vsphere)
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	pool := executables.NewExecutablesPool(docker)
+
+	docker.EXPECT().PullImage(tt.ctx, "myimage").Return(nil).Times(1)
+	docker.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(1)
+
+	deps := make([]*dependencies.Dependencies, 0, 2)
+	for i := 0; i < 2; i++ {
+		d, err := dependencies.NewFactory().
+			UseExecutablesDockerClient(dummyDockerClient{}).
+			UseExecutableImage("myimage").
+			WithExecutablesPool(pool).
+			WithHelm().
+			Build(tt.ctx)
+		tt.Expect(err).To(BeNil())
+		tt.Expect(d.Helm).NotTo(BeNil())
+		deps = append(deps, d)
+	}
+
+	tt.Expect(deps[0].Close(tt.ctx)).To(Succeed())
+	docker.EXPECT().Execute(tt.ctx, "rm", "-f", "-v", gomock.Any()).Return(bytes.Buffer{}, nil).Times(1)
+	tt.Expect(deps[1].Close(tt.ctx)).To(Succeed())
+}
+
 func TestFactoryBuildWithCNIInstallerCilium(t *testing.T) {
 	tt := newTest(t, vsphere)
 
@@ -633,3 +675,30 @@ func (b dummyDockerClient) Execute(ctx context.Context, args ...string) (stdout
 func (b dummyDockerClient) Login(ctx context.Context, endpoint, username, password string) error {
 	return nil
 }
+
+func TestFactoryBuildWithProviderCredentials(t *testing.T) {
+	tt := newTest(t, vsphere)
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "")
+	t.Setenv("VAULT_ADDR", "")
+	tt.clusterSpec.Cluster.Spec.ProviderCredentials = []anywherev1.ProviderCredential{
+		{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "vault://secret/data/vsphere#password"},
+	}
+
+	_, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithProviderCredentials(tt.clusterSpec.Cluster).
+		Build(context.Background())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("resolving provider credential EKSA_VSPHERE_PASSWORD")))
+}
+
+func TestFactoryBuildWithSecretResolver(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithSecretResolver().
+		WithSecretResolver(). // idempotency
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.SecretResolver).NotTo(BeNil())
+}
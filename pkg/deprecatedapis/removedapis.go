@@ -0,0 +1,95 @@
+package deprecatedapis
+
+import (
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// RemovedAPI is an API version of a kind that is no longer served starting on a Kubernetes version.
+type RemovedAPI struct {
+	APIVersion string
+	Kind       string
+	// Resource is the plural name of the resource, used to list its objects.
+	Resource  string
+	RemovedIn anywherev1.KubernetesVersion
+	// Replacement is the API version that should be used instead. Empty if the kind
+	// was removed without a replacement.
+	Replacement string
+}
+
+var removedAPIs = []RemovedAPI{
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "MutatingWebhookConfiguration", Resource: "mutatingwebhookconfigurations", RemovedIn: anywherev1.Kube122, Replacement: "admissionregistration.k8s.io/v1"},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "ValidatingWebhookConfiguration", Resource: "validatingwebhookconfigurations", RemovedIn: anywherev1.Kube122, Replacement: "admissionregistration.k8s.io/v1"},
+	{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "CustomResourceDefinition", Resource: "customresourcedefinitions", RemovedIn: anywherev1.Kube122, Replacement: "apiextensions.k8s.io/v1"},
+	{APIVersion: "apiregistration.k8s.io/v1beta1", Kind: "APIService", Resource: "apiservices", RemovedIn: anywherev1.Kube122, Replacement: "apiregistration.k8s.io/v1"},
+	{APIVersion: "certificates.k8s.io/v1beta1", Kind: "CertificateSigningRequest", Resource: "certificatesigningrequests", RemovedIn: anywherev1.Kube122, Replacement: "certificates.k8s.io/v1"},
+	{APIVersion: "coordination.k8s.io/v1beta1", Kind: "Lease", Resource: "leases", RemovedIn: anywherev1.Kube122, Replacement: "coordination.k8s.io/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "Ingress", Resource: "ingresses", RemovedIn: anywherev1.Kube122, Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "networking.k8s.io/v1beta1", Kind: "Ingress", Resource: "ingresses", RemovedIn: anywherev1.Kube122, Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "networking.k8s.io/v1beta1", Kind: "IngressClass", Resource: "ingressclasses", RemovedIn: anywherev1.Kube122, Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRole", Resource: "clusterroles", RemovedIn: anywherev1.Kube122, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRoleBinding", Resource: "clusterrolebindings", RemovedIn: anywherev1.Kube122, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "Role", Resource: "roles", RemovedIn: anywherev1.Kube122, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "RoleBinding", Resource: "rolebindings", RemovedIn: anywherev1.Kube122, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "scheduling.k8s.io/v1beta1", Kind: "PriorityClass", Resource: "priorityclasses", RemovedIn: anywherev1.Kube122, Replacement: "scheduling.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSIDriver", Resource: "csidrivers", RemovedIn: anywherev1.Kube122, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSINode", Resource: "csinodes", RemovedIn: anywherev1.Kube122, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "StorageClass", Resource: "storageclasses", RemovedIn: anywherev1.Kube122, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "VolumeAttachment", Resource: "volumeattachments", RemovedIn: anywherev1.Kube122, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "batch/v1beta1", Kind: "CronJob", Resource: "cronjobs", RemovedIn: anywherev1.Kube125, Replacement: "batch/v1"},
+	{APIVersion: "discovery.k8s.io/v1beta1", Kind: "EndpointSlice", Resource: "endpointslices", RemovedIn: anywherev1.Kube125, Replacement: "discovery.k8s.io/v1"},
+	{APIVersion: "events.k8s.io/v1beta1", Kind: "Event", Resource: "events", RemovedIn: anywherev1.Kube125, Replacement: "events.k8s.io/v1"},
+	{APIVersion: "autoscaling/v2beta1", Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", RemovedIn: anywherev1.Kube125, Replacement: "autoscaling/v2"},
+	{APIVersion: "policy/v1beta1", Kind: "PodDisruptionBudget", Resource: "poddisruptionbudgets", RemovedIn: anywherev1.Kube125, Replacement: "policy/v1"},
+	{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", Resource: "podsecuritypolicies", RemovedIn: anywherev1.Kube125},
+	{APIVersion: "node.k8s.io/v1beta1", Kind: "RuntimeClass", Resource: "runtimeclasses", RemovedIn: anywherev1.Kube125, Replacement: "node.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Kind: "FlowSchema", Resource: "flowschemas", RemovedIn: anywherev1.Kube126, Replacement: "flowcontrol.apiserver.k8s.io/v1beta2"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", RemovedIn: anywherev1.Kube126, Replacement: "flowcontrol.apiserver.k8s.io/v1beta2"},
+	{APIVersion: "autoscaling/v2beta2", Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", RemovedIn: anywherev1.Kube126, Replacement: "autoscaling/v2"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSIStorageCapacity", Resource: "csistoragecapacities", RemovedIn: anywherev1.Kube127, Replacement: "storage.k8s.io/v1"},
+}
+
+// RemovedAPIs returns the APIs served by the from Kubernetes version that are not served anymore by
+// the to Kubernetes version.
+func RemovedAPIs(from, to anywherev1.KubernetesVersion) ([]RemovedAPI, error) {
+	fromVersion, err := anywherev1.KubeVersionToSemver(from)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", from, err)
+	}
+	toVersion, err := anywherev1.KubeVersionToSemver(to)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", to, err)
+	}
+
+	apis := []RemovedAPI{}
+	for _, api := range removedAPIs {
+		removedIn, err := anywherev1.KubeVersionToSemver(api.RemovedIn)
+		if err != nil {
+			return nil, fmt.Errorf("parsing kubernetes version %s: %v", api.RemovedIn, err)
+		}
+
+		if removedIn.GreaterThan(fromVersion) && !removedIn.GreaterThan(toVersion) {
+			apis = append(apis, api)
+		}
+	}
+
+	return apis, nil
+}
+
+// resourceType returns the kubectl resource type used to list the objects of the kind of the API.
+// If the API has a replacement, the replacement version is used, since it's served by both versions.
+func (a RemovedAPI) resourceType() string {
+	apiVersion := a.APIVersion
+	if a.Replacement != "" {
+		apiVersion = a.Replacement
+	}
+
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return a.Resource
+	}
+
+	return a.Resource + "." + version + "." + group
+}
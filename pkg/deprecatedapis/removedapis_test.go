@@ -0,0 +1,70 @@
+package deprecatedapis_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+)
+
+func TestRemovedAPIs(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to anywherev1.KubernetesVersion
+		want     []string
+	}{
+		{
+			name: "same version",
+			from: anywherev1.Kube125,
+			to:   anywherev1.Kube125,
+			want: []string{},
+		},
+		{
+			name: "one minor version",
+			from: anywherev1.Kube125,
+			to:   anywherev1.Kube126,
+			want: []string{
+				"flowcontrol.apiserver.k8s.io/v1beta1 FlowSchema",
+				"flowcontrol.apiserver.k8s.io/v1beta1 PriorityLevelConfiguration",
+				"autoscaling/v2beta2 HorizontalPodAutoscaler",
+			},
+		},
+		{
+			name: "several minor versions",
+			from: anywherev1.Kube125,
+			to:   anywherev1.Kube127,
+			want: []string{
+				"flowcontrol.apiserver.k8s.io/v1beta1 FlowSchema",
+				"flowcontrol.apiserver.k8s.io/v1beta1 PriorityLevelConfiguration",
+				"autoscaling/v2beta2 HorizontalPodAutoscaler",
+				"storage.k8s.io/v1beta1 CSIStorageCapacity",
+			},
+		},
+		{
+			name: "no removals",
+			from: anywherev1.Kube127,
+			to:   anywherev1.Kube128,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			apis, err := deprecatedapis.RemovedAPIs(tt.from, tt.to)
+			g.Expect(err).NotTo(HaveOccurred())
+			got := []string{}
+			for _, api := range apis {
+				got = append(got, api.APIVersion+" "+api.Kind)
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRemovedAPIsInvalidVersion(t *testing.T) {
+	g := NewWithT(t)
+	_, err := deprecatedapis.RemovedAPIs("1.x", anywherev1.Kube128)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing kubernetes version 1.x")))
+}
@@ -0,0 +1,232 @@
+package deprecatedapis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/types"
+	yamlutil "github.com/aws/eks-anywhere/pkg/utils/yaml"
+)
+
+const (
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	helmReleaseSelector         = "owner=helm"
+	helmReleaseDeployed         = "deployed"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// Client lists objects in a cluster.
+type Client interface {
+	Get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...kubernetes.KubectlGetOption) error
+}
+
+// Finding is an object that uses a removed API.
+type Finding struct {
+	RemovedAPI
+	Namespace string
+	Name      string
+	// Source is where the usage was found: the last applied configuration of the object
+	// or the manifest of a helm release.
+	Source string
+}
+
+// String returns a human readable description of the finding.
+func (f Finding) String() string {
+	name := f.Name
+	if f.Namespace != "" {
+		name = f.Namespace + "/" + f.Name
+	}
+
+	return fmt.Sprintf("%s %s %s (%s)", f.APIVersion, f.Kind, name, f.Source)
+}
+
+// Scanner looks for objects in a cluster that use APIs removed in a Kubernetes version.
+type Scanner struct {
+	client Client
+}
+
+// NewScanner builds a Scanner.
+func NewScanner(client Client) *Scanner {
+	return &Scanner{
+		client: client,
+	}
+}
+
+// Scan returns the objects in the cluster that use APIs served by the from Kubernetes version and
+// removed in the to Kubernetes version. The API server converts stored objects to any served version,
+// so, like pluto, it checks the API version in the last applied configuration of the objects and in
+// the manifests of the deployed helm releases, since those are the ones that will be sent again.
+func (s *Scanner) Scan(ctx context.Context, cluster *types.Cluster, from, to anywherev1.KubernetesVersion) ([]Finding, error) {
+	apis, err := RemovedAPIs(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apis) == 0 {
+		return nil, nil
+	}
+
+	findings, err := s.scanObjects(ctx, cluster, apis)
+	if err != nil {
+		return nil, err
+	}
+
+	helmFindings, err := s.scanHelmReleases(ctx, cluster, apis)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(findings, helmFindings...), nil
+}
+
+func (s *Scanner) scanObjects(ctx context.Context, cluster *types.Cluster, apis []RemovedAPI) ([]Finding, error) {
+	lists := map[string]*unstructured.UnstructuredList{}
+	findings := []Finding{}
+	for _, api := range apis {
+		resourceType := api.resourceType()
+		list, ok := lists[resourceType]
+		if !ok {
+			list = &unstructured.UnstructuredList{}
+			if err := s.client.Get(ctx, resourceType, cluster.KubeconfigFile, list); err != nil {
+				return nil, fmt.Errorf("listing %s: %v", resourceType, err)
+			}
+			lists[resourceType] = list
+		}
+
+		for _, obj := range list.Items {
+			if api.Replacement != "" && !lastAppliedWith(obj, api) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				RemovedAPI: api,
+				Namespace:  obj.GetNamespace(),
+				Name:       obj.GetName(),
+				Source:     "object",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// lastAppliedWith checks if the object was last applied with kubectl using the removed API.
+func lastAppliedWith(obj unstructured.Unstructured, api RemovedAPI) bool {
+	lastApplied, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !ok {
+		return false
+	}
+
+	applied := &manifestObject{}
+	if err := json.Unmarshal([]byte(lastApplied), applied); err != nil {
+		return false
+	}
+
+	return applied.APIVersion == api.APIVersion && applied.Kind == api.Kind
+}
+
+type manifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Manifest  string `json:"manifest"`
+	Info      struct {
+		Status string `json:"status"`
+	} `json:"info"`
+}
+
+func (s *Scanner) scanHelmReleases(ctx context.Context, cluster *types.Cluster, apis []RemovedAPI) ([]Finding, error) {
+	secrets := &corev1.SecretList{}
+	if err := s.client.Get(ctx, "secrets", cluster.KubeconfigFile, secrets, &kubernetes.KubectlGetOptions{LabelSelector: helmReleaseSelector}); err != nil {
+		return nil, fmt.Errorf("listing helm releases: %v", err)
+	}
+
+	findings := []Finding{}
+	for _, secret := range secrets.Items {
+		release, err := decodeHelmRelease(secret.Data["release"])
+		if err != nil {
+			return nil, fmt.Errorf("decoding helm release %s/%s: %v", secret.Namespace, secret.Name, err)
+		}
+
+		if release.Info.Status != helmReleaseDeployed {
+			continue
+		}
+
+		docs, err := yamlutil.SplitDocuments(strings.NewReader(release.Manifest))
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest for helm release %s/%s: %v", release.Namespace, release.Name, err)
+		}
+
+		for _, doc := range docs {
+			obj := &manifestObject{}
+			if err := yaml.Unmarshal(doc, obj); err != nil {
+				return nil, fmt.Errorf("reading manifest for helm release %s/%s: %v", release.Namespace, release.Name, err)
+			}
+
+			for _, api := range apis {
+				if obj.APIVersion != api.APIVersion || obj.Kind != api.Kind {
+					continue
+				}
+
+				findings = append(findings, Finding{
+					RemovedAPI: api,
+					Namespace:  obj.Metadata.Namespace,
+					Name:       obj.Metadata.Name,
+					Source:     fmt.Sprintf("helm release %s/%s", release.Namespace, release.Name),
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// decodeHelmRelease decodes a release stored by helm in a Secret, which is a base64 encoded
+// and usually gzipped JSON document.
+func decodeHelmRelease(data []byte) (*helmRelease, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(b, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		if b, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+
+	release := &helmRelease{}
+	if err = json.Unmarshal(b, release); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
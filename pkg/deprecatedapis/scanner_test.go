@@ -0,0 +1,170 @@
+package deprecatedapis_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type scannerTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockClient
+	cluster *types.Cluster
+	scanner *deprecatedapis.Scanner
+}
+
+func newScannerTest(t *testing.T) *scannerTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	return &scannerTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  client,
+		cluster: &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		scanner: deprecatedapis.NewScanner(client),
+	}
+}
+
+func (tt *scannerTest) expectList(resourceType string, objs ...unstructured.Unstructured) {
+	tt.client.EXPECT().Get(tt.ctx, resourceType, tt.cluster.KubeconfigFile, &unstructured.UnstructuredList{}).DoAndReturn(
+		func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*unstructured.UnstructuredList).Items = objs
+			return nil
+		},
+	)
+}
+
+func (tt *scannerTest) expectHelmReleases(secrets ...corev1.Secret) {
+	tt.client.EXPECT().Get(tt.ctx, "secrets", tt.cluster.KubeconfigFile, &corev1.SecretList{}, &kubernetes.KubectlGetOptions{LabelSelector: "owner=helm"}).DoAndReturn(
+		func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*corev1.SecretList).Items = secrets
+			return nil
+		},
+	)
+}
+
+func appliedObject(apiVersion, kind, namespace, name, appliedAPIVersion string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if appliedAPIVersion != "" {
+		obj.SetAnnotations(map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"` + appliedAPIVersion + `","kind":"` + kind + `"}`,
+		})
+	}
+	return obj
+}
+
+func helmReleaseSecret(t *testing.T, name, status, manifest string) corev1.Secret {
+	release, err := json.Marshal(map[string]interface{}{
+		"name":      name,
+		"namespace": "default",
+		"manifest":  manifest,
+		"info":      map[string]string{"status": status},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err = w.Write(release); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1." + name + ".v1", Namespace: "default"},
+		Data:       map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(b.Bytes()))},
+	}
+}
+
+const helmManifest = `---
+apiVersion: autoscaling/v2beta2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: web
+  namespace: default
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+`
+
+func TestScannerScanNoRemovedAPIs(t *testing.T) {
+	tt := newScannerTest(t)
+	tt.Expect(tt.scanner.Scan(tt.ctx, tt.cluster, anywherev1.Kube127, anywherev1.Kube128)).To(BeEmpty())
+}
+
+func TestScannerScan(t *testing.T) {
+	tt := newScannerTest(t)
+	tt.expectList("flowschemas.v1beta2.flowcontrol.apiserver.k8s.io",
+		appliedObject("flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "", "custom", "flowcontrol.apiserver.k8s.io/v1beta1"),
+		appliedObject("flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "", "system", ""),
+	)
+	tt.expectList("prioritylevelconfigurations.v1beta2.flowcontrol.apiserver.k8s.io")
+	tt.expectList("horizontalpodautoscalers.v2.autoscaling",
+		appliedObject("autoscaling/v2", "HorizontalPodAutoscaler", "default", "api", "autoscaling/v2"),
+		appliedObject("autoscaling/v2", "HorizontalPodAutoscaler", "default", "worker", "autoscaling/v2beta2"),
+	)
+	tt.expectHelmReleases(
+		helmReleaseSecret(t, "web", "deployed", helmManifest),
+		helmReleaseSecret(t, "old", "superseded", helmManifest),
+	)
+
+	findings, err := tt.scanner.Scan(tt.ctx, tt.cluster, anywherev1.Kube125, anywherev1.Kube126)
+	tt.Expect(err).NotTo(HaveOccurred())
+	got := []string{}
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+	tt.Expect(got).To(Equal([]string{
+		"flowcontrol.apiserver.k8s.io/v1beta1 FlowSchema custom (object)",
+		"autoscaling/v2beta2 HorizontalPodAutoscaler default/worker (object)",
+		"autoscaling/v2beta2 HorizontalPodAutoscaler default/web (helm release default/web)",
+	}))
+}
+
+func TestScannerScanListError(t *testing.T) {
+	tt := newScannerTest(t)
+	tt.client.EXPECT().Get(tt.ctx, "csistoragecapacities.v1.storage.k8s.io", tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("connection refused"))
+
+	_, err := tt.scanner.Scan(tt.ctx, tt.cluster, anywherev1.Kube126, anywherev1.Kube127)
+	tt.Expect(err).To(MatchError("listing csistoragecapacities.v1.storage.k8s.io: connection refused"))
+}
+
+func TestScannerScanInvalidHelmRelease(t *testing.T) {
+	tt := newScannerTest(t)
+	tt.expectList("csistoragecapacities.v1.storage.k8s.io")
+	tt.expectHelmReleases(corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1.web.v1", Namespace: "default"},
+		Data:       map[string][]byte{"release": []byte("not base64")},
+	})
+
+	_, err := tt.scanner.Scan(tt.ctx, tt.cluster, anywherev1.Kube126, anywherev1.Kube127)
+	tt.Expect(err).To(MatchError(ContainSubstring("decoding helm release default/sh.helm.release.v1.web.v1")))
+}
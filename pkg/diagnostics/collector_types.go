@@ -33,9 +33,10 @@ type clusterInfo struct {
 }
 
 type logLimits struct {
-	MaxAge    string `json:"maxAge,omitempty"`
-	MaxLines  int64  `json:"maxLines,omitempty"`
-	SinceTime metav1.Time
+	MaxAge    string       `json:"maxAge,omitempty"`
+	MaxLines  int64        `json:"maxLines,omitempty"`
+	MaxBytes  int64        `json:"maxBytes,omitempty"`
+	SinceTime *metav1.Time `json:"sinceTime,omitempty"`
 }
 
 type logs struct {
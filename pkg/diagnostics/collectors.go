@@ -86,7 +86,7 @@ func (c *EKSACollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Re
 	case v1alpha1.CloudStackDatacenterKind:
 		return c.eksaCloudstackCollectors()
 	case v1alpha1.TinkerbellDatacenterKind:
-		return c.eksaTinkerbellCollectors()
+		return c.eksaTinkerbellCollectors(spec)
 	case v1alpha1.SnowDatacenterKind:
 		return c.eksaSnowCollectors()
 	case v1alpha1.NutanixDatacenterKind:
@@ -120,7 +120,7 @@ func (c *EKSACollectorFactory) eksaSnowCollectors() []*Collect {
 	return append(snowLogs, c.snowCrdCollectors()...)
 }
 
-func (c *EKSACollectorFactory) eksaTinkerbellCollectors() []*Collect {
+func (c *EKSACollectorFactory) eksaTinkerbellCollectors(spec *cluster.Spec) []*Collect {
 	tinkerbellLogs := []*Collect{
 		{
 			Logs: &logs{
@@ -129,7 +129,27 @@ func (c *EKSACollectorFactory) eksaTinkerbellCollectors() []*Collect {
 			},
 		},
 	}
-	return append(tinkerbellLogs, c.tinkerbellCrdCollectors()...)
+	tinkerbellLogs = append(tinkerbellLogs, c.tinkerbellCrdCollectors()...)
+	return append(tinkerbellLogs, c.consoleLogCollectors(spec)...)
+}
+
+// consoleLogCollectors collects the machine console logs captured by the CLI during provisioning, if any.
+func (c *EKSACollectorFactory) consoleLogCollectors(spec *cluster.Spec) []*Collect {
+	if spec == nil || spec.Cluster == nil {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(spec.Cluster.Name, constants.ConsoleLogsFolder, "*.log"))
+	if err != nil || len(paths) == 0 {
+		return nil
+	}
+
+	collectors := c.FileCollectors(paths)
+	for _, collector := range collectors {
+		collector.Data.Name = filepath.Join(constants.ConsoleLogsFolder, collector.Data.Name)
+	}
+
+	return collectors
 }
 
 func (c *EKSACollectorFactory) eksaVsphereCollectors(spec *cluster.Spec) []*Collect {
@@ -285,6 +305,18 @@ func (c *EKSACollectorFactory) defaultLogCollectors() []*Collect {
 	}
 }
 
+// AllNamespacesLogCollectors returns a collector for the logs of all the pods in the cluster.
+func (c *EKSACollectorFactory) AllNamespacesLogCollectors() []*Collect {
+	return []*Collect{
+		{
+			Logs: &logs{
+				Name:     "logs/all-namespaces",
+				Selector: []string{},
+			},
+		},
+	}
+}
+
 func (c *EKSACollectorFactory) packagesLogCollectors() []*Collect {
 	return []*Collect{
 		{
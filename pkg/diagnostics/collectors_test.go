@@ -2,6 +2,7 @@ package diagnostics_test
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -146,3 +147,30 @@ func TestNutanixCollectors(t *testing.T) {
 		g.Expect("eksa-diagnostics").To(Equal(collector.RunPod.Namespace))
 	}
 }
+
+func TestTinkerbellDataCenterConfigCollectorsWithConsoleLogs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, constants.ConsoleLogsFolder), os.ModePerm)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, constants.ConsoleLogsFolder, "bmc-worker1.log"), []byte("PXE boot"), 0o600)).To(Succeed())
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = dir
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(14), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[13].Data.Name).To(Equal(filepath.Join(constants.ConsoleLogsFolder, "bmc-worker1.log")))
+	g.Expect(collectors[13].Data.Data).To(Equal("PXE boot"))
+}
+
+func TestAllNamespacesLogCollectors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
+
+	collectors := factory.AllNamespacesLogCollectors()
+	g.Expect(collectors).To(HaveLen(1))
+	g.Expect(collectors[0].Logs.Namespace).To(BeEmpty())
+	g.Expect(collectors[0].Logs.Name).To(Equal("logs/all-namespaces"))
+}
@@ -35,6 +35,7 @@ const (
 type EksaDiagnosticBundle struct {
 	bundle           *supportBundle
 	bundlePath       string
+	archivePath      string
 	client           BundleClient
 	collectorFactory CollectorFactory
 	clusterSpec      *cluster.Spec
@@ -47,7 +48,7 @@ type EksaDiagnosticBundle struct {
 }
 
 func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactory, spec *cluster.Spec, client BundleClient,
-	kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter,
+	kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter, collection CollectionOptions,
 ) (*EksaDiagnosticBundle, error) {
 	b := &EksaDiagnosticBundle{
 		bundle: &supportBundle{
@@ -76,6 +77,8 @@ func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactor
 		WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef, spec).
 		WithLogTextAnalyzers()
 
+	collection.applyLimits(b.bundle.Spec.Collectors)
+
 	err := b.WriteBundleConfig()
 	if err != nil {
 		return nil, fmt.Errorf("writing bundle config: %v", err)
@@ -85,7 +88,7 @@ func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactor
 }
 
 func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *cluster.Spec, provider providers.Provider,
-	client BundleClient, kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter,
+	client BundleClient, kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter, collection CollectionOptions,
 ) (*EksaDiagnosticBundle, error) {
 	b := &EksaDiagnosticBundle{
 		bundle: &supportBundle{
@@ -108,18 +111,33 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		writer:           writer,
 	}
 
+	profile := collection.profile()
+
 	b = b.
 		WithGitOpsConfig(spec.GitOpsConfig).
 		WithOidcConfig(spec.OIDCConfig).
 		WithExternalEtcd(spec.Cluster.Spec.ExternalEtcdConfiguration).
-		WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef, spec).
-		WithMachineConfigs(provider.MachineConfigs(spec)).
+		WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef, spec)
+
+	if profile != MinimalProfile {
+		b = b.WithMachineConfigs(provider.MachineConfigs(spec))
+	}
+
+	b = b.
 		WithManagementCluster(spec.Cluster.IsSelfManaged()).
 		WithDefaultAnalyzers().
 		WithDefaultCollectors().
-		WithFileCollectors([]string{logger.GetOutputFilePath()}).
-		WithPackagesCollectors().
-		WithLogTextAnalyzers()
+		WithFileCollectors([]string{logger.GetOutputFilePath()})
+
+	if profile != MinimalProfile {
+		b = b.WithPackagesCollectors()
+	}
+	if profile == FullProfile {
+		b = b.WithAllNamespacesLogs()
+	}
+
+	b = b.WithLogTextAnalyzers()
+	collection.applyLimits(b.bundle.Spec.Collectors)
 
 	err := b.WriteBundleConfig()
 	if err != nil {
@@ -172,6 +190,7 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 	}
 
 	logger.Info("Support bundle archive created", "path", archivePath)
+	e.archivePath = archivePath
 
 	logger.Info("Analyzing support bundle", "bundle", e.bundlePath, "archive", archivePath)
 	analysis, err := e.client.Analyze(ctx, e.bundlePath, archivePath)
@@ -190,6 +209,12 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 	return nil
 }
 
+// ArchivePath returns the path of the archive created by CollectAndAnalyze, empty if the bundle
+// hasn't been collected yet.
+func (e *EksaDiagnosticBundle) ArchivePath() string {
+	return e.archivePath
+}
+
 func (e *EksaDiagnosticBundle) PrintBundleConfig() error {
 	bundleYaml, err := yaml.Marshal(e.bundle)
 	if err != nil {
@@ -276,6 +301,12 @@ func (e *EksaDiagnosticBundle) WithPackagesCollectors() *EksaDiagnosticBundle {
 	return e
 }
 
+// WithAllNamespacesLogs appends a collector for the logs of all the pods in the cluster.
+func (e *EksaDiagnosticBundle) WithAllNamespacesLogs() *EksaDiagnosticBundle {
+	e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.AllNamespacesLogCollectors()...)
+	return e
+}
+
 func (e *EksaDiagnosticBundle) WithDatacenterConfig(config v1alpha1.Ref, spec *cluster.Spec) *EksaDiagnosticBundle {
 	e.bundle.Spec.Analyzers = append(e.bundle.Spec.Analyzers, e.analyzerFactory.DataCenterConfigAnalyzers(config)...)
 	e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.DataCenterConfigCollectors(config, spec)...)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -17,6 +18,7 @@ import (
 	supportMocks "github.com/aws/eks-anywhere/pkg/diagnostics/interfaces/mocks"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providerMocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -395,6 +397,93 @@ func TestBundleFromSpecComplete(t *testing.T) {
 	})
 }
 
+func TestBundleFromSpecCollectionOptions(t *testing.T) {
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = &eksav1alpha1.Cluster{
+			Spec: eksav1alpha1.ClusterSpec{
+				DatacenterRef: eksav1alpha1.Ref{
+					Kind: eksav1alpha1.VSphereDatacenterKind,
+					Name: "testRef",
+				},
+			},
+		}
+	})
+	collectors := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
+
+	t.Run("minimal profile with limits", func(t *testing.T) {
+		g := NewWithT(t)
+		p := givenProvider(t)
+
+		a := givenMockAnalyzerFactory(t)
+		a.EXPECT().DataCenterConfigAnalyzers(spec.Cluster.Spec.DatacenterRef).Return(nil)
+		a.EXPECT().DefaultAnalyzers().Return(nil)
+		a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+		a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+
+		c := givenMockCollectorsFactory(t)
+		c.EXPECT().DefaultCollectors().Return(collectors.DefaultCollectors())
+		c.EXPECT().ManagementClusterCollectors().Return(nil)
+		c.EXPECT().DataCenterConfigCollectors(spec.Cluster.Spec.DatacenterRef, spec).Return(nil)
+		c.EXPECT().FileCollectors(gomock.Any()).Return(nil)
+
+		var bundleConfig string
+		w := givenWriter(t)
+		w.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			bundleConfig = string(content)
+			return "bundle.yaml", nil
+		})
+
+		f := diagnostics.NewFactory(diagnostics.EksaDiagnosticBundleFactoryOpts{
+			AnalyzerFactory:  a,
+			CollectorFactory: c,
+			Writer:           w,
+			Collection: diagnostics.CollectionOptions{
+				Profile:     diagnostics.MinimalProfile,
+				MaxLogLines: 1000,
+				MaxLogBytes: 5000000,
+			},
+		})
+		_, err := f.DiagnosticBundleWorkloadCluster(spec, p, "")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(bundleConfig).To(ContainSubstring("maxLines: 1000"))
+		g.Expect(bundleConfig).To(ContainSubstring("maxBytes: 5000000"))
+	})
+
+	t.Run("full profile", func(t *testing.T) {
+		g := NewWithT(t)
+		p := givenProvider(t)
+		p.EXPECT().MachineConfigs(spec).Return(machineConfigs())
+
+		a := givenMockAnalyzerFactory(t)
+		a.EXPECT().DataCenterConfigAnalyzers(spec.Cluster.Spec.DatacenterRef).Return(nil)
+		a.EXPECT().DefaultAnalyzers().Return(nil)
+		a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+		a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+		a.EXPECT().PackageAnalyzers().Return(nil)
+
+		c := givenMockCollectorsFactory(t)
+		c.EXPECT().DefaultCollectors().Return(nil)
+		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
+		c.EXPECT().ManagementClusterCollectors().Return(nil)
+		c.EXPECT().DataCenterConfigCollectors(spec.Cluster.Spec.DatacenterRef, spec).Return(nil)
+		c.EXPECT().PackagesCollectors().Return(nil)
+		c.EXPECT().FileCollectors(gomock.Any()).Return(nil)
+		c.EXPECT().AllNamespacesLogCollectors().Return(collectors.AllNamespacesLogCollectors())
+
+		w := givenWriter(t)
+		w.EXPECT().Write(gomock.Any(), gomock.Any())
+
+		f := diagnostics.NewFactory(diagnostics.EksaDiagnosticBundleFactoryOpts{
+			AnalyzerFactory:  a,
+			CollectorFactory: c,
+			Writer:           w,
+			Collection:       diagnostics.CollectionOptions{Profile: diagnostics.FullProfile},
+		})
+		_, err := f.DiagnosticBundleWorkloadCluster(spec, p, "")
+		g.Expect(err).NotTo(HaveOccurred())
+	})
+}
+
 func TestGenerateCustomBundle(t *testing.T) {
 	t.Run(t.Name(), func(t *testing.T) {
 		f := diagnostics.NewFactory(getOpts(t))
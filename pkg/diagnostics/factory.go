@@ -15,6 +15,8 @@ type EksaDiagnosticBundleFactoryOpts struct {
 	CollectorFactory CollectorFactory
 	Kubectl          *executables.Kubectl
 	Writer           filewriter.FileWriter
+	// Collection customizes the collectors of the bundles generated from a cluster spec.
+	Collection CollectionOptions
 }
 
 type eksaDiagnosticBundleFactory struct {
@@ -23,6 +25,7 @@ type eksaDiagnosticBundleFactory struct {
 	collectorFactory CollectorFactory
 	kubectl          *executables.Kubectl
 	writer           filewriter.FileWriter
+	collection       CollectionOptions
 }
 
 func NewFactory(opts EksaDiagnosticBundleFactoryOpts) *eksaDiagnosticBundleFactory {
@@ -32,6 +35,7 @@ func NewFactory(opts EksaDiagnosticBundleFactoryOpts) *eksaDiagnosticBundleFacto
 		collectorFactory: opts.CollectorFactory,
 		kubectl:          opts.Kubectl,
 		writer:           opts.Writer,
+		collection:       opts.Collection,
 	}
 }
 
@@ -44,11 +48,11 @@ func (f *eksaDiagnosticBundleFactory) DiagnosticBundle(spec *cluster.Spec, provi
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleManagementCluster(spec *cluster.Spec, kubeconfig string) (DiagnosticBundle, error) {
-	return newDiagnosticBundleManagementCluster(f.analyzerFactory, f.collectorFactory, spec, f.client, f.kubectl, kubeconfig, f.writer)
+	return newDiagnosticBundleManagementCluster(f.analyzerFactory, f.collectorFactory, spec, f.client, f.kubectl, kubeconfig, f.writer, f.collection)
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleWorkloadCluster(spec *cluster.Spec, provider providers.Provider, kubeconfig string) (DiagnosticBundle, error) {
-	return newDiagnosticBundleFromSpec(f.analyzerFactory, f.collectorFactory, spec, provider, f.client, f.kubectl, kubeconfig, f.writer)
+	return newDiagnosticBundleFromSpec(f.analyzerFactory, f.collectorFactory, spec, provider, f.client, f.kubectl, kubeconfig, f.writer, f.collection)
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleDefault() DiagnosticBundle {
@@ -29,6 +29,7 @@ type DiagnosticBundle interface {
 	PrintAnalysis() error
 	WriteAnalysisToFile() (path string, err error)
 	CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error
+	ArchivePath() string
 	WithDefaultAnalyzers() *EksaDiagnosticBundle
 	WithDefaultCollectors() *EksaDiagnosticBundle
 	WithFileCollectors(paths []string) *EksaDiagnosticBundle
@@ -59,4 +60,5 @@ type CollectorFactory interface {
 	ManagementClusterCollectors() []*Collect
 	EksaHostCollectors(configs []providers.MachineConfig) []*Collect
 	DataCenterConfigCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*Collect
+	AllNamespacesLogCollectors() []*Collect
 }
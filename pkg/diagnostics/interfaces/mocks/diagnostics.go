@@ -189,6 +189,20 @@ func (m *MockDiagnosticBundle) EXPECT() *MockDiagnosticBundleMockRecorder {
 	return m.recorder
 }
 
+// ArchivePath mocks base method.
+func (m *MockDiagnosticBundle) ArchivePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ArchivePath indicates an expected call of ArchivePath.
+func (mr *MockDiagnosticBundleMockRecorder) ArchivePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePath", reflect.TypeOf((*MockDiagnosticBundle)(nil).ArchivePath))
+}
+
 // CollectAndAnalyze mocks base method.
 func (m *MockDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error {
 	m.ctrl.T.Helper()
@@ -544,6 +558,20 @@ func (m *MockCollectorFactory) EXPECT() *MockCollectorFactoryMockRecorder {
 	return m.recorder
 }
 
+// AllNamespacesLogCollectors mocks base method.
+func (m *MockCollectorFactory) AllNamespacesLogCollectors() []*diagnostics.Collect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllNamespacesLogCollectors")
+	ret0, _ := ret[0].([]*diagnostics.Collect)
+	return ret0
+}
+
+// AllNamespacesLogCollectors indicates an expected call of AllNamespacesLogCollectors.
+func (mr *MockCollectorFactoryMockRecorder) AllNamespacesLogCollectors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllNamespacesLogCollectors", reflect.TypeOf((*MockCollectorFactory)(nil).AllNamespacesLogCollectors))
+}
+
 // DataCenterConfigCollectors mocks base method.
 func (m *MockCollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*diagnostics.Collect {
 	m.ctrl.T.Helper()
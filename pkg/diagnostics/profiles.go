@@ -0,0 +1,88 @@
+package diagnostics
+
+import (
+	"fmt"
+	"time"
+)
+
+// CollectionProfile controls how much information is collected in a support bundle.
+type CollectionProfile string
+
+const (
+	// MinimalProfile only collects the cluster resources and the logs of the EKS-A, CAPI and
+	// system components. It skips the host collectors, which run a pod on every node and
+	// account for most of the collection time on large clusters.
+	MinimalProfile CollectionProfile = "minimal"
+	// StandardProfile collects everything needed to troubleshoot most EKS-A issues.
+	StandardProfile CollectionProfile = "standard"
+	// FullProfile collects the same as StandardProfile plus the logs of all the pods in the
+	// cluster, including user workloads.
+	FullProfile CollectionProfile = "full"
+)
+
+// CollectionProfiles are the supported collection profiles.
+var CollectionProfiles = []CollectionProfile{MinimalProfile, StandardProfile, FullProfile}
+
+// ParseCollectionProfile returns the profile with the given name. It defaults to
+// StandardProfile if name is empty.
+func ParseCollectionProfile(name string) (CollectionProfile, error) {
+	if name == "" {
+		return StandardProfile, nil
+	}
+	for _, p := range CollectionProfiles {
+		if string(p) == name {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid collection profile %s, supported profiles are %v", name, CollectionProfiles)
+}
+
+// CollectionOptions customizes the collectors added to the generated support bundles.
+type CollectionOptions struct {
+	Profile CollectionProfile
+	// MaxLogLines limits the number of lines collected per container log. No limit if zero.
+	MaxLogLines int64
+	// MaxLogBytes limits the size of each collected container log. No limit if zero.
+	MaxLogBytes int64
+	// CollectorTimeout limits how long the collectors that run commands or pods in the
+	// cluster can take. Troubleshoot defaults are used if zero.
+	CollectorTimeout time.Duration
+}
+
+func (o CollectionOptions) profile() CollectionProfile {
+	if o.Profile == "" {
+		return StandardProfile
+	}
+	return o.Profile
+}
+
+func (o CollectionOptions) applyLimits(collectors []*Collect) {
+	for _, c := range collectors {
+		if c.Logs != nil && (o.MaxLogLines > 0 || o.MaxLogBytes > 0) {
+			if c.Logs.Limits == nil {
+				c.Logs.Limits = &logLimits{}
+			}
+			if o.MaxLogLines > 0 {
+				c.Logs.Limits.MaxLines = o.MaxLogLines
+			}
+			if o.MaxLogBytes > 0 {
+				c.Logs.Limits.MaxBytes = o.MaxLogBytes
+			}
+		}
+
+		if o.CollectorTimeout <= 0 {
+			continue
+		}
+		timeout := o.CollectorTimeout.String()
+		if c.Exec != nil {
+			c.Exec.Timeout = timeout
+		}
+		if c.CopyFromHost != nil {
+			c.CopyFromHost.Timeout = timeout
+		}
+		if c.RunPod != nil {
+			c.RunPod.Timeout = timeout
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package diagnostics_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+)
+
+func TestParseCollectionProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    diagnostics.CollectionProfile
+		wantErr string
+	}{
+		{name: "", want: diagnostics.StandardProfile},
+		{name: "minimal", want: diagnostics.MinimalProfile},
+		{name: "standard", want: diagnostics.StandardProfile},
+		{name: "full", want: diagnostics.FullProfile},
+		{name: "huge", wantErr: "invalid collection profile huge, supported profiles are [minimal standard full]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := diagnostics.ParseCollectionProfile(tt.name)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// ArchiveUploader uploads a support bundle archive once it has been collected.
+type ArchiveUploader interface {
+	Upload(ctx context.Context, archivePath string, metadata map[string]string) error
+}
+
+// ArchiveChecksums holds the checksums of a support bundle archive.
+type ArchiveChecksums struct {
+	// SHA256 is the hex encoded sha256 of the archive.
+	SHA256 string
+}
+
+// ComputeArchiveChecksums reads the archive and computes its checksums.
+func ComputeArchiveChecksums(archivePath string) (*ArchiveChecksums, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening support bundle archive: %v", err)
+	}
+	defer f.Close()
+
+	sha := sha256.New()
+	if _, err := io.Copy(sha, f); err != nil {
+		return nil, fmt.Errorf("reading support bundle archive: %v", err)
+	}
+
+	return &ArchiveChecksums{
+		SHA256: hex.EncodeToString(sha.Sum(nil)),
+	}, nil
+}
+
+// S3Uploader uploads support bundle archives to an S3 bucket.
+type S3Uploader struct {
+	uploader s3manageriface.UploaderAPI
+	bucket   string
+	prefix   string
+}
+
+// NewS3Uploader builds an S3Uploader from an s3:// URI. The archives are uploaded under the URI
+// path, keeping their file name.
+func NewS3Uploader(uploader s3manageriface.UploaderAPI, uri string) (*S3Uploader, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing s3 uri %s: %v", uri, err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 uri %s, it should follow the format s3://bucket/prefix", uri)
+	}
+
+	return &S3Uploader{
+		uploader: uploader,
+		bucket:   u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Upload uploads the archive to the bucket. The archive sha256 is added to the object metadata
+// so the bundle can be verified once downloaded.
+func (s *S3Uploader) Upload(ctx context.Context, archivePath string, metadata map[string]string) error {
+	checksums, err := ComputeArchiveChecksums(archivePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("opening support bundle archive: %v", err)
+	}
+	defer f.Close()
+
+	key := path.Join(s.prefix, filepath.Base(archivePath))
+	objectMetadata := map[string]*string{"sha256": aws.String(checksums.SHA256)}
+	for k, v := range metadata {
+		objectMetadata[k] = aws.String(v)
+	}
+
+	logger.Info("Uploading support bundle archive", "bucket", s.bucket, "key", key, "sha256", checksums.SHA256)
+	_, err = s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		Body:     f,
+		Metadata: objectMetadata,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading support bundle archive to s3://%s/%s: %v", s.bucket, key, err)
+	}
+
+	return nil
+}
+
+// PresignedURLUploader uploads support bundle archives to a pre-signed URL, like the ones
+// provided by AWS Support.
+type PresignedURLUploader struct {
+	client *http.Client
+	url    string
+}
+
+// NewPresignedURLUploader builds a PresignedURLUploader.
+func NewPresignedURLUploader(client *http.Client, url string) *PresignedURLUploader {
+	return &PresignedURLUploader{
+		client: client,
+		url:    url,
+	}
+}
+
+// Upload uploads the archive with a PUT request. No checksum header is sent and metadata is only
+// logged since pre-signed URLs reject the headers that weren't signed with them.
+func (p *PresignedURLUploader) Upload(ctx context.Context, archivePath string, metadata map[string]string) error {
+	checksums, err := ComputeArchiveChecksums(archivePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("opening support bundle archive: %v", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading support bundle archive: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.url, f)
+	if err != nil {
+		return fmt.Errorf("building support bundle upload request: %v", err)
+	}
+	req.ContentLength = info.Size()
+
+	logger.Info("Uploading support bundle archive to pre-signed url", "sha256", checksums.SHA256, "metadata", metadata)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("uploading support bundle archive: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("uploading support bundle archive: unexpected status %s: %s", resp.Status, string(body))
+	}
+
+	return nil
+}
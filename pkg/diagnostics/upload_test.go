@@ -0,0 +1,124 @@
+package diagnostics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+)
+
+const (
+	archiveContent = "support bundle"
+	archiveSHA256  = "5a22eada1ae887d64bc50f3fb7c4f9a38c58ebdcf73109333bc4ac175d445949"
+)
+
+func writeArchive(t *testing.T) string {
+	archive := filepath.Join(t.TempDir(), "support-bundle.tar.gz")
+	if err := os.WriteFile(archive, []byte(archiveContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+type fakeS3Uploader struct {
+	s3manageriface.UploaderAPI
+	input *s3manager.UploadInput
+	body  string
+	err   error
+}
+
+func (f *fakeS3Uploader) UploadWithContext(_ aws.Context, input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	f.input = input
+	body, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.body = string(body)
+	return &s3manager.UploadOutput{}, f.err
+}
+
+func TestComputeArchiveChecksums(t *testing.T) {
+	g := NewWithT(t)
+
+	checksums, err := diagnostics.ComputeArchiveChecksums(writeArchive(t))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(checksums.SHA256).To(Equal(archiveSHA256))
+}
+
+func TestS3UploaderUpload(t *testing.T) {
+	g := NewWithT(t)
+	fake := &fakeS3Uploader{}
+
+	u, err := diagnostics.NewS3Uploader(fake, "s3://my-bucket/bundles/")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(u.Upload(context.Background(), writeArchive(t), map[string]string{"cluster": "my-cluster"})).To(Succeed())
+
+	g.Expect(aws.StringValue(fake.input.Bucket)).To(Equal("my-bucket"))
+	g.Expect(aws.StringValue(fake.input.Key)).To(Equal("bundles/support-bundle.tar.gz"))
+	g.Expect(aws.StringValueMap(fake.input.Metadata)).To(Equal(map[string]string{
+		"sha256":  archiveSHA256,
+		"cluster": "my-cluster",
+	}))
+	g.Expect(fake.body).To(Equal(archiveContent))
+}
+
+func TestS3UploaderUploadError(t *testing.T) {
+	g := NewWithT(t)
+	fake := &fakeS3Uploader{err: errors.New("access denied")}
+
+	u, err := diagnostics.NewS3Uploader(fake, "s3://my-bucket")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(u.Upload(context.Background(), writeArchive(t), nil)).To(
+		MatchError("uploading support bundle archive to s3://my-bucket/support-bundle.tar.gz: access denied"),
+	)
+}
+
+func TestNewS3UploaderInvalidURI(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := diagnostics.NewS3Uploader(&fakeS3Uploader{}, "https://my-bucket/bundles")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid s3 uri")))
+}
+
+func TestPresignedURLUploaderUpload(t *testing.T) {
+	g := NewWithT(t)
+	var method, md5, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		md5 = r.Header.Get("Content-MD5")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	u := diagnostics.NewPresignedURLUploader(server.Client(), server.URL+"/upload?X-Amz-Signature=abc")
+	g.Expect(u.Upload(context.Background(), writeArchive(t), nil)).To(Succeed())
+	g.Expect(method).To(Equal(http.MethodPut))
+	g.Expect(md5).To(BeEmpty())
+	g.Expect(body).To(Equal(archiveContent))
+}
+
+func TestPresignedURLUploaderUploadErrorStatus(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("expired"))
+	}))
+	defer server.Close()
+
+	u := diagnostics.NewPresignedURLUploader(server.Client(), server.URL)
+	g.Expect(u.Upload(context.Background(), writeArchive(t), nil)).To(
+		MatchError("uploading support bundle archive: unexpected status 403 Forbidden: expired"),
+	)
+}
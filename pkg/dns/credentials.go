@@ -0,0 +1,116 @@
+package dns
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// Env variables the CLI reads the DNS provider credentials from. The AWS credentials for Route53
+// are read with the default AWS credentials chain.
+const (
+	TSIGSecretEnv       = "EKSA_DNS_TSIG_SECRET"
+	InfobloxUsernameEnv = "EKSA_INFOBLOX_USERNAME"
+	InfobloxPasswordEnv = "EKSA_INFOBLOX_PASSWORD"
+
+	awsAccessKeyIDEnv     = "AWS_ACCESS_KEY_ID"
+	awsSecretAccessKeyEnv = "AWS_SECRET_ACCESS_KEY"
+	awsSessionTokenEnv    = "AWS_SESSION_TOKEN"
+)
+
+const (
+	tsigSecretKey         = "tsigSecret"
+	infobloxUsernameKey   = "infobloxUsername"
+	infobloxPasswordKey   = "infobloxPassword"
+	awsAccessKeyIDKey     = "awsAccessKeyID"
+	awsSecretAccessKeyKey = "awsSecretAccessKey"
+	awsSessionTokenKey    = "awsSessionToken"
+)
+
+// Credentials authenticate with the DNS providers. Only the ones of the provider a cluster uses
+// need to be set.
+type Credentials struct {
+	// TSIGSecret is the base64 encoded secret of the TSIG key of RFC2136 servers.
+	TSIGSecret       string
+	InfobloxUsername string
+	InfobloxPassword string
+	// The AWS credentials are optional, the default AWS credentials chain is used when they are empty.
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+}
+
+// CredentialsFromEnv reads the Credentials from the env variables.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		TSIGSecret:         os.Getenv(TSIGSecretEnv),
+		InfobloxUsername:   os.Getenv(InfobloxUsernameEnv),
+		InfobloxPassword:   os.Getenv(InfobloxPasswordEnv),
+		AWSAccessKeyID:     os.Getenv(awsAccessKeyIDEnv),
+		AWSSecretAccessKey: os.Getenv(awsSecretAccessKeyEnv),
+		AWSSessionToken:    os.Getenv(awsSessionTokenEnv),
+	}
+}
+
+// ValidateEnv validates the env variables have the credentials required by the DNS provider in config.
+func (c Credentials) ValidateEnv(config *v1alpha1.EndpointDNS) error {
+	switch {
+	case config.RFC2136 != nil && config.RFC2136.TSIGKeyName != "" && c.TSIGSecret == "":
+		return fmt.Errorf("rfc2136 tsig key %s requires the %s env variable", config.RFC2136.TSIGKeyName, TSIGSecretEnv)
+	case config.Infoblox != nil && (c.InfobloxUsername == "" || c.InfobloxPassword == ""):
+		return fmt.Errorf("infoblox requires the %s and %s env variables", InfobloxUsernameEnv, InfobloxPasswordEnv)
+	}
+	return nil
+}
+
+// CredentialsSecretName returns the name of the Secret with the DNS credentials of a cluster.
+func CredentialsSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-dns-credentials", clusterName)
+}
+
+// Secret returns the Secret the controller reads the Credentials of the cluster from.
+func (c Credentials) Secret(clusterName string) *corev1.Secret {
+	data := map[string][]byte{}
+	for key, value := range map[string]string{
+		tsigSecretKey:         c.TSIGSecret,
+		infobloxUsernameKey:   c.InfobloxUsername,
+		infobloxPasswordKey:   c.InfobloxPassword,
+		awsAccessKeyIDKey:     c.AWSAccessKeyID,
+		awsSecretAccessKeyKey: c.AWSSecretAccessKey,
+		awsSessionTokenKey:    c.AWSSessionToken,
+	} {
+		if value != "" {
+			data[key] = []byte(value)
+		}
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialsSecretName(clusterName),
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// CredentialsFromSecret reads the Credentials from a Secret built with [Credentials.Secret].
+func CredentialsFromSecret(secret *corev1.Secret) Credentials {
+	return Credentials{
+		TSIGSecret:         string(secret.Data[tsigSecretKey]),
+		InfobloxUsername:   string(secret.Data[infobloxUsernameKey]),
+		InfobloxPassword:   string(secret.Data[infobloxPasswordKey]),
+		AWSAccessKeyID:     string(secret.Data[awsAccessKeyIDKey]),
+		AWSSecretAccessKey: string(secret.Data[awsSecretAccessKeyKey]),
+		AWSSessionToken:    string(secret.Data[awsSessionTokenKey]),
+	}
+}
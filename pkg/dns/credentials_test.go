@@ -0,0 +1,90 @@
+package dns_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+func TestCredentialsFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(dns.TSIGSecretEnv, "c2VjcmV0")
+	t.Setenv(dns.InfobloxUsernameEnv, "admin")
+	t.Setenv(dns.InfobloxPasswordEnv, "password")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIA")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	g.Expect(dns.CredentialsFromEnv()).To(Equal(dns.Credentials{
+		TSIGSecret:         "c2VjcmV0",
+		InfobloxUsername:   "admin",
+		InfobloxPassword:   "password",
+		AWSAccessKeyID:     "AKIA",
+		AWSSecretAccessKey: "secret",
+	}))
+}
+
+func TestCredentialsValidateEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *v1alpha1.EndpointDNS
+		credentials dns.Credentials
+		wantErr     string
+	}{
+		{
+			name:   "route53",
+			config: &v1alpha1.EndpointDNS{Route53: &v1alpha1.Route53DNS{HostedZoneID: "Z0123456789"}},
+		},
+		{
+			name:   "rfc2136 without tsig",
+			config: &v1alpha1.EndpointDNS{RFC2136: &v1alpha1.RFC2136DNS{Server: "10.0.0.2", Zone: "example.com"}},
+		},
+		{
+			name:    "rfc2136 tsig without secret",
+			config:  &v1alpha1.EndpointDNS{RFC2136: &v1alpha1.RFC2136DNS{Server: "10.0.0.2", Zone: "example.com", TSIGKeyName: "eksa"}},
+			wantErr: "rfc2136 tsig key eksa requires the EKSA_DNS_TSIG_SECRET env variable",
+		},
+		{
+			name:        "infoblox",
+			config:      &v1alpha1.EndpointDNS{Infoblox: &v1alpha1.InfobloxDNS{Server: "infoblox.example.com"}},
+			credentials: dns.Credentials{InfobloxUsername: "admin", InfobloxPassword: "password"},
+		},
+		{
+			name:        "infoblox without password",
+			config:      &v1alpha1.EndpointDNS{Infoblox: &v1alpha1.InfobloxDNS{Server: "infoblox.example.com"}},
+			credentials: dns.Credentials{InfobloxUsername: "admin"},
+			wantErr:     "infoblox requires the EKSA_INFOBLOX_USERNAME and EKSA_INFOBLOX_PASSWORD env variables",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.credentials.ValidateEnv(tt.config)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestCredentialsSecret(t *testing.T) {
+	g := NewWithT(t)
+	credentials := dns.Credentials{
+		InfobloxUsername: "admin",
+		InfobloxPassword: "password",
+	}
+
+	secret := credentials.Secret("prod")
+	g.Expect(secret.Name).To(Equal("prod-dns-credentials"))
+	g.Expect(secret.Namespace).To(Equal("eksa-system"))
+	g.Expect(secret.Data).To(Equal(map[string][]byte{
+		"infobloxUsername": []byte("admin"),
+		"infobloxPassword": []byte("password"),
+	}))
+	g.Expect(dns.CredentialsFromSecret(secret)).To(Equal(credentials))
+}
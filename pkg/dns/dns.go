@@ -0,0 +1,119 @@
+// Package dns registers the DNS record of the control plane endpoint of clusters in external
+// DNS providers, so the API server can be reached through a stable hostname.
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// DefaultTTL is the TTL of the records in seconds when the cluster doesn't set one.
+const DefaultTTL = 300
+
+// Record is an address record pointing a name to the control plane endpoint.
+type Record struct {
+	// Name is the fully qualified name of the record, without the trailing dot.
+	Name string `json:"name"`
+	IP   net.IP `json:"ip"`
+	TTL  int    `json:"ttl"`
+}
+
+// Type returns the type of the record, A for IPv4 addresses and AAAA for IPv6 ones.
+func (r Record) Type() string {
+	if r.IP.To4() != nil {
+		return "A"
+	}
+	return "AAAA"
+}
+
+// ControlPlaneRecord returns the record of the control plane endpoint of the cluster, or nil
+// if the cluster doesn't configure a DNS record for it.
+func ControlPlaneRecord(cluster *v1alpha1.Cluster) (*Record, error) {
+	endpoint := cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.DNS == nil {
+		return nil, nil
+	}
+
+	ip := net.ParseIP(endpoint.Host)
+	if ip == nil {
+		return nil, fmt.Errorf("control plane endpoint host %s is not an IP", endpoint.Host)
+	}
+
+	ttl := endpoint.DNS.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Record{
+		Name: strings.TrimSuffix(endpoint.DNS.Name, "."),
+		IP:   ip,
+		TTL:  ttl,
+	}, nil
+}
+
+// Provider manages the records of a DNS provider.
+type Provider interface {
+	// Upsert creates the record or updates it if it already exists.
+	Upsert(ctx context.Context, record Record) error
+	// Delete removes the record. Deleting a record that doesn't exist is not an error.
+	Delete(ctx context.Context, record Record) error
+}
+
+// ProviderFactory builds the Provider for a DNS configuration.
+type ProviderFactory func(config *v1alpha1.EndpointDNS, credentials Credentials) (Provider, error)
+
+// NewProvider builds the Provider for the DNS provider set in config.
+func NewProvider(config *v1alpha1.EndpointDNS, credentials Credentials) (Provider, error) {
+	switch {
+	case config.Route53 != nil:
+		client, err := newRoute53Client(credentials)
+		if err != nil {
+			return nil, err
+		}
+		return NewRoute53(client, config.Route53.HostedZoneID), nil
+	case config.RFC2136 != nil:
+		if config.RFC2136.TSIGKeyName != "" && credentials.TSIGSecret == "" {
+			return nil, fmt.Errorf("rfc2136 tsig key %s requires a tsig secret", config.RFC2136.TSIGKeyName)
+		}
+		return NewRFC2136(config.RFC2136, credentials.TSIGSecret), nil
+	case config.Infoblox != nil:
+		if credentials.InfobloxUsername == "" || credentials.InfobloxPassword == "" {
+			return nil, errors.New("infoblox requires a username and password")
+		}
+		return NewInfoblox(config.Infoblox, credentials.InfobloxUsername, credentials.InfobloxPassword), nil
+	default:
+		return nil, fmt.Errorf("no dns provider configured for %s", config.Name)
+	}
+}
+
+// Kubeconfig returns a copy of kubeconfig with the server of its clusters pointing to name instead
+// of the IP of the control plane endpoint.
+func Kubeconfig(kubeconfig []byte, name string) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %v", err)
+	}
+
+	for _, cluster := range config.Clusters {
+		server, err := url.Parse(cluster.Server)
+		if err != nil {
+			return nil, fmt.Errorf("parsing kubeconfig server %s: %v", cluster.Server, err)
+		}
+		if port := server.Port(); port != "" {
+			server.Host = net.JoinHostPort(name, port)
+		} else {
+			server.Host = name
+		}
+		cluster.Server = server.String()
+	}
+
+	return clientcmd.Write(*config)
+}
@@ -0,0 +1,163 @@
+package dns_test
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+func clusterWithDNS(host string, config *v1alpha1.EndpointDNS) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{Host: host, DNS: config},
+			},
+		},
+	}
+}
+
+func TestControlPlaneRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.Cluster
+		want    *dns.Record
+		wantErr string
+	}{
+		{
+			name:    "no dns",
+			cluster: clusterWithDNS("10.0.0.10", nil),
+		},
+		{
+			name:    "default ttl",
+			cluster: clusterWithDNS("10.0.0.10", &v1alpha1.EndpointDNS{Name: "api.prod.example.com."}),
+			want:    &dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL},
+		},
+		{
+			name:    "ipv6",
+			cluster: clusterWithDNS("fd00::10", &v1alpha1.EndpointDNS{Name: "api.prod.example.com", TTL: 60}),
+			want:    &dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("fd00::10"), TTL: 60},
+		},
+		{
+			name:    "host is not an ip",
+			cluster: clusterWithDNS("api.example.com", &v1alpha1.EndpointDNS{Name: "api.prod.example.com"}),
+			wantErr: "control plane endpoint host api.example.com is not an IP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := dns.ControlPlaneRecord(tt.cluster)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRecordType(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(dns.Record{IP: net.ParseIP("10.0.0.10")}.Type()).To(Equal("A"))
+	g.Expect(dns.Record{IP: net.ParseIP("fd00::10")}.Type()).To(Equal("AAAA"))
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *v1alpha1.EndpointDNS
+		credentials dns.Credentials
+		want        interface{}
+		wantErr     string
+	}{
+		{
+			name:   "route53",
+			config: &v1alpha1.EndpointDNS{Name: "api.example.com", Route53: &v1alpha1.Route53DNS{HostedZoneID: "Z0123456789"}},
+			want:   &dns.Route53{},
+		},
+		{
+			name:   "rfc2136 without tsig",
+			config: &v1alpha1.EndpointDNS{Name: "api.example.com", RFC2136: &v1alpha1.RFC2136DNS{Server: "10.0.0.2", Zone: "example.com"}},
+			want:   &dns.RFC2136{},
+		},
+		{
+			name: "rfc2136 tsig without secret",
+			config: &v1alpha1.EndpointDNS{Name: "api.example.com", RFC2136: &v1alpha1.RFC2136DNS{
+				Server: "10.0.0.2", Zone: "example.com", TSIGKeyName: "eksa",
+			}},
+			wantErr: "rfc2136 tsig key eksa requires a tsig secret",
+		},
+		{
+			name:        "infoblox",
+			config:      &v1alpha1.EndpointDNS{Name: "api.example.com", Infoblox: &v1alpha1.InfobloxDNS{Server: "infoblox.example.com"}},
+			credentials: dns.Credentials{InfobloxUsername: "admin", InfobloxPassword: "password"},
+			want:        &dns.Infoblox{},
+		},
+		{
+			name:    "infoblox without credentials",
+			config:  &v1alpha1.EndpointDNS{Name: "api.example.com", Infoblox: &v1alpha1.InfobloxDNS{Server: "infoblox.example.com"}},
+			wantErr: "infoblox requires a username and password",
+		},
+		{
+			name:    "no provider",
+			config:  &v1alpha1.EndpointDNS{Name: "api.example.com"},
+			wantErr: "no dns provider configured for api.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := dns.NewProvider(tt.config, tt.credentials)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(BeAssignableToTypeOf(tt.want))
+		})
+	}
+}
+
+func TestKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://10.0.0.10:6443
+  name: prod
+contexts:
+- context:
+    cluster: prod
+    user: prod-admin
+  name: prod-admin@prod
+current-context: prod-admin@prod
+users:
+- name: prod-admin
+  user:
+    token: token
+`)
+
+	got, err := dns.Kubeconfig(kubeconfig, "api.prod.example.com")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(got)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Clusters["prod"].Server).To(Equal("https://api.prod.example.com:6443"))
+	g.Expect(config.Clusters["prod"].CertificateAuthorityData).To(Equal([]byte("ca")))
+	g.Expect(config.CurrentContext).To(Equal("prod-admin@prod"))
+	g.Expect(config.AuthInfos["prod-admin"].Token).To(Equal("token"))
+}
+
+func TestKubeconfigInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := dns.Kubeconfig([]byte("not a kubeconfig"), "api.prod.example.com")
+	g.Expect(err).To(MatchError(ContainSubstring("loading kubeconfig")))
+}
@@ -0,0 +1,190 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	defaultInfobloxView        = "default"
+	defaultInfobloxWAPIVersion = "2.11"
+	infobloxTimeout            = 30 * time.Second
+)
+
+// infobloxRecord is an A or AAAA record object of the WAPI.
+type infobloxRecord struct {
+	Ref      string `json:"_ref,omitempty"`
+	Name     string `json:"name,omitempty"`
+	View     string `json:"view,omitempty"`
+	IPv4Addr string `json:"ipv4addr,omitempty"`
+	IPv6Addr string `json:"ipv6addr,omitempty"`
+	TTL      int    `json:"ttl"`
+	UseTTL   bool   `json:"use_ttl"`
+}
+
+// Infoblox manages records through the WAPI of an Infoblox grid.
+type Infoblox struct {
+	baseURL  string
+	view     string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewInfoblox builds an Infoblox for a grid master.
+func NewInfoblox(config *v1alpha1.InfobloxDNS, username, password string) *Infoblox {
+	view := config.View
+	if view == "" {
+		view = defaultInfobloxView
+	}
+	version := config.WAPIVersion
+	if version == "" {
+		version = defaultInfobloxWAPIVersion
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+
+	return &Infoblox{
+		baseURL:  fmt.Sprintf("https://%s/wapi/v%s", config.Server, version),
+		view:     view,
+		username: username,
+		password: password,
+		client:   &http.Client{Transport: transport, Timeout: infobloxTimeout},
+	}
+}
+
+// Upsert creates the record or updates the existing records of the name that don't match it.
+func (i *Infoblox) Upsert(ctx context.Context, record Record) error {
+	existing, err := i.find(ctx, record)
+	if err != nil {
+		return fmt.Errorf("upserting infoblox record %s: %v", record.Name, err)
+	}
+
+	want := i.record(record)
+	if len(existing) == 0 {
+		if err := i.do(ctx, http.MethodPost, objectType(record), want, nil); err != nil {
+			return fmt.Errorf("creating infoblox record %s: %v", record.Name, err)
+		}
+		return nil
+	}
+
+	for _, r := range existing {
+		if r.IPv4Addr == want.IPv4Addr && r.IPv6Addr == want.IPv6Addr && r.TTL == want.TTL && r.UseTTL {
+			continue
+		}
+		update := &infobloxRecord{IPv4Addr: want.IPv4Addr, IPv6Addr: want.IPv6Addr, TTL: want.TTL, UseTTL: true}
+		if err := i.do(ctx, http.MethodPut, r.Ref, update, nil); err != nil {
+			return fmt.Errorf("updating infoblox record %s: %v", record.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the records of the name.
+func (i *Infoblox) Delete(ctx context.Context, record Record) error {
+	existing, err := i.find(ctx, record)
+	if err != nil {
+		return fmt.Errorf("deleting infoblox record %s: %v", record.Name, err)
+	}
+
+	for _, r := range existing {
+		if err := i.do(ctx, http.MethodDelete, r.Ref, nil, nil); err != nil {
+			return fmt.Errorf("deleting infoblox record %s: %v", record.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Infoblox) find(ctx context.Context, record Record) ([]infobloxRecord, error) {
+	// Only one of the address fields exists for each object type.
+	fields := "name,view,ipv4addr,ttl,use_ttl"
+	if record.Type() == "AAAA" {
+		fields = "name,view,ipv6addr,ttl,use_ttl"
+	}
+	query := url.Values{
+		"name":           {record.Name},
+		"view":           {i.view},
+		"_return_fields": {fields},
+	}
+
+	var records []infobloxRecord
+	if err := i.do(ctx, http.MethodGet, objectType(record)+"?"+query.Encode(), nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (i *Infoblox) record(record Record) *infobloxRecord {
+	r := &infobloxRecord{
+		Name:   record.Name,
+		View:   i.view,
+		TTL:    record.TTL,
+		UseTTL: true,
+	}
+	if record.Type() == "A" {
+		r.IPv4Addr = record.IP.String()
+	} else {
+		r.IPv6Addr = record.IP.String()
+	}
+	return r
+}
+
+// do calls the WAPI with body encoded as JSON and decodes the response into out, if set.
+func (i *Infoblox) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, i.baseURL+"/"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(i.username, i.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading infoblox response: %v", err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("infoblox returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("parsing infoblox response: %v", err)
+	}
+	return nil
+}
+
+func objectType(record Record) string {
+	if record.Type() == "A" {
+		return "record:a"
+	}
+	return "record:aaaa"
+}
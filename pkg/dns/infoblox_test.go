@@ -0,0 +1,154 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+type infobloxRequest struct {
+	method string
+	path   string
+	query  string
+	body   map[string]interface{}
+}
+
+// fakeInfoblox serves the WAPI requests, answering the record searches with records.
+type fakeInfoblox struct {
+	server   *httptest.Server
+	records  []map[string]interface{}
+	requests []infobloxRequest
+}
+
+func newFakeInfoblox(t *testing.T, records ...map[string]interface{}) *fakeInfoblox {
+	f := &fakeInfoblox{records: records}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "admin" || password != "password" {
+			http.Error(w, `{"Error": "AdmConProtoError: Authorization Required"}`, http.StatusUnauthorized)
+			return
+		}
+
+		req := infobloxRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				t.Errorf("invalid request body %s: %v", data, err)
+			}
+		}
+		f.requests = append(f.requests, req)
+
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(f.records)
+			return
+		}
+		json.NewEncoder(w).Encode("record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:api.prod.example.com/default")
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeInfoblox) infoblox(username string) *dns.Infoblox {
+	return dns.NewInfoblox(&v1alpha1.InfobloxDNS{
+		Server:             strings.TrimPrefix(f.server.URL, "https://"),
+		InsecureSkipVerify: true,
+	}, username, "password")
+}
+
+func TestInfobloxUpsertCreate(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeInfoblox(t)
+
+	g.Expect(f.infoblox("admin").Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+	g.Expect(f.requests).To(Equal([]infobloxRequest{
+		{
+			method: http.MethodGet,
+			path:   "/wapi/v2.11/record:a",
+			query:  "_return_fields=name%2Cview%2Cipv4addr%2Cttl%2Cuse_ttl&name=api.prod.example.com&view=default",
+		},
+		{
+			method: http.MethodPost,
+			path:   "/wapi/v2.11/record:a",
+			body: map[string]interface{}{
+				"name":     "api.prod.example.com",
+				"view":     "default",
+				"ipv4addr": "10.0.0.10",
+				"ttl":      float64(300),
+				"use_ttl":  true,
+			},
+		},
+	}))
+}
+
+func TestInfobloxUpsertUpdate(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeInfoblox(t, map[string]interface{}{
+		"_ref":     "record:aaaa/ZG5zLmJpbmRfYWFhYQ:api.prod.example.com/default",
+		"name":     "api.prod.example.com",
+		"view":     "default",
+		"ipv6addr": "fd00::11",
+		"ttl":      300,
+		"use_ttl":  true,
+	})
+
+	g.Expect(f.infoblox("admin").Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("fd00::10"), TTL: 300})).To(Succeed())
+	g.Expect(f.requests).To(HaveLen(2))
+	g.Expect(f.requests[1]).To(Equal(infobloxRequest{
+		method: http.MethodPut,
+		path:   "/wapi/v2.11/record:aaaa/ZG5zLmJpbmRfYWFhYQ:api.prod.example.com/default",
+		body: map[string]interface{}{
+			"ipv6addr": "fd00::10",
+			"ttl":      float64(300),
+			"use_ttl":  true,
+		},
+	}))
+}
+
+func TestInfobloxUpsertUpToDate(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeInfoblox(t, map[string]interface{}{
+		"_ref":     "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:api.prod.example.com/default",
+		"name":     "api.prod.example.com",
+		"view":     "default",
+		"ipv4addr": "10.0.0.10",
+		"ttl":      300,
+		"use_ttl":  true,
+	})
+
+	g.Expect(f.infoblox("admin").Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+	g.Expect(f.requests).To(HaveLen(1))
+}
+
+func TestInfobloxUpsertUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeInfoblox(t)
+
+	err := f.infoblox("other").Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})
+	g.Expect(err).To(MatchError(ContainSubstring("upserting infoblox record api.prod.example.com: infoblox returned 401 Unauthorized")))
+}
+
+func TestInfobloxDelete(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeInfoblox(t, map[string]interface{}{
+		"_ref":     "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:api.prod.example.com/default",
+		"name":     "api.prod.example.com",
+		"ipv4addr": "10.0.0.10",
+	})
+
+	g.Expect(f.infoblox("admin").Delete(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+	g.Expect(f.requests).To(HaveLen(2))
+	g.Expect(f.requests[1]).To(Equal(infobloxRequest{
+		method: http.MethodDelete,
+		path:   "/wapi/v2.11/record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:api.prod.example.com/default",
+	}))
+}
@@ -0,0 +1,164 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+// registeredRecordAnnotation is set in the DNS credentials Secret of a cluster with the last record
+// registered for it, so the old record can be removed when the name or the endpoint change.
+const registeredRecordAnnotation = "anywhere.eks.amazonaws.com/registered-dns-record"
+
+// Reconciler keeps the DNS record of the control plane endpoint of a cluster up to date.
+type Reconciler struct {
+	client      client.Client
+	newProvider dns.ProviderFactory
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, newProvider dns.ProviderFactory) *Reconciler {
+	return &Reconciler{
+		client:      client,
+		newProvider: newProvider,
+	}
+}
+
+// Reconcile upserts the record of the control plane endpoint of the cluster with the credentials
+// in its DNS credentials Secret. The upsert runs on every reconciliation, which also restores the
+// record if it's changed out of band. If the cluster registered a different record before, like
+// when the DNS name changes, that one is deleted first.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	record, err := dns.ControlPlaneRecord(cluster)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return nil
+	}
+
+	secret, err := r.credentialsSecret(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	provider, err := r.newProvider(cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS, dns.CredentialsFromSecret(secret))
+	if err != nil {
+		return err
+	}
+
+	registered, err := registeredRecord(secret)
+	if err != nil {
+		return err
+	}
+	if registered != nil && (registered.Name != record.Name || registered.Type() != record.Type()) {
+		log.Info("Deleting previous control plane endpoint DNS record", "name", registered.Name)
+		if err := provider.Delete(ctx, *registered); err != nil {
+			return err
+		}
+	}
+
+	if err := provider.Upsert(ctx, *record); err != nil {
+		return err
+	}
+
+	return r.setRegisteredRecord(ctx, secret, record)
+}
+
+// ReconcileDelete deletes the record registered for the cluster and its DNS credentials Secret.
+func (r *Reconciler) ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: dns.CredentialsSecretName(cluster.Name)}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading dns credentials secret: %v", err)
+	}
+
+	registered, err := registeredRecord(secret)
+	if err != nil {
+		return err
+	}
+
+	endpoint := cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if registered != nil && endpoint != nil && endpoint.DNS != nil {
+		provider, err := r.newProvider(endpoint.DNS, dns.CredentialsFromSecret(secret))
+		if err != nil {
+			return err
+		}
+
+		log.Info("Deleting control plane endpoint DNS record", "name", registered.Name)
+		if err := provider.Delete(ctx, *registered); err != nil {
+			return err
+		}
+	}
+
+	if err := r.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting dns credentials secret: %v", err)
+	}
+
+	return nil
+}
+
+// credentialsSecret returns the DNS credentials Secret of the cluster. Clusters not created by the CLI
+// might not have one, so an empty one is created to track the registered record. Providers that support
+// it, like Route53, use the default credentials of the controller in that case.
+func (r *Reconciler) credentialsSecret(ctx context.Context, cluster *anywherev1.Cluster) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: dns.CredentialsSecretName(cluster.Name)}, secret)
+	if err == nil {
+		return secret, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("reading dns credentials secret: %v", err)
+	}
+
+	secret = dns.Credentials{}.Secret(cluster.Name)
+	if err := r.client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("creating dns credentials secret: %v", err)
+	}
+	return secret, nil
+}
+
+func (r *Reconciler) setRegisteredRecord(ctx context.Context, secret *corev1.Secret, record *dns.Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if secret.Annotations[registeredRecordAnnotation] == string(value) {
+		return nil
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[registeredRecordAnnotation] = string(value)
+	if err := r.client.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("updating dns credentials secret: %v", err)
+	}
+	return nil
+}
+
+func registeredRecord(secret *corev1.Secret) (*dns.Record, error) {
+	value, ok := secret.Annotations[registeredRecordAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	record := &dns.Record{}
+	if err := json.Unmarshal([]byte(value), record); err != nil {
+		return nil, fmt.Errorf("parsing registered dns record of secret %s: %v", secret.Name, err)
+	}
+	return record, nil
+}
@@ -0,0 +1,187 @@
+package reconciler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dns"
+	"github.com/aws/eks-anywhere/pkg/dns/reconciler"
+)
+
+const registeredRecordAnnotation = "anywhere.eks.amazonaws.com/registered-dns-record"
+
+type fakeProvider struct {
+	upserted []dns.Record
+	deleted  []dns.Record
+	err      error
+}
+
+func (f *fakeProvider) Upsert(_ context.Context, record dns.Record) error {
+	f.upserted = append(f.upserted, record)
+	return f.err
+}
+
+func (f *fakeProvider) Delete(_ context.Context, record dns.Record) error {
+	f.deleted = append(f.deleted, record)
+	return f.err
+}
+
+type reconcilerTest struct {
+	*WithT
+	ctx         context.Context
+	client      client.Client
+	provider    *fakeProvider
+	credentials []dns.Credentials
+	reconciler  *reconciler.Reconciler
+}
+
+func newReconcilerTest(t *testing.T, objs ...client.Object) *reconcilerTest {
+	tt := &reconcilerTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		client:   fake.NewClientBuilder().WithObjects(objs...).Build(),
+		provider: &fakeProvider{},
+	}
+	tt.reconciler = reconciler.New(tt.client, func(_ *anywherev1.EndpointDNS, credentials dns.Credentials) (dns.Provider, error) {
+		tt.credentials = append(tt.credentials, credentials)
+		return tt.provider, nil
+	})
+	return tt
+}
+
+func (tt *reconcilerTest) secret() *corev1.Secret {
+	secret := &corev1.Secret{}
+	err := tt.client.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "prod-dns-credentials"}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	tt.Expect(err).NotTo(HaveOccurred())
+	return secret
+}
+
+func dnsCluster(host, name string) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Endpoint: &anywherev1.Endpoint{
+					Host: host,
+					DNS: &anywherev1.EndpointDNS{
+						Name:    name,
+						Route53: &anywherev1.Route53DNS{HostedZoneID: "Z0123456789"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func credentialsSecret(registered *dns.Record) *corev1.Secret {
+	secret := dns.Credentials{AWSAccessKeyID: "AKIA", AWSSecretAccessKey: "secret"}.Secret("prod")
+	if registered != nil {
+		value, _ := json.Marshal(registered)
+		secret.Annotations = map[string]string{registeredRecordAnnotation: string(value)}
+	}
+	return secret
+}
+
+func TestReconcilerReconcile(t *testing.T) {
+	tt := newReconcilerTest(t, credentialsSecret(nil))
+	record := dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL}
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.credentials).To(Equal([]dns.Credentials{{AWSAccessKeyID: "AKIA", AWSSecretAccessKey: "secret"}}))
+	tt.Expect(tt.provider.upserted).To(Equal([]dns.Record{record}))
+	tt.Expect(tt.provider.deleted).To(BeEmpty())
+
+	registered := &dns.Record{}
+	tt.Expect(json.Unmarshal([]byte(tt.secret().Annotations[registeredRecordAnnotation]), registered)).To(Succeed())
+	tt.Expect(registered.Name).To(Equal(record.Name))
+	tt.Expect(registered.IP.Equal(record.IP)).To(BeTrue())
+}
+
+func TestReconcilerReconcileCreatesSecret(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.credentials).To(Equal([]dns.Credentials{{}}))
+	tt.Expect(tt.provider.upserted).To(HaveLen(1))
+	tt.Expect(tt.secret().Annotations).To(HaveKey(registeredRecordAnnotation))
+}
+
+func TestReconcilerReconcileNameChanged(t *testing.T) {
+	previous := &dns.Record{Name: "api.old.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL}
+	tt := newReconcilerTest(t, credentialsSecret(previous))
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.provider.deleted).To(HaveLen(1))
+	tt.Expect(tt.provider.deleted[0].Name).To(Equal("api.old.example.com"))
+	tt.Expect(tt.provider.upserted).To(HaveLen(1))
+	tt.Expect(tt.provider.upserted[0].Name).To(Equal("api.prod.example.com"))
+}
+
+func TestReconcilerReconcileSameName(t *testing.T) {
+	previous := &dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.11"), TTL: dns.DefaultTTL}
+	tt := newReconcilerTest(t, credentialsSecret(previous))
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.provider.deleted).To(BeEmpty())
+	tt.Expect(tt.provider.upserted).To(HaveLen(1))
+}
+
+func TestReconcilerReconcileNoDNS(t *testing.T) {
+	tt := newReconcilerTest(t)
+	cluster := dnsCluster("10.0.0.10", "api.prod.example.com")
+	cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS = nil
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), cluster)).To(Succeed())
+	tt.Expect(tt.credentials).To(BeEmpty())
+	tt.Expect(tt.secret()).To(BeNil())
+}
+
+func TestReconcilerReconcileUpsertError(t *testing.T) {
+	tt := newReconcilerTest(t, credentialsSecret(nil))
+	tt.provider.err = errors.New("access denied")
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(MatchError("access denied"))
+	tt.Expect(tt.secret().Annotations).NotTo(HaveKey(registeredRecordAnnotation))
+}
+
+func TestReconcilerReconcileDelete(t *testing.T) {
+	registered := &dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL}
+	tt := newReconcilerTest(t, credentialsSecret(registered))
+
+	tt.Expect(tt.reconciler.ReconcileDelete(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.provider.deleted).To(HaveLen(1))
+	tt.Expect(tt.provider.deleted[0].Name).To(Equal("api.prod.example.com"))
+	tt.Expect(tt.secret()).To(BeNil())
+}
+
+func TestReconcilerReconcileDeleteNoSecret(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	tt.Expect(tt.reconciler.ReconcileDelete(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(Succeed())
+	tt.Expect(tt.credentials).To(BeEmpty())
+}
+
+func TestReconcilerReconcileDeleteError(t *testing.T) {
+	registered := &dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL}
+	tt := newReconcilerTest(t, credentialsSecret(registered))
+	tt.provider.err = errors.New("access denied")
+
+	tt.Expect(tt.reconciler.ReconcileDelete(tt.ctx, logr.Discard(), dnsCluster("10.0.0.10", "api.prod.example.com"))).To(MatchError("access denied"))
+	tt.Expect(tt.secret()).NotTo(BeNil())
+}
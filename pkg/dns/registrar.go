@@ -0,0 +1,66 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// KubectlClient applies manifests to a cluster.
+type KubectlClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// EndpointRegistrar registers the DNS record of the control plane endpoint of the clusters created
+// by the CLI.
+type EndpointRegistrar struct {
+	kubectl     KubectlClient
+	credentials Credentials
+	newProvider ProviderFactory
+}
+
+// NewEndpointRegistrar builds an EndpointRegistrar.
+func NewEndpointRegistrar(kubectl KubectlClient, credentials Credentials, newProvider ProviderFactory) *EndpointRegistrar {
+	return &EndpointRegistrar{
+		kubectl:     kubectl,
+		credentials: credentials,
+		newProvider: newProvider,
+	}
+}
+
+// RegisterControlPlaneEndpoint upserts the record of the control plane endpoint of the cluster and
+// stores the DNS credentials in the management cluster, so the controller keeps the record updated.
+func (r *EndpointRegistrar) RegisterControlPlaneEndpoint(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) error {
+	record, err := ControlPlaneRecord(spec.Cluster)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return nil
+	}
+
+	provider, err := r.newProvider(spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS, r.credentials)
+	if err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Upserting control plane endpoint DNS record", "name", record.Name, "ip", record.IP.String())
+	if err := provider.Upsert(ctx, *record); err != nil {
+		return err
+	}
+
+	secret, err := yaml.Marshal(r.credentials.Secret(spec.Cluster.Name))
+	if err != nil {
+		return fmt.Errorf("marshalling dns credentials secret: %v", err)
+	}
+	if err := r.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, secret); err != nil {
+		return fmt.Errorf("applying dns credentials secret: %v", err)
+	}
+
+	return nil
+}
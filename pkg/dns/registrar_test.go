@@ -0,0 +1,113 @@
+package dns_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dns"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type fakeProvider struct {
+	upserted []dns.Record
+	deleted  []dns.Record
+	err      error
+}
+
+func (f *fakeProvider) Upsert(_ context.Context, record dns.Record) error {
+	f.upserted = append(f.upserted, record)
+	return f.err
+}
+
+func (f *fakeProvider) Delete(_ context.Context, record dns.Record) error {
+	f.deleted = append(f.deleted, record)
+	return f.err
+}
+
+type fakeKubectl struct {
+	cluster *types.Cluster
+	applied []byte
+}
+
+func (f *fakeKubectl) ApplyKubeSpecFromBytes(_ context.Context, cluster *types.Cluster, data []byte) error {
+	f.cluster = cluster
+	f.applied = data
+	return nil
+}
+
+func TestEndpointRegistrarRegisterControlPlaneEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "prod"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{
+			Host: "10.0.0.10",
+			DNS: &v1alpha1.EndpointDNS{
+				Name:     "api.prod.example.com",
+				Infoblox: &v1alpha1.InfobloxDNS{Server: "infoblox.example.com"},
+			},
+		}
+	})
+	managementCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	credentials := dns.Credentials{InfobloxUsername: "admin", InfobloxPassword: "password"}
+	provider := &fakeProvider{}
+	kubectl := &fakeKubectl{}
+
+	r := dns.NewEndpointRegistrar(kubectl, credentials, func(config *v1alpha1.EndpointDNS, c dns.Credentials) (dns.Provider, error) {
+		g.Expect(config).To(Equal(spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS))
+		g.Expect(c).To(Equal(credentials))
+		return provider, nil
+	})
+
+	g.Expect(r.RegisterControlPlaneEndpoint(ctx, managementCluster, spec)).To(Succeed())
+	g.Expect(provider.upserted).To(Equal([]dns.Record{{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: dns.DefaultTTL}}))
+	g.Expect(kubectl.cluster).To(Equal(managementCluster))
+	wantSecret, err := yaml.Marshal(credentials.Secret("prod"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(kubectl.applied).To(Equal(wantSecret))
+}
+
+func TestEndpointRegistrarRegisterControlPlaneEndpointNoDNS(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+	})
+	kubectl := &fakeKubectl{}
+
+	r := dns.NewEndpointRegistrar(kubectl, dns.Credentials{}, func(*v1alpha1.EndpointDNS, dns.Credentials) (dns.Provider, error) {
+		t.Fatal("provider should not be built for clusters without dns")
+		return nil, nil
+	})
+
+	g.Expect(r.RegisterControlPlaneEndpoint(context.Background(), &types.Cluster{}, spec)).To(Succeed())
+	g.Expect(kubectl.applied).To(BeNil())
+}
+
+func TestEndpointRegistrarRegisterControlPlaneEndpointUpsertError(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{
+			Host: "10.0.0.10",
+			DNS: &v1alpha1.EndpointDNS{
+				Name:    "api.prod.example.com",
+				Route53: &v1alpha1.Route53DNS{HostedZoneID: "Z0123456789"},
+			},
+		}
+	})
+	kubectl := &fakeKubectl{}
+
+	r := dns.NewEndpointRegistrar(kubectl, dns.Credentials{}, func(*v1alpha1.EndpointDNS, dns.Credentials) (dns.Provider, error) {
+		return &fakeProvider{err: errors.New("access denied")}, nil
+	})
+
+	g.Expect(r.RegisterControlPlaneEndpoint(context.Background(), &types.Cluster{}, spec)).To(MatchError("access denied"))
+	g.Expect(kubectl.applied).To(BeNil())
+}
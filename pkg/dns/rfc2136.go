@@ -0,0 +1,309 @@
+package dns
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	defaultTSIGAlgorithm = "hmac-sha256"
+	// updateOpCode is the DNS UPDATE operation code from RFC 2136.
+	updateOpCode = dnsmessage.OpCode(5)
+	// typeTSIG is the type of the TSIG records from RFC 8945.
+	typeTSIG = dnsmessage.Type(250)
+	// tsigFudge is the clock skew in seconds allowed between the CLI or controller and the server.
+	tsigFudge      = 300
+	rfc2136Timeout = 30 * time.Second
+)
+
+// rcodeNames are the response codes a server can answer an update with, including the ones
+// defined by RFC 2136 that dnsmessage doesn't know about.
+var rcodeNames = map[dnsmessage.RCode]string{
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+// RFC2136 manages records with dynamic updates to a DNS server, optionally signed with a TSIG key.
+type RFC2136 struct {
+	server     string
+	zone       string
+	keyName    string
+	algorithm  string
+	tsigSecret string
+	now        func() time.Time
+}
+
+// NewRFC2136 builds a RFC2136 for a server. tsigSecret is the base64 encoded secret of the TSIG key,
+// it's only used when the config sets a key name.
+func NewRFC2136(config *v1alpha1.RFC2136DNS, tsigSecret string) *RFC2136 {
+	server := config.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	algorithm := config.TSIGAlgorithm
+	if algorithm == "" {
+		algorithm = defaultTSIGAlgorithm
+	}
+
+	return &RFC2136{
+		server:     server,
+		zone:       config.Zone,
+		keyName:    config.TSIGKeyName,
+		algorithm:  algorithm,
+		tsigSecret: tsigSecret,
+		now:        time.Now,
+	}
+}
+
+// Upsert replaces the addresses of the record name with the record IP.
+func (r *RFC2136) Upsert(ctx context.Context, record Record) error {
+	if err := r.update(ctx, record, true); err != nil {
+		return fmt.Errorf("upserting rfc2136 record %s: %v", record.Name, err)
+	}
+	return nil
+}
+
+// Delete removes the addresses of the record name.
+func (r *RFC2136) Delete(ctx context.Context, record Record) error {
+	if err := r.update(ctx, record, false); err != nil {
+		return fmt.Errorf("deleting rfc2136 record %s: %v", record.Name, err)
+	}
+	return nil
+}
+
+func (r *RFC2136) update(ctx context.Context, record Record, add bool) error {
+	id := uint16(rand.Intn(1 << 16))
+	msg, err := r.updateMessage(id, record, add)
+	if err != nil {
+		return err
+	}
+
+	if r.keyName != "" {
+		if msg, err = r.sign(msg, id); err != nil {
+			return err
+		}
+	}
+
+	resp, err := r.exchange(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	var parser dnsmessage.Parser
+	header, err := parser.Start(resp)
+	if err != nil {
+		return fmt.Errorf("parsing response from %s: %v", r.server, err)
+	}
+	if header.ID != id {
+		return fmt.Errorf("response from %s has id %d, expected %d", r.server, header.ID, id)
+	}
+	if header.RCode != dnsmessage.RCodeSuccess {
+		name, ok := rcodeNames[header.RCode]
+		if !ok {
+			name = fmt.Sprintf("RCODE%d", header.RCode)
+		}
+		return fmt.Errorf("dns server %s refused the update: %s", r.server, name)
+	}
+
+	return nil
+}
+
+// updateMessage builds an update that deletes the addresses of the record name and, if add is true,
+// adds the record IP. Deleting the whole RRset first makes the update replace a previous address.
+func (r *RFC2136) updateMessage(id uint16, record Record, add bool) ([]byte, error) {
+	zone, err := dnsmessage.NewName(fqdn(r.zone))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone %s: %v", r.zone, err)
+	}
+	name, err := dnsmessage.NewName(fqdn(record.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid record name %s: %v", record.Name, err)
+	}
+
+	rtype := dnsmessage.TypeA
+	if record.Type() == "AAAA" {
+		rtype = dnsmessage.TypeAAAA
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, OpCode: updateOpCode})
+	// The zone section of an update has the layout of the question section.
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: zone, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+
+	// The update section of an update has the layout of the authority section.
+	if err := b.StartAuthorities(); err != nil {
+		return nil, err
+	}
+	// A record of class ANY without data deletes the RRset of the name and type.
+	if err := b.UnknownResource(
+		dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassANY},
+		dnsmessage.UnknownResource{Type: rtype},
+	); err != nil {
+		return nil, err
+	}
+
+	if add {
+		h := dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: uint32(record.TTL)}
+		if rtype == dnsmessage.TypeA {
+			var a dnsmessage.AResource
+			copy(a.A[:], record.IP.To4())
+			err = b.AResource(h, a)
+		} else {
+			var aaaa dnsmessage.AAAAResource
+			copy(aaaa.AAAA[:], record.IP.To16())
+			err = b.AAAAResource(h, aaaa)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
+
+// sign appends a TSIG record to msg as described in RFC 8945.
+func (r *RFC2136) sign(msg []byte, id uint16) ([]byte, error) {
+	secret, err := base64.StdEncoding.DecodeString(r.tsigSecret)
+	if err != nil {
+		return nil, fmt.Errorf("decoding tsig secret: %v", err)
+	}
+
+	var newHash func() hash.Hash
+	switch r.algorithm {
+	case "hmac-sha256":
+		newHash = sha256.New
+	case "hmac-sha512":
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported tsig algorithm %s", r.algorithm)
+	}
+
+	keyName := wireName(r.keyName)
+	algorithm := wireName(r.algorithm)
+	signedAt := uint64(r.now().Unix())
+
+	// The MAC covers the message and the TSIG variables, the TSIG record fields that aren't the MAC.
+	variables := append([]byte{}, keyName...)
+	variables = binary.BigEndian.AppendUint16(variables, uint16(dnsmessage.ClassANY))
+	variables = binary.BigEndian.AppendUint32(variables, 0) // TTL
+	variables = append(variables, algorithm...)
+	variables = appendUint48(variables, signedAt)
+	variables = binary.BigEndian.AppendUint16(variables, tsigFudge)
+	variables = binary.BigEndian.AppendUint16(variables, 0) // error
+	variables = binary.BigEndian.AppendUint16(variables, 0) // other data length
+
+	mac := hmac.New(newHash, secret)
+	mac.Write(msg)
+	mac.Write(variables)
+	sum := mac.Sum(nil)
+
+	rdata := append([]byte{}, algorithm...)
+	rdata = appendUint48(rdata, signedAt)
+	rdata = binary.BigEndian.AppendUint16(rdata, tsigFudge)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(sum)))
+	rdata = append(rdata, sum...)
+	rdata = binary.BigEndian.AppendUint16(rdata, id)
+	rdata = binary.BigEndian.AppendUint16(rdata, 0) // error
+	rdata = binary.BigEndian.AppendUint16(rdata, 0) // other data length
+
+	signed := append([]byte{}, msg...)
+	signed = append(signed, keyName...)
+	signed = binary.BigEndian.AppendUint16(signed, uint16(typeTSIG))
+	signed = binary.BigEndian.AppendUint16(signed, uint16(dnsmessage.ClassANY))
+	signed = binary.BigEndian.AppendUint32(signed, 0) // TTL
+	signed = binary.BigEndian.AppendUint16(signed, uint16(len(rdata)))
+	signed = append(signed, rdata...)
+
+	// The TSIG record is the last one of the additional section, bytes 10 and 11 of the header count them.
+	additionals := binary.BigEndian.Uint16(signed[10:12])
+	binary.BigEndian.PutUint16(signed[10:12], additionals+1)
+
+	return signed, nil
+}
+
+// exchange sends msg to the server over TCP and returns the response.
+func (r *RFC2136) exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	dialer := net.Dialer{Timeout: rfc2136Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", r.server)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to dns server %s: %v", r.server, err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(rfc2136Timeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// Messages over TCP are prefixed with their length.
+	frame := binary.BigEndian.AppendUint16(nil, uint16(len(msg)))
+	if _, err := conn.Write(append(frame, msg...)); err != nil {
+		return nil, fmt.Errorf("sending update to %s: %v", r.server, err)
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, fmt.Errorf("reading response from %s: %v", r.server, err)
+	}
+	resp := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, fmt.Errorf("reading response from %s: %v", r.server, err)
+	}
+
+	return resp, nil
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// wireName returns the uncompressed wire format of a name in canonical form, which TSIG requires.
+func wireName(name string) []byte {
+	var wire []byte
+	for _, label := range strings.Split(strings.TrimSuffix(strings.ToLower(name), "."), ".") {
+		if label == "" {
+			continue
+		}
+		wire = append(wire, byte(len(label)))
+		wire = append(wire, label...)
+	}
+	return append(wire, 0)
+}
+
+func appendUint48(b []byte, v uint64) []byte {
+	return append(b, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
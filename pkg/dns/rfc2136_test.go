@@ -0,0 +1,197 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+// fakeDNSServer accepts a single update over TCP and answers it with rcode.
+type fakeDNSServer struct {
+	listener net.Listener
+	requests chan []byte
+}
+
+func newFakeDNSServer(t *testing.T, rcode dnsmessage.RCode) *fakeDNSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeDNSServer{listener: listener, requests: make(chan []byte, 1)}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		s.requests <- req
+
+		b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+			ID:       binary.BigEndian.Uint16(req[:2]),
+			Response: true,
+			OpCode:   5,
+			RCode:    rcode,
+		})
+		resp, _ := b.Finish()
+		conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(resp))), resp...))
+	}()
+
+	return s
+}
+
+func (s *fakeDNSServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func parseUpdate(t *testing.T, req []byte) (dnsmessage.Header, []dnsmessage.Question, []dnsmessage.Resource, []byte) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(req)
+	if err != nil {
+		t.Fatalf("parsing update header: %v", err)
+	}
+	questions, err := parser.AllQuestions()
+	if err != nil {
+		t.Fatalf("parsing update zone: %v", err)
+	}
+	if err := parser.SkipAllAnswers(); err != nil {
+		t.Fatalf("parsing update prerequisites: %v", err)
+	}
+	// dnsmessage can't parse the data of the records of class ANY, which delete RRsets and have none.
+	var updates []dnsmessage.Resource
+	for {
+		h, err := parser.AuthorityHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			t.Fatalf("parsing update records: %v", err)
+		}
+		update := dnsmessage.Resource{Header: h}
+		switch {
+		case h.Class == dnsmessage.ClassANY:
+			err = parser.SkipAuthority()
+		case h.Type == dnsmessage.TypeA:
+			var a dnsmessage.AResource
+			a, err = parser.AResource()
+			update.Body = &a
+		default:
+			var aaaa dnsmessage.AAAAResource
+			aaaa, err = parser.AAAAResource()
+			update.Body = &aaaa
+		}
+		if err != nil {
+			t.Fatalf("parsing update record %s: %v", h.Name, err)
+		}
+		updates = append(updates, update)
+	}
+
+	// The TSIG record is the only additional one, its data isn't parsed by dnsmessage.
+	var tsig []byte
+	additional, err := parser.Additional()
+	if err == nil {
+		if additional.Header.Type != dnsmessage.Type(250) {
+			t.Fatalf("additional record has type %d, want TSIG", additional.Header.Type)
+		}
+		tsig = additional.Body.(*dnsmessage.UnknownResource).Data
+	} else if err != dnsmessage.ErrSectionDone {
+		t.Fatalf("parsing update additional: %v", err)
+	}
+
+	return header, questions, updates, tsig
+}
+
+func TestRFC2136Upsert(t *testing.T) {
+	g := NewWithT(t)
+	server := newFakeDNSServer(t, dnsmessage.RCodeSuccess)
+	r := dns.NewRFC2136(&v1alpha1.RFC2136DNS{Server: server.addr(), Zone: "prod.example.com"}, "")
+
+	g.Expect(r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+
+	header, questions, updates, tsig := parseUpdate(t, <-server.requests)
+	g.Expect(header.OpCode).To(Equal(dnsmessage.OpCode(5)))
+	g.Expect(questions).To(Equal([]dnsmessage.Question{
+		{Name: dnsmessage.MustNewName("prod.example.com."), Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET},
+	}))
+	g.Expect(updates).To(HaveLen(2))
+	g.Expect(updates[0].Header.Name).To(Equal(dnsmessage.MustNewName("api.prod.example.com.")))
+	g.Expect(updates[0].Header.Class).To(Equal(dnsmessage.ClassANY))
+	g.Expect(updates[0].Header.Type).To(Equal(dnsmessage.TypeA))
+	g.Expect(updates[1].Header.Class).To(Equal(dnsmessage.ClassINET))
+	g.Expect(updates[1].Header.TTL).To(Equal(uint32(300)))
+	g.Expect(updates[1].Body).To(Equal(&dnsmessage.AResource{A: [4]byte{10, 0, 0, 10}}))
+	g.Expect(tsig).To(BeNil())
+}
+
+func TestRFC2136UpsertSigned(t *testing.T) {
+	g := NewWithT(t)
+	server := newFakeDNSServer(t, dnsmessage.RCodeSuccess)
+	r := dns.NewRFC2136(&v1alpha1.RFC2136DNS{
+		Server:        server.addr(),
+		Zone:          "prod.example.com",
+		TSIGKeyName:   "eksa",
+		TSIGAlgorithm: "hmac-sha512",
+	}, "c2VjcmV0")
+
+	g.Expect(r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("fd00::10"), TTL: 60})).To(Succeed())
+
+	header, _, updates, tsig := parseUpdate(t, <-server.requests)
+	g.Expect(updates).To(HaveLen(2))
+	g.Expect(updates[0].Header.Type).To(Equal(dnsmessage.TypeAAAA))
+	g.Expect(updates[1].Body).To(Equal(&dnsmessage.AAAAResource{AAAA: [16]byte{0xfd, 15: 0x10}}))
+
+	// The TSIG data starts with the algorithm name, and the MAC of hmac-sha512 is 64 bytes long.
+	algorithm := []byte("\x0bhmac-sha512\x00")
+	g.Expect(tsig[:len(algorithm)]).To(Equal(algorithm))
+	macSize := tsig[len(algorithm)+8 : len(algorithm)+10]
+	g.Expect(binary.BigEndian.Uint16(macSize)).To(Equal(uint16(64)))
+	originalID := tsig[len(algorithm)+10+64 : len(algorithm)+12+64]
+	g.Expect(binary.BigEndian.Uint16(originalID)).To(Equal(header.ID))
+}
+
+func TestRFC2136Delete(t *testing.T) {
+	g := NewWithT(t)
+	server := newFakeDNSServer(t, dnsmessage.RCodeSuccess)
+	r := dns.NewRFC2136(&v1alpha1.RFC2136DNS{Server: server.addr(), Zone: "prod.example.com"}, "")
+
+	g.Expect(r.Delete(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+
+	_, _, updates, _ := parseUpdate(t, <-server.requests)
+	g.Expect(updates).To(HaveLen(1))
+	g.Expect(updates[0].Header.Class).To(Equal(dnsmessage.ClassANY))
+}
+
+func TestRFC2136UpsertRefused(t *testing.T) {
+	g := NewWithT(t)
+	server := newFakeDNSServer(t, dnsmessage.RCodeRefused)
+	r := dns.NewRFC2136(&v1alpha1.RFC2136DNS{Server: server.addr(), Zone: "prod.example.com"}, "")
+
+	err := r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})
+	g.Expect(err).To(MatchError("upserting rfc2136 record api.prod.example.com: dns server " + server.addr() + " refused the update: REFUSED"))
+}
+
+func TestRFC2136UpsertInvalidSecret(t *testing.T) {
+	g := NewWithT(t)
+	r := dns.NewRFC2136(&v1alpha1.RFC2136DNS{Server: "127.0.0.1", Zone: "prod.example.com", TSIGKeyName: "eksa"}, "not base64!")
+
+	err := r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})
+	g.Expect(err).To(MatchError(ContainSubstring("decoding tsig secret")))
+}
@@ -0,0 +1,87 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// newRoute53Client builds a Route53 client with the static AWS credentials if they are set, or
+// the default credentials chain and the shared config otherwise.
+func newRoute53Client(creds Credentials) (route53iface.Route53API, error) {
+	opts := session.Options{SharedConfigState: session.SharedConfigEnable}
+	if creds.AWSAccessKeyID != "" {
+		opts.Config.Credentials = credentials.NewStaticCredentials(creds.AWSAccessKeyID, creds.AWSSecretAccessKey, creds.AWSSessionToken)
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session: %v", err)
+	}
+
+	return route53.New(sess), nil
+}
+
+// Route53 manages records in an AWS Route53 hosted zone.
+type Route53 struct {
+	client       route53iface.Route53API
+	hostedZoneID string
+}
+
+// NewRoute53 builds a Route53 for a hosted zone.
+func NewRoute53(client route53iface.Route53API, hostedZoneID string) *Route53 {
+	return &Route53{
+		client:       client,
+		hostedZoneID: hostedZoneID,
+	}
+}
+
+// Upsert creates or updates the record.
+func (r *Route53) Upsert(ctx context.Context, record Record) error {
+	if err := r.change(ctx, route53.ChangeActionUpsert, record); err != nil {
+		return fmt.Errorf("upserting route53 record %s: %v", record.Name, err)
+	}
+	return nil
+}
+
+// Delete removes the record.
+func (r *Route53) Delete(ctx context.Context, record Record) error {
+	err := r.change(ctx, route53.ChangeActionDelete, record)
+	// Route53 fails deleting records that don't exist or don't match the given values with an
+	// invalid change batch error. The record is gone or not owned by the cluster either way.
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == route53.ErrCodeInvalidChangeBatch && strings.Contains(aerr.Message(), "not found") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deleting route53 record %s: %v", record.Name, err)
+	}
+	return nil
+}
+
+func (r *Route53) change(ctx context.Context, action string, record Record) error {
+	_, err := r.client.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(r.hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("EKS Anywhere control plane endpoint"),
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(action),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String(record.Name + "."),
+						Type:            aws.String(record.Type()),
+						TTL:             aws.Int64(int64(record.TTL)),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(record.IP.String())}},
+					},
+				},
+			},
+		},
+	})
+	return err
+}
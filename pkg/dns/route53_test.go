@@ -0,0 +1,99 @@
+package dns_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+type fakeRoute53 struct {
+	route53iface.Route53API
+	inputs []*route53.ChangeResourceRecordSetsInput
+	err    error
+}
+
+func (f *fakeRoute53) ChangeResourceRecordSetsWithContext(_ aws.Context, input *route53.ChangeResourceRecordSetsInput, _ ...request.Option) (*route53.ChangeResourceRecordSetsOutput, error) {
+	f.inputs = append(f.inputs, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func route53Change(action, recordType, value string) *route53.ChangeResourceRecordSetsInput {
+	return &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z0123456789"),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("EKS Anywhere control plane endpoint"),
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(action),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String("api.prod.example.com."),
+						Type:            aws.String(recordType),
+						TTL:             aws.Int64(300),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRoute53Upsert(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeRoute53{}
+	r := dns.NewRoute53(client, "Z0123456789")
+
+	g.Expect(r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+	g.Expect(r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("fd00::10"), TTL: 300})).To(Succeed())
+	g.Expect(client.inputs).To(Equal([]*route53.ChangeResourceRecordSetsInput{
+		route53Change("UPSERT", "A", "10.0.0.10"),
+		route53Change("UPSERT", "AAAA", "fd00::10"),
+	}))
+}
+
+func TestRoute53UpsertError(t *testing.T) {
+	g := NewWithT(t)
+	r := dns.NewRoute53(&fakeRoute53{err: errors.New("access denied")}, "Z0123456789")
+
+	err := r.Upsert(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})
+	g.Expect(err).To(MatchError("upserting route53 record api.prod.example.com: access denied"))
+}
+
+func TestRoute53Delete(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeRoute53{}
+	r := dns.NewRoute53(client, "Z0123456789")
+
+	g.Expect(r.Delete(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+	g.Expect(client.inputs).To(Equal([]*route53.ChangeResourceRecordSetsInput{route53Change("DELETE", "A", "10.0.0.10")}))
+}
+
+func TestRoute53DeleteNotFound(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeRoute53{
+		err: awserr.New(route53.ErrCodeInvalidChangeBatch, "Tried to delete resource record set [name='api.prod.example.com.', type='A'] but it was not found", nil),
+	}
+	r := dns.NewRoute53(client, "Z0123456789")
+
+	g.Expect(r.Delete(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})).To(Succeed())
+}
+
+func TestRoute53DeleteError(t *testing.T) {
+	g := NewWithT(t)
+	r := dns.NewRoute53(&fakeRoute53{err: errors.New("access denied")}, "Z0123456789")
+
+	err := r.Delete(context.Background(), dns.Record{Name: "api.prod.example.com", IP: net.ParseIP("10.0.0.10"), TTL: 300})
+	g.Expect(err).To(MatchError("deleting route53 record api.prod.example.com: access denied"))
+}
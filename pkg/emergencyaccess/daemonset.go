@@ -0,0 +1,160 @@
+package emergencyaccess
+
+import (
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// AccessLabel is the label with the name of the EmergencyAccess a DaemonSet authorizes the key for.
+	AccessLabel = "anywhere.eks.amazonaws.com/emergency-access"
+
+	// expiryTimeFormat is the format of the expiry-time option of the OpenSSH authorized_keys file.
+	// The Z suffix makes sshd read the time as UTC instead of in the node time zone.
+	expiryTimeFormat = "200601021504Z"
+	hostRootPath     = "/host"
+)
+
+// authorizeKeyScript appends the key to the authorized_keys file of the user in the host between
+// markers, so it can be removed without touching the rest of the file. The key is removed when the
+// pod is terminated, which happens when the DaemonSet is deleted or the node is no longer selected.
+// The expiry-time option makes sshd reject the key after the access expires even if the pod can't
+// clean it up, like when the node is unreachable at the time.
+const authorizeKeyScript = `set -eu
+entry=$(grep "^${USER}:" /host/etc/passwd) || { echo "user ${USER} not found in the node"; exit 1; }
+uid=$(echo "${entry}" | cut -d: -f3)
+gid=$(echo "${entry}" | cut -d: -f4)
+dir="/host$(echo "${entry}" | cut -d: -f6)/.ssh"
+keys="${dir}/authorized_keys"
+begin="# eksa-emergency-access ${NAME} begin"
+end="# eksa-emergency-access ${NAME} end"
+
+remove() {
+  if [ -f "${keys}" ]; then
+    sed -i "/^${begin}\$/,/^${end}\$/d" "${keys}"
+    chown "${uid}:${gid}" "${keys}"
+  fi
+}
+
+if [ ! -d "${dir}" ]; then
+  mkdir -m 700 -p "${dir}"
+  chown "${uid}:${gid}" "${dir}"
+fi
+remove
+printf '%s\nexpiry-time="%s" %s\n%s\n' "${begin}" "${EXPIRY}" "${KEY}" "${end}" >> "${keys}"
+chown "${uid}:${gid}" "${keys}"
+chmod 600 "${keys}"
+trap 'remove; echo "key removed"; exit 0' TERM INT
+echo "key authorized for ${USER} until ${EXPIRY} UTC"
+while true; do
+  sleep 3600 &
+  wait $!
+done
+`
+
+// DaemonSetName returns the name of the DaemonSet that authorizes the key of an access.
+func DaemonSetName(access *v1alpha1.EmergencyAccess) string {
+	return "emergency-access-" + access.Name
+}
+
+// DaemonSet builds the DaemonSet that authorizes the key of an access in nodes, which must not be empty.
+// The pods mount the host root filesystem and write the key to the authorized_keys file of the access user
+// with an expiry-time option set to expiresAt. They tolerate every taint, so control plane nodes can be
+// selected too.
+func DaemonSet(access *v1alpha1.EmergencyAccess, image string, nodes []string, expiresAt time.Time) *appsv1.DaemonSet {
+	labels := map[string]string{AccessLabel: access.Name}
+	privileged := true
+
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DaemonSetName(access),
+			Namespace: constants.KubeSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchFields: []corev1.NodeSelectorRequirement{
+											{
+												Key:      "metadata.name",
+												Operator: corev1.NodeSelectorOpIn,
+												Values:   nodes,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "authorize-key",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"/bin/sh", "-c", authorizeKeyScript},
+							Env: []corev1.EnvVar{
+								{Name: "NAME", Value: access.Name},
+								{Name: "USER", Value: access.AuthorizedUser()},
+								{Name: "KEY", Value: strings.TrimSpace(access.Spec.SSHAuthorizedKey)},
+								{Name: "EXPIRY", Value: expiresAt.UTC().Format(expiryTimeFormat)},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "host",
+									MountPath: hostRootPath,
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("1m"),
+									corev1.ResourceMemory: resource.MustParse("8Mi"),
+								},
+							},
+						},
+					},
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: "/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
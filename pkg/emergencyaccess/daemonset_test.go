@@ -0,0 +1,64 @@
+package emergencyaccess_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/emergencyaccess"
+)
+
+func TestDaemonSet(t *testing.T) {
+	g := NewWithT(t)
+	access := &v1alpha1.EmergencyAccess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "debug-kubelet",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.EmergencyAccessSpec{
+			ClusterName:      "workload",
+			SSHAuthorizedKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIYZTw/C3nMKfLhUzRt7DpeebiFjNautSCtz4oSGFPfr admin@example.com\n",
+			Reason:           "debug kubelet crash loop",
+		},
+	}
+	expiresAt := time.Date(2023, 9, 1, 11, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	ds := emergencyaccess.DaemonSet(access, "public.ecr.aws/eks-anywhere/diagnostic-collector:v0.16.0", []string{"node-1", "node-2"}, expiresAt)
+
+	g.Expect(ds.Name).To(Equal("emergency-access-debug-kubelet"))
+	g.Expect(ds.Namespace).To(Equal(constants.KubeSystemNamespace))
+	g.Expect(ds.Spec.Selector.MatchLabels).To(Equal(map[string]string{emergencyaccess.AccessLabel: "debug-kubelet"}))
+	g.Expect(ds.Spec.Template.Labels).To(Equal(ds.Spec.Selector.MatchLabels))
+
+	pod := ds.Spec.Template.Spec
+	g.Expect(pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal([]corev1.NodeSelectorTerm{
+		{
+			MatchFields: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "metadata.name",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"node-1", "node-2"},
+				},
+			},
+		},
+	}))
+	g.Expect(pod.Tolerations).To(Equal([]corev1.Toleration{{Operator: corev1.TolerationOpExists}}))
+	g.Expect(pod.Volumes[0].HostPath.Path).To(Equal("/"))
+
+	g.Expect(pod.Containers).To(HaveLen(1))
+	container := pod.Containers[0]
+	g.Expect(container.Image).To(Equal("public.ecr.aws/eks-anywhere/diagnostic-collector:v0.16.0"))
+	g.Expect(*container.SecurityContext.Privileged).To(BeTrue())
+	g.Expect(container.VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: "host", MountPath: "/host"}}))
+	g.Expect(container.Env).To(Equal([]corev1.EnvVar{
+		{Name: "NAME", Value: "debug-kubelet"},
+		{Name: "USER", Value: "ec2-user"},
+		{Name: "KEY", Value: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIYZTw/C3nMKfLhUzRt7DpeebiFjNautSCtz4oSGFPfr admin@example.com"},
+		{Name: "EXPIRY", Value: "202309010930Z"},
+	}))
+}
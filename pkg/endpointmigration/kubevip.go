@@ -0,0 +1,80 @@
+package endpointmigration
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kubeVipManifestPath          = "/etc/kubernetes/manifests/kube-vip.yaml"
+	migrationKubeVipManifestPath = "/etc/kubernetes/manifests/kube-vip-migration.yaml"
+
+	// The migration kube-vip competes for its own lease and serves its metrics in another port,
+	// so it runs next to the kube-vip of the old endpoint in the same node.
+	migrationKubeVipLeaseName        = "plndr-cp-lock-migration"
+	migrationKubeVipPrometheusServer = ":2113"
+)
+
+// migrationKubeVip returns the kube-vip static pod manifest serving the new endpoint during the
+// migration, built from the kube-vip manifest of the control plane.
+func migrationKubeVip(kcp *controlplanev1.KubeadmControlPlane, host string) (*bootstrapv1.File, error) {
+	var manifest *bootstrapv1.File
+	for i := range kcp.Spec.KubeadmConfigSpec.Files {
+		if kcp.Spec.KubeadmConfigSpec.Files[i].Path == kubeVipManifestPath {
+			manifest = &kcp.Spec.KubeadmConfigSpec.Files[i]
+			break
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("kubeadm control plane %s doesn't have a kube-vip manifest", kcp.Name)
+	}
+
+	pod := &corev1.Pod{}
+	if err := yaml.Unmarshal([]byte(manifest.Content), pod); err != nil {
+		return nil, fmt.Errorf("reading kube-vip manifest: %v", err)
+	}
+
+	if len(pod.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("kube-vip manifest doesn't have containers")
+	}
+
+	pod.Name = pod.Name + "-migration"
+	c := &pod.Spec.Containers[0]
+	setEnv(c, "address", host)
+	setEnv(c, "vip_leasename", migrationKubeVipLeaseName)
+	setEnv(c, "prometheus_server", migrationKubeVipPrometheusServer)
+
+	b, err := yaml.Marshal(pod)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling kube-vip pod: %v", err)
+	}
+
+	return &bootstrapv1.File{
+		Path:    migrationKubeVipManifestPath,
+		Owner:   manifest.Owner,
+		Content: string(b),
+	}, nil
+}
+
+func migrationKubeVipFile(kcp *controlplanev1.KubeadmControlPlane) *bootstrapv1.File {
+	for i := range kcp.Spec.KubeadmConfigSpec.Files {
+		if kcp.Spec.KubeadmConfigSpec.Files[i].Path == migrationKubeVipManifestPath {
+			return &kcp.Spec.KubeadmConfigSpec.Files[i]
+		}
+	}
+	return nil
+}
+
+func setEnv(c *corev1.Container, name, value string) {
+	for i := range c.Env {
+		if c.Env[i].Name == name {
+			c.Env[i].Value = value
+			return
+		}
+	}
+	c.Env = append(c.Env, corev1.EnvVar{Name: name, Value: value})
+}
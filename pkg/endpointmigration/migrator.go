@@ -0,0 +1,551 @@
+package endpointmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const defaultRolloutTimeout = time.Hour
+
+// ClientFactory builds clients for a cluster from its kubeconfig file. Clients are rebuilt once
+// the kubeconfig file points to the new endpoint, so they must not cache its contents.
+type ClientFactory interface {
+	BuildClientFromKubeconfig(kubeconfig string) (kubernetes.Client, error)
+}
+
+// Migration describes a control plane endpoint migration.
+type Migration struct {
+	// Cluster is the cluster to migrate, as read from the management cluster.
+	Cluster *v1alpha1.Cluster
+	// Host is the new control plane endpoint host.
+	Host string
+	// ManagementKubeconfig is the kubeconfig file of the management cluster. It's the same
+	// as WorkloadKubeconfig for management clusters.
+	ManagementKubeconfig string
+	// WorkloadKubeconfig is the kubeconfig file of the cluster. It's overwritten with a
+	// kubeconfig pointing to the new endpoint once it's served.
+	WorkloadKubeconfig string
+}
+
+// Migrator changes the control plane endpoint of existing clusters without rebuilding them.
+// The API server is served from both endpoints while the machines and objects using the old
+// one are moved to the new one.
+type Migrator struct {
+	clients   ClientFactory
+	netClient networkutils.NetClient
+	retrier   *retrier.Retrier
+}
+
+// MigratorOpt allows to customize a Migrator on construction.
+type MigratorOpt func(*Migrator)
+
+// WithRetrier sets the retrier used to wait for the machines to be rolled out and the new
+// endpoint to be served.
+func WithRetrier(r *retrier.Retrier) MigratorOpt {
+	return func(m *Migrator) {
+		m.retrier = r
+	}
+}
+
+// NewMigrator builds a Migrator.
+func NewMigrator(clients ClientFactory, netClient networkutils.NetClient, opts ...MigratorOpt) *Migrator {
+	m := &Migrator{
+		clients:   clients,
+		netClient: netClient,
+		retrier:   retrier.New(defaultRolloutTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(10*time.Second))),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Validate checks the control plane endpoint of the cluster can be migrated to the new host.
+func (m *Migrator) Validate(migration Migration) error {
+	cluster := migration.Cluster
+	switch cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind, v1alpha1.NutanixDatacenterKind:
+	default:
+		return fmt.Errorf("control plane endpoint migration is not supported for %s", cluster.Spec.DatacenterRef.Kind)
+	}
+
+	if cluster.Spec.ControlPlaneConfiguration.Endpoint == nil {
+		return fmt.Errorf("cluster %s doesn't have a control plane endpoint", cluster.Name)
+	}
+
+	if err := networkutils.ValidateIP(migration.Host); err != nil {
+		return fmt.Errorf("new control plane endpoint %v", err)
+	}
+
+	if migration.Host == cluster.Spec.ControlPlaneConfiguration.Endpoint.Host {
+		return fmt.Errorf("cluster %s control plane endpoint is already %s", cluster.Name, migration.Host)
+	}
+
+	if networkutils.IsIPInUse(m.netClient, migration.Host) {
+		return fmt.Errorf("new control plane endpoint %s is already in use", migration.Host)
+	}
+
+	return nil
+}
+
+// Migrate changes the control plane endpoint of a cluster to a new host in three phases:
+//  1. The control plane machines are rolled out serving the API server from both endpoints, with
+//     a second kube-vip static pod and the new host in the API server certificate SANs.
+//  2. The CAPI cluster, the kubeconfig secret and the kubeconfigs and kube-proxy and cilium configs
+//     in the cluster are moved to the new endpoint and the worker machines are rolled out.
+//  3. The cluster spec is updated to the new endpoint and its reconciliation resumed, which rolls
+//     out the control plane machines serving only the new endpoint.
+//
+// The reconciliation of the cluster is paused during the first two phases. If any of them fails,
+// the cluster is moved back to the old endpoint and its reconciliation resumed, which rolls out
+// the control plane machines serving only the old endpoint.
+func (m *Migrator) Migrate(ctx context.Context, migration Migration) error {
+	if err := m.Validate(migration); err != nil {
+		return err
+	}
+
+	managementClient, err := m.clients.BuildClientFromKubeconfig(migration.ManagementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	oldHost := migration.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+	if err := m.pause(ctx, managementClient, migration.Cluster, migration.Host); err != nil {
+		return err
+	}
+
+	managementClient, moved, err := m.move(ctx, managementClient, migration, oldHost)
+	if err != nil {
+		logger.Info("Control plane endpoint migration failed, restoring the cluster", "endpoint", oldHost)
+		if restoreErr := m.restore(ctx, migration, oldHost, moved); restoreErr != nil {
+			return fmt.Errorf("%v, restoring cluster %s to endpoint %s: %v", err, migration.Cluster.Name, oldHost, restoreErr)
+		}
+		return err
+	}
+
+	logger.Info("Rolling out control plane machines serving only the new endpoint")
+	if err := m.updateClusterSpec(ctx, managementClient, migration.Cluster, migration.Host); err != nil {
+		return err
+	}
+
+	return m.waitForControlPlane(ctx, managementClient, migration.Cluster, func(kcp *controlplanev1.KubeadmControlPlane) bool {
+		return migrationKubeVipFile(kcp) == nil
+	})
+}
+
+// move runs the first two phases of the migration and returns a management cluster client built
+// from the kubeconfig pointing to the new endpoint. moved is true once the cluster objects started
+// being moved to the new endpoint, so they need to be moved back if the migration fails.
+func (m *Migrator) move(ctx context.Context, managementClient kubernetes.Client, migration Migration, oldHost string) (kubernetes.Client, bool, error) {
+	logger.Info("Rolling out control plane machines serving both endpoints", "old", oldHost, "new", migration.Host)
+	if err := m.addEndpoint(ctx, managementClient, migration.Cluster, migration.Host); err != nil {
+		return nil, false, err
+	}
+
+	logger.Info("Moving cluster to the new endpoint")
+	managementClient, err := m.moveEndpoint(ctx, managementClient, migration, oldHost, migration.Host)
+	return managementClient, true, err
+}
+
+// moveEndpoint moves the kubeconfigs and configs in the cluster, the CAPI and infrastructure clusters
+// and the kubeconfig secret from the from host to the to host and rolls out the worker machines.
+// It returns a management cluster client built from the kubeconfig pointing to the to host.
+func (m *Migrator) moveEndpoint(ctx context.Context, managementClient kubernetes.Client, migration Migration, from, to string) (kubernetes.Client, error) {
+	workloadClient, err := m.clients.BuildClientFromKubeconfig(migration.WorkloadKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.updateWorkloadConfigs(ctx, workloadClient, from, to); err != nil {
+		return nil, err
+	}
+
+	if err := m.updateCAPICluster(ctx, managementClient, migration.Cluster, to); err != nil {
+		return nil, err
+	}
+
+	if err := m.regenerateKubeconfig(ctx, managementClient, migration.Cluster, migration.WorkloadKubeconfig); err != nil {
+		return nil, err
+	}
+
+	// The kubeconfig of management clusters now points to the to host.
+	if managementClient, err = m.clients.BuildClientFromKubeconfig(migration.ManagementKubeconfig); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Rolling out worker machines")
+	if err := m.rolloutWorkers(ctx, managementClient, migration.Cluster); err != nil {
+		return nil, err
+	}
+
+	return managementClient, nil
+}
+
+// restore moves the cluster back to oldHost if it was moved and resumes its reconciliation. Both
+// endpoints are still served at this point, so the clients work with either kubeconfig.
+func (m *Migrator) restore(ctx context.Context, migration Migration, oldHost string, moved bool) error {
+	managementClient, err := m.clients.BuildClientFromKubeconfig(migration.ManagementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if moved {
+		logger.Info("Moving cluster back to the old endpoint")
+		if managementClient, err = m.moveEndpoint(ctx, managementClient, migration, migration.Host, oldHost); err != nil {
+			return err
+		}
+	}
+
+	return m.resume(ctx, managementClient, migration.Cluster)
+}
+
+// resume removes the endpoint migration annotation and resumes the reconciliation of the cluster.
+func (m *Migrator) resume(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster) error {
+	c := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, cluster.Name, cluster.Namespace, c); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", cluster.Name, err)
+	}
+
+	delete(c.Annotations, v1alpha1.ControlPlaneEndpointMigrationAnnotation)
+	c.ClearPauseAnnotation()
+	if err := client.Update(ctx, c); err != nil {
+		return fmt.Errorf("resuming cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+// pause pauses the reconciliation of the cluster and sets the endpoint migration annotation, which
+// allows to change its endpoint to host once the cluster is ready to serve it.
+func (m *Migrator) pause(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, host string) error {
+	c := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, cluster.Name, cluster.Namespace, c); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", cluster.Name, err)
+	}
+
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations[c.PausedAnnotation()] = "true"
+	c.Annotations[v1alpha1.ControlPlaneEndpointMigrationAnnotation] = host
+	if err := client.Update(ctx, c); err != nil {
+		return fmt.Errorf("pausing cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) addEndpoint(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, host string) error {
+	kcp, err := kubeadmControlPlane(ctx, client, cluster)
+	if err != nil {
+		return err
+	}
+
+	if migrationKubeVipFile(kcp) == nil {
+		file, err := migrationKubeVip(kcp, host)
+		if err != nil {
+			return err
+		}
+		kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, *file)
+
+		if kcp.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+		if !contains(apiServer.CertSANs, host) {
+			apiServer.CertSANs = append(apiServer.CertSANs, host)
+		}
+
+		if err := client.Update(ctx, kcp); err != nil {
+			return fmt.Errorf("updating kubeadm control plane %s: %v", kcp.Name, err)
+		}
+	}
+
+	if err := m.waitForControlPlane(ctx, client, cluster, func(kcp *controlplanev1.KubeadmControlPlane) bool {
+		return migrationKubeVipFile(kcp) != nil
+	}); err != nil {
+		return err
+	}
+
+	err = m.retrier.Retry(func() error {
+		if !networkutils.IsPortInUse(m.netClient, host, v1alpha1.ControlEndpointDefaultPort) {
+			return fmt.Errorf("API server is not served at %s", net.JoinHostPort(host, v1alpha1.ControlEndpointDefaultPort))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the new control plane endpoint: %v", err)
+	}
+
+	return nil
+}
+
+// waitForControlPlane waits for the control plane machines to be rolled out to a KubeadmControlPlane
+// matching done.
+func (m *Migrator) waitForControlPlane(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, done func(*controlplanev1.KubeadmControlPlane) bool) error {
+	err := m.retrier.Retry(func() error {
+		kcp, err := kubeadmControlPlane(ctx, client, cluster)
+		if err != nil {
+			return err
+		}
+
+		if !done(kcp) || !controlPlaneRolledOut(kcp) {
+			return fmt.Errorf("kubeadm control plane %s is not rolled out", kcp.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for control plane machines: %v", err)
+	}
+
+	return nil
+}
+
+func controlPlaneRolledOut(kcp *controlplanev1.KubeadmControlPlane) bool {
+	if kcp.Spec.Replicas == nil || kcp.Status.ObservedGeneration < kcp.Generation {
+		return false
+	}
+
+	replicas := *kcp.Spec.Replicas
+	return kcp.Status.Replicas == replicas &&
+		kcp.Status.UpdatedReplicas == replicas &&
+		kcp.Status.ReadyReplicas == replicas
+}
+
+func kubeadmControlPlane(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster) (*controlplanev1.KubeadmControlPlane, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	name := clusterapi.KubeadmControlPlaneName(cluster)
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, kcp); err != nil {
+		return nil, fmt.Errorf("reading kubeadm control plane %s: %v", name, err)
+	}
+
+	return kcp, nil
+}
+
+// updateWorkloadConfigs points the kubeconfigs and configs stored in the cluster to the new endpoint,
+// so the machines joining it and the pods starting in them use it.
+func (m *Migrator) updateWorkloadConfigs(ctx context.Context, client kubernetes.Client, oldHost, newHost string) error {
+	oldAddress := net.JoinHostPort(oldHost, v1alpha1.ControlEndpointDefaultPort)
+	newAddress := net.JoinHostPort(newHost, v1alpha1.ControlEndpointDefaultPort)
+
+	configMaps := []struct {
+		name, namespace string
+		update          func(key, value string) string
+	}{
+		{name: "kube-proxy", namespace: constants.KubeSystemNamespace},
+		{name: "cluster-info", namespace: constants.KubePublicNamespace},
+		{name: "kubeadm-config", namespace: constants.KubeSystemNamespace},
+		{
+			name:      "cilium-config",
+			namespace: constants.KubeSystemNamespace,
+			update: func(key, value string) string {
+				if key == "k8s-service-host" && value == oldHost {
+					return newHost
+				}
+				return value
+			},
+		},
+	}
+
+	for _, c := range configMaps {
+		update := c.update
+		if update == nil {
+			update = func(_, value string) string {
+				return strings.ReplaceAll(value, oldAddress, newAddress)
+			}
+		}
+
+		cm := &corev1.ConfigMap{}
+		err := client.Get(ctx, c.name, c.namespace, cm)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading config map %s/%s: %v", c.namespace, c.name, err)
+		}
+
+		for k, v := range cm.Data {
+			cm.Data[k] = update(k, v)
+		}
+
+		if err := client.Update(ctx, cm); err != nil {
+			return fmt.Errorf("updating config map %s/%s: %v", c.namespace, c.name, err)
+		}
+	}
+
+	return nil
+}
+
+// updateCAPICluster sets the endpoint of the CAPI cluster and of its infrastructure cluster, the
+// VSphereCluster or NutanixCluster, which the infrastructure provider would otherwise copy back.
+func (m *Migrator) updateCAPICluster(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, host string) error {
+	capiCluster := &clusterv1.Cluster{}
+	if err := client.Get(ctx, cluster.Name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return fmt.Errorf("reading CAPI cluster %s: %v", cluster.Name, err)
+	}
+
+	if ref := capiCluster.Spec.InfrastructureRef; ref != nil {
+		infraCluster := &unstructured.Unstructured{}
+		infraCluster.SetAPIVersion(ref.APIVersion)
+		infraCluster.SetKind(ref.Kind)
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = capiCluster.Namespace
+		}
+		if err := client.Get(ctx, ref.Name, namespace, infraCluster); err != nil {
+			return fmt.Errorf("reading %s %s: %v", ref.Kind, ref.Name, err)
+		}
+
+		if err := unstructured.SetNestedField(infraCluster.Object, host, "spec", "controlPlaneEndpoint", "host"); err != nil {
+			return fmt.Errorf("setting %s %s control plane endpoint: %v", ref.Kind, ref.Name, err)
+		}
+		if err := client.Update(ctx, infraCluster); err != nil {
+			return fmt.Errorf("updating %s %s: %v", ref.Kind, ref.Name, err)
+		}
+	}
+
+	capiCluster.Spec.ControlPlaneEndpoint.Host = host
+	if err := client.Update(ctx, capiCluster); err != nil {
+		return fmt.Errorf("updating CAPI cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+// regenerateKubeconfig deletes the kubeconfig secret of the cluster so the control plane provider
+// generates it again with the new endpoint, and writes it to the kubeconfig file of the cluster.
+func (m *Migrator) regenerateKubeconfig(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, kubeconfig string) error {
+	name := secret.Name(cluster.Name, secret.Kubeconfig)
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+	}
+	if err := client.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting kubeconfig secret %s: %v", name, err)
+	}
+
+	var content []byte
+	err := m.retrier.Retry(func() error {
+		s := &corev1.Secret{}
+		if err := client.Get(ctx, name, constants.EksaSystemNamespace, s); err != nil {
+			return err
+		}
+
+		content = s.Data[secret.KubeconfigDataName]
+		if len(content) == 0 {
+			return fmt.Errorf("kubeconfig secret %s is empty", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for kubeconfig secret %s: %v", name, err)
+	}
+
+	if err := os.WriteFile(kubeconfig, content, 0o600); err != nil {
+		return fmt.Errorf("writing kubeconfig file: %v", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) rolloutWorkers(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster) error {
+	list := &clusterv1.MachineDeploymentList{}
+	if err := client.List(ctx, list); err != nil {
+		return fmt.Errorf("listing machine deployments: %v", err)
+	}
+
+	now := metav1.Now()
+	var names []string
+	for i := range list.Items {
+		md := &list.Items[i]
+		if md.Namespace != constants.EksaSystemNamespace || md.Labels[clusterv1.ClusterNameLabel] != cluster.Name {
+			continue
+		}
+
+		md.Spec.RolloutAfter = &now
+		if err := client.Update(ctx, md); err != nil {
+			return fmt.Errorf("updating machine deployment %s: %v", md.Name, err)
+		}
+		names = append(names, md.Name)
+	}
+
+	for _, name := range names {
+		err := m.retrier.Retry(func() error {
+			md := &clusterv1.MachineDeployment{}
+			if err := client.Get(ctx, name, constants.EksaSystemNamespace, md); err != nil {
+				return err
+			}
+
+			if !machineDeploymentRolledOut(md) {
+				return fmt.Errorf("machine deployment %s is not rolled out", name)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for worker machines: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func machineDeploymentRolledOut(md *clusterv1.MachineDeployment) bool {
+	if md.Spec.Replicas == nil || md.Status.ObservedGeneration < md.Generation {
+		return false
+	}
+
+	replicas := *md.Spec.Replicas
+	return md.Status.Replicas == replicas &&
+		md.Status.UpdatedReplicas == replicas &&
+		md.Status.ReadyReplicas == replicas
+}
+
+// updateClusterSpec sets the new endpoint in the cluster spec and resumes its reconciliation.
+func (m *Migrator) updateClusterSpec(ctx context.Context, client kubernetes.Client, cluster *v1alpha1.Cluster, host string) error {
+	c := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, cluster.Name, cluster.Namespace, c); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", cluster.Name, err)
+	}
+
+	if c.Spec.ControlPlaneConfiguration.Endpoint == nil {
+		return errors.New("cluster control plane endpoint was removed during the migration")
+	}
+
+	c.Spec.ControlPlaneConfiguration.Endpoint.Host = host
+	if err := client.Update(ctx, c); err != nil {
+		return fmt.Errorf("updating cluster %s endpoint: %v", cluster.Name, err)
+	}
+
+	return m.resume(ctx, client, cluster)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
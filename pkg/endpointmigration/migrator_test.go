@@ -0,0 +1,401 @@
+package endpointmigration_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/endpointmigration"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	oldHost              = "1.1.1.1"
+	newHost              = "2.2.2.2"
+	regeneratedKubeconig = "server: https://2.2.2.2:6443"
+)
+
+// fakeNetClient accepts connections to the open addresses only.
+type fakeNetClient struct {
+	open map[string]bool
+}
+
+func (f *fakeNetClient) DialTimeout(_, address string, _ time.Duration) (net.Conn, error) {
+	if !f.open[address] {
+		return nil, errors.New("i/o timeout")
+	}
+	conn, _ := net.Pipe()
+	return conn, nil
+}
+
+// controllersClient simulates the controllers of the management cluster: the kubeconfig secret is
+// generated again when deleted and the migration kube-vip is removed when the cluster is resumed.
+type controllersClient struct {
+	kubernetes.Client
+	migrationKubeVip string
+	// machineDeploymentUpdateErrs is the number of machine deployment updates that fail.
+	machineDeploymentUpdateErrs int
+}
+
+func (c *controllersClient) Delete(ctx context.Context, obj kubernetes.Object) error {
+	if err := c.Client.Delete(ctx, obj); err != nil {
+		return err
+	}
+
+	if s, ok := obj.(*corev1.Secret); ok {
+		return c.Client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: s.Name, Namespace: s.Namespace},
+			Data:       map[string][]byte{"value": []byte(regeneratedKubeconig)},
+		})
+	}
+
+	return nil
+}
+
+func (c *controllersClient) Update(ctx context.Context, obj kubernetes.Object) error {
+	if _, ok := obj.(*clusterv1.MachineDeployment); ok && c.machineDeploymentUpdateErrs > 0 {
+		c.machineDeploymentUpdateErrs--
+		return errors.New("machine deployment webhook denied the request")
+	}
+
+	if err := c.Client.Update(ctx, obj); err != nil {
+		return err
+	}
+
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok || cluster.IsReconcilePaused() {
+		return nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := c.Client.Get(ctx, cluster.Name, constants.EksaSystemNamespace, kcp); err != nil {
+		return err
+	}
+
+	files := kcp.Spec.KubeadmConfigSpec.Files[:0]
+	for _, f := range kcp.Spec.KubeadmConfigSpec.Files {
+		if f.Path == "/etc/kubernetes/manifests/kube-vip-migration.yaml" {
+			c.migrationKubeVip = f.Content
+			continue
+		}
+		files = append(files, f)
+	}
+	kcp.Spec.KubeadmConfigSpec.Files = files
+
+	return c.Client.Update(ctx, kcp)
+}
+
+type clientFactory struct {
+	client      kubernetes.Client
+	kubeconfigs []string
+}
+
+func (f *clientFactory) BuildClientFromKubeconfig(kubeconfig string) (kubernetes.Client, error) {
+	f.kubeconfigs = append(f.kubeconfigs, kubeconfig)
+	return f.client, nil
+}
+
+type migratorTest struct {
+	*WithT
+	ctx       context.Context
+	client    *controllersClient
+	clients   *clientFactory
+	netClient *fakeNetClient
+	migration endpointmigration.Migration
+	migrator  *endpointmigration.Migrator
+}
+
+func newMigratorTest(t *testing.T) migratorTest {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "my-cluster"},
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{Host: oldHost},
+			},
+			ManagementCluster: v1alpha1.ManagementCluster{Name: "my-cluster"},
+		},
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: ptr.Int32(3),
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Replicas:        3,
+			UpdatedReplicas: 3,
+			ReadyReplicas:   3,
+		},
+	}
+	if err := clusterapi.SetKubeVipInKubeadmControlPlane(kcp, oldHost, "kube-vip:v1", clusterapi.KubeVipLeaderElectionForCluster(cluster)); err != nil {
+		t.Fatal(err)
+	}
+
+	objs := []client.Object{
+		cluster.DeepCopy(),
+		kcp,
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: oldHost, Port: 6443},
+				InfrastructureRef: &corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "VSphereCluster",
+					Name:       "my-cluster",
+				},
+			},
+		},
+		&vspherev1.VSphereCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+			Spec: vspherev1.VSphereClusterSpec{
+				ControlPlaneEndpoint: vspherev1.APIEndpoint{Host: oldHost, Port: 6443},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-kubeconfig", Namespace: constants.EksaSystemNamespace},
+			Data:       map[string][]byte{"value": []byte("server: https://1.1.1.1:6443")},
+		},
+		machineDeployment("my-cluster-md-0", "my-cluster"),
+		machineDeployment("other-md-0", "other"),
+		configMap("kube-proxy", constants.KubeSystemNamespace, "kubeconfig.conf", "server: https://1.1.1.1:6443"),
+		configMap("cluster-info", constants.KubePublicNamespace, "kubeconfig", "server: https://1.1.1.1:6443"),
+		configMap("kubeadm-config", constants.KubeSystemNamespace, "ClusterConfiguration", "controlPlaneEndpoint: 1.1.1.1:6443"),
+		configMap("cilium-config", constants.KubeSystemNamespace, "k8s-service-host", oldHost),
+	}
+
+	c := &controllersClient{Client: test.NewFakeKubeClient(objs...)}
+	clients := &clientFactory{client: c}
+	netClient := &fakeNetClient{open: map[string]bool{"2.2.2.2:6443": true}}
+	kubeconfig := filepath.Join(t.TempDir(), "my-cluster-eks-a-cluster.kubeconfig")
+
+	return migratorTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		client:    c,
+		clients:   clients,
+		netClient: netClient,
+		migration: endpointmigration.Migration{
+			Cluster:              cluster,
+			Host:                 newHost,
+			ManagementKubeconfig: kubeconfig,
+			WorkloadKubeconfig:   kubeconfig,
+		},
+		migrator: endpointmigration.NewMigrator(clients, netClient, endpointmigration.WithRetrier(retrier.NewWithMaxRetries(1, 0))),
+	}
+}
+
+func machineDeployment(name, clusterName string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterName},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.Int32(2),
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			Replicas:        2,
+			UpdatedReplicas: 2,
+			ReadyReplicas:   2,
+		},
+	}
+}
+
+func configMap(name, namespace, key, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{key: value},
+	}
+}
+
+func (tt migratorTest) expectConfigMap(name, namespace, key, value string) {
+	cm := &corev1.ConfigMap{}
+	tt.Expect(tt.client.Get(tt.ctx, name, namespace, cm)).To(Succeed())
+	tt.Expect(cm.Data).To(HaveKeyWithValue(key, value))
+}
+
+func (tt migratorTest) expectCAPIClusterEndpoint(host string) {
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	tt.Expect(capiCluster.Spec.ControlPlaneEndpoint.Host).To(Equal(host))
+
+	vsphereCluster := &vspherev1.VSphereCluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, vsphereCluster)).To(Succeed())
+	tt.Expect(vsphereCluster.Spec.ControlPlaneEndpoint.Host).To(Equal(host))
+}
+
+func (tt migratorTest) expectClusterRestored() {
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	tt.Expect(cluster.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal(oldHost))
+	tt.Expect(cluster.IsReconcilePaused()).To(BeFalse())
+	tt.Expect(cluster.Annotations).ToNot(HaveKey(v1alpha1.ControlPlaneEndpointMigrationAnnotation))
+}
+
+func TestMigratorMigrate(t *testing.T) {
+	tt := newMigratorTest(t)
+
+	tt.Expect(tt.migrator.Migrate(tt.ctx, tt.migration)).To(Succeed())
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	tt.Expect(cluster.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal(newHost))
+	tt.Expect(cluster.Annotations).ToNot(HaveKey(cluster.PausedAnnotation()))
+	tt.Expect(cluster.Annotations).ToNot(HaveKey(v1alpha1.ControlPlaneEndpointMigrationAnnotation))
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, kcp)).To(Succeed())
+	tt.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs).To(ContainElement(newHost))
+	tt.Expect(tt.client.migrationKubeVip).To(ContainSubstring("name: kube-vip-migration"))
+	tt.Expect(tt.client.migrationKubeVip).To(ContainSubstring("value: 2.2.2.2"))
+	tt.Expect(tt.client.migrationKubeVip).To(ContainSubstring("value: plndr-cp-lock-migration"))
+	tt.Expect(tt.client.migrationKubeVip).ToNot(ContainSubstring(oldHost))
+
+	tt.expectCAPIClusterEndpoint(newHost)
+
+	tt.expectConfigMap("kube-proxy", constants.KubeSystemNamespace, "kubeconfig.conf", "server: https://2.2.2.2:6443")
+	tt.expectConfigMap("cluster-info", constants.KubePublicNamespace, "kubeconfig", "server: https://2.2.2.2:6443")
+	tt.expectConfigMap("kubeadm-config", constants.KubeSystemNamespace, "ClusterConfiguration", "controlPlaneEndpoint: 2.2.2.2:6443")
+	tt.expectConfigMap("cilium-config", constants.KubeSystemNamespace, "k8s-service-host", newHost)
+
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0", constants.EksaSystemNamespace, md)).To(Succeed())
+	tt.Expect(md.Spec.RolloutAfter).ToNot(BeNil())
+	tt.Expect(tt.client.Get(tt.ctx, "other-md-0", constants.EksaSystemNamespace, md)).To(Succeed())
+	tt.Expect(md.Spec.RolloutAfter).To(BeNil())
+
+	kubeconfig, err := os.ReadFile(tt.migration.WorkloadKubeconfig)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(string(kubeconfig)).To(Equal(regeneratedKubeconig))
+	tt.Expect(tt.clients.kubeconfigs).To(HaveLen(3))
+}
+
+func TestMigratorMigrateNewEndpointNotServed(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.netClient.open = nil
+
+	tt.Expect(tt.migrator.Migrate(tt.ctx, tt.migration)).To(MatchError(ContainSubstring("API server is not served at 2.2.2.2:6443")))
+
+	tt.expectClusterRestored()
+	tt.expectCAPIClusterEndpoint(oldHost)
+	tt.Expect(tt.client.migrationKubeVip).To(ContainSubstring("value: 2.2.2.2"))
+}
+
+func TestMigratorMigrateWorkersRolloutErrorRestoresCluster(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.machineDeploymentUpdateErrs = 1
+
+	tt.Expect(tt.migrator.Migrate(tt.ctx, tt.migration)).To(MatchError(ContainSubstring("machine deployment webhook denied the request")))
+
+	tt.expectClusterRestored()
+	tt.expectCAPIClusterEndpoint(oldHost)
+	tt.expectConfigMap("kube-proxy", constants.KubeSystemNamespace, "kubeconfig.conf", "server: https://1.1.1.1:6443")
+	tt.expectConfigMap("cilium-config", constants.KubeSystemNamespace, "k8s-service-host", oldHost)
+
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0", constants.EksaSystemNamespace, md)).To(Succeed())
+	tt.Expect(md.Spec.RolloutAfter).ToNot(BeNil())
+}
+
+func TestMigratorMigrateRestoreError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.machineDeploymentUpdateErrs = 2
+
+	err := tt.migrator.Migrate(tt.ctx, tt.migration)
+	tt.Expect(err).To(MatchError(ContainSubstring("restoring cluster my-cluster to endpoint 1.1.1.1")))
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	tt.Expect(cluster.IsReconcilePaused()).To(BeTrue())
+}
+
+func TestMigratorMigrateNoKubeVip(t *testing.T) {
+	tt := newMigratorTest(t)
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, kcp)).To(Succeed())
+	kcp.Spec.KubeadmConfigSpec.Files = nil
+	tt.Expect(tt.client.Client.Update(tt.ctx, kcp)).To(Succeed())
+
+	tt.Expect(tt.migrator.Migrate(tt.ctx, tt.migration)).To(MatchError("kubeadm control plane my-cluster doesn't have a kube-vip manifest"))
+}
+
+func TestMigratorValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*endpointmigration.Migration)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			update: func(*endpointmigration.Migration) {},
+		},
+		{
+			name: "unsupported provider",
+			update: func(m *endpointmigration.Migration) {
+				m.Cluster.Spec.DatacenterRef.Kind = v1alpha1.CloudStackDatacenterKind
+			},
+			wantErr: "control plane endpoint migration is not supported for CloudStackDatacenterConfig",
+		},
+		{
+			name: "no endpoint",
+			update: func(m *endpointmigration.Migration) {
+				m.Cluster.Spec.ControlPlaneConfiguration.Endpoint = nil
+			},
+			wantErr: "cluster my-cluster doesn't have a control plane endpoint",
+		},
+		{
+			name: "invalid host",
+			update: func(m *endpointmigration.Migration) {
+				m.Host = "my-endpoint"
+			},
+			wantErr: "new control plane endpoint is invalid: my-endpoint",
+		},
+		{
+			name: "same host",
+			update: func(m *endpointmigration.Migration) {
+				m.Host = oldHost
+			},
+			wantErr: "cluster my-cluster control plane endpoint is already 1.1.1.1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newMigratorTest(t)
+			tc.update(&tt.migration)
+
+			err := tt.migrator.Validate(tt.migration)
+			if tc.wantErr == "" {
+				tt.Expect(err).ToNot(HaveOccurred())
+			} else {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestMigratorValidateHostInUse(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.netClient.open["2.2.2.2:80"] = true
+
+	tt.Expect(tt.migrator.Validate(tt.migration)).To(MatchError("new control plane endpoint 2.2.2.2 is already in use"))
+}
@@ -0,0 +1,186 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Code is a stable identifier of a category of failure, with the format
+// EKSA-<CATEGORY>[-<SUBCATEGORY>]-<NUMBER>. Codes are never renumbered or reused for a different
+// failure, so automation wrapping the CLI can branch on them across releases.
+type Code string
+
+// Category groups the codes of the failures in the same area.
+type Category string
+
+// Categories of the codes.
+const (
+	CategoryInternal   Category = "internal"
+	CategoryInput      Category = "input"
+	CategoryConfig     Category = "config"
+	CategoryValidation Category = "validation"
+	CategoryProvider   Category = "provider"
+	CategoryBootstrap  Category = "bootstrap"
+	CategoryClusterAPI Category = "clusterapi"
+	CategoryCluster    Category = "cluster"
+	CategoryComponents Category = "components"
+	CategoryGitOps     Category = "gitops"
+	CategoryDNS        Category = "dns"
+	CategoryPackages   Category = "packages"
+)
+
+// Codes of the CLI failures. New codes are added with the next number in their category.
+const (
+	// CodeUnknown is used for the failures that don't have a code yet.
+	CodeUnknown Code = "EKSA-GEN-000"
+
+	CodeInvalidInput Code = "EKSA-CLI-001"
+
+	CodeClusterConfigRead    Code = "EKSA-CFG-001"
+	CodeClusterConfigInvalid Code = "EKSA-CFG-002"
+
+	CodeValidation Code = "EKSA-VAL-001"
+	CodeClockSkew  Code = "EKSA-VAL-002"
+
+	CodeBootstrapCluster Code = "EKSA-BOOT-001"
+
+	CodeCAPIInstall Code = "EKSA-CAPI-001"
+	CodeCAPIMove    Code = "EKSA-CAPI-002"
+
+	CodeClusterCreate  Code = "EKSA-CLUSTER-001"
+	CodeClusterUpgrade Code = "EKSA-CLUSTER-002"
+	CodeClusterDelete  Code = "EKSA-CLUSTER-003"
+
+	CodeComponents Code = "EKSA-COMP-001"
+
+	CodeGitOps Code = "EKSA-GITOPS-001"
+
+	CodeEndpointDNS Code = "EKSA-DNS-001"
+
+	CodePackages Code = "EKSA-PKG-001"
+
+	CodeVSphereSetup    Code = "EKSA-PROV-VSPHERE-001"
+	CodeCloudStackSetup Code = "EKSA-PROV-CLOUDSTACK-001"
+	CodeNutanixSetup    Code = "EKSA-PROV-NUTANIX-001"
+	CodeTinkerbellSetup Code = "EKSA-PROV-TINKERBELL-001"
+	CodeSnowSetup       Code = "EKSA-PROV-SNOW-001"
+	CodeDockerSetup     Code = "EKSA-PROV-DOCKER-001"
+)
+
+// CodeInfo describes a Code.
+type CodeInfo struct {
+	Code        Code     `json:"code"`
+	Category    Category `json:"category"`
+	Description string   `json:"description"`
+}
+
+var catalog = map[Code]CodeInfo{}
+
+func register(code Code, category Category, description string) {
+	catalog[code] = CodeInfo{Code: code, Category: category, Description: description}
+}
+
+func init() {
+	register(CodeUnknown, CategoryInternal, "The failure doesn't have a specific code. Check the error message and the logs.")
+	register(CodeInvalidInput, CategoryInput, "The command flags or arguments are invalid.")
+	register(CodeClusterConfigRead, CategoryConfig, "The cluster config file can't be read, parsed or doesn't match the schema.")
+	register(CodeClusterConfigInvalid, CategoryConfig, "The cluster config is not valid.")
+	register(CodeValidation, CategoryValidation, "One or more preflight validations failed.")
+	register(CodeClockSkew, CategoryValidation, "The clocks of the admin machine and the cluster nodes are not in sync.")
+	register(CodeBootstrapCluster, CategoryBootstrap, "The bootstrap cluster can't be created or deleted.")
+	register(CodeCAPIInstall, CategoryClusterAPI, "The Cluster API components can't be installed or upgraded.")
+	register(CodeCAPIMove, CategoryClusterAPI, "The Cluster API objects can't be moved between clusters.")
+	register(CodeClusterCreate, CategoryCluster, "The cluster can't be created.")
+	register(CodeClusterUpgrade, CategoryCluster, "The cluster can't be upgraded.")
+	register(CodeClusterDelete, CategoryCluster, "The cluster can't be deleted.")
+	register(CodeComponents, CategoryComponents, "The EKS Anywhere components can't be installed or upgraded.")
+	register(CodeGitOps, CategoryGitOps, "The GitOps controller or repository can't be configured.")
+	register(CodeEndpointDNS, CategoryDNS, "The control plane endpoint DNS record can't be registered.")
+	register(CodePackages, CategoryPackages, "The curated packages can't be installed or deleted.")
+	register(CodeVSphereSetup, CategoryProvider, "The vSphere provider setup or validation failed.")
+	register(CodeCloudStackSetup, CategoryProvider, "The CloudStack provider setup or validation failed.")
+	register(CodeNutanixSetup, CategoryProvider, "The Nutanix provider setup or validation failed.")
+	register(CodeTinkerbellSetup, CategoryProvider, "The Bare Metal provider setup or validation failed.")
+	register(CodeSnowSetup, CategoryProvider, "The Snow provider setup or validation failed.")
+	register(CodeDockerSetup, CategoryProvider, "The Docker provider setup or validation failed.")
+}
+
+// Codes returns the information of all the codes, sorted by code.
+func Codes() []CodeInfo {
+	codes := make([]CodeInfo, 0, len(catalog))
+	for _, info := range catalog {
+		codes = append(codes, info)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
+
+// Info returns the information of the code. Codes not in the catalog are described as CodeUnknown.
+func (c Code) Info() CodeInfo {
+	if info, ok := catalog[c]; ok {
+		return info
+	}
+	info := catalog[CodeUnknown]
+	info.Code = c
+	return info
+}
+
+// ProviderSetupCode returns the code of the setup and validation failures of a provider, by the
+// provider name.
+func ProviderSetupCode(provider string) Code {
+	code := Code(fmt.Sprintf("EKSA-PROV-%s-001", strings.ToUpper(provider)))
+	if _, ok := catalog[code]; !ok {
+		return CodeValidation
+	}
+	return code
+}
+
+// Error is an error with a Code. Details holds additional messages, like the individual
+// failures of an aggregated error.
+type Error struct {
+	Code    Code
+	Err     error
+	Details []string
+}
+
+// Error returns the message of the wrapped error, without the code.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode annotates err with code. If err already has a code, it's returned as is, so the most
+// specific code, set closest to the failure, is kept.
+func WithCode(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	if CodeOf(err) != CodeUnknown {
+		return err
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// CodeOf returns the code of err, or CodeUnknown if err doesn't have one.
+func CodeOf(err error) Code {
+	e := &Error{}
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return CodeUnknown
+}
+
+// DetailsOf returns the details of the coded error in err, if any.
+func DetailsOf(err error) []string {
+	e := &Error{}
+	if errors.As(err, &e) {
+		return e.Details
+	}
+	return nil
+}
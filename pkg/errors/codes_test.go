@@ -0,0 +1,79 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+)
+
+func TestCodesFormat(t *testing.T) {
+	g := NewWithT(t)
+	codes := eksaerrors.Codes()
+	g.Expect(codes).NotTo(BeEmpty())
+	for _, info := range codes {
+		g.Expect(string(info.Code)).To(MatchRegexp(`^EKSA-[A-Z]+(-[A-Z]+)?-\d{3}$`))
+		g.Expect(info.Category).NotTo(BeEmpty(), "code %s", info.Code)
+		g.Expect(info.Description).NotTo(BeEmpty(), "code %s", info.Code)
+	}
+}
+
+func TestCodeInfoUnknownCode(t *testing.T) {
+	g := NewWithT(t)
+	info := eksaerrors.Code("EKSA-FOO-999").Info()
+	g.Expect(info.Code).To(Equal(eksaerrors.Code("EKSA-FOO-999")))
+	g.Expect(info.Category).To(Equal(eksaerrors.CategoryInternal))
+}
+
+func TestProviderSetupCode(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     eksaerrors.Code
+	}{
+		{provider: "vsphere", want: eksaerrors.CodeVSphereSetup},
+		{provider: "cloudstack", want: eksaerrors.CodeCloudStackSetup},
+		{provider: "nutanix", want: eksaerrors.CodeNutanixSetup},
+		{provider: "tinkerbell", want: eksaerrors.CodeTinkerbellSetup},
+		{provider: "snow", want: eksaerrors.CodeSnowSetup},
+		{provider: "docker", want: eksaerrors.CodeDockerSetup},
+		{provider: "unknown", want: eksaerrors.CodeValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(eksaerrors.ProviderSetupCode(tt.provider)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	g := NewWithT(t)
+	cause := errors.New("datacenter not found")
+	err := fmt.Errorf("validating provider: %w", eksaerrors.WithCode(eksaerrors.CodeVSphereSetup, cause))
+
+	g.Expect(err).To(MatchError("validating provider: datacenter not found"))
+	g.Expect(errors.Is(err, cause)).To(BeTrue())
+	g.Expect(eksaerrors.CodeOf(err)).To(Equal(eksaerrors.CodeVSphereSetup))
+}
+
+func TestWithCodeKeepsExistingCode(t *testing.T) {
+	g := NewWithT(t)
+	err := eksaerrors.WithCode(eksaerrors.CodeVSphereSetup, errors.New("datacenter not found"))
+	err = eksaerrors.WithCode(eksaerrors.CodeValidation, fmt.Errorf("setup: %w", err))
+
+	g.Expect(eksaerrors.CodeOf(err)).To(Equal(eksaerrors.CodeVSphereSetup))
+}
+
+func TestWithCodeNilError(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(eksaerrors.WithCode(eksaerrors.CodeValidation, nil)).To(BeNil())
+}
+
+func TestCodeOfUnknown(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(eksaerrors.CodeOf(errors.New("boom"))).To(Equal(eksaerrors.CodeUnknown))
+	g.Expect(eksaerrors.DetailsOf(errors.New("boom"))).To(BeNil())
+}
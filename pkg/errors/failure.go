@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Formats of the failures printed by the CLI.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Failure is the machine-readable representation of an error printed by the CLI.
+type Failure struct {
+	Code     Code     `json:"code"`
+	Category Category `json:"category"`
+	Message  string   `json:"message"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// NewFailure builds the Failure for err.
+func NewFailure(err error) Failure {
+	code := CodeOf(err)
+	return Failure{
+		Code:     code,
+		Category: code.Info().Category,
+		Message:  err.Error(),
+		Details:  DetailsOf(err),
+	}
+}
+
+// WriteFailure writes err to w in the given format, text or json. The json format is a single
+// line object, so it can be parsed from the end of the command output.
+func WriteFailure(w io.Writer, format string, err error) error {
+	f := NewFailure(err)
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(f)
+	case FormatText, "":
+		// The details are not printed, they are already logged when the failures happen.
+		_, err := fmt.Fprintf(w, "Error: [%s] %s\n", f.Code, f.Message)
+		return err
+	default:
+		return fmt.Errorf("invalid error format %s, must be %s or %s", format, FormatText, FormatJSON)
+	}
+}
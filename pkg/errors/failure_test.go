@@ -0,0 +1,49 @@
+package errors_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+)
+
+func TestWriteFailureJSON(t *testing.T) {
+	g := NewWithT(t)
+	err := &eksaerrors.Error{
+		Code:    eksaerrors.CodeValidation,
+		Err:     errors.New("validations failed"),
+		Details: []string{"cluster name is valid: name too long"},
+	}
+	b := &bytes.Buffer{}
+
+	g.Expect(eksaerrors.WriteFailure(b, eksaerrors.FormatJSON, err)).To(Succeed())
+	g.Expect(b.String()).To(Equal(`{"code":"EKSA-VAL-001","category":"validation","message":"validations failed","details":["cluster name is valid: name too long"]}` + "\n"))
+}
+
+func TestWriteFailureJSONUnknownCode(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+
+	g.Expect(eksaerrors.WriteFailure(b, eksaerrors.FormatJSON, errors.New("boom"))).To(Succeed())
+	g.Expect(b.String()).To(Equal(`{"code":"EKSA-GEN-000","category":"internal","message":"boom"}` + "\n"))
+}
+
+func TestWriteFailureText(t *testing.T) {
+	g := NewWithT(t)
+	err := eksaerrors.WithCode(eksaerrors.CodeClusterConfigRead, errors.New("the cluster config file c.yaml does not exist"))
+	b := &bytes.Buffer{}
+
+	g.Expect(eksaerrors.WriteFailure(b, eksaerrors.FormatText, err)).To(Succeed())
+	g.Expect(b.String()).To(Equal("Error: [EKSA-CFG-001] the cluster config file c.yaml does not exist\n"))
+}
+
+func TestWriteFailureInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+
+	g.Expect(eksaerrors.WriteFailure(b, "yaml", errors.New("boom"))).To(MatchError("invalid error format yaml, must be text or json"))
+	g.Expect(b.String()).To(BeEmpty())
+}
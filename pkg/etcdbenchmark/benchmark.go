@@ -0,0 +1,155 @@
+package etcdbenchmark
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/pod.yaml
+var podTemplate string
+
+const (
+	// MaxFdatasyncLatency is the 99th percentile of the WAL fdatasync duration recommended by etcd.
+	MaxFdatasyncLatency = 10 * time.Millisecond
+
+	containerName = "fio"
+	// hostPath is a directory in the etcd data disk, so fio measures the same filesystem etcd uses.
+	hostPath       = "/var/lib/etcd/eksa-disk-benchmark"
+	podTimeout     = "5m"
+	percentile99th = "99.000000"
+)
+
+// KubectlClient runs the benchmark pods in a cluster.
+type KubectlClient interface {
+	GetControlPlaneNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForPodCompleted(ctx context.Context, cluster *types.Cluster, name string, timeout string, namespace string) error
+	GetPodLogs(ctx context.Context, namespace, podName, containerName, kubeconfig string) (string, error)
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Result is the outcome of the benchmark in a node.
+type Result struct {
+	Node string
+	// FdatasyncP99 is the 99th percentile of the fdatasync duration.
+	FdatasyncP99 time.Duration
+}
+
+// Benchmark measures the fdatasync latency of the etcd disks of the control plane nodes with fio,
+// following the etcd hardware guidance.
+type Benchmark struct {
+	kubectl KubectlClient
+	image   string
+}
+
+// NewBenchmark builds a Benchmark that runs fio from image, which must have fio in its PATH.
+func NewBenchmark(kubectl KubectlClient, image string) *Benchmark {
+	return &Benchmark{
+		kubectl: kubectl,
+		image:   image,
+	}
+}
+
+// Run runs the benchmark in each control plane node of the cluster, one at a time, so the pods
+// don't compete for the same disk. Only etcd members running in the control plane nodes are
+// benchmarked, external etcd machines are not part of the cluster nodes.
+func (b *Benchmark) Run(ctx context.Context, cluster *types.Cluster) ([]Result, error) {
+	nodes, err := b.kubectl.GetControlPlaneNodes(ctx, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(nodes))
+	for _, node := range nodes {
+		logger.V(3).Info("Running etcd disk benchmark", "node", node.Name)
+		latency, err := b.runInNode(ctx, cluster, node.Name)
+		if err != nil {
+			return nil, fmt.Errorf("running etcd disk benchmark in node %s: %v", node.Name, err)
+		}
+		results = append(results, Result{Node: node.Name, FdatasyncP99: latency})
+	}
+
+	return results, nil
+}
+
+func (b *Benchmark) runInNode(ctx context.Context, cluster *types.Cluster, node string) (latency time.Duration, reterr error) {
+	name := "eksa-etcd-disk-benchmark-" + node
+	pod, err := templater.Execute(podTemplate, map[string]string{
+		"name":      name,
+		"namespace": constants.KubeSystemNamespace,
+		"nodeName":  node,
+		"container": containerName,
+		"image":     b.image,
+		"hostPath":  hostPath,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("generating benchmark pod: %v", err)
+	}
+
+	if err = b.kubectl.ApplyKubeSpecFromBytes(ctx, cluster, pod); err != nil {
+		return 0, fmt.Errorf("creating benchmark pod: %v", err)
+	}
+	defer func() {
+		if err := b.kubectl.DeleteKubeSpecFromBytes(ctx, cluster, pod); err != nil && reterr == nil {
+			reterr = fmt.Errorf("deleting benchmark pod: %v", err)
+		}
+	}()
+
+	if err = b.kubectl.WaitForPodCompleted(ctx, cluster, name, podTimeout, constants.KubeSystemNamespace); err != nil {
+		return 0, fmt.Errorf("waiting for benchmark pod: %v", err)
+	}
+
+	logs, err := b.kubectl.GetPodLogs(ctx, constants.KubeSystemNamespace, name, containerName, cluster.KubeconfigFile)
+	if err != nil {
+		return 0, fmt.Errorf("reading benchmark output: %v", err)
+	}
+
+	return FdatasyncP99(logs)
+}
+
+type fioOutput struct {
+	Jobs []struct {
+		Sync struct {
+			LatencyNs struct {
+				Percentile map[string]float64 `json:"percentile"`
+			} `json:"lat_ns"`
+		} `json:"sync"`
+	} `json:"jobs"`
+}
+
+// FdatasyncP99 reads the 99th percentile of the fdatasync duration from the fio json output.
+// Anything fio prints before the json document, like warnings, is ignored.
+func FdatasyncP99(output string) (time.Duration, error) {
+	start := strings.IndexByte(output, '{')
+	if start < 0 {
+		return 0, errors.New("fio output doesn't include a json document")
+	}
+
+	o := &fioOutput{}
+	if err := json.Unmarshal([]byte(output[start:]), o); err != nil {
+		return 0, fmt.Errorf("parsing fio output: %v", err)
+	}
+
+	if len(o.Jobs) == 0 {
+		return 0, errors.New("fio output doesn't include any job")
+	}
+
+	p99, ok := o.Jobs[0].Sync.LatencyNs.Percentile[percentile99th]
+	if !ok {
+		return 0, errors.New("fio output doesn't include the fdatasync 99th percentile")
+	}
+
+	return time.Duration(p99), nil
+}
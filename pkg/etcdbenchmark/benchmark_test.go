@@ -0,0 +1,145 @@
+package etcdbenchmark_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const fioImage = "registry.example.com/fio:3.33"
+
+type benchmarkTest struct {
+	*WithT
+	ctx       context.Context
+	kubectl   *mocks.MockKubectlClient
+	cluster   *types.Cluster
+	benchmark *etcdbenchmark.Benchmark
+}
+
+func newBenchmarkTest(t *testing.T) *benchmarkTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	return &benchmarkTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		kubectl:   kubectl,
+		cluster:   &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		benchmark: etcdbenchmark.NewBenchmark(kubectl, fioImage),
+	}
+}
+
+func controlPlaneNodes(names ...string) []corev1.Node {
+	nodes := make([]corev1.Node, 0, len(names))
+	for _, n := range names {
+		nodes = append(nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: n}})
+	}
+	return nodes
+}
+
+func (tt *benchmarkTest) expectBenchmark(node, logs string) {
+	name := "eksa-etcd-disk-benchmark-" + node
+	pod := gomock.AssignableToTypeOf([]byte{})
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, pod).DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: " + name))
+			tt.Expect(string(data)).To(ContainSubstring("nodeName: " + node))
+			tt.Expect(string(data)).To(ContainSubstring("image: " + fioImage))
+			return nil
+		}),
+		tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, name, "5m", "kube-system").Return(nil),
+		tt.kubectl.EXPECT().GetPodLogs(tt.ctx, "kube-system", name, "fio", tt.cluster.KubeconfigFile).Return(logs, nil),
+		tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil),
+	)
+}
+
+func TestBenchmarkRun(t *testing.T) {
+	tt := newBenchmarkTest(t)
+	logs := string(test.ReadFileAsBytes(t, "testdata/fio-output.txt"))
+	tt.kubectl.EXPECT().GetControlPlaneNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(controlPlaneNodes("cp-1", "cp-2"), nil)
+	tt.expectBenchmark("cp-1", logs)
+	tt.expectBenchmark("cp-2", logs)
+
+	tt.Expect(tt.benchmark.Run(tt.ctx, tt.cluster)).To(Equal([]etcdbenchmark.Result{
+		{Node: "cp-1", FdatasyncP99: 4176896 * time.Nanosecond},
+		{Node: "cp-2", FdatasyncP99: 4176896 * time.Nanosecond},
+	}))
+}
+
+func TestBenchmarkRunPodFailed(t *testing.T) {
+	tt := newBenchmarkTest(t)
+	tt.kubectl.EXPECT().GetControlPlaneNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(controlPlaneNodes("cp-1"), nil)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "eksa-etcd-disk-benchmark-cp-1", "5m", "kube-system").Return(errors.New("timed out"))
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+
+	_, err := tt.benchmark.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("running etcd disk benchmark in node cp-1: waiting for benchmark pod: timed out"))
+}
+
+func TestBenchmarkRunDeleteFailed(t *testing.T) {
+	tt := newBenchmarkTest(t)
+	logs := string(test.ReadFileAsBytes(t, "testdata/fio-output.txt"))
+	tt.kubectl.EXPECT().GetControlPlaneNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(controlPlaneNodes("cp-1"), nil)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+	tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "eksa-etcd-disk-benchmark-cp-1", "5m", "kube-system").Return(nil)
+	tt.kubectl.EXPECT().GetPodLogs(tt.ctx, "kube-system", "eksa-etcd-disk-benchmark-cp-1", "fio", tt.cluster.KubeconfigFile).Return(logs, nil)
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("forbidden"))
+
+	_, err := tt.benchmark.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("running etcd disk benchmark in node cp-1: deleting benchmark pod: forbidden"))
+}
+
+func TestBenchmarkRunNodesError(t *testing.T) {
+	tt := newBenchmarkTest(t)
+	tt.kubectl.EXPECT().GetControlPlaneNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nil, errors.New("getting control plane nodes: connection refused"))
+
+	_, err := tt.benchmark.Run(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("getting control plane nodes: connection refused"))
+}
+
+func TestFdatasyncP99Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantErr string
+	}{
+		{
+			name:    "no json",
+			output:  "fio: command not found",
+			wantErr: "fio output doesn't include a json document",
+		},
+		{
+			name:    "invalid json",
+			output:  "{not json",
+			wantErr: "parsing fio output",
+		},
+		{
+			name:    "no jobs",
+			output:  `{"jobs": []}`,
+			wantErr: "fio output doesn't include any job",
+		},
+		{
+			name:    "no percentile",
+			output:  `{"jobs": [{"sync": {"lat_ns": {"percentile": {"50.000000": 1000}}}}]}`,
+			wantErr: "fio output doesn't include the fdatasync 99th percentile",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := etcdbenchmark.FdatasyncP99(tc.output)
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
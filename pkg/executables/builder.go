@@ -81,6 +81,16 @@ func (b *ExecutablesBuilder) BuildSSHExecutable() *SSH {
 	return NewSSH(b.executableBuilder.Build(sshPath))
 }
 
+// BuildSopsExecutable initializes a Sops executable and returns it.
+func (b *ExecutablesBuilder) BuildSopsExecutable() *Sops {
+	return NewSops(b.executableBuilder.Build(sopsPath))
+}
+
+// BuildIpmitoolExecutable initializes an Ipmitool executable and returns it.
+func (b *ExecutablesBuilder) BuildIpmitoolExecutable(writer filewriter.FileWriter) *Ipmitool {
+	return NewIpmitool(b.executableBuilder.Build(ipmitoolPath), writer)
+}
+
 // Init initializes the executable builder and returns a Closer
 // that needs to be called once the executables are not in used anymore
 // The closer will cleanup and free all internal resources.
@@ -50,6 +50,8 @@ func TestLocalExecutablesBuilderAllExecutables(t *testing.T) {
 	g.Expect(docker).NotTo(BeNil())
 	ssh := b.BuildSSHExecutable()
 	g.Expect(ssh).NotTo(BeNil())
+	ipmitool := b.BuildIpmitoolExecutable(writer)
+	g.Expect(ipmitool).NotTo(BeNil())
 
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(closer(ctx)).To(Succeed())
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
@@ -144,7 +145,12 @@ func (c *Clusterctl) writeInfrastructureBundle(clusterSpec *cluster.Spec, rootFo
 			return fmt.Errorf("can't load infrastructure bundle for manifest %s: %v", manifest.URI, err)
 		}
 
-		if err := os.WriteFile(filepath.Join(infraFolder, m.Filename), m.Content, 0o644); err != nil {
+		content, err := configureManagementControllers(m.Content, componentFolder(bundle.FolderName), clusterSpec.Cluster.Spec.ManagementControllers)
+		if err != nil {
+			return fmt.Errorf("configuring management controllers for manifest %s: %v", manifest.URI, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(infraFolder, m.Filename), content, 0o644); err != nil {
 			return fmt.Errorf("generating file for infrastructure bundle %s: %v", m.Filename, err)
 		}
 	}
@@ -152,6 +158,13 @@ func (c *Clusterctl) writeInfrastructureBundle(clusterSpec *cluster.Spec, rootFo
 	return nil
 }
 
+// componentFolder returns the component part of an overrides folder name, which has the
+// format component/version.
+func componentFolder(folderName string) string {
+	component, _, _ := strings.Cut(filepath.ToSlash(folderName), "/")
+	return component
+}
+
 // BackupManagement saves the CAPI resources of a cluster to the provided path. This will overwrite any existing contents
 // in the path if the backup succeeds. If `clusterName` is provided, it filters and backs up only the provided cluster.
 func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath, clusterName string) error {
@@ -258,46 +271,46 @@ func (c *Clusterctl) buildConfig(clusterSpec *cluster.Spec, clusterName string,
 
 	data := map[string]string{
 		"CertManagerInjectorRepository":                   imageRepository(versionsBundle.CertManager.Cainjector),
-		"CertManagerInjectorTag":                          versionsBundle.CertManager.Cainjector.Tag(),
+		"CertManagerInjectorTag":                          versionsBundle.CertManager.Cainjector.PinnedTag(),
 		"CertManagerControllerRepository":                 imageRepository(versionsBundle.CertManager.Controller),
-		"CertManagerControllerTag":                        versionsBundle.CertManager.Controller.Tag(),
+		"CertManagerControllerTag":                        versionsBundle.CertManager.Controller.PinnedTag(),
 		"CertManagerWebhookRepository":                    imageRepository(versionsBundle.CertManager.Webhook),
-		"CertManagerWebhookTag":                           versionsBundle.CertManager.Webhook.Tag(),
+		"CertManagerWebhookTag":                           versionsBundle.CertManager.Webhook.PinnedTag(),
 		"CertManagerVersion":                              versionsBundle.CertManager.Version,
 		"ClusterApiControllerRepository":                  imageRepository(versionsBundle.ClusterAPI.Controller),
-		"ClusterApiControllerTag":                         versionsBundle.ClusterAPI.Controller.Tag(),
+		"ClusterApiControllerTag":                         versionsBundle.ClusterAPI.Controller.PinnedTag(),
 		"ClusterApiKubeRbacProxyRepository":               imageRepository(versionsBundle.ClusterAPI.KubeProxy),
-		"ClusterApiKubeRbacProxyTag":                      versionsBundle.ClusterAPI.KubeProxy.Tag(),
+		"ClusterApiKubeRbacProxyTag":                      versionsBundle.ClusterAPI.KubeProxy.PinnedTag(),
 		"KubeadmBootstrapControllerRepository":            imageRepository(versionsBundle.Bootstrap.Controller),
-		"KubeadmBootstrapControllerTag":                   versionsBundle.Bootstrap.Controller.Tag(),
+		"KubeadmBootstrapControllerTag":                   versionsBundle.Bootstrap.Controller.PinnedTag(),
 		"KubeadmBootstrapKubeRbacProxyRepository":         imageRepository(versionsBundle.Bootstrap.KubeProxy),
-		"KubeadmBootstrapKubeRbacProxyTag":                versionsBundle.Bootstrap.KubeProxy.Tag(),
+		"KubeadmBootstrapKubeRbacProxyTag":                versionsBundle.Bootstrap.KubeProxy.PinnedTag(),
 		"KubeadmControlPlaneControllerRepository":         imageRepository(versionsBundle.ControlPlane.Controller),
-		"KubeadmControlPlaneControllerTag":                versionsBundle.ControlPlane.Controller.Tag(),
+		"KubeadmControlPlaneControllerTag":                versionsBundle.ControlPlane.Controller.PinnedTag(),
 		"KubeadmControlPlaneKubeRbacProxyRepository":      imageRepository(versionsBundle.ControlPlane.KubeProxy),
-		"KubeadmControlPlaneKubeRbacProxyTag":             versionsBundle.ControlPlane.KubeProxy.Tag(),
+		"KubeadmControlPlaneKubeRbacProxyTag":             versionsBundle.ControlPlane.KubeProxy.PinnedTag(),
 		"ClusterApiVSphereControllerRepository":           imageRepository(versionsBundle.VSphere.ClusterAPIController),
-		"ClusterApiVSphereControllerTag":                  versionsBundle.VSphere.ClusterAPIController.Tag(),
+		"ClusterApiVSphereControllerTag":                  versionsBundle.VSphere.ClusterAPIController.PinnedTag(),
 		"ClusterApiNutanixControllerRepository":           imageRepository(versionsBundle.Nutanix.ClusterAPIController),
-		"ClusterApiNutanixControllerTag":                  versionsBundle.Nutanix.ClusterAPIController.Tag(),
+		"ClusterApiNutanixControllerTag":                  versionsBundle.Nutanix.ClusterAPIController.PinnedTag(),
 		"ClusterApiCloudStackManagerRepository":           imageRepository(versionsBundle.CloudStack.ClusterAPIController),
-		"ClusterApiCloudStackManagerTag":                  versionsBundle.CloudStack.ClusterAPIController.Tag(),
+		"ClusterApiCloudStackManagerTag":                  versionsBundle.CloudStack.ClusterAPIController.PinnedTag(),
 		"ClusterApiCloudStackKubeRbacProxyRepository":     imageRepository(versionsBundle.CloudStack.KubeRbacProxy),
-		"ClusterApiCloudStackKubeRbacProxyTag":            versionsBundle.CloudStack.KubeRbacProxy.Tag(),
+		"ClusterApiCloudStackKubeRbacProxyTag":            versionsBundle.CloudStack.KubeRbacProxy.PinnedTag(),
 		"ClusterApiVSphereKubeRbacProxyRepository":        imageRepository(versionsBundle.VSphere.KubeProxy),
-		"ClusterApiVSphereKubeRbacProxyTag":               versionsBundle.VSphere.KubeProxy.Tag(),
+		"ClusterApiVSphereKubeRbacProxyTag":               versionsBundle.VSphere.KubeProxy.PinnedTag(),
 		"DockerKubeRbacProxyRepository":                   imageRepository(versionsBundle.Docker.KubeProxy),
-		"DockerKubeRbacProxyTag":                          versionsBundle.Docker.KubeProxy.Tag(),
+		"DockerKubeRbacProxyTag":                          versionsBundle.Docker.KubeProxy.PinnedTag(),
 		"DockerManagerRepository":                         imageRepository(versionsBundle.Docker.Manager),
-		"DockerManagerTag":                                versionsBundle.Docker.Manager.Tag(),
+		"DockerManagerTag":                                versionsBundle.Docker.Manager.PinnedTag(),
 		"EtcdadmBootstrapProviderRepository":              imageRepository(versionsBundle.ExternalEtcdBootstrap.Controller),
-		"EtcdadmBootstrapProviderTag":                     versionsBundle.ExternalEtcdBootstrap.Controller.Tag(),
+		"EtcdadmBootstrapProviderTag":                     versionsBundle.ExternalEtcdBootstrap.Controller.PinnedTag(),
 		"EtcdadmBootstrapProviderKubeRbacProxyRepository": imageRepository(versionsBundle.ExternalEtcdBootstrap.KubeProxy),
-		"EtcdadmBootstrapProviderKubeRbacProxyTag":        versionsBundle.ExternalEtcdBootstrap.KubeProxy.Tag(),
+		"EtcdadmBootstrapProviderKubeRbacProxyTag":        versionsBundle.ExternalEtcdBootstrap.KubeProxy.PinnedTag(),
 		"EtcdadmControllerRepository":                     imageRepository(versionsBundle.ExternalEtcdController.Controller),
-		"EtcdadmControllerTag":                            versionsBundle.ExternalEtcdController.Controller.Tag(),
+		"EtcdadmControllerTag":                            versionsBundle.ExternalEtcdController.Controller.PinnedTag(),
 		"EtcdadmControllerKubeRbacProxyRepository":        imageRepository(versionsBundle.ExternalEtcdController.KubeProxy),
-		"EtcdadmControllerKubeRbacProxyTag":               versionsBundle.ExternalEtcdController.KubeProxy.Tag(),
+		"EtcdadmControllerKubeRbacProxyTag":               versionsBundle.ExternalEtcdController.KubeProxy.PinnedTag(),
 		"DockerProviderVersion":                           versionsBundle.Docker.Version,
 		"VSphereProviderVersion":                          versionsBundle.VSphere.Version,
 		"CloudStackProviderVersion":                       versionsBundle.CloudStack.Version,
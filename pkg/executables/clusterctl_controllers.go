@@ -0,0 +1,147 @@
+package executables
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	yamlutil "github.com/aws/eks-anywhere/pkg/utils/yaml"
+)
+
+const (
+	managerContainerName = "manager"
+	kubeAPIQPSFlag       = "--kube-api-qps"
+	kubeAPIBurstFlag     = "--kube-api-burst"
+)
+
+// controllerConcurrencyFlags are the flags that set the concurrency of each reconciler in the
+// managers of the components that support ManagementControllersConfiguration, indexed by
+// the component overrides folder.
+var controllerConcurrencyFlags = map[string][]string{
+	"cluster-api": {
+		"--cluster-concurrency",
+		"--machine-concurrency",
+		"--machineset-concurrency",
+		"--machinedeployment-concurrency",
+		"--machinehealthcheck-concurrency",
+	},
+	"bootstrap-kubeadm":     {"--kubeadmconfig-concurrency"},
+	"control-plane-kubeadm": {"--kubeadmcontrolplane-concurrency"},
+}
+
+// machineConcurrencyFlags are the flags that set how many machines the managers of the
+// infrastructure providers reconcile in parallel, indexed by the component overrides folder.
+// Creating a machine is the slowest step of those reconcilers (cloning a VM, waiting for the
+// provider API), so this is what limits the machines created in parallel.
+var machineConcurrencyFlags = map[string][]string{
+	"infrastructure-vsphere":    {"--max-concurrent-reconciles"},
+	"infrastructure-nutanix":    {"--max-concurrent-reconciles"},
+	"infrastructure-cloudstack": {"--cloudstackmachine-concurrency"},
+	"infrastructure-docker":     {"--concurrency"},
+}
+
+// configureManagementControllers sets the rate limit and concurrency flags in the manager
+// container of the Deployments of a component manifest. Components that don't support
+// ManagementControllersConfiguration are returned unchanged.
+func configureManagementControllers(content []byte, component string, config *anywherev1.ManagementControllersConfiguration) ([]byte, error) {
+	if config == nil {
+		return content, nil
+	}
+
+	flags := map[string]int{}
+	if concurrencyFlags, ok := controllerConcurrencyFlags[component]; ok {
+		if config.KubeAPIQPS > 0 {
+			flags[kubeAPIQPSFlag] = config.KubeAPIQPS
+		}
+		if config.KubeAPIBurst > 0 {
+			flags[kubeAPIBurstFlag] = config.KubeAPIBurst
+		}
+		if config.Concurrency > 0 {
+			for _, f := range concurrencyFlags {
+				flags[f] = config.Concurrency
+			}
+		}
+	}
+	if machineFlags, ok := machineConcurrencyFlags[component]; ok && config.MachineConcurrency > 0 {
+		for _, f := range machineFlags {
+			flags[f] = config.MachineConcurrency
+		}
+	}
+	if len(flags) == 0 {
+		return content, nil
+	}
+
+	docs, err := yamlutil.SplitDocuments(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("splitting %s components manifest: %v", component, err)
+	}
+
+	for i, doc := range docs {
+		if !isDeployment(doc) {
+			continue
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := yaml.Unmarshal(doc, deployment); err != nil {
+			return nil, fmt.Errorf("parsing %s deployment: %v", component, err)
+		}
+
+		for j := range deployment.Spec.Template.Spec.Containers {
+			c := &deployment.Spec.Template.Spec.Containers[j]
+			if c.Name == managerContainerName {
+				c.Args = setFlags(c.Args, flags)
+			}
+		}
+
+		if docs[i], err = yaml.Marshal(deployment); err != nil {
+			return nil, fmt.Errorf("marshalling %s deployment: %v", component, err)
+		}
+	}
+
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+func isDeployment(doc []byte) bool {
+	meta := struct {
+		Kind string `json:"kind"`
+	}{}
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		return false
+	}
+	return meta.Kind == "Deployment"
+}
+
+// setFlags sets the value of the flags in args, replacing the existing values if already present.
+func setFlags(args []string, flags map[string]int) []string {
+	set := map[string]bool{}
+	for i, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if v, ok := flags[name]; ok {
+			args[i] = name + "=" + strconv.Itoa(v)
+			set[name] = true
+		}
+	}
+
+	for _, name := range sortedFlagNames(flags) {
+		if !set[name] {
+			args = append(args, name+"="+strconv.Itoa(flags[name]))
+		}
+	}
+
+	return args
+}
+
+func sortedFlagNames(flags map[string]int) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,163 @@
+package executables_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+const capiComponents = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: capi-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: capi-controller-manager
+  namespace: capi-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        args:
+        - --leader-elect
+        - --feature-gates=MachinePool=${EXP_MACHINE_POOL:=false}
+        - --kube-api-qps=10
+      - name: kube-rbac-proxy
+        args:
+        - --secure-listen-address=0.0.0.0:8443
+`
+
+func managerArgs(g *WithT, content []byte) (manager, proxy []string) {
+	docs := strings.Split(string(content), "---\n")
+	g.Expect(docs).To(HaveLen(2))
+	g.Expect(docs[0]).To(ContainSubstring("kind: Namespace"))
+
+	d := &appsv1.Deployment{}
+	g.Expect(yaml.Unmarshal([]byte(docs[1]), d)).To(Succeed())
+	return d.Spec.Template.Spec.Containers[0].Args, d.Spec.Template.Spec.Containers[1].Args
+}
+
+func TestConfigureManagementControllers(t *testing.T) {
+	g := NewWithT(t)
+	config := &anywherev1.ManagementControllersConfiguration{
+		KubeAPIQPS:   50,
+		KubeAPIBurst: 100,
+		Concurrency:  20,
+	}
+
+	got, err := executables.ConfigureManagementControllers([]byte(capiComponents), "cluster-api", config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manager, proxy := managerArgs(g, got)
+	g.Expect(manager).To(Equal([]string{
+		"--leader-elect",
+		"--feature-gates=MachinePool=${EXP_MACHINE_POOL:=false}",
+		"--kube-api-qps=50",
+		"--cluster-concurrency=20",
+		"--kube-api-burst=100",
+		"--machine-concurrency=20",
+		"--machinedeployment-concurrency=20",
+		"--machinehealthcheck-concurrency=20",
+		"--machineset-concurrency=20",
+	}))
+	g.Expect(proxy).To(Equal([]string{"--secure-listen-address=0.0.0.0:8443"}))
+}
+
+func TestConfigureManagementControllersOnlyRateLimits(t *testing.T) {
+	g := NewWithT(t)
+	config := &anywherev1.ManagementControllersConfiguration{KubeAPIBurst: 100}
+
+	got, err := executables.ConfigureManagementControllers([]byte(capiComponents), "control-plane-kubeadm", config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manager, _ := managerArgs(g, got)
+	g.Expect(manager).To(Equal([]string{
+		"--leader-elect",
+		"--feature-gates=MachinePool=${EXP_MACHINE_POOL:=false}",
+		"--kube-api-qps=10",
+		"--kube-api-burst=100",
+	}))
+}
+
+func TestConfigureManagementControllersMachineConcurrency(t *testing.T) {
+	tests := []struct {
+		component string
+		wantFlag  string
+	}{
+		{component: "infrastructure-vsphere", wantFlag: "--max-concurrent-reconciles=5"},
+		{component: "infrastructure-nutanix", wantFlag: "--max-concurrent-reconciles=5"},
+		{component: "infrastructure-cloudstack", wantFlag: "--cloudstackmachine-concurrency=5"},
+		{component: "infrastructure-docker", wantFlag: "--concurrency=5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &anywherev1.ManagementControllersConfiguration{
+				KubeAPIQPS:         50,
+				Concurrency:        20,
+				MachineConcurrency: 5,
+			}
+
+			got, err := executables.ConfigureManagementControllers([]byte(capiComponents), tt.component, config)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			manager, _ := managerArgs(g, got)
+			g.Expect(manager).To(Equal([]string{
+				"--leader-elect",
+				"--feature-gates=MachinePool=${EXP_MACHINE_POOL:=false}",
+				"--kube-api-qps=10",
+				tt.wantFlag,
+			}))
+		})
+	}
+}
+
+func TestConfigureManagementControllersUnchanged(t *testing.T) {
+	tests := []struct {
+		name      string
+		component string
+		config    *anywherev1.ManagementControllersConfiguration
+	}{
+		{
+			name:      "no config",
+			component: "cluster-api",
+		},
+		{
+			name:      "empty config",
+			component: "cluster-api",
+			config:    &anywherev1.ManagementControllersConfiguration{},
+		},
+		{
+			name:      "unsupported component",
+			component: "infrastructure-vsphere",
+			config:    &anywherev1.ManagementControllersConfiguration{Concurrency: 10},
+		},
+		{
+			name:      "machine concurrency in core component",
+			component: "cluster-api",
+			config:    &anywherev1.ManagementControllersConfiguration{MachineConcurrency: 5},
+		},
+		{
+			name:      "machine concurrency in unsupported provider",
+			component: "infrastructure-tinkerbell",
+			config:    &anywherev1.ManagementControllersConfiguration{MachineConcurrency: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := executables.ConfigureManagementControllers([]byte(capiComponents), tt.component, tt.config)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal(capiComponents))
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
@@ -34,6 +35,7 @@ type Cmk struct {
 	writer     filewriter.FileWriter
 	executable Executable
 	configMap  map[string]decoder.CloudStackProfileConfig
+	retrier    *retrier.Retrier
 }
 
 type listTemplatesResponse struct {
@@ -384,6 +386,8 @@ func NewCmk(executable Executable, writer filewriter.FileWriter, config *decoder
 		writer:     writer,
 		executable: executable,
 		configMap:  configMap,
+		// cmk calls are not retried, but they back off together when CloudStack is failing.
+		retrier: retrier.NewAPIRetrier(1, 0, retrier.NewAPICircuitBreaker()),
 	}, nil
 }
 
@@ -446,7 +450,11 @@ func (c *Cmk) exec(ctx context.Context, profile string, args ...string) (stdout
 	}
 
 	argsWithConfigFile := append([]string{"-c", configFile}, args...)
-	return c.executable.Execute(ctx, argsWithConfigFile...)
+	err = c.retrier.Retry(func() error {
+		stdout, err = c.executable.Execute(ctx, argsWithConfigFile...)
+		return err
+	})
+	return stdout, err
 }
 
 func (c *Cmk) buildCmkConfigFile(profile string) (configFile string, err error) {
@@ -1,10 +1,13 @@
 package executables
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -16,8 +19,29 @@ const (
 	defaultRegistry   = "public.ecr.aws"
 	packageProdDomain = "783794618700.dkr.ecr.us-west-2.amazonaws.com"
 	packageDevDomain  = "857151390494.dkr.ecr.us-west-2.amazonaws.com"
+
+	// dockerCreatedAtLayout is the layout of the creation time of the containers listed by docker ps.
+	dockerCreatedAtLayout = "2006-01-02 15:04:05 -0700 MST"
 )
 
+// ContainerInfo is a container listed by docker ps.
+type ContainerInfo struct {
+	ID        string
+	Name      string
+	State     string
+	CreatedAt time.Time
+	Labels    map[string]string
+}
+
+// dockerPsEntry is a line of the docker ps json output.
+type dockerPsEntry struct {
+	ID        string `json:"ID"`
+	Names     string `json:"Names"`
+	State     string `json:"State"`
+	CreatedAt string `json:"CreatedAt"`
+	Labels    string `json:"Labels"`
+}
+
 type Docker struct {
 	Executable
 }
@@ -137,6 +161,72 @@ func (d *Docker) ForceRemove(ctx context.Context, name string) error {
 	return nil
 }
 
+// RemoveContainers force removes the containers with names, including their anonymous volumes.
+func (d *Docker) RemoveContainers(ctx context.Context, names ...string) error {
+	params := append([]string{"rm", "-f", "-v"}, names...)
+
+	if _, err := d.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("removing docker containers %s: %v", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+// ListContainers lists all the containers, running or not, that match the docker ps filters.
+func (d *Docker) ListContainers(ctx context.Context, filters ...string) ([]ContainerInfo, error) {
+	params := []string{"ps", "-a", "--no-trunc", "--format", "{{json .}}"}
+	for _, f := range filters {
+		params = append(params, "--filter", f)
+	}
+
+	stdOut, err := d.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("listing docker containers: %v", err)
+	}
+
+	var containers []ContainerInfo
+	scanner := bufio.NewScanner(&stdOut)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		entry := dockerPsEntry{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("parsing docker ps output: %v", err)
+		}
+		createdAt, err := time.Parse(dockerCreatedAtLayout, entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing creation time of docker container %s: %v", entry.Names, err)
+		}
+
+		containers = append(containers, ContainerInfo{
+			ID:        entry.ID,
+			Name:      entry.Names,
+			State:     entry.State,
+			CreatedAt: createdAt,
+			Labels:    parseDockerLabels(entry.Labels),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading docker ps output: %v", err)
+	}
+
+	return containers, nil
+}
+
+func parseDockerLabels(labels string) map[string]string {
+	parsed := map[string]string{}
+	for _, label := range strings.Split(labels, ",") {
+		if label == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(label, "=")
+		parsed[key] = value
+	}
+	return parsed
+}
+
 // CheckContainerExistence checks whether a Docker container with the provided name exists
 // It returns true if a container with the name exists, false if it doesn't and an error if it encounters some other error.
 func (d *Docker) CheckContainerExistence(ctx context.Context, name string) (bool, error) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -281,3 +282,74 @@ func TestDockerCheckContainerExistenceOtherError(t *testing.T) {
 	assert.False(t, exists)
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
+
+func TestDockerRemoveContainersSuccess(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "rm", "-f", "-v", "a", "b")
+
+	if err := d.RemoveContainers(ctx, "a", "b"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDockerRemoveContainersFailure(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "rm", "-f", "-v", "a", "b").Return(bytes.Buffer{}, errors.New("no such container"))
+
+	err := d.RemoveContainers(ctx, "a", "b")
+	assert.EqualError(t, err, "removing docker containers a, b: no such container")
+}
+
+func TestDockerListContainers(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	output := `{"CreatedAt":"2023-09-01 10:00:00 +0000 UTC","ID":"1234","Labels":"io.x-k8s.kind.cluster=mgmt-eks-a-cluster,io.x-k8s.kind.role=control-plane","Names":"mgmt-eks-a-cluster-control-plane","State":"running"}
+
+{"CreatedAt":"2023-09-02 08:30:00 +0200 CEST","ID":"5678","Labels":"","Names":"eksa_1693641000000000000","State":"exited"}
+`
+	executable.EXPECT().Execute(ctx, "ps", "-a", "--no-trunc", "--format", "{{json .}}", "--filter", "label=io.x-k8s.kind.cluster").Return(*bytes.NewBufferString(output), nil)
+
+	containers, err := d.ListContainers(ctx, "label=io.x-k8s.kind.cluster")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(containers).To(HaveLen(2))
+	g.Expect(containers[0].Name).To(Equal("mgmt-eks-a-cluster-control-plane"))
+	g.Expect(containers[0].ID).To(Equal("1234"))
+	g.Expect(containers[0].State).To(Equal("running"))
+	g.Expect(containers[0].Labels).To(Equal(map[string]string{
+		"io.x-k8s.kind.cluster": "mgmt-eks-a-cluster",
+		"io.x-k8s.kind.role":    "control-plane",
+	}))
+	g.Expect(containers[0].CreatedAt.Equal(time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC))).To(BeTrue())
+	g.Expect(containers[1].Name).To(Equal("eksa_1693641000000000000"))
+	g.Expect(containers[1].Labels).To(BeEmpty())
+	g.Expect(containers[1].CreatedAt.UTC()).To(Equal(time.Date(2023, 9, 2, 6, 30, 0, 0, time.UTC)))
+}
+
+func TestDockerListContainersError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "ps", "-a", "--no-trunc", "--format", "{{json .}}").Return(*bytes.NewBufferString(`{"CreatedAt":"yesterday","Names":"a"}`), nil)
+
+	_, err := d.ListContainers(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing creation time of docker container a")))
+}
@@ -12,3 +12,5 @@ func KubectlWaitRetryPolicy(k *Kubectl, totalRetries int, err error) (retry bool
 func CallKubectlPrivateWait(k *Kubectl, ctx context.Context, kubeconfig string, timeoutTime time.Time, forCondition string, property string, namespace string) error {
 	return k.wait(ctx, kubeconfig, timeoutTime, forCondition, property, namespace)
 }
+
+var ConfigureManagementControllers = configureManagementControllers
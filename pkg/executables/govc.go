@@ -36,6 +36,7 @@ const (
 	govcTlsKnownHostsKey = "GOVC_TLS_KNOWN_HOSTS"
 	vSphereServerKey     = "VSPHERE_SERVER"
 	byteToGiB            = 1073741824.0
+	byteToMiB            = 1048576
 	DeployOptsFile       = "deploy-opts.json"
 	disk1                = "Hard disk 1"
 	disk2                = "Hard disk 2"
@@ -79,7 +80,7 @@ func NewGovc(executable Executable, writer filewriter.FileWriter, opts ...GovcOp
 	g := &Govc{
 		writer:       writer,
 		Executable:   executable,
-		Retrier:      retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:      retrier.NewAPIRetrier(maxRetries, backOffPeriod, retrier.NewAPICircuitBreaker()),
 		requiredEnvs: envVars,
 	}
 
@@ -343,6 +344,115 @@ func (g *Govc) GetWorkloadAvailableSpace(ctx context.Context, datastore string)
 	return 0, fmt.Errorf("getting datastore available space response: %v", err)
 }
 
+// ResourcePoolInfo holds the limits and current usage of a resource pool.
+type ResourcePoolInfo struct {
+	// MemoryLimitMiB is the memory limit of the pool, -1 if it's unlimited.
+	MemoryLimitMiB int64
+	// MemoryUsedMiB is the memory currently used by the VMs in the pool.
+	MemoryUsedMiB int64
+	// CPULimitMHz is the CPU limit of the pool, -1 if it's unlimited.
+	CPULimitMHz int64
+	// CPUUsedMHz is the CPU currently used by the VMs in the pool.
+	CPUUsedMHz int64
+}
+
+type resourceAllocation struct {
+	Limit int64 `json:"Limit"`
+}
+
+type resourceUsage struct {
+	OverallUsage int64 `json:"OverallUsage"`
+}
+
+type resourcePoolResponse struct {
+	ResourcePools []struct {
+		Config struct {
+			MemoryAllocation resourceAllocation `json:"MemoryAllocation"`
+			CpuAllocation    resourceAllocation `json:"CpuAllocation"`
+		} `json:"Config"`
+		Runtime struct {
+			Memory resourceUsage `json:"Memory"`
+			Cpu    resourceUsage `json:"Cpu"`
+		} `json:"Runtime"`
+	} `json:"ResourcePools"`
+}
+
+// GetResourcePoolInfo returns the memory and CPU limits and usage of a resource pool.
+func (g *Govc) GetResourcePoolInfo(ctx context.Context, datacenter, resourcePool string) (*ResourcePoolInfo, error) {
+	result, err := g.exec(ctx, "pool.info", "-json", "-dc", datacenter, resourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool %s info: %v", resourcePool, err)
+	}
+
+	response := &resourcePoolResponse{}
+	if err := json.Unmarshal(result.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("parsing resource pool %s info: %v", resourcePool, err)
+	}
+	if len(response.ResourcePools) == 0 {
+		return nil, fmt.Errorf("resource pool %s not found", resourcePool)
+	}
+
+	pool := response.ResourcePools[0]
+	return &ResourcePoolInfo{
+		// govc reports the memory limit in MiB but the usage in bytes.
+		MemoryLimitMiB: pool.Config.MemoryAllocation.Limit,
+		MemoryUsedMiB:  pool.Runtime.Memory.OverallUsage / byteToMiB,
+		CPULimitMHz:    pool.Config.CpuAllocation.Limit,
+		CPUUsedMHz:     pool.Runtime.Cpu.OverallUsage,
+	}, nil
+}
+
+// Host is an ESXi host in a vSphere compute resource.
+type Host struct {
+	Path              string
+	InMaintenanceMode bool
+}
+
+// ListHosts returns the hosts of a compute resource, like a cluster, and whether they are in
+// maintenance mode.
+func (g *Govc) ListHosts(ctx context.Context, computeResource string) ([]Host, error) {
+	paths, err := g.findHosts(ctx, computeResource)
+	if err != nil {
+		return nil, err
+	}
+	maintenance, err := g.findHosts(ctx, computeResource, "-runtime.inMaintenanceMode", "true")
+	if err != nil {
+		return nil, err
+	}
+
+	inMaintenance := make(map[string]bool, len(maintenance))
+	for _, p := range maintenance {
+		inMaintenance[p] = true
+	}
+
+	hosts := make([]Host, 0, len(paths))
+	for _, p := range paths {
+		hosts = append(hosts, Host{Path: p, InMaintenanceMode: inMaintenance[p]})
+	}
+
+	return hosts, nil
+}
+
+func (g *Govc) findHosts(ctx context.Context, computeResource string, filters ...string) ([]string, error) {
+	params := append([]string{"find", "-json", computeResource, "-type", "h"}, filters...)
+	result, err := g.exec(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("listing hosts in %s: %v", computeResource, err)
+	}
+
+	response := strings.TrimSpace(result.String())
+	if response == "" || response == "null" {
+		return nil, nil
+	}
+
+	paths := []string{}
+	if err := json.Unmarshal([]byte(response), &paths); err != nil {
+		return nil, fmt.Errorf("parsing hosts in %s: %v", computeResource, err)
+	}
+
+	return paths, nil
+}
+
 func (g *Govc) CreateLibrary(ctx context.Context, datastore, library string) error {
 	if _, err := g.exec(ctx, "library.create", "-ds", datastore, library); err != nil {
 		return fmt.Errorf("creating library %s: %v", library, err)
@@ -693,9 +803,20 @@ func (g *Govc) GetCertThumbprint(ctx context.Context) (string, error) {
 }
 
 func (g *Govc) ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error {
-	path, err := g.writer.Write(filepath.Base(govcTlsHostsFile), []byte(fmt.Sprintf("%s %s", server, thumbprint)))
+	hostsFile := govcTlsHostsFile
+	if g.envMap != nil {
+		// Clients with their own env can run concurrently against different servers, so they don't share the file.
+		hostsFile = fmt.Sprintf("%s-%s", govcTlsHostsFile, server)
+	}
+
+	path, err := g.writer.Write(filepath.Base(hostsFile), []byte(fmt.Sprintf("%s %s", server, thumbprint)))
 	if err != nil {
-		return fmt.Errorf("writing to file %s: %v", govcTlsHostsFile, err)
+		return fmt.Errorf("writing to file %s: %v", hostsFile, err)
+	}
+
+	if g.envMap != nil {
+		g.envMap[govcTlsKnownHostsKey] = path
+		return nil
 	}
 
 	if err = os.Setenv(govcTlsKnownHostsKey, path); err != nil {
@@ -754,6 +875,34 @@ func (g *Govc) NetworkExists(ctx context.Context, network string) (bool, error)
 	return exists, nil
 }
 
+// ListNetworks returns the inventory paths of the networks in datacenter.
+func (g *Govc) ListNetworks(ctx context.Context, datacenter string) ([]string, error) {
+	if !strings.HasPrefix(datacenter, "/") {
+		datacenter = "/" + datacenter
+	}
+
+	var networks []string
+	err := g.Retry(func() error {
+		response, err := g.exec(ctx, "find", datacenter, "-type", "n")
+		if err != nil {
+			return err
+		}
+
+		networks = nil
+		for _, line := range strings.Split(response.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				networks = append(networks, line)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing networks in datacenter '%s': %v", datacenter, err)
+	}
+
+	return networks, nil
+}
+
 func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
@@ -953,6 +1102,69 @@ func (g *Govc) CreateTag(ctx context.Context, tag, category string) error {
 	return nil
 }
 
+const (
+	vmPoweredOff = "poweredOff"
+	vmPoweredOn  = "poweredOn"
+)
+
+type vmInfo struct {
+	VirtualMachines []struct {
+		Runtime struct {
+			PowerState string
+		}
+	}
+}
+
+// VMPowerState returns the power state of a VM: poweredOff, poweredOn or suspended.
+func (g *Govc) VMPowerState(ctx context.Context, datacenter, vm string) (string, error) {
+	response, err := g.exec(ctx, "vm.info", "-dc", datacenter, "-json", vm)
+	if err != nil {
+		return "", fmt.Errorf("govc returned error when getting info of vm %s: %v", vm, err)
+	}
+
+	info := &vmInfo{}
+	if err = json.Unmarshal(response.Bytes(), info); err != nil {
+		return "", fmt.Errorf("unmarshalling info of vm %s: %v", vm, err)
+	}
+	if len(info.VirtualMachines) == 0 {
+		return "", fmt.Errorf("vm %s not found", vm)
+	}
+
+	return info.VirtualMachines[0].Runtime.PowerState, nil
+}
+
+// PowerOffVM shuts down the guest OS of a VM. It's a no-op if the VM is already powered off.
+func (g *Govc) PowerOffVM(ctx context.Context, datacenter, vm string) error {
+	state, err := g.VMPowerState(ctx, datacenter, vm)
+	if err != nil {
+		return err
+	}
+	if state == vmPoweredOff {
+		return nil
+	}
+
+	if _, err := g.exec(ctx, "vm.power", "-dc", datacenter, "-s", vm); err != nil {
+		return fmt.Errorf("govc returned error when powering off vm %s: %v", vm, err)
+	}
+	return nil
+}
+
+// PowerOnVM powers on a VM. It's a no-op if the VM is already powered on.
+func (g *Govc) PowerOnVM(ctx context.Context, datacenter, vm string) error {
+	state, err := g.VMPowerState(ctx, datacenter, vm)
+	if err != nil {
+		return err
+	}
+	if state == vmPoweredOn {
+		return nil
+	}
+
+	if _, err := g.exec(ctx, "vm.power", "-dc", datacenter, "-on", vm); err != nil {
+		return fmt.Errorf("govc returned error when powering on vm %s: %v", vm, err)
+	}
+	return nil
+}
+
 type category struct {
 	Id              string
 	Name            string
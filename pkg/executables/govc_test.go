@@ -779,6 +779,82 @@ func TestAddTagError(t *testing.T) {
 	}
 }
 
+func vmInfoResponse(powerState string) bytes.Buffer {
+	return *bytes.NewBufferString(`{"virtualMachines":[{"runtime":{"powerState":"` + powerState + `"}}]}`)
+}
+
+func TestGovcVMPowerState(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(vmInfoResponse("suspended"), nil)
+
+	state, err := g.VMPowerState(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde")
+	if err != nil {
+		t.Fatalf("Govc.VMPowerState() err = %v, want err nil", err)
+	}
+	if state != "suspended" {
+		t.Fatalf("Govc.VMPowerState() = %s, want suspended", state)
+	}
+}
+
+func TestGovcVMPowerStateNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(*bytes.NewBufferString(`{"virtualMachines":null}`), nil)
+
+	if _, err := g.VMPowerState(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde"); err == nil {
+		t.Fatal("Govc.VMPowerState() err = nil, want err not nil")
+	}
+}
+
+func TestGovcPowerOffVM(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(vmInfoResponse("poweredOn"), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-dc", "SDDC-Datacenter", "-s", "my-cluster-md-0-abcde").Return(*bytes.NewBufferString(""), nil)
+
+	if err := g.PowerOffVM(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde"); err != nil {
+		t.Fatalf("Govc.PowerOffVM() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcPowerOffVMAlreadyPoweredOff(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(vmInfoResponse("poweredOff"), nil)
+
+	if err := g.PowerOffVM(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde"); err != nil {
+		t.Fatalf("Govc.PowerOffVM() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcPowerOnVMAlreadyPoweredOn(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(vmInfoResponse("poweredOn"), nil)
+
+	if err := g.PowerOnVM(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde"); err != nil {
+		t.Fatalf("Govc.PowerOnVM() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcPowerOnVMError(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", "SDDC-Datacenter", "-json", "my-cluster-md-0-abcde").Return(vmInfoResponse("poweredOff"), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-dc", "SDDC-Datacenter", "-on", "my-cluster-md-0-abcde").Return(bytes.Buffer{}, errors.New("error from execute with env"))
+
+	if err := g.PowerOnVM(ctx, "SDDC-Datacenter", "my-cluster-md-0-abcde"); err == nil {
+		t.Fatal("Govc.PowerOnVM() err = nil, want err not nil")
+	}
+}
+
 func TestCreateTagSuccess(t *testing.T) {
 	category := "category"
 	tag := "tag"
@@ -1110,6 +1186,45 @@ func TestGovcConfigureCertThumbprint(t *testing.T) {
 	}
 }
 
+func TestGovcConfigureCertThumbprintWithEnvMap(t *testing.T) {
+	ctx := context.Background()
+	envMap := map[string]string{
+		"GOVC_USERNAME":   "team-user",
+		"GOVC_PASSWORD":   "team-pass",
+		"GOVC_URL":        "server.com",
+		"GOVC_INSECURE":   "false",
+		"GOVC_DATACENTER": "datacenter",
+	}
+	_, g, executable, _ := setup(t, executables.WithGovcEnvMap(envMap))
+	t.Setenv("GOVC_TLS_KNOWN_HOSTS", "")
+	server := "server.com"
+	thumbprint := "AB:AB:AB"
+	wantKnownHostsContent := "server.com AB:AB:AB"
+
+	if err := g.ConfigureCertThumbprint(ctx, server, thumbprint); err != nil {
+		t.Fatalf("Govc.ConfigureCertThumbprint() err = %v, want err nil", err)
+	}
+
+	if path := os.Getenv("GOVC_TLS_KNOWN_HOSTS"); path != "" {
+		t.Fatalf("GOVC_TLS_KNOWN_HOSTS = %s, want it not to be set in the process env", path)
+	}
+
+	path, ok := envMap["GOVC_TLS_KNOWN_HOSTS"]
+	if !ok {
+		t.Fatal("GOVC_TLS_KNOWN_HOSTS is not set in the govc env")
+	}
+
+	gotKnownHostsContent := test.ReadFile(t, path)
+	if gotKnownHostsContent != wantKnownHostsContent {
+		t.Fatalf("GOVC_TLS_KNOWN_HOSTS file content = %s, want %s", gotKnownHostsContent, wantKnownHostsContent)
+	}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, envMap, "datacenter.info", "datacenter").Return(*bytes.NewBufferString(""), nil)
+	if _, err := g.DatacenterExists(ctx, "datacenter"); err != nil {
+		t.Fatalf("Govc.DatacenterExists() err = %v, want err nil", err)
+	}
+}
+
 func TestGovcDatacenterExistsTrue(t *testing.T) {
 	ctx := context.Background()
 	_, g, executable, env := setup(t)
@@ -1144,6 +1259,37 @@ func TestGovcDatacenterExistsFalse(t *testing.T) {
 	}
 }
 
+func TestGovcListNetworks(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter", "-type", "n").Return(
+		*bytes.NewBufferString("/SDDC-Datacenter/network/VM Network\n/SDDC-Datacenter/network/sddc-cgw-network-1\n"), nil,
+	)
+
+	networks, err := g.ListNetworks(ctx, "SDDC-Datacenter")
+	if err != nil {
+		t.Fatalf("Govc.ListNetworks() err = %v, want err nil", err)
+	}
+
+	want := []string{"/SDDC-Datacenter/network/VM Network", "/SDDC-Datacenter/network/sddc-cgw-network-1"}
+	if !reflect.DeepEqual(networks, want) {
+		t.Fatalf("Govc.ListNetworks() = %v, want %v", networks, want)
+	}
+}
+
+func TestGovcListNetworksError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	g.Retrier = retrier.NewWithMaxRetries(5, 0)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter", "-type", "n").Return(bytes.Buffer{}, errors.New("find failed")).Times(5)
+
+	if _, err := g.ListNetworks(ctx, "/SDDC-Datacenter"); err == nil {
+		t.Fatal("Govc.ListNetworks() err = nil, want err not nil")
+	}
+}
+
 func TestGovcNetworkExistsTrue(t *testing.T) {
 	ctx := context.Background()
 	_, g, executable, env := setup(t)
@@ -1643,3 +1789,60 @@ func TestGovcGetHardDiskSizeError(t *testing.T) {
 		})
 	}
 }
+
+func TestGovcGetResourcePoolInfo(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	response := `{"ResourcePools":[{"Config":{"MemoryAllocation":{"Limit":8192},"CpuAllocation":{"Limit":-1}},"Runtime":{"Memory":{"OverallUsage":2147483648},"Cpu":{"OverallUsage":1200}}}]}`
+
+	_, gv, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "pool.info", "-json", "-dc", "SDDC-Datacenter", "/SDDC-Datacenter/host/Cluster-1/Resources").Return(*bytes.NewBufferString(response), nil)
+
+	g.Expect(gv.GetResourcePoolInfo(ctx, "SDDC-Datacenter", "/SDDC-Datacenter/host/Cluster-1/Resources")).To(Equal(&executables.ResourcePoolInfo{
+		MemoryLimitMiB: 8192,
+		MemoryUsedMiB:  2048,
+		CPULimitMHz:    -1,
+		CPUUsedMHz:     1200,
+	}))
+}
+
+func TestGovcGetResourcePoolInfoNotFound(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+
+	_, gv, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "pool.info", "-json", "-dc", "SDDC-Datacenter", "pool").Return(*bytes.NewBufferString(`{"ResourcePools":[]}`), nil)
+
+	_, err := gv.GetResourcePoolInfo(ctx, "SDDC-Datacenter", "pool")
+	g.Expect(err).To(MatchError("resource pool pool not found"))
+}
+
+func TestGovcListHosts(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	computeResource := "/SDDC-Datacenter/host/Cluster-1"
+
+	_, gv, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", computeResource, "-type", "h").Return(
+		*bytes.NewBufferString(`["/SDDC-Datacenter/host/Cluster-1/esxi-1","/SDDC-Datacenter/host/Cluster-1/esxi-2"]`), nil,
+	)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", computeResource, "-type", "h", "-runtime.inMaintenanceMode", "true").Return(
+		*bytes.NewBufferString(`["/SDDC-Datacenter/host/Cluster-1/esxi-2"]`), nil,
+	)
+
+	g.Expect(gv.ListHosts(ctx, computeResource)).To(Equal([]executables.Host{
+		{Path: "/SDDC-Datacenter/host/Cluster-1/esxi-1"},
+		{Path: "/SDDC-Datacenter/host/Cluster-1/esxi-2", InMaintenanceMode: true},
+	}))
+}
+
+func TestGovcListHostsError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+
+	_, gv, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "cluster", "-type", "h").Return(bytes.Buffer{}, errors.New("not authorized"))
+
+	_, err := gv.ListHosts(ctx, "cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("listing hosts in cluster")))
+}
@@ -3,8 +3,17 @@ package executables
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/yaml"
 
@@ -15,6 +24,8 @@ import (
 const (
 	helmPath               = "helm"
 	insecureSkipVerifyFlag = "--insecure-skip-tls-verify"
+	postRendererFlag       = "--post-renderer"
+	postRendererArgsFlag   = "--post-renderer-args"
 )
 
 type Helm struct {
@@ -22,6 +33,12 @@ type Helm struct {
 	registryMirror *registrymirror.RegistryMirror
 	env            map[string]string
 	insecure       bool
+	// templateCacheDir is the folder where rendered templates are cached. Caching is disabled if empty.
+	templateCacheDir string
+	tagLister        ChartTagLister
+	// postRenderer is the executable the rendered manifests are passed through. Disabled if empty.
+	postRenderer     string
+	postRendererArgs []string
 }
 
 type HelmOpt func(*Helm)
@@ -41,6 +58,23 @@ func WithInsecure() HelmOpt {
 	}
 }
 
+// WithTemplateCache enables caching the output of helm template in dir. Templates are cached by
+// chart, version, namespace, kube version and values, so retries don't need to pull the chart again.
+func WithTemplateCache(dir string) HelmOpt {
+	return func(h *Helm) {
+		h.templateCacheDir = dir
+	}
+}
+
+// WithPostRenderer makes helm pass the rendered manifests of the charts it templates and installs
+// through the binaryPath executable, called with args.
+func WithPostRenderer(binaryPath string, args ...string) HelmOpt {
+	return func(h *Helm) {
+		h.postRenderer = binaryPath
+		h.postRendererArgs = args
+	}
+}
+
 // join the default and the provided maps together.
 func WithEnv(env map[string]string) HelmOpt {
 	return func(h *Helm) {
@@ -66,26 +100,89 @@ func NewHelm(executable Executable, opts ...HelmOpt) *Helm {
 	return h
 }
 
-func (h *Helm) Template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error) {
+// Template renders a chart. The opts only apply to this call, ex. to use the post-renderer
+// of a specific cluster.
+func (h *Helm) Template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string, opts ...HelmOpt) ([]byte, error) {
+	h = h.withOpts(opts...)
 	valuesYaml, err := yaml.Marshal(values)
 	if err != nil {
 		return nil, fmt.Errorf("failed marshalling values for helm template: %v", err)
 	}
 
-	params := []string{"template", h.url(ociURI), "--version", version, "--namespace", namespace, "--kube-version", kubeVersion}
+	params := append([]string{"template"}, chartArgs(h.url(ociURI))...)
+	params = append(params, "--version", version, "--namespace", namespace, "--kube-version", kubeVersion)
 	params = h.addInsecureFlagIfProvided(params)
+	params = h.addPostRendererFlagsIfProvided(params)
 	params = append(params, "-f", "-")
 
+	cachePath := h.templateCachePath(params, valuesYaml)
+	if cachePath != "" {
+		if content, err := os.ReadFile(cachePath); err == nil {
+			logger.V(6).Info("Using cached helm template", "chart", ociURI, "version", version, "path", cachePath)
+			return content, nil
+		}
+	}
+
 	result, err := h.executable.Command(ctx, params...).WithStdIn(valuesYaml).WithEnvVars(h.env).Run()
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Bytes(), nil
+	content := result.Bytes()
+	if cachePath != "" {
+		if err := writeTemplateCache(cachePath, content); err != nil {
+			logger.V(4).Info("Failed caching helm template", "path", cachePath, "error", err)
+		}
+	}
+
+	return content, nil
+}
+
+// templateCachePath returns the cache file for a helm template call, or an empty string if the
+// cache is disabled. The key is a digest of the helm args and the values, which uniquely identify
+// the rendered output.
+func (h *Helm) templateCachePath(params []string, values []byte) string {
+	if h.templateCacheDir == "" {
+		return ""
+	}
+
+	digest := sha256.New()
+	for _, p := range params {
+		digest.Write([]byte(p))
+		digest.Write([]byte{0})
+	}
+	digest.Write(values)
+
+	return filepath.Join(h.templateCacheDir, hex.EncodeToString(digest.Sum(nil))+".yaml")
+}
+
+// writeTemplateCache writes the content to a temporary file first and renames it, so a failed
+// write never leaves a partial template in the cache.
+func writeTemplateCache(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
 }
 
 func (h *Helm) PullChart(ctx context.Context, ociURI, version string) error {
-	params := []string{"pull", h.url(ociURI), "--version", version}
+	params := append([]string{"pull"}, chartArgs(h.url(ociURI))...)
+	params = append(params, "--version", version)
 	params = h.addInsecureFlagIfProvided(params)
 	_, err := h.executable.Command(ctx, params...).
 		WithEnvVars(h.env).Run()
@@ -94,7 +191,8 @@ func (h *Helm) PullChart(ctx context.Context, ociURI, version string) error {
 
 // ShowValues get the values of a chart.
 func (h *Helm) ShowValues(ctx context.Context, ociURI, version string) (bytes.Buffer, error) {
-	params := []string{"show", "values", h.url(ociURI), "--version", version}
+	params := append([]string{"show", "values"}, chartArgs(h.url(ociURI))...)
+	params = append(params, "--version", version)
 	out, err := h.executable.Command(ctx, params...).
 		WithEnvVars(h.env).Run()
 	return out, err
@@ -118,8 +216,40 @@ func (h *Helm) RegistryLogin(ctx context.Context, registry, username, password s
 	return err
 }
 
+// RepoAdd adds a classic HTTP(S) chart repository, so its charts can be referenced as
+// <name>/<chart>. username and password are only passed if set, the password through stdin.
+func (h *Helm) RepoAdd(ctx context.Context, name, repoURL, username, password string) error {
+	params := []string{"repo", "add", name, repoURL, "--force-update"}
+	if username != "" {
+		params = append(params, "--username", username, "--password-stdin")
+	}
+	params = h.addInsecureFlagIfProvided(params)
+
+	cmd := h.executable.Command(ctx, params...).WithEnvVars(h.env)
+	if username != "" {
+		cmd = cmd.WithStdIn([]byte(password))
+	}
+	if _, err := cmd.Run(); err != nil {
+		return fmt.Errorf("adding helm repo %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// RepoUpdate refreshes the chart indexes of the repositories added with RepoAdd, or only the
+// ones in names if set.
+func (h *Helm) RepoUpdate(ctx context.Context, names ...string) error {
+	params := append([]string{"repo", "update"}, names...)
+	if _, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run(); err != nil {
+		return fmt.Errorf("updating helm repos: %v", err)
+	}
+
+	return nil
+}
+
 func (h *Helm) SaveChart(ctx context.Context, ociURI, version, folder string) error {
-	params := []string{"pull", h.url(ociURI), "--version", version, "--destination", folder}
+	params := append([]string{"pull"}, chartArgs(h.url(ociURI))...)
+	params = append(params, "--version", version, "--destination", folder)
 	params = h.addInsecureFlagIfProvided(params)
 	_, err := h.executable.Command(ctx, params...).
 		WithEnvVars(h.env).Run()
@@ -131,8 +261,10 @@ func (h *Helm) InstallChartFromName(ctx context.Context, ociURI, kubeConfig, nam
 	// upgrades it otherwise, making this more idempotent than install, which
 	// would error out if the chart is already installed, and has no similar
 	// "--upgrade" flag.
-	params := []string{"upgrade", "--install", name, ociURI, "--version", version, "--kubeconfig", kubeConfig}
+	params := append([]string{"upgrade", "--install", name}, chartArgs(ociURI)...)
+	params = append(params, "--version", version, "--kubeconfig", kubeConfig)
 	params = h.addInsecureFlagIfProvided(params)
+	params = h.addPostRendererFlagsIfProvided(params)
 	_, err := h.executable.Command(ctx, params...).
 		WithEnvVars(h.env).Run()
 	return err
@@ -141,9 +273,11 @@ func (h *Helm) InstallChartFromName(ctx context.Context, ociURI, kubeConfig, nam
 // InstallChart installs a helm chart to the target cluster.
 //
 // If kubeconfigFilePath is the empty string, it won't be passed at all.
-func (h *Helm) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string) error {
+func (h *Helm) InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string, opts ...HelmOpt) error {
+	h = h.withOpts(opts...)
 	valueArgs := GetHelmValueArgs(values)
-	params := []string{"upgrade", "--install", chart, ociURI, "--version", version}
+	params := append([]string{"upgrade", "--install", chart}, chartArgs(ociURI)...)
+	params = append(params, "--version", version)
 	if skipCRDs {
 		params = append(params, "--skip-crds")
 	}
@@ -158,6 +292,7 @@ func (h *Helm) InstallChart(ctx context.Context, chart, ociURI, version, kubecon
 		params = append(params, "-f", valueFilePath)
 	}
 	params = h.addInsecureFlagIfProvided(params)
+	params = h.addPostRendererFlagsIfProvided(params)
 
 	logger.Info("Installing helm chart on cluster", "chart", chart, "version", version)
 	_, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
@@ -167,8 +302,10 @@ func (h *Helm) InstallChart(ctx context.Context, chart, ociURI, version, kubecon
 // InstallChartWithValuesFile installs a helm chart with the provided values file and waits for the chart deployment to be ready
 // The default timeout for the chart to reach ready state is 5m.
 func (h *Helm) InstallChartWithValuesFile(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, valuesFilePath string) error {
-	params := []string{"upgrade", "--install", chart, ociURI, "--version", version, "--values", valuesFilePath, "--kubeconfig", kubeconfigFilePath, "--wait"}
+	params := append([]string{"upgrade", "--install", chart}, chartArgs(ociURI)...)
+	params = append(params, "--version", version, "--values", valuesFilePath, "--kubeconfig", kubeconfigFilePath, "--wait")
 	params = h.addInsecureFlagIfProvided(params)
+	params = h.addPostRendererFlagsIfProvided(params)
 	_, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
 	return err
 }
@@ -192,6 +329,146 @@ func (h *Helm) Delete(ctx context.Context, kubeconfigFilePath, installName, name
 	return nil
 }
 
+// Test runs the test hooks of a release and returns the helm output, which includes the logs of
+// the test pods. The output is also returned when the tests fail, so the logs can be reported.
+//
+// If namespace is the empty string, it won't be passed at all.
+func (h *Helm) Test(ctx context.Context, kubeconfigFilePath, release, namespace string) (string, error) {
+	params := []string{"test", release, "--kubeconfig", kubeconfigFilePath, "--logs"}
+	if namespace != "" {
+		params = append(params, "--namespace", namespace)
+	}
+	params = h.addInsecureFlagIfProvided(params)
+
+	out, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
+	if err != nil {
+		return out.String(), fmt.Errorf("testing helm release %s: %v", release, err)
+	}
+
+	return out.String(), nil
+}
+
+// ErrHelmReleaseNotFound is returned when a helm release doesn't exist.
+var ErrHelmReleaseNotFound = errors.New("helm release not found")
+
+// HelmReleaseStatus is the status of a helm release revision.
+type HelmReleaseStatus string
+
+const (
+	HelmReleaseStatusUnknown         HelmReleaseStatus = "unknown"
+	HelmReleaseStatusDeployed        HelmReleaseStatus = "deployed"
+	HelmReleaseStatusUninstalled     HelmReleaseStatus = "uninstalled"
+	HelmReleaseStatusSuperseded      HelmReleaseStatus = "superseded"
+	HelmReleaseStatusFailed          HelmReleaseStatus = "failed"
+	HelmReleaseStatusUninstalling    HelmReleaseStatus = "uninstalling"
+	HelmReleaseStatusPendingInstall  HelmReleaseStatus = "pending-install"
+	HelmReleaseStatusPendingUpgrade  HelmReleaseStatus = "pending-upgrade"
+	HelmReleaseStatusPendingRollback HelmReleaseStatus = "pending-rollback"
+)
+
+// IsPending returns true if an install, upgrade or rollback of the release is in progress or was
+// interrupted. Helm refuses to upgrade a release in a pending status, so it's not safe to retry
+// the operation until it's rolled back.
+func (s HelmReleaseStatus) IsPending() bool {
+	switch s {
+	case HelmReleaseStatusPendingInstall, HelmReleaseStatusPendingUpgrade, HelmReleaseStatusPendingRollback:
+		return true
+	}
+	return false
+}
+
+// HelmRelease is the current revision of a helm release, as returned by helm status.
+type HelmRelease struct {
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Version   int             `json:"version"`
+	Info      HelmReleaseInfo `json:"info"`
+	Chart     HelmChart       `json:"chart"`
+}
+
+// HelmReleaseInfo is the deployment information of a helm release revision.
+type HelmReleaseInfo struct {
+	Status        HelmReleaseStatus `json:"status"`
+	Description   string            `json:"description"`
+	FirstDeployed time.Time         `json:"first_deployed"`
+	LastDeployed  time.Time         `json:"last_deployed"`
+	Notes         string            `json:"notes,omitempty"`
+}
+
+// HelmChart is the chart of a helm release revision.
+type HelmChart struct {
+	Metadata HelmChartMetadata `json:"metadata"`
+}
+
+// HelmChartMetadata is the metadata of a helm chart.
+type HelmChartMetadata struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion"`
+}
+
+// HelmReleaseRevision is a revision of a helm release, as returned by helm history.
+type HelmReleaseRevision struct {
+	Revision    int               `json:"revision"`
+	Updated     time.Time         `json:"updated"`
+	Status      HelmReleaseStatus `json:"status"`
+	Chart       string            `json:"chart"`
+	AppVersion  string            `json:"app_version"`
+	Description string            `json:"description"`
+}
+
+// Status returns the current revision of a helm release. If the release doesn't exist,
+// it returns an error wrapping ErrHelmReleaseNotFound.
+//
+// If namespace is the empty string, it won't be passed at all.
+func (h *Helm) Status(ctx context.Context, release, namespace, kubeconfigFilePath string) (*HelmRelease, error) {
+	out, err := h.releaseCommand(ctx, "status", release, namespace, kubeconfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("getting status of helm release %s: %w", release, err)
+	}
+
+	status := &HelmRelease{}
+	if err := json.Unmarshal(out.Bytes(), status); err != nil {
+		return nil, fmt.Errorf("parsing status of helm release %s: %v", release, err)
+	}
+
+	return status, nil
+}
+
+// History returns the revisions of a helm release, sorted from the oldest to the latest. If the
+// release doesn't exist, it returns an error wrapping ErrHelmReleaseNotFound.
+//
+// If namespace is the empty string, it won't be passed at all.
+func (h *Helm) History(ctx context.Context, release, namespace, kubeconfigFilePath string) ([]HelmReleaseRevision, error) {
+	out, err := h.releaseCommand(ctx, "history", release, namespace, kubeconfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("getting history of helm release %s: %w", release, err)
+	}
+
+	var history []HelmReleaseRevision
+	if err := json.Unmarshal(out.Bytes(), &history); err != nil {
+		return nil, fmt.Errorf("parsing history of helm release %s: %v", release, err)
+	}
+
+	return history, nil
+}
+
+// releaseCommand runs a helm command for a release with json output.
+func (h *Helm) releaseCommand(ctx context.Context, command, release, namespace, kubeconfigFilePath string) (bytes.Buffer, error) {
+	params := []string{command, release, "--kubeconfig", kubeconfigFilePath, "-o", "json"}
+	if namespace != "" {
+		params = append(params, "--namespace", namespace)
+	}
+	params = h.addInsecureFlagIfProvided(params)
+
+	out, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
+	if err != nil && strings.Contains(err.Error(), "release: not found") {
+		return out, ErrHelmReleaseNotFound
+	}
+
+	return out, err
+}
+
 func (h *Helm) ListCharts(ctx context.Context, kubeconfigFilePath string) ([]string, error) {
 	params := []string{"list", "-q", "--kubeconfig", kubeconfigFilePath}
 	out, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
@@ -211,10 +488,56 @@ func (h *Helm) addInsecureFlagIfProvided(params []string) []string {
 	return params
 }
 
+func (h *Helm) addPostRendererFlagsIfProvided(params []string) []string {
+	if h.postRenderer == "" {
+		return params
+	}
+	params = append(params, postRendererFlag, h.postRenderer)
+	for _, arg := range h.postRendererArgs {
+		params = append(params, postRendererArgsFlag, arg)
+	}
+	return params
+}
+
+// withOpts returns a copy of h with opts applied, so per call options don't leak to other calls.
+func (h *Helm) withOpts(opts ...HelmOpt) *Helm {
+	if len(opts) == 0 {
+		return h
+	}
+	c := *h
+	c.env = make(map[string]string, len(h.env))
+	for k, v := range h.env {
+		c.env[k] = v
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c
+}
+
 func (h *Helm) url(originalURL string) string {
 	return h.registryMirror.ReplaceRegistry(originalURL)
 }
 
+// chartArgs returns the helm args referencing a chart. Charts of classic HTTP(S) repositories are
+// referenced by the repository URL followed by the chart name, ex. https://charts.example.com/my-chart,
+// and passed to helm as the chart name with --repo. OCI URIs, packaged chart URLs and charts of the
+// repositories added with RepoAdd, ex. my-repo/my-chart, are passed as they are.
+func chartArgs(chartURI string) []string {
+	u, err := url.Parse(chartURI)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || strings.HasSuffix(u.Path, ".tgz") {
+		return []string{chartURI}
+	}
+
+	chart := path.Base(u.Path)
+	if chart == "." || chart == "/" {
+		return []string{chartURI}
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/"+chart)
+
+	return []string{chart, "--repo", u.String()}
+}
+
 func GetHelmValueArgs(values []string) []string {
 	valueArgs := []string{}
 	for _, value := range values {
@@ -227,17 +550,18 @@ func GetHelmValueArgs(values []string) []string {
 // UpgradeChartWithValuesFile tuns a helm upgrade with the provided values file and waits for the
 // chart deployment to be ready.
 func (h *Helm) UpgradeChartWithValuesFile(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, valuesFilePath string, opts ...HelmOpt) error {
-	params := []string{
-		"upgrade", chart, ociURI,
+	params := append([]string{"upgrade", chart}, chartArgs(ociURI)...)
+	params = append(params,
 		"--version", version,
 		"--values", valuesFilePath,
 		"--kubeconfig", kubeconfigFilePath,
 		"--wait",
-	}
+	)
 	for _, opt := range opts {
 		opt(h)
 	}
 	params = h.addInsecureFlagIfProvided(params)
+	params = h.addPostRendererFlagsIfProvided(params)
 	_, err := h.executable.Command(ctx, params...).WithEnvVars(h.env).Run()
 	return err
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -109,6 +110,67 @@ func TestHelmTemplateSuccessWithEnv(t *testing.T) {
 	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent), "helm.Template() should succeed return correct template content")
 }
 
+func TestHelmTemplateSuccessWithPostRenderer(t *testing.T) {
+	tt := newHelmTemplateTest(t, executables.WithPostRenderer("/opt/bin/kustomize-labels", "--env", "prod"))
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22",
+		"--post-renderer", "/opt/bin/kustomize-labels", "--post-renderer-args", "--env", "--post-renderer-args", "prod", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent), "helm.Template() should succeed return correct template content")
+}
+
+func TestHelmTemplatePostRendererOptOnlyAppliesToCall(t *testing.T) {
+	tt := newHelmTemplateTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22",
+		"--post-renderer", "/opt/bin/kustomize-labels", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22", executables.WithPostRenderer("/opt/bin/kustomize-labels"))).To(Equal(tt.wantTemplateContent))
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent))
+}
+
+func TestHelmTemplateWithCache(t *testing.T) {
+	tt := newHelmTemplateTest(t, executables.WithTemplateCache(t.TempDir()))
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil).Times(1)
+
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent))
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent), "helm.Template() should return the cached template")
+}
+
+func TestHelmTemplateWithCacheDifferentValues(t *testing.T) {
+	tt := newHelmTemplateTest(t, executables.WithTemplateCache(t.TempDir()))
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn([]byte("key1: other\n")).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString("other-content"), nil)
+
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent))
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, map[string]string{"key1": "other"}, "1.22")).To(Equal([]byte("other-content")))
+}
+
+func TestHelmTemplateWithCacheDoesNotCacheErrors(t *testing.T) {
+	tt := newHelmTemplateTest(t, executables.WithTemplateCache(t.TempDir()))
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, errors.New("pulling chart"))
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+
+	_, err := tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")
+	tt.Expect(err).To(HaveOccurred())
+	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent))
+}
+
 func TestHelmTemplateErrorYaml(t *testing.T) {
 	tt := newHelmTemplateTest(t)
 	values := func() {}
@@ -169,6 +231,21 @@ func TestHelmInstallChartSuccess(t *testing.T) {
 	tt.Expect(tt.h.InstallChart(tt.ctx, chart, url, version, kubeconfig, "eksa-packages", "", false, values)).To(Succeed())
 }
 
+func TestHelmInstallChartSuccessWithPostRenderer(t *testing.T) {
+	tt := newHelmTest(t)
+	chart := "chart"
+	url := "url"
+	version := "1.1"
+	kubeconfig := "/root/.kube/config"
+	values := []string{"key1=value1"}
+	expectCommand(
+		tt.e, tt.ctx, "upgrade", "--install", chart, url, "--version", version, "--set", "key1=value1", "--kubeconfig", kubeconfig, "--create-namespace", "--namespace", "eksa-packages",
+		"--post-renderer", "/opt/bin/kustomize-labels",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.InstallChart(tt.ctx, chart, url, version, kubeconfig, "eksa-packages", "", false, values, executables.WithPostRenderer("/opt/bin/kustomize-labels"))).To(Succeed())
+}
+
 func TestHelmInstallChartSuccessWithValuesFile(t *testing.T) {
 	tt := newHelmTest(t)
 	chart := "chart"
@@ -267,6 +344,168 @@ func TestHelmInstallChartWithValuesFileSuccessWithInsecure(t *testing.T) {
 	tt.Expect(tt.h.InstallChartWithValuesFile(tt.ctx, chart, url, version, kubeconfig, valuesFileName)).To(Succeed())
 }
 
+func TestHelmInstallChartWithValuesFileSuccessWithPostRenderer(t *testing.T) {
+	tt := newHelmTest(t, executables.WithInsecure(), executables.WithPostRenderer("/opt/bin/kustomize-labels"))
+	chart := "chart"
+	url := "url"
+	version := "1.1"
+	kubeconfig := "/root/.kube/config"
+	valuesFileName := "values.yaml"
+	expectCommand(
+		tt.e, tt.ctx, "upgrade", "--install", chart, url, "--version", version, "--values", valuesFileName, "--kubeconfig", kubeconfig, "--wait", "--insecure-skip-tls-verify",
+		"--post-renderer", "/opt/bin/kustomize-labels",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.InstallChartWithValuesFile(tt.ctx, chart, url, version, kubeconfig, valuesFileName)).To(Succeed())
+}
+
+func TestHelmTest(t *testing.T) {
+	tt := newHelmTest(t)
+	output := "NAME: harbor\nPhase: Succeeded\nPOD LOGS: harbor-test\nok\n"
+	expectCommand(
+		tt.e, tt.ctx, "test", "harbor", "--kubeconfig", "kubeconfig", "--logs", "--namespace", "harbor",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString(output), nil)
+
+	tt.Expect(tt.h.Test(tt.ctx, "kubeconfig", "harbor", "harbor")).To(Equal(output))
+}
+
+func TestHelmTestWithoutNamespace(t *testing.T) {
+	tt := newHelmTest(t, executables.WithInsecure())
+	expectCommand(
+		tt.e, tt.ctx, "test", "harbor", "--kubeconfig", "kubeconfig", "--logs", "--insecure-skip-tls-verify",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.Test(tt.ctx, "kubeconfig", "harbor", "")).To(BeEmpty())
+}
+
+func TestHelmTestFailure(t *testing.T) {
+	tt := newHelmTest(t)
+	output := "NAME: harbor\nPhase: Failed\nPOD LOGS: harbor-test\nconnection refused\n"
+	expectCommand(
+		tt.e, tt.ctx, "test", "harbor", "--kubeconfig", "kubeconfig", "--logs", "--namespace", "harbor",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString(output), errors.New("pod harbor-test failed"))
+
+	got, err := tt.h.Test(tt.ctx, "kubeconfig", "harbor", "harbor")
+	tt.Expect(err).To(MatchError("testing helm release harbor: pod harbor-test failed"))
+	tt.Expect(got).To(Equal(output), "the test pod logs should be returned on failure")
+}
+
+func TestHelmStatus(t *testing.T) {
+	tt := newHelmTest(t)
+	output := `{"name":"cilium","info":{"first_deployed":"2026-10-01T10:00:00Z","last_deployed":"2026-10-15T10:00:00Z","deleted":"","description":"Preparing upgrade","status":"pending-upgrade"},"chart":{"metadata":{"name":"cilium","version":"1.13.9","appVersion":"1.13.9"}},"config":{},"version":3,"namespace":"kube-system"}`
+	expectCommand(
+		tt.e, tt.ctx, "status", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--namespace", "kube-system",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString(output), nil)
+
+	release, err := tt.h.Status(tt.ctx, "cilium", "kube-system", "kubeconfig")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(release).To(Equal(&executables.HelmRelease{
+		Name:      "cilium",
+		Namespace: "kube-system",
+		Version:   3,
+		Info: executables.HelmReleaseInfo{
+			Status:        executables.HelmReleaseStatusPendingUpgrade,
+			Description:   "Preparing upgrade",
+			FirstDeployed: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+			LastDeployed:  time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		},
+		Chart: executables.HelmChart{
+			Metadata: executables.HelmChartMetadata{Name: "cilium", Version: "1.13.9", AppVersion: "1.13.9"},
+		},
+	}))
+	tt.Expect(release.Info.Status.IsPending()).To(BeTrue())
+}
+
+func TestHelmStatusWithoutNamespace(t *testing.T) {
+	tt := newHelmTest(t, executables.WithInsecure())
+	expectCommand(
+		tt.e, tt.ctx, "status", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--insecure-skip-tls-verify",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString(`{"name":"cilium","info":{"status":"deployed"}}`), nil)
+
+	release, err := tt.h.Status(tt.ctx, "cilium", "", "kubeconfig")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(release.Info.Status).To(Equal(executables.HelmReleaseStatusDeployed))
+	tt.Expect(release.Info.Status.IsPending()).To(BeFalse())
+}
+
+func TestHelmStatusNotFound(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "status", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--namespace", "kube-system",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, errors.New("Error: release: not found"))
+
+	_, err := tt.h.Status(tt.ctx, "cilium", "kube-system", "kubeconfig")
+	tt.Expect(errors.Is(err, executables.ErrHelmReleaseNotFound)).To(BeTrue())
+}
+
+func TestHelmStatusError(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "status", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--namespace", "kube-system",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	_, err := tt.h.Status(tt.ctx, "cilium", "kube-system", "kubeconfig")
+	tt.Expect(err).To(MatchError("getting status of helm release cilium: connection refused"))
+	tt.Expect(errors.Is(err, executables.ErrHelmReleaseNotFound)).To(BeFalse())
+}
+
+func TestHelmStatusInvalidOutput(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "status", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--namespace", "kube-system",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString("NAME: cilium"), nil)
+
+	_, err := tt.h.Status(tt.ctx, "cilium", "kube-system", "kubeconfig")
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing status of helm release cilium")))
+}
+
+func TestHelmHistory(t *testing.T) {
+	tt := newHelmTest(t)
+	output := `[{"revision":1,"updated":"2026-10-01T10:00:00Z","status":"superseded","chart":"cilium-1.12.11","app_version":"1.12.11","description":"Install complete"},{"revision":2,"updated":"2026-10-15T10:00:00Z","status":"failed","chart":"cilium-1.13.9","app_version":"1.13.9","description":"Upgrade \"cilium\" failed: context deadline exceeded"}]`
+	expectCommand(
+		tt.e, tt.ctx, "history", "cilium", "--kubeconfig", "kubeconfig", "-o", "json", "--namespace", "kube-system",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString(output), nil)
+
+	tt.Expect(tt.h.History(tt.ctx, "cilium", "kube-system", "kubeconfig")).To(Equal([]executables.HelmReleaseRevision{
+		{
+			Revision:    1,
+			Updated:     time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+			Status:      executables.HelmReleaseStatusSuperseded,
+			Chart:       "cilium-1.12.11",
+			AppVersion:  "1.12.11",
+			Description: "Install complete",
+		},
+		{
+			Revision:    2,
+			Updated:     time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+			Status:      executables.HelmReleaseStatusFailed,
+			Chart:       "cilium-1.13.9",
+			AppVersion:  "1.13.9",
+			Description: `Upgrade "cilium" failed: context deadline exceeded`,
+		},
+	}))
+}
+
+func TestHelmHistoryNotFound(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "history", "cilium", "--kubeconfig", "kubeconfig", "-o", "json",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, errors.New("Error: release: not found"))
+
+	_, err := tt.h.History(tt.ctx, "cilium", "", "kubeconfig")
+	tt.Expect(errors.Is(err, executables.ErrHelmReleaseNotFound)).To(BeTrue())
+}
+
+func TestHelmHistoryInvalidOutput(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "history", "cilium", "--kubeconfig", "kubeconfig", "-o", "json",
+	).withEnvVars(tt.envVars).to().Return(*bytes.NewBufferString("REVISION\tUPDATED"), nil)
+
+	_, err := tt.h.History(tt.ctx, "cilium", "", "kubeconfig")
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing history of helm release cilium")))
+}
+
 func TestHelmListCharts(t *testing.T) {
 	tt := newHelmTest(t, executables.WithInsecure())
 	kubeconfig := "/root/.kube/config"
@@ -329,3 +568,87 @@ func TestHelmDelete(s *testing.T) {
 		tt.Expect(err).To(HaveOccurred())
 	})
 }
+
+func TestHelmTemplateRepoChart(t *testing.T) {
+	tt := newHelmTemplateTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "template", "my-chart", "--repo", "https://charts.example.com/stable", "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+
+	tt.Expect(tt.h.Template(tt.ctx, "https://charts.example.com/stable/my-chart", tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent))
+}
+
+func TestHelmPullChartRepoChart(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "pull", "my-chart", "--repo", "https://charts.example.com", "--version", "1.1",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.PullChart(tt.ctx, "https://charts.example.com/my-chart/", "1.1")).To(Succeed())
+}
+
+func TestHelmPullChartPackagedChart(t *testing.T) {
+	tt := newHelmTest(t)
+	url := "https://charts.example.com/my-chart-1.1.tgz"
+	expectCommand(
+		tt.e, tt.ctx, "pull", url, "--version", "1.1",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.PullChart(tt.ctx, url, "1.1")).To(Succeed())
+}
+
+func TestHelmInstallChartRepoChart(t *testing.T) {
+	tt := newHelmTest(t)
+	kubeconfig := "/root/.kube/config"
+	expectCommand(
+		tt.e, tt.ctx, "upgrade", "--install", "release", "my-chart", "--repo", "http://charts.example.com/stable", "--version", "1.1", "--kubeconfig", kubeconfig, "--create-namespace", "--namespace", "eksa-packages",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.InstallChart(tt.ctx, "release", "http://charts.example.com/stable/my-chart", "1.1", kubeconfig, "eksa-packages", "", false, nil)).To(Succeed())
+}
+
+func TestHelmInstallChartAddedRepoChart(t *testing.T) {
+	tt := newHelmTest(t)
+	kubeconfig := "/root/.kube/config"
+	expectCommand(
+		tt.e, tt.ctx, "upgrade", "--install", "release", "my-repo/my-chart", "--version", "1.1", "--kubeconfig", kubeconfig,
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.InstallChart(tt.ctx, "release", "my-repo/my-chart", "1.1", kubeconfig, "", "", false, nil)).To(Succeed())
+}
+
+func TestHelmRepoAdd(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "repo", "add", "my-repo", "https://charts.example.com", "--force-update",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.RepoAdd(tt.ctx, "my-repo", "https://charts.example.com", "", "")).To(Succeed())
+}
+
+func TestHelmRepoAddWithCredentials(t *testing.T) {
+	tt := newHelmTest(t, executables.WithInsecure())
+	expectCommand(
+		tt.e, tt.ctx, "repo", "add", "my-repo", "https://charts.example.com", "--force-update", "--username", "user", "--password-stdin", "--insecure-skip-tls-verify",
+	).withStdIn([]byte("pass")).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.RepoAdd(tt.ctx, "my-repo", "https://charts.example.com", "user", "pass")).To(Succeed())
+}
+
+func TestHelmRepoAddError(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "repo", "add", "my-repo", "https://charts.example.com", "--force-update",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, errors.New("not found"))
+
+	tt.Expect(tt.h.RepoAdd(tt.ctx, "my-repo", "https://charts.example.com", "", "")).To(MatchError("adding helm repo my-repo: not found"))
+}
+
+func TestHelmRepoUpdate(t *testing.T) {
+	tt := newHelmTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "repo", "update", "my-repo",
+	).withEnvVars(tt.envVars).to().Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.h.RepoUpdate(tt.ctx, "my-repo")).To(Succeed())
+}
@@ -0,0 +1,115 @@
+package executables
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+// ChartTagLister lists the tags of an OCI repository.
+type ChartTagLister interface {
+	Tags(ctx context.Context, repository string) ([]string, error)
+}
+
+// WithChartTagLister sets the ChartTagLister used to list the available versions of a chart.
+func WithChartTagLister(lister ChartTagLister) HelmOpt {
+	return func(h *Helm) {
+		h.tagLister = lister
+	}
+}
+
+// ListChartVersions lists the versions of a chart available in an OCI registry, newest first.
+// The registry mirror is used if configured.
+func (h *Helm) ListChartVersions(ctx context.Context, ociURI string) ([]string, error) {
+	repository := strings.TrimPrefix(h.url(ociURI), "oci://")
+
+	lister := h.tagLister
+	if lister == nil {
+		lister = &registryTagLister{insecure: h.insecure}
+	}
+
+	tags, err := lister.Tags(ctx, repository)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions for chart %s: %v", repository, err)
+	}
+
+	versions := make([]string, 0, len(tags))
+	for _, t := range tags {
+		// OCI tags don't allow '+', so helm replaces it with '_' when pushing charts.
+		versions = append(versions, strings.ReplaceAll(t, "_", "+"))
+	}
+	sortVersionsDescending(versions)
+
+	return versions, nil
+}
+
+// sortVersionsDescending sorts the semver versions newest first, followed by any other
+// version in alphabetical order.
+func sortVersionsDescending(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := semver.New(versions[i])
+		vj, errJ := semver.New(versions[j])
+		switch {
+		case errI == nil && errJ == nil:
+			return vi.GreaterThan(vj)
+		case errI == nil:
+			return true
+		case errJ == nil:
+			return false
+		default:
+			return versions[i] < versions[j]
+		}
+	})
+}
+
+// registryTagLister lists tags using the registry tags API, authenticating with the
+// credentials in the docker config.
+type registryTagLister struct {
+	insecure bool
+}
+
+func (l *registryTagLister) Tags(ctx context.Context, repository string) ([]string, error) {
+	repo, err := remote.NewRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := registry.NewCredentialStore()
+	if err := credentials.Init(); err != nil {
+		return nil, fmt.Errorf("reading registry credentials: %v", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	{ // #nosec G402
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: l.insecure}
+	}
+	client := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+		Credential: func(_ context.Context, host string) (auth.Credential, error) {
+			return credentials.Credential(host)
+		},
+	}
+	client.SetUserAgent("eksa")
+	repo.Client = client
+
+	var tags []string
+	err = repo.Tags(ctx, "", func(page []string) error {
+		tags = append(tags, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,57 @@
+package executables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+type fakeTagLister struct {
+	repository string
+	tags       []string
+	err        error
+}
+
+func (f *fakeTagLister) Tags(_ context.Context, repository string) ([]string, error) {
+	f.repository = repository
+	return f.tags, f.err
+}
+
+func TestHelmListChartVersions(t *testing.T) {
+	lister := &fakeTagLister{tags: []string{"0.1.0", "latest", "0.10.0", "0.2.0_build.1", "0.2.0"}}
+	tt := newHelmTest(t, executables.WithChartTagLister(lister))
+
+	tt.Expect(tt.h.ListChartVersions(tt.ctx, "oci://public.ecr.aws/eks-anywhere/harbor")).To(Equal(
+		[]string{"0.10.0", "0.2.0+build.1", "0.2.0", "0.1.0", "latest"},
+	))
+	tt.Expect(lister.repository).To(Equal("public.ecr.aws/eks-anywhere/harbor"))
+}
+
+func TestHelmListChartVersionsWithRegistryMirror(t *testing.T) {
+	lister := &fakeTagLister{}
+	tt := newHelmTest(t,
+		executables.WithChartTagLister(lister),
+		executables.WithRegistryMirror(&registrymirror.RegistryMirror{
+			BaseRegistry: "1.2.3.4:443",
+			NamespacedRegistryMap: map[string]string{
+				"public.ecr.aws": "1.2.3.4:443/curated-packages",
+			},
+		}),
+	)
+
+	_, err := tt.h.ListChartVersions(tt.ctx, "oci://public.ecr.aws/eks-anywhere/harbor")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(lister.repository).To(Equal("1.2.3.4:443/curated-packages/eks-anywhere/harbor"))
+}
+
+func TestHelmListChartVersionsError(t *testing.T) {
+	tt := newHelmTest(t, executables.WithChartTagLister(&fakeTagLister{err: errors.New("unauthorized")}))
+
+	_, err := tt.h.ListChartVersions(tt.ctx, "oci://public.ecr.aws/eks-anywhere/harbor")
+	tt.Expect(err).To(MatchError("listing versions for chart public.ecr.aws/eks-anywhere/harbor: unauthorized"))
+}
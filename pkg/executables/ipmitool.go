@@ -0,0 +1,70 @@
+package executables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const ipmitoolPath = "ipmitool"
+
+// Ipmitool is an executable for interacting with BMCs over IPMI.
+type Ipmitool struct {
+	Executable
+	writer filewriter.FileWriter
+}
+
+// NewIpmitool returns a new instance of Ipmitool. BMC passwords are written to files in the
+// writer's temp dir so they are never part of the command arguments.
+func NewIpmitool(executable Executable, writer filewriter.FileWriter) *Ipmitool {
+	return &Ipmitool{
+		Executable: executable,
+		writer:     writer,
+	}
+}
+
+// CaptureSOL attaches to the serial-over-LAN console of the BMC at host and returns the console output
+// produced until ctx is done. Reaching the ctx deadline is the expected way of ending a capture,
+// so the output read so far is returned without an error in that case.
+func (i *Ipmitool) CaptureSOL(ctx context.Context, host, username, password string) ([]byte, error) {
+	passwordFile, err := i.writePasswordFile(password)
+	if err != nil {
+		return nil, fmt.Errorf("capturing serial-over-LAN console for %s: %v", host, err)
+	}
+	defer os.Remove(passwordFile)
+
+	// Deactivate any dangling session left behind by a previous capture, otherwise the BMC refuses
+	// new SOL sessions. The error is ignored because deactivating an inactive session fails.
+	_, _ = i.Execute(ctx, i.lanplusArgs(host, username, passwordFile, "sol", "deactivate")...)
+
+	out, err := i.Execute(ctx, i.lanplusArgs(host, username, passwordFile, "sol", "activate")...)
+	if err != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) && !errors.Is(ctx.Err(), context.Canceled) {
+		return out.Bytes(), fmt.Errorf("capturing serial-over-LAN console for %s: %v", host, err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// writePasswordFile writes password to a new file only readable by the current user and returns
+// its path. Commands are logged with their arguments, so the password is passed with -f instead of -P.
+func (i *Ipmitool) writePasswordFile(password string) (string, error) {
+	f, err := os.CreateTemp(i.writer.TempDir(), "ipmi-password-")
+	if err != nil {
+		return "", fmt.Errorf("creating BMC password file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(password); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("writing BMC password file: %v", err)
+	}
+
+	return f.Name(), nil
+}
+
+func (i *Ipmitool) lanplusArgs(host, username, passwordFile string, args ...string) []string {
+	return append([]string{"-I", "lanplus", "-H", host, "-U", username, "-f", passwordFile}, args...)
+}
@@ -0,0 +1,77 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestIpmitoolCaptureSOLSuccess(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	ipmitool := executables.NewIpmitool(executable, writer)
+
+	var passwordFile string
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "deactivate").DoAndReturn(
+		func(_ context.Context, args ...string) (bytes.Buffer, error) {
+			passwordFile = args[7]
+			info, err := os.Stat(passwordFile)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			content, err := os.ReadFile(passwordFile)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(content)).To(Equal("pass"))
+			return bytes.Buffer{}, errors.New("no active session")
+		},
+	)
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "activate").Return(*bytes.NewBufferString("booting"), nil)
+
+	out, err := ipmitool.CaptureSOL(ctx, "1.2.3.4", "admin", "pass")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).To(Equal("booting"))
+	g.Expect(passwordFile).NotTo(BeEmpty())
+	g.Expect(passwordFile).NotTo(BeAnExistingFile())
+}
+
+func TestIpmitoolCaptureSOLDeadlineExceeded(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, writer := test.NewWriter(t)
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	ipmitool := executables.NewIpmitool(executable, writer)
+
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "deactivate")
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "activate").Return(*bytes.NewBufferString("partial"), errors.New("signal: killed"))
+
+	out, err := ipmitool.CaptureSOL(ctx, "1.2.3.4", "admin", "pass")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).To(Equal("partial"))
+}
+
+func TestIpmitoolCaptureSOLError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	ipmitool := executables.NewIpmitool(executable, writer)
+
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "deactivate")
+	executable.EXPECT().Execute(ctx, "-I", "lanplus", "-H", "1.2.3.4", "-U", "admin", "-f", gomock.Any(), "sol", "activate").Return(bytes.Buffer{}, errors.New("unable to establish session"))
+
+	_, err := ipmitool.CaptureSOL(ctx, "1.2.3.4", "admin", "pass")
+	g.Expect(err).To(MatchError("capturing serial-over-LAN console for 1.2.3.4: unable to establish session"))
+}
@@ -876,6 +876,24 @@ func (k *Kubectl) DeleteOldWorkerNodeGroup(ctx context.Context, md *clusterv1.Ma
 	return nil
 }
 
+// DrainNode cordons a node and evicts all its pods except the ones managed by DaemonSets.
+func (k *Kubectl) DrainNode(ctx context.Context, nodeName, kubeconfig string) error {
+	params := []string{"drain", nodeName, "--ignore-daemonsets", "--delete-emptydir-data", "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("draining node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// UncordonNode marks a node as schedulable.
+func (k *Kubectl) UncordonNode(ctx context.Context, nodeName, kubeconfig string) error {
+	params := []string{"uncordon", nodeName, "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("uncordoning node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) ValidateControlPlaneNodes(ctx context.Context, cluster *types.Cluster, clusterName string) error {
 	cp, err := k.GetKubeadmControlPlane(ctx, cluster, clusterName, WithCluster(cluster), WithNamespace(constants.EksaSystemNamespace))
 	if err != nil {
@@ -1214,6 +1232,18 @@ func (k *Kubectl) RolloutRestartDaemonSet(ctx context.Context, dsName, dsNamespa
 	return nil
 }
 
+// RolloutRestartDeployment restarts the pods of a deployment.
+func (k *Kubectl) RolloutRestartDeployment(ctx context.Context, name, namespace, kubeconfig string) error {
+	params := []string{
+		"rollout", "restart", "deployment", name,
+		"--kubeconfig", kubeconfig, "--namespace", namespace,
+	}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("restarting %s deployment in namespace %s: %v", name, namespace, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) SetEksaControllerEnvVar(ctx context.Context, envVar, envVarVal, kubeconfig string) error {
 	params := []string{
 		"set", "env", "deployment/eksa-controller-manager", fmt.Sprintf("%s=%s", envVar, envVarVal),
@@ -2048,6 +2078,39 @@ func (k *Kubectl) MergePatchResource(ctx context.Context, resource, name, patch,
 	return nil
 }
 
+// MergePatchClusterResourceStatus applies a merge patch to the status subresource of a cluster scoped object.
+func (k *Kubectl) MergePatchClusterResourceStatus(ctx context.Context, resource, name, patch, kubeconfig string) error {
+	params := []string{
+		"patch", resource, name, "--subresource=status", "--type=merge", "-p", patch, "--kubeconfig", kubeconfig,
+	}
+
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("patching status of %s %s: %v", resource, name, err)
+	}
+	return nil
+}
+
+// MergePatchResourceStatus applies a merge patch to the status subresource of a namespaced object.
+func (k *Kubectl) MergePatchResourceStatus(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error {
+	params := []string{
+		"patch", resource, name, "--subresource=status", "--type=merge", "-p", patch, "--kubeconfig", kubeconfig, "--namespace", namespace,
+	}
+
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("patching status of %s %s: %v", resource, name, err)
+	}
+	return nil
+}
+
+// ReplaceFromBytes replaces the objects in data, which can be a List, with kubectl. Unlike Replace,
+// the objects are sent as they are, so they can be written back without changes.
+func (k *Kubectl) ReplaceFromBytes(ctx context.Context, kubeconfig string, data []byte) error {
+	if _, err := k.ExecuteWithStdin(ctx, data, "replace", "-f", "-", "--kubeconfig", kubeconfig); err != nil {
+		return fmt.Errorf("replacing objects with kubectl: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error) {
 	return k.HasResource(ctx, "secret", fmt.Sprintf("%s-kubeconfig", clusterName), kubeconfig, namespace)
 }
@@ -2148,9 +2211,67 @@ func getParams(resourceType, kubeconfig string, o *kubernetes.KubectlGetOptions)
 		params = append(params, o.Name)
 	}
 
+	if o.LabelSelector != "" {
+		params = append(params, "-l", o.LabelSelector)
+	}
+	if o.FieldSelector != "" {
+		params = append(params, "--field-selector", o.FieldSelector)
+	}
+	if o.ChunkSize > 0 {
+		params = append(params, "--chunk-size", strconv.FormatInt(o.ChunkSize, 10))
+	}
+
 	return params
 }
 
+// kubeObject is a pointer to a Kubernetes API object type.
+type kubeObject[T any] interface {
+	*T
+	runtime.Object
+}
+
+// GetObject performs a kubectl get for a single object and returns it as a T.
+// If the object is not found, it returns an error implementing apimachinery errors.APIStatus.
+func GetObject[T any, PT kubeObject[T]](ctx context.Context, k *Kubectl, resourceType, kubeconfig string, opts ...kubernetes.KubectlGetOption) (PT, error) {
+	obj := PT(new(T))
+	if err := k.Get(ctx, resourceType, kubeconfig, obj, opts...); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ListObjects performs a kubectl get for a collection and returns its items. Large collections
+// should set a ChunkSize so the API server returns them in pages.
+func ListObjects[T any](ctx context.Context, k *Kubectl, resourceType, kubeconfig string, opts ...kubernetes.KubectlGetOption) ([]T, error) {
+	o := &kubernetes.KubectlGetOptions{}
+	for _, opt := range opts {
+		opt.ApplyToGet(o)
+	}
+
+	if o.Name != "" {
+		return nil, errors.New("listing objects doesn't support specifying a Name")
+	}
+
+	stdOut, err := k.Execute(ctx, getParams(resourceType, kubeconfig, o)...)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s with kubectl: %v", resourceType, err)
+	}
+
+	list := &struct {
+		Items []T `json:"items"`
+	}{}
+	if stdOut.Len() == 0 {
+		return list.Items, nil
+	}
+
+	if err = json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("parsing list %s response: %v", resourceType, err)
+	}
+
+	return list.Items, nil
+}
+
 // Create performs a kubectl create command.
 func (k *Kubectl) Create(ctx context.Context, kubeconfig string, obj runtime.Object) error {
 	b, err := yaml.Marshal(obj)
@@ -1539,6 +1539,32 @@ func TestKubectlRolloutRestartDaemonSetError(t *testing.T) {
 	}
 }
 
+func TestKubectlRolloutRestartDeploymentSuccess(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"rollout", "restart", "deployment", "capv-controller-manager",
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.CapvSystemNamespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.RolloutRestartDeployment(tt.ctx, "capv-controller-manager", constants.CapvSystemNamespace, tt.cluster.KubeconfigFile)).To(Succeed())
+}
+
+func TestKubectlRolloutRestartDeploymentError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"rollout", "restart", "deployment", "capv-controller-manager",
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.CapvSystemNamespace,
+	).Return(bytes.Buffer{}, errors.New("error"))
+
+	tt.Expect(tt.k.RolloutRestartDeployment(tt.ctx, "capv-controller-manager", constants.CapvSystemNamespace, tt.cluster.KubeconfigFile)).To(
+		MatchError(ContainSubstring("restarting capv-controller-manager deployment in namespace capv-system")),
+	)
+}
+
 func TestKubectlGetGetApiServerUrlError(t *testing.T) {
 	t.Parallel()
 	k, ctx, cluster, e := newKubectl(t)
@@ -2300,6 +2326,87 @@ func TestKubectlMergePatchResourceError(t *testing.T) {
 	tt.Expect(err).To(HaveOccurred())
 }
 
+func TestKubectlMergePatchResourceStatus(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	patch := `{"status":{"observedGeneration":1}}`
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "clusters.anywhere.eks.amazonaws.com", "test-cluster", "--subresource=status", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "test-cluster", patch,
+		tt.cluster.KubeconfigFile, tt.namespace)).To(Succeed())
+}
+
+func TestKubectlMergePatchResourceStatusError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	patch := `{"status":{"observedGeneration":1}}`
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "clusters.anywhere.eks.amazonaws.com", "test-cluster", "--subresource=status", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, errors.New("error"))
+
+	tt.Expect(tt.k.MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "test-cluster", patch,
+		tt.cluster.KubeconfigFile, tt.namespace)).To(MatchError(ContainSubstring("patching status of")))
+}
+
+func TestKubectlMergePatchClusterResourceStatus(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	patch := `{"status":{"storedVersions":["v1beta1"]}}`
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "customresourcedefinitions", "clusters.cluster.x-k8s.io", "--subresource=status", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "clusters.cluster.x-k8s.io", patch,
+		tt.cluster.KubeconfigFile)).To(Succeed())
+}
+
+func TestKubectlMergePatchClusterResourceStatusError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	patch := `{"status":{"storedVersions":["v1beta1"]}}`
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "customresourcedefinitions", "clusters.cluster.x-k8s.io", "--subresource=status", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	err := tt.k.MergePatchClusterResourceStatus(tt.ctx, "customresourcedefinitions", "clusters.cluster.x-k8s.io", patch,
+		tt.cluster.KubeconfigFile)
+	tt.Expect(err).To(MatchError(ContainSubstring("patching status of customresourcedefinitions clusters.cluster.x-k8s.io: forbidden")))
+}
+
+func TestKubectlReplaceFromBytes(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	data := []byte(`{"apiVersion":"v1","kind":"List","items":[]}`)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "replace", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, data)).To(Succeed())
+}
+
+func TestKubectlReplaceFromBytesError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	data := []byte(`{"apiVersion":"v1","kind":"List","items":[]}`)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "replace", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("conflict"))
+
+	tt.Expect(tt.k.ReplaceFromBytes(tt.ctx, tt.cluster.KubeconfigFile, data)).To(MatchError(ContainSubstring("conflict")))
+}
+
 func TestKubectlGetConfigMap(t *testing.T) {
 	t.Parallel()
 	tt := newKubectlTest(t)
@@ -2911,6 +3018,63 @@ func TestGetUnprovisionedTinkerbellHardware_MarshallingError(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestListObjectsEmptyResponse(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig, "secrets", "--namespace", "eksa-system",
+	).Return(bytes.Buffer{}, nil)
+
+	secrets, err := executables.ListObjects[corev1.Secret](tt.ctx, tt.k, "secrets", tt.kubeconfig, &kubernetes.KubectlGetOptions{Namespace: "eksa-system"})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(secrets).To(BeEmpty())
+}
+
+func TestListObjectsWithSelectorsAndChunkSize(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig, "secrets", "--all-namespaces",
+		"-l", "app=my-app", "--field-selector", "type=Opaque", "--chunk-size", "100",
+	).Return(*bytes.NewBufferString(`{"items":[{"metadata":{"name":"s1","namespace":"ns1"}},{"metadata":{"name":"s2","namespace":"ns2"}}]}`), nil)
+
+	secrets, err := executables.ListObjects[corev1.Secret](tt.ctx, tt.k, "secrets", tt.kubeconfig, &kubernetes.KubectlGetOptions{
+		LabelSelector: "app=my-app",
+		FieldSelector: "type=Opaque",
+		ChunkSize:     100,
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(secrets).To(HaveLen(2))
+	tt.Expect(secrets[0].Name).To(Equal("s1"))
+	tt.Expect(secrets[1].Namespace).To(Equal("ns2"))
+}
+
+func TestListObjectsWithName(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+
+	_, err := executables.ListObjects[corev1.Secret](tt.ctx, tt.k, "secrets", tt.kubeconfig, &kubernetes.KubectlGetOptions{Name: "s1", Namespace: "ns"})
+	tt.Expect(err).To(MatchError("listing objects doesn't support specifying a Name"))
+}
+
+func TestGetObjectGeneric(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig, "secrets", "--namespace", "eksa-system", "s1",
+	).Return(*bytes.NewBufferString(`{"metadata":{"name":"s1","namespace":"eksa-system"}}`), nil)
+
+	secret, err := executables.GetObject[corev1.Secret](tt.ctx, tt.k, "secrets", tt.kubeconfig, &kubernetes.KubectlGetOptions{Name: "s1", Namespace: "eksa-system"})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(secret.Name).To(Equal("s1"))
+}
+
 func TestGetVsphereMachine(t *testing.T) {
 	t.Parallel()
 	tt := newKubectlTest(t)
@@ -3966,3 +4130,25 @@ func TestKubectlDeleteCRD(t *testing.T) {
 		})
 	}
 }
+
+func TestKubectlDrainNode(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"drain", "node-1", "--ignore-daemonsets", "--delete-emptydir-data", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DrainNode(tt.ctx, "node-1", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlUncordonNodeError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"uncordon", "node-1", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, errors.New("error from execute"))
+
+	tt.Expect(tt.k.UncordonNode(tt.ctx, "node-1", tt.kubeconfig)).To(MatchError("uncordoning node node-1: error from execute"))
+}
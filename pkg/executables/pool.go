@@ -0,0 +1,147 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ExecutablesPool shares long running tools containers between the executables builders
+// that use the same image and mount dirs. Commands run as exec sessions in the shared
+// container, so only the first builder pays the container startup cost.
+// It's safe for concurrent use.
+type ExecutablesPool struct {
+	dockerClient DockerClient
+	lock         sync.Mutex
+	containers   map[string]*pooledContainer
+}
+
+type pooledContainer struct {
+	builder *ExecutablesBuilder
+	closer  Closer
+	users   int
+
+	// ready is closed once the container has been initialized, successfully or not. builder,
+	// closer and err can't be read until then.
+	ready chan struct{}
+	err   error
+}
+
+// NewExecutablesPool builds an empty ExecutablesPool.
+func NewExecutablesPool(dockerClient DockerClient) *ExecutablesPool {
+	return &ExecutablesPool{
+		dockerClient: dockerClient,
+		containers:   map[string]*pooledContainer{},
+	}
+}
+
+// Get returns an initialized executables builder that runs executables in a tools container
+// for the image and mount dirs, starting the container if there isn't one running already.
+// The returned Closer releases the container, which is removed once all its users have
+// released it.
+func (p *ExecutablesPool) Get(ctx context.Context, image string, mountDirs ...string) (*ExecutablesBuilder, Closer, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting current directory: %v", err)
+	}
+	key := poolKey(image, currentDir, mountDirs)
+
+	p.lock.Lock()
+	c, ok := p.containers[key]
+	if !ok {
+		c = &pooledContainer{ready: make(chan struct{})}
+		p.containers[key] = c
+	}
+	c.users++
+	p.lock.Unlock()
+
+	// The container is initialized outside of the pool lock, so getting a container for
+	// another image doesn't wait for this one to start.
+	if !ok {
+		p.init(ctx, key, c, image, mountDirs)
+	}
+
+	select {
+	case <-c.ready:
+	case <-ctx.Done():
+		go func() {
+			// The closer is only set once the container is initialized and it must run even
+			// though ctx is done if this was the last user.
+			<-c.ready
+			_ = p.release(context.Background(), key, c)
+		}()
+		return nil, nil, ctx.Err()
+	}
+	if c.err != nil {
+		_ = p.release(ctx, key, c)
+		return nil, nil, c.err
+	}
+
+	var once sync.Once
+	release := func(ctx context.Context) error {
+		var err error
+		once.Do(func() {
+			err = p.release(ctx, key, c)
+		})
+		return err
+	}
+
+	return c.builder, release, nil
+}
+
+func (p *ExecutablesPool) init(ctx context.Context, key string, c *pooledContainer, image string, mountDirs []string) {
+	defer close(c.ready)
+
+	c.err = p.initContainer(ctx, c, image, mountDirs)
+	if c.err != nil {
+		// A failed container is never served, the next Get for the key starts a new one.
+		p.lock.Lock()
+		if p.containers[key] == c {
+			delete(p.containers, key)
+		}
+		p.lock.Unlock()
+	}
+}
+
+func (p *ExecutablesPool) initContainer(ctx context.Context, c *pooledContainer, image string, mountDirs []string) error {
+	b, err := NewInDockerExecutablesBuilder(p.dockerClient, image, mountDirs...)
+	if err != nil {
+		return err
+	}
+
+	closer, err := b.Init(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.builder = b
+	c.closer = closer
+	return nil
+}
+
+func (p *ExecutablesPool) release(ctx context.Context, key string, c *pooledContainer) error {
+	p.lock.Lock()
+	c.users--
+	if c.users > 0 {
+		p.lock.Unlock()
+		return nil
+	}
+	if p.containers[key] == c {
+		delete(p.containers, key)
+	}
+	p.lock.Unlock()
+
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer(ctx)
+}
+
+func poolKey(image, currentDir string, mountDirs []string) string {
+	dirs := append([]string{currentDir}, mountDirs...)
+	sort.Strings(dirs)
+	return image + "|" + strings.Join(dirs, ",")
+}
@@ -0,0 +1,161 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestExecutablesPoolSharesContainer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	image := "cli-tools:v1"
+
+	docker.EXPECT().PullImage(ctx, image).Return(nil).Times(1)
+	docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(1)
+
+	pool := executables.NewExecutablesPool(docker)
+	var wg sync.WaitGroup
+	builders := make([]*executables.ExecutablesBuilder, 5)
+	closers := make([]executables.Closer, 5)
+	for i := range builders {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b, closer, err := pool.Get(ctx, image)
+			g.Expect(err).NotTo(HaveOccurred())
+			builders[i] = b
+			closers[i] = closer
+		}(i)
+	}
+	wg.Wait()
+
+	for _, b := range builders {
+		g.Expect(b).To(BeIdenticalTo(builders[0]))
+	}
+
+	for _, closer := range closers[1:] {
+		g.Expect(closer(ctx)).To(Succeed())
+	}
+	// Calling a closer twice doesn't release the container twice.
+	g.Expect(closers[1](ctx)).To(Succeed())
+
+	docker.EXPECT().Execute(ctx, "rm", "-f", "-v", gomock.Any()).Return(bytes.Buffer{}, nil).Times(1)
+	g.Expect(closers[0](ctx)).To(Succeed())
+}
+
+func TestExecutablesPoolNewContainerAfterRelease(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	image := "cli-tools:v1"
+
+	docker.EXPECT().PullImage(ctx, image).Return(nil).Times(2)
+	docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(4)
+
+	pool := executables.NewExecutablesPool(docker)
+	first, closer, err := pool.Get(ctx, image)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(closer(ctx)).To(Succeed())
+
+	second, closer, err := pool.Get(ctx, image)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(second).NotTo(BeIdenticalTo(first))
+	g.Expect(closer(ctx)).To(Succeed())
+}
+
+func TestExecutablesPoolDifferentImages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+
+	docker.EXPECT().PullImage(ctx, gomock.Any()).Return(nil).Times(2)
+	docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(2)
+
+	pool := executables.NewExecutablesPool(docker)
+	first, _, err := pool.Get(ctx, "cli-tools:v1")
+	g.Expect(err).NotTo(HaveOccurred())
+	second, _, err := pool.Get(ctx, "cli-tools:v2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(second).NotTo(BeIdenticalTo(first))
+}
+
+func TestExecutablesPoolInitDoesNotBlockOtherImages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	pulling := make(chan struct{})
+	pulled := make(chan struct{})
+
+	docker.EXPECT().PullImage(ctx, "cli-tools:v1").DoAndReturn(func(context.Context, string) error {
+		close(pulling)
+		<-pulled
+		return nil
+	})
+	docker.EXPECT().PullImage(ctx, "cli-tools:v2").Return(nil)
+	docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(2)
+
+	pool := executables.NewExecutablesPool(docker)
+	done := make(chan error)
+	go func() {
+		_, _, err := pool.Get(ctx, "cli-tools:v1")
+		done <- err
+	}()
+	<-pulling
+
+	// v1 is still starting, v2 doesn't wait for it.
+	_, _, err := pool.Get(ctx, "cli-tools:v2")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	close(pulled)
+	g.Expect(<-done).To(Succeed())
+}
+
+func TestExecutablesPoolInitErrorRetried(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	image := "cli-tools:v1"
+
+	docker.EXPECT().PullImage(ctx, image).Return(nil).Times(2)
+	gomock.InOrder(
+		docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("docker not running")),
+		docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil),
+	)
+
+	pool := executables.NewExecutablesPool(docker)
+	_, _, err := pool.Get(ctx, image)
+	g.Expect(err).To(MatchError("docker not running"))
+
+	_, _, err = pool.Get(ctx, image)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestExecutablesPoolInitError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	docker := mocks.NewMockDockerClient(ctrl)
+	image := "cli-tools:v1"
+
+	docker.EXPECT().PullImage(ctx, image).Return(nil)
+	docker.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("docker not running"))
+
+	pool := executables.NewExecutablesPool(docker)
+	_, _, err := pool.Get(ctx, image)
+	g.Expect(err).To(MatchError("docker not running"))
+}
@@ -0,0 +1,31 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+)
+
+const sopsPath = "sops"
+
+// Sops is an executable for decrypting SOPS encrypted files.
+type Sops struct {
+	Executable
+}
+
+// NewSops returns a new instance of Sops.
+func NewSops(executable Executable) *Sops {
+	return &Sops{
+		Executable: executable,
+	}
+}
+
+// Decrypt decrypts file and returns its content as JSON, independently of the format of the file.
+// The decryption keys are read by sops from its usual configuration in the environment.
+func (s *Sops) Decrypt(ctx context.Context, file string) ([]byte, error) {
+	out, err := s.Execute(ctx, "--decrypt", "--output-type", "json", file)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s with sops: %v", file, err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,39 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestSopsDecryptSuccess(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	sops := executables.NewSops(executable)
+
+	executable.EXPECT().Execute(ctx, "--decrypt", "--output-type", "json", "creds.enc.yaml").Return(*bytes.NewBufferString(`{"password":"pass"}`), nil)
+
+	out, err := sops.Decrypt(ctx, "creds.enc.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).To(Equal(`{"password":"pass"}`))
+}
+
+func TestSopsDecryptError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	sops := executables.NewSops(executable)
+
+	executable.EXPECT().Execute(ctx, "--decrypt", "--output-type", "json", "creds.enc.yaml").Return(bytes.Buffer{}, errors.New("no key could decrypt the data key"))
+
+	_, err := sops.Decrypt(ctx, "creds.enc.yaml")
+	g.Expect(err).To(MatchError("decrypting creds.enc.yaml with sops: no key could decrypt the data key"))
+}
@@ -0,0 +1,114 @@
+package externalsecrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+)
+
+// SecretsManagerClientFactory builds an AWS Secrets Manager client for a region. An empty region
+// means the region configured in the environment.
+type SecretsManagerClientFactory func(region string) (secretsmanageriface.SecretsManagerAPI, error)
+
+// NewSecretsManagerClient builds an AWS Secrets Manager client with the default credentials chain
+// and the shared config of the admin machine.
+func NewSecretsManagerClient(region string) (secretsmanageriface.SecretsManagerAPI, error) {
+	opts := session.Options{SharedConfigState: session.SharedConfigEnable}
+	if region != "" {
+		opts.Config.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session: %v", err)
+	}
+
+	return secretsmanager.New(sess), nil
+}
+
+// AWSSecretsManager reads secrets from AWS Secrets Manager.
+type AWSSecretsManager struct {
+	newClient SecretsManagerClientFactory
+}
+
+// NewAWSSecretsManager builds an AWSSecretsManager. Clients are only built when a secret is read,
+// so the AWS credentials aren't required unless a reference to AWS Secrets Manager is used.
+func NewAWSSecretsManager(newClient SecretsManagerClientFactory) *AWSSecretsManager {
+	return &AWSSecretsManager{
+		newClient: newClient,
+	}
+}
+
+// Read returns the secret string of the referenced secret or, if the reference has a key, the value of
+// that key in the secret string parsed as a JSON object. Secrets referenced by ARN are read from the
+// region in the ARN.
+func (a *AWSSecretsManager) Read(ctx context.Context, ref *Ref) (string, error) {
+	var region string
+	if parsed, err := arn.Parse(ref.Location); err == nil {
+		region = parsed.Region
+	}
+
+	client, err := a.newClient(region)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref.Location),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch {
+	case out.SecretString != nil:
+		value = *out.SecretString
+	case out.SecretBinary != nil:
+		value = string(out.SecretBinary)
+	}
+
+	if ref.Key == "" {
+		return value, nil
+	}
+
+	return jsonKey([]byte(value), ref.Key)
+}
+
+// jsonKey returns the value of a dot separated key path in a JSON object.
+func jsonKey(data []byte, key string) (string, error) {
+	var obj interface{}
+	// Numbers are decoded as json.Number so they are returned as they were written.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object: %v", err)
+	}
+
+	for _, k := range strings.Split(key, ".") {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("key %s not found in secret", key)
+		}
+		if obj, ok = m[k]; !ok {
+			return "", fmt.Errorf("key %s not found in secret", key)
+		}
+	}
+
+	switch v := obj.(type) {
+	case string:
+		return v, nil
+	case map[string]interface{}, []interface{}:
+		return "", fmt.Errorf("key %s in secret is not a scalar value", key)
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
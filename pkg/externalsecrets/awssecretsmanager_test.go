@@ -0,0 +1,124 @@
+package externalsecrets_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+)
+
+type fakeSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	secrets map[string]*secretsmanager.GetSecretValueOutput
+}
+
+func (f *fakeSecretsManager) GetSecretValueWithContext(_ aws.Context, in *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	out, ok := f.secrets[*in.SecretId]
+	if !ok {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	return out, nil
+}
+
+func newAWSSecretsManagerTest(regions *[]string, secrets map[string]*secretsmanager.GetSecretValueOutput) *externalsecrets.AWSSecretsManager {
+	return externalsecrets.NewAWSSecretsManager(func(region string) (secretsmanageriface.SecretsManagerAPI, error) {
+		*regions = append(*regions, region)
+		return &fakeSecretsManager{secrets: secrets}, nil
+	})
+}
+
+func TestAWSSecretsManagerRead(t *testing.T) {
+	arn := "arn:aws:secretsmanager:eu-west-1:123456789012:secret:eksa-AbCdEf"
+	secrets := map[string]*secretsmanager.GetSecretValueOutput{
+		"eksa/plain":  {SecretString: aws.String("hunter2")},
+		"eksa/binary": {SecretBinary: []byte("binary-pass")},
+		arn:           {SecretString: aws.String(`{"vsphere":{"username":"admin","port":8443}}`)},
+	}
+	tests := []struct {
+		name       string
+		ref        string
+		want       string
+		wantRegion string
+		wantErr    string
+	}{
+		{
+			name: "secret string",
+			ref:  "aws-secretsmanager://eksa/plain",
+			want: "hunter2",
+		},
+		{
+			name: "secret binary",
+			ref:  "aws-secretsmanager://eksa/binary",
+			want: "binary-pass",
+		},
+		{
+			name:       "nested key from arn",
+			ref:        "aws-secretsmanager://" + arn + "#vsphere.username",
+			want:       "admin",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:       "number key",
+			ref:        "aws-secretsmanager://" + arn + "#vsphere.port",
+			want:       "8443",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:       "missing key",
+			ref:        "aws-secretsmanager://" + arn + "#password",
+			wantErr:    "key password not found in secret",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:       "object key",
+			ref:        "aws-secretsmanager://" + arn + "#vsphere",
+			wantErr:    "key vsphere in secret is not a scalar value",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:    "key in plain secret",
+			ref:     "aws-secretsmanager://eksa/plain#password",
+			wantErr: "secret is not a JSON object",
+		},
+		{
+			name:    "missing secret",
+			ref:     "aws-secretsmanager://eksa/missing",
+			wantErr: "ResourceNotFoundException",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var regions []string
+			sm := newAWSSecretsManagerTest(&regions, secrets)
+			ref, err := externalsecrets.ParseRef(tt.ref)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := sm.Read(context.Background(), ref)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(got).To(Equal(tt.want))
+			}
+			g.Expect(regions).To(Equal([]string{tt.wantRegion}))
+		})
+	}
+}
+
+func TestAWSSecretsManagerReadClientError(t *testing.T) {
+	g := NewWithT(t)
+	sm := externalsecrets.NewAWSSecretsManager(func(string) (secretsmanageriface.SecretsManagerAPI, error) {
+		return nil, errors.New("creating aws session: no credentials")
+	})
+
+	_, err := sm.Read(context.Background(), &externalsecrets.Ref{Scheme: "aws-secretsmanager", Location: "eksa"})
+	g.Expect(err).To(MatchError("creating aws session: no credentials"))
+}
@@ -0,0 +1,175 @@
+package externalsecrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	// AWSSecretsManagerScheme is the scheme of the references to AWS Secrets Manager secrets,
+	// aws-secretsmanager://<secret name or ARN>[#<key>].
+	AWSSecretsManagerScheme = v1alpha1.AWSSecretsManagerSecretRefScheme
+	// VaultScheme is the scheme of the references to Vault secrets, vault://<secret path>#<key>.
+	VaultScheme = v1alpha1.VaultSecretRefScheme
+	// SOPSScheme is the scheme of the references to values in SOPS encrypted files, sops://<file path>#<key>.
+	SOPSScheme = v1alpha1.SOPSSecretRefScheme
+)
+
+// providerCredentials are the environment variables the providers read their credentials from.
+var providerCredentials = map[string]struct{}{
+	config.EksavSphereUsernameKey:                 {},
+	config.EksavSpherePasswordKey:                 {},
+	config.EksavSphereCPUsernameKey:               {},
+	config.EksavSphereCPPasswordKey:               {},
+	decoder.EksacloudStackCloudConfigB64SecretKey: {},
+	constants.EksaNutanixUsernameKey:              {},
+	constants.EksaNutanixPasswordKey:              {},
+}
+
+// Ref is a reference to a value stored in an external secret manager.
+type Ref struct {
+	Scheme string
+	// Location is the secret name or ARN in AWS Secrets Manager, the secret path in Vault
+	// and the file path for SOPS.
+	Location string
+	// Key is the field of the secret holding the value. It's optional for AWS Secrets Manager,
+	// where the whole secret string is used if not set.
+	Key string
+}
+
+func (r *Ref) String() string {
+	if r.Key == "" {
+		return r.Scheme + "://" + r.Location
+	}
+	return r.Scheme + "://" + r.Location + "#" + r.Key
+}
+
+// IsRef returns true if value is a reference to an external secret.
+func IsRef(value string) bool {
+	return v1alpha1.IsSecretRef(value)
+}
+
+// ParseRef parses a reference with the format <scheme>://<location>[#<key>].
+// The location isn't parsed as a URL since ARNs and file paths aren't valid hosts.
+func ParseRef(value string) (*Ref, error) {
+	if !IsRef(value) {
+		return nil, fmt.Errorf("invalid secret reference %s, it should start with %s://, %s:// or %s://",
+			value, AWSSecretsManagerScheme, VaultScheme, SOPSScheme)
+	}
+
+	scheme, rest, _ := strings.Cut(value, "://")
+	ref := &Ref{Scheme: scheme, Location: rest}
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		ref.Location, ref.Key = rest[:i], rest[i+1:]
+	}
+
+	if ref.Location == "" {
+		return nil, fmt.Errorf("invalid secret reference %s, location can't be empty", value)
+	}
+
+	if ref.Key == "" && scheme != AWSSecretsManagerScheme {
+		return nil, fmt.Errorf("invalid secret reference %s, a key is required for %s references", value, scheme)
+	}
+
+	return ref, nil
+}
+
+// Backend reads values from a secret manager.
+type Backend interface {
+	Read(ctx context.Context, ref *Ref) (string, error)
+}
+
+// Resolver resolves references to external secrets with the backend of their scheme.
+type Resolver struct {
+	backends map[string]Backend
+}
+
+// ResolverOpt allows to customize a Resolver.
+type ResolverOpt func(*Resolver)
+
+// WithBackend sets the backend used to resolve the references with scheme.
+func WithBackend(scheme string, backend Backend) ResolverOpt {
+	return func(r *Resolver) {
+		r.backends[scheme] = backend
+	}
+}
+
+// NewResolver builds a Resolver.
+func NewResolver(opts ...ResolverOpt) *Resolver {
+	r := &Resolver{
+		backends: map[string]Backend{},
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// NewDefaultResolver builds a Resolver for all the supported secret managers. AWS clients use the
+// default credentials chain, Vault is configured with the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE
+// environment variables and SOPS files are decrypted with the sops binary in the admin machine.
+func NewDefaultResolver(sops SOPSClient) *Resolver {
+	return NewResolver(
+		WithBackend(AWSSecretsManagerScheme, NewAWSSecretsManager(NewSecretsManagerClient)),
+		WithBackend(VaultScheme, NewVaultFromEnv()),
+		WithBackend(SOPSScheme, NewSOPS(sops)),
+	)
+}
+
+// Resolve returns the value referenced by ref.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	parsed, err := ParseRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	backend, ok := r.backends[parsed.Scheme]
+	if !ok {
+		return "", fmt.Errorf("no backend configured for %s secret references", parsed.Scheme)
+	}
+
+	value, err := backend.Read(ctx, parsed)
+	if err != nil {
+		return "", fmt.Errorf("reading secret %s: %v", ref, err)
+	}
+
+	return value, nil
+}
+
+// ResolveProviderCredentials resolves the provider credentials referenced by cluster and sets them in
+// the environment variables the providers read them from. Credentials already set in the environment
+// take precedence over the cluster references, so they can still be overridden in the admin machine.
+func ResolveProviderCredentials(ctx context.Context, resolver *Resolver, cluster *v1alpha1.Cluster) error {
+	for _, c := range cluster.Spec.ProviderCredentials {
+		if _, ok := providerCredentials[c.Name]; !ok {
+			return fmt.Errorf("%s is not a supported provider credential", c.Name)
+		}
+
+		if v, ok := os.LookupEnv(c.Name); ok && v != "" {
+			logger.V(4).Info("Provider credential already set in the environment, skipping secret reference", "credential", c.Name)
+			continue
+		}
+
+		value, err := resolver.Resolve(ctx, c.ValueFrom)
+		if err != nil {
+			return fmt.Errorf("resolving provider credential %s: %v", c.Name, err)
+		}
+
+		if err = os.Setenv(c.Name, value); err != nil {
+			return fmt.Errorf("setting provider credential %s: %v", c.Name, err)
+		}
+		logger.V(4).Info("Provider credential read from external secret", "credential", c.Name)
+	}
+
+	return nil
+}
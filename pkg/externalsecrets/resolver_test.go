@@ -0,0 +1,154 @@
+package externalsecrets_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+)
+
+type fakeBackend map[string]string
+
+func (f fakeBackend) Read(_ context.Context, ref *externalsecrets.Ref) (string, error) {
+	value, ok := f[ref.String()]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		want    *externalsecrets.Ref
+		wantErr string
+	}{
+		{
+			name: "aws secret name without key",
+			ref:  "aws-secretsmanager://eksa/vsphere-password",
+			want: &externalsecrets.Ref{Scheme: "aws-secretsmanager", Location: "eksa/vsphere-password"},
+		},
+		{
+			name: "aws secret arn with key",
+			ref:  "aws-secretsmanager://arn:aws:secretsmanager:us-west-2:123456789012:secret:eksa-AbCdEf#password",
+			want: &externalsecrets.Ref{
+				Scheme:   "aws-secretsmanager",
+				Location: "arn:aws:secretsmanager:us-west-2:123456789012:secret:eksa-AbCdEf",
+				Key:      "password",
+			},
+		},
+		{
+			name: "vault",
+			ref:  "vault://secret/data/eksa#vsphere.password",
+			want: &externalsecrets.Ref{Scheme: "vault", Location: "secret/data/eksa", Key: "vsphere.password"},
+		},
+		{
+			name: "sops",
+			ref:  "sops:///home/admin/creds.enc.yaml#password",
+			want: &externalsecrets.Ref{Scheme: "sops", Location: "/home/admin/creds.enc.yaml", Key: "password"},
+		},
+		{
+			name:    "plaintext",
+			ref:     "hunter2",
+			wantErr: "invalid secret reference hunter2",
+		},
+		{
+			name:    "empty location",
+			ref:     "vault://#password",
+			wantErr: "location can't be empty",
+		},
+		{
+			name:    "vault without key",
+			ref:     "vault://secret/data/eksa",
+			wantErr: "a key is required for vault references",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := externalsecrets.ParseRef(tt.ref)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+			g.Expect(got.String()).To(Equal(tt.ref))
+		})
+	}
+}
+
+func TestResolverResolve(t *testing.T) {
+	g := NewWithT(t)
+	r := externalsecrets.NewResolver(
+		externalsecrets.WithBackend(externalsecrets.VaultScheme, fakeBackend{"vault://secret/data/eksa#password": "pass"}),
+	)
+
+	g.Expect(r.Resolve(context.Background(), "vault://secret/data/eksa#password")).To(Equal("pass"))
+
+	_, err := r.Resolve(context.Background(), "vault://secret/data/eksa#username")
+	g.Expect(err).To(MatchError("reading secret vault://secret/data/eksa#username: secret not found"))
+
+	_, err = r.Resolve(context.Background(), "sops://creds.enc.yaml#password")
+	g.Expect(err).To(MatchError("no backend configured for sops secret references"))
+}
+
+func TestResolveProviderCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("EKSA_VSPHERE_USERNAME", "")
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "from-env")
+	r := externalsecrets.NewResolver(
+		externalsecrets.WithBackend(externalsecrets.SOPSScheme, fakeBackend{
+			"sops://creds.enc.yaml#username": "admin",
+			"sops://creds.enc.yaml#password": "from-sops",
+		}),
+	)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ProviderCredentials: []v1alpha1.ProviderCredential{
+				{Name: "EKSA_VSPHERE_USERNAME", ValueFrom: "sops://creds.enc.yaml#username"},
+				{Name: "EKSA_VSPHERE_PASSWORD", ValueFrom: "sops://creds.enc.yaml#password"},
+			},
+		},
+	}
+
+	g.Expect(externalsecrets.ResolveProviderCredentials(context.Background(), r, cluster)).To(Succeed())
+	g.Expect(os.Getenv("EKSA_VSPHERE_USERNAME")).To(Equal("admin"))
+	g.Expect(os.Getenv("EKSA_VSPHERE_PASSWORD")).To(Equal("from-env"), "credentials in the environment should take precedence")
+}
+
+func TestResolveProviderCredentialsUnsupported(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ProviderCredentials: []v1alpha1.ProviderCredential{
+				{Name: "LD_PRELOAD", ValueFrom: "sops://creds.enc.yaml#lib"},
+			},
+		},
+	}
+
+	err := externalsecrets.ResolveProviderCredentials(context.Background(), externalsecrets.NewResolver(), cluster)
+	g.Expect(err).To(MatchError("LD_PRELOAD is not a supported provider credential"))
+}
+
+func TestResolveProviderCredentialsError(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("EKSA_NUTANIX_PASSWORD", "")
+	r := externalsecrets.NewResolver(externalsecrets.WithBackend(externalsecrets.VaultScheme, fakeBackend{}))
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ProviderCredentials: []v1alpha1.ProviderCredential{
+				{Name: "EKSA_NUTANIX_PASSWORD", ValueFrom: "vault://secret/data/nutanix#password"},
+			},
+		},
+	}
+
+	err := externalsecrets.ResolveProviderCredentials(context.Background(), r, cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("resolving provider credential EKSA_NUTANIX_PASSWORD: reading secret vault://secret/data/nutanix#password: secret not found")))
+}
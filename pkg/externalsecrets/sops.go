@@ -0,0 +1,47 @@
+package externalsecrets
+
+import (
+	"context"
+	"sync"
+)
+
+// SOPSClient decrypts SOPS encrypted files.
+type SOPSClient interface {
+	// Decrypt returns the content of the decrypted file as JSON.
+	Decrypt(ctx context.Context, file string) ([]byte, error)
+}
+
+// SOPS reads values from SOPS encrypted files.
+type SOPS struct {
+	client SOPSClient
+
+	mu sync.Mutex
+	// files caches the decrypted files, so a file referenced by multiple credentials is only decrypted once.
+	files map[string][]byte
+}
+
+// NewSOPS builds a SOPS.
+func NewSOPS(client SOPSClient) *SOPS {
+	return &SOPS{
+		client: client,
+		files:  map[string][]byte{},
+	}
+}
+
+// Read returns the value of the reference key, a dot separated path, in the decrypted file.
+func (s *SOPS) Read(ctx context.Context, ref *Ref) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.files[ref.Location]
+	if !ok {
+		var err error
+		data, err = s.client.Decrypt(ctx, ref.Location)
+		if err != nil {
+			return "", err
+		}
+		s.files[ref.Location] = data
+	}
+
+	return jsonKey(data, ref.Key)
+}
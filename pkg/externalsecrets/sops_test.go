@@ -0,0 +1,35 @@
+package externalsecrets_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+	"github.com/aws/eks-anywhere/pkg/externalsecrets/mocks"
+)
+
+func TestSOPSRead(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockSOPSClient(gomock.NewController(t))
+	s := externalsecrets.NewSOPS(client)
+	client.EXPECT().Decrypt(ctx, "creds.enc.yaml").Return([]byte(`{"vsphere":{"username":"admin","password":"pass"}}`), nil)
+
+	g.Expect(s.Read(ctx, &externalsecrets.Ref{Location: "creds.enc.yaml", Key: "vsphere.username"})).To(Equal("admin"))
+	g.Expect(s.Read(ctx, &externalsecrets.Ref{Location: "creds.enc.yaml", Key: "vsphere.password"})).To(Equal("pass"), "the decrypted file should be cached")
+}
+
+func TestSOPSReadDecryptError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockSOPSClient(gomock.NewController(t))
+	s := externalsecrets.NewSOPS(client)
+	client.EXPECT().Decrypt(ctx, "creds.enc.yaml").Return(nil, errors.New("decrypting creds.enc.yaml with sops: no key"))
+
+	_, err := s.Read(ctx, &externalsecrets.Ref{Location: "creds.enc.yaml", Key: "password"})
+	g.Expect(err).To(MatchError("decrypting creds.enc.yaml with sops: no key"))
+}
@@ -0,0 +1,101 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	vaultAddrEnv      = "VAULT_ADDR"
+	vaultTokenEnv     = "VAULT_TOKEN"
+	vaultNamespaceEnv = "VAULT_NAMESPACE"
+
+	vaultRequestTimeout = 30 * time.Second
+)
+
+// Vault reads secrets from the Vault KV secrets engine, version 1 or 2, through the Vault HTTP API.
+type Vault struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+}
+
+// NewVault builds a Vault. namespace is optional and only used by Vault Enterprise.
+func NewVault(client *http.Client, address, token, namespace string) *Vault {
+	return &Vault{
+		client:    client,
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		namespace: namespace,
+	}
+}
+
+// NewVaultFromEnv builds a Vault configured with the same environment variables as the vault CLI.
+func NewVaultFromEnv() *Vault {
+	client := &http.Client{Timeout: vaultRequestTimeout}
+	return NewVault(client, os.Getenv(vaultAddrEnv), os.Getenv(vaultTokenEnv), os.Getenv(vaultNamespaceEnv))
+}
+
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// Read returns the value of the reference key in the secret at the reference path. For the KV
+// version 2 engine, the path must include the data segment, like secret/data/eksa.
+func (v *Vault) Read(ctx context.Context, ref *Ref) (string, error) {
+	if v.address == "" || v.token == "" {
+		return "", fmt.Errorf("%s and %s must be set to read secrets from vault", vaultAddrEnv, vaultTokenEnv)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+strings.TrimPrefix(ref.Location, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("building vault request: %v", err)
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting vault: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading vault response: %v", err)
+	}
+
+	secret := &vaultResponse{}
+	if err = json.Unmarshal(body, secret); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("parsing vault response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(secret.Errors, ", "))
+	}
+
+	data := secret.Data
+	// KV version 2 nests the secret data and adds its metadata.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret data: %v", err)
+	}
+
+	return jsonKey(raw, ref.Key)
+}
@@ -0,0 +1,93 @@
+package externalsecrets_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+)
+
+func newVaultServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/eksa":
+			g := NewWithT(t)
+			g.Expect(r.Header.Get("X-Vault-Namespace")).To(Equal("team"))
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"kv2-pass"},"metadata":{"version":3}}}`))
+		case "/v1/kv/eksa":
+			_, _ = w.Write([]byte(`{"data":{"password":"kv1-pass"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultReadKV2(t *testing.T) {
+	g := NewWithT(t)
+	server := newVaultServer(t)
+	v := externalsecrets.NewVault(server.Client(), server.URL+"/", "token", "team")
+
+	g.Expect(v.Read(context.Background(), &externalsecrets.Ref{Location: "secret/data/eksa", Key: "password"})).To(Equal("kv2-pass"))
+}
+
+func TestVaultReadKV1(t *testing.T) {
+	g := NewWithT(t)
+	server := newVaultServer(t)
+	v := externalsecrets.NewVault(server.Client(), server.URL, "token", "")
+
+	g.Expect(v.Read(context.Background(), &externalsecrets.Ref{Location: "/kv/eksa", Key: "password"})).To(Equal("kv1-pass"))
+}
+
+func TestVaultReadErrors(t *testing.T) {
+	server := newVaultServer(t)
+	tests := []struct {
+		name    string
+		vault   *externalsecrets.Vault
+		ref     *externalsecrets.Ref
+		wantErr string
+	}{
+		{
+			name:    "forbidden",
+			vault:   externalsecrets.NewVault(server.Client(), server.URL, "wrong", ""),
+			ref:     &externalsecrets.Ref{Location: "kv/eksa", Key: "password"},
+			wantErr: "vault returned status 403: permission denied",
+		},
+		{
+			name:    "not found",
+			vault:   externalsecrets.NewVault(server.Client(), server.URL, "token", ""),
+			ref:     &externalsecrets.Ref{Location: "kv/missing", Key: "password"},
+			wantErr: "vault returned status 404",
+		},
+		{
+			name:    "missing key",
+			vault:   externalsecrets.NewVault(server.Client(), server.URL, "token", ""),
+			ref:     &externalsecrets.Ref{Location: "kv/eksa", Key: "username"},
+			wantErr: "key username not found in secret",
+		},
+		{
+			name:    "not configured",
+			vault:   externalsecrets.NewVault(server.Client(), "", "", ""),
+			ref:     &externalsecrets.Ref{Location: "kv/eksa", Key: "password"},
+			wantErr: "VAULT_ADDR and VAULT_TOKEN must be set to read secrets from vault",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := tt.vault.Read(context.Background(), tt.ref)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
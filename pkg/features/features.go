@@ -9,6 +9,7 @@ const (
 	ExperimentalSelfManagedClusterUpgradeEnvVar = "EXP_SELF_MANAGED_API_UPGRADE"
 	ExperimentalSelfManagedClusterUpgradeGate   = "ExpSelfManagedAPIUpgrade"
 	K8s128SupportEnvVar                         = "K8S_1_28_SUPPORT"
+	TinkerbellConsoleLogsEnvVar                 = "TINKERBELL_CONSOLE_LOGS"
 )
 
 func FeedGates(featureGates []string) {
@@ -68,3 +69,11 @@ func K8s128Support() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(K8s128SupportEnvVar),
 	}
 }
+
+// TinkerbellConsoleLogs is the feature flag for capturing serial-over-LAN console logs during Tinkerbell provisioning.
+func TinkerbellConsoleLogs() Feature {
+	return Feature{
+		Name:     "Tinkerbell serial-over-LAN console log capture",
+		IsActive: globalFeatures.isActiveForEnvVar(TinkerbellConsoleLogsEnvVar),
+	}
+}
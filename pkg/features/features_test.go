@@ -77,3 +77,11 @@ func TestWithK8s128FeatureFlag(t *testing.T) {
 	g.Expect(os.Setenv(K8s128SupportEnvVar, "true")).To(Succeed())
 	g.Expect(IsActive(K8s128Support())).To(BeTrue())
 }
+
+func TestWithTinkerbellConsoleLogsFeatureFlag(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	g.Expect(os.Setenv(TinkerbellConsoleLogsEnvVar, "true")).To(Succeed())
+	g.Expect(IsActive(TinkerbellConsoleLogs())).To(BeTrue())
+}
@@ -25,6 +25,8 @@ type FluxClient interface {
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	SuspendKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	ResumeKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 }
 
 // KubeClient is an interface that abstracts the basic commands of kubectl executable.
@@ -81,6 +83,22 @@ func (c *fluxClient) Reconcile(ctx context.Context, cluster *types.Cluster, flux
 	)
 }
 
+func (c *fluxClient) SuspendKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.SuspendKustomization(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) ResumeKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.ResumeKustomization(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
 func (c *fluxClient) ForceReconcile(ctx context.Context, cluster *types.Cluster, namespace string) error {
 	annotations := map[string]string{
 		"reconcile.fluxcd.io/requestedAt": strconv.FormatInt(time.Now().Unix(), 10),
@@ -144,6 +144,38 @@ func TestFluxClientReconcileError(t *testing.T) {
 	tt.Expect(tt.c.Reconcile(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in reconcile")), "fluxClient.Reconcile() should fail after 5 tries")
 }
 
+func TestFluxClientSuspendKustomizationSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in suspend")).Times(4)
+	tt.f.EXPECT().SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.SuspendKustomization() should succeed with 5 tries")
+}
+
+func TestFluxClientSuspendKustomizationError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in suspend")).Times(5)
+	tt.f.EXPECT().SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.SuspendKustomization(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in suspend")), "fluxClient.SuspendKustomization() should fail after 5 tries")
+}
+
+func TestFluxClientResumeKustomizationSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in resume")).Times(4)
+	tt.f.EXPECT().ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.ResumeKustomization() should succeed with 5 tries")
+}
+
+func TestFluxClientResumeKustomizationError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in resume")).Times(5)
+	tt.f.EXPECT().ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.ResumeKustomization(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in resume")), "fluxClient.ResumeKustomization() should fail after 5 tries")
+}
+
 func TestFluxClientForceReconcileSuccess(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.k.EXPECT().UpdateAnnotation(tt.ctx, "gitrepositories", "flux-system", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error in force reconcile")).Times(4)
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
@@ -19,6 +20,28 @@ const (
 	fluxPatchFileName     = "gotk-patches.yaml"
 )
 
+// eksaKinds are the kinds of the eks-a objects that can be written to the cluster config file.
+var eksaKinds = []string{
+	v1alpha1.AWSIamConfigKind,
+	v1alpha1.CloudStackDatacenterKind,
+	v1alpha1.CloudStackMachineConfigKind,
+	v1alpha1.ClusterKind,
+	v1alpha1.DockerDatacenterKind,
+	v1alpha1.FluxConfigKind,
+	v1alpha1.GitOpsConfigKind,
+	v1alpha1.NutanixDatacenterKind,
+	v1alpha1.NutanixMachineConfigKind,
+	v1alpha1.OIDCConfigKind,
+	v1alpha1.SnowDatacenterKind,
+	v1alpha1.SnowIPPoolKind,
+	v1alpha1.SnowMachineConfigKind,
+	v1alpha1.TinkerbellDatacenterKind,
+	v1alpha1.TinkerbellMachineConfigKind,
+	v1alpha1.TinkerbellTemplateConfigKind,
+	v1alpha1.VSphereDatacenterKind,
+	v1alpha1.VSphereMachineConfigKind,
+}
+
 //go:embed manifests/eksa-system/kustomization.yaml
 var eksaKustomizeContent string
 
@@ -85,7 +108,7 @@ func (g *FileGenerator) WriteEksaFiles(clusterSpec *cluster.Spec, datacenterConf
 		return err
 	}
 
-	if err := g.WriteEksaKustomization(); err != nil {
+	if err := g.WriteEksaKustomization(clusterSpec); err != nil {
 		return err
 	}
 
@@ -120,9 +143,12 @@ func (g *FileGenerator) WriteClusterConfig(clusterSpec *cluster.Spec, datacenter
 	return nil
 }
 
-func (g *FileGenerator) WriteEksaKustomization() error {
-	values := map[string]string{
-		"ConfigFileName": clusterConfigFileName,
+// WriteEksaKustomization writes the kustomization for the eks-a system directory. The kinds with
+// a drift remediation policy other than remediate are patched to be skipped by Flux reconciliation.
+func (g *FileGenerator) WriteEksaKustomization(clusterSpec *cluster.Spec) error {
+	values := map[string]interface{}{
+		"ConfigFileName":    clusterConfigFileName,
+		"UnreconciledKinds": unreconciledKinds(clusterSpec.FluxConfig),
 	}
 
 	if path, err := g.eksaTemplater.WriteToFile(eksaKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
@@ -131,6 +157,22 @@ func (g *FileGenerator) WriteEksaKustomization() error {
 	return nil
 }
 
+// unreconciledKinds returns the eks-a kinds that Flux shouldn't reconcile based on the
+// drift remediation policies.
+func unreconciledKinds(fluxConfig *v1alpha1.FluxConfig) []string {
+	if fluxConfig == nil {
+		return nil
+	}
+
+	var kinds []string
+	for _, kind := range eksaKinds {
+		if fluxConfig.Spec.DriftRemediation.PolicyFor(kind) != v1alpha1.DriftRemediate {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
 func (g *FileGenerator) WriteFluxKustomization(clusterSpec *cluster.Spec) error {
 	values := map[string]string{
 		"Namespace": clusterSpec.FluxConfig.Spec.SystemNamespace,
@@ -3,12 +3,14 @@ package flux_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	writerMocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
@@ -84,7 +86,27 @@ spec:
 var wantEksaKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
 resources:
-- {{.ConfigFileName}}`
+- {{.ConfigFileName}}
+{{- if .UnreconciledKinds}}
+patches:
+{{- range .UnreconciledKinds}}
+- target:
+    group: anywhere.eks.amazonaws.com
+    kind: {{.}}
+  patch: |-
+    apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+    kind: {{.}}
+    metadata:
+      name: not-used
+      annotations:
+        kustomize.toolkit.fluxcd.io/reconcile: disabled
+{{- end}}
+{{- end}}`
+
+var wantEksaKustomizationValues = map[string]interface{}{
+	"ConfigFileName":    "eksa-cluster.yaml",
+	"UnreconciledKinds": []string(nil),
+}
 
 var wantFluxKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
@@ -211,7 +233,7 @@ func TestFileGeneratorWriteEksaFilesSuccess(t *testing.T) {
 	tt := newFileGeneratorTest(t)
 
 	tt.w.EXPECT().Write("eksa-cluster.yaml", []byte(wantConfig), gomock.Any()).Return("", nil)
-	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]string{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", nil)
+	tt.t.EXPECT().WriteToFile(wantEksaKustomization, wantEksaKustomizationValues, "kustomization.yaml", gomock.Any()).Return("", nil)
 
 	tt.Expect(tt.g.WriteEksaFiles(tt.clusterSpec, tt.datacenterConfig, tt.machineConfigs)).To(Succeed())
 }
@@ -234,7 +256,7 @@ func TestFileGeneratorWriteEksaFilesWriteToFileError(t *testing.T) {
 	tt := newFileGeneratorTest(t)
 
 	tt.w.EXPECT().Write("eksa-cluster.yaml", []byte(wantConfig), gomock.Any()).Return("", nil)
-	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]string{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", errors.New("error in write to file"))
+	tt.t.EXPECT().WriteToFile(wantEksaKustomization, wantEksaKustomizationValues, "kustomization.yaml", gomock.Any()).Return("", errors.New("error in write to file"))
 
 	tt.Expect(tt.g.WriteEksaFiles(tt.clusterSpec, tt.datacenterConfig, tt.machineConfigs)).To(MatchError(ContainSubstring("error in write to file")))
 }
@@ -294,3 +316,22 @@ func NewCluster(clusterName string) *anywherev1.Cluster {
 
 	return c
 }
+
+func TestFileGeneratorWriteEksaKustomizationDriftRemediation(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	generator := flux.NewFileGenerator()
+	g.Expect(generator.Init(w, "eksa-system", "flux-system")).To(Succeed())
+
+	clusterSpec := newClusterSpec(t, NewCluster("test-cluster"), "")
+	clusterSpec.FluxConfig.Spec.DriftRemediation = &anywherev1.DriftRemediationConfig{
+		Policy: anywherev1.DriftRemediate,
+		Overrides: []anywherev1.DriftRemediationOverride{
+			{Kind: anywherev1.ClusterKind, Policy: anywherev1.DriftAlert},
+			{Kind: anywherev1.VSphereMachineConfigKind, Policy: anywherev1.DriftIgnore},
+		},
+	}
+
+	g.Expect(generator.WriteEksaKustomization(clusterSpec)).To(Succeed())
+	test.AssertFilesEquals(t, filepath.Join(w.Dir(), "eksa-system", "kustomization.yaml"), "./testdata/kustomization-drift-remediation.yaml")
+}
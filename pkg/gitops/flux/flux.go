@@ -23,6 +23,7 @@ const (
 	initialClusterconfigCommitMessage = "Initial commit of cluster configuration; generated by EKS-A CLI"
 	updateClusterconfigCommitMessage  = "Update commit of cluster configuration; generated by EKS-A CLI"
 	deleteClusterconfigCommitMessage  = "Delete commit of cluster configuration; generated by EKS-A CLI"
+	renameClusterconfigCommitMessage  = "Rename commit of cluster configuration; generated by EKS-A CLI"
 )
 
 type GitOpsFluxClient interface {
@@ -34,6 +35,8 @@ type GitOpsFluxClient interface {
 	EnableResourceReconcile(ctx context.Context, cluster *types.Cluster, resourceType, objectName, namespace string) error
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	ForceReconcile(ctx context.Context, cluster *types.Cluster, namespace string) error
+	SuspendKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	ResumeKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	DeleteSystemSecret(ctx context.Context, cluster *types.Cluster, namespace string) error
 }
 
@@ -203,6 +206,38 @@ func (f *Flux) ResumeClusterResourcesReconcile(ctx context.Context, cluster *typ
 	return nil
 }
 
+// SuspendKustomization stops Flux from applying and pruning the objects in the git repository until
+// ResumeKustomization is called. It's used while the objects are changed in ways that can't be
+// expressed with a single commit, like renaming a cluster.
+func (f *Flux) SuspendKustomization(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.V(4).Info("GitOps field not specified, suspend kustomization skipped")
+		return nil
+	}
+
+	logger.V(3).Info("Suspend Flux kustomization")
+	if err := f.fluxClient.SuspendKustomization(ctx, cluster, clusterSpec.FluxConfig); err != nil {
+		return fmt.Errorf("suspending flux kustomization: %v", err)
+	}
+
+	return nil
+}
+
+// ResumeKustomization resumes the Flux kustomization suspended with SuspendKustomization.
+func (f *Flux) ResumeKustomization(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.V(4).Info("GitOps field not specified, resume kustomization skipped")
+		return nil
+	}
+
+	logger.V(3).Info("Resume Flux kustomization")
+	if err := f.fluxClient.ResumeKustomization(ctx, cluster, clusterSpec.FluxConfig); err != nil {
+		return fmt.Errorf("resuming flux kustomization: %v", err)
+	}
+
+	return nil
+}
+
 func (f *Flux) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if f.shouldSkipFlux() {
 		logger.Info("GitOps not configured, force reconcile flux git repo skipped")
@@ -300,6 +335,49 @@ func (f *Flux) CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) er
 	return nil
 }
 
+// RenameGitCluster moves the cluster config files of a cluster renamed from oldName to the name in
+// clusterSpec in a single commit, so Flux never sees both clusters or none of them.
+func (f *Flux) RenameGitCluster(ctx context.Context, oldName string, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, rename cluster in git repo skipped")
+		return nil
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteEksaFiles(clusterSpec, datacenterConfig, machineConfigs); err != nil {
+		return err
+	}
+
+	newPath := fc.eksaSystemDir()
+	if err := f.gitClient.Add(newPath); err != nil {
+		return fmt.Errorf("adding %s to git: %v", newPath, err)
+	}
+
+	oldPath := path.Join(fc.path(), oldName, eksaSystemDirName)
+	if validations.FileExists(path.Join(f.writer.Dir(), oldPath)) {
+		if err := f.gitClient.Remove(oldPath); err != nil {
+			return fmt.Errorf("removing %s in git: %v", oldPath, err)
+		}
+	}
+
+	if err := f.pushToRemoteRepo(ctx, newPath, renameClusterconfigCommitMessage); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished moving cluster config files in git", "from", oldPath, "to", newPath, "repository", fc.repository())
+	return nil
+}
+
 func (f *Flux) pushToRemoteRepo(ctx context.Context, path, msg string) error {
 	if err := f.gitClient.Commit(msg); err != nil {
 		return fmt.Errorf("committing %s to git: %v", path, err)
@@ -904,6 +904,129 @@ func TestForceReconcileGitRepoSkip(t *testing.T) {
 	g.Expect(f.ForceReconcileGitRepo(g.ctx, cluster, g.clusterSpec)).To(Succeed())
 }
 
+func TestSuspendKustomization(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	g := newFluxTest(t)
+
+	g.flux.EXPECT().SuspendKustomization(g.ctx, cluster, clusterSpec.FluxConfig)
+
+	g.Expect(g.gitOpsFlux.SuspendKustomization(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestSuspendKustomizationError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	g := newFluxTest(t)
+
+	g.flux.EXPECT().SuspendKustomization(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in suspend"))
+
+	g.Expect(g.gitOpsFlux.SuspendKustomization(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("suspending flux kustomization: error in suspend")))
+}
+
+func TestSuspendKustomizationSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	g.Expect(f.SuspendKustomization(g.ctx, &types.Cluster{}, g.clusterSpec)).To(Succeed())
+}
+
+func TestResumeKustomization(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	g := newFluxTest(t)
+
+	g.flux.EXPECT().ResumeKustomization(g.ctx, cluster, clusterSpec.FluxConfig)
+
+	g.Expect(g.gitOpsFlux.ResumeKustomization(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestResumeKustomizationError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	g := newFluxTest(t)
+
+	g.flux.EXPECT().ResumeKustomization(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in resume"))
+
+	g.Expect(g.gitOpsFlux.ResumeKustomization(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("resuming flux kustomization: error in resume")))
+}
+
+func TestResumeKustomizationSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	g.Expect(f.ResumeKustomization(g.ctx, &types.Cluster{}, g.clusterSpec)).To(Succeed())
+}
+
+func TestRenameGitCluster(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := NewCluster("workload-cluster-new")
+	clusterConfig.SetManagedBy("management-cluster")
+	oldPath := "clusters/management-cluster/workload-cluster/eksa-system"
+	newPath := "clusters/management-cluster/workload-cluster-new/eksa-system"
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	if _, err := g.writer.WithDir(oldPath); err != nil {
+		t.Fatalf("failed to add %s dir: %v", oldPath, err)
+	}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(newPath).Return(nil)
+	g.git.EXPECT().Remove(oldPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	datacenterConfig := datacenterConfig("workload-cluster-new")
+	machineConfig := machineConfig("workload-cluster-new")
+
+	g.Expect(g.gitOpsFlux.RenameGitCluster(g.ctx, "workload-cluster", clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+	g.Expect(path.Join(g.writer.Dir(), newPath, defaultEksaClusterConfigFileName)).To(BeAnExistingFile())
+}
+
+func TestRenameGitClusterOldPathMissing(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := NewCluster("workload-cluster-new")
+	clusterConfig.SetManagedBy("management-cluster")
+	newPath := "clusters/management-cluster/workload-cluster-new/eksa-system"
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(newPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.RenameGitCluster(g.ctx, "workload-cluster", clusterSpec, datacenterConfig("dc"), nil)).To(Succeed())
+}
+
+func TestRenameGitClusterRemoveError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := NewCluster("workload-cluster-new")
+	clusterConfig.SetManagedBy("management-cluster")
+	oldPath := "clusters/management-cluster/workload-cluster/eksa-system"
+	newPath := "clusters/management-cluster/workload-cluster-new/eksa-system"
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	if _, err := g.writer.WithDir(oldPath); err != nil {
+		t.Fatalf("failed to add %s dir: %v", oldPath, err)
+	}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(newPath).Return(nil)
+	g.git.EXPECT().Remove(oldPath).Return(errors.New("error in remove"))
+
+	g.Expect(g.gitOpsFlux.RenameGitCluster(g.ctx, "workload-cluster", clusterSpec, datacenterConfig("dc"), nil)).To(MatchError(ContainSubstring("error in remove")))
+}
+
+func TestRenameGitClusterSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	g.Expect(f.RenameGitCluster(g.ctx, "old", g.clusterSpec, datacenterConfig("dc"), nil)).To(Succeed())
+}
+
 func TestCleanupGitRepo(t *testing.T) {
 	g := newFluxTest(t)
 	mockCtrl := gomock.NewController(t)
@@ -0,0 +1,269 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	defaultUncordonTimeout = 30 * time.Minute
+
+	// StoppedAnnotation is set on the clusters stopped with Hibernator.Stop. Its value lists the
+	// objects Stop paused, so Start only resumes the reconciliation that was running before.
+	StoppedAnnotation = "anywhere.eks.amazonaws.com/stopped"
+
+	pausedCAPICluster = "capi-cluster"
+	pausedCluster     = "cluster"
+)
+
+// PowerManager powers on and off the VMs backing the machines of a cluster.
+type PowerManager interface {
+	PowerOffVM(ctx context.Context, name string) error
+	PowerOnVM(ctx context.Context, name string) error
+}
+
+// NodeDrainer cordons and drains nodes of the workload cluster and makes them schedulable again.
+type NodeDrainer interface {
+	DrainNode(ctx context.Context, name string) error
+	UncordonNode(ctx context.Context, name string) error
+}
+
+// Hibernator stops and starts all the machines of a workload cluster while keeping the
+// cluster objects in the management cluster, so the nodes come back with the same state.
+type Hibernator struct {
+	client  kubernetes.Client
+	power   PowerManager
+	drainer NodeDrainer
+	retrier *retrier.Retrier
+}
+
+// HibernatorOpt allows to customize a Hibernator on construction.
+type HibernatorOpt func(*Hibernator)
+
+// WithUncordonRetrier sets the retrier used to uncordon the nodes while the workload cluster
+// API server comes back up.
+func WithUncordonRetrier(r *retrier.Retrier) HibernatorOpt {
+	return func(h *Hibernator) {
+		h.retrier = r
+	}
+}
+
+// NewHibernator builds a Hibernator. client is a client for the management cluster.
+func NewHibernator(client kubernetes.Client, power PowerManager, drainer NodeDrainer, opts ...HibernatorOpt) *Hibernator {
+	h := &Hibernator{
+		client:  client,
+		power:   power,
+		drainer: drainer,
+		retrier: retrier.New(defaultUncordonTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(10*time.Second))),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+type clusterMachines struct {
+	etcd, controlPlane, workers []clusterv1.Machine
+}
+
+// Stop pauses the reconciliation of the cluster, drains the worker nodes and powers off all
+// the machines, workers first and etcd last.
+func (h *Hibernator) Stop(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	if cluster.IsSelfManaged() {
+		return errors.New("management clusters can't be stopped")
+	}
+
+	machines, err := h.machines(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	// Pausing the CAPI cluster also pauses the machine health checks, which would otherwise
+	// replace the powered off machines.
+	if err := h.pause(ctx, cluster); err != nil {
+		return err
+	}
+
+	for _, m := range machines.workers {
+		if m.Status.NodeRef == nil {
+			continue
+		}
+		logger.V(3).Info("Draining node", "node", m.Status.NodeRef.Name)
+		if err := h.drainer.DrainNode(ctx, m.Status.NodeRef.Name); err != nil {
+			return fmt.Errorf("draining node %s: %v", m.Status.NodeRef.Name, err)
+		}
+	}
+
+	for _, group := range [][]clusterv1.Machine{machines.workers, machines.controlPlane, machines.etcd} {
+		for _, m := range group {
+			logger.V(3).Info("Powering off machine", "machine", m.Name)
+			if err := h.power.PowerOffVM(ctx, m.Spec.InfrastructureRef.Name); err != nil {
+				return fmt.Errorf("powering off machine %s: %v", m.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Start powers on all the machines of a cluster stopped with Stop, etcd first and workers
+// last, makes the worker nodes schedulable again and resumes the reconciliation Stop paused.
+func (h *Hibernator) Start(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	machines, err := h.machines(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range [][]clusterv1.Machine{machines.etcd, machines.controlPlane, machines.workers} {
+		for _, m := range group {
+			logger.V(3).Info("Powering on machine", "machine", m.Name)
+			if err := h.power.PowerOnVM(ctx, m.Spec.InfrastructureRef.Name); err != nil {
+				return fmt.Errorf("powering on machine %s: %v", m.Name, err)
+			}
+		}
+	}
+
+	for _, m := range machines.workers {
+		if m.Status.NodeRef == nil {
+			continue
+		}
+		node := m.Status.NodeRef.Name
+		err := h.retrier.Retry(func() error {
+			return h.drainer.UncordonNode(ctx, node)
+		})
+		if err != nil {
+			return fmt.Errorf("uncordoning node %s: %v", node, err)
+		}
+	}
+
+	return h.resume(ctx, cluster)
+}
+
+func (h *Hibernator) machines(ctx context.Context, cluster *v1alpha1.Cluster) (*clusterMachines, error) {
+	list := &clusterv1.MachineList{}
+	if err := h.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing machines: %v", err)
+	}
+
+	machines := &clusterMachines{}
+	for _, m := range list.Items {
+		if m.Namespace != constants.EksaSystemNamespace || m.Labels[clusterv1.ClusterNameLabel] != cluster.Name {
+			continue
+		}
+
+		switch {
+		case hasLabel(m, clusterv1.MachineControlPlaneLabel):
+			machines.controlPlane = append(machines.controlPlane, m)
+		case hasLabel(m, clusterv1.MachineDeploymentNameLabel):
+			machines.workers = append(machines.workers, m)
+		default:
+			machines.etcd = append(machines.etcd, m)
+		}
+	}
+
+	return machines, nil
+}
+
+func hasLabel(m clusterv1.Machine, label string) bool {
+	_, ok := m.Labels[label]
+	return ok
+}
+
+// pause pauses the reconciliation of the EKS-A and CAPI clusters, recording which of them were
+// not paused yet in the StoppedAnnotation. The annotation is written before pausing the CAPI
+// cluster, so running Stop again after a failure doesn't record the pause as the prior state.
+func (h *Hibernator) pause(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	eksaCluster, capiCluster, err := h.clusters(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := eksaCluster.Annotations[StoppedAnnotation]; !ok {
+		var paused []string
+		if !capiCluster.Spec.Paused {
+			paused = append(paused, pausedCAPICluster)
+		}
+		if !eksaCluster.IsReconcilePaused() {
+			paused = append(paused, pausedCluster)
+		}
+
+		if eksaCluster.Annotations == nil {
+			eksaCluster.Annotations = map[string]string{}
+		}
+		eksaCluster.Annotations[StoppedAnnotation] = strings.Join(paused, ",")
+		eksaCluster.PauseReconcile()
+		if err := h.client.Update(ctx, eksaCluster); err != nil {
+			return fmt.Errorf("updating cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	if !capiCluster.Spec.Paused {
+		capiCluster.Spec.Paused = true
+		if err := h.client.Update(ctx, capiCluster); err != nil {
+			return fmt.Errorf("updating CAPI cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// resume resumes the reconciliation paused by pause and removes the StoppedAnnotation. Clusters
+// without the annotation are left as they are.
+func (h *Hibernator) resume(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	eksaCluster, capiCluster, err := h.clusters(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	value, ok := eksaCluster.Annotations[StoppedAnnotation]
+	if !ok {
+		return nil
+	}
+	paused := map[string]bool{}
+	for _, p := range strings.Split(value, ",") {
+		paused[p] = true
+	}
+
+	if paused[pausedCAPICluster] && capiCluster.Spec.Paused {
+		capiCluster.Spec.Paused = false
+		if err := h.client.Update(ctx, capiCluster); err != nil {
+			return fmt.Errorf("updating CAPI cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	if paused[pausedCluster] {
+		eksaCluster.ClearPauseAnnotation()
+	}
+	delete(eksaCluster.Annotations, StoppedAnnotation)
+	if err := h.client.Update(ctx, eksaCluster); err != nil {
+		return fmt.Errorf("updating cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+func (h *Hibernator) clusters(ctx context.Context, cluster *v1alpha1.Cluster) (*v1alpha1.Cluster, *clusterv1.Cluster, error) {
+	eksaCluster := &v1alpha1.Cluster{}
+	if err := h.client.Get(ctx, cluster.Name, cluster.Namespace, eksaCluster); err != nil {
+		return nil, nil, fmt.Errorf("reading cluster %s: %v", cluster.Name, err)
+	}
+
+	capiCluster := &clusterv1.Cluster{}
+	if err := h.client.Get(ctx, cluster.Name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return nil, nil, fmt.Errorf("reading CAPI cluster %s: %v", cluster.Name, err)
+	}
+
+	return eksaCluster, capiCluster, nil
+}
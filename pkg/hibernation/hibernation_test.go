@@ -0,0 +1,217 @@
+package hibernation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/hibernation"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+type recorder struct {
+	calls       []string
+	uncordonErr error
+}
+
+func (r *recorder) PowerOffVM(_ context.Context, name string) error {
+	r.calls = append(r.calls, "off "+name)
+	return nil
+}
+
+func (r *recorder) PowerOnVM(_ context.Context, name string) error {
+	r.calls = append(r.calls, "on "+name)
+	return nil
+}
+
+func (r *recorder) DrainNode(_ context.Context, name string) error {
+	r.calls = append(r.calls, "drain "+name)
+	return nil
+}
+
+func (r *recorder) UncordonNode(_ context.Context, name string) error {
+	if r.uncordonErr != nil {
+		return r.uncordonErr
+	}
+	r.calls = append(r.calls, "uncordon "+name)
+	return nil
+}
+
+type hibernationTest struct {
+	*WithT
+	ctx        context.Context
+	client     kubernetes.Client
+	cluster    *v1alpha1.Cluster
+	recorder   *recorder
+	hibernator *hibernation.Hibernator
+}
+
+func newHibernationTest(t *testing.T) hibernationTest {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			ManagementCluster: v1alpha1.ManagementCluster{Name: "mgmt"},
+		},
+	}
+
+	objs := []client.Object{
+		cluster.DeepCopy(),
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+		},
+		machine("my-cluster-md-0-1", "worker-vm", "worker-node", map[string]string{clusterv1.MachineDeploymentNameLabel: "my-cluster-md-0"}),
+		machine("my-cluster-cp-1", "cp-vm", "cp-node", map[string]string{clusterv1.MachineControlPlaneLabel: ""}),
+		machine("my-cluster-etcd-1", "etcd-vm", "", nil),
+		machine("my-cluster-md-0-2", "pending-vm", "", map[string]string{clusterv1.MachineDeploymentNameLabel: "my-cluster-md-0"}),
+	}
+	other := machine("other-cp-1", "other-vm", "other-node", map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+	other.Labels[clusterv1.ClusterNameLabel] = "other"
+	objs = append(objs, other)
+
+	r := &recorder{}
+	c := test.NewFakeKubeClient(objs...)
+
+	return hibernationTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		client:     c,
+		cluster:    cluster,
+		recorder:   r,
+		hibernator: hibernation.NewHibernator(c, r, r, hibernation.WithUncordonRetrier(retrier.NewWithMaxRetries(1, 0))),
+	}
+}
+
+func machine(name, vm, node string, labels map[string]string) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{Name: vm},
+		},
+	}
+	for k, v := range labels {
+		m.Labels[k] = v
+	}
+	if node != "" {
+		m.Status.NodeRef = &corev1.ObjectReference{Name: node}
+	}
+
+	return m
+}
+
+func (tt hibernationTest) expectPaused(paused bool) {
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	tt.Expect(capiCluster.Spec.Paused).To(Equal(paused))
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	if paused {
+		tt.Expect(cluster.Annotations).To(HaveKeyWithValue(cluster.PausedAnnotation(), "true"))
+	} else {
+		tt.Expect(cluster.Annotations).ToNot(HaveKey(cluster.PausedAnnotation()))
+	}
+}
+
+func TestHibernatorStop(t *testing.T) {
+	tt := newHibernationTest(t)
+
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.recorder.calls).To(Equal([]string{
+		"drain worker-node",
+		"off worker-vm",
+		"off pending-vm",
+		"off cp-vm",
+		"off etcd-vm",
+	}))
+	tt.expectPaused(true)
+}
+
+func TestHibernatorStopManagementCluster(t *testing.T) {
+	tt := newHibernationTest(t)
+	tt.cluster.Spec.ManagementCluster.Name = tt.cluster.Name
+
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(MatchError("management clusters can't be stopped"))
+	tt.Expect(tt.recorder.calls).To(BeEmpty())
+}
+
+func TestHibernatorStart(t *testing.T) {
+	tt := newHibernationTest(t)
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+	tt.recorder.calls = nil
+
+	tt.Expect(tt.hibernator.Start(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.recorder.calls).To(Equal([]string{
+		"on etcd-vm",
+		"on cp-vm",
+		"on worker-vm",
+		"on pending-vm",
+		"uncordon worker-node",
+	}))
+	tt.expectPaused(false)
+}
+
+func TestHibernatorStartUncordonError(t *testing.T) {
+	tt := newHibernationTest(t)
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+	tt.recorder.uncordonErr = errors.New("api server not ready")
+
+	err := tt.hibernator.Start(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("uncordoning node worker-node")))
+	tt.expectPaused(true)
+}
+
+func TestHibernatorStopTwiceStart(t *testing.T) {
+	tt := newHibernationTest(t)
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+
+	tt.Expect(tt.hibernator.Start(tt.ctx, tt.cluster)).To(Succeed())
+	tt.expectPaused(false)
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	tt.Expect(cluster.Annotations).ToNot(HaveKey(hibernation.StoppedAnnotation))
+}
+
+func TestHibernatorStartKeepsPriorPause(t *testing.T) {
+	tt := newHibernationTest(t)
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	capiCluster.Spec.Paused = true
+	tt.Expect(tt.client.Update(tt.ctx, capiCluster)).To(Succeed())
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", cluster)).To(Succeed())
+	cluster.PauseReconcile()
+	tt.Expect(tt.client.Update(tt.ctx, cluster)).To(Succeed())
+
+	tt.Expect(tt.hibernator.Stop(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.hibernator.Start(tt.ctx, tt.cluster)).To(Succeed())
+	tt.expectPaused(true)
+}
+
+func TestHibernatorStartNotStopped(t *testing.T) {
+	tt := newHibernationTest(t)
+	capiCluster := &clusterv1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	capiCluster.Spec.Paused = true
+	tt.Expect(tt.client.Update(tt.ctx, capiCluster)).To(Succeed())
+
+	tt.Expect(tt.hibernator.Start(tt.ctx, tt.cluster)).To(Succeed())
+
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, capiCluster)).To(Succeed())
+	tt.Expect(capiCluster.Spec.Paused).To(BeTrue())
+}
@@ -0,0 +1,131 @@
+package imagecache
+
+import (
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// DaemonSetName is the name of the DaemonSet that pre-pulls the images on every node.
+	DaemonSetName = "eksa-image-warm-cache"
+	appLabel      = "app.kubernetes.io/name"
+)
+
+// DaemonSet builds the DaemonSet that pre-pulls the cluster critical images on every node.
+// Each image is pulled by an init container that exits right away and the pod is then kept
+// running with the pause image. It runs in the host network and tolerates every taint, so
+// the images are pulled right after a node joins, even before the CNI is ready.
+func DaemonSet(spec *cluster.Spec) *appsv1.DaemonSet {
+	labels := map[string]string{appLabel: DaemonSetName}
+	images := Images(spec)
+
+	initContainers := make([]corev1.Container, 0, len(images))
+	for i, image := range images {
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sh", "-c", "exit 0"},
+			Resources:       containerResources(),
+		})
+	}
+
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DaemonSetName,
+			Namespace: constants.KubeSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					HostNetwork:    true,
+					InitContainers: initContainers,
+					Containers: []corev1.Container{
+						{
+							Name:            "pause",
+							Image:           spec.RootVersionsBundle().KubeDistro.Pause.VersionedImage(),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Resources:       containerResources(),
+						},
+					},
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Images returns the images pre-pulled on every node, excluding the pause image that is always
+// pulled by the DaemonSet pods. It includes the kube-proxy image for every Kubernetes version in
+// the cluster, the CNI image when managed by EKS-A and the additional images in the cluster spec.
+func Images(spec *cluster.Spec) []string {
+	seen := map[string]struct{}{}
+	var images []string
+	add := func(image string) {
+		if _, ok := seen[image]; ok || image == "" {
+			return
+		}
+		seen[image] = struct{}{}
+		images = append(images, image)
+	}
+
+	bundles := []*cluster.VersionsBundle{spec.RootVersionsBundle()}
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		bundles = append(bundles, spec.WorkerNodeGroupVersionsBundle(w))
+	}
+
+	var bundleImages []string
+	for _, b := range bundles {
+		if b == nil {
+			continue
+		}
+		bundleImages = append(bundleImages, b.KubeDistro.KubeProxy.VersionedImage())
+		if cni := spec.Cluster.Spec.ClusterNetwork.CNIConfig; cni != nil && cni.Cilium != nil && cni.Cilium.IsManaged() {
+			bundleImages = append(bundleImages, b.Cilium.Cilium.VersionedImage())
+		}
+	}
+	sort.Strings(bundleImages)
+	for _, image := range bundleImages {
+		add(image)
+	}
+
+	if c := spec.Cluster.Spec.ImageWarmCache; c != nil {
+		for _, image := range c.AdditionalImages {
+			add(image)
+		}
+	}
+
+	return images
+}
+
+func containerResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1m"),
+			corev1.ResourceMemory: resource.MustParse("8Mi"),
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package imagecache_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/imagecache"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func versionsBundle(kubeProxy, cilium string) *cluster.VersionsBundle {
+	return &cluster.VersionsBundle{
+		VersionsBundle: &releasev1.VersionsBundle{
+			Cilium: releasev1.CiliumBundle{
+				Cilium: releasev1.Image{URI: cilium},
+			},
+		},
+		KubeDistro: &cluster.KubeDistro{
+			Pause:     releasev1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1"},
+			KubeProxy: releasev1.Image{URI: kubeProxy},
+		},
+	}
+}
+
+func clusterSpec() *cluster.Spec {
+	kube126 := v1alpha1.Kube126
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube127
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0"},
+			{Name: "md-1", KubernetesVersion: &kube126},
+		}
+		s.Cluster.Spec.ImageWarmCache = &v1alpha1.ImageWarmCacheConfiguration{
+			AdditionalImages: []string{"public.ecr.aws/app/cache:v1"},
+		}
+		s.VersionsBundles = map[v1alpha1.KubernetesVersion]*cluster.VersionsBundle{
+			v1alpha1.Kube127: versionsBundle("public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.1", "public.ecr.aws/isovalent/cilium:v1.12"),
+			v1alpha1.Kube126: versionsBundle("public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.26.5", "public.ecr.aws/isovalent/cilium:v1.12"),
+		}
+	})
+}
+
+func TestImages(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(imagecache.Images(clusterSpec())).To(Equal([]string{
+		"public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.26.5",
+		"public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.1",
+		"public.ecr.aws/isovalent/cilium:v1.12",
+		"public.ecr.aws/app/cache:v1",
+	}))
+}
+
+func TestImagesCiliumNotManaged(t *testing.T) {
+	g := NewWithT(t)
+	skip := true
+	spec := clusterSpec()
+	spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.SkipUpgrade = &skip
+	spec.Cluster.Spec.ImageWarmCache = &v1alpha1.ImageWarmCacheConfiguration{}
+
+	g.Expect(imagecache.Images(spec)).To(Equal([]string{
+		"public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.26.5",
+		"public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.1",
+	}))
+}
+
+func TestDaemonSet(t *testing.T) {
+	g := NewWithT(t)
+	ds := imagecache.DaemonSet(clusterSpec())
+
+	g.Expect(ds.Name).To(Equal(imagecache.DaemonSetName))
+	g.Expect(ds.Namespace).To(Equal(constants.KubeSystemNamespace))
+	g.Expect(ds.Spec.Selector.MatchLabels).To(Equal(ds.Spec.Template.Labels))
+
+	podSpec := ds.Spec.Template.Spec
+	g.Expect(podSpec.HostNetwork).To(BeTrue())
+	g.Expect(podSpec.Tolerations).To(ConsistOf(corev1.Toleration{Operator: corev1.TolerationOpExists}))
+	g.Expect(podSpec.Containers).To(HaveLen(1))
+	g.Expect(podSpec.Containers[0].Image).To(Equal("public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1"))
+	g.Expect(podSpec.InitContainers).To(HaveLen(4))
+	for _, c := range podSpec.InitContainers {
+		g.Expect(c.Command).To(Equal([]string{"sh", "-c", "exit 0"}))
+		g.Expect(c.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+	}
+	g.Expect(podSpec.InitContainers[3].Image).To(Equal("public.ecr.aws/app/cache:v1"))
+}
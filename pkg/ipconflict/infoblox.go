@@ -0,0 +1,161 @@
+package ipconflict
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInfobloxNetworkView = "default"
+	defaultInfobloxWAPIVersion = "2.11"
+	infobloxTimeout            = 30 * time.Second
+)
+
+// Infoblox is an IPAM backed by the IPAM of an Infoblox grid.
+type Infoblox struct {
+	baseURL     string
+	networkView string
+	username    string
+	password    string
+	client      *http.Client
+}
+
+// NewInfoblox returns an Infoblox for the grid master in config.
+func NewInfoblox(config *InfobloxIPAMConfig, username, password string) *Infoblox {
+	networkView := config.NetworkView
+	if networkView == "" {
+		networkView = defaultInfobloxNetworkView
+	}
+	version := config.WAPIVersion
+	if version == "" {
+		version = defaultInfobloxWAPIVersion
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+
+	return &Infoblox{
+		baseURL:     fmt.Sprintf("https://%s/wapi/v%s", config.Server, version),
+		networkView: networkView,
+		username:    username,
+		password:    password,
+		client:      &http.Client{Transport: transport, Timeout: infobloxTimeout},
+	}
+}
+
+// Name returns the name of the IPAM.
+func (i *Infoblox) Name() string {
+	return "infoblox"
+}
+
+// Lookup returns the fixed address reserving ip, or the objects using it if it's used, like host
+// records or DHCP leases. IPs in networks Infoblox doesn't manage are free.
+func (i *Infoblox) Lookup(ctx context.Context, ip net.IP) (*Allocation, error) {
+	if ip.To4() != nil {
+		var fixed []struct {
+			Comment string `json:"comment"`
+		}
+		query := url.Values{"ipv4addr": {ip.String()}, "network_view": {i.networkView}, "_return_fields": {"comment"}}
+		if err := i.do(ctx, http.MethodGet, "fixedaddress?"+query.Encode(), nil, &fixed); err != nil {
+			return nil, err
+		}
+		if len(fixed) > 0 {
+			description := fixed[0].Comment
+			if description == "" {
+				description = "fixed address"
+			}
+			return &Allocation{Description: description}, nil
+		}
+	}
+
+	object := "ipv4address"
+	if ip.To4() == nil {
+		object = "ipv6address"
+	}
+	var addresses []struct {
+		Status string   `json:"status"`
+		Names  []string `json:"names"`
+		Types  []string `json:"types"`
+	}
+	query := url.Values{"ip_address": {ip.String()}, "network_view": {i.networkView}, "_return_fields": {"status,names,types"}}
+	if err := i.do(ctx, http.MethodGet, object+"?"+query.Encode(), nil, &addresses); err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.Status != "USED" {
+			continue
+		}
+		description := strings.Join(a.Names, ", ")
+		if description == "" {
+			description = strings.Join(a.Types, ", ")
+		}
+		return &Allocation{Description: description}, nil
+	}
+
+	return nil, nil
+}
+
+// Reserve creates a fixed address reserving ip, without a MAC address.
+func (i *Infoblox) Reserve(ctx context.Context, ip net.IP, description string) error {
+	if ip.To4() == nil {
+		return errors.New("infoblox reservations only support IPv4 addresses")
+	}
+	fixed := map[string]string{
+		"ipv4addr":     ip.String(),
+		"network_view": i.networkView,
+		"match_client": "RESERVED",
+		"comment":      description,
+	}
+	return i.do(ctx, http.MethodPost, "fixedaddress", fixed, nil)
+}
+
+// do calls the WAPI with body encoded as JSON and decodes the response into out, if set.
+func (i *Infoblox) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, i.baseURL+"/"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(i.username, i.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading infoblox response: %v", err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("infoblox returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("parsing infoblox response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package ipconflict_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+)
+
+type infobloxRequest struct {
+	method string
+	uri    string
+	body   map[string]interface{}
+}
+
+// newInfobloxServer serves the WAPI, answering the searches of each object type with objects.
+func newInfobloxServer(t *testing.T, objects map[string][]map[string]interface{}) (*ipconflict.Infoblox, *[]infobloxRequest) {
+	var requests []infobloxRequest
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		req := infobloxRequest{method: r.Method, uri: r.URL.RequestURI()}
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				t.Errorf("invalid request body %s: %v", data, err)
+			}
+		}
+		requests = append(requests, req)
+
+		if r.Method == http.MethodPost {
+			json.NewEncoder(w).Encode("fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.10/default")
+			return
+		}
+		found := objects[strings.TrimPrefix(r.URL.Path, "/wapi/v2.11/")]
+		if found == nil {
+			found = []map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(found)
+	}))
+	t.Cleanup(server.Close)
+
+	i := ipconflict.NewInfoblox(&ipconflict.InfobloxIPAMConfig{
+		Server:             strings.TrimPrefix(server.URL, "https://"),
+		InsecureSkipVerify: true,
+	}, "admin", "password")
+	return i, &requests
+}
+
+func TestInfobloxLookupFixedAddress(t *testing.T) {
+	g := NewWithT(t)
+	i, requests := newInfobloxServer(t, map[string][]map[string]interface{}{
+		"fixedaddress": {{"comment": "EKS Anywhere cluster prod"}},
+	})
+
+	got, err := i.Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&ipconflict.Allocation{Description: "EKS Anywhere cluster prod"}))
+	g.Expect(*requests).To(Equal([]infobloxRequest{
+		{method: http.MethodGet, uri: "/wapi/v2.11/fixedaddress?_return_fields=comment&ipv4addr=10.0.0.10&network_view=default"},
+	}))
+}
+
+func TestInfobloxLookupUsed(t *testing.T) {
+	g := NewWithT(t)
+	i, requests := newInfobloxServer(t, map[string][]map[string]interface{}{
+		"ipv4address": {{"status": "USED", "names": []string{"pxe.example.com"}, "types": []string{"HOST"}}},
+	})
+
+	got, err := i.Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&ipconflict.Allocation{Description: "pxe.example.com"}))
+	g.Expect(*requests).To(HaveLen(2))
+	g.Expect((*requests)[1].uri).To(Equal("/wapi/v2.11/ipv4address?_return_fields=status%2Cnames%2Ctypes&ip_address=10.0.0.10&network_view=default"))
+}
+
+func TestInfobloxLookupUsedWithoutNames(t *testing.T) {
+	g := NewWithT(t)
+	i, _ := newInfobloxServer(t, map[string][]map[string]interface{}{
+		"ipv6address": {{"status": "USED", "types": []string{"DHCP_LEASE"}}},
+	})
+
+	got, err := i.Lookup(context.Background(), net.ParseIP("fd00::10"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&ipconflict.Allocation{Description: "DHCP_LEASE"}))
+}
+
+func TestInfobloxLookupFree(t *testing.T) {
+	g := NewWithT(t)
+	i, _ := newInfobloxServer(t, map[string][]map[string]interface{}{
+		"ipv4address": {{"status": "UNUSED"}},
+	})
+
+	got, err := i.Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
+
+func TestInfobloxReserve(t *testing.T) {
+	g := NewWithT(t)
+	i, requests := newInfobloxServer(t, nil)
+
+	g.Expect(i.Reserve(context.Background(), net.ParseIP("10.0.0.10"), "EKS Anywhere cluster prod")).To(Succeed())
+	g.Expect(*requests).To(Equal([]infobloxRequest{
+		{
+			method: http.MethodPost,
+			uri:    "/wapi/v2.11/fixedaddress",
+			body: map[string]interface{}{
+				"ipv4addr":     "10.0.0.10",
+				"network_view": "default",
+				"match_client": "RESERVED",
+				"comment":      "EKS Anywhere cluster prod",
+			},
+		},
+	}))
+}
+
+func TestInfobloxReserveIPv6(t *testing.T) {
+	g := NewWithT(t)
+	i, _ := newInfobloxServer(t, nil)
+
+	g.Expect(i.Reserve(context.Background(), net.ParseIP("fd00::10"), "EKS Anywhere cluster prod")).To(MatchError(
+		"infoblox reservations only support IPv4 addresses",
+	))
+}
@@ -0,0 +1,83 @@
+package ipconflict
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/dns"
+)
+
+// NetboxTokenEnv is the environment variable holding the NetBox API token. It's the same one used to
+// import hardware from NetBox.
+const NetboxTokenEnv = "NETBOX_TOKEN"
+
+// IPAMConfig configures the IPAM the IPs of new clusters are checked against. Exactly one of the
+// backends must be set.
+type IPAMConfig struct {
+	Netbox   *NetboxIPAMConfig   `json:"netbox,omitempty"`
+	Infoblox *InfobloxIPAMConfig `json:"infoblox,omitempty"`
+	// Reserve reserves the IPs of the cluster in the IPAM once the checks pass.
+	Reserve bool `json:"reserve,omitempty"`
+}
+
+// NetboxIPAMConfig configures the NetBox IPAM.
+type NetboxIPAMConfig struct {
+	// URL is the URL of the NetBox instance.
+	URL string `json:"url"`
+}
+
+// InfobloxIPAMConfig configures the Infoblox IPAM.
+type InfobloxIPAMConfig struct {
+	// Server is the hostname or IP of the grid master.
+	Server string `json:"server"`
+	// NetworkView is the network view of the IPs, it defaults to "default".
+	NetworkView string `json:"networkView,omitempty"`
+	// WAPIVersion is the version of the WAPI, it defaults to 2.11.
+	WAPIVersion        string `json:"wapiVersion,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// ParseIPAMConfigFile reads an IPAMConfig from a yaml file.
+func ParseIPAMConfigFile(path string) (*IPAMConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ipam config: %v", err)
+	}
+
+	config := &IPAMConfig{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("parsing ipam config: %v", err)
+	}
+
+	switch {
+	case (config.Netbox == nil) == (config.Infoblox == nil):
+		return nil, errors.New("ipam config: exactly one of netbox or infoblox is required")
+	case config.Netbox != nil && config.Netbox.URL == "":
+		return nil, errors.New("ipam config: netbox url is required")
+	case config.Infoblox != nil && config.Infoblox.Server == "":
+		return nil, errors.New("ipam config: infoblox server is required")
+	}
+
+	return config, nil
+}
+
+// NewIPAM builds the IPAM of config with the credentials from the environment variables.
+func NewIPAM(config *IPAMConfig) (IPAM, error) {
+	if config.Netbox != nil {
+		token := os.Getenv(NetboxTokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("netbox ipam requires the %s env variable", NetboxTokenEnv)
+		}
+		return NewNetbox(http.DefaultClient, config.Netbox.URL, token), nil
+	}
+
+	username, password := os.Getenv(dns.InfobloxUsernameEnv), os.Getenv(dns.InfobloxPasswordEnv)
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("infoblox ipam requires the %s and %s env variables", dns.InfobloxUsernameEnv, dns.InfobloxPasswordEnv)
+	}
+	return NewInfoblox(config.Infoblox, username, password), nil
+}
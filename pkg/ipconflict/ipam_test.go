@@ -0,0 +1,65 @@
+package ipconflict_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+)
+
+func TestParseIPAMConfigFile(t *testing.T) {
+	g := NewWithT(t)
+	config, err := ipconflict.ParseIPAMConfigFile("testdata/netbox.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&ipconflict.IPAMConfig{
+		Netbox:  &ipconflict.NetboxIPAMConfig{URL: "https://netbox.example.com"},
+		Reserve: true,
+	}))
+}
+
+func TestParseIPAMConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "testdata/missing.yaml", wantErr: "reading ipam config"},
+		{path: "testdata/unknown_field.yaml", wantErr: "parsing ipam config"},
+		{path: "testdata/both.yaml", wantErr: "ipam config: exactly one of netbox or infoblox is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := ipconflict.ParseIPAMConfigFile(tt.path)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNewIPAM(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(ipconflict.NetboxTokenEnv, "token")
+	t.Setenv("EKSA_INFOBLOX_USERNAME", "admin")
+	t.Setenv("EKSA_INFOBLOX_PASSWORD", "password")
+
+	ipam, err := ipconflict.NewIPAM(&ipconflict.IPAMConfig{Netbox: &ipconflict.NetboxIPAMConfig{URL: "https://netbox.example.com"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ipam.Name()).To(Equal("netbox"))
+
+	ipam, err = ipconflict.NewIPAM(&ipconflict.IPAMConfig{Infoblox: &ipconflict.InfobloxIPAMConfig{Server: "infoblox.example.com"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ipam.Name()).To(Equal("infoblox"))
+}
+
+func TestNewIPAMMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(ipconflict.NetboxTokenEnv, "")
+	t.Setenv("EKSA_INFOBLOX_USERNAME", "admin")
+	t.Setenv("EKSA_INFOBLOX_PASSWORD", "")
+
+	_, err := ipconflict.NewIPAM(&ipconflict.IPAMConfig{Netbox: &ipconflict.NetboxIPAMConfig{URL: "https://netbox.example.com"}})
+	g.Expect(err).To(MatchError("netbox ipam requires the NETBOX_TOKEN env variable"))
+
+	_, err = ipconflict.NewIPAM(&ipconflict.IPAMConfig{Infoblox: &ipconflict.InfobloxIPAMConfig{Server: "infoblox.example.com"}})
+	g.Expect(err).To(MatchError("infoblox ipam requires the EKSA_INFOBLOX_USERNAME and EKSA_INFOBLOX_PASSWORD env variables"))
+}
@@ -0,0 +1,181 @@
+// Package ipconflict detects if the IPs a cluster requires, like the control plane VIP, are already
+// used by other hosts in the network or allocated in an IPAM, and optionally reserves them in the IPAM.
+package ipconflict
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+// errProbeUnavailable is returned by the probes that can't run in the current host, like the ICMP probe
+// without the privileges to open ICMP sockets. The detector ignores them.
+var errProbeUnavailable = errors.New("probe unavailable")
+
+// IP is an address a cluster requires to be free.
+type IP struct {
+	// Field is the path of the cluster spec field that sets the IP, used to report the conflicts.
+	Field   string
+	Address string
+}
+
+// ClusterIPs returns the IPs a new cluster requires to be free: the control plane endpoint, unless
+// the IP check is disabled, and the Tinkerbell stack IP of new management clusters. Workload clusters
+// share the Tinkerbell stack of their management cluster.
+func ClusterIPs(spec *cluster.Spec) []IP {
+	var ips []IP
+	if endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && !spec.Cluster.ControlPlaneIPCheckDisabled() {
+		ips = append(ips, IP{Field: "controlPlaneConfiguration.endpoint.host", Address: endpoint.Host})
+	}
+	if spec.TinkerbellDatacenter != nil && !spec.Cluster.IsManaged() {
+		ips = append(ips, IP{Field: "tinkerbellIP", Address: spec.TinkerbellDatacenter.Spec.TinkerbellIP})
+	}
+	return ips
+}
+
+// Probe checks if an IP answers in the network.
+type Probe interface {
+	// Name describes the probe in the conflict errors, like "ICMP echo".
+	Name() string
+	InUse(ctx context.Context, ip net.IP) (bool, error)
+}
+
+// Allocation is an IP allocated in an IPAM.
+type Allocation struct {
+	// Description identifies the owner of the allocation.
+	Description string
+}
+
+// IPAM is an IP address management backend.
+type IPAM interface {
+	// Name describes the IPAM in the conflict errors, like "netbox".
+	Name() string
+	// Lookup returns the allocation of the IP, or nil if it's free.
+	Lookup(ctx context.Context, ip net.IP) (*Allocation, error)
+	// Reserve allocates the IP with the description.
+	Reserve(ctx context.Context, ip net.IP, description string) error
+}
+
+// ReservationDescription returns the description of the IPs reserved for a cluster. IPs reserved with
+// it don't conflict with the cluster, so creates can be retried.
+func ReservationDescription(clusterName string) string {
+	return fmt.Sprintf("EKS Anywhere cluster %s", clusterName)
+}
+
+// Detector detects the conflicts of IPs with the hosts in the network and the allocations of an IPAM.
+type Detector struct {
+	probes  []Probe
+	ipam    IPAM
+	reserve bool
+}
+
+// DetectorOpt configures a Detector.
+type DetectorOpt func(*Detector)
+
+// WithProbes replaces the default probes of the Detector.
+func WithProbes(probes ...Probe) DetectorOpt {
+	return func(d *Detector) {
+		d.probes = probes
+	}
+}
+
+// WithIPAM makes the Detector check the allocations of ipam and, if reserve is true, reserve the IPs
+// that are free.
+func WithIPAM(ipam IPAM, reserve bool) DetectorOpt {
+	return func(d *Detector) {
+		d.ipam = ipam
+		d.reserve = reserve
+	}
+}
+
+// NewDetector returns a Detector that probes the IPs with TCP, ICMP and ARP.
+func NewDetector(opts ...DetectorOpt) *Detector {
+	d := &Detector{
+		probes: []Probe{NewTCPProbe(&networkutils.DefaultNetClient{}), NewICMPProbe(), NewARPProbe()},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Detect returns an error listing the IPs that are in use. The checks are best effort: hosts can
+// drop the probes and the ARP probe only sees the hosts in the same L2 network. When the Detector
+// reserves IPs, they are only reserved if none of the IPs is in use.
+func (d *Detector) Detect(ctx context.Context, clusterName string, ips []IP) error {
+	var conflicts []string
+	var free []net.IP
+	for _, ip := range ips {
+		addr := net.ParseIP(ip.Address)
+		if addr == nil {
+			return fmt.Errorf("%s %s is not a valid IP", ip.Field, ip.Address)
+		}
+
+		reason, reserved, err := d.check(ctx, clusterName, addr)
+		if err != nil {
+			return fmt.Errorf("checking %s %s: %v", ip.Field, ip.Address, err)
+		}
+		if reason != "" {
+			conflicts = append(conflicts, fmt.Sprintf("%s <%s> is already in use: %s", ip.Field, ip.Address, reason))
+			continue
+		}
+		if !reserved {
+			free = append(free, addr)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return errors.New(strings.Join(conflicts, "; "))
+	}
+
+	if d.ipam == nil || !d.reserve {
+		return nil
+	}
+	for _, ip := range free {
+		logger.V(3).Info("Reserving IP", "ip", ip.String(), "ipam", d.ipam.Name())
+		if err := d.ipam.Reserve(ctx, ip, ReservationDescription(clusterName)); err != nil {
+			return fmt.Errorf("reserving %s in %s: %v", ip, d.ipam.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// check returns why the ip is in use, or an empty reason if it's free. reserved is true if the ip is
+// already reserved for the cluster in the IPAM.
+func (d *Detector) check(ctx context.Context, clusterName string, ip net.IP) (reason string, reserved bool, err error) {
+	if d.ipam != nil {
+		allocation, err := d.ipam.Lookup(ctx, ip)
+		if err != nil {
+			return "", false, fmt.Errorf("looking up ip in %s: %v", d.ipam.Name(), err)
+		}
+		if allocation != nil {
+			if allocation.Description != ReservationDescription(clusterName) {
+				return fmt.Sprintf("allocated in %s (%s)", d.ipam.Name(), allocation.Description), false, nil
+			}
+			reserved = true
+		}
+	}
+
+	for _, probe := range d.probes {
+		inUse, err := probe.InUse(ctx, ip)
+		if errors.Is(err, errProbeUnavailable) {
+			logger.V(4).Info("Skipping IP probe", "probe", probe.Name(), "reason", err.Error())
+			continue
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("%s probe: %v", probe.Name(), err)
+		}
+		if inUse {
+			return fmt.Sprintf("answered %s", probe.Name()), reserved, nil
+		}
+	}
+
+	return "", reserved, nil
+}
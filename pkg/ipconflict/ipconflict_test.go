@@ -0,0 +1,194 @@
+package ipconflict_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+)
+
+type fakeProbe struct {
+	inUse map[string]bool
+	err   error
+	calls int
+}
+
+func (f *fakeProbe) Name() string {
+	return "fake probe"
+}
+
+func (f *fakeProbe) InUse(_ context.Context, ip net.IP) (bool, error) {
+	f.calls++
+	return f.inUse[ip.String()], f.err
+}
+
+type fakeIPAM struct {
+	allocations map[string]string
+	reserved    map[string]string
+	err         error
+}
+
+func (f *fakeIPAM) Name() string {
+	return "fake ipam"
+}
+
+func (f *fakeIPAM) Lookup(_ context.Context, ip net.IP) (*ipconflict.Allocation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	description, ok := f.allocations[ip.String()]
+	if !ok {
+		return nil, nil
+	}
+	return &ipconflict.Allocation{Description: description}, nil
+}
+
+func (f *fakeIPAM) Reserve(_ context.Context, ip net.IP, description string) error {
+	if f.reserved == nil {
+		f.reserved = map[string]string{}
+	}
+	f.reserved[ip.String()] = description
+	return nil
+}
+
+var clusterIPs = []ipconflict.IP{
+	{Field: "controlPlaneConfiguration.endpoint.host", Address: "10.0.0.10"},
+	{Field: "tinkerbellIP", Address: "10.0.0.11"},
+}
+
+func TestDetectorDetectFree(t *testing.T) {
+	g := NewWithT(t)
+	probe := &fakeProbe{}
+	d := ipconflict.NewDetector(ipconflict.WithProbes(probe))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(Succeed())
+	g.Expect(probe.calls).To(Equal(2))
+}
+
+func TestDetectorDetectInUse(t *testing.T) {
+	g := NewWithT(t)
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{inUse: map[string]bool{"10.0.0.10": true, "10.0.0.11": true}}))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(MatchError(
+		"controlPlaneConfiguration.endpoint.host <10.0.0.10> is already in use: answered fake probe; " +
+			"tinkerbellIP <10.0.0.11> is already in use: answered fake probe",
+	))
+}
+
+func TestDetectorDetectProbeError(t *testing.T) {
+	g := NewWithT(t)
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{err: errors.New("network unreachable")}))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(MatchError(
+		"checking controlPlaneConfiguration.endpoint.host 10.0.0.10: fake probe probe: network unreachable",
+	))
+}
+
+func TestDetectorDetectInvalidIP(t *testing.T) {
+	g := NewWithT(t)
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{}))
+
+	g.Expect(d.Detect(context.Background(), "prod", []ipconflict.IP{{Field: "tinkerbellIP", Address: "tinkerbell"}})).To(MatchError(
+		"tinkerbellIP tinkerbell is not a valid IP",
+	))
+}
+
+func TestDetectorDetectAllocatedInIPAM(t *testing.T) {
+	g := NewWithT(t)
+	probe := &fakeProbe{}
+	ipam := &fakeIPAM{allocations: map[string]string{"10.0.0.11": "pxe server"}}
+	d := ipconflict.NewDetector(ipconflict.WithProbes(probe), ipconflict.WithIPAM(ipam, true))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(MatchError(
+		"tinkerbellIP <10.0.0.11> is already in use: allocated in fake ipam (pxe server)",
+	))
+	g.Expect(ipam.reserved).To(BeEmpty())
+}
+
+func TestDetectorDetectReserves(t *testing.T) {
+	g := NewWithT(t)
+	ipam := &fakeIPAM{allocations: map[string]string{"10.0.0.11": ipconflict.ReservationDescription("prod")}}
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{}), ipconflict.WithIPAM(ipam, true))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(Succeed())
+	g.Expect(ipam.reserved).To(Equal(map[string]string{"10.0.0.10": "EKS Anywhere cluster prod"}))
+}
+
+func TestDetectorDetectWithoutReserve(t *testing.T) {
+	g := NewWithT(t)
+	ipam := &fakeIPAM{}
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{}), ipconflict.WithIPAM(ipam, false))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(Succeed())
+	g.Expect(ipam.reserved).To(BeEmpty())
+}
+
+func TestDetectorDetectIPAMError(t *testing.T) {
+	g := NewWithT(t)
+	d := ipconflict.NewDetector(ipconflict.WithProbes(&fakeProbe{}), ipconflict.WithIPAM(&fakeIPAM{err: errors.New("unauthorized")}, false))
+
+	g.Expect(d.Detect(context.Background(), "prod", clusterIPs)).To(MatchError(
+		"checking controlPlaneConfiguration.endpoint.host 10.0.0.10: looking up ip in fake ipam: unauthorized",
+	))
+}
+
+func TestClusterIPs(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *cluster.Spec
+		want []ipconflict.IP
+	}{
+		{
+			name: "vsphere",
+			spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+			}),
+			want: []ipconflict.IP{{Field: "controlPlaneConfiguration.endpoint.host", Address: "10.0.0.10"}},
+		},
+		{
+			name: "ip check disabled",
+			spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+				s.Cluster.DisableControlPlaneIPCheck()
+			}),
+		},
+		{
+			name: "docker",
+			spec: test.NewClusterSpec(),
+		},
+		{
+			name: "tinkerbell management cluster",
+			spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+				s.TinkerbellDatacenter = &v1alpha1.TinkerbellDatacenterConfig{
+					Spec: v1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.0.0.11"},
+				}
+			}),
+			want: clusterIPs,
+		},
+		{
+			name: "tinkerbell workload cluster",
+			spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+				s.Cluster.SetManagedBy("mgmt")
+				s.TinkerbellDatacenter = &v1alpha1.TinkerbellDatacenterConfig{
+					Spec: v1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.0.0.11"},
+				}
+			}),
+			want: []ipconflict.IP{{Field: "controlPlaneConfiguration.endpoint.host", Address: "10.0.0.10"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ipconflict.ClusterIPs(tt.spec)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package ipconflict
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const netboxIPAddressesPath = "/api/ipam/ip-addresses/"
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type netboxIPAddress struct {
+	Address     string `json:"address"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+}
+
+// netboxStatus is the status field of the NetBox objects read from the API.
+type netboxStatus struct {
+	Value string `json:"value"`
+}
+
+// Netbox is an IPAM backed by the IP addresses of a NetBox instance.
+type Netbox struct {
+	client HTTPClient
+	url    string
+	token  string
+}
+
+// NewNetbox returns a Netbox for the NetBox instance at url.
+func NewNetbox(client HTTPClient, url, token string) *Netbox {
+	return &Netbox{
+		client: client,
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+	}
+}
+
+// Name returns the name of the IPAM.
+func (n *Netbox) Name() string {
+	return "netbox"
+}
+
+// Lookup returns the allocation of the first IP address object with ip, regardless of its prefix length
+// and status.
+func (n *Netbox) Lookup(ctx context.Context, ip net.IP) (*Allocation, error) {
+	var page struct {
+		Results []struct {
+			ID          int          `json:"id"`
+			Status      netboxStatus `json:"status"`
+			DNSName     string       `json:"dns_name"`
+			Description string       `json:"description"`
+		} `json:"results"`
+	}
+	query := url.Values{"address": {ip.String()}}
+	if err := n.do(ctx, http.MethodGet, netboxIPAddressesPath+"?"+query.Encode(), nil, &page); err != nil {
+		return nil, err
+	}
+	if len(page.Results) == 0 {
+		return nil, nil
+	}
+
+	a := page.Results[0]
+	description := a.Description
+	if description == "" {
+		description = a.DNSName
+	}
+	if description == "" {
+		description = "ip address " + strconv.Itoa(a.ID) + ", " + a.Status.Value
+	}
+	return &Allocation{Description: description}, nil
+}
+
+// Reserve creates an IP address object for ip with the reserved status.
+func (n *Netbox) Reserve(ctx context.Context, ip net.IP, description string) error {
+	prefix := 32
+	if ip.To4() == nil {
+		prefix = 128
+	}
+	address := &netboxIPAddress{
+		Address:     fmt.Sprintf("%s/%d", ip, prefix),
+		Status:      "reserved",
+		Description: description,
+	}
+	return n.do(ctx, http.MethodPost, netboxIPAddressesPath, address, nil)
+}
+
+func (n *Netbox) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, n.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Token "+n.token)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response from %s: %v", req.URL, err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, req.URL, bytes.TrimSpace(data))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("parsing response from %s: %v", req.URL, err)
+	}
+	return nil
+}
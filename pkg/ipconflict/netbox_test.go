@@ -0,0 +1,125 @@
+package ipconflict_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+)
+
+type netboxRequest struct {
+	method string
+	uri    string
+	body   map[string]interface{}
+}
+
+func newNetboxServer(t *testing.T, results ...map[string]interface{}) (*httptest.Server, *[]netboxRequest) {
+	var requests []netboxRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		req := netboxRequest{method: r.Method, uri: r.URL.RequestURI()}
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				t.Errorf("invalid request body %s: %v", data, err)
+			}
+		}
+		requests = append(requests, req)
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write(data)
+			return
+		}
+		if results == nil {
+			results = []map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestNetboxLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		result map[string]interface{}
+		want   *ipconflict.Allocation
+	}{
+		{
+			name:   "description",
+			result: map[string]interface{}{"id": 7, "status": map[string]string{"value": "active"}, "dns_name": "pxe.example.com", "description": "pxe server"},
+			want:   &ipconflict.Allocation{Description: "pxe server"},
+		},
+		{
+			name:   "dns name",
+			result: map[string]interface{}{"id": 7, "status": map[string]string{"value": "active"}, "dns_name": "pxe.example.com"},
+			want:   &ipconflict.Allocation{Description: "pxe.example.com"},
+		},
+		{
+			name:   "no description",
+			result: map[string]interface{}{"id": 7, "status": map[string]string{"value": "deprecated"}},
+			want:   &ipconflict.Allocation{Description: "ip address 7, deprecated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			server, requests := newNetboxServer(t, tt.result)
+
+			got, err := ipconflict.NewNetbox(http.DefaultClient, server.URL+"/", "token").Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+			g.Expect(*requests).To(Equal([]netboxRequest{{method: http.MethodGet, uri: "/api/ipam/ip-addresses/?address=10.0.0.10"}}))
+		})
+	}
+}
+
+func TestNetboxLookupFree(t *testing.T) {
+	g := NewWithT(t)
+	server, _ := newNetboxServer(t)
+
+	got, err := ipconflict.NewNetbox(http.DefaultClient, server.URL, "token").Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
+
+func TestNetboxLookupForbidden(t *testing.T) {
+	g := NewWithT(t)
+	server, _ := newNetboxServer(t)
+
+	_, err := ipconflict.NewNetbox(http.DefaultClient, server.URL, "other").Lookup(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 403")))
+}
+
+func TestNetboxReserve(t *testing.T) {
+	g := NewWithT(t)
+	server, requests := newNetboxServer(t)
+	n := ipconflict.NewNetbox(http.DefaultClient, server.URL, "token")
+
+	g.Expect(n.Reserve(context.Background(), net.ParseIP("10.0.0.10"), "EKS Anywhere cluster prod")).To(Succeed())
+	g.Expect(n.Reserve(context.Background(), net.ParseIP("fd00::10"), "EKS Anywhere cluster prod")).To(Succeed())
+	g.Expect(*requests).To(Equal([]netboxRequest{
+		{
+			method: http.MethodPost,
+			uri:    "/api/ipam/ip-addresses/",
+			body:   map[string]interface{}{"address": "10.0.0.10/32", "status": "reserved", "description": "EKS Anywhere cluster prod"},
+		},
+		{
+			method: http.MethodPost,
+			uri:    "/api/ipam/ip-addresses/",
+			body:   map[string]interface{}{"address": "fd00::10/128", "status": "reserved", "description": "EKS Anywhere cluster prod"},
+		},
+	}))
+}
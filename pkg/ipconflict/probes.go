@@ -0,0 +1,235 @@
+package ipconflict
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+const (
+	icmpTimeout  = 2 * time.Second
+	icmpAttempts = 2
+	arpTimeout   = time.Second
+	arpInterval  = 100 * time.Millisecond
+
+	// linuxNeighborTable is the ARP cache of the Linux kernel.
+	linuxNeighborTable = "/proc/net/arp"
+	// arpCompleteFlag is set in the neighbor table entries with a resolved MAC address.
+	arpCompleteFlag = 0x2
+	// discardPort is the port the ARP probe sends a datagram to, to make the kernel resolve the MAC address.
+	discardPort = "9"
+)
+
+// TCPProbe considers an IP in use if it accepts or refuses a TCP connection.
+type TCPProbe struct {
+	client networkutils.NetClient
+}
+
+// NewTCPProbe returns a TCPProbe that connects with client.
+func NewTCPProbe(client networkutils.NetClient) *TCPProbe {
+	return &TCPProbe{client: client}
+}
+
+// Name returns the name of the probe.
+func (p *TCPProbe) Name() string {
+	return "TCP connection"
+}
+
+// InUse connects to port 80 of the ip.
+func (p *TCPProbe) InUse(_ context.Context, ip net.IP) (bool, error) {
+	return networkutils.IsIPInUse(p.client, ip.String()), nil
+}
+
+// ICMPProbe considers an IP in use if it answers ICMP echo requests. It uses unprivileged ICMP sockets
+// when the host allows them, and raw sockets otherwise, which require root.
+type ICMPProbe struct {
+	timeout time.Duration
+}
+
+// NewICMPProbe returns an ICMPProbe.
+func NewICMPProbe() *ICMPProbe {
+	return &ICMPProbe{timeout: icmpTimeout}
+}
+
+// Name returns the name of the probe.
+func (p *ICMPProbe) Name() string {
+	return "ICMP echo"
+}
+
+// InUse sends echo requests to the ip and waits for a reply.
+func (p *ICMPProbe) InUse(ctx context.Context, ip net.IP) (bool, error) {
+	unprivileged, privileged, protocol := "udp4", "ip4:icmp", 1
+	var requestType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if ip.To4() == nil {
+		unprivileged, privileged, protocol = "udp6", "ip6:ipv6-icmp", 58
+		requestType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	raw := false
+	conn, err := icmp.ListenPacket(unprivileged, "")
+	if err != nil {
+		raw = true
+		if conn, err = icmp.ListenPacket(privileged, ""); err != nil {
+			return false, fmt.Errorf("%w: opening icmp socket: %v", errProbeUnavailable, err)
+		}
+	}
+	defer conn.Close()
+
+	var dst net.Addr = &net.UDPAddr{IP: ip}
+	if raw {
+		dst = &net.IPAddr{IP: ip}
+	}
+
+	// Unprivileged sockets replace the ID with the local port and only receive the replies to their
+	// requests, the ID is only checked with raw sockets.
+	id := os.Getpid() & 0xffff
+	for seq := 0; seq < icmpAttempts; seq++ {
+		msg := icmp.Message{Type: requestType, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("eks-anywhere")}}
+		data, err := msg.Marshal(nil)
+		if err != nil {
+			return false, err
+		}
+		if _, err := conn.WriteTo(data, dst); err != nil {
+			return false, fmt.Errorf("sending echo request: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("reading echo reply: %v", err)
+		}
+
+		msg, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil || msg.Type != replyType {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok || (raw && echo.ID != id) {
+			continue
+		}
+		if peerIP(peer).Equal(ip) {
+			return true, nil
+		}
+	}
+}
+
+func peerIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+// ARPProbe considers an IPv4 address in use if a host in the same L2 network answers the ARP requests
+// for it. It doesn't send ARP requests itself, which requires raw sockets, but makes the kernel resolve
+// the address and reads the neighbor table. It's only available on Linux.
+type ARPProbe struct {
+	neighborTable string
+	timeout       time.Duration
+	dial          func(network, address string) (net.Conn, error)
+}
+
+// NewARPProbe returns an ARPProbe.
+func NewARPProbe() *ARPProbe {
+	return &ARPProbe{
+		neighborTable: linuxNeighborTable,
+		timeout:       arpTimeout,
+		dial:          net.Dial,
+	}
+}
+
+// Name returns the name of the probe.
+func (p *ARPProbe) Name() string {
+	return "ARP request"
+}
+
+// InUse sends a datagram to the ip, which makes the kernel send ARP requests for it if it's in an
+// attached network, and waits for the neighbor table to have its MAC address.
+func (p *ARPProbe) InUse(ctx context.Context, ip net.IP) (bool, error) {
+	if ip.To4() == nil {
+		return false, fmt.Errorf("%w: arp only resolves ipv4 addresses", errProbeUnavailable)
+	}
+	if _, err := os.Stat(p.neighborTable); err != nil {
+		return false, fmt.Errorf("%w: reading neighbor table: %v", errProbeUnavailable, err)
+	}
+
+	if conn, err := p.dial("udp4", net.JoinHostPort(ip.String(), discardPort)); err == nil {
+		_, _ = conn.Write([]byte{0})
+		conn.Close()
+	}
+
+	timeout := time.NewTimer(p.timeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(arpInterval)
+	defer ticker.Stop()
+	for {
+		resolved, err := p.resolved(ip)
+		if err != nil || resolved {
+			return resolved, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timeout.C:
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolved returns true if the neighbor table has the MAC address of ip. The table has a header line
+// and one line per entry with the IP address, HW type, flags, HW address, mask and device.
+func (p *ARPProbe) resolved(ip net.IP) (bool, error) {
+	f, err := os.Open(p.neighborTable)
+	if err != nil {
+		return false, fmt.Errorf("reading neighbor table: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !net.ParseIP(fields[0]).Equal(ip) {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[2], 0, 32)
+		if err != nil {
+			continue
+		}
+		if flags&arpCompleteFlag != 0 && fields[3] != "00:00:00:00:00:00" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,96 @@
+package ipconflict
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+const neighborTable = `IP address       HW type     Flags       HW address            Mask     Device
+10.0.0.1         0x1         0x2         52:54:00:12:34:56     *        eth0
+10.0.0.10        0x1         0x2         52:54:00:ab:cd:ef     *        eth0
+10.0.0.20        0x1         0x0         00:00:00:00:00:00     *        eth0
+`
+
+func newTestARPProbe(t *testing.T, table string) *ARPProbe {
+	path := filepath.Join(t.TempDir(), "arp")
+	if err := os.WriteFile(path, []byte(table), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return &ARPProbe{
+		neighborTable: path,
+		timeout:       200 * time.Millisecond,
+		dial: func(_, _ string) (net.Conn, error) {
+			return nil, errors.New("network unreachable")
+		},
+	}
+}
+
+func TestARPProbeInUse(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{name: "resolved", ip: "10.0.0.10", want: true},
+		{name: "incomplete", ip: "10.0.0.20", want: false},
+		{name: "missing", ip: "10.0.0.30", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := newTestARPProbe(t, neighborTable)
+			g.Expect(p.InUse(context.Background(), net.ParseIP(tt.ip))).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestARPProbeSendsDatagram(t *testing.T) {
+	g := NewWithT(t)
+	p := newTestARPProbe(t, neighborTable)
+	var address string
+	p.dial = func(_, a string) (net.Conn, error) {
+		address = a
+		return nil, errors.New("network unreachable")
+	}
+
+	_, err := p.InUse(context.Background(), net.ParseIP("10.0.0.30"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(address).To(Equal("10.0.0.30:9"))
+}
+
+func TestARPProbeUnavailable(t *testing.T) {
+	g := NewWithT(t)
+	p := newTestARPProbe(t, neighborTable)
+
+	_, err := p.InUse(context.Background(), net.ParseIP("fd00::10"))
+	g.Expect(errors.Is(err, errProbeUnavailable)).To(BeTrue())
+
+	p.neighborTable = filepath.Join(t.TempDir(), "missing")
+	_, err = p.InUse(context.Background(), net.ParseIP("10.0.0.10"))
+	g.Expect(errors.Is(err, errProbeUnavailable)).To(BeTrue())
+}
+
+func TestICMPProbeInUseLoopback(t *testing.T) {
+	g := NewWithT(t)
+	inUse, err := NewICMPProbe().InUse(context.Background(), net.ParseIP("127.0.0.1"))
+	if errors.Is(err, errProbeUnavailable) {
+		t.Skipf("icmp sockets are not available: %v", err)
+	}
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(inUse).To(BeTrue())
+}
+
+func TestTCPProbeInUseLoopback(t *testing.T) {
+	g := NewWithT(t)
+	// Loopback either accepts or refuses the connection, both mean the IP is in use.
+	g.Expect(NewTCPProbe(&networkutils.DefaultNetClient{}).InUse(context.Background(), net.ParseIP("127.0.0.1"))).To(BeTrue())
+}
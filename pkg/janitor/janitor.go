@@ -0,0 +1,295 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// DefaultMaxAge is the age after which the leftovers of the CLI are considered stale.
+	DefaultMaxAge = 24 * time.Hour
+
+	kindClusterLabel       = "io.x-k8s.kind.cluster"
+	bootstrapClusterSuffix = "-eks-a-cluster"
+	validateTempDirPrefix  = "tmpValidate"
+)
+
+// toolsContainerName matches the names of the containers the CLI runs the tools image in.
+var toolsContainerName = regexp.MustCompile(`^eksa_\d+$`)
+
+// DockerClient lists and removes docker containers.
+type DockerClient interface {
+	ListContainers(ctx context.Context, filters ...string) ([]executables.ContainerInfo, error)
+	RemoveContainers(ctx context.Context, names ...string) error
+}
+
+// Report lists the stale resources found by the Janitor.
+type Report struct {
+	// BootstrapClusters are the names of the kind bootstrap clusters.
+	BootstrapClusters []string
+	// ToolsContainers are the names of the tools containers.
+	ToolsContainers []string
+	// TempDirs are the paths of the temporary directories.
+	TempDirs []string
+}
+
+// IsEmpty returns true if no stale resources were found.
+func (r *Report) IsEmpty() bool {
+	return len(r.BootstrapClusters) == 0 && len(r.ToolsContainers) == 0 && len(r.TempDirs) == 0
+}
+
+// Janitor removes the resources left behind in the admin machine by interrupted CLI commands:
+// kind bootstrap clusters, tools containers and temporary directories.
+type Janitor struct {
+	docker                DockerClient
+	dir                   string
+	maxAge                time.Duration
+	dryRun                bool
+	skipTempDirs          bool
+	skipBootstrapClusters bool
+}
+
+// Option configures a Janitor.
+type Option func(*Janitor)
+
+// WithMaxAge sets the age after which a resource is stale. It defaults to DefaultMaxAge.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(j *Janitor) {
+		j.maxAge = maxAge
+	}
+}
+
+// WithDir sets the directory the CLI commands were run from, where the temporary directories are searched.
+// It defaults to the current directory.
+func WithDir(dir string) Option {
+	return func(j *Janitor) {
+		j.dir = dir
+	}
+}
+
+// WithDryRun makes the Janitor only report the stale resources without removing them.
+func WithDryRun() Option {
+	return func(j *Janitor) {
+		j.dryRun = true
+	}
+}
+
+// WithoutTempDirs makes the Janitor leave the temporary directories alone. They hold the checkpoints
+// used to resume failed commands, so they are only removed when explicitly requested.
+func WithoutTempDirs() Option {
+	return func(j *Janitor) {
+		j.skipTempDirs = true
+	}
+}
+
+// WithoutBootstrapClusters makes the Janitor leave the bootstrap clusters alone. The bootstrap cluster of
+// a failed management cluster upgrade can hold the management resources that need to be moved back to the
+// cluster, so they are only removed when explicitly requested.
+func WithoutBootstrapClusters() Option {
+	return func(j *Janitor) {
+		j.skipBootstrapClusters = true
+	}
+}
+
+// New constructs a new Janitor.
+func New(docker DockerClient, opts ...Option) *Janitor {
+	j := &Janitor{
+		docker: docker,
+		dir:    ".",
+		maxAge: DefaultMaxAge,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Clean removes the stale resources and reports them. Resources created less than the max age ago
+// are never removed, since they can belong to a CLI command still running.
+func (j *Janitor) Clean(ctx context.Context) (*Report, error) {
+	threshold := time.Now().Add(-j.maxAge)
+	report := &Report{}
+
+	if !j.skipBootstrapClusters {
+		clusters, err := j.cleanBootstrapClusters(ctx, threshold)
+		if err != nil {
+			return nil, err
+		}
+		report.BootstrapClusters = clusters
+	}
+
+	containers, err := j.cleanToolsContainers(ctx, threshold)
+	if err != nil {
+		return nil, err
+	}
+	report.ToolsContainers = containers
+
+	if !j.skipTempDirs {
+		dirs, err := j.cleanTempDirs(threshold)
+		if err != nil {
+			return nil, err
+		}
+		report.TempDirs = dirs
+	}
+
+	return report, nil
+}
+
+// cleanBootstrapClusters removes the node containers of the kind clusters created by the CLI to bootstrap
+// management clusters. Their names end in -eks-a-cluster, which tells them apart from other kind clusters.
+func (j *Janitor) cleanBootstrapClusters(ctx context.Context, threshold time.Time) ([]string, error) {
+	containers, err := j.docker.ListContainers(ctx, "label="+kindClusterLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string][]string{}
+	newest := map[string]time.Time{}
+	for _, c := range containers {
+		cluster := c.Labels[kindClusterLabel]
+		if !strings.HasSuffix(cluster, bootstrapClusterSuffix) {
+			continue
+		}
+		nodes[cluster] = append(nodes[cluster], c.Name)
+		if c.CreatedAt.After(newest[cluster]) {
+			newest[cluster] = c.CreatedAt
+		}
+	}
+
+	var stale []string
+	for cluster, createdAt := range newest {
+		if createdAt.Before(threshold) {
+			stale = append(stale, cluster)
+		}
+	}
+	sort.Strings(stale)
+
+	for _, cluster := range stale {
+		if j.dryRun {
+			continue
+		}
+		logger.V(3).Info("Removing stale bootstrap cluster", "cluster", cluster, "nodes", nodes[cluster])
+		if err := j.docker.RemoveContainers(ctx, nodes[cluster]...); err != nil {
+			return nil, fmt.Errorf("removing bootstrap cluster %s: %v", cluster, err)
+		}
+	}
+
+	return stale, nil
+}
+
+// cleanToolsContainers removes the containers the CLI runs the tools image in. They are removed when a command
+// finishes, so the ones left are from commands that were killed or interrupted by a restart of the machine.
+func (j *Janitor) cleanToolsContainers(ctx context.Context, threshold time.Time) ([]string, error) {
+	containers, err := j.docker.ListContainers(ctx, "name=eksa_")
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, c := range containers {
+		if toolsContainerName.MatchString(c.Name) && c.CreatedAt.Before(threshold) {
+			stale = append(stale, c.Name)
+		}
+	}
+	sort.Strings(stale)
+
+	if len(stale) > 0 && !j.dryRun {
+		logger.V(3).Info("Removing stale tools containers", "containers", stale)
+		if err := j.docker.RemoveContainers(ctx, stale...); err != nil {
+			return nil, err
+		}
+	}
+
+	return stale, nil
+}
+
+// cleanTempDirs removes the generated folders of the cluster folders and the folders left by validate create cluster.
+// A cluster folder is only considered when it has the cluster config or kubeconfig written by the CLI.
+func (j *Janitor) cleanTempDirs(threshold time.Time) ([]string, error) {
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %s: %v", j.dir, err)
+	}
+
+	var candidates []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if strings.HasPrefix(name, validateTempDirPrefix) {
+			candidates = append(candidates, filepath.Join(j.dir, name))
+			continue
+		}
+
+		generated := filepath.Join(j.dir, name, filewriter.DefaultTmpFolder)
+		if isDir(generated) && (exists(filepath.Join(j.dir, name, name+bootstrapClusterSuffix+".yaml")) ||
+			exists(filepath.Join(j.dir, name, name+bootstrapClusterSuffix+".kubeconfig"))) {
+			candidates = append(candidates, generated)
+		}
+	}
+
+	var stale []string
+	for _, dir := range candidates {
+		modTime, err := lastModified(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !modTime.Before(threshold) {
+			continue
+		}
+		stale = append(stale, dir)
+		if j.dryRun {
+			continue
+		}
+		logger.V(3).Info("Removing stale temporary directory", "dir", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("removing directory %s: %v", dir, err)
+		}
+	}
+
+	return stale, nil
+}
+
+// lastModified returns the most recent modification time of dir and everything in it.
+func lastModified(dir string) (time.Time, error) {
+	var last time.Time
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading directory %s: %v", dir, err)
+	}
+	return last, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,176 @@
+package janitor_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/janitor"
+	"github.com/aws/eks-anywhere/pkg/janitor/mocks"
+)
+
+type janitorTest struct {
+	*WithT
+	ctx    context.Context
+	docker *mocks.MockDockerClient
+	dir    string
+}
+
+func newJanitorTest(t *testing.T) *janitorTest {
+	return &janitorTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		docker: mocks.NewMockDockerClient(gomock.NewController(t)),
+		dir:    t.TempDir(),
+	}
+}
+
+func kindNode(name, cluster string, age time.Duration) executables.ContainerInfo {
+	return executables.ContainerInfo{
+		Name:      name,
+		State:     "running",
+		CreatedAt: time.Now().Add(-age),
+		Labels:    map[string]string{"io.x-k8s.kind.cluster": cluster},
+	}
+}
+
+func toolsContainer(name, state string, age time.Duration) executables.ContainerInfo {
+	return executables.ContainerInfo{
+		Name:      name,
+		State:     state,
+		CreatedAt: time.Now().Add(-age),
+	}
+}
+
+// writeFile writes a file in the test dir modified age ago.
+func (tt *janitorTest) writeFile(path string, age time.Duration) {
+	path = filepath.Join(tt.dir, path)
+	tt.Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+	tt.Expect(os.WriteFile(path, []byte("test"), 0o644)).To(Succeed())
+	modTime := time.Now().Add(-age)
+	tt.Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+	tt.Expect(os.Chtimes(filepath.Dir(path), modTime, modTime)).To(Succeed())
+}
+
+func (tt *janitorTest) expectContainers(kindNodes, tools []executables.ContainerInfo) {
+	tt.docker.EXPECT().ListContainers(tt.ctx, "label=io.x-k8s.kind.cluster").Return(kindNodes, nil)
+	tt.docker.EXPECT().ListContainers(tt.ctx, "name=eksa_").Return(tools, nil)
+}
+
+func TestJanitorClean(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.expectContainers(
+		[]executables.ContainerInfo{
+			kindNode("old-eks-a-cluster-control-plane", "old-eks-a-cluster", 48*time.Hour),
+			kindNode("old-eks-a-cluster-worker", "old-eks-a-cluster", 48*time.Hour),
+			kindNode("new-eks-a-cluster-control-plane", "new-eks-a-cluster", time.Hour),
+			kindNode("dev-control-plane", "dev", 48*time.Hour),
+		},
+		[]executables.ContainerInfo{
+			toolsContainer("eksa_1693641000000000000", "exited", 48*time.Hour),
+			toolsContainer("eksa_1693641000000000001", "running", 30*time.Hour),
+			toolsContainer("eksa_1693641000000000002", "running", time.Minute),
+			toolsContainer("my_eksa_app", "exited", 48*time.Hour),
+		},
+	)
+	tt.docker.EXPECT().RemoveContainers(tt.ctx, "old-eks-a-cluster-control-plane", "old-eks-a-cluster-worker")
+	tt.docker.EXPECT().RemoveContainers(tt.ctx, "eksa_1693641000000000000", "eksa_1693641000000000001")
+
+	tt.writeFile("old/old-eks-a-cluster.yaml", 48*time.Hour)
+	tt.writeFile("old/generated/old-checkpoint.yaml", 48*time.Hour)
+	tt.writeFile("recent/recent-eks-a-cluster.kubeconfig", 48*time.Hour)
+	tt.writeFile("recent/generated/kind_tmp.yaml", time.Minute)
+	tt.writeFile("app/generated/code.go", 48*time.Hour)
+	tt.writeFile("tmpValidate1234/cluster.yaml", 48*time.Hour)
+
+	report, err := janitor.New(tt.docker, janitor.WithDir(tt.dir)).Clean(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report).To(Equal(&janitor.Report{
+		BootstrapClusters: []string{"old-eks-a-cluster"},
+		ToolsContainers:   []string{"eksa_1693641000000000000", "eksa_1693641000000000001"},
+		TempDirs: []string{
+			filepath.Join(tt.dir, "old", "generated"),
+			filepath.Join(tt.dir, "tmpValidate1234"),
+		},
+	}))
+
+	tt.Expect(filepath.Join(tt.dir, "old", "generated")).NotTo(BeADirectory())
+	tt.Expect(filepath.Join(tt.dir, "old", "old-eks-a-cluster.yaml")).To(BeAnExistingFile())
+	tt.Expect(filepath.Join(tt.dir, "tmpValidate1234")).NotTo(BeADirectory())
+	tt.Expect(filepath.Join(tt.dir, "recent", "generated")).To(BeADirectory())
+	tt.Expect(filepath.Join(tt.dir, "app", "generated")).To(BeADirectory())
+}
+
+func TestJanitorCleanDryRun(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.expectContainers(
+		[]executables.ContainerInfo{kindNode("old-eks-a-cluster-control-plane", "old-eks-a-cluster", 48*time.Hour)},
+		[]executables.ContainerInfo{toolsContainer("eksa_1693641000000000000", "exited", 48*time.Hour)},
+	)
+	tt.writeFile("tmpValidate1234/cluster.yaml", 48*time.Hour)
+
+	report, err := janitor.New(tt.docker, janitor.WithDir(tt.dir), janitor.WithDryRun()).Clean(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.BootstrapClusters).To(Equal([]string{"old-eks-a-cluster"}))
+	tt.Expect(report.ToolsContainers).To(Equal([]string{"eksa_1693641000000000000"}))
+	tt.Expect(report.TempDirs).To(Equal([]string{filepath.Join(tt.dir, "tmpValidate1234")}))
+	tt.Expect(filepath.Join(tt.dir, "tmpValidate1234")).To(BeADirectory())
+}
+
+func TestJanitorCleanOnlyToolsContainers(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.docker.EXPECT().ListContainers(tt.ctx, "name=eksa_").Return(
+		[]executables.ContainerInfo{
+			toolsContainer("eksa_1693641000000000000", "exited", 2*time.Hour),
+			toolsContainer("eksa_1693641000000000001", "running", time.Minute),
+		}, nil,
+	)
+	tt.docker.EXPECT().RemoveContainers(tt.ctx, "eksa_1693641000000000000")
+	tt.writeFile("tmpValidate1234/cluster.yaml", 48*time.Hour)
+
+	report, err := janitor.New(tt.docker,
+		janitor.WithDir(tt.dir),
+		janitor.WithMaxAge(time.Hour),
+		janitor.WithoutBootstrapClusters(),
+		janitor.WithoutTempDirs(),
+	).Clean(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report).To(Equal(&janitor.Report{ToolsContainers: []string{"eksa_1693641000000000000"}}))
+	tt.Expect(report.IsEmpty()).To(BeFalse())
+	tt.Expect(filepath.Join(tt.dir, "tmpValidate1234")).To(BeADirectory())
+}
+
+func TestJanitorCleanNothingStale(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.expectContainers(nil, nil)
+
+	report, err := janitor.New(tt.docker, janitor.WithDir(tt.dir)).Clean(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.IsEmpty()).To(BeTrue())
+}
+
+func TestJanitorCleanRemoveError(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.docker.EXPECT().ListContainers(tt.ctx, "label=io.x-k8s.kind.cluster").Return(
+		[]executables.ContainerInfo{kindNode("old-eks-a-cluster-control-plane", "old-eks-a-cluster", 48*time.Hour)}, nil,
+	)
+	tt.docker.EXPECT().RemoveContainers(tt.ctx, "old-eks-a-cluster-control-plane").Return(errors.New("docker is down"))
+
+	_, err := janitor.New(tt.docker, janitor.WithDir(tt.dir)).Clean(tt.ctx)
+	tt.Expect(err).To(MatchError("removing bootstrap cluster old-eks-a-cluster: docker is down"))
+}
+
+func TestJanitorCleanListError(t *testing.T) {
+	tt := newJanitorTest(t)
+	tt.docker.EXPECT().ListContainers(tt.ctx, "label=io.x-k8s.kind.cluster").Return(nil, errors.New("docker is down"))
+
+	_, err := janitor.New(tt.docker, janitor.WithDir(tt.dir)).Clean(tt.ctx)
+	tt.Expect(err).To(MatchError("docker is down"))
+}
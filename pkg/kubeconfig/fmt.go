@@ -7,3 +7,9 @@ import "fmt"
 func FormatWorkloadClusterKubeconfigFilename(clusterName string) string {
 	return fmt.Sprintf("%s-eks-a-cluster.kubeconfig", clusterName)
 }
+
+// FormatEndpointDNSKubeconfigFilename returns a filename for the Kubeconfig of clusters that
+// uses the DNS name of the control plane endpoint. The filename does not include a basepath.
+func FormatEndpointDNSKubeconfigFilename(clusterName string) string {
+	return fmt.Sprintf("%s-eks-a-cluster.dns.kubeconfig", clusterName)
+}
@@ -111,6 +111,26 @@ func ValidateFilename(filename string) error {
 	return nil
 }
 
+// ClusterNamesInDir returns the names of the clusters that have a kubeconfig in the directory,
+// following the folder structure created by the CLI and described in [FromClusterName].
+func ClusterNamesInDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading clusters directory: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if validations.FileExistsAndIsNotEmpty(filepath.Join(dir, FromClusterName(e.Name()))) {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
 func ValidateKubeconfigPath(clusterName string, parentFolders ...string) error {
 	kubeconfigPath := FromClusterName(clusterName)
 	for _, folder := range parentFolders {
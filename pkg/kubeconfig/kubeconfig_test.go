@@ -3,6 +3,7 @@ package kubeconfig_test
 import (
 	"bytes"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -185,3 +186,31 @@ func TestResolveAndValidateFilename(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestClusterNamesInDir(t *testing.T) {
+	t.Run("returns the clusters with a kubeconfig", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"mgmt", "workload"} {
+			if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, kubeconfig.FromClusterName(name)), goodKubeconfig, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "eksa-cli-logs"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		names, err := kubeconfig.ClusterNamesInDir(dir)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"mgmt", "workload"}, names)
+		}
+	})
+
+	t.Run("returns an error if the directory doesn't exist", func(t *testing.T) {
+		_, err := kubeconfig.ClusterNamesInDir(filepath.Join(t.TempDir(), "missing"))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package manifestexport
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+var errReadOnly = errors.New("manifest export can't write objects")
+
+// emptyClient is a kubernetes.Client for a cluster without objects. The CAPI builders use it to
+// check for existing objects, so the exported objects get the names of a new cluster.
+type emptyClient struct{}
+
+var _ kubernetes.Client = emptyClient{}
+
+func (emptyClient) Get(_ context.Context, name, _ string, obj kubernetes.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, name)
+}
+
+func (emptyClient) List(_ context.Context, _ kubernetes.ObjectList) error {
+	return nil
+}
+
+func (emptyClient) Create(_ context.Context, _ kubernetes.Object) error {
+	return errReadOnly
+}
+
+func (emptyClient) Update(_ context.Context, _ kubernetes.Object) error {
+	return errReadOnly
+}
+
+func (emptyClient) ApplyServerSide(_ context.Context, _ string, _ kubernetes.Object, _ ...kubernetes.ApplyServerSideOption) error {
+	return errReadOnly
+}
+
+func (emptyClient) Delete(_ context.Context, _ kubernetes.Object) error {
+	return errReadOnly
+}
+
+func (emptyClient) DeleteAllOf(_ context.Context, _ kubernetes.Object, _ ...kubernetes.DeleteAllOfOption) error {
+	return errReadOnly
+}
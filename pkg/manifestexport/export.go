@@ -0,0 +1,239 @@
+// Package manifestexport renders the Kubernetes manifests EKS Anywhere applies for a cluster,
+// so they can be reviewed and scanned without creating the cluster.
+package manifestexport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/manifests"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const (
+	// ControlPlaneFile is the file with the CAPI control plane objects.
+	ControlPlaneFile = "control-plane.yaml"
+	// WorkersFile is the file with the CAPI worker node groups objects.
+	WorkersFile = "workers.yaml"
+	// CNIFile is the file with the CNI manifest.
+	CNIFile = "cni.yaml"
+
+	redactedValue = "REDACTED"
+)
+
+// CiliumTemplater generates the Cilium manifest.
+type CiliumTemplater interface {
+	GenerateManifest(ctx context.Context, spec *cluster.Spec, opts ...cilium.ManifestOpt) ([]byte, error)
+}
+
+// Exporter renders the manifests of a cluster and writes them to files.
+type Exporter struct {
+	cilium CiliumTemplater
+	reader manifests.FileReader
+	writer filewriter.FileWriter
+}
+
+// NewExporter constructs a new Exporter.
+func NewExporter(cilium CiliumTemplater, reader manifests.FileReader, writer filewriter.FileWriter) *Exporter {
+	return &Exporter{
+		cilium: cilium,
+		reader: reader,
+		writer: writer,
+	}
+}
+
+// Export renders the CAPI and CNI manifests for a new cluster and writes them to the exporter writer.
+// It returns the paths of the written files. The objects are built the same way the EKS Anywhere
+// controller builds them and they are written in the same order, so the output is the same for
+// the same cluster spec and bundle. The values of the secrets are redacted.
+func (e *Exporter) Export(ctx context.Context, log logr.Logger, spec *cluster.Spec) ([]string, error) {
+	controlPlane, workers, err := capiObjects(ctx, log, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		file    string
+		objects []kubernetes.Object
+	}{
+		{file: ControlPlaneFile, objects: controlPlane},
+		{file: WorkersFile, objects: workers},
+	}
+
+	paths := make([]string, 0, len(files)+1)
+	for _, f := range files {
+		content, err := objectsToYaml(f.objects)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := e.write(f.file, content)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	cni, err := e.cniManifest(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if cni == nil {
+		log.V(4).Info("CNI is not managed by EKS Anywhere, skipping CNI manifest")
+		return paths, nil
+	}
+
+	path, err := e.write(CNIFile, cni)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(paths, path), nil
+}
+
+func (e *Exporter) write(file string, content []byte) (string, error) {
+	path, err := e.writer.Write(file, content, filewriter.PersistentFile)
+	if err != nil {
+		return "", fmt.Errorf("writing %s: %v", file, err)
+	}
+
+	return path, nil
+}
+
+func (e *Exporter) cniManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	cniConfig := spec.Cluster.Spec.ClusterNetwork.CNIConfig
+	switch {
+	case cniConfig.Kindnetd != nil:
+		manifest, err := kindnetd.GenerateManifest(e.reader, spec)
+		if err != nil {
+			return nil, fmt.Errorf("generating kindnetd manifest: %v", err)
+		}
+		return manifest, nil
+	case cniConfig.Cilium != nil && cniConfig.Cilium.IsManaged():
+		manifest, err := e.cilium.GenerateManifest(ctx, spec)
+		if err != nil {
+			return nil, fmt.Errorf("generating cilium manifest: %v", err)
+		}
+		return manifest, nil
+	default:
+		return nil, nil
+	}
+}
+
+// capiObjects builds the provider CAPI objects for the control plane and the workers of a new cluster.
+func capiObjects(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controlPlane, workers []kubernetes.Object, err error) {
+	client := emptyClient{}
+
+	switch spec.Cluster.Spec.DatacenterRef.Kind {
+	case anywherev1.VSphereDatacenterKind:
+		cp, err := vsphere.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := vsphere.WorkersSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.WorkerObjects(), nil
+	case anywherev1.CloudStackDatacenterKind:
+		cp, err := cloudstack.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := cloudstack.WorkersSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.WorkerObjects(), nil
+	case anywherev1.DockerDatacenterKind:
+		cp, err := docker.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := docker.WorkersSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.WorkerObjects(), nil
+	case anywherev1.NutanixDatacenterKind:
+		cp, err := nutanix.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := nutanix.WorkersSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.WorkerObjects(), nil
+	case anywherev1.TinkerbellDatacenterKind:
+		cp, err := tinkerbell.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := tinkerbell.WorkersSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.WorkerObjects(), nil
+	case anywherev1.SnowDatacenterKind:
+		cp, err := snow.ControlPlaneSpec(ctx, log, client, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, err := snow.WorkersSpec(ctx, log, spec, client)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cp.Objects(), w.Objects(), nil
+	default:
+		return nil, nil, fmt.Errorf("exporting manifests is not supported for datacenter %s", spec.Cluster.Spec.DatacenterRef.Kind)
+	}
+}
+
+func objectsToYaml(objs []kubernetes.Object) ([]byte, error) {
+	redacted := make([]kubernetes.Object, 0, len(objs))
+	for _, o := range objs {
+		if s, ok := o.(*corev1.Secret); ok {
+			// Optional secrets, like the registry credentials, are nil when not configured.
+			if s == nil {
+				continue
+			}
+			o = redactSecret(s)
+		}
+		redacted = append(redacted, o)
+	}
+
+	return templater.ObjectsToYaml(kubernetes.ObjectsToRuntimeObjects(redacted)...)
+}
+
+// redactSecret returns a copy of the secret with all its values replaced, so credentials
+// aren't written to the exported files.
+func redactSecret(secret *corev1.Secret) *corev1.Secret {
+	s := secret.DeepCopy()
+	stringData := make(map[string]string, len(s.Data)+len(s.StringData))
+	for k := range s.Data {
+		stringData[k] = redactedValue
+	}
+	for k := range s.StringData {
+		stringData[k] = redactedValue
+	}
+	s.Data = nil
+	s.StringData = stringData
+
+	return s
+}
@@ -0,0 +1,114 @@
+package manifestexport_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/manifestexport"
+	"github.com/aws/eks-anywhere/pkg/manifestexport/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type exporterTest struct {
+	*WithT
+	ctx      context.Context
+	dir      string
+	cilium   *mocks.MockCiliumTemplater
+	exporter *manifestexport.Exporter
+	spec     *cluster.Spec
+}
+
+func newExporterTest(t *testing.T) *exporterTest {
+	dir := t.TempDir()
+	writer, err := filewriter.NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cilium := mocks.NewMockCiliumTemplater(gomock.NewController(t))
+
+	return &exporterTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		dir:      dir,
+		cilium:   cilium,
+		exporter: manifestexport.NewExporter(cilium, nil, writer),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "test-cluster"
+			s.Cluster.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.DockerDatacenterKind, Name: "test-cluster"}
+			s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+			s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+			s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+				{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"},
+			}
+		}),
+	}
+}
+
+func (tt *exporterTest) readFile(name string) string {
+	content, err := os.ReadFile(filepath.Join(tt.dir, name))
+	tt.Expect(err).NotTo(HaveOccurred())
+	return string(content)
+}
+
+func TestExporterExport(t *testing.T) {
+	tt := newExporterTest(t)
+	tt.cilium.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return([]byte("cilium"), nil).Times(2)
+
+	paths, err := tt.exporter.Export(tt.ctx, logr.Discard(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(paths).To(Equal([]string{
+		filepath.Join(tt.dir, manifestexport.ControlPlaneFile),
+		filepath.Join(tt.dir, manifestexport.WorkersFile),
+		filepath.Join(tt.dir, manifestexport.CNIFile),
+	}))
+
+	controlPlane := tt.readFile(manifestexport.ControlPlaneFile)
+	tt.Expect(controlPlane).To(ContainSubstring("kind: KubeadmControlPlane"))
+	tt.Expect(controlPlane).To(ContainSubstring("name: test-cluster-control-plane-1"))
+	workers := tt.readFile(manifestexport.WorkersFile)
+	tt.Expect(workers).To(ContainSubstring("kind: MachineDeployment"))
+	tt.Expect(workers).To(ContainSubstring("name: test-cluster-md-0-1"))
+	tt.Expect(tt.readFile(manifestexport.CNIFile)).To(Equal("cilium"))
+
+	_, err = tt.exporter.Export(tt.ctx, logr.Discard(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.readFile(manifestexport.ControlPlaneFile)).To(Equal(controlPlane), "export should be deterministic")
+	tt.Expect(tt.readFile(manifestexport.WorkersFile)).To(Equal(workers), "export should be deterministic")
+}
+
+func TestExporterExportUnmanagedCilium(t *testing.T) {
+	tt := newExporterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.SkipUpgrade = ptr.Bool(true)
+
+	paths, err := tt.exporter.Export(tt.ctx, logr.Discard(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(paths).To(HaveLen(2))
+	tt.Expect(filepath.Join(tt.dir, manifestexport.CNIFile)).NotTo(BeAnExistingFile())
+}
+
+func TestExporterExportCiliumError(t *testing.T) {
+	tt := newExporterTest(t)
+	tt.cilium.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return(nil, errors.New("helm failed"))
+
+	_, err := tt.exporter.Export(tt.ctx, logr.Discard(), tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating cilium manifest: helm failed")))
+}
+
+func TestExporterExportUnsupportedDatacenter(t *testing.T) {
+	tt := newExporterTest(t)
+	tt.spec.Cluster.Spec.DatacenterRef.Kind = "FakeDatacenterConfig"
+
+	_, err := tt.exporter.Export(tt.ctx, logr.Discard(), tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("exporting manifests is not supported for datacenter FakeDatacenterConfig")))
+}
@@ -0,0 +1,37 @@
+package manifestexport
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+func TestObjectsToYamlRedactsSecrets(t *testing.T) {
+	g := NewWithT(t)
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+		StringData: map[string]string{"username": "admin"},
+	}
+	var registryCredentials *corev1.Secret
+
+	got, err := objectsToYaml([]kubernetes.Object{secret, registryCredentials})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(`apiVersion: v1
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: credentials
+stringData:
+  password: REDACTED
+  username: REDACTED
+
+---
+`))
+	g.Expect(secret.Data).To(HaveKeyWithValue("password", []byte("secret")), "original secret shouldn't be modified")
+}
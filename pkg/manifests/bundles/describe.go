@@ -0,0 +1,139 @@
+package bundles
+
+import (
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// Description is a summary of the components, images and charts included in a Bundles manifest.
+type Description struct {
+	Number          int                         `json:"number"`
+	CliMinVersion   string                      `json:"cliMinVersion"`
+	CliMaxVersion   string                      `json:"cliMaxVersion"`
+	VersionsBundles []VersionsBundleDescription `json:"versionsBundles"`
+}
+
+// VersionsBundleDescription is a summary of the artifacts for one Kubernetes version.
+type VersionsBundleDescription struct {
+	KubeVersion string             `json:"kubeVersion"`
+	EksD        string             `json:"eksD"`
+	Components  []ComponentVersion `json:"components"`
+	Images      []Artifact         `json:"images"`
+	Charts      []Artifact         `json:"charts"`
+}
+
+// ComponentVersion is the version of a component in a VersionsBundle.
+type ComponentVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Artifact is an image or chart in a VersionsBundle.
+type Artifact struct {
+	Name string `json:"name"`
+	URI  string `json:"uri"`
+}
+
+// Describe builds a Description for the Bundles, filtered by kubernetes version. If no kubernetes
+// versions are provided, all the VersionsBundles are included. Components, images and charts
+// are sorted by name and the ones without a version or URI are skipped.
+func Describe(bundles *releasev1.Bundles, kubeVersions ...string) *Description {
+	description := &Description{
+		Number:          bundles.Spec.Number,
+		CliMinVersion:   bundles.Spec.CliMinVersion,
+		CliMaxVersion:   bundles.Spec.CliMaxVersion,
+		VersionsBundles: []VersionsBundleDescription{},
+	}
+
+	for i := range bundles.Spec.VersionsBundles {
+		v := &bundles.Spec.VersionsBundles[i]
+		if len(kubeVersions) > 0 && !slices.Contains(kubeVersions, v.KubeVersion) {
+			continue
+		}
+
+		description.VersionsBundles = append(description.VersionsBundles, VersionsBundleDescription{
+			KubeVersion: v.KubeVersion,
+			EksD:        v.EksD.Name,
+			Components:  componentVersions(v),
+			Images:      images(v),
+			Charts:      charts(v),
+		})
+	}
+
+	return description
+}
+
+func componentVersions(v *releasev1.VersionsBundle) []ComponentVersion {
+	versions := map[string]string{
+		"cert-manager":                      v.CertManager.Version,
+		"cilium":                            v.Cilium.Version,
+		"cluster-api":                       v.ClusterAPI.Version,
+		"cluster-api-bootstrap-kubeadm":     v.Bootstrap.Version,
+		"cluster-api-control-plane-kubeadm": v.ControlPlane.Version,
+		"cluster-api-provider-cloudstack":   v.CloudStack.Version,
+		"cluster-api-provider-docker":       v.Docker.Version,
+		"cluster-api-provider-nutanix":      v.Nutanix.Version,
+		"cluster-api-provider-snow":         v.Snow.Version,
+		"cluster-api-provider-tinkerbell":   v.Tinkerbell.Version,
+		"cluster-api-provider-vsphere":      v.VSphere.Version,
+		"eks-anywhere":                      v.Eksa.Version,
+		"eks-anywhere-packages":             v.PackageController.Version,
+		"etcdadm-bootstrap-provider":        v.ExternalEtcdBootstrap.Version,
+		"etcdadm-controller":                v.ExternalEtcdController.Version,
+		"flux":                              v.Flux.Version,
+		"kindnetd":                          v.Kindnetd.Version,
+	}
+
+	components := make([]ComponentVersion, 0, len(versions))
+	for name, version := range versions {
+		if version == "" {
+			continue
+		}
+		components = append(components, ComponentVersion{Name: name, Version: version})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return components
+}
+
+func images(v *releasev1.VersionsBundle) []Artifact {
+	seen := map[string]struct{}{}
+	images := []Artifact{}
+	for _, i := range v.Images() {
+		if _, ok := seen[i.URI]; ok || i.URI == "" {
+			continue
+		}
+		seen[i.URI] = struct{}{}
+		images = append(images, Artifact{Name: i.Name, URI: i.URI})
+	}
+	sortArtifacts(images)
+
+	return images
+}
+
+func charts(v *releasev1.VersionsBundle) []Artifact {
+	charts := []Artifact{}
+	for name, c := range v.Charts() {
+		if c.URI == "" {
+			continue
+		}
+		charts = append(charts, Artifact{Name: name, URI: c.URI})
+	}
+	sortArtifacts(charts)
+
+	return charts
+}
+
+func sortArtifacts(artifacts []Artifact) {
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Name != artifacts[j].Name {
+			return artifacts[i].Name < artifacts[j].Name
+		}
+		return artifacts[i].URI < artifacts[j].URI
+	})
+}
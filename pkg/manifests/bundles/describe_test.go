@@ -0,0 +1,86 @@
+package bundles_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestDescribe(t *testing.T) {
+	g := NewWithT(t)
+	b := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number:        10,
+			CliMinVersion: "v0.17.0",
+			CliMaxVersion: "v0.17.0",
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.27",
+					EksD:        releasev1.EksDRelease{Name: "kubernetes-1-27-eks-5"},
+					Cilium: releasev1.CiliumBundle{
+						Version:   "v1.12.11-eksa.1",
+						Cilium:    releasev1.Image{Name: "cilium", URI: "public.ecr.aws/isovalent/cilium:v1.12.11-eksa.1"},
+						Operator:  releasev1.Image{Name: "operator-generic", URI: "public.ecr.aws/isovalent/operator-generic:v1.12.11-eksa.1"},
+						HelmChart: releasev1.Image{Name: "cilium-chart", URI: "public.ecr.aws/isovalent/cilium:1.12.11-eksa.1"},
+					},
+					ClusterAPI: releasev1.CoreClusterAPI{
+						Version:    "v1.5.0",
+						Controller: releasev1.Image{Name: "cluster-api-controller", URI: "public.ecr.aws/eks-anywhere/cluster-api-controller:v1.5.0"},
+						KubeProxy:  releasev1.Image{Name: "kube-rbac-proxy", URI: "public.ecr.aws/eks-anywhere/kube-rbac-proxy:v0.14.2"},
+					},
+					Bootstrap: releasev1.KubeadmBootstrapBundle{
+						KubeProxy: releasev1.Image{Name: "kube-rbac-proxy", URI: "public.ecr.aws/eks-anywhere/kube-rbac-proxy:v0.14.2"},
+					},
+				},
+				{
+					KubeVersion: "1.26",
+				},
+			},
+		},
+	}
+
+	g.Expect(bundles.Describe(b, "1.27")).To(Equal(&bundles.Description{
+		Number:        10,
+		CliMinVersion: "v0.17.0",
+		CliMaxVersion: "v0.17.0",
+		VersionsBundles: []bundles.VersionsBundleDescription{
+			{
+				KubeVersion: "1.27",
+				EksD:        "kubernetes-1-27-eks-5",
+				Components: []bundles.ComponentVersion{
+					{Name: "cilium", Version: "v1.12.11-eksa.1"},
+					{Name: "cluster-api", Version: "v1.5.0"},
+				},
+				Images: []bundles.Artifact{
+					{Name: "cilium", URI: "public.ecr.aws/isovalent/cilium:v1.12.11-eksa.1"},
+					{Name: "cluster-api-controller", URI: "public.ecr.aws/eks-anywhere/cluster-api-controller:v1.5.0"},
+					{Name: "kube-rbac-proxy", URI: "public.ecr.aws/eks-anywhere/kube-rbac-proxy:v0.14.2"},
+					{Name: "operator-generic", URI: "public.ecr.aws/isovalent/operator-generic:v1.12.11-eksa.1"},
+				},
+				Charts: []bundles.Artifact{
+					{Name: "cilium", URI: "public.ecr.aws/isovalent/cilium:1.12.11-eksa.1"},
+				},
+			},
+		},
+	}))
+}
+
+func TestDescribeAllKubeVersions(t *testing.T) {
+	g := NewWithT(t)
+	b := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{KubeVersion: "1.27"},
+				{KubeVersion: "1.26"},
+			},
+		},
+	}
+
+	description := bundles.Describe(b)
+	g.Expect(description.VersionsBundles).To(HaveLen(2))
+	g.Expect(description.VersionsBundles[1].KubeVersion).To(Equal("1.26"))
+	g.Expect(description.VersionsBundles[1].Images).To(BeEmpty())
+}
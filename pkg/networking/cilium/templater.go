@@ -11,6 +11,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -25,7 +26,7 @@ const (
 )
 
 type Helm interface {
-	Template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error)
+	Template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string, opts ...executables.HelmOpt) ([]byte, error)
 	RegistryLogin(ctx context.Context, registry, username, password string) error
 }
 
@@ -44,7 +45,7 @@ func (t *Templater) GenerateUpgradePreflightManifest(ctx context.Context, spec *
 	v := templateValues(spec, versionsBundle)
 	v.set(true, "preflight", "enabled")
 	v.set(versionsBundle.Cilium.Cilium.Image(), "preflight", "image", "repository")
-	v.set(versionsBundle.Cilium.Cilium.Tag(), "preflight", "image", "tag")
+	v.set(versionsBundle.Cilium.Cilium.PinnedTag(), "preflight", "image", "tag")
 	v.set(false, "agent")
 	v.set(false, "operator", "enabled")
 
@@ -62,7 +63,7 @@ func (t *Templater) GenerateUpgradePreflightManifest(ctx context.Context, spec *
 		return nil, err
 	}
 
-	manifest, err := t.helm.Template(ctx, uri, version, namespace, v, kubeVersion)
+	manifest, err := t.helm.Template(ctx, uri, version, namespace, v, kubeVersion, helmOpts(spec)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed generating cilium upgrade preflight manifest: %v", err)
 	}
@@ -145,7 +146,7 @@ func (t *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec, op
 	}
 
 	err = c.retrier.Retry(func() error {
-		manifest, err = t.helm.Template(ctx, uri, version, namespace, c.values, c.kubeVersion)
+		manifest, err = t.helm.Template(ctx, uri, version, namespace, c.values, c.kubeVersion, helmOpts(spec)...)
 		return err
 	})
 	if err != nil {
@@ -163,6 +164,16 @@ func (t *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec, op
 	return manifest, nil
 }
 
+// helmOpts returns the helm options for the cluster. The templater is shared by all the clusters
+// in the controller, so the cluster post-renderer is passed in each call.
+func helmOpts(spec *cluster.Spec) []executables.HelmOpt {
+	renderer := spec.Cluster.Spec.HelmPostRenderer
+	if renderer == nil {
+		return nil
+	}
+	return []executables.HelmOpt{executables.WithPostRenderer(renderer.BinaryPath, renderer.Args...)}
+}
+
 func (t *Templater) GenerateNetworkPolicyManifest(spec *cluster.Spec, namespaces []string) ([]byte, error) {
 	values := map[string]interface{}{
 		"managementCluster":  spec.Cluster.IsSelfManaged(),
@@ -210,14 +221,14 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 		"tunnel":            "geneve",
 		"image": values{
 			"repository": versionsBundle.Cilium.Cilium.Image(),
-			"tag":        versionsBundle.Cilium.Cilium.Tag(),
+			"tag":        versionsBundle.Cilium.Cilium.PinnedTag(),
 		},
 		"operator": values{
 			"image": values{
 				// The chart expects an "incomplete" repository
 				// and will add the necessary suffix ("-generic" in our case)
 				"repository": strings.TrimSuffix(versionsBundle.Cilium.Operator.Image(), "-generic"),
-				"tag":        versionsBundle.Cilium.Operator.Tag(),
+				"tag":        versionsBundle.Cilium.Operator.PinnedTag(),
 			},
 			"prometheus": values{
 				"enabled": true,
@@ -237,6 +248,23 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 		val["egressMasqueradeInterfaces"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.EgressMasqueradeInterfaces
 	}
 
+	// Cilium subtracts the tunnel overhead from the configured MTU for the pod interfaces.
+	if spec.Cluster.Spec.ClusterNetwork.MTU != 0 {
+		val["MTU"] = spec.Cluster.Spec.ClusterNetwork.MTU
+	}
+
+	// Without kube-proxy, the kubernetes Service can't be used to reach the API server
+	// until Cilium implements it, so Cilium needs to connect to the control plane endpoint.
+	if spec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		host, port, err := anywherev1.GetControlPlaneHostPort(spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, anywherev1.ControlEndpointDefaultPort)
+		if err != nil {
+			host, port = spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, anywherev1.ControlEndpointDefaultPort
+		}
+		val["kubeProxyReplacement"] = "strict"
+		val["k8sServiceHost"] = host
+		val["k8sServicePort"] = port
+	}
+
 	return val
 }
 
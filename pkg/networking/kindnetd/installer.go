@@ -45,7 +45,7 @@ func NewInstaller(client Client, reader manifests.FileReader) *Installer {
 
 // Install configures kindnetd in an EKS-A cluster.
 func (i *Installer) Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error {
-	manifest, err := generateManifest(i.reader, spec)
+	manifest, err := GenerateManifest(i.reader, spec)
 	if err != nil {
 		return fmt.Errorf("generating kindnetd manifest for install: %v", err)
 	}
@@ -16,7 +16,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
-func generateManifest(reader manifests.FileReader, clusterSpec *cluster.Spec) ([]byte, error) {
+// GenerateManifest generates the kindnetd manifest for a cluster from the manifest in the bundle.
+func GenerateManifest(reader manifests.FileReader, clusterSpec *cluster.Spec) ([]byte, error) {
 	versionsBundle := clusterSpec.RootVersionsBundle()
 	kindnetdManifest, err := bundles.ReadManifest(reader, versionsBundle.Kindnetd.Manifest)
 	if err != nil {
@@ -32,7 +32,7 @@ func (u Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentSp
 		return nil, nil
 	}
 
-	manifest, err := generateManifest(u.reader, newSpec)
+	manifest, err := GenerateManifest(u.reader, newSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,168 @@
+package mtu
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/pod.yaml
+var podTemplate string
+
+const (
+	containerName = "probe"
+	podTimeout    = "5m"
+	// ipv4ICMPHeaders is the size of the IPv4 and ICMP headers, which are not part of the ping payload.
+	ipv4ICMPHeaders = 28
+)
+
+// KubectlClient runs the probe pods in a cluster.
+type KubectlClient interface {
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForPodCompleted(ctx context.Context, cluster *types.Cluster, name string, timeout string, namespace string) error
+	GetPodLogs(ctx context.Context, namespace, podName, containerName, kubeconfig string) (string, error)
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Result is the outcome of probing the path from a node to another node.
+type Result struct {
+	Node string
+	// Peer is the internal IP of the probed node.
+	Peer string
+	// Fits is true when packets of the probed MTU reach the peer.
+	Fits bool
+}
+
+// Prober checks that packets of a given MTU reach every node of a cluster from every other node,
+// without being fragmented.
+type Prober struct {
+	kubectl KubectlClient
+	image   string
+}
+
+// NewProber builds a Prober that runs the probes from image, which must have a shell and
+// the iputils ping in its PATH.
+func NewProber(kubectl KubectlClient, image string) *Prober {
+	return &Prober{
+		kubectl: kubectl,
+		image:   image,
+	}
+}
+
+// Probe pings the internal IP of every other node from each node of the cluster, one node at a time,
+// with packets of mtu bytes and the don't fragment bit set. A ping fails when any link in the path
+// has a lower MTU, which usually happens with VPN or overlay underlays.
+func (p *Prober) Probe(ctx context.Context, cluster *types.Cluster, mtu int) ([]Result, error) {
+	nodes, err := p.kubectl.GetNodes(ctx, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		ip := internalIP(node)
+		if ip == "" {
+			return nil, fmt.Errorf("node %s doesn't have an internal IP", node.Name)
+		}
+		ips[node.Name] = ip
+	}
+
+	var results []Result
+	for _, node := range nodes {
+		var peers []string
+		for _, peer := range nodes {
+			if peer.Name != node.Name {
+				peers = append(peers, ips[peer.Name])
+			}
+		}
+		if len(peers) == 0 {
+			continue
+		}
+
+		logger.V(3).Info("Probing MTU", "node", node.Name, "mtu", mtu)
+		r, err := p.probeFromNode(ctx, cluster, node.Name, peers, mtu)
+		if err != nil {
+			return nil, fmt.Errorf("probing MTU from node %s: %v", node.Name, err)
+		}
+		results = append(results, r...)
+	}
+
+	return results, nil
+}
+
+func (p *Prober) probeFromNode(ctx context.Context, cluster *types.Cluster, node string, peers []string, mtu int) (results []Result, reterr error) {
+	name := "eksa-mtu-probe-" + node
+	pod, err := templater.Execute(podTemplate, map[string]interface{}{
+		"name":        name,
+		"namespace":   constants.KubeSystemNamespace,
+		"nodeName":    node,
+		"container":   containerName,
+		"image":       p.image,
+		"peers":       peers,
+		"payloadSize": mtu - ipv4ICMPHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating probe pod: %v", err)
+	}
+
+	if err = p.kubectl.ApplyKubeSpecFromBytes(ctx, cluster, pod); err != nil {
+		return nil, fmt.Errorf("creating probe pod: %v", err)
+	}
+	defer func() {
+		if err := p.kubectl.DeleteKubeSpecFromBytes(ctx, cluster, pod); err != nil && reterr == nil {
+			reterr = fmt.Errorf("deleting probe pod: %v", err)
+		}
+	}()
+
+	if err = p.kubectl.WaitForPodCompleted(ctx, cluster, name, podTimeout, constants.KubeSystemNamespace); err != nil {
+		return nil, fmt.Errorf("waiting for probe pod: %v", err)
+	}
+
+	logs, err := p.kubectl.GetPodLogs(ctx, constants.KubeSystemNamespace, name, containerName, cluster.KubeconfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading probe output: %v", err)
+	}
+
+	return parseProbeOutput(node, peers, logs)
+}
+
+// parseProbeOutput reads the "<peer> ok|fail" lines printed by the probe pod.
+func parseProbeOutput(node string, peers []string, output string) ([]Result, error) {
+	fits := make(map[string]bool, len(peers))
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		fits[fields[0]] = fields[1] == "ok"
+	}
+
+	results := make([]Result, 0, len(peers))
+	for _, peer := range peers {
+		f, ok := fits[peer]
+		if !ok {
+			return nil, fmt.Errorf("probe output doesn't include peer %s", peer)
+		}
+		results = append(results, Result{Node: node, Peer: peer, Fits: f})
+	}
+
+	return results, nil
+}
+
+func internalIP(node corev1.Node) string {
+	for _, a := range node.Status.Addresses {
+		if a.Type == corev1.NodeInternalIP {
+			return a.Address
+		}
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package mtu_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/networking/mtu"
+	"github.com/aws/eks-anywhere/pkg/networking/mtu/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const probeImage = "registry.example.com/netshoot:v0.11"
+
+type proberTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlClient
+	cluster *types.Cluster
+	prober  *mtu.Prober
+}
+
+func newProberTest(t *testing.T) *proberTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	return &proberTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		cluster: &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		prober:  mtu.NewProber(kubectl, probeImage),
+	}
+}
+
+func node(name, ip string) corev1.Node {
+	n := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if ip != "" {
+		n.Status.Addresses = []corev1.NodeAddress{
+			{Type: corev1.NodeHostName, Address: name},
+			{Type: corev1.NodeInternalIP, Address: ip},
+		}
+	}
+	return n
+}
+
+func (tt *proberTest) expectProbe(node, logs string, peers ...string) {
+	name := "eksa-mtu-probe-" + node
+	pod := gomock.AssignableToTypeOf([]byte{})
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, pod).DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: " + name))
+			tt.Expect(string(data)).To(ContainSubstring("nodeName: " + node))
+			tt.Expect(string(data)).To(ContainSubstring("image: " + probeImage))
+			tt.Expect(string(data)).To(ContainSubstring("hostNetwork: true"))
+			tt.Expect(string(data)).To(ContainSubstring("-M do -s 1372"))
+			for _, p := range peers {
+				tt.Expect(string(data)).To(ContainSubstring(" " + p))
+			}
+			return nil
+		}),
+		tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, name, "5m", "kube-system").Return(nil),
+		tt.kubectl.EXPECT().GetPodLogs(tt.ctx, "kube-system", name, "probe", tt.cluster.KubeconfigFile).Return(logs, nil),
+		tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil),
+	)
+}
+
+func TestProberProbe(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp-1", "10.0.0.1"),
+		node("md-1", "10.0.0.2"),
+	}, nil)
+	tt.expectProbe("cp-1", "10.0.0.2 ok\n", "10.0.0.2")
+	tt.expectProbe("md-1", "10.0.0.1 fail\n", "10.0.0.1")
+
+	tt.Expect(tt.prober.Probe(tt.ctx, tt.cluster, 1400)).To(Equal([]mtu.Result{
+		{Node: "cp-1", Peer: "10.0.0.2", Fits: true},
+		{Node: "md-1", Peer: "10.0.0.1", Fits: false},
+	}))
+}
+
+func TestProberProbeSingleNode(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{node("cp-1", "10.0.0.1")}, nil)
+
+	tt.Expect(tt.prober.Probe(tt.ctx, tt.cluster, 1400)).To(BeEmpty())
+}
+
+func TestProberProbeGetNodesError(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(nil, errors.New("connection refused"))
+
+	_, err := tt.prober.Probe(tt.ctx, tt.cluster, 1400)
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestProberProbeNodeWithoutInternalIP(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp-1", "10.0.0.1"),
+		node("md-1", ""),
+	}, nil)
+
+	_, err := tt.prober.Probe(tt.ctx, tt.cluster, 1400)
+	tt.Expect(err).To(MatchError("node md-1 doesn't have an internal IP"))
+}
+
+func TestProberProbePodFailed(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp-1", "10.0.0.1"),
+		node("md-1", "10.0.0.2"),
+	}, nil)
+	pod := gomock.AssignableToTypeOf([]byte{})
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil)
+	tt.kubectl.EXPECT().WaitForPodCompleted(tt.ctx, tt.cluster, "eksa-mtu-probe-cp-1", "5m", "kube-system").Return(errors.New("timed out"))
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, pod).Return(nil)
+
+	_, err := tt.prober.Probe(tt.ctx, tt.cluster, 1400)
+	tt.Expect(err).To(MatchError("probing MTU from node cp-1: waiting for probe pod: timed out"))
+}
+
+func TestProberProbeMissingPeerInOutput(t *testing.T) {
+	tt := newProberTest(t)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp-1", "10.0.0.1"),
+		node("md-1", "10.0.0.2"),
+	}, nil)
+	tt.expectProbe("cp-1", "ping: not found\n", "10.0.0.2")
+
+	_, err := tt.prober.Probe(tt.ctx, tt.cluster, 1400)
+	tt.Expect(err).To(MatchError("probing MTU from node cp-1: probe output doesn't include peer 10.0.0.2"))
+}
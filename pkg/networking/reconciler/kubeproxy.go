@@ -0,0 +1,62 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const kubeProxyName = "kube-proxy"
+
+// reconcileKubeProxyImage sets the kube-proxy image override in the kube-proxy DaemonSet.
+// kubeadm builds the kube-proxy image from the Kubernetes image repository and version,
+// so the override can't be set in the control plane config and it's patched after kubeadm
+// installs it. Control plane upgrades reset the image, which is patched again once
+// the new control plane is ready.
+func reconcileKubeProxyImage(ctx context.Context, log logr.Logger, c client.Client, spec *cluster.Spec) error {
+	overrides := spec.Cluster.Spec.ComponentImageOverrides
+	if overrides == nil || overrides.KubeProxy == "" {
+		return nil
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, client.ObjectKey{Name: kubeProxyName, Namespace: constants.KubeSystemNamespace}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("kube-proxy DaemonSet not found, skipping image override")
+			return nil
+		}
+		return fmt.Errorf("reading kube-proxy DaemonSet: %v", err)
+	}
+
+	patchHelper, err := patch.NewHelper(ds, c)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	for i := range ds.Spec.Template.Spec.Containers {
+		container := &ds.Spec.Template.Spec.Containers[i]
+		if container.Name == kubeProxyName && container.Image != overrides.KubeProxy {
+			container.Image = overrides.KubeProxy
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+
+	log.Info("Updating kube-proxy image", "image", overrides.KubeProxy)
+	if err := patchHelper.Patch(ctx, ds); err != nil {
+		return fmt.Errorf("patching kube-proxy DaemonSet: %v", err)
+	}
+
+	return nil
+}
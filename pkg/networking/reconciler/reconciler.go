@@ -29,7 +29,12 @@ func New(ciliumReconciler CiliumReconciler) *Reconciler {
 // It uses a controller.Result to indicate when requeues are needed
 // Intended to be used in a kubernetes controller
 // Only Cilium CNI is supported for now.
+// It also sets the kube-proxy image override, if any.
 func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	if err := reconcileKubeProxyImage(ctx, logger, client, spec); err != nil {
+		return controller.Result{}, err
+	}
+
 	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium != nil {
 		return r.ciliumReconciler.Reconcile(ctx, logger, client, spec)
 	} else {
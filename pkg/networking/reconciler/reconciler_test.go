@@ -6,6 +6,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -53,3 +57,68 @@ func TestReconcilerReconcileUnsupportedCNI(t *testing.T) {
 	_, err := r.Reconcile(ctx, logger, client, spec)
 	g.Expect(err).To(MatchError(ContainSubstring("unsupported CNI, only Cilium is supported at this time")))
 }
+
+func TestReconcilerReconcileKubeProxyImageOverride(t *testing.T) {
+	ctx := context.Background()
+	logger := test.NewNullLogger()
+	kubeProxy := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-proxy",
+			Namespace: "kube-system",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "kube-proxy", Image: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4-eks-1-27-9"},
+					},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(kubeProxy).Build()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{},
+		}
+		s.Cluster.Spec.ComponentImageOverrides = &v1alpha1.ComponentImageOverrides{
+			KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4-eks-1-27-9-patched",
+		}
+	})
+
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
+	ciliumReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+
+	r := reconciler.New(ciliumReconciler)
+	_, err := r.Reconcile(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ds := &appsv1.DaemonSet{}
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: "kube-proxy", Namespace: "kube-system"}, ds)).To(Succeed())
+	g.Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4-eks-1-27-9-patched"))
+}
+
+func TestReconcilerReconcileKubeProxyImageOverrideNoDaemonSet(t *testing.T) {
+	ctx := context.Background()
+	logger := test.NewNullLogger()
+	client := fake.NewClientBuilder().Build()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{},
+		}
+		s.Cluster.Spec.ComponentImageOverrides = &v1alpha1.ComponentImageOverrides{
+			KubeProxy: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.4-eks-1-27-9-patched",
+		}
+	})
+
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
+	ciliumReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+
+	r := reconciler.New(ciliumReconciler)
+	_, err := r.Reconcile(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+}
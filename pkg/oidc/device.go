@@ -0,0 +1,213 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+const (
+	discoveryPath   = "/.well-known/openid-configuration"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultPollInterval is the polling interval defined by RFC 8628 when the
+	// provider doesn't return one.
+	defaultPollInterval = 5 * time.Second
+	slowDownIncrement   = 5 * time.Second
+)
+
+// defaultScopes are always requested. offline_access returns a refresh token, so kubectl can
+// renew the ID token without running the device flow again.
+var defaultScopes = []string{"openid", "offline_access"}
+
+type discoveryDocument struct {
+	Issuer                      string `json:"issuer"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// DeviceAuthorization is the code the user needs to enter in the verification page of the provider
+// to authorize the CLI.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Token holds the tokens issued by the OIDC provider.
+type Token struct {
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenResponse struct {
+	Token
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceFlow obtains OIDC tokens with the OAuth 2.0 device authorization grant (RFC 8628),
+// which doesn't need a browser on the machine running the CLI.
+type DeviceFlow struct {
+	client    HTTPClient
+	issuerURL string
+	clientID  string
+	scopes    []string
+	after     func(time.Duration) <-chan time.Time
+}
+
+// DeviceFlowOpt allows to customize a DeviceFlow.
+type DeviceFlowOpt func(*DeviceFlow)
+
+// WithTimer sets the function used to wait between token requests. It defaults to time.After.
+func WithTimer(after func(time.Duration) <-chan time.Time) DeviceFlowOpt {
+	return func(d *DeviceFlow) {
+		d.after = after
+	}
+}
+
+// NewDeviceFlow builds a DeviceFlow for a public OIDC client. scopes are requested on top of openid and offline_access.
+func NewDeviceFlow(client HTTPClient, issuerURL, clientID string, scopes []string, opts ...DeviceFlowOpt) *DeviceFlow {
+	d := &DeviceFlow{
+		client:    client,
+		issuerURL: strings.TrimSuffix(issuerURL, "/"),
+		clientID:  clientID,
+		scopes:    append(append([]string{}, defaultScopes...), scopes...),
+		after:     time.After,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Login runs the device flow: it requests a device code, calls prompt so the user can authorize it
+// with the provider and polls the provider until the tokens are issued or the code expires.
+func (d *DeviceFlow) Login(ctx context.Context, prompt func(*DeviceAuthorization)) (*Token, error) {
+	discovery := &discoveryDocument{}
+	if err := d.getJSON(ctx, d.issuerURL+discoveryPath, discovery); err != nil {
+		return nil, fmt.Errorf("getting OIDC discovery document for issuer %s: %v", d.issuerURL, err)
+	}
+	if discovery.DeviceAuthorizationEndpoint == "" || discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC issuer %s doesn't support the device authorization grant", d.issuerURL)
+	}
+
+	auth := &DeviceAuthorization{}
+	if err := d.postForm(ctx, discovery.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {d.clientID},
+		"scope":     {strings.Join(d.scopes, " ")},
+	}, auth); err != nil {
+		return nil, fmt.Errorf("requesting device code: %v", err)
+	}
+
+	prompt(auth)
+
+	return d.pollToken(ctx, discovery.TokenEndpoint, auth)
+}
+
+func (d *DeviceFlow) pollToken(ctx context.Context, tokenEndpoint string, auth *DeviceAuthorization) (*Token, error) {
+	interval := defaultPollInterval
+	if auth.Interval > 0 {
+		interval = time.Duration(auth.Interval) * time.Second
+	}
+
+	form := url.Values{
+		"grant_type":  {deviceGrantType},
+		"device_code": {auth.DeviceCode},
+		"client_id":   {d.clientID},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-d.after(interval):
+		}
+
+		resp := &tokenResponse{}
+		err := d.postForm(ctx, tokenEndpoint, form, resp)
+		switch resp.Error {
+		case "":
+			if err != nil {
+				return nil, fmt.Errorf("requesting token: %v", err)
+			}
+			if resp.IDToken == "" {
+				return nil, errors.New("OIDC provider didn't return an ID token")
+			}
+			return &resp.Token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		case "access_denied":
+			return nil, errors.New("authorization was denied")
+		case "expired_token":
+			return nil, errors.New("device code expired before the authorization was completed, please login again")
+		default:
+			return nil, fmt.Errorf("requesting token: %s: %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+}
+
+func (d *DeviceFlow) getJSON(ctx context.Context, endpoint string, obj interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return d.do(req, obj)
+}
+
+func (d *DeviceFlow) postForm(ctx context.Context, endpoint string, form url.Values, obj interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return d.do(req, obj)
+}
+
+// do sends req and parses the JSON response into obj. OAuth errors are returned with a 400 status code
+// and a JSON body, so the body is parsed for those too.
+func (d *DeviceFlow) do(req *http.Request, obj interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response from %s: %v", req.URL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL)
+	}
+
+	if err := json.Unmarshal(body, obj); err != nil {
+		return fmt.Errorf("parsing response from %s: %v", req.URL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL)
+	}
+
+	return nil
+}
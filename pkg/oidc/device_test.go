@@ -0,0 +1,148 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/oidc"
+)
+
+type provider struct {
+	*httptest.Server
+	tokenResponses []string
+	tokenRequests  int
+	deviceScope    string
+}
+
+func newProvider(t *testing.T, tokenResponses ...string) *provider {
+	p := &provider{tokenResponses: tokenResponses}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        p.URL,
+			"token_endpoint":                p.URL + "/token",
+			"device_authorization_endpoint": p.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		p.deviceScope = r.FormValue("scope")
+		_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-EFGH","verification_uri":"https://idp/device","interval":1}`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("device_code") != "device" || r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+		resp := p.tokenResponses[p.tokenRequests]
+		p.tokenRequests++
+		if strings.Contains(resp, `"error"`) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(resp))
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func noWait(time.Duration) <-chan time.Time {
+	c := make(chan time.Time, 1)
+	c <- time.Now()
+	return c
+}
+
+func TestDeviceFlowLogin(t *testing.T) {
+	g := NewWithT(t)
+	p := newProvider(t,
+		`{"error":"authorization_pending"}`,
+		`{"error":"slow_down"}`,
+		`{"id_token":"id","refresh_token":"refresh"}`,
+	)
+	var intervals []time.Duration
+	after := func(d time.Duration) <-chan time.Time {
+		intervals = append(intervals, d)
+		return noWait(d)
+	}
+	flow := oidc.NewDeviceFlow(http.DefaultClient, p.URL+"/", "eks-a", []string{"groups"}, oidc.WithTimer(after))
+
+	var prompted *oidc.DeviceAuthorization
+	token, err := flow.Login(context.Background(), func(auth *oidc.DeviceAuthorization) { prompted = auth })
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(&oidc.Token{IDToken: "id", RefreshToken: "refresh"}))
+	g.Expect(prompted.UserCode).To(Equal("ABCD-EFGH"))
+	g.Expect(prompted.VerificationURI).To(Equal("https://idp/device"))
+	g.Expect(p.deviceScope).To(Equal("openid offline_access groups"))
+	g.Expect(intervals).To(Equal([]time.Duration{time.Second, time.Second, 6 * time.Second}))
+}
+
+func TestDeviceFlowLoginErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{
+			name:     "access denied",
+			response: `{"error":"access_denied"}`,
+			wantErr:  "authorization was denied",
+		},
+		{
+			name:     "expired",
+			response: `{"error":"expired_token"}`,
+			wantErr:  "device code expired before the authorization was completed, please login again",
+		},
+		{
+			name:     "other error",
+			response: `{"error":"invalid_client","error_description":"unknown client"}`,
+			wantErr:  "requesting token: invalid_client: unknown client",
+		},
+		{
+			name:     "no id token",
+			response: `{"access_token":"access"}`,
+			wantErr:  "OIDC provider didn't return an ID token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := newProvider(t, tt.response)
+			flow := oidc.NewDeviceFlow(http.DefaultClient, p.URL, "eks-a", nil, oidc.WithTimer(noWait))
+
+			_, err := flow.Login(context.Background(), func(*oidc.DeviceAuthorization) {})
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestDeviceFlowLoginNoDeviceGrant(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issuer":"https://idp","token_endpoint":"https://idp/token"}`))
+	}))
+	defer server.Close()
+	flow := oidc.NewDeviceFlow(http.DefaultClient, server.URL, "eks-a", nil)
+
+	_, err := flow.Login(context.Background(), func(*oidc.DeviceAuthorization) {})
+	g.Expect(err).To(MatchError(ContainSubstring("doesn't support the device authorization grant")))
+}
+
+func TestDeviceFlowLoginContextCanceled(t *testing.T) {
+	g := NewWithT(t)
+	p := newProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	flow := oidc.NewDeviceFlow(http.DefaultClient, p.URL, "eks-a", nil, oidc.WithTimer(func(time.Duration) <-chan time.Time {
+		return make(chan time.Time)
+	}))
+
+	_, err := flow.Login(ctx, func(*oidc.DeviceAuthorization) { cancel() })
+	g.Expect(err).To(MatchError(context.Canceled))
+}
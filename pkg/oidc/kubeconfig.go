@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// oidcAuthProvider is the kubeconfig auth provider built into kubectl and client-go that sends the
+// ID token and renews it with the refresh token once it expires.
+const oidcAuthProvider = "oidc"
+
+// KubeconfigConfig defines the cluster and OIDC client a kubeconfig authenticates against.
+type KubeconfigConfig struct {
+	ClusterName string
+	Server      string
+	CAData      []byte
+	IssuerURL   string
+	ClientID    string
+}
+
+// Kubeconfig returns a kubeconfig that authenticates to the cluster with token.
+func Kubeconfig(config KubeconfigConfig, token *Token) ([]byte, error) {
+	userName := fmt.Sprintf("%s-oidc", config.ClusterName)
+	contextName := fmt.Sprintf("%s@%s", userName, config.ClusterName)
+
+	authConfig := map[string]string{
+		"idp-issuer-url": config.IssuerURL,
+		"client-id":      config.ClientID,
+		"id-token":       token.IDToken,
+	}
+	if token.RefreshToken != "" {
+		authConfig["refresh-token"] = token.RefreshToken
+	}
+
+	kubeconfig := clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			config.ClusterName: {
+				Server:                   config.Server,
+				CertificateAuthorityData: config.CAData,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			userName: {
+				AuthProvider: &clientcmdapi.AuthProviderConfig{
+					Name:   oidcAuthProvider,
+					Config: authConfig,
+				},
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			contextName: {
+				Cluster:  config.ClusterName,
+				AuthInfo: userName,
+			},
+		},
+		CurrentContext: contextName,
+	}
+
+	content, err := clientcmd.Write(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("writing OIDC kubeconfig: %v", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,35 @@
+package oidc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/oidc"
+)
+
+func TestKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	content, err := oidc.Kubeconfig(oidc.KubeconfigConfig{
+		ClusterName: "mgmt",
+		Server:      "https://1.2.3.4:6443",
+		CAData:      []byte("ca"),
+		IssuerURL:   "https://idp",
+		ClientID:    "eks-a",
+	}, &oidc.Token{IDToken: "id", RefreshToken: "refresh"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.CurrentContext).To(Equal("mgmt-oidc@mgmt"))
+	g.Expect(config.Clusters["mgmt"].Server).To(Equal("https://1.2.3.4:6443"))
+	g.Expect(config.Clusters["mgmt"].CertificateAuthorityData).To(Equal([]byte("ca")))
+	g.Expect(config.AuthInfos["mgmt-oidc"].AuthProvider.Name).To(Equal("oidc"))
+	g.Expect(config.AuthInfos["mgmt-oidc"].AuthProvider.Config).To(Equal(map[string]string{
+		"idp-issuer-url": "https://idp",
+		"client-id":      "eks-a",
+		"id-token":       "id",
+		"refresh-token":  "refresh",
+	}))
+}
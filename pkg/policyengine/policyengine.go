@@ -0,0 +1,163 @@
+package policyengine
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/baseline-policies.yaml
+var baselinePoliciesTemplate string
+
+const (
+	// Namespace is where the policy engine is installed.
+	Namespace = "kyverno"
+
+	kyvernoChartName     = "kyverno"
+	kyvernoChartRegistry = "ghcr.io"
+	kyvernoChartURI      = "oci://" + kyvernoChartRegistry + "/kyverno/charts/kyverno"
+	kyvernoChartVersion  = "3.1.4"
+)
+
+// HelmClient installs helm charts in a cluster.
+type HelmClient interface {
+	InstallChart(ctx context.Context, chart, ociURI, version, kubeconfigFilePath, namespace, valueFilePath string, skipCRDs bool, values []string, opts ...executables.HelmOpt) error
+}
+
+// KubernetesClient applies manifests to a cluster.
+type KubernetesClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Installer installs and upgrades the policy engine and its baseline policies.
+type Installer struct {
+	helm   HelmClient
+	client KubernetesClient
+}
+
+// NewInstaller builds an Installer.
+func NewInstaller(helm HelmClient, client KubernetesClient) *Installer {
+	return &Installer{
+		helm:   helm,
+		client: client,
+	}
+}
+
+// Install installs the policy engine chart and applies the baseline policies. Since it runs
+// a helm upgrade and an apply, it's also used to upgrade them. It's a noop if the cluster
+// doesn't have a policy engine configured.
+func (i *Installer) Install(ctx context.Context, c *types.Cluster, spec *cluster.Spec) error {
+	if spec.Cluster.Spec.PolicyEngine == nil {
+		return nil
+	}
+
+	mirror := registrymirror.FromCluster(spec.Cluster)
+	if err := i.helm.InstallChart(ctx, kyvernoChartName, mirror.ReplaceRegistry(kyvernoChartURI), kyvernoChartVersion,
+		c.KubeconfigFile, Namespace, "", false, chartValues(mirror)); err != nil {
+		return fmt.Errorf("installing policy engine chart: %v", err)
+	}
+
+	policies, err := BaselinePolicies(spec)
+	if err != nil {
+		return err
+	}
+
+	if err = i.client.ApplyKubeSpecFromBytes(ctx, c, policies); err != nil {
+		return fmt.Errorf("applying policy engine baseline policies: %v", err)
+	}
+
+	return nil
+}
+
+// BaselinePolicies generates the manifest with the baseline policies: no privileged containers
+// and images only from the allowed registries. The system namespaces are excluded, since they run
+// the privileged components of the cluster, like the CNI.
+func BaselinePolicies(spec *cluster.Spec) ([]byte, error) {
+	failureAction := "Enforce"
+	if spec.Cluster.Spec.PolicyEngine.Mode == v1alpha1.PolicyEngineAudit {
+		failureAction = "Audit"
+	}
+
+	registries := AllowedRegistries(spec)
+	patterns := make([]string, 0, len(registries))
+	for _, r := range registries {
+		patterns = append(patterns, r+"/*")
+	}
+
+	values := map[string]interface{}{
+		"validationFailureAction": failureAction,
+		"excludedNamespaces":      []string{constants.KubeSystemNamespace, constants.EksaSystemNamespace, Namespace},
+		"allowedRegistriesList":   strings.Join(registries, ", "),
+		"imagePattern":            strings.Join(patterns, " | "),
+	}
+
+	manifest, err := templater.Execute(baselinePoliciesTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("generating policy engine baseline policies: %v", err)
+	}
+
+	return manifest, nil
+}
+
+// AllowedRegistries returns the sorted registries pods can pull images from: the ones used by
+// the EKS Anywhere components, the registry mirror if configured and the ones in the cluster spec.
+func AllowedRegistries(spec *cluster.Spec) []string {
+	seen := map[string]struct{}{}
+	add := func(registry string) {
+		if registry != "" {
+			seen[registry] = struct{}{}
+		}
+	}
+
+	bundles := []*cluster.VersionsBundle{spec.RootVersionsBundle()}
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		bundles = append(bundles, spec.WorkerNodeGroupVersionsBundle(w))
+	}
+	for _, b := range bundles {
+		if b == nil {
+			continue
+		}
+		if b.VersionsBundle != nil {
+			for _, image := range b.Images() {
+				add(image.Registry())
+			}
+		}
+		if b.KubeDistro != nil {
+			add(b.KubeDistro.KubeProxy.Registry())
+		}
+	}
+
+	if mirror := registrymirror.FromCluster(spec.Cluster); mirror != nil {
+		add(mirror.BaseRegistry)
+	}
+
+	for _, r := range spec.Cluster.Spec.PolicyEngine.AllowedRegistries {
+		add(strings.TrimSuffix(r, "/"))
+	}
+
+	registries := make([]string, 0, len(seen))
+	for r := range seen {
+		registries = append(registries, r)
+	}
+	sort.Strings(registries)
+
+	return registries
+}
+
+func chartValues(mirror *registrymirror.RegistryMirror) []string {
+	registry := mirror.ReplaceRegistry(kyvernoChartRegistry)
+	if registry == kyvernoChartRegistry {
+		return nil
+	}
+	return []string{"global.image.registry=" + registry}
+}
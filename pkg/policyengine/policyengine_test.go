@@ -0,0 +1,163 @@
+package policyengine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/policyengine"
+	"github.com/aws/eks-anywhere/pkg/policyengine/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func clusterSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube127
+		s.Cluster.Spec.PolicyEngine = &v1alpha1.PolicyEngineConfiguration{
+			Engine:            v1alpha1.KyvernoPolicyEngine,
+			AllowedRegistries: []string{"registry.example.com/"},
+		}
+		s.VersionsBundles = map[v1alpha1.KubernetesVersion]*cluster.VersionsBundle{
+			v1alpha1.Kube127: {
+				VersionsBundle: &releasev1.VersionsBundle{
+					Cilium: releasev1.CiliumBundle{
+						Cilium: releasev1.Image{URI: "public.ecr.aws/isovalent/cilium:v1.12"},
+					},
+					Snow: releasev1.SnowBundle{
+						KubeVip: releasev1.Image{URI: "oci.example.com/kube-vip:v0.5"},
+					},
+				},
+				KubeDistro: &cluster.KubeDistro{
+					KubeProxy: releasev1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.27.1"},
+				},
+			},
+		}
+	})
+}
+
+type installerTest struct {
+	*WithT
+	ctx       context.Context
+	helm      *mocks.MockHelmClient
+	client    *mocks.MockKubernetesClient
+	installer *policyengine.Installer
+	cluster   *types.Cluster
+	spec      *cluster.Spec
+}
+
+func newInstallerTest(t *testing.T) *installerTest {
+	ctrl := gomock.NewController(t)
+	helm := mocks.NewMockHelmClient(ctrl)
+	client := mocks.NewMockKubernetesClient(ctrl)
+	return &installerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		helm:      helm,
+		client:    client,
+		installer: policyengine.NewInstaller(helm, client),
+		cluster:   &types.Cluster{Name: "workload", KubeconfigFile: "workload.kubeconfig"},
+		spec:      clusterSpec(),
+	}
+}
+
+func TestAllowedRegistries(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(policyengine.AllowedRegistries(clusterSpec())).To(Equal([]string{
+		"oci.example.com",
+		"public.ecr.aws",
+		"registry.example.com",
+	}))
+}
+
+func TestAllowedRegistriesRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	spec := clusterSpec()
+	spec.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "1.2.3.4",
+		Port:     "443",
+	}
+	g.Expect(policyengine.AllowedRegistries(spec)).To(Equal([]string{
+		"1.2.3.4:443",
+		"oci.example.com",
+		"public.ecr.aws",
+		"registry.example.com",
+	}))
+}
+
+func TestBaselinePolicies(t *testing.T) {
+	g := NewWithT(t)
+	policies, err := policyengine.BaselinePolicies(clusterSpec())
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(policies), "testdata/expected_baseline_policies.yaml")
+}
+
+func TestBaselinePoliciesAudit(t *testing.T) {
+	g := NewWithT(t)
+	spec := clusterSpec()
+	spec.Cluster.Spec.PolicyEngine.Mode = v1alpha1.PolicyEngineAudit
+	policies, err := policyengine.BaselinePolicies(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(policies)).To(ContainSubstring("validationFailureAction: Audit"))
+	g.Expect(string(policies)).NotTo(ContainSubstring("validationFailureAction: Enforce"))
+}
+
+func TestInstallerInstall(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.helm.EXPECT().InstallChart(
+		tt.ctx, "kyverno", "oci://ghcr.io/kyverno/charts/kyverno", gomock.Any(), "workload.kubeconfig", "kyverno", "", false, nil,
+	)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallRegistryMirror(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "1.2.3.4",
+		Port:     "443",
+		OCINamespaces: []v1alpha1.OCINamespace{
+			{Registry: "ghcr.io", Namespace: "ghcr"},
+		},
+	}
+	tt.helm.EXPECT().InstallChart(
+		tt.ctx, "kyverno", "oci://1.2.3.4:443/ghcr/kyverno/charts/kyverno", gomock.Any(), "workload.kubeconfig", "kyverno", "", false,
+		[]string{"global.image.registry=1.2.3.4:443/ghcr"},
+	)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallNoPolicyEngine(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.PolicyEngine = nil
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallChartError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.helm.EXPECT().InstallChart(
+		tt.ctx, "kyverno", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(errors.New("helm error"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("installing policy engine chart: helm error"))
+}
+
+func TestInstallerInstallApplyError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.helm.EXPECT().InstallChart(
+		tt.ctx, "kyverno", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("apply error"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("applying policy engine baseline policies: apply error"))
+}
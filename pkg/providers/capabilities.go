@@ -0,0 +1,21 @@
+package providers
+
+import releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+
+// Capabilities describes the optional cluster lifecycle features a provider supports.
+// Validations and workflows should gate features on Capabilities instead of comparing provider names.
+type Capabilities struct {
+	// Autoscaling indicates the provider supports the cluster autoscaler on worker node groups.
+	Autoscaling bool
+	// ExternalEtcd indicates the provider can create clusters with an unstacked etcd topology.
+	ExternalEtcd bool
+	// KubeProxyReplacement indicates the provider supports running Cilium in kube-proxy replacement
+	// mode, which requires a static control plane endpoint.
+	KubeProxyReplacement bool
+	// TemplateOverridePaths are the paths of the generated CAPI objects that can be overridden with
+	// the cluster templateOverrides, by kind. Providers without paths don't support templateOverrides.
+	TemplateOverridePaths map[string][]string
+	// BundleImages returns the provider specific images of a versions bundle, the ones a cluster
+	// runs on top of the bundle shared images. It can be nil for providers without images of their own.
+	BundleImages func(vb *releasev1alpha1.VersionsBundle) []releasev1alpha1.Image
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
@@ -268,6 +269,17 @@ func (p *cloudstackProvider) BootstrapClusterOpts(clusterSpec *cluster.Spec) ([]
 	return common.BootstrapClusterOpts(p.clusterConfig, endpoints...)
 }
 
+// Capabilities returns the optional features supported by the provider.
+func (p *cloudstackProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{
+		Autoscaling:           true,
+		ExternalEtcd:          true,
+		KubeProxyReplacement:  true,
+		TemplateOverridePaths: templateOverridePaths,
+		BundleImages:          (*releasev1alpha1.VersionsBundle).CloudStackImages,
+	}
+}
+
 func (p *cloudstackProvider) Name() string {
 	return constants.CloudStackProviderName
 }
@@ -439,6 +451,9 @@ func needsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldCsmc, newCs
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return NeedNewMachineTemplate(oldSpec.CloudStackDatacenter, newSpec.CloudStackDatacenter, oldCsmc, newCsmc, log)
 }
 
@@ -452,6 +467,9 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldCsdc, newCsdc *
 		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(oldWorker, newWorker, oldSpec.Cluster, newSpec.Cluster) {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return NeedNewMachineTemplate(oldCsdc, newCsdc, oldCsmc, newCsmc, log)
 }
 
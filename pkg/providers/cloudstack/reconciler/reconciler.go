@@ -68,6 +68,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileCoreDNS,
+		r.CheckControlPlaneUpgraded,
 		r.ReconcileWorkers,
 	).Run(ctx, log, clusterSpec)
 }
@@ -142,6 +144,13 @@ func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger
 	return clusters.CheckControlPlaneReady(ctx, r.client, log, spec.Cluster)
 }
 
+// CheckControlPlaneUpgraded checks whether all the control plane machines run the desired Kubernetes version,
+// so the worker nodes are only upgraded after the control plane. Requeues while the control plane is being upgraded.
+func (r *Reconciler) CheckControlPlaneUpgraded(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneUpgraded")
+	return clusters.CheckControlPlaneUpgraded(ctx, r.client, log, spec.Cluster)
+}
+
 // ReconcileWorkerNodes validates the cluster definition and reconciles the worker nodes
 // to the desired state.
 func (r *Reconciler) ReconcileWorkerNodes(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
@@ -166,7 +175,7 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clus
 		return controller.Result{}, errors.Wrap(err, "Generate worker node CAPI spec")
 	}
 
-	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, clusterSpec.Cluster, clusters.ToWorkers(w))
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, r.remoteClientRegistry, clusterSpec.Cluster, clusters.ToWorkers(w))
 }
 
 // ReconcileCNI reconciles the CNI to the desired state.
@@ -179,3 +188,18 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
+
+// ReconcileCoreDNS scales up CoreDNS with the number of nodes of large clusters.
+func (r *Reconciler) ReconcileCoreDNS(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	if !clusterSpec.Cluster.IsLargeScale() {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("phase", "reconcileCoreDNS")
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileCoreDNS(ctx, log, client, clusterSpec.Cluster)
+}
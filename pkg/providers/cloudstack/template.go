@@ -8,7 +8,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
@@ -23,6 +22,12 @@ type TemplateBuilder struct {
 	now types.NowFunc
 }
 
+// templateOverridePaths are the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides.
+var templateOverridePaths = clusterapi.TemplateOverridePaths(map[string][]string{
+	"CloudStackMachineTemplate": {"spec.template.spec.details"},
+})
+
 // NewTemplateBuilder creates a new TemplateBuilder.
 func NewTemplateBuilder(now types.NowFunc) *TemplateBuilder {
 	return &TemplateBuilder{
@@ -62,7 +67,7 @@ func (cs *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		bytes = append(bytes, etcdMachineTemplateBytes...)
 	}
 
-	return bytes, nil
+	return clusterapi.ApplyTemplateOverrides(bytes, clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 // GenerateCAPISpecWorkers builds the CAPI worker template containing the CAPI objects for the worker node groups configuration defined in the cluster.Spec.
@@ -94,7 +99,7 @@ func (cs *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 		workerSpecs = append(workerSpecs, workerMachineTemplateBytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return clusterapi.ApplyTemplateOverrides(templater.AppendYamlResources(workerSpecs...), clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 // nolint:gocyclo
@@ -108,19 +113,23 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		return nil, err
 	}
 
-	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
-	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	etcdExtraArgs := clusterapi.EtcdTLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.LargeClusterEtcdExtraArgs(clusterSpec.Cluster))
+	sharedExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster)
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.PodSecurityAdmissionExtraArgs(clusterSpec.Cluster.Spec.PodSecurityAdmission)).
 		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.Spec.EtcdEncryption)).
+		Append(clusterapi.LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
 
-	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	controllerManagerExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.LargeClusterControllerManagerExtraArgs(clusterSpec.Cluster))
 
 	controlPlaneMachineSpec := controlPlaneMachineConfig(clusterSpec).Spec
 	controlPlaneSSHKey, err := common.StripSshAuthorizedKeyComment(controlPlaneMachineSpec.Users[0].SshAuthorizedKeys[0])
@@ -156,6 +165,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"managerImage":                               versionsBundle.CloudStack.ClusterAPIController.VersionedImage(),
 		"kubeRbacProxyImage":                         versionsBundle.CloudStack.KubeRbacProxy.VersionedImage(),
 		"kubeVipImage":                               versionsBundle.CloudStack.KubeVip.VersionedImage(),
+		"kubeVipLeaderElection":                      clusterapi.KubeVipLeaderElectionForCluster(clusterSpec.Cluster),
 		"cloudstackKubeVip":                          !features.IsActive(features.CloudStackKubeVipDisabled()),
 		"cloudstackAvailabilityZones":                datacenterConfigSpec.AvailabilityZones,
 		"cloudstackAnnotationSuffix":                 constants.CloudstackAnnotationSuffix,
@@ -180,13 +190,15 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"cloudstackEtcdSshAuthorizedKey":             etcdSSHAuthorizedKey,
 		"podCidrs":                                   clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                               clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
+		"apiserverCertSANs":                          clusterapi.ControlPlaneCertSANs(clusterSpec.Cluster),
 		"apiserverExtraArgs":                         apiServerExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                           kubeletExtraArgs.ToPartialYaml(),
 		"etcdExtraArgs":                              etcdExtraArgs.ToPartialYaml(),
-		"etcdCipherSuites":                           crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":                           clusterapi.EtcdCipherSuites(clusterSpec.Cluster),
 		"controllermanagerExtraArgs":                 controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":                         sharedExtraArgs.ToPartialYaml(),
 		"format":                                     format,
+		"fips":                                       clusterSpec.Cluster.Spec.FIPS,
 		"externalEtcdVersion":                        versionsBundle.KubeDistro.EtcdVersion,
 		"externalEtcdReleaseUrl":                     versionsBundle.KubeDistro.EtcdURL,
 		"etcdImage":                                  versionsBundle.KubeDistro.EtcdImage.VersionedImage(),
@@ -233,6 +245,18 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	if clusterSpec.AWSIamConfig != nil {
 		values["awsIamAuth"] = true
 	}
+
+	if clusterSpec.Cluster.Spec.PodSecurityAdmission != nil {
+		conf, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfig"] = conf
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		values["skipKubeProxy"] = true
+	}
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
 		values["upgradeRolloutStrategy"] = true
 		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
@@ -325,7 +349,7 @@ func fillProxyConfigurations(values map[string]interface{}, clusterSpec *cluster
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) (map[string]interface{}, error) {
 	versionsBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
 
@@ -352,6 +376,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		"workerSshUsername":                workerNodeGroupMachineSpec.Users[0].Name,
 		"cloudstackWorkerSshAuthorizedKey": workerSSHKey,
 		"format":                           format,
+		"fips":                             clusterSpec.Cluster.Spec.FIPS,
 		"kubeletExtraArgs":                 kubeletExtraArgs.ToPartialYaml(),
 		"eksaSystemNamespace":              constants.EksaSystemNamespace,
 		"workerNodeGroupName":              fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
@@ -17,7 +17,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -100,6 +99,15 @@ func (p *provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
+// Capabilities returns the optional features supported by the provider.
+func (p *provider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{
+		Autoscaling:           true,
+		ExternalEtcd:          true,
+		TemplateOverridePaths: templateOverridePaths,
+	}
+}
+
 func (p *provider) Name() string {
 	return constants.DockerProviderName
 }
@@ -147,6 +155,12 @@ func (p *provider) UpdateSecrets(ctx context.Context, cluster *types.Cluster, _
 	return nil
 }
 
+// templateOverridePaths are the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides.
+var templateOverridePaths = clusterapi.TemplateOverridePaths(map[string][]string{
+	"DockerMachineTemplate": {"spec.template.spec.extraMounts"},
+})
+
 // NewDockerTemplateBuilder returns a docker template builder object.
 func NewDockerTemplateBuilder(now types.NowFunc) *DockerTemplateBuilder {
 	return &DockerTemplateBuilder{
@@ -172,7 +186,7 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluste
 		return nil, err
 	}
 
-	return bytes, nil
+	return clusterapi.ApplyTemplateOverrides(bytes, clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
@@ -192,7 +206,7 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spe
 		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return clusterapi.ApplyTemplateOverrides(templater.AppendYamlResources(workerSpecs...), clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 // CAPIWorkersSpecWithInitialNames generates a yaml spec with the CAPI objects representing the worker
@@ -233,9 +247,10 @@ func kubeletCgroupDriverExtraArgs(kubeVersion v1alpha1.KubernetesVersion) (clust
 
 func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, error) {
 	versionsBundle := clusterSpec.RootVersionsBundle()
-	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
-	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	etcdExtraArgs := clusterapi.EtcdTLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.LargeClusterEtcdExtraArgs(clusterSpec.Cluster))
+	sharedExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster)
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
@@ -250,9 +265,12 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.PodSecurityAdmissionExtraArgs(clusterSpec.Cluster.Spec.PodSecurityAdmission)).
+		Append(clusterapi.LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
-	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	controllerManagerExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.LargeClusterControllerManagerExtraArgs(clusterSpec.Cluster))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
@@ -266,7 +284,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"corednsVersion":                versionsBundle.KubeDistro.CoreDNS.Tag,
 		"kindNodeImage":                 versionsBundle.EksD.KindNode.VersionedImage(),
 		"etcdExtraArgs":                 etcdExtraArgs.ToPartialYaml(),
-		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":              clusterapi.EtcdCipherSuites(clusterSpec.Cluster),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"controllermanagerExtraArgs":    controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":            sharedExtraArgs.ToPartialYaml(),
@@ -276,7 +294,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"haproxyImageRepository":        getHAProxyImageRepo(versionsBundle.Haproxy.Image),
-		"haproxyImageTag":               versionsBundle.Haproxy.Image.Tag(),
+		"haproxyImageTag":               versionsBundle.Haproxy.Image.PinnedTag(),
 		"workerNodeGroupConfigurations": clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
 	}
 
@@ -288,6 +306,14 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.Spec.PodSecurityAdmission != nil {
+		conf, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfig"] = conf
+	}
+
 	values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
@@ -312,7 +338,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		kubeVersion = *workerNodeGroupConfiguration.KubernetesVersion
 	}
 	versionsBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
 
@@ -346,14 +372,16 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 }
 
 func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
-	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
+	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number) ||
+		clusterapi.TemplateOverridesChanged(oldSpec, newSpec)
 }
 
 // NeedsNewWorkloadTemplate determines if a new workload template is needed.
 func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorker, newWorker v1alpha1.WorkerNodeGroupConfiguration) bool {
 	if !v1alpha1.TaintsSliceEqual(oldWorker.Taints, newWorker.Taints) ||
 		!v1alpha1.MapEqual(oldWorker.Labels, newWorker.Labels) ||
-		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(&oldWorker, &newWorker, oldSpec.Cluster, newSpec.Cluster) {
+		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(&oldWorker, &newWorker, oldSpec.Cluster, newSpec.Cluster) ||
+		clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
 		return true
 	}
 	return oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number
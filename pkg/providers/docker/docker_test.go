@@ -894,6 +894,45 @@ func TestDockerTemplateBuilderGenerateCAPISpecWorkers(t *testing.T) {
 	}
 }
 
+func TestDockerTemplateBuilderGenerateCAPISpecWorkersTemplateOverrides(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+		s.Cluster.Spec.TemplateOverrides = []v1alpha1.TemplateOverride{
+			{
+				Kind:  "KubeadmConfigTemplate",
+				Patch: "spec:\n  template:\n    spec:\n      joinConfiguration:\n        nodeRegistration:\n          kubeletExtraArgs:\n            max-pods: \"50\"\n",
+			},
+			{
+				Kind:  "MachineDeployment",
+				Patch: "metadata:\n  labels:\n    team: edge\n",
+			},
+		}
+	})
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	gotContent, err := builder.GenerateCAPISpecWorkers(clusterSpec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(gotContent)).To(ContainSubstring("max-pods: \"50\""))
+	g.Expect(string(gotContent)).To(ContainSubstring("team: edge"))
+}
+
+func TestDockerTemplateBuilderGenerateCAPISpecWorkersTemplateOverridesNotAllowed(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+		s.Cluster.Spec.TemplateOverrides = []v1alpha1.TemplateOverride{
+			{Kind: "MachineDeployment", Patch: "spec:\n  replicas: 5\n"},
+		}
+	})
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	_, err := builder.GenerateCAPISpecWorkers(clusterSpec, nil, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("templateOverrides can't override spec.replicas in MachineDeployment")))
+}
+
 func TestInvalidDockerTemplateWithControlplaneEndpoint(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -0,0 +1,92 @@
+package nutanix
+
+import (
+	"context"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+// circuitBreakerClient is a Client that stops calling Prism Central while it's failing, so
+// all the callers back off together instead of piling up retries.
+type circuitBreakerClient struct {
+	client  Client
+	retrier *retrier.Retrier
+}
+
+func newCircuitBreakerClient(client Client, cb *retrier.CircuitBreaker) *circuitBreakerClient {
+	return &circuitBreakerClient{
+		client: client,
+		// Calls are not retried, the retrier only waits for the circuit breaker.
+		retrier: retrier.NewAPIRetrier(1, 0, cb),
+	}
+}
+
+func call[T any](c *circuitBreakerClient, fn func() (T, error)) (T, error) {
+	var out T
+	err := c.retrier.Retry(func() error {
+		var err error
+		out, err = fn()
+		return err
+	})
+	return out, err
+}
+
+func (c *circuitBreakerClient) GetSubnet(ctx context.Context, uuid string) (*v3.SubnetIntentResponse, error) {
+	return call(c, func() (*v3.SubnetIntentResponse, error) { return c.client.GetSubnet(ctx, uuid) })
+}
+
+func (c *circuitBreakerClient) ListSubnet(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.SubnetListIntentResponse, error) {
+	return call(c, func() (*v3.SubnetListIntentResponse, error) { return c.client.ListSubnet(ctx, getEntitiesRequest) })
+}
+
+func (c *circuitBreakerClient) GetImage(ctx context.Context, uuid string) (*v3.ImageIntentResponse, error) {
+	return call(c, func() (*v3.ImageIntentResponse, error) { return c.client.GetImage(ctx, uuid) })
+}
+
+func (c *circuitBreakerClient) ListImage(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ImageListIntentResponse, error) {
+	return call(c, func() (*v3.ImageListIntentResponse, error) { return c.client.ListImage(ctx, getEntitiesRequest) })
+}
+
+func (c *circuitBreakerClient) GetCluster(ctx context.Context, uuid string) (*v3.ClusterIntentResponse, error) {
+	return call(c, func() (*v3.ClusterIntentResponse, error) { return c.client.GetCluster(ctx, uuid) })
+}
+
+func (c *circuitBreakerClient) ListCluster(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ClusterListIntentResponse, error) {
+	return call(c, func() (*v3.ClusterListIntentResponse, error) { return c.client.ListCluster(ctx, getEntitiesRequest) })
+}
+
+func (c *circuitBreakerClient) GetProject(ctx context.Context, uuid string) (*v3.Project, error) {
+	return call(c, func() (*v3.Project, error) { return c.client.GetProject(ctx, uuid) })
+}
+
+func (c *circuitBreakerClient) ListProject(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ProjectListResponse, error) {
+	return call(c, func() (*v3.ProjectListResponse, error) { return c.client.ListProject(ctx, getEntitiesRequest) })
+}
+
+func (c *circuitBreakerClient) GetCurrentLoggedInUser(ctx context.Context) (*v3.UserIntentResponse, error) {
+	return call(c, func() (*v3.UserIntentResponse, error) { return c.client.GetCurrentLoggedInUser(ctx) })
+}
+
+func (c *circuitBreakerClient) ListCategories(ctx context.Context, getEntitiesRequest *v3.CategoryListMetadata) (*v3.CategoryKeyListResponse, error) {
+	return call(c, func() (*v3.CategoryKeyListResponse, error) { return c.client.ListCategories(ctx, getEntitiesRequest) })
+}
+
+func (c *circuitBreakerClient) GetCategoryKey(ctx context.Context, name string) (*v3.CategoryKeyStatus, error) {
+	return call(c, func() (*v3.CategoryKeyStatus, error) { return c.client.GetCategoryKey(ctx, name) })
+}
+
+func (c *circuitBreakerClient) ListCategoryValues(ctx context.Context, name string, getEntitiesRequest *v3.CategoryListMetadata) (*v3.CategoryValueListResponse, error) {
+	return call(c, func() (*v3.CategoryValueListResponse, error) {
+		return c.client.ListCategoryValues(ctx, name, getEntitiesRequest)
+	})
+}
+
+func (c *circuitBreakerClient) GetCategoryValue(ctx context.Context, name string, value string) (*v3.CategoryValueStatus, error) {
+	return call(c, func() (*v3.CategoryValueStatus, error) { return c.client.GetCategoryValue(ctx, name, value) })
+}
+
+func (c *circuitBreakerClient) GetCategoryQuery(ctx context.Context, query *v3.CategoryQueryInput) (*v3.CategoryQueryResponse, error) {
+	return call(c, func() (*v3.CategoryQueryResponse, error) { return c.client.GetCategoryQuery(ctx, query) })
+}
@@ -0,0 +1,47 @@
+package nutanix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestCircuitBreakerClientPassesThrough(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockClient := mocknutanix.NewMockClient(ctrl)
+	want := &v3.ClusterIntentResponse{}
+	mockClient.EXPECT().GetCluster(ctx, "uuid").Return(want, nil)
+
+	c := newCircuitBreakerClient(mockClient, retrier.NewAPICircuitBreaker())
+	got, err := c.GetCluster(ctx, "uuid")
+	assert.NoError(t, err)
+	assert.Same(t, want, got)
+}
+
+func TestCircuitBreakerClientStopsCallingWhenOpen(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockClient := mocknutanix.NewMockClient(ctrl)
+	mockClient.EXPECT().GetSubnet(ctx, "uuid").Return(nil, errors.New("prism central unavailable")).Times(2)
+
+	cb := retrier.NewCircuitBreaker(1, time.Minute, time.Hour)
+	c := newCircuitBreakerClient(mockClient, cb)
+	c.retrier = retrier.New(time.Second, retrier.WithMaxRetries(1, 0), retrier.WithCircuitBreaker(cb))
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetSubnet(ctx, "uuid")
+		assert.EqualError(t, err, "prism central unavailable")
+	}
+
+	_, err := c.GetSubnet(ctx, "uuid")
+	assert.ErrorIs(t, err, retrier.ErrCircuitOpen)
+}
@@ -12,6 +12,7 @@ import (
 	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
 // ClientCache is a map of NutanixDatacenterConfig name to Nutanix client.
@@ -62,6 +63,7 @@ func (cb *ClientCache) GetNutanixClient(datacenterConfig *anywherev1.NutanixData
 		return nil, fmt.Errorf("error creating nutanix client: %v", err)
 	}
 
-	cb.clients[datacenterConfig.Name] = client.V3
-	return client.V3, nil
+	c := newCircuitBreakerClient(client.V3, retrier.NewAPICircuitBreaker())
+	cb.clients[datacenterConfig.Name] = c
+	return c, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -136,6 +137,17 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
+// Capabilities returns the optional features supported by the provider.
+func (p *Provider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{
+		Autoscaling:           true,
+		ExternalEtcd:          true,
+		KubeProxyReplacement:  true,
+		TemplateOverridePaths: templateOverridePaths,
+		BundleImages:          (*releasev1alpha1.VersionsBundle).NutanixImages,
+	}
+}
+
 func (p *Provider) Name() string {
 	return constants.NutanixProviderName
 }
@@ -303,9 +315,21 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldNmc, newNmc
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
+	if clusterLabelsChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldNmc, newNmc)
 }
 
+// clusterLabelsChanged returns true if the cluster labels changed, since they are added as categories
+// to the machine templates of all the cluster VMs.
+func clusterLabelsChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !v1alpha1.MapEqual(oldSpec.Cluster.Spec.ClusterLabels, newSpec.Cluster.Spec.ClusterLabels)
+}
+
 func nutanixIdentifierChanged(old, new v1alpha1.NutanixResourceIdentifier) bool {
 	if old.Type != new.Type {
 		return true
@@ -386,6 +410,12 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldNmc, newNmc *v1
 		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(&oldWorker, &newWorker, oldSpec.Cluster, newSpec.Cluster) {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
+	if clusterLabelsChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldNmc, newNmc)
 }
 
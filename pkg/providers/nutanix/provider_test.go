@@ -677,6 +677,28 @@ func TestNeedsNewControlPlaneTemplate(t *testing.T) {
 			},
 			expectedResult: true,
 		},
+		{
+			name: "cluster labels changed",
+			newClusterSpec: func(spec cluster.Spec) cluster.Spec {
+				s := spec.DeepCopy()
+				s.Cluster.Spec.ClusterLabels = map[string]string{"team": "platform"}
+				return *s
+			},
+			newMachineConfig: func(spec anywherev1.NutanixMachineConfig) anywherev1.NutanixMachineConfig {
+				return spec
+			},
+			expectedResult: true,
+		},
+		{
+			name: "no changes",
+			newClusterSpec: func(spec cluster.Spec) cluster.Spec {
+				return *spec.DeepCopy()
+			},
+			newMachineConfig: func(spec anywherev1.NutanixMachineConfig) anywherev1.NutanixMachineConfig {
+				return spec
+			},
+			expectedResult: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -736,6 +758,28 @@ func TestNeedsNewWorkloadTemplate(t *testing.T) {
 			},
 			expectedResult: true,
 		},
+		{
+			name: "cluster labels changed",
+			newClusterSpec: func(spec cluster.Spec) cluster.Spec {
+				s := spec.DeepCopy()
+				s.Cluster.Spec.ClusterLabels = map[string]string{"team": "platform"}
+				return *s
+			},
+			newMachineConfig: func(spec anywherev1.NutanixMachineConfig) anywherev1.NutanixMachineConfig {
+				return spec
+			},
+			expectedResult: true,
+		},
+		{
+			name: "no changes",
+			newClusterSpec: func(spec cluster.Spec) cluster.Spec {
+				return *spec.DeepCopy()
+			},
+			newMachineConfig: func(spec anywherev1.NutanixMachineConfig) anywherev1.NutanixMachineConfig {
+				return spec
+			},
+			expectedResult: false,
+		},
 	}
 
 	for _, tt := range tests {
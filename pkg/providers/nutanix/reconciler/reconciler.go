@@ -150,6 +150,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileCoreDNS,
+		r.CheckControlPlaneUpgraded,
 		r.ReconcileWorkers,
 	).Run(ctx, log, clusterSpec)
 }
@@ -166,6 +168,21 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return r.cniReconciler.Reconcile(ctx, log, c, clusterSpec)
 }
 
+// ReconcileCoreDNS scales up CoreDNS with the number of nodes of large clusters.
+func (r *Reconciler) ReconcileCoreDNS(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	if !clusterSpec.Cluster.IsLargeScale() {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("phase", "reconcileCoreDNS")
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileCoreDNS(ctx, log, client, clusterSpec.Cluster)
+}
+
 // ValidateClusterSpec performs additional, context-aware validations on the cluster spec.
 func (r *Reconciler) ValidateClusterSpec(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "validateClusterSpec")
@@ -234,7 +251,7 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, r.remoteClientRegistry, spec.Cluster, clusters.ToWorkers(w))
 }
 
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
@@ -243,3 +260,10 @@ func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger
 	log = log.WithValues("phase", "checkControlPlaneReady")
 	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
 }
+
+// CheckControlPlaneUpgraded checks whether all the control plane machines run the desired Kubernetes version,
+// so the worker nodes are only upgraded after the control plane. Requeues while the control plane is being upgraded.
+func (r *Reconciler) CheckControlPlaneUpgraded(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneUpgraded")
+	return clusters.CheckControlPlaneUpgraded(ctx, r.client, log, clusterSpec.Cluster)
+}
@@ -4,15 +4,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
@@ -22,6 +24,11 @@ import (
 
 var jsonMarshal = json.Marshal
 
+// templateOverridePaths are the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides. The Nutanix machine templates can't be patched, so only
+// the kubeadm objects can be overridden.
+var templateOverridePaths = clusterapi.TemplateOverridePaths(nil)
+
 // TemplateBuilder builds templates for nutanix.
 type TemplateBuilder struct {
 	datacenterSpec              *v1alpha1.NutanixDatacenterConfigSpec
@@ -71,7 +78,7 @@ func (ntb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Sp
 		return nil, err
 	}
 
-	return bytes, nil
+	return clusterapi.ApplyTemplateOverrides(bytes, clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func (ntb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
@@ -92,7 +99,7 @@ func (ntb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, w
 		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return clusterapi.ApplyTemplateOverrides(templater.AppendYamlResources(workerSpecs...), clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 // GenerateCAPISpecSecret generates the secret containing the credentials for the nutanix prism central and is used by the
@@ -154,11 +161,15 @@ func buildTemplateMapCP(
 	format := "cloud-config"
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
-		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig))
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.PodSecurityAdmissionExtraArgs(clusterSpec.Cluster.Spec.PodSecurityAdmission)).
+		Append(clusterapi.LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.CustomTLSExtraArgs(clusterSpec.Cluster))
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	values := map[string]interface{}{
+		"apiServerCertSANs":            clusterapi.ControlPlaneCertSANs(clusterSpec.Cluster),
 		"apiServerExtraArgs":           apiServerExtraArgs.ToPartialYaml(),
 		"clusterName":                  clusterSpec.Cluster.Name,
 		"controlPlaneEndpointIp":       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
@@ -168,6 +179,7 @@ func buildTemplateMapCP(
 		"controlPlaneTaints":           clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 		"eksaSystemNamespace":          constants.EksaSystemNamespace,
 		"format":                       format,
+		"fips":                         clusterSpec.Cluster.Spec.FIPS,
 		"podCidrs":                     clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                 clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"kubernetesVersion":            versionsBundle.KubeDistro.Kubernetes.Tag,
@@ -178,10 +190,11 @@ func buildTemplateMapCP(
 		"etcdImageTag":                 versionsBundle.KubeDistro.Etcd.Tag,
 		"kubeletExtraArgs":             kubeletExtraArgs.ToPartialYaml(),
 		"kubeVipImage":                 versionsBundle.Nutanix.KubeVip.VersionedImage(),
+		"kubeVipLeaderElection":        clusterapi.KubeVipLeaderElectionForCluster(clusterSpec.Cluster),
 		"kubeVipSvcEnable":             false,
 		"kubeVipLBEnable":              false,
 		"externalEtcdVersion":          versionsBundle.KubeDistro.EtcdVersion,
-		"etcdCipherSuites":             crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":             clusterapi.EtcdCipherSuites(clusterSpec.Cluster),
 		"nutanixEndpoint":              datacenterSpec.Endpoint,
 		"nutanixPort":                  datacenterSpec.Port,
 		"nutanixAdditionalTrustBundle": datacenterSpec.AdditionalTrustBundle,
@@ -239,6 +252,18 @@ func buildTemplateMapCP(
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.Spec.PodSecurityAdmission != nil {
+		conf, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfig"] = conf
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		values["skipKubeProxy"] = true
+	}
+
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
 		values["proxyConfig"] = true
 		values["httpProxy"] = clusterSpec.Cluster.Spec.ProxyConfiguration.HttpProxy
@@ -246,8 +271,8 @@ func buildTemplateMapCP(
 		values["noProxy"] = generateNoProxyList(clusterSpec)
 	}
 
-	if len(controlPlaneMachineSpec.AdditionalCategories) > 0 {
-		values["additionalCategories"] = controlPlaneMachineSpec.AdditionalCategories
+	if categories := additionalCategories(clusterSpec.Cluster, controlPlaneMachineSpec); len(categories) > 0 {
+		values["additionalCategories"] = categories
 	}
 
 	return values, nil
@@ -257,13 +282,14 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 	versionsBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
 
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration))
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
 		"eksaSystemNamespace":    constants.EksaSystemNamespace,
 		"format":                 format,
+		"fips":                   clusterSpec.Cluster.Spec.FIPS,
 		"kubernetesVersion":      versionsBundle.KubeDistro.Kubernetes.Tag,
 		"workerReplicas":         *workerNodeGroupConfiguration.Count,
 		"workerPoolName":         "md-0",
@@ -322,13 +348,29 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		values["noProxy"] = generateNoProxyList(clusterSpec)
 	}
 
-	if len(workerNodeGroupMachineSpec.AdditionalCategories) > 0 {
-		values["additionalCategories"] = workerNodeGroupMachineSpec.AdditionalCategories
+	if categories := additionalCategories(clusterSpec.Cluster, workerNodeGroupMachineSpec); len(categories) > 0 {
+		values["additionalCategories"] = categories
 	}
 
 	return values, nil
 }
 
+// additionalCategories returns the categories from the machine config followed by the
+// cluster labels, sorted by key, so all the cluster VMs can be tied back to the cluster.
+func additionalCategories(cluster *v1alpha1.Cluster, machineSpec v1alpha1.NutanixMachineConfigSpec) []v1alpha1.NutanixCategoryIdentifier {
+	categories := append([]v1alpha1.NutanixCategoryIdentifier{}, machineSpec.AdditionalCategories...)
+	keys := maps.Keys(cluster.Spec.ClusterLabels)
+	sort.Strings(keys)
+	for _, k := range keys {
+		category := v1alpha1.NutanixCategoryIdentifier{Key: k, Value: cluster.Spec.ClusterLabels[k]}
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories
+}
+
 func buildTemplateMapSecret(secretName string, creds credentials.BasicAuthCredential) (map[string]interface{}, error) {
 	encodedCreds, err := jsonMarshal(creds)
 	if err != nil {
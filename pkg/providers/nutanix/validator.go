@@ -66,6 +66,54 @@ func (v *Validator) ValidateClusterSpec(ctx context.Context, spec *cluster.Spec,
 		}
 	}
 
+	if err := v.validateClusterLabels(ctx, client, spec.Cluster); err != nil {
+		return err
+	}
+
+	if err := validateDedicatedPlacement(spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateDedicatedPlacement checks that worker nodes don't run in the same Prism Element cluster as the
+// control plane when the control plane requires dedicated placement.
+func validateDedicatedPlacement(spec *cluster.Spec) error {
+	if !spec.Cluster.Spec.ControlPlaneConfiguration.DedicatedPlacement {
+		return nil
+	}
+
+	controlPlaneMachineConfig, ok := spec.NutanixMachineConfigs[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	if !ok {
+		return fmt.Errorf("cannot find NutanixMachineConfig %v for control plane", spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+	}
+
+	for _, workerNodeGroupConfiguration := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		workerMachineConfig, ok := spec.NutanixMachineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]
+		if !ok {
+			return fmt.Errorf("cannot find NutanixMachineConfig %v for worker nodes", workerNodeGroupConfiguration.MachineGroupRef.Name)
+		}
+		if !nutanixIdentifierChanged(controlPlaneMachineConfig.Spec.Cluster, workerMachineConfig.Spec.Cluster) {
+			return fmt.Errorf("worker node group %s can't use the control plane Prism Element cluster, it's dedicated to the control plane machines", workerNodeGroupConfiguration.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateClusterLabels validates the cluster labels map to existing categories since they are
+// added to all the cluster VMs.
+func (v *Validator) validateClusterLabels(ctx context.Context, client Client, cluster *anywherev1.Cluster) error {
+	categories := make([]anywherev1.NutanixCategoryIdentifier, 0, len(cluster.Spec.ClusterLabels))
+	for k, val := range cluster.Spec.ClusterLabels {
+		categories = append(categories, anywherev1.NutanixCategoryIdentifier{Key: k, Value: val})
+	}
+
+	if err := v.validateAdditionalCategories(ctx, client, categories); err != nil {
+		return fmt.Errorf("failed to validate cluster labels: %v", err)
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	mockCrypto "github.com/aws/eks-anywhere/pkg/crypto/mocks"
 	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
@@ -551,6 +552,61 @@ func TestNutanixValidatorValidateMachineConfig(t *testing.T) {
 	}
 }
 
+func TestNutanixValidatorValidateClusterLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocknutanix.NewMockClient(ctrl)
+	clientCache := &ClientCache{clients: map[string]Client{"test": mockClient}}
+	validator := NewValidator(clientCache, mockCrypto.NewMockTlsValidator(ctrl), &http.Client{Transport: mocknutanix.NewMockRoundTripper(ctrl)})
+
+	cluster := &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			ClusterLabels: map[string]string{"team": "platform"},
+		},
+	}
+
+	categoryKey := v3.CategoryKeyStatus{Name: ptr.String("team")}
+	mockClient.EXPECT().GetCategoryKey(gomock.Any(), "team").Return(&categoryKey, nil).Times(2)
+	mockClient.EXPECT().GetCategoryValue(gomock.Any(), "team", "platform").Return(&v3.CategoryValueStatus{}, nil)
+	assert.NoError(t, validator.validateClusterLabels(context.Background(), mockClient, cluster))
+
+	mockClient.EXPECT().GetCategoryValue(gomock.Any(), "team", "platform").Return(nil, errors.New("category value not found"))
+	err := validator.validateClusterLabels(context.Background(), mockClient, cluster)
+	assert.ErrorContains(t, err, "failed to validate cluster labels: failed to find category value \"platform\" for category \"team\"")
+}
+
+func TestNutanixValidateDedicatedPlacement(t *testing.T) {
+	clusterSpec := &cluster.Spec{
+		Config: &cluster.Config{
+			Cluster: &anywherev1.Cluster{
+				Spec: anywherev1.ClusterSpec{
+					ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+						MachineGroupRef:    &anywherev1.Ref{Name: "cp"},
+						DedicatedPlacement: true,
+					},
+					WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+						{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "worker"}},
+					},
+				},
+			},
+			NutanixMachineConfigs: map[string]*anywherev1.NutanixMachineConfig{
+				"cp": {Spec: anywherev1.NutanixMachineConfigSpec{
+					Cluster: anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: ptr.String("pe-cp")},
+				}},
+				"worker": {Spec: anywherev1.NutanixMachineConfigSpec{
+					Cluster: anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: ptr.String("pe-workers")},
+				}},
+			},
+		},
+	}
+	assert.NoError(t, validateDedicatedPlacement(clusterSpec))
+
+	clusterSpec.NutanixMachineConfigs["worker"].Spec.Cluster.Name = ptr.String("pe-cp")
+	assert.ErrorContains(t, validateDedicatedPlacement(clusterSpec), "worker node group md-0 can't use the control plane Prism Element cluster")
+
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.DedicatedPlacement = false
+	assert.NoError(t, validateDedicatedPlacement(clusterSpec))
+}
+
 func TestNutanixValidatorValidateDatacenterConfig(t *testing.T) {
 	tests := []struct {
 		name       string
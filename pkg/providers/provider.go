@@ -11,6 +11,8 @@ import (
 
 type Provider interface {
 	Name() string
+	// Capabilities returns the optional features supported by the provider.
+	Capabilities() Capabilities
 	SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error
 	SetupAndValidateDeleteCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, currentSpec *cluster.Spec) error
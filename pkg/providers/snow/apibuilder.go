@@ -43,7 +43,7 @@ func KubeadmControlPlane(log logr.Logger, clusterSpec *cluster.Spec, snowMachine
 
 	versionsBundle := clusterSpec.RootVersionsBundle()
 
-	if err := clusterapi.SetKubeVipInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, versionsBundle.Snow.KubeVip.VersionedImage()); err != nil {
+	if err := clusterapi.SetKubeVipInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, versionsBundle.Snow.KubeVip.VersionedImage(), clusterapi.KubeVipLeaderElectionForCluster(clusterSpec.Cluster)); err != nil {
 		return nil, fmt.Errorf("setting kube-vip: %v", err)
 	}
 
@@ -92,6 +92,14 @@ func KubeadmControlPlane(log logr.Logger, clusterSpec *cluster.Spec, snowMachine
 		log.Info("Warning: unsupported OS family when setting up KubeadmControlPlane", "OS family", osFamily)
 	}
 
+	if err := clusterapi.SetHostFirewallInKubeadmControlPlane(kcp, machineConfig.Spec.HostOSConfiguration); err != nil {
+		return nil, err
+	}
+
+	if err := clusterapi.SetCloudInitUserDataInKubeadmControlPlane(kcp, machineConfig.Spec.HostOSConfiguration); err != nil {
+		return nil, err
+	}
+
 	return kcp, nil
 }
 
@@ -102,7 +110,7 @@ func KubeadmConfigTemplate(log logr.Logger, clusterSpec *cluster.Spec, workerNod
 		return nil, fmt.Errorf("generating KubeadmConfigTemplate: %v", err)
 	}
 
-	versionsBundle := clusterSpec.RootVersionsBundle()
+	versionsBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfig)
 
 	joinConfigKubeletExtraArg := kct.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs
 	joinConfigKubeletExtraArg["provider-id"] = "aws-snow:////'{{ ds.meta_data.instance_id }}'"
@@ -137,6 +145,14 @@ func KubeadmConfigTemplate(log logr.Logger, clusterSpec *cluster.Spec, workerNod
 		log.Info("Warning: unsupported OS family when setting up KubeadmConfigTemplate", "OS family", osFamily)
 	}
 
+	if err := clusterapi.SetHostFirewallInKubeadmConfigTemplate(kct, machineConfig.Spec.HostOSConfiguration); err != nil {
+		return nil, err
+	}
+
+	if err := clusterapi.SetCloudInitUserDataInKubeadmConfigTemplate(kct, machineConfig.Spec.HostOSConfiguration); err != nil {
+		return nil, err
+	}
+
 	return kct, nil
 }
 
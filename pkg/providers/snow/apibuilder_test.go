@@ -549,6 +549,37 @@ func TestKubeadmControlPlaneWithProxyConfigUbuntu(t *testing.T) {
 	}
 }
 
+func TestKubeadmControlPlaneWithHostFirewall(t *testing.T) {
+	g := newApiBuilerTest(t)
+	g.clusterSpec.SnowMachineConfig("test-cp").Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+		FirewallConfiguration: &v1alpha1.FirewallConfiguration{
+			NodePortRange: "31000-31999",
+			Rules:         []string{"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop"},
+		},
+	}
+	controlPlaneMachineTemplate := snow.MachineTemplate("snow-test-control-plane-1", g.machineConfigs["test-cp"], nil)
+	got, err := snow.KubeadmControlPlane(g.logger, g.clusterSpec, controlPlaneMachineTemplate)
+	g.Expect(err).To(Succeed())
+
+	g.Expect(got.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs).To(HaveKeyWithValue("service-node-port-range", "31000-31999"))
+	g.Expect(got.Spec.KubeadmConfigSpec.Files).To(ContainElement(HaveField("Path", "/etc/eks-anywhere/host-firewall.nft")))
+	g.Expect(got.Spec.KubeadmConfigSpec.Files).To(ContainElement(HaveField("Content", ContainSubstring("tcp dport 31000-31999 ip saddr != 10.0.0.0/8 drop"))))
+	g.Expect(got.Spec.KubeadmConfigSpec.PreKubeadmCommands).To(ContainElement("systemctl enable --now eksa-host-firewall.service"))
+}
+
+func TestKubeadmConfigTemplateWithCloudInitUserData(t *testing.T) {
+	g := newApiBuilerTest(t)
+	workerNodeGroupConfig := g.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	g.clusterSpec.SnowMachineConfigs["test-wn"].Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+		CloudInitUserData: "write_files:\n- path: /etc/agent.yaml\n  content: a\nruncmd:\n- /usr/local/bin/install-agent.sh\n",
+	}
+	got, err := snow.KubeadmConfigTemplate(g.logger, g.clusterSpec, workerNodeGroupConfig)
+	g.Expect(err).To(Succeed())
+
+	g.Expect(got.Spec.Template.Spec.Files).To(ContainElement(bootstrapv1.File{Path: "/etc/agent.yaml", Content: "a"}))
+	g.Expect(got.Spec.Template.Spec.PostKubeadmCommands).To(Equal([]string{"/usr/local/bin/install-agent.sh"}))
+}
+
 func TestKubeadmControlPlaneWithProxyConfigBottlerocket(t *testing.T) {
 	for _, tt := range proxyTests {
 		t.Run(tt.name, func(t *testing.T) {
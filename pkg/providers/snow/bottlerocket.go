@@ -16,7 +16,7 @@ func bottlerocketBootstrapSnow(image releasev1.Image) bootstrapv1.BottlerocketBo
 		Name: bottlerocketBootstrapImage,
 		ImageMeta: bootstrapv1.ImageMeta{
 			ImageRepository: image.Image(),
-			ImageTag:        image.Tag(),
+			ImageTag:        image.PinnedTag(),
 		},
 		Mode: "always",
 	}
@@ -64,6 +64,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileCoreDNS,
+		r.CheckControlPlaneUpgraded,
 		r.ReconcileWorkers,
 	).Run(ctx, log, clusterSpec)
 }
@@ -119,6 +121,13 @@ func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger
 	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
 }
 
+// CheckControlPlaneUpgraded checks whether all the control plane machines run the desired Kubernetes version,
+// so the worker nodes are only upgraded after the control plane. Requeues while the control plane is being upgraded.
+func (r *Reconciler) CheckControlPlaneUpgraded(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneUpgraded")
+	return clusters.CheckControlPlaneUpgraded(ctx, r.client, log, clusterSpec.Cluster)
+}
+
 func (s *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileCNI")
 
@@ -130,6 +139,21 @@ func (s *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return s.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileCoreDNS scales up CoreDNS with the number of nodes of large clusters.
+func (s *Reconciler) ReconcileCoreDNS(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	if !clusterSpec.Cluster.IsLargeScale() {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("phase", "reconcileCoreDNS")
+
+	client, err := s.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileCoreDNS(ctx, log, client, clusterSpec.Cluster)
+}
+
 func (s *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
 	log.Info("Applying worker CAPI objects")
@@ -139,7 +163,7 @@ func (s *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clus
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileWorkersForEKSA(ctx, log, s.client, clusterSpec.Cluster, toClientWorkers(w))
+	return clusters.ReconcileWorkersForEKSA(ctx, log, s.client, s.remoteClientRegistry, clusterSpec.Cluster, toClientWorkers(w))
 }
 
 func toClientControlPlane(cp *snow.ControlPlane) *clusters.ControlPlane {
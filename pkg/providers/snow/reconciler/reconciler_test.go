@@ -3,6 +3,7 @@ package reconciler_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -237,6 +238,38 @@ func TestReconcilerCheckControlPlaneReadyItIsReady(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerCheckControlPlaneUpgradedItIsUpgraded(t *testing.T) {
+	tt := newReconcilerTest(t)
+	kcp := test.KubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Name = tt.cluster.Name
+		k.Spec.Version = "v1.24.9-eks-1-24-7"
+		k.Status.Version = ptr.String("v1.24.9-eks-1-24-7")
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, kcp)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().CheckControlPlaneUpgraded(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerCheckControlPlaneUpgradedItIsUpgrading(t *testing.T) {
+	tt := newReconcilerTest(t)
+	kcp := test.KubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Name = tt.cluster.Name
+		k.Spec.Version = "v1.24.9-eks-1-24-7"
+		k.Status.Version = ptr.String("v1.23.16-eks-1-23-16")
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, kcp)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().CheckControlPlaneUpgraded(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
 func TestReconcilerReconcileCNISuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
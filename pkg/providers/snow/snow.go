@@ -65,6 +65,15 @@ func NewProvider(kubeUnAuthClient KubeUnAuthClient, configManager *ConfigManager
 	}
 }
 
+// Capabilities returns the optional features supported by the provider.
+func (p *SnowProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{
+		ExternalEtcd:         true,
+		KubeProxyReplacement: true,
+		BundleImages:         (*releasev1alpha1.VersionsBundle).SnowImages,
+	}
+}
+
 func (p *SnowProvider) Name() string {
 	return constants.SnowProviderName
 }
@@ -86,6 +86,23 @@ func AssertK8SVersionNot120(spec *ClusterSpec) error {
 	return nil
 }
 
+// AssertWorkerNodeGroupKubernetesVersionsSupported ensures worker node groups only run a Kubernetes
+// version different from the control plane when the OS images are retrieved from the bundle, since a
+// custom osImageURL points to a single image built for a single Kubernetes version.
+func AssertWorkerNodeGroupKubernetesVersionsSupported(spec *ClusterSpec) error {
+	if spec.DatacenterConfig.Spec.OSImageURL == "" {
+		return nil
+	}
+
+	for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if group.KubernetesVersion != nil && *group.KubernetesVersion != spec.Cluster.Spec.KubernetesVersion {
+			return fmt.Errorf("worker node group %s kubernetesVersion %s is different from the cluster kubernetesVersion, which is not supported with a custom osImageURL", group.Name, *group.KubernetesVersion)
+		}
+	}
+
+	return nil
+}
+
 func AssertOsFamilyValid(spec *ClusterSpec) error {
 	return validateOsFamily(spec)
 }
@@ -123,6 +140,28 @@ func AssertTinkerbellIPAndControlPlaneIPNotSame(spec *ClusterSpec) error {
 	return nil
 }
 
+// AssertStackReplicasSchedulable ensures the Tinkerbell stack replicas don't exceed the number of
+// nodes in the cluster so each replica can run on a different node.
+func AssertStackReplicasSchedulable(spec *ClusterSpec) error {
+	stackReplicas := spec.DatacenterConfig.Spec.StackReplicas
+	if stackReplicas < 2 {
+		return nil
+	}
+
+	nodes := spec.Cluster.Spec.ControlPlaneConfiguration.Count
+	for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if group.Count != nil {
+			nodes += *group.Count
+		}
+	}
+
+	if stackReplicas > nodes {
+		return fmt.Errorf("stackReplicas %d exceeds the %d cluster nodes, each Tinkerbell stack replica requires a different node", stackReplicas, nodes)
+	}
+
+	return nil
+}
+
 // AssertHookRetrievableWithoutProxy ensures the executing machine can retrieve Hook
 // from the host URL without a proxy configured. It does not guarantee the target node
 // will be able to download Hook.
@@ -324,7 +363,7 @@ func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current Validatable
 			return err
 		}
 
-		if spec.HasExternalEtcd() {
+		if spec.HasExternalEtcd() && !capabilities.ExternalEtcd {
 			return fmt.Errorf("scale up/down not supported for external etcd")
 		}
 		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
@@ -429,7 +468,7 @@ func ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue *hardware.Catalo
 			}
 		}
 
-		if spec.HasExternalEtcd() {
+		if spec.HasExternalEtcd() && !capabilities.ExternalEtcd {
 			return fmt.Errorf("external etcd upgrade is not supported")
 		}
 
@@ -76,6 +76,13 @@ func TestAssertDatacenterConfigValid_InvalidFails(t *testing.T) {
 		"TinkerbellIPInvalid": func(c *tinkerbell.ClusterSpec) {
 			c.DatacenterConfig.Spec.TinkerbellIP = "invalid"
 		},
+		"NegativeStackReplicas": func(c *tinkerbell.ClusterSpec) {
+			c.DatacenterConfig.Spec.StackReplicas = -1
+		},
+		"StackReplicasWithoutLoadBalancer": func(c *tinkerbell.ClusterSpec) {
+			c.DatacenterConfig.Spec.StackReplicas = 2
+			c.DatacenterConfig.Spec.SkipLoadBalancerDeployment = true
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			cluster := NewDefaultValidClusterSpecBuilder().Build()
@@ -99,6 +106,42 @@ func TestAssertDatacenterConfigValidEmptyHookImagesURLPath(t *testing.T) {
 	g.Expect(tinkerbell.AssertDatacenterConfigValid(clusterSpec)).To(gomega.MatchError("parsing hookOverride: parse \"test\": invalid URI for request"))
 }
 
+func TestAssertWorkerNodeGroupKubernetesVersionsSupported(t *testing.T) {
+	kube126 := eksav1alpha1.Kube126
+	for name, tc := range map[string]struct {
+		osImageURL    string
+		workerVersion *eksav1alpha1.KubernetesVersion
+		wantErr       bool
+	}{
+		"NoWorkerVersion": {
+			osImageURL: "https://ubuntu.gz",
+		},
+		"WorkerVersionWithBundleImages": {
+			workerVersion: &kube126,
+		},
+		"WorkerVersionWithOSImageURL": {
+			osImageURL:    "https://ubuntu.gz",
+			workerVersion: &kube126,
+			wantErr:       true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+			clusterSpec.Cluster.Spec.KubernetesVersion = eksav1alpha1.Kube127
+			clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = tc.workerVersion
+			clusterSpec.DatacenterConfig.Spec.OSImageURL = tc.osImageURL
+
+			err := tinkerbell.AssertWorkerNodeGroupKubernetesVersionsSupported(clusterSpec)
+			if tc.wantErr {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not supported with a custom osImageURL")))
+			} else {
+				g.Expect(err).To(gomega.Succeed())
+			}
+		})
+	}
+}
+
 func TestAssertMachineConfigNamespaceMatchesDatacenterConfig_Same(t *testing.T) {
 	g := gomega.NewWithT(t)
 	builder := NewDefaultValidClusterSpecBuilder()
@@ -251,6 +294,24 @@ func TestAssertTinkerbellIPAndControlPlaneIPNotSame_SameFails(t *testing.T) {
 	g.Expect(tinkerbell.AssertTinkerbellIPAndControlPlaneIPNotSame(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestAssertStackReplicasSchedulable_Succeeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.StackReplicas = 2
+
+	g.Expect(tinkerbell.AssertStackReplicasSchedulable(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertStackReplicasSchedulable_TooManyReplicasFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.StackReplicas = 10
+
+	g.Expect(tinkerbell.AssertStackReplicasSchedulable(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("stackReplicas 10 exceeds")))
+}
+
 func TestAssertPortsNotInUse_Succeeds(t *testing.T) {
 	g := gomega.NewWithT(t)
 	ctrl := gomock.NewController(t)
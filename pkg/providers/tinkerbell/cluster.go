@@ -105,10 +105,12 @@ func NewClusterSpecValidator(assertions ...ClusterSpecAssertion) *ClusterSpecVal
 		AssertControlPlaneMachineRefExists,
 		AssertEtcdMachineRefExists,
 		AssertWorkerNodeGroupMachineRefsExists,
+		AssertWorkerNodeGroupKubernetesVersionsSupported,
 		AssertMachineConfigsValid,
 		AssertMachineConfigNamespaceMatchesDatacenterConfig,
 		AssertOsFamilyValid,
 		AssertTinkerbellIPAndControlPlaneIPNotSame,
+		AssertStackReplicasSchedulable,
 		AssertHookRetrievableWithoutProxy,
 	)
 	v.Register(assertions...)
@@ -0,0 +1,142 @@
+package tinkerbell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const (
+	defaultConsoleCaptureWindow = time.Minute
+	defaultConsoleRetryBackoff  = 10 * time.Second
+)
+
+// SOLCapturer captures the serial-over-LAN console output of a BMC.
+type SOLCapturer interface {
+	CaptureSOL(ctx context.Context, host, username, password string) ([]byte, error)
+}
+
+// ConsoleLogCollector streams the serial-over-LAN console output of every BMC in a hardware catalogue
+// to log files under the constants.ConsoleLogsFolder of the writer. The log files are picked up
+// by the diagnostics bundle so PXE and cloud-init failures on bare metal can be inspected.
+type ConsoleLogCollector struct {
+	capturer SOLCapturer
+	writer   filewriter.FileWriter
+
+	// captureWindow is the duration of a single SOL session. Sessions are re-established until
+	// the collector is stopped so BMCs that drop idle sessions don't end the capture.
+	captureWindow time.Duration
+	retryBackoff  time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	paths  []string
+}
+
+// NewConsoleLogCollector returns a new ConsoleLogCollector.
+func NewConsoleLogCollector(capturer SOLCapturer, writer filewriter.FileWriter) *ConsoleLogCollector {
+	return &ConsoleLogCollector{
+		capturer:      capturer,
+		writer:        writer,
+		captureWindow: defaultConsoleCaptureWindow,
+		retryBackoff:  defaultConsoleRetryBackoff,
+	}
+}
+
+// Start begins capturing the console of every BMC in catalogue in the background. Capturing
+// continues until Stop is called or ctx is done. Calling Start on a running collector is a no-op.
+func (c *ConsoleLogCollector) Start(ctx context.Context, catalogue *hardware.Catalogue) error {
+	if c.cancel != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(c.writer.Dir(), constants.ConsoleLogsFolder), os.ModePerm); err != nil {
+		return fmt.Errorf("creating console logs folder: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+
+	for _, bmc := range catalogue.AllBMCs() {
+		secrets, err := catalogue.LookupSecret(hardware.SecretNameIndex, bmc.Spec.Connection.AuthSecretRef.Name)
+		if err != nil || len(secrets) == 0 {
+			logger.V(4).Info("Skipping console log capture, BMC secret not found", "bmc", bmc.Name)
+			continue
+		}
+		secret := secrets[0]
+
+		file, path, err := c.writer.Create(
+			filepath.Join(constants.ConsoleLogsFolder, fmt.Sprintf("%s.log", bmc.Name)),
+			filewriter.PersistentFile,
+		)
+		if err != nil {
+			c.Stop()
+			return fmt.Errorf("creating console log file for %s: %v", bmc.Name, err)
+		}
+		c.paths = append(c.paths, path)
+
+		c.wg.Add(1)
+		go func(host, username, password string, out io.WriteCloser) {
+			defer c.wg.Done()
+			defer out.Close()
+			c.capture(ctx, host, username, password, out)
+		}(bmc.Spec.Connection.Host, string(secret.Data["username"]), string(secret.Data["password"]), file)
+	}
+
+	logger.V(4).Info("Capturing serial-over-LAN console logs", "machines", len(c.paths))
+
+	return nil
+}
+
+// Stop ends all console captures and waits for them to flush. It returns the paths to the
+// console log files.
+func (c *ConsoleLogCollector) Stop() []string {
+	if c.cancel == nil {
+		return c.paths
+	}
+
+	c.cancel()
+	c.wg.Wait()
+	c.cancel = nil
+
+	return c.paths
+}
+
+// Close stops the collector if it's running. It satisfies types.Closer so the collector can be
+// stopped along with the rest of the command dependencies.
+func (c *ConsoleLogCollector) Close(_ context.Context) error {
+	c.Stop()
+	return nil
+}
+
+func (c *ConsoleLogCollector) capture(ctx context.Context, host, username, password string, out io.Writer) {
+	for ctx.Err() == nil {
+		captureCtx, cancel := context.WithTimeout(ctx, c.captureWindow)
+		output, err := c.capturer.CaptureSOL(captureCtx, host, username, password)
+		cancel()
+
+		if len(output) > 0 {
+			if _, werr := out.Write(output); werr != nil {
+				logger.V(4).Info("Failed writing console log", "host", host, "error", werr)
+				return
+			}
+		}
+
+		if err != nil {
+			logger.V(5).Info("Console capture failed, retrying", "host", host, "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.retryBackoff):
+			}
+		}
+	}
+}
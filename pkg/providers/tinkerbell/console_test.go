@@ -0,0 +1,106 @@
+package tinkerbell
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type fakeSOLCapturer struct {
+	output []byte
+	err    error
+}
+
+func (f *fakeSOLCapturer) CaptureSOL(ctx context.Context, host, username, password string) ([]byte, error) {
+	<-ctx.Done()
+	return f.output, f.err
+}
+
+func givenCatalogueWithBMC(t *testing.T) *hardware.Catalogue {
+	t.Helper()
+	catalogue := hardware.NewCatalogue(hardware.WithSecretNameIndex())
+	machine := hardware.Machine{
+		Hostname:     "worker1",
+		MACAddress:   "00:00:00:00:00:01",
+		BMCIPAddress: "10.0.0.1",
+		BMCUsername:  "admin",
+		BMCPassword:  "password",
+	}
+	if err := hardware.NewBMCCatalogueWriter(catalogue).Write(machine); err != nil {
+		t.Fatal(err)
+	}
+	if err := hardware.NewSecretCatalogueWriter(catalogue).Write(machine); err != nil {
+		t.Fatal(err)
+	}
+	return catalogue
+}
+
+func TestConsoleLogCollectorCapturesToFile(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	collector := NewConsoleLogCollector(&fakeSOLCapturer{output: []byte("PXE boot\n")}, writer)
+	collector.captureWindow = 10 * time.Millisecond
+
+	g.Expect(collector.Start(context.Background(), givenCatalogueWithBMC(t))).To(Succeed())
+	time.Sleep(50 * time.Millisecond)
+	paths := collector.Stop()
+
+	g.Expect(paths).To(ConsistOf(filepath.Join(writer.Dir(), constants.ConsoleLogsFolder, "bmc-worker1.log")))
+	content, err := os.ReadFile(paths[0])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("PXE boot\n"))
+}
+
+func TestConsoleLogCollectorRetriesOnError(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	collector := NewConsoleLogCollector(&fakeSOLCapturer{err: errors.New("session refused")}, writer)
+	collector.captureWindow = time.Millisecond
+	collector.retryBackoff = time.Millisecond
+
+	g.Expect(collector.Start(context.Background(), givenCatalogueWithBMC(t))).To(Succeed())
+	time.Sleep(10 * time.Millisecond)
+	g.Expect(collector.Stop()).To(HaveLen(1))
+}
+
+func TestConsoleLogCollectorSkipsBMCWithoutSecret(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	catalogue := hardware.NewCatalogue(hardware.WithSecretNameIndex())
+	g.Expect(hardware.NewBMCCatalogueWriter(catalogue).Write(hardware.Machine{
+		Hostname:     "worker1",
+		BMCIPAddress: "10.0.0.1",
+	})).To(Succeed())
+	collector := NewConsoleLogCollector(&fakeSOLCapturer{}, writer)
+
+	g.Expect(collector.Start(context.Background(), catalogue)).To(Succeed())
+	g.Expect(collector.Stop()).To(BeEmpty())
+}
+
+func TestConsoleLogCollectorStopWithoutStart(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	collector := NewConsoleLogCollector(&fakeSOLCapturer{}, writer)
+
+	g.Expect(collector.Stop()).To(BeEmpty())
+}
+
+func TestConsoleLogCollectorCloseStopsCapture(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	collector := NewConsoleLogCollector(&fakeSOLCapturer{}, writer)
+	collector.captureWindow = time.Hour
+
+	g.Expect(collector.Start(context.Background(), givenCatalogueWithBMC(t))).To(Succeed())
+	g.Expect(collector.Close(context.Background())).To(Succeed())
+	g.Expect(collector.cancel).To(BeNil())
+}
@@ -47,7 +47,17 @@ func (p *Provider) PreCAPIInstallOnBootstrap(ctx context.Context, cluster *types
 }
 
 func (p *Provider) PostBootstrapSetup(ctx context.Context, clusterConfig *v1alpha1.Cluster, cluster *types.Cluster) error {
-	return p.applyHardware(ctx, cluster)
+	if err := p.applyHardware(ctx, cluster); err != nil {
+		return err
+	}
+
+	if p.consoleLogs != nil && p.catalogue.TotalBMCs() > 0 {
+		if err := p.consoleLogs.Start(ctx, p.catalogue); err != nil {
+			return fmt.Errorf("starting console log capture: %v", err)
+		}
+	}
+
+	return nil
 }
 
 // ApplyHardwareToCluster adds all the hardwares to the cluster.
@@ -90,15 +100,41 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 		stack.WithLoadBalancerEnabled(
 			len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations) != 0 && // load balancer is handled by kube-vip in control plane nodes
 				!p.datacenterConfig.Spec.SkipLoadBalancerDeployment), // configure load balancer based on datacenterConfig.Spec.SkipLoadBalancerDeployment
+		stack.WithStackReplicas(p.datacenterConfig.Spec.StackReplicas),
 	)
 	if err != nil {
 		return fmt.Errorf("installing stack on workload cluster: %v", err)
 	}
 
+	if err := p.waitForStackReplicas(ctx, cluster); err != nil {
+		return err
+	}
+
 	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
 		return err
 	}
 
+	if p.consoleLogs != nil {
+		logger.V(4).Info("Stopped console log capture", "files", p.consoleLogs.Stop())
+	}
+
+	return nil
+}
+
+// waitForStackReplicas waits for all the replicas of the Tinkerbell services to be available
+// when the stack runs in HA.
+func (p *Provider) waitForStackReplicas(ctx context.Context, cluster *types.Cluster) error {
+	if p.datacenterConfig.Spec.StackReplicas < 2 {
+		return nil
+	}
+
+	for _, deployment := range stackHADeployments {
+		err := p.providerKubectlClient.WaitForDeployment(ctx, cluster, stackDeploymentWaitTimeout, "Available", deployment, p.stackInstaller.GetNamespace())
+		if err != nil {
+			return fmt.Errorf("waiting for Tinkerbell stack deployment %s replicas to be available: %v", deployment, err)
+		}
+	}
+
 	return nil
 }
 
@@ -117,7 +153,7 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 		return err
 	}
 	if p.hardwareCSVIsProvided() {
-		if err := p.readCSVToCatalogue(); err != nil {
+		if err := p.readCSVToCatalogue(ctx); err != nil {
 			return err
 		}
 	}
@@ -224,7 +260,22 @@ func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster
 	return nil
 }
 
-func (p *Provider) readCSVToCatalogue() error {
+// newHardwareCSVReader reads the machines in the hardware CSV, resolving the BMC credentials that
+// reference external secrets if a secret resolver is configured.
+func (p *Provider) newHardwareCSVReader(ctx context.Context) (hardware.MachineReader, error) {
+	machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.secretResolver != nil {
+		machines = hardware.NewBMCSecretResolver(ctx, machines, p.secretResolver)
+	}
+
+	return machines, nil
+}
+
+func (p *Provider) readCSVToCatalogue(ctx context.Context) error {
 	// Create a catalogue writer used to write hardware to the catalogue.
 	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
@@ -233,7 +284,7 @@ func (p *Provider) readCSVToCatalogue() error {
 	// Translate all Machine instances from the p.machines source into Kubernetes object types.
 	// The PostBootstrapSetup() call invoked elsewhere in the program serializes the catalogue
 	// and submits it to the clsuter.
-	machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
+	machines, err := p.newHardwareCSVReader(ctx)
 	if err != nil {
 		return err
 	}
@@ -98,12 +98,17 @@ func BuildHardwareYAML(path string) ([]byte, error) {
 		return nil, fmt.Errorf("reading csv: %v", err)
 	}
 
+	return BuildHardwareYAMLFromReader(reader)
+}
+
+// BuildHardwareYAMLFromReader builds a hardware yaml from the machines read from reader.
+func BuildHardwareYAMLFromReader(reader MachineReader) ([]byte, error) {
 	var b bytes.Buffer
 	writer := NewTinkerbellManifestYAML(&b)
 
 	validator := NewDefaultMachineValidator()
 
-	err = TranslateAll(reader, writer, validator)
+	err := TranslateAll(reader, writer, validator)
 	if err != nil {
 		return nil, fmt.Errorf("generating hardware yaml: %v", err)
 	}
@@ -9,6 +9,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
@@ -94,3 +96,27 @@ func (kr *KubeReader) LoadRufioMachines(ctx context.Context) error {
 
 	return nil
 }
+
+// CountAvailable returns the number of hardware objects in the eksa-system namespace that match
+// selector and aren't owned by a cluster yet.
+func CountAvailable(ctx context.Context, reader kubernetes.Reader, selector v1alpha1.HardwareSelector) (int, error) {
+	hwList := &tinkv1alpha1.HardwareList{}
+	if err := reader.List(ctx, hwList); err != nil {
+		return 0, fmt.Errorf("listing hardware: %v", err)
+	}
+
+	available := 0
+	for _, hw := range hwList.Items {
+		if hw.Namespace != constants.EksaSystemNamespace {
+			continue
+		}
+		if _, owned := hw.Labels[OwnerNameLabel]; owned {
+			continue
+		}
+		if LabelsMatchSelector(selector, hw.Labels) {
+			available++
+		}
+	}
+
+	return available, nil
+}
@@ -2,8 +2,10 @@ package hardware_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
@@ -11,6 +13,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	kubemocks "github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
@@ -113,3 +118,39 @@ func TestLoadRufioMachinesListFail(t *testing.T) {
 	err := kubeReader.LoadRufioMachines(ctx)
 	g.Expect(err).To(HaveOccurred())
 }
+
+func TestCountAvailable(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	reader := kubemocks.NewMockReader(gomock.NewController(t))
+
+	newHardware := func(name, namespace string, labels map[string]string) tinkv1alpha1.Hardware {
+		return tinkv1alpha1.Hardware{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		}
+	}
+	reader.EXPECT().List(ctx, &tinkv1alpha1.HardwareList{}).DoAndReturn(func(_ context.Context, list kubernetes.ObjectList) error {
+		list.(*tinkv1alpha1.HardwareList).Items = []tinkv1alpha1.Hardware{
+			newHardware("hw1", constants.EksaSystemNamespace, map[string]string{"type": "worker"}),
+			newHardware("hw2", constants.EksaSystemNamespace, map[string]string{"type": "worker", hardware.OwnerNameLabel: "machine"}),
+			newHardware("hw3", constants.EksaSystemNamespace, map[string]string{"type": "cp"}),
+			newHardware("hw4", "default", map[string]string{"type": "worker"}),
+			newHardware("hw5", constants.EksaSystemNamespace, map[string]string{"type": "worker"}),
+		}
+		return nil
+	})
+
+	available, err := hardware.CountAvailable(ctx, reader, v1alpha1.HardwareSelector{"type": "worker"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(available).To(Equal(2))
+}
+
+func TestCountAvailableListError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	reader := kubemocks.NewMockReader(gomock.NewController(t))
+	reader.EXPECT().List(ctx, &tinkv1alpha1.HardwareList{}).Return(errors.New("list failed"))
+
+	_, err := hardware.CountAvailable(ctx, reader, v1alpha1.HardwareSelector{"type": "worker"})
+	g.Expect(err).To(MatchError("listing hardware: list failed"))
+}
@@ -0,0 +1,390 @@
+package hardware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// NetboxTokenEnv is the environment variable holding the NetBox API token.
+	NetboxTokenEnv = "NETBOX_TOKEN"
+	// BMCUsernameEnv is the environment variable holding the BMC username of the machines imported from
+	// NetBox when the mapping rules don't read it from the devices.
+	BMCUsernameEnv = "EKSA_BMC_USERNAME"
+	// BMCPasswordEnv is the environment variable holding the BMC password of the machines imported from NetBox.
+	BMCPasswordEnv = "EKSA_BMC_PASSWORD"
+
+	netboxDevicesPath     = "/api/dcim/devices/"
+	netboxInterfacesPath  = "/api/dcim/interfaces/"
+	netboxIPAddressesPath = "/api/ipam/ip-addresses/"
+	netboxPageSize        = "1000"
+
+	defaultBMCIPAddressPath = "oob_ip.address"
+)
+
+// NetboxConfig configures the import of machines from the NetBox DCIM.
+type NetboxConfig struct {
+	// URL is the URL of the NetBox instance.
+	URL string `json:"url"`
+	// Filters are the query parameters of the NetBox devices API that select the devices to import,
+	// like tag, site, role or status.
+	Filters map[string][]string `json:"filters,omitempty"`
+	// Mapping are the rules to build the hardware fields that NetBox doesn't model directly.
+	Mapping NetboxMapping `json:"mapping,omitempty"`
+}
+
+// NetboxMapping maps the fields of the NetBox devices to the hardware fields. The hostname, IP address,
+// netmask and MAC address are always taken from the device name, its primary IPv4 address and the
+// interface the address is assigned to.
+type NetboxMapping struct {
+	Disk        NetboxFieldRule `json:"disk,omitempty"`
+	Gateway     NetboxFieldRule `json:"gateway,omitempty"`
+	Nameservers NetboxFieldRule `json:"nameservers,omitempty"`
+	// VLANID defaults to the untagged VLAN of the interface with the primary IPv4 address.
+	VLANID NetboxFieldRule `json:"vlanID,omitempty"`
+	// BMCIPAddress defaults to the out-of-band IP address of the device.
+	BMCIPAddress NetboxFieldRule `json:"bmcIPAddress,omitempty"`
+	// BMCUsername defaults to the EKSA_BMC_USERNAME environment variable.
+	BMCUsername NetboxFieldRule `json:"bmcUsername,omitempty"`
+	// Labels are the labels set in the hardware, by label key.
+	Labels map[string]NetboxFieldRule `json:"labels,omitempty"`
+}
+
+// NetboxFieldRule reads a hardware field from a NetBox device.
+type NetboxFieldRule struct {
+	// Path is the path of the field in the device object returned by the NetBox API, with the
+	// fields separated by dots, like custom_fields.disk or config_context.nameservers.
+	Path string `json:"path,omitempty"`
+	// Default is the value used when the device doesn't have the field.
+	Default string `json:"default,omitempty"`
+}
+
+// ParseNetboxConfigFile reads a NetboxConfig from a yaml file.
+func ParseNetboxConfigFile(path string) (*NetboxConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading netbox config: %v", err)
+	}
+
+	config := &NetboxConfig{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("parsing netbox config: %v", err)
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("netbox config: url is required")
+	}
+
+	return config, nil
+}
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NetboxReader is a MachineReader that reads the machines from the devices of a NetBox DCIM.
+// The devices are read in the first call to Read.
+type NetboxReader struct {
+	ctx      context.Context
+	client   HTTPClient
+	config   *NetboxConfig
+	token    string
+	machines []Machine
+	loaded   bool
+}
+
+var _ MachineReader = &NetboxReader{}
+
+// NewNetboxReader returns a NetboxReader that reads the devices selected by config with the token
+// from the NETBOX_TOKEN environment variable.
+func NewNetboxReader(ctx context.Context, client HTTPClient, config *NetboxConfig) *NetboxReader {
+	return &NetboxReader{
+		ctx:    ctx,
+		client: client,
+		config: config,
+		token:  os.Getenv(NetboxTokenEnv),
+	}
+}
+
+// NewNormalizedNetboxReader creates a MachineReader backed by a NetboxReader that applies default normalizations to machines.
+func NewNormalizedNetboxReader(ctx context.Context, client HTTPClient, config *NetboxConfig) MachineReader {
+	return NewNormalizer(NewNetboxReader(ctx, client, config))
+}
+
+// Read returns the next machine. It returns io.EOF when all the devices have been read.
+func (r *NetboxReader) Read() (Machine, error) {
+	if !r.loaded {
+		machines, err := r.readMachines()
+		if err != nil {
+			return Machine{}, err
+		}
+		r.machines = machines
+		r.loaded = true
+	}
+
+	if len(r.machines) == 0 {
+		return Machine{}, io.EOF
+	}
+	m := r.machines[0]
+	r.machines = r.machines[1:]
+
+	return m, nil
+}
+
+type netboxInterface struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	MACAddress   string `json:"mac_address"`
+	UntaggedVLAN *struct {
+		VID int `json:"vid"`
+	} `json:"untagged_vlan"`
+}
+
+type netboxIPAddress struct {
+	Address            string `json:"address"`
+	AssignedObjectType string `json:"assigned_object_type"`
+	AssignedObjectID   int    `json:"assigned_object_id"`
+}
+
+func (r *NetboxReader) readMachines() ([]Machine, error) {
+	query := url.Values{}
+	for k, v := range r.config.Filters {
+		query[k] = v
+	}
+
+	var devices []map[string]interface{}
+	if err := r.list(netboxDevicesPath, query, &devices); err != nil {
+		return nil, fmt.Errorf("listing netbox devices: %v", err)
+	}
+
+	machines := make([]Machine, 0, len(devices))
+	for _, device := range devices {
+		m, err := r.machineFromDevice(device)
+		if err != nil {
+			return nil, fmt.Errorf("netbox device %v: %v", device["name"], err)
+		}
+		machines = append(machines, m)
+	}
+
+	return machines, nil
+}
+
+func (r *NetboxReader) machineFromDevice(device map[string]interface{}) (Machine, error) {
+	mapping := r.config.Mapping
+	m := Machine{
+		Hostname: fieldString(device, "name"),
+		Disk:     mapping.Disk.value(device),
+		Gateway:  mapping.Gateway.value(device),
+		VLANID:   mapping.VLANID.value(device),
+		Labels:   Labels{},
+	}
+
+	address := fieldString(device, "primary_ip4.address")
+	if address == "" {
+		return Machine{}, fmt.Errorf("device doesn't have a primary IPv4 address")
+	}
+	ip, netmask, err := splitCIDR(address)
+	if err != nil {
+		return Machine{}, err
+	}
+	m.IPAddress, m.Netmask = ip, netmask
+
+	iface, err := r.primaryInterface(device, address)
+	if err != nil {
+		return Machine{}, err
+	}
+	m.MACAddress = iface.MACAddress
+	if m.VLANID == "" && iface.UntaggedVLAN != nil {
+		m.VLANID = strconv.Itoa(iface.UntaggedVLAN.VID)
+	}
+
+	for _, ns := range strings.FieldsFunc(mapping.Nameservers.value(device), func(r rune) bool {
+		return r == ',' || r == '|' || r == ' '
+	}) {
+		m.Nameservers = append(m.Nameservers, ns)
+	}
+
+	for key, rule := range mapping.Labels {
+		if value := rule.value(device); value != "" {
+			m.Labels[key] = value
+		}
+	}
+
+	bmcIPRule := mapping.BMCIPAddress
+	if bmcIPRule.Path == "" {
+		bmcIPRule.Path = defaultBMCIPAddressPath
+	}
+	if bmcIP := bmcIPRule.value(device); bmcIP != "" {
+		m.BMCIPAddress, _, _ = strings.Cut(bmcIP, "/")
+		m.BMCUsername = mapping.BMCUsername.value(device)
+		if m.BMCUsername == "" {
+			m.BMCUsername = os.Getenv(BMCUsernameEnv)
+		}
+		m.BMCPassword = os.Getenv(BMCPasswordEnv)
+	}
+
+	return m, nil
+}
+
+// primaryInterface returns the interface of device the primary IPv4 address is assigned to.
+func (r *NetboxReader) primaryInterface(device map[string]interface{}, address string) (*netboxInterface, error) {
+	deviceID := fieldString(device, "id")
+
+	var addresses []netboxIPAddress
+	if err := r.list(netboxIPAddressesPath, url.Values{"device_id": {deviceID}}, &addresses); err != nil {
+		return nil, fmt.Errorf("listing ip addresses: %v", err)
+	}
+	interfaceID := 0
+	for _, a := range addresses {
+		if a.Address == address && a.AssignedObjectType == "dcim.interface" {
+			interfaceID = a.AssignedObjectID
+		}
+	}
+	if interfaceID == 0 {
+		return nil, fmt.Errorf("primary IPv4 address %s is not assigned to an interface", address)
+	}
+
+	var interfaces []netboxInterface
+	if err := r.list(netboxInterfacesPath, url.Values{"device_id": {deviceID}}, &interfaces); err != nil {
+		return nil, fmt.Errorf("listing interfaces: %v", err)
+	}
+	for i := range interfaces {
+		if interfaces[i].ID == interfaceID {
+			if interfaces[i].MACAddress == "" {
+				return nil, fmt.Errorf("interface %s doesn't have a MAC address", interfaces[i].Name)
+			}
+			return &interfaces[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %d of primary IPv4 address not found", interfaceID)
+}
+
+type netboxPage struct {
+	Next    string          `json:"next"`
+	Results json.RawMessage `json:"results"`
+}
+
+// list reads all the pages of a NetBox list API into items, which must be a pointer to a slice.
+func (r *NetboxReader) list(path string, query url.Values, items interface{}) error {
+	query.Set("limit", netboxPageSize)
+	next := strings.TrimSuffix(r.config.URL, "/") + path + "?" + query.Encode()
+
+	var all []json.RawMessage
+	for next != "" {
+		page := &netboxPage{}
+		if err := r.get(next, page); err != nil {
+			return err
+		}
+		var results []json.RawMessage
+		if err := json.Unmarshal(page.Results, &results); err != nil {
+			return fmt.Errorf("parsing results from %s: %v", next, err)
+		}
+		all = append(all, results...)
+		next = page.Next
+	}
+
+	content, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, items)
+}
+
+func (r *NetboxReader) get(endpoint string, obj interface{}) error {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Token "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(obj); err != nil {
+		return fmt.Errorf("parsing response from %s: %v", req.URL, err)
+	}
+
+	return nil
+}
+
+// value returns the value of the field of device read by the rule, or the default value if
+// device doesn't have it. Lists are joined with commas.
+func (r NetboxFieldRule) value(device map[string]interface{}) string {
+	if r.Path == "" {
+		return r.Default
+	}
+	if value := fieldString(device, r.Path); value != "" {
+		return value
+	}
+	return r.Default
+}
+
+// fieldString returns the value of the field in path as a string. Lists are joined with commas and
+// NetBox choice fields, which are objects with a value, return their value.
+func fieldString(obj map[string]interface{}, path string) string {
+	var current interface{} = obj
+	for _, field := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = m[field]
+	}
+
+	switch v := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		if value, ok := v["value"]; ok {
+			return fmt.Sprint(value)
+		}
+		return ""
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, i := range v {
+			items = append(items, fmt.Sprint(i))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// splitCIDR returns the IP address and dotted netmask of a NetBox address in CIDR notation.
+func splitCIDR(address string) (ip, netmask string, err error) {
+	addr, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address %s: %v", address, err)
+	}
+	if addr.To4() == nil {
+		return "", "", fmt.Errorf("address %s is not an IPv4 address", address)
+	}
+
+	return addr.String(), net.IP(ipNet.Mask).String(), nil
+}
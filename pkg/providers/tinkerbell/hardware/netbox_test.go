@@ -0,0 +1,220 @@
+package hardware_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// netboxServer serves a NetBox API with two devices. The devices list is split in two pages.
+func netboxServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	responses := map[string]string{
+		"/api/dcim/devices/?limit=1000&tag=eks-a": `{"next": "%[1]s/api/dcim/devices/?limit=1000&offset=1&tag=eks-a", "results": [
+			{"id": 1, "name": "cp-1", "status": {"value": "active"}, "primary_ip4": {"address": "10.0.0.11/24"},
+			 "oob_ip": {"address": "10.0.1.11/24"}, "custom_fields": {"disk": "/dev/nvme0n1", "node_type": "cp"},
+			 "config_context": {"gateway": "10.0.0.1", "nameservers": ["1.1.1.1", "8.8.8.8"]}}
+		]}`,
+		"/api/dcim/devices/?limit=1000&offset=1&tag=eks-a": `{"next": null, "results": [
+			{"id": 2, "name": "worker-1", "primary_ip4": {"address": "10.0.0.21/24"}, "oob_ip": null,
+			 "custom_fields": {"disk": null, "node_type": "worker"},
+			 "config_context": {"gateway": "10.0.0.1", "nameservers": ["1.1.1.1"]}}
+		]}`,
+		"/api/ipam/ip-addresses/?device_id=1&limit=1000": `{"next": null, "results": [
+			{"address": "10.0.1.11/24", "assigned_object_type": "dcim.interface", "assigned_object_id": 10},
+			{"address": "10.0.0.11/24", "assigned_object_type": "dcim.interface", "assigned_object_id": 11}
+		]}`,
+		"/api/dcim/interfaces/?device_id=1&limit=1000": `{"next": null, "results": [
+			{"id": 10, "name": "bmc", "mac_address": "AA:BB:CC:00:00:10"},
+			{"id": 11, "name": "eth0", "mac_address": "AA:BB:CC:00:00:11", "untagged_vlan": {"vid": 100}}
+		]}`,
+		"/api/ipam/ip-addresses/?device_id=2&limit=1000": `{"next": null, "results": [
+			{"address": "10.0.0.21/24", "assigned_object_type": "dcim.interface", "assigned_object_id": 21}
+		]}`,
+		"/api/dcim/interfaces/?device_id=2&limit=1000": `{"next": null, "results": [
+			{"id": 21, "name": "eth0", "mac_address": "AA:BB:CC:00:00:21", "untagged_vlan": null}
+		]}`,
+	}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		response, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, response, server.URL)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func netboxTestConfig(url string) *hardware.NetboxConfig {
+	return &hardware.NetboxConfig{
+		URL:     url,
+		Filters: map[string][]string{"tag": {"eks-a"}},
+		Mapping: hardware.NetboxMapping{
+			Disk:        hardware.NetboxFieldRule{Path: "custom_fields.disk", Default: "/dev/sda"},
+			Gateway:     hardware.NetboxFieldRule{Path: "config_context.gateway"},
+			Nameservers: hardware.NetboxFieldRule{Path: "config_context.nameservers"},
+			Labels: map[string]hardware.NetboxFieldRule{
+				"type":   {Path: "custom_fields.node_type"},
+				"status": {Path: "status"},
+			},
+		},
+	}
+}
+
+func readAll(g *WithT, reader hardware.MachineReader) []hardware.Machine {
+	var machines []hardware.Machine
+	for {
+		m, err := reader.Read()
+		if err == io.EOF {
+			return machines
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		machines = append(machines, m)
+	}
+}
+
+func TestNetboxReaderRead(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(hardware.NetboxTokenEnv, "test-token")
+	t.Setenv(hardware.BMCUsernameEnv, "admin")
+	t.Setenv(hardware.BMCPasswordEnv, "secret")
+	server := netboxServer(t)
+
+	reader := hardware.NewNormalizedNetboxReader(context.Background(), server.Client(), netboxTestConfig(server.URL))
+	g.Expect(readAll(g, reader)).To(Equal([]hardware.Machine{
+		{
+			Hostname:     "cp-1",
+			IPAddress:    "10.0.0.11",
+			Netmask:      "255.255.255.0",
+			Gateway:      "10.0.0.1",
+			Nameservers:  hardware.Nameservers{"1.1.1.1", "8.8.8.8"},
+			MACAddress:   "aa:bb:cc:00:00:11",
+			Disk:         "/dev/nvme0n1",
+			Labels:       hardware.Labels{"type": "cp", "status": "active"},
+			BMCIPAddress: "10.0.1.11",
+			BMCUsername:  "admin",
+			BMCPassword:  "secret",
+			VLANID:       "100",
+		},
+		{
+			Hostname:    "worker-1",
+			IPAddress:   "10.0.0.21",
+			Netmask:     "255.255.255.0",
+			Gateway:     "10.0.0.1",
+			Nameservers: hardware.Nameservers{"1.1.1.1"},
+			MACAddress:  "aa:bb:cc:00:00:21",
+			Disk:        "/dev/sda",
+			Labels:      hardware.Labels{"type": "worker"},
+		},
+	}))
+}
+
+func TestNetboxReaderReadUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(hardware.NetboxTokenEnv, "wrong-token")
+	server := netboxServer(t)
+
+	reader := hardware.NewNetboxReader(context.Background(), server.Client(), netboxTestConfig(server.URL))
+	_, err := reader.Read()
+	g.Expect(err).To(MatchError(ContainSubstring("listing netbox devices: unexpected status code 403")))
+}
+
+func TestNetboxReaderReadNoPrimaryIP(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"next": null, "results": [{"id": 1, "name": "cp-1", "primary_ip4": null}]}`)
+	}))
+	defer server.Close()
+
+	reader := hardware.NewNetboxReader(context.Background(), server.Client(), &hardware.NetboxConfig{URL: server.URL})
+	_, err := reader.Read()
+	g.Expect(err).To(MatchError("netbox device cp-1: device doesn't have a primary IPv4 address"))
+}
+
+func TestBuildHardwareYAMLFromNetbox(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(hardware.NetboxTokenEnv, "test-token")
+	t.Setenv(hardware.BMCUsernameEnv, "admin")
+	t.Setenv(hardware.BMCPasswordEnv, "secret")
+	server := netboxServer(t)
+
+	content, err := hardware.BuildHardwareYAMLFromReader(hardware.NewNormalizedNetboxReader(context.Background(), server.Client(), netboxTestConfig(server.URL)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("name: cp-1"))
+	g.Expect(string(content)).To(ContainSubstring("name: bmc-cp-1"))
+	g.Expect(string(content)).To(ContainSubstring("name: worker-1"))
+}
+
+func TestParseNetboxConfigFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "netbox.yaml")
+	g.Expect(os.WriteFile(path, []byte(`url: https://netbox.example.com
+filters:
+  tag: [eks-a]
+  site: [dc1]
+mapping:
+  disk:
+    path: custom_fields.disk
+    default: /dev/sda
+  labels:
+    type:
+      path: custom_fields.node_type
+`), 0o600)).To(Succeed())
+
+	config, err := hardware.ParseNetboxConfigFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&hardware.NetboxConfig{
+		URL:     "https://netbox.example.com",
+		Filters: map[string][]string{"tag": {"eks-a"}, "site": {"dc1"}},
+		Mapping: hardware.NetboxMapping{
+			Disk:   hardware.NetboxFieldRule{Path: "custom_fields.disk", Default: "/dev/sda"},
+			Labels: map[string]hardware.NetboxFieldRule{"type": {Path: "custom_fields.node_type"}},
+		},
+	}))
+}
+
+func TestParseNetboxConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "missing url",
+			content: "filters: {}",
+			wantErr: "netbox config: url is required",
+		},
+		{
+			name:    "unknown field",
+			content: "url: https://netbox.example.com\nmappings: {}",
+			wantErr: "parsing netbox config",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			path := filepath.Join(t.TempDir(), "netbox.yaml")
+			g.Expect(os.WriteFile(path, []byte(tc.content), 0o600)).To(Succeed())
+
+			_, err := hardware.ParseNetboxConfigFile(path)
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package hardware
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// netboxSignatureHeader is the header where NetBox sends the HMAC-SHA512 signature of the webhook body
+// when the webhook has a secret.
+const netboxSignatureHeader = "X-Hook-Signature"
+
+// maxNetboxWebhookBody limits the size of the webhook requests read by NetboxWebhook.
+const maxNetboxWebhookBody = 1 << 20
+
+// netboxSyncModels are the NetBox models the hardware is built from. Changes to other models are ignored.
+var netboxSyncModels = map[string]bool{
+	"device":    true,
+	"interface": true,
+	"ipaddress": true,
+}
+
+// NetboxWebhook is an http.Handler for NetBox webhooks that notifies the changes to the devices,
+// interfaces and IP addresses in its Events channel, so the hardware can be synced as soon as the
+// inventory changes.
+type NetboxWebhook struct {
+	secret []byte
+	events chan struct{}
+}
+
+// NewNetboxWebhook returns a NetboxWebhook that verifies the requests are signed with secret.
+// The signature is not verified if secret is empty.
+func NewNetboxWebhook(secret string) *NetboxWebhook {
+	return &NetboxWebhook{
+		secret: []byte(secret),
+		// A single pending event is enough since a sync reads the whole inventory.
+		events: make(chan struct{}, 1),
+	}
+}
+
+// Events returns the channel that receives a value when the inventory changes.
+func (w *NetboxWebhook) Events() <-chan struct{} {
+	return w.events
+}
+
+// ServeHTTP handles a NetBox webhook request.
+func (w *NetboxWebhook) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxNetboxWebhookBody))
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if len(w.secret) > 0 && !w.validSignature(body, req.Header.Get(netboxSignatureHeader)) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	event := struct {
+		Model string `json:"model"`
+	}{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if netboxSyncModels[event.Model] {
+		select {
+		case w.events <- struct{}{}:
+		default:
+		}
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (w *NetboxWebhook) validSignature(body []byte, signature string) bool {
+	mac := hmac.New(sha512.New, w.secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
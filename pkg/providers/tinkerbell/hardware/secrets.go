@@ -0,0 +1,53 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/externalsecrets"
+)
+
+// SecretResolver resolves references to values stored in external secret managers.
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// BMCSecretResolver is a MachineReader decorator that replaces the BMC credentials referencing
+// external secrets, like vault://secret/data/ipmi#password, with their values, so they don't have
+// to be stored in plaintext in the hardware CSV.
+type BMCSecretResolver struct {
+	ctx      context.Context
+	reader   MachineReader
+	resolver SecretResolver
+}
+
+// NewBMCSecretResolver creates a BMCSecretResolver that decorates r's Read().
+func NewBMCSecretResolver(ctx context.Context, r MachineReader, resolver SecretResolver) *BMCSecretResolver {
+	return &BMCSecretResolver{
+		ctx:      ctx,
+		reader:   r,
+		resolver: resolver,
+	}
+}
+
+// Read reads a Machine from the decorated MachineReader and resolves its BMC credentials.
+func (r *BMCSecretResolver) Read() (Machine, error) {
+	machine, err := r.reader.Read()
+	if err != nil {
+		return Machine{}, err
+	}
+
+	for _, field := range []*string{&machine.BMCUsername, &machine.BMCPassword} {
+		if !externalsecrets.IsRef(*field) {
+			continue
+		}
+
+		value, err := r.resolver.Resolve(r.ctx, *field)
+		if err != nil {
+			return Machine{}, fmt.Errorf("resolving bmc credentials of %s: %v", machine.Hostname, err)
+		}
+		*field = value
+	}
+
+	return machine, nil
+}
@@ -0,0 +1,61 @@
+package hardware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+type fakeSecretResolver map[string]string
+
+func (f fakeSecretResolver) Resolve(_ context.Context, ref string) (string, error) {
+	value, ok := f[ref]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func TestBMCSecretResolver(t *testing.T) {
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(gomock.NewController(t))
+	resolver := fakeSecretResolver{"vault://secret/data/ipmi#password": "secret"}
+
+	machine := NewValidMachine()
+	machine.BMCUsername = "admin"
+	machine.BMCPassword = "vault://secret/data/ipmi#password"
+	reader.EXPECT().Read().Return(machine, nil)
+
+	got, err := hardware.NewBMCSecretResolver(context.Background(), reader, resolver).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine.BMCPassword = "secret"
+	g.Expect(got).To(gomega.Equal(machine))
+}
+
+func TestBMCSecretResolverResolveError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(gomock.NewController(t))
+
+	machine := NewValidMachine()
+	machine.BMCUsername = "sops://ipmi.enc.yaml#username"
+	reader.EXPECT().Read().Return(machine, nil)
+
+	_, err := hardware.NewBMCSecretResolver(context.Background(), reader, fakeSecretResolver{}).Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("resolving bmc credentials of " + machine.Hostname + ": secret not found")))
+}
+
+func TestBMCSecretResolverReadError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(gomock.NewController(t))
+	reader.EXPECT().Read().Return(hardware.Machine{}, errors.New("invalid csv"))
+
+	_, err := hardware.NewBMCSecretResolver(context.Background(), reader, fakeSecretResolver{}).Read()
+	g.Expect(err).To(gomega.MatchError("invalid csv"))
+}
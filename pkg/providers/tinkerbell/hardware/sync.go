@@ -0,0 +1,226 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// SourceLabel is set in the hardware objects created by a Syncer to the name of the inventory
+// they are synced from. Only hardware with this label is updated or deleted by the Syncer.
+const SourceLabel = "anywhere.eks.amazonaws.com/hardware-source"
+
+// SyncResult summarizes the changes made by a sync.
+type SyncResult struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+	// Skipped are the machines that couldn't be synced, with the reason.
+	Skipped map[string]string
+}
+
+// Syncer keeps the Tinkerbell hardware objects of a cluster in sync with an external hardware inventory.
+// Hardware already provisioned in a cluster is never changed or deleted.
+type Syncer struct {
+	client client.Client
+	source string
+}
+
+// NewSyncer returns a Syncer that syncs the hardware of source with the cluster of client.
+func NewSyncer(client client.Client, source string) *Syncer {
+	return &Syncer{
+		client: client,
+		source: source,
+	}
+}
+
+// Sync creates or updates the Hardware, BMC Machine and Secret objects for the machines read from reader and
+// deletes the hardware synced from the same source that is no longer in reader. Invalid machines are skipped,
+// so a single wrong entry in the inventory doesn't block the rest.
+func (s *Syncer) Sync(ctx context.Context, reader MachineReader) (*SyncResult, error) {
+	result := &SyncResult{Skipped: map[string]string{}}
+	validator := NewDefaultMachineValidator()
+
+	existing := &tinkv1alpha1.HardwareList{}
+	if err := s.client.List(ctx, existing, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing hardware: %v", err)
+	}
+	current := map[string]*tinkv1alpha1.Hardware{}
+	for i := range existing.Items {
+		current[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	synced := map[string]bool{}
+	for {
+		m, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading machines: %v", err)
+		}
+		synced[m.Hostname] = true
+
+		if err := validator.Validate(m); err != nil {
+			result.Skipped[m.Hostname] = err.Error()
+			continue
+		}
+
+		if err := s.syncMachine(ctx, m, current[m.Hostname], result); err != nil {
+			return nil, fmt.Errorf("syncing hardware %s: %v", m.Hostname, err)
+		}
+	}
+
+	for name, hw := range current {
+		if synced[name] || hw.Labels[SourceLabel] != s.source {
+			continue
+		}
+		if _, owned := hw.Labels[OwnerNameLabel]; owned {
+			result.Skipped[name] = "hardware was removed from the inventory but it's in use by a cluster"
+			continue
+		}
+		if err := s.deleteHardware(ctx, hw); err != nil {
+			return nil, fmt.Errorf("deleting hardware %s: %v", name, err)
+		}
+		result.Deleted = append(result.Deleted, name)
+	}
+
+	for _, names := range [][]string{result.Created, result.Updated, result.Deleted, result.Unchanged} {
+		sort.Strings(names)
+	}
+
+	return result, nil
+}
+
+func (s *Syncer) syncMachine(ctx context.Context, m Machine, current *tinkv1alpha1.Hardware, result *SyncResult) error {
+	hw := hardwareFromMachine(m)
+	hw.Labels = s.labels(m.Labels)
+
+	switch {
+	case current == nil:
+		if err := s.applyBMC(ctx, m); err != nil {
+			return err
+		}
+		if err := s.client.Create(ctx, hw); err != nil {
+			return err
+		}
+		result.Created = append(result.Created, m.Hostname)
+		return nil
+	case current.Labels[SourceLabel] != s.source:
+		result.Skipped[m.Hostname] = "hardware already exists and it's not managed by the sync"
+		return nil
+	case current.Labels[OwnerNameLabel] != "":
+		result.Skipped[m.Hostname] = "hardware is in use by a cluster"
+		return nil
+	case equality.Semantic.DeepEqual(current.Spec, hw.Spec) && equality.Semantic.DeepEqual(current.Labels, hw.Labels):
+		if err := s.applyBMC(ctx, m); err != nil {
+			return err
+		}
+		result.Unchanged = append(result.Unchanged, m.Hostname)
+		return nil
+	}
+
+	if err := s.applyBMC(ctx, m); err != nil {
+		return err
+	}
+	current.Spec = hw.Spec
+	current.Labels = hw.Labels
+	if err := s.client.Update(ctx, current); err != nil {
+		return err
+	}
+	result.Updated = append(result.Updated, m.Hostname)
+
+	return nil
+}
+
+func (s *Syncer) labels(machineLabels Labels) map[string]string {
+	labels := map[string]string{SourceLabel: s.source}
+	for k, v := range machineLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// applyBMC creates or updates the BMC Machine and Secret of m.
+func (s *Syncer) applyBMC(ctx context.Context, m Machine) error {
+	if !m.HasBMC() {
+		return nil
+	}
+
+	secret := baseboardManagementSecretFromMachine(m)
+	secret.Labels[SourceLabel] = s.source
+	if err := s.createOrUpdate(ctx, secret, &corev1.Secret{}, func(current client.Object) {
+		c := current.(*corev1.Secret)
+		c.Data = secret.Data
+		c.Labels = secret.Labels
+	}); err != nil {
+		return fmt.Errorf("applying bmc secret: %v", err)
+	}
+
+	bmc := toRufioMachine(m)
+	bmc.Labels = map[string]string{SourceLabel: s.source}
+	if err := s.createOrUpdate(ctx, bmc, &rufiov1alpha1.Machine{}, func(current client.Object) {
+		c := current.(*rufiov1alpha1.Machine)
+		c.Spec = bmc.Spec
+		c.Labels = bmc.Labels
+	}); err != nil {
+		return fmt.Errorf("applying bmc machine: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Syncer) createOrUpdate(ctx context.Context, obj, current client.Object, update func(current client.Object)) error {
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if apierrors.IsNotFound(err) {
+		return s.client.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	update(current)
+	return s.client.Update(ctx, current)
+}
+
+// deleteHardware deletes hw and the BMC Machine and Secret it references.
+func (s *Syncer) deleteHardware(ctx context.Context, hw *tinkv1alpha1.Hardware) error {
+	if hw.Spec.BMCRef != nil {
+		bmc := &rufiov1alpha1.Machine{}
+		err := s.client.Get(ctx, client.ObjectKey{Name: hw.Spec.BMCRef.Name, Namespace: hw.Namespace}, bmc)
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return err
+		default:
+			secret := &corev1.Secret{}
+			secret.Name = bmc.Spec.Connection.AuthSecretRef.Name
+			secret.Namespace = bmc.Spec.Connection.AuthSecretRef.Namespace
+			if err := s.deleteIgnoreNotFound(ctx, secret); err != nil {
+				return err
+			}
+			if err := s.deleteIgnoreNotFound(ctx, bmc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.deleteIgnoreNotFound(ctx, hw)
+}
+
+func (s *Syncer) deleteIgnoreNotFound(ctx context.Context, obj client.Object) error {
+	if err := s.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package hardware_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type sliceReader []hardware.Machine
+
+func (r *sliceReader) Read() (hardware.Machine, error) {
+	if len(*r) == 0 {
+		return hardware.Machine{}, io.EOF
+	}
+	m := (*r)[0]
+	*r = (*r)[1:]
+	return m, nil
+}
+
+func newSyncClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	_ = rufiov1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func syncedHardware(name string, labels map[string]string) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+	}
+}
+
+func TestSyncerSync(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	unchanged := NewValidMachine()
+	unchanged.Hostname = "unchanged"
+	unchanged.IPAddress = "10.10.10.20"
+	unchanged.MACAddress = "00:00:00:00:00:20"
+	unchanged.BMCIPAddress = ""
+	unchanged.BMCUsername = ""
+	unchanged.BMCPassword = ""
+
+	created := NewValidMachine()
+	created.Hostname = "created"
+
+	updated := NewValidMachine()
+	updated.Hostname = "updated"
+	updated.IPAddress = "10.10.10.30"
+	updated.MACAddress = "00:00:00:00:00:30"
+	updated.BMCIPAddress = ""
+	updated.BMCUsername = ""
+	updated.BMCPassword = ""
+
+	invalid := NewValidMachine()
+	invalid.Hostname = "invalid"
+	invalid.Gateway = ""
+
+	manual := NewValidMachine()
+	manual.Hostname = "manual"
+	manual.IPAddress = "10.10.10.40"
+	manual.MACAddress = "00:00:00:00:00:40"
+	manual.BMCIPAddress = "10.10.10.41"
+
+	inUse := NewValidMachine()
+	inUse.Hostname = "in-use"
+	inUse.IPAddress = "10.10.10.50"
+	inUse.MACAddress = "00:00:00:00:00:50"
+	inUse.BMCIPAddress = "10.10.10.51"
+
+	cl := newSyncClient(
+		syncedHardware("manual", map[string]string{"type": "cp"}),
+		syncedHardware("in-use", map[string]string{hardware.SourceLabel: "netbox", hardware.OwnerNameLabel: "cluster"}),
+		syncedHardware("removed-in-use", map[string]string{hardware.SourceLabel: "netbox", hardware.OwnerNameLabel: "cluster"}),
+		syncedHardware("other-source", map[string]string{hardware.SourceLabel: "other"}),
+	)
+	syncer := hardware.NewSyncer(cl, "netbox")
+	previous := NewValidMachine()
+	previous.Hostname = "removed"
+	previous.IPAddress = "10.10.10.60"
+	previous.MACAddress = "00:00:00:00:00:60"
+	// The first sync creates the hardware that the second sync updates, keeps and deletes.
+	_, err := syncer.Sync(ctx, &sliceReader{unchanged, updated, previous})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updated.Disk = "/dev/nvme0n1"
+	result, err := syncer.Sync(ctx, &sliceReader{unchanged, created, updated, invalid, manual, inUse})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Created).To(Equal([]string{"created"}))
+	g.Expect(result.Updated).To(Equal([]string{"updated"}))
+	g.Expect(result.Deleted).To(Equal([]string{"removed"}))
+	g.Expect(result.Unchanged).To(Equal([]string{"unchanged"}))
+	g.Expect(result.Skipped).To(Equal(map[string]string{
+		"invalid":        "machine: Gateway is empty",
+		"manual":         "hardware already exists and it's not managed by the sync",
+		"in-use":         "hardware is in use by a cluster",
+		"removed-in-use": "hardware was removed from the inventory but it's in use by a cluster",
+	}))
+
+	hw := &tinkv1alpha1.Hardware{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "created", Namespace: constants.EksaSystemNamespace}, hw)).To(Succeed())
+	g.Expect(hw.Labels).To(Equal(map[string]string{"type": "cp", hardware.SourceLabel: "netbox"}))
+	g.Expect(hw.Spec.BMCRef.Name).To(Equal("bmc-created"))
+
+	bmc := &rufiov1alpha1.Machine{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "bmc-created", Namespace: constants.EksaSystemNamespace}, bmc)).To(Succeed())
+	g.Expect(bmc.Spec.Connection.Host).To(Equal("10.10.10.11"))
+	secret := &corev1.Secret{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "bmc-created-auth", Namespace: constants.EksaSystemNamespace}, secret)).To(Succeed())
+	g.Expect(secret.Data["password"]).To(Equal([]byte("password")))
+
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "updated", Namespace: constants.EksaSystemNamespace}, hw)).To(Succeed())
+	g.Expect(hw.Spec.Disks).To(Equal([]tinkv1alpha1.Disk{{Device: "/dev/nvme0n1"}}))
+
+	deleted := map[string]client.Object{
+		"removed":          &tinkv1alpha1.Hardware{},
+		"bmc-removed":      &rufiov1alpha1.Machine{},
+		"bmc-removed-auth": &corev1.Secret{},
+	}
+	for name, obj := range deleted {
+		err := cl.Get(ctx, client.ObjectKey{Name: name, Namespace: constants.EksaSystemNamespace}, obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", name)
+	}
+
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "other-source", Namespace: constants.EksaSystemNamespace}, hw)).To(Succeed())
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "manual", Namespace: constants.EksaSystemNamespace}, hw)).To(Succeed())
+}
+
+func signNetboxBody(secret, body string) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNetboxWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		signature  string
+		wantStatus int
+		wantEvent  bool
+	}{
+		{
+			name:       "device change",
+			method:     http.MethodPost,
+			body:       `{"event": "updated", "model": "device"}`,
+			signature:  signNetboxBody("secret", `{"event": "updated", "model": "device"}`),
+			wantStatus: http.StatusNoContent,
+			wantEvent:  true,
+		},
+		{
+			name:       "unrelated model",
+			method:     http.MethodPost,
+			body:       `{"event": "updated", "model": "site"}`,
+			signature:  signNetboxBody("secret", `{"event": "updated", "model": "site"}`),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid signature",
+			method:     http.MethodPost,
+			body:       `{"event": "updated", "model": "device"}`,
+			signature:  signNetboxBody("other", `{"event": "updated", "model": "device"}`),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			webhook := hardware.NewNetboxWebhook("secret")
+			req := httptest.NewRequest(tc.method, "/netbox", strings.NewReader(tc.body))
+			req.Header.Set("X-Hook-Signature", tc.signature)
+			rec := httptest.NewRecorder()
+
+			webhook.ServeHTTP(rec, req)
+			g.Expect(rec.Code).To(Equal(tc.wantStatus))
+			if tc.wantEvent {
+				g.Expect(webhook.Events()).To(Receive())
+			} else {
+				g.Expect(webhook.Events()).NotTo(Receive())
+			}
+		})
+	}
+}
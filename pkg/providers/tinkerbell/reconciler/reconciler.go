@@ -106,6 +106,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileCoreDNS,
+		r.CheckControlPlaneUpgraded,
 		r.ReconcileWorkers,
 	).Run(ctx, log, NewScope(clusterSpec))
 }
@@ -246,6 +248,14 @@ func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger
 	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
 }
 
+// CheckControlPlaneUpgraded checks whether all the control plane machines run the desired Kubernetes version,
+// so the worker nodes are only upgraded after the control plane. Requeues while the control plane is being upgraded.
+func (r *Reconciler) CheckControlPlaneUpgraded(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
+	clusterSpec := tinkerbellScope.ClusterSpec
+	log = log.WithValues("phase", "checkControlPlaneUpgraded")
+	return clusters.CheckControlPlaneUpgraded(ctx, r.client, log, clusterSpec.Cluster)
+}
+
 // ReconcileWorkerNodes reconciles the worker nodes to the desired state.
 func (r *Reconciler) ReconcileWorkerNodes(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
 	log = log.WithValues("provider", "tinkerbell", "reconcile type", "workers")
@@ -269,7 +279,7 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, tink
 	log = log.WithValues("phase", "reconcileWorkers")
 	log.Info("Applying worker CAPI objects")
 
-	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(tinkerbellScope.Workers))
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, r.remoteClientRegistry, spec.Cluster, clusters.ToWorkers(tinkerbellScope.Workers))
 }
 
 // ValidateDatacenterConfig updates the cluster status if the TinkerbellDatacenter status indicates that the spec is invalid.
@@ -299,6 +309,22 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, tinkerbe
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileCoreDNS scales up CoreDNS with the number of nodes of large clusters.
+func (r *Reconciler) ReconcileCoreDNS(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
+	clusterSpec := tinkerbellScope.ClusterSpec
+	if !clusterSpec.Cluster.IsLargeScale() {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("phase", "reconcileCoreDNS")
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileCoreDNS(ctx, log, client, clusterSpec.Cluster)
+}
+
 func (r *Reconciler) validateTinkerbellIPMatch(ctx context.Context, clusterSpec *c.Spec) error {
 	if clusterSpec.Cluster.IsManaged() {
 
@@ -28,6 +28,7 @@ const (
 	namespace         = "namespace"
 	overridesFileName = "tinkerbell-chart-overrides.yaml"
 	port              = "port"
+	replicas          = "replicas"
 
 	boots          = "boots"
 	hegel          = "hegel"
@@ -58,7 +59,7 @@ type StackInstaller interface {
 	CleanupLocalBoots(ctx context.Context, forceCleanup bool) error
 	Install(ctx context.Context, bundle releasev1alpha1.TinkerbellBundle, tinkerbellIP, kubeconfig, hookOverride string, opts ...InstallOption) error
 	UninstallLocal(ctx context.Context) error
-	Upgrade(_ context.Context, _ releasev1alpha1.TinkerbellBundle, tinkerbellIP, kubeconfig string, hookOverride string) error
+	Upgrade(_ context.Context, _ releasev1alpha1.TinkerbellBundle, tinkerbellIP, kubeconfig string, hookOverride string, opts ...InstallOption) error
 	AddNoProxyIP(IP string)
 	GetNamespace() string
 }
@@ -76,6 +77,7 @@ type Installer struct {
 	hostPort        bool
 	loadBalancer    bool
 	envoy           bool
+	replicas        int
 }
 
 type InstallOption func(s *Installer)
@@ -121,6 +123,14 @@ func WithLoadBalancerEnabled(enabled bool) InstallOption {
 	}
 }
 
+// WithStackReplicas is an InstallOption that sets the number of replicas for the tink-server, hegel and boots
+// deployments. Values lower than 2 keep the chart default of a single replica.
+func WithStackReplicas(stackReplicas int) InstallOption {
+	return func(s *Installer) {
+		s.replicas = stackReplicas
+	}
+}
+
 // AddNoProxyIP is for workload cluster upgrade, we have to pass
 // controlPlaneEndpoint IP of managemement cluster if proxy is configured.
 func (s *Installer) AddNoProxyIP(IP string) {
@@ -206,6 +216,7 @@ func (s *Installer) Install(ctx context.Context, bundle releasev1alpha1.Tinkerbe
 			"externalIp": tinkerbellIP,
 		},
 	}
+	s.setStackReplicas(valuesMap)
 
 	values, err := yaml.Marshal(valuesMap)
 	if err != nil {
@@ -378,9 +389,13 @@ func (s *Installer) authenticateHelmRegistry(ctx context.Context) error {
 }
 
 // Upgrade the Tinkerbell stack using images specified in bundle.
-func (s *Installer) Upgrade(ctx context.Context, bundle releasev1alpha1.TinkerbellBundle, tinkerbellIP, kubeconfig string, hookOverride string) error {
+func (s *Installer) Upgrade(ctx context.Context, bundle releasev1alpha1.TinkerbellBundle, tinkerbellIP, kubeconfig string, hookOverride string, opts ...InstallOption) error {
 	logger.V(6).Info("Upgrading Tinkerbell helm chart")
 
+	for _, option := range opts {
+		option(s)
+	}
+
 	bootEnv := s.getBootsEnv(bundle.TinkerbellStack, tinkerbellIP)
 
 	osiePath, err := getURIDir(bundle.TinkerbellStack.Hook.Initramfs.Amd.URI)
@@ -427,6 +442,7 @@ func (s *Installer) Upgrade(ctx context.Context, bundle releasev1alpha1.Tinkerbe
 			image: bundle.Envoy.URI,
 		},
 	}
+	s.setStackReplicas(valuesMap)
 
 	values, err := yaml.Marshal(valuesMap)
 	if err != nil {
@@ -457,6 +473,18 @@ func (s *Installer) Upgrade(ctx context.Context, bundle releasev1alpha1.Tinkerbe
 	)
 }
 
+// setStackReplicas sets the replicas of the services exposed behind the Tinkerbell IP
+// when running the stack in HA.
+func (s *Installer) setStackReplicas(valuesMap map[string]interface{}) {
+	if s.replicas < 2 {
+		return
+	}
+
+	for _, service := range []string{tinkServer, hegel, boots} {
+		valuesMap[service].(map[string]interface{})[replicas] = s.replicas
+	}
+}
+
 // GetNamespace retrieves the namespace the installer is using for stack deployment.
 func (s *Installer) GetNamespace() string {
 	return s.namespace
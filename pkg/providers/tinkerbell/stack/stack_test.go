@@ -163,6 +163,11 @@ func TestTinkerbellStackInstallWithDifferentOptions(t *testing.T) {
 			expectedFile: "testdata/expected_with_load_balancer_enabled_false.yaml",
 			opts:         []stack.InstallOption{stack.WithLoadBalancerEnabled(false)},
 		},
+		{
+			name:         "with_stack_replicas",
+			expectedFile: "testdata/expected_with_stack_replicas.yaml",
+			opts:         []stack.InstallOption{stack.WithStackReplicas(3)},
+		},
 		{
 			name:         "with_kubernetes_options",
 			expectedFile: "testdata/expected_with_kubernetes_options.yaml",
@@ -369,6 +374,27 @@ func TestUpgrade(t *testing.T) {
 	assertYamlFilesEqual(t, "testdata/expected_upgrade.yaml", valuesFile)
 }
 
+func TestUpgradeWithStackReplicas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	docker := mocks.NewMockDocker(mockCtrl)
+	helm := mocks.NewMockHelm(mockCtrl)
+
+	folder, writer := test.NewWriter(t)
+	valuesFile := filepath.Join(folder, "generated", overridesFileName)
+	cluster := &types.Cluster{Name: "test"}
+	ctx := context.Background()
+
+	helm.EXPECT().
+		UpgradeChartWithValuesFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any())
+	s := stack.NewInstaller(docker, writer, helm, constants.EksaSystemNamespace, "192.168.0.0/16", nil, nil)
+
+	err := s.Upgrade(ctx, getTinkBundle(), testIP, cluster.KubeconfigFile, "", stack.WithStackReplicas(3))
+	assert.NoError(t, err)
+
+	assertYamlFilesEqual(t, "testdata/expected_upgrade_with_stack_replicas.yaml", valuesFile)
+}
+
 func TestUpgradeWithRegistryMirrorAuthError(t *testing.T) {
 	var (
 		mockCtrl  = gomock.NewController(t)
@@ -20,7 +20,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
@@ -42,6 +41,11 @@ const (
 	defaultRegistry               = "public.ecr.aws"
 )
 
+// templateOverridePaths are the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides. The Tinkerbell machine templates are fully managed by
+// EKS Anywhere, so only the kubeadm objects can be overridden.
+var templateOverridePaths = clusterapi.TemplateOverridePaths(nil)
+
 type TemplateBuilder struct {
 	controlPlaneMachineSpec     *v1alpha1.TinkerbellMachineConfigSpec
 	datacenterSpec              *v1alpha1.TinkerbellDatacenterConfigSpec
@@ -71,7 +75,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 	}
 	if cpTemplateConfig == nil {
 		versionBundle := bundle.VersionsBundle
-		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
+		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily, tb.controlPlaneMachineSpec.HostNetwork)
 	}
 
 	cpTemplateString, err := cpTemplateConfig.ToTemplateString()
@@ -86,7 +90,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		etcdTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.etcdMachineSpec.TemplateRef.Name]
 		if etcdTemplateConfig == nil {
 			versionBundle := bundle.VersionsBundle
-			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
+			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily, tb.etcdMachineSpec.HostNetwork)
 		}
 		etcdTemplateString, err = etcdTemplateConfig.ToTemplateString()
 		if err != nil {
@@ -105,18 +109,17 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 	if err != nil {
 		return nil, err
 	}
-	return bytes, nil
+	return clusterapi.ApplyTemplateOverrides(bytes, clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
 	workerSpecs := make([][]byte, 0, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
-	bundle := clusterSpec.RootVersionsBundle()
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		workerNodeMachineSpec := tb.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name]
 		wTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[workerNodeMachineSpec.TemplateRef.Name]
 		if wTemplateConfig == nil {
-			versionBundle := bundle.VersionsBundle
-			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
+			versionBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration).VersionsBundle
+			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster, *versionBundle, tb.datacenterSpec.OSImageURL, tb.tinkerbellIP, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily, workerNodeMachineSpec.HostNetwork)
 		}
 
 		wTemplateString, err := wTemplateConfig.ToTemplateString()
@@ -156,7 +159,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 		}
 		workerSpecs = append(workerSpecs, bytes)
 	}
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return clusterapi.ApplyTemplateOverrides(templater.AppendYamlResources(workerSpecs...), clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
@@ -367,14 +370,18 @@ func buildTemplateMapCP(
 
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
-		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig))
+		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.PodSecurityAdmissionExtraArgs(clusterSpec.Cluster.Spec.PodSecurityAdmission)).
+		Append(clusterapi.NodePortRangeExtraArgs(controlPlaneMachineSpec.HostOSConfiguration)).
+		Append(clusterapi.LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.CustomTLSExtraArgs(clusterSpec.Cluster))
 
 	// LoadBalancerClass is feature gated in K8S v1.21 and needs to be enabled manually
 	if clusterSpec.Cluster.Spec.KubernetesVersion == v1alpha1.Kube121 {
 		apiServerExtraArgs.Append(clusterapi.FeatureGatesExtraArgs("ServiceLoadBalancerClass=true"))
 	}
 
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
@@ -386,10 +393,13 @@ func buildTemplateMapCP(
 		"controlPlaneSshUsername":       controlPlaneMachineSpec.Users[0].Name,
 		"eksaSystemNamespace":           constants.EksaSystemNamespace,
 		"format":                        format,
+		"fips":                          clusterSpec.Cluster.Spec.FIPS,
 		"kubernetesVersion":             versionsBundle.KubeDistro.Kubernetes.Tag,
 		"kubeVipImage":                  versionsBundle.Tinkerbell.KubeVip.VersionedImage(),
+		"kubeVipLeaderElection":         clusterapi.KubeVipLeaderElectionForCluster(clusterSpec.Cluster),
 		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
+		"apiserverCertSANs":             clusterapi.ControlPlaneCertSANs(clusterSpec.Cluster),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"baseRegistry":                  "", // TODO: need to get this values for creating template IMAGE_URL
 		"osDistro":                      "", // TODO: need to get this values for creating template IMAGE_URL
@@ -401,7 +411,7 @@ func buildTemplateMapCP(
 		"etcdImageTag":                  versionsBundle.KubeDistro.Etcd.Tag,
 		"externalEtcdVersion":           versionsBundle.KubeDistro.EtcdVersion,
 		"externalEtcdReleaseUrl":        versionsBundle.KubeDistro.EtcdURL,
-		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":              clusterapi.EtcdCipherSuites(clusterSpec.Cluster),
 		"kubeletExtraArgs":              kubeletExtraArgs.ToPartialYaml(),
 		"hardwareSelector":              controlPlaneMachineSpec.HardwareSelector,
 		"controlPlaneTaints":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
@@ -447,15 +457,27 @@ func buildTemplateMapCP(
 	if controlPlaneMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
 		values["pauseRepository"] = versionsBundle.KubeDistro.Pause.Image()
-		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.Tag()
+		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.PinnedTag()
 		values["bottlerocketBootstrapRepository"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
-		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
+		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.PinnedTag()
 	}
 
 	if clusterSpec.AWSIamConfig != nil {
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.Spec.PodSecurityAdmission != nil {
+		conf, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfig"] = conf
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		values["skipKubeProxy"] = true
+	}
+
 	if controlPlaneMachineSpec.HostOSConfiguration != nil {
 		if controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration != nil {
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
@@ -470,6 +492,14 @@ func buildTemplateMapCP(
 			return nil, err
 		}
 		values["bottlerocketSettings"] = brSettings
+
+		hostOSConfig, err := clusterapi.HostOSKubeadmConfigSpec(controlPlaneMachineSpec.HostOSConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		values["hostOSFiles"] = hostOSConfig.Files
+		values["hostOSPreKubeadmCommands"] = hostOSConfig.PreKubeadmCommands
+		values["hostOSPostKubeadmCommands"] = hostOSConfig.PostKubeadmCommands
 	}
 
 	return values, nil
@@ -485,7 +515,7 @@ func buildTemplateMapMD(
 	versionsBundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
 
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
 
@@ -494,6 +524,7 @@ func buildTemplateMapMD(
 		"eksaSystemNamespace":    constants.EksaSystemNamespace,
 		"kubeletExtraArgs":       kubeletExtraArgs.ToPartialYaml(),
 		"format":                 format,
+		"fips":                   clusterSpec.Cluster.Spec.FIPS,
 		"kubernetesVersion":      versionsBundle.KubeDistro.Kubernetes.Tag,
 		"workerNodeGroupName":    workerNodeGroupConfiguration.Name,
 		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
@@ -505,9 +536,9 @@ func buildTemplateMapMD(
 	if workerNodeGroupMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
 		values["pauseRepository"] = versionsBundle.KubeDistro.Pause.Image()
-		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.Tag()
+		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.PinnedTag()
 		values["bottlerocketBootstrapRepository"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
-		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
+		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.PinnedTag()
 	}
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
@@ -544,6 +575,14 @@ func buildTemplateMapMD(
 			return nil, err
 		}
 		values["bottlerocketSettings"] = brSettings
+
+		hostOSConfig, err := clusterapi.HostOSKubeadmConfigSpec(workerNodeGroupMachineSpec.HostOSConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		values["hostOSFiles"] = hostOSConfig.Files
+		values["hostOSPreKubeadmCommands"] = hostOSConfig.PreKubeadmCommands
+		values["hostOSPostKubeadmCommands"] = hostOSConfig.PostKubeadmCommands
 	}
 
 	return values, nil
@@ -2,6 +2,7 @@ package tinkerbell
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -56,3 +57,44 @@ func TestGenerateTemplateBuilder(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(gotEtcdMachineSpec).To(Equal(expectedEtcdMachineSpec))
 }
+
+func TestTemplateBuilderGenerateCAPISpecHostFirewall(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+	hostOSConfig := &v1alpha1.HostOSConfiguration{
+		FirewallConfiguration: &v1alpha1.FirewallConfiguration{
+			NodePortRange: "31000-31999",
+			Rules:         []string{"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop"},
+		},
+	}
+	for _, m := range clusterSpec.TinkerbellMachineConfigs {
+		m.Spec.HostOSConfiguration = hostOSConfig
+	}
+
+	cpMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	workerMachineSpecs, err := getWorkerNodeGroupMachineSpec(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	builder := NewTemplateBuilder(&clusterSpec.TinkerbellDatacenter.Spec, cpMachineSpec, nil, workerMachineSpecs, "0.0.0.0", time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring(`          service-node-port-range: 31000-31999`))
+	g.Expect(string(cp)).To(ContainSubstring(`            tcp dport 31000-31999 ip saddr != 10.0.0.0/8 drop`))
+	g.Expect(string(cp)).To(ContainSubstring(`        path: "/etc/eks-anywhere/host-firewall.nft"
+        owner: root:root
+        permissions: "0600"`))
+	g.Expect(string(cp)).To(ContainSubstring(`    preKubeadmCommands:
+    - "systemctl daemon-reload"
+    - "systemctl enable --now eksa-host-firewall.service"`))
+
+	names := map[string]string{"md-0": "test-md-0-1"}
+	workers, err := builder.GenerateCAPISpecWorkers(clusterSpec, names, names)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring(`      files:
+        - content: |
+            #!/usr/sbin/nft -f`))
+	g.Expect(string(workers)).To(ContainSubstring(`      preKubeadmCommands:
+      - "systemctl daemon-reload"
+      - "systemctl enable --now eksa-host-firewall.service"`))
+}
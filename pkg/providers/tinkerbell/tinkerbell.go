@@ -33,12 +33,16 @@ import (
 const (
 	maxRetries    = 30
 	backOffPeriod = 5 * time.Second
+
+	stackDeploymentWaitTimeout = "5m"
 )
 
 var (
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	tinkerbellStackPorts                 = []int{42113, 50051, 50061}
+	// stackHADeployments are the Tinkerbell stack deployments scaled by stackReplicas.
+	stackHADeployments = []string{"tink-server", "hegel", "boots"}
 
 	// errExternalEtcdUnsupported is returned from create or update when the user attempts to create
 	// or upgrade a cluster with an external etcd configuration.
@@ -66,6 +70,11 @@ type Provider struct {
 	// constructor call for constructing the validator in-line.
 	netClient networkutils.NetClient
 
+	// consoleLogs is optional and captures machine console output during provisioning when set.
+	consoleLogs *ConsoleLogCollector
+	// secretResolver is optional and resolves the BMC credentials in the hardware CSV that reference external secrets.
+	secretResolver hardware.SecretResolver
+
 	forceCleanup bool
 	skipIpCheck  bool
 	retrier      *retrier.Retrier
@@ -192,6 +201,32 @@ func NewProvider(
 	}, nil
 }
 
+// EnableConsoleLogs configures the provider to capture the serial-over-LAN console of all machines
+// with a BMC while they're provisioned.
+func (p *Provider) EnableConsoleLogs(collector *ConsoleLogCollector) {
+	p.consoleLogs = collector
+}
+
+// ResolveBMCSecretsWith configures the provider to resolve the BMC credentials in the hardware CSV
+// that reference external secrets with resolver.
+func (p *Provider) ResolveBMCSecretsWith(resolver hardware.SecretResolver) {
+	p.secretResolver = resolver
+}
+
+// capabilities are the optional features supported by the provider. The hardware assertions
+// check the cluster spec against them too.
+var capabilities = providers.Capabilities{
+	Autoscaling:           true,
+	KubeProxyReplacement:  true,
+	TemplateOverridePaths: templateOverridePaths,
+	BundleImages:          (*releasev1alpha1.VersionsBundle).TinkerbellImages,
+}
+
+// Capabilities returns the optional features supported by the provider.
+func (p *Provider) Capabilities() providers.Capabilities {
+	return capabilities
+}
+
 func (p *Provider) Name() string {
 	return constants.TinkerbellProviderName
 }
@@ -466,6 +466,7 @@ func TestPostWorkloadInitSuccess(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	)
 	stackInstaller.EXPECT().UninstallLocal(ctx)
 
@@ -475,6 +476,39 @@ func TestPostWorkloadInitSuccess(t *testing.T) {
 	}
 }
 
+func TestPostWorkloadInitStackReplicasWaitsForDeployments(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	datacenterConfig.Spec.StackReplicas = 2
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(ctx, gomock.Any(), testIP, "test.kubeconfig", "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	stackInstaller.EXPECT().GetNamespace().Return(constants.EksaSystemNamespace).Times(3)
+	for _, deployment := range []string{"tink-server", "hegel", "boots"} {
+		kubectl.EXPECT().WaitForDeployment(ctx, cluster, "5m", "Available", deployment, constants.EksaSystemNamespace)
+	}
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed PostWorkloadInit: %v", err)
+	}
+}
+
 func TestPostBootstrapSetupSuccess(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -494,7 +528,7 @@ func TestPostBootstrapSetupSuccess(t *testing.T) {
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).MaxTimes(2)
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -524,7 +558,7 @@ func TestPostBootstrapSetupWaitForRufioMachinesFail(t *testing.T) {
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(wantError)
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -567,7 +601,7 @@ func TestPostMoveManagementToBootstrapSuccess(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 			provider.hardwareCSVFile = test.hardwareCSVFile
-			if err := provider.readCSVToCatalogue(); err != nil {
+			if err := provider.readCSVToCatalogue(ctx); err != nil {
 				t.Fatalf("failed to read hardware csv: %v", err)
 			}
 
@@ -597,7 +631,7 @@ func TestPostMoveManagementToBootstrapWaitForRufioMachinesFail(t *testing.T) {
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, "5m", "Contactable", gomock.Any()).Return(wantError)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -13,10 +13,12 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/rufiounreleased"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/yaml"
 )
@@ -33,6 +35,10 @@ func needsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
 		return true
 	}
 
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
+
 	return false
 }
 
@@ -47,6 +53,30 @@ func needsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorker, newWork
 		return true
 	}
 
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
+
+	return false
+}
+
+// workerNodeGroupKubeVersionChanged returns true if any of the existing worker node groups is upgraded
+// to a different Kubernetes version, even if the control plane version doesn't change.
+func workerNodeGroupKubeVersionChanged(oldSpec, newSpec *cluster.Spec) bool {
+	oldWorkers := make(map[string]*v1alpha1.WorkerNodeGroupConfiguration, len(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for i := range oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		w := &oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		oldWorkers[w.Name] = w
+	}
+
+	for i := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		newWorker := &newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		oldWorker, ok := oldWorkers[newWorker.Name]
+		if ok && !v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(oldWorker, newWorker, oldSpec.Cluster, newSpec.Cluster) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -68,7 +98,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	if p.hardwareCSVIsProvided() {
 		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
-		machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
+		machines, err := p.newHardwareCSVReader(ctx)
 		if err != nil {
 			return err
 		}
@@ -148,7 +178,8 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 
 	rollingUpgrade := false
 	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion ||
-		currentSpec.Bundles.Spec.Number != newClusterSpec.Bundles.Spec.Number {
+		currentSpec.Bundles.Spec.Number != newClusterSpec.Bundles.Spec.Number ||
+		workerNodeGroupKubeVersionChanged(currentSpec, newClusterSpec) {
 		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForRollingUpgrade(p.catalogue))
 		rollingUpgrade = true
 	}
@@ -427,11 +458,16 @@ func (p *Provider) PreCoreComponentsUpgrade(
 		p.datacenterConfig.Spec.TinkerbellIP,
 		cluster.KubeconfigFile,
 		p.datacenterConfig.Spec.HookImagesURLPath,
+		stack.WithStackReplicas(p.datacenterConfig.Spec.StackReplicas),
 	)
 	if err != nil {
 		return fmt.Errorf("upgrading stack: %v", err)
 	}
 
+	if err := p.waitForStackReplicas(ctx, cluster); err != nil {
+		return err
+	}
+
 	hasBaseboardManagement, err := p.providerKubectlClient.HasCRD(
 		ctx,
 		rufiounreleased.BaseboardManagementResourceName,
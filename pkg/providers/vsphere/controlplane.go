@@ -14,7 +14,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
@@ -59,15 +61,28 @@ func (b *ControlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) erro
 
 // ControlPlaneSpec builds a vsphere ControlPlane definition based on an eks-a cluster spec.
 func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*ControlPlane, error) {
+	return controlPlaneSpec(ctx, logger, client, spec)
+}
+
+// ControlPlaneSpecWithCredentials builds a vsphere ControlPlane definition based on an eks-a cluster spec
+// that authenticates to vCenter as the user in vuc instead of the user in the process env.
+func ControlPlaneSpecWithCredentials(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, vuc *config.VSphereUserConfig) (*ControlPlane, error) {
+	return controlPlaneSpec(ctx, logger, client, spec, func(values map[string]interface{}) {
+		values["eksaVsphereUsername"] = vuc.EksaVsphereUsername
+		values["eksaVspherePassword"] = vuc.EksaVspherePassword
+		values["eksaCloudProviderUsername"] = vuc.EksaVsphereCPUsername
+		values["eksaCloudProviderPassword"] = vuc.EksaVsphereCPPassword
+	})
+}
+
+func controlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, buildOptions ...providers.BuildMapOption) (*ControlPlane, error) {
 	templateBuilder := NewVsphereTemplateBuilder(time.Now)
 
-	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
-		spec,
-		func(values map[string]interface{}) {
-			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
-			values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
-		},
-	)
+	buildOptions = append(buildOptions, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+		values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+	})
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(spec, buildOptions...)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating vsphere control plane yaml spec")
 	}
@@ -21,6 +21,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
@@ -116,6 +117,40 @@ func TestControlPlaneSpecNewCluster(t *testing.T) {
 	g.Expect(cp.EtcdMachineTemplate.Name).To(Equal("test-etcd-1"))
 }
 
+func TestControlPlaneSpecWithCredentials(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	t.Setenv(config.EksavSphereUsernameKey, "process-user")
+	t.Setenv(config.EksavSpherePasswordKey, "process-pass")
+	vuc := &config.VSphereUserConfig{
+		EksaVsphereUsername:   "team-user",
+		EksaVspherePassword:   "team-pass",
+		EksaVsphereCPUsername: "team-userCP",
+		EksaVsphereCPPassword: "team-passCP",
+	}
+
+	cp, err := vsphere.ControlPlaneSpecWithCredentials(ctx, logger, client, spec, vuc)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secrets := map[string]*corev1.Secret{}
+	for _, s := range cp.Secrets {
+		secrets[s.Name] = s
+	}
+	g.Expect(secrets).To(HaveKey("test-vsphere-credentials"))
+	g.Expect(secrets["test-vsphere-credentials"].StringData).To(Equal(map[string]string{
+		"username": "team-user",
+		"password": "team-pass",
+	}))
+	g.Expect(secrets).To(HaveKey("test-cloud-provider-vsphere-credentials"))
+	cloudProviderCredentials := secrets["test-cloud-provider-vsphere-credentials"].StringData["data"]
+	g.Expect(cloudProviderCredentials).To(ContainSubstring(`.username: "team-userCP"`))
+	g.Expect(cloudProviderCredentials).To(ContainSubstring(`.password: "team-passCP"`))
+	g.Expect(cloudProviderCredentials).NotTo(ContainSubstring("process-user"))
+}
+
 func TestControlPlaneSpecUpdateMachineTemplates(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
@@ -250,6 +285,11 @@ func TestControlPlaneSpecRegistryMirrorConfiguration(t *testing.T) {
 			mirrorConfig: test.RegistryMirrorInsecureSkipVerifyEnabledAndCACert(),
 			files:        test.RegistryMirrorConfigFilesInsecureSkipVerifyAndCACert(),
 		},
+		{
+			name:         "registry hosts",
+			mirrorConfig: test.RegistryMirrorWithHosts(),
+			files:        test.RegistryMirrorConfigFilesWithHosts(),
+		},
 	}
 
 	for _, tt := range tests {
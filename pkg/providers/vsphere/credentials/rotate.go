@@ -0,0 +1,203 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// CAPVBootstrapCredentialsName is the secret with the default credentials of the CAPV controller.
+	CAPVBootstrapCredentialsName = "capv-manager-bootstrap-credentials"
+	// CAPVControllerManagerName is the deployment of the CAPV controller.
+	CAPVControllerManagerName = "capv-controller-manager"
+
+	rolloutTimeout = "5m"
+)
+
+var eksaClusterResourceType = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+
+// GovcClient validates the vSphere credentials against vCenter.
+type GovcClient interface {
+	ValidateVCenterConnection(ctx context.Context, server string) error
+	ValidateVCenterAuthentication(ctx context.Context) error
+}
+
+// KubectlClient updates the credentials in the management cluster.
+type KubectlClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	RolloutRestartDeployment(ctx context.Context, name, namespace, kubeconfig string) error
+	WaitForResourceRolledout(ctx context.Context, cluster *types.Cluster, timeout string, target string, namespace string, resource string) error
+	Get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...kubernetes.KubectlGetOption) error
+	MergePatchResourceStatus(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error
+}
+
+// Rotator rotates the vSphere credentials used by the controllers of a management cluster.
+type Rotator struct {
+	govc    GovcClient
+	kubectl KubectlClient
+	now     func() time.Time
+}
+
+// RotatorOpt allows to customize a Rotator.
+type RotatorOpt func(*Rotator)
+
+// WithNow sets the function used to get the rotation timestamp.
+func WithNow(now func() time.Time) RotatorOpt {
+	return func(r *Rotator) {
+		r.now = now
+	}
+}
+
+// NewRotator builds a Rotator.
+func NewRotator(govc GovcClient, kubectl KubectlClient, opts ...RotatorOpt) *Rotator {
+	r := &Rotator{
+		govc:    govc,
+		kubectl: kubectl,
+		now:     time.Now,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Rotate replaces the vSphere credentials in managementCluster with the ones set in the EKSA_VSPHERE_*
+// environment variables. The new credentials are validated against vCenter before any change is made.
+// Then the EKS-A and CAPV credential secrets are updated, the CAPV controller is restarted so it
+// drops the sessions opened with the old credentials and the rotation time is recorded in the status
+// of every vSphere cluster managed by managementCluster. The status update also clears the reconciled
+// generation and spec hash of the clusters, so the EKS-A controller reconciles them again and
+// regenerates the per cluster credential secrets even if their specs haven't changed.
+func (r *Rotator) Rotate(ctx context.Context, managementCluster *types.Cluster, datacenterConfig *v1alpha1.VSphereDatacenterConfig) error {
+	if err := vsphere.SetupEnvVars(datacenterConfig); err != nil {
+		return fmt.Errorf("reading new vSphere credentials: %v", err)
+	}
+
+	logger.Info("Validating new vSphere credentials")
+	if err := r.govc.ValidateVCenterConnection(ctx, datacenterConfig.Spec.Server); err != nil {
+		return fmt.Errorf("validating connection to vCenter %s: %v", datacenterConfig.Spec.Server, err)
+	}
+	if err := r.govc.ValidateVCenterAuthentication(ctx); err != nil {
+		return fmt.Errorf("validating new vSphere credentials: %v", err)
+	}
+
+	logger.Info("Updating vSphere credentials in the management cluster")
+	secrets, err := credentialSecrets()
+	if err != nil {
+		return err
+	}
+	if err := r.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, secrets); err != nil {
+		return fmt.Errorf("updating vSphere credential secrets: %v", err)
+	}
+
+	logger.Info("Restarting CAPV controller")
+	if err := r.kubectl.RolloutRestartDeployment(ctx, CAPVControllerManagerName, constants.CapvSystemNamespace, managementCluster.KubeconfigFile); err != nil {
+		return err
+	}
+	if err := r.kubectl.WaitForResourceRolledout(ctx, managementCluster, rolloutTimeout, CAPVControllerManagerName, constants.CapvSystemNamespace, "deployment"); err != nil {
+		return fmt.Errorf("waiting for CAPV controller to restart: %v", err)
+	}
+
+	return r.recordRotation(ctx, managementCluster)
+}
+
+func credentialSecrets() ([]byte, error) {
+	vuc := config.NewVsphereUserConfig()
+
+	capvCredentials, err := yaml.Marshal(map[string]string{
+		"username": vuc.EksaVsphereUsername,
+		"password": vuc.EksaVspherePassword,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating CAPV credentials: %v", err)
+	}
+
+	secrets := []*corev1.Secret{
+		{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.VSphereCredentialsName,
+				Namespace: constants.EksaSystemNamespace,
+			},
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				"username":   []byte(vuc.EksaVsphereUsername),
+				"password":   []byte(vuc.EksaVspherePassword),
+				"usernameCP": []byte(vuc.EksaVsphereCPUsername),
+				"passwordCP": []byte(vuc.EksaVsphereCPPassword),
+			},
+		},
+		{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CAPVBootstrapCredentialsName,
+				Namespace: constants.CapvSystemNamespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"credentials.yaml": capvCredentials,
+			},
+		},
+	}
+
+	var contents bytes.Buffer
+	for _, s := range secrets {
+		b, err := yaml.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling secret %s: %v", s.Name, err)
+		}
+		contents.WriteString("---\n")
+		contents.Write(b)
+	}
+
+	return contents.Bytes(), nil
+}
+
+func (r *Rotator) recordRotation(ctx context.Context, managementCluster *types.Cluster) error {
+	clusters := &v1alpha1.ClusterList{}
+	if err := r.kubectl.Get(ctx, eksaClusterResourceType, managementCluster.KubeconfigFile, clusters); err != nil {
+		return fmt.Errorf("listing clusters: %v", err)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"providerCredentialsRotatedAt": metav1.NewTime(r.now()),
+			"reconciledGeneration":         nil,
+			"specHash":                     nil,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("generating cluster status patch: %v", err)
+	}
+
+	for _, c := range clusters.Items {
+		if c.Spec.DatacenterRef.Kind != v1alpha1.VSphereDatacenterKind {
+			continue
+		}
+
+		if err := r.kubectl.MergePatchResourceStatus(ctx, eksaClusterResourceType, c.Name, string(patch), managementCluster.KubeconfigFile, c.Namespace); err != nil {
+			return fmt.Errorf("recording credentials rotation for cluster %s: %v", c.Name, err)
+		}
+		logger.V(3).Info("Recorded vSphere credentials rotation", "cluster", c.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/credentials"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/credentials/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const clustersResourceType = "clusters.anywhere.eks.amazonaws.com"
+
+type rotatorTest struct {
+	*WithT
+	ctx        context.Context
+	govc       *mocks.MockGovcClient
+	kubectl    *mocks.MockKubectlClient
+	rotator    *credentials.Rotator
+	cluster    *types.Cluster
+	datacenter *v1alpha1.VSphereDatacenterConfig
+}
+
+func newRotatorTest(t *testing.T) *rotatorTest {
+	ctrl := gomock.NewController(t)
+	govc := mocks.NewMockGovcClient(ctrl)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Setenv("EKSA_VSPHERE_USERNAME", "new-user")
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "new-password")
+	t.Setenv("EKSA_VSPHERE_CP_USERNAME", "")
+	t.Setenv("EKSA_VSPHERE_CP_PASSWORD", "")
+	// SetupEnvVars sets these, register them so they are restored after the test.
+	t.Setenv("VSPHERE_USERNAME", "")
+	t.Setenv("VSPHERE_PASSWORD", "")
+	t.Setenv("VSPHERE_SERVER", "")
+	t.Setenv("EXP_CLUSTER_RESOURCE_SET", "")
+	t.Setenv("GOVC_INSECURE", "")
+	t.Setenv("GOVC_DATACENTER", "")
+
+	return &rotatorTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		govc:    govc,
+		kubectl: kubectl,
+		rotator: credentials.NewRotator(govc, kubectl, credentials.WithNow(func() time.Time { return now })),
+		cluster: &types.Cluster{
+			Name:           "mgmt",
+			KubeconfigFile: "mgmt.kubeconfig",
+		},
+		datacenter: &v1alpha1.VSphereDatacenterConfig{
+			Spec: v1alpha1.VSphereDatacenterConfigSpec{
+				Server:     "vcenter.local",
+				Datacenter: "datacenter",
+			},
+		},
+	}
+}
+
+func (tt *rotatorTest) expectValidate() {
+	tt.govc.EXPECT().ValidateVCenterConnection(tt.ctx, "vcenter.local")
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx)
+}
+
+func (tt *rotatorTest) expectUpdateSecrets() {
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: vsphere-credentials"))
+			tt.Expect(string(data)).To(ContainSubstring("username: bmV3LXVzZXI="))
+			tt.Expect(string(data)).To(ContainSubstring("usernameCP: bmV3LXVzZXI="))
+			tt.Expect(string(data)).To(ContainSubstring("name: capv-manager-bootstrap-credentials"))
+			tt.Expect(string(data)).To(ContainSubstring("namespace: capv-system"))
+			return nil
+		},
+	)
+}
+
+func (tt *rotatorTest) expectRestartCAPV() {
+	tt.kubectl.EXPECT().RolloutRestartDeployment(tt.ctx, "capv-controller-manager", constants.CapvSystemNamespace, "mgmt.kubeconfig")
+	tt.kubectl.EXPECT().WaitForResourceRolledout(tt.ctx, tt.cluster, "5m", "capv-controller-manager", constants.CapvSystemNamespace, "deployment")
+}
+
+func (tt *rotatorTest) expectListClusters(clusters ...v1alpha1.Cluster) {
+	tt.kubectl.EXPECT().Get(tt.ctx, clustersResourceType, "mgmt.kubeconfig", &v1alpha1.ClusterList{}).DoAndReturn(
+		func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*v1alpha1.ClusterList).Items = clusters
+			return nil
+		},
+	)
+}
+
+func cluster(name, namespace, datacenterKind string) v1alpha1.Cluster {
+	return v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{Kind: datacenterKind},
+		},
+	}
+}
+
+func TestRotatorRotateSuccess(t *testing.T) {
+	tt := newRotatorTest(t)
+	patch := `{"status":{"providerCredentialsRotatedAt":"2023-05-10T12:00:00Z","reconciledGeneration":null,"specHash":null}}`
+
+	tt.expectValidate()
+	tt.expectUpdateSecrets()
+	tt.expectRestartCAPV()
+	tt.expectListClusters(
+		cluster("mgmt", "default", v1alpha1.VSphereDatacenterKind),
+		cluster("workload", "ns", v1alpha1.VSphereDatacenterKind),
+		cluster("docker", "default", v1alpha1.DockerDatacenterKind),
+	)
+	tt.kubectl.EXPECT().MergePatchResourceStatus(tt.ctx, clustersResourceType, "mgmt", patch, "mgmt.kubeconfig", "default")
+	tt.kubectl.EXPECT().MergePatchResourceStatus(tt.ctx, clustersResourceType, "workload", patch, "mgmt.kubeconfig", "ns")
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(Succeed())
+}
+
+func TestRotatorRotateMissingCredentials(t *testing.T) {
+	tt := newRotatorTest(t)
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "")
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("reading new vSphere credentials")),
+	)
+}
+
+func TestRotatorRotateInvalidCredentials(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.govc.EXPECT().ValidateVCenterConnection(tt.ctx, "vcenter.local")
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(errors.New("unauthorized"))
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("validating new vSphere credentials: unauthorized")),
+	)
+}
+
+func TestRotatorRotateConnectionError(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.govc.EXPECT().ValidateVCenterConnection(tt.ctx, "vcenter.local").Return(errors.New("timeout"))
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("validating connection to vCenter vcenter.local: timeout")),
+	)
+}
+
+func TestRotatorRotateUpdateSecretsError(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.expectValidate()
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("forbidden"))
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("updating vSphere credential secrets: forbidden")),
+	)
+}
+
+func TestRotatorRotateRestartError(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.expectValidate()
+	tt.expectUpdateSecrets()
+	tt.kubectl.EXPECT().RolloutRestartDeployment(tt.ctx, "capv-controller-manager", constants.CapvSystemNamespace, "mgmt.kubeconfig")
+	tt.kubectl.EXPECT().WaitForResourceRolledout(tt.ctx, tt.cluster, "5m", "capv-controller-manager", constants.CapvSystemNamespace, "deployment").
+		Return(errors.New("timed out"))
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("waiting for CAPV controller to restart: timed out")),
+	)
+}
+
+func TestRotatorRotateRecordRotationError(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.expectValidate()
+	tt.expectUpdateSecrets()
+	tt.expectRestartCAPV()
+	tt.expectListClusters(cluster("mgmt", "default", v1alpha1.VSphereDatacenterKind))
+	tt.kubectl.EXPECT().MergePatchResourceStatus(tt.ctx, clustersResourceType, "mgmt", gomock.Any(), "mgmt.kubeconfig", "default").
+		Return(errors.New("conflict"))
+
+	tt.Expect(tt.rotator.Rotate(tt.ctx, tt.cluster, tt.datacenter)).To(
+		MatchError(ContainSubstring("recording credentials rotation for cluster mgmt: conflict")),
+	)
+}
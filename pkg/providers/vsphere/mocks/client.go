@@ -258,6 +258,21 @@ func (mr *MockProviderGovcClientMockRecorder) GetLibraryElementContentVersion(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryElementContentVersion", reflect.TypeOf((*MockProviderGovcClient)(nil).GetLibraryElementContentVersion), arg0, arg1)
 }
 
+// GetResourcePoolInfo mocks base method.
+func (m *MockProviderGovcClient) GetResourcePoolInfo(arg0 context.Context, arg1, arg2 string) (*executables.ResourcePoolInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*executables.ResourcePoolInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolInfo indicates an expected call of GetResourcePoolInfo.
+func (mr *MockProviderGovcClientMockRecorder) GetResourcePoolInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolInfo", reflect.TypeOf((*MockProviderGovcClient)(nil).GetResourcePoolInfo), arg0, arg1, arg2)
+}
+
 // GetTags mocks base method.
 func (m *MockProviderGovcClient) GetTags(arg0 context.Context, arg1 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +391,21 @@ func (mr *MockProviderGovcClientMockRecorder) ListCategories(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockProviderGovcClient)(nil).ListCategories), arg0)
 }
 
+// ListHosts mocks base method.
+func (m *MockProviderGovcClient) ListHosts(arg0 context.Context, arg1 string) ([]executables.Host, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHosts", arg0, arg1)
+	ret0, _ := ret[0].([]executables.Host)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHosts indicates an expected call of ListHosts.
+func (mr *MockProviderGovcClientMockRecorder) ListHosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHosts", reflect.TypeOf((*MockProviderGovcClient)(nil).ListHosts), arg0, arg1)
+}
+
 // ListTags mocks base method.
 func (m *MockProviderGovcClient) ListTags(arg0 context.Context) ([]executables.Tag, error) {
 	m.ctrl.T.Helper()
@@ -3,17 +3,18 @@ package reconciler
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	c "github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
@@ -38,8 +39,7 @@ type IPValidator interface {
 
 type Reconciler struct {
 	client               client.Client
-	validator            *vsphere.Validator
-	defaulter            *vsphere.Defaulter
+	validatorFactory     *vsphere.ValidatorFactory
 	cniReconciler        CNIReconciler
 	remoteClientRegistry RemoteClientRegistry
 	ipValidator          IPValidator
@@ -47,11 +47,10 @@ type Reconciler struct {
 }
 
 // New defines a new VSphere reconciler.
-func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, validatorFactory *vsphere.ValidatorFactory, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
 	return &Reconciler{
 		client:               client,
-		validator:            validator,
-		defaulter:            defaulter,
+		validatorFactory:     validatorFactory,
 		cniReconciler:        cniReconciler,
 		remoteClientRegistry: remoteClientRegistry,
 		ipValidator:          ipValidator,
@@ -59,10 +58,23 @@ func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.
 	}
 }
 
-func VsphereCredentials(ctx context.Context, cli client.Client) (*apiv1.Secret, error) {
+// VsphereCredentials returns the vSphere credentials secret for the clusters in namespace. Each namespace can
+// have its own vsphere-credentials secret, so the teams managing the clusters in a namespace can use their own
+// vSphere account. If the namespace doesn't have one, the credentials in eksa-system are used.
+func VsphereCredentials(ctx context.Context, cli client.Client, namespace string) (*apiv1.Secret, error) {
 	secret := &apiv1.Secret{}
+	if namespace != "" && namespace != constants.EksaSystemNamespace {
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: vsphere.CredentialsObjectName}, secret)
+		if err == nil {
+			return secret, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
 	secretKey := client.ObjectKey{
-		Namespace: "eksa-system",
+		Namespace: constants.EksaSystemNamespace,
 		Name:      vsphere.CredentialsObjectName,
 	}
 	if err := cli.Get(ctx, secretKey, secret); err != nil {
@@ -71,39 +83,31 @@ func VsphereCredentials(ctx context.Context, cli client.Client) (*apiv1.Secret,
 	return secret, nil
 }
 
-func SetupEnvVars(ctx context.Context, vsphereDatacenter *anywherev1.VSphereDatacenterConfig, cli client.Client) error {
-	secret, err := VsphereCredentials(ctx, cli)
+// UserConfig returns the vSphere users for the clusters of vsphereDatacenter, read from the credentials secret
+// of its namespace. They are passed explicitly to govc and the CAPI templates instead of through the process env,
+// since clusters in different namespaces can be reconciled concurrently with different credentials.
+func UserConfig(ctx context.Context, vsphereDatacenter *anywherev1.VSphereDatacenterConfig, cli client.Client) (*config.VSphereUserConfig, error) {
+	secret, err := VsphereCredentials(ctx, cli, vsphereDatacenter.Namespace)
 	if err != nil {
-		return fmt.Errorf("failed getting vsphere credentials secret: %v", err)
+		return nil, fmt.Errorf("failed getting vsphere credentials secret: %v", err)
 	}
 
-	vsphereUsername := secret.Data["username"]
-	vspherePassword := secret.Data["password"]
-
-	if err := os.Setenv(config.EksavSphereUsernameKey, string(vsphereUsername)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSphereUsernameKey, err)
+	vuc := &config.VSphereUserConfig{
+		EksaVsphereUsername:   string(secret.Data["username"]),
+		EksaVspherePassword:   string(secret.Data["password"]),
+		EksaVsphereCPUsername: string(secret.Data["usernameCP"]),
+		EksaVsphereCPPassword: string(secret.Data["passwordCP"]),
 	}
-
-	if err := os.Setenv(config.EksavSpherePasswordKey, string(vspherePassword)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSpherePasswordKey, err)
+	if vuc.EksaVsphereUsername == "" || vuc.EksaVspherePassword == "" {
+		return nil, fmt.Errorf("vsphere credentials secret %s/%s is missing the username or password", secret.Namespace, secret.Name)
 	}
 
-	vsphereCPUsername := secret.Data["usernameCP"]
-	vsphereCPPassword := secret.Data["passwordCP"]
-
-	if err := os.Setenv(config.EksavSphereCPUsernameKey, string(vsphereCPUsername)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSphereCPUsernameKey, err)
+	if vuc.EksaVsphereCPUsername == "" {
+		vuc.EksaVsphereCPUsername = vuc.EksaVsphereUsername
+		vuc.EksaVsphereCPPassword = vuc.EksaVspherePassword
 	}
 
-	if err := os.Setenv(config.EksavSphereCPPasswordKey, string(vsphereCPPassword)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSphereCPPasswordKey, err)
-	}
-
-	if err := vsphere.SetupEnvVars(vsphereDatacenter); err != nil {
-		return fmt.Errorf("failed setting env vars: %v", err)
-	}
-
-	return nil
+	return vuc, nil
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
@@ -121,6 +125,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileCoreDNS,
+		r.CheckControlPlaneUpgraded,
 		r.ReconcileWorkers,
 	).Run(ctx, log, clusterSpec)
 }
@@ -166,15 +172,19 @@ func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger
 	log = log.WithValues("phase", "validateMachineConfigs")
 	datacenterConfig := clusterSpec.VSphereDatacenter
 
-	// Set up env vars for executing Govc cmd
-	if err := SetupEnvVars(ctx, datacenterConfig, r.client); err != nil {
-		log.Error(err, "Failed to set up env vars for Govc")
+	vuc, err := UserConfig(ctx, datacenterConfig, r.client)
+	if err != nil {
+		log.Error(err, "Failed to get vSphere credentials for Govc")
 		return controller.Result{}, err
 	}
 
 	vsphereClusterSpec := vsphere.NewSpec(clusterSpec)
+	validator, err := r.validatorFactory.Validator(ctx, datacenterConfig, vuc)
+	if err != nil {
+		return controller.Result{}, err
+	}
 
-	if err := r.validator.ValidateClusterMachineConfigs(ctx, vsphereClusterSpec); err != nil {
+	if err := validator.ValidateClusterMachineConfigs(ctx, vsphereClusterSpec); err != nil {
 		log.Error(err, "Invalid VSphereMachineConfig")
 		failureMessage := err.Error()
 		clusterSpec.Cluster.SetFailure(anywherev1.MachineConfigInvalidReason, failureMessage)
@@ -187,7 +197,12 @@ func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger
 func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileControlPlane")
 	log.Info("Applying control plane CAPI objects")
-	cp, err := vsphere.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), spec)
+	vuc, err := UserConfig(ctx, spec.VSphereDatacenter, r.client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	cp, err := vsphere.ControlPlaneSpecWithCredentials(ctx, log, clientutil.NewKubeClient(r.client), spec, vuc)
 	if err != nil {
 		return controller.Result{}, err
 	}
@@ -202,6 +217,13 @@ func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger
 	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
 }
 
+// CheckControlPlaneUpgraded checks whether all the control plane machines run the desired Kubernetes version,
+// so the worker nodes are only upgraded after the control plane. Requeues while the control plane is being upgraded.
+func (r *Reconciler) CheckControlPlaneUpgraded(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneUpgraded")
+	return clusters.CheckControlPlaneUpgraded(ctx, r.client, log, clusterSpec.Cluster)
+}
+
 // ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
 func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileCNI")
@@ -213,6 +235,21 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileCoreDNS scales up CoreDNS with the number of nodes of large clusters.
+func (r *Reconciler) ReconcileCoreDNS(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	if !clusterSpec.Cluster.IsLargeScale() {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("phase", "reconcileCoreDNS")
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileCoreDNS(ctx, log, client, clusterSpec.Cluster)
+}
+
 // ReconcileWorkers applies the worker CAPI objects to the cluster.
 func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
@@ -222,7 +259,7 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, r.remoteClientRegistry, spec.Cluster, clusters.ToWorkers(w))
 }
 
 func toClientControlPlane(cp *vsphere.ControlPlane) *clusters.ControlPlane {
@@ -3,13 +3,13 @@ package reconciler_test
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
@@ -151,18 +151,90 @@ func TestReconcilerFailToSetUpMachineConfigCP(t *testing.T) {
 	tt.Expect(tt.cluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.MachineConfigInvalidReason)))
 }
 
-func TestSetupEnvVars(t *testing.T) {
+func TestUserConfig(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
 
-	err := reconciler.SetupEnvVars(context.Background(), tt.datacenterConfig, tt.client)
-	tt.Expect(os.Getenv(config.EksavSphereUsernameKey)).To(Equal("user"))
-	tt.Expect(os.Getenv(config.EksavSpherePasswordKey)).To(Equal("pass"))
+	vuc, err := reconciler.UserConfig(context.Background(), tt.datacenterConfig, tt.client)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(vuc).To(Equal(&config.VSphereUserConfig{
+		EksaVsphereUsername:   "user",
+		EksaVspherePassword:   "pass",
+		EksaVsphereCPUsername: "userCP",
+		EksaVsphereCPPassword: "passCP",
+	}))
+}
 
-	tt.Expect(os.Getenv(config.EksavSphereCPUsernameKey)).To(Equal("userCP"))
-	tt.Expect(os.Getenv(config.EksavSphereCPPasswordKey)).To(Equal("passCP"))
+func TestUserConfigWithoutCloudProviderCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.credentialsSecret.Data = map[string][]byte{
+		"username": []byte("user"),
+		"password": []byte("pass"),
+	}
+	tt.withFakeClient()
 
+	vuc, err := reconciler.UserConfig(context.Background(), tt.datacenterConfig, tt.client)
 	tt.Expect(err).To(BeNil())
+	tt.Expect(vuc.EksaVsphereCPUsername).To(Equal("user"))
+	tt.Expect(vuc.EksaVsphereCPPassword).To(Equal("pass"))
+}
+
+func TestUserConfigMissingPassword(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.credentialsSecret.Data = map[string][]byte{
+		"username": []byte("user"),
+	}
+	tt.withFakeClient()
+
+	_, err := reconciler.UserConfig(context.Background(), tt.datacenterConfig, tt.client)
+	tt.Expect(err).To(MatchError(ContainSubstring("missing the username or password")))
+}
+
+func TestUserConfigNamespaceCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	namespaceSecret := test.VSphereCredentialsSecret()
+	namespaceSecret.Namespace = tt.datacenterConfig.Namespace
+	namespaceSecret.Data = map[string][]byte{
+		"username":   []byte("team-user"),
+		"password":   []byte("team-pass"),
+		"usernameCP": []byte("team-userCP"),
+		"passwordCP": []byte("team-passCP"),
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, namespaceSecret)
+	tt.withFakeClient()
+
+	vuc, err := reconciler.UserConfig(context.Background(), tt.datacenterConfig, tt.client)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(vuc).To(Equal(&config.VSphereUserConfig{
+		EksaVsphereUsername:   "team-user",
+		EksaVspherePassword:   "team-pass",
+		EksaVsphereCPUsername: "team-userCP",
+		EksaVsphereCPPassword: "team-passCP",
+	}))
+}
+
+func TestReconcilerValidateMachineConfigsUsesNamespaceCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	namespaceSecret := test.VSphereCredentialsSecret()
+	namespaceSecret.Namespace = tt.datacenterConfig.Namespace
+	namespaceSecret.Data = map[string][]byte{
+		"username": []byte("team-user"),
+		"password": []byte("team-pass"),
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, namespaceSecret)
+	tt.withFakeClient()
+	t.Setenv(config.EksavSphereUsernameKey, "process-user")
+
+	tt.govcClient.EXPECT().ValidateVCenterSetupMachineConfig(tt.ctx, tt.datacenterConfig, tt.machineConfigControlPlane, gomock.Any()).Return(fmt.Errorf("error"))
+	tt.govcClient.EXPECT().ValidateVCenterSetupMachineConfig(tt.ctx, tt.datacenterConfig, tt.machineConfigWorker, gomock.Any()).Return(nil).MaxTimes(1)
+
+	_, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+	tt.Expect(err).To(BeNil())
+	tt.Expect(tt.govcEnvs).To(ConsistOf(vsphere.GovcEnvMap(tt.datacenterConfig, &config.VSphereUserConfig{
+		EksaVsphereUsername: "team-user",
+		EksaVspherePassword: "team-pass",
+	})))
+	tt.Expect(tt.govcEnvs[0]).To(HaveKeyWithValue("GOVC_USERNAME", "team-user"))
 }
 
 func TestReconcilerControlPlaneIsNotReady(t *testing.T) {
@@ -283,6 +355,41 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcileCoreDNSLargeCluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.Scale = anywherev1.ClusterScaleLarge
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	coreDNS := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: constants.KubeSystemNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(1)},
+	}
+	remoteClient := fake.NewClientBuilder().WithObjects(coreDNS).Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+
+	result, err := tt.reconciler().ReconcileCoreDNS(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKeyFromObject(coreDNS), coreDNS)).To(Succeed())
+	tt.Expect(*coreDNS.Spec.Replicas).To(Equal(int32(2)))
+}
+
+func TestReconcileCoreDNSStandardCluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ReconcileCoreDNS(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcilerReconcileControlPlaneSuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.createAllObjs()
@@ -338,8 +445,9 @@ type reconcilerTest struct {
 	ctx                       context.Context
 	cniReconciler             *vspherereconcilermocks.MockCNIReconciler
 	govcClient                *mocks.MockProviderGovcClient
-	validator                 *vsphere.Validator
-	defaulter                 *vsphere.Defaulter
+	validatorFactory          *vsphere.ValidatorFactory
+	govcEnvs                  []map[string]string
+	credentialsSecret         *corev1.Secret
 	remoteClientRegistry      *vspherereconcilermocks.MockRemoteClientRegistry
 	cluster                   *anywherev1.Cluster
 	client                    client.Client
@@ -360,8 +468,6 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 
 	govcClient := mocks.NewMockProviderGovcClient(ctrl)
 	vcb := govmomi.NewVMOMIClientBuilder()
-	validator := vsphere.NewValidator(govcClient, vcb)
-	defaulter := vsphere.NewDefaulter(govcClient)
 	ipValidator := vspherereconcilermocks.NewMockIPValidator(ctrl)
 
 	bundle := test.Bundle()
@@ -444,8 +550,6 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		ctx:                  context.Background(),
 		cniReconciler:        cniReconciler,
 		govcClient:           govcClient,
-		validator:            validator,
-		defaulter:            defaulter,
 		ipValidator:          ipValidator,
 		remoteClientRegistry: remoteClientRegistry,
 		client:               c,
@@ -461,12 +565,18 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 			test.EKSARelease(),
 		},
 		bundle:                    bundle,
+		credentialsSecret:         credentialsSecret,
 		cluster:                   cluster,
 		datacenterConfig:          workloadClusterDatacenter,
 		machineConfigControlPlane: machineConfigCP,
 		machineConfigWorker:       machineConfigWN,
 	}
 
+	tt.validatorFactory = vsphere.NewValidatorFactory(func(envMap map[string]string) vsphere.ProviderGovcClient {
+		tt.govcEnvs = append(tt.govcEnvs, envMap)
+		return govcClient
+	}, vcb)
+
 	t.Cleanup(tt.cleanup)
 	return tt
 }
@@ -492,7 +602,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.validatorFactory, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
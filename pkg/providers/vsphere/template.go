@@ -8,7 +8,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
@@ -18,6 +17,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// templateOverridePaths are the paths of the generated CAPI objects that can be overridden
+// with the cluster templateOverrides.
+var templateOverridePaths = clusterapi.TemplateOverridePaths(map[string][]string{
+	"VSphereMachineTemplate": {"spec.template.spec.customVMXKeys"},
+})
+
 func NewVsphereTemplateBuilder(
 	now types.NowFunc,
 ) *VsphereTemplateBuilder {
@@ -57,7 +62,7 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(
 		return nil, err
 	}
 
-	return bytes, nil
+	return clusterapi.ApplyTemplateOverrides(bytes, clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(clusterSpec *cluster.Spec, worker anywherev1.WorkerNodeGroupConfiguration) (bool, error) {
@@ -116,7 +121,7 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(
 		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return clusterapi.ApplyTemplateOverrides(templater.AppendYamlResources(workerSpecs...), clusterSpec.Cluster.Spec.TemplateOverrides, templateOverridePaths)
 }
 
 func buildTemplateMapCP(
@@ -126,17 +131,22 @@ func buildTemplateMapCP(
 ) (map[string]interface{}, error) {
 	versionsBundle := clusterSpec.RootVersionsBundle()
 	format := "cloud-config"
-	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
-	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	etcdExtraArgs := clusterapi.EtcdTLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.LargeClusterEtcdExtraArgs(clusterSpec.Cluster))
+	sharedExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster)
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.PodSecurityAdmissionExtraArgs(clusterSpec.Cluster.Spec.PodSecurityAdmission)).
+		Append(clusterapi.NodePortRangeExtraArgs(controlPlaneMachineSpec.HostOSConfiguration)).
+		Append(clusterapi.LargeClusterAPIServerExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
-	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	controllerManagerExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.LargeClusterControllerManagerExtraArgs(clusterSpec.Cluster))
 
 	vuc := config.NewVsphereUserConfig()
 
@@ -167,8 +177,10 @@ func buildTemplateMapCP(
 		"controlPlaneVsphereFolder":            controlPlaneMachineSpec.Folder,
 		"managerImage":                         versionsBundle.VSphere.Manager.VersionedImage(),
 		"kubeVipImage":                         versionsBundle.VSphere.KubeVip.VersionedImage(),
+		"kubeVipLeaderElection":                clusterapi.KubeVipLeaderElectionForCluster(clusterSpec.Cluster),
 		"insecure":                             datacenterSpec.Insecure,
 		"vsphereNetwork":                       datacenterSpec.Network,
+		"vsphereNetworkMTU":                    clusterSpec.Cluster.Spec.ClusterNetwork.MTU,
 		"controlPlaneVsphereResourcePool":      controlPlaneMachineSpec.ResourcePool,
 		"vsphereServer":                        datacenterSpec.Server,
 		"controlPlaneVsphereStoragePolicyName": controlPlaneMachineSpec.StoragePolicyName,
@@ -184,12 +196,14 @@ func buildTemplateMapCP(
 		"podCidrs":                             clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                         clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"etcdExtraArgs":                        etcdExtraArgs.ToPartialYaml(),
-		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":                     clusterapi.EtcdCipherSuites(clusterSpec.Cluster),
+		"apiserverCertSANs":                    clusterapi.ControlPlaneCertSANs(clusterSpec.Cluster),
 		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
 		"controllerManagerExtraArgs":           controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
 		"format":                               format,
+		"fips":                                 clusterSpec.Cluster.Spec.FIPS,
 		"externalEtcdVersion":                  versionsBundle.KubeDistro.EtcdVersion,
 		"etcdImage":                            versionsBundle.KubeDistro.EtcdImage.VersionedImage(),
 		"eksaSystemNamespace":                  constants.EksaSystemNamespace,
@@ -217,6 +231,9 @@ func buildTemplateMapCP(
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
+		if containerd.UsesHostsFiles(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration) {
+			values["registryHostsFiles"] = containerd.HostsFiles(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration)
+		}
 
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
@@ -295,9 +312,9 @@ func buildTemplateMapCP(
 	if controlPlaneMachineSpec.OSFamily == anywherev1.Bottlerocket {
 		values["format"] = string(anywherev1.Bottlerocket)
 		values["pauseRepository"] = versionsBundle.KubeDistro.Pause.Image()
-		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.Tag()
+		values["pauseVersion"] = versionsBundle.KubeDistro.Pause.PinnedTag()
 		values["bottlerocketBootstrapRepository"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
-		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
+		values["bottlerocketBootstrapVersion"] = versionsBundle.BottleRocketHostContainers.KubeadmBootstrap.PinnedTag()
 	}
 
 	if len(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints) > 0 {
@@ -308,6 +325,18 @@ func buildTemplateMapCP(
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.Spec.PodSecurityAdmission != nil {
+		conf, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.KubernetesVersion, clusterSpec.Cluster.Spec.PodSecurityAdmission)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfig"] = conf
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		values["skipKubeProxy"] = true
+	}
+
 	if controlPlaneMachineSpec.HostOSConfiguration != nil {
 		if controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration != nil {
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
@@ -322,6 +351,14 @@ func buildTemplateMapCP(
 			return nil, err
 		}
 		values["bottlerocketSettings"] = brSettings
+
+		hostOSConfig, err := clusterapi.HostOSKubeadmConfigSpec(controlPlaneMachineSpec.HostOSConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		values["hostOSFiles"] = hostOSConfig.Files
+		values["hostOSPreKubeadmCommands"] = hostOSConfig.PreKubeadmCommands
+		values["hostOSPostKubeadmCommands"] = hostOSConfig.PostKubeadmCommands
 	}
 
 	return values, nil
@@ -338,7 +375,7 @@ func buildTemplateMapMD(
 		return nil, fmt.Errorf("could not find VersionsBundle")
 	}
 	format := "cloud-config"
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+	kubeletExtraArgs := clusterapi.TLSExtraArgs(clusterSpec.Cluster).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
 
@@ -356,6 +393,7 @@ func buildTemplateMapMD(
 		"workerVsphereDatastore":         workerNodeGroupMachineSpec.Datastore,
 		"workerVsphereFolder":            workerNodeGroupMachineSpec.Folder,
 		"vsphereNetwork":                 datacenterSpec.Network,
+		"vsphereNetworkMTU":              clusterSpec.Cluster.Spec.ClusterNetwork.MTU,
 		"workerVsphereResourcePool":      workerNodeGroupMachineSpec.ResourcePool,
 		"vsphereServer":                  datacenterSpec.Server,
 		"workerVsphereStoragePolicyName": workerNodeGroupMachineSpec.StoragePolicyName,
@@ -367,6 +405,7 @@ func buildTemplateMapMD(
 		"workerSshUsername":              firstUser.Name,
 		"vsphereWorkerSshAuthorizedKey":  sshKey,
 		"format":                         format,
+		"fips":                           clusterSpec.Cluster.Spec.FIPS,
 		"eksaSystemNamespace":            constants.EksaSystemNamespace,
 		"kubeletExtraArgs":               kubeletExtraArgs.ToPartialYaml(),
 		"workerReplicas":                 *workerNodeGroupConfiguration.Count,
@@ -385,6 +424,9 @@ func buildTemplateMapMD(
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
+		if containerd.UsesHostsFiles(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration) {
+			values["registryHostsFiles"] = containerd.HostsFiles(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration)
+		}
 
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
@@ -422,9 +464,9 @@ func buildTemplateMapMD(
 	if workerNodeGroupMachineSpec.OSFamily == anywherev1.Bottlerocket {
 		values["format"] = string(anywherev1.Bottlerocket)
 		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
-		values["pauseVersion"] = bundle.KubeDistro.Pause.Tag()
+		values["pauseVersion"] = bundle.KubeDistro.Pause.PinnedTag()
 		values["bottlerocketBootstrapRepository"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Image()
-		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.Tag()
+		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketHostContainers.KubeadmBootstrap.PinnedTag()
 	}
 
 	if workerNodeGroupMachineSpec.HostOSConfiguration != nil {
@@ -441,6 +483,14 @@ func buildTemplateMapMD(
 			return nil, err
 		}
 		values["bottlerocketSettings"] = brSettings
+
+		hostOSConfig, err := clusterapi.HostOSKubeadmConfigSpec(workerNodeGroupMachineSpec.HostOSConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		values["hostOSFiles"] = hostOSConfig.Files
+		values["hostOSPreKubeadmCommands"] = hostOSConfig.PreKubeadmCommands
+		values["hostOSPostKubeadmCommands"] = hostOSConfig.PostKubeadmCommands
 	}
 
 	return values, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
@@ -125,3 +126,188 @@ func vsphereClusterSpec(opts ...test.ClusterSpecOpt) *cluster.Spec {
 
 	return spec
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlanePodSecurityAdmission(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.PodSecurityAdmission = &v1alpha1.PodSecurityAdmissionConfiguration{
+		Enforce: v1alpha1.PodSecurityBaseline,
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	content := string(cp)
+	g.Expect(content).To(ContainSubstring("admission-control-config-file: /etc/kubernetes/admission/admission-configuration.yaml"))
+	g.Expect(content).To(ContainSubstring(`        - hostPath: /var/lib/kubeadm/admission/
+          mountPath: /etc/kubernetes/admission/
+          name: admission-config
+          pathType: DirectoryOrCreate
+          readOnly: true`))
+	g.Expect(content).To(ContainSubstring("      path: /var/lib/kubeadm/admission/admission-configuration.yaml"))
+	g.Expect(content).To(ContainSubstring("          enforce: baseline"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecMTU(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ClusterNetwork.MTU = 1400
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring(`        - dhcp4: true
+          mtu: 1400
+          networkName: /SDDC-Datacenter/network/sddc-cgw-network-1`))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring(`        - dhcp4: true
+          mtu: 1400
+          networkName: /SDDC-Datacenter/network/sddc-cgw-network-1`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneEndpointDNS(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.DNS = &v1alpha1.EndpointDNS{
+		Name:    "api.prod.example.com.",
+		Route53: &v1alpha1.Route53DNS{HostedZoneID: "Z0123456789"},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring(`      apiServer:
+        certSANs:
+        - api.prod.example.com
+        extraArgs:`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecHostFirewall(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	for _, m := range spec.VSphereMachineConfigs {
+		m.Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+			FirewallConfiguration: &v1alpha1.FirewallConfiguration{
+				NodePortRange: "31000-31999",
+				Rules:         []string{"tcp dport {{ .NodePortRange }} ip saddr != 10.0.0.0/8 drop"},
+			},
+		}
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(string(cp)).To(ContainSubstring(`          service-node-port-range: 31000-31999`))
+	g.Expect(string(cp)).To(ContainSubstring(`    - content: |
+        #!/usr/sbin/nft -f`))
+	g.Expect(string(cp)).To(ContainSubstring(`            tcp dport 31000-31999 ip saddr != 10.0.0.0/8 drop`))
+	g.Expect(string(cp)).To(ContainSubstring(`      path: "/etc/eks-anywhere/host-firewall.nft"
+      owner: root:root
+      permissions: "0600"`))
+	g.Expect(string(cp)).To(ContainSubstring(`    - "systemctl enable --now eksa-host-firewall.service"`))
+
+	g.Expect(string(workers)).To(ContainSubstring(`        path: "/etc/systemd/system/eksa-host-firewall.service"`))
+	g.Expect(string(workers)).To(ContainSubstring(`      - "systemctl enable --now eksa-host-firewall.service"`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecCloudInitUserData(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	for _, m := range spec.VSphereMachineConfigs {
+		m.Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+			CloudInitUserData: `write_files:
+- path: /etc/agent/config.yaml
+  content: |
+    endpoint: agent.local
+bootcmd:
+- mkdir -p /var/lib/agent
+runcmd:
+- 'echo "agent: installed" > /var/lib/agent/status'
+`,
+		}
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring(`    - content: |
+        endpoint: agent.local
+        
+      path: "/etc/agent/config.yaml"`))
+	g.Expect(string(cp)).To(ContainSubstring(`    - "mkdir -p /var/lib/agent"
+    postKubeadmCommands:
+    - "echo \"agent: installed\" > /var/lib/agent/status"`))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring(`      files:
+      - content: |
+          endpoint: agent.local`))
+	g.Expect(string(workers)).To(ContainSubstring(`      postKubeadmCommands:
+      - "echo \"agent: installed\" > /var/lib/agent/status"`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneKubeProxyReplacement(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement = true
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	content := string(data)
+	g.Expect(content).To(ContainSubstring(`  annotations:
+    controlplane.cluster.x-k8s.io/skip-kube-proxy: ""`))
+	g.Expect(content).To(ContainSubstring(`    initConfiguration:
+      skipPhases:
+      - addon/kube-proxy`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecFIPS(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.FIPS = true
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	fipsCheck := `- 'grep -qx 1 /proc/sys/crypto/fips_enabled || { echo "FIPS mode is not enabled in the node kernel" >&2; exit 1; }'`
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	content := string(cp)
+	g.Expect(content).To(ContainSubstring("tls-cipher-suites: " + crypto.FIPSCipherSuitesString()))
+	g.Expect(content).To(ContainSubstring("tls-min-version: VersionTLS12"))
+	g.Expect(content).To(ContainSubstring("cipherSuites: " + crypto.FIPSCipherSuitesString()))
+	g.Expect(content).To(ContainSubstring(`    preKubeadmCommands:
+    ` + fipsCheck))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring(`      preKubeadmCommands:
+      ` + fipsCheck))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneLargeScale(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.Scale = v1alpha1.ClusterScaleLarge
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	content := string(cp)
+	g.Expect(content).To(ContainSubstring(`max-requests-inflight: "800"`))
+	g.Expect(content).To(ContainSubstring(`max-mutating-requests-inflight: "400"`))
+	g.Expect(content).To(ContainSubstring(`kube-api-qps: "100"`))
+	g.Expect(content).To(ContainSubstring(`kube-api-burst: "200"`))
+	g.Expect(content).To(ContainSubstring(`            - name: vip_leaseduration
+              value: "30"
+            - name: vip_renewdeadline
+              value: "20"
+            - name: vip_retryperiod
+              value: "4"`))
+}
@@ -154,6 +154,10 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
+	if err := validateDedicatedPlacement(vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	if err := v.validateTemplates(ctx, vsphereClusterSpec); err != nil {
 		return err
 	}
@@ -175,6 +179,29 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	return nil
 }
 
+// validateDedicatedPlacement checks that worker nodes don't share a resource pool with the control plane
+// or etcd machines when the control plane requires dedicated placement.
+func validateDedicatedPlacement(spec *Spec) error {
+	if !spec.Cluster.Spec.ControlPlaneConfiguration.DedicatedPlacement {
+		return nil
+	}
+
+	dedicated := map[string]string{}
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		dedicated[spec.etcdMachineConfig().Spec.ResourcePool] = "etcd"
+	}
+	dedicated[spec.controlPlaneMachineConfig().Spec.ResourcePool] = "control plane"
+
+	for _, workerNodeGroupConfiguration := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		resourcePool := spec.workerMachineConfig(workerNodeGroupConfiguration).Spec.ResourcePool
+		if role, ok := dedicated[resourcePool]; ok {
+			return fmt.Errorf("worker node group %s can't use resource pool %s, it's dedicated to the %s machines", workerNodeGroupConfiguration.Name, resourcePool, role)
+		}
+	}
+
+	return nil
+}
+
 func (v *Validator) validateControlPlaneIp(ip string) error {
 	// check if controlPlaneEndpointIp is valid
 	parsedIp := net.ParseIP(ip)
@@ -0,0 +1,73 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+)
+
+const (
+	govcUsernameKey = "GOVC_USERNAME"
+	govcPasswordKey = "GOVC_PASSWORD"
+	govcURLKey      = "GOVC_URL"
+)
+
+// GovcBuilder builds a govc client that runs its commands with envMap instead of the process env.
+type GovcBuilder func(envMap map[string]string) ProviderGovcClient
+
+// ValidatorFactory builds Validators and Defaulters that authenticate to vCenter with explicit credentials
+// instead of the process env, so clusters with different credentials can be reconciled concurrently.
+type ValidatorFactory struct {
+	govcBuilder          GovcBuilder
+	vSphereClientBuilder VSphereClientBuilder
+}
+
+// NewValidatorFactory constructs a new ValidatorFactory.
+func NewValidatorFactory(govcBuilder GovcBuilder, vscb VSphereClientBuilder) *ValidatorFactory {
+	return &ValidatorFactory{
+		govcBuilder:          govcBuilder,
+		vSphereClientBuilder: vscb,
+	}
+}
+
+// Validator returns a Validator for the vCenter of datacenterConfig that authenticates as the user in vuc.
+func (f *ValidatorFactory) Validator(ctx context.Context, datacenterConfig *anywherev1.VSphereDatacenterConfig, vuc *config.VSphereUserConfig) (*Validator, error) {
+	govc, err := f.govc(ctx, datacenterConfig, vuc)
+	if err != nil {
+		return nil, err
+	}
+	return NewValidator(govc, f.vSphereClientBuilder), nil
+}
+
+// Defaulter returns a Defaulter for the vCenter of datacenterConfig that authenticates as the user in vuc.
+func (f *ValidatorFactory) Defaulter(ctx context.Context, datacenterConfig *anywherev1.VSphereDatacenterConfig, vuc *config.VSphereUserConfig) (*Defaulter, error) {
+	govc, err := f.govc(ctx, datacenterConfig, vuc)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefaulter(govc), nil
+}
+
+func (f *ValidatorFactory) govc(ctx context.Context, datacenterConfig *anywherev1.VSphereDatacenterConfig, vuc *config.VSphereUserConfig) (ProviderGovcClient, error) {
+	govc := f.govcBuilder(GovcEnvMap(datacenterConfig, vuc))
+	if datacenterConfig.Spec.Thumbprint != "" {
+		if err := govc.ConfigureCertThumbprint(ctx, datacenterConfig.Spec.Server, datacenterConfig.Spec.Thumbprint); err != nil {
+			return nil, fmt.Errorf("failed configuring govc cert thumbprint: %v", err)
+		}
+	}
+	return govc, nil
+}
+
+// GovcEnvMap returns the env for govc to run commands against the vCenter of datacenterConfig as the user in vuc.
+func GovcEnvMap(datacenterConfig *anywherev1.VSphereDatacenterConfig, vuc *config.VSphereUserConfig) map[string]string {
+	return map[string]string{
+		govcUsernameKey:   vuc.EksaVsphereUsername,
+		govcPasswordKey:   vuc.EksaVspherePassword,
+		govcURLKey:        datacenterConfig.Spec.Server,
+		govcInsecure:      strconv.FormatBool(datacenterConfig.Spec.Insecure),
+		govcDatacenterKey: datacenterConfig.Spec.Datacenter,
+	}
+}
@@ -0,0 +1,86 @@
+package vsphere_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+func TestGovcEnvMap(t *testing.T) {
+	g := NewWithT(t)
+	datacenterConfig := test.VSphereDatacenter(func(d *anywherev1.VSphereDatacenterConfig) {
+		d.Spec.Server = "vcenter.com"
+		d.Spec.Datacenter = "datacenter"
+		d.Spec.Insecure = true
+	})
+	vuc := &config.VSphereUserConfig{
+		EksaVsphereUsername: "team-user",
+		EksaVspherePassword: "team-pass",
+	}
+
+	g.Expect(vsphere.GovcEnvMap(datacenterConfig, vuc)).To(Equal(map[string]string{
+		"GOVC_USERNAME":   "team-user",
+		"GOVC_PASSWORD":   "team-pass",
+		"GOVC_URL":        "vcenter.com",
+		"GOVC_INSECURE":   "true",
+		"GOVC_DATACENTER": "datacenter",
+	}))
+}
+
+func TestValidatorFactoryConfiguresThumbprint(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	datacenterConfig := test.VSphereDatacenter(func(d *anywherev1.VSphereDatacenterConfig) {
+		d.Spec.Server = "vcenter.com"
+		d.Spec.Thumbprint = "AB:AB:AB"
+	})
+	vuc := &config.VSphereUserConfig{
+		EksaVsphereUsername: "team-user",
+		EksaVspherePassword: "team-pass",
+	}
+	var envs []map[string]string
+	f := vsphere.NewValidatorFactory(func(envMap map[string]string) vsphere.ProviderGovcClient {
+		envs = append(envs, envMap)
+		return govc
+	}, govmomi.NewVMOMIClientBuilder())
+
+	govc.EXPECT().ConfigureCertThumbprint(ctx, "vcenter.com", "AB:AB:AB").Return(nil).Times(2)
+
+	validator, err := f.Validator(ctx, datacenterConfig, vuc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validator).NotTo(BeNil())
+	defaulter, err := f.Defaulter(ctx, datacenterConfig, vuc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(defaulter).NotTo(BeNil())
+	g.Expect(envs).To(HaveLen(2))
+	g.Expect(envs[0]).To(HaveKeyWithValue("GOVC_USERNAME", "team-user"))
+}
+
+func TestValidatorFactoryThumbprintError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	datacenterConfig := test.VSphereDatacenter(func(d *anywherev1.VSphereDatacenterConfig) {
+		d.Spec.Server = "vcenter.com"
+		d.Spec.Thumbprint = "AB:AB:AB"
+	})
+	f := vsphere.NewValidatorFactory(func(map[string]string) vsphere.ProviderGovcClient {
+		return govc
+	}, govmomi.NewVMOMIClientBuilder())
+
+	govc.EXPECT().ConfigureCertThumbprint(ctx, "vcenter.com", "AB:AB:AB").Return(errors.New("writing file"))
+
+	_, err := f.Validator(ctx, datacenterConfig, &config.VSphereUserConfig{})
+	g.Expect(err).To(MatchError(ContainSubstring("failed configuring govc cert thumbprint: writing file")))
+}
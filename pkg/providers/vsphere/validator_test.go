@@ -603,3 +603,76 @@ func TestValidator_validateTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDedicatedPlacement(t *testing.T) {
+	tests := []struct {
+		name             string
+		workerPool       string
+		etcdPool         string
+		dedicated        bool
+		wantErrSubstring string
+	}{
+		{
+			name:       "dedicated placement disabled",
+			workerPool: "cp-pool",
+			etcdPool:   "etcd-pool",
+			dedicated:  false,
+		},
+		{
+			name:       "dedicated resource pools",
+			workerPool: "worker-pool",
+			etcdPool:   "etcd-pool",
+			dedicated:  true,
+		},
+		{
+			name:             "worker in control plane pool",
+			workerPool:       "cp-pool",
+			etcdPool:         "etcd-pool",
+			dedicated:        true,
+			wantErrSubstring: "worker node group md-0 can't use resource pool cp-pool, it's dedicated to the control plane machines",
+		},
+		{
+			name:             "worker in etcd pool",
+			workerPool:       "etcd-pool",
+			etcdPool:         "etcd-pool",
+			dedicated:        true,
+			wantErrSubstring: "worker node group md-0 can't use resource pool etcd-pool, it's dedicated to the etcd machines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := NewSpec(&cluster.Spec{
+				Config: &cluster.Config{
+					Cluster: &v1alpha1.Cluster{
+						Spec: v1alpha1.ClusterSpec{
+							ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+								MachineGroupRef:    &v1alpha1.Ref{Name: "cp"},
+								DedicatedPlacement: tt.dedicated,
+							},
+							ExternalEtcdConfiguration: &v1alpha1.ExternalEtcdConfiguration{
+								MachineGroupRef: &v1alpha1.Ref{Name: "etcd"},
+							},
+							WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+								{Name: "md-0", MachineGroupRef: &v1alpha1.Ref{Name: "worker"}},
+							},
+						},
+					},
+					VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+						"cp":     {Spec: v1alpha1.VSphereMachineConfigSpec{ResourcePool: "cp-pool"}},
+						"etcd":   {Spec: v1alpha1.VSphereMachineConfigSpec{ResourcePool: tt.etcdPool}},
+						"worker": {Spec: v1alpha1.VSphereMachineConfigSpec{ResourcePool: tt.workerPool}},
+					},
+				},
+			})
+
+			err := validateDedicatedPlacement(spec)
+			if tt.wantErrSubstring == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErrSubstring)))
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -95,6 +98,8 @@ type ProviderGovcClient interface {
 	DeleteLibraryElement(ctx context.Context, element string) error
 	TemplateHasSnapshot(ctx context.Context, template string) (bool, error)
 	GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error)
+	GetResourcePoolInfo(ctx context.Context, datacenter, resourcePool string) (*executables.ResourcePoolInfo, error)
+	ListHosts(ctx context.Context, computeResource string) ([]executables.Host, error)
 	ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, selfSigned *bool) error
 	ValidateVCenterConnection(ctx context.Context, server string) error
 	ValidateVCenterAuthentication(ctx context.Context) error
@@ -248,6 +253,17 @@ func (p *vsphereProvider) BootstrapClusterOpts(spec *cluster.Spec) ([]bootstrapp
 	return common.BootstrapClusterOpts(p.clusterConfig, spec.VSphereDatacenter.Spec.Server)
 }
 
+// Capabilities returns the optional features supported by the provider.
+func (p *vsphereProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{
+		Autoscaling:           true,
+		ExternalEtcd:          true,
+		KubeProxyReplacement:  true,
+		TemplateOverridePaths: templateOverridePaths,
+		BundleImages:          (*releasev1alpha1.VersionsBundle).VsphereImages,
+	}
+}
+
 func (p *vsphereProvider) Name() string {
 	return constants.VSphereProviderName
 }
@@ -338,6 +354,9 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	if err := p.validateDatastoreUsageForCreate(ctx, vSphereClusterSpec); err != nil {
 		return fmt.Errorf("validating vsphere machine configs datastore usage: %v", err)
 	}
+	if err := p.validateComputeCapacityForCreate(ctx, vSphereClusterSpec); err != nil {
+		return fmt.Errorf("validating vsphere resource pools capacity: %v", err)
+	}
 
 	if err := p.generateSSHKeysIfNotSet(clusterSpec.VSphereMachineConfigs); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -592,6 +611,80 @@ func (p *vsphereProvider) validateDatastoreUsageForCreate(ctx context.Context, v
 	return nil
 }
 
+// validateComputeCapacityForCreate checks the resource pools have enough memory left for all
+// the machines of the cluster and that their hosts are not all in maintenance mode, so the
+// create doesn't fail once part of the machines have been provisioned.
+func (p *vsphereProvider) validateComputeCapacityForCreate(ctx context.Context, vsphereClusterSpec *Spec) error {
+	neededMemoryMiB := map[string]int64{}
+	addMachines := func(machineConfig *v1alpha1.VSphereMachineConfig, count int) {
+		neededMemoryMiB[machineConfig.Spec.ResourcePool] += int64(machineConfig.Spec.MemoryMiB * count)
+	}
+
+	addMachines(vsphereClusterSpec.controlPlaneMachineConfig(), vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count)
+	for _, workerNodeGroupConfiguration := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		addMachines(vsphereClusterSpec.workerMachineConfig(workerNodeGroupConfiguration), *workerNodeGroupConfiguration.Count)
+	}
+	if etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig(); etcdMachineConfig != nil {
+		addMachines(etcdMachineConfig, vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count)
+	}
+
+	datacenter := vsphereClusterSpec.VSphereDatacenter.Spec.Datacenter
+	computeResources := map[string]struct{}{}
+	for _, pool := range sortedKeys(neededMemoryMiB) {
+		info, err := p.providerGovcClient.GetResourcePoolInfo(ctx, datacenter, pool)
+		if err != nil {
+			return err
+		}
+		if info.MemoryLimitMiB >= 0 {
+			available := info.MemoryLimitMiB - info.MemoryUsedMiB
+			if neededMemoryMiB[pool] > available {
+				return fmt.Errorf("not enough memory in resource pool %s: machines need %d MiB but only %d MiB are available", pool, neededMemoryMiB[pool], available)
+			}
+		}
+		computeResources[computeResourceForPool(pool)] = struct{}{}
+	}
+
+	for computeResource := range computeResources {
+		hosts, err := p.providerGovcClient.ListHosts(ctx, computeResource)
+		if err != nil {
+			return err
+		}
+
+		inMaintenance := 0
+		for _, h := range hosts {
+			if h.InMaintenanceMode {
+				inMaintenance++
+			}
+		}
+		if len(hosts) > 0 && inMaintenance == len(hosts) {
+			return fmt.Errorf("all hosts in %s are in maintenance mode", computeResource)
+		}
+		if inMaintenance > 0 {
+			logger.Info("Warning: some hosts are in maintenance mode, machines won't be placed on them", "computeResource", computeResource, "hosts", inMaintenance)
+		}
+	}
+
+	return nil
+}
+
+// computeResourceForPool returns the path of the cluster or host a resource pool belongs to.
+// Resource pool paths follow the format /<datacenter>/host/<compute resource>/Resources/<pool>.
+func computeResourceForPool(pool string) string {
+	if i := strings.Index(pool, "/Resources"); i > 0 {
+		return pool[:i]
+	}
+	return pool
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (p *vsphereProvider) UpdateSecrets(ctx context.Context, cluster *types.Cluster, _ *cluster.Spec) error {
 	var contents bytes.Buffer
 	err := p.createSecret(ctx, cluster, &contents)
@@ -626,6 +719,9 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -639,6 +735,9 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(&oldWorker, &newWorker, oldSpec.Cluster, newSpec.Cluster) {
 		return true
 	}
+	if clusterapi.TemplateOverridesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -685,6 +784,9 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig,
 	if oldVmc.Spec.Template != newVmc.Spec.Template {
 		return true
 	}
+	if oldVmc.Spec.StoragePolicyName != newVmc.Spec.StoragePolicyName {
+		return true
+	}
 	return false
 }
 
@@ -1071,7 +1173,9 @@ func (p *vsphereProvider) validateMachineConfigImmutability(ctx context.Context,
 		return err
 	}
 
-	if newConfig.Spec.StoragePolicyName != prevMachineConfig.Spec.StoragePolicyName {
+	// Workload clusters can change the storage policy, which rolls out the machines with a new
+	// machine template, the same way the webhook allows it.
+	if newConfig.Spec.StoragePolicyName != prevMachineConfig.Spec.StoragePolicyName && !clusterSpec.Cluster.IsManaged() {
 		return fmt.Errorf("spec.storagePolicyName is immutable. Previous value %s, new value %s", prevMachineConfig.Spec.StoragePolicyName, newConfig.Spec.StoragePolicyName)
 	}
 
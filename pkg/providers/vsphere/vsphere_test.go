@@ -79,6 +79,14 @@ func (pc *DummyProviderGovcClient) GetWorkloadAvailableSpace(ctx context.Context
 	return math.MaxFloat64, nil
 }
 
+func (pc *DummyProviderGovcClient) GetResourcePoolInfo(ctx context.Context, datacenter, resourcePool string) (*executables.ResourcePoolInfo, error) {
+	return &executables.ResourcePoolInfo{MemoryLimitMiB: -1, CPULimitMHz: -1}, nil
+}
+
+func (pc *DummyProviderGovcClient) ListHosts(ctx context.Context, computeResource string) ([]executables.Host, error) {
+	return nil, nil
+}
+
 func (pc *DummyProviderGovcClient) DeployTemplate(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig) error {
 	return nil
 }
@@ -960,6 +968,95 @@ func TestProviderGenerateCAPISpecForUpgradeNotUpdateMachineTemplate(t *testing.T
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_no_machinetemplate_update_md.yaml")
 }
 
+func TestProviderGenerateCAPISpecForUpgradeStoragePolicyChangedUpdateMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	bootstrapCluster := &types.Cluster{
+		Name: "bootstrap-test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	oldCP := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: v1.ObjectReference{
+					Name: "test-control-plane-template-original",
+				},
+			},
+		},
+	}
+	oldMD := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: v1.ObjectReference{
+						Name: "test-md-0-original",
+					},
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &v1.ObjectReference{
+							Name: "test-md-0-template-original",
+						},
+					},
+				},
+			},
+		},
+	}
+	etcdadmCluster := &etcdv1.EtcdadmCluster{
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: v1.ObjectReference{
+				Name: "test-etcd-template-original",
+			},
+		},
+	}
+
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	provider := newProviderWithKubectl(t, datacenterConfig, clusterSpec.Cluster, kubectl, ipValidator)
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	controlPlaneMachineConfigName := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	workerNodeMachineConfigName := clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	machineDeploymentName := fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name)
+	etcdMachineConfigName := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
+	oldControlPlaneMachineConfig := clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName].DeepCopy()
+	oldControlPlaneMachineConfig.Spec.StoragePolicyName = "old-storage-policy"
+	oldWorkerNodeMachineConfig := clusterSpec.VSphereMachineConfigs[workerNodeMachineConfigName].DeepCopy()
+	oldWorkerNodeMachineConfig.Spec.StoragePolicyName = "old-storage-policy"
+
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaVSphereDatacenterConfig(ctx, cluster.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, controlPlaneMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(oldControlPlaneMachineConfig, nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, workerNodeMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(oldWorkerNodeMachineConfig, nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, etcdMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereMachineConfigs[etcdMachineConfigName], nil)
+	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldCP, nil).AnyTimes()
+	kubectl.EXPECT().GetMachineDeployment(ctx, machineDeploymentName, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldMD, nil).AnyTimes()
+	kubectl.EXPECT().GetEtcdadmCluster(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(etcdadmCluster, nil)
+	cp, md, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	g.Expect(string(cp)).NotTo(ContainSubstring("test-control-plane-template-original"))
+	g.Expect(string(cp)).To(ContainSubstring("test-etcd-template-original"))
+	g.Expect(string(md)).NotTo(ContainSubstring("name: test-md-0-original"))
+	g.Expect(string(md)).To(ContainSubstring("name: test-md-0-template-original"))
+}
+
 func TestProviderGenerateCAPISpecForCreate(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	setupContext(t)
@@ -2336,6 +2433,8 @@ func TestSetupAndValidateCreateClusterFullCloneDiskGiBLessThan20TemplateDiskSize
 	tt.govc.EXPECT().GetTags(tt.ctx, template).Return([]string{"eksdRelease:kubernetes-1-21-eks-4", "os:ubuntu"}, nil)
 	tt.govc.EXPECT().ListTags(tt.ctx)
 	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, tt.clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName].Spec.Datastore).Return(100.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, tt.datacenterConfig.Spec.Datacenter, tt.clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName].Spec.ResourcePool).Return(&executables.ResourcePoolInfo{MemoryLimitMiB: -1}, nil)
+	tt.govc.EXPECT().ListHosts(tt.ctx, gomock.Any())
 	tt.ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(tt.cluster)
 
 	err := tt.provider.SetupAndValidateCreateCluster(context.Background(), tt.clusterSpec)
@@ -3093,6 +3192,37 @@ func TestValidateNewSpecStoragePolicyNameImmutableWorker(t *testing.T) {
 	assert.ErrorContains(t, err, "spec.storagePolicyName is immutable", "StoragePolicyName should be immutable")
 }
 
+func TestValidateNewSpecStoragePolicyNameMutableWorkloadCluster(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	clusterSpec.Cluster.SetManagedBy("mgmt")
+	newClusterSpec := clusterSpec.DeepCopy()
+	setupContext(t)
+
+	provider := givenProvider(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	provider.providerKubectlClient = kubectl
+
+	clusterVsphereSecret := &v1.Secret{
+		Data: map[string][]byte{
+			"username": []byte("vsphere_username"),
+			"password": []byte("vsphere_password"),
+		},
+	}
+
+	controlPlaneMachineConfigName := newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	controlPlaneMachineConfig := newClusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName]
+	controlPlaneMachineConfig.Spec.StoragePolicyName = "new-" + controlPlaneMachineConfig.Spec.StoragePolicyName
+
+	kubectl.EXPECT().GetEksaCluster(context.TODO(), gomock.Any(), gomock.Any()).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaVSphereDatacenterConfig(context.TODO(), clusterSpec.Cluster.Spec.DatacenterRef.Name, gomock.Any(), clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereDatacenter, nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(context.TODO(), gomock.Any(), gomock.Any(), clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName], nil).AnyTimes()
+	kubectl.EXPECT().GetSecretFromNamespace(gomock.Any(), gomock.Any(), CredentialsObjectName, gomock.Any()).Return(clusterVsphereSecret, nil)
+
+	err := provider.ValidateNewSpec(context.TODO(), &types.Cluster{}, newClusterSpec)
+	assert.NoError(t, err, "StoragePolicyName should be mutable for workload clusters")
+}
+
 func TestValidateNewSpecOSFamilyImmutableControlPlane(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
@@ -3456,6 +3586,57 @@ func TestValidateMachineConfigsDatastoreUsageCreateError(t *testing.T) {
 	thenErrorExpected(t, fmt.Sprintf("not enough space in datastore %s for given diskGiB and count for respective machine groups", tt.clusterSpec.VSphereMachineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Datastore), err)
 }
 
+func TestValidateComputeCapacityForCreateSuccess(t *testing.T) {
+	tt := newProviderTest(t)
+	for _, pool := range resourcePools(tt.clusterSpec) {
+		tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, tt.datacenterConfig.Spec.Datacenter, pool).Return(&executables.ResourcePoolInfo{MemoryLimitMiB: 1 << 20}, nil)
+	}
+	tt.govc.EXPECT().ListHosts(tt.ctx, gomock.Any()).Return([]executables.Host{
+		{Path: "/SDDC-Datacenter/host/Cluster-1/esxi-1", InMaintenanceMode: true},
+		{Path: "/SDDC-Datacenter/host/Cluster-1/esxi-2"},
+	}, nil).AnyTimes()
+
+	if err := tt.provider.validateComputeCapacityForCreate(tt.ctx, NewSpec(tt.clusterSpec)); err != nil {
+		t.Fatalf("unexpected failure %v", err)
+	}
+}
+
+func TestValidateComputeCapacityForCreateNotEnoughMemory(t *testing.T) {
+	tt := newProviderTest(t)
+	pools := resourcePools(tt.clusterSpec)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, tt.datacenterConfig.Spec.Datacenter, pools[0]).Return(&executables.ResourcePoolInfo{MemoryLimitMiB: 4096, MemoryUsedMiB: 2048}, nil)
+
+	err := tt.provider.validateComputeCapacityForCreate(tt.ctx, NewSpec(tt.clusterSpec))
+	thenErrorPrefixExpected(t, fmt.Sprintf("not enough memory in resource pool %s", pools[0]), err)
+}
+
+func TestValidateComputeCapacityForCreateAllHostsInMaintenanceMode(t *testing.T) {
+	tt := newProviderTest(t)
+	for _, pool := range resourcePools(tt.clusterSpec) {
+		tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, tt.datacenterConfig.Spec.Datacenter, pool).Return(&executables.ResourcePoolInfo{MemoryLimitMiB: -1}, nil)
+	}
+	tt.govc.EXPECT().ListHosts(tt.ctx, gomock.Any()).Return([]executables.Host{
+		{Path: "/SDDC-Datacenter/host/Cluster-1/esxi-1", InMaintenanceMode: true},
+	}, nil)
+
+	err := tt.provider.validateComputeCapacityForCreate(tt.ctx, NewSpec(tt.clusterSpec))
+	thenErrorPrefixExpected(t, "all hosts in", err)
+}
+
+func TestComputeResourceForPool(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(computeResourceForPool("/SDDC-Datacenter/host/Cluster-1/Resources/Compute-ResourcePool")).To(Equal("/SDDC-Datacenter/host/Cluster-1"))
+	g.Expect(computeResourceForPool("/SDDC-Datacenter/host/Cluster-1/Resources")).To(Equal("/SDDC-Datacenter/host/Cluster-1"))
+}
+
+func resourcePools(spec *cluster.Spec) []string {
+	seen := map[string]int64{}
+	for _, m := range spec.VSphereMachineConfigs {
+		seen[m.Spec.ResourcePool] = 0
+	}
+	return sortedKeys(seen)
+}
+
 func TestValidateMachineConfigsDatastoreUsageUpgradeError(t *testing.T) {
 	tt := newProviderTest(t)
 	cluster := &types.Cluster{
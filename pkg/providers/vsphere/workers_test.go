@@ -236,6 +236,11 @@ func TestWorkersSpecRegistryMirrorConfiguration(t *testing.T) {
 			mirrorConfig: test.RegistryMirrorInsecureSkipVerifyEnabledAndCACert(),
 			files:        test.RegistryMirrorConfigFilesInsecureSkipVerifyAndCACert(),
 		},
+		{
+			name:         "registry hosts",
+			mirrorConfig: test.RegistryMirrorWithHosts(),
+			files:        test.RegistryMirrorConfigFilesWithHosts(),
+		},
 	}
 
 	for _, tt := range tests {
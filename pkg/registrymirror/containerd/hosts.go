@@ -0,0 +1,153 @@
+package containerd
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// CertsDir is the directory containerd loads the per registry hosts configuration from.
+const CertsDir = "/etc/containerd/certs.d"
+
+// HostsFile is a file of the containerd per registry hosts configuration.
+type HostsFile struct {
+	Path    string
+	Content string
+}
+
+// UsesHostsFiles returns true if the registry mirror configuration needs to be written
+// to the nodes as containerd hosts files.
+func UsesHostsFiles(config *v1alpha1.RegistryMirrorConfiguration) bool {
+	return config != nil && len(config.Hosts) > 0
+}
+
+// HostsFiles builds the containerd hosts.toml files for every upstream registry in the registry
+// mirror configuration, plus the CA certificates they reference. The OCI namespaces are turned
+// into one host per upstream registry pointing to the mirror and the configuration hosts
+// override them.
+func HostsFiles(config *v1alpha1.RegistryMirrorConfiguration) []HostsFile {
+	if config == nil {
+		return nil
+	}
+
+	base := net.JoinHostPort(config.Endpoint, config.Port)
+	registries := map[string]v1alpha1.RegistryHostsConfiguration{}
+	mirrorHost := func(namespace string) v1alpha1.RegistryHost {
+		host := v1alpha1.RegistryHost{
+			URL:                "https://" + base,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		}
+		if namespace != "" {
+			host.URL = "https://" + path.Join(base, "v2", namespace)
+			host.OverridePath = true
+		}
+		return host
+	}
+
+	for _, ociNamespace := range config.OCINamespaces {
+		registries[ociNamespace.Registry] = v1alpha1.RegistryHostsConfiguration{
+			Registry: ociNamespace.Registry,
+			Hosts:    []v1alpha1.RegistryHost{mirrorHost(ociNamespace.Namespace)},
+		}
+	}
+	if len(config.OCINamespaces) == 0 {
+		registries[constants.DefaultCoreEKSARegistry] = v1alpha1.RegistryHostsConfiguration{
+			Registry: constants.DefaultCoreEKSARegistry,
+			Hosts:    []v1alpha1.RegistryHost{mirrorHost("")},
+		}
+	}
+
+	for _, hostsConfig := range config.Hosts {
+		registries[hostsConfig.Registry] = hostsConfig
+	}
+
+	names := make([]string, 0, len(registries))
+	for name := range registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var files []HostsFile
+	if config.CACertContent != "" {
+		files = append(files, HostsFile{
+			Path:    filepath.Join(CertsDir, base, "ca.crt"),
+			Content: config.CACertContent,
+		})
+	}
+
+	for _, name := range names {
+		hostsConfig := registries[name]
+		var sections []string
+		if hostsConfig.Server != "" {
+			sections = append(sections, fmt.Sprintf("server = %q", hostsConfig.Server))
+		}
+
+		for _, host := range hostsConfig.Hosts {
+			caFile := ""
+			switch {
+			case host.CACertContent != "":
+				caFile = filepath.Join(CertsDir, name, hostCertName(host.URL))
+				files = append(files, HostsFile{Path: caFile, Content: host.CACertContent})
+			case config.CACertContent != "" && isMirrorHost(host.URL, base):
+				caFile = filepath.Join(CertsDir, base, "ca.crt")
+			}
+
+			sections = append(sections, hostSection(host, caFile))
+		}
+
+		files = append(files, HostsFile{
+			Path:    filepath.Join(CertsDir, name, "hosts.toml"),
+			Content: strings.Join(sections, "\n\n"),
+		})
+	}
+
+	return files
+}
+
+func hostSection(host v1alpha1.RegistryHost, caFile string) string {
+	capabilities := host.Capabilities
+	if len(capabilities) == 0 {
+		capabilities = []v1alpha1.RegistryHostCapability{v1alpha1.RegistryHostPull, v1alpha1.RegistryHostResolve}
+	}
+	quoted := make([]string, 0, len(capabilities))
+	for _, c := range capabilities {
+		quoted = append(quoted, fmt.Sprintf("%q", c))
+	}
+
+	lines := []string{
+		fmt.Sprintf("[host.%q]", host.URL),
+		fmt.Sprintf("  capabilities = [%s]", strings.Join(quoted, ", ")),
+	}
+	if caFile != "" {
+		lines = append(lines, fmt.Sprintf("  ca = %q", caFile))
+	}
+	if host.InsecureSkipVerify {
+		lines = append(lines, "  skip_verify = true")
+	}
+	if host.OverridePath {
+		lines = append(lines, "  override_path = true")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// hostCertName returns the name of the CA certificate file for a host URL.
+func hostCertName(hostURL string) string {
+	u, err := url.Parse(hostURL)
+	if err != nil || u.Host == "" {
+		return "ca.crt"
+	}
+	return strings.ReplaceAll(u.Host, ":", "_") + ".crt"
+}
+
+func isMirrorHost(hostURL, base string) bool {
+	u, err := url.Parse(hostURL)
+	return err == nil && u.Host == base
+}
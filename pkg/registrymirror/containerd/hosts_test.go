@@ -0,0 +1,149 @@
+package containerd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
+)
+
+func TestUsesHostsFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.RegistryMirrorConfiguration
+		want   bool
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   false,
+		},
+		{
+			name: "no hosts",
+			config: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+			},
+			want: false,
+		},
+		{
+			name: "with hosts",
+			config: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+				Hosts: []v1alpha1.RegistryHostsConfiguration{
+					{
+						Registry: "docker.io",
+						Hosts:    []v1alpha1.RegistryHost{{URL: "https://harbor.test"}},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(containerd.UsesHostsFiles(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestHostsFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.RegistryMirrorConfiguration
+		want   []containerd.HostsFile
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name: "oci namespaces with insecure skip",
+			config: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint:           "1.2.3.4",
+				Port:               "443",
+				InsecureSkipVerify: true,
+				OCINamespaces: []v1alpha1.OCINamespace{
+					{
+						Registry:  "public.ecr.aws",
+						Namespace: "eks-anywhere",
+					},
+					{
+						Registry:  "783794618700.dkr.ecr.us-west-2.amazonaws.com",
+						Namespace: "curated-packages",
+					},
+				},
+			},
+			want: []containerd.HostsFile{
+				{
+					Path: "/etc/containerd/certs.d/783794618700.dkr.ecr.us-west-2.amazonaws.com/hosts.toml",
+					Content: `[host."https://1.2.3.4:443/v2/curated-packages"]
+  capabilities = ["pull", "resolve"]
+  skip_verify = true
+  override_path = true`,
+				},
+				{
+					Path: "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+					Content: `[host."https://1.2.3.4:443/v2/eks-anywhere"]
+  capabilities = ["pull", "resolve"]
+  skip_verify = true
+  override_path = true`,
+				},
+			},
+		},
+		{
+			name: "hosts override oci namespaces",
+			config: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint:      "1.2.3.4",
+				Port:          "443",
+				CACertContent: "xyz",
+				OCINamespaces: []v1alpha1.OCINamespace{
+					{
+						Registry:  "public.ecr.aws",
+						Namespace: "eks-anywhere",
+					},
+				},
+				Hosts: []v1alpha1.RegistryHostsConfiguration{
+					{
+						Registry: "public.ecr.aws",
+						Hosts: []v1alpha1.RegistryHost{
+							{
+								URL:          "https://1.2.3.4:443/v2/eks-anywhere",
+								OverridePath: true,
+							},
+							{
+								URL:          "https://public.ecr.aws",
+								Capabilities: []v1alpha1.RegistryHostCapability{v1alpha1.RegistryHostPull},
+							},
+						},
+					},
+				},
+			},
+			want: []containerd.HostsFile{
+				{
+					Path:    "/etc/containerd/certs.d/1.2.3.4:443/ca.crt",
+					Content: "xyz",
+				},
+				{
+					Path: "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+					Content: `[host."https://1.2.3.4:443/v2/eks-anywhere"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"
+  override_path = true
+
+[host."https://public.ecr.aws"]
+  capabilities = ["pull"]`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(containerd.HostsFiles(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package retrier
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker tracks the failures of the calls to an API shared by multiple callers.
+// Once the failures in a time window exceed the failure budget, the circuit opens and the
+// retriers using it stop calling the API until the cooldown expires. After that, the next
+// failure opens the circuit again right away, while a success closes it.
+// A CircuitBreaker is safe for concurrent use.
+type CircuitBreaker struct {
+	failureBudget int
+	window        time.Duration
+	cooldown      time.Duration
+
+	lock      sync.Mutex
+	failures  []time.Time
+	openUntil time.Time
+	halfOpen  bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker builds a closed CircuitBreaker that opens for cooldown when more than
+// failureBudget failures happen in window.
+func NewCircuitBreaker(failureBudget int, window, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureBudget: failureBudget,
+		window:        window,
+		cooldown:      cooldown,
+		now:           time.Now,
+	}
+}
+
+// Open returns true if the API shouldn't be called.
+func (c *CircuitBreaker) Open() bool {
+	return c.waitTime() > 0
+}
+
+// waitTime returns how long callers need to wait before calling the API again.
+func (c *CircuitBreaker) waitTime() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.openUntil.IsZero() {
+		return 0
+	}
+
+	now := c.now()
+	if now.Before(c.openUntil) {
+		return c.openUntil.Sub(now)
+	}
+
+	c.openUntil = time.Time{}
+	c.halfOpen = true
+	return 0
+}
+
+// record registers the result of a call to the API.
+func (c *CircuitBreaker) record(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err == nil {
+		c.halfOpen = false
+		c.failures = nil
+		return
+	}
+
+	now := c.now()
+	if c.halfOpen {
+		c.open(now)
+		return
+	}
+
+	recent := c.failures[:0]
+	for _, f := range c.failures {
+		if now.Sub(f) < c.window {
+			recent = append(recent, f)
+		}
+	}
+	c.failures = append(recent, now)
+
+	if len(c.failures) > c.failureBudget {
+		c.open(now)
+	}
+}
+
+func (c *CircuitBreaker) open(now time.Time) {
+	c.openUntil = now.Add(c.cooldown)
+	c.halfOpen = false
+	c.failures = nil
+}
+
+const (
+	apiFailureBudget = 20
+	apiFailureWindow = time.Minute
+	apiCooldown      = 30 * time.Second
+	apiRetryJitter   = 0.2
+)
+
+// NewAPICircuitBreaker builds a CircuitBreaker with the default failure budget for the
+// infrastructure provider API clients.
+func NewAPICircuitBreaker() *CircuitBreaker {
+	return NewCircuitBreaker(apiFailureBudget, apiFailureWindow, apiCooldown)
+}
+
+// NewAPIRetrier builds a retrier for the calls to an infrastructure provider API. It retries
+// up to maxRetries times with a jittered backOffPeriod between retries and backs off while
+// the circuit breaker is open.
+func NewAPIRetrier(maxRetries int, backOffPeriod time.Duration, cb *CircuitBreaker) *Retrier {
+	return New(
+		time.Duration(math.MaxInt64),
+		WithMaxRetries(maxRetries, backOffPeriod),
+		WithJitter(apiRetryJitter),
+		WithCircuitBreaker(cb),
+	)
+}
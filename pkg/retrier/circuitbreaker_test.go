@@ -0,0 +1,98 @@
+package retrier_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestCircuitBreakerOpensAfterFailureBudget(t *testing.T) {
+	g := NewWithT(t)
+	cb := retrier.NewCircuitBreaker(2, time.Minute, time.Hour)
+	r := retrier.New(time.Minute, retrier.WithCircuitBreaker(cb), retrier.WithMaxRetries(3, 0))
+
+	calls := 0
+	err := r.Retry(func() error {
+		calls++
+		return errors.New("vcenter unavailable")
+	})
+	g.Expect(err).To(MatchError("vcenter unavailable"))
+	g.Expect(calls).To(Equal(3))
+	g.Expect(cb.Open()).To(BeTrue())
+
+	// Other retriers sharing the circuit breaker don't call the API while it's open.
+	other := retrier.New(time.Second, retrier.WithCircuitBreaker(cb))
+	err = other.Retry(func() error {
+		calls++
+		return nil
+	})
+	g.Expect(err).To(MatchError(retrier.ErrCircuitOpen))
+	g.Expect(calls).To(Equal(3))
+}
+
+func TestCircuitBreakerWaitsForCooldown(t *testing.T) {
+	g := NewWithT(t)
+	cb := retrier.NewCircuitBreaker(0, time.Minute, 50*time.Millisecond)
+	r := retrier.New(time.Minute, retrier.WithCircuitBreaker(cb))
+
+	calls := 0
+	start := time.Now()
+	err := r.Retry(func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("vcenter unavailable")
+		}
+		return nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal(2))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+	g.Expect(cb.Open()).To(BeFalse())
+}
+
+func TestCircuitBreakerReopensOnFailureAfterCooldown(t *testing.T) {
+	g := NewWithT(t)
+	cb := retrier.NewCircuitBreaker(1, time.Minute, 20*time.Millisecond)
+	opened := retrier.New(time.Minute, retrier.WithCircuitBreaker(cb), retrier.WithMaxRetries(2, 0))
+
+	g.Expect(opened.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	g.Expect(cb.Open()).To(BeTrue())
+	g.Eventually(cb.Open).Should(BeFalse())
+
+	// A single failure after the cooldown opens the circuit again.
+	halfOpen := retrier.New(time.Minute, retrier.WithCircuitBreaker(cb), retrier.WithMaxRetries(1, 0))
+	g.Expect(halfOpen.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	g.Expect(cb.Open()).To(BeTrue())
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	g := NewWithT(t)
+	cb := retrier.NewCircuitBreaker(1, time.Minute, time.Hour)
+	r := retrier.New(time.Minute, retrier.WithCircuitBreaker(cb), retrier.WithMaxRetries(2, 0))
+
+	calls := 0
+	g.Expect(r.Retry(func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	})).To(Succeed())
+	g.Expect(r.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	g.Expect(cb.Open()).To(BeTrue())
+}
+
+func TestRetrierWithJitter(t *testing.T) {
+	g := NewWithT(t)
+	r := retrier.New(time.Minute, retrier.WithMaxRetries(3, 10*time.Millisecond), retrier.WithJitter(0.5))
+
+	start := time.Now()
+	g.Expect(r.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	elapsed := time.Since(start)
+	g.Expect(elapsed).To(BeNumerically(">=", 10*time.Millisecond))
+	g.Expect(elapsed).To(BeNumerically("<", time.Second))
+}
@@ -0,0 +1,33 @@
+package retrier
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// defaultClock is the clock of the retriers built without WithClock.
+var defaultClock clock.Clock = clock.RealClock{}
+
+// UseFastRetries makes the retriers built from now on without WithClock wait factor times less
+// between retries. Timeouts are not shortened, so functions are retried more times before they
+// time out. It's a debug mode to iterate faster on failing operations, not meant for production.
+// A factor of 1 or less restores the real waits.
+func UseFastRetries(factor int) {
+	if factor <= 1 {
+		defaultClock = clock.RealClock{}
+		return
+	}
+
+	defaultClock = fastClock{factor: time.Duration(factor)}
+}
+
+// fastClock is a real clock that sleeps factor times less than asked.
+type fastClock struct {
+	clock.RealClock
+	factor time.Duration
+}
+
+func (c fastClock) Sleep(d time.Duration) {
+	c.RealClock.Sleep(d / c.factor)
+}
@@ -1,16 +1,27 @@
 package retrier
 
 import (
+	"errors"
 	"math"
+	"math/rand"
 	"time"
 
+	"k8s.io/utils/clock"
+
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
+// ErrCircuitOpen is returned when the circuit breaker of a retrier doesn't allow to call
+// the retried function before the retrier times out.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
 type Retrier struct {
-	retryPolicy   RetryPolicy
-	timeout       time.Duration
-	backoffFactor *float32
+	retryPolicy    RetryPolicy
+	timeout        time.Duration
+	backoffFactor  *float32
+	jitter         float64
+	circuitBreaker *CircuitBreaker
+	clock          clock.Clock
 }
 
 type (
@@ -26,6 +37,7 @@ func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
 	r := &Retrier{
 		timeout:     timeout,
 		retryPolicy: zeroWaitPolicy,
+		clock:       defaultClock,
 	}
 	for _, o := range opts {
 		o(r)
@@ -59,6 +71,32 @@ func WithBackoffFactor(factor float32) RetrierOpt {
 	}
 }
 
+// WithJitter randomizes the wait time between retries by up to plus or minus the given
+// fraction of it, so callers that failed at the same time don't retry at the same time.
+func WithJitter(fraction float64) RetrierOpt {
+	return func(r *Retrier) {
+		r.jitter = fraction
+	}
+}
+
+// WithCircuitBreaker makes the retrier wait while the circuit breaker is open instead of
+// calling the retried function. The same circuit breaker can be shared by all the retriers
+// calling the same API, so they back off together when the API is struggling.
+func WithCircuitBreaker(cb *CircuitBreaker) RetrierOpt {
+	return func(r *Retrier) {
+		r.circuitBreaker = cb
+	}
+}
+
+// WithClock sets the clock the retrier measures the timeout with and waits between retries
+// with. Tests can use a fake clock, whose sleeps advance its time right away, so timeouts and
+// back offs don't slow them down.
+func WithClock(c clock.Clock) RetrierOpt {
+	return func(r *Retrier) {
+		r.clock = c
+	}
+}
+
 func WithRetryPolicy(policy RetryPolicy) RetrierOpt {
 	return func(r *Retrier) {
 		r.retryPolicy = policy
@@ -76,15 +114,32 @@ func (r *Retrier) Retry(fn func() error) error {
 		return fn()
 	}
 
-	start := time.Now()
+	start := r.clock.Now()
 	retries := 0
 	var err error
 	logger.V(5).Info("Retrier:", "timeout", r.timeout, "backoffFactor", r.backoffFactor)
-	for retry := true; retry; retry = time.Since(start) < r.timeout {
+	for retry := true; retry; retry = r.clock.Since(start) < r.timeout {
+		if r.circuitBreaker != nil {
+			if wait := r.circuitBreaker.waitTime(); wait > 0 {
+				if start.Add(r.timeout).Before(r.clock.Now().Add(wait)) {
+					logger.V(5).Info("Circuit breaker open until after timeout. Returning error", "retries", retries)
+					if err == nil {
+						err = ErrCircuitOpen
+					}
+					return err
+				}
+				logger.V(5).Info("Circuit breaker open, sleeping before next call", "time", wait)
+				r.clock.Sleep(wait)
+			}
+		}
+
 		err = fn()
+		if r.circuitBreaker != nil {
+			r.circuitBreaker.record(err)
+		}
 		retries += 1
 		if err == nil {
-			logger.V(5).Info("Retry execution successful", "retries", retries, "duration", time.Since(start))
+			logger.V(5).Info("Retry execution successful", "retries", retries, "duration", r.clock.Since(start))
 			return nil
 		}
 		logger.V(5).Info("Error happened during retry", "error", err, "retries", retries)
@@ -97,20 +152,23 @@ func (r *Retrier) Retry(fn func() error) error {
 		if r.backoffFactor != nil {
 			wait = time.Duration(float32(wait) * (*r.backoffFactor * float32(retries)))
 		}
+		if r.jitter > 0 {
+			wait += time.Duration(float64(wait) * r.jitter * (2*rand.Float64() - 1))
+		}
 
 		// If there's not enough time left for the policy-proposed wait, there's no value in waiting that duration
 		// before quitting at the bottom of the loop.  Just do it now.
 		retrierTimeoutTime := start.Add(r.timeout)
-		policyTimeoutTime := time.Now().Add(wait)
+		policyTimeoutTime := r.clock.Now().Add(wait)
 		if retrierTimeoutTime.Before(policyTimeoutTime) {
 			break
 		}
 
 		logger.V(5).Info("Sleeping before next retry", "time", wait)
-		time.Sleep(wait)
+		r.clock.Sleep(wait)
 	}
 
-	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", time.Since(start), "error", err)
+	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", r.clock.Since(start), "error", err)
 
 	return err
 }
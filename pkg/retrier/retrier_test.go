@@ -6,6 +6,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	"github.com/aws/eks-anywhere/pkg/retrier"
 )
@@ -209,3 +210,58 @@ func TestBackOffPolicy(t *testing.T) {
 	g.Expect(retry).To(BeTrue())
 	g.Expect(gotBackOff).To(Equal(backOff))
 }
+
+func TestRetryWithClockFinishByTimeout(t *testing.T) {
+	g := NewWithT(t)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	r := retrier.New(time.Hour, retrier.WithRetryPolicy(retrier.BackOffPolicy(10*time.Minute)), retrier.WithClock(fakeClock))
+
+	start := fakeClock.Now()
+	gotRetries := 0
+	err := r.Retry(func() error {
+		gotRetries++
+		return errors.New("failed")
+	})
+
+	g.Expect(err).To(MatchError("failed"))
+	g.Expect(gotRetries).To(Equal(6))
+	g.Expect(fakeClock.Since(start)).To(Equal(time.Hour))
+}
+
+func TestRetryWithClockSuccessAfterBackOff(t *testing.T) {
+	g := NewWithT(t)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	r := retrier.New(time.Hour, retrier.WithMaxRetries(5, time.Minute), retrier.WithClock(fakeClock))
+
+	start := fakeClock.Now()
+	gotRetries := 0
+	err := r.Retry(func() error {
+		gotRetries++
+		if gotRetries < 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotRetries).To(Equal(3))
+	g.Expect(fakeClock.Since(start)).To(Equal(2 * time.Minute))
+}
+
+func TestUseFastRetries(t *testing.T) {
+	g := NewWithT(t)
+	retrier.UseFastRetries(1000)
+	t.Cleanup(func() { retrier.UseFastRetries(1) })
+
+	r := retrier.NewWithMaxRetries(3, time.Second)
+	start := time.Now()
+	gotRetries := 0
+	err := r.Retry(func() error {
+		gotRetries++
+		return errors.New("failed")
+	})
+
+	g.Expect(err).To(MatchError("failed"))
+	g.Expect(gotRetries).To(Equal(3))
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
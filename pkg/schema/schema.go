@@ -0,0 +1,187 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/config/crd"
+)
+
+const (
+	group   = "anywhere.eks.amazonaws.com"
+	version = "v1alpha1"
+
+	// APIVersion is the api version of the config kinds covered by the schemas.
+	APIVersion = group + "/" + version
+
+	jsonSchemaDraft = "http://json-schema.org/draft-04/schema#"
+)
+
+// Registry holds the OpenAPI schemas for the EKS Anywhere config kinds.
+type Registry struct {
+	schemas    map[string]*apiextensionsv1.JSONSchemaProps
+	validators map[string]*kindValidator
+}
+
+// Load builds a Registry from the CRD manifests embedded in the binary.
+func Load() (*Registry, error) {
+	return LoadFromFS(crd.Bases)
+}
+
+// LoadFromFS builds a Registry from all the CRD manifests in fsys.
+func LoadFromFS(fsys fs.FS) (*Registry, error) {
+	r := &Registry{
+		schemas:    map[string]*apiextensionsv1.JSONSchemaProps{},
+		validators: map[string]*kindValidator{},
+	}
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("reading CRD %s: %v", path, err)
+		}
+		if err := r.addCRDs(content); err != nil {
+			return fmt.Errorf("loading CRD %s: %v", path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Registry) addCRDs(content []byte) error {
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		d, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(d, crd); err != nil {
+			return err
+		}
+		if crd.Spec.Group != group {
+			continue
+		}
+
+		for _, v := range crd.Spec.Versions {
+			if v.Name != version || v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			validator, err := newKindValidator(v.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return fmt.Errorf("kind %s: %v", crd.Spec.Names.Kind, err)
+			}
+			r.schemas[crd.Spec.Names.Kind] = v.Schema.OpenAPIV3Schema
+			r.validators[crd.Spec.Names.Kind] = validator
+		}
+	}
+}
+
+// Kinds returns the sorted list of kinds with a schema.
+func (r *Registry) Kinds() []string {
+	kinds := make([]string, 0, len(r.schemas))
+	for k := range r.schemas {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// JSONSchema returns the JSON schema for kind. The apiVersion and kind fields are restricted
+// to the values of kind so the schema can be used to discriminate documents.
+func (r *Registry) JSONSchema(kind string) ([]byte, error) {
+	s, err := r.kindSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = jsonSchemaDraft
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// CombinedJSONSchema returns a JSON schema that accepts a document of any of the
+// registered kinds. It's meant to be used by editors to validate and autocomplete cluster
+// config files.
+func (r *Registry) CombinedJSONSchema() ([]byte, error) {
+	combined := &apiextensionsv1.JSONSchemaProps{
+		Schema:      jsonSchemaDraft,
+		Title:       "EKS Anywhere cluster config",
+		Description: "A document of any of the EKS Anywhere " + APIVersion + " config kinds.",
+	}
+	for _, kind := range r.Kinds() {
+		s, err := r.kindSchema(kind)
+		if err != nil {
+			return nil, err
+		}
+		combined.OneOf = append(combined.OneOf, *s)
+	}
+
+	return json.MarshalIndent(combined, "", "  ")
+}
+
+func (r *Registry) kindSchema(kind string) (*apiextensionsv1.JSONSchemaProps, error) {
+	s, ok := r.schemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("no schema for kind %s", kind)
+	}
+
+	s = s.DeepCopy()
+	// The CLI defaults most of the fields marked as required before validating the config, so
+	// flagging them as missing would reject valid configs.
+	removeRequired(s)
+	s.Title = kind
+	s.Required = []string{"apiVersion", "kind"}
+	if s.Properties == nil {
+		s.Properties = map[string]apiextensionsv1.JSONSchemaProps{}
+	}
+	s.Properties["apiVersion"] = enumProperty(s.Properties["apiVersion"], APIVersion)
+	s.Properties["kind"] = enumProperty(s.Properties["kind"], kind)
+
+	return s, nil
+}
+
+func enumProperty(p apiextensionsv1.JSONSchemaProps, value string) apiextensionsv1.JSONSchemaProps {
+	p.Type = "string"
+	p.Enum = []apiextensionsv1.JSON{{Raw: []byte(fmt.Sprintf("%q", value))}}
+	return p
+}
+
+func removeRequired(s *apiextensionsv1.JSONSchemaProps) {
+	s.Required = nil
+	for name, p := range s.Properties {
+		removeRequired(&p)
+		s.Properties[name] = p
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		removeRequired(s.Items.Schema)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		removeRequired(s.AdditionalProperties.Schema)
+	}
+}
@@ -0,0 +1,132 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+const testCRD = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.anywhere.eks.amazonaws.com
+spec:
+  group: anywhere.eks.amazonaws.com
+  names:
+    kind: Widget
+  versions:
+  - name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            type: string
+          kind:
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              size:
+                minimum: 1
+                type: integer
+              color:
+                enum:
+                - red
+                - blue
+                type: string
+              labels:
+                additionalProperties:
+                  type: string
+                type: object
+              extra:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+                properties:
+                  known:
+                    type: string
+            required:
+            - size
+            type: object
+        type: object
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: others.example.com
+spec:
+  group: example.com
+  names:
+    kind: Other
+  versions:
+  - name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        type: object
+`
+
+func testRegistry(t *testing.T) *schema.Registry {
+	t.Helper()
+	r, err := schema.LoadFromFS(fstest.MapFS{
+		"crds/widgets.yaml": {Data: []byte(testCRD)},
+		"README.md":         {Data: []byte("not a crd")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestLoad(t *testing.T) {
+	g := NewWithT(t)
+	r, err := schema.Load()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Kinds()).To(ContainElements("Cluster", "VSphereDatacenterConfig", "VSphereMachineConfig", "TinkerbellTemplateConfig"))
+}
+
+func TestLoadFromFSOnlyEKSAKinds(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(testRegistry(t).Kinds()).To(Equal([]string{"Widget"}))
+}
+
+func TestJSONSchema(t *testing.T) {
+	g := NewWithT(t)
+	content, err := testRegistry(t).JSONSchema("Widget")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := map[string]interface{}{}
+	g.Expect(json.Unmarshal(content, &s)).To(Succeed())
+	g.Expect(s["$schema"]).To(Equal("http://json-schema.org/draft-04/schema#"))
+	g.Expect(s["required"]).To(ConsistOf("apiVersion", "kind"))
+
+	properties := s["properties"].(map[string]interface{})
+	g.Expect(properties["kind"]).To(HaveKeyWithValue("enum", ConsistOf("Widget")))
+	g.Expect(properties["apiVersion"]).To(HaveKeyWithValue("enum", ConsistOf(schema.APIVersion)))
+	g.Expect(properties["spec"]).ToNot(HaveKey("required"))
+}
+
+func TestJSONSchemaUnknownKind(t *testing.T) {
+	g := NewWithT(t)
+	_, err := testRegistry(t).JSONSchema("Other")
+	g.Expect(err).To(MatchError("no schema for kind Other"))
+}
+
+func TestCombinedJSONSchema(t *testing.T) {
+	g := NewWithT(t)
+	r, err := schema.Load()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	content, err := r.CombinedJSONSchema()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := map[string]interface{}{}
+	g.Expect(json.Unmarshal(content, &s)).To(Succeed())
+	g.Expect(s["oneOf"]).To(HaveLen(len(r.Kinds())))
+}
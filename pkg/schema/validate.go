@@ -0,0 +1,307 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	fieldvalidation "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// FieldError is a schema violation of a field in a config document.
+type FieldError struct {
+	// Kind and Name identify the document the field belongs to.
+	Kind string
+	Name string
+	// Line is the line in the input where the invalid field is defined.
+	Line int
+	// Field is the path to the field, ex. spec.workerNodeGroupConfigurations[0].count.
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("line %d: %s %s: %s: %s", e.Line, e.Kind, e.Name, e.Field, e.Message)
+}
+
+// ValidationError contains all the schema violations found in a config.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+
+	return fmt.Sprintf("cluster config doesn't match the schema:\n%s", strings.Join(msgs, "\n"))
+}
+
+// kindValidator validates the documents of a kind the same way the API server validates the
+// custom resources: with the OpenAPI schema validator and by pruning the unknown fields.
+type kindValidator struct {
+	schema     *validate.SchemaValidator
+	structural *structuralschema.Structural
+}
+
+func newKindValidator(s *apiextensionsv1.JSONSchemaProps) (*kindValidator, error) {
+	s = s.DeepCopy()
+	// The CLI defaults most of the fields marked as required after validating the config.
+	removeRequired(s)
+
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(s, internal, nil); err != nil {
+		return nil, fmt.Errorf("converting schema: %v", err)
+	}
+
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		return nil, fmt.Errorf("building structural schema: %v", err)
+	}
+
+	schemaValidator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internal})
+	if err != nil {
+		return nil, fmt.Errorf("building schema validator: %v", err)
+	}
+
+	return &kindValidator{schema: schemaValidator, structural: structural}, nil
+}
+
+// Validate validates all the documents in the multi document yaml content that have a
+// registered schema. Documents of other kinds or api versions are ignored. It returns a
+// *ValidationError if any of the documents is not valid.
+func (r *Registry) Validate(content []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	var fieldErrors []FieldError
+	for {
+		doc := &yaml.Node{}
+		err := decoder.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("parsing cluster config: %v", err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+
+		root := resolve(doc.Content[0])
+		if root.Kind != yaml.MappingNode || scalarField(root, "apiVersion") != APIVersion {
+			continue
+		}
+
+		kind := scalarField(root, "kind")
+		v, ok := r.validators[kind]
+		if !ok {
+			continue
+		}
+
+		name := ""
+		if metadata := field(root, "metadata"); metadata != nil {
+			name = scalarField(metadata, "name")
+		}
+
+		obj, err := toObject(root, v.structural)
+		if err != nil {
+			return fmt.Errorf("parsing cluster config: %v", err)
+		}
+
+		invalid := validation.ValidateCustomResource(nil, obj, v.schema)
+		for _, e := range invalid {
+			fieldErrors = append(fieldErrors, FieldError{
+				Kind:    kind,
+				Name:    name,
+				Line:    line(root, e.Field),
+				Field:   e.Field,
+				Message: e.ErrorBody(),
+			})
+		}
+
+		unknown := pruning.PruneWithOptions(obj, v.structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+		for _, path := range unknown {
+			if underInvalidField(path, invalid) {
+				continue
+			}
+			fieldErrors = append(fieldErrors, FieldError{
+				Kind:    kind,
+				Name:    name,
+				Line:    line(root, path),
+				Field:   path,
+				Message: "unknown field",
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Errors: fieldErrors}
+	}
+
+	return nil
+}
+
+// toObject converts node to the unstructured object the API server would validate. Null values
+// are dropped, same as if the field wasn't set, and scalars are kept as strings where s expects a
+// string since the CLI converts numbers and booleans to strings when parsing the config.
+func toObject(node *yaml.Node, s *structuralschema.Structural) (interface{}, error) {
+	node = resolve(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		obj := map[string]interface{}{}
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key, value := node.Content[i].Value, resolve(node.Content[i+1])
+			if isNull(value) {
+				continue
+			}
+			v, err := toObject(value, propertySchema(s, key))
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = v
+		}
+		return obj, nil
+	case yaml.SequenceNode:
+		var items *structuralschema.Structural
+		if s != nil {
+			items = s.Items
+		}
+		list := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			v, err := toObject(item, items)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+
+	if s != nil && s.Type == "string" && !s.XIntOrString && node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return nil, err
+	}
+	// The schema validator expects the numbers as decoded from JSON.
+	if i, ok := v.(int); ok {
+		return int64(i), nil
+	}
+	return v, nil
+}
+
+// underInvalidField returns true if path is a field of one of the invalid fields, which are
+// reported already.
+func underInvalidField(path string, invalid fieldvalidation.ErrorList) bool {
+	for _, e := range invalid {
+		if rest, ok := strings.CutPrefix(path, e.Field); ok && rest != "" && (rest[0] == '.' || rest[0] == '[') {
+			return true
+		}
+	}
+	return false
+}
+
+func propertySchema(s *structuralschema.Structural, key string) *structuralschema.Structural {
+	if s == nil {
+		return nil
+	}
+	if p, ok := s.Properties[key]; ok {
+		return &p
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Structural
+	}
+	return nil
+}
+
+// line returns the line where the field at path is defined in node, or the line of the closest
+// parent found if the field isn't.
+func line(node *yaml.Node, path string) int {
+	for path != "" {
+		node = resolve(node)
+		next, rest := child(node, path)
+		if next == nil {
+			break
+		}
+		node, path = next, rest
+	}
+	return node.Line
+}
+
+// child returns the child of node the path starts with and the rest of the path. Map keys might
+// contain dots, so the longest key matching the path is used.
+func child(node *yaml.Node, path string) (*yaml.Node, string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var match *yaml.Node
+		var rest string
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key := node.Content[i].Value
+			r, ok := strings.CutPrefix(path, key)
+			if !ok || (r != "" && r[0] != '.' && r[0] != '[') || (match != nil && len(r) >= len(rest)) {
+				continue
+			}
+			match, rest = node.Content[i], r
+			if r == "" {
+				// Point to the key of the invalid field.
+				return match, ""
+			}
+			match = node.Content[i+1]
+		}
+		return match, strings.TrimPrefix(rest, ".")
+	case yaml.SequenceNode:
+		if !strings.HasPrefix(path, "[") {
+			return nil, ""
+		}
+		end := strings.Index(path, "]")
+		if end < 0 {
+			return nil, ""
+		}
+		i, err := strconv.Atoi(path[1:end])
+		if err != nil || i < 0 || i >= len(node.Content) {
+			return nil, ""
+		}
+		return node.Content[i], strings.TrimPrefix(path[end+1:], ".")
+	}
+	return nil, ""
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// resolve follows aliases so anchors are validated as if they were inlined.
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func field(node *yaml.Node, name string) *yaml.Node {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == name {
+			return resolve(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func scalarField(node *yaml.Node, name string) string {
+	if f := field(node, name); f != nil && f.Kind == yaml.ScalarNode {
+		return f.Value
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+func TestValidateValidConfig(t *testing.T) {
+	g := NewWithT(t)
+	r, err := schema.Load()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	content, err := os.ReadFile("testdata/valid_cluster.yaml")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(r.Validate(content)).To(Succeed())
+}
+
+func TestValidateInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	r, err := schema.Load()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	content, err := os.ReadFile("testdata/invalid_cluster.yaml")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = r.Validate(content)
+	validationErr := &schema.ValidationError{}
+	g.Expect(errors.As(err, &validationErr)).To(BeTrue())
+	g.Expect(validationErr.Errors).To(ConsistOf(
+		schema.FieldError{
+			Kind: "Cluster", Name: "my-cluster", Line: 7,
+			Field: "spec.controlPlaneConfiguration.count", Message: `Invalid value: "string": spec.controlPlaneConfiguration.count in body must be of type integer: "string"`,
+		},
+		schema.FieldError{
+			Kind: "Cluster", Name: "my-cluster", Line: 14,
+			Field: "spec.workerNodeGroupConfigurations[0].taints", Message: `Invalid value: "string": spec.workerNodeGroupConfigurations[0].taints in body must be of type array: "string"`,
+		},
+		schema.FieldError{
+			Kind: "VSphereMachineConfig", Name: "my-cluster-cp", Line: 21,
+			Field: "spec.numCPU", Message: "unknown field",
+		},
+	))
+	g.Expect(err).To(MatchError(ContainSubstring("line 21: VSphereMachineConfig my-cluster-cp: spec.numCPU: unknown field")))
+}
+
+func TestValidateConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: "size: 2\n  color: red\n  labels:\n    a: b\n  extra:\n    anything: 1",
+		},
+		{
+			name: "null values are ignored",
+			spec: "size:\n  color: null",
+		},
+		{
+			name:    "minimum",
+			spec:    "size: 0",
+			wantErr: "line 6: Widget w: spec.size: Invalid value: 0: spec.size in body should be greater than or equal to 1",
+		},
+		{
+			name:    "enum",
+			spec:    "color: green",
+			wantErr: `line 6: Widget w: spec.color: Unsupported value: "green": supported values: "red", "blue"`,
+		},
+		{
+			name:    "additional properties",
+			spec:    "labels:\n    a:\n      b: c",
+			wantErr: `line 7: Widget w: spec.labels.a: Invalid value: "object": spec.labels.a in body must be of type string: "object"`,
+		},
+		{
+			name:    "preserve unknown fields still validates known fields",
+			spec:    "extra:\n    known: [a]",
+			wantErr: `line 7: Widget w: spec.extra.known: Invalid value: "array": spec.extra.known in body must be of type string: "array"`,
+		},
+		{
+			name: "scalars are accepted for strings",
+			spec: "size: 1\n  color: red\n  labels:\n    a: 1.20\n    b: true",
+		},
+		{
+			name:    "unknown field",
+			spec:    "size: 1\n  shape: round",
+			wantErr: "line 7: Widget w: spec.shape: unknown field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			content := "apiVersion: anywhere.eks.amazonaws.com/v1alpha1\nkind: Widget\nmetadata:\n  name: w\nspec:\n  " + tt.spec + "\n"
+
+			err := testRegistry(t).Validate([]byte(content))
+			if tt.wantErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateInvalidYaml(t *testing.T) {
+	g := NewWithT(t)
+	err := testRegistry(t).Validate([]byte("kind: [Widget"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing cluster config")))
+}
@@ -0,0 +1,157 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+const (
+	crdEstablishedRequeue = 5 * time.Second
+	// handoffRequeue gives time to the new controller to roll out and take over the leader lease. This
+	// controller only requeues in case the rollout fails and it keeps running.
+	handoffRequeue = time.Minute
+)
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// ComponentsGenerator generates the eks-a components for a cluster spec.
+type ComponentsGenerator interface {
+	Objects(spec *cluster.Spec) (*clustermanager.EKSAComponentObjects, error)
+}
+
+// Reconciler upgrades the eks-a components of a self-managed cluster from within the running
+// eks-anywhere controller, when the bundle of the cluster has a newer eks-a version.
+type Reconciler struct {
+	client    client.Client
+	generator ComponentsGenerator
+	version   string
+}
+
+// New returns a new Reconciler. version is the version of the running controller.
+func New(client client.Client, generator ComponentsGenerator, version string) *Reconciler {
+	return &Reconciler{
+		client:    client,
+		generator: generator,
+		version:   version,
+	}
+}
+
+// Reconcile applies the eks-a components of the cluster bundle if their version is newer than the
+// running controller. The CRDs are applied first and the controller Deployment last, once the new
+// CRDs are established, so the new controller never runs against old CRDs. After that, it stops the
+// reconciliation so this controller doesn't reconcile the cluster with a bundle it might not support
+// and the new controller takes over once it acquires the leader lease.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywherev1.Cluster) (controller.Result, error) {
+	if !c.IsSelfUpgradeEnabled() {
+		return controller.Result{}, nil
+	}
+
+	spec, err := cluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), c)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	target := spec.RootVersionsBundle().Eksa.Version
+	if target == r.version {
+		return controller.Result{}, nil
+	}
+
+	running, err := semver.New(r.version)
+	if err != nil {
+		log.Info("Skipping EKS-A components self-upgrade, the running controller version is not a valid semver", "version", r.version)
+		return controller.Result{}, nil
+	}
+
+	targetVersion, err := semver.New(target)
+	if err != nil {
+		return controller.Result{}, fmt.Errorf("parsing eks-a version in bundle: %v", err)
+	}
+
+	if !targetVersion.GreaterThan(running) {
+		log.Info("Skipping EKS-A components self-upgrade, downgrades are not supported", "runningVersion", r.version, "bundleVersion", target)
+		return controller.Result{}, nil
+	}
+
+	components, err := r.generator.Objects(spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Upgrading EKS-A components", "fromVersion", r.version, "toVersion", target)
+
+	if err = serverside.ReconcileObjects(ctx, r.client, components.CRDs); err != nil {
+		return controller.Result{}, fmt.Errorf("applying eks-a CRDs: %v", err)
+	}
+
+	established, err := r.crdsEstablished(ctx, components.CRDs)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if !established {
+		log.Info("Waiting for EKS-A CRDs to be established")
+		return controller.ResultWithRequeue(crdEstablishedRequeue), nil
+	}
+
+	if err = serverside.ReconcileObjects(ctx, r.client, components.Objects); err != nil {
+		return controller.Result{}, fmt.Errorf("applying eks-a components: %v", err)
+	}
+
+	if err = serverside.ReconcileObject(ctx, r.client, components.Deployment); err != nil {
+		return controller.Result{}, fmt.Errorf("applying eks-a controller deployment: %v", err)
+	}
+
+	log.Info("New EKS-A controller deployed, handing off reconciliation", "version", target)
+
+	return controller.ResultWithRequeue(handoffRequeue), nil
+}
+
+func (r *Reconciler) crdsEstablished(ctx context.Context, crds []client.Object) (bool, error) {
+	for _, o := range crds {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		err := r.client.Get(ctx, client.ObjectKey{Name: o.GetName()}, crd)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("reading CRD %s: %v", o.GetName(), err)
+		}
+
+		if !isEstablished(crd) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func isEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Established" && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
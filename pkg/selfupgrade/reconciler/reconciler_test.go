@@ -0,0 +1,217 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/selfupgrade/reconciler"
+	"github.com/aws/eks-anywhere/pkg/selfupgrade/reconciler/mocks"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx       context.Context
+	cluster   *anywherev1.Cluster
+	generator *mocks.MockComponentsGenerator
+	objs      []client.Object
+}
+
+func newReconcilerTest(t *testing.T, bundleVersion string) *reconcilerTest {
+	bundle := test.Bundle()
+	bundle.Spec.VersionsBundles[0].Eksa.Version = bundleVersion
+	version := test.DevEksaVersion()
+
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mgmt",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube119,
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+			ManagementControllers: &anywherev1.ManagementControllersConfiguration{
+				SelfUpgrade: true,
+			},
+		},
+	}
+
+	return &reconcilerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		cluster:   cluster,
+		generator: mocks.NewMockComponentsGenerator(gomock.NewController(t)),
+		objs: []client.Object{
+			cluster,
+			bundle,
+			test.EksdRelease("1-19"),
+			test.EKSARelease(),
+		},
+	}
+}
+
+func (tt *reconcilerTest) client() client.Client {
+	return fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+}
+
+func crd(established bool) *unstructured.Unstructured {
+	c := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": "clusters.anywhere.eks.amazonaws.com",
+			},
+		},
+	}
+	if established {
+		c.Object["status"] = map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Established", "status": "True"},
+			},
+		}
+	}
+	return c
+}
+
+func components() *clustermanager.EKSAComponentObjects {
+	return &clustermanager.EKSAComponentObjects{
+		CRDs: []client.Object{crd(false)},
+		Objects: []client.Object{
+			&corev1.ServiceAccount{
+				TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eksa-controller-manager",
+					Namespace: "eksa-system",
+				},
+			},
+		},
+		Deployment: &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eksa-controller-manager",
+				Namespace: "eksa-system",
+			},
+		},
+	}
+}
+
+func TestReconcilerReconcileSelfUpgradeDisabled(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	tt.cluster.Spec.ManagementControllers = nil
+	r := reconciler.New(tt.client(), tt.generator, "v0.1.0")
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileSameVersion(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.1.0")
+	r := reconciler.New(tt.client(), tt.generator, "v0.1.0")
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileDowngrade(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.1.0")
+	r := reconciler.New(tt.client(), tt.generator, "v0.2.0")
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileInvalidRunningVersion(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	r := reconciler.New(tt.client(), tt.generator, "dev")
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileInvalidBundleVersion(t *testing.T) {
+	tt := newReconcilerTest(t, "latest")
+	r := reconciler.New(tt.client(), tt.generator, "v0.1.0")
+
+	_, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing eks-a version in bundle")))
+}
+
+func TestReconcilerReconcileGeneratorError(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	r := reconciler.New(tt.client(), tt.generator, "v0.1.0")
+	tt.generator.EXPECT().Objects(gomock.Any()).Return(nil, errors.New("reading manifest"))
+
+	_, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading manifest")))
+}
+
+func TestReconcilerReconcileWaitForCRDs(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	tt.objs = append(tt.objs, crd(false))
+	c := tt.client()
+	r := reconciler.New(c, tt.generator, "v0.1.0")
+	tt.generator.EXPECT().Objects(gomock.Any()).Return(components(), nil)
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+
+	deployment := &appsv1.Deployment{}
+	err = c.Get(tt.ctx, client.ObjectKey{Name: "eksa-controller-manager", Namespace: "eksa-system"}, deployment)
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the deployment shouldn't be applied before the CRDs are established")
+}
+
+func TestReconcilerReconcileUpgrade(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	current := components()
+	current.Deployment.Labels = map[string]string{"version": "v0.1.0"}
+	tt.objs = append(tt.objs, crd(true), current.Objects[0], current.Deployment)
+	c := tt.client()
+	r := reconciler.New(c, tt.generator, "v0.1.0")
+	target := components()
+	target.Deployment.Labels = map[string]string{"version": "v0.2.0"}
+	tt.generator.EXPECT().Objects(gomock.Any()).Return(target, nil)
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(time.Minute)))
+
+	deployment := &appsv1.Deployment{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "eksa-controller-manager", Namespace: "eksa-system"}, deployment)).To(Succeed())
+	tt.Expect(deployment.Labels).To(HaveKeyWithValue("version", "v0.2.0"))
+}
+
+func TestReconcilerReconcileApplyDeploymentError(t *testing.T) {
+	tt := newReconcilerTest(t, "v0.2.0")
+	tt.objs = append(tt.objs, crd(true), components().Objects[0])
+	r := reconciler.New(tt.client(), tt.generator, "v0.1.0")
+	tt.generator.EXPECT().Objects(gomock.Any()).Return(components(), nil)
+
+	_, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("applying eks-a controller deployment")))
+}
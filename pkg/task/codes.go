@@ -0,0 +1,48 @@
+package task
+
+import eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+
+// taskErrorCodes are the codes of the errors returned by each task, by task name. Tasks
+// can return errors with a more specific code, which are kept.
+var taskErrorCodes = map[string]eksaerrors.Code{
+	"setup-validate":                              eksaerrors.CodeValidation,
+	"setup-and-validate":                          eksaerrors.CodeValidation,
+	"setup-and-validate-management-components":    eksaerrors.CodeValidation,
+	"validate-clock-skew":                         eksaerrors.CodeClockSkew,
+	"bootstrap-cluster-init":                      eksaerrors.CodeBootstrapCluster,
+	"delete-kind-cluster":                         eksaerrors.CodeBootstrapCluster,
+	"kind-cluster-delete":                         eksaerrors.CodeBootstrapCluster,
+	"management-cluster-init":                     eksaerrors.CodeBootstrapCluster,
+	"install-capi":                                eksaerrors.CodeCAPIInstall,
+	"ensure-etcd-capi-components-exist":           eksaerrors.CodeCAPIInstall,
+	"ensure-management-components-etcd-providers": eksaerrors.CodeCAPIInstall,
+	"capi-management-move":                        eksaerrors.CodeCAPIMove,
+	"capi-management-move-to-bootstrap":           eksaerrors.CodeCAPIMove,
+	"capi-management-move-to-workload":            eksaerrors.CodeCAPIMove,
+	"cluster-management-move":                     eksaerrors.CodeCAPIMove,
+	"workload-cluster-init":                       eksaerrors.CodeClusterCreate,
+	"upgrade-needed":                              eksaerrors.CodeClusterUpgrade,
+	"upgrade-workload-cluster":                    eksaerrors.CodeClusterUpgrade,
+	"pre-cluster-upgrade":                         eksaerrors.CodeClusterUpgrade,
+	"post-cluster-upgrade":                        eksaerrors.CodeClusterUpgrade,
+	"delete-workload-cluster":                     eksaerrors.CodeClusterDelete,
+	"eksa-components-install":                     eksaerrors.CodeComponents,
+	"install-resources-on-management-cluster":     eksaerrors.CodeComponents,
+	"upgrade-core-components":                     eksaerrors.CodeComponents,
+	"install-new-eksa-version-components":         eksaerrors.CodeComponents,
+	"upgrade-management-components":               eksaerrors.CodeComponents,
+	"migrate-management-components-crds":          eksaerrors.CodeComponents,
+	"gitops-manager-install":                      eksaerrors.CodeGitOps,
+	"clean-up-git-repo":                           eksaerrors.CodeGitOps,
+	"register-control-plane-dns":                  eksaerrors.CodeEndpointDNS,
+	"package-resource-delete":                     eksaerrors.CodePackages,
+}
+
+// withTaskErrorCode annotates the error of a failed task with the task's code.
+func withTaskErrorCode(taskName string, err error) error {
+	code, ok := taskErrorCodes[taskName]
+	if !ok {
+		return err
+	}
+	return eksaerrors.WithCode(code, err)
+}
@@ -25,6 +25,16 @@ type Task interface {
 	Restore(ctx context.Context, commandContext *CommandContext, completedTask *CompletedTask) (Task, error)
 }
 
+// ProgressTask is a Task that saves its partial progress when it fails, so the next run resumes it
+// from where it stopped instead of running it from scratch.
+type ProgressTask interface {
+	Task
+	// Progress returns the partial progress of the task. It's only called after the task fails.
+	Progress() *CompletedTask
+	// ResumeProgress restores the progress saved by a previous run. It's called before Run.
+	ResumeProgress(progress *CompletedTask) error
+}
+
 // Command context maintains the mutable and shared entities.
 type CommandContext struct {
 	Bootstrapper              interfaces.Bootstrapper
@@ -38,6 +48,10 @@ type CommandContext struct {
 	EksdUpgrader              interfaces.EksdUpgrader
 	ClusterUpgrader           interfaces.ClusterUpgrader
 	CAPIManager               interfaces.CAPIManager
+	WorkloadBackup            interfaces.WorkloadBackup
+	ClockSkewValidator        interfaces.ClockSkewValidator
+	DNSRegistrar              interfaces.DNSRegistrar
+	CRDStorageMigrator        interfaces.CRDStorageMigrator
 	ClusterSpec               *cluster.Spec
 	CurrentClusterSpec        *cluster.Spec
 	UpgradeChangeDiff         *types.ChangeDiff
@@ -126,7 +140,7 @@ func WithCheckpointFile() TaskRunnerOpt {
 }
 
 func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContext) error {
-	checkpointFileName := fmt.Sprintf("%s-checkpoint.yaml", commandContext.ClusterSpec.Cluster.Name)
+	checkpointFileName := CheckpointFileName(commandContext.ClusterSpec.Cluster.Name)
 	var checkpointInfo CheckpointInfo
 	var err error
 
@@ -154,13 +168,22 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 			task = nextTask
 			continue
 		}
+		if err := tr.resumeProgress(task, checkpointInfo); err != nil {
+			return err
+		}
 		logger.V(4).Info("Task start", "task_name", task.Name())
 		commandContext.Profiler.SetStartTask(task.Name())
+		failed := commandContext.OriginalError != nil
 		nextTask := task.Run(ctx, commandContext)
+		if !failed && commandContext.OriginalError != nil {
+			commandContext.OriginalError = withTaskErrorCode(task.Name(), commandContext.OriginalError)
+		}
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
 		if commandContext.OriginalError == nil {
 			checkpointInfo.taskCompleted(task.Name(), task.Checkpoint())
+		} else if !failed {
+			checkpointInfo.taskFailed(task)
 		}
 		task = nextTask
 	}
@@ -172,6 +195,12 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	return commandContext.OriginalError
 }
 
+// CheckpointFileName returns the name of the file, relative to the writer's temp dir, used to
+// store the checkpoint of a failed run for clusterName.
+func CheckpointFileName(clusterName string) string {
+	return fmt.Sprintf("%s-checkpoint.yaml", clusterName)
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
@@ -188,6 +217,22 @@ func NewTaskRunner(task Task, writer filewriter.FileWriter, opts ...TaskRunnerOp
 	return t
 }
 
+func (tr *taskRunner) resumeProgress(task Task, checkpointInfo CheckpointInfo) error {
+	progressTask, ok := task.(ProgressTask)
+	if !ok {
+		return nil
+	}
+	progress, ok := checkpointInfo.InProgressTasks[task.Name()]
+	if !ok {
+		return nil
+	}
+	logger.V(4).Info("Resuming task progress", "task_name", task.Name())
+	if err := progressTask.ResumeProgress(progress); err != nil {
+		return fmt.Errorf("resuming task progress: %v", err)
+	}
+	return nil
+}
+
 func (tr *taskRunner) saveCheckpoint(checkpointInfo CheckpointInfo, filename string) error {
 	logger.V(4).Info("Saving checkpoint", "file", filename)
 	content, err := yaml.Marshal(checkpointInfo)
@@ -211,6 +256,9 @@ func (tr *taskRunner) setupCheckpointInfo(commandContext *CommandContext, checkp
 				return checkpointInfo, err
 			}
 			checkpointInfo.CompletedTasks = checkpointFile.CompletedTasks
+			if checkpointFile.InProgressTasks != nil {
+				checkpointInfo.InProgressTasks = checkpointFile.InProgressTasks
+			}
 		}
 	}
 	return checkpointInfo, nil
@@ -220,6 +268,8 @@ type TaskCheckpoint interface{}
 
 type CheckpointInfo struct {
 	CompletedTasks map[string]*CompletedTask `json:"completedTasks"`
+	// InProgressTasks holds the partial progress of the ProgressTasks that failed.
+	InProgressTasks map[string]*CompletedTask `json:"inProgressTasks,omitempty"`
 }
 
 type CompletedTask struct {
@@ -228,12 +278,24 @@ type CompletedTask struct {
 
 func newCheckpointInfo() CheckpointInfo {
 	return CheckpointInfo{
-		CompletedTasks: make(map[string]*CompletedTask),
+		CompletedTasks:  make(map[string]*CompletedTask),
+		InProgressTasks: make(map[string]*CompletedTask),
 	}
 }
 
 func (c CheckpointInfo) taskCompleted(name string, completedTask *CompletedTask) {
 	c.CompletedTasks[name] = completedTask
+	delete(c.InProgressTasks, name)
+}
+
+func (c CheckpointInfo) taskFailed(task Task) {
+	progressTask, ok := task.(ProgressTask)
+	if !ok {
+		return
+	}
+	if progress := progressTask.Progress(); progress != nil {
+		c.InProgressTasks[task.Name()] = progress
+	}
 }
 
 func readCheckpointFile(file string) (*CheckpointInfo, error) {
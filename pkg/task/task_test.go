@@ -2,15 +2,20 @@ package task_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
@@ -167,6 +172,96 @@ func TestTaskRunnerRunTaskWithCheckpointReadFailure(t *testing.T) {
 	}
 }
 
+func TestTaskRunnerRunTaskErrorCode(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	failed := errors.New("kind create failed")
+
+	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(failed)
+		return tt.taskB
+	})
+	tt.taskA.EXPECT().Name().Return("bootstrap-cluster-init").AnyTimes()
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("collecting diagnostics failed"))
+		return nil
+	})
+	tt.taskB.EXPECT().Name().Return("delete-workload-cluster").AnyTimes()
+
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any())
+
+	err := task.NewTaskRunner(tt.taskA, tt.writer).RunTask(tt.ctx, tt.cmdContext)
+	if !errors.Is(err, failed) {
+		t.Fatalf("Task.RunTask err = %v, want %v", err, failed)
+	}
+	if code := eksaerrors.CodeOf(err); code != eksaerrors.CodeBootstrapCluster {
+		t.Fatalf("Task.RunTask error code = %s, want %s", code, eksaerrors.CodeBootstrapCluster)
+	}
+}
+
+func TestTaskRunnerRunTaskErrorCodeFromTask(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+
+	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(eksaerrors.WithCode(eksaerrors.CodeVSphereSetup, errors.New("datacenter not found")))
+		return nil
+	})
+	tt.taskA.EXPECT().Name().Return("setup-validate").AnyTimes()
+
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any())
+
+	err := task.NewTaskRunner(tt.taskA, tt.writer).RunTask(tt.ctx, tt.cmdContext)
+	if code := eksaerrors.CodeOf(err); code != eksaerrors.CodeVSphereSetup {
+		t.Fatalf("Task.RunTask error code = %s, want %s", code, eksaerrors.CodeVSphereSetup)
+	}
+}
+
+func TestTaskRunnerRunTaskSavesProgressOfFailedTask(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	progressTask := &fakeProgressTask{
+		run: func(c *task.CommandContext) {
+			c.SetError(errors.New("installing step-b"))
+		},
+		progress: []string{"step-a"},
+	}
+
+	var checkpoint []byte
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any()).DoAndReturn(
+		func(_ string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			checkpoint = content
+			return "", nil
+		},
+	)
+
+	if err := task.NewTaskRunner(progressTask, tt.writer).RunTask(tt.ctx, tt.cmdContext); err == nil {
+		t.Fatal("Task.RunTask want err, got nil")
+	}
+
+	want := "inProgressTasks:\n  progress-task:\n    checkpoint:\n      completed:\n      - step-a\n"
+	if !strings.Contains(string(checkpoint), want) {
+		t.Fatalf("checkpoint = %s, want it to contain %s", checkpoint, want)
+	}
+}
+
+func TestTaskRunnerRunTaskWithCheckpointResumesProgress(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	tt.cmdContext.ClusterSpec.Cluster.Name = "progress-cluster"
+	tt.writer.EXPECT().TempDir().Return("testdata")
+	progressTask := &fakeProgressTask{}
+
+	t.Setenv(features.CheckpointEnabledEnvVar, "true")
+	runner := task.NewTaskRunner(progressTask, tt.cmdContext.Writer, task.WithCheckpointFile())
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err != nil {
+		t.Fatal(err)
+	}
+
+	if !progressTask.ran {
+		t.Fatal("task didn't run")
+	}
+	if want := []string{"step-a"}; !reflect.DeepEqual(progressTask.resumed, want) {
+		t.Fatalf("resumed progress = %v, want %v", progressTask.resumed, want)
+	}
+}
+
 func TestUnmarshalTaskCheckpointSuccess(t *testing.T) {
 	testConfigType := types.Cluster{}
 	testTaskCheckpoint := types.Cluster{
@@ -180,6 +275,50 @@ func TestUnmarshalTaskCheckpointSuccess(t *testing.T) {
 	}
 }
 
+type fakeProgressTask struct {
+	run      func(*task.CommandContext)
+	progress []string
+	resumed  []string
+	ran      bool
+}
+
+type fakeProgress struct {
+	Completed []string `json:"completed"`
+}
+
+func (f *fakeProgressTask) Run(_ context.Context, c *task.CommandContext) task.Task {
+	f.ran = true
+	if f.run != nil {
+		f.run(c)
+	}
+	return nil
+}
+
+func (f *fakeProgressTask) Name() string {
+	return "progress-task"
+}
+
+func (f *fakeProgressTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{}
+}
+
+func (f *fakeProgressTask) Restore(context.Context, *task.CommandContext, *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (f *fakeProgressTask) Progress() *task.CompletedTask {
+	return &task.CompletedTask{Checkpoint: fakeProgress{Completed: f.progress}}
+}
+
+func (f *fakeProgressTask) ResumeProgress(progress *task.CompletedTask) error {
+	p := &fakeProgress{}
+	if err := task.UnmarshalTaskCheckpoint(progress.Checkpoint, p); err != nil {
+		return err
+	}
+	f.resumed = p.Completed
+	return nil
+}
+
 type taskRunnerTest struct {
 	ctx        context.Context
 	cmdContext *task.CommandContext
@@ -0,0 +1,41 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// Dialer opens network connections.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+const bgpPort = 179
+
+// ValidateBGPPeers checks that every MetalLB BGP peer accepts TCP connections on the BGP port.
+// A misconfigured peer otherwise only surfaces after the cluster is created, as Service addresses
+// that are never announced. The connections are opened from the machine running the CLI, which
+// should share the network setup of the nodes. No BGP session is established, since peers only
+// accept sessions from the configured neighbors, which are the cluster nodes.
+func ValidateBGPPeers(ctx context.Context, dialer Dialer, spec *cluster.Spec) error {
+	metalLB := spec.Cluster.Spec.MetalLB
+	if metalLB == nil || metalLB.Mode != v1alpha1.MetalLBBGPMode {
+		return nil
+	}
+
+	for _, peer := range metalLB.BGPPeers {
+		address := net.JoinHostPort(peer.PeerAddress, strconv.Itoa(bgpPort))
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("connecting to BGP peer %s (ASN %d): %v", address, peer.PeerASN, err)
+		}
+		conn.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package validations_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type fakeDialer struct {
+	addresses []string
+	errs      map[string]error
+}
+
+func (d *fakeDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	d.addresses = append(d.addresses, address)
+	if err := d.errs[address]; err != nil {
+		return nil, err
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func bgpSpec(mode v1alpha1.MetalLBMode) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.MetalLB = &v1alpha1.MetalLBConfiguration{
+			Mode:         mode,
+			AddressPools: []v1alpha1.MetalLBAddressPool{{Name: "default", Addresses: []string{"10.0.0.0/28"}}},
+			BGPPeers: []v1alpha1.MetalLBBGPPeer{
+				{PeerAddress: "10.0.0.1", PeerASN: 64501, MyASN: 64500},
+				{PeerAddress: "fd00::1", PeerASN: 64502, MyASN: 64500},
+			},
+		}
+	})
+}
+
+func TestValidateBGPPeersNoMetalLB(t *testing.T) {
+	g := NewWithT(t)
+	dialer := &fakeDialer{}
+	g.Expect(validations.ValidateBGPPeers(context.Background(), dialer, test.NewClusterSpec())).To(Succeed())
+	g.Expect(dialer.addresses).To(BeEmpty())
+}
+
+func TestValidateBGPPeersL2Mode(t *testing.T) {
+	g := NewWithT(t)
+	dialer := &fakeDialer{}
+	g.Expect(validations.ValidateBGPPeers(context.Background(), dialer, bgpSpec(v1alpha1.MetalLBL2Mode))).To(Succeed())
+	g.Expect(dialer.addresses).To(BeEmpty())
+}
+
+func TestValidateBGPPeersSuccess(t *testing.T) {
+	g := NewWithT(t)
+	dialer := &fakeDialer{}
+	g.Expect(validations.ValidateBGPPeers(context.Background(), dialer, bgpSpec(v1alpha1.MetalLBBGPMode))).To(Succeed())
+	g.Expect(dialer.addresses).To(Equal([]string{"10.0.0.1:179", "[fd00::1]:179"}))
+}
+
+func TestValidateBGPPeersUnreachable(t *testing.T) {
+	g := NewWithT(t)
+	dialer := &fakeDialer{errs: map[string]error{"[fd00::1]:179": errors.New("connection refused")}}
+	g.Expect(validations.ValidateBGPPeers(context.Background(), dialer, bgpSpec(v1alpha1.MetalLBBGPMode))).To(
+		MatchError("connecting to BGP peer [fd00::1]:179 (ASN 64502): connection refused"),
+	)
+}
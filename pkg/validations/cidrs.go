@@ -0,0 +1,144 @@
+package validations
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+type namedCIDR struct {
+	name  string
+	block string
+	net   *net.IPNet
+}
+
+type namedAddress struct {
+	name    string
+	address string
+}
+
+// ValidateCIDRConflicts checks that the pods and services CIDR blocks don't overlap with each other
+// nor with the addresses and node networks the cluster needs to reach: the control plane endpoint,
+// the Tinkerbell stack IP, the Snow IP pools, the HTTP proxy and the registry mirror.
+// Only addresses specified as IPs are checked, host names are not resolved.
+func ValidateCIDRConflicts(clusterSpec *cluster.Spec) error {
+	clusterNetwork := clusterSpec.Cluster.Spec.ClusterNetwork
+
+	var clusterCIDRs []namedCIDR
+	for _, c := range []struct {
+		name   string
+		blocks []string
+	}{
+		{name: "pods", blocks: clusterNetwork.Pods.CidrBlocks},
+		{name: "services", blocks: clusterNetwork.Services.CidrBlocks},
+	} {
+		for _, block := range c.blocks {
+			_, ipNet, err := net.ParseCIDR(block)
+			if err != nil {
+				return fmt.Errorf("invalid %s CIDR block %s: %v", c.name, block, err)
+			}
+			clusterCIDRs = append(clusterCIDRs, namedCIDR{name: c.name, block: block, net: ipNet})
+		}
+	}
+
+	for i, a := range clusterCIDRs {
+		for _, b := range clusterCIDRs[i+1:] {
+			if a.name != b.name && cidrsOverlap(a.net, b.net) {
+				return fmt.Errorf("%s CIDR block %s conflicts with %s CIDR block %s", a.name, a.block, b.name, b.block)
+			}
+		}
+	}
+
+	for _, nodeCIDR := range nodeNetworkCIDRs(clusterSpec) {
+		for _, c := range clusterCIDRs {
+			if cidrsOverlap(nodeCIDR.net, c.net) {
+				return fmt.Errorf("%s %s conflicts with %s CIDR block %s", nodeCIDR.name, nodeCIDR.block, c.name, c.block)
+			}
+		}
+	}
+
+	for _, a := range clusterAddresses(clusterSpec) {
+		ip := addressIP(a.address)
+		if ip == nil {
+			continue
+		}
+		for _, c := range clusterCIDRs {
+			if c.net.Contains(ip) {
+				return fmt.Errorf("%s %s conflicts with %s CIDR block %s", a.name, a.address, c.name, c.block)
+			}
+		}
+	}
+
+	return nil
+}
+
+func nodeNetworkCIDRs(clusterSpec *cluster.Spec) []namedCIDR {
+	var cidrs []namedCIDR
+	for _, pool := range clusterSpec.SnowIPPools {
+		for _, p := range pool.Spec.Pools {
+			_, ipNet, err := net.ParseCIDR(p.Subnet)
+			if err != nil {
+				// Invalid subnets are reported by the Snow IP pool validations.
+				continue
+			}
+			cidrs = append(cidrs, namedCIDR{
+				name:  fmt.Sprintf("SnowIPPool %s subnet", pool.Name),
+				block: p.Subnet,
+				net:   ipNet,
+			})
+		}
+	}
+
+	return cidrs
+}
+
+func clusterAddresses(clusterSpec *cluster.Spec) []namedAddress {
+	spec := clusterSpec.Cluster.Spec
+	var addresses []namedAddress
+
+	if spec.ControlPlaneConfiguration.Endpoint != nil {
+		addresses = append(addresses, namedAddress{name: "control plane endpoint", address: spec.ControlPlaneConfiguration.Endpoint.Host})
+	}
+
+	if clusterSpec.TinkerbellDatacenter != nil {
+		addresses = append(addresses, namedAddress{name: "tinkerbellIP", address: clusterSpec.TinkerbellDatacenter.Spec.TinkerbellIP})
+	}
+
+	if spec.ProxyConfiguration != nil {
+		addresses = append(addresses,
+			namedAddress{name: "httpProxy", address: spec.ProxyConfiguration.HttpProxy},
+			namedAddress{name: "httpsProxy", address: spec.ProxyConfiguration.HttpsProxy},
+		)
+	}
+
+	if spec.RegistryMirrorConfiguration != nil {
+		addresses = append(addresses, namedAddress{name: "registry mirror endpoint", address: spec.RegistryMirrorConfiguration.Endpoint})
+	}
+
+	return addresses
+}
+
+// addressIP returns the IP of an address with an optional scheme and port,
+// or nil if the address host is not an IP.
+func addressIP(address string) net.IP {
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil
+		}
+		address = u.Host
+	}
+
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	return net.ParseIP(address)
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
@@ -0,0 +1,112 @@
+package validations_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestValidateCIDRConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *cluster.Spec)
+		wantErr string
+	}{
+		{
+			name:    "no conflicts",
+			modify:  func(s *cluster.Spec) {},
+			wantErr: "",
+		},
+		{
+			name: "pods and services overlap",
+			modify: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"192.168.128.0/20"}
+			},
+			wantErr: "pods CIDR block 192.168.0.0/16 conflicts with services CIDR block 192.168.128.0/20",
+		},
+		{
+			name: "control plane endpoint in pods CIDR",
+			modify: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: "192.168.1.10"}
+			},
+			wantErr: "control plane endpoint 192.168.1.10 conflicts with pods CIDR block 192.168.0.0/16",
+		},
+		{
+			name: "proxy in services CIDR",
+			modify: func(s *cluster.Spec) {
+				s.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+					HttpProxy:  "10.96.0.20:3128",
+					HttpsProxy: "http://10.96.0.20:3128",
+				}
+			},
+			wantErr: "httpProxy 10.96.0.20:3128 conflicts with services CIDR block 10.96.0.0/12",
+		},
+		{
+			name: "proxy host name is not resolved",
+			modify: func(s *cluster.Spec) {
+				s.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+					HttpProxy:  "http://proxy.local:3128",
+					HttpsProxy: "proxy.local:3128",
+				}
+			},
+			wantErr: "",
+		},
+		{
+			name: "registry mirror in pods CIDR",
+			modify: func(s *cluster.Spec) {
+				s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{Endpoint: "192.168.10.10", Port: "443"}
+			},
+			wantErr: "registry mirror endpoint 192.168.10.10 conflicts with pods CIDR block 192.168.0.0/16",
+		},
+		{
+			name: "tinkerbell ip in services CIDR",
+			modify: func(s *cluster.Spec) {
+				s.TinkerbellDatacenter = &anywherev1.TinkerbellDatacenterConfig{
+					Spec: anywherev1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.100.0.1"},
+				}
+			},
+			wantErr: "tinkerbellIP 10.100.0.1 conflicts with services CIDR block 10.96.0.0/12",
+		},
+		{
+			name: "snow ip pool subnet overlaps pods CIDR",
+			modify: func(s *cluster.Spec) {
+				s.SnowIPPools = map[string]*anywherev1.SnowIPPool{
+					"pool": {
+						ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+						Spec: anywherev1.SnowIPPoolSpec{
+							Pools: []anywherev1.IPPool{{Subnet: "192.168.5.0/24"}},
+						},
+					},
+				}
+			},
+			wantErr: "SnowIPPool pool subnet 192.168.5.0/24 conflicts with pods CIDR block 192.168.0.0/16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork = anywherev1.ClusterNetwork{
+					Pods:     anywherev1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+					Services: anywherev1.Services{CidrBlocks: []string{"10.96.0.0/12"}},
+				}
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: "172.16.0.10"}
+				tt.modify(s)
+			})
+
+			err := validations.ValidateCIDRConflicts(spec)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -36,6 +37,70 @@ func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.P
 	return nil
 }
 
+// ValidateProviderCapabilities checks the cluster spec only uses optional features supported by the provider.
+func ValidateProviderCapabilities(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	cluster := clusterSpec.Cluster
+	usesAutoscaling := false
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if wng.AutoScalingConfiguration != nil {
+			usesAutoscaling = true
+			break
+		}
+	}
+	usesExternalEtcd := cluster.Spec.ExternalEtcdConfiguration != nil
+
+	if !usesAutoscaling && !usesExternalEtcd {
+		return nil
+	}
+
+	capabilities := provider.Capabilities()
+	if usesAutoscaling && !capabilities.Autoscaling {
+		return fmt.Errorf("autoscaling configuration is not supported by provider %s", provider.Name())
+	}
+	if usesExternalEtcd && !capabilities.ExternalEtcd {
+		return fmt.Errorf("external etcd configuration is not supported by provider %s", provider.Name())
+	}
+
+	return nil
+}
+
+// ValidateKubeProxyReplacement checks the provider and the OS of every machine support running Cilium
+// in kube-proxy replacement mode. It needs eBPF features added in Linux 4.19.57, not available in
+// the 4.18 kernel of RHEL 8.
+func ValidateKubeProxyReplacement(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if !clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplacementEnabled() {
+		return nil
+	}
+
+	if !provider.Capabilities().KubeProxyReplacement {
+		return fmt.Errorf("cilium kubeProxyReplacement is not supported by provider %s", provider.Name())
+	}
+
+	for _, mc := range provider.MachineConfigs(clusterSpec) {
+		if mc.OSFamily() == v1alpha1.RedHat {
+			return fmt.Errorf("cilium kubeProxyReplacement is not supported by the %s kernel used in machine config %s", mc.OSFamily(), mc.GetName())
+		}
+	}
+
+	return nil
+}
+
+// ValidateTemplateOverrides checks the provider supports templateOverrides and the overrides only
+// set paths of the generated CAPI objects the provider allows to override.
+func ValidateTemplateOverrides(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	overrides := clusterSpec.Cluster.Spec.TemplateOverrides
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	paths := provider.Capabilities().TemplateOverridePaths
+	if len(paths) == 0 {
+		return fmt.Errorf("templateOverrides are not supported by provider %s", provider.Name())
+	}
+
+	return clusterapi.ValidateTemplateOverrides(overrides, paths)
+}
+
 func ValidateCertForRegistryMirror(clusterSpec *cluster.Spec, tlsValidator TlsValidator) error {
 	cluster := clusterSpec.Cluster
 	if cluster.Spec.RegistryMirrorConfiguration == nil {
@@ -245,6 +245,173 @@ func TestValidateOSForRegistryMirrorInsecureSkipVerifyEnabled(t *testing.T) {
 	}
 }
 
+func TestValidateProviderCapabilities(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         func(s *cluster.Spec)
+		capabilities providers.Capabilities
+		wantErr      string
+	}{
+		{
+			name: "no optional features",
+			spec: func(s *cluster.Spec) {},
+		},
+		{
+			name: "autoscaling supported",
+			spec: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{
+					AutoScalingConfiguration: &anywherev1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				}}
+			},
+			capabilities: providers.Capabilities{Autoscaling: true},
+		},
+		{
+			name: "autoscaling not supported",
+			spec: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{
+					AutoScalingConfiguration: &anywherev1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				}}
+			},
+			wantErr: "autoscaling configuration is not supported by provider test",
+		},
+		{
+			name: "external etcd not supported",
+			spec: func(s *cluster.Spec) {
+				s.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+			},
+			capabilities: providers.Capabilities{Autoscaling: true},
+			wantErr:      "external etcd configuration is not supported by provider test",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tc.spec(tt.clusterSpec)
+			tt.provider.EXPECT().Capabilities().Return(tc.capabilities).AnyTimes()
+			tt.provider.EXPECT().Name().Return("test").AnyTimes()
+
+			err := validations.ValidateProviderCapabilities(tt.clusterSpec, tt.provider)
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				tt.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestValidateKubeProxyReplacement(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		capabilities   providers.Capabilities
+		machineConfigs []providers.MachineConfig
+		wantErr        string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:         "supported",
+			enabled:      true,
+			capabilities: providers.Capabilities{KubeProxyReplacement: true},
+			machineConfigs: []providers.MachineConfig{
+				&anywherev1.VSphereMachineConfig{Spec: anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.Ubuntu}},
+				&anywherev1.VSphereMachineConfig{Spec: anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.Bottlerocket}},
+			},
+		},
+		{
+			name:    "not supported by provider",
+			enabled: true,
+			wantErr: "cilium kubeProxyReplacement is not supported by provider test",
+		},
+		{
+			name:         "not supported by redhat",
+			enabled:      true,
+			capabilities: providers.Capabilities{KubeProxyReplacement: true},
+			machineConfigs: []providers.MachineConfig{
+				&anywherev1.VSphereMachineConfig{
+					ObjectMeta: v1.ObjectMeta{Name: "rhel"},
+					Spec:       anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.RedHat},
+				},
+			},
+			wantErr: "cilium kubeProxyReplacement is not supported by the redhat kernel used in machine config rhel",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+				Cilium: &anywherev1.CiliumConfig{KubeProxyReplacement: tc.enabled},
+			}
+			tt.provider.EXPECT().Capabilities().Return(tc.capabilities).AnyTimes()
+			tt.provider.EXPECT().Name().Return("test").AnyTimes()
+			tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return(tc.machineConfigs).AnyTimes()
+
+			err := validations.ValidateKubeProxyReplacement(tt.clusterSpec, tt.provider)
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				tt.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestValidateTemplateOverrides(t *testing.T) {
+	tests := []struct {
+		name         string
+		overrides    []anywherev1.TemplateOverride
+		capabilities providers.Capabilities
+		wantErr      string
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "allowed path",
+			overrides: []anywherev1.TemplateOverride{
+				{Kind: "MachineDeployment", Patch: "metadata:\n  labels:\n    team: edge\n"},
+			},
+			capabilities: providers.Capabilities{
+				TemplateOverridePaths: map[string][]string{"MachineDeployment": {"metadata.labels"}},
+			},
+		},
+		{
+			name: "not supported by provider",
+			overrides: []anywherev1.TemplateOverride{
+				{Kind: "MachineDeployment", Patch: "metadata:\n  labels:\n    team: edge\n"},
+			},
+			wantErr: "templateOverrides are not supported by provider test",
+		},
+		{
+			name: "path not allowed",
+			overrides: []anywherev1.TemplateOverride{
+				{Kind: "MachineDeployment", Patch: "spec:\n  replicas: 3\n"},
+			},
+			capabilities: providers.Capabilities{
+				TemplateOverridePaths: map[string][]string{"MachineDeployment": {"metadata.labels"}},
+			},
+			wantErr: "templateOverrides can't override spec.replicas in MachineDeployment, only metadata.labels can be overridden",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.clusterSpec.Cluster.Spec.TemplateOverrides = tc.overrides
+			tt.provider.EXPECT().Capabilities().Return(tc.capabilities).AnyTimes()
+			tt.provider.EXPECT().Name().Return("test").AnyTimes()
+
+			err := validations.ValidateTemplateOverrides(tt.clusterSpec, tt.provider)
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				tt.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestValidateManagementClusterNameValid(t *testing.T) {
 	mgmtName := "test"
 	tt := newTest(t, withKubectl())
@@ -7,6 +7,9 @@ import (
 // SkippableValidations represents all the validations we offer for users to skip.
 var SkippableValidations = []string{
 	validations.VSphereUserPriv,
+	validations.OIDCIssuer,
+	validations.BGPPeers,
+	validations.IPConflicts,
 }
 
 func New(opts *validations.Opts) *CreateValidations {
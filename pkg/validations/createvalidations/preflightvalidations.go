@@ -28,6 +28,41 @@ func (v *CreateValidations) PreflightValidations(ctx context.Context) []validati
 				Err:         validations.ValidateOSForRegistryMirror(v.Opts.Spec, v.Opts.Provider),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate cluster features are supported by the provider",
+				Remediation: "remove the configuration for features not supported by the provider",
+				Err:         validations.ValidateProviderCapabilities(v.Opts.Spec, v.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate the provider and OS support cilium kube-proxy replacement",
+				Remediation: "use a provider and OS supported by cilium kube-proxy replacement or disable kubeProxyReplacement",
+				Err:         validations.ValidateKubeProxyReplacement(v.Opts.Spec, v.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate the bundles include FIPS images",
+				Remediation: "use an EKS-A release that publishes FIPS images or disable fips",
+				Err:         validations.ValidateFIPSImages(v.Opts.Spec, v.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate templateOverrides only override paths allowed by the provider",
+				Remediation: "remove the templateOverrides patches for kinds or paths not supported by the provider",
+				Err:         validations.ValidateTemplateOverrides(v.Opts.Spec, v.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate cluster CIDR blocks don't conflict with other networks",
+				Remediation: "use pods and services CIDR blocks that don't overlap with each other nor with the node network, proxy and registry mirror addresses",
+				Err:         validations.ValidateCIDRConflicts(v.Opts.Spec),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate certificate for registry mirror",
@@ -59,6 +94,42 @@ func (v *CreateValidations) PreflightValidations(ctx context.Context) []validati
 		},
 	}
 
+	if !v.Opts.SkippedValidations[validations.OIDCIssuer] {
+		createValidations = append(
+			createValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate OIDC issuer is reachable",
+					Remediation: fmt.Sprintf("ensure the OIDC issuer is reachable from the control plane network and its certificate is signed by a trusted CA, or skip this validation with --skip-validations=%s", validations.OIDCIssuer),
+					Err:         validations.ValidateOIDCIssuer(ctx, v.Opts.HTTPClient, v.Opts.Spec),
+				}
+			})
+	}
+
+	if !v.Opts.SkippedValidations[validations.BGPPeers] {
+		createValidations = append(
+			createValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate BGP peers are reachable",
+					Remediation: fmt.Sprintf("ensure the metalLB BGP peers accept connections on port 179 from the node network, or skip this validation with --skip-validations=%s", validations.BGPPeers),
+					Err:         validations.ValidateBGPPeers(ctx, v.Opts.Dialer, v.Opts.Spec),
+				}
+			})
+	}
+
+	if v.Opts.IPConflicts != nil && !v.Opts.SkippedValidations[validations.IPConflicts] {
+		createValidations = append(
+			createValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate cluster IPs are not in use",
+					Remediation: fmt.Sprintf("use IPs that aren't used by other hosts nor allocated in the IPAM, or skip this validation with --skip-validations=%s", validations.IPConflicts),
+					Err:         validations.ValidateIPConflicts(ctx, v.Opts.IPConflicts, v.Opts.Spec),
+				}
+			})
+	}
+
 	if v.Opts.Spec.Cluster.IsManaged() {
 		createValidations = append(
 			createValidations,
@@ -2,6 +2,7 @@ package createvalidations_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -89,3 +90,23 @@ func TestPreFlightValidationsWorkloadCluster(t *testing.T) {
 
 	tt.Expect(validations.ProcessValidationResults(tt.c.PreflightValidations(tt.ctx))).To(Succeed())
 }
+
+func TestPreFlightValidationsIPConflicts(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	detector := mocks.NewMockIPConflictDetector(gomock.NewController(t))
+	tt.c.Opts.IPConflicts = detector
+	tt.c.Opts.Spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+
+	detector.EXPECT().Detect(tt.ctx, tt.c.Opts.Spec.Cluster.Name, gomock.Len(1)).Return(errors.New("ip in use"))
+
+	tt.Expect(validations.ProcessValidationResults(tt.c.PreflightValidations(tt.ctx))).NotTo(Succeed())
+}
+
+func TestPreFlightValidationsIPConflictsSkipped(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	tt.c.Opts.IPConflicts = mocks.NewMockIPConflictDetector(gomock.NewController(t))
+	tt.c.Opts.Spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+	tt.c.Opts.SkippedValidations = map[string]bool{validations.IPConflicts: true}
+
+	tt.Expect(validations.ProcessValidationResults(tt.c.PreflightValidations(tt.ctx))).To(Succeed())
+}
@@ -0,0 +1,60 @@
+package validations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// ValidateFIPSImages checks the bundles of a cluster in FIPS mode have a FIPS variant for every image
+// the cluster runs. EKS-D images are not checked since EKS-D publishes a single variant of them.
+func ValidateFIPSImages(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if !clusterSpec.Cluster.Spec.FIPS {
+		return nil
+	}
+
+	missing := map[string]struct{}{}
+	for version, vb := range clusterSpec.VersionsBundles {
+		for _, image := range fipsImages(vb.VersionsBundle, provider.Capabilities()) {
+			if image.FIPSURI == "" {
+				missing[fmt.Sprintf("%s (kubernetes %s)", image.Name, version)] = struct{}{}
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("the bundles don't include a FIPS variant for images: %s", strings.Join(names, ", "))
+}
+
+// fipsImages returns the images of the bundle a cluster running with a provider with capabilities
+// needs in FIPS mode. Images the bundle doesn't include are skipped, as are the kind node and haproxy
+// images, which are only used by the docker provider that doesn't support FIPS.
+func fipsImages(vb *releasev1alpha1.VersionsBundle, capabilities providers.Capabilities) []releasev1alpha1.Image {
+	images := vb.SharedImages()
+	if capabilities.BundleImages != nil {
+		images = append(images, capabilities.BundleImages(vb)...)
+	}
+
+	dockerOnly := map[string]bool{vb.EksD.KindNode.URI: true, vb.Haproxy.Image.URI: true}
+	used := make([]releasev1alpha1.Image, 0, len(images))
+	for _, image := range images {
+		if image.URI != "" && !dockerOnly[image.URI] {
+			used = append(used, image)
+		}
+	}
+
+	return used
+}
@@ -0,0 +1,94 @@
+package validations_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func fipsImage(name string) releasev1alpha1.Image {
+	return releasev1alpha1.Image{
+		Name:    name,
+		URI:     "public.ecr.aws/eks-anywhere/" + name + ":v0.18.0-fips",
+		FIPSURI: "public.ecr.aws/eks-anywhere/" + name + ":v0.18.0-fips",
+	}
+}
+
+func TestValidateFIPSImages(t *testing.T) {
+	tests := []struct {
+		name         string
+		fips         bool
+		capabilities providers.Capabilities
+		bundle       *releasev1alpha1.VersionsBundle
+		wantErr      string
+	}{
+		{
+			name: "fips disabled",
+			bundle: &releasev1alpha1.VersionsBundle{
+				Eksa: releasev1alpha1.EksaBundle{
+					ClusterController: releasev1alpha1.Image{Name: "cluster-controller", URI: "cluster-controller:v0.18.0"},
+				},
+			},
+		},
+		{
+			name: "all images have fips variant",
+			fips: true,
+			capabilities: providers.Capabilities{
+				BundleImages: (*releasev1alpha1.VersionsBundle).VsphereImages,
+			},
+			bundle: &releasev1alpha1.VersionsBundle{
+				Eksa: releasev1alpha1.EksaBundle{
+					ClusterController: fipsImage("cluster-controller"),
+				},
+				VSphere: releasev1alpha1.VSphereBundle{
+					Manager: fipsImage("cloud-provider-vsphere"),
+				},
+				EksD: releasev1alpha1.EksDRelease{
+					KindNode: releasev1alpha1.Image{Name: "kind-node", URI: "kind-node:v1.19.8"},
+				},
+				Haproxy: releasev1alpha1.HaproxyBundle{
+					Image: releasev1alpha1.Image{Name: "haproxy", URI: "haproxy:v0.18.0"},
+				},
+				Tinkerbell: releasev1alpha1.TinkerbellBundle{
+					Envoy: releasev1alpha1.Image{Name: "envoy", URI: "envoy:v0.18.0"},
+				},
+			},
+		},
+		{
+			name: "shared and provider images without fips variant",
+			fips: true,
+			capabilities: providers.Capabilities{
+				BundleImages: (*releasev1alpha1.VersionsBundle).TinkerbellImages,
+			},
+			bundle: &releasev1alpha1.VersionsBundle{
+				Eksa: releasev1alpha1.EksaBundle{
+					ClusterController: fipsImage("cluster-controller"),
+					CliTools:          releasev1alpha1.Image{Name: "cli-tools", URI: "cli-tools:v0.18.0"},
+				},
+				Tinkerbell: releasev1alpha1.TinkerbellBundle{
+					Envoy: releasev1alpha1.Image{Name: "envoy", URI: "envoy:v0.18.0"},
+				},
+			},
+			wantErr: "the bundles don't include a FIPS variant for images: cli-tools (kubernetes 1.19), envoy (kubernetes 1.19)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.clusterSpec.Cluster.Spec.FIPS = tc.fips
+			tt.clusterSpec.VersionsBundles["1.19"].VersionsBundle = tc.bundle
+			tt.provider.EXPECT().Capabilities().Return(tc.capabilities).AnyTimes()
+
+			err := validations.ValidateFIPSImages(tt.clusterSpec, tt.provider)
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				tt.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
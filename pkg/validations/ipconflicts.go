@@ -0,0 +1,19 @@
+package validations
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+)
+
+// ValidateIPConflicts checks the control plane VIP and, for new management clusters, the Tinkerbell
+// stack IP aren't used by other hosts. VIP conflicts otherwise only surface once the control plane
+// machines are up, as an API server that intermittently answers from another host.
+func ValidateIPConflicts(ctx context.Context, detector IPConflictDetector, spec *cluster.Spec) error {
+	ips := ipconflict.ClusterIPs(spec)
+	if len(ips) == 0 {
+		return nil
+	}
+	return detector.Detect(ctx, spec.Cluster.Name, ips)
+}
@@ -0,0 +1,40 @@
+package validations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+)
+
+func TestValidateIPConflicts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	detector := mocks.NewMockIPConflictDetector(gomock.NewController(t))
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "prod"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "10.0.0.10"}
+	})
+
+	detector.EXPECT().Detect(ctx, "prod", []ipconflict.IP{
+		{Field: "controlPlaneConfiguration.endpoint.host", Address: "10.0.0.10"},
+	}).Return(errors.New("controlPlaneConfiguration.endpoint.host <10.0.0.10> is already in use: answered ICMP echo"))
+
+	g.Expect(validations.ValidateIPConflicts(ctx, detector, spec)).To(MatchError(ContainSubstring("answered ICMP echo")))
+}
+
+func TestValidateIPConflictsNoIPs(t *testing.T) {
+	g := NewWithT(t)
+	detector := mocks.NewMockIPConflictDetector(gomock.NewController(t))
+
+	g.Expect(validations.ValidateIPConflicts(context.Background(), detector, test.NewClusterSpec())).To(Succeed())
+}
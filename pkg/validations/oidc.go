@@ -0,0 +1,91 @@
+package validations
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+// oidcDiscoveryDocument is the subset of the OIDC discovery document the kube-apiserver needs.
+type oidcDiscoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// ValidateOIDCIssuer checks that the OIDC issuer discovery document and the JWKS it points to
+// can be fetched over HTTPS with a trusted certificate. The kube-apiserver needs both to verify
+// tokens and, when they are not reachable, the failure only surfaces after the cluster is created.
+// The requests are sent from the machine running the CLI, which should share the network setup
+// of the control plane.
+func ValidateOIDCIssuer(ctx context.Context, client HTTPClient, spec *cluster.Spec) error {
+	if spec.OIDCConfig == nil {
+		return nil
+	}
+
+	issuerURL := strings.TrimSuffix(spec.OIDCConfig.Spec.IssuerUrl, "/")
+	discovery := &oidcDiscoveryDocument{}
+	if err := getJSON(ctx, client, issuerURL+oidcDiscoveryPath, discovery); err != nil {
+		return fmt.Errorf("getting OIDC discovery document for issuer %s: %v", issuerURL, err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuerURL {
+		return fmt.Errorf("OIDC discovery document issuer %s doesn't match issuerUrl %s", discovery.Issuer, issuerURL)
+	}
+
+	if discovery.JWKSURI == "" {
+		return fmt.Errorf("OIDC discovery document for issuer %s doesn't include a jwks_uri", issuerURL)
+	}
+
+	jwks := map[string]interface{}{}
+	if err := getJSON(ctx, client, discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("getting OIDC JWKS for issuer %s: %v", issuerURL, err)
+	}
+
+	return nil
+}
+
+func getJSON(ctx context.Context, client HTTPClient, url string, obj interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var unknownAuthority x509.UnknownAuthorityError
+		if errors.As(err, &unknownAuthority) {
+			return fmt.Errorf("certificate for %s is not signed by a trusted CA: %v", url, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response from %s: %v", url, err)
+	}
+
+	if err := json.Unmarshal(body, obj); err != nil {
+		return fmt.Errorf("parsing response from %s: %v", url, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package validations_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func newOIDCIssuerServer(t *testing.T, discovery func(serverURL string) string, jwksStatus int) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, discovery(server.URL))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(jwksStatus)
+		fmt.Fprint(w, `{"keys": []}`)
+	})
+	server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func validDiscovery(serverURL string) string {
+	return fmt.Sprintf(`{"issuer": "%s", "jwks_uri": "%s/keys"}`, serverURL, serverURL)
+}
+
+func oidcSpec(issuerURL string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.OIDCConfig = &anywherev1.OIDCConfig{
+			Spec: anywherev1.OIDCConfigSpec{IssuerUrl: issuerURL},
+		}
+	})
+}
+
+func TestValidateOIDCIssuerNoOIDC(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), http.DefaultClient, test.NewClusterSpec())).To(Succeed())
+}
+
+func TestValidateOIDCIssuerSuccess(t *testing.T) {
+	g := NewWithT(t)
+	server := newOIDCIssuerServer(t, validDiscovery, http.StatusOK)
+
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), server.Client(), oidcSpec(server.URL+"/"))).To(Succeed())
+}
+
+func TestValidateOIDCIssuerUntrustedCA(t *testing.T) {
+	g := NewWithT(t)
+	server := newOIDCIssuerServer(t, validDiscovery, http.StatusOK)
+
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), &http.Client{}, oidcSpec(server.URL))).To(
+		MatchError(ContainSubstring("is not signed by a trusted CA")),
+	)
+}
+
+func TestValidateOIDCIssuerMismatch(t *testing.T) {
+	g := NewWithT(t)
+	server := newOIDCIssuerServer(t, func(string) string {
+		return `{"issuer": "https://other-issuer", "jwks_uri": "https://other-issuer/keys"}`
+	}, http.StatusOK)
+
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), server.Client(), oidcSpec(server.URL))).To(
+		MatchError(ContainSubstring("OIDC discovery document issuer https://other-issuer doesn't match issuerUrl")),
+	)
+}
+
+func TestValidateOIDCIssuerMissingJWKSURI(t *testing.T) {
+	g := NewWithT(t)
+	server := newOIDCIssuerServer(t, func(serverURL string) string {
+		return fmt.Sprintf(`{"issuer": "%s"}`, serverURL)
+	}, http.StatusOK)
+
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), server.Client(), oidcSpec(server.URL))).To(
+		MatchError(ContainSubstring("doesn't include a jwks_uri")),
+	)
+}
+
+func TestValidateOIDCIssuerJWKSNotReachable(t *testing.T) {
+	g := NewWithT(t)
+	server := newOIDCIssuerServer(t, validDiscovery, http.StatusNotFound)
+
+	g.Expect(validations.ValidateOIDCIssuer(context.Background(), server.Client(), oidcSpec(server.URL))).To(
+		MatchError(ContainSubstring("getting OIDC JWKS for issuer")),
+	)
+}
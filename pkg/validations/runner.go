@@ -1,6 +1,11 @@
 package validations
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+)
 
 var errRunnerValidation = errors.New("validations failed")
 
@@ -18,19 +23,30 @@ func (r *Runner) Register(validations ...Validation) {
 	r.validations = append(r.validations, validations...)
 }
 
+// Run runs all the validations and reports their results. If any of them fails, the error has
+// the code of the first failed validation with one, or the generic validation code, and the
+// failures as details.
 func (r *Runner) Run() error {
-	failed := false
+	var details []string
+	code := eksaerrors.CodeUnknown
 	for _, v := range r.validations {
 		result := v()
 		result.Report()
 		if result.Err != nil {
-			failed = true
+			details = append(details, fmt.Sprintf("%s: %v", result.Name, result.Err))
+			if code == eksaerrors.CodeUnknown {
+				code = eksaerrors.CodeOf(result.Err)
+			}
 		}
 	}
 
-	if failed {
-		return errRunnerValidation
+	if details == nil {
+		return nil
+	}
+
+	if code == eksaerrors.CodeUnknown {
+		code = eksaerrors.CodeValidation
 	}
 
-	return nil
+	return &eksaerrors.Error{Code: code, Err: errRunnerValidation, Details: details}
 }
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/gomega"
 
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
@@ -44,3 +45,41 @@ func TestRunnerRunSuccess(t *testing.T) {
 
 	g.Expect(r.Run()).To(Succeed())
 }
+
+func TestRunnerRunErrorCode(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "kubernetes version",
+			Err:  errors.New("unsupported version"),
+		}
+	})
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "vsphere Provider setup is valid",
+			Err:  eksaerrors.WithCode(eksaerrors.CodeVSphereSetup, errors.New("datacenter not found")),
+		}
+	})
+
+	err := r.Run()
+	g.Expect(err).To(MatchError("validations failed"))
+	g.Expect(eksaerrors.CodeOf(err)).To(Equal(eksaerrors.CodeVSphereSetup))
+	g.Expect(eksaerrors.DetailsOf(err)).To(Equal([]string{
+		"kubernetes version: unsupported version",
+		"vsphere Provider setup is valid: datacenter not found",
+	}))
+}
+
+func TestRunnerRunErrorDefaultCode(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "kubernetes version",
+			Err:  errors.New("unsupported version"),
+		}
+	})
+
+	g.Expect(eksaerrors.CodeOf(r.Run())).To(Equal(eksaerrors.CodeValidation))
+}
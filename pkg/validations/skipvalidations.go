@@ -10,6 +10,10 @@ const (
 	PDB             = "pod-disruption"
 	VSphereUserPriv = "vsphere-user-privilege"
 	EksaVersionSkew = "eksa-version-skew"
+	OIDCIssuer      = "oidc-issuer"
+	BGPPeers        = "bgp-peers"
+	DeprecatedAPIs  = "deprecated-apis"
+	IPConflicts     = "ip-conflicts"
 )
 
 // ValidSkippableValidationsMap returns a map for all valid skippable validations as keys, defaulting values to false.
@@ -0,0 +1,41 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// ValidateDeprecatedAPIs returns an error if any object in the cluster uses an API that is
+// served by the current Kubernetes version of the cluster but removed in the new one.
+func ValidateDeprecatedAPIs(ctx context.Context, kubectl validations.KubectlClient, scanner validations.DeprecatedAPIScanner, newCluster *anywherev1.Cluster, cluster *types.Cluster, mgmtCluster *types.Cluster) error {
+	managementCluster := cluster
+	if !cluster.ExistingManagement {
+		managementCluster = mgmtCluster
+	}
+
+	eksaCluster, err := kubectl.GetEksaCluster(ctx, managementCluster, newCluster.Name)
+	if err != nil {
+		return fmt.Errorf("fetching old cluster: %v", err)
+	}
+
+	findings, err := scanner.Scan(ctx, cluster, eksaCluster.Spec.KubernetesVersion, newCluster.Spec.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("scanning cluster for removed APIs: %v", err)
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	usages := make([]string, 0, len(findings))
+	for _, f := range findings {
+		usages = append(usages, f.String())
+	}
+
+	return fmt.Errorf("objects using APIs removed in kubernetes %s: %s", newCluster.Spec.KubernetesVersion, strings.Join(usages, ", "))
+}
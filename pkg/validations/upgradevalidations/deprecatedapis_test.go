@@ -0,0 +1,92 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func TestValidateDeprecatedAPIs(t *testing.T) {
+	hpa := deprecatedapis.RemovedAPI{
+		APIVersion:  "autoscaling/v2beta2",
+		Kind:        "HorizontalPodAutoscaler",
+		Resource:    "horizontalpodautoscalers",
+		RemovedIn:   anywherev1.Kube126,
+		Replacement: "autoscaling/v2",
+	}
+	tests := []struct {
+		name     string
+		findings []deprecatedapis.Finding
+		scanErr  error
+		wantErr  string
+	}{
+		{
+			name: "no findings",
+		},
+		{
+			name: "findings",
+			findings: []deprecatedapis.Finding{
+				{RemovedAPI: hpa, Namespace: "default", Name: "web", Source: "object"},
+				{RemovedAPI: hpa, Namespace: "default", Name: "api", Source: "helm release default/api"},
+			},
+			wantErr: "objects using APIs removed in kubernetes 1.26: autoscaling/v2beta2 HorizontalPodAutoscaler default/web (object), " +
+				"autoscaling/v2beta2 HorizontalPodAutoscaler default/api (helm release default/api)",
+		},
+		{
+			name:    "scan error",
+			scanErr: errors.New("connection refused"),
+			wantErr: "scanning cluster for removed APIs: connection refused",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctrl := gomock.NewController(t)
+			k := mocks.NewMockKubectlClient(ctrl)
+			scanner := mocks.NewMockDeprecatedAPIScanner(ctrl)
+			ctx := context.Background()
+
+			newCluster := baseCluster()
+			newCluster.Spec.KubernetesVersion = anywherev1.Kube126
+			oldCluster := baseCluster()
+			oldCluster.Spec.KubernetesVersion = anywherev1.Kube125
+			cluster := &types.Cluster{KubeconfigFile: "test.kubeconfig"}
+
+			k.EXPECT().GetEksaCluster(ctx, cluster, newCluster.Name).Return(oldCluster, nil)
+			scanner.EXPECT().Scan(ctx, cluster, anywherev1.Kube125, anywherev1.Kube126).Return(tc.findings, tc.scanErr)
+
+			err := upgradevalidations.ValidateDeprecatedAPIs(ctx, k, scanner, newCluster, cluster, cluster)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateDeprecatedAPIsGetClusterError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	k := mocks.NewMockKubectlClient(ctrl)
+	scanner := mocks.NewMockDeprecatedAPIScanner(ctrl)
+	ctx := context.Background()
+	newCluster := baseCluster()
+	cluster := &types.Cluster{KubeconfigFile: "test.kubeconfig"}
+
+	k.EXPECT().GetEksaCluster(ctx, cluster, newCluster.Name).Return(nil, errors.New("not found"))
+
+	g.Expect(upgradevalidations.ValidateDeprecatedAPIs(ctx, k, scanner, newCluster, cluster, cluster)).To(
+		MatchError("fetching old cluster: not found"),
+	)
+}
@@ -0,0 +1,43 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// ValidateEtcdDiskLatency returns an error if the fdatasync latency of the etcd disk of any control plane
+// node is over the etcd recommendation. Slow disks make etcd miss heartbeats and trigger leader elections,
+// which an upgrade rolling the control plane nodes makes worse. Clusters with external etcd are not
+// validated, since the etcd machines are not cluster nodes.
+func ValidateEtcdDiskLatency(ctx context.Context, benchmark validations.EtcdDiskBenchmark, c *anywherev1.Cluster, cluster *types.Cluster) error {
+	if c.Spec.ExternalEtcdConfiguration != nil {
+		logger.Info("Skipping etcd disk benchmark for cluster with external etcd")
+		return nil
+	}
+
+	results, err := benchmark.Run(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	var slow []string
+	for _, r := range results {
+		logger.V(3).Info("Etcd disk fdatasync 99th percentile", "node", r.Node, "latency", r.FdatasyncP99)
+		if r.FdatasyncP99 > etcdbenchmark.MaxFdatasyncLatency {
+			slow = append(slow, fmt.Sprintf("%s (%s)", r.Node, r.FdatasyncP99))
+		}
+	}
+
+	if len(slow) > 0 {
+		return fmt.Errorf("etcd disk 99th percentile fdatasync latency is over %s in nodes: %s", etcdbenchmark.MaxFdatasyncLatency, strings.Join(slow, ", "))
+	}
+
+	return nil
+}
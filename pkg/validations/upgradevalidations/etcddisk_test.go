@@ -0,0 +1,73 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func TestValidateEtcdDiskLatency(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []etcdbenchmark.Result
+		benchErr error
+		wantErr  string
+	}{
+		{
+			name: "fast disks",
+			results: []etcdbenchmark.Result{
+				{Node: "cp-1", FdatasyncP99: 2 * time.Millisecond},
+				{Node: "cp-2", FdatasyncP99: 10 * time.Millisecond},
+			},
+		},
+		{
+			name: "slow disks",
+			results: []etcdbenchmark.Result{
+				{Node: "cp-1", FdatasyncP99: 2 * time.Millisecond},
+				{Node: "cp-2", FdatasyncP99: 25 * time.Millisecond},
+				{Node: "cp-3", FdatasyncP99: 11 * time.Millisecond},
+			},
+			wantErr: "etcd disk 99th percentile fdatasync latency is over 10ms in nodes: cp-2 (25ms), cp-3 (11ms)",
+		},
+		{
+			name:     "benchmark error",
+			benchErr: errors.New("running etcd disk benchmark in node cp-1: timed out"),
+			wantErr:  "running etcd disk benchmark in node cp-1: timed out",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			benchmark := mocks.NewMockEtcdDiskBenchmark(gomock.NewController(t))
+			cluster := &types.Cluster{KubeconfigFile: "test.kubeconfig"}
+			benchmark.EXPECT().Run(ctx, cluster).Return(tc.results, tc.benchErr)
+
+			err := upgradevalidations.ValidateEtcdDiskLatency(ctx, benchmark, baseCluster(), cluster)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateEtcdDiskLatencyExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	benchmark := mocks.NewMockEtcdDiskBenchmark(gomock.NewController(t))
+	c := baseCluster()
+	c.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+
+	g.Expect(upgradevalidations.ValidateEtcdDiskLatency(context.Background(), benchmark, c, &types.Cluster{})).To(Succeed())
+}
@@ -0,0 +1,42 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// defaultMTU is the MTU node interfaces get when the cluster doesn't configure one.
+const defaultMTU = 1500
+
+// ValidateNodeMTU returns an error if packets of the MTU configured for the cluster network, or the
+// default Ethernet MTU if none is configured, don't reach every node from every other node without
+// being fragmented. Those paths drop large packets silently once the cluster is upgraded.
+func ValidateNodeMTU(ctx context.Context, prober validations.MTUProber, c *anywherev1.Cluster, cluster *types.Cluster) error {
+	mtu := c.Spec.ClusterNetwork.MTU
+	if mtu == 0 {
+		mtu = defaultMTU
+	}
+
+	results, err := prober.Probe(ctx, cluster, mtu)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, r := range results {
+		if !r.Fits {
+			failed = append(failed, fmt.Sprintf("%s -> %s", r.Node, r.Peer))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("packets of %d bytes are dropped or fragmented between nodes: %s", mtu, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
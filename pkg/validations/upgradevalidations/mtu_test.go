@@ -0,0 +1,71 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/networking/mtu"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func TestValidateNodeMTU(t *testing.T) {
+	tests := []struct {
+		name     string
+		mtu      int
+		wantMTU  int
+		results  []mtu.Result
+		probeErr error
+		wantErr  string
+	}{
+		{
+			name:    "configured mtu fits",
+			mtu:     1400,
+			wantMTU: 1400,
+			results: []mtu.Result{
+				{Node: "cp-1", Peer: "10.0.0.2", Fits: true},
+				{Node: "md-1", Peer: "10.0.0.1", Fits: true},
+			},
+		},
+		{
+			name:    "default mtu doesn't fit",
+			wantMTU: 1500,
+			results: []mtu.Result{
+				{Node: "cp-1", Peer: "10.0.0.2", Fits: true},
+				{Node: "md-1", Peer: "10.0.0.1", Fits: false},
+				{Node: "md-2", Peer: "10.0.0.1", Fits: false},
+			},
+			wantErr: "packets of 1500 bytes are dropped or fragmented between nodes: md-1 -> 10.0.0.1, md-2 -> 10.0.0.1",
+		},
+		{
+			name:     "probe error",
+			mtu:      1400,
+			wantMTU:  1400,
+			probeErr: errors.New("probing MTU from node cp-1: timed out"),
+			wantErr:  "probing MTU from node cp-1: timed out",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			prober := mocks.NewMockMTUProber(gomock.NewController(t))
+			cluster := &types.Cluster{KubeconfigFile: "test.kubeconfig"}
+			c := baseCluster()
+			c.Spec.ClusterNetwork.MTU = tc.mtu
+			prober.EXPECT().Probe(ctx, cluster, tc.wantMTU).Return(tc.results, tc.probeErr)
+
+			err := upgradevalidations.ValidateNodeMTU(ctx, prober, c, cluster)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
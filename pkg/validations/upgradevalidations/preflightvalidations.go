@@ -8,6 +8,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -37,6 +38,34 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) []validat
 				Err:         validations.ValidateOSForRegistryMirror(u.Opts.Spec, u.Opts.Provider),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate cluster features are supported by the provider",
+				Remediation: "remove the configuration for features not supported by the provider",
+				Err:         validations.ValidateProviderCapabilities(u.Opts.Spec, u.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate the provider and OS support cilium kube-proxy replacement",
+				Remediation: "use a provider and OS supported by cilium kube-proxy replacement or disable kubeProxyReplacement",
+				Err:         validations.ValidateKubeProxyReplacement(u.Opts.Spec, u.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate the bundles include FIPS images",
+				Remediation: "use an EKS-A release that publishes FIPS images or disable fips",
+				Err:         validations.ValidateFIPSImages(u.Opts.Spec, u.Opts.Provider),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate templateOverrides only override paths allowed by the provider",
+				Remediation: "remove the templateOverrides patches for kinds or paths not supported by the provider",
+				Err:         validations.ValidateTemplateOverrides(u.Opts.Spec, u.Opts.Provider),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate certificate for registry mirror",
@@ -159,6 +188,50 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) []validat
 				}
 			})
 	}
+	if !u.Opts.SkippedValidations[validations.DeprecatedAPIs] && u.Opts.APIScanner != nil {
+		upgradeValidations = append(
+			upgradeValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate workloads don't use APIs removed in the new kubernetes version",
+					Remediation: fmt.Sprintf("migrate the objects to the replacement APIs, or use the --skip-validations=%s flag to proceed with the upgrade", validations.DeprecatedAPIs),
+					Err:         ValidateDeprecatedAPIs(ctx, k, u.Opts.APIScanner, u.Opts.Spec.Cluster, u.Opts.WorkloadCluster, u.Opts.ManagementCluster),
+				}
+			})
+	}
+	if u.Opts.EtcdDiskBenchmark != nil {
+		upgradeValidations = append(
+			upgradeValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate etcd disk fdatasync latency",
+					Remediation: fmt.Sprintf("move the etcd data of the control plane nodes to disks with a 99th percentile fdatasync latency under %s, like local SSDs", etcdbenchmark.MaxFdatasyncLatency),
+					Err:         ValidateEtcdDiskLatency(ctx, u.Opts.EtcdDiskBenchmark, u.Opts.Spec.Cluster, u.Opts.WorkloadCluster),
+				}
+			})
+	}
+	if u.Opts.MTUProber != nil {
+		upgradeValidations = append(
+			upgradeValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate the network MTU between nodes",
+					Remediation: "set clusterNetwork.mtu to the MTU supported by the network between the nodes, accounting for any VPN or overlay underlay",
+					Err:         ValidateNodeMTU(ctx, u.Opts.MTUProber, u.Opts.Spec.Cluster, u.Opts.WorkloadCluster),
+				}
+			})
+	}
+	if u.Opts.ClockSkew != nil {
+		upgradeValidations = append(
+			upgradeValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate the node clocks are in sync",
+					Remediation: "configure NTP servers with hostOSConfiguration.ntpConfiguration in the machine configs and make sure they are reachable from the nodes",
+					Err:         u.Opts.ClockSkew.ValidateClockSkew(ctx, u.Opts.WorkloadCluster),
+				}
+			})
+	}
 	return upgradeValidations
 }
 
@@ -1,14 +1,54 @@
 package validations
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/etcdbenchmark"
+	"github.com/aws/eks-anywhere/pkg/ipconflict"
+	"github.com/aws/eks-anywhere/pkg/networking/mtu"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
+const (
+	httpClientTimeout = 30 * time.Second
+	dialTimeout       = 10 * time.Second
+)
+
+// DeprecatedAPIScanner looks for objects in a cluster using APIs removed between two Kubernetes versions.
+type DeprecatedAPIScanner interface {
+	Scan(ctx context.Context, cluster *types.Cluster, from, to v1alpha1.KubernetesVersion) ([]deprecatedapis.Finding, error)
+}
+
+// EtcdDiskBenchmark measures the disk latency of the etcd members of a cluster.
+type EtcdDiskBenchmark interface {
+	Run(ctx context.Context, cluster *types.Cluster) ([]etcdbenchmark.Result, error)
+}
+
+// MTUProber checks the MTU supported by the network between the nodes of a cluster.
+type MTUProber interface {
+	Probe(ctx context.Context, cluster *types.Cluster, size int) ([]mtu.Result, error)
+}
+
+// ClockSkewValidator validates the clocks of the nodes of a cluster are in sync.
+type ClockSkewValidator interface {
+	ValidateClockSkew(ctx context.Context, cluster *types.Cluster) error
+}
+
+// IPConflictDetector checks the IPs a new cluster requires aren't used by other hosts.
+type IPConflictDetector interface {
+	Detect(ctx context.Context, clusterName string, ips []ipconflict.IP) error
+}
+
 type Opts struct {
 	Kubectl            KubectlClient
 	Spec               *cluster.Spec
@@ -16,6 +56,13 @@ type Opts struct {
 	ManagementCluster  *types.Cluster
 	Provider           providers.Provider
 	TLSValidator       TlsValidator
+	HTTPClient         HTTPClient
+	Dialer             Dialer
+	APIScanner         DeprecatedAPIScanner
+	EtcdDiskBenchmark  EtcdDiskBenchmark
+	MTUProber          MTUProber
+	ClockSkew          ClockSkewValidator
+	IPConflicts        IPConflictDetector
 	CliConfig          *config.CliConfig
 	SkippedValidations map[string]bool
 	CliVersion         string
@@ -25,6 +72,12 @@ func (o *Opts) SetDefaults() {
 	if o.TLSValidator == nil {
 		o.TLSValidator = crypto.NewTlsValidator()
 	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: httpClientTimeout}
+	}
+	if o.Dialer == nil {
+		o.Dialer = &net.Dialer{Timeout: dialTimeout}
+	}
 	if o.CliVersion == "" {
 		o.CliVersion = version.Get().GitVersion
 	}
@@ -0,0 +1,138 @@
+package velero
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// DefaultNamespace is the namespace Velero is installed in by default.
+	DefaultNamespace = "velero"
+
+	deploymentName = "velero"
+	backupTimeout  = 2 * time.Hour
+	backupBackoff  = 10 * time.Second
+
+	phaseCompleted        = "Completed"
+	phaseFailed           = "Failed"
+	phasePartiallyFailed  = "PartiallyFailed"
+	phaseFailedValidation = "FailedValidation"
+)
+
+// ClientFactory builds Kubernetes clients.
+type ClientFactory interface {
+	// BuildClientFromKubeconfig builds a Kubernetes client from a kubeconfig file.
+	BuildClientFromKubeconfig(kubeconfigPath string) (kubernetes.Client, error)
+}
+
+// Backup creates Velero backups of the workloads running in a cluster.
+type Backup struct {
+	clientFactory ClientFactory
+	namespace     string
+	retrier       *retrier.Retrier
+	now           func() time.Time
+}
+
+// BackupOpt allows to customize a Backup on construction.
+type BackupOpt func(*Backup)
+
+// WithRetrier sets the retrier used to wait for the backups to finish.
+func WithRetrier(r *retrier.Retrier) BackupOpt {
+	return func(b *Backup) {
+		b.retrier = r
+	}
+}
+
+// WithClock sets the function used to get the current time, which is used in the backups names.
+func WithClock(now func() time.Time) BackupOpt {
+	return func(b *Backup) {
+		b.now = now
+	}
+}
+
+// NewBackup builds a Backup for Velero installed in namespace.
+func NewBackup(clientFactory ClientFactory, namespace string, opts ...BackupOpt) *Backup {
+	b := &Backup{
+		clientFactory: clientFactory,
+		namespace:     namespace,
+		retrier:       retrier.New(backupTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(backupBackoff))),
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Backup creates a Velero backup of all the namespaces in the cluster and waits for it to complete.
+// It fails if Velero, usually installed as a curated package, is not running in the cluster.
+func (b *Backup) Backup(ctx context.Context, cluster *types.Cluster) error {
+	client, err := b.clientFactory.BuildClientFromKubeconfig(cluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	if err = client.Get(ctx, deploymentName, b.namespace, &appsv1.Deployment{}); apierrors.IsNotFound(err) {
+		return fmt.Errorf("velero is not installed in namespace %s of cluster %s", b.namespace, cluster.Name)
+	} else if err != nil {
+		return fmt.Errorf("checking velero installation: %v", err)
+	}
+
+	backup := newBackupObject(b.namespace, fmt.Sprintf("eksa-pre-upgrade-%s-%s", cluster.Name, b.now().UTC().Format("20060102150405")))
+	logger.Info("Creating velero backup", "backup", backup.GetName())
+	if err = client.Create(ctx, backup); err != nil {
+		return fmt.Errorf("creating velero backup: %v", err)
+	}
+
+	return b.waitForCompletion(ctx, client, backup.GetName())
+}
+
+func (b *Backup) waitForCompletion(ctx context.Context, client kubernetes.Client, name string) error {
+	var backupErr error
+	err := b.retrier.Retry(func() error {
+		backup := newBackupObject(b.namespace, name)
+		if err := client.Get(ctx, name, b.namespace, backup); err != nil {
+			return err
+		}
+
+		phase, _, _ := unstructured.NestedString(backup.Object, "status", "phase")
+		switch phase {
+		case phaseCompleted:
+			return nil
+		case phaseFailed, phasePartiallyFailed, phaseFailedValidation:
+			backupErr = fmt.Errorf("velero backup %s finished with phase %s, check it with 'velero backup describe %s -n %s'", name, phase, name, b.namespace)
+			return nil
+		}
+
+		return fmt.Errorf("velero backup %s is not completed, phase: %s", name, phase)
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for velero backup: %v", err)
+	}
+
+	return backupErr
+}
+
+func newBackupObject(namespace, name string) *unstructured.Unstructured {
+	backup := &unstructured.Unstructured{}
+	backup.SetAPIVersion("velero.io/v1")
+	backup.SetKind("Backup")
+	backup.SetNamespace(namespace)
+	backup.SetName(name)
+	backup.Object["spec"] = map[string]interface{}{
+		"includedNamespaces": []interface{}{"*"},
+	}
+
+	return backup
+}
@@ -0,0 +1,129 @@
+package velero_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	kubemocks "github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/velero"
+	"github.com/aws/eks-anywhere/pkg/velero/mocks"
+)
+
+const backupName = "eksa-pre-upgrade-my-cluster-20231010120000"
+
+type backupTest struct {
+	*WithT
+	ctx     context.Context
+	client  *kubemocks.MockClient
+	cluster *types.Cluster
+	backup  *velero.Backup
+}
+
+func newBackupTest(t *testing.T) *backupTest {
+	ctrl := gomock.NewController(t)
+	client := kubemocks.NewMockClient(ctrl)
+	factory := mocks.NewMockClientFactory(ctrl)
+	cluster := &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"}
+	factory.EXPECT().BuildClientFromKubeconfig(cluster.KubeconfigFile).Return(client, nil)
+
+	return &backupTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  client,
+		cluster: cluster,
+		backup: velero.NewBackup(factory, velero.DefaultNamespace,
+			velero.WithRetrier(retrier.NewWithMaxRetries(3, 0)),
+			velero.WithClock(func() time.Time { return time.Date(2023, 10, 10, 12, 0, 0, 0, time.UTC) }),
+		),
+	}
+}
+
+func (tt *backupTest) expectVeleroInstalled() {
+	tt.client.EXPECT().Get(tt.ctx, "velero", "velero", &appsv1.Deployment{}).Return(nil)
+}
+
+func (tt *backupTest) expectCreateBackup() {
+	tt.client.EXPECT().Create(tt.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, obj kubernetes.Object) error {
+		backup := obj.(*unstructured.Unstructured)
+		tt.Expect(backup.GetAPIVersion()).To(Equal("velero.io/v1"))
+		tt.Expect(backup.GetKind()).To(Equal("Backup"))
+		tt.Expect(backup.GetNamespace()).To(Equal("velero"))
+		tt.Expect(backup.GetName()).To(Equal(backupName))
+		tt.Expect(backup.Object["spec"]).To(Equal(map[string]interface{}{"includedNamespaces": []interface{}{"*"}}))
+		return nil
+	})
+}
+
+func (tt *backupTest) expectBackupPhase(phase string) *gomock.Call {
+	return tt.client.EXPECT().Get(tt.ctx, backupName, "velero", gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, obj kubernetes.Object) error {
+		backup := obj.(*unstructured.Unstructured)
+		if phase != "" {
+			return unstructured.SetNestedField(backup.Object, phase, "status", "phase")
+		}
+		return nil
+	})
+}
+
+func TestBackupSuccess(t *testing.T) {
+	tt := newBackupTest(t)
+	tt.expectVeleroInstalled()
+	tt.expectCreateBackup()
+	gomock.InOrder(
+		tt.expectBackupPhase(""),
+		tt.expectBackupPhase("InProgress"),
+		tt.expectBackupPhase("Completed"),
+	)
+
+	tt.Expect(tt.backup.Backup(tt.ctx, tt.cluster)).To(Succeed())
+}
+
+func TestBackupFailed(t *testing.T) {
+	tt := newBackupTest(t)
+	tt.expectVeleroInstalled()
+	tt.expectCreateBackup()
+	tt.expectBackupPhase("PartiallyFailed")
+
+	tt.Expect(tt.backup.Backup(tt.ctx, tt.cluster)).To(MatchError(
+		"velero backup " + backupName + " finished with phase PartiallyFailed, check it with 'velero backup describe " + backupName + " -n velero'",
+	))
+}
+
+func TestBackupTimeout(t *testing.T) {
+	tt := newBackupTest(t)
+	tt.expectVeleroInstalled()
+	tt.expectCreateBackup()
+	tt.expectBackupPhase("InProgress").Times(3)
+
+	tt.Expect(tt.backup.Backup(tt.ctx, tt.cluster)).To(MatchError(
+		"waiting for velero backup: velero backup " + backupName + " is not completed, phase: InProgress",
+	))
+}
+
+func TestBackupVeleroNotInstalled(t *testing.T) {
+	tt := newBackupTest(t)
+	tt.client.EXPECT().Get(tt.ctx, "velero", "velero", &appsv1.Deployment{}).Return(
+		apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "velero"),
+	)
+
+	tt.Expect(tt.backup.Backup(tt.ctx, tt.cluster)).To(MatchError("velero is not installed in namespace velero of cluster my-cluster"))
+}
+
+func TestBackupCreateError(t *testing.T) {
+	tt := newBackupTest(t)
+	tt.expectVeleroInstalled()
+	tt.client.EXPECT().Create(tt.ctx, gomock.Any()).Return(errors.New("forbidden"))
+
+	tt.Expect(tt.backup.Backup(tt.ctx, tt.cluster)).To(MatchError("creating velero backup: forbidden"))
+}
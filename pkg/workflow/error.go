@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 // ErrorHandler is a function called when a workflow experiences an error during execution. The
@@ -19,3 +20,26 @@ type ErrDuplicateTaskName struct {
 func (e ErrDuplicateTaskName) Error() string {
 	return fmt.Sprintf("duplicate task name: %v", e.Name)
 }
+
+// ErrUnknownDependency indicates a task depends on a task that isn't part of the graph.
+type ErrUnknownDependency struct {
+	Task       TaskName
+	Dependency TaskName
+}
+
+func (e ErrUnknownDependency) Error() string {
+	return fmt.Sprintf("task %v depends on unknown task: %v", e.Task, e.Dependency)
+}
+
+// ErrCyclicDependency indicates the tasks in a graph depend on each other in a cycle.
+type ErrCyclicDependency struct {
+	Cycle []TaskName
+}
+
+func (e ErrCyclicDependency) Error() string {
+	names := make([]string, 0, len(e.Cycle))
+	for _, n := range e.Cycle {
+		names = append(names, string(n))
+	}
+	return fmt.Sprintf("cyclic task dependency: %v", strings.Join(names, " -> "))
+}
@@ -0,0 +1,280 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+// Checkpointer records completed tasks so a graph can skip them when re-executed.
+type Checkpointer interface {
+	// IsCompleted returns true if the task identified by name completed in a previous execution.
+	IsCompleted(name TaskName) bool
+
+	// TaskCompleted records the successful completion of the task identified by name. It may be
+	// called concurrently.
+	TaskCompleted(name TaskName) error
+}
+
+// GraphConfig is the configuration for constructing a Graph instance.
+type GraphConfig struct {
+	// ErrorHandler is handler called when the graph experiences an error. The original error is
+	// always returned from the graph's Execute.
+	// Optional. Defaults to a no-op handler.
+	ErrorHandler ErrorHandler
+
+	// MaxConcurrency is the maximum number of tasks run in parallel.
+	// Optional. Defaults to unbounded.
+	MaxConcurrency int
+
+	// Checkpointer is used to skip tasks completed in previous executions.
+	// Optional.
+	Checkpointer Checkpointer
+}
+
+// Graph is a workflow that executes tasks as a directed acyclic graph. Tasks declare the tasks
+// they depend on and are run as soon as all their dependencies have completed, so independent
+// tasks run concurrently.
+//
+// Because concurrently executed tasks can't share a single context chain, the contexts returned
+// by tasks are discarded. Every task receives the context passed to Execute.
+type Graph struct {
+	GraphConfig
+
+	tasks map[TaskName]*graphTask
+
+	// order keeps insertion order so execution is deterministic for tasks that become ready at
+	// the same time.
+	order []TaskName
+}
+
+type graphTask struct {
+	namedTask
+	dependsOn []TaskName
+	retrier   *retrier.Retrier
+}
+
+// GraphTaskOpt configures a task added to a Graph.
+type GraphTaskOpt func(*graphTask)
+
+// DependsOn declares the tasks that must complete before the task is run.
+func DependsOn(names ...TaskName) GraphTaskOpt {
+	return func(t *graphTask) {
+		t.dependsOn = append(t.dependsOn, names...)
+	}
+}
+
+// WithTaskRetrier configures r to retry the task when it fails.
+func WithTaskRetrier(r *retrier.Retrier) GraphTaskOpt {
+	return func(t *graphTask) {
+		t.retrier = r
+	}
+}
+
+// NewGraph initializes a Graph instance without any tasks.
+func NewGraph(cfg GraphConfig) *Graph {
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = nopErrorHandler
+	}
+
+	return &Graph{
+		GraphConfig: cfg,
+		tasks:       make(map[TaskName]*graphTask),
+	}
+}
+
+// AddTask adds t to the graph. Task names must be unique within a graph. Duplicate names will
+// receive an ErrDuplicateTaskName.
+func (g *Graph) AddTask(name TaskName, t Task, opts ...GraphTaskOpt) error {
+	if _, found := g.tasks[name]; found {
+		return ErrDuplicateTaskName{name}
+	}
+
+	task := &graphTask{namedTask: namedTask{Task: t, Name: name}}
+	for _, opt := range opts {
+		opt(task)
+	}
+
+	g.tasks[name] = task
+	g.order = append(g.order, name)
+	return nil
+}
+
+// Validate ensures all dependencies reference tasks in the graph and the graph has no cycles.
+func (g *Graph) Validate() error {
+	for _, name := range g.order {
+		for _, dep := range g.tasks[name].dependsOn {
+			if _, ok := g.tasks[dep]; !ok {
+				return ErrUnknownDependency{Task: name, Dependency: dep}
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[TaskName]int, len(g.tasks))
+	var path []TaskName
+	var visit func(name TaskName) error
+	visit = func(name TaskName) error {
+		switch state[name] {
+		case visiting:
+			return ErrCyclicDependency{Cycle: append(path, name)}
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range g.tasks[name].dependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range g.order {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Execute runs all tasks in the graph respecting their dependencies. When a task fails no new
+// tasks are started, running tasks are allowed to finish and the first error is returned.
+func (g *Graph) Execute(ctx context.Context) error {
+	if err := g.Validate(); err != nil {
+		return g.handleError(ctx, err)
+	}
+
+	pending := make(map[TaskName]int, len(g.tasks))
+	dependents := make(map[TaskName][]TaskName, len(g.tasks))
+	var ready []TaskName
+	for _, name := range g.order {
+		task := g.tasks[name]
+		pending[name] = len(task.dependsOn)
+		for _, dep := range task.dependsOn {
+			dependents[dep] = append(dependents[dep], name)
+		}
+		if len(task.dependsOn) == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	type result struct {
+		name TaskName
+		err  error
+	}
+	results := make(chan result)
+	running := 0
+	var firstErr error
+
+	for len(ready) > 0 || running > 0 {
+		for firstErr == nil && len(ready) > 0 && (g.MaxConcurrency <= 0 || running < g.MaxConcurrency) {
+			name := ready[0]
+			ready = ready[1:]
+			running++
+			go func(task *graphTask) {
+				// Send the result from a deferred call so Execute doesn't wait forever on tasks
+				// that exit their goroutine without returning, like tests calling t.FailNow.
+				err := fmt.Errorf("task %s exited without returning", task.Name)
+				defer func() {
+					results <- result{name: task.Name, err: err}
+				}()
+				err = g.runTask(ctx, task)
+			}(g.tasks[name])
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+
+		for _, dependent := range dependents[r.name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return g.handleError(ctx, firstErr)
+	}
+
+	return nil
+}
+
+func (g *Graph) runTask(ctx context.Context, task *graphTask) error {
+	if g.Checkpointer != nil && g.Checkpointer.IsCompleted(task.Name) {
+		return nil
+	}
+
+	err := task.retrier.Retry(func() error {
+		_, err := task.RunTask(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("task %s: %w", task.Name, err)
+	}
+
+	if g.Checkpointer != nil {
+		if err := g.Checkpointer.TaskCompleted(task.Name); err != nil {
+			return fmt.Errorf("checkpointing task %s: %w", task.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *Graph) handleError(ctx context.Context, err error) error {
+	g.ErrorHandler(ctx, err)
+	return err
+}
+
+// completedTasks is a concurrency safe, in-memory Checkpointer.
+type completedTasks struct {
+	mu    sync.Mutex
+	names map[TaskName]struct{}
+}
+
+// NewInMemoryCheckpointer returns a Checkpointer that keeps completed tasks in memory. It's
+// useful for re-executing a graph within the same process.
+func NewInMemoryCheckpointer(completed ...TaskName) Checkpointer {
+	c := &completedTasks{names: make(map[TaskName]struct{}, len(completed))}
+	for _, n := range completed {
+		c.names[n] = struct{}{}
+	}
+	return c
+}
+
+func (c *completedTasks) IsCompleted(name TaskName) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.names[name]
+	return ok
+}
+
+func (c *completedTasks) TaskCompleted(name TaskName) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names[name] = struct{}{}
+	return nil
+}
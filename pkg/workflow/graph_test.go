@@ -0,0 +1,208 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	order []workflow.TaskName
+}
+
+func (r *recorder) task(name workflow.TaskName) workflow.Task {
+	return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.order = append(r.order, name)
+		return ctx, nil
+	})
+}
+
+func (r *recorder) indexOf(name workflow.TaskName) int {
+	for i, n := range r.order {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestGraphExecuteRespectsDependencies(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("bootstrap", rec.task("bootstrap"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("cni", rec.task("cni"), workflow.DependsOn("bootstrap"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("storage", rec.task("storage"), workflow.DependsOn("bootstrap"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("move", rec.task("move"), workflow.DependsOn("cni", "storage"))).To(gomega.Succeed())
+
+	g.Expect(graph.Execute(context.Background())).To(gomega.Succeed())
+
+	g.Expect(rec.order).To(gomega.HaveLen(4))
+	g.Expect(rec.indexOf("bootstrap")).To(gomega.Equal(0))
+	g.Expect(rec.indexOf("move")).To(gomega.Equal(3))
+}
+
+func TestGraphExecuteRunsIndependentTasksConcurrently(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	// Each task waits for the other to start, so the graph only completes if they run in parallel.
+	barrier := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		wg.Done()
+		wg.Wait()
+		return ctx, nil
+	})
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("task1", barrier)).To(gomega.Succeed())
+	g.Expect(graph.AddTask("task2", barrier)).To(gomega.Succeed())
+
+	done := make(chan error)
+	go func() { done <- graph.Execute(context.Background()) }()
+
+	select {
+	case err := <-done:
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	case <-time.After(5 * time.Second):
+		t.Fatal("independent tasks were not run concurrently")
+	}
+}
+
+func TestGraphExecuteMaxConcurrency(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var running, maxRunning int32
+	task := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return ctx, nil
+	})
+
+	graph := workflow.NewGraph(workflow.GraphConfig{MaxConcurrency: 2})
+	for _, name := range []workflow.TaskName{"a", "b", "c", "d", "e"} {
+		g.Expect(graph.AddTask(name, task)).To(gomega.Succeed())
+	}
+
+	g.Expect(graph.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(&maxRunning)).To(gomega.BeNumerically("<=", 2))
+}
+
+func TestGraphExecuteStopsOnError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+	expectErr := errors.New("install failed")
+	var handled error
+
+	graph := workflow.NewGraph(workflow.GraphConfig{
+		ErrorHandler: func(_ context.Context, err error) { handled = err },
+	})
+	g.Expect(graph.AddTask("cni", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, expectErr
+	}))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("move", rec.task("move"), workflow.DependsOn("cni"))).To(gomega.Succeed())
+
+	err := graph.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("task cni: install failed")))
+	g.Expect(errors.Is(err, expectErr)).To(gomega.BeTrue())
+	g.Expect(handled).To(gomega.Equal(err))
+	g.Expect(rec.order).To(gomega.BeEmpty())
+}
+
+func TestGraphExecuteTaskExitsGoroutine(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("cni", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		runtime.Goexit()
+		return ctx, nil
+	}))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("move", rec.task("move"), workflow.DependsOn("cni"))).To(gomega.Succeed())
+
+	err := graph.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError("task cni exited without returning"))
+	g.Expect(rec.order).To(gomega.BeEmpty())
+}
+
+func TestGraphExecuteRetriesTask(t *testing.T) {
+	g := gomega.NewWithT(t)
+	attempts := 0
+	task := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		attempts++
+		if attempts < 3 {
+			return ctx, errors.New("transient")
+		}
+		return ctx, nil
+	})
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("flaky", task, workflow.WithTaskRetrier(retrier.NewWithMaxRetries(3, 0)))).To(gomega.Succeed())
+
+	g.Expect(graph.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(attempts).To(gomega.Equal(3))
+}
+
+func TestGraphExecuteSkipsCheckpointedTasks(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+	checkpointer := workflow.NewInMemoryCheckpointer("bootstrap")
+
+	graph := workflow.NewGraph(workflow.GraphConfig{Checkpointer: checkpointer})
+	g.Expect(graph.AddTask("bootstrap", rec.task("bootstrap"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("cni", rec.task("cni"), workflow.DependsOn("bootstrap"))).To(gomega.Succeed())
+
+	g.Expect(graph.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(rec.order).To(gomega.Equal([]workflow.TaskName{"cni"}))
+	g.Expect(checkpointer.IsCompleted("cni")).To(gomega.BeTrue())
+}
+
+func TestGraphAddTaskDuplicateName(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("task", rec.task("task"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("task", rec.task("task"))).To(gomega.MatchError(workflow.ErrDuplicateTaskName{Name: "task"}))
+}
+
+func TestGraphValidateUnknownDependency(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("cni", rec.task("cni"), workflow.DependsOn("bootstrap"))).To(gomega.Succeed())
+
+	g.Expect(graph.Execute(context.Background())).To(gomega.MatchError("task cni depends on unknown task: bootstrap"))
+	g.Expect(rec.order).To(gomega.BeEmpty())
+}
+
+func TestGraphValidateCycle(t *testing.T) {
+	g := gomega.NewWithT(t)
+	rec := &recorder{}
+
+	graph := workflow.NewGraph(workflow.GraphConfig{})
+	g.Expect(graph.AddTask("a", rec.task("a"), workflow.DependsOn("c"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("b", rec.task("b"), workflow.DependsOn("a"))).To(gomega.Succeed())
+	g.Expect(graph.AddTask("c", rec.task("c"), workflow.DependsOn("b"))).To(gomega.Succeed())
+
+	g.Expect(graph.Validate()).To(gomega.MatchError("cyclic task dependency: a -> c -> b -> a"))
+}
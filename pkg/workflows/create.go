@@ -3,16 +3,23 @@ package workflows
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dns"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflow"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -24,14 +31,44 @@ type Create struct {
 	writer           filewriter.FileWriter
 	eksdInstaller    interfaces.EksdInstaller
 	packageInstaller interfaces.PackageInstaller
+	clockSkew        interfaces.ClockSkewValidator
+	dnsRegistrar     interfaces.DNSRegistrar
+	recorder         interfaces.OperationRecorder
+}
+
+// CreateOpt allows to customize a Create on construction.
+type CreateOpt func(*Create)
+
+// WithClockSkewValidator makes the create validate the clocks of the new cluster nodes are in
+// sync once the workload cluster is up.
+func WithClockSkewValidator(validator interfaces.ClockSkewValidator) CreateOpt {
+	return func(c *Create) {
+		c.clockSkew = validator
+	}
+}
+
+// WithDNSRegistrar makes the create register the DNS record of the control plane endpoint once
+// the workload cluster is up.
+func WithDNSRegistrar(registrar interfaces.DNSRegistrar) CreateOpt {
+	return func(c *Create) {
+		c.dnsRegistrar = registrar
+	}
+}
+
+// WithCreateOperationRecorder makes the create record the operation as a ClusterOperation.
+func WithCreateOperationRecorder(recorder interfaces.OperationRecorder) CreateOpt {
+	return func(c *Create) {
+		c.recorder = recorder
+	}
 }
 
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	clusterManager interfaces.ClusterManager, gitOpsManager interfaces.GitOpsManager,
 	writer filewriter.FileWriter, eksdInstaller interfaces.EksdInstaller,
 	packageInstaller interfaces.PackageInstaller,
+	opts ...CreateOpt,
 ) *Create {
-	return &Create{
+	c := &Create{
 		bootstrapper:     bootstrapper,
 		provider:         provider,
 		clusterManager:   clusterManager,
@@ -40,6 +77,11 @@ func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 		eksdInstaller:    eksdInstaller,
 		packageInstaller: packageInstaller,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup bool) error {
@@ -51,33 +93,60 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		}
 	}
 	commandContext := &task.CommandContext{
-		Bootstrapper:     c.bootstrapper,
-		Provider:         c.provider,
-		ClusterManager:   c.clusterManager,
-		GitOpsManager:    c.gitOpsManager,
-		ClusterSpec:      clusterSpec,
-		Writer:           c.writer,
-		Validations:      validator,
-		EksdInstaller:    c.eksdInstaller,
-		PackageInstaller: c.packageInstaller,
+		Bootstrapper:       c.bootstrapper,
+		Provider:           c.provider,
+		ClusterManager:     c.clusterManager,
+		GitOpsManager:      c.gitOpsManager,
+		ClusterSpec:        clusterSpec,
+		Writer:             c.writer,
+		Validations:        validator,
+		EksdInstaller:      c.eksdInstaller,
+		PackageInstaller:   c.packageInstaller,
+		ClockSkewValidator: c.clockSkew,
+		DNSRegistrar:       c.dnsRegistrar,
 	}
 
 	if clusterSpec.ManagementCluster != nil {
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	err := task.NewTaskRunner(&SetAndValidateTask{}, c.writer).RunTask(ctx, commandContext)
+	record := StartOperationRecord(ctx, c.recorder, clusterSpec, v1alpha1.CreateClusterOperation, clusterSpec.ManagementCluster)
+
+	var runnerOpts []task.TaskRunnerOpt
+	// A force cleanup deletes the bootstrap cluster a checkpoint might reference, so the run
+	// always starts from scratch in that case.
+	if features.IsActive(features.CheckpointEnabled()) && !forceCleanup {
+		runnerOpts = append(runnerOpts, task.WithCheckpointFile())
+	}
+	err := task.NewTaskRunner(&SetAndValidateTask{}, c.writer, runnerOpts...).RunTask(ctx, commandContext)
+
+	// A new management cluster holds its own EKS-A objects, so the operation can only be
+	// recorded once it has been created.
+	holder := clusterSpec.ManagementCluster
+	if holder == nil && err == nil {
+		holder = commandContext.WorkloadCluster
+	}
+	record.Complete(ctx, holder, err)
 
 	return err
 }
 
 // task related entities
 
-type CreateBootStrapClusterTask struct{}
+type CreateBootStrapClusterTask struct {
+	bootstrapCluster *types.Cluster
+}
 
 type SetAndValidateTask struct{}
 
-type CreateWorkloadClusterTask struct{}
+type CreateWorkloadClusterTask struct {
+	workloadCluster *types.Cluster
+	progress        *stepsProgress
+}
+
+type RegisterControlPlaneDNSTask struct{}
+
+type ValidateClockSkewTask struct{}
 
 type InstallResourcesOnManagementTask struct{}
 
@@ -106,6 +175,7 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 				return &CollectMgmtClusterDiagnosticsTask{}
 			}
 		}
+		s.bootstrapCluster = commandContext.BootstrapCluster
 
 		return &CreateWorkloadClusterTask{}
 	}
@@ -149,6 +219,7 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 		commandContext.SetError(err)
 		return &CollectMgmtClusterDiagnosticsTask{}
 	}
+	s.bootstrapCluster = bootstrapCluster
 
 	return &CreateWorkloadClusterTask{}
 }
@@ -158,11 +229,19 @@ func (s *CreateBootStrapClusterTask) Name() string {
 }
 
 func (s *CreateBootStrapClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	s.bootstrapCluster = &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.bootstrapCluster); err != nil {
+		return nil, err
+	}
+	logger.Info("Reusing existing bootstrap cluster", "cluster", s.bootstrapCluster.Name)
+	commandContext.BootstrapCluster = s.bootstrapCluster
+	return &CreateWorkloadClusterTask{}, nil
 }
 
 func (s *CreateBootStrapClusterTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: s.bootstrapCluster,
+	}
 }
 
 // SetAndValidateTask implementation
@@ -185,9 +264,13 @@ func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.Comma
 func (s *SetAndValidateTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
 		func() *validations.ValidationResult {
+			err := commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec)
+			if err != nil {
+				err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+			}
 			return &validations.ValidationResult{
 				Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec),
+				Err:  err,
 			}
 		},
 	}
@@ -197,12 +280,23 @@ func (s *SetAndValidateTask) Name() string {
 	return "setup-validate"
 }
 
+// Restore only runs the provider setup. Preflight validations are skipped since they passed in the
+// previous run and some of them, like the cluster name uniqueness check, fail once the cluster
+// objects exist.
 func (s *SetAndValidateTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec); err != nil {
+		err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+		commandContext.SetError(err)
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()))
+	return &CreateBootStrapClusterTask{}, nil
 }
 
 func (s *SetAndValidateTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // CreateWorkloadClusterTask implementation
@@ -223,65 +317,294 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		return &CollectDiagnosticsTask{}
 	}
 
-	logger.V(4).Info("Installing machine health checks on bootstrap cluster")
-	err = commandContext.ClusterManager.InstallMachineHealthChecks(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+	if s.progress == nil {
+		s.progress = newStepsProgress()
 	}
-
-	if err = commandContext.ClusterManager.RunPostCreateWorkloadCluster(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.ClusterSpec); err != nil {
+	if err = runSteps(ctx, s.progress, s.workloadClusterSteps(commandContext, workloadCluster)...); err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+	s.workloadCluster = workloadCluster
 
-	if commandContext.ClusterSpec.AWSIamConfig != nil {
-		logger.Info("Installing aws-iam-authenticator on workload cluster")
-		err = commandContext.ClusterManager.InstallAwsIamAuth(ctx, commandContext.BootstrapCluster, workloadCluster, commandContext.ClusterSpec)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
-	}
+	return afterCreateWorkloadCluster(commandContext)
+}
 
-	if !commandContext.BootstrapCluster.ExistingManagement {
-		logger.Info("Creating EKS-A namespace")
-		err = commandContext.ClusterManager.CreateEKSANamespace(ctx, workloadCluster)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+func afterCreateWorkloadCluster(commandContext *task.CommandContext) task.Task {
+	if commandContext.DNSRegistrar != nil {
+		return &RegisterControlPlaneDNSTask{}
+	}
 
-		logger.Info("Installing cluster-api providers on workload cluster")
-		err = commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster, commandContext.Provider)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+	return afterRegisterControlPlaneDNS(commandContext)
+}
 
-		logger.Info("Installing EKS-A secrets on workload cluster")
-		err := commandContext.Provider.UpdateSecrets(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+func afterRegisterControlPlaneDNS(commandContext *task.CommandContext) task.Task {
+	if commandContext.ClockSkewValidator != nil {
+		return &ValidateClockSkewTask{}
 	}
 
 	return &InstallResourcesOnManagementTask{}
 }
 
+const (
+	imageWarmCacheStep      workflow.TaskName = "image-warm-cache"
+	machineHealthChecksStep workflow.TaskName = "machine-health-checks"
+	workloadNodesReadyStep  workflow.TaskName = "workload-nodes-ready"
+	awsIamAuthStep          workflow.TaskName = "aws-iam-auth"
+	accessEntriesStep       workflow.TaskName = "access-entries"
+	eksaNamespaceStep       workflow.TaskName = "eksa-namespace"
+	capiStep                workflow.TaskName = "capi"
+	eksaSecretsStep         workflow.TaskName = "eksa-secrets"
+	certManagerStep         workflow.TaskName = "cert-manager"
+	policyEngineStep        workflow.TaskName = "policy-engine"
+)
+
+// workloadClusterSteps returns the steps run once the workload cluster has networking. Steps that
+// don't apply to the cluster spec succeed without doing anything so other steps can depend on them.
+// The policy engine goes last so its admission policies can't reject the other add-ons.
+func (s *CreateWorkloadClusterTask) workloadClusterSteps(commandContext *task.CommandContext, workloadCluster *types.Cluster) []step {
+	spec := commandContext.ClusterSpec
+	ownsEKSAObjects := !commandContext.BootstrapCluster.ExistingManagement
+
+	return []step{
+		{
+			name: imageWarmCacheStep,
+			run: func(ctx context.Context) error {
+				if spec.Cluster.Spec.ImageWarmCache == nil {
+					return nil
+				}
+				logger.Info("Installing image warm cache on workload cluster")
+				return commandContext.ClusterManager.InstallImageWarmCache(ctx, spec, workloadCluster)
+			},
+		},
+		{
+			name: machineHealthChecksStep,
+			run: func(ctx context.Context) error {
+				logger.V(4).Info("Installing machine health checks on bootstrap cluster")
+				return commandContext.ClusterManager.InstallMachineHealthChecks(ctx, spec, commandContext.BootstrapCluster)
+			},
+		},
+		{
+			name: workloadNodesReadyStep,
+			run: func(ctx context.Context) error {
+				return commandContext.ClusterManager.RunPostCreateWorkloadCluster(ctx, commandContext.BootstrapCluster, workloadCluster, spec)
+			},
+		},
+		{
+			name: awsIamAuthStep,
+			run: func(ctx context.Context) error {
+				if spec.AWSIamConfig == nil {
+					return nil
+				}
+				logger.Info("Installing aws-iam-authenticator on workload cluster")
+				return commandContext.ClusterManager.InstallAwsIamAuth(ctx, commandContext.BootstrapCluster, workloadCluster, spec)
+			},
+		},
+		{
+			name: accessEntriesStep,
+			run: func(ctx context.Context) error {
+				if len(spec.Cluster.Spec.AccessEntries) == 0 {
+					return nil
+				}
+				logger.Info("Installing access entries on workload cluster")
+				return commandContext.ClusterManager.InstallAccessEntries(ctx, spec, workloadCluster)
+			},
+		},
+		{
+			name: eksaNamespaceStep,
+			run: func(ctx context.Context) error {
+				if !ownsEKSAObjects {
+					return nil
+				}
+				logger.Info("Creating EKS-A namespace")
+				return commandContext.ClusterManager.CreateEKSANamespace(ctx, workloadCluster)
+			},
+		},
+		{
+			name: capiStep,
+			run: func(ctx context.Context) error {
+				if !ownsEKSAObjects {
+					return nil
+				}
+				logger.Info("Installing cluster-api providers on workload cluster")
+				return commandContext.ClusterManager.InstallCAPI(ctx, spec, workloadCluster, commandContext.Provider)
+			},
+			dependsOn: []workflow.TaskName{eksaNamespaceStep},
+		},
+		{
+			name: eksaSecretsStep,
+			run: func(ctx context.Context) error {
+				if !ownsEKSAObjects {
+					return nil
+				}
+				logger.Info("Installing EKS-A secrets on workload cluster")
+				return commandContext.Provider.UpdateSecrets(ctx, workloadCluster, spec)
+			},
+			dependsOn: []workflow.TaskName{capiStep},
+		},
+		{
+			name: certManagerStep,
+			run: func(ctx context.Context) error {
+				if spec.Cluster.Spec.CertManager == nil {
+					return nil
+				}
+				logger.Info("Installing cert-manager on workload cluster")
+				return commandContext.ClusterManager.InstallCertManager(ctx, spec, workloadCluster)
+			},
+			dependsOn: []workflow.TaskName{capiStep},
+		},
+		{
+			name: policyEngineStep,
+			run: func(ctx context.Context) error {
+				if spec.Cluster.Spec.PolicyEngine == nil {
+					return nil
+				}
+				logger.Info("Installing policy engine on workload cluster")
+				return commandContext.ClusterManager.InstallPolicyEngine(ctx, spec, workloadCluster)
+			},
+			dependsOn: []workflow.TaskName{
+				imageWarmCacheStep, machineHealthChecksStep, workloadNodesReadyStep, awsIamAuthStep,
+				accessEntriesStep, eksaSecretsStep, certManagerStep,
+			},
+		},
+	}
+}
+
 func (s *CreateWorkloadClusterTask) Name() string {
 	return "workload-cluster-init"
 }
 
 func (s *CreateWorkloadClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	s.workloadCluster = &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.workloadCluster); err != nil {
+		return nil, err
+	}
+	commandContext.WorkloadCluster = s.workloadCluster
+	return afterCreateWorkloadCluster(commandContext), nil
 }
 
 func (s *CreateWorkloadClusterTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: s.workloadCluster,
+	}
+}
+
+// Progress returns the workload cluster steps completed before the task failed.
+func (s *CreateWorkloadClusterTask) Progress() *task.CompletedTask {
+	if s.progress == nil {
+		return nil
+	}
+	return &task.CompletedTask{
+		Checkpoint: s.progress,
+	}
+}
+
+// ResumeProgress makes the task skip the workload cluster steps completed by a previous run.
+func (s *CreateWorkloadClusterTask) ResumeProgress(progress *task.CompletedTask) error {
+	p, err := restoreStepsProgress(progress)
+	if err != nil {
+		return err
+	}
+	s.progress = p
+	return nil
+}
+
+// RegisterControlPlaneDNSTask implementation
+
+func (s *RegisterControlPlaneDNSTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Registering control plane endpoint DNS record")
+	// The DNS credentials are stored in the cluster that will hold the EKS-A objects, so its controller
+	// keeps the record updated. The EKS-A namespace already exists in the workload cluster at this point.
+	managementCluster := commandContext.WorkloadCluster
+	if commandContext.BootstrapCluster.ExistingManagement {
+		managementCluster = commandContext.BootstrapCluster
+	}
+
+	if err := commandContext.DNSRegistrar.RegisterControlPlaneEndpoint(ctx, managementCluster, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	if err := writeEndpointDNSKubeconfig(commandContext); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	return afterRegisterControlPlaneDNS(commandContext)
+}
+
+// writeEndpointDNSKubeconfig writes a copy of the workload cluster kubeconfig that uses the DNS name
+// of the control plane endpoint. The CLI keeps using the one with the IP, since the record might take
+// a while to propagate to the DNS servers of the admin machine.
+func writeEndpointDNSKubeconfig(commandContext *task.CommandContext) error {
+	record, err := dns.ControlPlaneRecord(commandContext.ClusterSpec.Cluster)
+	if err != nil || record == nil {
+		return err
+	}
+
+	content, err := os.ReadFile(commandContext.WorkloadCluster.KubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("reading workload kubeconfig: %v", err)
+	}
+
+	content, err = dns.Kubeconfig(content, record.Name)
+	if err != nil {
+		return err
+	}
+
+	path, err := commandContext.Writer.Write(
+		kubeconfig.FormatEndpointDNSKubeconfigFilename(commandContext.ClusterSpec.Cluster.Name),
+		content,
+		filewriter.PersistentFile,
+		filewriter.Permission0600,
+	)
+	if err != nil {
+		return fmt.Errorf("writing control plane endpoint dns kubeconfig: %v", err)
+	}
+	logger.V(3).Info("Control plane endpoint DNS kubeconfig written", "path", path)
+
 	return nil
 }
 
+func (s *RegisterControlPlaneDNSTask) Name() string {
+	return "register-control-plane-dns"
+}
+
+func (s *RegisterControlPlaneDNSTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return afterRegisterControlPlaneDNS(commandContext), nil
+}
+
+func (s *RegisterControlPlaneDNSTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+// ValidateClockSkewTask implementation
+
+func (s *ValidateClockSkewTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Validating the node clocks are in sync")
+	if err := commandContext.ClockSkewValidator.ValidateClockSkew(ctx, commandContext.WorkloadCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	return &InstallResourcesOnManagementTask{}
+}
+
+func (s *ValidateClockSkewTask) Name() string {
+	return "validate-clock-skew"
+}
+
+func (s *ValidateClockSkewTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &InstallResourcesOnManagementTask{}, nil
+}
+
+func (s *ValidateClockSkewTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
 // InstallResourcesOnManagement implementation.
 func (s *InstallResourcesOnManagementTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster.ExistingManagement {
@@ -301,11 +624,13 @@ func (s *InstallResourcesOnManagementTask) Name() string {
 }
 
 func (s *InstallResourcesOnManagementTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &MoveClusterManagementTask{}, nil
 }
 
 func (s *InstallResourcesOnManagementTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // MoveClusterManagementTask implementation
@@ -329,11 +654,13 @@ func (s *MoveClusterManagementTask) Name() string {
 }
 
 func (s *MoveClusterManagementTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &InstallEksaComponentsTask{}, nil
 }
 
 func (s *MoveClusterManagementTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // InstallEksaComponentsTask implementation
@@ -385,11 +712,13 @@ func (s *InstallEksaComponentsTask) Name() string {
 }
 
 func (s *InstallEksaComponentsTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &InstallGitOpsManagerTask{}, nil
 }
 
 func (s *InstallEksaComponentsTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // InstallGitOpsManagerTask implementation
@@ -410,11 +739,13 @@ func (s *InstallGitOpsManagerTask) Name() string {
 }
 
 func (s *InstallGitOpsManagerTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &WriteClusterConfigTask{}, nil
 }
 
 func (s *InstallGitOpsManagerTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *WriteClusterConfigTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -424,6 +755,13 @@ func (s *WriteClusterConfigTask) Run(ctx context.Context, commandContext *task.C
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+
+	targetCluster := commandContext.WorkloadCluster
+	if commandContext.BootstrapCluster.ExistingManagement {
+		targetCluster = commandContext.BootstrapCluster
+	}
+	RecordClusterSpecRevision(ctx, commandContext, targetCluster)
+
 	return &DeleteBootstrapClusterTask{}
 }
 
@@ -432,11 +770,13 @@ func (s *WriteClusterConfigTask) Name() string {
 }
 
 func (s *WriteClusterConfigTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &DeleteBootstrapClusterTask{}, nil
 }
 
 func (s *WriteClusterConfigTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // DeleteBootstrapClusterTask implementation
@@ -459,6 +799,16 @@ func (s *DeleteBootstrapClusterTask) Name() string {
 	return "delete-kind-cluster"
 }
 
+func (s *DeleteBootstrapClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &InstallCuratedPackagesTask{}, nil
+}
+
+func (s *DeleteBootstrapClusterTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
 func (cp *InstallCuratedPackagesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	commandContext.PackageInstaller.InstallCuratedPackages(ctx)
 	return nil
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -12,6 +15,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -31,6 +37,8 @@ type createTestSetup struct {
 	writer           *writermocks.MockFileWriter
 	validator        *mocks.MockValidator
 	eksd             *mocks.MockEksdInstaller
+	clockSkew        *mocks.MockClockSkewValidator
+	dnsRegistrar     *mocks.MockDNSRegistrar
 	datacenterConfig providers.DatacenterConfig
 	machineConfigs   []providers.MachineConfig
 	workflow         *workflows.Create
@@ -50,6 +58,8 @@ func newCreateTest(t *testing.T) *createTestSetup {
 	writer := writermocks.NewMockFileWriter(mockCtrl)
 	eksd := mocks.NewMockEksdInstaller(mockCtrl)
 	packageInstaller := mocks.NewMockPackageInstaller(mockCtrl)
+	clockSkew := mocks.NewMockClockSkewValidator(mockCtrl)
+	dnsRegistrar := mocks.NewMockDNSRegistrar(mockCtrl)
 
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
@@ -65,6 +75,8 @@ func newCreateTest(t *testing.T) *createTestSetup {
 		writer:           writer,
 		validator:        validator,
 		eksd:             eksd,
+		clockSkew:        clockSkew,
+		dnsRegistrar:     dnsRegistrar,
 		packageInstaller: packageInstaller,
 		datacenterConfig: datacenterConfig,
 		machineConfigs:   machineConfigs,
@@ -76,6 +88,23 @@ func newCreateTest(t *testing.T) *createTestSetup {
 	}
 }
 
+func (c *createTestSetup) WithClockSkewValidator() *createTestSetup {
+	c.workflow = workflows.NewCreate(c.bootstrapper, c.provider, c.clusterManager, c.gitOpsManager, c.writer, c.eksd, c.packageInstaller, workflows.WithClockSkewValidator(c.clockSkew))
+	return c
+}
+
+func (c *createTestSetup) WithDNSRegistrar() *createTestSetup {
+	c.workflow = workflows.NewCreate(c.bootstrapper, c.provider, c.clusterManager, c.gitOpsManager, c.writer, c.eksd, c.packageInstaller, workflows.WithDNSRegistrar(c.dnsRegistrar))
+	c.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{
+		Host: "10.0.0.10",
+		DNS: &v1alpha1.EndpointDNS{
+			Name:    "api.prod.example.com",
+			Route53: &v1alpha1.Route53DNS{HostedZoneID: "Z0123456789"},
+		},
+	}
+	return c
+}
+
 func (c *createTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateCreateCluster(c.ctx, c.clusterSpec)
 	c.provider.EXPECT().Name()
@@ -101,19 +130,9 @@ func (c *createTestSetup) expectCreateBootstrap() {
 }
 
 func (c *createTestSetup) expectCreateWorkload() {
+	networking := c.expectCreateWorkloadNetworking()
 	gomock.InOrder(
-		c.clusterManager.EXPECT().CreateWorkloadCluster(
-			c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider,
-		).Return(c.workloadCluster, nil),
-		c.clusterManager.EXPECT().InstallNetworking(
-			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
-		),
-		c.clusterManager.EXPECT().InstallMachineHealthChecks(
-			c.ctx, c.clusterSpec, c.bootstrapCluster,
-		),
-		c.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
-			c.ctx, c.bootstrapCluster, c.workloadCluster, c.clusterSpec,
-		),
+		networking,
 		c.clusterManager.EXPECT().CreateEKSANamespace(
 			c.ctx, c.workloadCluster,
 		),
@@ -124,28 +143,36 @@ func (c *createTestSetup) expectCreateWorkload() {
 	)
 }
 
-func (c *createTestSetup) expectInstallResourcesOnManagementTask() {
-	gomock.InOrder(
-		c.provider.EXPECT().PostWorkloadInit(c.ctx, c.workloadCluster, c.clusterSpec),
+// expectCreateWorkloadNetworking expects the workload cluster to be created with networking
+// and the steps that don't depend on each other to run after it.
+func (c *createTestSetup) expectCreateWorkloadNetworking() *gomock.Call {
+	networking := c.clusterManager.EXPECT().InstallNetworking(
+		c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
 	)
-}
-
-func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().CreateWorkloadCluster(
 			c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider,
 		).Return(c.workloadCluster, nil),
+		networking,
+	)
+	c.clusterManager.EXPECT().InstallMachineHealthChecks(
+		c.ctx, c.clusterSpec, c.bootstrapCluster,
+	).After(networking)
+	c.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
+		c.ctx, c.bootstrapCluster, c.workloadCluster, c.clusterSpec,
+	).After(networking)
 
-		c.clusterManager.EXPECT().InstallNetworking(
-			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
-		),
-		c.clusterManager.EXPECT().InstallMachineHealthChecks(
-			c.ctx, c.clusterSpec, c.bootstrapCluster,
-		),
-		c.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
-			c.ctx, c.bootstrapCluster, c.workloadCluster, c.clusterSpec,
-		),
+	return networking
+}
+
+func (c *createTestSetup) expectInstallResourcesOnManagementTask() {
+	gomock.InOrder(
+		c.provider.EXPECT().PostWorkloadInit(c.ctx, c.workloadCluster, c.clusterSpec),
 	)
+}
+
+func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
+	c.expectCreateWorkloadNetworking()
 	c.clusterManager.EXPECT().InstallCAPI(
 		c.ctx, c.clusterSpec, c.workloadCluster, c.provider,
 	).Times(0)
@@ -227,6 +254,9 @@ func (c *createTestSetup) expectWriteClusterConfig() {
 		c.provider.EXPECT().DatacenterConfig(c.clusterSpec).Return(c.datacenterConfig),
 		c.provider.EXPECT().MachineConfigs(c.clusterSpec).Return(c.machineConfigs),
 		c.writer.EXPECT().Write("cluster-name-eks-a-cluster.yaml", gomock.Any(), gomock.Any()),
+		c.provider.EXPECT().DatacenterConfig(c.clusterSpec).Return(c.datacenterConfig),
+		c.provider.EXPECT().MachineConfigs(c.clusterSpec).Return(c.machineConfigs),
+		c.clusterManager.EXPECT().CreateSpecRevision(c.ctx, gomock.Any(), c.clusterSpec, c.datacenterConfig, c.machineConfigs),
 	)
 }
 
@@ -273,6 +303,113 @@ func TestCreateRunSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunClockSkewValidationSuccess(t *testing.T) {
+	test := newCreateTest(t).WithClockSkewValidator()
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.clockSkew.EXPECT().ValidateClockSkew(test.ctx, test.workloadCluster).Return(nil)
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunRegisterControlPlaneDNSSuccess(t *testing.T) {
+	test := newCreateTest(t).WithDNSRegistrar()
+	test.workloadCluster.KubeconfigFile = filepath.Join(t.TempDir(), "workload.kubeconfig")
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://10.0.0.10:6443
+  name: cluster-name
+`)
+	if err := os.WriteFile(test.workloadCluster.KubeconfigFile, kubeconfig, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.dnsRegistrar.EXPECT().RegisterControlPlaneEndpoint(test.ctx, test.workloadCluster, test.clusterSpec).Return(nil)
+	test.writer.EXPECT().Write("cluster-name-eks-a-cluster.dns.kubeconfig", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(name string, content []byte, opts ...filewriter.FileOptionsFunc) (string, error) {
+			if !strings.Contains(string(content), "server: https://api.prod.example.com:6443") {
+				t.Errorf("dns kubeconfig doesn't use the dns name:\n%s", content)
+			}
+			return name, nil
+		},
+	)
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunRegisterControlPlaneDNSFailed(t *testing.T) {
+	wantError := errors.New("upserting route53 record api.prod.example.com: access denied")
+	test := newCreateTest(t).WithDNSRegistrar()
+
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.dnsRegistrar.EXPECT().RegisterControlPlaneEndpoint(test.ctx, test.workloadCluster, test.clusterSpec).Return(wantError)
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.clusterSpec, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, test.workloadCluster)
+	test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.clusterSpec.Cluster.Name), gomock.Any())
+
+	err := test.run()
+	if !errors.Is(err, wantError) {
+		t.Fatalf("Create.Run() err = %v, want err = %v", err, wantError)
+	}
+	if code := eksaerrors.CodeOf(err); code != eksaerrors.CodeEndpointDNS {
+		t.Fatalf("Create.Run() error code = %s, want %s", code, eksaerrors.CodeEndpointDNS)
+	}
+}
+
+func TestCreateRunClockSkewValidationFailed(t *testing.T) {
+	wantError := errors.New("clock skew with the kube-apiserver is over 2s in nodes: md-1 (-35s)")
+	test := newCreateTest(t).WithClockSkewValidator()
+
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.clockSkew.EXPECT().ValidateClockSkew(test.ctx, test.workloadCluster).Return(wantError)
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.clusterSpec, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, test.workloadCluster)
+	test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.clusterSpec.Cluster.Name), gomock.Any())
+
+	err := test.run()
+	if !errors.Is(err, wantError) {
+		t.Fatalf("Create.Run() err = %v, want err = %v", err, wantError)
+	}
+	if code := eksaerrors.CodeOf(err); code != eksaerrors.CodeClockSkew {
+		t.Fatalf("Create.Run() error code = %s, want %s", code, eksaerrors.CodeClockSkew)
+	}
+}
+
 func TestCreateRunAWSIamConfigFail(t *testing.T) {
 	wantError := errors.New("test error")
 	test := newCreateTest(t)
@@ -320,6 +457,98 @@ func TestCreateRunAWSIamConfigSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunImageWarmCacheSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.ImageWarmCache = &v1alpha1.ImageWarmCacheConfiguration{}
+	test.clusterManager.EXPECT().InstallImageWarmCache(test.ctx, test.clusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunAccessEntriesSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.AccessEntries = []v1alpha1.AccessEntry{{Group: "developers", ClusterRole: "view"}}
+	test.clusterManager.EXPECT().InstallAccessEntries(test.ctx, test.clusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunPolicyEngineSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.PolicyEngine = &v1alpha1.PolicyEngineConfiguration{Engine: v1alpha1.KyvernoPolicyEngine}
+	test.clusterManager.EXPECT().InstallPolicyEngine(test.ctx, test.clusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunCertManagerSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.CertManager = &v1alpha1.CertManagerConfiguration{}
+	test.clusterManager.EXPECT().InstallCertManager(test.ctx, test.clusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	test := newCreateTest(t)
 	test.forceCleanup = true
@@ -342,6 +571,77 @@ func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	}
 }
 
+func TestCreateRunWithCheckpointResumesAfterFailure(t *testing.T) {
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	t.Setenv(features.CheckpointEnabledEnvVar, "true")
+	checkpointDir := t.TempDir()
+
+	test := newCreateTest(t)
+	test.writer.EXPECT().TempDir().Return(checkpointDir)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectCreateBootstrap()
+	test.clusterManager.EXPECT().CreateWorkloadCluster(
+		test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+	).Return(nil, errors.New("creating workload cluster"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.clusterSpec, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, nil)
+	test.writer.EXPECT().Write(task.CheckpointFileName(test.clusterSpec.Cluster.Name), gomock.Any()).DoAndReturn(
+		func(name string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			path := filepath.Join(checkpointDir, name)
+			return path, os.WriteFile(path, content, 0o600)
+		},
+	)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want err not nil")
+	}
+
+	// The second run reuses the bootstrap cluster and skips the preflight validations.
+	test2 := newCreateTest(t)
+	test2.writer.EXPECT().TempDir().Return(checkpointDir)
+	test2.provider.EXPECT().SetupAndValidateCreateCluster(test2.ctx, test2.clusterSpec)
+	test2.provider.EXPECT().Name()
+	test2.expectCreateWorkload()
+	test2.expectInstallResourcesOnManagementTask()
+	test2.expectMoveManagement()
+	test2.expectInstallEksaComponents()
+	test2.expectInstallGitOpsManager()
+	test2.expectWriteClusterConfig()
+	test2.expectDeleteBootstrap()
+	test2.expectCuratedPackagesInstallation()
+
+	if err := test2.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunWithCheckpointForceCleanupIgnoresCheckpoint(t *testing.T) {
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	t.Setenv(features.CheckpointEnabledEnvVar, "true")
+
+	test := newCreateTest(t)
+	test.forceCleanup = true
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, &types.Cluster{Name: "cluster-name"}, gomock.Any(), gomock.Any())
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 	managementKubeconfig := "test.kubeconfig"
 	test := newCreateTest(t)
@@ -470,16 +770,21 @@ func TestCreateWorkloadClusterTaskRunPostCreateWorkloadClusterFailure(t *testing
 		ClusterManager:   test.clusterManager,
 	}
 
+	networking := test.clusterManager.EXPECT().InstallNetworking(
+		test.ctx, test.workloadCluster, test.clusterSpec, test.provider,
+	)
+	// Steps that don't depend on the failed one might have started before it failed.
+	test.clusterManager.EXPECT().InstallMachineHealthChecks(
+		test.ctx, test.clusterSpec, test.bootstrapCluster,
+	).After(networking).MaxTimes(1)
+	test.clusterManager.EXPECT().CreateEKSANamespace(test.ctx, test.workloadCluster).After(networking).MaxTimes(1)
+	test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider).MaxTimes(1)
+	test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster, test.clusterSpec).MaxTimes(1)
 	gomock.InOrder(
 		test.clusterManager.EXPECT().CreateWorkloadCluster(
 			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
 		).Return(test.workloadCluster, nil),
-		test.clusterManager.EXPECT().InstallNetworking(
-			test.ctx, test.workloadCluster, test.clusterSpec, test.provider,
-		),
-		test.clusterManager.EXPECT().InstallMachineHealthChecks(
-			test.ctx, test.clusterSpec, test.bootstrapCluster,
-		),
+		networking,
 		test.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
 			test.ctx, test.bootstrapCluster, test.workloadCluster, test.clusterSpec,
 		).Return(errors.New("test")),
@@ -496,3 +801,49 @@ func TestCreateWorkloadClusterTaskRunPostCreateWorkloadClusterFailure(t *testing
 		t.Fatalf("expected error from task")
 	}
 }
+
+func TestCreateWorkloadClusterTaskResumeProgress(t *testing.T) {
+	test := newCreateTest(t)
+	commandContext := &task.CommandContext{
+		BootstrapCluster: test.bootstrapCluster,
+		ClusterSpec:      test.clusterSpec,
+		Provider:         test.provider,
+		ClusterManager:   test.clusterManager,
+	}
+
+	test.clusterManager.EXPECT().CreateWorkloadCluster(
+		test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+	).Return(test.workloadCluster, nil).Times(2)
+	test.clusterManager.EXPECT().InstallNetworking(
+		test.ctx, test.workloadCluster, test.clusterSpec, test.provider,
+	).Times(2)
+	test.clusterManager.EXPECT().InstallMachineHealthChecks(test.ctx, test.clusterSpec, test.bootstrapCluster)
+	test.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
+		test.ctx, test.bootstrapCluster, test.workloadCluster, test.clusterSpec,
+	)
+	test.clusterManager.EXPECT().CreateEKSANamespace(test.ctx, test.workloadCluster)
+	gomock.InOrder(
+		test.clusterManager.EXPECT().InstallCAPI(
+			test.ctx, test.clusterSpec, test.workloadCluster, test.provider,
+		).Return(errors.New("installing capi")),
+		test.clusterManager.EXPECT().InstallCAPI(
+			test.ctx, test.clusterSpec, test.workloadCluster, test.provider,
+		),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster, test.clusterSpec),
+	)
+
+	failed := &workflows.CreateWorkloadClusterTask{}
+	if next := failed.Run(test.ctx, commandContext); next.Name() != (&workflows.CollectDiagnosticsTask{}).Name() {
+		t.Fatalf("CreateWorkloadClusterTask.Run() next task = %s, want collect diagnostics", next.Name())
+	}
+
+	commandContext.OriginalError = nil
+	resumed := &workflows.CreateWorkloadClusterTask{}
+	if err := resumed.ResumeProgress(failed.Progress()); err != nil {
+		t.Fatalf("CreateWorkloadClusterTask.ResumeProgress() error = %v", err)
+	}
+	resumed.Run(test.ctx, commandContext)
+	if commandContext.OriginalError != nil {
+		t.Fatalf("CreateWorkloadClusterTask.Run() error = %v", commandContext.OriginalError)
+	}
+}
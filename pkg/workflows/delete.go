@@ -3,8 +3,10 @@ package workflows
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -19,19 +21,37 @@ type Delete struct {
 	clusterManager interfaces.ClusterManager
 	gitOpsManager  interfaces.GitOpsManager
 	writer         filewriter.FileWriter
+	recorder       interfaces.OperationRecorder
+}
+
+// DeleteOpt allows to customize a Delete on construction.
+type DeleteOpt func(*Delete)
+
+// WithDeleteOperationRecorder makes the delete record the operation as a ClusterOperation.
+// Only the deletion of workload clusters is recorded, since the objects of a management
+// cluster are deleted with it.
+func WithDeleteOperationRecorder(recorder interfaces.OperationRecorder) DeleteOpt {
+	return func(d *Delete) {
+		d.recorder = recorder
+	}
 }
 
 func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	clusterManager interfaces.ClusterManager, gitOpsManager interfaces.GitOpsManager,
-	writer filewriter.FileWriter,
+	writer filewriter.FileWriter, opts ...DeleteOpt,
 ) *Delete {
-	return &Delete{
+	d := &Delete{
 		bootstrapper:   bootstrapper,
 		provider:       provider,
 		clusterManager: clusterManager,
 		gitOpsManager:  gitOpsManager,
 		writer:         writer,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
 }
 
 func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup bool, kubeconfig string) error {
@@ -56,7 +76,11 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}, c.writer).RunTask(ctx, commandContext)
+	record := StartOperationRecord(ctx, c.recorder, clusterSpec, v1alpha1.DeleteClusterOperation, clusterSpec.ManagementCluster)
+	err := task.NewTaskRunner(&setupAndValidate{}, c.writer).RunTask(ctx, commandContext)
+	record.Complete(ctx, clusterSpec.ManagementCluster, err)
+
+	return err
 }
 
 type setupAndValidate struct{}
@@ -79,6 +103,7 @@ func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.Command
 	logger.Info("Performing provider setup and validations")
 	err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec)
 	if err != nil {
+		err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
 		commandContext.SetError(err)
 		return nil
 	}
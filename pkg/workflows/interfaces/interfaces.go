@@ -3,9 +3,11 @@ package interfaces
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/crdmigration"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -34,12 +36,17 @@ type ClusterManager interface {
 	InstallCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	CreateEKSANamespace(ctx context.Context, cluster *types.Cluster) error
 	CreateEKSAResources(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
+	CreateSpecRevision(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
 	ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
 	ApplyReleases(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
 	PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
 	InstallMachineHealthChecks(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error
+	InstallImageWarmCache(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error
+	InstallAccessEntries(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error
+	InstallPolicyEngine(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error
+	InstallCertManager(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error
 	GetCurrentClusterSpec(ctx context.Context, cluster *types.Cluster, clusterName string) (*cluster.Spec, error)
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 	InstallAwsIamAuth(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
@@ -77,6 +84,31 @@ type EksdUpgrader interface {
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 }
 
+// WorkloadBackup backs up the workloads running in a cluster.
+type WorkloadBackup interface {
+	Backup(ctx context.Context, cluster *types.Cluster) error
+}
+
+// ClockSkewValidator validates the clocks of the nodes of a cluster are in sync.
+type ClockSkewValidator interface {
+	ValidateClockSkew(ctx context.Context, cluster *types.Cluster) error
+}
+
+// DNSRegistrar registers the DNS record of the control plane endpoint of a cluster.
+type DNSRegistrar interface {
+	RegisterControlPlaneEndpoint(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+}
+
+// OperationRecorder records the lifecycle operations run on a cluster as ClusterOperations in the cluster holding its EKS-A objects.
+type OperationRecorder interface {
+	RecordOperation(ctx context.Context, cluster *types.Cluster, op *v1alpha1.ClusterOperation) error
+}
+
+// CRDStorageMigrator migrates the objects of the CRDs of a cluster to their storage version.
+type CRDStorageMigrator interface {
+	Migrate(ctx context.Context, cluster *types.Cluster) ([]crdmigration.Result, error)
+}
+
 type PackageInstaller interface {
 	InstallCuratedPackages(ctx context.Context)
 }
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,GitOpsManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller,ClusterUpgrader)
+// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,GitOpsManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller,ClusterUpgrader,WorkloadBackup,ClockSkewValidator,DNSRegistrar,OperationRecorder,CRDStorageMigrator)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -8,9 +8,11 @@ import (
 	context "context"
 	reflect "reflect"
 
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	bootstrapper "github.com/aws/eks-anywhere/pkg/bootstrapper"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
 	constants "github.com/aws/eks-anywhere/pkg/constants"
+	crdmigration "github.com/aws/eks-anywhere/pkg/crdmigration"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	validations "github.com/aws/eks-anywhere/pkg/validations"
@@ -195,6 +197,20 @@ func (mr *MockClusterManagerMockRecorder) CreateEKSAResources(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEKSAResources", reflect.TypeOf((*MockClusterManager)(nil).CreateEKSAResources), arg0, arg1, arg2, arg3, arg4)
 }
 
+// CreateSpecRevision mocks base method.
+func (m *MockClusterManager) CreateSpecRevision(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.DatacenterConfig, arg4 []providers.MachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSpecRevision", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSpecRevision indicates an expected call of CreateSpecRevision.
+func (mr *MockClusterManagerMockRecorder) CreateSpecRevision(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSpecRevision", reflect.TypeOf((*MockClusterManager)(nil).CreateSpecRevision), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateWorkloadCluster mocks base method.
 func (m *MockClusterManager) CreateWorkloadCluster(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) (*types.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +284,20 @@ func (mr *MockClusterManagerMockRecorder) GetCurrentClusterSpec(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentClusterSpec", reflect.TypeOf((*MockClusterManager)(nil).GetCurrentClusterSpec), arg0, arg1, arg2)
 }
 
+// InstallAccessEntries mocks base method.
+func (m *MockClusterManager) InstallAccessEntries(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallAccessEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallAccessEntries indicates an expected call of InstallAccessEntries.
+func (mr *MockClusterManagerMockRecorder) InstallAccessEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallAccessEntries", reflect.TypeOf((*MockClusterManager)(nil).InstallAccessEntries), arg0, arg1, arg2)
+}
+
 // InstallAwsIamAuth mocks base method.
 func (m *MockClusterManager) InstallAwsIamAuth(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -296,6 +326,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCAPI(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCAPI", reflect.TypeOf((*MockClusterManager)(nil).InstallCAPI), arg0, arg1, arg2, arg3)
 }
 
+// InstallCertManager mocks base method.
+func (m *MockClusterManager) InstallCertManager(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallCertManager", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallCertManager indicates an expected call of InstallCertManager.
+func (mr *MockClusterManagerMockRecorder) InstallCertManager(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCertManager", reflect.TypeOf((*MockClusterManager)(nil).InstallCertManager), arg0, arg1, arg2)
+}
+
 // InstallCustomComponents mocks base method.
 func (m *MockClusterManager) InstallCustomComponents(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -310,6 +354,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCustomComponents(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCustomComponents", reflect.TypeOf((*MockClusterManager)(nil).InstallCustomComponents), arg0, arg1, arg2, arg3)
 }
 
+// InstallImageWarmCache mocks base method.
+func (m *MockClusterManager) InstallImageWarmCache(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallImageWarmCache", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallImageWarmCache indicates an expected call of InstallImageWarmCache.
+func (mr *MockClusterManagerMockRecorder) InstallImageWarmCache(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallImageWarmCache", reflect.TypeOf((*MockClusterManager)(nil).InstallImageWarmCache), arg0, arg1, arg2)
+}
+
 // InstallMachineHealthChecks mocks base method.
 func (m *MockClusterManager) InstallMachineHealthChecks(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -338,6 +396,20 @@ func (mr *MockClusterManagerMockRecorder) InstallNetworking(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNetworking", reflect.TypeOf((*MockClusterManager)(nil).InstallNetworking), arg0, arg1, arg2, arg3)
 }
 
+// InstallPolicyEngine mocks base method.
+func (m *MockClusterManager) InstallPolicyEngine(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallPolicyEngine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallPolicyEngine indicates an expected call of InstallPolicyEngine.
+func (mr *MockClusterManagerMockRecorder) InstallPolicyEngine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPolicyEngine", reflect.TypeOf((*MockClusterManager)(nil).InstallPolicyEngine), arg0, arg1, arg2)
+}
+
 // MoveCAPI mocks base method.
 func (m *MockClusterManager) MoveCAPI(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 string, arg4 *cluster.Spec, arg5 ...types.NodeReadyChecker) error {
 	m.ctrl.T.Helper()
@@ -898,3 +970,189 @@ func (mr *MockClusterUpgraderMockRecorder) Run(arg0, arg1, arg2 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockClusterUpgrader)(nil).Run), arg0, arg1, arg2)
 }
+
+// MockWorkloadBackup is a mock of WorkloadBackup interface.
+type MockWorkloadBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadBackupMockRecorder
+}
+
+// MockWorkloadBackupMockRecorder is the mock recorder for MockWorkloadBackup.
+type MockWorkloadBackupMockRecorder struct {
+	mock *MockWorkloadBackup
+}
+
+// NewMockWorkloadBackup creates a new mock instance.
+func NewMockWorkloadBackup(ctrl *gomock.Controller) *MockWorkloadBackup {
+	mock := &MockWorkloadBackup{ctrl: ctrl}
+	mock.recorder = &MockWorkloadBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadBackup) EXPECT() *MockWorkloadBackupMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockWorkloadBackup) Backup(arg0 context.Context, arg1 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockWorkloadBackupMockRecorder) Backup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockWorkloadBackup)(nil).Backup), arg0, arg1)
+}
+
+// MockClockSkewValidator is a mock of ClockSkewValidator interface.
+type MockClockSkewValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockSkewValidatorMockRecorder
+}
+
+// MockClockSkewValidatorMockRecorder is the mock recorder for MockClockSkewValidator.
+type MockClockSkewValidatorMockRecorder struct {
+	mock *MockClockSkewValidator
+}
+
+// NewMockClockSkewValidator creates a new mock instance.
+func NewMockClockSkewValidator(ctrl *gomock.Controller) *MockClockSkewValidator {
+	mock := &MockClockSkewValidator{ctrl: ctrl}
+	mock.recorder = &MockClockSkewValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClockSkewValidator) EXPECT() *MockClockSkewValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateClockSkew mocks base method.
+func (m *MockClockSkewValidator) ValidateClockSkew(arg0 context.Context, arg1 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateClockSkew", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateClockSkew indicates an expected call of ValidateClockSkew.
+func (mr *MockClockSkewValidatorMockRecorder) ValidateClockSkew(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClockSkew", reflect.TypeOf((*MockClockSkewValidator)(nil).ValidateClockSkew), arg0, arg1)
+}
+
+// MockDNSRegistrar is a mock of DNSRegistrar interface.
+type MockDNSRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockDNSRegistrarMockRecorder
+}
+
+// MockDNSRegistrarMockRecorder is the mock recorder for MockDNSRegistrar.
+type MockDNSRegistrarMockRecorder struct {
+	mock *MockDNSRegistrar
+}
+
+// NewMockDNSRegistrar creates a new mock instance.
+func NewMockDNSRegistrar(ctrl *gomock.Controller) *MockDNSRegistrar {
+	mock := &MockDNSRegistrar{ctrl: ctrl}
+	mock.recorder = &MockDNSRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDNSRegistrar) EXPECT() *MockDNSRegistrarMockRecorder {
+	return m.recorder
+}
+
+// RegisterControlPlaneEndpoint mocks base method.
+func (m *MockDNSRegistrar) RegisterControlPlaneEndpoint(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterControlPlaneEndpoint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterControlPlaneEndpoint indicates an expected call of RegisterControlPlaneEndpoint.
+func (mr *MockDNSRegistrarMockRecorder) RegisterControlPlaneEndpoint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterControlPlaneEndpoint", reflect.TypeOf((*MockDNSRegistrar)(nil).RegisterControlPlaneEndpoint), arg0, arg1, arg2)
+}
+
+// MockOperationRecorder is a mock of OperationRecorder interface.
+type MockOperationRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationRecorderMockRecorder
+}
+
+// MockOperationRecorderMockRecorder is the mock recorder for MockOperationRecorder.
+type MockOperationRecorderMockRecorder struct {
+	mock *MockOperationRecorder
+}
+
+// NewMockOperationRecorder creates a new mock instance.
+func NewMockOperationRecorder(ctrl *gomock.Controller) *MockOperationRecorder {
+	mock := &MockOperationRecorder{ctrl: ctrl}
+	mock.recorder = &MockOperationRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationRecorder) EXPECT() *MockOperationRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordOperation mocks base method.
+func (m *MockOperationRecorder) RecordOperation(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.ClusterOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOperation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOperation indicates an expected call of RecordOperation.
+func (mr *MockOperationRecorderMockRecorder) RecordOperation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOperation", reflect.TypeOf((*MockOperationRecorder)(nil).RecordOperation), arg0, arg1, arg2)
+}
+
+// MockCRDStorageMigrator is a mock of CRDStorageMigrator interface.
+type MockCRDStorageMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCRDStorageMigratorMockRecorder
+}
+
+// MockCRDStorageMigratorMockRecorder is the mock recorder for MockCRDStorageMigrator.
+type MockCRDStorageMigratorMockRecorder struct {
+	mock *MockCRDStorageMigrator
+}
+
+// NewMockCRDStorageMigrator creates a new mock instance.
+func NewMockCRDStorageMigrator(ctrl *gomock.Controller) *MockCRDStorageMigrator {
+	mock := &MockCRDStorageMigrator{ctrl: ctrl}
+	mock.recorder = &MockCRDStorageMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCRDStorageMigrator) EXPECT() *MockCRDStorageMigratorMockRecorder {
+	return m.recorder
+}
+
+// Migrate mocks base method.
+func (m *MockCRDStorageMigrator) Migrate(arg0 context.Context, arg1 *types.Cluster) ([]crdmigration.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", arg0, arg1)
+	ret0, _ := ret[0].([]crdmigration.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockCRDStorageMigratorMockRecorder) Migrate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockCRDStorageMigrator)(nil).Migrate), arg0, arg1)
+}
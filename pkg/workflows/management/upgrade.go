@@ -3,12 +3,14 @@ package management
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -23,6 +25,17 @@ type Upgrade struct {
 	eksdUpgrader      interfaces.EksdUpgrader
 	upgradeChangeDiff *types.ChangeDiff
 	clusterUpgrader   interfaces.ClusterUpgrader
+	recorder          interfaces.OperationRecorder
+}
+
+// UpgradeOpt allows to customize an Upgrade on construction.
+type UpgradeOpt func(*Upgrade)
+
+// WithOperationRecorder makes the upgrade record the operation as a ClusterOperation.
+func WithOperationRecorder(recorder interfaces.OperationRecorder) UpgradeOpt {
+	return func(u *Upgrade) {
+		u.recorder = recorder
+	}
 }
 
 // NewUpgrade builds a new upgrade construct.
@@ -34,9 +47,10 @@ func NewUpgrade(provider providers.Provider,
 	eksdUpgrader interfaces.EksdUpgrader,
 	eksdInstaller interfaces.EksdInstaller,
 	clusterUpgrade interfaces.ClusterUpgrader,
+	opts ...UpgradeOpt,
 ) *Upgrade {
 	upgradeChangeDiff := types.NewChangeDiff()
-	return &Upgrade{
+	u := &Upgrade{
 		provider:          provider,
 		clusterManager:    clusterManager,
 		gitOpsManager:     gitOpsManager,
@@ -47,6 +61,11 @@ func NewUpgrade(provider providers.Provider,
 		upgradeChangeDiff: upgradeChangeDiff,
 		clusterUpgrader:   clusterUpgrade,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
 }
 
 // Run Upgrade implements upgrade functionality for management cluster's upgrade operation.
@@ -65,9 +84,14 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		UpgradeChangeDiff: c.upgradeChangeDiff,
 		ClusterUpgrader:   c.clusterUpgrader,
 	}
+	record := workflows.StartOperationRecord(ctx, c.recorder, clusterSpec, v1alpha1.UpgradeClusterOperation, managementCluster)
+
+	var runnerOpts []task.TaskRunnerOpt
 	if features.IsActive(features.CheckpointEnabled()) {
-		return task.NewTaskRunner(&setupAndValidate{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+		runnerOpts = append(runnerOpts, task.WithCheckpointFile())
 	}
+	err := task.NewTaskRunner(&setupAndValidate{}, c.writer, runnerOpts...).RunTask(ctx, commandContext)
+	record.Complete(ctx, managementCluster, err)
 
-	return task.NewTaskRunner(&setupAndValidate{}, c.writer).RunTask(ctx, commandContext)
+	return err
 }
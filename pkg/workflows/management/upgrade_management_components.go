@@ -0,0 +1,472 @@
+package management
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+// UpgradeManagementComponents upgrades the components running in a management cluster
+// (CAPI core and providers, GitOps controllers and the EKS-A controller) to the versions in the new bundle,
+// without changing the Kubernetes version of the management cluster or its workload clusters.
+// If any component fails to upgrade, the already upgraded ones are rolled back to their previous versions.
+type UpgradeManagementComponents struct {
+	provider       providers.Provider
+	clusterManager interfaces.ClusterManager
+	gitOpsManager  interfaces.GitOpsManager
+	writer         filewriter.FileWriter
+	capiManager    interfaces.CAPIManager
+	crdMigrator    interfaces.CRDStorageMigrator
+}
+
+// UpgradeManagementComponentsOpt allows to customize an UpgradeManagementComponents on construction.
+type UpgradeManagementComponentsOpt func(*UpgradeManagementComponents)
+
+// WithCRDStorageMigrator makes the upgrade migrate the objects of the management cluster CRDs
+// to their storage version once the components are upgraded.
+func WithCRDStorageMigrator(migrator interfaces.CRDStorageMigrator) UpgradeManagementComponentsOpt {
+	return func(u *UpgradeManagementComponents) {
+		u.crdMigrator = migrator
+	}
+}
+
+// NewUpgradeManagementComponents builds a new UpgradeManagementComponents construct.
+func NewUpgradeManagementComponents(provider providers.Provider,
+	capiManager interfaces.CAPIManager,
+	clusterManager interfaces.ClusterManager,
+	gitOpsManager interfaces.GitOpsManager,
+	writer filewriter.FileWriter,
+	opts ...UpgradeManagementComponentsOpt,
+) *UpgradeManagementComponents {
+	u := &UpgradeManagementComponents{
+		provider:       provider,
+		clusterManager: clusterManager,
+		gitOpsManager:  gitOpsManager,
+		writer:         writer,
+		capiManager:    capiManager,
+	}
+
+	for _, o := range opts {
+		o(u)
+	}
+
+	return u
+}
+
+// Run upgrades the management components of managementCluster to the versions in clusterSpec's bundle.
+func (u *UpgradeManagementComponents) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, validator interfaces.Validator) error {
+	commandContext := &task.CommandContext{
+		Provider:           u.provider,
+		ClusterManager:     u.clusterManager,
+		GitOpsManager:      u.gitOpsManager,
+		ManagementCluster:  managementCluster,
+		ClusterSpec:        clusterSpec,
+		Validations:        validator,
+		Writer:             u.writer,
+		CAPIManager:        u.capiManager,
+		CRDStorageMigrator: u.crdMigrator,
+		UpgradeChangeDiff:  types.NewChangeDiff(),
+	}
+	if features.IsActive(features.CheckpointEnabled()) {
+		return task.NewTaskRunner(&setupAndValidateManagementComponents{}, u.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+	}
+
+	return task.NewTaskRunner(&setupAndValidateManagementComponents{}, u.writer).RunTask(ctx, commandContext)
+}
+
+// managementComponent is one of the groups of management components upgraded, and rolled back, together.
+type managementComponent string
+
+const (
+	capiComponents   managementComponent = "capi"
+	gitOpsComponents managementComponent = "gitops"
+	eksaComponents   managementComponent = "eks-a"
+)
+
+const crdMigrationReportFile = "crd-storage-migration-report.yaml"
+
+type setupAndValidateManagementComponents struct{}
+
+// Run setupAndValidateManagementComponents validates the management cluster before the management components upgrade starts.
+func (s *setupAndValidateManagementComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing setup and validations")
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+	runner := validations.NewRunner()
+	runner.Register(s.validations(ctx, commandContext)...)
+	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
+
+	if err = runner.Run(); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	return &ensureManagementComponentsEtcdProviders{}
+}
+
+func (s *setupAndValidateManagementComponents) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate cluster is a management cluster",
+				Remediation: "run upgrade management-components against the management cluster of this workload cluster",
+				Err:         validateIsManagementCluster(commandContext.ClusterSpec),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate kubernetes versions are unchanged",
+				Remediation: "use upgrade cluster to change the kubernetes version of the cluster",
+				Err:         validateKubernetesVersionsUnchanged(commandContext.CurrentClusterSpec, commandContext.ClusterSpec),
+			}
+		},
+		func() *validations.ValidationResult {
+			err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec)
+			if err != nil {
+				err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+			}
+			return &validations.ValidationResult{
+				Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
+				Err:  err,
+			}
+		},
+	}
+}
+
+func validateIsManagementCluster(spec *cluster.Spec) error {
+	if !spec.Cluster.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is not a management cluster", spec.Cluster.Name)
+	}
+	return nil
+}
+
+func validateKubernetesVersionsUnchanged(currentSpec, newSpec *cluster.Spec) error {
+	if currentSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+		return fmt.Errorf("kubernetes version can't be changed from %s to %s when upgrading management components",
+			currentSpec.Cluster.Spec.KubernetesVersion, newSpec.Cluster.Spec.KubernetesVersion)
+	}
+
+	currentVersions := map[string]string{}
+	for _, w := range currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		currentVersions[w.Name] = workerKubernetesVersion(currentSpec, w.KubernetesVersion)
+	}
+	for _, w := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		current, ok := currentVersions[w.Name]
+		if !ok {
+			return fmt.Errorf("worker node group %s can't be added when upgrading management components", w.Name)
+		}
+		if newVersion := workerKubernetesVersion(newSpec, w.KubernetesVersion); current != newVersion {
+			return fmt.Errorf("kubernetes version of worker node group %s can't be changed from %s to %s when upgrading management components",
+				w.Name, current, newVersion)
+		}
+	}
+
+	return nil
+}
+
+func workerKubernetesVersion(spec *cluster.Spec, version *v1alpha1.KubernetesVersion) string {
+	if version != nil {
+		return string(*version)
+	}
+	return string(spec.Cluster.Spec.KubernetesVersion)
+}
+
+func (s *setupAndValidateManagementComponents) Name() string {
+	return "setup-and-validate-management-components"
+}
+
+func (s *setupAndValidateManagementComponents) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
+		err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+		commandContext.SetError(err)
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()))
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+	return &ensureManagementComponentsEtcdProviders{}, nil
+}
+
+func (s *setupAndValidateManagementComponents) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+type ensureManagementComponentsEtcdProviders struct{}
+
+// Run ensureManagementComponentsEtcdProviders ensures ETCD CAPI providers on the management cluster.
+func (s *ensureManagementComponentsEtcdProviders) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Ensuring etcd CAPI providers exist on management cluster before upgrade")
+	if err := commandContext.CAPIManager.EnsureEtcdProvidersInstallation(ctx, commandContext.ManagementCluster, commandContext.Provider, commandContext.CurrentClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Pausing GitOps cluster resources reconcile")
+	if err := commandContext.GitOpsManager.PauseClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	return &upgradeManagementComponents{}
+}
+
+func (s *ensureManagementComponentsEtcdProviders) Name() string {
+	return "ensure-management-components-etcd-providers"
+}
+
+func (s *ensureManagementComponentsEtcdProviders) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *ensureManagementComponentsEtcdProviders) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &upgradeManagementComponents{}, nil
+}
+
+type upgradeManagementComponents struct {
+	UpgradeChangeDiff *types.ChangeDiff
+}
+
+// Run upgradeManagementComponents upgrades the CAPI, GitOps and EKS-A components and applies the new bundle.
+// EKS-D and the cluster machines are left untouched.
+func (s *upgradeManagementComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Upgrading management components")
+	var upgraded []managementComponent
+
+	rollback := func(err error) task.Task {
+		commandContext.SetError(err)
+		return &rollbackManagementComponents{upgraded: upgraded}
+	}
+
+	if err := commandContext.Provider.PreCoreComponentsUpgrade(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	changeDiff, err := commandContext.CAPIManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.Provider, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+	if err != nil {
+		return rollback(err)
+	}
+	upgraded = append(upgraded, capiComponents)
+	commandContext.UpgradeChangeDiff.Append(changeDiff)
+
+	if err = commandContext.GitOpsManager.Install(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec); err != nil {
+		return rollback(err)
+	}
+
+	changeDiff, err = commandContext.GitOpsManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+	if err != nil {
+		return rollback(err)
+	}
+	upgraded = append(upgraded, gitOpsComponents)
+	commandContext.UpgradeChangeDiff.Append(changeDiff)
+
+	changeDiff, err = commandContext.ClusterManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+	if err != nil {
+		return rollback(err)
+	}
+	upgraded = append(upgraded, eksaComponents)
+	commandContext.UpgradeChangeDiff.Append(changeDiff)
+
+	if commandContext.UpgradeChangeDiff.Changed() {
+		if err = commandContext.ClusterManager.ApplyBundles(ctx, commandContext.ClusterSpec, commandContext.ManagementCluster); err != nil {
+			return rollback(err)
+		}
+
+		if err = commandContext.ClusterManager.ApplyReleases(ctx, commandContext.ClusterSpec, commandContext.ManagementCluster); err != nil {
+			return rollback(err)
+		}
+	}
+	s.UpgradeChangeDiff = commandContext.UpgradeChangeDiff
+
+	return &migrateManagementComponentsCRDs{}
+}
+
+func (s *upgradeManagementComponents) Name() string {
+	return "upgrade-management-components"
+}
+
+func (s *upgradeManagementComponents) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: s.UpgradeChangeDiff,
+	}
+}
+
+func (s *upgradeManagementComponents) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	s.UpgradeChangeDiff = &types.ChangeDiff{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.UpgradeChangeDiff); err != nil {
+		return nil, err
+	}
+	commandContext.UpgradeChangeDiff = s.UpgradeChangeDiff
+	return &migrateManagementComponentsCRDs{}, nil
+}
+
+// rollbackManagementComponents downgrades the upgraded management components, in reverse order,
+// back to the versions in the current cluster spec after a failed upgrade.
+type rollbackManagementComponents struct {
+	upgraded []managementComponent
+}
+
+// Run rollbackManagementComponents rolls back the upgraded management components. The upgrade error is
+// kept as the command error, rollback errors are only logged.
+func (s *rollbackManagementComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if len(s.upgraded) == 0 {
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Rolling back management components")
+	for i := len(s.upgraded) - 1; i >= 0; i-- {
+		component := s.upgraded[i]
+		if err := rollbackManagementComponent(ctx, commandContext, component); err != nil {
+			logger.Error(err, "Failed rolling back management components, manual intervention might be needed", "component", component)
+			return &workflows.CollectMgmtClusterDiagnosticsTask{}
+		}
+		logger.V(3).Info("Management components rolled back", "component", component)
+	}
+
+	logger.Info("Resuming GitOps cluster resources kustomization")
+	if err := commandContext.GitOpsManager.ResumeClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+		logger.Error(err, "Failed resuming GitOps cluster resources reconcile")
+	}
+
+	return &workflows.CollectMgmtClusterDiagnosticsTask{}
+}
+
+func rollbackManagementComponent(ctx context.Context, commandContext *task.CommandContext, component managementComponent) error {
+	// Rolling back is upgrading from the new spec to the current one.
+	from, to := commandContext.ClusterSpec, commandContext.CurrentClusterSpec
+	var err error
+	switch component {
+	case capiComponents:
+		_, err = commandContext.CAPIManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.Provider, from, to)
+	case gitOpsComponents:
+		_, err = commandContext.GitOpsManager.Upgrade(ctx, commandContext.ManagementCluster, from, to)
+	case eksaComponents:
+		_, err = commandContext.ClusterManager.Upgrade(ctx, commandContext.ManagementCluster, from, to)
+	}
+	return err
+}
+
+func (s *rollbackManagementComponents) Name() string {
+	return "rollback-management-components"
+}
+
+func (s *rollbackManagementComponents) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+func (s *rollbackManagementComponents) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return s.Run(ctx, commandContext), nil
+}
+
+type migrateManagementComponentsCRDs struct{}
+
+// Run migrateManagementComponentsCRDs migrates the objects of the CRDs in the management cluster to their
+// storage version, so the old versions can be removed from the CRDs in future upgrades, and writes a report
+// with the migrated CRDs. There is no rollback, since objects are only rewritten, never changed.
+func (s *migrateManagementComponentsCRDs) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.CRDStorageMigrator == nil {
+		return &resumeManagementComponentsGitOps{}
+	}
+
+	logger.Info("Migrating CRD objects to their storage versions")
+	results, err := commandContext.CRDStorageMigrator.Migrate(ctx, commandContext.ManagementCluster)
+	if err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	var migrated int
+	for _, r := range results {
+		if !r.Migrated {
+			continue
+		}
+		migrated++
+		logger.V(4).Info("CRD objects migrated", "crd", r.CRD, "storedVersions", r.StoredVersions, "storageVersion", r.StorageVersion, "objects", r.Objects)
+	}
+
+	report, err := yaml.Marshal(results)
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("generating CRD migration report: %v", err))
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	path, err := commandContext.Writer.Write(crdMigrationReportFile, report)
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("writing CRD migration report: %v", err))
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+	logger.Info("CRD storage versions migrated", "migratedCRDs", migrated, "totalCRDs", len(results), "report", path)
+
+	return &resumeManagementComponentsGitOps{}
+}
+
+func (s *migrateManagementComponentsCRDs) Name() string {
+	return "migrate-management-components-crds"
+}
+
+func (s *migrateManagementComponentsCRDs) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *migrateManagementComponentsCRDs) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &resumeManagementComponentsGitOps{}, nil
+}
+
+type resumeManagementComponentsGitOps struct{}
+
+// Run resumeManagementComponentsGitOps resumes the GitOps reconciler after the management components upgrade.
+func (s *resumeManagementComponentsGitOps) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Resuming GitOps cluster resources kustomization")
+	err := commandContext.GitOpsManager.ResumeClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	workflows.RecordClusterSpecRevision(ctx, commandContext, commandContext.ManagementCluster)
+
+	logger.MarkSuccess("Management components upgraded!")
+	return nil
+}
+
+func (s *resumeManagementComponentsGitOps) Name() string {
+	return "resume-management-components-gitops-reconciliation"
+}
+
+func (s *resumeManagementComponentsGitOps) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *resumeManagementComponentsGitOps) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
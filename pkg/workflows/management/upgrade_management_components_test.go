@@ -0,0 +1,313 @@
+package management_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/crdmigration"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+	"github.com/aws/eks-anywhere/pkg/workflows/management"
+)
+
+type upgradeManagementComponentsTest struct {
+	ctx                context.Context
+	clusterManager     *mocks.MockClusterManager
+	gitOpsManager      *mocks.MockGitOpsManager
+	capiManager        *mocks.MockCAPIManager
+	crdMigrator        *mocks.MockCRDStorageMigrator
+	provider           *providermocks.MockProvider
+	writer             *writermocks.MockFileWriter
+	validator          *mocks.MockValidator
+	newClusterSpec     *cluster.Spec
+	currentClusterSpec *cluster.Spec
+	managementCluster  *types.Cluster
+	workflow           *management.UpgradeManagementComponents
+}
+
+func newUpgradeManagementComponentsTest(t *testing.T) *upgradeManagementComponentsTest {
+	features.ClearCache()
+	t.Setenv(features.CheckpointEnabledEnvVar, "false")
+	mockCtrl := gomock.NewController(t)
+	tt := &upgradeManagementComponentsTest{
+		ctx:               context.Background(),
+		clusterManager:    mocks.NewMockClusterManager(mockCtrl),
+		gitOpsManager:     mocks.NewMockGitOpsManager(mockCtrl),
+		capiManager:       mocks.NewMockCAPIManager(mockCtrl),
+		crdMigrator:       mocks.NewMockCRDStorageMigrator(mockCtrl),
+		provider:          providermocks.NewMockProvider(mockCtrl),
+		writer:            writermocks.NewMockFileWriter(mockCtrl),
+		validator:         mocks.NewMockValidator(mockCtrl),
+		managementCluster: &types.Cluster{Name: "management"},
+	}
+	tt.workflow = management.NewUpgradeManagementComponents(tt.provider, tt.capiManager, tt.clusterManager, tt.gitOpsManager, tt.writer)
+
+	newSpec := func(s *cluster.Spec) {
+		s.Cluster.Name = "management"
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+	}
+	tt.newClusterSpec = test.NewClusterSpec(newSpec)
+	tt.currentClusterSpec = test.NewClusterSpec(newSpec)
+	return tt
+}
+
+func (tt *upgradeManagementComponentsTest) expectSetup() {
+	tt.clusterManager.EXPECT().GetCurrentClusterSpec(tt.ctx, tt.managementCluster, "management").Return(tt.currentClusterSpec, nil)
+	tt.provider.EXPECT().Name().AnyTimes()
+	tt.provider.EXPECT().SetupAndValidateUpgradeCluster(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.currentClusterSpec)
+	tt.validator.EXPECT().PreflightValidations(tt.ctx).Return(nil)
+}
+
+func (tt *upgradeManagementComponentsTest) expectPrepare() {
+	gomock.InOrder(
+		tt.capiManager.EXPECT().EnsureEtcdProvidersInstallation(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec),
+		tt.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+		tt.provider.EXPECT().PreCoreComponentsUpgrade(tt.ctx, tt.managementCluster, tt.newClusterSpec),
+	)
+}
+
+func (tt *upgradeManagementComponentsTest) expectCreateSpecRevision() {
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
+	tt.provider.EXPECT().DatacenterConfig(tt.newClusterSpec).Return(datacenterConfig)
+	tt.provider.EXPECT().MachineConfigs(tt.newClusterSpec).Return(machineConfigs)
+	tt.clusterManager.EXPECT().CreateSpecRevision(tt.ctx, tt.managementCluster, tt.newClusterSpec, datacenterConfig, machineConfigs)
+}
+
+func (tt *upgradeManagementComponentsTest) expectSaveLogs() {
+	tt.clusterManager.EXPECT().SaveLogsManagementCluster(tt.ctx, tt.newClusterSpec, tt.managementCluster)
+}
+
+func (tt *upgradeManagementComponentsTest) expectWriteCheckpointFile() {
+	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.newClusterSpec.Cluster.Name), gomock.Any())
+}
+
+func (tt *upgradeManagementComponentsTest) withCRDMigrator() *upgradeManagementComponentsTest {
+	tt.workflow = management.NewUpgradeManagementComponents(tt.provider, tt.capiManager, tt.clusterManager, tt.gitOpsManager, tt.writer,
+		management.WithCRDStorageMigrator(tt.crdMigrator))
+	return tt
+}
+
+func (tt *upgradeManagementComponentsTest) expectUpgrade() {
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+	)
+}
+
+func (tt *upgradeManagementComponentsTest) run() error {
+	return tt.workflow.Run(tt.ctx, tt.newClusterSpec, tt.managementCluster, tt.validator)
+}
+
+func changeDiff(component string) *types.ChangeDiff {
+	return types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: component,
+		OldVersion:    "v0.0.1",
+		NewVersion:    "v0.0.2",
+	})
+}
+
+func TestUpgradeManagementComponentsRunSuccess(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec).Return(changeDiff("vsphere"), nil),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec).Return(changeDiff("eks-a"), nil),
+		tt.clusterManager.EXPECT().ApplyBundles(tt.ctx, tt.newClusterSpec, tt.managementCluster),
+		tt.clusterManager.EXPECT().ApplyReleases(tt.ctx, tt.newClusterSpec, tt.managementCluster),
+		tt.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+	)
+	tt.expectCreateSpecRevision()
+
+	g.Expect(tt.run()).To(Succeed())
+}
+
+func TestUpgradeManagementComponentsRunNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+	)
+	tt.expectCreateSpecRevision()
+
+	g.Expect(tt.run()).To(Succeed())
+}
+
+func TestUpgradeManagementComponentsRunKubernetesVersionChanged(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.newClusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube125
+	tt.expectSetup()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(HaveOccurred())
+}
+
+func TestUpgradeManagementComponentsRunWorkerKubernetesVersionChanged(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	version := v1alpha1.Kube123
+	tt.newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = &version
+	tt.expectSetup()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(HaveOccurred())
+}
+
+func TestUpgradeManagementComponentsRunNotManagementCluster(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.newClusterSpec.Cluster.SetManagedBy("other-management")
+	tt.expectSetup()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(HaveOccurred())
+}
+
+func TestUpgradeManagementComponentsRunRollbackOnEKSAUpgradeFailure(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec).Return(changeDiff("vsphere"), nil),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec).Return(nil, errors.New("eks-a upgrade failed")),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.currentClusterSpec),
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.newClusterSpec, tt.currentClusterSpec),
+		tt.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+	)
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError("eks-a upgrade failed"))
+}
+
+func TestUpgradeManagementComponentsRunRollbackOnApplyBundlesFailure(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec).Return(changeDiff("vsphere"), nil),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec),
+		tt.clusterManager.EXPECT().ApplyBundles(tt.ctx, tt.newClusterSpec, tt.managementCluster).Return(errors.New("apply bundles failed")),
+		tt.clusterManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.currentClusterSpec),
+		tt.gitOpsManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.currentClusterSpec),
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.newClusterSpec, tt.currentClusterSpec),
+		tt.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+	)
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError("apply bundles failed"))
+}
+
+func TestUpgradeManagementComponentsRunRollbackFailure(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	gomock.InOrder(
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec).Return(changeDiff("vsphere"), nil),
+		tt.gitOpsManager.EXPECT().Install(tt.ctx, tt.managementCluster, tt.currentClusterSpec, tt.newClusterSpec).Return(errors.New("flux install failed")),
+		tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.newClusterSpec, tt.currentClusterSpec).Return(nil, errors.New("capi rollback failed")),
+	)
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError("flux install failed"))
+}
+
+func TestUpgradeManagementComponentsRunCAPIUpgradeFailureNoRollback(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t)
+	tt.expectSetup()
+	tt.expectPrepare()
+	tt.capiManager.EXPECT().Upgrade(tt.ctx, tt.managementCluster, tt.provider, tt.currentClusterSpec, tt.newClusterSpec).Return(nil, errors.New("capi upgrade failed"))
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError("capi upgrade failed"))
+}
+
+func TestUpgradeManagementComponentsRunMigrateCRDs(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t).withCRDMigrator()
+	tt.expectSetup()
+	tt.expectPrepare()
+	tt.expectUpgrade()
+	results := []crdmigration.Result{
+		{CRD: "clusters.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1alpha4", "v1beta1"}, Migrated: true, Objects: 2},
+		{CRD: "widgets.example.com", StorageVersion: "v1", StoredVersions: []string{"v1"}},
+	}
+	gomock.InOrder(
+		tt.crdMigrator.EXPECT().Migrate(tt.ctx, tt.managementCluster).Return(results, nil),
+		tt.writer.EXPECT().Write("crd-storage-migration-report.yaml", gomock.Any()).
+			DoAndReturn(func(_ string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+				g.Expect(string(content)).To(ContainSubstring("crd: clusters.cluster.x-k8s.io"))
+				g.Expect(string(content)).To(ContainSubstring("objects: 2"))
+				return "management/crd-storage-migration-report.yaml", nil
+			}),
+		tt.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(tt.ctx, tt.managementCluster, tt.newClusterSpec, tt.provider),
+	)
+	tt.expectCreateSpecRevision()
+
+	g.Expect(tt.run()).To(Succeed())
+}
+
+func TestUpgradeManagementComponentsRunMigrateCRDsFailure(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t).withCRDMigrator()
+	tt.expectSetup()
+	tt.expectPrepare()
+	tt.expectUpgrade()
+	tt.crdMigrator.EXPECT().Migrate(tt.ctx, tt.managementCluster).Return(nil, errors.New("migrating objects of CRD machines.cluster.x-k8s.io: conflict"))
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError(ContainSubstring("conflict")))
+}
+
+func TestUpgradeManagementComponentsRunMigrateCRDsReportFailure(t *testing.T) {
+	g := NewWithT(t)
+	tt := newUpgradeManagementComponentsTest(t).withCRDMigrator()
+	tt.expectSetup()
+	tt.expectPrepare()
+	tt.expectUpgrade()
+	tt.crdMigrator.EXPECT().Migrate(tt.ctx, tt.managementCluster).Return([]crdmigration.Result{}, nil)
+	tt.writer.EXPECT().Write("crd-storage-migration-report.yaml", gomock.Any()).Return("", errors.New("disk full"))
+	tt.expectSaveLogs()
+	tt.expectWriteCheckpointFile()
+
+	g.Expect(tt.run()).To(MatchError(ContainSubstring("writing CRD migration report: disk full")))
+}
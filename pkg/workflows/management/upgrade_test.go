@@ -255,6 +255,12 @@ func (c *upgradeManagementTestSetup) expectWriteManagementClusterConfig(err erro
 	)
 }
 
+func (c *upgradeManagementTestSetup) expectCreateSpecRevision() {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().CreateSpecRevision(c.ctx, c.managementCluster, c.newClusterSpec, c.datacenterConfig, c.machineConfigs).Return(nil),
+	)
+}
+
 func (c *upgradeManagementTestSetup) expectSaveLogs() {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, c.newClusterSpec, c.managementCluster).Return(nil),
@@ -516,6 +522,7 @@ func TestUpgradeManagementRunResumeCAPIWorkloadFailed(t *testing.T) {
 	test.expectForceReconcileGitRepo(nil)
 	test.expectResumeGitOpsReconcile(nil)
 	test.expectWriteManagementClusterConfig(nil)
+	test.expectCreateSpecRevision()
 	test.expectResumeCAPIWorkloadClustersAPI(errors.New(""))
 	test.expectSaveLogs()
 	test.expectWriteCheckpointFile()
@@ -641,6 +648,7 @@ func TestUpgradeManagementRunSuccess(t *testing.T) {
 	test.expectForceReconcileGitRepo(nil)
 	test.expectResumeGitOpsReconcile(nil)
 	test.expectWriteManagementClusterConfig(nil)
+	test.expectCreateSpecRevision()
 
 	err := test.run()
 	if err != nil {
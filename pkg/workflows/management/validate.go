@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -36,9 +37,13 @@ func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.Command
 func (s *setupAndValidate) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
 		func() *validations.ValidationResult {
+			err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec)
+			if err != nil {
+				err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+			}
 			return &validations.ValidationResult{
 				Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec),
+				Err:  err,
 			}
 		},
 	}
@@ -50,6 +55,7 @@ func (s *setupAndValidate) Name() string {
 
 func (s *setupAndValidate) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
 	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
+		err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
 		commandContext.SetError(err)
 		return nil, err
 	}
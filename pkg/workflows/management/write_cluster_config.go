@@ -6,6 +6,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 type writeClusterConfig struct{}
@@ -17,6 +18,7 @@ func (s *writeClusterConfig) Run(ctx context.Context, commandContext *task.Comma
 	if err != nil {
 		commandContext.SetError(err)
 	}
+	workflows.RecordClusterSpecRevision(ctx, commandContext, commandContext.ManagementCluster)
 
 	if commandContext.OriginalError == nil {
 		logger.MarkSuccess("Cluster upgraded!")
@@ -0,0 +1,68 @@
+package workflows
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+// OperationRecord records a lifecycle operation run by the CLI as a ClusterOperation. Failures to
+// write the record are only logged, since they shouldn't make the operation itself fail.
+// A nil OperationRecord doesn't record anything.
+type OperationRecord struct {
+	recorder  interfaces.OperationRecorder
+	operation *v1alpha1.ClusterOperation
+}
+
+// StartOperationRecord starts recording an operation on the cluster of spec. If holder, the cluster
+// holding the EKS-A objects, is not nil the operation is recorded right away as in progress, so the
+// controller doesn't record the changes applied by the CLI as a separate operation. It returns nil
+// if recorder is nil.
+func StartOperationRecord(ctx context.Context, recorder interfaces.OperationRecorder, spec *cluster.Spec,
+	opType v1alpha1.ClusterOperationType, holder *types.Cluster,
+) *OperationRecord {
+	if recorder == nil {
+		return nil
+	}
+
+	op := v1alpha1.NewClusterOperation(spec.Cluster, opType, cluster.CLIOperationInitiator(), time.Now())
+	if opType != v1alpha1.DeleteClusterOperation {
+		hash, err := spec.Config.SpecHash()
+		if err != nil {
+			logger.V(3).Info("Failed to compute cluster spec hash for cluster operation", "error", err)
+		}
+		op.Spec.SpecHash = hash
+	}
+
+	r := &OperationRecord{recorder: recorder, operation: op}
+	r.record(ctx, holder)
+
+	return r
+}
+
+// Complete records the outcome of the operation, failed if err is not nil, in holder.
+// Nothing is recorded if holder is nil, like when the creation of a management cluster fails.
+func (r *OperationRecord) Complete(ctx context.Context, holder *types.Cluster, err error) {
+	if r == nil {
+		return
+	}
+
+	r.operation.Complete(time.Now(), err)
+	r.record(ctx, holder)
+}
+
+func (r *OperationRecord) record(ctx context.Context, holder *types.Cluster) {
+	if holder == nil {
+		return
+	}
+
+	logger.V(3).Info("Recording cluster operation", "operation", r.operation.Name, "outcome", r.operation.Status.Outcome)
+	if err := r.recorder.RecordOperation(ctx, holder, r.operation); err != nil {
+		logger.Info("Warning: failed to record cluster operation", "operation", r.operation.Name, "error", err)
+	}
+}
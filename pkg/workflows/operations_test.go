@@ -0,0 +1,74 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+func TestOperationRecordInProgressAndComplete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := mocks.NewMockOperationRecorder(gomock.NewController(t))
+	spec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "my-cluster" })
+	holder := &types.Cluster{Name: "management"}
+
+	var recorded []v1alpha1.ClusterOperation
+	recorder.EXPECT().RecordOperation(ctx, holder, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, op *v1alpha1.ClusterOperation) error {
+			recorded = append(recorded, *op.DeepCopy())
+			return nil
+		},
+	).Times(2)
+
+	record := workflows.StartOperationRecord(ctx, recorder, spec, v1alpha1.UpgradeClusterOperation, holder)
+	record.Complete(ctx, holder, errors.New("upgrade failed"))
+
+	g.Expect(recorded).To(HaveLen(2))
+	g.Expect(recorded[0].Spec.ClusterName).To(Equal("my-cluster"))
+	g.Expect(recorded[0].Spec.Type).To(Equal(v1alpha1.UpgradeClusterOperation))
+	g.Expect(recorded[0].Spec.Initiator.Type).To(Equal(v1alpha1.CLIInitiator))
+	g.Expect(recorded[0].Spec.SpecHash).NotTo(BeEmpty())
+	g.Expect(recorded[0].Status.Outcome).To(Equal(v1alpha1.OperationInProgress))
+	g.Expect(recorded[1].Name).To(Equal(recorded[0].Name))
+	g.Expect(recorded[1].Status.Outcome).To(Equal(v1alpha1.OperationFailed))
+	g.Expect(recorded[1].Status.Message).To(Equal("upgrade failed"))
+}
+
+func TestOperationRecordOnlyOnCompletion(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := mocks.NewMockOperationRecorder(gomock.NewController(t))
+	spec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "my-cluster" })
+	holder := &types.Cluster{Name: "my-cluster"}
+
+	recorder.EXPECT().RecordOperation(ctx, holder, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, op *v1alpha1.ClusterOperation) error {
+			g.Expect(op.Status.Outcome).To(Equal(v1alpha1.OperationSucceeded))
+			return errors.New("api server not reachable")
+		},
+	)
+
+	record := workflows.StartOperationRecord(ctx, recorder, spec, v1alpha1.CreateClusterOperation, nil)
+	record.Complete(ctx, holder, nil)
+}
+
+func TestOperationRecordNilRecorder(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := test.NewClusterSpec()
+
+	record := workflows.StartOperationRecord(ctx, nil, spec, v1alpha1.DeleteClusterOperation, &types.Cluster{})
+	g.Expect(record).To(BeNil())
+	record.Complete(ctx, &types.Cluster{}, nil)
+}
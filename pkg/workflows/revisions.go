@@ -0,0 +1,30 @@
+package workflows
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// RecordClusterSpecRevision records the cluster spec applied by a successful create or upgrade as a new
+// ClusterSpecRevision in cluster, the cluster holding the EKS-A objects. A failure is only logged since
+// the cluster changes have already been applied at this point.
+func RecordClusterSpecRevision(ctx context.Context, commandContext *task.CommandContext, cluster *types.Cluster) {
+	if commandContext.OriginalError != nil {
+		return
+	}
+
+	logger.V(3).Info("Recording cluster spec revision")
+	err := commandContext.ClusterManager.CreateSpecRevision(
+		ctx,
+		cluster,
+		commandContext.ClusterSpec,
+		commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec),
+		commandContext.Provider.MachineConfigs(commandContext.ClusterSpec),
+	)
+	if err != nil {
+		logger.Info("Warning: failed to record cluster spec revision", "error", err)
+	}
+}
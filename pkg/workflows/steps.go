@@ -0,0 +1,96 @@
+package workflows
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// step is a unit of work of a task that runs as part of a workflow.Graph.
+type step struct {
+	name      workflow.TaskName
+	run       func(context.Context) error
+	dependsOn []workflow.TaskName
+}
+
+// runSteps runs steps respecting their dependencies, so independent steps run concurrently.
+// Steps recorded as completed in progress are skipped.
+func runSteps(ctx context.Context, progress *stepsProgress, steps ...step) error {
+	g := workflow.NewGraph(workflow.GraphConfig{Checkpointer: progress})
+	for _, s := range steps {
+		run := s.run
+		t := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			return ctx, run(ctx)
+		})
+		if err := g.AddTask(s.name, t, workflow.DependsOn(s.dependsOn...)); err != nil {
+			return err
+		}
+	}
+
+	return g.Execute(ctx)
+}
+
+// stepsProgress is the partial progress of a task that runs its steps with runSteps. It's saved
+// when the task fails so the next run only runs the steps that didn't complete.
+type stepsProgress struct {
+	mu sync.Mutex
+
+	Completed   []workflow.TaskName                     `json:"completed,omitempty"`
+	ChangeDiffs map[workflow.TaskName]*types.ChangeDiff `json:"changeDiffs,omitempty"`
+}
+
+func newStepsProgress() *stepsProgress {
+	return &stepsProgress{}
+}
+
+func restoreStepsProgress(completedTask *task.CompletedTask) (*stepsProgress, error) {
+	p := newStepsProgress()
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// IsCompleted satisfies workflow.Checkpointer.
+func (p *stepsProgress) IsCompleted(name workflow.TaskName) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, n := range p.Completed {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskCompleted satisfies workflow.Checkpointer.
+func (p *stepsProgress) TaskCompleted(name workflow.TaskName) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Completed = append(p.Completed, name)
+	return nil
+}
+
+// setChangeDiff records the components changed by the step name.
+func (p *stepsProgress) setChangeDiff(name workflow.TaskName, diff *types.ChangeDiff) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ChangeDiffs == nil {
+		p.ChangeDiffs = make(map[workflow.TaskName]*types.ChangeDiff)
+	}
+	p.ChangeDiffs[name] = diff
+}
+
+// changeDiff returns the components changed by the steps, in the order they are passed.
+func (p *stepsProgress) changeDiff(names ...workflow.TaskName) *types.ChangeDiff {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	diff := &types.ChangeDiff{}
+	for _, n := range names {
+		diff.Append(p.ChangeDiffs[n])
+	}
+	return diff
+}
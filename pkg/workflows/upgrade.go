@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	eksaerrors "github.com/aws/eks-anywhere/pkg/errors"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -16,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflow"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -29,6 +32,26 @@ type Upgrade struct {
 	eksdInstaller     interfaces.EksdInstaller
 	eksdUpgrader      interfaces.EksdUpgrader
 	upgradeChangeDiff *types.ChangeDiff
+	workloadBackup    interfaces.WorkloadBackup
+	recorder          interfaces.OperationRecorder
+}
+
+// UpgradeOpt allows to customize an Upgrade on construction.
+type UpgradeOpt func(*Upgrade)
+
+// WithWorkloadBackup makes the upgrade back up the cluster workloads after the validations,
+// before any component or node is upgraded.
+func WithWorkloadBackup(backup interfaces.WorkloadBackup) UpgradeOpt {
+	return func(u *Upgrade) {
+		u.workloadBackup = backup
+	}
+}
+
+// WithUpgradeOperationRecorder makes the upgrade record the operation as a ClusterOperation.
+func WithUpgradeOperationRecorder(recorder interfaces.OperationRecorder) UpgradeOpt {
+	return func(u *Upgrade) {
+		u.recorder = recorder
+	}
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -38,9 +61,10 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 	writer filewriter.FileWriter,
 	eksdUpgrader interfaces.EksdUpgrader,
 	eksdInstaller interfaces.EksdInstaller,
+	opts ...UpgradeOpt,
 ) *Upgrade {
 	upgradeChangeDiff := types.NewChangeDiff()
-	return &Upgrade{
+	u := &Upgrade{
 		bootstrapper:      bootstrapper,
 		provider:          provider,
 		clusterManager:    clusterManager,
@@ -51,6 +75,11 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 		eksdInstaller:     eksdInstaller,
 		upgradeChangeDiff: upgradeChangeDiff,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
 }
 
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
@@ -68,23 +97,32 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		EksdInstaller:     c.eksdInstaller,
 		EksdUpgrader:      c.eksdUpgrader,
 		UpgradeChangeDiff: c.upgradeChangeDiff,
+		WorkloadBackup:    c.workloadBackup,
 		ForceCleanup:      forceCleanup,
 	}
+	record := StartOperationRecord(ctx, c.recorder, clusterSpec, v1alpha1.UpgradeClusterOperation, managementCluster)
+
+	var runnerOpts []task.TaskRunnerOpt
 	if features.IsActive(features.CheckpointEnabled()) {
-		return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+		runnerOpts = append(runnerOpts, task.WithCheckpointFile())
 	}
+	err := task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, runnerOpts...).RunTask(ctx, commandContext)
+	record.Complete(ctx, managementCluster, err)
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer).RunTask(ctx, commandContext)
+	return err
 }
 
 type setupAndValidateTasks struct{}
 
+type backupWorkloads struct{}
+
 type updateSecrets struct{}
 
 type ensureEtcdCAPIComponentsExistTask struct{}
 
 type upgradeCoreComponents struct {
 	UpgradeChangeDiff *types.ChangeDiff
+	progress          *stepsProgress
 }
 
 type upgradeNeeded struct{}
@@ -136,15 +174,27 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 		return nil
 	}
 
+	return afterSetupAndValidate(commandContext)
+}
+
+func afterSetupAndValidate(commandContext *task.CommandContext) task.Task {
+	if commandContext.WorkloadBackup != nil {
+		return &backupWorkloads{}
+	}
+
 	return &updateSecrets{}
 }
 
 func (s *setupAndValidateTasks) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
 		func() *validations.ValidationResult {
+			err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec)
+			if err != nil {
+				err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
+			}
 			return &validations.ValidationResult{
 				Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec),
+				Err:  err,
 			}
 		},
 	}
@@ -156,6 +206,7 @@ func (s *setupAndValidateTasks) Name() string {
 
 func (s *setupAndValidateTasks) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
 	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
+		err = eksaerrors.WithCode(eksaerrors.ProviderSetupCode(commandContext.Provider.Name()), err)
 		commandContext.SetError(err)
 		return nil, err
 	}
@@ -166,7 +217,7 @@ func (s *setupAndValidateTasks) Restore(ctx context.Context, commandContext *tas
 		return nil, err
 	}
 	commandContext.CurrentClusterSpec = currentSpec
-	return &updateSecrets{}, nil
+	return afterSetupAndValidate(commandContext), nil
 }
 
 func (s *setupAndValidateTasks) Checkpoint() *task.CompletedTask {
@@ -175,6 +226,30 @@ func (s *setupAndValidateTasks) Checkpoint() *task.CompletedTask {
 	}
 }
 
+func (s *backupWorkloads) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Backing up cluster workloads")
+	if err := commandContext.WorkloadBackup.Backup(ctx, commandContext.WorkloadCluster); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	return &updateSecrets{}
+}
+
+func (s *backupWorkloads) Name() string {
+	return "backup-workloads"
+}
+
+func (s *backupWorkloads) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *backupWorkloads) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &updateSecrets{}, nil
+}
+
 func (s *updateSecrets) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	err := commandContext.Provider.UpdateSecrets(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec)
 	if err != nil {
@@ -234,48 +309,144 @@ func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.Co
 		return &CollectDiagnosticsTask{}
 	}
 
-	changeDiff, err := commandContext.ClusterManager.UpgradeNetworking(ctx, commandContext.WorkloadCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec, commandContext.Provider)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+	if s.progress == nil {
+		s.progress = newStepsProgress()
 	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
-
-	changeDiff, err = commandContext.CAPIManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.Provider, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
+	if err = runSteps(ctx, s.progress, s.coreComponentsSteps(commandContext)...); err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
+	commandContext.UpgradeChangeDiff.Append(s.progress.changeDiff(coreComponentsChangeDiffSteps...))
+	s.UpgradeChangeDiff = commandContext.UpgradeChangeDiff
 
-	if err = commandContext.GitOpsManager.Install(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec); err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
-	}
+	return &upgradeNeeded{}
+}
 
-	changeDiff, err = commandContext.GitOpsManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
-	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
+const (
+	networkingStep     workflow.TaskName = "networking"
+	capiComponentsStep workflow.TaskName = "capi-components"
+	gitOpsInstallStep  workflow.TaskName = "gitops-install"
+	gitOpsUpgradeStep  workflow.TaskName = "gitops-upgrade"
+	eksaComponentsStep workflow.TaskName = "eksa-components"
+	eksdComponentsStep workflow.TaskName = "eksd-components"
+)
 
-	changeDiff, err = commandContext.ClusterManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
-	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
+// coreComponentsChangeDiffSteps are the core components steps that report changed components, in
+// the order their changes are reported.
+var coreComponentsChangeDiffSteps = []workflow.TaskName{
+	networkingStep, capiComponentsStep, gitOpsUpgradeStep, eksaComponentsStep, eksdComponentsStep,
+}
 
-	changeDiff, err = commandContext.EksdUpgrader.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+// coreComponentsSteps returns the steps that upgrade the core components. The EKS-A components
+// are upgraded after the CAPI providers they build on, and the policy engine goes last so its
+// admission policies can't reject the other components.
+func (s *upgradeCoreComponents) coreComponentsSteps(commandContext *task.CommandContext) []step {
+	currentSpec := commandContext.CurrentClusterSpec
+	newSpec := commandContext.ClusterSpec
+	managementCluster := commandContext.ManagementCluster
+	workloadCluster := commandContext.WorkloadCluster
+
+	return []step{
+		{
+			name: networkingStep,
+			run: func(ctx context.Context) error {
+				changeDiff, err := commandContext.ClusterManager.UpgradeNetworking(ctx, workloadCluster, currentSpec, newSpec, commandContext.Provider)
+				if err != nil {
+					return err
+				}
+				s.progress.setChangeDiff(networkingStep, changeDiff)
+				return nil
+			},
+		},
+		{
+			name: imageWarmCacheStep,
+			run: func(ctx context.Context) error {
+				if newSpec.Cluster.Spec.ImageWarmCache == nil {
+					return nil
+				}
+				logger.Info("Upgrading image warm cache")
+				return commandContext.ClusterManager.InstallImageWarmCache(ctx, newSpec, workloadCluster)
+			},
+			dependsOn: []workflow.TaskName{networkingStep},
+		},
+		{
+			name: capiComponentsStep,
+			run: func(ctx context.Context) error {
+				changeDiff, err := commandContext.CAPIManager.Upgrade(ctx, managementCluster, commandContext.Provider, currentSpec, newSpec)
+				if err != nil {
+					return err
+				}
+				s.progress.setChangeDiff(capiComponentsStep, changeDiff)
+				return nil
+			},
+		},
+		{
+			name: certManagerStep,
+			run: func(ctx context.Context) error {
+				if newSpec.Cluster.Spec.CertManager == nil {
+					return nil
+				}
+				logger.Info("Upgrading cert-manager")
+				return commandContext.ClusterManager.InstallCertManager(ctx, newSpec, workloadCluster)
+			},
+			dependsOn: []workflow.TaskName{capiComponentsStep},
+		},
+		{
+			name: gitOpsInstallStep,
+			run: func(ctx context.Context) error {
+				return commandContext.GitOpsManager.Install(ctx, managementCluster, currentSpec, newSpec)
+			},
+		},
+		{
+			name: gitOpsUpgradeStep,
+			run: func(ctx context.Context) error {
+				changeDiff, err := commandContext.GitOpsManager.Upgrade(ctx, managementCluster, currentSpec, newSpec)
+				if err != nil {
+					return err
+				}
+				s.progress.setChangeDiff(gitOpsUpgradeStep, changeDiff)
+				return nil
+			},
+			dependsOn: []workflow.TaskName{gitOpsInstallStep},
+		},
+		{
+			name: eksaComponentsStep,
+			run: func(ctx context.Context) error {
+				changeDiff, err := commandContext.ClusterManager.Upgrade(ctx, managementCluster, currentSpec, newSpec)
+				if err != nil {
+					return err
+				}
+				s.progress.setChangeDiff(eksaComponentsStep, changeDiff)
+				return nil
+			},
+			dependsOn: []workflow.TaskName{capiComponentsStep},
+		},
+		{
+			name: eksdComponentsStep,
+			run: func(ctx context.Context) error {
+				changeDiff, err := commandContext.EksdUpgrader.Upgrade(ctx, managementCluster, currentSpec, newSpec)
+				if err != nil {
+					return err
+				}
+				s.progress.setChangeDiff(eksdComponentsStep, changeDiff)
+				return nil
+			},
+			dependsOn: []workflow.TaskName{eksaComponentsStep},
+		},
+		{
+			name: policyEngineStep,
+			run: func(ctx context.Context) error {
+				if newSpec.Cluster.Spec.PolicyEngine == nil {
+					return nil
+				}
+				logger.Info("Upgrading policy engine")
+				return commandContext.ClusterManager.InstallPolicyEngine(ctx, newSpec, workloadCluster)
+			},
+			dependsOn: []workflow.TaskName{
+				imageWarmCacheStep, certManagerStep, gitOpsUpgradeStep, eksdComponentsStep,
+			},
+		},
 	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
-	s.UpgradeChangeDiff = commandContext.UpgradeChangeDiff
-
-	return &upgradeNeeded{}
 }
 
 func (s *upgradeCoreComponents) Name() string {
@@ -288,6 +459,26 @@ func (s *upgradeCoreComponents) Checkpoint() *task.CompletedTask {
 	}
 }
 
+// Progress returns the core components steps completed before the task failed.
+func (s *upgradeCoreComponents) Progress() *task.CompletedTask {
+	if s.progress == nil {
+		return nil
+	}
+	return &task.CompletedTask{
+		Checkpoint: s.progress,
+	}
+}
+
+// ResumeProgress makes the task skip the core components steps completed by a previous run.
+func (s *upgradeCoreComponents) ResumeProgress(progress *task.CompletedTask) error {
+	p, err := restoreStepsProgress(progress)
+	if err != nil {
+		return err
+	}
+	s.progress = p
+	return nil
+}
+
 func (s *upgradeCoreComponents) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
 	s.UpgradeChangeDiff = &types.ChangeDiff{}
 	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.UpgradeChangeDiff); err != nil {
@@ -665,6 +856,8 @@ func (s *writeClusterConfigTask) Run(ctx context.Context, commandContext *task.C
 	if err != nil {
 		commandContext.SetError(err)
 	}
+	RecordClusterSpecRevision(ctx, commandContext, commandContext.ManagementCluster)
+
 	return &deleteBootstrapClusterTask{}
 }
 
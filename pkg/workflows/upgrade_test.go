@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -34,6 +36,7 @@ type upgradeTestSetup struct {
 	eksdInstaller       *mocks.MockEksdInstaller
 	eksdUpgrader        *mocks.MockEksdUpgrader
 	capiManager         *mocks.MockCAPIManager
+	workloadBackup      *mocks.MockWorkloadBackup
 	datacenterConfig    providers.DatacenterConfig
 	machineConfigs      []providers.MachineConfig
 	workflow            *workflows.Upgrade
@@ -60,6 +63,7 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 	eksdUpgrader := mocks.NewMockEksdUpgrader(mockCtrl)
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	capiUpgrader := mocks.NewMockCAPIManager(mockCtrl)
+	workloadBackup := mocks.NewMockWorkloadBackup(mockCtrl)
 	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
 	workflow := workflows.NewUpgrade(
 		bootstrapper,
@@ -87,6 +91,7 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 		eksdInstaller:       eksdInstaller,
 		eksdUpgrader:        eksdUpgrader,
 		capiManager:         capiUpgrader,
+		workloadBackup:      workloadBackup,
 		datacenterConfig:    datacenterConfig,
 		machineConfigs:      machineConfigs,
 		workflow:            workflow,
@@ -102,6 +107,21 @@ func (c *upgradeTestSetup) WithForceCleanup() *upgradeTestSetup {
 	return c
 }
 
+func (c *upgradeTestSetup) WithWorkloadBackup() *upgradeTestSetup {
+	c.workflow = workflows.NewUpgrade(
+		c.bootstrapper,
+		c.provider,
+		c.capiManager,
+		c.clusterManager,
+		c.gitOpsManager,
+		c.writer,
+		c.eksdUpgrader,
+		c.eksdInstaller,
+		workflows.WithWorkloadBackup(c.workloadBackup),
+	)
+	return c
+}
+
 func newUpgradeSelfManagedClusterTest(t *testing.T) *upgradeTestSetup {
 	tt := newUpgradeTest(t)
 	tt.bootstrapCluster = &types.Cluster{
@@ -177,14 +197,16 @@ func (c *upgradeTestSetup) expectUpgradeCoreComponents(managementCluster *types.
 		OldVersion:    "v0.0.1",
 		NewVersion:    "v0.0.2",
 	})
+	c.clusterManager.EXPECT().UpgradeNetworking(c.ctx, workloadCluster, currentSpec, c.newClusterSpec, c.provider).Return(networkingChangeDiff, nil)
 	gomock.InOrder(
-		c.clusterManager.EXPECT().UpgradeNetworking(c.ctx, workloadCluster, currentSpec, c.newClusterSpec, c.provider).Return(networkingChangeDiff, nil),
 		c.capiManager.EXPECT().Upgrade(c.ctx, managementCluster, c.provider, currentSpec, c.newClusterSpec).Return(capiChangeDiff, nil),
-		c.gitOpsManager.EXPECT().Install(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil),
-		c.gitOpsManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(fluxChangeDiff, nil),
 		c.clusterManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(eksaChangeDiff, nil),
 		c.eksdUpgrader.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(eksdChangeDiff, nil),
 	)
+	gomock.InOrder(
+		c.gitOpsManager.EXPECT().Install(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil),
+		c.gitOpsManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(fluxChangeDiff, nil),
+	)
 }
 
 func (c *upgradeTestSetup) expectForceCleanupBootstrap() {
@@ -226,6 +248,9 @@ func (c *upgradeTestSetup) expectWriteClusterConfig() {
 		c.provider.EXPECT().DatacenterConfig(c.newClusterSpec).Return(c.datacenterConfig),
 		c.provider.EXPECT().MachineConfigs(c.newClusterSpec).Return(c.machineConfigs),
 		c.writer.EXPECT().Write("cluster-name-eks-a-cluster.yaml", gomock.Any(), gomock.Any()),
+		c.provider.EXPECT().DatacenterConfig(c.newClusterSpec).Return(c.datacenterConfig),
+		c.provider.EXPECT().MachineConfigs(c.newClusterSpec).Return(c.machineConfigs),
+		c.clusterManager.EXPECT().CreateSpecRevision(c.ctx, gomock.Any(), c.newClusterSpec, c.datacenterConfig, c.machineConfigs),
 	)
 }
 
@@ -513,6 +538,131 @@ func TestUpgradeRunSuccess(t *testing.T) {
 	}
 }
 
+func TestUpgradeRunWorkloadBackupSuccess(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	test := newUpgradeSelfManagedClusterTest(t).WithWorkloadBackup()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.workloadBackup.EXPECT().Backup(test.ctx, test.workloadCluster).Return(nil)
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponents(test.workloadCluster, test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded(test.workloadCluster)
+	test.expectVerifyClusterSpecChanged(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsReconcile(test.workloadCluster)
+	test.expectCreateBootstrap()
+	test.expectMoveManagementToBootstrap()
+	test.expectUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
+	test.expectMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectPostBootstrapDeleteForUpgrade()
+	test.expectPreCoreComponentsUpgrade()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeRunWorkloadBackupFailed(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	test := newUpgradeSelfManagedClusterTest(t).WithWorkloadBackup()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.workloadBackup.EXPECT().Backup(test.ctx, test.workloadCluster).Return(errors.New("velero is not installed"))
+	test.provider.EXPECT().UpdateSecrets(test.ctx, gomock.Any(), gomock.Any()).Times(0)
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeRunPolicyEngineSuccess(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.newClusterSpec.Cluster.Spec.PolicyEngine = &v1alpha1.PolicyEngineConfiguration{Engine: v1alpha1.KyvernoPolicyEngine}
+	test.clusterManager.EXPECT().InstallPolicyEngine(test.ctx, test.newClusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponents(test.workloadCluster, test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded(test.workloadCluster)
+	test.expectVerifyClusterSpecChanged(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsReconcile(test.workloadCluster)
+	test.expectCreateBootstrap()
+	test.expectMoveManagementToBootstrap()
+	test.expectUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
+	test.expectMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectPostBootstrapDeleteForUpgrade()
+	test.expectPreCoreComponentsUpgrade()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeRunCertManagerSuccess(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.newClusterSpec.Cluster.Spec.CertManager = &v1alpha1.CertManagerConfiguration{}
+	test.clusterManager.EXPECT().InstallCertManager(test.ctx, test.newClusterSpec, test.workloadCluster)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponents(test.workloadCluster, test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded(test.workloadCluster)
+	test.expectVerifyClusterSpecChanged(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsReconcile(test.workloadCluster)
+	test.expectCreateBootstrap()
+	test.expectMoveManagementToBootstrap()
+	test.expectUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
+	test.expectMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectPostBootstrapDeleteForUpgrade()
+	test.expectPreCoreComponentsUpgrade()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestUpgradeRunSuccessForceCleanup(t *testing.T) {
 	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t).WithForceCleanup()
@@ -754,3 +904,70 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
 	}
 }
+
+func TestUpgradeWithCheckpointResumeCoreComponents(t *testing.T) {
+	features.ClearCache()
+	t.Setenv(features.CheckpointEnabledEnvVar, "true")
+	checkpointDir := t.TempDir()
+
+	test := newUpgradeSelfManagedClusterTest(t)
+	currentSpec := test.currentClusterSpec
+	test.writer.EXPECT().TempDir().Return(checkpointDir)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsReconcile(test.workloadCluster)
+	test.expectPreCoreComponentsUpgrade()
+	test.clusterManager.EXPECT().UpgradeNetworking(test.ctx, test.workloadCluster, currentSpec, test.newClusterSpec, test.provider)
+	test.capiManager.EXPECT().Upgrade(test.ctx, test.workloadCluster, test.provider, currentSpec, test.newClusterSpec)
+	test.gitOpsManager.EXPECT().Install(test.ctx, test.workloadCluster, currentSpec, test.newClusterSpec)
+	test.gitOpsManager.EXPECT().Upgrade(test.ctx, test.workloadCluster, currentSpec, test.newClusterSpec)
+	test.clusterManager.EXPECT().Upgrade(test.ctx, test.workloadCluster, currentSpec, test.newClusterSpec)
+	test.eksdUpgrader.EXPECT().Upgrade(test.ctx, test.workloadCluster, currentSpec, test.newClusterSpec).Return(nil, errors.New("upgrading eks-d"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.newClusterSpec, gomock.Any())
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.newClusterSpec, test.workloadCluster)
+	test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.newClusterSpec.Cluster.Name), gomock.Any()).DoAndReturn(
+		func(name string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			path := filepath.Join(checkpointDir, name)
+			return path, os.WriteFile(path, content, 0o600)
+		},
+	)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+
+	eksdChangeDiff := types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: "eks-d",
+		OldVersion:    "v0.0.1",
+		NewVersion:    "v0.0.2",
+	})
+	test2 := newUpgradeSelfManagedClusterTest(t)
+	test2.writer.EXPECT().TempDir().Return(checkpointDir)
+	test2.expectSetup()
+	test2.expectPreCoreComponentsUpgrade()
+	test2.eksdUpgrader.EXPECT().Upgrade(test2.ctx, test2.workloadCluster, test2.currentClusterSpec, test2.newClusterSpec).Return(eksdChangeDiff, nil)
+	test2.expectProviderNoUpgradeNeeded(test2.workloadCluster)
+	test2.expectVerifyClusterSpecChanged(test2.workloadCluster)
+	test2.expectCreateBootstrap()
+	test2.expectMoveManagementToBootstrap()
+	test2.expectUpgradeWorkload(test2.bootstrapCluster, test2.workloadCluster)
+	test2.expectMoveManagementToWorkload()
+	test2.expectWriteClusterConfig()
+	test2.expectDeleteBootstrap()
+	test2.expectDatacenterConfig()
+	test2.expectMachineConfigs()
+	test2.expectCreateEKSAResources(test2.workloadCluster)
+	test2.expectInstallEksdManifest(test2.workloadCluster)
+	test2.expectResumeEKSAControllerReconcile(test2.workloadCluster)
+	test2.expectUpdateGitEksaSpec()
+	test2.expectForceReconcileGitRepo(test2.workloadCluster)
+	test2.expectResumeGitOpsReconcile(test2.workloadCluster)
+	test2.expectPostBootstrapDeleteForUpgrade()
+
+	if err := test2.run(); err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
+	}
+}
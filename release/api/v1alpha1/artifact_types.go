@@ -40,6 +40,10 @@ type Image struct {
 
 	// The SHA256 digest of the image manifest
 	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// +optional
+	// The image repository, name, and tag of the FIPS compliant variant of the image
+	FIPSURI string `json:"fipsUri,omitempty"`
 }
 
 func (i Image) VersionedImage() string {
@@ -47,19 +51,42 @@ func (i Image) VersionedImage() string {
 }
 
 func (i Image) Image() string {
-	lastInd := strings.LastIndex(i.URI, ":")
+	ref, _ := i.splitDigest()
+	lastInd := strings.LastIndex(ref, ":")
 	if lastInd == -1 {
-		return i.URI
+		return ref
 	}
-	return i.URI[:lastInd]
+	return ref[:lastInd]
 }
 
+// Tag returns the tag of the image, without the digest for images pinned to one.
 func (i Image) Tag() string {
-	lastInd := strings.LastIndex(i.URI, ":")
-	if lastInd == -1 || lastInd == len(i.URI)-1 {
+	ref, _ := i.splitDigest()
+	lastInd := strings.LastIndex(ref, ":")
+	if lastInd == -1 || lastInd == len(ref)-1 {
 		return ""
 	}
-	return i.URI[lastInd+1:]
+	return ref[lastInd+1:]
+}
+
+// PinnedTag returns the tag of the image followed by its digest for images pinned to one, like
+// repo:tag@sha256:..., so an image reference built from the name and this tag is still pinned.
+// For images not pinned, it returns the same as Tag.
+func (i Image) PinnedTag() string {
+	_, digest := i.splitDigest()
+	if digest == "" {
+		return i.Tag()
+	}
+	return i.Tag() + digest
+}
+
+// splitDigest splits a trailing @sha256: digest from the URI. The returned digest includes the "@".
+func (i Image) splitDigest() (ref, digest string) {
+	ind := strings.LastIndex(i.URI, "@sha256:")
+	if ind == -1 {
+		return i.URI, ""
+	}
+	return i.URI[:ind], i.URI[ind:]
 }
 
 func (i Image) ChartName() string {
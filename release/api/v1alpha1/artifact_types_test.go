@@ -70,6 +70,16 @@ func TestImageImage(t *testing.T) {
 			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node",
 			want:     "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node",
 		},
+		{
+			testName: "pinned to digest",
+			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node:v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38@sha256:0123456789abcdef",
+			want:     "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node",
+		},
+		{
+			testName: "digest without tag",
+			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node@sha256:0123456789abcdef",
+			want:     "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -109,6 +119,16 @@ func TestImageTag(t *testing.T) {
 			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node:",
 			want:     "",
 		},
+		{
+			testName: "pinned to digest",
+			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node:v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38@sha256:0123456789abcdef",
+			want:     "v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38",
+		},
+		{
+			testName: "digest without tag",
+			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node@sha256:0123456789abcdef",
+			want:     "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -122,6 +142,35 @@ func TestImageTag(t *testing.T) {
 	}
 }
 
+func TestImagePinnedTag(t *testing.T) {
+	tests := []struct {
+		testName string
+		URI      string
+		want     string
+	}{
+		{
+			testName: "not pinned",
+			URI:      "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node:v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38",
+			want:     "v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38",
+		},
+		{
+			testName: "pinned to digest",
+			URI:      "public.ecr.aws:8484/l0g8r8j6/kubernetes-sigs/kind/node:v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38@sha256:0123456789abcdef",
+			want:     "v1.20.4-eks-d-1-20-1-eks-a-0.0.1.build.38@sha256:0123456789abcdef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			i := v1alpha1.Image{
+				URI: tt.URI,
+			}
+			if got := i.PinnedTag(); got != tt.want {
+				t.Errorf("Image.PinnedTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestImage_Registry(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -14,6 +14,11 @@
 
 package v1alpha1
 
+import (
+	"reflect"
+	"strings"
+)
+
 func (vb *VersionsBundle) Manifests() map[string][]*string {
 	return map[string][]*string{
 		"core-cluster-api": {
@@ -227,3 +232,57 @@ func (vb *VersionsBundle) Charts() map[string]*Image {
 		"tinkerbell-chart":      &vb.Tinkerbell.TinkerbellStack.TinkebellChart,
 	}
 }
+
+// UseFIPSImages replaces every image in the bundle that has a FIPS compliant variant with it. The digest
+// of those images is cleared since it belongs to the non FIPS variant.
+func (vb *VersionsBundle) UseFIPSImages() {
+	forEachImage(reflect.ValueOf(vb).Elem(), func(i *Image) {
+		if i.FIPSURI == "" {
+			return
+		}
+		i.URI = i.FIPSURI
+		i.ImageDigest = ""
+	})
+}
+
+// PinImageDigests appends the digest to the URI of every image in the bundle, so they are pulled by
+// digest even if their tag is moved. The Helm charts are not pinned since Helm pulls them by version.
+// It returns the URIs of the images without a digest, which are left unchanged.
+func (vb *VersionsBundle) PinImageDigests() []string {
+	charts := map[*Image]bool{}
+	for _, c := range vb.Charts() {
+		charts[c] = true
+	}
+
+	var unpinned []string
+	forEachImage(reflect.ValueOf(vb).Elem(), func(i *Image) {
+		if charts[i] || i.URI == "" || strings.Contains(i.URI, "@") {
+			return
+		}
+		if i.ImageDigest == "" {
+			unpinned = append(unpinned, i.URI)
+			return
+		}
+		i.URI = i.URI + "@" + i.ImageDigest
+	})
+
+	return unpinned
+}
+
+// forEachImage calls f with every Image in v, walking nested structs.
+func forEachImage(v reflect.Value, f func(*Image)) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	if i, ok := v.Addr().Interface().(*Image); ok {
+		f(i)
+		return
+	}
+
+	for n := 0; n < v.NumField(); n++ {
+		if field := v.Field(n); field.CanSet() {
+			forEachImage(field, f)
+		}
+	}
+}
@@ -22,6 +22,75 @@ import (
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+func TestVersionsBundleUseFIPSImages(t *testing.T) {
+	g := NewWithT(t)
+	vb := &v1alpha1.VersionsBundle{
+		Eksa: v1alpha1.EksaBundle{
+			ClusterController: v1alpha1.Image{
+				URI:         "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0",
+				FIPSURI:     "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0-fips",
+				ImageDigest: "sha256:0123456789abcdef",
+			},
+			CliTools: v1alpha1.Image{
+				URI:         "public.ecr.aws/eks-anywhere/cli-tools:v0.18.0",
+				ImageDigest: "sha256:fedcba9876543210",
+			},
+		},
+		Tinkerbell: v1alpha1.TinkerbellBundle{
+			TinkerbellStack: v1alpha1.TinkerbellStackBundle{
+				Hook: v1alpha1.HookBundle{
+					Bootkit: v1alpha1.Image{
+						URI:     "public.ecr.aws/eks-anywhere/hook-bootkit:v0.18.0",
+						FIPSURI: "public.ecr.aws/eks-anywhere/hook-bootkit:v0.18.0-fips",
+					},
+				},
+			},
+		},
+	}
+
+	vb.UseFIPSImages()
+
+	g.Expect(vb.Eksa.ClusterController).To(Equal(v1alpha1.Image{
+		URI:     "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0-fips",
+		FIPSURI: "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0-fips",
+	}))
+	g.Expect(vb.Eksa.CliTools).To(Equal(v1alpha1.Image{
+		URI:         "public.ecr.aws/eks-anywhere/cli-tools:v0.18.0",
+		ImageDigest: "sha256:fedcba9876543210",
+	}))
+	g.Expect(vb.Tinkerbell.TinkerbellStack.Hook.Bootkit.URI).To(Equal("public.ecr.aws/eks-anywhere/hook-bootkit:v0.18.0-fips"))
+}
+
+func TestVersionsBundlePinImageDigests(t *testing.T) {
+	g := NewWithT(t)
+	vb := &v1alpha1.VersionsBundle{
+		Eksa: v1alpha1.EksaBundle{
+			ClusterController: v1alpha1.Image{
+				URI:         "public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0",
+				ImageDigest: "sha256:0123456789abcdef",
+			},
+			CliTools: v1alpha1.Image{
+				URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.18.0",
+			},
+		},
+		Cilium: v1alpha1.CiliumBundle{
+			HelmChart: v1alpha1.Image{
+				URI:         "public.ecr.aws/isovalent/cilium:1.13.9-eksa.1",
+				ImageDigest: "sha256:fedcba9876543210",
+			},
+		},
+	}
+
+	g.Expect(vb.PinImageDigests()).To(ConsistOf("public.ecr.aws/eks-anywhere/cli-tools:v0.18.0"))
+	g.Expect(vb.Eksa.ClusterController.URI).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0@sha256:0123456789abcdef"))
+	g.Expect(vb.Eksa.CliTools.URI).To(Equal("public.ecr.aws/eks-anywhere/cli-tools:v0.18.0"))
+	g.Expect(vb.Cilium.HelmChart.URI).To(Equal("public.ecr.aws/isovalent/cilium:1.13.9-eksa.1"))
+
+	// Pinning twice doesn't append the digest again.
+	vb.PinImageDigests()
+	g.Expect(vb.Eksa.ClusterController.URI).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.18.0@sha256:0123456789abcdef"))
+}
+
 func TestVersionsBundleSnowImages(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -887,6 +887,34 @@ func TestDockerKubernetes124UpgradeAndRemoveWorkerNodeGroupsAPI(t *testing.T) {
 	)
 }
 
+func TestDockerKubernetes126to128WorkerNodeGroupVersionSkewAPI(t *testing.T) {
+	provider := framework.NewDocker(t)
+	test := framework.NewClusterE2ETest(
+		t, provider,
+	).WithClusterConfig(
+		api.ClusterToConfigFiller(
+			api.WithKubernetesVersion(v1alpha1.Kube126),
+			api.WithControlPlaneCount(1),
+			api.RemoveAllWorkerNodeGroups(), // This gives us a blank slate
+		),
+		provider.WithNewWorkerNodeGroup("", framework.WithWorkerNodeGroup("worker-1", api.WithCount(1))),
+		provider.WithNewWorkerNodeGroup("", framework.WithWorkerNodeGroup("worker-2", api.WithCount(1))),
+	)
+
+	runUpgradeFlowWithAPIInSteps(
+		test,
+		// Upgrade the control plane and worker-2, keeping worker-1 two minor versions behind
+		api.ClusterToConfigFiller(
+			api.WithKubernetesVersion(v1alpha1.Kube128),
+			api.WithWorkerNodeGroup("worker-1", api.WithWorkerKubernetesVersion(v1alpha1.Kube126)),
+		),
+		// Upgrade worker-1 on its own
+		api.ClusterToConfigFiller(
+			api.WithWorkerNodeGroup("worker-1", api.WithWorkerKubernetesVersion(v1alpha1.Kube128)),
+		),
+	)
+}
+
 // Workload Cluster API
 func TestDockerUpgradeKubernetes123to124WorkloadClusterScaleupAPI(t *testing.T) {
 	provider := framework.NewDocker(t)
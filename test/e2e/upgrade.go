@@ -75,6 +75,20 @@ func runUpgradeFlowWithAPI(test *framework.ClusterE2ETest, fillers ...api.Cluste
 	test.DeleteCluster()
 }
 
+// runUpgradeFlowWithAPIInSteps applies each of the upgrades through the API one after the other,
+// validating the cluster state after each of them.
+func runUpgradeFlowWithAPIInSteps(test *framework.ClusterE2ETest, upgrades ...api.ClusterConfigFiller) {
+	test.CreateCluster()
+	test.LoadClusterConfigGeneratedByCLI()
+	for _, upgrade := range upgrades {
+		test.UpdateClusterConfig(upgrade)
+		test.ApplyClusterManifest()
+		test.ValidateClusterState()
+		test.StopIfFailed()
+	}
+	test.DeleteCluster()
+}
+
 func runUpgradeFlowForBareMetalWithAPI(test *framework.ClusterE2ETest, fillers ...api.ClusterConfigFiller) {
 	test.GenerateClusterConfig()
 	test.GenerateHardwareConfig()
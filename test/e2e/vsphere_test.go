@@ -2715,6 +2715,32 @@ func TestVSphereKubernetes124UbuntuUpgradeAndRemoveWorkerNodeGroupsAPI(t *testin
 	)
 }
 
+func TestVSphereKubernetes126to128UbuntuWorkerNodeGroupVersionSkewAPI(t *testing.T) {
+	provider := framework.NewVSphere(t)
+	test := framework.NewClusterE2ETest(
+		t, provider,
+	).WithClusterConfig(
+		api.ClusterToConfigFiller(
+			api.WithControlPlaneCount(1),
+			api.RemoveAllWorkerNodeGroups(), // This gives us a blank slate
+		),
+		provider.WithNewWorkerNodeGroup("worker-1", framework.WithWorkerNodeGroup("worker-1", api.WithCount(1))),
+		provider.WithNewWorkerNodeGroup("worker-2", framework.WithWorkerNodeGroup("worker-2", api.WithCount(1))),
+		provider.WithUbuntu126(),
+	)
+
+	runUpgradeFlowWithAPIInSteps(
+		test,
+		// Upgrade the control plane and worker-2, keeping worker-1 two minor versions behind
+		api.JoinClusterConfigFillers(
+			provider.WithUbuntu128(),
+			provider.WithWorkerNodeGroupKubeVersionAndOS("worker-1", v1alpha1.Kube126, framework.Ubuntu2004),
+		),
+		// Upgrade worker-1 on its own
+		provider.WithWorkerNodeGroupKubeVersionAndOS("worker-1", v1alpha1.Kube128, framework.Ubuntu2004),
+	)
+}
+
 func TestVSphereKubernetes123to124UpgradeFromLatestMinorReleaseBottleRocketAPI(t *testing.T) {
 	release := latestMinorRelease(t)
 	provider := framework.NewVSphere(t)
@@ -1309,6 +1309,41 @@ func (e *ClusterE2ETest) WithPersistentCluster(f func(e *ClusterE2ETest)) {
 	f(e)
 }
 
+// Attach reattaches the test to an existing cluster created by a previous run of the test with
+// the given name, reusing its cluster config and kubeconfig from the cluster folder. The cluster
+// is treated as persistent, so creating it and generating its config are skipped. Useful to
+// iterate on the later phases of long tests after a failed run.
+func (e *ClusterE2ETest) Attach(clusterName string) {
+	previousFolder := e.ClusterConfigFolder
+	// The cluster folder defaults to the cluster name, follow it unless it was customized.
+	if previousFolder == e.ClusterName {
+		e.ClusterConfigFolder = clusterName
+	}
+	e.ClusterName = clusterName
+	if e.HardwareConfigLocation == filepath.Join(previousFolder, hardwareYamlPath) {
+		e.HardwareConfigLocation = filepath.Join(e.ClusterConfigFolder, hardwareYamlPath)
+	}
+	if e.HardwareCsvLocation == filepath.Join(previousFolder, hardwareCsvPath) {
+		e.HardwareCsvLocation = filepath.Join(e.ClusterConfigFolder, hardwareCsvPath)
+	}
+	e.ClusterConfigLocation = filepath.Join(e.ClusterConfigFolder, clusterName+"-eks-a.yaml")
+
+	if !fileExists(e.ClusterConfigLocation) {
+		e.T.Fatalf("Can't attach to cluster %s: cluster config %s not found", clusterName, e.ClusterConfigLocation)
+	}
+	if !fileExists(e.KubeconfigFilePath()) {
+		e.T.Fatalf("Can't attach to cluster %s: kubeconfig %s not found", clusterName, e.KubeconfigFilePath())
+	}
+
+	e.parseClusterConfigFromDisk(e.ClusterConfigLocation)
+	e.PersistentCluster = true
+
+	if _, err := e.KubectlClient.GetEksaCluster(context.Background(), e.Cluster(), clusterName); err != nil {
+		e.T.Fatalf("Can't attach to cluster %s: %v", clusterName, err)
+	}
+	e.T.Logf("Attached to existing cluster %s", clusterName)
+}
+
 // VerifyHarborPackageInstalled is checking if the harbor package gets installed correctly.
 func (e *ClusterE2ETest) VerifyHarborPackageInstalled(prefix, namespace string) {
 	ctx, cancel := context.WithCancel(context.Background())
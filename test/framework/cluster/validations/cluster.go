@@ -104,8 +104,12 @@ func ValidateWorkerNodes(ctx context.Context, vc clusterf.StateValidationConfig)
 		}
 		workerNodes := filterWorkerNodes(nodes.Items, ms, w)
 		workerGroupCount += len(workerNodes)
+		kubeVersion := clus.Spec.KubernetesVersion
+		if w.KubernetesVersion != nil {
+			kubeVersion = *w.KubernetesVersion
+		}
 		for _, node := range workerNodes {
-			if err := validateNodeReady(node, vc.ClusterSpec.Cluster.Spec.KubernetesVersion); err != nil {
+			if err := validateNodeReady(node, kubeVersion); err != nil {
 				errorList = append(errorList, fmt.Errorf("failed to validate worker node ready %v", err))
 			}
 			if err := api.ValidateWorkerNodeTaints(w, node); err != nil {
@@ -172,6 +176,28 @@ func ValidateCilium(ctx context.Context, vc clusterf.StateValidationConfig) erro
 	return nil
 }
 
+// ValidateGitOpsReconciled gets the Flux Kustomization that applies the cluster config path of the GitOps
+// repository from the management cluster and validates that Flux has fully reconciled the repository content.
+func ValidateGitOpsReconciled(ctx context.Context, vc clusterf.StateValidationConfig) error {
+	fluxConfig := vc.ClusterSpec.FluxConfig
+	if fluxConfig == nil {
+		return nil
+	}
+
+	kustomization, err := controller.GetFluxKustomization(ctx, vc.ManagementClusterClient, fluxConfig)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve flux kustomization: %s", err)
+	}
+	if kustomization == nil {
+		return errors.New("flux kustomization does not exist")
+	}
+	if !kustomization.Reconciled {
+		return fmt.Errorf("flux kustomization %s/%s not reconciled yet. %s", kustomization.Namespace, kustomization.Name, kustomization.Message)
+	}
+
+	return nil
+}
+
 func validateNodeReady(node corev1.Node, kubeVersion v1alpha1.KubernetesVersion) error {
 	for _, condition := range node.Status.Conditions {
 		if condition.Type == "Ready" && condition.Status != corev1.ConditionTrue {
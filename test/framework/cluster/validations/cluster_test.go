@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	clusterf "github.com/aws/eks-anywhere/test/framework/cluster"
 	"github.com/aws/eks-anywhere/test/framework/cluster/validations"
@@ -527,6 +529,77 @@ func TestValidateCilium(t *testing.T) {
 	}
 }
 
+func TestValidateGitOpsReconciled(t *testing.T) {
+	tests := []struct {
+		name          string
+		fluxConfig    *v1alpha1.FluxConfig
+		kustomization *unstructured.Unstructured
+		wantErr       string
+	}{
+		{
+			name: "no gitops",
+		},
+		{
+			name:          "kustomization reconciled",
+			fluxConfig:    &v1alpha1.FluxConfig{},
+			kustomization: fluxKustomization("True", "Applied revision: main@sha1:abc"),
+		},
+		{
+			name:          "kustomization not reconciled",
+			fluxConfig:    &v1alpha1.FluxConfig{},
+			kustomization: fluxKustomization("False", "health check failed"),
+			wantErr:       "flux kustomization flux-system/flux-system not reconciled yet. health check failed",
+		},
+		{
+			name:       "kustomization does not exist",
+			fluxConfig: &v1alpha1.FluxConfig{},
+			wantErr:    "flux kustomization does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			vt := newStateValidatorTest(t, test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster = testCluster()
+				s.FluxConfig = tt.fluxConfig
+			}))
+			if tt.kustomization != nil {
+				vt.createManagementClusterObjects(ctx, tt.kustomization)
+			}
+
+			err := validations.ValidateGitOpsReconciled(ctx, vt.config)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func fluxKustomization(readyStatus, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"observedGeneration": int64(0),
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               "Ready",
+					"status":             readyStatus,
+					"reason":             "ReconciliationSucceeded",
+					"message":            message,
+					"lastTransitionTime": "2023-06-01T00:00:00Z",
+				},
+			},
+		},
+	}}
+	u.SetGroupVersionKind(controller.FluxKustomizationGVK)
+	u.SetName("flux-system")
+	u.SetNamespace("flux-system")
+	return u
+}
+
 func testCluster() *v1alpha1.Cluster {
 	return &v1alpha1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
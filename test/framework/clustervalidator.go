@@ -25,6 +25,7 @@ func validationsForExpectedObjects() []clusterf.StateValidation {
 		clusterf.RetriableStateValidation(longRetier, validations.ValidateControlPlaneNodes),
 		clusterf.RetriableStateValidation(longRetier, validations.ValidateWorkerNodes),
 		clusterf.RetriableStateValidation(mediumRetier, validations.ValidateCilium),
+		clusterf.RetriableStateValidation(mediumRetier, validations.ValidateGitOpsReconciled),
 		// This should be checked last as the Cluster should only be ready after all the other validations pass.
 		clusterf.RetriableStateValidation(mediumRetier, validations.ValidateClusterReady),
 	}
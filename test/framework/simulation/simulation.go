@@ -0,0 +1,128 @@
+// Package simulation runs the CLI workflows in "dry" mode, replacing the provider and the rest
+// of the clients that talk to real infrastructure with programmable fakes. It allows testing
+// workflow level behavior, like task ordering, checkpoint resume or how errors are surfaced,
+// without creating any cluster.
+package simulation
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Simulator turns gomock mocks into programmable fakes. Every method of a stubbed mock succeeds
+// by default, returning zero values, and records its call. Faults and return values can be
+// programmed per method, identified as "<name>.<Method>", where name is the one used when
+// stubbing the mock.
+type Simulator struct {
+	mu      sync.Mutex
+	calls   []string
+	faults  map[string][]error
+	returns map[string][]interface{}
+}
+
+// New builds a Simulator with no faults.
+func New() *Simulator {
+	return &Simulator{
+		faults:  map[string][]error{},
+		returns: map[string][]interface{}{},
+	}
+}
+
+// FailOn makes the next calls to method return the errors, one per call and in order.
+// Once they are consumed, the method succeeds again.
+func (s *Simulator) FailOn(method string, errs ...error) *Simulator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = append(s.faults[method], errs...)
+	return s
+}
+
+// Return sets the values returned by all the calls to method, excluding the error,
+// which remains controlled by FailOn. Values are assigned in order to the non error outputs.
+func (s *Simulator) Return(method string, values ...interface{}) *Simulator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.returns[method] = values
+	return s
+}
+
+// Calls returns the methods called so far, in order.
+func (s *Simulator) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// Reset clears the recorded calls and the pending faults, keeping the programmed return values.
+// It allows to rerun a workflow, simulating a resume after a failure.
+func (s *Simulator) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+	s.faults = map[string][]error{}
+}
+
+// Stub programs all the methods of a gomock mock to be handled by the Simulator.
+// It accepts any arguments and any number of calls.
+func (s *Simulator) Stub(ctrl *gomock.Controller, name string, mock interface{}) {
+	v := reflect.ValueOf(mock)
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if method.Name == "EXPECT" {
+			continue
+		}
+
+		methodType := v.Method(i).Type()
+		args := make([]interface{}, methodType.NumIn())
+		for j := range args {
+			args[j] = gomock.Any()
+		}
+
+		key := fmt.Sprintf("%s.%s", name, method.Name)
+		ctrl.RecordCallWithMethodType(mock, method.Name, methodType, args...).
+			AnyTimes().
+			DoAndReturn(reflect.MakeFunc(methodType, func([]reflect.Value) []reflect.Value {
+				return s.call(key, methodType)
+			}).Interface())
+	}
+}
+
+func (s *Simulator) call(method string, methodType reflect.Type) []reflect.Value {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, method)
+
+	var fault error
+	if faults := s.faults[method]; len(faults) > 0 {
+		fault = faults[0]
+		s.faults[method] = faults[1:]
+	}
+	values := s.returns[method]
+
+	outs := make([]reflect.Value, methodType.NumOut())
+	next := 0
+	for i := range outs {
+		out := methodType.Out(i)
+		outs[i] = reflect.Zero(out)
+		if out == errorType {
+			if fault != nil {
+				outs[i] = reflect.ValueOf(&fault).Elem()
+			}
+			continue
+		}
+		if next < len(values) {
+			if values[next] != nil {
+				outs[i] = reflect.ValueOf(values[next]).Convert(out)
+			}
+			next++
+		}
+	}
+
+	return outs
+}
@@ -0,0 +1,124 @@
+package simulation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+	"github.com/aws/eks-anywhere/test/framework/simulation"
+)
+
+type createSimulation struct {
+	*simulation.Simulator
+	workflow  *workflows.Create
+	validator *mocks.MockValidator
+	spec      *cluster.Spec
+}
+
+func newCreateSimulation(t *testing.T) *createSimulation {
+	ctrl := gomock.NewController(t)
+	s := simulation.New()
+
+	bootstrapper := mocks.NewMockBootstrapper(ctrl)
+	provider := providermocks.NewMockProvider(ctrl)
+	clusterManager := mocks.NewMockClusterManager(ctrl)
+	gitOpsManager := mocks.NewMockGitOpsManager(ctrl)
+	writer := writermocks.NewMockFileWriter(ctrl)
+	eksd := mocks.NewMockEksdInstaller(ctrl)
+	packageInstaller := mocks.NewMockPackageInstaller(ctrl)
+	validator := mocks.NewMockValidator(ctrl)
+
+	s.Stub(ctrl, "Bootstrapper", bootstrapper)
+	s.Stub(ctrl, "Provider", provider)
+	s.Stub(ctrl, "ClusterManager", clusterManager)
+	s.Stub(ctrl, "GitOpsManager", gitOpsManager)
+	s.Stub(ctrl, "Writer", writer)
+	s.Stub(ctrl, "EksdInstaller", eksd)
+	s.Stub(ctrl, "PackageInstaller", packageInstaller)
+	s.Stub(ctrl, "Validator", validator)
+
+	s.Return("Bootstrapper.CreateBootstrapCluster", &types.Cluster{Name: "bootstrap"})
+	s.Return("ClusterManager.CreateWorkloadCluster", &types.Cluster{Name: "workload"})
+	s.Return("Provider.DatacenterConfig", &v1alpha1.VSphereDatacenterConfig{})
+
+	return &createSimulation{
+		Simulator: s,
+		workflow:  workflows.NewCreate(bootstrapper, provider, clusterManager, gitOpsManager, writer, eksd, packageInstaller),
+		validator: validator,
+		spec:      test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+	}
+}
+
+func (c *createSimulation) run() error {
+	return c.workflow.Run(context.Background(), c.spec, c.validator, false)
+}
+
+func indexOf(calls []string, call string) int {
+	for i, c := range calls {
+		if c == call {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestCreateSimulationTaskOrdering(t *testing.T) {
+	g := NewWithT(t)
+	c := newCreateSimulation(t)
+
+	g.Expect(c.run()).To(Succeed())
+
+	calls := c.Calls()
+	ordered := []string{
+		"Provider.SetupAndValidateCreateCluster",
+		"Bootstrapper.CreateBootstrapCluster",
+		"ClusterManager.InstallCAPI",
+		"ClusterManager.CreateWorkloadCluster",
+		"ClusterManager.InstallNetworking",
+		"ClusterManager.MoveCAPI",
+		"ClusterManager.CreateEKSAResources",
+		"Bootstrapper.DeleteBootstrapCluster",
+	}
+	previous := -1
+	for _, call := range ordered {
+		i := indexOf(calls, call)
+		g.Expect(i).To(BeNumerically(">", previous), "%s should be called after %v", call, calls[:previous+1])
+		previous = i
+	}
+}
+
+func TestCreateSimulationProviderFault(t *testing.T) {
+	g := NewWithT(t)
+	c := newCreateSimulation(t)
+	c.FailOn("ClusterManager.CreateWorkloadCluster", errors.New("vCenter unreachable"))
+
+	g.Expect(c.run()).To(MatchError(ContainSubstring("vCenter unreachable")))
+
+	calls := c.Calls()
+	g.Expect(calls).To(ContainElement("ClusterManager.SaveLogsWorkloadCluster"))
+	g.Expect(calls).NotTo(ContainElement("ClusterManager.InstallNetworking"))
+	g.Expect(calls).NotTo(ContainElement("Bootstrapper.DeleteBootstrapCluster"))
+}
+
+func TestCreateSimulationResumeAfterFault(t *testing.T) {
+	g := NewWithT(t)
+	c := newCreateSimulation(t)
+	c.FailOn("ClusterManager.InstallNetworking", errors.New("timed out"))
+
+	g.Expect(c.run()).NotTo(Succeed())
+
+	c.Reset()
+	g.Expect(c.run()).To(Succeed())
+	g.Expect(c.Calls()).To(ContainElement("ClusterManager.InstallNetworking"))
+}
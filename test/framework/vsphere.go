@@ -400,6 +400,15 @@ func (v *VSphere) WithKubeVersionAndOS(kubeVersion anywherev1.KubernetesVersion,
 	)
 }
 
+// WithWorkerNodeGroupKubeVersionAndOS returns a cluster config filler that sets the kube version of the worker node group
+// and the right template for its vsphere machine config, which must share the name of the worker node group.
+func (v *VSphere) WithWorkerNodeGroupKubeVersionAndOS(name string, kubeVersion anywherev1.KubernetesVersion, os OS) api.ClusterConfigFiller {
+	return api.JoinClusterConfigFillers(
+		api.ClusterToConfigFiller(api.WithWorkerNodeGroup(name, api.WithWorkerKubernetesVersion(kubeVersion))),
+		api.VSphereToConfigFiller(api.WithMachineTemplate(name, v.templateForDevRelease(kubeVersion, os))),
+	)
+}
+
 // WithUbuntu123 returns a cluster config filler that sets the kubernetes version of the cluster to 1.23
 // as well as the right ubuntu template and osFamily for all VSphereMachineConfigs.
 func (v *VSphere) WithUbuntu123() api.ClusterConfigFiller {